@@ -0,0 +1,269 @@
+// Package backup creates and restores backup bundles of a deployment: a gzipped tar archive holding a
+// manifest, every application table as JSON lines and the document files.
+//
+// Bundle layout:
+//
+//	manifest.json           format version, tables with row counts, files with size and SHA-256
+//	data/<table>.jsonl      one row per line, as PostgreSQL row_to_json renders it
+//	files/<path>            document files, relative to DOCUMENTS_PATH
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FormatVersion is the bundle format this build writes and restores
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	Tables    []TableEntry `json:"tables"`
+	Files     []FileEntry  `json:"files"`
+}
+
+// TableEntry is a table in a bundle
+type TableEntry struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// FileEntry is a document file in a bundle
+type FileEntry struct {
+	Path   string `json:"path"` // Relative to DOCUMENTS_PATH, with forward slashes
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Progress reports how far a backup or restore has got within its current stage
+type Progress struct {
+	Stage string `json:"stage"` // database, hashing files or files; restores end with finalizing
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+// ProgressFunc receives progress updates; it may be nil
+type ProgressFunc func(Progress)
+
+func (report ProgressFunc) send(stage string, done, total int) {
+	if report != nil {
+		report(Progress{Stage: stage, Done: done, Total: total})
+	}
+}
+
+// Create writes a bundle of the database and the document files to w. Every table is read in a single
+// read-only, repeatable-read transaction, so the dump is consistent even while the API is in use.
+func Create(ctx context.Context, w io.Writer, report ProgressFunc) (*Manifest, error) {
+	if !database.IsPostgres() {
+		return nil, ErrUnsupportedDatabase
+	}
+	tables, err := database.Tables()
+	if err != nil {
+		return nil, err
+	}
+
+	// Tables are dumped to temporary files first, as tar needs each entry's size up front
+	tmpDir, err := os.MkdirTemp("", "hrms-backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manifest := &Manifest{Version: FormatVersion, CreatedAt: time.Now().UTC()}
+	tx := database.DB.WithContext(ctx).Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+	for i, table := range tables {
+		report.send("database", i, len(tables))
+		rows, err := dumpTable(tx, table, filepath.Join(tmpDir, table+".jsonl"))
+		if err != nil {
+			return nil, fmt.Errorf("dumping %s: %w", table, err)
+		}
+		manifest.Tables = append(manifest.Tables, TableEntry{Name: table, Rows: rows})
+	}
+	tx.Rollback()
+	report.send("database", len(tables), len(tables))
+
+	manifest.Files, err = listDocuments(report)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeEntry(archive, manifestName, int64(len(manifestData)), bytes.NewReader(manifestData)); err != nil {
+		return nil, err
+	}
+	for _, table := range manifest.Tables {
+		if err := copyFileEntry(archive, "data/"+table.Name+".jsonl", filepath.Join(tmpDir, table.Name+".jsonl"), ""); err != nil {
+			return nil, err
+		}
+	}
+	for i, file := range manifest.Files {
+		report.send("files", i, len(manifest.Files))
+		if err := copyFileEntry(archive, "files/"+file.Path, documentPath(config.AppConfig.DocumentsPath, file.Path), file.SHA256); err != nil {
+			return nil, err
+		}
+	}
+	report.send("files", len(manifest.Files), len(manifest.Files))
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// dumpTable writes every row of a table to a file as JSON lines and returns the row count
+func dumpTable(tx *gorm.DB, table, filename string) (int64, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	out := bufio.NewWriter(file)
+
+	rows, err := tx.Raw("SELECT row_to_json(t)::text FROM " + tx.Statement.Quote(table) + " AS t").Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return 0, err
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return count, out.Flush()
+}
+
+// listDocuments lists and hashes every file under DOCUMENTS_PATH. A missing directory has no files.
+func listDocuments(report ProgressFunc) ([]FileEntry, error) {
+	root := config.AppConfig.DocumentsPath
+	var paths []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing documents: %w", err)
+	}
+
+	files := make([]FileEntry, 0, len(paths))
+	for i, p := range paths {
+		report.send("hashing files", i, len(paths))
+		size, sum, err := hashFile(documentPath(root, p))
+		if err != nil {
+			return nil, fmt.Errorf("reading document %s: %w", p, err)
+		}
+		files = append(files, FileEntry{Path: p, Size: size, SHA256: sum})
+	}
+	return files, nil
+}
+
+func hashFile(filename string) (int64, string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// copyFileEntry adds a file to the archive. When sum is set the file must still have that SHA-256,
+// which catches documents replaced while the backup ran.
+func copyFileEntry(archive *tar.Writer, name, filename, sum string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	if err := writeEntry(archive, name, info.Size(), io.TeeReader(file, hash)); err != nil {
+		return fmt.Errorf("adding %s: %w", name, err)
+	}
+	if sum != "" && hex.EncodeToString(hash.Sum(nil)) != sum {
+		return fmt.Errorf("%s changed while the backup was running, run the backup again", name)
+	}
+	return nil
+}
+
+func writeEntry(archive *tar.Writer, name string, size int64, r io.Reader) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: size, ModTime: time.Now(), Typeflag: tar.TypeReg}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.CopyN(archive, r, size)
+	return err
+}
+
+// documentPath turns a bundle path into a path below root
+func documentPath(root, p string) string {
+	return filepath.Join(root, filepath.FromSlash(p))
+}
+
+// validBundlePath reports whether a path from a bundle stays within the directory it is restored into
+func validBundlePath(p string) bool {
+	return p != "" && path.Clean(p) == p && filepath.IsLocal(filepath.FromSlash(p))
+}
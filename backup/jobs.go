@@ -0,0 +1,217 @@
+package backup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Job statuses
+const (
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+// bundleSuffix is the file extension of backup bundles
+const bundleSuffix = ".tar.gz"
+
+// ErrJobRunning is returned when a backup or restore is started while another one is running
+var ErrJobRunning = errors.New("a backup or restore is already running")
+
+// ErrUnsupportedDatabase is returned when backing up or restoring a database other than Postgres, as
+// bundles are written and loaded with Postgres JSON functions
+var ErrUnsupportedDatabase = errors.New("backups are only supported on PostgreSQL")
+
+// ErrBundleNotFound is returned for a bundle name that is not in BACKUPS_PATH
+var ErrBundleNotFound = errors.New("backup not found")
+
+// Job is a backup or restore running in the background
+type Job struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`   // backup or restore
+	Status string `json:"status"` // running, completed or failed
+	Progress
+	Bundle     string     `json:"bundle,omitempty"` // Name of the bundle written or restored
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Bundle is a backup bundle stored in BACKUPS_PATH
+type Bundle struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+var (
+	jobsMu  sync.Mutex
+	jobs    = map[string]*Job{}
+	running bool
+	jobRuns sync.WaitGroup // Lets shutdown wait for a running job
+)
+
+// StartBackup starts writing a bundle to BACKUPS_PATH in the background
+func StartBackup() (Job, error) {
+	if err := os.MkdirAll(config.AppConfig.BackupsPath, 0750); err != nil {
+		return Job{}, err
+	}
+	name := "hrms-backup-" + time.Now().Format("20060102-150405") + bundleSuffix
+	filename := filepath.Join(config.AppConfig.BackupsPath, name)
+
+	return startJob("backup", name, func(ctx context.Context, report ProgressFunc) error {
+		// The bundle only gets its name once complete, so a failed backup is never listed
+		partial := filename + ".partial"
+		file, err := os.OpenFile(partial, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+		if err != nil {
+			return err
+		}
+		_, err = Create(ctx, file, report)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(partial)
+			return err
+		}
+		return os.Rename(partial, filename)
+	})
+}
+
+// StartRestore starts restoring a bundle file in the background. The file is deleted afterwards when
+// remove is set, as it is for uploaded bundles.
+func StartRestore(filename string, remove bool) (Job, error) {
+	job, err := startJob("restore", filepath.Base(filename), func(ctx context.Context, report ProgressFunc) error {
+		if remove {
+			defer os.Remove(filename)
+		}
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = Restore(ctx, file, report)
+		return err
+	})
+	if err != nil && remove {
+		os.Remove(filename)
+	}
+	return job, err
+}
+
+// startJob runs fn in the background unless another job is running
+func startJob(kind, bundle string, fn func(ctx context.Context, report ProgressFunc) error) (Job, error) {
+	if !database.IsPostgres() {
+		return Job{}, ErrUnsupportedDatabase
+	}
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	if running {
+		return Job{}, ErrJobRunning
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return Job{}, err
+	}
+	job := &Job{ID: hex.EncodeToString(id), Kind: kind, Status: JobRunning, Bundle: bundle, StartedAt: time.Now()}
+	jobs[job.ID] = job
+	running = true
+
+	jobRuns.Add(1)
+	go func() {
+		defer jobRuns.Done()
+		err := fn(context.Background(), func(progress Progress) {
+			jobsMu.Lock()
+			job.Progress = progress
+			jobsMu.Unlock()
+		})
+
+		jobsMu.Lock()
+		defer jobsMu.Unlock()
+		finishedAt := time.Now()
+		job.FinishedAt = &finishedAt
+		job.Status = JobCompleted
+		if err != nil {
+			job.Status = JobFailed
+			job.Error = err.Error()
+			log.Printf("❌ %s %s failed: %v", kind, job.ID, err)
+		} else {
+			log.Printf("✅ %s %s completed (%s)", kind, job.ID, bundle)
+		}
+		running = false
+	}()
+	return *job, nil
+}
+
+// GetJob returns a job started since the server started
+func GetJob(id string) (Job, bool) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	job, ok := jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// WaitForJobs blocks until a running backup or restore finishes
+func WaitForJobs() {
+	jobRuns.Wait()
+}
+
+// ListBundles lists the bundles in BACKUPS_PATH, newest first
+func ListBundles() ([]Bundle, error) {
+	entries, err := os.ReadDir(config.AppConfig.BackupsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Bundle{}, nil
+		}
+		return nil, err
+	}
+	bundles := []Bundle{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), bundleSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		bundles = append(bundles, Bundle{Name: entry.Name(), Size: info.Size(), CreatedAt: info.ModTime()})
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].CreatedAt.After(bundles[j].CreatedAt) })
+	return bundles, nil
+}
+
+// BundlePath returns the path of a bundle in BACKUPS_PATH
+func BundlePath(name string) (string, error) {
+	if name != filepath.Base(name) || !strings.HasSuffix(name, bundleSuffix) {
+		return "", ErrBundleNotFound
+	}
+	filename := filepath.Join(config.AppConfig.BackupsPath, name)
+	if info, err := os.Stat(filename); err != nil || !info.Mode().IsRegular() {
+		return "", ErrBundleNotFound
+	}
+	return filename, nil
+}
+
+// UploadPath returns a new path in BACKUPS_PATH for an uploaded bundle waiting to be restored
+func UploadPath() (string, error) {
+	if err := os.MkdirAll(config.AppConfig.BackupsPath, 0750); err != nil {
+		return "", err
+	}
+	return filepath.Join(config.AppConfig.BackupsPath, fmt.Sprintf("upload-%s.restore", time.Now().Format("20060102-150405.000"))), nil
+}
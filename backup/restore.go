@@ -0,0 +1,329 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidBundle is returned when a file is not a backup bundle this build can restore
+var ErrInvalidBundle = errors.New("invalid backup bundle")
+
+// restoreBatchSize is how many rows are inserted per statement
+const restoreBatchSize = 500
+
+// foreignKey is a foreign key constraint, dropped while tables are loaded and added back afterwards
+type foreignKey struct {
+	TableName  string
+	Name       string
+	Definition string
+}
+
+// Restore replaces every application table and the document files with the contents of a bundle.
+// Tables must already exist, so run migrations first. The database is restored in a single transaction
+// and only committed once every table's row count and every file's SHA-256 matches the manifest, so a
+// failed restore leaves the instance as it was. The previous documents directory is kept next to the
+// restored one with a ".pre-restore-<time>" suffix.
+func Restore(ctx context.Context, r io.Reader, report ProgressFunc) (*Manifest, error) {
+	if !database.IsPostgres() {
+		return nil, ErrUnsupportedDatabase
+	}
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	archive := tar.NewReader(gz)
+
+	header, err := archive.Next()
+	if err != nil || header.Name != manifestName {
+		return nil, fmt.Errorf("%w: the bundle does not start with a manifest", ErrInvalidBundle)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(archive).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: reading manifest: %v", ErrInvalidBundle, err)
+	}
+	if manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: bundle format version %d is not supported", ErrInvalidBundle, manifest.Version)
+	}
+
+	tables, err := database.Tables()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		known[table] = true
+	}
+	expectedRows := make(map[string]int64, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		if !known[table.Name] {
+			return nil, fmt.Errorf("%w: table %s does not exist in this version", ErrInvalidBundle, table.Name)
+		}
+		expectedRows[table.Name] = table.Rows
+	}
+	expectedFiles := make(map[string]FileEntry, len(manifest.Files))
+	for _, file := range manifest.Files {
+		if !validBundlePath(file.Path) {
+			return nil, fmt.Errorf("%w: invalid file path %q", ErrInvalidBundle, file.Path)
+		}
+		expectedFiles[file.Path] = file
+	}
+
+	// Documents are extracted next to the documents directory and swapped in once everything checks out
+	documentsDir := filepath.Clean(config.AppConfig.DocumentsPath)
+	suffix := time.Now().Format("20060102-150405")
+	stagingDir := documentsDir + ".restore-" + suffix
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	tx := database.DB.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+
+	foreignKeys, err := dropForeignKeys(tx)
+	if err != nil {
+		return nil, fmt.Errorf("dropping foreign keys: %w", err)
+	}
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = tx.Statement.Quote(table)
+	}
+	if err := tx.Exec("TRUNCATE TABLE " + strings.Join(quoted, ", ") + " RESTART IDENTITY CASCADE").Error; err != nil {
+		return nil, fmt.Errorf("emptying tables: %w", err)
+	}
+
+	restoredRows := make(map[string]int64, len(manifest.Tables))
+	restoredFiles := 0
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+
+		switch {
+		case strings.HasPrefix(header.Name, "data/") && strings.HasSuffix(header.Name, ".jsonl"):
+			table := strings.TrimSuffix(strings.TrimPrefix(header.Name, "data/"), ".jsonl")
+			expected, ok := expectedRows[table]
+			if _, done := restoredRows[table]; !ok || done {
+				return nil, fmt.Errorf("%w: unexpected entry %s", ErrInvalidBundle, header.Name)
+			}
+			report.send("database", len(restoredRows), len(manifest.Tables))
+			rows, err := restoreTable(tx, table, archive)
+			if err != nil {
+				return nil, fmt.Errorf("restoring %s: %w", table, err)
+			}
+			if rows != expected {
+				return nil, fmt.Errorf("%w: %s has %d rows, the manifest lists %d", ErrInvalidBundle, table, rows, expected)
+			}
+			restoredRows[table] = rows
+
+		case strings.HasPrefix(header.Name, "files/"):
+			p := strings.TrimPrefix(header.Name, "files/")
+			file, ok := expectedFiles[p]
+			if !ok {
+				return nil, fmt.Errorf("%w: unexpected entry %s", ErrInvalidBundle, header.Name)
+			}
+			report.send("files", restoredFiles, len(manifest.Files))
+			if err := extractFile(archive, documentPath(stagingDir, p), file); err != nil {
+				return nil, err
+			}
+			delete(expectedFiles, p)
+			restoredFiles++
+
+		default:
+			return nil, fmt.Errorf("%w: unexpected entry %s", ErrInvalidBundle, header.Name)
+		}
+	}
+	if len(restoredRows) != len(manifest.Tables) || len(expectedFiles) > 0 {
+		return nil, fmt.Errorf("%w: the bundle is incomplete", ErrInvalidBundle)
+	}
+
+	report.send("finalizing", 0, 1)
+	if err := resetSequences(tx, tables); err != nil {
+		return nil, fmt.Errorf("resetting sequences: %w", err)
+	}
+	for _, fk := range foreignKeys {
+		if err := tx.Exec("ALTER TABLE " + fk.TableName + " ADD CONSTRAINT " + tx.Statement.Quote(fk.Name) + " " + fk.Definition).Error; err != nil {
+			return nil, fmt.Errorf("restoring foreign key %s: %w", fk.Name, err)
+		}
+	}
+
+	previousDir := documentsDir + ".pre-restore-" + suffix
+	hadDocuments := true
+	if err := os.Rename(documentsDir, previousDir); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("moving documents aside: %w", err)
+		}
+		hadDocuments = false
+	}
+	if err := os.Rename(stagingDir, documentsDir); err != nil {
+		if hadDocuments {
+			os.Rename(previousDir, documentsDir)
+		}
+		return nil, fmt.Errorf("moving restored documents into place: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		os.RemoveAll(documentsDir)
+		if hadDocuments {
+			os.Rename(previousDir, documentsDir)
+		}
+		return nil, err
+	}
+	report.send("finalizing", 1, 1)
+	return &manifest, nil
+}
+
+// restoreTable inserts the JSON lines of a table dump and returns how many rows were inserted. Only
+// columns that exist in both the dump and the table are restored, so a bundle from an older version
+// leaves newer columns at their defaults. Generated columns are left for the database to compute.
+func restoreTable(tx *gorm.DB, table string, r io.Reader) (int64, error) {
+	var tableColumns []string
+	if err := tx.Raw("SELECT column_name FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND is_generated = 'NEVER' ORDER BY ordinal_position", table).
+		Scan(&tableColumns).Error; err != nil {
+		return 0, err
+	}
+
+	var columnList string
+	var batch [][]byte
+	var count int64
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		rows := append(append([]byte("["), bytes.Join(batch, []byte(","))...), ']')
+		quotedTable := tx.Statement.Quote(table)
+		sql := "INSERT INTO " + quotedTable + " (" + columnList + ") SELECT " + columnList +
+			" FROM json_populate_recordset(NULL::" + quotedTable + ", ?::json)"
+		if err := tx.Exec(sql, string(rows)).Error; err != nil {
+			return err
+		}
+		count += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	lines := bufio.NewReader(r)
+	for {
+		line, err := lines.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if columnList == "" {
+				var first map[string]json.RawMessage
+				if err := json.Unmarshal(line, &first); err != nil {
+					return 0, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+				}
+				var columns []string
+				for _, column := range tableColumns {
+					if _, ok := first[column]; ok {
+						columns = append(columns, tx.Statement.Quote(column))
+					}
+				}
+				if len(columns) == 0 {
+					return 0, fmt.Errorf("%w: no columns match the table", ErrInvalidBundle)
+				}
+				columnList = strings.Join(columns, ", ")
+			}
+			batch = append(batch, line)
+			if len(batch) == restoreBatchSize {
+				if err := flush(); err != nil {
+					return 0, err
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// dropForeignKeys drops every foreign key in the schema, so that tables can be loaded in any order, and
+// returns them to be added back
+func dropForeignKeys(tx *gorm.DB) ([]foreignKey, error) {
+	var foreignKeys []foreignKey
+	if err := tx.Raw(`SELECT c.conrelid::regclass::text AS table_name, c.conname AS name, pg_get_constraintdef(c.oid) AS definition
+		FROM pg_constraint c JOIN pg_namespace n ON n.oid = c.connamespace
+		WHERE c.contype = 'f' AND n.nspname = CURRENT_SCHEMA()`).Scan(&foreignKeys).Error; err != nil {
+		return nil, err
+	}
+	for _, fk := range foreignKeys {
+		if err := tx.Exec("ALTER TABLE " + fk.TableName + " DROP CONSTRAINT " + tx.Statement.Quote(fk.Name)).Error; err != nil {
+			return nil, err
+		}
+	}
+	return foreignKeys, nil
+}
+
+// resetSequences moves every serial column's sequence past the restored rows
+func resetSequences(tx *gorm.DB, tables []string) error {
+	var serials []struct {
+		TableName  string
+		ColumnName string
+		Sequence   string
+	}
+	if err := tx.Raw(`SELECT table_name, column_name, pg_get_serial_sequence(quote_ident(table_name), column_name) AS sequence
+		FROM information_schema.columns
+		WHERE table_schema = CURRENT_SCHEMA() AND table_name IN ? AND column_default LIKE 'nextval(%'`, tables).Scan(&serials).Error; err != nil {
+		return err
+	}
+	for _, serial := range serials {
+		if serial.Sequence == "" {
+			continue
+		}
+		query := "SELECT setval(?::text::regclass, COALESCE((SELECT MAX(" + tx.Statement.Quote(serial.ColumnName) + ") FROM " + tx.Statement.Quote(serial.TableName) + "), 0) + 1, false)"
+		if err := tx.Exec(query, serial.Sequence).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractFile writes a document from the bundle, checking its size and SHA-256 against the manifest
+func extractFile(r io.Reader, filename string, entry FileEntry) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), r)
+	if err != nil {
+		return err
+	}
+	if size != entry.Size || hex.EncodeToString(hash.Sum(nil)) != entry.SHA256 {
+		return fmt.Errorf("%w: document %s does not match the manifest", ErrInvalidBundle, entry.Path)
+	}
+	return nil
+}
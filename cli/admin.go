@@ -0,0 +1,323 @@
+// Package cli implements the administrative commands run as "hrms-api admin <command>", so that operators
+// can maintain a deployment without making authenticated HTTP calls against it.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"hrms-api/backup"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/scheduler"
+	"hrms-api/utils"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// command is one administrative command
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"migrate":      {"Run database migrations", migrate},
+	"seed":         {"Create missing reference data and the initial admin account", seed},
+	"create-admin": {"Create an admin account", createAdmin},
+	"run-accruals": {"Process monthly leave accruals for every active employee", runAccruals},
+	"leave-preset": {"Set up an organization's leave types from a statutory leave preset", applyLeavePreset},
+	"reindex":      {"Rebuild the indexes of every application table", reindex},
+	"backup":       {"Write a backup bundle of the database and document files", createBackup},
+	"restore":      {"Replace all data and document files with a backup bundle", restoreBackup},
+}
+
+// commandOrder is the order commands are listed in the usage message
+var commandOrder = []string{"migrate", "seed", "create-admin", "run-accruals", "leave-preset", "reindex", "backup", "restore"}
+
+// RunAdmin runs the administrative command named by args[0] with the remaining args as its flags.
+// Configuration must already be loaded; commands connect to the database once their flags are parsed.
+func RunAdmin(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stdout)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(os.Stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	if err := cmd.run(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: hrms-api admin <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range commandOrder {
+		fmt.Fprintf(w, "  %-14s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "hrms-api admin <command> -h" for a command's flags.`)
+}
+
+func migrate(args []string) error {
+	if err := parse(newFlagSet("migrate"), args); err != nil {
+		return err
+	}
+	return database.Migrate()
+}
+
+func seed(args []string) error {
+	if err := parse(newFlagSet("seed"), args); err != nil {
+		return err
+	}
+	return database.SeedData()
+}
+
+func createAdmin(args []string) error {
+	flags := newFlagSet("create-admin")
+	username := flags.String("username", "", "username the admin signs in with (required)")
+	firstname := flags.String("firstname", "Admin", "first name")
+	lastname := flags.String("lastname", "User", "last name")
+	email := flags.String("email", "", "email address")
+	department := flags.String("department", "Administration", "department")
+	organizationCode := flags.String("organization", "", "code of the organization the admin manages (default: the default organization)")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from the first line of standard input instead of prompting")
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("-username is required")
+	}
+
+	organization := models.Organization{ID: models.DefaultOrganizationID}
+	if *organizationCode != "" {
+		if err := database.DB.Where("code = ?", *organizationCode).First(&organization).Error; err != nil {
+			return fmt.Errorf("organization %q not found", *organizationCode)
+		}
+	}
+
+	var existing int64
+	database.DB.Model(&models.Employee{}).Where("username = ?", *username).Count(&existing)
+	if existing > 0 {
+		return fmt.Errorf("username %q already exists", *username)
+	}
+
+	if !*passwordStdin {
+		fmt.Print("Password: ")
+	}
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && password != "") {
+		return fmt.Errorf("reading password: %w", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+
+	admin := models.Employee{
+		OrganizationID: organization.ID,
+		Username:       username,
+		Firstname:      *firstname,
+		Lastname:       *lastname,
+		Department:     *department,
+	}
+	if *email != "" {
+		admin.Email = email
+	}
+	if err := database.CreateAdmin(database.DB, &admin, password); err != nil {
+		return err
+	}
+	fmt.Printf("Admin account created: ID=%d Username=%s\n", admin.ID, *username)
+	return nil
+}
+
+func runAccruals(args []string) error {
+	flags := newFlagSet("run-accruals")
+	month := flags.String("month", "", "month to accrue as YYYY-MM (default: the previous month)")
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+	// The accrual rate is a runtime setting
+	if err := utils.LoadSettings(); err != nil {
+		return fmt.Errorf("loading settings: %w", err)
+	}
+
+	processMonth := utils.CompanyNow().AddDate(0, -1, 0)
+	if *month != "" {
+		parsed, err := time.Parse("2006-01", *month)
+		if err != nil {
+			return errors.New("invalid -month, use YYYY-MM")
+		}
+		processMonth = parsed
+	}
+
+	processed, errorDetails, err := scheduler.ProcessAccrualsForMonth(context.Background(), processMonth.Year(), processMonth.Month())
+	if err != nil {
+		return err
+	}
+	for _, detail := range errorDetails {
+		fmt.Fprintln(os.Stderr, detail)
+	}
+	fmt.Printf("Accruals for %s: %d processed, %d errors\n", processMonth.Format("2006-01"), processed, len(errorDetails))
+	if len(errorDetails) > 0 {
+		return fmt.Errorf("%d accrual(s) failed", len(errorDetails))
+	}
+	return nil
+}
+
+func applyLeavePreset(args []string) error {
+	flags := newFlagSet("leave-preset")
+	codes := make([]string, len(models.LeavePresets))
+	for i, preset := range models.LeavePresets {
+		codes[i] = preset.Code
+	}
+	presetCode := flags.String("preset", "", "leave preset to apply: "+strings.Join(codes, ", ")+" (required)")
+	organizationCode := flags.String("organization", "", "code of the organization to apply it to (default: the default organization)")
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+	preset, ok := models.FindLeavePreset(*presetCode)
+	if !ok {
+		return fmt.Errorf("-preset must be one of %s", strings.Join(codes, ", "))
+	}
+
+	organization := models.Organization{ID: models.DefaultOrganizationID}
+	if *organizationCode != "" {
+		if err := database.DB.Where("code = ?", *organizationCode).First(&organization).Error; err != nil {
+			return fmt.Errorf("organization %q not found", *organizationCode)
+		}
+	}
+
+	var changes []utils.LeavePresetChange
+	orgDB := database.DB.WithContext(database.WithOrganization(context.Background(), organization.ID))
+	err := orgDB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		changes, err = utils.ApplyLeavePreset(tx, organization.ID, preset)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		fmt.Printf("%-9s %s: %d days", change.Action, change.LeaveType.Name, change.LeaveType.MaxDays)
+		if change.LeaveType.UsesBalance {
+			fmt.Printf(", accruing %g a month", change.LeaveType.AccrualRate)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%s leave preset applied\n", preset.Name)
+	return nil
+}
+
+func reindex(args []string) error {
+	if err := parse(newFlagSet("reindex"), args); err != nil {
+		return err
+	}
+	if err := database.Reindex(); err != nil {
+		return err
+	}
+	fmt.Println("Indexes rebuilt")
+	return nil
+}
+
+func createBackup(args []string) error {
+	flags := newFlagSet("backup")
+	out := flags.String("out", "", "file to write the bundle to (default: a new bundle in BACKUPS_PATH)")
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+
+	filename := *out
+	if filename == "" {
+		if err := os.MkdirAll(config.AppConfig.BackupsPath, 0750); err != nil {
+			return err
+		}
+		filename = filepath.Join(config.AppConfig.BackupsPath, "hrms-backup-"+time.Now().Format("20060102-150405")+".tar.gz")
+	}
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	manifest, err := backup.Create(context.Background(), file, printProgress())
+	fmt.Fprintln(os.Stderr)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return err
+	}
+	fmt.Printf("Backup written to %s: %d tables, %d documents\n", filename, len(manifest.Tables), len(manifest.Files))
+	return nil
+}
+
+func restoreBackup(args []string) error {
+	flags := newFlagSet("restore")
+	in := flags.String("in", "", "bundle to restore (required)")
+	yes := flags.Bool("yes", false, "confirm that all data and document files are replaced")
+	if err := parse(flags, args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return errors.New("-in is required")
+	}
+	if !*yes {
+		return errors.New("restoring replaces all data and document files; pass -yes to confirm")
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// A fresh instance may not have its tables yet
+	if err := database.Migrate(); err != nil {
+		return err
+	}
+	manifest, err := backup.Restore(context.Background(), file, printProgress())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored backup of %s: %d tables, %d documents\n", manifest.CreatedAt.Format(time.RFC3339), len(manifest.Tables), len(manifest.Files))
+	return nil
+}
+
+// printProgress reports backup and restore progress on standard error, one line per stage. Callers end
+// the last line once done.
+func printProgress() backup.ProgressFunc {
+	stage := ""
+	return func(progress backup.Progress) {
+		if stage != "" && progress.Stage != stage {
+			fmt.Fprintln(os.Stderr)
+		}
+		stage = progress.Stage
+		fmt.Fprintf(os.Stderr, "\r%-13s %d/%d", progress.Stage, progress.Done, progress.Total)
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("hrms-api admin "+name, flag.ContinueOnError)
+}
+
+// parse parses a command's flags and, unless only help was asked for, connects to the database
+func parse(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := database.Connect(); err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	return nil
+}
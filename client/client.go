@@ -0,0 +1,226 @@
+// Package client is the Go client of the HRMS API. Its methods and types are generated from the API's
+// handlers (see operations_gen.go and types_gen.go), one method per operation, named after the handler
+// serving it.
+//
+//	c := client.New("https://hrms.example.com")
+//	auth, err := c.Login(ctx, client.LoginRequest{NRC: "123456/78/9", Password: "secret"})
+//	if err != nil {
+//		return err
+//	}
+//	c.SetToken(auth.Token)
+//	leaves, err := c.GetMyLeaves(ctx, &client.GetMyLeavesParams{Status: "Pending"})
+//
+// Failed requests return an *APIError carrying the API's error code and message.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Version is the version of this client, sent in the User-Agent of its requests
+const Version = "1.0.0"
+
+// Client calls the HRMS API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	language   string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient makes the client send its requests with httpClient instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithToken authenticates the client's requests with a JWT obtained from Login
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithLanguage asks for error messages in a language, such as "fr" or "pt"
+func WithLanguage(language string) Option {
+	return func(c *Client) { c.language = language }
+}
+
+// New returns a client of the API served at baseURL, such as "https://hrms.example.com"
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{baseURL: strings.TrimRight(baseURL, "/"), httpClient: http.DefaultClient}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// SetToken authenticates the client's requests from now on with a JWT obtained from Login
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// APIError is returned when the API answers with an error status
+type APIError struct {
+	StatusCode int
+	Code       string          `json:"code"`    // Machine-readable error code, such as validation_failed
+	Message    string          `json:"message"` // In the client's language
+	RequestID  string          `json:"request_id"`
+	Details    json.RawMessage `json:"details"` // The rejected fields for validation errors, the current record for conflicts
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("hrms api: %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("hrms api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// File is a file uploaded with a multipart request
+type File struct {
+	Name    string // File name, whose extension tells the API the file's format
+	Content io.Reader
+}
+
+// multipartForm is the body of a request uploading files
+type multipartForm struct {
+	values map[string]string
+	files  map[string]*File
+}
+
+func (f *multipartForm) set(name, value string) {
+	if f.values == nil {
+		f.values = map[string]string{}
+	}
+	f.values[name] = value
+}
+
+func (f *multipartForm) setFile(name string, file *File) {
+	if f.files == nil {
+		f.files = map[string]*File{}
+	}
+	f.files[name] = file
+}
+
+func (f *multipartForm) encode() (io.Reader, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range f.values {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, "", err
+		}
+	}
+	for name, file := range f.files {
+		part, err := writer.CreateFormFile(name, file.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, writer.FormDataContentType(), nil
+}
+
+// call sends a request and decodes a successful response into out, unless out is nil
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	response, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if out == nil || response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("hrms api: decoding %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// download sends a request for a file and returns its content, which the caller must close
+func (c *Client) download(ctx context.Context, method, path string, query url.Values, body interface{}) (io.ReadCloser, error) {
+	response, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// send makes a request, returning the response when its status is a success and an *APIError otherwise.
+// body is sent as multipart when it is a *multipartForm and as JSON otherwise.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	contentType := ""
+	switch body := body.(type) {
+	case nil:
+	case *multipartForm:
+		var err error
+		if reader, contentType, err = body.encode(); err != nil {
+			return nil, err
+		}
+	default:
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader, contentType = bytes.NewReader(encoded), "application/json"
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "hrms-api-client-go/"+Version)
+	if c.language != "" {
+		request.Header.Set("Accept-Language", c.language)
+	}
+	c.mu.RLock()
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	c.mu.RUnlock()
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		defer response.Body.Close()
+		apiErr := &APIError{StatusCode: response.StatusCode}
+		content, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+		if json.Unmarshal(content, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(content))
+			if apiErr.Message == "" {
+				apiErr.Message = http.StatusText(response.StatusCode)
+			}
+		}
+		return nil, apiErr
+	}
+	return response, nil
+}
@@ -0,0 +1,3 @@
+module github.com/andray-nkhatel/hrms-api/client
+
+go 1.24.0
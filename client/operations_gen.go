@@ -0,0 +1,6820 @@
+// Code generated by clientgen from the handler annotations. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// AddEmployeeCertification records a certification held by an employee
+//
+// Record a certification held by an employee. If the certification is linked to a compliance
+// requirement, a matching compliance record is kept in sync so expiry reminders are sent.
+//
+// POST /api/employees/{id}/certifications
+func (c *Client) AddEmployeeCertification(ctx context.Context, id uint, request AddEmployeeCertificationRequest) (*EmployeeCertification, error) {
+	var out EmployeeCertification
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/certifications", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddGrievanceNote adds a note to a grievance
+//
+// Add a note to a grievance. Submitters can add information to their own grievances; admins can add
+// internal notes hidden from the submitter.
+//
+// POST /api/grievances/{id}/notes
+func (c *Client) AddGrievanceNote(ctx context.Context, id uint, request AddGrievanceNoteRequest) (*GrievanceUpdate, error) {
+	var out GrievanceUpdate
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/grievances/%d/notes", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddManualAccrual manually adds an accrual record for an employee
+//
+// Manually add an accrual record for a specific month (Admin only).
+//
+// POST /api/hr/employees/{id}/annual-leave-balance/accrual
+func (c *Client) AddManualAccrual(ctx context.Context, id uint, request ManualAccrualRequest) (*LeaveAccrual, error) {
+	var out LeaveAccrual
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/hr/employees/%d/annual-leave-balance/accrual", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdjustLeaveBalance manually adjusts an employee's annual leave balance
+//
+// Manually adjust an employee's annual leave balance (add or subtract days) (Admin only).
+//
+// POST /api/hr/employees/{id}/annual-leave-balance/adjust
+func (c *Client) AdjustLeaveBalance(ctx context.Context, id uint, request AdjustLeaveBalanceRequest) (*AnnualLeaveBalanceResponse, error) {
+	var out AnnualLeaveBalanceResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/hr/employees/%d/annual-leave-balance/adjust", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminLogin authenticates an admin with username and password
+//
+// Authenticate admin with username and password, returns JWT token.
+//
+// POST /auth/admin/login
+func (c *Client) AdminLogin(ctx context.Context, request AdminLoginRequest) (*AuthResponse, error) {
+	var out AuthResponse
+	if err := c.call(ctx, "POST", "/auth/admin/login", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AnonymizeEmployee irreversibly scrubs a former employee's personal data
+//
+// Irreversibly scrub a former employee's personal data instead of deleting them: name, NRC, employee
+// number, login, contact, emergency and bank details, identity, bank and education records, document
+// files, leave forms and reasons, and the values recorded in the audit trail of those records. Leaves,
+// employment details and lifecycle events are kept, so leave and headcount statistics are unchanged.
+// Former employees are those deleted, deactivated, or terminated or resigned in their employment
+// details. Preview first with GET /api/admin/employees/{id}/anonymization and send the employee's full
+// name as confirm (Admin only).
+//
+// POST /api/admin/employees/{id}/anonymize
+func (c *Client) AnonymizeEmployee(ctx context.Context, id uint, request AnonymizeEmployeeRequest) (*AnonymizationResponse, error) {
+	var out AnonymizationResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/employees/%d/anonymize", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApplyLeave creates a new leave request
+//
+// Submit a new leave request.
+//
+// POST /api/leaves
+func (c *Client) ApplyLeave(ctx context.Context, request ApplyLeaveRequest) (*Leave, error) {
+	var out Leave
+	if err := c.call(ctx, "POST", "/api/leaves", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApplyLeavePreset sets up the organization's leave types from a leave preset
+//
+// Set up the organization's leave types from a leave preset. Leave types the organization does not
+// have, matched by name, are created; existing ones whose maximum days or accrual rate are below the
+// preset's are raised to it. Nothing is lowered or removed, so applying a preset again is safe (Admin
+// only).
+//
+// POST /api/admin/leave-presets/{code}/apply
+func (c *Client) ApplyLeavePreset(ctx context.Context, code string) (*ApplyLeavePresetResponse, error) {
+	var out ApplyLeavePresetResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/leave-presets/%s/apply", url.PathEscape(code)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveAttendanceCorrection approves a correction and applies it to the attendance record
+//
+// Approve an attendance correction. The attendance record is updated and re-evaluated against the work
+// schedule (Employee's manager or Admin).
+//
+// PUT /api/attendance/corrections/{id}/approve
+func (c *Client) ApproveAttendanceCorrection(ctx context.Context, id uint, request *ReviewAttendanceCorrectionRequest) (*AttendanceCorrection, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out AttendanceCorrection
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/attendance/corrections/%d/approve", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveHeadcountRequest approves a headcount request and increases the budget
+//
+// Approve a pending headcount request and add the requested seats to the matching budget (Admin only).
+//
+// PUT /api/headcount/requests/{id}/approve
+func (c *Client) ApproveHeadcountRequest(ctx context.Context, id uint, request *ReviewHeadcountRequestRequest) (*HeadcountRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out HeadcountRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/headcount/requests/%d/approve", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveLeave approves a leave request
+//
+// Approve a pending leave request (Manager/Admin only).
+//
+// PUT /api/leaves/{id}/approve
+func (c *Client) ApproveLeave(ctx context.Context, id uint) (*Leave, error) {
+	var out Leave
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/leaves/%d/approve", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveRemoteWork approves a remote work request
+//
+// Approve a pending remote work request (Employee's manager or Admin).
+//
+// PUT /api/remote-work/{id}/approve
+func (c *Client) ApproveRemoteWork(ctx context.Context, id uint, request *ReviewRemoteWorkRequest) (*RemoteWorkRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out RemoteWorkRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/remote-work/%d/approve", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveShiftSwap approves a shift swap and updates the rota
+//
+// Approve a shift swap. The rota is updated straight away; the swap is refused if either employee has
+// leave or another shift on the new day (Requester's manager or Admin).
+//
+// PUT /api/shifts/swaps/{id}/approve
+func (c *Client) ApproveShiftSwap(ctx context.Context, id uint, request *ReviewShiftSwapRequest) (*ShiftSwapRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out ShiftSwapRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/shifts/swaps/%d/approve", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveTransferRequest approves a pending transfer request
+//
+// Approve a pending transfer. Only the receiving manager or an admin can approve. Transfers effective
+// today or earlier are applied immediately; later ones are applied on their effective date
+// (Manager/Admin only).
+//
+// PUT /api/transfers/{id}/approve
+func (c *Client) ApproveTransferRequest(ctx context.Context, id uint, request *ReviewTransferRequestRequest) (*TransferRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out TransferRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/transfers/%d/approve", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssignEmployeeSkill assigns a skill to an employee or updates the existing assignment
+//
+// Assign a skill to an employee with a proficiency level, or update it if already assigned.
+//
+// POST /api/employees/{id}/skills
+func (c *Client) AssignEmployeeSkill(ctx context.Context, id uint, request AssignSkillRequest) (*EmployeeSkill, error) {
+	var out EmployeeSkill
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/skills", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssignGrievance assigns a grievance to an HR case owner
+//
+// Assign a grievance to an admin who will own the case. The new owner is notified (Admin only).
+//
+// PUT /api/grievances/{id}/assign
+func (c *Client) AssignGrievance(ctx context.Context, id uint, request AssignGrievanceRequest) (*GrievanceResponse, error) {
+	var out GrievanceResponse
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/grievances/%d/assign", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssignPosition assigns a position to an employee
+//
+// Assign a position to an employee. A primary assignment ends the employee's open primary assignment
+// the day before it starts (Manager/Admin only).
+//
+// POST /api/employees/{id}/positions
+func (c *Client) AssignPosition(ctx context.Context, id uint, request PositionAssignment) (*AssignPositionResponse, error) {
+	var out AssignPositionResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/positions", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssignShift rosters an employee onto a shift for one or more days
+//
+// Roster an employee onto a shift for the given days, replacing any shift already rostered on those
+// days. Days that clash with pending or approved leave are skipped and reported unless
+// allow_leave_conflicts is set (Manager/Admin only).
+//
+// POST /api/shifts/assignments
+func (c *Client) AssignShift(ctx context.Context, request AssignShiftRequest) (*AssignShiftResponse, error) {
+	var out AssignShiftResponse
+	if err := c.call(ctx, "POST", "/api/shifts/assignments", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Batch runs several API requests in one call
+//
+// Run up to 50 API requests one after the other, each as the caller and with the result it would have
+// on its own. Results are returned in order with each request's status and body; the batch itself
+// succeeds even when some requests fail. With atomic set, the changes of all requests are committed
+// together once every request has succeeded: the first request that fails (status 400 or above) rolls
+// the batch back, and the requests after it are not run and get status 424. Events, webhooks and
+// notifications of an atomic batch are only sent once it commits.
+//
+// POST /api/batch
+func (c *Client) Batch(ctx context.Context, request BatchRequest) (*BatchResponse, error) {
+	var out BatchResponse
+	if err := c.call(ctx, "POST", "/api/batch", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkAddManualAccruals adds multiple accrual records for an employee at once
+//
+// Add multiple accrual records for an employee at once (useful for onboarding existing employees).
+//
+// POST /api/hr/employees/{id}/annual-leave-balance/accruals/bulk
+func (c *Client) BulkAddManualAccruals(ctx context.Context, id uint, request BulkAccrualRequest) (*BulkAccrualResponse, error) {
+	var out BulkAccrualResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/hr/employees/%d/annual-leave-balance/accruals/bulk", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkAdjustLeaveBalances adjusts the annual leave balances of many employees at once
+//
+// Add days to, or take them off, the annual leave balances of many employees. Employees are found by
+// nrc, or by employee_number when there is no nrc. Each adjustment works as the single employee
+// adjustment does, and is made on its own: adjustments with an error are reported by their position in
+// the list, counting from 1, without affecting the others. The request's reason is used for
+// adjustments without one. Every adjustment's audit log entry carries the batch ID returned, to find
+// them by. With dry_run, every adjustment is checked and nothing is saved (Admin only).
+//
+// POST /api/hr/employees/annual-leave-balances/adjust
+func (c *Client) BulkAdjustLeaveBalances(ctx context.Context, request BulkAdjustLeaveBalancesRequest) (*BulkAdjustLeaveBalancesResponse, error) {
+	var out BulkAdjustLeaveBalancesResponse
+	if err := c.call(ctx, "POST", "/api/hr/employees/annual-leave-balances/adjust", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkCreateLeavesParams holds the parameters of BulkCreateLeaves. Parameters left at their zero value are not sent.
+type BulkCreateLeavesParams struct {
+	File            *File // CSV file with leave data (required)
+	SkipInvalidRows bool  // Skip invalid rows instead of failing entire import
+}
+
+// BulkCreateLeaves creates multiple leave records from CSV file
+//
+// Import multiple leave records from CSV file. CSV format: Employee Name, Leave Type, Start Date
+// (YYYY-MM-DD), End Date (YYYY-MM-DD), Reason (optional).
+//
+// POST /api/hr/leaves/bulk-import
+func (c *Client) BulkCreateLeaves(ctx context.Context, params *BulkCreateLeavesParams) (*BulkCreateLeavesResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+		if params.SkipInvalidRows {
+			form.set("skip_invalid_rows", "true")
+		}
+	}
+	var out BulkCreateLeavesResponse
+	if err := c.call(ctx, "POST", "/api/hr/leaves/bulk-import", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkCreateLeavesFromTemplate creates leaves for multiple employees using a template
+//
+// Create the same leave for multiple employees (e.g., public holiday for all).
+//
+// POST /api/hr/leaves/bulk-template
+func (c *Client) BulkCreateLeavesFromTemplate(ctx context.Context, request BulkCreateLeavesTemplateRequest) (*BulkCreateLeavesResponse, error) {
+	var out BulkCreateLeavesResponse
+	if err := c.call(ctx, "POST", "/api/hr/leaves/bulk-template", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkImportLeaveBalancesParams holds the parameters of BulkImportLeaveBalances. Parameters left at their zero value are not sent.
+type BulkImportLeaveBalancesParams struct {
+	File     *File  // CSV file with leave balance data (required)
+	Month    string // Override month from CSV (YYYY-MM format)
+	ResetAll bool   // Delete all existing accruals before import
+}
+
+// BulkImportLeaveBalances imports leave balances from CSV file (matching the legacy format)
+//
+// Import leave balances for multiple employees from CSV file matching the legacy system format (Admin
+// only).
+//
+// POST /api/hr/leave-balances/import
+func (c *Client) BulkImportLeaveBalances(ctx context.Context, params *BulkImportLeaveBalancesParams) (*BulkImportLeaveBalancesResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+		if params.Month != "" {
+			form.set("month", params.Month)
+		}
+		if params.ResetAll {
+			form.set("reset_all", "true")
+		}
+	}
+	var out BulkImportLeaveBalancesResponse
+	if err := c.call(ctx, "POST", "/api/hr/leave-balances/import", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkUploadEmployeesParams holds the parameters of BulkUploadEmployees. Parameters left at their zero value are not sent.
+type BulkUploadEmployeesParams struct {
+	File *File // CSV or Excel file with employee data (required)
+}
+
+// BulkUploadEmployees uploads employees from a CSV or Excel file
+//
+// Upload multiple employees from a CSV or Excel (.xlsx) file laid out like the template. Excel files
+// are read from their first sheet (Admin only).
+//
+// POST /api/employees/bulk
+func (c *Client) BulkUploadEmployees(ctx context.Context, params *BulkUploadEmployeesParams) (*BulkUploadResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+	}
+	var out BulkUploadResponse
+	if err := c.call(ctx, "POST", "/api/employees/bulk", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelLeave cancels a leave request
+//
+// Cancel own pending or approved leave request.
+//
+// PUT /api/leaves/{id}/cancel
+func (c *Client) CancelLeave(ctx context.Context, id uint) (*Leave, error) {
+	var out Leave
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/leaves/%d/cancel", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelRemoteWork cancels one of the current user's remote work requests
+//
+// Cancel a pending or approved remote work request that has not started yet.
+//
+// PUT /api/remote-work/{id}/cancel
+func (c *Client) CancelRemoteWork(ctx context.Context, id uint) (*RemoteWorkRequest, error) {
+	var out RemoteWorkRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/remote-work/%d/cancel", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelShiftSwap cancels a pending shift swap
+//
+// Cancel a pending shift swap you requested.
+//
+// PUT /api/shifts/swaps/{id}/cancel
+func (c *Client) CancelShiftSwap(ctx context.Context, id uint) (*ShiftSwapRequest, error) {
+	var out ShiftSwapRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/shifts/swaps/%d/cancel", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelTrainingEnrollment cancels an enrollment before the session is delivered
+//
+// Cancel an enrollment. Employees can cancel their own; managers and admins can cancel anyone's.
+//
+// PUT /api/training/enrollments/{id}/cancel
+func (c *Client) CancelTrainingEnrollment(ctx context.Context, id uint) (*TrainingEnrollment, error) {
+	var out TrainingEnrollment
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/training/enrollments/%d/cancel", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelTransferRequest cancels a transfer that has not yet been applied
+//
+// Cancel a pending or approved transfer before it takes effect. Only the requester or an admin can
+// cancel (Manager/Admin only).
+//
+// PUT /api/transfers/{id}/cancel
+func (c *Client) CancelTransferRequest(ctx context.Context, id uint) (*TransferRequest, error) {
+	var out TransferRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/transfers/%d/cancel", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ChangePassword allows an employee to change their own password
+//
+// Change password for the authenticated user (requires current password).
+//
+// PUT /api/employees/{id}/password
+func (c *Client) ChangePassword(ctx context.Context, id uint, request ChangePasswordRequest) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/password", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ClockIn records the current user's arrival for today
+//
+// Clock in for today. The client IP and optional coordinates are captured and the day is flagged late
+// if after the schedule's grace period.
+//
+// POST /api/attendance/clock-in
+func (c *Client) ClockIn(ctx context.Context, request *ClockRequest) (*AttendanceRecord, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out AttendanceRecord
+	if err := c.call(ctx, "POST", "/api/attendance/clock-in", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ClockOut records the current user's departure for today
+//
+// Clock out for today. The client IP and optional coordinates are captured and worked time is
+// calculated.
+//
+// POST /api/attendance/clock-out
+func (c *Client) ClockOut(ctx context.Context, request *ClockRequest) (*AttendanceRecord, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out AttendanceRecord
+	if err := c.call(ctx, "POST", "/api/attendance/clock-out", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CompleteTraining records the outcome of an attended enrollment
+//
+// Record whether an attended employee passed. A pass generates a completion certificate stored in the
+// employee's documents and updates the linked compliance record (Manager/Admin only).
+//
+// PUT /api/training/enrollments/{id}/complete
+func (c *Client) CompleteTraining(ctx context.Context, id uint, request CompleteTrainingRequest) (*TrainingEnrollment, error) {
+	var out TrainingEnrollment
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/training/enrollments/%d/complete", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectCalendar starts connecting a calendar for the current user
+//
+// Get the Google or Microsoft consent page to send the browser to. Once the user consents, the
+// provider redirects to the server, which saves the connection and adds the user's upcoming approved
+// leaves, and their direct reports' if they manage a team, to the calendar. The link expires after 10
+// minutes. Connecting a provider again replaces its account and keeps the preferences.
+//
+// POST /api/calendar-connections/authorize
+func (c *Client) ConnectCalendar(ctx context.Context, request ConnectCalendarRequest) (*ConnectCalendarResponse, error) {
+	var out ConnectCalendarResponse
+	if err := c.call(ctx, "POST", "/api/calendar-connections/authorize", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAdmin creates a new admin account with username
+//
+// Create a new admin account with username (Admin only).
+//
+// POST /api/admins
+func (c *Client) CreateAdmin(ctx context.Context, request CreateAdminRequest) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "POST", "/api/admins", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAnnualLeaveBalancesExportJob queues an export of annual leave balances
+//
+// Queue an export of the annual leave balances of all employees to Excel or PDF, generated in the
+// background in the language and on the paper size asked for. Poll GET /api/export-jobs/{id} until the
+// job is completed, then download the file from its download_url. Use this instead of GET
+// /api/hr/employees/annual-leave-balances/export for large organizations (Manager/Admin only).
+//
+// POST /api/hr/employees/annual-leave-balances/export-jobs
+func (c *Client) CreateAnnualLeaveBalancesExportJob(ctx context.Context, request CreateExportJobRequest) (*ExportJob, error) {
+	var out ExportJob
+	if err := c.call(ctx, "POST", "/api/hr/employees/annual-leave-balances/export-jobs", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAttendanceCorrection requests a correction to an attendance day
+//
+// Request a correction to the clock times of a past attendance day. Employees request for themselves;
+// managers and admins may request for others. The employee's manager or an admin reviews it.
+//
+// POST /api/attendance/corrections
+func (c *Client) CreateAttendanceCorrection(ctx context.Context, request CreateAttendanceCorrectionRequest) (*AttendanceCorrection, error) {
+	var out AttendanceCorrection
+	if err := c.call(ctx, "POST", "/api/attendance/corrections", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAttendanceDevice registers an attendance terminal
+//
+// Register a terminal and issue the key it sends clock events with, in the X-Device-Key header of POST
+// /integrations/attendance/events. The key is only returned here and when it is rotated (Admin only).
+//
+// POST /api/admin/attendance/devices
+func (c *Client) CreateAttendanceDevice(ctx context.Context, request AttendanceDeviceRequest) (*AttendanceDeviceResponse, error) {
+	var out AttendanceDeviceResponse
+	if err := c.call(ctx, "POST", "/api/admin/attendance/devices", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateBackup starts a backup of the database and document files
+//
+// Start writing a backup bundle (database dump plus document files with their checksums) to
+// BACKUPS_PATH. The backup runs in the background; follow it with GET /api/admin/backup-jobs/{id}.
+// Only one backup or restore runs at a time (Admins of the default organization only).
+//
+// POST /api/admin/backups
+func (c *Client) CreateBackup(ctx context.Context) (*Job, error) {
+	var out Job
+	if err := c.call(ctx, "POST", "/api/admin/backups", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCertification adds a certification to the catalogue
+//
+// Add a new certification to the catalogue. Linking a compliance requirement mirrors employee holdings
+// as compliance records so expiry reminders go through the compliance pipeline (Manager/Admin only).
+//
+// POST /api/certifications
+func (c *Client) CreateCertification(ctx context.Context, request CreateCertificationRequest) (*Certification, error) {
+	var out Certification
+	if err := c.call(ctx, "POST", "/api/certifications", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateChatLinkCode creates a code linking a chat account to the current user
+//
+// Create a one-time code that links the Slack or Teams account sending it to the leave bot to the
+// current user. It expires after 10 minutes and replaces any earlier code.
+//
+// POST /api/chat-accounts/link-code
+func (c *Client) CreateChatLinkCode(ctx context.Context) (*ChatLinkCodeResponse, error) {
+	var out ChatLinkCodeResponse
+	if err := c.call(ctx, "POST", "/api/chat-accounts/link-code", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCompanyValue adds a company value
+//
+// Add a company value that kudos can be given against (Admin only).
+//
+// POST /api/recognition/values
+func (c *Client) CreateCompanyValue(ctx context.Context, request CompanyValueRequest) (*CompanyValue, error) {
+	var out CompanyValue
+	if err := c.call(ctx, "POST", "/api/recognition/values", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCompensationRecord records a change to an employee's pay
+//
+// Record an employee's pay from an effective date. The amount is checked against the salary band of
+// the position, by default the employee's current primary position; amounts in ZMW outside the band
+// are refused with code salary_out_of_band unless out_of_band_reason explains them. Records cannot be
+// changed or deleted: record a correction instead. When the record is the one in effect today, the
+// salary of the employee's current assignment to that position is updated to match. Payroll users
+// cannot record their own pay (Payroll access only).
+//
+// POST /api/payroll/employees/{id}/compensation
+func (c *Client) CreateCompensationRecord(ctx context.Context, id uint, request CompensationRequest) (*CompensationRecord, error) {
+	var out CompensationRecord
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/payroll/employees/%d/compensation", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateComplianceRecord creates a new compliance record
+//
+// Create a new compliance record for an employee (Manager/Admin only).
+//
+// POST /api/employees/{id}/compliance
+func (c *Client) CreateComplianceRecord(ctx context.Context, id uint, request ComplianceRecord) (*ComplianceRecord, error) {
+	var out ComplianceRecord
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/compliance", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateComplianceRequirement creates a new compliance requirement
+//
+// Create a new compliance requirement (Manager/Admin only).
+//
+// POST /api/compliance/requirements
+func (c *Client) CreateComplianceRequirement(ctx context.Context, request ComplianceRequirement) (*ComplianceRequirement, error) {
+	var out ComplianceRequirement
+	if err := c.call(ctx, "POST", "/api/compliance/requirements", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCostCenter creates a cost center
+//
+// Create a cost center employees and positions can be allocated to. Codes are unique (Admin only).
+//
+// POST /api/admin/cost-centers
+func (c *Client) CreateCostCenter(ctx context.Context, request CostCenterRequest) (*CostCenter, error) {
+	var out CostCenter
+	if err := c.call(ctx, "POST", "/api/admin/cost-centers", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateDocumentParams holds the parameters of CreateDocument. Parameters left at their zero value are not sent.
+type CreateDocumentParams struct {
+	File           *File  // Document file to upload (required)
+	DocumentType   string // Document type (id, contract, offer_letter, resume, certificate, license, performance, disciplinary, compliance, other) (required)
+	Title          string // Document title (required)
+	Description    string // Document description
+	IssueDate      string // Issue date (YYYY-MM-DD)
+	ExpiryDate     string // Expiry date (YYYY-MM-DD)
+	IsConfidential bool   // Is document confidential
+	Tags           string // Document tags (comma-separated)
+}
+
+// CreateDocument handles file upload and creates a new document record
+//
+// Upload a file and create a new document record for an employee. Accepts multipart/form-data with
+// file upload.
+//
+// POST /api/employees/{id}/documents
+func (c *Client) CreateDocument(ctx context.Context, id uint, params *CreateDocumentParams) (*Document, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+		if params.DocumentType != "" {
+			form.set("document_type", params.DocumentType)
+		}
+		if params.Title != "" {
+			form.set("title", params.Title)
+		}
+		if params.Description != "" {
+			form.set("description", params.Description)
+		}
+		if params.IssueDate != "" {
+			form.set("issue_date", params.IssueDate)
+		}
+		if params.ExpiryDate != "" {
+			form.set("expiry_date", params.ExpiryDate)
+		}
+		if params.IsConfidential {
+			form.set("is_confidential", "true")
+		}
+		if params.Tags != "" {
+			form.set("tags", params.Tags)
+		}
+	}
+	var out Document
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/documents", id), query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateDocumentTemplate adds a document template
+//
+// Add a template for employment contracts or offer letters. The title and body may use the
+// placeholders listed by /api/admin/document-templates/placeholders, written as {{key}}; unknown
+// placeholders are rejected (Admin only).
+//
+// POST /api/admin/document-templates
+func (c *Client) CreateDocumentTemplate(ctx context.Context, request DocumentTemplateRequest) (*DocumentTemplate, error) {
+	var out DocumentTemplate
+	if err := c.call(ctx, "POST", "/api/admin/document-templates", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateEducation adds an education record for an employee
+//
+// Add an education or qualification record for an employee. New records start with pending
+// verification.
+//
+// POST /api/employees/{id}/education
+func (c *Client) CreateEducation(ctx context.Context, id uint, request EducationRequest) (*Education, error) {
+	var out Education
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/education", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateEmailTemplate adds an email template
+//
+// Replace the built-in wording of a notification category's emails in one language. The subject and
+// bodies may use the placeholders listed for the category by /api/admin/email-templates/placeholders,
+// written as {{key}}; unknown placeholders are rejected. Each category has at most one template per
+// language, and recipients whose language has none get the built-in email (Admin only).
+//
+// POST /api/admin/email-templates
+func (c *Client) CreateEmailTemplate(ctx context.Context, request EmailTemplateRequest) (*EmailTemplate, error) {
+	var out EmailTemplate
+	if err := c.call(ctx, "POST", "/api/admin/email-templates", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateEmployee creates a new employee/manager account (not admin)
+//
+// Create a new employee or manager account with NRC (Admin only). Use /api/admins for admin accounts.
+//
+// POST /api/employees
+func (c *Client) CreateEmployee(ctx context.Context, request CreateEmployeeRequest) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "POST", "/api/employees", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateEmploymentLetter issues a proof-of-employment letter for the current user
+//
+// Issue a proof-of-employment letter, for a bank for example, stating your name, position, department
+// and hire date, and your salary if include_salary is set. The letter carries a verification code
+// third parties can check at /verify/employment/{code} for 90 days. Download it as a PDF from
+// /api/me/employment-letters/{id}/download. Only current employees can issue letters.
+//
+// POST /api/me/employment-letters
+func (c *Client) CreateEmploymentLetter(ctx context.Context, request *EmploymentLetterRequest) (*EmploymentLetter, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out EmploymentLetter
+	if err := c.call(ctx, "POST", "/api/me/employment-letters", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateExitQuestionSet creates an exit interview question set
+//
+// Create an exit interview question set. Questions are asked in the order given (Admin only).
+//
+// POST /api/exit-interviews/question-sets
+func (c *Client) CreateExitQuestionSet(ctx context.Context, request ExitQuestionSetRequest) (*ExitQuestionSet, error) {
+	var out ExitQuestionSet
+	if err := c.call(ctx, "POST", "/api/exit-interviews/question-sets", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateHRISMapping saves an HRIS field mapping
+//
+// Save a mapping from the field names of another HR system to the fields of the HRIS interchange
+// schema, for imports and exports to refer to by name. Field names in the other system use dots for
+// nested objects; mapping a field to "" drops it on import. Schema fields the mapping leaves out keep
+// their own names (Admin only).
+//
+// POST /api/admin/hris/mappings
+func (c *Client) CreateHRISMapping(ctx context.Context, request HRISMappingRequest) (*HRISMapping, error) {
+	var out HRISMapping
+	if err := c.call(ctx, "POST", "/api/admin/hris/mappings", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateHeadcountRequest submits a request to increase headcount
+//
+// Submit a request to increase the budgeted headcount of a position or department (Manager/Admin
+// only).
+//
+// POST /api/headcount/requests
+func (c *Client) CreateHeadcountRequest(ctx context.Context, request CreateHeadcountRequestRequest) (*HeadcountRequest, error) {
+	var out HeadcountRequest
+	if err := c.call(ctx, "POST", "/api/headcount/requests", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateHoliday adds a public holiday by hand
+//
+// Add a holiday to the organization's holiday calendar, such as one declared at short notice. It is
+// active straight away (Admin only).
+//
+// POST /api/admin/holidays
+func (c *Client) CreateHoliday(ctx context.Context, request HolidayRequest) (*PublicHoliday, error) {
+	var out PublicHoliday
+	if err := c.call(ctx, "POST", "/api/admin/holidays", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateLeaveForEmployee creates a leave record for an employee (Admin only)
+//
+// Admin creates a leave record for any employee (Admin only).
+//
+// POST /api/hr/leaves
+func (c *Client) CreateLeaveForEmployee(ctx context.Context, request AdminLeaveRequest) (*Leave, error) {
+	var out Leave
+	if err := c.call(ctx, "POST", "/api/hr/leaves", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateLeaveType creates a new leave type
+//
+// Create a new leave type (Admin only).
+//
+// POST /api/leave-types
+func (c *Client) CreateLeaveType(ctx context.Context, request CreateLeaveTypeRequest) (*LeaveType, error) {
+	var out LeaveType
+	if err := c.call(ctx, "POST", "/api/leave-types", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateLegalHold places an employee's records under legal hold
+//
+// Exempt all of an employee's records from retention purges, e.g. while litigation or an investigation
+// is pending, until the hold is released (Admin only).
+//
+// POST /api/admin/legal-holds
+func (c *Client) CreateLegalHold(ctx context.Context, request LegalHoldRequest) (*LegalHold, error) {
+	var out LegalHold
+	if err := c.call(ctx, "POST", "/api/admin/legal-holds", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateLifecycleEvent creates a new lifecycle event
+//
+// Create a new lifecycle event for an employee (Manager/Admin only). Resigned, terminated, retired and
+// status_change events also update the employment status, and leaving ones the termination date.
+//
+// POST /api/employees/{id}/lifecycle
+func (c *Client) CreateLifecycleEvent(ctx context.Context, id uint, request WorkLifecycleEvent) (*WorkLifecycleEvent, error) {
+	var out WorkLifecycleEvent
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/lifecycle", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateNationalIDFormat sets up a country's national ID format
+//
+// Set up how NRCs of a country are validated and stored. IDs are matched in compact form, upper case
+// with spaces and punctuation removed, against the pattern, their check digit is verified if the
+// format has one, and they are stored laid out by the format so the same ID cannot be registered twice
+// written differently. The example must pass the format. NRCs already stored are not changed (Admin
+// only).
+//
+// POST /api/admin/national-id-formats
+func (c *Client) CreateNationalIDFormat(ctx context.Context, request NationalIDFormatRequest) (*NationalIDFormat, error) {
+	var out NationalIDFormat
+	if err := c.call(ctx, "POST", "/api/admin/national-id-formats", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOffboardingProcess creates a new offboarding process
+//
+// Create a new offboarding process for an employee (Manager/Admin only).
+//
+// POST /api/employees/{id}/offboarding
+func (c *Client) CreateOffboardingProcess(ctx context.Context, id uint, request OffboardingProcess) (*OffboardingProcess, error) {
+	var out OffboardingProcess
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/offboarding", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOnboardingProcess creates a new onboarding process
+//
+// Create a new onboarding process for an employee (Manager/Admin only).
+//
+// POST /api/employees/{id}/onboarding
+func (c *Client) CreateOnboardingProcess(ctx context.Context, id uint, request OnboardingProcess) (*OnboardingProcess, error) {
+	var out OnboardingProcess
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/onboarding", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOrUpdateBankDetails creates or updates an employee's bank details
+//
+// Create or update an employee's bank details. The response masks the account number (Admin only).
+//
+// PUT /api/employees/{id}/bank-details
+func (c *Client) CreateOrUpdateBankDetails(ctx context.Context, id uint, request BankDetailsRequest) (*BankDetails, error) {
+	var out BankDetails
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/bank-details", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOrUpdateEmploymentDetails creates or updates employment details
+//
+// Create or update employment details for an employee. When updating, send the version from the last
+// read; if the details have changed since, nothing is saved and 409 is returned with the current
+// details. Without a version the update applies to whatever is stored.
+//
+// POST /api/employees/{id}/employment
+func (c *Client) CreateOrUpdateEmploymentDetails(ctx context.Context, id uint, request EmploymentDetails) (*EmploymentDetails, error) {
+	var out EmploymentDetails
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/employment", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOrUpdateIdentityInformation creates or updates identity information
+//
+// Create or update identity information for an employee.
+//
+// POST /api/employees/{id}/identity
+func (c *Client) CreateOrUpdateIdentityInformation(ctx context.Context, id uint, request IdentityInformation) (*IdentityInformation, error) {
+	var out IdentityInformation
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/identity", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOrganization creates an organization with its first admin and the leave types of a leave preset
+//
+// Create an organization together with its first admin account and the leave types of a leave preset,
+// such as a country's statutory leave (see GET /api/admin/leave-presets). The admin signs in with
+// their username and manages the new organization's data, which no other organization can see (Admins
+// of the default organization only).
+//
+// POST /api/organizations
+func (c *Client) CreateOrganization(ctx context.Context, request CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	var out CreateOrganizationResponse
+	if err := c.call(ctx, "POST", "/api/organizations", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreatePosition creates a new position
+//
+// Create a new position (Manager/Admin only).
+//
+// POST /api/positions
+func (c *Client) CreatePosition(ctx context.Context, request Position) (*Position, error) {
+	var out Position
+	if err := c.call(ctx, "POST", "/api/positions", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateRemoteWork submits a remote work request for the current user
+//
+// Request to work remotely for a date range. The range may not overlap pending or approved leave or
+// another open remote work request.
+//
+// POST /api/remote-work
+func (c *Client) CreateRemoteWork(ctx context.Context, request CreateRemoteWorkRequest) (*RemoteWorkRequest, error) {
+	var out RemoteWorkRequest
+	if err := c.call(ctx, "POST", "/api/remote-work", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateShift defines a new shift
+//
+// Define a shift. An end time earlier than the start time makes an overnight shift (Manager/Admin
+// only).
+//
+// POST /api/shifts
+func (c *Client) CreateShift(ctx context.Context, request CreateShiftRequest) (*Shift, error) {
+	var out Shift
+	if err := c.call(ctx, "POST", "/api/shifts", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateShiftSwap requests to swap a rostered shift with a colleague
+//
+// Request to swap one of your rostered shifts for a colleague's shift, or hand it over to a colleague.
+// The swap takes effect once a manager approves it.
+//
+// POST /api/shifts/swaps
+func (c *Client) CreateShiftSwap(ctx context.Context, request CreateShiftSwapRequest) (*ShiftSwapRequest, error) {
+	var out ShiftSwapRequest
+	if err := c.call(ctx, "POST", "/api/shifts/swaps", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateSkill adds a skill to the catalogue
+//
+// Add a new skill to the catalogue (Manager/Admin only).
+//
+// POST /api/skills
+func (c *Client) CreateSkill(ctx context.Context, request CreateSkillRequest) (*Skill, error) {
+	var out Skill
+	if err := c.call(ctx, "POST", "/api/skills", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateTrainingCourse adds a course to the catalogue
+//
+// Add a training course. Linking a compliance requirement mirrors completions as compliance records
+// (Manager/Admin only).
+//
+// POST /api/training/courses
+func (c *Client) CreateTrainingCourse(ctx context.Context, request CreateTrainingCourseRequest) (*TrainingCourse, error) {
+	var out TrainingCourse
+	if err := c.call(ctx, "POST", "/api/training/courses", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateTrainingSession schedules a session of a course
+//
+// Schedule a session of a training course (Manager/Admin only).
+//
+// POST /api/training/sessions
+func (c *Client) CreateTrainingSession(ctx context.Context, request CreateTrainingSessionRequest) (*TrainingSession, error) {
+	var out TrainingSession
+	if err := c.call(ctx, "POST", "/api/training/sessions", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateTransferRequest raises a transfer request for an employee
+//
+// Raise a request to move an employee to a new department, position and/or manager from an effective
+// date (Manager/Admin only).
+//
+// POST /api/transfers
+func (c *Client) CreateTransferRequest(ctx context.Context, request CreateTransferRequestRequest) (*TransferRequest, error) {
+	var out TransferRequest
+	if err := c.call(ctx, "POST", "/api/transfers", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateWebhookSubscription registers a webhook endpoint
+//
+// Register an endpoint to receive the given event types. Each delivery is a JSON POST signed with the
+// subscription secret: X-Webhook-Signature is sha256= followed by the hex HMAC-SHA256 of
+// "<X-Webhook-Timestamp>.<body>". The secret is only returned here and when it is rotated (Admin
+// only).
+//
+// POST /api/webhooks
+func (c *Client) CreateWebhookSubscription(ctx context.Context, request WebhookSubscriptionRequest) (*WebhookSubscriptionResponse, error) {
+	var out WebhookSubscriptionResponse
+	if err := c.call(ctx, "POST", "/api/webhooks", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateWorkSchedule creates a work schedule
+//
+// Create a work schedule. Employees use it when their employment work_schedule matches its name (Admin
+// only).
+//
+// POST /api/work-schedules
+func (c *Client) CreateWorkSchedule(ctx context.Context, request CreateWorkScheduleRequest) (*WorkSchedule, error) {
+	var out WorkSchedule
+	if err := c.call(ctx, "POST", "/api/work-schedules", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeactivatePosition closes a position so it no longer accepts assignments
+//
+// Deactivate (close) a position. Fails if the position still has active assignments (Manager/Admin
+// only).
+//
+// DELETE /api/positions/{id}
+func (c *Client) DeactivatePosition(ctx context.Context, id uint) (*Position, error) {
+	var out Position
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/positions/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAttendanceDevice removes an attendance terminal
+//
+// Remove a terminal that has sent no clock events. Deactivate a terminal with events instead, so its
+// history is kept (Admin only).
+//
+// DELETE /api/admin/attendance/devices/{id}
+func (c *Client) DeleteAttendanceDevice(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/attendance/devices/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteCalendarConnection disconnects a calendar from the current user
+//
+// Stop adding leaves to a connected calendar. The events already added are removed from it in the
+// background.
+//
+// DELETE /api/calendar-connections/{id}
+func (c *Client) DeleteCalendarConnection(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/calendar-connections/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteChatAccount unlinks a chat account from the current user
+//
+// Stop the leave bot acting as the current user for a Slack or Teams account.
+//
+// DELETE /api/chat-accounts/{id}
+func (c *Client) DeleteChatAccount(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/chat-accounts/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteCostCenter deletes a cost center
+//
+// Delete a cost center nothing was ever allocated to. Deactivate cost centers with allocations
+// instead, so past allocations and exports keep them (Admin only).
+//
+// DELETE /api/admin/cost-centers/{id}
+func (c *Client) DeleteCostCenter(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/cost-centers/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDevice unregisters one of the current user's devices
+//
+// Stop pushing notifications to a device of the current user, e.g. when they sign out of the app.
+//
+// DELETE /api/notifications/devices/{id}
+func (c *Client) DeleteDevice(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/notifications/devices/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDocument deletes a document and its file
+//
+// Delete a document record and its associated file.
+//
+// DELETE /api/employees/{id}/documents/{doc_id}
+func (c *Client) DeleteDocument(ctx context.Context, id uint, docID uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/employees/%d/documents/%d", id, docID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDocumentTemplate deletes a document template
+//
+// Delete a document template. Documents already generated from it are kept (Admin only).
+//
+// DELETE /api/admin/document-templates/{id}
+func (c *Client) DeleteDocumentTemplate(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/document-templates/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteEducation deletes an education record
+//
+// Delete an education record for an employee.
+//
+// DELETE /api/employees/{id}/education/{education_id}
+func (c *Client) DeleteEducation(ctx context.Context, id uint, educationID uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/employees/%d/education/%d", id, educationID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteEmailTemplate deletes an email template
+//
+// Delete an email template, so the category's emails in its language go back to the built-in wording
+// (Admin only).
+//
+// DELETE /api/admin/email-templates/{id}
+func (c *Client) DeleteEmailTemplate(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/email-templates/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteEmployee deletes an employee
+//
+// Delete an employee (Admin only).
+//
+// DELETE /api/employees/{id}
+func (c *Client) DeleteEmployee(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/employees/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteHRISMapping deletes an HRIS field mapping
+//
+// Delete an HRIS field mapping (Admin only).
+//
+// DELETE /api/admin/hris/mappings/{id}
+func (c *Client) DeleteHRISMapping(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/hris/mappings/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteHoliday deletes a public holiday added by hand
+//
+// Delete a holiday added by hand. Imported holidays are rejected instead, so later imports do not add
+// them again (Admin only).
+//
+// DELETE /api/admin/holidays/{id}
+func (c *Client) DeleteHoliday(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/holidays/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteKudos removes inappropriate kudos
+//
+// Remove kudos from the feed and reports (Admin only).
+//
+// DELETE /api/recognition/kudos/{id}
+func (c *Client) DeleteKudos(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/recognition/kudos/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteLeaveForEmployee deletes a leave record (Admin only)
+//
+// Admin deletes a leave record for any employee (Admin only).
+//
+// DELETE /api/hr/leaves/{id}
+func (c *Client) DeleteLeaveForEmployee(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/hr/leaves/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteLeaveType deletes a leave type
+//
+// Delete a leave type (Admin only).
+//
+// DELETE /api/leave-types/{id}
+func (c *Client) DeleteLeaveType(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/leave-types/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteMandatoryTraining stops a course being mandatory for a role
+//
+// Stop requiring a course for a role (Admin only).
+//
+// DELETE /api/training/mandatory/{id}
+func (c *Client) DeleteMandatoryTraining(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/training/mandatory/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteNationalIDFormat deletes a country's national ID format
+//
+// Delete a national ID format. NRCs of that country are then rejected until it is set up again (Admin
+// only).
+//
+// DELETE /api/admin/national-id-formats/{id}
+func (c *Client) DeleteNationalIDFormat(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/national-id-formats/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteShiftAssignment removes a day from the rota
+//
+// Remove an employee's shift from the rota (Manager/Admin only).
+//
+// DELETE /api/shifts/assignments/{id}
+func (c *Client) DeleteShiftAssignment(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/shifts/assignments/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription
+//
+// Delete a subscription. Its delivery log is kept and its pending retries are given up (Admin only).
+//
+// DELETE /api/webhooks/{id}
+func (c *Client) DeleteWebhookSubscription(ctx context.Context, id uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/webhooks/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DownloadBackup downloads a backup bundle
+//
+// Download a backup bundle, e.g. to keep it off the server or restore it into another instance (Admins
+// of the default organization only).
+//
+// GET /api/admin/backups/{name}
+func (c *Client) DownloadBackup(ctx context.Context, name string) (io.ReadCloser, error) {
+	return c.download(ctx, "GET", fmt.Sprintf("/api/admin/backups/%s", url.PathEscape(name)), nil, nil)
+}
+
+// DownloadDocument downloads a document file
+//
+// Download the actual file for a document.
+//
+// GET /api/employees/{id}/documents/{doc_id}/download
+func (c *Client) DownloadDocument(ctx context.Context, id uint, docID uint) (io.ReadCloser, error) {
+	return c.download(ctx, "GET", fmt.Sprintf("/api/employees/%d/documents/%d/download", id, docID), nil, nil)
+}
+
+// DownloadEmployeeTemplateParams holds the parameters of DownloadEmployeeTemplate. Parameters left at their zero value are not sent.
+type DownloadEmployeeTemplateParams struct {
+	Format string // Template format: csv or xlsx (default: csv)
+}
+
+// DownloadEmployeeTemplate returns a CSV or Excel template for bulk employee upload
+//
+// Download a CSV or Excel template for bulk employee upload. The Excel template keeps NRCs as text and
+// offers dropdowns for the role and the existing departments (Admin only).
+//
+// GET /api/employees/template
+func (c *Client) DownloadEmployeeTemplate(ctx context.Context, params *DownloadEmployeeTemplateParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+	}
+	return c.download(ctx, "GET", "/api/employees/template", query, nil)
+}
+
+// DownloadEmploymentLetter downloads one of the current user's employment letters as a PDF
+//
+// Download one of your employment letters as a PDF.
+//
+// GET /api/me/employment-letters/{id}/download
+func (c *Client) DownloadEmploymentLetter(ctx context.Context, id uint) (io.ReadCloser, error) {
+	return c.download(ctx, "GET", fmt.Sprintf("/api/me/employment-letters/%d/download", id), nil, nil)
+}
+
+// DownloadEmploymentTemplate returns a CSV template for importing employment details
+//
+// Download a CSV template for importing employment details. Each row is keyed by nrc or
+// employee_number (Admin only).
+//
+// GET /api/employees/employment/template
+func (c *Client) DownloadEmploymentTemplate(ctx context.Context) (io.ReadCloser, error) {
+	return c.download(ctx, "GET", "/api/employees/employment/template", nil, nil)
+}
+
+// DownloadExportJobParams holds the parameters of DownloadExportJob. Parameters left at their zero value are not sent.
+type DownloadExportJobParams struct {
+	Expires   int    // Expiry of the link, as a Unix time (required)
+	Signature string // Signature of the link (required)
+}
+
+// DownloadExportJob downloads the file of a completed export job
+//
+// Download the file of a completed export job through the signed download_url of GET
+// /api/export-jobs/{id}. No token is needed; the link expires after 15 minutes.
+//
+// GET /api/export-jobs/{id}/download
+func (c *Client) DownloadExportJob(ctx context.Context, id uint, params *DownloadExportJobParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Expires != 0 {
+			query.Set("expires", strconv.Itoa(params.Expires))
+		}
+		if params.Signature != "" {
+			query.Set("signature", params.Signature)
+		}
+	}
+	return c.download(ctx, "GET", fmt.Sprintf("/api/export-jobs/%d/download", id), query, nil)
+}
+
+// DownloadIdentityTemplate returns a CSV template for importing identity information
+//
+// Download a CSV template for importing identity and contact information. Each row is keyed by nrc or
+// employee_number (Admin only).
+//
+// GET /api/employees/identity/template
+func (c *Client) DownloadIdentityTemplate(ctx context.Context) (io.ReadCloser, error) {
+	return c.download(ctx, "GET", "/api/employees/identity/template", nil, nil)
+}
+
+// DownloadLeaveForm downloads the leave form attachment
+//
+// Download the leave form file (PNG/PDF) attached to a leave record.
+//
+// GET /api/hr/leaves/:id/form
+func (c *Client) DownloadLeaveForm(ctx context.Context) (io.ReadCloser, error) {
+	return c.download(ctx, "GET", "/api/hr/leaves/:id/form", nil, nil)
+}
+
+// EndPositionAssignment closes an employee's position assignment
+//
+// Set the end date of an employee's position assignment. Clears the employee's current position if the
+// assignment was primary (Manager/Admin only).
+//
+// PUT /api/employees/{id}/positions/{assignment_id}/end
+func (c *Client) EndPositionAssignment(ctx context.Context, id uint, assignmentID uint, request *EndPositionAssignmentRequest) (*PositionAssignment, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out PositionAssignment
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/positions/%d/end", id, assignmentID), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EnrollInTrainingSession enrolls employees on a session
+//
+// Enroll yourself on a training session. Managers and admins can enroll other employees by passing
+// employee_ids.
+//
+// POST /api/training/sessions/{id}/enroll
+func (c *Client) EnrollInTrainingSession(ctx context.Context, id uint, request *EnrollTrainingRequest) ([]TrainingEnrollment, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out []TrainingEnrollment
+	err := c.call(ctx, "POST", fmt.Sprintf("/api/training/sessions/%d/enroll", id), nil, body, &out)
+	return out, err
+}
+
+// ExpireCarryOvers manually expires carry-overs that have passed their expiry date
+//
+// Manually expire carry-overs that have passed their expiry date (HR/Admin only).
+//
+// POST /api/hr/leaves/expire-carryovers
+func (c *Client) ExpireCarryOvers(ctx context.Context) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "POST", "/api/hr/leaves/expire-carryovers", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportAnnualLeaveBalancesParams holds the parameters of ExportAnnualLeaveBalances. Parameters left at their zero value are not sent.
+type ExportAnnualLeaveBalancesParams struct {
+	Format     string // Export format (excel or pdf) (required)
+	Department string // Filter by department
+	Status     string // Filter by employment status
+	Detailed   bool   // Add a summary sheet by department and a sheet per employee with their accrual ledger and approved leaves (Excel only)
+	Lang       string // Language of the file: en, fr or pt (default: the Accept-Language header)
+	PaperSize  string // Paper size of a PDF: A4 or Letter (default: the export_paper_size setting)
+}
+
+// ExportAnnualLeaveBalances exports annual leave balances to Excel or PDF
+//
+// Export annual leave balances for all employees to Excel or PDF format (Admin only).
+//
+// GET /api/hr/employees/annual-leave-balances/export
+func (c *Client) ExportAnnualLeaveBalances(ctx context.Context, params *ExportAnnualLeaveBalancesParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Detailed {
+			query.Set("detailed", "true")
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+		if params.PaperSize != "" {
+			query.Set("paper_size", params.PaperSize)
+		}
+	}
+	return c.download(ctx, "GET", "/api/hr/employees/annual-leave-balances/export", query, nil)
+}
+
+// ExportEmployeeParams holds the parameters of ExportEmployee. Parameters left at their zero value are not sent.
+type ExportEmployeeParams struct {
+	Lang      string // Language of the PDF: en, fr or pt (default: the Accept-Language header)
+	PaperSize string // Paper size of the PDF: A4 or Letter (default: the export_paper_size setting)
+}
+
+// ExportEmployee exports single employee data to PDF
+//
+// Export single employee detailed data to PDF format (Admin only).
+//
+// GET /api/employees/{id}/export
+func (c *Client) ExportEmployee(ctx context.Context, id uint, params *ExportEmployeeParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+		if params.PaperSize != "" {
+			query.Set("paper_size", params.PaperSize)
+		}
+	}
+	return c.download(ctx, "GET", fmt.Sprintf("/api/employees/%d/export", id), query, nil)
+}
+
+// ExportEmployeeAnnualLeaveParams holds the parameters of ExportEmployeeAnnualLeave. Parameters left at their zero value are not sent.
+type ExportEmployeeAnnualLeaveParams struct {
+	Format    string // Export format (excel or pdf) (required)
+	Lang      string // Language of the file: en, fr or pt (default: the Accept-Language header)
+	PaperSize string // Paper size of a PDF: A4 or Letter (default: the export_paper_size setting)
+}
+
+// ExportEmployeeAnnualLeave exports single employee annual leave report to Excel or PDF
+//
+// Export annual leave report for a specific employee to Excel or PDF format (HR/Admin only).
+//
+// GET /api/hr/employees/{id}/annual-leave-balance/export
+func (c *Client) ExportEmployeeAnnualLeave(ctx context.Context, id uint, params *ExportEmployeeAnnualLeaveParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+		if params.PaperSize != "" {
+			query.Set("paper_size", params.PaperSize)
+		}
+	}
+	return c.download(ctx, "GET", fmt.Sprintf("/api/hr/employees/%d/annual-leave-balance/export", id), query, nil)
+}
+
+// ExportEmployeesParams holds the parameters of ExportEmployees. Parameters left at their zero value are not sent.
+type ExportEmployeesParams struct {
+	Format     string // Export format: pdf, xlsx or csv (default: pdf)
+	Columns    string // Comma-separated roster columns, e.g. employee_number,firstname,lastname,department,position_title,hire_date
+	Department string // Only export the roster of this department
+	Status     string // Only export the roster of employees with this status
+	Lang       string // Language of the PDF: en, fr or pt (default: the Accept-Language header)
+	PaperSize  string // Paper size of the PDF: A4 or Letter (default: the export_paper_size setting)
+}
+
+// ExportEmployees exports all employees data to PDF, or the employee roster to Excel or CSV
+//
+// Export all employees data to PDF (Admins only), or a roster of employees with their employment
+// details and position to Excel or CSV (Managers and Admins). Roster columns can be chosen with
+// columns; without it every column the user may see is exported. Personal columns (nrc, date_of_birth,
+// gender, address, city, postal_code, emergency contact and notes) are only exported for admins, and
+// bank_name, bank_account_number and tax_id only for users with payroll access.
+//
+// GET /api/employees/export
+func (c *Client) ExportEmployees(ctx context.Context, params *ExportEmployeesParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.Columns != "" {
+			query.Set("columns", params.Columns)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+		if params.PaperSize != "" {
+			query.Set("paper_size", params.PaperSize)
+		}
+	}
+	return c.download(ctx, "GET", "/api/employees/export", query, nil)
+}
+
+// ExportExpiringComplianceParams holds the parameters of ExportExpiringCompliance. Parameters left at their zero value are not sent.
+type ExportExpiringComplianceParams struct {
+	Format         string // Export format (excel or pdf) (required)
+	Days           int    // Look-ahead window in days (default 30)
+	Department     string // Filter by department
+	IncludeExpired bool   // Include records that have already expired
+	Lang           string // Language of the file: en, fr or pt (default: the Accept-Language header)
+	PaperSize      string // Paper size of a PDF: A4 or Letter (default: the export_paper_size setting)
+}
+
+// ExportExpiringCompliance exports compliance records expiring soon to Excel or PDF
+//
+// Export compliance records expiring in the next N days, grouped by department and requirement, to
+// Excel or PDF (Manager/Admin only).
+//
+// GET /api/compliance/expiring/export
+func (c *Client) ExportExpiringCompliance(ctx context.Context, params *ExportExpiringComplianceParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.Days != 0 {
+			query.Set("days", strconv.Itoa(params.Days))
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.IncludeExpired {
+			query.Set("include_expired", "true")
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+		if params.PaperSize != "" {
+			query.Set("paper_size", params.PaperSize)
+		}
+	}
+	return c.download(ctx, "GET", "/api/compliance/expiring/export", query, nil)
+}
+
+// ExportHRISParams holds the parameters of ExportHRIS. Parameters left at their zero value are not sent.
+type ExportHRISParams struct {
+	Mapping       string // Name of the saved mapping to write the field names with
+	LeaveBalances bool   // Include leave balances (default true)
+}
+
+// ExportHRIS exports employees in the HRIS interchange schema
+//
+// Export every employee and manager with their employment details and current leave balances, in the
+// HRIS interchange schema or in the field names of a saved mapping. Leave balances are as of the
+// current month (Admin only).
+//
+// GET /api/admin/hris/export
+func (c *Client) ExportHRIS(ctx context.Context, params *ExportHRISParams) (*HRISDocument, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Mapping != "" {
+			query.Set("mapping", params.Mapping)
+		}
+		if params.LeaveBalances {
+			query.Set("leave_balances", "true")
+		}
+	}
+	var out HRISDocument
+	if err := c.call(ctx, "GET", "/api/admin/hris/export", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportHeadcountCostCentersParams holds the parameters of ExportHeadcountCostCenters. Parameters left at their zero value are not sent.
+type ExportHeadcountCostCentersParams struct {
+	Format     string // Export format: xlsx or csv (default: xlsx)
+	Date       string // Date the splits are taken on, whose year is the fiscal year (YYYY-MM-DD, default today)
+	Department string // Only export this department
+}
+
+// ExportHeadcountCostCenters exports budgeted and filled headcount by position and cost center
+//
+// Export one row per active position and cost center with the position's budgeted headcount for the
+// fiscal year of date, its seats filled on date, and the full-time equivalents of both charged to the
+// cost center. Positions without an allocation are exported as unallocated (Manager/Admin only).
+//
+// GET /api/headcount/export
+func (c *Client) ExportHeadcountCostCenters(ctx context.Context, params *ExportHeadcountCostCentersParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.Date != "" {
+			query.Set("date", params.Date)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	return c.download(ctx, "GET", "/api/headcount/export", query, nil)
+}
+
+// ExportHeadcountReportParams holds the parameters of ExportHeadcountReport. Parameters left at their zero value are not sent.
+type ExportHeadcountReportParams struct {
+	From       string // First month (YYYY-MM)
+	To         string // Last month (YYYY-MM)
+	Department string // Filter by department
+	Lang       string // Language of the file: en, fr or pt (default: the Accept-Language header)
+}
+
+// ExportHeadcountReport exports the headcount report to Excel
+//
+// Export the headcount report to Excel for management packs: a sheet of today's headcount and
+// vacancies by department and employment type, one of the month-end headcount by employment type, and
+// one of the month-end headcount by department, a column per month (HR/Admin only).
+//
+// GET /api/hr/reports/headcount/export
+func (c *Client) ExportHeadcountReport(ctx context.Context, params *ExportHeadcountReportParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+	}
+	return c.download(ctx, "GET", "/api/hr/reports/headcount/export", query, nil)
+}
+
+// ExportLeaveCalendarParams holds the parameters of ExportLeaveCalendar. Parameters left at their zero value are not sent.
+type ExportLeaveCalendarParams struct {
+	StartDate  string // Start date (YYYY-MM-DD)
+	EndDate    string // End date (YYYY-MM-DD), at most 366 days after the start
+	Department string // Filter by department
+	Lang       string // Language of the file: en, fr or pt (default: the Accept-Language header)
+}
+
+// ExportLeaveCalendar exports the leave calendar as an Excel grid
+//
+// Export the approved leaves in a date range to Excel as a grid for roster planning: a row per active
+// employee, a column per day, the days of leave filled in the color of the leave type and marked with
+// its initial, and weekends and public holidays shaded (HR/Admin only).
+//
+// GET /api/hr/leaves/calendar/export
+func (c *Client) ExportLeaveCalendar(ctx context.Context, params *ExportLeaveCalendarParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.StartDate != "" {
+			query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			query.Set("end_date", params.EndDate)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+	}
+	return c.download(ctx, "GET", "/api/hr/leaves/calendar/export", query, nil)
+}
+
+// ExportMonthlyLeaveReportParams holds the parameters of ExportMonthlyLeaveReport. Parameters left at their zero value are not sent.
+type ExportMonthlyLeaveReportParams struct {
+	Month        string // Month in YYYY-MM format (e.g., 2025-02) (required)
+	Organization string // Organization name (default: 'CHUDLEIGH HOUSE SCHOOL')
+	Lang         string // Language of the file: en, fr or pt (default: the Accept-Language header)
+}
+
+// ExportMonthlyLeaveReport exports monthly leave report to Excel format
+//
+// Export monthly leave report to Excel format matching CSV structure (HR/Admin only).
+//
+// GET /api/hr/leaves/monthly-report/export
+func (c *Client) ExportMonthlyLeaveReport(ctx context.Context, params *ExportMonthlyLeaveReportParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+		if params.Organization != "" {
+			query.Set("organization", params.Organization)
+		}
+		if params.Lang != "" {
+			query.Set("lang", params.Lang)
+		}
+	}
+	return c.download(ctx, "GET", "/api/hr/leaves/monthly-report/export", query, nil)
+}
+
+// ExportPayrollCostAllocationParams holds the parameters of ExportPayrollCostAllocation. Parameters left at their zero value are not sent.
+type ExportPayrollCostAllocationParams struct {
+	Format     string // Export format: xlsx or csv (default: xlsx)
+	Date       string // Date the pay and splits are taken on (YYYY-MM-DD, default today)
+	Department string // Only export this department
+}
+
+// ExportPayrollCostAllocation exports how each employee's pay is split across cost centers
+//
+// Export one row per active employee and cost center with the employee's current pay, the percentage
+// charged to the cost center and the amount that comes to, for finance to allocate the payroll. Pay is
+// the employee's compensation record in effect on date, or else the salary of their current primary
+// assignment in ZMW. Employees without an allocation are exported as unallocated. Every export is
+// audit logged (Payroll access only).
+//
+// GET /api/payroll/cost-allocation/export
+func (c *Client) ExportPayrollCostAllocation(ctx context.Context, params *ExportPayrollCostAllocationParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.Date != "" {
+			query.Set("date", params.Date)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	return c.download(ctx, "GET", "/api/payroll/cost-allocation/export", query, nil)
+}
+
+// ExportRecognitionStatsParams holds the parameters of ExportRecognitionStats. Parameters left at their zero value are not sent.
+type ExportRecognitionStatsParams struct {
+	Year    int // Year
+	Quarter int // Quarter (1-4)
+}
+
+// ExportRecognitionStats exports recognition stats for a quarter to Excel
+//
+// Export quarterly recognition stats to Excel. Defaults to the current quarter (HR/Admin only).
+//
+// GET /api/hr/recognition/stats/export
+func (c *Client) ExportRecognitionStats(ctx context.Context, params *ExportRecognitionStatsParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Year != 0 {
+			query.Set("year", strconv.Itoa(params.Year))
+		}
+		if params.Quarter != 0 {
+			query.Set("quarter", strconv.Itoa(params.Quarter))
+		}
+	}
+	return c.download(ctx, "GET", "/api/hr/recognition/stats/export", query, nil)
+}
+
+// ExportSubjectAccessParams holds the parameters of ExportSubjectAccess. Parameters left at their zero value are not sent.
+type ExportSubjectAccessParams struct {
+	Format string // zip, json or pdf (default: zip)
+}
+
+// ExportSubjectAccess assembles everything held about an employee for a subject access request
+//
+// Assemble everything held about one employee (profile, identity, employment, leaves, documents list,
+// audit trail and every other record that references them) for a data protection subject access
+// request. The default zip bundle holds the data as JSON and as a readable PDF; format=json or
+// format=pdf returns one of them. Document files themselves are listed but not included. Bank account
+// numbers are masked unless the user has payroll access. Review the export before releasing it, as
+// records such as grievance updates may mention other people. Every export is recorded in the
+// employee's audit trail (Admin only).
+//
+// GET /api/employees/{id}/subject-access
+func (c *Client) ExportSubjectAccess(ctx context.Context, id uint, params *ExportSubjectAccessParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+	}
+	return c.download(ctx, "GET", fmt.Sprintf("/api/employees/%d/subject-access", id), query, nil)
+}
+
+// GenerateDocument generates a contract or offer letter for an employee from a template
+//
+// Fill in a document template from the employee's records, employment details and current position,
+// render it as a PDF and store it as one of the employee's documents pending their signature.
+// Placeholders the records have no value for are rejected unless allow_missing is set (Admin only).
+//
+// POST /api/employees/{id}/documents/generate
+func (c *Client) GenerateDocument(ctx context.Context, id uint, request GenerateDocumentRequest) (*Document, error) {
+	var out Document
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/documents/generate", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAPIKeyUsageParams holds the parameters of GetAPIKeyUsage. Parameters left at their zero value are not sent.
+type GetAPIKeyUsageParams struct {
+	From string // Start date (YYYY-MM-DD), defaults to 30 days ago
+	To   string // End date (YYYY-MM-DD), defaults to today
+	Key  string // Only report the key with this name
+}
+
+// GetAPIKeyUsage reports the call volume of each API key
+//
+// Report the gRPC calls made with each API key by method and by day, with the key's limits, for
+// capacity planning and spotting abuse. Counts lag by up to 15 seconds (Admin of the default
+// organization only).
+//
+// GET /api/admin/api-keys/usage
+func (c *Client) GetAPIKeyUsage(ctx context.Context, params *GetAPIKeyUsageParams) (*APIKeyUsageReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Key != "" {
+			query.Set("key", params.Key)
+		}
+	}
+	var out APIKeyUsageReport
+	if err := c.call(ctx, "GET", "/api/admin/api-keys/usage", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAbsenceByTypeReportParams holds the parameters of GetAbsenceByTypeReport. Parameters left at their zero value are not sent.
+type GetAbsenceByTypeReportParams struct {
+	From       string // First month (YYYY-MM)
+	To         string // Last month (YYYY-MM)
+	Department string // Filter by department
+}
+
+// GetAbsenceByTypeReport reports absence over a period by type
+//
+// Days absent over the months given, by leave type, overall and by department, with each type's share
+// of all days absent and the employees and leaves behind it. Days marked absent by attendance without
+// leave are reported as "Unauthorized absence". Admin accounts are excluded. Defaults to the last 12
+// months (HR/Admin only).
+//
+// GET /api/hr/reports/absence-by-type
+func (c *Client) GetAbsenceByTypeReport(ctx context.Context, params *GetAbsenceByTypeReportParams) (*AbsenceByTypeReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out AbsenceByTypeReport
+	if err := c.call(ctx, "GET", "/api/hr/reports/absence-by-type", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAbsenceRateReportParams holds the parameters of GetAbsenceRateReport. Parameters left at their zero value are not sent.
+type GetAbsenceRateReportParams struct {
+	From            string  // First month (YYYY-MM)
+	To              string  // Last month (YYYY-MM)
+	Department      string  // Filter by department
+	Target          float64 // Absence rate to flag departments above, in percent, 0 for none
+	UnplannedTarget float64 // Unplanned absence rate to flag departments above, in percent, 0 for none
+}
+
+// GetAbsenceRateReport reports absence rates by department and month
+//
+// Working days lost to absence as a percentage of the working days available, over the months given
+// and for each month, overall and by department, split into planned and unplanned absence. Working
+// days are the days of the default work schedule that are not public holidays, for each employee in
+// post. Leave is unplanned when its leave type is, and days marked absent by attendance without leave
+// are unplanned. Departments whose rate or unplanned rate is above the target are flagged; the targets
+// default to the absence_rate_target and unplanned_absence_rate_target settings. Admin accounts are
+// excluded. Defaults to the last 12 months (HR/Admin only).
+//
+// GET /api/hr/reports/absence-rate
+func (c *Client) GetAbsenceRateReport(ctx context.Context, params *GetAbsenceRateReportParams) (*AbsenceRateReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Target != 0 {
+			query.Set("target", strconv.FormatFloat(params.Target, 'f', -1, 64))
+		}
+		if params.UnplannedTarget != 0 {
+			query.Set("unplanned_target", strconv.FormatFloat(params.UnplannedTarget, 'f', -1, 64))
+		}
+	}
+	var out AbsenceRateReport
+	if err := c.call(ctx, "GET", "/api/hr/reports/absence-rate", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAdminHolidaysParams holds the parameters of GetAdminHolidays. Parameters left at their zero value are not sent.
+type GetAdminHolidaysParams struct {
+	Year    int    // Year, defaults to this year
+	Country string // Country code, e.g. ZM
+	Status  string // Filter by status (pending, active, rejected)
+}
+
+// GetAdminHolidays lists the organization's public holidays in every status
+//
+// List the public holidays of a year in the organization's holiday calendar, including imported ones
+// pending review and rejected ones (Admin only).
+//
+// GET /api/admin/holidays
+func (c *Client) GetAdminHolidays(ctx context.Context, params *GetAdminHolidaysParams) ([]PublicHoliday, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Year != 0 {
+			query.Set("year", strconv.Itoa(params.Year))
+		}
+		if params.Country != "" {
+			query.Set("country", params.Country)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+	}
+	var out []PublicHoliday
+	err := c.call(ctx, "GET", "/api/admin/holidays", query, nil, &out)
+	return out, err
+}
+
+// GetAllEmployeesLeaveBalancesParams holds the parameters of GetAllEmployeesLeaveBalances. Parameters left at their zero value are not sent.
+type GetAllEmployeesLeaveBalancesParams struct {
+	Department string // Filter by department
+	Status     string // Filter by employment status (active, on_leave, etc.)
+}
+
+// GetAllEmployeesLeaveBalances gets annual leave balances for all employees
+//
+// Get annual leave balances for all employees with filtering options (HR/Admin only).
+//
+// GET /api/hr/employees/annual-leave-balances
+func (c *Client) GetAllEmployeesLeaveBalances(ctx context.Context, params *GetAllEmployeesLeaveBalancesParams) ([]AnnualLeaveBalanceResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+	}
+	var out []AnnualLeaveBalanceResponse
+	err := c.call(ctx, "GET", "/api/hr/employees/annual-leave-balances", query, nil, &out)
+	return out, err
+}
+
+// GetAllEmployeesLeaveBalancesSimpleParams holds the parameters of GetAllEmployeesLeaveBalancesSimple. Parameters left at their zero value are not sent.
+type GetAllEmployeesLeaveBalancesSimpleParams struct {
+	LeaveTypeID int // Leave type ID (defaults to Annual leave)
+}
+
+// GetAllEmployeesLeaveBalancesSimple returns leave balances for all employees
+//
+// Get leave balances for all employees using simplified calculation (Admin only).
+//
+// GET /api/admin/employees/leave-balances
+func (c *Client) GetAllEmployeesLeaveBalancesSimple(ctx context.Context, params *GetAllEmployeesLeaveBalancesSimpleParams) ([]map[string]interface{}, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+	}
+	var out []map[string]interface{}
+	err := c.call(ctx, "GET", "/api/admin/employees/leave-balances", query, nil, &out)
+	return out, err
+}
+
+// GetAnnualLeaveBalance gets detailed annual leave balance for an employee
+//
+// Get detailed annual leave balance including accruals for an employee (HR/Admin only).
+//
+// GET /api/hr/employees/{id}/annual-leave-balance
+func (c *Client) GetAnnualLeaveBalance(ctx context.Context, id uint) (*AnnualLeaveBalanceResponse, error) {
+	var out AnnualLeaveBalanceResponse
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/hr/employees/%d/annual-leave-balance", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAttendanceCorrectionsParams holds the parameters of GetAttendanceCorrections. Parameters left at their zero value are not sent.
+type GetAttendanceCorrectionsParams struct {
+	Status     string // Status filter (pending, approved, rejected)
+	EmployeeID int    // Employee ID
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, created_at, status). Defaults to -created_at
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetAttendanceCorrections lists attendance corrections
+//
+// List attendance corrections. Managers see corrections for their direct reports; admins see all
+// (Manager/Admin only).
+//
+// GET /api/attendance/corrections
+func (c *Client) GetAttendanceCorrections(ctx context.Context, params *GetAttendanceCorrectionsParams) (*PaginatedResponse[[]AttendanceCorrection], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]AttendanceCorrection]
+	if err := c.call(ctx, "GET", "/api/attendance/corrections", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAttendanceDevices lists attendance terminals
+//
+// List the biometric and badge terminals registered to send clock events. Keys are not returned (Admin
+// only).
+//
+// GET /api/admin/attendance/devices
+func (c *Client) GetAttendanceDevices(ctx context.Context) ([]AttendanceDevice, error) {
+	var out []AttendanceDevice
+	err := c.call(ctx, "GET", "/api/admin/attendance/devices", nil, nil, &out)
+	return out, err
+}
+
+// GetAttendanceReportParams holds the parameters of GetAttendanceReport. Parameters left at their zero value are not sent.
+type GetAttendanceReportParams struct {
+	Month      string // Month (YYYY-MM), defaults to the current month
+	Department string // Department filter
+}
+
+// GetAttendanceReport reports monthly attendance per employee grouped by department
+//
+// Report present, late, absent and on-leave days and worked hours per employee for a month, grouped by
+// department (Manager/Admin only).
+//
+// GET /api/attendance/report
+func (c *Client) GetAttendanceReport(ctx context.Context, params *GetAttendanceReportParams) (*AttendanceReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out AttendanceReport
+	if err := c.call(ctx, "GET", "/api/attendance/report", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAuditLogsParams holds the parameters of GetAuditLogs. Parameters left at their zero value are not sent.
+type GetAuditLogsParams struct {
+	EntityType  string // Entity type filter (comma-separated for several)
+	EntityID    int    // Entity ID filter
+	Action      string // Action filter, e.g. CREATE, UPDATE, DELETE (comma-separated for several)
+	PerformedBy int    // Performed by user ID filter
+	BatchID     string // Batch reference filter, for the changes of one bulk operation
+	From        string // Only logs created at or after this time (RFC3339 or YYYY-MM-DD)
+	To          string // Only logs created at or before this time (RFC3339, or YYYY-MM-DD for the whole day)
+	Limit       int    // Page size (default 50, max 500)
+	Cursor      string // Cursor from a previous page's next_cursor
+}
+
+// GetAuditLogs retrieves audit logs with filtering and cursor-based pagination
+//
+// Get audit logs newest first, filtered by entity type, entity ID, action, performer, batch and
+// created_at range. Use next_cursor from the response to fetch the following page.
+//
+// GET /api/audit-logs
+func (c *Client) GetAuditLogs(ctx context.Context, params *GetAuditLogsParams) (*AuditLogPage, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.EntityType != "" {
+			query.Set("entity_type", params.EntityType)
+		}
+		if params.EntityID != 0 {
+			query.Set("entity_id", strconv.Itoa(params.EntityID))
+		}
+		if params.Action != "" {
+			query.Set("action", params.Action)
+		}
+		if params.PerformedBy != 0 {
+			query.Set("performed_by", strconv.Itoa(params.PerformedBy))
+		}
+		if params.BatchID != "" {
+			query.Set("batch_id", params.BatchID)
+		}
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Limit != 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+	}
+	var out AuditLogPage
+	if err := c.call(ctx, "GET", "/api/audit-logs", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBackupJob returns the progress of a backup or restore
+//
+// Get the status and progress of a backup or restore started since the server started (Admins of the
+// default organization only).
+//
+// GET /api/admin/backup-jobs/{id}
+func (c *Client) GetBackupJob(ctx context.Context, id string) (*Job, error) {
+	var out Job
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/admin/backup-jobs/%s", url.PathEscape(id)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBackups lists the backup bundles
+//
+// List the completed backup bundles in BACKUPS_PATH, newest first (Admins of the default organization
+// only).
+//
+// GET /api/admin/backups
+func (c *Client) GetBackups(ctx context.Context) ([]Bundle, error) {
+	var out []Bundle
+	err := c.call(ctx, "GET", "/api/admin/backups", nil, nil, &out)
+	return out, err
+}
+
+// GetBalanceLiabilityReportParams holds the parameters of GetBalanceLiabilityReport. Parameters left at their zero value are not sent.
+type GetBalanceLiabilityReportParams struct {
+	Department string // Filter by department
+}
+
+// GetBalanceLiabilityReport reports the leave owed to employees
+//
+// Days of leave owed to current employees today, for each leave type that keeps a balance, overall and
+// by department. Overdrawn balances are reported separately rather than netted off. Balances are
+// brought up to date first (HR/Admin only).
+//
+// GET /api/hr/reports/balance-liability
+func (c *Client) GetBalanceLiabilityReport(ctx context.Context, params *GetBalanceLiabilityReportParams) (*BalanceLiabilityReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out BalanceLiabilityReport
+	if err := c.call(ctx, "GET", "/api/hr/reports/balance-liability", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBankDetails retrieves an employee's bank details with the account number masked
+//
+// Get an employee's bank details with the account number masked. Employees can view their own; admins
+// can view anyone's.
+//
+// GET /api/employees/{id}/bank-details
+func (c *Client) GetBankDetails(ctx context.Context, id uint) (*BankDetails, error) {
+	var out BankDetails
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/bank-details", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCalendarConnections lists the current user's connected calendars
+//
+// List the Google and Outlook calendars approved leaves are added to for the current user, with the
+// providers this server can connect.
+//
+// GET /api/calendar-connections
+func (c *Client) GetCalendarConnections(ctx context.Context) (*CalendarConnectionsResponse, error) {
+	var out CalendarConnectionsResponse
+	if err := c.call(ctx, "GET", "/api/calendar-connections", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCarryOverBalanceParams holds the parameters of GetCarryOverBalance. Parameters left at their zero value are not sent.
+type GetCarryOverBalanceParams struct {
+	LeaveTypeID int // Leave type ID (defaults to Annual leave)
+}
+
+// GetCarryOverBalance gets current carry-over balance for an employee
+//
+// Get current carry-over balance for an employee (HR/Admin only).
+//
+// GET /api/hr/employees/{id}/carryover-balance
+func (c *Client) GetCarryOverBalance(ctx context.Context, id uint, params *GetCarryOverBalanceParams) (map[string]interface{}, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+	}
+	var out map[string]interface{}
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/hr/employees/%d/carryover-balance", id), query, nil, &out)
+	return out, err
+}
+
+// GetCarryOverHistoryParams holds the parameters of GetCarryOverHistory. Parameters left at their zero value are not sent.
+type GetCarryOverHistoryParams struct {
+	LeaveTypeID int // Leave type ID (defaults to Annual leave)
+}
+
+// GetCarryOverHistory gets carry-over history for an employee
+//
+// Get carry-over history for an employee (HR/Admin only).
+//
+// GET /api/hr/employees/{id}/carryover-history
+func (c *Client) GetCarryOverHistory(ctx context.Context, id uint, params *GetCarryOverHistoryParams) ([]LeaveCarryOver, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+	}
+	var out []LeaveCarryOver
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/hr/employees/%d/carryover-history", id), query, nil, &out)
+	return out, err
+}
+
+// GetCertifications lists the certifications catalogue
+//
+// List active certifications in the catalogue.
+//
+// GET /api/certifications
+func (c *Client) GetCertifications(ctx context.Context) ([]Certification, error) {
+	var out []Certification
+	err := c.call(ctx, "GET", "/api/certifications", nil, nil, &out)
+	return out, err
+}
+
+// GetChatAccounts lists the chat accounts linked to the current user
+//
+// List the Slack and Teams accounts the leave bot acts as the current user for.
+//
+// GET /api/chat-accounts
+func (c *Client) GetChatAccounts(ctx context.Context) ([]ChatAccount, error) {
+	var out []ChatAccount
+	err := c.call(ctx, "GET", "/api/chat-accounts", nil, nil, &out)
+	return out, err
+}
+
+// GetClockEventsParams holds the parameters of GetClockEvents. Parameters left at their zero value are not sent.
+type GetClockEventsParams struct {
+	DeviceID   int    // Device ID
+	Badge      string // Badge
+	EmployeeID int    // Employee ID
+	Status     string // Status (applied, duplicate, unmatched, ignored)
+	Source     string // Source (device, csv)
+	From       string // Only events at or after this time (RFC3339 or YYYY-MM-DD)
+	To         string // Only events at or before this time (RFC3339, or YYYY-MM-DD for the whole day)
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, timestamp, created_at). Defaults to -timestamp
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetClockEvents lists clock events received from attendance terminals
+//
+// List clock events received from terminals, newest first, with what became of each: applied to
+// attendance, duplicate of an event already received, unmatched to any employee, or ignored because
+// the day's attendance was corrected (Admin only).
+//
+// GET /api/admin/attendance/clock-events
+func (c *Client) GetClockEvents(ctx context.Context, params *GetClockEventsParams) (*PaginatedResponse[[]ClockEvent], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.DeviceID != 0 {
+			query.Set("device_id", strconv.Itoa(params.DeviceID))
+		}
+		if params.Badge != "" {
+			query.Set("badge", params.Badge)
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Source != "" {
+			query.Set("source", params.Source)
+		}
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]ClockEvent]
+	if err := c.call(ctx, "GET", "/api/admin/attendance/clock-events", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCompaRatioReportParams holds the parameters of GetCompaRatioReport. Parameters left at their zero value are not sent.
+type GetCompaRatioReportParams struct {
+	Department string // Department filter
+}
+
+// GetCompaRatioReport reports where active employees' pay sits in their salary bands, by department
+//
+// For each department, the compa-ratio of its active employees: their current pay divided by the
+// midpoint of their primary position's salary band, with the department average and how many are paid
+// below, within and above the band. Only pay in ZMW, the currency bands are set in, is compared;
+// employees without it or without a banded position are counted in excluded (Payroll access only).
+//
+// GET /api/payroll/reports/compa-ratio
+func (c *Client) GetCompaRatioReport(ctx context.Context, params *GetCompaRatioReportParams) (*CompaRatioReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out CompaRatioReport
+	if err := c.call(ctx, "GET", "/api/payroll/reports/compa-ratio", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCompanyValuesParams holds the parameters of GetCompanyValues. Parameters left at their zero value are not sent.
+type GetCompanyValuesParams struct {
+	IncludeInactive bool // Include inactive values (Admin only)
+}
+
+// GetCompanyValues lists the company values kudos can be given against
+//
+// List active company values. Admins can include inactive values with include_inactive=true.
+//
+// GET /api/recognition/values
+func (c *Client) GetCompanyValues(ctx context.Context, params *GetCompanyValuesParams) ([]CompanyValue, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.IncludeInactive {
+			query.Set("include_inactive", "true")
+		}
+	}
+	var out []CompanyValue
+	err := c.call(ctx, "GET", "/api/recognition/values", query, nil, &out)
+	return out, err
+}
+
+// GetCompensationHistory lists an employee's compensation records
+//
+// List an employee's compensation records, newest effective date first. Every view is audit logged
+// (Payroll access only).
+//
+// GET /api/payroll/employees/{id}/compensation
+func (c *Client) GetCompensationHistory(ctx context.Context, id uint) ([]CompensationRecord, error) {
+	var out []CompensationRecord
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/payroll/employees/%d/compensation", id), nil, nil, &out)
+	return out, err
+}
+
+// GetComplianceNotificationsParams holds the parameters of GetComplianceNotifications. Parameters left at their zero value are not sent.
+type GetComplianceNotificationsParams struct {
+	EmployeeID int // Recipient employee ID
+	Page       int // Page number (default 1)
+	PerPage    int // Items per page (default 25, max 100)
+}
+
+// GetComplianceNotifications lists compliance notifications that have been sent
+//
+// List compliance reminder and expiry notifications sent on all channels, optionally filtered by
+// employee (Manager/Admin only).
+//
+// GET /api/compliance/notifications
+func (c *Client) GetComplianceNotifications(ctx context.Context, params *GetComplianceNotificationsParams) (*PaginatedResponse[[]Notification], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Notification]
+	if err := c.call(ctx, "GET", "/api/compliance/notifications", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetComplianceRecords retrieves compliance records for an employee
+//
+// Get all compliance records for an employee.
+//
+// GET /api/employees/{id}/compliance
+func (c *Client) GetComplianceRecords(ctx context.Context, id uint) ([]ComplianceRecord, error) {
+	var out []ComplianceRecord
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/compliance", id), nil, nil, &out)
+	return out, err
+}
+
+// GetComplianceRequirements retrieves all compliance requirements
+//
+// Get list of all active compliance requirements.
+//
+// GET /api/compliance/requirements
+func (c *Client) GetComplianceRequirements(ctx context.Context) ([]ComplianceRequirement, error) {
+	var out []ComplianceRequirement
+	err := c.call(ctx, "GET", "/api/compliance/requirements", nil, nil, &out)
+	return out, err
+}
+
+// GetCostCentersParams holds the parameters of GetCostCenters. Parameters left at their zero value are not sent.
+type GetCostCentersParams struct {
+	IncludeInactive bool // Include inactive cost centers
+}
+
+// GetCostCenters lists the organization's cost centers
+//
+// List the cost centers personnel costs are allocated to, by code. Inactive ones are included with
+// include_inactive=true (Admin only).
+//
+// GET /api/admin/cost-centers
+func (c *Client) GetCostCenters(ctx context.Context, params *GetCostCentersParams) ([]CostCenter, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.IncludeInactive {
+			query.Set("include_inactive", "true")
+		}
+	}
+	var out []CostCenter
+	err := c.call(ctx, "GET", "/api/admin/cost-centers", query, nil, &out)
+	return out, err
+}
+
+// GetCurrentOrganization returns the organization of the current user
+//
+// Get the organization the current user belongs to.
+//
+// GET /api/organization
+func (c *Client) GetCurrentOrganization(ctx context.Context) (*Organization, error) {
+	var out Organization
+	if err := c.call(ctx, "GET", "/api/organization", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeadLetter gets a dead letter with its message
+//
+// Get an email or webhook delivery that was given up, with the message and the last error (Admin
+// only).
+//
+// GET /api/admin/dead-letters/{id}
+func (c *Client) GetDeadLetter(ctx context.Context, id uint) (*DeadLetter, error) {
+	var out DeadLetter
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/admin/dead-letters/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeadLettersParams holds the parameters of GetDeadLetters. Parameters left at their zero value are not sent.
+type GetDeadLettersParams struct {
+	Kind      string // Kind (email, sms, webhook)
+	Permanent bool   // Only permanent failures (true) or only those that used all their attempts (false)
+	Resolved  bool   // List the dead letters re-sent or delivered since (true), or every dead letter (all). Defaults to false
+	Sort      string // Sort keys, comma separated, - prefix for descending (id, created_at, updated_at, attempts, resolved_at). Defaults to -created_at
+	Page      int    // Page number (default 1)
+	PerPage   int    // Items per page (default 25, max 100)
+}
+
+// GetDeadLetters lists emails, text messages and webhook deliveries that were given up
+//
+// List notification emails and text messages, and webhook deliveries, that were given up, because they
+// failed permanently or used all their attempts, with the message and the last error. Only those not
+// yet re-sent or delivered are listed unless resolved is given (Admin only).
+//
+// GET /api/admin/dead-letters
+func (c *Client) GetDeadLetters(ctx context.Context, params *GetDeadLettersParams) (*PaginatedResponse[[]DeadLetter], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Kind != "" {
+			query.Set("kind", params.Kind)
+		}
+		if params.Permanent {
+			query.Set("permanent", "true")
+		}
+		if params.Resolved {
+			query.Set("resolved", "true")
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]DeadLetter]
+	if err := c.call(ctx, "GET", "/api/admin/dead-letters", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeletedEmployeesParams holds the parameters of GetDeletedEmployees. Parameters left at their zero value are not sent.
+type GetDeletedEmployeesParams struct {
+	Search     string // Search term matching the start of words in employees' names and email
+	Department string // Department filter (comma separated for several)
+	Role       string // Role filter (employee, manager)
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, firstname, lastname, department, role, created_at, deleted_at). Defaults to -deleted_at
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetDeletedEmployees returns soft-deleted employees
+//
+// Get list of soft-deleted employees that can be restored (Admin only). Supports search query
+// parameter for filtering by name.
+//
+// GET /api/admin/employees/deleted
+func (c *Client) GetDeletedEmployees(ctx context.Context, params *GetDeletedEmployeesParams) (*PaginatedResponse[[]DeletedEmployeeResponse], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Search != "" {
+			query.Set("search", params.Search)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Role != "" {
+			query.Set("role", params.Role)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]DeletedEmployeeResponse]
+	if err := c.call(ctx, "GET", "/api/admin/employees/deleted", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDepartmentLeaveReport gets leave statistics by department
+//
+// Get leave statistics aggregated by department (HR/Admin only).
+//
+// GET /api/hr/leaves/department-report
+func (c *Client) GetDepartmentLeaveReport(ctx context.Context) ([]DepartmentLeaveReport, error) {
+	var out []DepartmentLeaveReport
+	err := c.call(ctx, "GET", "/api/hr/leaves/department-report", nil, nil, &out)
+	return out, err
+}
+
+// GetDirectReports lists the employees who report to an employee
+//
+// List the current direct reports of an employee, the employees whose employment details name them as
+// manager, leaving out those who left or are inactive. Each comes with their position and how many
+// direct reports they have in turn, so the organization chart can be walked down. Employees can only
+// see their own direct reports, managers and admins anyone's.
+//
+// GET /api/employees/{id}/direct-reports
+func (c *Client) GetDirectReports(ctx context.Context, id uint) ([]ReportingLineEmployee, error) {
+	var out []ReportingLineEmployee
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/direct-reports", id), nil, nil, &out)
+	return out, err
+}
+
+// GetDocumentPlaceholders lists the placeholders document templates can use
+//
+// List the placeholders document templates can use, written as {{key}} (Admin only).
+//
+// GET /api/admin/document-templates/placeholders
+func (c *Client) GetDocumentPlaceholders(ctx context.Context) ([]DocumentPlaceholder, error) {
+	var out []DocumentPlaceholder
+	err := c.call(ctx, "GET", "/api/admin/document-templates/placeholders", nil, nil, &out)
+	return out, err
+}
+
+// GetDocumentStorageUsageParams holds the parameters of GetDocumentStorageUsage. Parameters left at their zero value are not sent.
+type GetDocumentStorageUsageParams struct {
+	Limit int // Number of employees to list (default 50, max 500)
+}
+
+// GetDocumentStorageUsage reports the document storage used against the quotas
+//
+// Report the bytes of documents stored for the organization and for each employee, largest first, with
+// the per-employee and organization quotas set through the employee_document_quota_mb and
+// document_storage_quota_mb settings (Admin only).
+//
+// GET /api/admin/documents/storage-usage
+func (c *Client) GetDocumentStorageUsage(ctx context.Context, params *GetDocumentStorageUsageParams) (*DocumentStorageReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Limit != 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out DocumentStorageReport
+	if err := c.call(ctx, "GET", "/api/admin/documents/storage-usage", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDocumentTemplatesParams holds the parameters of GetDocumentTemplates. Parameters left at their zero value are not sent.
+type GetDocumentTemplatesParams struct {
+	DocumentType string // Filter by document type (contract, offer_letter)
+}
+
+// GetDocumentTemplates lists the organization's document templates
+//
+// List the templates employment contracts and offer letters are generated from (Admin only).
+//
+// GET /api/admin/document-templates
+func (c *Client) GetDocumentTemplates(ctx context.Context, params *GetDocumentTemplatesParams) ([]DocumentTemplate, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.DocumentType != "" {
+			query.Set("document_type", params.DocumentType)
+		}
+	}
+	var out []DocumentTemplate
+	err := c.call(ctx, "GET", "/api/admin/document-templates", query, nil, &out)
+	return out, err
+}
+
+// GetDocumentsParams holds the parameters of GetDocuments. Parameters left at their zero value are not sent.
+type GetDocumentsParams struct {
+	Search       string // Search term matching the start of words in the title, description and tags
+	DocumentType string // Document type filter (comma separated for several)
+	Status       string // Status filter
+	Sort         string // Sort keys, comma separated, - prefix for descending (id, title, document_type, expiry_date, created_at). Defaults to -created_at
+	Page         int    // Page number (default 1)
+	PerPage      int    // Items per page (default 25, max 100)
+}
+
+// GetDocuments retrieves documents for an employee
+//
+// Get all documents for an employee.
+//
+// GET /api/employees/{id}/documents
+func (c *Client) GetDocuments(ctx context.Context, id uint, params *GetDocumentsParams) (*PaginatedResponse[[]Document], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Search != "" {
+			query.Set("search", params.Search)
+		}
+		if params.DocumentType != "" {
+			query.Set("document_type", params.DocumentType)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Document]
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/documents", id), query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEducation retrieves education records for an employee
+//
+// Get all education and qualification records for an employee. Employees can only view their own
+// records.
+//
+// GET /api/employees/{id}/education
+func (c *Client) GetEducation(ctx context.Context, id uint) ([]Education, error) {
+	var out []Education
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/education", id), nil, nil, &out)
+	return out, err
+}
+
+// GetEducationRecordsParams holds the parameters of GetEducationRecords. Parameters left at their zero value are not sent.
+type GetEducationRecordsParams struct {
+	Status             string // Verification status filter (pending, verified, rejected)
+	QualificationLevel string // Qualification level filter
+	Sort               string // Sort keys, comma separated, - prefix for descending (id, created_at, end_date, status). Defaults to -created_at
+	Page               int    // Page number (default 1)
+	PerPage            int    // Items per page (default 25, max 100)
+}
+
+// GetEducationRecords lists education records across employees for HR review
+//
+// List education records across all employees, optionally filtered by verification status and
+// qualification level (Manager/Admin only).
+//
+// GET /api/education
+func (c *Client) GetEducationRecords(ctx context.Context, params *GetEducationRecordsParams) (*PaginatedResponse[[]Education], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.QualificationLevel != "" {
+			query.Set("qualification_level", params.QualificationLevel)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Education]
+	if err := c.call(ctx, "GET", "/api/education", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmailPlaceholders lists the placeholders email templates can use
+//
+// List, for each notification category, the placeholders its email templates can use, written as
+// {{key}}, with the example values previews are filled in with (Admin only).
+//
+// GET /api/admin/email-templates/placeholders
+func (c *Client) GetEmailPlaceholders(ctx context.Context) ([]EmailCategoryPlaceholders, error) {
+	var out []EmailCategoryPlaceholders
+	err := c.call(ctx, "GET", "/api/admin/email-templates/placeholders", nil, nil, &out)
+	return out, err
+}
+
+// GetEmailTemplatesParams holds the parameters of GetEmailTemplates. Parameters left at their zero value are not sent.
+type GetEmailTemplatesParams struct {
+	Category string // Filter by notification category
+	Language string // Filter by language (en, fr, pt)
+}
+
+// GetEmailTemplates lists the organization's email templates
+//
+// List the templates that replace the built-in wording of notification emails (Admin only).
+//
+// GET /api/admin/email-templates
+func (c *Client) GetEmailTemplates(ctx context.Context, params *GetEmailTemplatesParams) ([]EmailTemplate, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Category != "" {
+			query.Set("category", params.Category)
+		}
+		if params.Language != "" {
+			query.Set("language", params.Language)
+		}
+	}
+	var out []EmailTemplate
+	err := c.call(ctx, "GET", "/api/admin/email-templates", query, nil, &out)
+	return out, err
+}
+
+// GetEmployee returns a specific employee by ID
+//
+// Get a specific employee by ID (Admin only).
+//
+// GET /api/employees/{id}
+func (c *Client) GetEmployee(ctx context.Context, id uint) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmployeeAttendanceParams holds the parameters of GetEmployeeAttendance. Parameters left at their zero value are not sent.
+type GetEmployeeAttendanceParams struct {
+	Month string // Month (YYYY-MM), defaults to the current month
+}
+
+// GetEmployeeAttendance returns an employee's attendance for a month
+//
+// Get an employee's daily attendance records and totals for a month. Employees can only view their own
+// attendance.
+//
+// GET /api/employees/{id}/attendance
+func (c *Client) GetEmployeeAttendance(ctx context.Context, id uint, params *GetEmployeeAttendanceParams) (*MonthlyAttendance, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+	}
+	var out MonthlyAttendance
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/attendance", id), query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmployeeAuditLogsParams holds the parameters of GetEmployeeAuditLogs. Parameters left at their zero value are not sent.
+type GetEmployeeAuditLogsParams struct {
+	Page    int // Page number (default 1)
+	PerPage int // Items per page (default 25, max 100)
+}
+
+// GetEmployeeAuditLogs retrieves audit logs for a specific employee
+//
+// Get audit logs related to a specific employee.
+//
+// GET /api/employees/{id}/audit-logs
+func (c *Client) GetEmployeeAuditLogs(ctx context.Context, id uint, params *GetEmployeeAuditLogsParams) (*PaginatedResponse[[]AuditLog], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]AuditLog]
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/audit-logs", id), query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmployeeCertifications retrieves the certifications held by an employee
+//
+// Get all certifications held by an employee. Employees can only view their own certifications.
+//
+// GET /api/employees/{id}/certifications
+func (c *Client) GetEmployeeCertifications(ctx context.Context, id uint) ([]EmployeeCertification, error) {
+	var out []EmployeeCertification
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/certifications", id), nil, nil, &out)
+	return out, err
+}
+
+// GetEmployeeCostCenters returns how an employee's cost is split across cost centers
+//
+// Get the cost center split in effect today for an employee, from their own allocations or else their
+// primary position's, and the history of their own allocations (Manager/Admin only).
+//
+// GET /api/employees/{id}/cost-centers
+func (c *Client) GetEmployeeCostCenters(ctx context.Context, id uint) (*CostCenterAllocationResponse, error) {
+	var out CostCenterAllocationResponse
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/cost-centers", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmployeeLeaveHistoryParams holds the parameters of GetEmployeeLeaveHistory. Parameters left at their zero value are not sent.
+type GetEmployeeLeaveHistoryParams struct {
+	LeaveTypeID int // Filter by leave type ID
+}
+
+// GetEmployeeLeaveHistory returns all leave taken records for an employee
+//
+// Get all leave taken records for an employee (Admin only).
+//
+// GET /api/admin/employees/{id}/leave-taken
+func (c *Client) GetEmployeeLeaveHistory(ctx context.Context, id uint, params *GetEmployeeLeaveHistoryParams) ([]LeaveTaken, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+	}
+	var out []LeaveTaken
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/admin/employees/%d/leave-taken", id), query, nil, &out)
+	return out, err
+}
+
+// GetEmployeeLeavesParams holds the parameters of GetEmployeeLeaves. Parameters left at their zero value are not sent.
+type GetEmployeeLeavesParams struct {
+	Status      string // Filter by status (Pending, Approved, Rejected, Cancelled)
+	LeaveTypeID int    // Filter by leave type ID
+	StartDate   string // Filter by start date (YYYY-MM-DD)
+	EndDate     string // Filter by end date (YYYY-MM-DD)
+	Sort        string // Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to -start_date,-created_at
+	Page        int    // Page number (default 1)
+	PerPage     int    // Items per page (default 25, max 100)
+}
+
+// GetEmployeeLeaves gets all leave records for an employee (Admin only)
+//
+// Admin gets all leave records for any employee (Admin only).
+//
+// GET /api/hr/employees/{id}/leaves
+func (c *Client) GetEmployeeLeaves(ctx context.Context, id uint, params *GetEmployeeLeavesParams) (*PaginatedResponse[[]Leave], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+		if params.StartDate != "" {
+			query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			query.Set("end_date", params.EndDate)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Leave]
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/hr/employees/%d/leaves", id), query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmployeeSkills retrieves the skills assigned to an employee
+//
+// Get all skills assigned to an employee with proficiency levels. Employees can only view their own
+// skills.
+//
+// GET /api/employees/{id}/skills
+func (c *Client) GetEmployeeSkills(ctx context.Context, id uint) ([]EmployeeSkill, error) {
+	var out []EmployeeSkill
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/skills", id), nil, nil, &out)
+	return out, err
+}
+
+// GetEmployeeTraining returns an employee's training history and mandatory training status
+//
+// Get an employee's training enrollments and progress on the courses mandatory for their role.
+// Employees can only view their own.
+//
+// GET /api/employees/{id}/training
+func (c *Client) GetEmployeeTraining(ctx context.Context, id uint) (*EmployeeTraining, error) {
+	var out EmployeeTraining
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/training", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmployeesParams holds the parameters of GetEmployees. Parameters left at their zero value are not sent.
+type GetEmployeesParams struct {
+	Search     string // Search term matching the start of words in employees' names and email
+	Department string // Department filter (comma separated for several)
+	Role       string // Role filter (employee, manager)
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, firstname, lastname, department, role, created_at)
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetEmployees returns all employees
+//
+// Get list of all employees (Admin only). Supports search query parameter for filtering by name.
+//
+// GET /api/employees
+func (c *Client) GetEmployees(ctx context.Context, params *GetEmployeesParams) (*PaginatedResponse[[]Employee], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Search != "" {
+			query.Set("search", params.Search)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Role != "" {
+			query.Set("role", params.Role)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Employee]
+	if err := c.call(ctx, "GET", "/api/employees", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmploymentDetails retrieves employment details for an employee
+//
+// Get employment details for an employee.
+//
+// GET /api/employees/{id}/employment
+func (c *Client) GetEmploymentDetails(ctx context.Context, id uint) (*EmploymentDetails, error) {
+	var out EmploymentDetails
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/employment", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEmploymentHistory retrieves employment history for an employee
+//
+// Get employment history for an employee.
+//
+// GET /api/employees/{id}/employment/history
+func (c *Client) GetEmploymentHistory(ctx context.Context, id uint) ([]EmploymentHistory, error) {
+	var out []EmploymentHistory
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/employment/history", id), nil, nil, &out)
+	return out, err
+}
+
+// GetExitInterview returns the exit interview recorded for an employee
+//
+// Get the exit interview recorded for an employee's offboarding, with answers (Admin only).
+//
+// GET /api/employees/{id}/offboarding/exit-interview
+func (c *Client) GetExitInterview(ctx context.Context, id uint) (*ExitInterview, error) {
+	var out ExitInterview
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/offboarding/exit-interview", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExitInterviewReportParams holds the parameters of GetExitInterviewReport. Parameters left at their zero value are not sent.
+type GetExitInterviewReportParams struct {
+	From string // Start date (YYYY-MM-DD), defaults to 12 months ago
+	To   string // End date (YYYY-MM-DD), defaults to today
+}
+
+// GetExitInterviewReport returns an anonymized summary of exit interviews
+//
+// Summarise exit interviews held in a period by reason for leaving and department, with average scores
+// for rating questions. No individual is identified: departments with fewer than 3 leavers are grouped
+// as "Other", rating questions with fewer than 3 answers are left out, and the whole report is
+// suppressed below 3 interviews (Admin only).
+//
+// GET /api/exit-interviews/report
+func (c *Client) GetExitInterviewReport(ctx context.Context, params *GetExitInterviewReportParams) (*ExitInterviewReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+	}
+	var out ExitInterviewReport
+	if err := c.call(ctx, "GET", "/api/exit-interviews/report", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExitQuestionSetsParams holds the parameters of GetExitQuestionSets. Parameters left at their zero value are not sent.
+type GetExitQuestionSetsParams struct {
+	IncludeInactive bool // Include inactive question sets
+}
+
+// GetExitQuestionSets lists exit interview question sets
+//
+// List exit interview question sets with their questions (Admin only).
+//
+// GET /api/exit-interviews/question-sets
+func (c *Client) GetExitQuestionSets(ctx context.Context, params *GetExitQuestionSetsParams) ([]ExitQuestionSet, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.IncludeInactive {
+			query.Set("include_inactive", "true")
+		}
+	}
+	var out []ExitQuestionSet
+	err := c.call(ctx, "GET", "/api/exit-interviews/question-sets", query, nil, &out)
+	return out, err
+}
+
+// GetExpiringCertificationsParams holds the parameters of GetExpiringCertifications. Parameters left at their zero value are not sent.
+type GetExpiringCertificationsParams struct {
+	Days int // Look-ahead window in days (default 30)
+}
+
+// GetExpiringCertifications lists employee certifications and skills expiring soon
+//
+// List employee certifications and skill assignments that expire within the given number of days,
+// including those already expired (Manager/Admin only).
+//
+// GET /api/certifications/expiring
+func (c *Client) GetExpiringCertifications(ctx context.Context, params *GetExpiringCertificationsParams) (map[string]interface{}, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Days != 0 {
+			query.Set("days", strconv.Itoa(params.Days))
+		}
+	}
+	var out map[string]interface{}
+	err := c.call(ctx, "GET", "/api/certifications/expiring", query, nil, &out)
+	return out, err
+}
+
+// GetExportJob returns the status of an export job
+//
+// Get the status of an export job the current user queued. Once it is completed, download_url is a
+// link to the file that works without signing in for 15 minutes; get the job again for a fresh link.
+//
+// GET /api/export-jobs/{id}
+func (c *Client) GetExportJob(ctx context.Context, id uint) (*ExportJob, error) {
+	var out ExportJob
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/export-jobs/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExportJobs lists the current user's export jobs
+//
+// List the export jobs the current user has queued, newest first. Jobs are deleted with their files a
+// day after they finish.
+//
+// GET /api/export-jobs
+func (c *Client) GetExportJobs(ctx context.Context) ([]ExportJob, error) {
+	var out []ExportJob
+	err := c.call(ctx, "GET", "/api/export-jobs", nil, nil, &out)
+	return out, err
+}
+
+// GetFileAccessLogsParams holds the parameters of GetFileAccessLogs. Parameters left at their zero value are not sent.
+type GetFileAccessLogsParams struct {
+	UserID       int    // Only downloads by this user
+	EmployeeID   int    // Only files about this employee
+	DocumentID   int    // Only downloads of this document
+	Confidential bool   // Only downloads of confidential documents
+	Route        string // Route filter, such as GET /api/employees/:id/documents/:doc_id/download (comma separated for several)
+	From         string // Only downloads at or after this time (RFC3339 or YYYY-MM-DD)
+	To           string // Only downloads at or before this time (RFC3339, or YYYY-MM-DD for the whole day)
+	Sort         string // Sort keys, comma separated, - prefix for descending (id, created_at). Defaults to -created_at
+	Page         int    // Page number (default 1)
+	PerPage      int    // Items per page (default 25, max 100)
+}
+
+// GetFileAccessLogs lists file downloads and report exports
+//
+// List the files downloaded and reports exported in the organization, newest first: who did, which
+// route and file, the employee and document it concerned, when and from which IP address. Every
+// successful download is logged, including documents, leave forms, employment letters, subject access
+// and other exports, and export job and backup files (Manager/Admin only).
+//
+// GET /api/hr/file-access-logs
+func (c *Client) GetFileAccessLogs(ctx context.Context, params *GetFileAccessLogsParams) (*PaginatedResponse[[]FileAccessLog], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.UserID != 0 {
+			query.Set("user_id", strconv.Itoa(params.UserID))
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.DocumentID != 0 {
+			query.Set("document_id", strconv.Itoa(params.DocumentID))
+		}
+		if params.Confidential {
+			query.Set("confidential", "true")
+		}
+		if params.Route != "" {
+			query.Set("route", params.Route)
+		}
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]FileAccessLog]
+	if err := c.call(ctx, "GET", "/api/hr/file-access-logs", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGrievance returns a grievance with its history
+//
+// Get a grievance with its stage history and notes. Submitters see their own grievances without
+// internal notes; admins see all.
+//
+// GET /api/grievances/{id}
+func (c *Client) GetGrievance(ctx context.Context, id uint) (*GrievanceResponse, error) {
+	var out GrievanceResponse
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/grievances/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGrievanceReportParams holds the parameters of GetGrievanceReport. Parameters left at their zero value are not sent.
+type GetGrievanceReportParams struct {
+	From string // Start date (YYYY-MM-DD), defaults to 90 days ago
+	To   string // End date (YYYY-MM-DD), defaults to today
+}
+
+// GetGrievanceReport returns an anonymized grievance summary for leadership
+//
+// Summarise grievances raised in a period by category, stage and department with SLA performance. No
+// individual is identified and departments with fewer than 3 grievances are grouped as "Other" (Admin
+// only).
+//
+// GET /api/grievances/report
+func (c *Client) GetGrievanceReport(ctx context.Context, params *GetGrievanceReportParams) (*GrievanceReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+	}
+	var out GrievanceReport
+	if err := c.call(ctx, "GET", "/api/grievances/report", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGrievancesParams holds the parameters of GetGrievances. Parameters left at their zero value are not sent.
+type GetGrievancesParams struct {
+	Stage      string // Stage (submitted, acknowledged, investigating, resolved)
+	Category   string // Category
+	OwnerID    int    // Case owner ID
+	Unassigned bool   // Only grievances without a case owner
+	Breached   bool   // Only open grievances that have missed a deadline
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, created_at, stage, resolve_due_at). Defaults to -created_at
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetGrievances lists grievances for HR case handling
+//
+// List grievances with their SLA status. Submitters of anonymous grievances are hidden (Admin only).
+//
+// GET /api/grievances
+func (c *Client) GetGrievances(ctx context.Context, params *GetGrievancesParams) (*PaginatedResponse[[]GrievanceResponse], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Stage != "" {
+			query.Set("stage", params.Stage)
+		}
+		if params.Category != "" {
+			query.Set("category", params.Category)
+		}
+		if params.OwnerID != 0 {
+			query.Set("owner_id", strconv.Itoa(params.OwnerID))
+		}
+		if params.Unassigned {
+			query.Set("unassigned", "true")
+		}
+		if params.Breached {
+			query.Set("breached", "true")
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]GrievanceResponse]
+	if err := c.call(ctx, "GET", "/api/grievances", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHRISMappings lists the organization's HRIS field mappings
+//
+// List the saved mappings that rename the fields of other HR systems to the HRIS interchange schema
+// (Admin only).
+//
+// GET /api/admin/hris/mappings
+func (c *Client) GetHRISMappings(ctx context.Context) ([]HRISMapping, error) {
+	var out []HRISMapping
+	err := c.call(ctx, "GET", "/api/admin/hris/mappings", nil, nil, &out)
+	return out, err
+}
+
+// GetHRISSchema documents the HRIS interchange schema
+//
+// Describe the JSON schema employees, their employment details and leave balances are exported and
+// imported in, with an example document. Mappings rename these fields to those of another HR system
+// (Admin only).
+//
+// GET /api/admin/hris/schema
+func (c *Client) GetHRISSchema(ctx context.Context) (*HRISSchema, error) {
+	var out HRISSchema
+	if err := c.call(ctx, "GET", "/api/admin/hris/schema", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHeadcountAnalyticsParams holds the parameters of GetHeadcountAnalytics. Parameters left at their zero value are not sent.
+type GetHeadcountAnalyticsParams struct {
+	From       string // First month (YYYY-MM)
+	To         string // Last month (YYYY-MM)
+	Department string // Filter by department
+}
+
+// GetHeadcountAnalytics reports monthly headcount, joiners and leavers
+//
+// Monthly opening and closing headcount, joiners, leavers, turnover rate and average tenure, overall
+// and by department. Computed from employment details and lifecycle events; admin accounts are
+// excluded. Defaults to the last 12 months (HR/Admin only).
+//
+// GET /api/hr/analytics/headcount
+func (c *Client) GetHeadcountAnalytics(ctx context.Context, params *GetHeadcountAnalyticsParams) (*HeadcountAnalytics, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out HeadcountAnalytics
+	if err := c.call(ctx, "GET", "/api/hr/analytics/headcount", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHeadcountBudgetsParams holds the parameters of GetHeadcountBudgets. Parameters left at their zero value are not sent.
+type GetHeadcountBudgetsParams struct {
+	FiscalYear int    // Fiscal year (defaults to current year)
+	Department string // Filter by department
+}
+
+// GetHeadcountBudgets returns headcount budgets with current utilisation
+//
+// Get headcount budgets per position/department with filled and available seats (Manager/Admin only).
+//
+// GET /api/headcount/budgets
+func (c *Client) GetHeadcountBudgets(ctx context.Context, params *GetHeadcountBudgetsParams) ([]HeadcountBudgetResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.FiscalYear != 0 {
+			query.Set("fiscal_year", strconv.Itoa(params.FiscalYear))
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []HeadcountBudgetResponse
+	err := c.call(ctx, "GET", "/api/headcount/budgets", query, nil, &out)
+	return out, err
+}
+
+// GetHeadcountReportParams holds the parameters of GetHeadcountReport. Parameters left at their zero value are not sent.
+type GetHeadcountReportParams struct {
+	From       string // First month (YYYY-MM)
+	To         string // Last month (YYYY-MM)
+	Department string // Filter by department
+}
+
+// GetHeadcountReport reports the headcount by department and employment type
+//
+// Headcount today and at the end of each month given, overall and by department, split by employment
+// type, with the budgeted and filled seats of each department's active positions and the vacancies
+// between them. Seats are budgeted by this year's headcount budget for the position, else its
+// headcount. Employees are counted under their current employment type, "unspecified" when they have
+// none. Admin accounts are excluded. Defaults to the last 12 months (HR/Admin only).
+//
+// GET /api/hr/reports/headcount
+func (c *Client) GetHeadcountReport(ctx context.Context, params *GetHeadcountReportParams) (*HeadcountReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out HeadcountReport
+	if err := c.call(ctx, "GET", "/api/hr/reports/headcount", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHeadcountRequestsParams holds the parameters of GetHeadcountRequests. Parameters left at their zero value are not sent.
+type GetHeadcountRequestsParams struct {
+	Status     string // Status filter (pending, approved, rejected)
+	FiscalYear int    // Fiscal year filter
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, created_at, fiscal_year, status). Defaults to -created_at
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetHeadcountRequests lists headcount increase requests
+//
+// List headcount increase requests, optionally filtered by status and fiscal year (Manager/Admin
+// only).
+//
+// GET /api/headcount/requests
+func (c *Client) GetHeadcountRequests(ctx context.Context, params *GetHeadcountRequestsParams) (*PaginatedResponse[[]HeadcountRequest], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.FiscalYear != 0 {
+			query.Set("fiscal_year", strconv.Itoa(params.FiscalYear))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]HeadcountRequest]
+	if err := c.call(ctx, "GET", "/api/headcount/requests", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHolidayCountries lists the countries whose public holidays are imported
+//
+// List the countries whose public holidays the organization imports (Admin only).
+//
+// GET /api/admin/holiday-countries
+func (c *Client) GetHolidayCountries(ctx context.Context) ([]HolidayCountry, error) {
+	var out []HolidayCountry
+	err := c.call(ctx, "GET", "/api/admin/holiday-countries", nil, nil, &out)
+	return out, err
+}
+
+// GetHolidaysParams holds the parameters of GetHolidays. Parameters left at their zero value are not sent.
+type GetHolidaysParams struct {
+	Year    int    // Year, defaults to this year
+	Country string // Country code, e.g. ZM
+}
+
+// GetHolidays lists the organization's active public holidays
+//
+// List the active public holidays of a year in the organization's holiday calendar, by date.
+//
+// GET /api/holidays
+func (c *Client) GetHolidays(ctx context.Context, params *GetHolidaysParams) ([]PublicHoliday, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Year != 0 {
+			query.Set("year", strconv.Itoa(params.Year))
+		}
+		if params.Country != "" {
+			query.Set("country", params.Country)
+		}
+	}
+	var out []PublicHoliday
+	err := c.call(ctx, "GET", "/api/holidays", query, nil, &out)
+	return out, err
+}
+
+// GetIdentityInformation retrieves identity information for an employee
+//
+// Get identity information for an employee.
+//
+// GET /api/employees/{id}/identity
+func (c *Client) GetIdentityInformation(ctx context.Context, id uint) (*IdentityInformation, error) {
+	var out IdentityInformation
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/identity", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLeaveAudit returns audit trail for a leave request
+//
+// Get audit history for a leave request (Manager/Admin only).
+//
+// GET /api/leaves/{id}/audit
+func (c *Client) GetLeaveAudit(ctx context.Context, id uint) ([]LeaveAudit, error) {
+	var out []LeaveAudit
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/leaves/%d/audit", id), nil, nil, &out)
+	return out, err
+}
+
+// GetLeaveBalance returns the leave balance for all leave types
+//
+// Get remaining leave balance for all leave types.
+//
+// GET /api/leaves/balance
+func (c *Client) GetLeaveBalance(ctx context.Context) ([]LeaveBalanceResponse, error) {
+	var out []LeaveBalanceResponse
+	err := c.call(ctx, "GET", "/api/leaves/balance", nil, nil, &out)
+	return out, err
+}
+
+// GetLeaveBalanceSimpleParams holds the parameters of GetLeaveBalanceSimple. Parameters left at their zero value are not sent.
+type GetLeaveBalanceSimpleParams struct {
+	LeaveTypeID int // Leave type ID (defaults to Annual leave)
+}
+
+// GetLeaveBalanceSimple returns the leave balance for an employee using simplified calculation
+//
+// Get leave balance calculated as Total Accrued - Total Taken.
+//
+// GET /api/admin/employees/{id}/leave-balance
+func (c *Client) GetLeaveBalanceSimple(ctx context.Context, id uint, params *GetLeaveBalanceSimpleParams) (map[string]interface{}, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+	}
+	var out map[string]interface{}
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/admin/employees/%d/leave-balance", id), query, nil, &out)
+	return out, err
+}
+
+// GetLeaveCalendarParams holds the parameters of GetLeaveCalendar. Parameters left at their zero value are not sent.
+type GetLeaveCalendarParams struct {
+	StartDate  string // Start date (YYYY-MM-DD)
+	EndDate    string // End date (YYYY-MM-DD)
+	Department string // Filter by department
+}
+
+// GetLeaveCalendar gets leave calendar for a date range
+//
+// Get leave calendar showing all approved leaves in a date range (HR/Admin only).
+//
+// GET /api/hr/leaves/calendar
+func (c *Client) GetLeaveCalendar(ctx context.Context, params *GetLeaveCalendarParams) ([]LeaveCalendarResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.StartDate != "" {
+			query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			query.Set("end_date", params.EndDate)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []LeaveCalendarResponse
+	err := c.call(ctx, "GET", "/api/hr/leaves/calendar", query, nil, &out)
+	return out, err
+}
+
+// GetLeavePresets lists the leave presets
+//
+// List the leave presets an organization can apply: packs of leave types with a country's statutory
+// entitlements, and the standard pack new deployments start with (Admin only).
+//
+// GET /api/admin/leave-presets
+func (c *Client) GetLeavePresets(ctx context.Context) ([]LeavePreset, error) {
+	var out []LeavePreset
+	err := c.call(ctx, "GET", "/api/admin/leave-presets", nil, nil, &out)
+	return out, err
+}
+
+// GetLeaveTypes returns all leave types
+//
+// Get list of all available leave types, in display order, with the color, icon and description to
+// show them with (Admin only).
+//
+// GET /api/leave-types
+func (c *Client) GetLeaveTypes(ctx context.Context) ([]LeaveType, error) {
+	var out []LeaveType
+	err := c.call(ctx, "GET", "/api/leave-types", nil, nil, &out)
+	return out, err
+}
+
+// GetLeaveUtilizationReportParams holds the parameters of GetLeaveUtilizationReport. Parameters left at their zero value are not sent.
+type GetLeaveUtilizationReportParams struct {
+	From       string // First month (YYYY-MM)
+	To         string // Last month (YYYY-MM)
+	Department string // Filter by department
+}
+
+// GetLeaveUtilizationReport reports leave taken by month
+//
+// Days of approved leave taken each month, in total and by leave type, with the number of employees on
+// leave, month-end headcount and days taken per employee. A leave spanning months counts towards each
+// month by its days in that month. Admin accounts are excluded. Defaults to the last 12 months
+// (HR/Admin only).
+//
+// GET /api/hr/reports/leave-utilization
+func (c *Client) GetLeaveUtilizationReport(ctx context.Context, params *GetLeaveUtilizationReportParams) (*LeaveUtilizationReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out LeaveUtilizationReport
+	if err := c.call(ctx, "GET", "/api/hr/reports/leave-utilization", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLegalHoldsParams holds the parameters of GetLegalHolds. Parameters left at their zero value are not sent.
+type GetLegalHoldsParams struct {
+	IncludeReleased bool // Include released holds
+}
+
+// GetLegalHolds lists legal holds
+//
+// List legal holds, newest first. Only active holds are listed unless include_released is true (Admin
+// only).
+//
+// GET /api/admin/legal-holds
+func (c *Client) GetLegalHolds(ctx context.Context, params *GetLegalHoldsParams) ([]LegalHold, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.IncludeReleased {
+			query.Set("include_released", "true")
+		}
+	}
+	var out []LegalHold
+	err := c.call(ctx, "GET", "/api/admin/legal-holds", query, nil, &out)
+	return out, err
+}
+
+// GetLifecycleEvents retrieves lifecycle events for an employee
+//
+// Get all lifecycle events for an employee.
+//
+// GET /api/employees/{id}/lifecycle
+func (c *Client) GetLifecycleEvents(ctx context.Context, id uint) ([]WorkLifecycleEvent, error) {
+	var out []WorkLifecycleEvent
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/lifecycle", id), nil, nil, &out)
+	return out, err
+}
+
+// GetMandatoryTraining lists the mandatory training rules
+//
+// List the courses each role is required to complete (Manager/Admin only).
+//
+// GET /api/training/mandatory
+func (c *Client) GetMandatoryTraining(ctx context.Context) ([]MandatoryTraining, error) {
+	var out []MandatoryTraining
+	err := c.call(ctx, "GET", "/api/training/mandatory", nil, nil, &out)
+	return out, err
+}
+
+// GetMandatoryTrainingGapsParams holds the parameters of GetMandatoryTrainingGaps. Parameters left at their zero value are not sent.
+type GetMandatoryTrainingGapsParams struct {
+	Department string // Department filter
+}
+
+// GetMandatoryTrainingGaps lists employees with outstanding, overdue or expired mandatory training
+//
+// List active employees who have not completed, or whose completion has expired for, courses mandatory
+// for their role (Manager/Admin only).
+//
+// GET /api/training/mandatory/gaps
+func (c *Client) GetMandatoryTrainingGaps(ctx context.Context, params *GetMandatoryTrainingGapsParams) ([]MandatoryTrainingGap, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []MandatoryTrainingGap
+	err := c.call(ctx, "GET", "/api/training/mandatory/gaps", query, nil, &out)
+	return out, err
+}
+
+// GetMonthlyLeaveReportParams holds the parameters of GetMonthlyLeaveReport. Parameters left at their zero value are not sent.
+type GetMonthlyLeaveReportParams struct {
+	Month string // Month in YYYY-MM format (e.g., 2025-02) (required)
+}
+
+// GetMonthlyLeaveReport gets monthly leave report for a specific month
+//
+// Get monthly leave report in CSV format matching the legacy system (HR/Admin only).
+//
+// GET /api/hr/leaves/monthly-report
+func (c *Client) GetMonthlyLeaveReport(ctx context.Context, params *GetMonthlyLeaveReportParams) ([]MonthlyLeaveReportResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+	}
+	var out []MonthlyLeaveReportResponse
+	err := c.call(ctx, "GET", "/api/hr/leaves/monthly-report", query, nil, &out)
+	return out, err
+}
+
+// GetMyAttendanceParams holds the parameters of GetMyAttendance. Parameters left at their zero value are not sent.
+type GetMyAttendanceParams struct {
+	Month string // Month (YYYY-MM), defaults to the current month
+}
+
+// GetMyAttendance returns the current user's attendance for a month
+//
+// Get the current user's daily attendance records and totals for a month.
+//
+// GET /api/attendance/me
+func (c *Client) GetMyAttendance(ctx context.Context, params *GetMyAttendanceParams) (*MonthlyAttendance, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+	}
+	var out MonthlyAttendance
+	if err := c.call(ctx, "GET", "/api/attendance/me", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyCompensation lists the current user's compensation records
+//
+// List your compensation records, newest effective date first.
+//
+// GET /api/me/compensation
+func (c *Client) GetMyCompensation(ctx context.Context) ([]CompensationRecord, error) {
+	var out []CompensationRecord
+	err := c.call(ctx, "GET", "/api/me/compensation", nil, nil, &out)
+	return out, err
+}
+
+// GetMyDashboardParams holds the parameters of GetMyDashboard. Parameters left at their zero value are not sent.
+type GetMyDashboardParams struct {
+	ExpiringWithinDays int // Days ahead to list expiring documents for (default 30, max 365)
+}
+
+// GetMyDashboard returns the current user's home page in one call
+//
+// Get what the home page shows the current user in one call: the balance of each leave type, their
+// pending and upcoming leaves, their outstanding onboarding tasks (of their own onboarding or assigned
+// to them), their documents and compliance records that have expired or expire within
+// expiring_within_days, and their unread notifications with the latest 5. Balances of leave types
+// using a balance are the current leave year's balance with carry-over; for the others they are what
+// is left of max_days after the leave approved this leave year.
+//
+// GET /api/me/dashboard
+func (c *Client) GetMyDashboard(ctx context.Context, params *GetMyDashboardParams) (*DashboardResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.ExpiringWithinDays != 0 {
+			query.Set("expiring_within_days", strconv.Itoa(params.ExpiringWithinDays))
+		}
+	}
+	var out DashboardResponse
+	if err := c.call(ctx, "GET", "/api/me/dashboard", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyDevices lists the current user's devices registered for push notifications
+//
+// List the mobile app installations of the current user that notifications are pushed to, with the
+// last push and error.
+//
+// GET /api/notifications/devices
+func (c *Client) GetMyDevices(ctx context.Context) ([]DeviceToken, error) {
+	var out []DeviceToken
+	err := c.call(ctx, "GET", "/api/notifications/devices", nil, nil, &out)
+	return out, err
+}
+
+// GetMyEmploymentLetters lists the employment letters the current user issued
+//
+// List the proof-of-employment letters you issued, newest first.
+//
+// GET /api/me/employment-letters
+func (c *Client) GetMyEmploymentLetters(ctx context.Context) ([]EmploymentLetter, error) {
+	var out []EmploymentLetter
+	err := c.call(ctx, "GET", "/api/me/employment-letters", nil, nil, &out)
+	return out, err
+}
+
+// GetMyGrievancesParams holds the parameters of GetMyGrievances. Parameters left at their zero value are not sent.
+type GetMyGrievancesParams struct {
+	Page    int // Page number (default 1)
+	PerPage int // Items per page (default 25, max 100)
+}
+
+// GetMyGrievances lists the grievances the current user has raised
+//
+// List grievances raised by the current user, including anonymous ones.
+//
+// GET /api/grievances/mine
+func (c *Client) GetMyGrievances(ctx context.Context, params *GetMyGrievancesParams) (*PaginatedResponse[[]GrievanceResponse], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]GrievanceResponse]
+	if err := c.call(ctx, "GET", "/api/grievances/mine", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyKudos lists kudos the current user has received and sent
+//
+// List the kudos the current user has received and sent, newest first.
+//
+// GET /api/recognition/me
+func (c *Client) GetMyKudos(ctx context.Context) (*MyKudosResponse, error) {
+	var out MyKudosResponse
+	if err := c.call(ctx, "GET", "/api/recognition/me", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyLeavesParams holds the parameters of GetMyLeaves. Parameters left at their zero value are not sent.
+type GetMyLeavesParams struct {
+	Status      string // Status filter (comma separated for several)
+	LeaveTypeID int    // Leave type ID
+	Sort        string // Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to -created_at
+	Page        int    // Page number (default 1)
+	PerPage     int    // Items per page (default 25, max 100)
+}
+
+// GetMyLeaves returns the leave history for the authenticated employee
+//
+// Get all leave requests for the authenticated employee.
+//
+// GET /api/leaves
+func (c *Client) GetMyLeaves(ctx context.Context, params *GetMyLeavesParams) (*PaginatedResponse[[]Leave], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Leave]
+	if err := c.call(ctx, "GET", "/api/leaves", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyNotificationsParams holds the parameters of GetMyNotifications. Parameters left at their zero value are not sent.
+type GetMyNotificationsParams struct {
+	Unread  bool // Only return unread notifications
+	Page    int  // Page number (default 1)
+	PerPage int  // Items per page (default 25, max 100)
+}
+
+// GetMyNotifications returns the current user's in-app notifications
+//
+// Get the current user's in-app notifications, newest first.
+//
+// GET /api/notifications
+func (c *Client) GetMyNotifications(ctx context.Context, params *GetMyNotificationsParams) (*PaginatedResponse[[]Notification], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Unread {
+			query.Set("unread", "true")
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Notification]
+	if err := c.call(ctx, "GET", "/api/notifications", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyPreferences returns the current user's notification settings
+//
+// Get the channels (in-app, email, SMS and push) and groups of notifications (approvals, reminders and
+// announcements) that reach the current user at all, with the categories of each group and whether
+// email, SMS and push can reach them. Everything is on until they choose otherwise. The channels of
+// each category are set in the notification preferences.
+//
+// GET /api/me/preferences
+func (c *Client) GetMyPreferences(ctx context.Context) (*MyPreferencesResponse, error) {
+	var out MyPreferencesResponse
+	if err := c.call(ctx, "GET", "/api/me/preferences", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyRemoteWorkParams holds the parameters of GetMyRemoteWork. Parameters left at their zero value are not sent.
+type GetMyRemoteWorkParams struct {
+	Status  string // Status filter (pending, approved, rejected, cancelled)
+	Sort    string // Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to -start_date
+	Page    int    // Page number (default 1)
+	PerPage int    // Items per page (default 25, max 100)
+}
+
+// GetMyRemoteWork lists the current user's remote work requests
+//
+// List the current user's remote work requests, newest first.
+//
+// GET /api/remote-work/me
+func (c *Client) GetMyRemoteWork(ctx context.Context, params *GetMyRemoteWorkParams) (*PaginatedResponse[[]RemoteWorkRequest], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]RemoteWorkRequest]
+	if err := c.call(ctx, "GET", "/api/remote-work/me", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMyScheduleParams holds the parameters of GetMySchedule. Parameters left at their zero value are not sent.
+type GetMyScheduleParams struct {
+	From string // Start date (YYYY-MM-DD)
+	To   string // End date (YYYY-MM-DD)
+}
+
+// GetMySchedule returns the current user's rostered shifts
+//
+// Get the current user's rostered shifts for a date range. Defaults to the next 14 days.
+//
+// GET /api/shifts/me
+func (c *Client) GetMySchedule(ctx context.Context, params *GetMyScheduleParams) ([]ShiftAssignment, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+	}
+	var out []ShiftAssignment
+	err := c.call(ctx, "GET", "/api/shifts/me", query, nil, &out)
+	return out, err
+}
+
+// GetMyTeam returns the current user's manager and direct reports
+//
+// Get who you report to and your current direct reports, built from the manager on employment details.
+//
+// GET /api/me/team
+func (c *Client) GetMyTeam(ctx context.Context) (*TeamResponse, error) {
+	var out TeamResponse
+	if err := c.call(ctx, "GET", "/api/me/team", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetNationalIDFormats lists the organization's national ID formats
+//
+// List the national ID formats NRCs are validated and stored by, by country (Admin only).
+//
+// GET /api/admin/national-id-formats
+func (c *Client) GetNationalIDFormats(ctx context.Context) ([]NationalIDFormat, error) {
+	var out []NationalIDFormat
+	err := c.call(ctx, "GET", "/api/admin/national-id-formats", nil, nil, &out)
+	return out, err
+}
+
+// GetNotificationPreferences returns the current user's notification channels
+//
+// Get the channels the current user's notifications of every category reach them on besides in-app.
+// Categories never set use the defaults: email and push, and a text message for leave decisions and
+// password changes.
+//
+// GET /api/notifications/preferences
+func (c *Client) GetNotificationPreferences(ctx context.Context) (*NotificationPreferencesResponse, error) {
+	var out NotificationPreferencesResponse
+	if err := c.call(ctx, "GET", "/api/notifications/preferences", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOffboardingProcess retrieves offboarding process for an employee
+//
+// Get offboarding process for an employee.
+//
+// GET /api/employees/{id}/offboarding
+func (c *Client) GetOffboardingProcess(ctx context.Context, id uint) (*OffboardingProcess, error) {
+	var out OffboardingProcess
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/offboarding", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOnboardingProcess retrieves onboarding process for an employee
+//
+// Get onboarding process for an employee.
+//
+// GET /api/employees/{id}/onboarding
+func (c *Client) GetOnboardingProcess(ctx context.Context, id uint) (*OnboardingProcess, error) {
+	var out OnboardingProcess
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/onboarding", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrganizationDashboard returns the admin landing page in one call
+//
+// Get the organization-wide figures of the admin landing page in one call: active headcount, employees
+// on approved leave today, requests waiting for a decision, documents and compliance records expiring
+// from today to the end of the month, and the latest 10 audit log entries by the organization's users
+// (Admin only).
+//
+// GET /api/admin/dashboard
+func (c *Client) GetOrganizationDashboard(ctx context.Context) (*OrganizationDashboardResponse, error) {
+	var out OrganizationDashboardResponse
+	if err := c.call(ctx, "GET", "/api/admin/dashboard", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrganizations lists every organization
+//
+// List every organization (Admins of the default organization only).
+//
+// GET /api/organizations
+func (c *Client) GetOrganizations(ctx context.Context) ([]Organization, error) {
+	var out []Organization
+	err := c.call(ctx, "GET", "/api/organizations", nil, nil, &out)
+	return out, err
+}
+
+// GetPayrollBankDetailsParams holds the parameters of GetPayrollBankDetails. Parameters left at their zero value are not sent.
+type GetPayrollBankDetailsParams struct {
+	Department string // Department filter
+	Sort       string // Sort keys, comma separated, - prefix for descending (employee_id, department, lastname). Defaults to employee_id
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetPayrollBankDetails lists full bank details for all employees for a payroll run
+//
+// List bank details with full account numbers for all employees, optionally filtered by department.
+// Every record returned is audit logged (Payroll access only).
+//
+// GET /api/payroll/bank-details
+func (c *Client) GetPayrollBankDetails(ctx context.Context, params *GetPayrollBankDetailsParams) (*PaginatedResponse[[]BankDetails], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]BankDetails]
+	if err := c.call(ctx, "GET", "/api/payroll/bank-details", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPendingLeavesParams holds the parameters of GetPendingLeaves. Parameters left at their zero value are not sent.
+type GetPendingLeavesParams struct {
+	LeaveTypeID int    // Leave type ID
+	Sort        string // Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at). Defaults to created_at
+	Page        int    // Page number (default 1)
+	PerPage     int    // Items per page (default 25, max 100)
+}
+
+// GetPendingLeaves returns all pending leave requests
+//
+// Get all pending leave requests (Manager/Admin only).
+//
+// GET /api/leaves/pending
+func (c *Client) GetPendingLeaves(ctx context.Context, params *GetPendingLeavesParams) (*PaginatedResponse[[]Leave], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.LeaveTypeID != 0 {
+			query.Set("leave_type_id", strconv.Itoa(params.LeaveTypeID))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Leave]
+	if err := c.call(ctx, "GET", "/api/leaves/pending", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPosition retrieves a specific position
+//
+// Get a specific position by ID.
+//
+// GET /api/positions/{id}
+func (c *Client) GetPosition(ctx context.Context, id uint) (*Position, error) {
+	var out Position
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/positions/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPositionAssignments retrieves position assignments for an employee
+//
+// Get all current and past position assignments for an employee. Employees can only view their own;
+// salaries are only included for users with payroll access.
+//
+// GET /api/employees/{id}/positions
+func (c *Client) GetPositionAssignments(ctx context.Context, id uint) ([]PositionAssignment, error) {
+	var out []PositionAssignment
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/positions", id), nil, nil, &out)
+	return out, err
+}
+
+// GetPositionCostCenters returns how a position's cost is split across cost centers
+//
+// Get the cost center split in effect today for a position and the history of its allocations.
+// Employees in the position are charged by it unless they have their own allocation (Manager/Admin
+// only).
+//
+// GET /api/positions/{id}/cost-centers
+func (c *Client) GetPositionCostCenters(ctx context.Context, id uint) (*CostCenterAllocationResponse, error) {
+	var out CostCenterAllocationResponse
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/positions/%d/cost-centers", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPositionVacanciesParams holds the parameters of GetPositionVacancies. Parameters left at their zero value are not sent.
+type GetPositionVacanciesParams struct {
+	Department string // Filter by department
+}
+
+// GetPositionVacancies lists active positions with unfilled budgeted headcount
+//
+// Compare active assignments against budgeted headcount and list positions with vacancies.
+//
+// GET /api/positions/vacancies
+func (c *Client) GetPositionVacancies(ctx context.Context, params *GetPositionVacanciesParams) ([]PositionVacancyResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []PositionVacancyResponse
+	err := c.call(ctx, "GET", "/api/positions/vacancies", query, nil, &out)
+	return out, err
+}
+
+// GetPositionsParams holds the parameters of GetPositions. Parameters left at their zero value are not sent.
+type GetPositionsParams struct {
+	Department string // Department filter (comma separated for several)
+	Level      string // Level filter
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, code, title, department)
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetPositions retrieves all positions
+//
+// Get list of all active positions.
+//
+// GET /api/positions
+func (c *Client) GetPositions(ctx context.Context, params *GetPositionsParams) (*PaginatedResponse[[]Position], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Level != "" {
+			query.Set("level", params.Level)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Position]
+	if err := c.call(ctx, "GET", "/api/positions", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProfileCompleteness reports which sections of an employee's profile are missing or stale
+//
+// Report which profile sections (identity, employment, documents, emergency contact, compliance) are
+// complete, missing or stale for an employee. Employees can only view their own profile.
+//
+// GET /api/employees/{id}/profile-completeness
+func (c *Client) GetProfileCompleteness(ctx context.Context, id uint) (*ProfileCompleteness, error) {
+	var out ProfileCompleteness
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/profile-completeness", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProfileCompletenessReportParams holds the parameters of GetProfileCompletenessReport. Parameters left at their zero value are not sent.
+type GetProfileCompletenessReportParams struct {
+	Department string // Department filter
+}
+
+// GetProfileCompletenessReport rolls up profile completeness by department
+//
+// Show the average profile completeness percentage per department and how many employees have each
+// section missing or stale (Manager/Admin only).
+//
+// GET /api/hr/profile-completeness
+func (c *Client) GetProfileCompletenessReport(ctx context.Context, params *GetProfileCompletenessReportParams) (*ProfileCompletenessReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out ProfileCompletenessReport
+	if err := c.call(ctx, "GET", "/api/hr/profile-completeness", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRecognitionFeedParams holds the parameters of GetRecognitionFeed. Parameters left at their zero value are not sent.
+type GetRecognitionFeedParams struct {
+	ValueID    int    // Company value ID
+	Department string // Recipient department
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetRecognitionFeed lists recent kudos across the organisation
+//
+// List kudos, newest first, across the organisation, optionally filtered by company value or
+// department.
+//
+// GET /api/recognition/feed
+func (c *Client) GetRecognitionFeed(ctx context.Context, params *GetRecognitionFeedParams) (*PaginatedResponse[[]Kudos], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.ValueID != 0 {
+			query.Set("value_id", strconv.Itoa(params.ValueID))
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Kudos]
+	if err := c.call(ctx, "GET", "/api/recognition/feed", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRecognitionStatsParams holds the parameters of GetRecognitionStats. Parameters left at their zero value are not sent.
+type GetRecognitionStatsParams struct {
+	Year    int // Year
+	Quarter int // Quarter (1-4)
+}
+
+// GetRecognitionStats reports recognition stats for a quarter
+//
+// Report kudos by company value, department and top recipients for a quarter, with participation rate.
+// Defaults to the current quarter (HR/Admin only).
+//
+// GET /api/hr/recognition/stats
+func (c *Client) GetRecognitionStats(ctx context.Context, params *GetRecognitionStatsParams) (*RecognitionStats, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Year != 0 {
+			query.Set("year", strconv.Itoa(params.Year))
+		}
+		if params.Quarter != 0 {
+			query.Set("quarter", strconv.Itoa(params.Quarter))
+		}
+	}
+	var out RecognitionStats
+	if err := c.call(ctx, "GET", "/api/hr/recognition/stats", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRemoteWorkRequestsParams holds the parameters of GetRemoteWorkRequests. Parameters left at their zero value are not sent.
+type GetRemoteWorkRequestsParams struct {
+	Status     string // Status filter (pending, approved, rejected, cancelled, all)
+	EmployeeID int    // Employee ID
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to start_date
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetRemoteWorkRequests lists remote work requests for review
+//
+// List remote work requests. Managers see their direct reports; admins see all. Defaults to pending
+// requests (Manager/Admin only).
+//
+// GET /api/remote-work
+func (c *Client) GetRemoteWorkRequests(ctx context.Context, params *GetRemoteWorkRequestsParams) (*PaginatedResponse[[]RemoteWorkRequest], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]RemoteWorkRequest]
+	if err := c.call(ctx, "GET", "/api/remote-work", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRemoteWorkUtilizationParams holds the parameters of GetRemoteWorkUtilization. Parameters left at their zero value are not sent.
+type GetRemoteWorkUtilizationParams struct {
+	Month      string // Month (YYYY-MM), defaults to the current month
+	Department string // Filter by department
+}
+
+// GetRemoteWorkUtilization reports remote work utilization for a month
+//
+// Report office, remote and leave days per employee and department for a month. Remote percentage
+// excludes leave days (Manager/Admin only).
+//
+// GET /api/hr/remote-work/utilization
+func (c *Client) GetRemoteWorkUtilization(ctx context.Context, params *GetRemoteWorkUtilizationParams) (*RemoteWorkUtilizationReport, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out RemoteWorkUtilizationReport
+	if err := c.call(ctx, "GET", "/api/hr/remote-work/utilization", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReportingLineHistory lists the changes of who an employee reports to
+//
+// List the changes of an employee's manager recorded in their employment history, newest first, with
+// the names of the previous and new manager. Employees can only see their own history, managers and
+// admins anyone's.
+//
+// GET /api/employees/{id}/reporting-line/history
+func (c *Client) GetReportingLineHistory(ctx context.Context, id uint) ([]ReportingLineChange, error) {
+	var out []ReportingLineChange
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/employees/%d/reporting-line/history", id), nil, nil, &out)
+	return out, err
+}
+
+// GetRetentionPolicies lists the retention policy of every data category
+//
+// List the retention policy of every data category (audit_logs, ex_employee_documents, leave_history,
+// login_logs). Categories without a policy are listed disabled with retention_days 0 and are never
+// purged (Admin only).
+//
+// GET /api/admin/retention-policies
+func (c *Client) GetRetentionPolicies(ctx context.Context) ([]RetentionPolicy, error) {
+	var out []RetentionPolicy
+	err := c.call(ctx, "GET", "/api/admin/retention-policies", nil, nil, &out)
+	return out, err
+}
+
+// GetRetentionReport reports what the retention policies would purge
+//
+// Dry run of the enabled retention policies: how many records and files each would purge now, and how
+// many are kept because of a legal hold. Nothing is deleted (Admin only).
+//
+// GET /api/admin/retention-policies/report
+func (c *Client) GetRetentionReport(ctx context.Context) ([]RetentionResult, error) {
+	var out []RetentionResult
+	err := c.call(ctx, "GET", "/api/admin/retention-policies/report", nil, nil, &out)
+	return out, err
+}
+
+// GetRotaParams holds the parameters of GetRota. Parameters left at their zero value are not sent.
+type GetRotaParams struct {
+	From       string // Start date (YYYY-MM-DD)
+	To         string // End date (YYYY-MM-DD)
+	Department string // Department filter
+	EmployeeID int    // Employee ID
+	ShiftID    int    // Shift ID
+}
+
+// GetRota returns shift assignments for a date range
+//
+// Get shift assignments for a date range, optionally filtered by department, employee or shift.
+// Defaults to the next 7 days (Manager/Admin only).
+//
+// GET /api/shifts/rota
+func (c *Client) GetRota(ctx context.Context, params *GetRotaParams) ([]ShiftAssignment, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.ShiftID != 0 {
+			query.Set("shift_id", strconv.Itoa(params.ShiftID))
+		}
+	}
+	var out []ShiftAssignment
+	err := c.call(ctx, "GET", "/api/shifts/rota", query, nil, &out)
+	return out, err
+}
+
+// GetScheduledJob gets a background job
+//
+// Get a background job with its schedule and last run, e.g. to follow a run started by hand (Admins of
+// the default organization only).
+//
+// GET /api/admin/scheduled-jobs/{name}
+func (c *Client) GetScheduledJob(ctx context.Context, name string) (*JobStatus, error) {
+	var out JobStatus
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/admin/scheduled-jobs/%s", url.PathEscape(name)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetScheduledJobs lists the background jobs
+//
+// List the background jobs, such as monthly accruals, with their schedule, next run on this server,
+// whether they are paused, and when and how their last run on any server went (Admins of the default
+// organization only).
+//
+// GET /api/admin/scheduled-jobs
+func (c *Client) GetScheduledJobs(ctx context.Context) ([]JobStatus, error) {
+	var out []JobStatus
+	err := c.call(ctx, "GET", "/api/admin/scheduled-jobs", nil, nil, &out)
+	return out, err
+}
+
+// GetSettings lists the runtime settings
+//
+// List the settings that can be changed without a restart, with their current value and default. They
+// apply to the whole installation (Admin only).
+//
+// GET /api/admin/settings
+func (c *Client) GetSettings(ctx context.Context) ([]SettingResponse, error) {
+	var out []SettingResponse
+	err := c.call(ctx, "GET", "/api/admin/settings", nil, nil, &out)
+	return out, err
+}
+
+// GetShiftLeaveConflictsParams holds the parameters of GetShiftLeaveConflicts. Parameters left at their zero value are not sent.
+type GetShiftLeaveConflictsParams struct {
+	From       string // Start date (YYYY-MM-DD)
+	To         string // End date (YYYY-MM-DD)
+	Department string // Department filter
+}
+
+// GetShiftLeaveConflicts lists rostered shifts that clash with leave
+//
+// List rostered shifts that fall on days the employee has pending or approved leave. Defaults to the
+// next 30 days (Manager/Admin only).
+//
+// GET /api/shifts/conflicts
+func (c *Client) GetShiftLeaveConflicts(ctx context.Context, params *GetShiftLeaveConflictsParams) ([]ShiftLeaveConflict, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []ShiftLeaveConflict
+	err := c.call(ctx, "GET", "/api/shifts/conflicts", query, nil, &out)
+	return out, err
+}
+
+// GetShiftSwapsParams holds the parameters of GetShiftSwaps. Parameters left at their zero value are not sent.
+type GetShiftSwapsParams struct {
+	Status  string // Status filter (pending, approved, rejected, cancelled)
+	Sort    string // Sort keys, comma separated, - prefix for descending (id, created_at, status). Defaults to -created_at
+	Page    int    // Page number (default 1)
+	PerPage int    // Items per page (default 25, max 100)
+}
+
+// GetShiftSwaps lists shift swap requests
+//
+// List shift swap requests. Employees see swaps they requested or are the target of; admins see all.
+//
+// GET /api/shifts/swaps
+func (c *Client) GetShiftSwaps(ctx context.Context, params *GetShiftSwapsParams) (*PaginatedResponse[[]ShiftSwapRequest], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]ShiftSwapRequest]
+	if err := c.call(ctx, "GET", "/api/shifts/swaps", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetShiftsParams holds the parameters of GetShifts. Parameters left at their zero value are not sent.
+type GetShiftsParams struct {
+	Department string // Department filter
+}
+
+// GetShifts lists shift definitions
+//
+// List active shift definitions, optionally filtered by department.
+//
+// GET /api/shifts
+func (c *Client) GetShifts(ctx context.Context, params *GetShiftsParams) ([]Shift, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []Shift
+	err := c.call(ctx, "GET", "/api/shifts", query, nil, &out)
+	return out, err
+}
+
+// GetSkillsParams holds the parameters of GetSkills. Parameters left at their zero value are not sent.
+type GetSkillsParams struct {
+	Category string // Category filter
+}
+
+// GetSkills lists the skills catalogue
+//
+// List active skills in the catalogue, optionally filtered by category.
+//
+// GET /api/skills
+func (c *Client) GetSkills(ctx context.Context, params *GetSkillsParams) ([]Skill, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Category != "" {
+			query.Set("category", params.Category)
+		}
+	}
+	var out []Skill
+	err := c.call(ctx, "GET", "/api/skills", query, nil, &out)
+	return out, err
+}
+
+// GetTeamCalendarParams holds the parameters of GetTeamCalendar. Parameters left at their zero value are not sent.
+type GetTeamCalendarParams struct {
+	From       string // Start date (YYYY-MM-DD)
+	To         string // End date (YYYY-MM-DD)
+	Department string // Filter by department
+}
+
+// GetTeamCalendar shows who is in the office, remote or on leave each day
+//
+// Show each employee's status per day (in_office, remote, on_leave, non_working) from approved leave,
+// approved remote work and work schedules. Managers see their direct reports; admins see everyone.
+// Defaults to the next 14 days (Manager/Admin only).
+//
+// GET /api/hr/team-calendar
+func (c *Client) GetTeamCalendar(ctx context.Context, params *GetTeamCalendarParams) ([]EmployeePresence, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out []EmployeePresence
+	err := c.call(ctx, "GET", "/api/hr/team-calendar", query, nil, &out)
+	return out, err
+}
+
+// GetTeamDashboardParams holds the parameters of GetTeamDashboard. Parameters left at their zero value are not sent.
+type GetTeamDashboardParams struct {
+	WithinDays int // Days ahead to list probation and contract end dates for (default 30, max 365)
+}
+
+// GetTeamDashboard returns the team page of the current manager in one call
+//
+// Get what the team page shows a manager about their direct reports, the active employees whose
+// employment details name them as manager: who is off today and this week (Monday to Sunday), how many
+// of their leave, remote work and attendance correction requests are pending, their balances of each
+// leave type using a balance, and their probation and contract end dates within within_days. Only
+// direct reports are included, for admins too (Manager/Admin only).
+//
+// GET /api/manager/dashboard
+func (c *Client) GetTeamDashboard(ctx context.Context, params *GetTeamDashboardParams) (*TeamDashboardResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.WithinDays != 0 {
+			query.Set("within_days", strconv.Itoa(params.WithinDays))
+		}
+	}
+	var out TeamDashboardResponse
+	if err := c.call(ctx, "GET", "/api/manager/dashboard", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTeamRecognitionParams holds the parameters of GetTeamRecognition. Parameters left at their zero value are not sent.
+type GetTeamRecognitionParams struct {
+	ValueID int // Company value ID
+	Page    int // Page number (default 1)
+	PerPage int // Items per page (default 25, max 100)
+}
+
+// GetTeamRecognition lists kudos received by the current manager's team
+//
+// List kudos, newest first, received by the manager's direct reports. Admins see kudos across all
+// teams (Manager/Admin only).
+//
+// GET /api/recognition/team
+func (c *Client) GetTeamRecognition(ctx context.Context, params *GetTeamRecognitionParams) (*PaginatedResponse[[]Kudos], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.ValueID != 0 {
+			query.Set("value_id", strconv.Itoa(params.ValueID))
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Kudos]
+	if err := c.call(ctx, "GET", "/api/recognition/team", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTrainingCourses lists the training course catalogue
+//
+// List active training courses.
+//
+// GET /api/training/courses
+func (c *Client) GetTrainingCourses(ctx context.Context) ([]TrainingCourse, error) {
+	var out []TrainingCourse
+	err := c.call(ctx, "GET", "/api/training/courses", nil, nil, &out)
+	return out, err
+}
+
+// GetTrainingSessionEnrollments lists the enrollments of a session
+//
+// List employees enrolled on a training session with their attendance and completion status
+// (Manager/Admin only).
+//
+// GET /api/training/sessions/{id}/enrollments
+func (c *Client) GetTrainingSessionEnrollments(ctx context.Context, id uint) ([]TrainingEnrollment, error) {
+	var out []TrainingEnrollment
+	err := c.call(ctx, "GET", fmt.Sprintf("/api/training/sessions/%d/enrollments", id), nil, nil, &out)
+	return out, err
+}
+
+// GetTrainingSessionsParams holds the parameters of GetTrainingSessions. Parameters left at their zero value are not sent.
+type GetTrainingSessionsParams struct {
+	CourseID    int    // Course ID
+	Status      string // Status (scheduled, completed, cancelled)
+	IncludePast bool   // Include sessions that have already started
+	Sort        string // Sort keys, comma separated, - prefix for descending (id, start_date, end_date, status). Defaults to start_date
+	Page        int    // Page number (default 1)
+	PerPage     int    // Items per page (default 25, max 100)
+}
+
+// GetTrainingSessions lists training sessions
+//
+// List training sessions, optionally filtered by course and status. Upcoming sessions are returned by
+// default.
+//
+// GET /api/training/sessions
+func (c *Client) GetTrainingSessions(ctx context.Context, params *GetTrainingSessionsParams) (*PaginatedResponse[[]TrainingSession], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.CourseID != 0 {
+			query.Set("course_id", strconv.Itoa(params.CourseID))
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.IncludePast {
+			query.Set("include_past", "true")
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]TrainingSession]
+	if err := c.call(ctx, "GET", "/api/training/sessions", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTransferRequest returns a single transfer request
+//
+// Get a transfer request by ID (Manager/Admin only).
+//
+// GET /api/transfers/{id}
+func (c *Client) GetTransferRequest(ctx context.Context, id uint) (*TransferRequest, error) {
+	var out TransferRequest
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/transfers/%d", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTransferRequestsParams holds the parameters of GetTransferRequests. Parameters left at their zero value are not sent.
+type GetTransferRequestsParams struct {
+	Status     string // Status filter (pending, approved, rejected, completed, cancelled)
+	EmployeeID int    // Employee ID filter
+	Sort       string // Sort keys, comma separated, - prefix for descending (id, created_at, effective_date, status). Defaults to -created_at
+	Page       int    // Page number (default 1)
+	PerPage    int    // Items per page (default 25, max 100)
+}
+
+// GetTransferRequests lists transfer requests
+//
+// List transfer requests. Admins see all requests; managers see requests they raised or that involve
+// them as current or receiving manager (Manager/Admin only).
+//
+// GET /api/transfers
+func (c *Client) GetTransferRequests(ctx context.Context, params *GetTransferRequestsParams) (*PaginatedResponse[[]TransferRequest], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]TransferRequest]
+	if err := c.call(ctx, "GET", "/api/transfers", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTurnoverAnalyticsParams holds the parameters of GetTurnoverAnalytics. Parameters left at their zero value are not sent.
+type GetTurnoverAnalyticsParams struct {
+	From       string // First month (YYYY-MM)
+	To         string // Last month (YYYY-MM)
+	Department string // Filter by department
+}
+
+// GetTurnoverAnalytics reports turnover over a period
+//
+// Leavers split into voluntary and involuntary, turnover rate against average headcount (also
+// annualized) and average tenure of leavers, overall, by month and by department. Defaults to the last
+// 12 months (HR/Admin only).
+//
+// GET /api/hr/analytics/turnover
+func (c *Client) GetTurnoverAnalytics(ctx context.Context, params *GetTurnoverAnalyticsParams) (*TurnoverAnalytics, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			query.Set("from", params.From)
+		}
+		if params.To != "" {
+			query.Set("to", params.To)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+	}
+	var out TurnoverAnalytics
+	if err := c.call(ctx, "GET", "/api/hr/analytics/turnover", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUnmaskedBankDetails retrieves an employee's full bank details for payroll
+//
+// Get an employee's bank details including the full account number. Every call is audit logged
+// (Payroll access only).
+//
+// GET /api/payroll/employees/{id}/bank-details
+func (c *Client) GetUnmaskedBankDetails(ctx context.Context, id uint) (*BankDetails, error) {
+	var out BankDetails
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/payroll/employees/%d/bank-details", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUpcomingLeavesParams holds the parameters of GetUpcomingLeaves. Parameters left at their zero value are not sent.
+type GetUpcomingLeavesParams struct {
+	Days int // Number of days to look ahead
+}
+
+// GetUpcomingLeaves gets all upcoming approved leaves
+//
+// Get all upcoming approved leaves within specified days (HR/Admin only).
+//
+// GET /api/hr/leaves/upcoming
+func (c *Client) GetUpcomingLeaves(ctx context.Context, params *GetUpcomingLeavesParams) ([]Leave, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Days != 0 {
+			query.Set("days", strconv.Itoa(params.Days))
+		}
+	}
+	var out []Leave
+	err := c.call(ctx, "GET", "/api/hr/leaves/upcoming", query, nil, &out)
+	return out, err
+}
+
+// GetVersion reports the version of the build that is running
+//
+// Returns the release version, git commit and build time set when the binary was built, and a
+// fingerprint of the database schema the build migrates to, so operators can check what is deployed.
+// Servers running the same build report the same values.
+//
+// GET /version
+func (c *Client) GetVersion(ctx context.Context) (*VersionResponse, error) {
+	var out VersionResponse
+	if err := c.call(ctx, "GET", "/version", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhookDeliveriesParams holds the parameters of GetWebhookDeliveries. Parameters left at their zero value are not sent.
+type GetWebhookDeliveriesParams struct {
+	SubscriptionID int    // Subscription ID
+	EventType      string // Event type
+	Status         string // Status (pending, succeeded, failed)
+	Sort           string // Sort keys, comma separated, - prefix for descending (id, created_at, updated_at, attempts, next_attempt_at). Defaults to -created_at
+	Page           int    // Page number (default 1)
+	PerPage        int    // Items per page (default 25, max 100)
+}
+
+// GetWebhookDeliveries lists webhook deliveries for debugging integrations
+//
+// List webhook deliveries with their attempts, the endpoint's last response and the last error. Use
+// status=failed to find deliveries that were given up (Admin only).
+//
+// GET /api/webhooks/deliveries
+func (c *Client) GetWebhookDeliveries(ctx context.Context, params *GetWebhookDeliveriesParams) (*PaginatedResponse[[]WebhookDelivery], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.SubscriptionID != 0 {
+			query.Set("subscription_id", strconv.Itoa(params.SubscriptionID))
+		}
+		if params.EventType != "" {
+			query.Set("event_type", params.EventType)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]WebhookDelivery]
+	if err := c.call(ctx, "GET", "/api/webhooks/deliveries", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhookSubscriptions lists webhook subscriptions
+//
+// List webhook subscriptions. Signing secrets are not returned (Admin only).
+//
+// GET /api/webhooks
+func (c *Client) GetWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	var out []WebhookSubscription
+	err := c.call(ctx, "GET", "/api/webhooks", nil, nil, &out)
+	return out, err
+}
+
+// GetWorkSchedules lists the configured work schedules
+//
+// List the work schedules used to flag late and absent days.
+//
+// GET /api/work-schedules
+func (c *Client) GetWorkSchedules(ctx context.Context) ([]WorkSchedule, error) {
+	var out []WorkSchedule
+	err := c.call(ctx, "GET", "/api/work-schedules", nil, nil, &out)
+	return out, err
+}
+
+// ImportClockEventsParams holds the parameters of ImportClockEvents. Parameters left at their zero value are not sent.
+type ImportClockEventsParams struct {
+	File *File // CSV file with clock events (required)
+}
+
+// ImportClockEvents imports clock events from a CSV file
+//
+// Import clock events exported from attendance terminals, for terminals that cannot push them. The
+// file has device_id, badge and timestamp columns and an optional direction column (in or out).
+// device_id is the ID the terminal was registered with, and timestamps are RFC3339 or YYYY-MM-DD
+// HH:MM:SS in server time. Events are applied in time order whatever the order of the file, events
+// already received are counted as duplicates, and events whose badge matches no employee's badge or
+// employee number are kept as unmatched. Invalid rows are reported by line and column (Admin only).
+//
+// POST /api/admin/attendance/clock-events/import
+func (c *Client) ImportClockEvents(ctx context.Context, params *ImportClockEventsParams) (*ClockEventsResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+	}
+	var out ClockEventsResponse
+	if err := c.call(ctx, "POST", "/api/admin/attendance/clock-events/import", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportEmploymentDetailsParams holds the parameters of ImportEmploymentDetails. Parameters left at their zero value are not sent.
+type ImportEmploymentDetailsParams struct {
+	File *File // CSV file with employment details (required)
+}
+
+// ImportEmploymentDetails creates or updates employment details from a CSV file
+//
+// Create or update employment details from a CSV file laid out like the template. Rows are keyed by
+// nrc or employee_number; only the columns present are imported and empty cells leave the stored value
+// unchanged. New details default to full_time and active. Each row is imported on its own, and rows
+// with an error are reported by line and column without affecting the others (Admin only).
+//
+// POST /api/employees/employment/bulk
+func (c *Client) ImportEmploymentDetails(ctx context.Context, params *ImportEmploymentDetailsParams) (*ImportResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+	}
+	var out ImportResponse
+	if err := c.call(ctx, "POST", "/api/employees/employment/bulk", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportHRIS creates or updates employees from records in the HRIS interchange schema
+//
+// Create or update employees, their employment details and leave balances from records in the HRIS
+// interchange schema, or in the field names of a saved mapping. Records are matched to employees by
+// nrc, or by employee_number when there is no nrc; a record for an NRC not on file creates the
+// employee, with default_password. Only the fields present are imported. Leave balances are set as
+// initial balances for their as_of month, and accrual carries on from them. Each record is imported on
+// its own, and records with an error are reported by position and field without affecting the others.
+// With dry_run, every record is checked and nothing is saved (Admin only).
+//
+// POST /api/admin/hris/import
+func (c *Client) ImportHRIS(ctx context.Context, request HRISImportRequest) (*HRISImportResponse, error) {
+	var out HRISImportResponse
+	if err := c.call(ctx, "POST", "/api/admin/hris/import", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportHolidays imports public holidays from the holiday provider now
+//
+// Import the public holidays of the organization's holiday countries from Nager.Date now instead of
+// waiting for the monthly import. New holidays are added pending review; holidays already in the
+// calendar, including changed and rejected ones, are left alone. Holidays only observed in part of a
+// country are skipped (Admin only).
+//
+// POST /api/admin/holidays/import
+func (c *Client) ImportHolidays(ctx context.Context, request *ImportHolidaysRequest) ([]HolidayImportResult, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out []HolidayImportResult
+	err := c.call(ctx, "POST", "/api/admin/holidays/import", nil, body, &out)
+	return out, err
+}
+
+// ImportIdentityInformationParams holds the parameters of ImportIdentityInformation. Parameters left at their zero value are not sent.
+type ImportIdentityInformationParams struct {
+	File *File // CSV file with identity information (required)
+}
+
+// ImportIdentityInformation creates or updates identity information from a CSV file
+//
+// Create or update identity and contact information from a CSV file laid out like the template. Rows
+// are keyed by nrc or employee_number; only the columns present are imported and empty cells leave the
+// stored value unchanged. Each row is imported on its own, and rows with an error are reported by line
+// and column without affecting the others (Admin only).
+//
+// POST /api/employees/identity/bulk
+func (c *Client) ImportIdentityInformation(ctx context.Context, params *ImportIdentityInformationParams) (*ImportResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+	}
+	var out ImportResponse
+	if err := c.call(ctx, "POST", "/api/employees/identity/bulk", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportLeaveBalanceAdjustmentsParams holds the parameters of ImportLeaveBalanceAdjustments. Parameters left at their zero value are not sent.
+type ImportLeaveBalanceAdjustmentsParams struct {
+	File   *File  // CSV file with nrc or employee_number, days and reason columns (required)
+	Reason string // Reason of the rows that do not give one
+	DryRun bool   // Check every row without saving anything
+}
+
+// ImportLeaveBalanceAdjustments adjusts the annual leave balances of many employees from a CSV file
+//
+// Adjust the annual leave balances of many employees from a CSV file with nrc or employee_number, days
+// and reason columns, as the bulk adjustment does. Rows with an error are reported by line and column
+// without affecting the others. The reason form field is used for rows that leave it empty. With
+// dry_run, every row is checked and nothing is saved (Admin only).
+//
+// POST /api/hr/employees/annual-leave-balances/adjust/import
+func (c *Client) ImportLeaveBalanceAdjustments(ctx context.Context, params *ImportLeaveBalanceAdjustmentsParams) (*BulkAdjustLeaveBalancesResponse, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+		if params.Reason != "" {
+			form.set("reason", params.Reason)
+		}
+		if params.DryRun {
+			form.set("dry_run", "true")
+		}
+	}
+	var out BulkAdjustLeaveBalancesResponse
+	if err := c.call(ctx, "POST", "/api/hr/employees/annual-leave-balances/adjust/import", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// LiveHealth reports that the process is running
+//
+// Returns 200 while the process is able to serve requests. It does not check dependencies, so a
+// database outage does not get the pod restarted.
+//
+// GET /health/live
+func (c *Client) LiveHealth(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.call(ctx, "GET", "/health/live", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Login authenticates an employee/manager with NRC and password
+//
+// Authenticate employee or manager with NRC and password, returns JWT token.
+//
+// POST /auth/login
+func (c *Client) Login(ctx context.Context, request LoginRequest) (*AuthResponse, error) {
+	var out AuthResponse
+	if err := c.call(ctx, "POST", "/auth/login", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MarkNotificationRead marks one of the current user's notifications as read
+//
+// Mark one of the current user's notifications as read.
+//
+// PUT /api/notifications/{id}/read
+func (c *Client) MarkNotificationRead(ctx context.Context, id uint) (*Notification, error) {
+	var out Notification
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/notifications/%d/read", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PauseScheduledJob pauses a background job
+//
+// Stop a background job from running on its schedule or at startup, on every server, until it is
+// resumed. A run in progress finishes, and the job can still be run by hand (Admins of the default
+// organization only).
+//
+// POST /api/admin/scheduled-jobs/{name}/pause
+func (c *Client) PauseScheduledJob(ctx context.Context, name string) (*JobStatus, error) {
+	var out JobStatus
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/scheduled-jobs/%s/pause", url.PathEscape(name)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PreviewEmailTemplate fills in an email template without sending it
+//
+// Fill in an email template with the example values of its category's placeholders, or the values
+// given, and return the subject and bodies as they would be sent. Placeholders left blank are listed
+// under missing (Admin only).
+//
+// POST /api/admin/email-templates/{id}/preview
+func (c *Client) PreviewEmailTemplate(ctx context.Context, id uint, request *EmailTemplatePreviewRequest) (*RenderedEmail, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out RenderedEmail
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/email-templates/%d/preview", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PreviewEmployeeAnonymization shows what anonymizing a former employee would scrub
+//
+// Show what anonymizing a former employee would scrub, and the confirmation to send to POST
+// /api/admin/employees/{id}/anonymize. Nothing is changed (Admin only).
+//
+// GET /api/admin/employees/{id}/anonymization
+func (c *Client) PreviewEmployeeAnonymization(ctx context.Context, id uint) (*AnonymizationPreviewResponse, error) {
+	var out AnonymizationPreviewResponse
+	if err := c.call(ctx, "GET", fmt.Sprintf("/api/admin/employees/%d/anonymization", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessAbsencesParams holds the parameters of ProcessAbsences. Parameters left at their zero value are not sent.
+type ProcessAbsencesParams struct {
+	Date string // Date (YYYY-MM-DD)
+}
+
+// ProcessAbsences runs the absence marking job on demand
+//
+// Mark employees who were scheduled to work but never clocked in as absent, or on leave when they have
+// approved leave. Defaults to yesterday (Manager/Admin only).
+//
+// POST /api/attendance/process-absences
+func (c *Client) ProcessAbsences(ctx context.Context, params *ProcessAbsencesParams) (*AttendanceAbsenceResult, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Date != "" {
+			query.Set("date", params.Date)
+		}
+	}
+	var out AttendanceAbsenceResult
+	if err := c.call(ctx, "POST", "/api/attendance/process-absences", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessComplianceExpiry runs the compliance expiry job on demand
+//
+// Mark lapsed compliance records as expired and send due reminders now instead of waiting for the
+// daily job (Manager/Admin only).
+//
+// POST /api/compliance/process-expiry
+func (c *Client) ProcessComplianceExpiry(ctx context.Context) (*ComplianceExpiryResult, error) {
+	var out ComplianceExpiryResult
+	if err := c.call(ctx, "POST", "/api/compliance/process-expiry", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessMonthlyAccrualsParams holds the parameters of ProcessMonthlyAccruals. Parameters left at their zero value are not sent.
+type ProcessMonthlyAccrualsParams struct {
+	Month string // Month to process (YYYY-MM) - deprecated, use request body
+}
+
+// ProcessMonthlyAccruals processes leave accruals for employees for a specific month
+//
+// Process leave accruals for all employees or selected employees for a specific month: the accrual
+// periods of the annual leave type's accrual frequency that start in the month (Manager/Admin only).
+//
+// POST /api/hr/leaves/process-accruals
+func (c *Client) ProcessMonthlyAccruals(ctx context.Context, request *ProcessAccrualsRequest, params *ProcessMonthlyAccrualsParams) (*MessageResponse, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.Month != "" {
+			query.Set("month", params.Month)
+		}
+	}
+	var out MessageResponse
+	if err := c.call(ctx, "POST", "/api/hr/leaves/process-accruals", query, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessYearEndCarryOver processes carry-over for all employees at year-end
+//
+// Process carry-over for all employees from a leave year into the next. Leave years start in the month
+// of the leave_year_start_month setting and are named by the calendar year they start in, so with an
+// April start from_year 2024 carries over what was left of April 2024 to March 2025 (HR/Admin only).
+//
+// POST /api/hr/leaves/process-carryover
+func (c *Client) ProcessYearEndCarryOver(ctx context.Context, request ProcessYearEndCarryOverRequest) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "POST", "/api/hr/leaves/process-carryover", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReadyHealth reports whether the API's dependencies are usable
+//
+// Checks database connectivity, that document storage is writable and that no migrations are pending.
+// Returns 503 with the failing checks when any check fails, so traffic is held back until the
+// dependencies recover.
+//
+// GET /health/ready
+func (c *Client) ReadyHealth(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.call(ctx, "GET", "/health/ready", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RecordExitInterview records the exit interview for an employee's offboarding
+//
+// Record the exit interview for an employee who has an offboarding process, with answers to the chosen
+// question set and the reason for leaving. Reasons: career_growth, compensation, management,
+// work_life_balance, culture, relocation, personal, retirement, contract_end, dismissal, other (Admin
+// only).
+//
+// POST /api/employees/{id}/offboarding/exit-interview
+func (c *Client) RecordExitInterview(ctx context.Context, id uint, request RecordExitInterviewRequest) (*ExitInterview, error) {
+	var out ExitInterview
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/offboarding/exit-interview", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RecordLeaveTaken records leave taken by an employee (Admin only)
+//
+// Admin records actual leave taken for an employee (no approval workflow).
+//
+// POST /api/admin/leave-taken
+func (c *Client) RecordLeaveTaken(ctx context.Context, request RecordLeaveTakenRequest) (*LeaveTaken, error) {
+	var out LeaveTaken
+	if err := c.call(ctx, "POST", "/api/admin/leave-taken", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RecordTrainingAttendance records whether an enrolled employee attended
+//
+// Mark an enrolled employee as attended or a no-show (Manager/Admin only).
+//
+// PUT /api/training/enrollments/{id}/attendance
+func (c *Client) RecordTrainingAttendance(ctx context.Context, id uint, request TrainingAttendanceRequest) (*TrainingEnrollment, error) {
+	var out TrainingEnrollment
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/training/enrollments/%d/attendance", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Register creates a new employee account
+//
+// Create a new employee account.
+//
+// POST /auth/register
+func (c *Client) Register(ctx context.Context, request RegisterRequest) (*AuthResponse, error) {
+	var out AuthResponse
+	if err := c.call(ctx, "POST", "/auth/register", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RegisterDevice registers a device of the current user for push notifications
+//
+// Register the mobile app on a device, with the token FCM or APNs issued it, so the current user's
+// notifications are pushed to it. Call it on every app start and whenever the token changes;
+// registering a known token again updates it, and moves it to the current user if another user signed
+// in on the device before.
+//
+// POST /api/notifications/devices
+func (c *Client) RegisterDevice(ctx context.Context, request RegisterDeviceRequest) (*DeviceToken, error) {
+	var out DeviceToken
+	if err := c.call(ctx, "POST", "/api/notifications/devices", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectAttendanceCorrection rejects a correction
+//
+// Reject an attendance correction (Employee's manager or Admin).
+//
+// PUT /api/attendance/corrections/{id}/reject
+func (c *Client) RejectAttendanceCorrection(ctx context.Context, id uint, request *ReviewAttendanceCorrectionRequest) (*AttendanceCorrection, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out AttendanceCorrection
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/attendance/corrections/%d/reject", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectHeadcountRequest rejects a headcount request
+//
+// Reject a pending headcount request (Admin only).
+//
+// PUT /api/headcount/requests/{id}/reject
+func (c *Client) RejectHeadcountRequest(ctx context.Context, id uint, request *ReviewHeadcountRequestRequest) (*HeadcountRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out HeadcountRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/headcount/requests/%d/reject", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectLeave rejects a leave request
+//
+// Reject a pending leave request with reason (Manager/Admin only).
+//
+// PUT /api/leaves/{id}/reject
+func (c *Client) RejectLeave(ctx context.Context, id uint, request RejectLeaveRequest) (*Leave, error) {
+	var out Leave
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/leaves/%d/reject", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectRemoteWork rejects a remote work request
+//
+// Reject a pending remote work request (Employee's manager or Admin).
+//
+// PUT /api/remote-work/{id}/reject
+func (c *Client) RejectRemoteWork(ctx context.Context, id uint, request *ReviewRemoteWorkRequest) (*RemoteWorkRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out RemoteWorkRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/remote-work/%d/reject", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectShiftSwap rejects a shift swap
+//
+// Reject a shift swap (Requester's manager or Admin).
+//
+// PUT /api/shifts/swaps/{id}/reject
+func (c *Client) RejectShiftSwap(ctx context.Context, id uint, request *ReviewShiftSwapRequest) (*ShiftSwapRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out ShiftSwapRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/shifts/swaps/%d/reject", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectTransferRequest rejects a pending transfer request
+//
+// Reject a pending transfer. Only the receiving manager or an admin can reject (Manager/Admin only).
+//
+// PUT /api/transfers/{id}/reject
+func (c *Client) RejectTransferRequest(ctx context.Context, id uint, request *ReviewTransferRequestRequest) (*TransferRequest, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out TransferRequest
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/transfers/%d/reject", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReleaseLegalHold releases a legal hold
+//
+// Release a legal hold, so the employee's records are purged again by the retention policies unless
+// another hold is active (Admin only).
+//
+// POST /api/admin/legal-holds/{id}/release
+func (c *Client) ReleaseLegalHold(ctx context.Context, id uint) (*LegalHold, error) {
+	var out LegalHold
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/legal-holds/%d/release", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RematchClockEvents matches unmatched clock events to employees again
+//
+// Match unmatched clock events to employees again, after badge numbers have been assigned, and apply
+// those that now match to attendance (Admin only).
+//
+// POST /api/admin/attendance/clock-events/rematch
+func (c *Client) RematchClockEvents(ctx context.Context) (*ClockEventCounts, error) {
+	var out ClockEventCounts
+	if err := c.call(ctx, "POST", "/api/admin/attendance/clock-events/rematch", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RemoveEmployeeCertification removes a certification held by an employee
+//
+// Remove a certification record from an employee.
+//
+// DELETE /api/employees/{id}/certifications/{certification_id}
+func (c *Client) RemoveEmployeeCertification(ctx context.Context, id uint, certificationID uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/employees/%d/certifications/%d", id, certificationID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RemoveEmployeeSkill removes a skill from an employee
+//
+// Remove a skill assignment from an employee.
+//
+// DELETE /api/employees/{id}/skills/{skill_id}
+func (c *Client) RemoveEmployeeSkill(ctx context.Context, id uint, skillID uint) (*MessageResponse, error) {
+	var out MessageResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/employees/%d/skills/%d", id, skillID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ResendDeadLetter re-sends the message of a dead letter
+//
+// Put the email, text message or webhook delivery back in the delivery queue with all its attempts and
+// send it now. Emails and text messages go to the recipient's current address or mobile number, so a
+// bounced message can be re-sent after correcting it. The dead letter is closed; if the message is
+// given up again, a new dead letter is opened. Returns the dead letter with the message after the
+// attempt (Admin only).
+//
+// POST /api/admin/dead-letters/{id}/resend
+func (c *Client) ResendDeadLetter(ctx context.Context, id uint) (*DeadLetter, error) {
+	var out DeadLetter
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/dead-letters/%d/resend", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ResetSetting restores the default of a runtime setting
+//
+// Restore a setting to its default, taking effect like an update (Admin only).
+//
+// DELETE /api/admin/settings/{key}
+func (c *Client) ResetSetting(ctx context.Context, key string) (*SettingResponse, error) {
+	var out SettingResponse
+	if err := c.call(ctx, "DELETE", fmt.Sprintf("/api/admin/settings/%s", url.PathEscape(key)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RestoreBackupParams holds the parameters of RestoreBackup. Parameters left at their zero value are not sent.
+type RestoreBackupParams struct {
+	File    *File  // Backup bundle to upload
+	Name    string // Name of a bundle in BACKUPS_PATH
+	Confirm bool   // Must be true (required)
+}
+
+// RestoreBackup starts restoring a backup bundle
+//
+// Replace all data and document files with a backup bundle, either uploaded as file or named by name
+// from GET /api/admin/backups. Meant for a fresh instance: everything currently stored is replaced,
+// including the accounts, so sign in again with an account from the backup afterwards. The restore
+// runs in the background in a single transaction and leaves the instance unchanged if the bundle fails
+// its checks; follow it with GET /api/admin/backup-jobs/{id}. Set confirm to true to proceed (Admins
+// of the default organization only).
+//
+// POST /api/admin/backups/restore
+func (c *Client) RestoreBackup(ctx context.Context, params *RestoreBackupParams) (*Job, error) {
+	query := url.Values{}
+	form := &multipartForm{}
+	if params != nil {
+		if params.File != nil {
+			form.setFile("file", params.File)
+		}
+		if params.Name != "" {
+			form.set("name", params.Name)
+		}
+		if params.Confirm {
+			form.set("confirm", "true")
+		}
+	}
+	var out Job
+	if err := c.call(ctx, "POST", "/api/admin/backups/restore", query, form, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RestoreEmployee restores a soft-deleted employee
+//
+// Restore a soft-deleted employee. Fails with 409 if the employee's NRC, email, username or employee
+// number has since been reused by another employee (Admin only).
+//
+// POST /api/admin/employees/{id}/restore
+func (c *Client) RestoreEmployee(ctx context.Context, id uint) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/employees/%d/restore", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ResumeScheduledJob resumes a paused background job
+//
+// Let a paused background job run on its schedule again, on every server (Admins of the default
+// organization only).
+//
+// POST /api/admin/scheduled-jobs/{name}/resume
+func (c *Client) ResumeScheduledJob(ctx context.Context, name string) (*JobStatus, error) {
+	var out JobStatus
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/scheduled-jobs/%s/resume", url.PathEscape(name)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RetryWebhookDelivery re-sends a webhook delivery now
+//
+// Re-send a pending or failed delivery now and return it with the endpoint's response. A delivery that
+// fails again is given up if it has used all its attempts (Admin only).
+//
+// POST /api/webhooks/deliveries/{id}/retry
+func (c *Client) RetryWebhookDelivery(ctx context.Context, id uint) (*WebhookDelivery, error) {
+	var out WebhookDelivery
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/webhooks/deliveries/%d/retry", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReviewHolidays activates or rejects public holidays
+//
+// Activate imported holidays once checked, or reject those the organization does not observe. Rejected
+// holidays are kept so later imports do not add them again, and can be activated later (Admin only).
+//
+// POST /api/admin/holidays/review
+func (c *Client) ReviewHolidays(ctx context.Context, request ReviewHolidaysRequest) (*ReviewHolidaysResponse, error) {
+	var out ReviewHolidaysResponse
+	if err := c.call(ctx, "POST", "/api/admin/holidays/review", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RevokeEmploymentLetter revokes one of the current user's employment letters
+//
+// Revoke one of your employment letters, so its verification code no longer confirms it.
+//
+// POST /api/me/employment-letters/{id}/revoke
+func (c *Client) RevokeEmploymentLetter(ctx context.Context, id uint) (*EmploymentLetter, error) {
+	var out EmploymentLetter
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/me/employment-letters/%d/revoke", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RunRetentionPolicies purges the records past their retention policy now
+//
+// Purge the records past the enabled retention policies now instead of waiting for the daily run at
+// 03:30. Purged records and files cannot be recovered (Admin only).
+//
+// POST /api/admin/retention-policies/run
+func (c *Client) RunRetentionPolicies(ctx context.Context) ([]RetentionResult, error) {
+	var out []RetentionResult
+	err := c.call(ctx, "POST", "/api/admin/retention-policies/run", nil, nil, &out)
+	return out, err
+}
+
+// RunScheduledJob runs a background job now
+//
+// Start a run of a background job on this server now, in the background, even when the job is paused.
+// Follow it with GET /api/admin/scheduled-jobs/{name} (Admins of the default organization only).
+//
+// POST /api/admin/scheduled-jobs/{name}/run
+func (c *Client) RunScheduledJob(ctx context.Context, name string) (*JobStatus, error) {
+	var out JobStatus
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/scheduled-jobs/%s/run", url.PathEscape(name)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchDocumentContentsParams holds the parameters of SearchDocumentContents. Parameters left at their zero value are not sent.
+type SearchDocumentContentsParams struct {
+	Q            string // Words the text must contain. Words in double quotes must appear together, or between two words matches either, and a - before a word excludes documents with it. On MySQL and SQLite, every word must appear somewhere in the text instead (required)
+	DocumentType string // Document type filter (comma separated for several)
+	Status       string // Status filter
+	EmployeeID   int    // Only the documents of this employee
+	Sort         string // Sort keys, comma separated, - prefix for descending (id, title, document_type, expiry_date, created_at). Defaults to -created_at
+	Page         int    // Page number (default 1)
+	PerPage      int    // Items per page (default 25, max 100)
+}
+
+// SearchDocumentContents searches the text of the documents of all employees
+//
+// Search the text extracted from the files of all employees' documents, such as to find every contract
+// mentioning a clause, with the employee each belongs to and the matching passages. Text is extracted
+// in the background after upload when TEXT_EXTRACTOR is set; documents show their progress in
+// content_status. Confidential documents are only searched for admins (Manager/Admin only).
+//
+// GET /api/hr/documents/content-search
+func (c *Client) SearchDocumentContents(ctx context.Context, params *SearchDocumentContentsParams) (*PaginatedResponse[[]DocumentContentMatch], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Q != "" {
+			query.Set("q", params.Q)
+		}
+		if params.DocumentType != "" {
+			query.Set("document_type", params.DocumentType)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.EmployeeID != 0 {
+			query.Set("employee_id", strconv.Itoa(params.EmployeeID))
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]DocumentContentMatch]
+	if err := c.call(ctx, "GET", "/api/hr/documents/content-search", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchDocumentsParams holds the parameters of SearchDocuments. Parameters left at their zero value are not sent.
+type SearchDocumentsParams struct {
+	Search       string // Search term matching the start of words in the title, description and tags
+	DocumentType string // Document type filter (comma separated for several)
+	Status       string // Status filter
+	Sort         string // Sort keys, comma separated, - prefix for descending (id, title, document_type, expiry_date, created_at). Defaults to -created_at
+	Page         int    // Page number (default 1)
+	PerPage      int    // Items per page (default 25, max 100)
+}
+
+// SearchDocuments searches the documents of all employees
+//
+// Search the documents of all employees by title, description and tags, with the employee each belongs
+// to (Admin only).
+//
+// GET /api/admin/documents
+func (c *Client) SearchDocuments(ctx context.Context, params *SearchDocumentsParams) (*PaginatedResponse[[]Document], error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Search != "" {
+			query.Set("search", params.Search)
+		}
+		if params.DocumentType != "" {
+			query.Set("document_type", params.DocumentType)
+		}
+		if params.Status != "" {
+			query.Set("status", params.Status)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Page != 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+		}
+	}
+	var out PaginatedResponse[[]Document]
+	if err := c.call(ctx, "GET", "/api/admin/documents", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchEmployeesBySkillParams holds the parameters of SearchEmployeesBySkill. Parameters left at their zero value are not sent.
+type SearchEmployeesBySkillParams struct {
+	SkillID        int    // Skill ID
+	Skill          string // Skill name (partial match)
+	MinProficiency string // Minimum proficiency (beginner, intermediate, advanced, expert)
+	Department     string // Department filter
+	IncludeExpired bool   // Include expired skill assignments
+}
+
+// SearchEmployeesBySkill finds employees holding a skill
+//
+// Find employees who have a skill, optionally at or above a minimum proficiency. Expired skill
+// assignments are excluded unless include_expired=true (Manager/Admin only).
+//
+// GET /api/skills/search
+func (c *Client) SearchEmployeesBySkill(ctx context.Context, params *SearchEmployeesBySkillParams) ([]EmployeeSkill, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.SkillID != 0 {
+			query.Set("skill_id", strconv.Itoa(params.SkillID))
+		}
+		if params.Skill != "" {
+			query.Set("skill", params.Skill)
+		}
+		if params.MinProficiency != "" {
+			query.Set("min_proficiency", params.MinProficiency)
+		}
+		if params.Department != "" {
+			query.Set("department", params.Department)
+		}
+		if params.IncludeExpired {
+			query.Set("include_expired", "true")
+		}
+	}
+	var out []EmployeeSkill
+	err := c.call(ctx, "GET", "/api/skills/search", query, nil, &out)
+	return out, err
+}
+
+// SendKudos recognises a colleague for living a company value
+//
+// Send kudos to a colleague tied to an active company value. The recipient is notified.
+//
+// POST /api/recognition/kudos
+func (c *Client) SendKudos(ctx context.Context, request SendKudosRequest) (*Kudos, error) {
+	var out Kudos
+	if err := c.call(ctx, "POST", "/api/recognition/kudos", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SendTestEmailTemplate sends an email template, filled in with example values, to check how it looks
+//
+// Fill in an email template as the preview does and send it now to the address given, or to the
+// admin's own address. The email is not recorded as a notification and is not retried (Admin only).
+//
+// POST /api/admin/email-templates/{id}/test
+func (c *Client) SendTestEmailTemplate(ctx context.Context, id uint, request *EmailTemplateTestRequest) (*EmailTemplateTestResponse, error) {
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+	var out EmailTemplateTestResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/admin/email-templates/%d/test", id), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetEmployeeCostCenters replaces how an employee's cost is split across cost centers from a date
+//
+// Split an employee's cost across cost centers from start_date, overriding their position's split. The
+// percentages must add up to 100. The allocation in effect before start_date ends the day before, and
+// allocations starting on or after it are replaced. Empty splits end the employee's own allocation, so
+// their position's split applies again (Admin only).
+//
+// PUT /api/employees/{id}/cost-centers
+func (c *Client) SetEmployeeCostCenters(ctx context.Context, id uint, request CostCenterAllocationRequest) ([]CostCenterAllocation, error) {
+	var out []CostCenterAllocation
+	err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/cost-centers", id), nil, request, &out)
+	return out, err
+}
+
+// SetHeadcountBudget creates or replaces a headcount budget
+//
+// Create or replace the budgeted headcount for a position or department in a fiscal year (Admin only).
+//
+// POST /api/headcount/budgets
+func (c *Client) SetHeadcountBudget(ctx context.Context, request SetHeadcountBudgetRequest) (*HeadcountBudget, error) {
+	var out HeadcountBudget
+	if err := c.call(ctx, "POST", "/api/headcount/budgets", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetInitialBalance sets the initial balance for an employee (for onboarding from old system)
+// This sets the balance to an absolute value rather than adjusting it
+//
+// Set the initial/annual leave balance to a specific value (for onboarding employees from old system).
+//
+// POST /api/hr/employees/{id}/annual-leave-balance/set-initial
+func (c *Client) SetInitialBalance(ctx context.Context, id uint, request SetInitialBalanceRequest) (*AnnualLeaveBalanceResponse, error) {
+	var out AnnualLeaveBalanceResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/hr/employees/%d/annual-leave-balance/set-initial", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetMandatoryTraining makes a course mandatory for a role
+//
+// Require every employee with a role to complete a course, optionally within a number of days of their
+// hire date (Admin only).
+//
+// POST /api/training/mandatory
+func (c *Client) SetMandatoryTraining(ctx context.Context, request SetMandatoryTrainingRequest) (*MandatoryTraining, error) {
+	var out MandatoryTraining
+	if err := c.call(ctx, "POST", "/api/training/mandatory", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetPIIAccess grants or revokes an employee's access to other people's unmasked personal data
+//
+// Grant or revoke an employee's permission to see other people's NRC, date of birth and address
+// unmasked. Without it, managers and employees see these fields masked in every response, except in
+// their own records; admins always see them (Admin only).
+//
+// PUT /api/employees/{id}/pii-access
+func (c *Client) SetPIIAccess(ctx context.Context, id uint, request SetPIIAccessRequest) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/pii-access", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetPayrollAccess grants or revokes an employee's access to unmasked bank details
+//
+// Grant or revoke an employee's permission to view unmasked bank details (Admin only).
+//
+// PUT /api/employees/{id}/payroll-access
+func (c *Client) SetPayrollAccess(ctx context.Context, id uint, request SetPayrollAccessRequest) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/payroll-access", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetPositionCostCenters replaces how a position's cost is split across cost centers from a date
+//
+// Split a position's cost across cost centers from start_date. The percentages must add up to 100. The
+// allocation in effect before start_date ends the day before, and allocations starting on or after it
+// are replaced. Empty splits end the position's allocation (Admin only).
+//
+// PUT /api/positions/{id}/cost-centers
+func (c *Client) SetPositionCostCenters(ctx context.Context, id uint, request CostCenterAllocationRequest) ([]CostCenterAllocation, error) {
+	var out []CostCenterAllocation
+	err := c.call(ctx, "PUT", fmt.Sprintf("/api/positions/%d/cost-centers", id), nil, request, &out)
+	return out, err
+}
+
+// SignDocument records that an employee has signed a document generated for them
+//
+// Sign a contract or offer letter generated for you, which makes it active. Only the employee the
+// document is for can sign it.
+//
+// POST /api/employees/{id}/documents/{doc_id}/sign
+func (c *Client) SignDocument(ctx context.Context, id uint, docID uint) (*Document, error) {
+	var out Document
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/documents/%d/sign", id, docID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StreamEventsParams holds the parameters of StreamEvents. Parameters left at their zero value are not sent.
+type StreamEventsParams struct {
+	Token string // JWT, for clients that cannot set the Authorization header
+}
+
+// StreamEvents streams real-time events to the current user using server-sent events
+//
+// Server-sent events stream for the current user. Managers and admins receive leave_submitted,
+// leave_approved, leave_rejected and leave_cancelled events for all leave requests in their
+// organization; employees receive leave_approved and leave_rejected for their own requests; everyone
+// receives notification events for their in-app notifications. Each event's data is JSON with type,
+// data and occurred_at. Clients that cannot set headers, such as a browser EventSource, may pass the
+// JWT in the token query parameter.
+//
+// GET /api/events
+func (c *Client) StreamEvents(ctx context.Context, params *StreamEventsParams) (*Event, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			query.Set("token", params.Token)
+		}
+	}
+	var out Event
+	if err := c.call(ctx, "GET", "/api/events", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SubmitGrievance raises a confidential grievance
+//
+// Raise a confidential grievance. Anonymous grievances never reveal the submitter to HR or in reports;
+// the submitter can still track them.
+//
+// POST /api/grievances
+func (c *Client) SubmitGrievance(ctx context.Context, request SubmitGrievanceRequest) (*GrievanceResponse, error) {
+	var out GrievanceResponse
+	if err := c.call(ctx, "POST", "/api/grievances", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TestDevice pushes a test notification to one of the current user's devices
+//
+// Push a test notification to a device of the current user straight away, to check the app receives
+// pushes. A token the push service reports as no longer valid is unregistered.
+//
+// POST /api/notifications/devices/{id}/test
+func (c *Client) TestDevice(ctx context.Context, id uint) (*DeviceToken, error) {
+	var out DeviceToken
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/notifications/devices/%d/test", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TestWebhookSubscription sends a ping event to a webhook endpoint
+//
+// Send a signed ping event to the endpoint now and return the delivery with the endpoint's response.
+// Test deliveries are logged but not retried (Admin only).
+//
+// POST /api/webhooks/{id}/test
+func (c *Client) TestWebhookSubscription(ctx context.Context, id uint) (*WebhookDelivery, error) {
+	var out WebhookDelivery
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/webhooks/%d/test", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TransferPosition ends the employee's current primary assignment and creates a new one atomically
+//
+// End the current primary position assignment and create a new primary assignment in a single
+// transaction. Updates the employee's current position (Manager/Admin only).
+//
+// POST /api/employees/{id}/positions/transfer
+func (c *Client) TransferPosition(ctx context.Context, id uint, request TransferPositionRequest) (*TransferPositionResponse, error) {
+	var out TransferPositionResponse
+	if err := c.call(ctx, "POST", fmt.Sprintf("/api/employees/%d/positions/transfer", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateAttendanceDevice updates an attendance terminal
+//
+// Rename or move a terminal, deactivate it so its events are refused, or rotate its key (Admin only).
+//
+// PUT /api/admin/attendance/devices/{id}
+func (c *Client) UpdateAttendanceDevice(ctx context.Context, id uint, request AttendanceDeviceRequest) (*AttendanceDeviceResponse, error) {
+	var out AttendanceDeviceResponse
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/attendance/devices/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateCalendarConnection changes which leaves a connected calendar shows
+//
+// Choose whether a connected calendar shows the current user's own approved leaves and those of their
+// direct reports. Leaves turned on are added from today onwards, and the events of leaves turned off
+// are removed.
+//
+// PUT /api/calendar-connections/{id}
+func (c *Client) UpdateCalendarConnection(ctx context.Context, id uint, request UpdateCalendarConnectionRequest) (*CalendarConnection, error) {
+	var out CalendarConnection
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/calendar-connections/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateCompanyValue updates or deactivates a company value
+//
+// Rename, describe or deactivate a company value. Existing kudos keep their value (Admin only).
+//
+// PUT /api/recognition/values/{id}
+func (c *Client) UpdateCompanyValue(ctx context.Context, id uint, request CompanyValueRequest) (*CompanyValue, error) {
+	var out CompanyValue
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/recognition/values/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateCostCenter updates a cost center
+//
+// Change a cost center's code, name, description or whether it is active. Inactive cost centers stay
+// on existing allocations but cannot be allocated to anew (Admin only).
+//
+// PUT /api/admin/cost-centers/{id}
+func (c *Client) UpdateCostCenter(ctx context.Context, id uint, request CostCenterRequest) (*CostCenter, error) {
+	var out CostCenter
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/cost-centers/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateDocumentTemplate replaces a document template
+//
+// Replace a document template. Documents already generated from it are not changed (Admin only).
+//
+// PUT /api/admin/document-templates/{id}
+func (c *Client) UpdateDocumentTemplate(ctx context.Context, id uint, request DocumentTemplateRequest) (*DocumentTemplate, error) {
+	var out DocumentTemplate
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/document-templates/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateEducation updates an education record
+//
+// Update an education record. Changing a verified record resets it to pending verification.
+//
+// PUT /api/employees/{id}/education/{education_id}
+func (c *Client) UpdateEducation(ctx context.Context, id uint, educationID uint, request EducationRequest) (*Education, error) {
+	var out Education
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/education/%d", id, educationID), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateEmailTemplate replaces an email template
+//
+// Replace an email template. Emails already sent or queued keep their wording (Admin only).
+//
+// PUT /api/admin/email-templates/{id}
+func (c *Client) UpdateEmailTemplate(ctx context.Context, id uint, request EmailTemplateRequest) (*EmailTemplate, error) {
+	var out EmailTemplate
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/email-templates/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateEmployee updates an employee
+//
+// Update an employee's information (Admin only).
+//
+// PUT /api/employees/{id}
+func (c *Client) UpdateEmployee(ctx context.Context, id uint, request UpdateEmployeeRequest) (*Employee, error) {
+	var out Employee
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateExitQuestionSet updates an exit interview question set
+//
+// Rename, describe or deactivate a question set, or replace its questions. Questions cannot be
+// replaced once an interview has used the set; create a new set instead (Admin only).
+//
+// PUT /api/exit-interviews/question-sets/{id}
+func (c *Client) UpdateExitQuestionSet(ctx context.Context, id uint, request ExitQuestionSetRequest) (*ExitQuestionSet, error) {
+	var out ExitQuestionSet
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/exit-interviews/question-sets/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateGrievanceStage moves a grievance to a later stage
+//
+// Move a grievance forward to acknowledged, investigating or resolved. A resolution is required when
+// resolving. The submitter is notified (Admin only).
+//
+// PUT /api/grievances/{id}/stage
+func (c *Client) UpdateGrievanceStage(ctx context.Context, id uint, request UpdateGrievanceStageRequest) (*GrievanceResponse, error) {
+	var out GrievanceResponse
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/grievances/%d/stage", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateHRISMapping replaces an HRIS field mapping
+//
+// Replace the name, description and fields of an HRIS field mapping (Admin only).
+//
+// PUT /api/admin/hris/mappings/{id}
+func (c *Client) UpdateHRISMapping(ctx context.Context, id uint, request HRISMappingRequest) (*HRISMapping, error) {
+	var out HRISMapping
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/hris/mappings/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateHoliday changes a public holiday
+//
+// Change a holiday's date or name, such as when it is observed on another day. Changes to imported
+// holidays are kept by later imports (Admin only).
+//
+// PUT /api/admin/holidays/{id}
+func (c *Client) UpdateHoliday(ctx context.Context, id uint, request UpdateHolidayRequest) (*PublicHoliday, error) {
+	var out PublicHoliday
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/holidays/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateHolidayCountries sets the countries whose public holidays are imported
+//
+// Replace the countries whose public holidays the organization imports each month. Holidays already
+// imported are kept (Admin only).
+//
+// PUT /api/admin/holiday-countries
+func (c *Client) UpdateHolidayCountries(ctx context.Context, request HolidayCountriesRequest) ([]HolidayCountry, error) {
+	var out []HolidayCountry
+	err := c.call(ctx, "PUT", "/api/admin/holiday-countries", nil, request, &out)
+	return out, err
+}
+
+// UpdateLeaveForEmployee updates a leave record (Admin only)
+//
+// Admin updates a leave record for any employee (Admin only).
+//
+// PUT /api/hr/leaves/{id}
+func (c *Client) UpdateLeaveForEmployee(ctx context.Context, id uint, request UpdateLeaveRequest) (*Leave, error) {
+	var out Leave
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/hr/leaves/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateLeaveType updates an existing leave type
+//
+// Update an existing leave type. Changing its accrual frequency rebuilds the accruals made on the old
+// schedule the next time balances are read; initial balances and manual adjustments are kept (Admin
+// only).
+//
+// PUT /api/leave-types/{id}
+func (c *Client) UpdateLeaveType(ctx context.Context, id uint, request CreateLeaveTypeRequest) (*LeaveType, error) {
+	var out LeaveType
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/leave-types/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateMyPreferences switches the current user's notification channels and groups on or off
+//
+// Switch notification channels (in-app, email, SMS and push) and groups of notifications (approvals,
+// reminders and announcements) on or off for the current user. A channel switched off carries none of
+// their notifications, whatever the preference of the category; a group switched off is not sent on
+// any channel. Password change notices are in no group and are always sent on the channels left on.
+//
+// PUT /api/me/preferences
+func (c *Client) UpdateMyPreferences(ctx context.Context, request UpdateMyPreferencesRequest) (*MyPreferencesResponse, error) {
+	var out MyPreferencesResponse
+	if err := c.call(ctx, "PUT", "/api/me/preferences", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateNationalIDFormat changes a country's national ID format
+//
+// Replace a national ID format. The example must pass the new format. NRCs already stored are not
+// changed (Admin only).
+//
+// PUT /api/admin/national-id-formats/{id}
+func (c *Client) UpdateNationalIDFormat(ctx context.Context, id uint, request NationalIDFormatRequest) (*NationalIDFormat, error) {
+	var out NationalIDFormat
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/national-id-formats/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateNotificationPreferences sets the current user's notification channels
+//
+// Choose whether notifications of some categories also reach the current user by email, by push to
+// their mobile devices and, for leave decisions and password changes, by text message. In-app
+// notifications are kept unless switched off for every category in /api/me/preferences, which also
+// switches off channels and groups of categories as a whole.
+//
+// PUT /api/notifications/preferences
+func (c *Client) UpdateNotificationPreferences(ctx context.Context, request UpdateNotificationPreferencesRequest) (*NotificationPreferencesResponse, error) {
+	var out NotificationPreferencesResponse
+	if err := c.call(ctx, "PUT", "/api/notifications/preferences", nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdatePosition updates a position
+//
+// Update an existing position (Manager/Admin only). Send the version from the last read; if the
+// position has changed since, nothing is saved and 409 is returned with the current position.
+//
+// PUT /api/positions/{id}
+func (c *Client) UpdatePosition(ctx context.Context, id uint, request Position) (*Position, error) {
+	var out Position
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/positions/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateRetentionPolicy sets the retention policy of a data category
+//
+// Set how many days the records of a data category are kept. audit_logs purges audit entries by age,
+// login_logs sign-in attempts, leave_history leave requests (with their approval trail and forms) and
+// recorded leave taken by end date, and ex_employee_documents the documents of former employees by the
+// date they left. Records of employees under legal hold are never purged. Check GET
+// /api/admin/retention-policies/report before enabling a policy (Admin only).
+//
+// PUT /api/admin/retention-policies/{category}
+func (c *Client) UpdateRetentionPolicy(ctx context.Context, category string, request RetentionPolicyRequest) (*RetentionPolicy, error) {
+	var out RetentionPolicy
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/retention-policies/%s", url.PathEscape(category)), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateSetting changes a runtime setting
+//
+// Change a setting for the whole installation. It takes effect on this server immediately and on other
+// servers sharing the database within 15 seconds, without a restart: annual_leave_days_per_month for
+// accruals processed from then on, email_notifications_enabled and muted_email_categories for
+// notifications sent from then on, cors_allowed_origins for the next browser request, and the
+// company_* settings for PDFs and exports generated from then on (Admin only).
+//
+// PUT /api/admin/settings/{key}
+func (c *Client) UpdateSetting(ctx context.Context, key string, request SettingRequest) (*SettingResponse, error) {
+	var out SettingResponse
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/admin/settings/%s", url.PathEscape(key)), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateWebhookSubscription updates a webhook subscription
+//
+// Change a subscription's URL, event types or active flag, or rotate its secret by sending a new one.
+// Deactivated subscriptions receive no new deliveries and their pending retries are given up (Admin
+// only).
+//
+// PUT /api/webhooks/{id}
+func (c *Client) UpdateWebhookSubscription(ctx context.Context, id uint, request WebhookSubscriptionRequest) (*WebhookSubscriptionResponse, error) {
+	var out WebhookSubscriptionResponse
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/webhooks/%d", id), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyEducation records HR's verification decision on an education record
+//
+// Mark an education record as verified or rejected (Manager/Admin only).
+//
+// PUT /api/employees/{id}/education/{education_id}/verify
+func (c *Client) VerifyEducation(ctx context.Context, id uint, educationID uint, request VerifyEducationRequest) (*Education, error) {
+	var out Education
+	if err := c.call(ctx, "PUT", fmt.Sprintf("/api/employees/%d/education/%d/verify", id, educationID), nil, request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyEmploymentLetter confirms an employment letter from its verification code
+//
+// Check the verification code printed on an employment letter. Returns what the letter states and
+// whether it is still valid, so banks and other third parties can compare it with the letter they were
+// given. No authentication is needed.
+//
+// GET /verify/employment/{code}
+func (c *Client) VerifyEmploymentLetter(ctx context.Context, code string) (*EmploymentVerificationResponse, error) {
+	var out EmploymentVerificationResponse
+	if err := c.call(ctx, "GET", fmt.Sprintf("/verify/employment/%s", url.PathEscape(code)), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// APIKey is an API key accepted from an internal service, with the limits on its calls
+type APIKey struct {
+	Name      string // Usage is recorded and reported under the name, never the key
+	Key       string
+	PerMinute int // Calls allowed per minute; 0 is unlimited
+	PerDay    int // Calls allowed per company calendar day; 0 is unlimited
+}
+
+// loadAPIKeys reads GRPC_API_KEYS, a comma separated list of keys each optionally named as name:key,
+// with the default limits of GRPC_API_KEY_RATE_PER_MINUTE and GRPC_API_KEY_QUOTA_PER_DAY and the
+// per key overrides of GRPC_API_KEY_LIMITS, a comma separated list of name=perMinute/perDay
+func loadAPIKeys() ([]APIKey, error) {
+	perMinute := getEnvAsInt("GRPC_API_KEY_RATE_PER_MINUTE", 0)
+	perDay := getEnvAsInt("GRPC_API_KEY_QUOTA_PER_DAY", 0)
+	if perMinute < 0 || perDay < 0 {
+		return nil, fmt.Errorf("GRPC_API_KEY_RATE_PER_MINUTE and GRPC_API_KEY_QUOTA_PER_DAY cannot be negative")
+	}
+
+	var keys []APIKey
+	byName := map[string]int{}
+	for _, entry := range getEnvAsList("GRPC_API_KEYS") {
+		key := APIKey{Key: entry, PerMinute: perMinute, PerDay: perDay}
+		if name, value, ok := strings.Cut(entry, ":"); ok {
+			key.Name, key.Key = strings.TrimSpace(name), strings.TrimSpace(value)
+			if key.Name == "" || key.Key == "" {
+				return nil, fmt.Errorf("GRPC_API_KEYS: %q must be a key or name:key", entry)
+			}
+		} else {
+			// Unnamed keys are told apart by a fingerprint, so the key itself never appears in reports
+			sum := sha256.Sum256([]byte(entry))
+			key.Name = "key-" + hex.EncodeToString(sum[:4])
+		}
+		if _, ok := byName[key.Name]; ok {
+			return nil, fmt.Errorf("GRPC_API_KEYS: %s is listed twice", key.Name)
+		}
+		byName[key.Name] = len(keys)
+		keys = append(keys, key)
+	}
+
+	for _, entry := range getEnvAsList("GRPC_API_KEY_LIMITS") {
+		name, limits, _ := strings.Cut(entry, "=")
+		minuteLimit, dayLimit, _ := strings.Cut(limits, "/")
+		minute, minuteErr := strconv.Atoi(strings.TrimSpace(minuteLimit))
+		day, dayErr := strconv.Atoi(strings.TrimSpace(dayLimit))
+		if minuteErr != nil || dayErr != nil || minute < 0 || day < 0 {
+			return nil, fmt.Errorf("GRPC_API_KEY_LIMITS: %q must be name=perMinute/perDay", entry)
+		}
+		i, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("GRPC_API_KEY_LIMITS: no key in GRPC_API_KEYS is named %s", strings.TrimSpace(name))
+		}
+		keys[i].PerMinute, keys[i].PerDay = minute, day
+	}
+	return keys, nil
+}
+
+// FindAPIKey returns the configured API key matching key
+func (c *Config) FindAPIKey(key string) (APIKey, bool) {
+	for _, apiKey := range c.GRPCAPIKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey.Key), []byte(key)) == 1 {
+			return apiKey, true
+		}
+	}
+	return APIKey{}, false
+}
@@ -1,45 +1,345 @@
 package config
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"hrms-api/models"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Timezones resolve even where the host has no zoneinfo, such as Alpine images
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBHost             string
-	DBPort             string
-	DBUser             string
-	DBPassword         string
-	DBName             string
-	JWTSecret          string
-	JWTExpirationHours int
-	Port               string
-	GinMode            string
-	DocumentsPath      string
-	MaxFileSize        int64 // in bytes
+	DBDriver              string // Database the data is kept in: postgres, mysql or sqlite
+	DBPath                string // SQLite database file, used instead of the DB_HOST settings when DBDriver is sqlite
+	DBHost                string
+	DBPort                string
+	DBUser                string
+	DBPassword            string
+	DBName                string
+	DBDSN                 string // Data source name used as is instead of DB_PATH and the DB_HOST settings, when set
+	DBMaxOpenConns        int    // Upper bound on open database connections, shared by requests and background jobs
+	DBMaxIdleConns        int    // Connections kept open when idle
+	DBConnMaxLifetime     int    // Minutes before a connection is closed and replaced; 0 keeps connections indefinitely
+	DBLogLevel            string // GORM log level: silent, error, warn or info (logs every query)
+	JWTSecret             string
+	JWTExpirationHours    int
+	TokenEncryptionKey    string // Key third-party OAuth tokens are encrypted at rest with; derived from JWTSecret when empty
+	Port                  string
+	GinMode               string
+	DocumentsPath         string
+	BackupsPath           string // Directory backup bundles are written to and uploaded bundles are kept in until restored
+	ExportsPath           string // Directory the files of export jobs are kept in until they expire
+	MaxFileSize           int64  // in bytes
+	TextExtractor         string // Engine document text is extracted with for content search: tesseract or tika; disabled when empty
+	TikaURL               string // Apache Tika server the tika extractor sends documents to
+	OCRLanguages          string // Tesseract languages scanned documents are read in, such as eng+fra
+	SMTPHost              string // Email notifications are disabled when empty
+	SMTPPort              string
+	SMTPUsername          string
+	SMTPPassword          string
+	SMTPFrom              string
+	SMSProvider           string   // Gateway SMS notifications are sent through: twilio or africastalking; disabled when empty
+	SMSAccount            string   // Twilio account SID, or Africa's Talking username
+	SMSAPIKey             string   // Twilio auth token, or Africa's Talking API key
+	SMSFrom               string   // Sender number or alphanumeric sender ID; Africa's Talking uses its shared short code when empty
+	SMSAPIURL             string   // Base URL of the gateway's API, e.g. the Africa's Talking sandbox; the provider's live API when empty
+	SMSCountryCode        string   // Calling code, such as 260, that local mobile numbers starting with 0 are sent with
+	SMSMaxAttempts        int      // Text messages still failing after this many attempts are given up
+	FCMCredentials        string   // Firebase service account key, as JSON, push notifications to Android and FCM devices are sent with; disabled when empty
+	FCMAPIURL             string   // Base URL of the FCM API, for testing; Google's when empty
+	APNsAuthKey           string   // Apple push notification token signing key (.p8 contents); push to iOS devices registered with APNs is disabled when empty
+	APNsKeyID             string   // Key ID of the APNs signing key
+	APNsTeamID            string   // Apple developer team the signing key belongs to
+	APNsTopic             string   // Bundle ID of the mobile app
+	APNsSandbox           bool     // Send to the APNs development environment, for app builds signed for development
+	APNsAPIURL            string   // Base URL of the APNs API, for testing; Apple's production or sandbox gateway when empty
+	GrievanceAckHours     int      // SLA for acknowledging a grievance
+	GrievanceSLADays      int      // SLA for resolving a grievance
+	GRPCPort              string   // gRPC server for internal services; not started when empty
+	GRPCAPIKeys           []APIKey // API keys accepted from internal services, with their usage limits
+	WebhookMaxAttempts    int      // Deliveries still failing after this many attempts are given up
+	EmailMaxAttempts      int      // Emails still failing after this many attempts are given up
+	SlackSigningSecret    string   // Signs requests from the Slack leave bot; the Slack endpoints are disabled when empty
+	TeamsWebhookSecret    string   // Base64 security token of the Teams outgoing webhook; the Teams endpoint is disabled when empty
+	PublicURL             string   // Address the API is reached at from browsers, such as https://hr.example.com; OAuth redirects come back to it and employment letters link to it
+	GoogleClientID        string   // OAuth client of the Google Calendar integration; disabled when empty
+	GoogleClientSecret    string   // Secret of the Google OAuth client
+	MicrosoftClientID     string   // OAuth client of the Outlook calendar integration, registered in Microsoft Entra ID; disabled when empty
+	MicrosoftClientSecret string   // Secret of the Microsoft OAuth client
+	MicrosoftTenant       string   // Entra tenant accounts sign in from: a tenant ID or domain, or common for any
+	CalendarReturnURL     string   // Page the browser is sent back to after connecting a calendar; a JSON response is shown when empty
+	HolidayAPIURL         string   // Base URL of the Nager.Date compatible API public holidays are imported from
+	OTLPEndpoint          string   // Traces are exported over OTLP/HTTP when set
+	ServiceName           string   // Service name reported on exported traces
+	HTTPReadTimeout       int      // Seconds allowed to read a request, including uploads
+	HTTPWriteTimeout      int      // Seconds allowed to write a response, including exports; event streams are exempt
+	HTTPIdleTimeout       int      // Seconds idle keep-alive connections stay open
+	ShutdownTimeout       int      // Seconds allowed on SIGINT/SIGTERM for in-flight requests and background jobs to finish
+	TLSCertFile           string   // Serve HTTPS with this certificate and TLSKeyFile
+	TLSKeyFile            string
+	TLSAutocertDomains    string   // Comma separated domains to obtain Let's Encrypt certificates for, instead of TLSCertFile
+	TLSAutocertCache      string   // Directory obtained certificates are cached in
+	TLSAutocertEmail      string   // Contact address registered with Let's Encrypt
+	HTTPRedirectPort      string   // Plain HTTP port redirecting to HTTPS and answering ACME challenges; disabled when empty
+	HSTSMaxAge            int      // Seconds browsers keep to HTTPS once seen over it; 0 disables the header
+	ContentSecurityPolicy string   // Content-Security-Policy header sent with every response; disabled when empty
+	CORSAllowedOrigins    []string // Origins browsers may call the API from, exact or with wildcards (see ValidateOriginPattern)
+	SeedData              bool     // Seed reference data and the initial admin account on startup
+	SeedDemoData          bool     // Also seed demo employee accounts; never enable in production
+	LeavePreset           string   // Leave preset seeded for the default organization and new organizations, such as ZM
+	AdminUsername         string   // Initial admin account, created only when no admin exists
+	AdminPassword         string
+	AdminEmail            string
+	Timezone              string         // IANA timezone the company calendar runs on, such as Africa/Lusaka
+	Location              *time.Location // Timezone loaded from Timezone
 }
 
 var AppConfig *Config
 
+// defaultJWTSecret is the development JWT secret, refused in release mode
+const defaultJWTSecret = "change-this-secret-key-in-production"
+
+// DefaultContentSecurityPolicy only allows same-origin content. Inline scripts and styles are allowed
+// because the Swagger UI page relies on them.
+const DefaultContentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline'; " +
+	"style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'; base-uri 'self'; form-action 'self'"
+
+// developmentCORSOrigins are allowed outside release mode when CORS_ALLOWED_ORIGINS is not set, so
+// local frontend dev servers work out of the box
+var developmentCORSOrigins = []string{
+	"http://localhost:*", "https://localhost:*", "http://127.0.0.1:*", "https://127.0.0.1:*",
+}
+
+// TLSEnabled reports whether the server serves HTTPS itself, from certificate files or autocert
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSAutocertDomains != ""
+}
+
 func LoadConfig() error {
 	// Try to load .env file, but don't fail if it doesn't exist
 	_ = godotenv.Load()
 
+	// Secrets from a secrets manager take precedence over environment variables and _FILE secrets
+	secrets, err := loadProviderSecrets(os.Getenv("SECRETS_PROVIDER"))
+	if err != nil {
+		return err
+	}
+	providerSecrets = secrets
+
 	AppConfig = &Config{
-		DBHost:             getEnv("DB_HOST", "localhost"),
-		DBPort:             getEnv("DB_PORT", "5432"),
-		DBUser:             getEnv("DB_USER", "postgres"),
-		DBPassword:         getEnv("DB_PASSWORD", "postgres"),
-		DBName:             getEnv("DB_NAME", "hrms_db"),
-		JWTSecret:          getEnv("JWT_SECRET", "change-this-secret-key-in-production"),
-		JWTExpirationHours: getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
-		Port:               getEnv("PORT", "8070"),
-		GinMode:            getEnv("GIN_MODE", "release"),
-		DocumentsPath:      getEnv("DOCUMENTS_PATH", "./uploads/documents"),
-		MaxFileSize:        int64(getEnvAsInt("MAX_FILE_SIZE_MB", 5)) * 1024 * 1024, // Default 5MB
+		DBDriver:              getEnv("DB_DRIVER", "postgres"),
+		DBPath:                getEnv("DB_PATH", "./hrms.db"),
+		DBHost:                getEnv("DB_HOST", "localhost"),
+		DBPort:                getEnv("DB_PORT", ""),
+		DBUser:                getEnv("DB_USER", "postgres"),
+		DBName:                getEnv("DB_NAME", "hrms_db"),
+		DBMaxOpenConns:        getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:        getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime:     getEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 30),
+		DBLogLevel:            getEnv("DB_LOG_LEVEL", "warn"),
+		JWTExpirationHours:    getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
+		Port:                  getEnv("PORT", "8070"),
+		GinMode:               getEnv("GIN_MODE", "release"),
+		DocumentsPath:         getEnv("DOCUMENTS_PATH", "./uploads/documents"),
+		BackupsPath:           getEnv("BACKUPS_PATH", "./backups"),
+		ExportsPath:           getEnv("EXPORTS_PATH", "./exports"),
+		MaxFileSize:           int64(getEnvAsInt("MAX_FILE_SIZE_MB", 5)) * 1024 * 1024, // Default 5MB
+		TextExtractor:         getEnv("TEXT_EXTRACTOR", ""),
+		TikaURL:               strings.TrimSuffix(getEnv("TIKA_URL", "http://localhost:9998"), "/"),
+		OCRLanguages:          getEnv("OCR_LANGUAGES", "eng"),
+		SMTPHost:              getEnv("SMTP_HOST", ""),
+		SMTPPort:              getEnv("SMTP_PORT", "587"),
+		SMTPUsername:          getEnv("SMTP_USERNAME", ""),
+		SMTPFrom:              getEnv("SMTP_FROM", "hrms@localhost"),
+		SMSProvider:           getEnv("SMS_PROVIDER", ""),
+		SMSAccount:            getEnv("SMS_ACCOUNT", ""),
+		SMSFrom:               getEnv("SMS_FROM", ""),
+		SMSAPIURL:             strings.TrimSuffix(getEnv("SMS_API_URL", ""), "/"),
+		SMSCountryCode:        strings.TrimPrefix(getEnv("SMS_DEFAULT_COUNTRY_CODE", ""), "+"),
+		SMSMaxAttempts:        getEnvAsInt("SMS_MAX_ATTEMPTS", 5),
+		FCMAPIURL:             strings.TrimSuffix(getEnv("FCM_API_URL", ""), "/"),
+		APNsKeyID:             getEnv("APNS_KEY_ID", ""),
+		APNsTeamID:            getEnv("APNS_TEAM_ID", ""),
+		APNsTopic:             getEnv("APNS_TOPIC", ""),
+		APNsSandbox:           getEnvAsBool("APNS_SANDBOX", false),
+		APNsAPIURL:            strings.TrimSuffix(getEnv("APNS_API_URL", ""), "/"),
+		GrievanceAckHours:     getEnvAsInt("GRIEVANCE_ACK_HOURS", 48),
+		GrievanceSLADays:      getEnvAsInt("GRIEVANCE_SLA_DAYS", 30),
+		GRPCPort:              getEnv("GRPC_PORT", ""),
+		WebhookMaxAttempts:    getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		EmailMaxAttempts:      getEnvAsInt("EMAIL_MAX_ATTEMPTS", 8),
+		PublicURL:             strings.TrimSuffix(getEnv("PUBLIC_URL", ""), "/"),
+		GoogleClientID:        getEnv("GOOGLE_CLIENT_ID", ""),
+		MicrosoftClientID:     getEnv("MICROSOFT_CLIENT_ID", ""),
+		MicrosoftTenant:       getEnv("MICROSOFT_TENANT", "common"),
+		CalendarReturnURL:     getEnv("CALENDAR_RETURN_URL", ""),
+		HolidayAPIURL:         strings.TrimSuffix(getEnv("HOLIDAY_API_URL", "https://date.nager.at/api/v3"), "/"),
+		OTLPEndpoint:          getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		ServiceName:           getEnv("OTEL_SERVICE_NAME", "hrms-api"),
+		HTTPReadTimeout:       getEnvAsInt("HTTP_READ_TIMEOUT_SECONDS", 30),
+		HTTPWriteTimeout:      getEnvAsInt("HTTP_WRITE_TIMEOUT_SECONDS", 120),
+		HTTPIdleTimeout:       getEnvAsInt("HTTP_IDLE_TIMEOUT_SECONDS", 120),
+		ShutdownTimeout:       getEnvAsInt("SHUTDOWN_TIMEOUT_SECONDS", 60),
+		TLSCertFile:           getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:            getEnv("TLS_KEY_FILE", ""),
+		TLSAutocertDomains:    getEnv("TLS_AUTOCERT_DOMAINS", ""),
+		TLSAutocertCache:      getEnv("TLS_AUTOCERT_CACHE_DIR", "./certs"),
+		TLSAutocertEmail:      getEnv("TLS_AUTOCERT_EMAIL", ""),
+		HTTPRedirectPort:      getEnv("HTTP_REDIRECT_PORT", ""),
+		HSTSMaxAge:            getEnvAsInt("HSTS_MAX_AGE_SECONDS", 31536000),
+		ContentSecurityPolicy: DefaultContentSecurityPolicy,
+		SeedData:              getEnvAsBool("SEED_DATA", false),
+		SeedDemoData:          getEnvAsBool("SEED_DEMO_DATA", false),
+		LeavePreset:           getEnv("LEAVE_PRESET", models.DefaultLeavePreset),
+		AdminUsername:         getEnv("ADMIN_USERNAME", "admin"),
+		AdminEmail:            getEnv("ADMIN_EMAIL", "admin@example.com"),
+		Timezone:              getEnv("TIMEZONE", "Africa/Lusaka"),
+	}
+
+	location, err := time.LoadLocation(AppConfig.Timezone)
+	if err != nil {
+		return fmt.Errorf("loading TIMEZONE: %w", err)
+	}
+	AppConfig.Location = location
+
+	preset, ok := models.FindLeavePreset(AppConfig.LeavePreset)
+	if !ok {
+		return fmt.Errorf("LEAVE_PRESET %q is not a known leave preset", AppConfig.LeavePreset)
+	}
+	AppConfig.LeavePreset = preset.Code
+
+	// An empty CONTENT_SECURITY_POLICY disables the header rather than falling back to the default
+	if csp, ok := os.LookupEnv("CONTENT_SECURITY_POLICY"); ok {
+		AppConfig.ContentSecurityPolicy = strings.TrimSpace(csp)
+	}
+
+	AppConfig.CORSAllowedOrigins = getEnvAsList("CORS_ALLOWED_ORIGINS")
+	if AppConfig.CORSAllowedOrigins == nil && AppConfig.GinMode != "release" {
+		AppConfig.CORSAllowedOrigins = developmentCORSOrigins
+	}
+	for _, pattern := range AppConfig.CORSAllowedOrigins {
+		if err := ValidateOriginPattern(pattern); err != nil {
+			return fmt.Errorf("CORS_ALLOWED_ORIGINS: %w", err)
+		}
+	}
+
+	AppConfig.GRPCAPIKeys, err = loadAPIKeys()
+	if err != nil {
+		return err
+	}
+
+	switch AppConfig.DBDriver {
+	case "postgres":
+		if AppConfig.DBPort == "" {
+			AppConfig.DBPort = "5432"
+		}
+	case "mysql":
+		if AppConfig.DBPort == "" {
+			AppConfig.DBPort = "3306"
+		}
+	case "sqlite":
+	default:
+		return fmt.Errorf("DB_DRIVER must be postgres, mysql or sqlite, not %q", AppConfig.DBDriver)
+	}
+
+	switch AppConfig.TextExtractor {
+	case "", "tesseract", "tika":
+	default:
+		return fmt.Errorf("TEXT_EXTRACTOR must be tesseract, tika or empty, not %q", AppConfig.TextExtractor)
+	}
+
+	if (AppConfig.TLSCertFile == "") != (AppConfig.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if AppConfig.TLSCertFile != "" && AppConfig.TLSAutocertDomains != "" {
+		return fmt.Errorf("set either TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS, not both")
+	}
+
+	for _, secret := range []struct {
+		key, defaultValue string
+		target            *string
+	}{
+		{"DB_PASSWORD", "postgres", &AppConfig.DBPassword},
+		{"DB_DSN", "", &AppConfig.DBDSN},
+		{"JWT_SECRET", defaultJWTSecret, &AppConfig.JWTSecret},
+		{"TOKEN_ENCRYPTION_KEY", "", &AppConfig.TokenEncryptionKey},
+		{"SMTP_PASSWORD", "", &AppConfig.SMTPPassword},
+		{"SMS_API_KEY", "", &AppConfig.SMSAPIKey},
+		{"FCM_CREDENTIALS", "", &AppConfig.FCMCredentials},
+		{"APNS_AUTH_KEY", "", &AppConfig.APNsAuthKey},
+		{"ADMIN_PASSWORD", "", &AppConfig.AdminPassword},
+		{"SLACK_SIGNING_SECRET", "", &AppConfig.SlackSigningSecret},
+		{"TEAMS_WEBHOOK_SECRET", "", &AppConfig.TeamsWebhookSecret},
+		{"GOOGLE_CLIENT_SECRET", "", &AppConfig.GoogleClientSecret},
+		{"MICROSOFT_CLIENT_SECRET", "", &AppConfig.MicrosoftClientSecret},
+	} {
+		value, err := getSecret(secret.key)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			value = secret.defaultValue
+		}
+		*secret.target = value
+	}
+
+	switch AppConfig.SMSProvider {
+	case "":
+	case "twilio", "africastalking":
+		if AppConfig.SMSAccount == "" || AppConfig.SMSAPIKey == "" {
+			return fmt.Errorf("SMS_ACCOUNT and SMS_API_KEY must be set with SMS_PROVIDER")
+		}
+		if AppConfig.SMSProvider == "twilio" && AppConfig.SMSFrom == "" {
+			return fmt.Errorf("SMS_FROM must be set to a Twilio number or sender ID with SMS_PROVIDER=twilio")
+		}
+	default:
+		return fmt.Errorf("SMS_PROVIDER must be twilio, africastalking or empty, not %q", AppConfig.SMSProvider)
+	}
+
+	if AppConfig.FCMCredentials != "" {
+		var account struct {
+			ProjectID   string `json:"project_id"`
+			ClientEmail string `json:"client_email"`
+			PrivateKey  string `json:"private_key"`
+		}
+		if json.Unmarshal([]byte(AppConfig.FCMCredentials), &account) != nil || account.ProjectID == "" || account.ClientEmail == "" || account.PrivateKey == "" {
+			return fmt.Errorf("FCM_CREDENTIALS must be the JSON key of a Firebase service account")
+		}
+	}
+	if AppConfig.APNsAuthKey != "" && (AppConfig.APNsKeyID == "" || AppConfig.APNsTeamID == "" || AppConfig.APNsTopic == "") {
+		return fmt.Errorf("APNS_KEY_ID, APNS_TEAM_ID and APNS_TOPIC must be set with APNS_AUTH_KEY")
+	}
+
+	if AppConfig.TeamsWebhookSecret != "" {
+		if _, err := base64.StdEncoding.DecodeString(AppConfig.TeamsWebhookSecret); err != nil {
+			return fmt.Errorf("TEAMS_WEBHOOK_SECRET must be the base64 security token Teams shows when the outgoing webhook is created")
+		}
+	}
+
+	for _, client := range []struct{ name, id, secret string }{
+		{"GOOGLE", AppConfig.GoogleClientID, AppConfig.GoogleClientSecret},
+		{"MICROSOFT", AppConfig.MicrosoftClientID, AppConfig.MicrosoftClientSecret},
+	} {
+		if client.id == "" {
+			continue
+		}
+		if client.secret == "" {
+			return fmt.Errorf("%s_CLIENT_SECRET must be set with %s_CLIENT_ID", client.name, client.name)
+		}
+		if AppConfig.PublicURL == "" {
+			return fmt.Errorf("PUBLIC_URL must be set for calendar integrations, as OAuth redirects come back to it")
+		}
+	}
+
+	// Anyone could sign tokens with the well-known default, so it is only allowed in development
+	if AppConfig.GinMode == "release" && AppConfig.JWTSecret == defaultJWTSecret {
+		return fmt.Errorf("JWT_SECRET must be set to a strong random value in release mode (e.g. openssl rand -base64 32)")
 	}
 
 	return nil
@@ -64,7 +364,60 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsList splits a comma separated environment variable, returning nil when it is unset or empty
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getSecret reads a secret from the secrets provider, or from the file named by <key>_FILE so it can
+// be mounted from a Docker or Kubernetes secret, or else from the environment variable
+func getSecret(key string) (string, error) {
+	if value, ok := providerSecrets[key]; ok {
+		return value, nil
+	}
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading %s_FILE: %w", key, err)
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+	return os.Getenv(key), nil
+}
+
+// GetDSN returns the data source name DBDriver connects with: DBDSN when set, else one built from the
+// other settings. SQLite enforces foreign keys as the other databases do, and waits for other
+// connections to finish writing instead of failing. DBPath may be a file: URI with parameters of its
+// own, such as file:test?mode=memory&cache=shared for a database held in memory.
 func (c *Config) GetDSN() string {
+	if c.DBDSN != "" {
+		return c.DBDSN
+	}
+	switch c.DBDriver {
+	case "mysql":
+		return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+			c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName)
+	case "sqlite":
+		separator := "?"
+		if strings.Contains(c.DBPath, "?") {
+			separator = "&"
+		}
+		return c.DBPath + separator + "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	}
 	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
 		c.DBHost, c.DBUser, c.DBPassword, c.DBName, c.DBPort)
 }
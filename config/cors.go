@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateOriginPattern checks an allowed-origin pattern: "*" for any origin, or scheme://host[:port]
+// where the host may start with "*." for any subdomain and the port may be "*" for any port, such as
+// https://*.example.com or http://localhost:*
+func ValidateOriginPattern(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+	scheme, rest, ok := strings.Cut(pattern, "://")
+	if !ok || (scheme != "http" && scheme != "https") {
+		return fmt.Errorf("invalid origin %q: must start with http:// or https://", pattern)
+	}
+	host, port := splitOriginHost(rest)
+	if strings.ContainsAny(rest, "/?#") || host == "" {
+		return fmt.Errorf("invalid origin %q: use scheme://host[:port] without a path", pattern)
+	}
+	if strings.Contains(strings.TrimPrefix(host, "*."), "*") || (strings.Contains(port, "*") && port != "*") {
+		return fmt.Errorf("invalid origin %q: * can only stand for the whole port or a leading subdomain", pattern)
+	}
+	return nil
+}
+
+// splitOriginHost splits the host[:port] part of an origin, keeping IPv6 hosts in brackets
+func splitOriginHost(hostPort string) (host, port string) {
+	if i := strings.LastIndex(hostPort, ":"); i > strings.LastIndex(hostPort, "]") {
+		return hostPort[:i], hostPort[i+1:]
+	}
+	return hostPort, ""
+}
+
+// OriginAllowed reports whether a browser origin matches any of the patterns
+func OriginAllowed(patterns []string, origin string) bool {
+	for _, pattern := range patterns {
+		if OriginMatches(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// OriginMatches reports whether a browser origin matches an allowed-origin pattern (see
+// ValidateOriginPattern). Schemes and hosts are compared case-insensitively. A "*." host matches
+// subdomains at any depth but not the bare domain, and a "*" port matches any port, including none.
+func OriginMatches(pattern, origin string) bool {
+	pattern, origin = strings.ToLower(pattern), strings.ToLower(origin)
+	if pattern == "*" || pattern == origin {
+		return true
+	}
+	if !strings.Contains(pattern, "*") {
+		return false
+	}
+
+	patternScheme, patternRest, ok := strings.Cut(pattern, "://")
+	if !ok {
+		return false
+	}
+	originScheme, originRest, ok := strings.Cut(origin, "://")
+	if !ok || originScheme != patternScheme || strings.ContainsAny(originRest, "/?#") {
+		return false
+	}
+	patternHost, patternPort := splitOriginHost(patternRest)
+	originHost, originPort := splitOriginHost(originRest)
+
+	if patternPort != "*" && patternPort != originPort {
+		return false
+	}
+	if suffix, ok := strings.CutPrefix(patternHost, "*"); ok {
+		return strings.HasPrefix(suffix, ".") && strings.HasSuffix(originHost, suffix) && len(originHost) > len(suffix)
+	}
+	return patternHost == originHost
+}
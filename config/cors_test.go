@@ -0,0 +1,104 @@
+package config
+
+import "testing"
+
+func TestOriginMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		origin  string
+		want    bool
+	}{
+		// Exact origins
+		{"https://app.example.com", "https://app.example.com", true},
+		{"https://app.example.com", "HTTPS://App.Example.com", true},
+		{"https://app.example.com", "https://app.example.com:8443", false},
+		{"https://app.example.com", "https://other.example.com", false},
+		{"*", "https://anything.test", true},
+
+		// Subdomain wildcards match subdomains at any depth, but not the bare domain or lookalikes
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://eu.app.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://.example.com", false},
+		{"https://*.example.com", "https://evilexample.com", false},
+		{"https://*.example.com", "https://example.com.evil.test", false},
+		{"https://*.example.com", "https://app.example.com:8443", false},
+		{"https://*.example.com", "https://app.example.com/path", false},
+
+		// Port wildcards match any port, including none
+		{"http://localhost:*", "http://localhost:3000", true},
+		{"http://localhost:*", "http://localhost", true},
+		{"http://localhost:*", "http://localhost.evil.test:3000", false},
+		{"https://*.example.com:*", "https://app.example.com:8443", true},
+
+		// Schemes must match
+		{"https://app.example.com", "http://app.example.com", false},
+		{"https://*.example.com", "http://app.example.com", false},
+		{"http://localhost:*", "https://localhost:3000", false},
+		{"https://*.example.com", "app.example.com", false},
+
+		// IPv6 hosts keep their colons inside the brackets
+		{"http://[::1]:8080", "http://[::1]:8080", true},
+		{"http://[::1]:*", "http://[::1]:3000", true},
+		{"http://[::1]:*", "http://[::1]", true},
+		{"http://[::1]:*", "http://[::2]:3000", false},
+		{"http://[::1]:8080", "http://[::1]:3000", false},
+	}
+	for _, tt := range tests {
+		if got := OriginMatches(tt.pattern, tt.origin); got != tt.want {
+			t.Errorf("OriginMatches(%q, %q) = %v, want %v", tt.pattern, tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestOriginAllowed(t *testing.T) {
+	patterns := []string{"https://hr.example.com", "http://localhost:*"}
+	if !OriginAllowed(patterns, "http://localhost:5173") {
+		t.Error("origin matching the second pattern is not allowed")
+	}
+	if OriginAllowed(patterns, "https://evil.test") {
+		t.Error("origin matching no pattern is allowed")
+	}
+	if OriginAllowed(nil, "https://hr.example.com") {
+		t.Error("origin is allowed without any patterns")
+	}
+}
+
+func TestValidateOriginPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{"*", true},
+		{"https://app.example.com", true},
+		{"http://localhost:3000", true},
+		{"https://*.example.com", true},
+		{"http://localhost:*", true},
+		{"https://*.example.com:*", true},
+		{"http://[::1]:8080", true},
+		{"http://[::1]:*", true},
+
+		{"", false},
+		{"app.example.com", false},
+		{"ftp://files.example.com", false},
+		{"https://", false},
+		{"https://app.example.com/", false},
+		{"https://app.example.com/path", false},
+		{"https://app.example.com?query", false},
+		{"https://app.example.com#fragment", false},
+		{"https://*example.com", false},
+		{"https://app.*.example.com", false},
+		{"https://*.*.example.com", false},
+		{"https://app.example.*", false},
+		{"http://localhost:80*", false},
+	}
+	for _, tt := range tests {
+		err := ValidateOriginPattern(tt.pattern)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateOriginPattern(%q) = %v, want valid", tt.pattern, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("ValidateOriginPattern(%q) is valid, want an error", tt.pattern)
+		}
+	}
+}
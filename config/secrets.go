@@ -0,0 +1,205 @@
+package config
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// secretsTimeout bounds each request to the secrets provider at startup
+const secretsTimeout = 10 * time.Second
+
+// providerSecrets holds the secrets loaded from SECRETS_PROVIDER, keyed by environment variable name
+var providerSecrets map[string]string
+
+// loadProviderSecrets fetches the secrets of the configured provider: a Vault KV secret or an AWS
+// Secrets Manager secret whose fields are named after the environment variables they replace, such
+// as JWT_SECRET and DB_PASSWORD
+func loadProviderSecrets(provider string) (map[string]string, error) {
+	switch provider {
+	case "":
+		return nil, nil
+	case "vault":
+		return loadVaultSecrets()
+	case "aws":
+		return loadAWSSecrets()
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q: use vault or aws", provider)
+	}
+}
+
+// loadVaultSecrets reads the secret at VAULT_SECRET_PATH from KV version 1 or 2, e.g. secret/data/hrms
+func loadVaultSecrets() (map[string]string, error) {
+	addr := strings.TrimRight(os.Getenv("VAULT_ADDR"), "/")
+	path := strings.Trim(os.Getenv("VAULT_SECRET_PATH"), "/")
+	token, err := getSecret("VAULT_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+	if addr == "" || path == "" || token == "" {
+		return nil, fmt.Errorf("VAULT_ADDR, VAULT_SECRET_PATH and VAULT_TOKEN are required with SECRETS_PROVIDER=vault")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, addr+"/v1/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	body, err := doSecretsRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("reading Vault secret %s: %w", path, err)
+	}
+
+	var response struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("reading Vault secret %s: %w", path, err)
+	}
+	// KV version 2 nests the fields under data.data, next to data.metadata
+	fields := response.Data
+	if nested, ok := fields["data"]; ok {
+		if _, versioned := fields["metadata"]; versioned {
+			fields = nil
+			if err := json.Unmarshal(nested, &fields); err != nil {
+				return nil, fmt.Errorf("reading Vault secret %s: %w", path, err)
+			}
+		}
+	}
+	return secretFields(fields)
+}
+
+// loadAWSSecrets reads the JSON secret AWS_SECRET_ID from AWS Secrets Manager in AWS_REGION, signing
+// the request with the credentials in AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+func loadAWSSecrets() (map[string]string, error) {
+	secretID := os.Getenv("AWS_SECRET_ID")
+	region := getEnv("AWS_REGION", os.Getenv("AWS_DEFAULT_REGION"))
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey, err := getSecret("AWS_SECRET_ACCESS_KEY")
+	if err != nil {
+		return nil, err
+	}
+	if secretID == "" || region == "" || accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("AWS_SECRET_ID, AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required with SECRETS_PROVIDER=aws")
+	}
+
+	payload, _ := json.Marshal(map[string]string{"SecretId": secretID})
+	endpoint := getEnv("AWS_SECRETS_MANAGER_ENDPOINT", "https://secretsmanager."+region+".amazonaws.com")
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if sessionToken := os.Getenv("AWS_SESSION_TOKEN"); sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+	signAWSRequest(req, payload, region, "secretsmanager", accessKey, secretKey, time.Now().UTC())
+	body, err := doSecretsRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("reading AWS secret %s: %w", secretID, err)
+	}
+
+	var response struct {
+		SecretString string `json:"SecretString"`
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("reading AWS secret %s: %w", secretID, err)
+	}
+	if err := json.Unmarshal([]byte(response.SecretString), &fields); err != nil {
+		return nil, fmt.Errorf("AWS secret %s must be a JSON object of key/value pairs: %w", secretID, err)
+	}
+	return secretFields(fields)
+}
+
+// signAWSRequest adds an AWS Signature Version 4 Authorization header to the request
+func signAWSRequest(req *http.Request, payload []byte, region, service, accessKey, secretKey string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, sha256Hex(payload),
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// doSecretsRequest sends a request to the secrets provider and returns the body of a successful response
+func doSecretsRequest(req *http.Request) ([]byte, error) {
+	client := &http.Client{Timeout: secretsTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// secretFields keeps the string fields of a secret, rejecting any other value so a mistyped secret
+// fails at startup rather than being silently ignored
+func secretFields(fields map[string]json.RawMessage) (map[string]string, error) {
+	secrets := make(map[string]string, len(fields))
+	for key, raw := range fields {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("secret field %s must be a string", key)
+		}
+		secrets[key] = value
+	}
+	return secrets, nil
+}
@@ -1,14 +1,22 @@
 package database
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"hrms-api/config"
 	"hrms-api/models"
 	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 // Helper function to create string pointer
@@ -18,166 +26,475 @@ func stringPtr(s string) *string {
 
 var DB *gorm.DB
 
+const (
+	minAdminPasswordLength = 8
+	demoPassword           = "password123" // Only used for SEED_DEMO_DATA accounts
+)
+
 func Connect() error {
-	var err error
+	cfg := config.AppConfig
+	logLevel, err := parseLogLevel(cfg.DBLogLevel)
+	if err != nil {
+		return err
+	}
 
-	dsn := config.AppConfig.GetDSN()
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	DB, err = gorm.Open(openDialector(cfg), &gorm.Config{
+		Logger: logger.Default.LogMode(logLevel),
 	})
 
 	if err != nil {
 		return err
 	}
+	if err := adaptSchemas(); err != nil {
+		return err
+	}
 
-	log.Println("Database connected successfully")
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.DBConnMaxLifetime) * time.Minute)
+
+	// Trace queries as children of the span in the query's context (see DB.WithContext). Query
+	// parameters are left out of spans as they may hold personal data.
+	if err := DB.Use(tracing.NewPlugin(tracing.WithoutQueryVariables(), tracing.WithoutMetrics())); err != nil {
+		return err
+	}
+	if err := registerTenancy(DB); err != nil {
+		return err
+	}
+
+	log.Printf("Database connected successfully (%s, max open connections: %d, max idle: %d)", Dialect(), cfg.DBMaxOpenConns, cfg.DBMaxIdleConns)
 	return nil
 }
 
+func parseLogLevel(level string) (logger.LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "silent":
+		return logger.Silent, nil
+	case "error":
+		return logger.Error, nil
+	case "warn", "":
+		return logger.Warn, nil
+	case "info":
+		return logger.Info, nil
+	}
+	return 0, fmt.Errorf("invalid DB_LOG_LEVEL %q: must be silent, error, warn or info", level)
+}
+
+// migrationModels are the models whose tables AutoMigrate creates and keeps up to date
+var migrationModels = []interface{}{
+	// Core models
+	&models.Organization{},
+	&models.Employee{},
+	&models.LeaveType{},
+	&models.Leave{},
+	&models.LeaveAudit{},
+	&models.LeaveAccrual{},
+	&models.LeaveTaken{},
+	&models.LeaveCarryOver{},
+	// Core HR models
+	&models.IdentityInformation{},
+	&models.EmploymentDetails{},
+	&models.EmploymentHistory{},
+	&models.Position{},
+	&models.PositionAssignment{},
+	&models.Document{},
+	&models.WorkLifecycleEvent{},
+	&models.OnboardingProcess{},
+	&models.OnboardingTask{},
+	&models.OffboardingProcess{},
+	&models.OffboardingTask{},
+	&models.ComplianceRequirement{},
+	&models.ComplianceRecord{},
+	&models.AuditLog{},
+	&models.HeadcountBudget{},
+	&models.HeadcountRequest{},
+	&models.TransferRequest{},
+	&models.Education{},
+	&models.Skill{},
+	&models.Certification{},
+	&models.EmployeeSkill{},
+	&models.EmployeeCertification{},
+	&models.BankDetails{},
+	&models.Notification{},
+	&models.WorkSchedule{},
+	&models.AttendanceRecord{},
+	&models.AttendanceCorrection{},
+	&models.Shift{},
+	&models.ShiftAssignment{},
+	&models.ShiftSwapRequest{},
+	&models.TrainingCourse{},
+	&models.TrainingSession{},
+	&models.TrainingEnrollment{},
+	&models.MandatoryTraining{},
+	&models.Grievance{},
+	&models.GrievanceUpdate{},
+	&models.RemoteWorkRequest{},
+	&models.CompanyValue{},
+	&models.Kudos{},
+	&models.ExitQuestionSet{},
+	&models.ExitQuestion{},
+	&models.ExitInterview{},
+	&models.ExitInterviewResponse{},
+	&models.WebhookSubscription{},
+	&models.WebhookDelivery{},
+	&models.RetentionPolicy{},
+	&models.LegalHold{},
+	&models.LoginLog{},
+	&models.Setting{},
+	&models.APIKeyUsage{},
+	&models.ChatAccount{},
+	&models.ChatLinkCode{},
+	&models.CalendarConnection{},
+	&models.CalendarEvent{},
+	&models.PublicHoliday{},
+	&models.HolidayCountry{},
+	&models.NationalIDFormat{},
+	&models.DocumentTemplate{},
+	&models.EmploymentLetter{},
+	&models.CompensationRecord{},
+	&models.CostCenter{},
+	&models.CostCenterAllocation{},
+	&models.ExportJob{},
+	&models.FileAccessLog{},
+	&models.ScheduledJob{},
+	&models.DeadLetter{},
+	&models.EmailTemplate{},
+	&models.NotificationPreference{},
+	&models.NotificationSettings{},
+	&models.DeviceToken{},
+	&models.HRISMapping{},
+	&models.AttendanceDevice{},
+	&models.ClockEvent{},
+}
+
 func Migrate() error {
-	err := DB.AutoMigrate(
-		// Core models
-		&models.Employee{},
-		&models.LeaveType{},
-		&models.Leave{},
-		&models.LeaveAudit{},
-		&models.LeaveAccrual{},
-		&models.LeaveTaken{},
-		&models.LeaveCarryOver{},
-		// Core HR models
-		&models.IdentityInformation{},
-		&models.EmploymentDetails{},
-		&models.EmploymentHistory{},
-		&models.Position{},
-		&models.PositionAssignment{},
-		&models.Document{},
-		&models.WorkLifecycleEvent{},
-		&models.OnboardingProcess{},
-		&models.OnboardingTask{},
-		&models.OffboardingProcess{},
-		&models.OffboardingTask{},
-		&models.ComplianceRequirement{},
-		&models.ComplianceRecord{},
-		&models.AuditLog{},
-	)
+	err := DB.AutoMigrate(migrationModels...)
 
 	if err != nil {
 		return err
 	}
+	switch Dialect() {
+	case DriverPostgres:
+		if err := rebuildPartialIndexes(); err != nil {
+			return err
+		}
+	case DriverMySQL:
+		if err := createFunctionalIndexes(); err != nil {
+			return err
+		}
+	}
+
+	// Records that existed before organizations were introduced default to organization 1, so it is
+	// created before anything else
+	var organizationCount int64
+	DB.Model(&models.Organization{}).Count(&organizationCount)
+	if organizationCount == 0 {
+		organization := models.Organization{ID: models.DefaultOrganizationID, Name: "Default", Code: "default", IsActive: true}
+		if err := DB.Create(&organization).Error; err != nil {
+			return err
+		}
+	}
+
+	// Ensure existing Annual leave type has UsesBalance = true (for DBs created before UsesBalance column)
+	DB.Model(&models.LeaveType{}).Where("name = ? OR max_days = ?", "Annual", 24).Update("uses_balance", true)
 
 	log.Println("Database migration completed")
 	return nil
 }
 
-func SeedData() error {
-	// Ensure existing Annual leave type has UsesBalance = true (for DBs created before UsesBalance column)
-	DB.Model(&models.LeaveType{}).Where("name = ? OR max_days = ?", "Annual", 24).Update("uses_balance", true)
+// rebuildPartialIndexes recreates the unique indexes declared with a WHERE clause that were created
+// without one, because AutoMigrate leaves existing indexes alone. Unique identifiers such as NRCs are
+// only unique among records that are not deleted, so deleted employees can be hired again.
+func rebuildPartialIndexes() error {
+	for _, model := range migrationModels {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for name, index := range stmt.Schema.ParseIndexes() {
+			if index.Where == "" {
+				continue
+			}
+			var definition string
+			err := DB.Raw("SELECT indexdef FROM pg_indexes WHERE schemaname = current_schema() AND tablename = ? AND indexname = ?",
+				stmt.Schema.Table, name).Scan(&definition).Error
+			if err != nil {
+				return err
+			}
+			if definition == "" || strings.Contains(definition, " WHERE ") {
+				continue
+			}
+			err = DB.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropIndex(model, name); err != nil {
+					return err
+				}
+				return tx.Migrator().CreateIndex(model, name)
+			})
+			if err != nil {
+				return fmt.Errorf("rebuilding index %s: %w", name, err)
+			}
+			log.Printf("Index %s rebuilt to leave out deleted records", name)
+		}
+	}
+	return nil
+}
 
+// SeedData creates reference data (leave types, work schedule, company values, exit interview
+// questions) and the initial admin account when they are missing. Existing data is never changed.
+func SeedData() error {
 	// Seed Leave Types (only if they don't exist)
 	var leaveTypeCount int64
 	DB.Model(&models.LeaveType{}).Count(&leaveTypeCount)
 	if leaveTypeCount == 0 {
-		maxCarryOver := 5.0 // Allow up to 5 days carry-over
-		expiryMonths := 3   // Carry-over expires 3 months into next year (end of Q1)
-
-		leaveTypes := []models.LeaveType{
-			{Name: "Sick", AccrualRate: 0, MaxDays: 3, UsesBalance: false},
-			{Name: "Compassionate", AccrualRate: 0, MaxDays: 7, UsesBalance: false},
-			{
-				Name:                  "Annual",
-				AccrualRate:           2.0, // 2 days per month
-				MaxDays:               24,  // 24 days/year, accrues 2 days/month
-				UsesBalance:           true,
-				AllowCarryOver:        true,
-				MaxCarryOverDays:      &maxCarryOver,
-				CarryOverExpiryMonths: &expiryMonths,
+		preset, _ := models.FindLeavePreset(config.AppConfig.LeavePreset)
+		if err := SeedLeaveTypes(DB, preset); err != nil {
+			return err
+		}
+		if err := DB.Model(&models.Organization{}).Where("id = ?", models.DefaultOrganizationID).Update("leave_preset", preset.Code).Error; err != nil {
+			return err
+		}
+		log.Printf("Leave types seeded from the %s leave preset", preset.Name)
+	}
+
+	// Seed a default work schedule for attendance tracking
+	var scheduleCount int64
+	DB.Model(&models.WorkSchedule{}).Count(&scheduleCount)
+	if scheduleCount == 0 {
+		schedule := models.WorkSchedule{Name: "Standard", StartTime: "08:00", EndTime: "17:00", GraceMinutes: 15, WorkDays: "1,2,3,4,5", IsDefault: true}
+		if err := DB.Create(&schedule).Error; err != nil {
+			return err
+		}
+		log.Println("Default work schedule seeded")
+	}
+
+	// Seed default company values for peer recognition
+	var valueCount int64
+	DB.Model(&models.CompanyValue{}).Count(&valueCount)
+	if valueCount == 0 {
+		values := []models.CompanyValue{{Name: "Integrity"}, {Name: "Teamwork"}, {Name: "Excellence"}, {Name: "Customer Focus"}}
+		if err := DB.Create(&values).Error; err != nil {
+			return err
+		}
+		log.Println("Default company values seeded")
+	}
+
+	// Seed a default exit interview question set
+	var questionSetCount int64
+	DB.Model(&models.ExitQuestionSet{}).Count(&questionSetCount)
+	if questionSetCount == 0 {
+		choices := "Better pay,Career progression,Management,Workload,Relocation,Other"
+		questionSet := models.ExitQuestionSet{
+			Name: "Standard Exit Interview",
+			Questions: []models.ExitQuestion{
+				{Text: "What is the main reason you are leaving?", Type: models.ExitQuestionChoice, Options: &choices, IsRequired: true, Order: 1},
+				{Text: "How satisfied were you with your role overall?", Type: models.ExitQuestionRating, IsRequired: true, Order: 2},
+				{Text: "How well did your manager support you?", Type: models.ExitQuestionRating, IsRequired: true, Order: 3},
+				{Text: "Did you have the training and resources you needed?", Type: models.ExitQuestionYesNo, Order: 4},
+				{Text: "What could we have done to keep you?", Type: models.ExitQuestionText, Order: 5},
 			},
-			{Name: "Maternity", AccrualRate: 0, MaxDays: 90, UsesBalance: false},
-			{Name: "Paternity", AccrualRate: 0, MaxDays: 7, UsesBalance: false},
 		}
+		if err := DB.Create(&questionSet).Error; err != nil {
+			return err
+		}
+		log.Println("Default exit interview question set seeded")
+	}
+
+	if err := seedAdmin(); err != nil {
+		return err
+	}
+
+	if config.AppConfig.SeedDemoData {
+		if err := seedDemoEmployees(); err != nil {
+			return err
+		}
+	}
 
-		for _, lt := range leaveTypes {
-			if err := DB.Create(&lt).Error; err != nil {
-				return err
-			}
+	log.Println("Seed data check completed")
+	return nil
+}
+
+// SeedLeaveTypes creates the leave types of a leave preset through db. When db's context carries an
+// organization (see WithOrganization) the leave types belong to it.
+func SeedLeaveTypes(db *gorm.DB, preset models.LeavePreset) error {
+	for _, presetType := range preset.LeaveTypes {
+		lt := presetType.LeaveType()
+		if err := db.Create(&lt).Error; err != nil {
+			return err
 		}
-		log.Println("Leave types seeded")
 	}
+	return nil
+}
 
-	// Default password for all test users: "password123"
-	defaultPassword := "password123"
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(defaultPassword), bcrypt.DefaultCost)
+// CreateAdmin creates admin as an admin account signing in with password, through db. The password
+// must be at least minAdminPasswordLength characters.
+func CreateAdmin(db *gorm.DB, admin *models.Employee, password string) error {
+	if len(password) < minAdminPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minAdminPasswordLength)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
+	admin.PasswordHash = string(hashedPassword)
+	admin.Role = models.RoleAdmin
+	if admin.Department == "" {
+		admin.Department = "Administration"
+	}
+	return db.Create(admin).Error
+}
 
-	// Helper for string pointers
-	strPtr := func(s string) *string { return &s }
-
-	// Seed Test Employees (only if they don't exist)
-	var employeeCount int64
-	DB.Model(&models.Employee{}).Count(&employeeCount)
-	if employeeCount == 0 {
-		testEmployees := []models.Employee{
-			{
-				NRC:          strPtr("123456/78/9"),
-				Firstname:    "John",
-				Lastname:     "Doe",
-				Email:        stringPtr("john.doe@example.com"),
-				PasswordHash: string(hashedPassword),
-				Department:   "IT",
-				Role:         models.RoleEmployee,
-			},
-			{
-				NRC:          strPtr("987654/32/1"),
-				Firstname:    "Jane",
-				Lastname:     "Manager",
-				Email:        stringPtr("jane.manager@example.com"),
-				PasswordHash: string(hashedPassword),
-				Department:   "HR",
-				Role:         models.RoleManager,
-			},
+// Reindex rebuilds the indexes of every table the application migrates, e.g. after bulk imports or
+// to recover from index bloat. Each table is locked against writes while it is reindexed. MySQL
+// rebuilds each table with its indexes.
+func Reindex() error {
+	statement := "REINDEX TABLE "
+	switch Dialect() {
+	case DriverMySQL:
+		statement = "OPTIMIZE TABLE "
+	case DriverSQLite:
+		statement = "REINDEX "
+	}
+	for _, model := range migrationModels {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return err
 		}
-
-		for _, emp := range testEmployees {
-			if err := DB.Create(&emp).Error; err != nil {
-				return err
-			}
+		if err := DB.Exec(statement + stmt.Quote(stmt.Schema.Table)).Error; err != nil {
+			return fmt.Errorf("reindexing %s: %w", stmt.Schema.Table, err)
 		}
+	}
+	return nil
+}
 
-		log.Println("Test accounts created:")
-		log.Println("  Employee: NRC=123456/78/9, Password=password123")
-		log.Println("  Manager:  NRC=987654/32/1, Password=password123")
+// seedAdmin creates the initial admin account from ADMIN_USERNAME and ADMIN_PASSWORD when no admin
+// exists. An existing admin account is never modified.
+func seedAdmin() error {
+	var adminCount int64
+	DB.Model(&models.Employee{}).Where("role = ?", models.RoleAdmin).Count(&adminCount)
+	if adminCount > 0 {
+		return nil
 	}
 
-	// Always ensure admin user exists with correct password
-	var adminUser models.Employee
-	adminUsername := "admin"
-	if err := DB.Where("username = ? AND role = ?", adminUsername, models.RoleAdmin).First(&adminUser).Error; err != nil {
-		// Admin doesn't exist, create it
-		adminUser = models.Employee{
-			Username:     strPtr(adminUsername),
-			Firstname:    "Admin",
-			Lastname:     "User",
-			Email:        stringPtr("admin@example.com"),
+	cfg := config.AppConfig
+	if cfg.AdminPassword == "" {
+		log.Println("⚠️  No admin account exists and ADMIN_PASSWORD is not set - skipping admin creation")
+		return nil
+	}
+	if len(cfg.AdminPassword) < minAdminPasswordLength {
+		return fmt.Errorf("ADMIN_PASSWORD must be at least %d characters", minAdminPasswordLength)
+	}
+
+	admin := models.Employee{
+		Username:  stringPtr(cfg.AdminUsername),
+		Firstname: "Admin",
+		Lastname:  "User",
+		Email:     stringPtr(cfg.AdminEmail),
+	}
+	if err := CreateAdmin(DB, &admin, cfg.AdminPassword); err != nil {
+		return err
+	}
+	log.Printf("Admin account created: Username=%s", cfg.AdminUsername)
+	return nil
+}
+
+// seedDemoEmployees creates an employee and a manager account with a well-known password for local
+// development and demos
+func seedDemoEmployees() error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(demoPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	demoEmployees := []models.Employee{
+		{
+			NRC:          stringPtr("123456/78/9"),
+			Firstname:    "John",
+			Lastname:     "Doe",
+			Email:        stringPtr("john.doe@example.com"),
+			PasswordHash: string(hashedPassword),
+			Department:   "IT",
+			Role:         models.RoleEmployee,
+		},
+		{
+			NRC:          stringPtr("987654/32/1"),
+			Firstname:    "Jane",
+			Lastname:     "Manager",
+			Email:        stringPtr("jane.manager@example.com"),
 			PasswordHash: string(hashedPassword),
-			Department:   "Administration",
-			Role:         models.RoleAdmin,
+			Department:   "HR",
+			Role:         models.RoleManager,
+		},
+	}
+
+	for _, emp := range demoEmployees {
+		var existing int64
+		DB.Model(&models.Employee{}).Where("nrc = ?", *emp.NRC).Count(&existing)
+		if existing > 0 {
+			continue
 		}
-		if err := DB.Create(&adminUser).Error; err != nil {
+		if err := DB.Create(&emp).Error; err != nil {
 			return err
 		}
-		log.Println("Admin account created: Username=admin, Password=password123")
-	} else {
-		// Admin exists, ensure password is correct
-		adminUser.PasswordHash = string(hashedPassword)
-		if err := DB.Save(&adminUser).Error; err != nil {
-			log.Printf("Warning: Failed to update admin password: %v", err)
-		} else {
-			log.Println("Admin password reset to: password123")
+		log.Printf("⚠️  Demo account created: NRC=%s, Password=%s", *emp.NRC, demoPassword)
+	}
+	return nil
+}
+
+// Tables returns the names of the tables Migrate creates, in migration order
+func Tables() ([]string, error) {
+	tables := make([]string, 0, len(migrationModels))
+	for _, model := range migrationModels {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		tables = append(tables, stmt.Schema.Table)
+	}
+	return tables, nil
+}
+
+// SchemaVersion returns a fingerprint of the tables and columns Migrate creates, the same for every
+// build with the same models and different when they change. It does not need a connection.
+func SchemaVersion() (string, error) {
+	hash := sha256.New()
+	cache := &sync.Map{}
+	for _, model := range migrationModels {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			return "", err
+		}
+		for _, field := range s.Fields {
+			if field.DBName != "" {
+				fmt.Fprintf(hash, "%s.%s %s\n", s.Table, field.DBName, field.DataType)
+			}
 		}
 	}
+	return hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
 
-	log.Println("Seed data check completed")
-	return nil
+// PendingMigrations lists the "table.column" pairs the models define that are missing from the
+// database, which means Migrate has not run against it since the models changed
+func PendingMigrations(ctx context.Context) ([]string, error) {
+	existing, err := TableColumns(DB.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, model := range migrationModels {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		for _, column := range stmt.Schema.DBNames {
+			if field := stmt.Schema.FieldsByDBName[column]; !field.IgnoreMigration && !existing[stmt.Schema.Table][column] {
+				pending = append(pending, stmt.Schema.Table+"."+column)
+			}
+		}
+	}
+	return pending, nil
 }
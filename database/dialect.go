@@ -0,0 +1,260 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Databases DB_DRIVER selects, named as their gorm dialects are
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// functionalIndex is a partial unique index of a model, created on MySQL as a unique index of
+// CASE WHEN <where> THEN <column> END, which is NULL, and so never a duplicate, where the WHERE clause
+// does not hold
+type functionalIndex struct {
+	model interface{}
+	index schema.Index
+}
+
+// functionalIndexes are the partial unique indexes adaptSchemas took out of the models on MySQL
+var functionalIndexes []functionalIndex
+
+// openDialector returns the gorm dialect of the database DB_DRIVER selects
+func openDialector(cfg *config.Config) gorm.Dialector {
+	switch cfg.DBDriver {
+	case DriverMySQL:
+		return mysql.Open(cfg.GetDSN())
+	case DriverSQLite:
+		return sqlite.Open(cfg.GetDSN())
+	}
+	return postgres.Open(cfg.GetDSN())
+}
+
+// Dialect returns the database connected to, one of DriverPostgres, DriverMySQL or DriverSQLite
+func Dialect() string {
+	if DB == nil {
+		return DriverPostgres
+	}
+	return DB.Dialector.Name()
+}
+
+// IsPostgres reports whether the database connected to is Postgres, the only one with full-text
+// search, advisory locks and backups
+func IsPostgres() bool {
+	return Dialect() == DriverPostgres
+}
+
+// ConcatSQL returns SQL joining string expressions into one
+func ConcatSQL(expressions ...string) string {
+	if Dialect() == DriverMySQL {
+		return "CONCAT(" + strings.Join(expressions, ", ") + ")"
+	}
+	return strings.Join(expressions, " || ")
+}
+
+// ExcludedSQL returns SQL for the value an upsert would have inserted into a column, in the update
+// it makes instead when the row exists
+func ExcludedSQL(column string) string {
+	if Dialect() == DriverMySQL {
+		return "VALUES(" + column + ")"
+	}
+	return "excluded." + column
+}
+
+// MonthStartSQL returns SQL for the first day of a month, from integer year and month expressions
+func MonthStartSQL(year, month string) string {
+	switch Dialect() {
+	case DriverMySQL:
+		return "DATE_ADD(MAKEDATE(" + year + ", 1), INTERVAL " + month + " - 1 MONTH)"
+	case DriverSQLite:
+		return "DATE(PRINTF('%04d-%02d-01', " + year + ", " + month + "))"
+	}
+	return "MAKE_DATE(" + year + "::integer, " + month + "::integer, 1)"
+}
+
+// CompactSQL returns SQL for a string expression in upper case with everything but letters and digits
+// removed. SQLite has no regular expressions, so there only spaces and the separators / - . and , are.
+func CompactSQL(expression string) string {
+	switch Dialect() {
+	case DriverMySQL:
+		return "REGEXP_REPLACE(UPPER(" + expression + "), '[^A-Z0-9]', '')"
+	case DriverSQLite:
+		compact := "UPPER(" + expression + ")"
+		for _, separator := range []string{" ", "/", "-", ".", ","} {
+			compact = "REPLACE(" + compact + ", '" + separator + "', '')"
+		}
+		return compact
+	}
+	return "regexp_replace(upper(" + expression + "), '[^A-Z0-9]', '', 'g')"
+}
+
+// RowJSONSQL returns SQL for each row of the table aliased t as a JSON object of its columns. Postgres
+// renders the whole row, the other databases the columns listed.
+func RowJSONSQL(columns []string) string {
+	if IsPostgres() {
+		return "row_to_json(t)::text"
+	}
+	pairs := make([]string, len(columns))
+	for i, column := range columns {
+		pairs[i] = "'" + column + "', t." + DB.Statement.Quote(column)
+	}
+	return "JSON_OBJECT(" + strings.Join(pairs, ", ") + ")"
+}
+
+// TableColumns returns the columns of every table in the database db is connected to, by table
+func TableColumns(db *gorm.DB) (map[string]map[string]bool, error) {
+	query := "SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA()"
+	switch Dialect() {
+	case DriverMySQL:
+		// MySQL names the columns of information_schema in upper case unless aliased
+		query = "SELECT table_name AS table_name, column_name AS column_name FROM information_schema.columns WHERE table_schema = DATABASE()"
+	case DriverSQLite:
+		query = "SELECT m.name AS table_name, p.name AS column_name FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE m.type = 'table'"
+	}
+
+	var columns []struct {
+		TableName  string
+		ColumnName string
+	}
+	if err := db.Raw(query).Scan(&columns).Error; err != nil {
+		return nil, err
+	}
+	tableColumns := make(map[string]map[string]bool)
+	for _, column := range columns {
+		if tableColumns[column.TableName] == nil {
+			tableColumns[column.TableName] = make(map[string]bool)
+		}
+		tableColumns[column.TableName][column.ColumnName] = true
+	}
+	return tableColumns, nil
+}
+
+// SortedColumns returns the columns of a table TableColumns found, in alphabetical order
+func SortedColumns(columns map[string]bool) []string {
+	sorted := make([]string, 0, len(columns))
+	for column := range columns {
+		sorted = append(sorted, column)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// IsDuplicateKey reports whether err, or an error it wraps, is the violation of a unique index
+func IsDuplicateKey(err error) bool {
+	translator, ok := DB.Dialector.(gorm.ErrorTranslator)
+	if !ok {
+		return false
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// adaptSchemas fits the models, which are written for Postgres, to MySQL and SQLite before anything is
+// migrated or queried. The search_vector columns, generated tsvector columns only Postgres has, are left
+// out, as searches fall back to LIKE, and jsonb columns are stored as json or text. MySQL has no partial
+// indexes, so the unique indexes that leave out deleted records are taken out for
+// createFunctionalIndexes, which needs MySQL 8.0.13 or later. The schemas gorm parses are cached, so
+// the changes last as long as the connection.
+func adaptSchemas() error {
+	if IsPostgres() {
+		return nil
+	}
+	functionalIndexes = nil
+	for _, model := range migrationModels {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+
+		if Dialect() == DriverMySQL {
+			for _, index := range stmt.Schema.ParseIndexes() {
+				if index.Class == "UNIQUE" && index.Where != "" {
+					functionalIndexes = append(functionalIndexes, functionalIndex{model: model, index: index})
+					for _, option := range index.Fields {
+						// Parsing the index marked the column itself unique
+						option.Field.Unique = false
+						removeTagSettings(option.Field, func(setting string) bool {
+							return strings.HasPrefix(strings.ToUpper(setting), "UNIQUEINDEX:"+strings.ToUpper(index.Name)+",")
+						}, "UNIQUEINDEX")
+					}
+				}
+			}
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			dataType := strings.ToLower(string(field.DataType))
+			switch {
+			case strings.HasPrefix(dataType, "tsvector"):
+				field.IgnoreMigration = true
+				removeTagSettings(field, func(setting string) bool {
+					return strings.HasPrefix(strings.ToUpper(setting), "INDEX:")
+				}, "INDEX")
+			case dataType == "jsonb" && Dialect() == DriverMySQL:
+				field.DataType = "json"
+			case dataType == "jsonb":
+				field.DataType = "text"
+			}
+		}
+	}
+	return nil
+}
+
+// removeTagSettings takes the gorm tag settings matching drop out of a parsed field. Indexes are parsed
+// from the tag itself, so it is rewritten as well as the settings parsed from it.
+func removeTagSettings(field *schema.Field, drop func(setting string) bool, keys ...string) {
+	tag := field.Tag.Get("gorm")
+	var kept []string
+	for _, setting := range strings.Split(tag, ";") {
+		if !drop(strings.TrimSpace(setting)) {
+			kept = append(kept, setting)
+		}
+	}
+	field.Tag = reflect.StructTag(strings.Replace(string(field.Tag), `gorm:"`+tag+`"`, `gorm:"`+strings.Join(kept, ";")+`"`, 1))
+	for _, key := range keys {
+		delete(field.TagSettings, key)
+	}
+}
+
+// createFunctionalIndexes creates the unique indexes adaptSchemas took out of the models on MySQL
+func createFunctionalIndexes() error {
+	for _, functional := range functionalIndexes {
+		if DB.Migrator().HasIndex(functional.model, functional.index.Name) {
+			continue
+		}
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(functional.model); err != nil {
+			return err
+		}
+		parts := make([]string, len(functional.index.Fields))
+		for i, option := range functional.index.Fields {
+			parts[i] = "(CASE WHEN " + functional.index.Where + " THEN " + stmt.Quote(option.DBName) + " END)"
+		}
+		err := DB.Exec("CREATE UNIQUE INDEX ? ON ? ("+strings.Join(parts, ", ")+")",
+			clause.Column{Name: functional.index.Name}, clause.Table{Name: stmt.Schema.Table}).Error
+		if err != nil {
+			return fmt.Errorf("creating index %s: %w", functional.index.Name, err)
+		}
+		log.Printf("Index %s created to leave out deleted records", functional.index.Name)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrOtherOrganization is returned when a record is created under an owner from another organization
+var ErrOtherOrganization = errors.New("owner belongs to another organization")
+
+type organizationKey struct{}
+
+// WithOrganization returns a context whose queries only see the organization's records. Queries
+// whose context has no organization, such as those made by background jobs, see every organization.
+func WithOrganization(ctx context.Context, organizationID uint) context.Context {
+	return context.WithValue(ctx, organizationKey{}, organizationID)
+}
+
+// OrganizationFrom returns the organization a context's queries are confined to
+func OrganizationFrom(ctx context.Context) (uint, bool) {
+	organizationID, ok := ctx.Value(organizationKey{}).(uint)
+	return organizationID, ok && organizationID != 0
+}
+
+// organizationEmployees selects the IDs of an organization's employees
+const organizationEmployees = "SELECT id FROM employees WHERE organization_id = ?"
+
+// owners lists, in order of preference, the fields through which a record without an OrganizationID
+// of its own belongs to an organization, with a query selecting the IDs that organization owns
+var owners = []struct {
+	field    string
+	subquery string
+}{
+	{"EmployeeID", organizationEmployees},
+	{"RecipientID", organizationEmployees},
+	{"RequesterID", organizationEmployees},
+	{"PerformedBy", organizationEmployees},
+	{"LeaveID", "SELECT id FROM leaves WHERE employee_id IN (" + organizationEmployees + ")"},
+	{"OnboardingProcessID", "SELECT id FROM onboarding_processes WHERE employee_id IN (" + organizationEmployees + ")"},
+	{"OffboardingProcessID", "SELECT id FROM offboarding_processes WHERE employee_id IN (" + organizationEmployees + ")"},
+	{"GrievanceID", "SELECT id FROM grievances WHERE employee_id IN (" + organizationEmployees + ")"},
+	{"ExitInterviewID", "SELECT id FROM exit_interviews WHERE employee_id IN (" + organizationEmployees + ")"},
+	{"SubscriptionID", "SELECT id FROM webhook_subscriptions WHERE organization_id = ?"},
+}
+
+// registerTenancy adds callbacks that confine queries, updates and deletes to the organization in the
+// statement's context, and stamp it on records being created
+func registerTenancy(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("tenancy:scope", scopeToOrganization); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenancy:scope", scopeToOrganization); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenancy:scope", scopeToOrganization); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenancy:scope", scopeToOrganization); err != nil {
+		return err
+	}
+	return callbacks.Create().Before("gorm:create").Register("tenancy:assign", assignOrganization)
+}
+
+// scopeToOrganization adds a condition on organization_id, or on the organization of the record's owner
+func scopeToOrganization(db *gorm.DB) {
+	organizationID, ok := OrganizationFrom(db.Statement.Context)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+	if field := db.Statement.Schema.LookUpField("OrganizationID"); field != nil {
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: organizationID},
+		}})
+		return
+	}
+	if field, subquery := ownerOf(db.Statement.Schema); field != nil {
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Expr{
+				SQL:  "? IN (" + subquery + ")",
+				Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: field.DBName}, organizationID},
+			},
+		}})
+	}
+}
+
+// assignOrganization sets OrganizationID on new records to the context's organization, and refuses new
+// records whose owner belongs to another organization
+func assignOrganization(db *gorm.DB) {
+	organizationID, ok := OrganizationFrom(db.Statement.Context)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+	if field := db.Statement.Schema.LookUpField("OrganizationID"); field != nil {
+		eachRecord(db, func(record reflect.Value) {
+			if err := field.Set(db.Statement.Context, record, organizationID); err != nil {
+				db.AddError(err)
+			}
+		})
+		return
+	}
+	field, subquery := ownerOf(db.Statement.Schema)
+	if field == nil {
+		return
+	}
+	ownerIDs := map[interface{}]bool{}
+	eachRecord(db, func(record reflect.Value) {
+		if ownerID, zero := field.ValueOf(db.Statement.Context, record); !zero {
+			ownerIDs[reflect.Indirect(reflect.ValueOf(ownerID)).Interface()] = true
+		}
+	})
+	for ownerID := range ownerIDs {
+		var count int64
+		err := db.Session(&gorm.Session{NewDB: true}).
+			Raw("SELECT COUNT(*) FROM ("+subquery+") AS owners WHERE id = ?", organizationID, ownerID).Scan(&count).Error
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		if count == 0 {
+			db.AddError(ErrOtherOrganization)
+			return
+		}
+	}
+}
+
+func ownerOf(s *schema.Schema) (*schema.Field, string) {
+	for _, owner := range owners {
+		if field := s.LookUpField(owner.field); field != nil {
+			return field, owner.subquery
+		}
+	}
+	return nil, ""
+}
+
+// eachRecord calls fn with every struct being created, whether a single record or a slice
+func eachRecord(db *gorm.DB, fn func(record reflect.Value)) {
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			record := reflect.Indirect(value.Index(i))
+			if record.Kind() == reflect.Struct {
+				fn(record)
+			}
+		}
+	case reflect.Struct:
+		fn(value)
+	}
+}
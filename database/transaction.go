@@ -0,0 +1,24 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+// WithTransaction returns a context whose queries made through Session run in tx, so that the changes
+// of several requests are committed or rolled back together
+func WithTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+// Session returns db bound to ctx, or the transaction ctx carries from WithTransaction. A transaction
+// started on the result of a session in a transaction is nested in it as a savepoint.
+func Session(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
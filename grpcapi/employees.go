@@ -0,0 +1,99 @@
+package grpcapi
+
+import (
+	"context"
+	"hrms-api/models"
+	hrmsv1 "hrms-api/proto/hrms/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPerPage = 25
+	maxPerPage     = 100
+)
+
+type employeeServer struct {
+	hrmsv1.UnimplementedEmployeeServiceServer
+}
+
+// GetEmployee returns one employee
+func (s *employeeServer) GetEmployee(ctx context.Context, req *hrmsv1.GetEmployeeRequest) (*hrmsv1.Employee, error) {
+	if !callerFrom(ctx).canAccess(ctx, uint(req.GetId())) {
+		return nil, status.Error(codes.PermissionDenied, "not allowed to read this employee")
+	}
+
+	var employee models.Employee
+	if err := callDB(ctx).Preload("Employment").First(&employee, req.GetId()).Error; err != nil {
+		return nil, status.Error(codes.NotFound, "employee not found")
+	}
+	return toProtoEmployee(employee), nil
+}
+
+// ListEmployees lists employees, excluding admin accounts (services and admins only)
+func (s *employeeServer) ListEmployees(ctx context.Context, req *hrmsv1.ListEmployeesRequest) (*hrmsv1.ListEmployeesResponse, error) {
+	if !callerFrom(ctx).unrestricted() {
+		return nil, status.Error(codes.PermissionDenied, "not allowed to list employees")
+	}
+
+	page, perPage := int(req.GetPage()), int(req.GetPerPage())
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	query := callDB(ctx).Model(&models.Employee{}).Where("role != ?", models.RoleAdmin)
+	if department := req.GetDepartment(); department != "" {
+		query = query.Where("department = ?", department)
+	}
+
+	response := &hrmsv1.ListEmployeesResponse{}
+	if err := query.Count(&response.Total).Error; err != nil {
+		return nil, status.Error(codes.Internal, "failed to count employees")
+	}
+
+	var employees []models.Employee
+	if err := query.Preload("Employment").Order("id").Offset((page - 1) * perPage).Limit(perPage).Find(&employees).Error; err != nil {
+		return nil, status.Error(codes.Internal, "failed to fetch employees")
+	}
+	for _, employee := range employees {
+		response.Employees = append(response.Employees, toProtoEmployee(employee))
+	}
+	return response, nil
+}
+
+func toProtoEmployee(employee models.Employee) *hrmsv1.Employee {
+	result := &hrmsv1.Employee{
+		Id:         uint32(employee.ID),
+		Firstname:  employee.Firstname,
+		Lastname:   employee.Lastname,
+		Department: employee.Department,
+		Role:       string(employee.Role),
+		Status:     employee.Status,
+	}
+	if employee.EmployeeNumber != nil {
+		result.EmployeeNumber = *employee.EmployeeNumber
+	}
+	if employee.Email != nil {
+		result.Email = *employee.Email
+	}
+	if employee.JobTitle != nil {
+		result.JobTitle = *employee.JobTitle
+	}
+	if employee.PositionID != nil {
+		result.PositionId = uint32(*employee.PositionID)
+	}
+	if employee.DateJoined != nil {
+		result.DateJoined = employee.DateJoined.Format("2006-01-02")
+	}
+	if employee.Employment != nil && employee.Employment.ManagerID != nil {
+		result.ManagerId = uint32(*employee.Employment.ManagerID)
+	}
+	return result
+}
@@ -0,0 +1,8 @@
+// Package grpcapi serves employee and leave data over gRPC to internal services such as payroll and
+// identity, when GRPC_PORT is set. The generated code in proto/hrms/v1 is committed; after changing
+// hrms.proto, regenerate it with protoc, protoc-gen-go and protoc-gen-go-grpc installed:
+//
+//	go generate ./grpcapi
+package grpcapi
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=hrms-api --go-grpc_out=.. --go-grpc_opt=module=hrms-api hrms/v1/hrms.proto
@@ -0,0 +1,186 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"hrms-api/models"
+	hrmsv1 "hrms-api/proto/hrms/v1"
+	"hrms-api/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultEventLimit = 100
+	maxEventLimit     = 500
+	watchInterval     = 5 * time.Second
+)
+
+type leaveServer struct {
+	hrmsv1.UnimplementedLeaveServiceServer
+}
+
+// GetLeaveBalance returns an employee's annual leave balance for the current leave year
+func (s *leaveServer) GetLeaveBalance(ctx context.Context, req *hrmsv1.GetLeaveBalanceRequest) (*hrmsv1.LeaveBalance, error) {
+	employeeID := uint(req.GetEmployeeId())
+	if !callerFrom(ctx).canAccess(ctx, employeeID) {
+		return nil, status.Error(codes.PermissionDenied, "not allowed to read this employee's leave")
+	}
+
+	var employee models.Employee
+	if err := callDB(ctx).First(&employee, employeeID).Error; err != nil {
+		return nil, status.Error(codes.NotFound, "employee not found")
+	}
+
+	summary, err := utils.GetAnnualLeaveSummary(callDB(ctx), employeeID)
+	if err == utils.ErrNoAnnualLeaveType {
+		return nil, status.Error(codes.NotFound, "annual leave type not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to calculate leave balance")
+	}
+
+	return &hrmsv1.LeaveBalance{
+		EmployeeId:    uint32(employeeID),
+		LeaveTypeId:   uint32(summary.LeaveType.ID),
+		LeaveTypeName: summary.LeaveType.Name,
+		MaxDays:       int32(summary.LeaveType.MaxDays),
+		UsedDays:      int32(summary.UsedDays),
+		Balance:       summary.Balance,
+	}, nil
+}
+
+// ListLeaveEvents returns leaves created or changed after the cursor, oldest change first. Each event is
+// the leave's state at its latest change.
+func (s *leaveServer) ListLeaveEvents(ctx context.Context, req *hrmsv1.ListLeaveEventsRequest) (*hrmsv1.ListLeaveEventsResponse, error) {
+	if err := authorizeLeaveEvents(ctx, uint(req.GetEmployeeId())); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit < 1 {
+		limit = defaultEventLimit
+	}
+	if limit > maxEventLimit {
+		limit = maxEventLimit
+	}
+
+	events, err := loadLeaveEvents(ctx, req.GetAfterCursor(), uint(req.GetEmployeeId()), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &hrmsv1.ListLeaveEventsResponse{Events: events, NextCursor: req.GetAfterCursor()}
+	if len(events) > 0 {
+		response.NextCursor = events[len(events)-1].Cursor
+	}
+	return response, nil
+}
+
+// WatchLeaveEvents streams leaves created or changed after the cursor, polling for new changes until the
+// client disconnects
+func (s *leaveServer) WatchLeaveEvents(req *hrmsv1.WatchLeaveEventsRequest, stream hrmsv1.LeaveService_WatchLeaveEventsServer) error {
+	if err := authorizeLeaveEvents(stream.Context(), uint(req.GetEmployeeId())); err != nil {
+		return err
+	}
+
+	cursor := req.GetAfterCursor()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := loadLeaveEvents(stream.Context(), cursor, uint(req.GetEmployeeId()), maxEventLimit)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			cursor = event.Cursor
+		}
+		// Keep draining while there is a backlog
+		if len(events) == maxEventLimit {
+			continue
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-shuttingDown:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+// authorizeLeaveEvents lets services and admins read the leave events of every employee in their
+// organization, and other callers only those of employees they may access
+func authorizeLeaveEvents(ctx context.Context, employeeID uint) error {
+	c := callerFrom(ctx)
+	if employeeID == 0 && !c.unrestricted() {
+		return status.Error(codes.PermissionDenied, "employee_id is required")
+	}
+	if employeeID != 0 && !c.canAccess(ctx, employeeID) {
+		return status.Error(codes.PermissionDenied, "not allowed to read this employee's leave")
+	}
+	return nil
+}
+
+func loadLeaveEvents(ctx context.Context, cursor string, employeeID uint, limit int) ([]*hrmsv1.LeaveEvent, error) {
+	query := callDB(ctx).Preload("LeaveType")
+	if cursor != "" {
+		updatedAt, leaveID, err := parseLeaveCursor(cursor)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		query = query.Where("updated_at > ? OR (updated_at = ? AND id > ?)", updatedAt, updatedAt, leaveID)
+	}
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+
+	var leaves []models.Leave
+	if err := query.Order("updated_at, id").Limit(limit).Find(&leaves).Error; err != nil {
+		return nil, status.Error(codes.Internal, "failed to fetch leave events")
+	}
+
+	events := make([]*hrmsv1.LeaveEvent, 0, len(leaves))
+	for _, leave := range leaves {
+		events = append(events, &hrmsv1.LeaveEvent{
+			Cursor:        fmt.Sprintf("%d-%d", leave.UpdatedAt.UnixMicro(), leave.ID),
+			LeaveId:       uint32(leave.ID),
+			EmployeeId:    uint32(leave.EmployeeID),
+			LeaveTypeId:   uint32(leave.LeaveTypeID),
+			LeaveTypeName: leave.LeaveType.Name,
+			StartDate:     leave.StartDate.Format("2006-01-02"),
+			EndDate:       leave.EndDate.Format("2006-01-02"),
+			Days:          int32(leave.GetDuration()),
+			Status:        string(leave.Status),
+			UpdatedAt:     timestamppb.New(leave.UpdatedAt),
+		})
+	}
+	return events, nil
+}
+
+// parseLeaveCursor splits a "<updated_at unix micro>-<leave id>" cursor
+func parseLeaveCursor(cursor string) (time.Time, uint64, error) {
+	micros, id, found := strings.Cut(cursor, "-")
+	if !found {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	updatedAt, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	leaveID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.UnixMicro(updatedAt), leaveID, nil
+}
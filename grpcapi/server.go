@@ -0,0 +1,211 @@
+package grpcapi
+
+import (
+	"context"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	hrmsv1 "hrms-api/proto/hrms/v1"
+	"hrms-api/utils"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var (
+	server       *grpc.Server
+	shuttingDown = make(chan struct{}) // Closed by Stop so watch streams end instead of holding shutdown open
+)
+
+// Start serves the gRPC API on the configured port in the background
+func Start() {
+	if config.AppConfig.GRPCPort == "" {
+		return
+	}
+
+	listener, err := net.Listen("tcp", "0.0.0.0:"+config.AppConfig.GRPCPort)
+	if err != nil {
+		log.Printf("Failed to start gRPC server: %v", err)
+		return
+	}
+
+	server = grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAuthInterceptor),
+		grpc.StreamInterceptor(streamAuthInterceptor),
+	)
+	hrmsv1.RegisterEmployeeServiceServer(server, &employeeServer{})
+	hrmsv1.RegisterLeaveServiceServer(server, &leaveServer{})
+
+	go func() {
+		log.Printf("gRPC server starting on %s", listener.Addr())
+		if err := server.Serve(listener); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
+}
+
+// Stop gracefully stops the gRPC server, ending watch streams and waiting for in-flight calls. Calls
+// still running when ctx is done are cancelled.
+func Stop(ctx context.Context) {
+	if server == nil {
+		return
+	}
+	close(shuttingDown)
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// caller is the authenticated client of a gRPC call: either an internal service using an API key or an
+// employee using the same JWT as the REST API. Its call only sees the data of its organization.
+type caller struct {
+	service        bool
+	organizationID uint
+	employeeID     uint
+	role           models.Role
+}
+
+type callerKey struct{}
+
+func unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream carries the caller in the stream's context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate reads an x-api-key or "authorization: Bearer <token>" header from the call metadata, and
+// confines the call's queries to the caller's organization: the token's, or for an API key the one named
+// by the x-organization-id header. Calls with an API key are counted against its limits and refused once
+// it is over them.
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		apiKey, ok := config.AppConfig.FindAPIKey(keys[0])
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		organizationID, err := requestedOrganization(ctx, md)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.AllowAPIKeyCall(apiKey, method); err != nil {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return withCaller(ctx, &caller{service: true, organizationID: organizationID}), nil
+	}
+
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "API key or bearer token required")
+	}
+	parts := strings.Split(authorization[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+	claims, err := utils.ValidateToken(parts[1])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	// Tokens issued before organizations were introduced belong to the default one, as in middleware.Tenancy
+	organizationID := claims.OrganizationID
+	if organizationID == 0 {
+		organizationID = models.DefaultOrganizationID
+	}
+	return withCaller(ctx, &caller{organizationID: organizationID, employeeID: claims.UserID, role: claims.Role}), nil
+}
+
+// requestedOrganization returns the organization an API key call names in its x-organization-id header
+func requestedOrganization(ctx context.Context, md metadata.MD) (uint, error) {
+	values := md.Get("x-organization-id")
+	if len(values) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "x-organization-id header required with an API key")
+	}
+	organizationID, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil || organizationID == 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid x-organization-id header")
+	}
+	var count int64
+	if err := database.DB.WithContext(ctx).Model(&models.Organization{}).Where("id = ?", organizationID).Count(&count).Error; err != nil {
+		return 0, status.Error(codes.Internal, "failed to look up organization")
+	}
+	if count == 0 {
+		return 0, status.Error(codes.NotFound, "organization not found")
+	}
+	return uint(organizationID), nil
+}
+
+// withCaller carries the caller in the context, and confines queries made with it to the caller's
+// organization
+func withCaller(ctx context.Context, c *caller) context.Context {
+	return database.WithOrganization(context.WithValue(ctx, callerKey{}, c), c.organizationID)
+}
+
+// callDB returns the database confined to the caller's organization
+func callDB(ctx context.Context) *gorm.DB {
+	return database.Session(ctx, database.DB)
+}
+
+func callerFrom(ctx context.Context) *caller {
+	c, _ := ctx.Value(callerKey{}).(*caller)
+	if c == nil {
+		return &caller{}
+	}
+	return c
+}
+
+// unrestricted reports whether the caller may read data for every employee of its organization
+func (c *caller) unrestricted() bool {
+	return c.service || c.role == models.RoleAdmin
+}
+
+// canAccess reports whether the caller may read an employee's data: services and admins may read
+// anyone in their organization, managers their direct reports and everyone else only themselves
+func (c *caller) canAccess(ctx context.Context, employeeID uint) bool {
+	if c.unrestricted() || (c.employeeID != 0 && c.employeeID == employeeID) {
+		return true
+	}
+	if c.role != models.RoleManager {
+		return false
+	}
+	var count int64
+	callDB(ctx).Model(&models.EmploymentDetails{}).
+		Where("employee_id = ? AND manager_id = ?", employeeID, c.employeeID).Count(&count)
+	return count > 0
+}
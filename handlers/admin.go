@@ -4,15 +4,18 @@ import (
 	"encoding/csv"
 	"fmt"
 	"hrms-api/database"
+	"hrms-api/i18n"
 	"hrms-api/models"
 	"hrms-api/utils"
 	"io"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // CreateLeaveTypeRequest represents data for creating a leave type
@@ -20,11 +23,53 @@ type CreateLeaveTypeRequest struct {
 	Name        string `json:"name" binding:"required" example:"Sabbatical"`
 	MaxDays     int    `json:"max_days" binding:"required,min=1" example:"30"`
 	UsesBalance *bool  `json:"uses_balance,omitempty" example:"false"` // If true, leave deducts from balance; if false, record-only. Default false for new types.
+	Unplanned   *bool  `json:"unplanned,omitempty" example:"false"`    // If true, taken at short notice like sick leave rather than booked ahead, for absence rates. Default false for new types.
+	// How often balance leave accrues: monthly (the default for new types), biweekly or annual (granted up front each leave year)
+	AccrualFrequency *models.AccrualFrequency `json:"accrual_frequency,omitempty" example:"monthly"`
+	// Display fields: left as they are when omitted, cleared with an empty string
+	Color        *string `json:"color,omitempty" example:"#4CAF50"` // Hex color, #RRGGBB
+	Icon         *string `json:"icon,omitempty" binding:"omitempty,max=50" example:"beach_access"`
+	DisplayOrder *int    `json:"display_order,omitempty" example:"1"`
+	Description  *string `json:"description,omitempty" binding:"omitempty,max=500" example:"Unpaid leave of up to a year after five years of service"`
+}
+
+// leaveTypeColor is the form of a leave type's display color
+var leaveTypeColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// validLeaveTypeRequest checks the fields of a leave type request that binding does not, responding
+// with the error when one is invalid
+func validLeaveTypeRequest(c *gin.Context, req CreateLeaveTypeRequest) bool {
+	if req.AccrualFrequency != nil && !utils.ValidAccrualFrequency(*req.AccrualFrequency) {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid accrual frequency. Use monthly, biweekly or annual")
+		return false
+	}
+	if req.Color != nil && *req.Color != "" && !leaveTypeColor.MatchString(*req.Color) {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid color. Use a hex color such as #4CAF50")
+		return false
+	}
+	return true
+}
+
+// applyLeaveTypeDisplay sets the display fields a request gives on a leave type
+func applyLeaveTypeDisplay(leaveType *models.LeaveType, req CreateLeaveTypeRequest) {
+	if req.Color != nil {
+		leaveType.Color = strings.ToUpper(*req.Color)
+	}
+	if req.Icon != nil {
+		leaveType.Icon = *req.Icon
+	}
+	if req.DisplayOrder != nil {
+		leaveType.DisplayOrder = *req.DisplayOrder
+	}
+	if req.Description != nil {
+		leaveType.Description = *req.Description
+	}
 }
 
 // CreateEmployeeRequest represents data for creating an employee/manager (uses NRC)
 type CreateEmployeeRequest struct {
 	NRC        string      `json:"nrc" binding:"required" example:"555666/77/8"`
+	NRCCountry string      `json:"nrc_country,omitempty" example:"ZM"` // Country whose national ID format the NRC follows, defaults to the organization's default format
 	Firstname  string      `json:"firstname" binding:"required" example:"Jane"`
 	Lastname   string      `json:"lastname" binding:"required" example:"Smith"`
 	Email      string      `json:"email" binding:"omitempty,email" example:"jane@example.com"` // Optional now
@@ -51,6 +96,7 @@ type UpdateEmployeeRequest struct {
 	Email      string      `json:"email" example:"jane.doe@example.com"`
 	Department string      `json:"department" example:"Finance"`
 	Role       models.Role `json:"role" example:"admin"`
+	Timezone   *string     `json:"timezone" example:"Africa/Johannesburg"` // Empty to use the company timezone
 }
 
 // MessageResponse represents a simple message response
@@ -60,7 +106,7 @@ type MessageResponse struct {
 
 // GetLeaveTypes returns all leave types
 // @Summary Get all leave types
-// @Description Get list of all available leave types (Admin only)
+// @Description Get list of all available leave types, in display order, with the color, icon and description to show them with (Admin only)
 // @Tags Admin - Leave Types
 // @Produce json
 // @Security BearerAuth
@@ -70,8 +116,8 @@ type MessageResponse struct {
 // @Router /api/leave-types [get]
 func GetLeaveTypes(c *gin.Context) {
 	var leaveTypes []models.LeaveType
-	if err := database.DB.Find(&leaveTypes).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leave types"})
+	if err := requestDB(c).Order("display_order, id").Find(&leaveTypes).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leave types")
 		return
 	}
 
@@ -87,14 +133,18 @@ func GetLeaveTypes(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body CreateLeaveTypeRequest true "Leave type data"
 // @Success 201 {object} models.LeaveType
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /api/leave-types [post]
 func CreateLeaveType(c *gin.Context) {
 	var req CreateLeaveTypeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+
+	if !validLeaveTypeRequest(c, req) {
 		return
 	}
 
@@ -102,9 +152,16 @@ func CreateLeaveType(c *gin.Context) {
 		Name:    req.Name,
 		MaxDays: req.MaxDays,
 	}
+	if req.AccrualFrequency != nil {
+		leaveType.AccrualFrequency = *req.AccrualFrequency
+	}
+	if req.Unplanned != nil {
+		leaveType.Unplanned = *req.Unplanned
+	}
+	applyLeaveTypeDisplay(&leaveType, req)
 
-	if err := database.DB.Create(&leaveType).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create leave type"})
+	if err := requestDB(c).Create(&leaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create leave type")
 		return
 	}
 
@@ -113,7 +170,7 @@ func CreateLeaveType(c *gin.Context) {
 
 // UpdateLeaveType updates an existing leave type
 // @Summary Update leave type
-// @Description Update an existing leave type (Admin only)
+// @Description Update an existing leave type. Changing its accrual frequency rebuilds the accruals made on the old schedule the next time balances are read; initial balances and manual adjustments are kept (Admin only)
 // @Tags Admin - Leave Types
 // @Accept json
 // @Produce json
@@ -121,7 +178,7 @@ func CreateLeaveType(c *gin.Context) {
 // @Param id path int true "Leave Type ID"
 // @Param request body CreateLeaveTypeRequest true "Leave type data"
 // @Success 200 {object} models.LeaveType
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -129,19 +186,23 @@ func CreateLeaveType(c *gin.Context) {
 func UpdateLeaveType(c *gin.Context) {
 	leaveTypeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave type ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave type ID")
 		return
 	}
 
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, uint(leaveTypeID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave type not found"})
+	if err := requestDB(c).First(&leaveType, uint(leaveTypeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Leave type not found")
 		return
 	}
 
 	var req CreateLeaveTypeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+
+	if !validLeaveTypeRequest(c, req) {
 		return
 	}
 
@@ -150,9 +211,29 @@ func UpdateLeaveType(c *gin.Context) {
 	if req.UsesBalance != nil {
 		leaveType.UsesBalance = *req.UsesBalance
 	}
+	if req.Unplanned != nil {
+		leaveType.Unplanned = *req.Unplanned
+	}
+	frequencyChanged := req.AccrualFrequency != nil && *req.AccrualFrequency != utils.AccrualFrequencyOf(leaveType)
+	if req.AccrualFrequency != nil {
+		leaveType.AccrualFrequency = *req.AccrualFrequency
+	}
+	applyLeaveTypeDisplay(&leaveType, req)
 
-	if err := database.DB.Save(&leaveType).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update leave type"})
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Save(&leaveType).Error; err != nil {
+			return err
+		}
+		if !frequencyChanged {
+			return nil
+		}
+		// Accruals made on the old schedule are rebuilt on the new one the next time balances are
+		// brought up to date. Accruals with notes, such as initial balances and manual adjustments, are kept.
+		return tx.Where("leave_type_id = ? AND accrual_month IS NOT NULL AND (notes IS NULL OR notes = '')", leaveType.ID).
+			Delete(&models.LeaveAccrual{}).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update leave type")
 		return
 	}
 
@@ -174,12 +255,12 @@ func UpdateLeaveType(c *gin.Context) {
 func DeleteLeaveType(c *gin.Context) {
 	leaveTypeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave type ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave type ID")
 		return
 	}
 
-	if err := database.DB.Delete(&models.LeaveType{}, uint(leaveTypeID)).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete leave type"})
+	if err := requestDB(c).Delete(&models.LeaveType{}, uint(leaveTypeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete leave type")
 		return
 	}
 
@@ -195,7 +276,7 @@ func DeleteLeaveType(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body CreateEmployeeRequest true "Employee data"
 // @Success 201 {object} models.Employee
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse "NRC or email already exists"
@@ -203,66 +284,56 @@ func DeleteLeaveType(c *gin.Context) {
 func CreateEmployee(c *gin.Context) {
 	var req CreateEmployeeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Validate role - only employee or manager allowed here
 	if req.Role != models.RoleEmployee && req.Role != models.RoleManager {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Use /api/admins endpoint to create admin accounts"})
+		utils.RespondError(c, http.StatusBadRequest, "Use /api/admins endpoint to create admin accounts")
 		return
 	}
 
-	// Check if NRC or email already exists (including soft-deleted records)
+	nrc, ok := normalizeNRC(c, c.GetUint("organization_id"), req.NRCCountry, req.NRC)
+	if !ok {
+		return
+	}
+
+	// Deleted employees do not hold on to their NRC or email, so former employees can be hired again
 	var existingEmployee models.Employee
 	emailCheck := req.Email
 	if emailCheck == "" {
-		emailCheck = "NO_EMAIL_" + req.NRC // Use a placeholder if email is empty
-	}
-	if err := database.DB.Unscoped().Where("nrc = ? OR (email IS NOT NULL AND email = ?)", req.NRC, emailCheck).First(&existingEmployee).Error; err == nil {
-		// If found and it's soft-deleted, permanently delete it to allow reuse
-		if existingEmployee.DeletedAt.Valid {
-			// Permanently delete the soft-deleted employee to allow NRC/email reuse
-			if err := database.DB.Unscoped().Delete(&existingEmployee).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clean up deleted employee record"})
-				return
-			}
-		} else {
-			// Active employee with this NRC/email exists
-			c.JSON(http.StatusConflict, gin.H{"error": "NRC or email already exists"})
-			return
-		}
+		emailCheck = "NO_EMAIL_" + nrc // Use a placeholder if email is empty
+	}
+	if err := requestDB(c).Where("nrc = ? OR "+utils.NationalIDCompactSQL()+" = ? OR (email IS NOT NULL AND email = ?)", nrc, utils.CompactNationalID(nrc), emailCheck).First(&existingEmployee).Error; err == nil {
+		utils.RespondError(c, http.StatusConflict, "NRC or email already exists")
+		return
+	}
+	previousEmployeeID, err := formerEmployeeID(requestDB(c), nrc)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create employee")
+		return
 	}
 
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to hash password")
 		return
 	}
 
-	nrc := req.NRC
 	var email *string
 	if req.Email != "" {
 		email = &req.Email
 	}
 	employee := models.Employee{
-		NRC:          &nrc,
-		Firstname:    req.Firstname,
-		Lastname:     req.Lastname,
-		Email:        email,
-		PasswordHash: hashedPassword,
-		Department:   req.Department,
-		Role:         req.Role,
-	}
-
-	if err := database.DB.Create(&employee).Error; err != nil {
-		// Check for duplicate key constraint violation
-		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
-			c.JSON(http.StatusConflict, gin.H{"error": "NRC or email already exists in the database"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create employee: " + err.Error()})
-		return
+		NRC:                &nrc,
+		Firstname:          req.Firstname,
+		Lastname:           req.Lastname,
+		Email:              email,
+		PasswordHash:       hashedPassword,
+		Department:         req.Department,
+		Role:               req.Role,
+		PreviousEmployeeID: previousEmployeeID,
 	}
 
 	// Automatically create EmploymentDetails with hire date
@@ -285,20 +356,27 @@ func CreateEmployee(c *gin.Context) {
 
 	// Create EmploymentDetails
 	employmentDetails := models.EmploymentDetails{
-		EmployeeID:       employee.ID,
 		EmploymentType:   models.EmploymentTypeFullTime,
 		EmploymentStatus: models.EmploymentStatusActive,
 		HireDate:         hireDate,
 		StartDate:        hireDate, // Set start date same as hire date
 	}
 
-	if err := database.DB.Create(&employmentDetails).Error; err != nil {
-		// Log error but don't fail employee creation
-		// EmploymentDetails can be added later via the employment endpoint
-		c.JSON(http.StatusCreated, gin.H{
-			"employee": employee,
-			"warning":  "Employee created but employment details could not be created. Please add them manually.",
-		})
+	// The employee and their employment details are created together
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&employee).Error; err != nil {
+			return err
+		}
+		employmentDetails.EmployeeID = employee.ID
+		return tx.Create(&employmentDetails).Error
+	})
+	if err != nil {
+		// Check for duplicate key constraint violation
+		if database.IsDuplicateKey(err) {
+			utils.RespondError(c, http.StatusConflict, "NRC or email already exists in the database")
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create employee: "+err.Error())
 		return
 	}
 
@@ -315,7 +393,7 @@ func CreateEmployee(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body CreateAdminRequest true "Admin data"
 // @Success 201 {object} models.Employee
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse "Username or email already exists"
@@ -323,34 +401,24 @@ func CreateEmployee(c *gin.Context) {
 func CreateAdmin(c *gin.Context) {
 	var req CreateAdminRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	// Check if username or email already exists (including soft-deleted records)
+	// Deleted accounts do not hold on to their username or email
 	var existingEmployee models.Employee
 	emailCheck := req.Email
 	if emailCheck == "" {
 		emailCheck = "NO_EMAIL_" + req.Username // Use a placeholder if email is empty
 	}
-	if err := database.DB.Unscoped().Where("username = ? OR (email IS NOT NULL AND email = ?)", req.Username, emailCheck).First(&existingEmployee).Error; err == nil {
-		// If found and it's soft-deleted, permanently delete it to allow reuse
-		if existingEmployee.DeletedAt.Valid {
-			// Permanently delete the soft-deleted employee to allow username/email reuse
-			if err := database.DB.Unscoped().Delete(&existingEmployee).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clean up deleted employee record"})
-				return
-			}
-		} else {
-			// Active employee with this username/email exists
-			c.JSON(http.StatusConflict, gin.H{"error": "Username or email already exists"})
-			return
-		}
+	if err := requestDB(c).Where("username = ? OR (email IS NOT NULL AND email = ?)", req.Username, emailCheck).First(&existingEmployee).Error; err == nil {
+		utils.RespondError(c, http.StatusConflict, "Username or email already exists")
+		return
 	}
 
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to hash password")
 		return
 	}
 
@@ -369,13 +437,13 @@ func CreateAdmin(c *gin.Context) {
 		Role:         models.RoleAdmin,
 	}
 
-	if err := database.DB.Create(&employee).Error; err != nil {
+	if err := requestDB(c).Create(&employee).Error; err != nil {
 		// Check for duplicate key constraint violation
-		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
-			c.JSON(http.StatusConflict, gin.H{"error": "Username or email already exists in the database"})
+		if database.IsDuplicateKey(err) {
+			utils.RespondError(c, http.StatusConflict, "Username or email already exists in the database")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create admin: " + err.Error()})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create admin: "+err.Error())
 		return
 	}
 
@@ -383,39 +451,65 @@ func CreateAdmin(c *gin.Context) {
 	c.JSON(http.StatusCreated, employee)
 }
 
+// employeeListFields are the filters and sort keys accepted by the employee list
+var employeeListFields = ListFields{
+	Filters: map[string]string{"department": "department", "role": "role"},
+	Sorts: map[string]string{
+		"id": "id", "firstname": "firstname", "lastname": "lastname", "department": "department",
+		"role": "role", "created_at": "created_at",
+	},
+	DefaultSort: "id",
+}
+
+// employeeSearchColumns are the columns the search_vector of employees is built from
+var employeeSearchColumns = []string{"firstname", "lastname", "email"}
+
 // GetEmployees returns all employees
 // @Summary Get all employees
 // @Description Get list of all employees (Admin only). Supports search query parameter for filtering by name.
 // @Tags Admin - Employees
 // @Produce json
 // @Security BearerAuth
-// @Param search query string false "Search term to filter employees by name (firstname, lastname, or full name)"
-// @Success 200 {array} models.Employee
+// @Param search query string false "Search term matching the start of words in employees' names and email"
+// @Param department query string false "Department filter (comma separated for several)"
+// @Param role query string false "Role filter (employee, manager)"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, firstname, lastname, department, role, created_at)"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Employee}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /api/employees [get]
 func GetEmployees(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
 	var employees []models.Employee
-	query := database.DB.Where("role != ?", models.RoleAdmin) // Exclude admin users
+	query := requestDB(c).Where("role != ?", models.RoleAdmin) // Exclude admin users
 	
 	// Support search parameter for filtering by name
 	search := c.Query("search")
 	if search != "" {
-		searchPattern := "%" + strings.ToLower(search) + "%"
-		query = query.Where(
-			"LOWER(firstname) LIKE ? OR LOWER(lastname) LIKE ? OR LOWER(CONCAT(firstname, ' ', lastname)) LIKE ?",
-			searchPattern, searchPattern, searchPattern,
-		)
+		query = utils.WhereSearch(query, search, employeeSearchColumns...)
 	}
 	
-	if err := query.Preload("Employment").
-		Select("id", "nrc", "username", "firstname", "lastname", "email", "department", "role", "created_at", "updated_at").
-		Find(&employees).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch employees"})
+	query, ok = applyListQuery(c, query, employeeListFields)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, employees)
+	query = query.Preload("Employment").
+		Select("id", "nrc", "username", "firstname", "lastname", "email", "department", "role", "created_at", "updated_at")
+	response, err := paginate(query, pagination, &employees)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetEmployee returns a specific employee by ID
@@ -434,14 +528,14 @@ func GetEmployees(c *gin.Context) {
 func GetEmployee(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
 	var employee models.Employee
-	if err := database.DB.Select("id", "nrc", "username", "firstname", "lastname", "email", "department", "role", "created_at", "updated_at").
+	if err := requestDB(c).Select("id", "nrc", "username", "firstname", "lastname", "email", "department", "role", "created_at", "updated_at").
 		First(&employee, uint(employeeID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
@@ -458,7 +552,7 @@ func GetEmployee(c *gin.Context) {
 // @Param id path int true "Employee ID"
 // @Param request body UpdateEmployeeRequest true "Employee data to update"
 // @Success 200 {object} models.Employee
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -466,13 +560,13 @@ func GetEmployee(c *gin.Context) {
 func UpdateEmployee(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
 	var employee models.Employee
-	if err := database.DB.First(&employee, uint(employeeID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
@@ -485,6 +579,7 @@ func UpdateEmployee(c *gin.Context) {
 		Role                        models.Role `json:"role"`
 		Phone                       *string     `json:"phone"`
 		Mobile                      *string     `json:"mobile"`
+		BadgeNumber                 *string     `json:"badge_number"`
 		Address                     *string     `json:"address"`
 		City                        *string     `json:"city"`
 		PostalCode                  *string     `json:"postal_code"`
@@ -501,10 +596,11 @@ func UpdateEmployee(c *gin.Context) {
 		TaxID                       *string     `json:"tax_id"`
 		Notes                       *string     `json:"notes"`
 		HireDate                    *string     `json:"hire_date"`
+		Timezone                    *string     `json:"timezone"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -520,6 +616,20 @@ func UpdateEmployee(c *gin.Context) {
 	if req.Department != "" {
 		employee.Department = req.Department
 	}
+	if req.Mobile != nil {
+		// Text message notifications are sent to it
+		employee.Mobile = req.Mobile
+		if *req.Mobile == "" {
+			employee.Mobile = nil
+		}
+	}
+	if req.BadgeNumber != nil {
+		// Clock events from attendance terminals are matched to the employee by it
+		employee.BadgeNumber = req.BadgeNumber
+		if *req.BadgeNumber == "" {
+			employee.BadgeNumber = nil
+		}
+	}
 	if req.Role != "" {
 		validRoles := []models.Role{models.RoleEmployee, models.RoleManager, models.RoleAdmin}
 		valid := false
@@ -530,14 +640,30 @@ func UpdateEmployee(c *gin.Context) {
 			}
 		}
 		if !valid {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid role")
 			return
 		}
 		employee.Role = req.Role
 	}
+	if req.Timezone != nil {
+		if *req.Timezone != "" {
+			if _, err := time.LoadLocation(*req.Timezone); err != nil {
+				utils.RespondError(c, http.StatusBadRequest, "Invalid timezone. Use an IANA name such as Africa/Lusaka")
+				return
+			}
+		}
+		employee.Timezone = *req.Timezone
+	}
 
-	if err := database.DB.Save(&employee).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update employee"})
+	before := utils.TakeEmploymentSnapshot(requestDB(c), employee.ID)
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Save(&employee).Error; err != nil {
+			return err
+		}
+		return recordEmploymentChange(tx, c, employee.ID, before, "Employee profile updated")
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update employee")
 		return
 	}
 
@@ -555,7 +681,7 @@ func UpdateEmployee(c *gin.Context) {
 // @Param id path int true "Employee ID"
 // @Param request body ChangePasswordRequest true "Password change data"
 // @Success 200 {object} MessageResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -563,26 +689,26 @@ func UpdateEmployee(c *gin.Context) {
 func ChangePassword(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
 	// Get current user ID from token
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		utils.RespondError(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	// Users can only change their own password
 	if uint(employeeID) != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only change your own password"})
+		utils.RespondError(c, http.StatusForbidden, "You can only change your own password")
 		return
 	}
 
 	var employee models.Employee
-	if err := database.DB.First(&employee, uint(employeeID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
@@ -592,30 +718,38 @@ func ChangePassword(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Verify current password
 	if !utils.CheckPasswordHash(req.CurrentPassword, employee.PasswordHash) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Current password is incorrect"})
+		utils.RespondError(c, http.StatusBadRequest, "Current password is incorrect")
 		return
 	}
 
 	// Hash new password
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to hash password")
 		return
 	}
 
 	// Update password
 	employee.PasswordHash = hashedPassword
-	if err := database.DB.Save(&employee).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update password"})
+	if err := requestDB(c).Save(&employee).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update password")
 		return
 	}
 
+	// Tell the employee on every channel they want it, so a change they did not make is noticed
+	changedAt := utils.Now().In(utils.CompanyLocation()).Format("2006-01-02 15:04")
+	subject := i18n.M("Your password was changed")
+	message := i18n.M("Your HRMS password was changed on %s. If you did not change it, contact HR straight away.", changedAt)
+	afterCommit(c, func() {
+		utils.Notify(employee, models.NotificationPasswordChanged, subject, message, map[string]string{"password.changed_at": changedAt}, models.AuditEntityEmployee, employee.ID)
+	})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
 }
 
@@ -640,29 +774,216 @@ type ChangePasswordRequest struct {
 func DeleteEmployee(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
-	if err := database.DB.Delete(&models.Employee{}, uint(employeeID)).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete employee"})
+	if err := requestDB(c).Delete(&models.Employee{}, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete employee")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Employee deleted successfully"})
 }
 
-// DownloadEmployeeTemplate returns a CSV template for bulk employee upload
-// @Summary Download employee CSV template
-// @Description Download a CSV template for bulk employee upload (Admin only)
+// DeletedEmployeeResponse represents a soft-deleted employee with its deletion time
+type DeletedEmployeeResponse struct {
+	models.Employee
+	DeletedAt time.Time `json:"deleted_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// RestoreConflictResponse describes identifiers that have been reused since the employee was deleted
+type RestoreConflictResponse struct {
+	ErrorResponse
+	Details RestoreConflictDetails `json:"details"`
+}
+
+// RestoreConflictDetails lists the identifiers in use by another employee
+type RestoreConflictDetails struct {
+	Conflicts []string `json:"conflicts" example:"nrc,email"`
+}
+
+// deletedEmployeeListFields are the filters and sort keys accepted by the deleted employee list
+var deletedEmployeeListFields = ListFields{
+	Filters: employeeListFields.Filters,
+	Sorts: map[string]string{
+		"id": "id", "firstname": "firstname", "lastname": "lastname", "department": "department",
+		"role": "role", "created_at": "created_at", "deleted_at": "deleted_at",
+	},
+	DefaultSort: "-deleted_at",
+}
+
+// GetDeletedEmployees returns soft-deleted employees
+// @Summary Get deleted employees
+// @Description Get list of soft-deleted employees that can be restored (Admin only). Supports search query parameter for filtering by name.
 // @Tags Admin - Employees
-// @Produce text/csv
+// @Produce json
 // @Security BearerAuth
-// @Success 200 {file} file "CSV template file"
+// @Param search query string false "Search term matching the start of words in employees' names and email"
+// @Param department query string false "Department filter (comma separated for several)"
+// @Param role query string false "Role filter (employee, manager)"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, firstname, lastname, department, role, created_at, deleted_at). Defaults to -deleted_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]DeletedEmployeeResponse}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/employees/deleted [get]
+func GetDeletedEmployees(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	var employees []models.Employee
+	query := requestDB(c).Unscoped().Where("deleted_at IS NOT NULL")
+
+	search := c.Query("search")
+	if search != "" {
+		query = utils.WhereSearch(query, search, employeeSearchColumns...)
+	}
+
+	query, ok = applyListQuery(c, query, deletedEmployeeListFields)
+	if !ok {
+		return
+	}
+
+	response, err := paginate(query, pagination, &employees)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch deleted employees")
+		return
+	}
+
+	deleted := make([]DeletedEmployeeResponse, 0, len(employees))
+	for _, employee := range employees {
+		deleted = append(deleted, DeletedEmployeeResponse{
+			Employee:  employee,
+			DeletedAt: employee.DeletedAt.Time,
+		})
+	}
+	response.Data = deleted
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreEmployee restores a soft-deleted employee
+// @Summary Restore deleted employee
+// @Description Restore a soft-deleted employee. Fails with 409 if the employee's NRC, email, username or employee number has since been reused by another employee (Admin only)
+// @Tags Admin - Employees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} models.Employee
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} RestoreConflictResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/employees/{id}/restore [post]
+func RestoreEmployee(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).Unscoped().Where("deleted_at IS NOT NULL").First(&employee, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Deleted employee not found")
+		return
+	}
+
+	// Unique identifiers may have been given to a new employee while this one was deleted
+	identifiers := []struct {
+		column string
+		value  *string
+	}{
+		{"nrc", employee.NRC},
+		{"email", employee.Email},
+		{"username", employee.Username},
+		{"employee_number", employee.EmployeeNumber},
+	}
+	var conflicts []string
+	for _, identifier := range identifiers {
+		if identifier.value == nil || *identifier.value == "" {
+			continue
+		}
+		var count int64
+		requestDB(c).Model(&models.Employee{}).
+			Where(identifier.column+" = ? AND id != ?", *identifier.value, employee.ID).
+			Count(&count)
+		if count > 0 {
+			conflicts = append(conflicts, identifier.column)
+		}
+	}
+	if len(conflicts) > 0 {
+		utils.RespondErrorCode(c, http.StatusConflict, utils.CodeConflict,
+			"Cannot restore employee: identifiers are in use by another employee",
+			RestoreConflictDetails{Conflicts: conflicts})
+		return
+	}
+
+	if err := requestDB(c).Unscoped().Model(&employee).Update("deleted_at", nil).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to restore employee")
+		return
+	}
+	employee.DeletedAt = gorm.DeletedAt{}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityEmployee, employee.ID, models.AuditActionRestore, userID.(uint), c, nil, employee)
+
+	c.JSON(http.StatusOK, employee)
+}
+
+// employeeUploadHeader is the column layout of bulk employee uploads
+var employeeUploadHeader = []string{"nrc", "firstname", "lastname", "email", "password", "department", "role"}
+
+// employeeUploadExamples are the example rows of the bulk employee upload template
+var employeeUploadExamples = [][]string{
+	{"123456/78/9", "John", "Doe", "john.doe@example.com", "password123", "IT", "employee"},
+	{"987654/32/1", "Jane", "Smith", "jane.smith@example.com", "password123", "HR", "manager"},
+}
+
+// DownloadEmployeeTemplate returns a CSV or Excel template for bulk employee upload
+// @Summary Download employee upload template
+// @Description Download a CSV or Excel template for bulk employee upload. The Excel template keeps NRCs as text and offers dropdowns for the role and the existing departments (Admin only)
+// @Tags Admin - Employees
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Template format: csv or xlsx (default: csv)"
+// @Success 200 {file} file "Template file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
 // @Router /api/employees/template [get]
 func DownloadEmployeeTemplate(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "xlsx" {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'csv' or 'xlsx'")
+		return
+	}
+
+	if format == "xlsx" {
+		var departments []string
+		requestDB(c).Model(&models.Employee{}).Where("department <> ''").Distinct().Order("department").Pluck("department", &departments)
+
+		fileData, err := utils.UploadTemplateToExcel("Employees", employeeUploadHeader, employeeUploadExamples, []string{"nrc"}, []utils.TemplateDropdown{
+			{Column: "department", Values: departments},
+			{Column: "role", Values: []string{string(models.RoleEmployee), string(models.RoleManager)}},
+		})
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate template file")
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=employee_template.xlsx")
+		c.Data(http.StatusOK, utils.XLSXContentType, fileData)
+		return
+	}
+
 	c.Header("Content-Type", "text/csv")
 	c.Header("Content-Disposition", "attachment; filename=employee_template.csv")
 
@@ -670,10 +991,9 @@ func DownloadEmployeeTemplate(c *gin.Context) {
 	defer writer.Flush()
 
 	// Header row
-	writer.Write([]string{"nrc", "firstname", "lastname", "email", "password", "department", "role"})
+	writer.Write(employeeUploadHeader)
 	// Example rows
-	writer.Write([]string{"123456/78/9", "John", "Doe", "john.doe@example.com", "password123", "IT", "employee"})
-	writer.Write([]string{"987654/32/1", "Jane", "Smith", "jane.smith@example.com", "password123", "HR", "manager"})
+	writer.WriteAll(employeeUploadExamples)
 }
 
 // BulkUploadResponse represents the response for bulk upload
@@ -684,40 +1004,59 @@ type BulkUploadResponse struct {
 	Errors  []string `json:"errors,omitempty" example:"Row 3: NRC already exists"`
 }
 
-// BulkUploadEmployees uploads employees from a CSV file
+// BulkUploadEmployees uploads employees from a CSV or Excel file
 // @Summary Bulk upload employees
-// @Description Upload multiple employees from a CSV file (Admin only)
+// @Description Upload multiple employees from a CSV or Excel (.xlsx) file laid out like the template. Excel files are read from their first sheet (Admin only)
 // @Tags Admin - Employees
 // @Accept multipart/form-data
 // @Produce json
 // @Security BearerAuth
-// @Param file formData file true "CSV file with employee data"
+// @Param file formData file true "CSV or Excel file with employee data"
 // @Success 200 {object} BulkUploadResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /api/employees/bulk [post]
 func BulkUploadEmployees(c *gin.Context) {
-	file, _, err := c.Request.FormFile("file")
+	file, fileHeader, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		utils.RespondError(c, http.StatusBadRequest, "No file uploaded")
 		return
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	// Both formats are read a record at a time, so that CSV parse errors only fail their row
+	var readRecord func() ([]string, error)
+	invalidFile, invalidFormat := "Invalid CSV file", "Invalid CSV format. Download the template for correct format."
+	if utils.IsXLSXUpload(fileHeader.Filename, fileHeader.Header.Get("Content-Type")) {
+		invalidFile, invalidFormat = "Invalid Excel file", "Invalid Excel format. Download the template for correct format."
+		rows, err := utils.ReadXLSXRows(file)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, invalidFile)
+			return
+		}
+		readRecord = func() ([]string, error) {
+			if len(rows) == 0 {
+				return nil, io.EOF
+			}
+			record := rows[0]
+			rows = rows[1:]
+			return record, nil
+		}
+	} else {
+		readRecord = csv.NewReader(file).Read
+	}
 
 	// Read header row
-	header, err := reader.Read()
+	header, err := readRecord()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSV file"})
+		utils.RespondError(c, http.StatusBadRequest, invalidFile)
 		return
 	}
 
 	// Validate header
-	expectedHeader := []string{"nrc", "firstname", "lastname", "email", "password", "department", "role"}
-	if len(header) < len(expectedHeader) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSV format. Download the template for correct format."})
+	if len(header) < len(employeeUploadHeader) {
+		utils.RespondError(c, http.StatusBadRequest, invalidFormat)
 		return
 	}
 
@@ -726,7 +1065,7 @@ func BulkUploadEmployees(c *gin.Context) {
 
 	rowNum := 1
 	for {
-		record, err := reader.Read()
+		record, err := readRecord()
 		if err == io.EOF {
 			break
 		}
@@ -737,8 +1076,12 @@ func BulkUploadEmployees(c *gin.Context) {
 			continue
 		}
 
-		total++
 		rowNum++
+		// Spreadsheets often carry blank rows between or after the data
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		total++
 
 		if len(record) < 7 {
 			errors = append(errors, fmt.Sprintf("Row %d: Incomplete data", rowNum))
@@ -768,13 +1111,25 @@ func BulkUploadEmployees(c *gin.Context) {
 			continue
 		}
 
+		// Rows follow the organization's default national ID format
+		nrc, err = utils.NormalizeNationalID(requestDB(c), c.GetUint("organization_id"), "", nrc)
+		if err != nil {
+			if message := nationalIDErrorMessage(i18n.Default, err, ""); message != "" {
+				errors = append(errors, fmt.Sprintf("Row %d: %s", rowNum, message))
+			} else {
+				errors = append(errors, fmt.Sprintf("Row %d: Failed to validate NRC", rowNum))
+			}
+			failed++
+			continue
+		}
+
 		// Check if NRC or email already exists
 		var existing models.Employee
 		emailCheck := email
 		if emailCheck == "" {
 			emailCheck = "NO_EMAIL_" + nrc // Use a placeholder if email is empty
 		}
-		if err := database.DB.Where("nrc = ? OR (email IS NOT NULL AND email = ?)", nrc, emailCheck).First(&existing).Error; err == nil {
+		if err := requestDB(c).Where("nrc = ? OR "+utils.NationalIDCompactSQL()+" = ? OR (email IS NOT NULL AND email = ?)", nrc, utils.CompactNationalID(nrc), emailCheck).First(&existing).Error; err == nil {
 			errors = append(errors, fmt.Sprintf("Row %d: NRC or email already exists", rowNum))
 			failed++
 			continue
@@ -801,7 +1156,7 @@ func BulkUploadEmployees(c *gin.Context) {
 			Role:         models.Role(role),
 		}
 
-		if err := database.DB.Create(&employee).Error; err != nil {
+		if err := requestDB(c).Create(&employee).Error; err != nil {
 			errors = append(errors, fmt.Sprintf("Row %d: Failed to create employee", rowNum))
 			failed++
 			continue
@@ -818,21 +1173,48 @@ func BulkUploadEmployees(c *gin.Context) {
 	})
 }
 
-// ExportEmployees exports all employees data to PDF
+// ExportEmployees exports all employees data to PDF, or the employee roster to Excel or CSV
 // @Summary Export all employees
-// @Description Export all employees data to PDF format (Admin only)
+// @Description Export all employees data to PDF (Admins only), or a roster of employees with their employment details and position to Excel or CSV (Managers and Admins). Roster columns can be chosen with columns; without it every column the user may see is exported. Personal columns (nrc, date_of_birth, gender, address, city, postal_code, emergency contact and notes) are only exported for admins, and bank_name, bank_account_number and tax_id only for users with payroll access
 // @Tags Admin - Employees
-// @Produce application/pdf
+// @Produce application/pdf,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv
 // @Security BearerAuth
-// @Success 200 {file} file "PDF file"
+// @Param format query string false "Export format: pdf, xlsx or csv (default: pdf)"
+// @Param columns query string false "Comma-separated roster columns, e.g. employee_number,firstname,lastname,department,position_title,hire_date"
+// @Param department query string false "Only export the roster of this department"
+// @Param status query string false "Only export the roster of employees with this status"
+// @Param lang query string false "Language of the PDF: en, fr or pt (default: the Accept-Language header)"
+// @Param paper_size query string false "Paper size of the PDF: A4 or Letter (default: the export_paper_size setting)"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
-// @Failure 403 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse "Not an admin (PDF) or column not allowed for the user"
+// @Failure 500 {object} ErrorResponse
 // @Router /api/employees/export [get]
 func ExportEmployees(c *gin.Context) {
+	format := c.DefaultQuery("format", "pdf")
+	switch format {
+	case "xlsx", "csv":
+		exportEmployeeRoster(c, format)
+		return
+	case "pdf":
+		if role, _ := c.Get("role"); role != models.RoleAdmin {
+			utils.RespondError(c, http.StatusForbidden, "Only admins can export employees to PDF")
+			return
+		}
+	default:
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'pdf', 'xlsx' or 'csv'")
+		return
+	}
+	locale, ok := exportLocale(c)
+	if !ok {
+		return
+	}
+
 	// Get all employees (excluding admin users)
 	var employees []models.Employee
-	if err := database.DB.Where("role != ?", models.RoleAdmin).Find(&employees).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch employees"})
+	if err := requestDB(c).Where("role != ?", models.RoleAdmin).Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
 		return
 	}
 
@@ -843,11 +1225,11 @@ func ExportEmployees(c *gin.Context) {
 		var employment models.EmploymentDetails
 		var startDate string
 		var tenure string
-		if err := database.DB.Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
+		if err := requestDB(c).Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
 			if employment.StartDate != nil {
-				startDate = employment.StartDate.Format("2006-01-02")
+				startDate = locale.Date(*employment.StartDate)
 			} else if employment.HireDate != nil {
-				startDate = employment.HireDate.Format("2006-01-02")
+				startDate = locale.Date(*employment.HireDate)
 			}
 			// Calculate tenure
 			if employment.StartDate != nil {
@@ -860,9 +1242,9 @@ func ExportEmployees(c *gin.Context) {
 					months += 12
 				}
 				if years > 0 {
-					tenure = fmt.Sprintf("%d years, %d months", years, months)
+					tenure = locale.T("%d years, %d months", years, months)
 				} else {
-					tenure = fmt.Sprintf("%d months", months)
+					tenure = locale.T("%d months", months)
 				}
 			}
 		}
@@ -882,7 +1264,7 @@ func ExportEmployees(c *gin.Context) {
 			Address:                   getStringValue(emp.Address),
 			City:                      getStringValue(emp.City),
 			PostalCode:                getStringValue(emp.PostalCode),
-			DateOfBirth:               formatDate(emp.DateOfBirth),
+			DateOfBirth:               formatDate(emp.DateOfBirth, locale),
 			Gender:                    getStringValue(emp.Gender),
 			JobTitle:                  getStringValue(emp.JobTitle),
 			EmploymentStatus:          getStringValue(emp.EmploymentStatus),
@@ -898,17 +1280,11 @@ func ExportEmployees(c *gin.Context) {
 		})
 	}
 
-	// Generate PDF
-	fileData, err := utils.ExportEmployeesToPDF(exportData)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PDF"})
-		return
-	}
-
+	// Stream the PDF
 	filename := fmt.Sprintf("employees_%s.pdf", time.Now().Format("20060102_150405"))
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
-	c.Header("Content-Type", "application/pdf")
-	c.Data(http.StatusOK, "application/pdf", fileData)
+	streamDownload(c, filename, "application/pdf", "Failed to generate PDF", func(w io.Writer) error {
+		return utils.ExportEmployeesToPDF(w, exportData, locale)
+	})
 }
 
 // ExportEmployee exports single employee data to PDF
@@ -918,7 +1294,10 @@ func ExportEmployees(c *gin.Context) {
 // @Produce application/pdf
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
+// @Param lang query string false "Language of the PDF: en, fr or pt (default: the Accept-Language header)"
+// @Param paper_size query string false "Paper size of the PDF: A4 or Letter (default: the export_paper_size setting)"
 // @Success 200 {file} file "PDF file"
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -926,13 +1305,17 @@ func ExportEmployees(c *gin.Context) {
 func ExportEmployee(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+	locale, ok := exportLocale(c)
+	if !ok {
 		return
 	}
 
 	var employee models.Employee
-	if err := database.DB.First(&employee, uint(employeeID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
@@ -940,11 +1323,11 @@ func ExportEmployee(c *gin.Context) {
 	var employment models.EmploymentDetails
 	var startDate string
 	var tenure string
-	if err := database.DB.Where("employee_id = ?", employee.ID).First(&employment).Error; err == nil {
+	if err := requestDB(c).Where("employee_id = ?", employee.ID).First(&employment).Error; err == nil {
 		if employment.StartDate != nil {
-			startDate = employment.StartDate.Format("2006-01-02")
+			startDate = locale.Date(*employment.StartDate)
 		} else if employment.HireDate != nil {
-			startDate = employment.HireDate.Format("2006-01-02")
+			startDate = locale.Date(*employment.HireDate)
 		}
 		// Calculate tenure
 		if employment.StartDate != nil {
@@ -957,9 +1340,9 @@ func ExportEmployee(c *gin.Context) {
 				months += 12
 			}
 			if years > 0 {
-				tenure = fmt.Sprintf("%d years, %d months", years, months)
+				tenure = locale.T("%d years, %d months", years, months)
 			} else {
-				tenure = fmt.Sprintf("%d months", months)
+				tenure = locale.T("%d months", months)
 			}
 		}
 	}
@@ -979,7 +1362,7 @@ func ExportEmployee(c *gin.Context) {
 		Address:                   getStringValue(employee.Address),
 		City:                      getStringValue(employee.City),
 		PostalCode:                getStringValue(employee.PostalCode),
-		DateOfBirth:               formatDate(employee.DateOfBirth),
+		DateOfBirth:               formatDate(employee.DateOfBirth, locale),
 		Gender:                    getStringValue(employee.Gender),
 		JobTitle:                  getStringValue(employee.JobTitle),
 		EmploymentStatus:          getStringValue(employee.EmploymentStatus),
@@ -995,12 +1378,13 @@ func ExportEmployee(c *gin.Context) {
 	}
 
 	// Generate PDF
-	fileData, err := utils.ExportEmployeeToPDF(exportData)
+	fileData, err := utils.ExportEmployeeToPDF(exportData, locale)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PDF"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate PDF")
 		return
 	}
 
+	noteFileAccess(c, models.FileAccessLog{EmployeeID: &employee.ID})
 	filename := fmt.Sprintf("employee_%s_%s_%s.pdf", employee.Firstname, employee.Lastname, time.Now().Format("20060102"))
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 	c.Header("Content-Type", "application/pdf")
@@ -1015,9 +1399,9 @@ func getStringValue(s *string) string {
 	return *s
 }
 
-func formatDate(t *time.Time) string {
+func formatDate(t *time.Time, locale utils.ExportLocale) string {
 	if t == nil {
 		return "-"
 	}
-	return t.Format("2006-01-02")
+	return locale.Date(*t)
 }
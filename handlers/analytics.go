@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeadcountAnalytics is monthly headcount movement overall and by department
+type HeadcountAnalytics struct {
+	From        string                      `json:"from" example:"2024-04"`
+	To          string                      `json:"to" example:"2025-03"`
+	Months      []utils.WorkforceMonth      `json:"months"`
+	Departments []utils.DepartmentWorkforce `json:"departments"`
+}
+
+// DepartmentTurnover is turnover over a period for one department
+type DepartmentTurnover struct {
+	Department string `json:"department" example:"Finance"`
+	utils.TurnoverSummary
+}
+
+// TurnoverAnalytics is turnover over a period overall, by month and by department
+type TurnoverAnalytics struct {
+	From string `json:"from" example:"2024-04"`
+	To   string `json:"to" example:"2025-03"`
+	utils.TurnoverSummary
+	Months      []utils.WorkforceMonth `json:"months"`
+	Departments []DepartmentTurnover   `json:"departments"`
+}
+
+// GetHeadcountAnalytics reports monthly headcount, joiners and leavers
+// @Summary Get headcount analytics
+// @Description Monthly opening and closing headcount, joiners, leavers, turnover rate and average tenure, overall and by department. Computed from employment details and lifecycle events; admin accounts are excluded. Defaults to the last 12 months (HR/Admin only)
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Success 200 {object} HeadcountAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/analytics/headcount [get]
+func GetHeadcountAnalytics(c *gin.Context) {
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return
+	}
+
+	c.JSON(http.StatusOK, HeadcountAnalytics{
+		From:        from.Format("2006-01"),
+		To:          to.Format("2006-01"),
+		Months:      utils.ComputeWorkforceMonths(members, from, to),
+		Departments: utils.ComputeDepartmentWorkforce(members, from, to),
+	})
+}
+
+// GetTurnoverAnalytics reports turnover over a period
+// @Summary Get turnover analytics
+// @Description Leavers split into voluntary and involuntary, turnover rate against average headcount (also annualized) and average tenure of leavers, overall, by month and by department. Defaults to the last 12 months (HR/Admin only)
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Success 200 {object} TurnoverAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/analytics/turnover [get]
+func GetTurnoverAnalytics(c *gin.Context) {
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return
+	}
+
+	byDepartment := map[string][]utils.WorkforceMember{}
+	for _, member := range members {
+		byDepartment[member.Department] = append(byDepartment[member.Department], member)
+	}
+	departments := make([]DepartmentTurnover, 0, len(byDepartment))
+	for department, deptMembers := range byDepartment {
+		departments = append(departments, DepartmentTurnover{
+			Department:      department,
+			TurnoverSummary: utils.SummarizeTurnover(deptMembers, from, to),
+		})
+	}
+	sort.Slice(departments, func(i, j int) bool {
+		return departments[i].Department < departments[j].Department
+	})
+
+	c.JSON(http.StatusOK, TurnoverAnalytics{
+		From:            from.Format("2006-01"),
+		To:              to.Format("2006-01"),
+		TurnoverSummary: utils.SummarizeTurnover(members, from, to),
+		Months:          utils.ComputeWorkforceMonths(members, from, to),
+		Departments:     departments,
+	})
+}
+
+// parseAnalyticsRange reads the from/to month query parameters, defaulting to the 12 months ending this month
+func parseAnalyticsRange(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01", toStr)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to month format. Use YYYY-MM")
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, -11, 0)
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01", fromStr)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from month format. Use YYYY-MM")
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		utils.RespondError(c, http.StatusBadRequest, "to must be on or after from")
+		return time.Time{}, time.Time{}, false
+	}
+	if from.AddDate(5, 0, 0).Before(to) {
+		utils.RespondError(c, http.StatusBadRequest, "Range cannot exceed 60 months")
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, to, true
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AnonymizeEmployeeRequest confirms the anonymization of an employee
+type AnonymizeEmployeeRequest struct {
+	Confirm string `json:"confirm" binding:"required" example:"John Banda"` // The employee's full name, as shown by the preview
+	Reason  string `json:"reason" binding:"required" example:"Erasure request received 2025-06-02"`
+}
+
+// AnonymizationPreviewResponse shows what anonymizing an employee would scrub
+type AnonymizationPreviewResponse struct {
+	EmployeeID uint                       `json:"employee_id" example:"12"`
+	Name       string                     `json:"name" example:"John Banda"`
+	Confirm    string                     `json:"confirm" example:"John Banda"` // Send as confirm to anonymize
+	Summary    utils.AnonymizationSummary `json:"summary"`
+}
+
+// AnonymizationResponse reports what anonymizing an employee scrubbed
+type AnonymizationResponse struct {
+	Message string                     `json:"message" example:"Employee anonymized successfully"`
+	Summary utils.AnonymizationSummary `json:"summary"`
+	Warning string                     `json:"warning,omitempty"` // Set when some files could not be deleted
+}
+
+// PreviewEmployeeAnonymization shows what anonymizing a former employee would scrub
+// @Summary Preview employee anonymization
+// @Description Show what anonymizing a former employee would scrub, and the confirmation to send to POST /api/admin/employees/{id}/anonymize. Nothing is changed (Admin only)
+// @Tags Admin - Employees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} AnonymizationPreviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Not a former employee, or already anonymized"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/employees/{id}/anonymization [get]
+func PreviewEmployeeAnonymization(c *gin.Context) {
+	employee, ok := loadEmployeeForAnonymization(c)
+	if !ok {
+		return
+	}
+
+	// The preview runs the anonymization and rolls it back, so it counts exactly what would change
+	tx := requestDB(c).Begin()
+	if tx.Error != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to preview anonymization")
+		return
+	}
+	defer tx.Rollback()
+	name := employee.Firstname + " " + employee.Lastname
+	summary, err := utils.AnonymizeEmployee(tx, employee)
+	if err != nil {
+		respondAnonymizationError(c, err, "Failed to preview anonymization")
+		return
+	}
+
+	c.JSON(http.StatusOK, AnonymizationPreviewResponse{EmployeeID: employee.ID, Name: name, Confirm: name, Summary: summary})
+}
+
+// AnonymizeEmployee irreversibly scrubs a former employee's personal data
+// @Summary Anonymize employee
+// @Description Irreversibly scrub a former employee's personal data instead of deleting them: name, NRC, employee number, login, contact, emergency and bank details, identity, bank and education records, document files, leave forms and reasons, and the values recorded in the audit trail of those records. Leaves, employment details and lifecycle events are kept, so leave and headcount statistics are unchanged. Former employees are those deleted, deactivated, or terminated or resigned in their employment details. Preview first with GET /api/admin/employees/{id}/anonymization and send the employee's full name as confirm (Admin only)
+// @Tags Admin - Employees
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body AnonymizeEmployeeRequest true "Confirmation"
+// @Success 200 {object} AnonymizationResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Not a former employee, or already anonymized"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/employees/{id}/anonymize [post]
+func AnonymizeEmployee(c *gin.Context) {
+	employee, ok := loadEmployeeForAnonymization(c)
+	if !ok {
+		return
+	}
+	var req AnonymizeEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if req.Confirm != employee.Firstname+" "+employee.Lastname {
+		utils.RespondError(c, http.StatusBadRequest, "Confirmation does not match the employee's full name")
+		return
+	}
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if user.ID == employee.ID {
+		utils.RespondError(c, http.StatusBadRequest, "You cannot anonymize yourself")
+		return
+	}
+
+	var summary utils.AnonymizationSummary
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		var err error
+		if summary, err = utils.AnonymizeEmployee(tx, employee); err != nil {
+			return err
+		}
+		return recordAuditLog(tx, models.AuditEntityEmployee, employee.ID, models.AuditActionAnonymize, user.ID, c,
+			nil, gin.H{"reason": req.Reason, "summary": summary})
+	})
+	if err != nil {
+		respondAnonymizationError(c, err, "Failed to anonymize employee")
+		return
+	}
+
+	// Their leaves are still shown in their manager's calendar, under the name they no longer have
+	var syncedLeaveIDs []uint
+	employeeLeaves := requestDB(c).Model(&models.Leave{}).Select("id").Where("employee_id = ?", employee.ID)
+	requestDB(c).Model(&models.CalendarEvent{}).Where("leave_id IN (?)", employeeLeaves).Distinct().Pluck("leave_id", &syncedLeaveIDs)
+	afterCommit(c, func() {
+		utils.SyncLeaveCalendars(syncedLeaveIDs...)
+	})
+
+	response := AnonymizationResponse{Message: "Employee anonymized successfully", Summary: summary}
+	if err := utils.DeleteAnonymizedFiles(employee.ID, summary.Files); err != nil {
+		response.Warning = i18n.T(utils.RequestLanguage(c), "Some document files could not be deleted")
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// loadEmployeeForAnonymization loads the employee named by the id parameter, including deleted ones
+func loadEmployeeForAnonymization(c *gin.Context) (*models.Employee, bool) {
+	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
+		return nil, false
+	}
+	var employee models.Employee
+	if err := requestDB(c).Unscoped().First(&employee, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return nil, false
+	}
+	return &employee, true
+}
+
+func respondAnonymizationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFormerEmployee):
+		utils.RespondError(c, http.StatusConflict, "Only former employees can be anonymized")
+	case errors.Is(err, utils.ErrAlreadyAnonymized):
+		utils.RespondError(c, http.StatusConflict, "Employee has already been anonymized")
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"hrms-api/config"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyUsageReport summarises the calls internal services made with their API keys
+type APIKeyUsageReport struct {
+	From string            `json:"from" example:"2025-01-01"`
+	To   string            `json:"to" example:"2025-01-31"`
+	Keys []APIKeyUsageStat `json:"keys"`
+}
+
+// APIKeyUsageStat is the usage of one API key over the report's period
+type APIKeyUsageStat struct {
+	Name          string              `json:"name" example:"payroll"`
+	Configured    bool                `json:"configured" example:"true"`      // False for keys since removed from GRPC_API_KEYS
+	RatePerMinute int                 `json:"rate_per_minute" example:"600"`  // 0 is unlimited
+	QuotaPerDay   int                 `json:"quota_per_day" example:"100000"` // 0 is unlimited
+	Calls         int64               `json:"calls" example:"48210"`          // Calls served
+	Rejected      int64               `json:"rejected" example:"12"`          // Calls refused for exceeding the limits
+	PeakDay       int64               `json:"peak_day_calls" example:"3120"`  // Most calls served on a single day
+	Methods       []APIKeyMethodUsage `json:"methods"`                        // Busiest first
+	Days          []APIKeyDayUsage    `json:"days"`                           // Oldest first, days without calls omitted
+}
+
+// APIKeyMethodUsage is the number of calls an API key made to a gRPC method
+type APIKeyMethodUsage struct {
+	Method   string `json:"method" example:"/hrms.v1.EmployeeService/ListEmployees"`
+	Calls    int64  `json:"calls" example:"40100"`
+	Rejected int64  `json:"rejected" example:"0"`
+}
+
+// APIKeyDayUsage is the number of calls an API key made on a day
+type APIKeyDayUsage struct {
+	Date     string `json:"date" example:"2025-01-15"`
+	Calls    int64  `json:"calls" example:"1604"`
+	Rejected int64  `json:"rejected" example:"0"`
+}
+
+// GetAPIKeyUsage reports the call volume of each API key
+// @Summary Get API key usage
+// @Description Report the gRPC calls made with each API key by method and by day, with the key's limits, for capacity planning and spotting abuse. Counts lag by up to 15 seconds (Admin of the default organization only)
+// @Tags Admin - API Keys
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param key query string false "Only report the key with this name"
+// @Success 200 {object} APIKeyUsageReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/api-keys/usage [get]
+func GetAPIKeyUsage(c *gin.Context) {
+	// API keys belong to the installation, not to an organization
+	if c.GetUint("organization_id") != models.DefaultOrganizationID {
+		utils.RespondError(c, http.StatusForbidden, "Only admins of the default organization can view API key usage")
+		return
+	}
+
+	to := utils.CompanyToday()
+	from := to.AddDate(0, 0, -30)
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD")
+			return
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		utils.RespondError(c, http.StatusBadRequest, "to must be on or after from")
+		return
+	}
+
+	keyName := c.Query("key")
+	query := requestDB(c).Where("date >= ? AND date <= ?", from, to)
+	if keyName != "" {
+		query = query.Where("key_name = ?", keyName)
+	}
+	var usages []models.APIKeyUsage
+	if err := query.Order("key_name, date, method").Find(&usages).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch API key usage")
+		return
+	}
+
+	// Every configured key is reported, even without calls, followed by removed keys that made calls
+	stats := map[string]*APIKeyUsageStat{}
+	var names []string
+	for _, apiKey := range config.AppConfig.GRPCAPIKeys {
+		if keyName != "" && keyName != apiKey.Name {
+			continue
+		}
+		stats[apiKey.Name] = &APIKeyUsageStat{
+			Name: apiKey.Name, Configured: true, RatePerMinute: apiKey.PerMinute, QuotaPerDay: apiKey.PerDay,
+		}
+		names = append(names, apiKey.Name)
+	}
+	methods := map[string]map[string]*APIKeyMethodUsage{}
+	for _, usage := range usages {
+		stat := stats[usage.KeyName]
+		if stat == nil {
+			stat = &APIKeyUsageStat{Name: usage.KeyName}
+			stats[usage.KeyName] = stat
+			names = append(names, usage.KeyName)
+		}
+		stat.Calls += usage.Calls
+		stat.Rejected += usage.Rejected
+
+		date := usage.Date.Format("2006-01-02")
+		if n := len(stat.Days); n == 0 || stat.Days[n-1].Date != date {
+			stat.Days = append(stat.Days, APIKeyDayUsage{Date: date})
+		}
+		day := &stat.Days[len(stat.Days)-1]
+		day.Calls += usage.Calls
+		day.Rejected += usage.Rejected
+		if day.Calls > stat.PeakDay {
+			stat.PeakDay = day.Calls
+		}
+
+		if methods[usage.KeyName] == nil {
+			methods[usage.KeyName] = map[string]*APIKeyMethodUsage{}
+		}
+		method := methods[usage.KeyName][usage.Method]
+		if method == nil {
+			method = &APIKeyMethodUsage{Method: usage.Method}
+			methods[usage.KeyName][usage.Method] = method
+		}
+		method.Calls += usage.Calls
+		method.Rejected += usage.Rejected
+	}
+
+	report := APIKeyUsageReport{From: from.Format("2006-01-02"), To: to.Format("2006-01-02"), Keys: []APIKeyUsageStat{}}
+	for _, name := range names {
+		stat := stats[name]
+		stat.Methods = []APIKeyMethodUsage{}
+		for _, method := range methods[name] {
+			stat.Methods = append(stat.Methods, *method)
+		}
+		sort.Slice(stat.Methods, func(i, j int) bool {
+			if stat.Methods[i].Calls != stat.Methods[j].Calls {
+				return stat.Methods[i].Calls > stat.Methods[j].Calls
+			}
+			return stat.Methods[i].Method < stat.Methods[j].Method
+		})
+		if stat.Days == nil {
+			stat.Days = []APIKeyDayUsage{}
+		}
+		report.Keys = append(report.Keys, *stat)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
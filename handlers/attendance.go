@@ -0,0 +1,731 @@
+package handlers
+
+import (
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClockRequest represents the optional location captured when clocking in or out
+type ClockRequest struct {
+	Latitude  *float64 `json:"latitude,omitempty" example:"-15.4167"`
+	Longitude *float64 `json:"longitude,omitempty" example:"28.2833"`
+	Notes     *string  `json:"notes,omitempty" example:"Working from the Ndola office"`
+}
+
+// CreateWorkScheduleRequest represents data for creating a work schedule
+type CreateWorkScheduleRequest struct {
+	Name         string `json:"name" binding:"required" example:"Early"`
+	StartTime    string `json:"start_time" binding:"required" example:"07:00"`
+	EndTime      string `json:"end_time" binding:"required" example:"16:00"`
+	GraceMinutes int    `json:"grace_minutes" example:"10"`
+	WorkDays     string `json:"work_days,omitempty" example:"1,2,3,4,5"` // Comma-separated weekdays, 0=Sunday
+	IsDefault    bool   `json:"is_default" example:"false"`
+}
+
+// CreateAttendanceCorrectionRequest represents a request to correct an attendance day
+type CreateAttendanceCorrectionRequest struct {
+	EmployeeID *uint   `json:"employee_id,omitempty" example:"12"` // Defaults to the current user; managers may request for their reports
+	Date       string  `json:"date" binding:"required" example:"2025-03-14"`
+	ClockIn    *string `json:"clock_in,omitempty" example:"08:05"`  // HH:MM
+	ClockOut   *string `json:"clock_out,omitempty" example:"17:10"` // HH:MM
+	Reason     string  `json:"reason" binding:"required" example:"Forgot to clock in after the site visit"`
+}
+
+// ReviewAttendanceCorrectionRequest represents an optional comment when reviewing a correction
+type ReviewAttendanceCorrectionRequest struct {
+	Comment *string `json:"comment,omitempty" example:"Confirmed with the site supervisor"`
+}
+
+// MonthlyAttendance represents one employee's attendance for a month
+type MonthlyAttendance struct {
+	Month   string                    `json:"month" example:"2025-03"`
+	Summary utils.AttendanceSummary   `json:"summary"`
+	Records []models.AttendanceRecord `json:"records"`
+}
+
+// DepartmentAttendance totals attendance for one department
+type DepartmentAttendance struct {
+	Department  string                    `json:"department" example:"Finance"`
+	Present     int                       `json:"present" example:"210"`
+	Late        int                       `json:"late" example:"14"`
+	Absent      int                       `json:"absent" example:"6"`
+	OnLeave     int                       `json:"on_leave" example:"9"`
+	WorkedHours float64                   `json:"worked_hours" example:"1920.5"`
+	Employees   []utils.AttendanceSummary `json:"employees"`
+}
+
+// AttendanceReport represents the monthly attendance report across departments
+type AttendanceReport struct {
+	Month       string                 `json:"month" example:"2025-03"`
+	Departments []DepartmentAttendance `json:"departments"`
+}
+
+// GetWorkSchedules lists the configured work schedules
+// @Summary Get work schedules
+// @Description List the work schedules used to flag late and absent days
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.WorkSchedule
+// @Failure 401 {object} ErrorResponse
+// @Router /api/work-schedules [get]
+func GetWorkSchedules(c *gin.Context) {
+	var schedules []models.WorkSchedule
+	requestDB(c).Order("name").Find(&schedules)
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// CreateWorkSchedule creates a work schedule
+// @Summary Create work schedule
+// @Description Create a work schedule. Employees use it when their employment work_schedule matches its name (Admin only)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWorkScheduleRequest true "Work schedule"
+// @Success 201 {object} models.WorkSchedule
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/work-schedules [post]
+func CreateWorkSchedule(c *gin.Context) {
+	var req CreateWorkScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	start, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_time format. Use HH:MM")
+		return
+	}
+	end, err := time.Parse("15:04", req.EndTime)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_time format. Use HH:MM")
+		return
+	}
+	if !end.After(start) {
+		utils.RespondError(c, http.StatusBadRequest, "end_time must be after start_time")
+		return
+	}
+
+	schedule := models.WorkSchedule{
+		Name:         req.Name,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		GraceMinutes: req.GraceMinutes,
+		WorkDays:     req.WorkDays,
+		IsDefault:    req.IsDefault,
+	}
+	if schedule.WorkDays == "" {
+		schedule.WorkDays = "1,2,3,4,5"
+	}
+
+	tx := requestDB(c).Begin()
+	if schedule.IsDefault {
+		tx.Model(&models.WorkSchedule{}).Where("is_default = ?", true).Update("is_default", false)
+	}
+	if err := tx.Create(&schedule).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create work schedule")
+		return
+	}
+	tx.Commit()
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// ClockIn records the current user's arrival for today
+// @Summary Clock in
+// @Description Clock in for today. The client IP and optional coordinates are captured and the day is flagged late if after the schedule's grace period
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ClockRequest false "Location"
+// @Success 201 {object} models.AttendanceRecord
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/attendance/clock-in [post]
+func ClockIn(c *gin.Context) {
+	var req ClockRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	userID, _ := c.Get("user_id")
+	employeeID := userID.(uint)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var record models.AttendanceRecord
+	err := requestDB(c).Where("employee_id = ? AND date = ?", employeeID, today).First(&record).Error
+	if err == nil && record.ClockIn != nil {
+		utils.RespondError(c, http.StatusBadRequest, "You have already clocked in today")
+		return
+	}
+
+	ip := c.ClientIP()
+	record.EmployeeID = employeeID
+	record.Date = today
+	record.ClockIn = &now
+	record.ClockInIP = &ip
+	record.ClockInLatitude = req.Latitude
+	record.ClockInLongitude = req.Longitude
+	if req.Notes != nil {
+		record.Notes = req.Notes
+	}
+	// A day already marked absent or on leave is overwritten by an actual clock-in
+	record.Status = ""
+	utils.EvaluateAttendance(&record, utils.ResolveWorkSchedule(employeeID, today))
+
+	if err := requestDB(c).Save(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to clock in")
+		return
+	}
+
+	c.JSON(http.StatusCreated, record)
+}
+
+// ClockOut records the current user's departure for today
+// @Summary Clock out
+// @Description Clock out for today. The client IP and optional coordinates are captured and worked time is calculated
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ClockRequest false "Location"
+// @Success 200 {object} models.AttendanceRecord
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/attendance/clock-out [post]
+func ClockOut(c *gin.Context) {
+	var req ClockRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	userID, _ := c.Get("user_id")
+	employeeID := userID.(uint)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var record models.AttendanceRecord
+	if err := requestDB(c).Where("employee_id = ? AND date = ?", employeeID, today).First(&record).Error; err != nil || record.ClockIn == nil {
+		utils.RespondError(c, http.StatusBadRequest, "You have not clocked in today")
+		return
+	}
+	if record.ClockOut != nil {
+		utils.RespondError(c, http.StatusBadRequest, "You have already clocked out today")
+		return
+	}
+
+	ip := c.ClientIP()
+	record.ClockOut = &now
+	record.ClockOutIP = &ip
+	record.ClockOutLatitude = req.Latitude
+	record.ClockOutLongitude = req.Longitude
+	if req.Notes != nil {
+		record.Notes = req.Notes
+	}
+	utils.EvaluateAttendance(&record, utils.ResolveWorkSchedule(employeeID, today))
+
+	if err := requestDB(c).Save(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to clock out")
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// GetMyAttendance returns the current user's attendance for a month
+// @Summary Get my attendance
+// @Description Get the current user's daily attendance records and totals for a month
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM), defaults to the current month"
+// @Success 200 {object} MonthlyAttendance
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/attendance/me [get]
+func GetMyAttendance(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	respondMonthlyAttendance(c, userID.(uint))
+}
+
+// GetEmployeeAttendance returns an employee's attendance for a month
+// @Summary Get employee attendance
+// @Description Get an employee's daily attendance records and totals for a month. Employees can only view their own attendance
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param month query string false "Month (YYYY-MM), defaults to the current month"
+// @Success 200 {object} MonthlyAttendance
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/employees/{id}/attendance [get]
+func GetEmployeeAttendance(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	respondMonthlyAttendance(c, uint(employeeID))
+}
+
+// GetAttendanceReport reports monthly attendance per employee grouped by department
+// @Summary Get monthly attendance report
+// @Description Report present, late, absent and on-leave days and worked hours per employee for a month, grouped by department (Manager/Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM), defaults to the current month"
+// @Param department query string false "Department filter"
+// @Success 200 {object} AttendanceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/attendance/report [get]
+func GetAttendanceReport(c *gin.Context) {
+	monthStart, ok := parseAttendanceMonth(c)
+	if !ok {
+		return
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	query := requestDB(c).Where("role != ? AND status = ?", models.RoleAdmin, "active")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var employees []models.Employee
+	if err := query.Order("department, firstname, lastname").Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+	var records []models.AttendanceRecord
+	requestDB(c).Where("employee_id IN ? AND date >= ? AND date <= ?", employeeIDs, monthStart, monthEnd).Find(&records)
+
+	departments := map[string]*DepartmentAttendance{}
+	for _, summary := range utils.SummarizeAttendance(employees, records) {
+		dept, ok := departments[summary.Department]
+		if !ok {
+			dept = &DepartmentAttendance{Department: summary.Department}
+			departments[summary.Department] = dept
+		}
+		dept.Present += summary.Present
+		dept.Late += summary.Late
+		dept.Absent += summary.Absent
+		dept.OnLeave += summary.OnLeave
+		dept.WorkedHours += summary.WorkedHours
+		dept.Employees = append(dept.Employees, summary)
+	}
+
+	report := AttendanceReport{Month: monthStart.Format("2006-01"), Departments: []DepartmentAttendance{}}
+	for _, dept := range departments {
+		report.Departments = append(report.Departments, *dept)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ProcessAbsences runs the absence marking job on demand
+// @Summary Process absences
+// @Description Mark employees who were scheduled to work but never clocked in as absent, or on leave when they have approved leave. Defaults to yesterday (Manager/Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Date (YYYY-MM-DD)"
+// @Success 200 {object} utils.AttendanceAbsenceResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/attendance/process-absences [post]
+func ProcessAbsences(c *gin.Context) {
+	day := time.Now().AddDate(0, 0, -1)
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		if !parsed.Before(time.Now()) {
+			utils.RespondError(c, http.StatusBadRequest, "Absences can only be processed for past days")
+			return
+		}
+		day = parsed
+	}
+
+	c.JSON(http.StatusOK, utils.MarkAbsences(day))
+}
+
+// CreateAttendanceCorrection requests a correction to an attendance day
+// @Summary Request attendance correction
+// @Description Request a correction to the clock times of a past attendance day. Employees request for themselves; managers and admins may request for others. The employee's manager or an admin reviews it
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateAttendanceCorrectionRequest true "Correction"
+// @Success 201 {object} models.AttendanceCorrection
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/attendance/corrections [post]
+func CreateAttendanceCorrection(c *gin.Context) {
+	var req CreateAttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+
+	employeeID := user.ID
+	if req.EmployeeID != nil && *req.EmployeeID != user.ID {
+		if user.Role != models.RoleManager && user.Role != models.RoleAdmin {
+			utils.RespondError(c, http.StatusForbidden, "You can only request corrections for your own attendance")
+			return
+		}
+		employeeID = *req.EmployeeID
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", req.Date, time.Local)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+	if date.After(time.Now()) {
+		utils.RespondError(c, http.StatusBadRequest, "Cannot correct attendance for a future date")
+		return
+	}
+	if req.ClockIn == nil && req.ClockOut == nil {
+		utils.RespondError(c, http.StatusBadRequest, "At least one of clock_in or clock_out is required")
+		return
+	}
+
+	correction := models.AttendanceCorrection{
+		EmployeeID:  employeeID,
+		Date:        date,
+		Reason:      req.Reason,
+		Status:      models.AttendanceCorrectionPending,
+		RequestedBy: user.ID,
+	}
+	if req.ClockIn != nil {
+		clockIn, err := parseClockOnDate(date, *req.ClockIn)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid clock_in format. Use HH:MM")
+			return
+		}
+		correction.RequestedClockIn = &clockIn
+	}
+	if req.ClockOut != nil {
+		clockOut, err := parseClockOnDate(date, *req.ClockOut)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid clock_out format. Use HH:MM")
+			return
+		}
+		correction.RequestedClockOut = &clockOut
+	}
+	if correction.RequestedClockIn != nil && correction.RequestedClockOut != nil &&
+		!correction.RequestedClockOut.After(*correction.RequestedClockIn) {
+		utils.RespondError(c, http.StatusBadRequest, "clock_out must be after clock_in")
+		return
+	}
+
+	var record models.AttendanceRecord
+	if requestDB(c).Where("employee_id = ? AND date = ?", employeeID, date).First(&record).Error == nil {
+		correction.AttendanceID = &record.ID
+	}
+
+	var open int64
+	requestDB(c).Model(&models.AttendanceCorrection{}).
+		Where("employee_id = ? AND date = ? AND status = ?", employeeID, date, models.AttendanceCorrectionPending).
+		Count(&open)
+	if open > 0 {
+		utils.RespondError(c, http.StatusBadRequest, "A correction for this day is already pending")
+		return
+	}
+
+	if err := requestDB(c).Create(&correction).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create attendance correction")
+		return
+	}
+
+	createAuditLog(models.AuditEntityAttendance, correction.ID, models.AuditActionCreate, user.ID, c, nil, correction)
+
+	c.JSON(http.StatusCreated, correction)
+}
+
+// attendanceCorrectionListFields are the filters and sort keys accepted by the attendance correction list
+var attendanceCorrectionListFields = ListFields{
+	Filters: map[string]string{"status": "status", "employee_id": "employee_id"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "status": "status",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetAttendanceCorrections lists attendance corrections
+// @Summary Get attendance corrections
+// @Description List attendance corrections. Managers see corrections for their direct reports; admins see all (Manager/Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected)"
+// @Param employee_id query int false "Employee ID"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, status). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.AttendanceCorrection}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/attendance/corrections [get]
+func GetAttendanceCorrections(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").Preload("Attendance").Preload("Requester").Preload("Reviewer")
+
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin {
+		query = query.Where("employee_id IN (?)",
+			requestDB(c).Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ?", user.ID))
+	}
+
+	query, ok = applyListQuery(c, query, attendanceCorrectionListFields)
+	if !ok {
+		return
+	}
+
+	var corrections []models.AttendanceCorrection
+	response, err := paginate(query, pagination, &corrections)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch attendance corrections")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveAttendanceCorrection approves a correction and applies it to the attendance record
+// @Summary Approve attendance correction
+// @Description Approve an attendance correction. The attendance record is updated and re-evaluated against the work schedule (Employee's manager or Admin)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Correction ID"
+// @Param request body ReviewAttendanceCorrectionRequest false "Review comment"
+// @Success 200 {object} models.AttendanceCorrection
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/attendance/corrections/{id}/approve [put]
+func ApproveAttendanceCorrection(c *gin.Context) {
+	reviewAttendanceCorrection(c, models.AttendanceCorrectionApproved)
+}
+
+// RejectAttendanceCorrection rejects a correction
+// @Summary Reject attendance correction
+// @Description Reject an attendance correction (Employee's manager or Admin)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Correction ID"
+// @Param request body ReviewAttendanceCorrectionRequest false "Review comment"
+// @Success 200 {object} models.AttendanceCorrection
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/attendance/corrections/{id}/reject [put]
+func RejectAttendanceCorrection(c *gin.Context) {
+	reviewAttendanceCorrection(c, models.AttendanceCorrectionRejected)
+}
+
+func reviewAttendanceCorrection(c *gin.Context, newStatus models.AttendanceCorrectionStatus) {
+	correctionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req ReviewAttendanceCorrectionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	var correction models.AttendanceCorrection
+	if err := requestDB(c).First(&correction, correctionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Attendance correction not found")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if user.Role != models.RoleAdmin && !managesEmployee(user.ID, correction.EmployeeID) {
+		utils.RespondError(c, http.StatusForbidden, "Only the employee's manager or an admin can review this correction")
+		return
+	}
+	if correction.RequestedBy == user.ID && user.Role != models.RoleAdmin {
+		utils.RespondError(c, http.StatusForbidden, "You cannot review a correction you requested")
+		return
+	}
+	if correction.Status != models.AttendanceCorrectionPending {
+		utils.RespondError(c, http.StatusBadRequest, "Attendance correction has already been reviewed")
+		return
+	}
+
+	oldValues := correction
+	now := time.Now()
+	correction.Status = newStatus
+	correction.ReviewedBy = &user.ID
+	correction.ReviewedAt = &now
+	correction.ReviewComment = req.Comment
+
+	tx := requestDB(c).Begin()
+	if newStatus == models.AttendanceCorrectionApproved {
+		var record models.AttendanceRecord
+		if err := tx.Where("employee_id = ? AND date = ?", correction.EmployeeID, correction.Date).First(&record).Error; err != nil {
+			record = models.AttendanceRecord{EmployeeID: correction.EmployeeID, Date: correction.Date}
+		}
+		if correction.RequestedClockIn != nil {
+			record.ClockIn = correction.RequestedClockIn
+		}
+		if correction.RequestedClockOut != nil {
+			record.ClockOut = correction.RequestedClockOut
+		}
+		record.Corrected = true
+		record.Status = ""
+		utils.EvaluateAttendance(&record, utils.ResolveWorkSchedule(correction.EmployeeID, correction.Date))
+
+		if err := tx.Save(&record).Error; err != nil {
+			tx.Rollback()
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update attendance record")
+			return
+		}
+		correction.AttendanceID = &record.ID
+	}
+	if err := tx.Save(&correction).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to review attendance correction")
+		return
+	}
+	tx.Commit()
+
+	action := models.AuditActionReject
+	if newStatus == models.AttendanceCorrectionApproved {
+		action = models.AuditActionApprove
+	}
+	createAuditLog(models.AuditEntityAttendance, correction.ID, action, user.ID, c, oldValues, correction)
+
+	c.JSON(http.StatusOK, correction)
+}
+
+// respondMonthlyAttendance writes an employee's attendance for the month in the query string
+func respondMonthlyAttendance(c *gin.Context, employeeID uint) {
+	monthStart, ok := parseAttendanceMonth(c)
+	if !ok {
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	var records []models.AttendanceRecord
+	requestDB(c).Where("employee_id = ? AND date >= ? AND date <= ?", employeeID, monthStart, monthStart.AddDate(0, 1, -1)).
+		Order("date").Find(&records)
+
+	c.JSON(http.StatusOK, MonthlyAttendance{
+		Month:   monthStart.Format("2006-01"),
+		Summary: utils.SummarizeAttendance([]models.Employee{employee}, records)[0],
+		Records: records,
+	})
+}
+
+// parseAttendanceMonth reads the month query parameter, defaulting to the current month
+func parseAttendanceMonth(c *gin.Context) (time.Time, bool) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if month := c.Query("month"); month != "" {
+		parsed, err := time.ParseInLocation("2006-01", month, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid month format. Use YYYY-MM")
+			return time.Time{}, false
+		}
+		monthStart = parsed
+	}
+	return monthStart, true
+}
+
+// parseClockOnDate combines a date with an HH:MM clock time
+func parseClockOnDate(date time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, date.Location()), nil
+}
+
+// managesEmployee reports whether managerID is recorded as the employee's manager
+func managesEmployee(managerID, employeeID uint) bool {
+	var count int64
+	database.DB.Model(&models.EmploymentDetails{}).Where("employee_id = ? AND manager_id = ?", employeeID, managerID).Count(&count)
+	return count > 0
+}
@@ -0,0 +1,525 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// clockEventMaxSkew is how far ahead of the server's clock a terminal's clock may run
+const clockEventMaxSkew = 10 * time.Minute
+
+// clockEventTimeLayouts are accepted for clock event timestamps besides RFC3339. They carry no
+// timezone and are read in the server's local time, which attendance days run on.
+var clockEventTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04"}
+
+// clockEventImportColumns are the columns of a clock event import file; direction is optional
+var clockEventImportColumns = []string{"device_id", "badge", "timestamp", "direction"}
+
+// AttendanceDeviceRequest represents data for registering or updating an attendance terminal
+type AttendanceDeviceRequest struct {
+	DeviceID  string  `json:"device_id" binding:"required,max=100" example:"ZK-F22-0457"` // Serial number or name the terminal reports
+	Name      string  `json:"name" binding:"required,max=100" example:"Head office main entrance"`
+	Location  *string `json:"location,omitempty" binding:"omitempty,max=100" example:"Lusaka"`
+	IsActive  *bool   `json:"is_active,omitempty" example:"true"`
+	RotateKey bool    `json:"rotate_key,omitempty" example:"false"` // Issue a new key; the old one stops working at once
+}
+
+// AttendanceDeviceResponse is an attendance terminal, with its key when it has just been issued
+type AttendanceDeviceResponse struct {
+	models.AttendanceDevice
+	Key string `json:"key,omitempty" example:"dev_5b0e..."`
+}
+
+// ClockEventRequest is one clock event pushed by a terminal
+type ClockEventRequest struct {
+	Badge     string `json:"badge" binding:"required,max=50" example:"00417"`
+	Timestamp string `json:"timestamp" binding:"required" example:"2025-03-14T07:58:12+02:00"` // RFC3339, or YYYY-MM-DD HH:MM:SS in server time
+	Direction string `json:"direction,omitempty" binding:"omitempty,oneof=in out" example:"in"`
+}
+
+// ClockEventsRequest is a batch of clock events pushed by a terminal
+type ClockEventsRequest struct {
+	Events []ClockEventRequest `json:"events" binding:"required,max=1000,dive"`
+}
+
+// ClockEventsResponse says what became of a batch of clock events. Events that were invalid are
+// listed in errors by their position in the batch, or their line in an import file.
+type ClockEventsResponse struct {
+	utils.ClockEventCounts
+	Total  int              `json:"total" example:"46"`
+	Failed int              `json:"failed" example:"0"`
+	Errors []ImportRowError `json:"errors,omitempty"`
+}
+
+var clockEventListFields = ListFields{
+	Filters: map[string]string{
+		"device_id": "device_id", "badge": "badge", "employee_id": "employee_id", "status": "status", "source": "source",
+	},
+	Sorts:       map[string]string{"id": "id", "timestamp": "occurred_at", "created_at": "created_at"},
+	DefaultSort: "-timestamp",
+}
+
+// GetAttendanceDevices lists attendance terminals
+// @Summary Get attendance devices
+// @Description List the biometric and badge terminals registered to send clock events. Keys are not returned (Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.AttendanceDevice
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/admin/attendance/devices [get]
+func GetAttendanceDevices(c *gin.Context) {
+	var devices []models.AttendanceDevice
+	requestDB(c).Order("name").Find(&devices)
+
+	c.JSON(http.StatusOK, devices)
+}
+
+// CreateAttendanceDevice registers an attendance terminal
+// @Summary Create attendance device
+// @Description Register a terminal and issue the key it sends clock events with, in the X-Device-Key header of POST /integrations/attendance/events. The key is only returned here and when it is rotated (Admin only)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body AttendanceDeviceRequest true "Device"
+// @Success 201 {object} AttendanceDeviceResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/attendance/devices [post]
+func CreateAttendanceDevice(c *gin.Context) {
+	var req AttendanceDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	key, err := utils.GenerateDeviceKey()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate device key")
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	device := models.AttendanceDevice{
+		DeviceID:  strings.TrimSpace(req.DeviceID),
+		Name:      req.Name,
+		Location:  req.Location,
+		KeyHash:   utils.HashDeviceKey(key),
+		IsActive:  true,
+		CreatedBy: &userID,
+	}
+	if req.IsActive != nil {
+		device.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Create(&device).Error; err != nil {
+		if database.IsDuplicateKey(err) {
+			utils.RespondError(c, http.StatusConflict, "A device with this device ID is already registered")
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create attendance device")
+		return
+	}
+
+	createAuditLog(models.AuditEntityDevice, device.ID, models.AuditActionCreate, userID, c, nil, device)
+
+	c.JSON(http.StatusCreated, AttendanceDeviceResponse{AttendanceDevice: device, Key: key})
+}
+
+// UpdateAttendanceDevice updates an attendance terminal
+// @Summary Update attendance device
+// @Description Rename or move a terminal, deactivate it so its events are refused, or rotate its key (Admin only)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Device ID"
+// @Param request body AttendanceDeviceRequest true "Device"
+// @Success 200 {object} AttendanceDeviceResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/attendance/devices/{id} [put]
+func UpdateAttendanceDevice(c *gin.Context) {
+	deviceID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req AttendanceDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var device models.AttendanceDevice
+	if err := requestDB(c).First(&device, deviceID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Attendance device not found")
+		return
+	}
+	oldDevice := device
+
+	device.DeviceID = strings.TrimSpace(req.DeviceID)
+	device.Name = req.Name
+	device.Location = req.Location
+	if req.IsActive != nil {
+		device.IsActive = *req.IsActive
+	}
+	response := AttendanceDeviceResponse{}
+	if req.RotateKey {
+		key, err := utils.GenerateDeviceKey()
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate device key")
+			return
+		}
+		device.KeyHash = utils.HashDeviceKey(key)
+		response.Key = key
+	}
+	if err := requestDB(c).Save(&device).Error; err != nil {
+		if database.IsDuplicateKey(err) {
+			utils.RespondError(c, http.StatusConflict, "A device with this device ID is already registered")
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update attendance device")
+		return
+	}
+
+	createAuditLog(models.AuditEntityDevice, device.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldDevice, device)
+
+	response.AttendanceDevice = device
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteAttendanceDevice removes an attendance terminal
+// @Summary Delete attendance device
+// @Description Remove a terminal that has sent no clock events. Deactivate a terminal with events instead, so its history is kept (Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Device ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/attendance/devices/{id} [delete]
+func DeleteAttendanceDevice(c *gin.Context) {
+	deviceID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var device models.AttendanceDevice
+	if err := requestDB(c).First(&device, deviceID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Attendance device not found")
+		return
+	}
+
+	var events int64
+	requestDB(c).Model(&models.ClockEvent{}).Where("device_id = ?", device.ID).Count(&events)
+	if events > 0 {
+		utils.RespondError(c, http.StatusBadRequest, "Device has clock events. Deactivate it instead")
+		return
+	}
+
+	if err := requestDB(c).Delete(&device).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete attendance device")
+		return
+	}
+
+	createAuditLog(models.AuditEntityDevice, device.ID, models.AuditActionDelete, c.GetUint("user_id"), c, device, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attendance device deleted successfully"})
+}
+
+// PushClockEvents receives clock events from an attendance terminal, which authenticates with its
+// key in the X-Device-Key header. Terminals may resend events they are unsure were received; events
+// already received are counted as duplicates.
+func PushClockEvents(c *gin.Context) {
+	key := c.GetHeader("X-Device-Key")
+	var device models.AttendanceDevice
+	if key == "" || database.DB.Where("key_hash = ?", utils.HashDeviceKey(key)).First(&device).Error != nil {
+		utils.RespondError(c, http.StatusUnauthorized, "Invalid device key")
+		return
+	}
+	if !device.IsActive {
+		utils.RespondError(c, http.StatusForbidden, "Device is deactivated")
+		return
+	}
+	c.Set("organization_id", device.OrganizationID)
+	c.Request = c.Request.WithContext(database.WithOrganization(c.Request.Context(), device.OrganizationID))
+
+	var req ClockEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	now := time.Now()
+	requestDB(c).Model(&device).Update("last_seen_at", now)
+
+	response := ClockEventsResponse{Total: len(req.Events)}
+	var inputs []utils.ClockEventInput
+	for i, event := range req.Events {
+		timestamp, err := parseClockEventTime(event.Timestamp, now)
+		if err != nil {
+			response.fail(c, i, "timestamp", err.Error())
+			continue
+		}
+		inputs = append(inputs, utils.ClockEventInput{
+			DeviceID: device.ID, Badge: event.Badge, Timestamp: timestamp, Direction: models.ClockDirection(event.Direction),
+		})
+	}
+
+	ingestClockEvents(c, models.ClockEventSourceDevice, inputs, response)
+}
+
+// ImportClockEvents imports clock events from a CSV file
+// @Summary Import clock events
+// @Description Import clock events exported from attendance terminals, for terminals that cannot push them. The file has device_id, badge and timestamp columns and an optional direction column (in or out). device_id is the ID the terminal was registered with, and timestamps are RFC3339 or YYYY-MM-DD HH:MM:SS in server time. Events are applied in time order whatever the order of the file, events already received are counted as duplicates, and events whose badge matches no employee's badge or employee number are kept as unmatched. Invalid rows are reported by line and column (Admin only)
+// @Tags Attendance
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file with clock events"
+// @Success 200 {object} ClockEventsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/attendance/clock-events/import [post]
+func ImportClockEvents(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid CSV file")
+		return
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		// Spreadsheet programs may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		known := false
+		for _, column := range clockEventImportColumns {
+			known = known || name == column
+		}
+		if !known {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Unknown column %s. Use device_id, badge, timestamp and direction.", name))
+			return
+		}
+		columns[name] = i
+	}
+	for _, column := range clockEventImportColumns[:3] {
+		if _, ok := columns[column]; !ok {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "The file needs a %s column", column))
+			return
+		}
+	}
+
+	var devices []models.AttendanceDevice
+	requestDB(c).Find(&devices)
+	deviceIDs := map[string]uint{}
+	for _, device := range devices {
+		deviceIDs[strings.ToLower(device.DeviceID)] = device.ID
+	}
+
+	now := time.Now()
+	var response ClockEventsResponse
+	var inputs []utils.ClockEventInput
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			response.Total++
+			response.fail(c, line, "", "Failed to parse row")
+			continue
+		}
+		cell := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if cell("device_id") == "" && cell("badge") == "" && cell("timestamp") == "" {
+			continue // Blank line
+		}
+		response.Total++
+
+		deviceID, ok := deviceIDs[strings.ToLower(cell("device_id"))]
+		if !ok {
+			response.fail(c, line, "device_id", "No attendance device with device ID %s", cell("device_id"))
+			continue
+		}
+		badge := cell("badge")
+		if badge == "" || len(badge) > 50 {
+			response.fail(c, line, "badge", "Badge is required and at most 50 characters")
+			continue
+		}
+		timestamp, err := parseClockEventTime(cell("timestamp"), now)
+		if err != nil {
+			response.fail(c, line, "timestamp", err.Error())
+			continue
+		}
+		direction := models.ClockDirection(strings.ToLower(cell("direction")))
+		if direction != "" && direction != models.ClockDirectionIn && direction != models.ClockDirectionOut {
+			response.fail(c, line, "direction", "Direction must be in or out")
+			continue
+		}
+		inputs = append(inputs, utils.ClockEventInput{DeviceID: deviceID, Badge: badge, Timestamp: timestamp, Direction: direction})
+	}
+
+	ingestClockEvents(c, models.ClockEventSourceCSV, inputs, response)
+}
+
+// ingestClockEvents stores the valid events of a batch and responds with what became of them
+func ingestClockEvents(c *gin.Context, source models.ClockEventSource, inputs []utils.ClockEventInput, response ClockEventsResponse) {
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		var err error
+		response.ClockEventCounts, err = utils.IngestClockEvents(tx, source, inputs)
+		return err
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to record clock events")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (r *ClockEventsResponse) fail(c *gin.Context, row int, column, message string, args ...interface{}) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRowError{Row: row, Column: column, Message: i18n.T(utils.RequestLanguage(c), message, args...)})
+}
+
+// parseClockEventTime reads a clock event timestamp, refusing times ahead of now by more than a
+// terminal's clock may be off
+func parseClockEventTime(value string, now time.Time) (time.Time, error) {
+	timestamp, err := time.Parse(time.RFC3339, value)
+	for _, layout := range clockEventTimeLayouts {
+		if err == nil {
+			break
+		}
+		timestamp, err = time.ParseInLocation(layout, value, time.Local)
+	}
+	if err != nil {
+		return time.Time{}, errors.New("Invalid timestamp. Use RFC3339 or YYYY-MM-DD HH:MM:SS")
+	}
+	if timestamp.After(now.Add(clockEventMaxSkew)) {
+		return time.Time{}, errors.New("Timestamp is in the future")
+	}
+	return timestamp, nil
+}
+
+// GetClockEvents lists clock events received from attendance terminals
+// @Summary Get clock events
+// @Description List clock events received from terminals, newest first, with what became of each: applied to attendance, duplicate of an event already received, unmatched to any employee, or ignored because the day's attendance was corrected (Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param device_id query int false "Device ID"
+// @Param badge query string false "Badge"
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "Status (applied, duplicate, unmatched, ignored)"
+// @Param source query string false "Source (device, csv)"
+// @Param from query string false "Only events at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only events at or before this time (RFC3339, or YYYY-MM-DD for the whole day)"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, timestamp, created_at). Defaults to -timestamp"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.ClockEvent}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/attendance/clock-events [get]
+func GetClockEvents(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Model(&models.ClockEvent{})
+	if from := c.Query("from"); from != "" {
+		fromTime, _, err := parseAuditTime(from)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from. Use RFC3339 or YYYY-MM-DD")
+			return
+		}
+		query = query.Where("occurred_at >= ?", fromTime.UTC())
+	}
+	if to := c.Query("to"); to != "" {
+		toTime, dateOnly, err := parseAuditTime(to)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to. Use RFC3339 or YYYY-MM-DD")
+			return
+		}
+		if dateOnly {
+			query = query.Where("occurred_at < ?", toTime.AddDate(0, 0, 1).UTC())
+		} else {
+			query = query.Where("occurred_at <= ?", toTime.UTC())
+		}
+	}
+	query, ok = applyListQuery(c, query, clockEventListFields)
+	if !ok {
+		return
+	}
+
+	var events []models.ClockEvent
+	response, err := paginate(query, pagination, &events)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch clock events")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RematchClockEvents matches unmatched clock events to employees again
+// @Summary Rematch clock events
+// @Description Match unmatched clock events to employees again, after badge numbers have been assigned, and apply those that now match to attendance (Admin only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ClockEventCounts
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/attendance/clock-events/rematch [post]
+func RematchClockEvents(c *gin.Context) {
+	var counts utils.ClockEventCounts
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		var err error
+		counts, err = utils.RematchClockEvents(tx)
+		return err
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to rematch clock events")
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
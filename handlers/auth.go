@@ -5,10 +5,10 @@ import (
 	"hrms-api/models"
 	"hrms-api/utils"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // LoginRequest represents login credentials (use NRC for employees/managers, username for admins)
@@ -21,6 +21,7 @@ type LoginRequest struct {
 // RegisterRequest represents new employee registration data
 type RegisterRequest struct {
 	NRC        string      `json:"nrc" binding:"required" example:"123456/78/9"`
+	NRCCountry string      `json:"nrc_country,omitempty" example:"ZM"` // Country whose national ID format the NRC follows, defaults to the organization's default format
 	Firstname  string      `json:"firstname" binding:"required" example:"John"`
 	Lastname   string      `json:"lastname" binding:"required" example:"Doe"`
 	Email      string      `json:"email" binding:"required,email" example:"john@example.com"`
@@ -28,6 +29,8 @@ type RegisterRequest struct {
 	Department string      `json:"department" example:"IT"`
 	Role       models.Role `json:"role" example:"employee"`
 	HireDate   *string     `json:"hire_date,omitempty" example:"2025-01-15"` // Optional: YYYY-MM-DD format, defaults to today if not provided
+	// Optional: code of the organization to join, defaults to the default organization
+	OrganizationCode string `json:"organization_code,omitempty" example:"default"`
 }
 
 // AdminLoginRequest represents admin login credentials
@@ -43,9 +46,7 @@ type AuthResponse struct {
 }
 
 // ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error" example:"Invalid credentials"`
-}
+type ErrorResponse = utils.ErrorResponse
 
 // Login authenticates an employee/manager with NRC and password
 // @Summary Employee/Manager login
@@ -55,41 +56,47 @@ type ErrorResponse struct {
 // @Produce json
 // @Param request body LoginRequest true "Login credentials (use NRC)"
 // @Success 200 {object} AuthResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /auth/login [post]
 func Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	if req.NRC == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "NRC is required for employee/manager login"})
+		utils.RespondError(c, http.StatusBadRequest, "NRC is required for employee/manager login")
 		return
 	}
 
 	var employee models.Employee
-	if err := database.DB.Where("nrc = ?", req.NRC).First(&employee).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	if err := utils.WhereNationalID(requestDB(c), req.NRC).First(&employee).Error; err != nil {
+		recordLogin(c, req.NRC, nil, false)
+		utils.RespondErrorCode(c, http.StatusUnauthorized, utils.CodeInvalidCredentials, "Invalid credentials", nil)
 		return
 	}
 
 	// Prevent admin from logging in via NRC endpoint
 	if employee.Role == models.RoleAdmin {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admins must use /auth/admin/login"})
+		recordLogin(c, req.NRC, &employee, false)
+		utils.RespondError(c, http.StatusUnauthorized, "Admins must use /auth/admin/login")
 		return
 	}
 
 	if !utils.CheckPasswordHash(req.Password, employee.PasswordHash) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		recordLogin(c, req.NRC, &employee, false)
+		utils.RespondErrorCode(c, http.StatusUnauthorized, utils.CodeInvalidCredentials, "Invalid credentials", nil)
 		return
 	}
 
+	recordLogin(c, req.NRC, &employee, true)
+	rememberLanguage(c, &employee)
+
 	token, err := utils.GenerateToken(&employee)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
@@ -109,32 +116,37 @@ func Login(c *gin.Context) {
 // @Produce json
 // @Param request body AdminLoginRequest true "Admin login credentials"
 // @Success 200 {object} AuthResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /auth/admin/login [post]
 func AdminLogin(c *gin.Context) {
 	var req AdminLoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	var employee models.Employee
-	if err := database.DB.Where("username = ? AND role = ?", req.Username, models.RoleAdmin).First(&employee).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	if err := requestDB(c).Where("username = ? AND role = ?", req.Username, models.RoleAdmin).First(&employee).Error; err != nil {
+		recordLogin(c, req.Username, nil, false)
+		utils.RespondErrorCode(c, http.StatusUnauthorized, utils.CodeInvalidCredentials, "Invalid credentials", nil)
 		return
 	}
 
 	// Check password hash
 	passwordValid := utils.CheckPasswordHash(req.Password, employee.PasswordHash)
 	if !passwordValid {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		recordLogin(c, req.Username, &employee, false)
+		utils.RespondErrorCode(c, http.StatusUnauthorized, utils.CodeInvalidCredentials, "Invalid credentials", nil)
 		return
 	}
 
+	recordLogin(c, req.Username, &employee, true)
+	rememberLanguage(c, &employee)
+
 	token, err := utils.GenerateToken(&employee)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
@@ -145,6 +157,39 @@ func AdminLogin(c *gin.Context) {
 	})
 }
 
+// recordLogin adds a sign-in attempt to the login log. employee is nil when no account matched the
+// identifier, in which case the attempt is logged under the default organization.
+func recordLogin(c *gin.Context, identifier string, employee *models.Employee, success bool) {
+	entry := models.LoginLog{
+		OrganizationID: models.DefaultOrganizationID,
+		Identifier:     identifier,
+		Success:        success,
+		IPAddress:      getStringPtr(c.ClientIP()),
+		UserAgent:      getStringPtr(c.GetHeader("User-Agent")),
+	}
+	if employee != nil {
+		entry.OrganizationID = employee.OrganizationID
+		entry.EmployeeID = &employee.ID
+	}
+	requestDB(c).Create(&entry)
+}
+
+// rememberLanguage stores the language negotiated from the client's Accept-Language header on the
+// employee, so that notifications sent to them later, outside any request, use it too. Clients that
+// send no Accept-Language leave the stored language as it is.
+func rememberLanguage(c *gin.Context, employee *models.Employee) {
+	if c.GetHeader("Accept-Language") == "" {
+		return
+	}
+	lang := string(utils.RequestLanguage(c))
+	if lang == employee.Language {
+		return
+	}
+	if err := requestDB(c).Model(employee).Update("language", lang).Error; err == nil {
+		employee.Language = lang
+	}
+}
+
 // Register creates a new employee account
 // @Summary Register new employee
 // @Description Create a new employee account
@@ -153,13 +198,13 @@ func AdminLogin(c *gin.Context) {
 // @Produce json
 // @Param request body RegisterRequest true "Employee registration data"
 // @Success 201 {object} AuthResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 409 {object} ErrorResponse "NRC or email already exists"
 // @Router /auth/register [post]
 func Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -174,7 +219,7 @@ func Register(c *gin.Context) {
 			}
 		}
 		if !valid {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Must be: employee, manager, or admin"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid role. Must be: employee, manager, or admin")
 			return
 		}
 	} else {
@@ -183,56 +228,55 @@ func Register(c *gin.Context) {
 
 	// Admins cannot be registered via this endpoint
 	if req.Role == models.RoleAdmin {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Admin accounts cannot be created via registration"})
+		utils.RespondError(c, http.StatusBadRequest, "Admin accounts cannot be created via registration")
 		return
 	}
 
-	// Check if NRC or email already exists (including soft-deleted records)
-	var existingEmployee models.Employee
-	if err := database.DB.Unscoped().Where("nrc = ? OR email = ?", req.NRC, req.Email).First(&existingEmployee).Error; err == nil {
-		// If found and it's soft-deleted, permanently delete it to allow reuse
-		if existingEmployee.DeletedAt.Valid {
-			// Permanently delete the soft-deleted employee to allow NRC/email reuse
-			if err := database.DB.Unscoped().Delete(&existingEmployee).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clean up deleted employee record"})
-				return
-			}
-		} else {
-			// Active employee with this NRC/email exists
-			c.JSON(http.StatusConflict, gin.H{"error": "NRC or email already exists"})
+	organization := models.Organization{ID: models.DefaultOrganizationID}
+	if req.OrganizationCode != "" {
+		if err := requestDB(c).Where("code = ? AND is_active = ?", req.OrganizationCode, true).First(&organization).Error; err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Unknown organization code")
 			return
 		}
 	}
 
+	nrc, ok := normalizeNRC(c, organization.ID, req.NRCCountry, req.NRC)
+	if !ok {
+		return
+	}
+
+	// Deleted employees do not hold on to their NRC or email, so former employees can register again
+	var existingEmployee models.Employee
+	if err := requestDB(c).Where("nrc = ? OR "+utils.NationalIDCompactSQL()+" = ? OR email = ?", nrc, utils.CompactNationalID(nrc), req.Email).First(&existingEmployee).Error; err == nil {
+		utils.RespondError(c, http.StatusConflict, "NRC or email already exists")
+		return
+	}
+	previousEmployeeID, err := formerEmployeeID(requestDB(c).Where("organization_id = ?", organization.ID), nrc)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create employee")
+		return
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to hash password")
 		return
 	}
 
-	nrc := req.NRC
 	var emailPtr *string
 	if req.Email != "" {
 		emailPtr = &req.Email
 	}
 	employee := models.Employee{
-		NRC:          &nrc,
-		Firstname:    req.Firstname,
-		Lastname:     req.Lastname,
-		Email:        emailPtr,
-		PasswordHash: hashedPassword,
-		Department:   req.Department,
-		Role:         req.Role,
-	}
-
-	if err := database.DB.Create(&employee).Error; err != nil {
-		// Check for duplicate key constraint violation
-		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
-			c.JSON(http.StatusConflict, gin.H{"error": "NRC or email already exists in the database"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create employee: " + err.Error()})
-		return
+		OrganizationID:     organization.ID,
+		NRC:                &nrc,
+		Firstname:          req.Firstname,
+		Lastname:           req.Lastname,
+		Email:              emailPtr,
+		PasswordHash:       hashedPassword,
+		Department:         req.Department,
+		Role:               req.Role,
+		PreviousEmployeeID: previousEmployeeID,
 	}
 
 	// Automatically create EmploymentDetails with hire date
@@ -255,22 +299,35 @@ func Register(c *gin.Context) {
 
 	// Create EmploymentDetails
 	employmentDetails := models.EmploymentDetails{
-		EmployeeID:       employee.ID,
 		EmploymentType:   models.EmploymentTypeFullTime,
 		EmploymentStatus: models.EmploymentStatusActive,
 		HireDate:         hireDate,
 		StartDate:        hireDate, // Set start date same as hire date
 	}
 
-	if err := database.DB.Create(&employmentDetails).Error; err != nil {
-		// Log error but don't fail employee creation
-		// EmploymentDetails can be added later via the employment endpoint
-		// Continue with token generation
+	// The employee and their employment details are created together
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&employee).Error; err != nil {
+			return err
+		}
+		employmentDetails.EmployeeID = employee.ID
+		return tx.Create(&employmentDetails).Error
+	})
+	if err != nil {
+		// Check for duplicate key constraint violation
+		if database.IsDuplicateKey(err) {
+			utils.RespondError(c, http.StatusConflict, "NRC or email already exists in the database")
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create employee: "+err.Error())
+		return
 	}
 
+	rememberLanguage(c, &employee)
+
 	token, err := utils.GenerateToken(&employee)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
@@ -280,3 +337,15 @@ func Register(c *gin.Context) {
 		Employee: employee,
 	})
 }
+
+// formerEmployeeID returns the ID of the most recently deleted employee with the given NRC, so a
+// rehired employee's new record can point to their history, or nil if there is none
+func formerEmployeeID(db *gorm.DB, nrc string) (*uint, error) {
+	var former models.Employee
+	err := utils.WhereNationalID(db.Unscoped().Where("deleted_at IS NOT NULL"), nrc).
+		Order("deleted_at DESC").Limit(1).Find(&former).Error
+	if err != nil || former.ID == 0 {
+		return nil, err
+	}
+	return &former.ID, nil
+}
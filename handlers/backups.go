@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/backup"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBackup starts a backup of the database and document files
+// @Summary Create backup
+// @Description Start writing a backup bundle (database dump plus document files with their checksums) to BACKUPS_PATH. The backup runs in the background; follow it with GET /api/admin/backup-jobs/{id}. Only one backup or restore runs at a time (Admins of the default organization only)
+// @Tags Admin - Backups
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} backup.Job
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "A backup or restore is already running"
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse "The database is not PostgreSQL"
+// @Router /api/admin/backups [post]
+func CreateBackup(c *gin.Context) {
+	if !requireBackupAccess(c) {
+		return
+	}
+
+	job, err := backup.StartBackup()
+	if err != nil {
+		respondBackupJobError(c, err, "Failed to start backup")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetBackups lists the backup bundles
+// @Summary Get backups
+// @Description List the completed backup bundles in BACKUPS_PATH, newest first (Admins of the default organization only)
+// @Tags Admin - Backups
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} backup.Bundle
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/backups [get]
+func GetBackups(c *gin.Context) {
+	if !requireBackupAccess(c) {
+		return
+	}
+
+	bundles, err := backup.ListBundles()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to list backups")
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles)
+}
+
+// DownloadBackup downloads a backup bundle
+// @Summary Download backup
+// @Description Download a backup bundle, e.g. to keep it off the server or restore it into another instance (Admins of the default organization only)
+// @Tags Admin - Backups
+// @Produce application/gzip
+// @Security BearerAuth
+// @Param name path string true "Bundle name"
+// @Success 200 {file} file "Backup bundle"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/admin/backups/{name} [get]
+func DownloadBackup(c *gin.Context) {
+	if !requireBackupAccess(c) {
+		return
+	}
+
+	filename, err := backup.BundlePath(c.Param("name"))
+	if err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Backup not found")
+		return
+	}
+
+	c.FileAttachment(filename, c.Param("name"))
+}
+
+// RestoreBackup starts restoring a backup bundle
+// @Summary Restore backup
+// @Description Replace all data and document files with a backup bundle, either uploaded as file or named by name from GET /api/admin/backups. Meant for a fresh instance: everything currently stored is replaced, including the accounts, so sign in again with an account from the backup afterwards. The restore runs in the background in a single transaction and leaves the instance unchanged if the bundle fails its checks; follow it with GET /api/admin/backup-jobs/{id}. Set confirm to true to proceed (Admins of the default organization only)
+// @Tags Admin - Backups
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file false "Backup bundle to upload"
+// @Param name formData string false "Name of a bundle in BACKUPS_PATH"
+// @Param confirm formData bool true "Must be true"
+// @Success 202 {object} backup.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "A backup or restore is already running"
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse "The database is not PostgreSQL"
+// @Router /api/admin/backups/restore [post]
+func RestoreBackup(c *gin.Context) {
+	if !requireBackupAccess(c) {
+		return
+	}
+	if c.PostForm("confirm") != "true" {
+		utils.RespondError(c, http.StatusBadRequest, "Restoring replaces all data and documents; set confirm to true to proceed")
+		return
+	}
+
+	var filename string
+	uploaded := false
+	if name := c.PostForm("name"); name != "" {
+		path, err := backup.BundlePath(name)
+		if err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Backup not found")
+			return
+		}
+		filename = path
+	} else {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Upload a backup file or name a stored backup")
+			return
+		}
+		path, err := backup.UploadPath()
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to save uploaded backup")
+			return
+		}
+		if err := c.SaveUploadedFile(fileHeader, path); err != nil {
+			os.Remove(path)
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to save uploaded backup")
+			return
+		}
+		filename = path
+		uploaded = true
+	}
+
+	job, err := backup.StartRestore(filename, uploaded)
+	if err != nil {
+		respondBackupJobError(c, err, "Failed to start restore")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetBackupJob returns the progress of a backup or restore
+// @Summary Get backup job
+// @Description Get the status and progress of a backup or restore started since the server started (Admins of the default organization only)
+// @Tags Admin - Backups
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} backup.Job
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/admin/backup-jobs/{id} [get]
+func GetBackupJob(c *gin.Context) {
+	if !requireBackupAccess(c) {
+		return
+	}
+
+	job, ok := backup.GetJob(c.Param("id"))
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, "Backup job not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// requireBackupAccess only lets admins of the default organization back up and restore, as bundles
+// hold every organization's data
+func requireBackupAccess(c *gin.Context) bool {
+	if c.GetUint("organization_id") != models.DefaultOrganizationID {
+		utils.RespondError(c, http.StatusForbidden, "Only admins of the default organization can manage backups")
+		return false
+	}
+	return true
+}
+
+func respondBackupJobError(c *gin.Context, err error, message string) {
+	if errors.Is(err, backup.ErrJobRunning) {
+		utils.RespondError(c, http.StatusConflict, "A backup or restore is already running")
+		return
+	}
+	if errors.Is(err, backup.ErrUnsupportedDatabase) {
+		utils.RespondError(c, http.StatusNotImplemented, "Backups are only supported on PostgreSQL")
+		return
+	}
+	utils.RespondError(c, http.StatusInternalServerError, message)
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BankDetailsRequest represents data for creating or updating an employee's bank details
+type BankDetailsRequest struct {
+	BankName      string  `json:"bank_name" binding:"required" example:"Zanaco"`
+	BranchName    *string `json:"branch_name,omitempty" example:"Cairo Road"`
+	BranchCode    *string `json:"branch_code,omitempty" example:"010203"`
+	AccountName   string  `json:"account_name" binding:"required" example:"Jane Doe"`
+	AccountNumber string  `json:"account_number" binding:"required" example:"0123456789012"`
+	AccountType   *string `json:"account_type,omitempty" example:"savings"`
+	SwiftCode     *string `json:"swift_code,omitempty" example:"ZNCOZMLU"`
+	Currency      string  `json:"currency,omitempty" example:"ZMW"`
+}
+
+// SetPayrollAccessRequest represents a change to an employee's payroll access permission
+type SetPayrollAccessRequest struct {
+	PayrollAccess bool `json:"payroll_access" example:"true"`
+}
+
+// GetBankDetails retrieves an employee's bank details with the account number masked
+// @Summary Get employee bank details
+// @Description Get an employee's bank details with the account number masked. Employees can view their own; admins can view anyone's
+// @Tags Core HR - Bank Details
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} models.BankDetails
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/employees/{id}/bank-details [get]
+func GetBankDetails(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	user := getCurrentUser(c)
+	if user == nil || (user.ID != uint(employeeID) && user.Role != models.RoleAdmin) {
+		utils.RespondError(c, http.StatusForbidden, "You can only view your own bank details")
+		return
+	}
+
+	var details models.BankDetails
+	if err := requestDB(c).Where("employee_id = ?", employeeID).First(&details).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Bank details not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, maskBankDetails(details))
+}
+
+// CreateOrUpdateBankDetails creates or updates an employee's bank details
+// @Summary Create or update bank details
+// @Description Create or update an employee's bank details. The response masks the account number (Admin only)
+// @Tags Core HR - Bank Details
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body BankDetailsRequest true "Bank details"
+// @Success 200 {object} models.BankDetails
+// @Success 201 {object} models.BankDetails
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/bank-details [put]
+func CreateOrUpdateBankDetails(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req BankDetailsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	updatedBy := userID.(uint)
+
+	var details models.BankDetails
+	err := requestDB(c).Where("employee_id = ?", employeeID).First(&details).Error
+	isNew := err != nil
+	oldValues := maskBankDetails(details)
+
+	details.EmployeeID = uint(employeeID)
+	details.BankName = req.BankName
+	details.BranchName = req.BranchName
+	details.BranchCode = req.BranchCode
+	details.AccountName = req.AccountName
+	details.AccountNumber = req.AccountNumber
+	details.AccountType = req.AccountType
+	details.SwiftCode = req.SwiftCode
+	if req.Currency != "" {
+		details.Currency = req.Currency
+	}
+	details.UpdatedBy = &updatedBy
+
+	if err := requestDB(c).Save(&details).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save bank details")
+		return
+	}
+
+	// Audit entries only ever hold the masked account number
+	masked := maskBankDetails(details)
+	if isNew {
+		createAuditLog(models.AuditEntityBankDetails, details.ID, models.AuditActionCreate, updatedBy, c, nil, masked)
+		c.JSON(http.StatusCreated, masked)
+		return
+	}
+	createAuditLog(models.AuditEntityBankDetails, details.ID, models.AuditActionUpdate, updatedBy, c, oldValues, masked)
+	c.JSON(http.StatusOK, masked)
+}
+
+// GetUnmaskedBankDetails retrieves an employee's full bank details for payroll
+// @Summary Get unmasked bank details
+// @Description Get an employee's bank details including the full account number. Every call is audit logged (Payroll access only)
+// @Tags Core HR - Bank Details
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} models.BankDetails
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/payroll/employees/{id}/bank-details [get]
+func GetUnmaskedBankDetails(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var details models.BankDetails
+	if err := requestDB(c).Where("employee_id = ?", employeeID).First(&details).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Bank details not found")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityBankDetails, details.ID, models.AuditActionView, userID.(uint), c, nil, nil)
+
+	c.JSON(http.StatusOK, details)
+}
+
+// bankDetailsListFields are the filters and sort keys accepted by the payroll bank details list
+var bankDetailsListFields = ListFields{
+	Filters: map[string]string{"department": "employees.department"},
+	Sorts: map[string]string{
+		"employee_id": "bank_details.employee_id", "department": "employees.department", "lastname": "employees.lastname",
+	},
+	DefaultSort: "employee_id",
+	Tiebreaker:  "bank_details.id",
+}
+
+// GetPayrollBankDetails lists full bank details for all employees for a payroll run
+// @Summary List unmasked bank details
+// @Description List bank details with full account numbers for all employees, optionally filtered by department. Every record returned is audit logged (Payroll access only)
+// @Tags Core HR - Bank Details
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Department filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (employee_id, department, lastname). Defaults to employee_id"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.BankDetails}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/payroll/bank-details [get]
+func GetPayrollBankDetails(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").
+		Joins("JOIN employees ON employees.id = bank_details.employee_id AND employees.deleted_at IS NULL")
+
+	query, ok = applyListQuery(c, query, bankDetailsListFields)
+	if !ok {
+		return
+	}
+
+	var details []models.BankDetails
+	response, err := paginate(query, pagination, &details)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch bank details")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	for _, d := range details {
+		createAuditLog(models.AuditEntityBankDetails, d.ID, models.AuditActionView, userID.(uint), c, nil, nil)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetPayrollAccess grants or revokes an employee's access to unmasked bank details
+// @Summary Set payroll access
+// @Description Grant or revoke an employee's permission to view unmasked bank details (Admin only)
+// @Tags Core HR - Bank Details
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body SetPayrollAccessRequest true "Payroll access"
+// @Success 200 {object} models.Employee
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/payroll-access [put]
+func SetPayrollAccess(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req SetPayrollAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	oldValues := gin.H{"payroll_access": employee.PayrollAccess}
+	if err := requestDB(c).Model(&employee).Update("payroll_access", req.PayrollAccess).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update payroll access")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityEmployee, employee.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, gin.H{"payroll_access": req.PayrollAccess})
+
+	c.JSON(http.StatusOK, employee)
+}
+
+// maskBankDetails returns a copy of the bank details with the account number masked
+func maskBankDetails(details models.BankDetails) models.BankDetails {
+	details.AccountNumber = utils.MaskAccountNumber(details.AccountNumber)
+	return details
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/utils"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// batchTransactionTimeout bounds how long an atomic batch may hold its transaction open
+const batchTransactionTimeout = time.Minute
+
+// batchMethods are the methods a batched request may use
+var batchMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// unbatchablePaths are the API paths that cannot be called from a batch: batches do not nest, and an
+// event stream never finishes
+var unbatchablePaths = []string{"/api/batch", "/api/events"}
+
+// errBatchRequestFailed rolls back an atomic batch when one of its requests fails
+var errBatchRequestFailed = errors.New("batch request failed")
+
+// BatchItemRequest represents one request of a batch
+type BatchItemRequest struct {
+	Method string          `json:"method" binding:"required" example:"POST"`
+	Path   string          `json:"path" binding:"required" example:"/api/employees/12/employment"` // API path, with any query string
+	Body   json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+}
+
+// BatchRequest represents requests to run one after the other
+type BatchRequest struct {
+	Atomic   bool               `json:"atomic" example:"true"` // Commit every request's changes together, or none if one fails
+	Requests []BatchItemRequest `json:"requests" binding:"required,min=1,max=50,dive"`
+}
+
+// BatchItemResponse represents the outcome of one request of a batch
+type BatchItemResponse struct {
+	Status int             `json:"status" example:"200"`
+	Body   json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+}
+
+// BatchResponse represents the outcome of each request of a batch, in order
+type BatchResponse struct {
+	RolledBack bool                `json:"rolled_back" example:"false"` // An atomic batch was rolled back because a request failed
+	Results    []BatchItemResponse `json:"results"`
+}
+
+// BatchHandler runs batches of API requests through the router, each with the caller's credentials
+type BatchHandler struct {
+	router http.Handler
+}
+
+// NewBatchHandler returns a BatchHandler that serves batched requests with router
+func NewBatchHandler(router http.Handler) *BatchHandler {
+	return &BatchHandler{router: router}
+}
+
+// Batch runs several API requests in one call
+// @Summary Run a batch of requests
+// @Description Run up to 50 API requests one after the other, each as the caller and with the result it would have on its own. Results are returned in order with each request's status and body; the batch itself succeeds even when some requests fail. With atomic set, the changes of all requests are committed together once every request has succeeded: the first request that fails (status 400 or above) rolls the batch back, and the requests after it are not run and get status 424. Events, webhooks and notifications of an atomic batch are only sent once it commits
+// @Tags Batch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BatchRequest true "Requests to run"
+// @Success 200 {object} BatchResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/batch [post]
+func (h *BatchHandler) Batch(c *gin.Context) {
+	var req BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	for i := range req.Requests {
+		if err := validateBatchItem(&req.Requests[i]); err != nil {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Invalid batch request %d: %s", i+1, err.Error()))
+			return
+		}
+	}
+
+	// Every result has already been masked by its own request
+	utils.SkipPIIMasking(c)
+
+	response := BatchResponse{Results: make([]BatchItemResponse, len(req.Requests))}
+	if !req.Atomic {
+		for i, item := range req.Requests {
+			response.Results[i] = h.serve(c, c.Request.Context(), item)
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), batchTransactionTimeout)
+	defer cancel()
+	var committed []func()
+	served := 0
+	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		batchCtx := context.WithValue(database.WithTransaction(ctx, tx), afterCommitKey{}, &committed)
+		for _, item := range req.Requests {
+			result := h.serve(c, batchCtx, item)
+			response.Results[served] = result
+			served++
+			if result.Status >= http.StatusBadRequest {
+				return errBatchRequestFailed
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errBatchRequestFailed):
+		response.RolledBack = true
+		for i := served; i < len(response.Results); i++ {
+			response.Results[i] = BatchItemResponse{Status: http.StatusFailedDependency}
+		}
+	case err != nil:
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to commit batch")
+		return
+	default:
+		for _, fn := range committed {
+			fn()
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// validateBatchItem checks a batched request's method and path, normalizing the method
+func validateBatchItem(item *BatchItemRequest) error {
+	item.Method = strings.ToUpper(item.Method)
+	supported := false
+	for _, method := range batchMethods {
+		supported = supported || item.Method == method
+	}
+	if !supported {
+		return fmt.Errorf("unsupported method %s", item.Method)
+	}
+
+	path, _, _ := strings.Cut(item.Path, "?")
+	if !strings.HasPrefix(path, "/api/") {
+		return fmt.Errorf("path must start with /api/")
+	}
+	for _, unbatchable := range unbatchablePaths {
+		if strings.TrimRight(path, "/") == unbatchable {
+			return fmt.Errorf("%s cannot be batched", unbatchable)
+		}
+	}
+	return nil
+}
+
+// serve runs a batched request through the router with ctx and the batch's own headers, so that it is
+// authenticated, authorized and logged as if the caller had sent it
+func (h *BatchHandler) serve(c *gin.Context, ctx context.Context, item BatchItemRequest) BatchItemResponse {
+	var body io.Reader = http.NoBody
+	if len(item.Body) > 0 {
+		body = bytes.NewReader(item.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, item.Method, item.Path, body)
+	if err != nil {
+		message, _ := json.Marshal(err.Error())
+		return BatchItemResponse{Status: http.StatusBadRequest, Body: message}
+	}
+	req.Header = c.Request.Header.Clone()
+	req.Header.Del("Accept-Encoding")
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", c.GetString("request_id"))
+	req.RemoteAddr = c.Request.RemoteAddr
+	req.Host = c.Request.Host
+
+	recorder := httptest.NewRecorder()
+	h.router.ServeHTTP(recorder, req)
+
+	result := BatchItemResponse{Status: recorder.Code}
+	switch {
+	case recorder.Body.Len() == 0:
+	case json.Valid(recorder.Body.Bytes()):
+		result.Body = recorder.Body.Bytes()
+	default:
+		// Not JSON, such as a file download: returned as a string
+		result.Body, _ = json.Marshal(recorder.Body.String())
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/config"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarConnectionsResponse lists the calendars the current user has connected
+type CalendarConnectionsResponse struct {
+	Providers   []models.CalendarProvider   `json:"providers"` // Providers that can be connected on this server
+	Connections []models.CalendarConnection `json:"connections"`
+}
+
+// ConnectCalendarRequest names the calendar provider to connect
+type ConnectCalendarRequest struct {
+	Provider models.CalendarProvider `json:"provider" binding:"required" example:"google"` // google or outlook
+}
+
+// ConnectCalendarResponse is the provider's consent page to send the browser to
+type ConnectCalendarResponse struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://accounts.google.com/o/oauth2/v2/auth?client_id=..."`
+}
+
+// UpdateCalendarConnectionRequest changes which leaves a connected calendar shows
+type UpdateCalendarConnectionRequest struct {
+	SyncOwnLeaves  *bool `json:"sync_own_leaves,omitempty" example:"true"`
+	SyncTeamLeaves *bool `json:"sync_team_leaves,omitempty" example:"false"`
+}
+
+// GetCalendarConnections lists the current user's connected calendars
+// @Summary Get connected calendars
+// @Description List the Google and Outlook calendars approved leaves are added to for the current user, with the providers this server can connect
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} CalendarConnectionsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/calendar-connections [get]
+func GetCalendarConnections(c *gin.Context) {
+	var connections []models.CalendarConnection
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).Order("created_at").Find(&connections).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch calendar connections")
+		return
+	}
+	c.JSON(http.StatusOK, CalendarConnectionsResponse{Providers: utils.CalendarProviders(), Connections: connections})
+}
+
+// ConnectCalendar starts connecting a calendar for the current user
+// @Summary Connect a calendar
+// @Description Get the Google or Microsoft consent page to send the browser to. Once the user consents, the provider redirects to the server, which saves the connection and adds the user's upcoming approved leaves, and their direct reports' if they manage a team, to the calendar. The link expires after 10 minutes. Connecting a provider again replaces its account and keeps the preferences
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ConnectCalendarRequest true "Provider"
+// @Success 200 {object} ConnectCalendarResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/calendar-connections/authorize [post]
+func ConnectCalendar(c *gin.Context) {
+	var req ConnectCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	authorizationURL, err := utils.CalendarAuthorizationURL(c.GetUint("user_id"), req.Provider)
+	if errors.Is(err, utils.ErrCalendarNotConfigured) {
+		utils.RespondError(c, http.StatusBadRequest, "Calendar provider is not configured")
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to connect calendar")
+		return
+	}
+	c.JSON(http.StatusOK, ConnectCalendarResponse{AuthorizationURL: authorizationURL})
+}
+
+// UpdateCalendarConnection changes which leaves a connected calendar shows
+// @Summary Update calendar preferences
+// @Description Choose whether a connected calendar shows the current user's own approved leaves and those of their direct reports. Leaves turned on are added from today onwards, and the events of leaves turned off are removed
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Calendar connection ID"
+// @Param request body UpdateCalendarConnectionRequest true "Preferences"
+// @Success 200 {object} models.CalendarConnection
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/calendar-connections/{id} [put]
+func UpdateCalendarConnection(c *gin.Context) {
+	connectionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req UpdateCalendarConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var connection models.CalendarConnection
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).First(&connection, connectionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Calendar connection not found")
+		return
+	}
+	if req.SyncOwnLeaves != nil || req.SyncTeamLeaves != nil {
+		if req.SyncOwnLeaves != nil {
+			connection.SyncOwnLeaves = *req.SyncOwnLeaves
+		}
+		if req.SyncTeamLeaves != nil {
+			connection.SyncTeamLeaves = *req.SyncTeamLeaves
+		}
+		if err := requestDB(c).Model(&connection).Select("sync_own_leaves", "sync_team_leaves").Updates(&connection).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update calendar connection")
+			return
+		}
+		employeeID := connection.EmployeeID
+		afterCommit(c, func() {
+			utils.SyncEmployeeCalendars(employeeID)
+		})
+	}
+
+	c.JSON(http.StatusOK, connection)
+}
+
+// DeleteCalendarConnection disconnects a calendar from the current user
+// @Summary Disconnect a calendar
+// @Description Stop adding leaves to a connected calendar. The events already added are removed from it in the background
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Calendar connection ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/calendar-connections/{id} [delete]
+func DeleteCalendarConnection(c *gin.Context) {
+	connectionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var connection models.CalendarConnection
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).First(&connection, connectionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Calendar connection not found")
+		return
+	}
+	if err := utils.DisconnectCalendar(connection); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to disconnect calendar")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar disconnected successfully"})
+}
+
+// CalendarCallback is where Google and Microsoft send the browser back to once the user has consented
+// to a calendar connection. The browser is then sent on to CALENDAR_RETURN_URL, with the outcome in
+// its calendar and provider parameters, or shown a JSON response when that is not set.
+func CalendarCallback(c *gin.Context) {
+	provider := models.CalendarProvider(c.Param("provider"))
+	if c.Query("error") != "" {
+		respondCalendarCallback(c, provider, http.StatusBadRequest, "Calendar access was not granted")
+		return
+	}
+
+	connection, err := utils.ConnectCalendar(provider, c.Query("code"), c.Query("state"))
+	switch {
+	case errors.Is(err, utils.ErrCalendarNotConfigured):
+		respondCalendarCallback(c, provider, http.StatusNotFound, "Calendar provider is not configured")
+	case errors.Is(err, utils.ErrCalendarState):
+		respondCalendarCallback(c, provider, http.StatusBadRequest, "Calendar authorization is invalid or has expired")
+	case err != nil:
+		log.Printf("❌ Calendars: failed to connect %s calendar: %v", provider, err)
+		respondCalendarCallback(c, provider, http.StatusBadGateway, "Failed to connect calendar")
+	default:
+		log.Printf("✅ Calendars: employee %d connected their %s calendar", connection.EmployeeID, provider)
+		respondCalendarCallback(c, provider, http.StatusOK, "")
+	}
+}
+
+// respondCalendarCallback ends a calendar connection, redirecting to CALENDAR_RETURN_URL when it is set
+func respondCalendarCallback(c *gin.Context, provider models.CalendarProvider, status int, errorMessage string) {
+	returnURL, err := url.Parse(config.AppConfig.CalendarReturnURL)
+	if config.AppConfig.CalendarReturnURL == "" || err != nil {
+		if errorMessage != "" {
+			utils.RespondError(c, status, errorMessage)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Calendar connected successfully"})
+		return
+	}
+
+	query := returnURL.Query()
+	query.Set("provider", string(provider))
+	query.Set("calendar", "connected")
+	if errorMessage != "" {
+		query.Set("calendar", "error")
+	}
+	returnURL.RawQuery = query.Encode()
+	c.Redirect(http.StatusFound, returnURL.String())
+}
@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// chatLinkCodeTTL is how long a chat link code can be used
+const chatLinkCodeTTL = 10 * time.Minute
+
+// chatLinkCodeAlphabet leaves out characters easily mistaken for one another
+const chatLinkCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// chatPendingLimit is the number of pending leaves listed in reply to the pending command
+const chatPendingLimit = 10
+
+// chatMaxBody bounds the size of requests from chat platforms
+const chatMaxBody = 1 << 20
+
+// ChatLinkCodeResponse represents a code to send the leave bot to link a chat account
+type ChatLinkCodeResponse struct {
+	Code      string    `json:"code" example:"K7QF-2M9X"`
+	Command   string    `json:"command" example:"link K7QF-2M9X"` // Sent as /leave link K7QF-2M9X in Slack, or after a mention of the bot in Teams
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateChatLinkCode creates a code linking a chat account to the current user
+// @Summary Create a chat link code
+// @Description Create a one-time code that links the Slack or Teams account sending it to the leave bot to the current user. It expires after 10 minutes and replaces any earlier code
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} ChatLinkCodeResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/chat-accounts/link-code [post]
+func CreateChatLinkCode(c *gin.Context) {
+	employeeID := c.GetUint("user_id")
+
+	code, err := generateChatLinkCode()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create link code")
+		return
+	}
+	linkCode := models.ChatLinkCode{
+		EmployeeID: employeeID,
+		CodeHash:   hashChatLinkCode(code),
+		ExpiresAt:  time.Now().Add(chatLinkCodeTTL),
+	}
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Where("employee_id = ?", employeeID).Delete(&models.ChatLinkCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&linkCode).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create link code")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ChatLinkCodeResponse{Code: code, Command: "link " + code, ExpiresAt: linkCode.ExpiresAt})
+}
+
+// GetChatAccounts lists the chat accounts linked to the current user
+// @Summary Get linked chat accounts
+// @Description List the Slack and Teams accounts the leave bot acts as the current user for
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ChatAccount
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/chat-accounts [get]
+func GetChatAccounts(c *gin.Context) {
+	var accounts []models.ChatAccount
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).Order("created_at").Find(&accounts).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch chat accounts")
+		return
+	}
+	c.JSON(http.StatusOK, accounts)
+}
+
+// DeleteChatAccount unlinks a chat account from the current user
+// @Summary Unlink a chat account
+// @Description Stop the leave bot acting as the current user for a Slack or Teams account
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Chat account ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/chat-accounts/{id} [delete]
+func DeleteChatAccount(c *gin.Context) {
+	accountID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var account models.ChatAccount
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).First(&account, accountID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Chat account not found")
+		return
+	}
+	if err := requestDB(c).Delete(&account).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to unlink chat account")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Chat account unlinked successfully"})
+}
+
+// generateChatLinkCode returns a random code of the form XXXX-XXXX
+func generateChatLinkCode() (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	code := make([]byte, 0, 9)
+	for i, b := range random {
+		if i == 4 {
+			code = append(code, '-')
+		}
+		code = append(code, chatLinkCodeAlphabet[int(b)%len(chatLinkCodeAlphabet)])
+	}
+	return string(code), nil
+}
+
+// hashChatLinkCode hashes a code as typed, ignoring case and dashes
+func hashChatLinkCode(code string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChatHandler answers leave bot commands sent from Slack and Teams. Commands run as the employee linked
+// to the sender's chat account, through the same API routes the apps use, so they are authorized,
+// validated, audited and notified exactly as if the employee had made the request.
+type ChatHandler struct {
+	router http.Handler
+}
+
+func NewChatHandler(router http.Handler) *ChatHandler {
+	return &ChatHandler{router: router}
+}
+
+// chatReply is the answer to a command. Pending leaves are offered with approve buttons where the
+// platform supports them.
+type chatReply struct {
+	text         string
+	pending      []chatPendingLeave
+	approveLabel string // Label of the approve buttons
+}
+
+type chatPendingLeave struct {
+	id          uint
+	description string
+}
+
+// chatSession runs a command for the employee linked to a chat account
+type chatSession struct {
+	handler  *ChatHandler
+	c        *gin.Context
+	employee models.Employee
+	lang     i18n.Language
+	token    string
+}
+
+// chatMention matches the mention of the bot that starts a Teams message
+var chatMention = regexp.MustCompile(`<at>.*?</at>`)
+
+// runCommand answers a command from a chat user, such as "apply 2025-07-01 2025-07-03 annual"
+func (h *ChatHandler) runCommand(c *gin.Context, platform models.ChatPlatform, externalID, text string) chatReply {
+	text = chatMention.ReplaceAllString(text, " ")
+	text = strings.NewReplacer("&nbsp;", " ", "<p>", " ", "</p>", " ").Replace(text)
+	args := strings.Fields(text)
+	// Teams messages name the command in full ("@HRMS leave balance")
+	if len(args) > 0 && strings.EqualFold(args[0], "leave") {
+		args = args[1:]
+	}
+	command := "help"
+	if len(args) > 0 {
+		command = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	ctx := c.Request.Context()
+	if command == "link" {
+		return chatReply{text: linkChatAccount(ctx, platform, externalID, args)}
+	}
+
+	var account models.ChatAccount
+	err := database.DB.WithContext(ctx).Where("platform = ? AND external_id = ?", platform, externalID).First(&account).Error
+	if err != nil {
+		return chatReply{text: i18n.T(i18n.Default, "Your chat account is not linked to HRMS. Create a link code in HRMS, then send: link <code>")}
+	}
+	session := &chatSession{handler: h, c: c}
+	if err := database.DB.WithContext(ctx).First(&session.employee, account.EmployeeID).Error; err != nil {
+		return chatReply{text: i18n.T(i18n.Default, "Your chat account is not linked to HRMS. Create a link code in HRMS, then send: link <code>")}
+	}
+	session.lang, _ = i18n.Parse(session.employee.Language)
+	if session.lang == "" {
+		session.lang = i18n.Default
+	}
+	if session.token, err = utils.GenerateToken(&session.employee); err != nil {
+		log.Printf("Chat: failed to generate token for employee %d: %v", session.employee.ID, err)
+		return chatReply{text: i18n.T(session.lang, "Something went wrong, please try again later")}
+	}
+
+	switch command {
+	case "balance":
+		return session.balance()
+	case "apply":
+		return session.apply(args)
+	case "pending":
+		return session.listPending()
+	case "approve":
+		return session.approve(args)
+	case "reject":
+		return session.reject(args)
+	case "unlink":
+		if err := database.DB.WithContext(ctx).Delete(&account).Error; err != nil {
+			return chatReply{text: i18n.T(session.lang, "Something went wrong, please try again later")}
+		}
+		return chatReply{text: i18n.T(session.lang, "Your chat account is no longer linked to HRMS")}
+	case "help":
+		return chatReply{text: i18n.T(session.lang, chatHelp)}
+	default:
+		return chatReply{text: i18n.T(session.lang, "Unknown command %s. Send help for the list of commands", command)}
+	}
+}
+
+// chatHelp lists the leave bot's commands
+const chatHelp = "Commands:\n" +
+	"balance - your leave balances\n" +
+	"apply <start YYYY-MM-DD> <end YYYY-MM-DD> <leave type> [reason] - apply for leave\n" +
+	"pending - leave requests waiting for your approval (managers)\n" +
+	"approve <leave ID> - approve a leave request (managers)\n" +
+	"reject <leave ID> <reason> - reject a leave request (managers)\n" +
+	"unlink - stop using this chat account with HRMS"
+
+// linkChatAccount links the chat account to the employee who created the code, replacing any earlier link
+func linkChatAccount(ctx context.Context, platform models.ChatPlatform, externalID string, args []string) string {
+	if len(args) != 1 {
+		return i18n.T(i18n.Default, "Usage: link <code>")
+	}
+
+	var employee models.Employee
+	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var linkCode models.ChatLinkCode
+		if err := tx.Where("code_hash = ? AND expires_at > ?", hashChatLinkCode(args[0]), time.Now()).First(&linkCode).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&linkCode).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&employee, linkCode.EmployeeID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("platform = ? AND external_id = ?", platform, externalID).Delete(&models.ChatAccount{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ChatAccount{EmployeeID: employee.ID, Platform: platform, ExternalID: externalID}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return i18n.T(i18n.Default, "Invalid or expired link code")
+	}
+	if err != nil {
+		log.Printf("Chat: failed to link %s account: %v", platform, err)
+		return i18n.T(i18n.Default, "Something went wrong, please try again later")
+	}
+
+	lang, ok := i18n.Parse(employee.Language)
+	if !ok {
+		lang = i18n.Default
+	}
+	return i18n.T(lang, "Linked to %s. Send help for the list of commands", employee.Firstname+" "+employee.Lastname)
+}
+
+func (s *chatSession) balance() chatReply {
+	var balances []LeaveBalanceResponse
+	if reply, ok := s.call(http.MethodGet, "/api/leaves/balance", nil, &balances); !ok {
+		return reply
+	}
+	if len(balances) == 0 {
+		return chatReply{text: i18n.T(s.lang, "You have no leave balances")}
+	}
+	lines := make([]string, 0, len(balances))
+	for _, balance := range balances {
+		lines = append(lines, i18n.T(s.lang, "%s: %d of %d days left", balance.LeaveTypeName, balance.Balance, balance.MaxDays))
+	}
+	return chatReply{text: strings.Join(lines, "\n")}
+}
+
+func (s *chatSession) apply(args []string) chatReply {
+	if len(args) < 3 {
+		return chatReply{text: i18n.T(s.lang, "Usage: apply <start YYYY-MM-DD> <end YYYY-MM-DD> <leave type> [reason]")}
+	}
+
+	var leaveTypes []models.LeaveType
+	if reply, ok := s.call(http.MethodGet, "/api/leave-types", nil, &leaveTypes); !ok {
+		return reply
+	}
+	// The leave type is matched on its name, or the start of it ("annual" for "Annual Leave")
+	var matched []models.LeaveType
+	names := make([]string, 0, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		names = append(names, leaveType.Name)
+		if strings.EqualFold(leaveType.Name, args[2]) {
+			matched = []models.LeaveType{leaveType}
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(leaveType.Name), strings.ToLower(args[2])) {
+			matched = append(matched, leaveType)
+		}
+	}
+	if len(matched) != 1 {
+		return chatReply{text: i18n.T(s.lang, "Unknown leave type %s. Leave types: %s", args[2], strings.Join(names, ", "))}
+	}
+
+	request := ApplyLeaveRequest{
+		LeaveTypeID: matched[0].ID,
+		StartDate:   args[0],
+		EndDate:     args[1],
+		Reason:      strings.Join(args[3:], " "),
+	}
+	var leave models.Leave
+	if reply, ok := s.call(http.MethodPost, "/api/leaves", request, &leave); !ok {
+		return reply
+	}
+	return chatReply{text: i18n.T(s.lang, "Applied for %s from %s to %s (leave %d), waiting for approval",
+		matched[0].Name, leave.StartDate.Format("2006-01-02"), leave.EndDate.Format("2006-01-02"), leave.ID)}
+}
+
+func (s *chatSession) listPending() chatReply {
+	var page struct {
+		Data  []models.Leave `json:"data"`
+		Total int64          `json:"total"`
+	}
+	path := fmt.Sprintf("/api/leaves/pending?per_page=%d", chatPendingLimit)
+	if reply, ok := s.call(http.MethodGet, path, nil, &page); !ok {
+		return reply
+	}
+	if len(page.Data) == 0 {
+		return chatReply{text: i18n.T(s.lang, "No leave requests are waiting for approval")}
+	}
+
+	reply := chatReply{
+		text:         i18n.T(s.lang, "%d leave requests are waiting for approval", page.Total),
+		approveLabel: i18n.T(s.lang, "Approve"),
+	}
+	if page.Total > int64(len(page.Data)) {
+		reply.text += "\n" + i18n.T(s.lang, "Showing the oldest %d", len(page.Data))
+	}
+	reply.text += "\n" + i18n.T(s.lang, "Send approve <leave ID>, or reject <leave ID> <reason>")
+	for _, leave := range page.Data {
+		reply.pending = append(reply.pending, chatPendingLeave{
+			id: leave.ID,
+			description: i18n.T(s.lang, "Leave %d: %s, %s from %s to %s", leave.ID,
+				leave.Employee.Firstname+" "+leave.Employee.Lastname, leave.LeaveType.Name,
+				leave.StartDate.Format("2006-01-02"), leave.EndDate.Format("2006-01-02")),
+		})
+	}
+	return reply
+}
+
+func (s *chatSession) approve(args []string) chatReply {
+	leaveID, err := strconv.ParseUint(strings.TrimPrefix(firstArg(args), "#"), 10, 32)
+	if err != nil || len(args) != 1 {
+		return chatReply{text: i18n.T(s.lang, "Usage: approve <leave ID>")}
+	}
+	return s.approveLeave(uint(leaveID))
+}
+
+// approveLeave approves a pending leave, from the approve command or an approve button
+func (s *chatSession) approveLeave(leaveID uint) chatReply {
+	var leave models.Leave
+	if reply, ok := s.call(http.MethodPut, fmt.Sprintf("/api/leaves/%d/approve", leaveID), nil, &leave); !ok {
+		return reply
+	}
+	return chatReply{text: i18n.T(s.lang, "Approved leave %d", leave.ID)}
+}
+
+func (s *chatSession) reject(args []string) chatReply {
+	leaveID, err := strconv.ParseUint(strings.TrimPrefix(firstArg(args), "#"), 10, 32)
+	if err != nil || len(args) < 2 {
+		return chatReply{text: i18n.T(s.lang, "Usage: reject <leave ID> <reason>")}
+	}
+	var leave models.Leave
+	request := RejectLeaveRequest{Reason: strings.Join(args[1:], " ")}
+	if reply, ok := s.call(http.MethodPut, fmt.Sprintf("/api/leaves/%d/reject", leaveID), request, &leave); !ok {
+		return reply
+	}
+	return chatReply{text: i18n.T(s.lang, "Rejected leave %d", leave.ID)}
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// call makes an API request as the employee and decodes a successful response into out. A failed
+// request returns the API's error message, in the employee's language, as the reply.
+func (s *chatSession) call(method, path string, body, out interface{}) (chatReply, bool) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return chatReply{text: i18n.T(s.lang, "Something went wrong, please try again later")}, false
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(s.c.Request.Context(), method, path, reader)
+	if err != nil {
+		return chatReply{text: i18n.T(s.lang, "Something went wrong, please try again later")}, false
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", string(s.lang))
+	req.Header.Set("X-Request-Id", s.c.GetString("request_id"))
+	req.RemoteAddr = s.c.Request.RemoteAddr
+	req.Host = s.c.Request.Host
+
+	recorder := httptest.NewRecorder()
+	s.handler.router.ServeHTTP(recorder, req)
+
+	if recorder.Code >= http.StatusBadRequest {
+		var apiErr utils.ErrorResponse
+		if json.Unmarshal(recorder.Body.Bytes(), &apiErr) != nil || apiErr.Message == "" {
+			return chatReply{text: i18n.T(s.lang, "Something went wrong, please try again later")}, false
+		}
+		return chatReply{text: apiErr.Message}, false
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), out); err != nil {
+		log.Printf("Chat: failed to decode %s %s: %v", method, path, err)
+		return chatReply{text: i18n.T(s.lang, "Something went wrong, please try again later")}, false
+	}
+	return chatReply{}, true
+}
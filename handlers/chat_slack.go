@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slackSignatureMaxAge is how old a signed Slack request may be, so captured requests cannot be replayed
+const slackSignatureMaxAge = 5 * time.Minute
+
+// slackApproveAction identifies the approve buttons of pending leaves
+const slackApproveAction = "approve_leave"
+
+// slackResponseURLPrefix is where Slack's response URLs point; replies are never sent anywhere else
+const slackResponseURLPrefix = "https://hooks.slack.com/"
+
+// slackClient posts replies to interactions; Slack shows an error when they take over 3 seconds
+var slackClient = &http.Client{Timeout: 2 * time.Second}
+
+// slackMessage is a message in Slack's format, only shown to the user who sent the command
+type slackMessage struct {
+	ResponseType    string       `json:"response_type"`
+	ReplaceOriginal bool         `json:"replace_original"`
+	Text            string       `json:"text"`
+	Blocks          []slackBlock `json:"blocks,omitempty"`
+}
+
+type slackBlock struct {
+	Type      string       `json:"type"`
+	Text      *slackText   `json:"text,omitempty"`
+	Accessory *slackButton `json:"accessory,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackButton struct {
+	Type     string    `json:"type"`
+	Text     slackText `json:"text"`
+	Style    string    `json:"style"`
+	ActionID string    `json:"action_id"`
+	Value    string    `json:"value"`
+}
+
+// newSlackMessage renders a reply, with an approve button next to each pending leave
+func newSlackMessage(reply chatReply) slackMessage {
+	message := slackMessage{ResponseType: "ephemeral", Text: reply.text}
+	if len(reply.pending) == 0 {
+		return message
+	}
+	message.Blocks = append(message.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "plain_text", Text: reply.text}})
+	for _, leave := range reply.pending {
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "plain_text", Text: leave.description},
+			Accessory: &slackButton{
+				Type:     "button",
+				Text:     slackText{Type: "plain_text", Text: reply.approveLabel},
+				Style:    "primary",
+				ActionID: slackApproveAction,
+				Value:    strconv.FormatUint(uint64(leave.id), 10),
+			},
+		})
+	}
+	return message
+}
+
+// SlackCommand answers the leave bot's slash command, such as "/leave balance". Slack signs the
+// request with the app's signing secret.
+func (h *ChatHandler) SlackCommand(c *gin.Context) {
+	form, ok := readSlackRequest(c)
+	if !ok {
+		return
+	}
+	externalID := form.Get("team_id") + ":" + form.Get("user_id")
+	reply := h.runCommand(c, models.ChatPlatformSlack, externalID, form.Get("text"))
+	c.JSON(http.StatusOK, newSlackMessage(reply))
+}
+
+// slackInteraction is the payload Slack sends when a button is clicked
+type slackInteraction struct {
+	Type string `json:"type"`
+	Team struct {
+		ID string `json:"id"`
+	} `json:"team"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	ResponseURL string `json:"response_url"`
+}
+
+// SlackInteraction handles clicks on the approve buttons of pending leaves. The outcome is posted
+// to the interaction's response URL, as Slack ignores the response body.
+func (h *ChatHandler) SlackInteraction(c *gin.Context) {
+	form, ok := readSlackRequest(c)
+	if !ok {
+		return
+	}
+	var interaction slackInteraction
+	if err := json.Unmarshal([]byte(form.Get("payload")), &interaction); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	externalID := interaction.Team.ID + ":" + interaction.User.ID
+	for _, action := range interaction.Actions {
+		if interaction.Type != "block_actions" || action.ActionID != slackApproveAction {
+			continue
+		}
+		reply := h.runCommand(c, models.ChatPlatformSlack, externalID, "approve "+action.Value)
+		if err := postSlackResponse(interaction.ResponseURL, newSlackMessage(reply)); err != nil {
+			log.Printf("Chat: failed to post Slack reply: %v", err)
+		}
+	}
+	c.Status(http.StatusOK)
+}
+
+// readSlackRequest checks a request's Slack signature and returns its form
+func readSlackRequest(c *gin.Context) (url.Values, bool) {
+	secret := config.AppConfig.SlackSigningSecret
+	if secret == "" {
+		utils.RespondError(c, http.StatusNotFound, "Slack integration is not configured")
+		return nil, false
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, chatMaxBody))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request body")
+		return nil, false
+	}
+
+	timestamp := c.GetHeader("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(seconds, 0)).Abs() > slackSignatureMaxAge {
+		utils.RespondError(c, http.StatusUnauthorized, "Invalid request signature")
+		return nil, false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(c.GetHeader("X-Slack-Signature"))) {
+		utils.RespondError(c, http.StatusUnauthorized, "Invalid request signature")
+		return nil, false
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request body")
+		return nil, false
+	}
+	return form, true
+}
+
+func postSlackResponse(responseURL string, message slackMessage) error {
+	if !strings.HasPrefix(responseURL, slackResponseURLPrefix) {
+		return fmt.Errorf("response URL %q is not Slack's", responseURL)
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	response, err := slackClient.Post(responseURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"hrms-api/config"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// teamsActivity is the message Teams posts to an outgoing webhook when the bot is mentioned
+type teamsActivity struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	From struct {
+		ID          string `json:"id"`
+		AADObjectID string `json:"aadObjectId"`
+	} `json:"from"`
+	ChannelData struct {
+		Tenant struct {
+			ID string `json:"id"`
+		} `json:"tenant"`
+	} `json:"channelData"`
+}
+
+// teamsMessage is the reply to an outgoing webhook, posted in the conversation
+type teamsMessage struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// TeamsMessage answers a message mentioning the leave bot, such as "@HRMS leave balance". Teams signs
+// the request with the outgoing webhook's security token. Teams has no buttons for outgoing webhooks,
+// so pending leaves are approved with the approve command.
+func (h *ChatHandler) TeamsMessage(c *gin.Context) {
+	secret := config.AppConfig.TeamsWebhookSecret
+	if secret == "" {
+		utils.RespondError(c, http.StatusNotFound, "Teams integration is not configured")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, chatMaxBody))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	key, _ := base64.StdEncoding.DecodeString(secret) // Checked when the configuration is loaded
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	expected := "HMAC " + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(c.GetHeader("Authorization"))) {
+		utils.RespondError(c, http.StatusUnauthorized, "Invalid request signature")
+		return
+	}
+
+	var activity teamsActivity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	user := activity.From.AADObjectID
+	if user == "" {
+		user = activity.From.ID
+	}
+	reply := h.runCommand(c, models.ChatPlatformTeams, activity.ChannelData.Tenant.ID+":"+user, activity.Text)
+
+	lines := []string{reply.text}
+	for _, leave := range reply.pending {
+		lines = append(lines, leave.description)
+	}
+	// Teams joins single line breaks
+	text := strings.ReplaceAll(strings.Join(lines, "\n"), "\n", "\n\n")
+	c.JSON(http.StatusOK, teamsMessage{Type: "message", Text: text})
+}
@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CompensationRequest represents a change to an employee's pay
+type CompensationRequest struct {
+	EffectiveDate   string                    `json:"effective_date" binding:"required" example:"2026-07-01"`
+	Amount          float64                   `json:"amount" binding:"required,gt=0" example:"15000"` // Monthly base salary
+	Currency        string                    `json:"currency,omitempty" example:"ZMW"`               // Defaults to ZMW, the currency salary bands are set in
+	Reason          models.CompensationReason `json:"reason" binding:"required,oneof=hire promotion merit market_adjustment correction other" example:"merit"`
+	Notes           *string                   `json:"notes,omitempty"`
+	PositionID      *uint                     `json:"position_id,omitempty" example:"4"`                      // Defaults to the employee's current primary position
+	OutOfBandReason *string                   `json:"out_of_band_reason,omitempty" example:"Retention offer"` // Required when the amount is outside the position's salary band
+}
+
+// CompaRatioEntry is where one employee's pay sits in their position's salary band
+type CompaRatioEntry struct {
+	EmployeeID    uint    `json:"employee_id" example:"12"`
+	EmployeeName  string  `json:"employee_name" example:"Chanda Mwila"`
+	PositionTitle string  `json:"position_title" example:"Accountant"`
+	Amount        float64 `json:"amount" example:"15000"`
+	BandMin       float64 `json:"band_min" example:"12000"`
+	BandMax       float64 `json:"band_max" example:"18000"`
+	CompaRatio    float64 `json:"compa_ratio" example:"1"`      // Amount divided by the band midpoint
+	BandStatus    string  `json:"band_status" example:"within"` // below, within or above
+}
+
+// DepartmentCompaRatio summarizes the compa-ratios of a department
+type DepartmentCompaRatio struct {
+	Department        string            `json:"department" example:"Finance"`
+	Employees         int               `json:"employees" example:"8"`
+	AverageCompaRatio float64           `json:"average_compa_ratio" example:"0.97"`
+	BelowBand         int               `json:"below_band" example:"1"`
+	WithinBand        int               `json:"within_band" example:"6"`
+	AboveBand         int               `json:"above_band" example:"1"`
+	Members           []CompaRatioEntry `json:"members"`
+}
+
+// CompaRatioReport is the compa-ratio of active employees, by department
+type CompaRatioReport struct {
+	Date        time.Time              `json:"date"`
+	Currency    string                 `json:"currency" example:"ZMW"`
+	Departments []DepartmentCompaRatio `json:"departments"`
+	Excluded    int                    `json:"excluded" example:"3"` // Active employees left out for having no pay recorded in the band currency, or no position with a salary band
+}
+
+// GetCompensationHistory lists an employee's compensation records
+// @Summary Get compensation history
+// @Description List an employee's compensation records, newest effective date first. Every view is audit logged (Payroll access only)
+// @Tags Compensation
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} models.CompensationRecord
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/payroll/employees/{id}/compensation [get]
+func GetCompensationHistory(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+	records, err := compensationHistory(requestDB(c), employee.ID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch compensation history")
+		return
+	}
+
+	createAuditLog(models.AuditEntityCompensation, employee.ID, models.AuditActionView, c.GetUint("user_id"), c, nil, nil)
+	c.JSON(http.StatusOK, records)
+}
+
+// GetMyCompensation lists the current user's compensation records
+// @Summary Get my compensation history
+// @Description List your compensation records, newest effective date first
+// @Tags Compensation
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.CompensationRecord
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/compensation [get]
+func GetMyCompensation(c *gin.Context) {
+	records, err := compensationHistory(requestDB(c), c.GetUint("user_id"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch compensation history")
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
+
+// CreateCompensationRecord records a change to an employee's pay
+// @Summary Record a compensation change
+// @Description Record an employee's pay from an effective date. The amount is checked against the salary band of the position, by default the employee's current primary position; amounts in ZMW outside the band are refused with code salary_out_of_band unless out_of_band_reason explains them. Records cannot be changed or deleted: record a correction instead. When the record is the one in effect today, the salary of the employee's current assignment to that position is updated to match. Payroll users cannot record their own pay (Payroll access only)
+// @Tags Compensation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body CompensationRequest true "Compensation change"
+// @Success 201 {object} models.CompensationRecord
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/payroll/employees/{id}/compensation [post]
+func CreateCompensationRecord(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req CompensationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	userID := c.GetUint("user_id")
+	if uint(employeeID) == userID {
+		utils.RespondError(c, http.StatusForbidden, "You cannot record your own compensation")
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid effective_date format. Use YYYY-MM-DD")
+		return
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if !utils.IsCurrencyCode(currency) {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid currency. Use a three-letter ISO code such as ZMW")
+		return
+	}
+
+	record := models.CompensationRecord{
+		EmployeeID:    employee.ID,
+		PositionID:    req.PositionID,
+		EffectiveDate: effectiveDate,
+		Amount:        req.Amount,
+		Currency:      currency,
+		Reason:        req.Reason,
+		Notes:         req.Notes,
+		CreatedBy:     userID,
+	}
+	if req.OutOfBandReason != nil && strings.TrimSpace(*req.OutOfBandReason) != "" {
+		reason := strings.TrimSpace(*req.OutOfBandReason)
+		record.OutOfBandReason = &reason
+	}
+
+	var position models.Position
+	if record.PositionID == nil {
+		var assignment models.PositionAssignment
+		err := requestDB(c).Preload("Position").Where("employee_id = ? AND is_primary = ? AND end_date IS NULL", employee.ID, true).
+			Order("start_date DESC").Limit(1).Find(&assignment).Error
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to record compensation")
+			return
+		}
+		if assignment.ID != 0 {
+			position = assignment.Position
+			record.PositionID = &position.ID
+		}
+	} else if err := requestDB(c).First(&position, *record.PositionID).Error; err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Position not found")
+		return
+	}
+
+	// Bands are set in the default currency, so amounts in other currencies cannot be compared with them
+	if status := utils.SalaryBandStatus(position, record.Amount); currency == models.DefaultCurrency &&
+		status != "" && status != utils.SalaryBandWithin && record.OutOfBandReason == nil {
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeSalaryOutOfBand,
+			"The amount is outside the position's salary band. Give out_of_band_reason to record it anyway",
+			gin.H{"band_min": *position.MinSalary, "band_max": *position.MaxSalary, "band_status": status})
+		return
+	}
+
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		if err := syncAssignmentSalary(tx, record); err != nil {
+			return err
+		}
+		return recordAuditLog(tx, models.AuditEntityCompensation, record.ID, models.AuditActionCreate, userID, c, nil, record)
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to record compensation")
+		return
+	}
+	if record.PositionID != nil {
+		record.Position = &position
+	}
+	c.JSON(http.StatusCreated, record)
+}
+
+// GetCompaRatioReport reports where active employees' pay sits in their salary bands, by department
+// @Summary Get compa-ratio report
+// @Description For each department, the compa-ratio of its active employees: their current pay divided by the midpoint of their primary position's salary band, with the department average and how many are paid below, within and above the band. Only pay in ZMW, the currency bands are set in, is compared; employees without it or without a banded position are counted in excluded (Payroll access only)
+// @Tags Compensation
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Department filter"
+// @Success 200 {object} CompaRatioReport
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/payroll/reports/compa-ratio [get]
+func GetCompaRatioReport(c *gin.Context) {
+	db := requestDB(c)
+	today := utils.CompanyToday()
+
+	query := db.Where("status = ?", "active")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var employees []models.Employee
+	if err := query.Order("firstname, lastname").Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate compa-ratio report")
+		return
+	}
+	ids := make([]uint, len(employees))
+	for i, employee := range employees {
+		ids[i] = employee.ID
+	}
+
+	current, err := utils.CurrentCompensation(db, ids, today)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate compa-ratio report")
+		return
+	}
+	var assignments []models.PositionAssignment
+	if len(ids) > 0 {
+		err = db.Preload("Position").Where("employee_id IN ? AND is_primary = ? AND end_date IS NULL", ids, true).
+			Order("start_date").Find(&assignments).Error
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate compa-ratio report")
+			return
+		}
+	}
+	positions := map[uint]models.Position{}
+	for _, assignment := range assignments {
+		positions[assignment.EmployeeID] = assignment.Position // The latest primary assignment wins
+	}
+
+	report := CompaRatioReport{Date: today, Currency: models.DefaultCurrency, Departments: []DepartmentCompaRatio{}}
+	departments := map[string]*DepartmentCompaRatio{}
+	for _, employee := range employees {
+		record, paid := current[employee.ID]
+		position, placed := positions[employee.ID]
+		if !paid || !placed || record.Currency != models.DefaultCurrency || !utils.HasSalaryBand(position) {
+			report.Excluded++
+			continue
+		}
+		entry := CompaRatioEntry{
+			EmployeeID:    employee.ID,
+			EmployeeName:  strings.TrimSpace(employee.Firstname + " " + employee.Lastname),
+			PositionTitle: position.Title,
+			Amount:        record.Amount,
+			BandMin:       *position.MinSalary,
+			BandMax:       *position.MaxSalary,
+			CompaRatio:    *utils.CompaRatio(position, record.Amount),
+			BandStatus:    utils.SalaryBandStatus(position, record.Amount),
+		}
+		summary, ok := departments[employee.Department]
+		if !ok {
+			summary = &DepartmentCompaRatio{Department: employee.Department, Members: []CompaRatioEntry{}}
+			departments[employee.Department] = summary
+		}
+		summary.Members = append(summary.Members, entry)
+		summary.Employees++
+		summary.AverageCompaRatio += entry.CompaRatio
+		switch entry.BandStatus {
+		case utils.SalaryBandBelow:
+			summary.BelowBand++
+		case utils.SalaryBandAbove:
+			summary.AboveBand++
+		default:
+			summary.WithinBand++
+		}
+	}
+	for _, summary := range departments {
+		summary.AverageCompaRatio /= float64(summary.Employees)
+		report.Departments = append(report.Departments, *summary)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+
+	createAuditLog(models.AuditEntityCompensation, 0, models.AuditActionView, c.GetUint("user_id"), c, nil,
+		gin.H{"report": "compa_ratio", "department": c.Query("department")})
+	c.JSON(http.StatusOK, report)
+}
+
+// compensationHistory returns an employee's compensation records, newest effective date first
+func compensationHistory(db *gorm.DB, employeeID uint) ([]models.CompensationRecord, error) {
+	records := []models.CompensationRecord{}
+	err := db.Preload("Position").Where("employee_id = ?", employeeID).Order("effective_date DESC, id DESC").Find(&records).Error
+	return records, err
+}
+
+// syncAssignmentSalary sets the salary of the employee's current assignment to record's position when
+// record is the compensation in effect today, so documents and letters quoting the salary stay current
+func syncAssignmentSalary(tx *gorm.DB, record models.CompensationRecord) error {
+	if record.PositionID == nil || record.Currency != models.DefaultCurrency {
+		return nil
+	}
+	current, err := utils.CurrentCompensation(tx, []uint{record.EmployeeID}, utils.CompanyToday())
+	if err != nil {
+		return err
+	}
+	if current[record.EmployeeID].ID != record.ID {
+		return nil
+	}
+	return tx.Model(&models.PositionAssignment{}).
+		Where("employee_id = ? AND position_id = ? AND end_date IS NULL", record.EmployeeID, *record.PositionID).
+		Update("salary", record.Amount).Error
+}
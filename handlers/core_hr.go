@@ -1,15 +1,21 @@
 package handlers
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"hrms-api/database"
 	"hrms-api/models"
 	"hrms-api/utils"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // Helper function to get current user from context
@@ -20,7 +26,7 @@ func getCurrentUser(c *gin.Context) *models.Employee {
 	}
 
 	var employee models.Employee
-	if err := database.DB.First(&employee, userID).Error; err != nil {
+	if err := requestDB(c).First(&employee, userID).Error; err != nil {
 		return nil
 	}
 	return &employee
@@ -28,6 +34,18 @@ func getCurrentUser(c *gin.Context) *models.Employee {
 
 // Helper function to create audit log entry
 func createAuditLog(entityType models.AuditEntityType, entityID uint, action models.AuditAction, performedBy uint, c *gin.Context, oldValues, newValues interface{}) {
+	recordAuditLog(requestDB(c), entityType, entityID, action, performedBy, c, oldValues, newValues)
+}
+
+// recordAuditLog writes an audit log entry through db; pass the transaction making the change so the
+// entry is saved, or rolled back, with it
+func recordAuditLog(db *gorm.DB, entityType models.AuditEntityType, entityID uint, action models.AuditAction, performedBy uint, c *gin.Context, oldValues, newValues interface{}) error {
+	auditLog := newAuditLog(entityType, entityID, action, performedBy, c, oldValues, newValues)
+	return db.Create(&auditLog).Error
+}
+
+// newAuditLog builds an audit log entry for the request without saving it
+func newAuditLog(entityType models.AuditEntityType, entityID uint, action models.AuditAction, performedBy uint, c *gin.Context, oldValues, newValues interface{}) models.AuditLog {
 	var oldJSON, newJSON, changesJSON []byte
 
 	if oldValues != nil {
@@ -37,16 +55,12 @@ func createAuditLog(entityType models.AuditEntityType, entityID uint, action mod
 		newJSON, _ = json.Marshal(newValues)
 	}
 
-	// Calculate changes if both old and new values exist
+	// Calculate field-level changes if both old and new values exist
 	if oldValues != nil && newValues != nil {
-		// Simple diff calculation - in production, you might want a more sophisticated diff
-		changesJSON, _ = json.Marshal(map[string]interface{}{
-			"old": oldValues,
-			"new": newValues,
-		})
+		changesJSON, _ = json.Marshal(utils.ComputeFieldChanges(oldValues, newValues))
 	}
 
-	auditLog := models.AuditLog{
+	return models.AuditLog{
 		EntityType:    entityType,
 		EntityID:      entityID,
 		Action:        action,
@@ -59,8 +73,18 @@ func createAuditLog(entityType models.AuditEntityType, entityID uint, action mod
 		NewValues:     getStringPtr(string(newJSON)),
 		Changes:       getStringPtr(string(changesJSON)),
 	}
+}
 
-	database.DB.Create(&auditLog)
+// Helper function to append an employment history row when the employee's status, position,
+// department or manager differ from the snapshot taken before the change
+func recordEmploymentChange(db *gorm.DB, c *gin.Context, employeeID uint, before utils.EmploymentSnapshot, reason string) error {
+	var changedBy *uint
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(uint)
+		changedBy = &id
+	}
+	after := utils.TakeEmploymentSnapshot(db, employeeID)
+	return utils.RecordEmploymentChange(db, employeeID, before, after, time.Now(), reason, changedBy)
 }
 
 func getStringPtr(s string) *string {
@@ -87,8 +111,8 @@ func GetIdentityInformation(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var identity models.IdentityInformation
-	if err := database.DB.Where("employee_id = ?", employeeID).First(&identity).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Identity information not found"})
+	if err := requestDB(c).Where("employee_id = ?", employeeID).First(&identity).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Identity information not found")
 		return
 	}
 
@@ -106,7 +130,7 @@ func GetIdentityInformation(c *gin.Context) {
 // @Param request body models.IdentityInformation true "Identity information"
 // @Success 200 {object} models.IdentityInformation
 // @Success 201 {object} models.IdentityInformation
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/employees/{id}/identity [post]
@@ -115,19 +139,19 @@ func CreateOrUpdateIdentityInformation(c *gin.Context) {
 
 	var req models.IdentityInformation
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	req.EmployeeID = uint(employeeID)
 
 	var existing models.IdentityInformation
-	err := database.DB.Where("employee_id = ?", employeeID).First(&existing).Error
+	err := requestDB(c).Where("employee_id = ?", employeeID).First(&existing).Error
 
 	if err != nil {
 		// Create new
-		if err := database.DB.Create(&req).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create identity information"})
+		if err := requestDB(c).Create(&req).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to create identity information")
 			return
 		}
 		user := getCurrentUser(c)
@@ -139,8 +163,8 @@ func CreateOrUpdateIdentityInformation(c *gin.Context) {
 		// Update existing
 		oldValues := existing
 		req.ID = existing.ID
-		if err := database.DB.Save(&req).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update identity information"})
+		if err := requestDB(c).Save(&req).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update identity information")
 			return
 		}
 		user := getCurrentUser(c)
@@ -168,8 +192,8 @@ func GetEmploymentDetails(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var employment models.EmploymentDetails
-	if err := database.DB.Preload("Manager").Where("employee_id = ?", employeeID).First(&employment).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employment details not found"})
+	if err := requestDB(c).Preload("Manager").Where("employee_id = ?", employeeID).First(&employment).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employment details not found")
 		return
 	}
 
@@ -178,7 +202,7 @@ func GetEmploymentDetails(c *gin.Context) {
 
 // CreateOrUpdateEmploymentDetails creates or updates employment details
 // @Summary Create or update employment details
-// @Description Create or update employment details for an employee
+// @Description Create or update employment details for an employee. When updating, send the version from the last read; if the details have changed since, nothing is saved and 409 is returned with the current details. Without a version the update applies to whatever is stored
 // @Tags Core HR - Employment
 // @Accept json
 // @Produce json
@@ -187,8 +211,9 @@ func GetEmploymentDetails(c *gin.Context) {
 // @Param request body models.EmploymentDetails true "Employment details"
 // @Success 200 {object} models.EmploymentDetails
 // @Success 201 {object} models.EmploymentDetails
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ConflictResponse "Details were changed since the submitted version"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/employees/{id}/employment [post]
 func CreateOrUpdateEmploymentDetails(c *gin.Context) {
@@ -196,18 +221,26 @@ func CreateOrUpdateEmploymentDetails(c *gin.Context) {
 
 	var req models.EmploymentDetails
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	req.EmployeeID = uint(employeeID)
 
+	before := utils.TakeEmploymentSnapshot(requestDB(c), req.EmployeeID)
+
 	var existing models.EmploymentDetails
-	err := database.DB.Where("employee_id = ?", employeeID).First(&existing).Error
+	err := requestDB(c).Where("employee_id = ?", employeeID).First(&existing).Error
 
 	if err != nil {
-		if err := database.DB.Create(&req).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create employment details"})
+		err := withTransaction(c, func(tx *gorm.DB) error {
+			if err := tx.Create(&req).Error; err != nil {
+				return err
+			}
+			return recordEmploymentChange(tx, c, req.EmployeeID, before, "Employment details created")
+		})
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to create employment details")
 			return
 		}
 		user := getCurrentUser(c)
@@ -218,8 +251,28 @@ func CreateOrUpdateEmploymentDetails(c *gin.Context) {
 	} else {
 		oldValues := existing
 		req.ID = existing.ID
-		if err := database.DB.Save(&req).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update employment details"})
+		req.CreatedAt = existing.CreatedAt
+		expected := req.Version
+		if expected == 0 {
+			expected = existing.Version
+		}
+		saved := false
+		err := withTransaction(c, func(tx *gorm.DB) error {
+			var err error
+			saved, err = saveVersioned(tx, &req, &req.Version, expected)
+			if err != nil || !saved {
+				return err
+			}
+			return recordEmploymentChange(tx, c, req.EmployeeID, before, "Employment details updated")
+		})
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update employment details")
+			return
+		}
+		if !saved {
+			var current models.EmploymentDetails
+			requestDB(c).Where("employee_id = ?", employeeID).First(&current)
+			respondStaleVersion(c, current)
 			return
 		}
 		user := getCurrentUser(c)
@@ -244,26 +297,52 @@ func GetEmploymentHistory(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var history []models.EmploymentHistory
-	database.DB.Preload("Changer").Where("employee_id = ?", employeeID).Order("change_date DESC").Find(&history)
+	requestDB(c).Preload("Changer").Where("employee_id = ?", employeeID).Order("change_date DESC").Find(&history)
 
 	c.JSON(http.StatusOK, history)
 }
 
 // ==================== Position Handlers ====================
 
+// positionListFields are the filters and sort keys accepted by the position list
+var positionListFields = ListFields{
+	Filters:     map[string]string{"department": "department", "level": "level"},
+	Sorts:       map[string]string{"id": "id", "code": "code", "title": "title", "department": "department"},
+	DefaultSort: "id",
+}
+
 // GetPositions retrieves all positions
 // @Summary Get all positions
 // @Description Get list of all active positions
 // @Tags Core HR - Positions
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Position
+// @Param department query string false "Department filter (comma separated for several)"
+// @Param level query string false "Level filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, code, title, department)"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Position}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /api/positions [get]
 func GetPositions(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
 	var positions []models.Position
-	database.DB.Preload("ReportsTo").Where("is_active = ?", true).Find(&positions)
-	c.JSON(http.StatusOK, positions)
+	query, ok := applyListQuery(c, requestDB(c).Preload("ReportsTo").Where("is_active = ?", true), positionListFields)
+	if !ok {
+		return
+	}
+	response, err := paginate(query, pagination, &positions)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch positions")
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // GetPosition retrieves a specific position
@@ -281,8 +360,8 @@ func GetPosition(c *gin.Context) {
 	positionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var position models.Position
-	if err := database.DB.Preload("ReportsTo").First(&position, positionID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Position not found"})
+	if err := requestDB(c).Preload("ReportsTo").First(&position, positionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
 		return
 	}
 
@@ -298,7 +377,7 @@ func GetPosition(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body models.Position true "Position data"
 // @Success 201 {object} models.Position
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -306,12 +385,12 @@ func GetPosition(c *gin.Context) {
 func CreatePosition(c *gin.Context) {
 	var req models.Position
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create position"})
+	if err := requestDB(c).Create(&req).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create position")
 		return
 	}
 
@@ -325,7 +404,7 @@ func CreatePosition(c *gin.Context) {
 
 // UpdatePosition updates a position
 // @Summary Update position
-// @Description Update an existing position (Manager/Admin only)
+// @Description Update an existing position (Manager/Admin only). Send the version from the last read; if the position has changed since, nothing is saved and 409 is returned with the current position
 // @Tags Core HR - Positions
 // @Accept json
 // @Produce json
@@ -333,31 +412,98 @@ func CreatePosition(c *gin.Context) {
 // @Param id path int true "Position ID"
 // @Param request body models.Position true "Position data"
 // @Success 200 {object} models.Position
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ConflictResponse "Position was changed since the submitted version"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/positions/{id} [put]
 func UpdatePosition(c *gin.Context) {
 	positionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var position models.Position
-	if err := database.DB.First(&position, positionID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Position not found"})
+	if err := requestDB(c).First(&position, positionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
 		return
 	}
 
 	oldValues := position
 
 	if err := c.ShouldBindJSON(&position); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
+	// A version missing from the request keeps the one just loaded
 	position.ID = uint(positionID)
-	if err := database.DB.Save(&position).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update position"})
+	position.CreatedAt = oldValues.CreatedAt
+	saved, err := saveVersioned(requestDB(c), &position, &position.Version, position.Version)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update position")
+		return
+	}
+	if !saved {
+		var current models.Position
+		requestDB(c).First(&current, positionID)
+		respondStaleVersion(c, current)
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user != nil {
+		createAuditLog(models.AuditEntityPosition, position.ID, models.AuditActionUpdate, user.ID, c, oldValues, position)
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+// DeactivatePosition closes a position so it no longer accepts assignments
+// @Summary Deactivate position
+// @Description Deactivate (close) a position. Fails if the position still has active assignments (Manager/Admin only)
+// @Tags Core HR - Positions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Position ID"
+// @Success 200 {object} models.Position
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Position has active assignments"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/positions/{id} [delete]
+func DeactivatePosition(c *gin.Context) {
+	positionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var position models.Position
+	if err := requestDB(c).First(&position, positionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
+		return
+	}
+
+	// Active assignments must be ended before the position can be closed
+	var activeAssignments int64
+	today := time.Now().Truncate(24 * time.Hour)
+	requestDB(c).Model(&models.PositionAssignment{}).
+		Where("position_id = ? AND (end_date IS NULL OR end_date >= ?)", position.ID, today).
+		Count(&activeAssignments)
+	if activeAssignments > 0 {
+		utils.RespondErrorCode(c, http.StatusConflict, utils.CodeConflict, "Position has active assignments",
+			gin.H{"active_assignments": activeAssignments})
+		return
+	}
+
+	oldValues := position
+	position.IsActive = false
+	saved, err := saveVersioned(requestDB(c), &position, &position.Version, oldValues.Version)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to deactivate position")
+		return
+	}
+	if !saved {
+		var current models.Position
+		requestDB(c).First(&current, positionID)
+		respondStaleVersion(c, current)
 		return
 	}
 
@@ -369,19 +515,109 @@ func UpdatePosition(c *gin.Context) {
 	c.JSON(http.StatusOK, position)
 }
 
+// PositionVacancyResponse represents the staffing level of an active position
+type PositionVacancyResponse struct {
+	PositionID        uint   `json:"position_id" example:"1"`
+	Code              string `json:"code" example:"ENG-001"`
+	Title             string `json:"title" example:"Software Engineer"`
+	Department        string `json:"department" example:"IT"`
+	Headcount         int    `json:"headcount" example:"3"`
+	ActiveAssignments int    `json:"active_assignments" example:"1"`
+	Vacancies         int    `json:"vacancies" example:"2"`
+}
+
+// GetPositionVacancies lists active positions with unfilled budgeted headcount
+// @Summary Get open positions
+// @Description Compare active assignments against budgeted headcount and list positions with vacancies
+// @Tags Core HR - Positions
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Filter by department"
+// @Success 200 {array} PositionVacancyResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/positions/vacancies [get]
+func GetPositionVacancies(c *gin.Context) {
+	var positions []models.Position
+	query := requestDB(c).Where("is_active = ?", true)
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+	if err := query.Order("department, title").Find(&positions).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch positions")
+		return
+	}
+
+	// Count active assignments per position in a single query
+	type assignmentCount struct {
+		PositionID uint
+		Count      int
+	}
+	var counts []assignmentCount
+	today := time.Now().Truncate(24 * time.Hour)
+	requestDB(c).Model(&models.PositionAssignment{}).
+		Select("position_id, COUNT(*) AS count").
+		Where("end_date IS NULL OR end_date >= ?", today).
+		Group("position_id").
+		Scan(&counts)
+
+	filled := make(map[uint]int, len(counts))
+	for _, ac := range counts {
+		filled[ac.PositionID] = ac.Count
+	}
+
+	// A budget for the current fiscal year overrides the position's default headcount
+	var budgets []models.HeadcountBudget
+	requestDB(c).Where("fiscal_year = ? AND position_id IS NOT NULL", time.Now().Year()).Find(&budgets)
+	budgeted := make(map[uint]int, len(budgets))
+	for _, budget := range budgets {
+		budgeted[*budget.PositionID] = budget.BudgetedHeadcount
+	}
+
+	vacancies := make([]PositionVacancyResponse, 0)
+	for _, position := range positions {
+		headcount := position.Headcount
+		if b, ok := budgeted[position.ID]; ok {
+			headcount = b
+		}
+		open := headcount - filled[position.ID]
+		if open <= 0 {
+			continue
+		}
+		vacancies = append(vacancies, PositionVacancyResponse{
+			PositionID:        position.ID,
+			Code:              position.Code,
+			Title:             position.Title,
+			Department:        position.Department,
+			Headcount:         headcount,
+			ActiveAssignments: filled[position.ID],
+			Vacancies:         open,
+		})
+	}
+
+	c.JSON(http.StatusOK, vacancies)
+}
+
+// AssignPositionResponse represents a new position assignment
+type AssignPositionResponse struct {
+	Assignment models.PositionAssignment `json:"assignment"`
+	Warnings   []string                  `json:"warnings,omitempty"` // Headcount budget overruns, which don't block the assignment
+}
+
 // AssignPosition assigns a position to an employee
 // @Summary Assign position to employee
-// @Description Assign a position to an employee (Manager/Admin only)
+// @Description Assign a position to an employee. A primary assignment ends the employee's open primary assignment the day before it starts (Manager/Admin only)
 // @Tags Core HR - Positions
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
 // @Param request body models.PositionAssignment true "Position assignment"
-// @Success 201 {object} models.PositionAssignment
-// @Failure 400 {object} ErrorResponse
+// @Success 201 {object} AssignPositionResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/employees/{id}/positions [post]
 func AssignPosition(c *gin.Context) {
@@ -389,7 +625,24 @@ func AssignPosition(c *gin.Context) {
 
 	var req models.PositionAssignment
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+
+	var position models.Position
+	if err := requestDB(c).First(&position, req.PositionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
+		return
+	}
+	if !position.IsActive {
+		utils.RespondError(c, http.StatusBadRequest, "Cannot assign an inactive position")
+		return
+	}
+
+	// Budget overruns don't block the assignment, but are reported back to the caller
+	warnings, err := checkHeadcountBudget(requestDB(c), position, req.StartDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to assign position")
 		return
 	}
 
@@ -399,8 +652,30 @@ func AssignPosition(c *gin.Context) {
 		req.AssignedBy = &user.ID
 	}
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign position"})
+	before := utils.TakeEmploymentSnapshot(requestDB(c), req.EmployeeID)
+	err = requestDB(c).Transaction(func(tx *gorm.DB) error {
+		// A new primary assignment replaces the open primary one, which ends the day before, as in a transfer
+		if req.IsPrimary {
+			if err := tx.Model(&models.PositionAssignment{}).
+				Where("employee_id = ? AND is_primary = ? AND end_date IS NULL AND position_id <> ?", req.EmployeeID, true, req.PositionID).
+				Update("end_date", req.StartDate.AddDate(0, 0, -1)).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&req).Error; err != nil {
+			return err
+		}
+		// A primary assignment becomes the employee's current position
+		if !req.IsPrimary {
+			return nil
+		}
+		if err := tx.Model(&models.Employee{}).Where("id = ?", req.EmployeeID).Update("position_id", req.PositionID).Error; err != nil {
+			return err
+		}
+		return recordEmploymentChange(tx, c, req.EmployeeID, before, "Position assigned")
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to assign position")
 		return
 	}
 
@@ -408,11 +683,298 @@ func AssignPosition(c *gin.Context) {
 		createAuditLog(models.AuditEntityPosition, req.ID, models.AuditActionCreate, user.ID, c, nil, req)
 	}
 
-	c.JSON(http.StatusCreated, req)
+	c.JSON(http.StatusCreated, AssignPositionResponse{Assignment: req, Warnings: warnings})
+}
+
+// GetPositionAssignments retrieves position assignments for an employee
+// @Summary Get employee position assignments
+// @Description Get all current and past position assignments for an employee. Employees can only view their own; salaries are only included for users with payroll access
+// @Tags Core HR - Positions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} models.PositionAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/positions [get]
+func GetPositionAssignments(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	var assignments []models.PositionAssignment
+	if err := requestDB(c).Preload("Position").Preload("Assigner").Where("employee_id = ?", employee.ID).
+		Order("start_date DESC").Find(&assignments).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch position assignments")
+		return
+	}
+
+	// Salaries are payroll data, like compensation records
+	if user := getCurrentUser(c); user == nil || !user.PayrollAccess {
+		for i := range assignments {
+			assignments[i].Salary = nil
+		}
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// EndPositionAssignmentRequest represents data for ending a position assignment
+type EndPositionAssignmentRequest struct {
+	EndDate *string `json:"end_date,omitempty" example:"2025-06-30"` // Optional: YYYY-MM-DD format, defaults to today
+	Notes   *string `json:"notes,omitempty" example:"Contract ended"`
+}
+
+// EndPositionAssignment closes an employee's position assignment
+// @Summary End position assignment
+// @Description Set the end date of an employee's position assignment. Clears the employee's current position if the assignment was primary (Manager/Admin only)
+// @Tags Core HR - Positions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param assignment_id path int true "Assignment ID"
+// @Param request body EndPositionAssignmentRequest false "End date"
+// @Success 200 {object} models.PositionAssignment
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/positions/{assignment_id}/end [put]
+func EndPositionAssignment(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	assignmentID, _ := strconv.ParseUint(c.Param("assignment_id"), 10, 32)
+
+	var req EndPositionAssignmentRequest
+	// Body is optional - an empty request ends the assignment today
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	endDate := time.Now().Truncate(24 * time.Hour)
+	if req.EndDate != nil && *req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
+			return
+		}
+		endDate = parsed
+	}
+
+	var assignment models.PositionAssignment
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", assignmentID, employeeID).First(&assignment).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position assignment not found")
+		return
+	}
+
+	if assignment.EndDate != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Position assignment has already ended")
+		return
+	}
+	if endDate.Before(assignment.StartDate) {
+		utils.RespondError(c, http.StatusBadRequest, "End date cannot be before the assignment start date")
+		return
+	}
+
+	oldValues := assignment
+	before := utils.TakeEmploymentSnapshot(requestDB(c), assignment.EmployeeID)
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		assignment.EndDate = &endDate
+		if req.Notes != nil {
+			assignment.AssignmentNotes = req.Notes
+		}
+		if err := tx.Save(&assignment).Error; err != nil {
+			return err
+		}
+
+		// The employee no longer holds this position as their primary role
+		if !assignment.IsPrimary {
+			return nil
+		}
+		if err := tx.Model(&models.Employee{}).
+			Where("id = ? AND position_id = ?", assignment.EmployeeID, assignment.PositionID).
+			Update("position_id", nil).Error; err != nil {
+			return err
+		}
+		return recordEmploymentChange(tx, c, assignment.EmployeeID, before, "Position assignment ended")
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to end position assignment")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user != nil {
+		createAuditLog(models.AuditEntityPosition, assignment.ID, models.AuditActionUpdate, user.ID, c, oldValues, assignment)
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// TransferPositionRequest represents data for moving an employee to a new primary position
+type TransferPositionRequest struct {
+	PositionID uint     `json:"position_id" binding:"required" example:"2"`
+	StartDate  string   `json:"start_date" binding:"required" example:"2025-07-01"` // YYYY-MM-DD; previous primary assignment ends the day before
+	Salary     *float64 `json:"salary,omitempty" example:"15000"`
+	Notes      *string  `json:"notes,omitempty" example:"Transferred to Finance"`
+}
+
+// TransferPositionResponse represents the result of a position transfer
+type TransferPositionResponse struct {
+	EndedAssignments []models.PositionAssignment `json:"ended_assignments"`
+	NewAssignment    models.PositionAssignment   `json:"new_assignment"`
+	Warnings         []string                    `json:"warnings,omitempty"`
+}
+
+// TransferPosition ends the employee's current primary assignment and creates a new one atomically
+// @Summary Transfer employee to a new position
+// @Description End the current primary position assignment and create a new primary assignment in a single transaction. Updates the employee's current position (Manager/Admin only)
+// @Tags Core HR - Positions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body TransferPositionRequest true "Transfer data"
+// @Success 201 {object} TransferPositionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/positions/transfer [post]
+func TransferPosition(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req TransferPositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	var position models.Position
+	if err := requestDB(c).First(&position, req.PositionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
+		return
+	}
+	if !position.IsActive {
+		utils.RespondError(c, http.StatusBadRequest, "Cannot assign an inactive position")
+		return
+	}
+
+	warnings, err := checkHeadcountBudget(requestDB(c), position, startDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to transfer position")
+		return
+	}
+
+	user := getCurrentUser(c)
+	response := TransferPositionResponse{Warnings: warnings}
+	before := utils.TakeEmploymentSnapshot(requestDB(c), employee.ID)
+	err = requestDB(c).Transaction(func(tx *gorm.DB) error {
+		// Close every open primary assignment the day before the new one starts
+		var current []models.PositionAssignment
+		if err := tx.Where("employee_id = ? AND is_primary = ? AND end_date IS NULL", employee.ID, true).Find(&current).Error; err != nil {
+			return err
+		}
+		endDate := startDate.AddDate(0, 0, -1)
+		for i := range current {
+			if current[i].PositionID == req.PositionID {
+				return utils.ErrAlreadyInPosition
+			}
+			if endDate.Before(current[i].StartDate) {
+				return utils.ErrTransferBeforeStart
+			}
+			current[i].EndDate = &endDate
+			if err := tx.Save(&current[i]).Error; err != nil {
+				return err
+			}
+		}
+		response.EndedAssignments = current
+
+		response.NewAssignment = models.PositionAssignment{
+			EmployeeID:      employee.ID,
+			PositionID:      req.PositionID,
+			StartDate:       startDate,
+			IsPrimary:       true,
+			Salary:          req.Salary,
+			AssignmentNotes: req.Notes,
+		}
+		if user != nil {
+			response.NewAssignment.AssignedBy = &user.ID
+		}
+		if err := tx.Create(&response.NewAssignment).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&employee).Update("position_id", req.PositionID).Error; err != nil {
+			return err
+		}
+		return recordEmploymentChange(tx, c, employee.ID, before, "Position transfer")
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrAlreadyInPosition) || errors.Is(err, utils.ErrTransferBeforeStart) {
+			utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to transfer position")
+		return
+	}
+
+	if user != nil {
+		for _, ended := range response.EndedAssignments {
+			createAuditLog(models.AuditEntityPosition, ended.ID, models.AuditActionUpdate, user.ID, c, nil, ended)
+		}
+		createAuditLog(models.AuditEntityPosition, response.NewAssignment.ID, models.AuditActionCreate, user.ID, c, nil, response.NewAssignment)
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
 // ==================== Document Handlers ====================
 
+// documentListFields are the filters and sort keys accepted by the document list
+var documentListFields = ListFields{
+	Filters: map[string]string{"document_type": "document_type", "status": "status"},
+	Sorts: map[string]string{
+		"id": "id", "title": "title", "document_type": "document_type", "expiry_date": "expiry_date",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+}
+
+// documentSearchColumns are the columns the search_vector of documents is built from
+var documentSearchColumns = []string{"title", "description", "tags"}
+
 // GetDocuments retrieves documents for an employee
 // @Summary Get employee documents
 // @Description Get all documents for an employee
@@ -420,16 +982,182 @@ func AssignPosition(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
-// @Success 200 {array} models.Document
+// @Param search query string false "Search term matching the start of words in the title, description and tags"
+// @Param document_type query string false "Document type filter (comma separated for several)"
+// @Param status query string false "Status filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, title, document_type, expiry_date, created_at). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Document}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /api/employees/{id}/documents [get]
 func GetDocuments(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	var documents []models.Document
+	query := requestDB(c).Preload("Uploader").Preload("Verifier").Where("employee_id = ?", employeeID)
+	if search := c.Query("search"); search != "" {
+		query = utils.WhereSearch(query, search, documentSearchColumns...)
+	}
+	query, ok = applyListQuery(c, query, documentListFields)
+	if !ok {
+		return
+	}
+	response, err := paginate(query, pagination, &documents)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch documents")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SearchDocuments searches the documents of all employees
+// @Summary Search documents
+// @Description Search the documents of all employees by title, description and tags, with the employee each belongs to (Admin only)
+// @Tags Core HR - Documents
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search term matching the start of words in the title, description and tags"
+// @Param document_type query string false "Document type filter (comma separated for several)"
+// @Param status query string false "Status filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, title, document_type, expiry_date, created_at). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Document}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/documents [get]
+func SearchDocuments(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	var documents []models.Document
+	query := requestDB(c).Preload("Employee", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "employee_number", "firstname", "lastname", "department")
+	})
+	if search := c.Query("search"); search != "" {
+		query = utils.WhereSearch(query, search, documentSearchColumns...)
+	}
+	query, ok = applyListQuery(c, query, documentListFields)
+	if !ok {
+		return
+	}
+	response, err := paginate(query, pagination, &documents)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch documents")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// documentContentListFields are the filters and sort keys accepted by the document content search
+var documentContentListFields = ListFields{
+	Filters:     map[string]string{"document_type": "document_type", "status": "status", "employee_id": "employee_id"},
+	Sorts:       documentListFields.Sorts,
+	DefaultSort: "-created_at",
+}
+
+// DocumentContentMatch is a document whose text matches a content search, with the passages that match
+type DocumentContentMatch struct {
+	models.Document
+	Snippet string `json:"snippet" example:"... either party may end this contract by giving **three** **months** **notice** in writing ..."` // Matching words are between **; empty on MySQL and SQLite
+}
+
+// SearchDocumentContents searches the text of the documents of all employees
+// @Summary Search document contents
+// @Description Search the text extracted from the files of all employees' documents, such as to find every contract mentioning a clause, with the employee each belongs to and the matching passages. Text is extracted in the background after upload when TEXT_EXTRACTOR is set; documents show their progress in content_status. Confidential documents are only searched for admins (Manager/Admin only)
+// @Tags Core HR - Documents
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Words the text must contain. Words in double quotes must appear together, or between two words matches either, and a - before a word excludes documents with it. On MySQL and SQLite, every word must appear somewhere in the text instead"
+// @Param document_type query string false "Document type filter (comma separated for several)"
+// @Param status query string false "Status filter"
+// @Param employee_id query int false "Only the documents of this employee"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, title, document_type, expiry_date, created_at). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]DocumentContentMatch}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/documents/content-search [get]
+func SearchDocumentContents(c *gin.Context) {
+	search := strings.TrimSpace(c.Query("q"))
+	if search == "" {
+		utils.RespondError(c, http.StatusBadRequest, "Search term is required")
+		return
+	}
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "employee_number", "firstname", "lastname", "department")
+	})
+	if database.IsPostgres() {
+		query = query.Where("content_vector @@ websearch_to_tsquery('simple', ?)", search)
+	} else {
+		query = utils.WhereWords(query, search, "content_text")
+	}
+	if user := getCurrentUser(c); user == nil || user.Role != models.RoleAdmin {
+		query = query.Where("is_confidential = ?", false)
+	}
+	query, ok = applyListQuery(c, query, documentContentListFields)
+	if !ok {
+		return
+	}
 
 	var documents []models.Document
-	database.DB.Preload("Uploader").Preload("Verifier").Where("employee_id = ?", employeeID).Find(&documents)
+	response, err := paginate(query, pagination, &documents)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to search documents")
+		return
+	}
+
+	// Passages are only cut from the text of the documents on the page, by Postgres
+	ids := make([]uint, len(documents))
+	for i, document := range documents {
+		ids[i] = document.ID
+	}
+	var snippets []struct {
+		ID      uint
+		Snippet string
+	}
+	if len(ids) > 0 && database.IsPostgres() {
+		err = requestDB(c).Model(&models.Document{}).
+			Select("id, ts_headline('simple', content_text, websearch_to_tsquery('simple', ?), "+
+				"'StartSel=**, StopSel=**, MaxFragments=3, MinWords=5, MaxWords=20') AS snippet", search).
+			Where("id IN ?", ids).Scan(&snippets).Error
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to search documents")
+			return
+		}
+	}
+	snippetByID := make(map[uint]string, len(snippets))
+	for _, snippet := range snippets {
+		snippetByID[snippet.ID] = snippet.Snippet
+	}
+
+	matches := make([]DocumentContentMatch, len(documents))
+	for i, document := range documents {
+		matches[i] = DocumentContentMatch{Document: document, Snippet: snippetByID[document.ID]}
+	}
+	response.Data = matches
 
-	c.JSON(http.StatusOK, documents)
+	c.JSON(http.StatusOK, response)
 }
 
 // CreateDocumentRequest represents the form data for document upload
@@ -452,7 +1180,7 @@ type CreateDocumentRequest struct {
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
 // @Param file formData file true "Document file to upload"
-// @Param document_type formData string true "Document type (id, contract, resume, certificate, license, performance, disciplinary, compliance, other)"
+// @Param document_type formData string true "Document type (id, contract, offer_letter, resume, certificate, license, performance, disciplinary, compliance, other)"
 // @Param title formData string true "Document title"
 // @Param description formData string false "Document description"
 // @Param issue_date formData string false "Issue date (YYYY-MM-DD)"
@@ -460,11 +1188,12 @@ type CreateDocumentRequest struct {
 // @Param is_confidential formData boolean false "Is document confidential"
 // @Param tags formData string false "Document tags (comma-separated)"
 // @Success 201 {object} models.Document
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 413 {object} ErrorResponse "File too large"
 // @Failure 415 {object} ErrorResponse "Unsupported file type"
 // @Failure 500 {object} ErrorResponse
+// @Failure 507 {object} ErrorResponse "Document storage quota exceeded, with the usage in details"
 // @Router /api/employees/{id}/documents [post]
 func CreateDocument(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -472,33 +1201,39 @@ func CreateDocument(c *gin.Context) {
 	// Parse form data
 	var formData CreateDocumentRequest
 	if err := c.ShouldBind(&formData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid form data: " + err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Get uploaded file
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required: " + err.Error()})
+		utils.RespondError(c, http.StatusBadRequest, "File is required: "+err.Error())
 		return
 	}
 
 	// Validate file extension
 	if err := utils.ValidateFileExtension(file.Filename); err != nil {
-		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		utils.RespondErrorFrom(c, http.StatusUnsupportedMediaType, err)
 		return
 	}
 
 	// Validate file size
 	if err := utils.ValidateFileSize(file.Size); err != nil {
-		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		utils.RespondErrorFrom(c, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	// Check the storage quotas before storing the file, and again with its stored size when the record is saved
+	if usage, err := utils.CheckDocumentQuota(requestDB(c), uint(employeeID), file.Size); err != nil {
+		respondDocumentQuotaError(c, usage, err)
 		return
 	}
 
 	// Open uploaded file
 	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to open uploaded file")
 		return
 	}
 	defer src.Close()
@@ -506,21 +1241,21 @@ func CreateDocument(c *gin.Context) {
 	// Detect MIME type
 	mimeType := utils.GetFileMimeType(file.Filename)
 	if err := utils.ValidateMimeType(mimeType); err != nil {
-		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		utils.RespondErrorFrom(c, http.StatusUnsupportedMediaType, err)
 		return
 	}
 
 	// Generate secure filename
 	secureFilename, err := utils.GenerateSecureFileName(file.Filename, uint(employeeID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate filename"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate filename")
 		return
 	}
 
 	// Save file to storage
 	relativePath, fileSize, err := utils.SaveFile(src, secureFilename, uint(employeeID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file: " + err.Error()})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save file: "+err.Error())
 		return
 	}
 
@@ -561,20 +1296,34 @@ func CreateDocument(c *gin.Context) {
 		document.UploadedBy = &user.ID
 	}
 
-	if err := database.DB.Create(&document).Error; err != nil {
-		// Clean up file if database save fails
+	// The document record and its audit entry are saved together; the stored file is removed if either fails
+	var usage utils.StorageUsage
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		var err error
+		if usage, err = utils.CheckDocumentQuota(tx, uint(employeeID), fileSize); err != nil {
+			return err
+		}
+		if err := tx.Create(&document).Error; err != nil {
+			return err
+		}
+		if user != nil {
+			return recordAuditLog(tx, models.AuditEntityDocument, document.ID, models.AuditActionCreate, user.ID, c, nil, document)
+		}
+		return nil
+	})
+	if err != nil {
 		utils.DeleteFile(relativePath)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create document record"})
+		if errors.Is(err, utils.ErrStorageQuotaExceeded) {
+			respondDocumentQuotaError(c, usage, err)
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create document record")
 		return
 	}
 
-	// Create audit log
-	if user != nil {
-		createAuditLog(models.AuditEntityDocument, document.ID, models.AuditActionCreate, user.ID, c, nil, document)
-	}
-
 	// Load associations
-	database.DB.Preload("Uploader").Preload("Verifier").First(&document, document.ID)
+	requestDB(c).Preload("Uploader").Preload("Verifier").First(&document, document.ID)
+	utils.StartTextExtraction()
 
 	c.JSON(http.StatusCreated, document)
 }
@@ -597,20 +1346,24 @@ func DownloadDocument(c *gin.Context) {
 
 	// Get document from database
 	var document models.Document
-	if err := database.DB.Where("id = ? AND employee_id = ?", documentID, employeeID).First(&document).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", documentID, employeeID).First(&document).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Document not found")
 		return
 	}
 
 	// Check if file exists
 	if !utils.FileExists(document.FilePath) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Document file not found on server"})
+		utils.RespondError(c, http.StatusNotFound, "Document file not found on server")
 		return
 	}
 
 	// Get full file path
 	fullPath := utils.GetFullFilePath(document.FilePath)
 
+	noteFileAccess(c, models.FileAccessLog{
+		EmployeeID: &document.EmployeeID, DocumentID: &document.ID, Confidential: document.IsConfidential,
+	})
+
 	// Set headers for file download
 	c.Header("Content-Disposition", `attachment; filename="`+document.FileName+`"`)
 	if document.MimeType != nil {
@@ -640,8 +1393,8 @@ func DeleteDocument(c *gin.Context) {
 
 	// Get document from database
 	var document models.Document
-	if err := database.DB.Where("id = ? AND employee_id = ?", documentID, employeeID).First(&document).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", documentID, employeeID).First(&document).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Document not found")
 		return
 	}
 
@@ -653,10 +1406,14 @@ func DeleteDocument(c *gin.Context) {
 		}
 	}
 
-	// Delete from database
+	// Delete from database; the record is kept deleted, but the text extracted from the file goes with it
 	oldValues := document
-	if err := database.DB.Delete(&document).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete document"})
+	if err := requestDB(c).Model(&document).UpdateColumn("content_text", nil).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete document")
+		return
+	}
+	if err := requestDB(c).Delete(&document).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete document")
 		return
 	}
 
@@ -685,14 +1442,53 @@ func GetLifecycleEvents(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var events []models.WorkLifecycleEvent
-	database.DB.Preload("Initiator").Preload("Approver").Where("employee_id = ?", employeeID).Order("event_date DESC").Find(&events)
+	requestDB(c).Preload("Initiator").Preload("Approver").Where("employee_id = ?", employeeID).Order("event_date DESC").Find(&events)
 
 	c.JSON(http.StatusOK, events)
 }
 
+// lifecycleEventStatus maps lifecycle events to the employment status they imply
+var lifecycleEventStatus = map[models.LifecycleEventType]models.EmploymentStatus{
+	models.LifecycleEventHired:      models.EmploymentStatusActive,
+	models.LifecycleEventResigned:   models.EmploymentStatusResigned,
+	models.LifecycleEventTerminated: models.EmploymentStatusTerminated,
+	models.LifecycleEventRetired:    models.EmploymentStatusTerminated,
+}
+
+// applyLifecycleStatus sets the employment status a lifecycle event implies on the employee's
+// employment details, creating them when missing. Leaving statuses also record the date and reason
+// the employment ended, which anonymization and retention go by.
+func applyLifecycleStatus(tx *gorm.DB, employeeID uint, status models.EmploymentStatus, changeDate time.Time, reason *string) error {
+	updates := map[string]interface{}{
+		"employment_status": status,
+		"version":           gorm.Expr("version + 1"),
+	}
+	leaving := status == models.EmploymentStatusTerminated || status == models.EmploymentStatusResigned
+	if leaving {
+		updates["termination_date"] = changeDate
+		if reason != nil && *reason != "" {
+			updates["termination_reason"] = *reason
+		}
+	}
+
+	var employment models.EmploymentDetails
+	err := tx.Where("employee_id = ?", employeeID).First(&employment).Error
+	if err == gorm.ErrRecordNotFound {
+		employment = models.EmploymentDetails{EmployeeID: employeeID, EmploymentStatus: status}
+		if leaving {
+			employment.TerminationDate = &changeDate
+			employment.TerminationReason = reason
+		}
+		return tx.Create(&employment).Error
+	} else if err != nil {
+		return err
+	}
+	return tx.Model(&employment).Updates(updates).Error
+}
+
 // CreateLifecycleEvent creates a new lifecycle event
 // @Summary Create lifecycle event
-// @Description Create a new lifecycle event for an employee (Manager/Admin only)
+// @Description Create a new lifecycle event for an employee (Manager/Admin only). Resigned, terminated, retired and status_change events also update the employment status, and leaving ones the termination date.
 // @Tags Core HR - Lifecycle
 // @Accept json
 // @Produce json
@@ -700,7 +1496,7 @@ func GetLifecycleEvents(c *gin.Context) {
 // @Param id path int true "Employee ID"
 // @Param request body models.WorkLifecycleEvent true "Lifecycle event data"
 // @Success 201 {object} models.WorkLifecycleEvent
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -710,7 +1506,7 @@ func CreateLifecycleEvent(c *gin.Context) {
 
 	var req models.WorkLifecycleEvent
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -720,8 +1516,41 @@ func CreateLifecycleEvent(c *gin.Context) {
 		req.InitiatedBy = &user.ID
 	}
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create lifecycle event"})
+	// Every lifecycle event is reflected in the employment history; events that
+	// end or start employment carry the corresponding status
+	snapshot := utils.TakeEmploymentSnapshot(requestDB(c), req.EmployeeID)
+	after := snapshot
+	if status, ok := lifecycleEventStatus[req.EventType]; ok {
+		after.Status = &status
+	} else if req.EventType == models.LifecycleEventStatusChange && req.NewValue != nil {
+		status := models.EmploymentStatus(*req.NewValue)
+		if !importEmploymentStatuses[status] {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid employment status")
+			return
+		}
+		after.Status = &status
+	}
+	changeDate := req.EventDate
+	if req.EffectiveDate != nil {
+		changeDate = *req.EffectiveDate
+	}
+	reason := "Lifecycle event: " + string(req.EventType)
+	if req.Description != nil && *req.Description != "" {
+		reason += " - " + *req.Description
+	}
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&req).Error; err != nil {
+			return err
+		}
+		if after.Status != nil && (snapshot.Status == nil || *after.Status != *snapshot.Status) {
+			if err := applyLifecycleStatus(tx, req.EmployeeID, *after.Status, changeDate, req.Description); err != nil {
+				return err
+			}
+		}
+		return utils.RecordEmploymentEvent(tx, req.EmployeeID, snapshot, after, changeDate, reason, req.InitiatedBy)
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create lifecycle event")
 		return
 	}
 
@@ -749,8 +1578,8 @@ func GetOnboardingProcess(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var process models.OnboardingProcess
-	if err := database.DB.Preload("Tasks").Preload("Assignee").Preload("Initiator").Where("employee_id = ?", employeeID).First(&process).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Onboarding process not found"})
+	if err := requestDB(c).Preload("Tasks").Preload("Assignee").Preload("Initiator").Where("employee_id = ?", employeeID).First(&process).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Onboarding process not found")
 		return
 	}
 
@@ -767,7 +1596,7 @@ func GetOnboardingProcess(c *gin.Context) {
 // @Param id path int true "Employee ID"
 // @Param request body models.OnboardingProcess true "Onboarding process data"
 // @Success 201 {object} models.OnboardingProcess
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -777,7 +1606,7 @@ func CreateOnboardingProcess(c *gin.Context) {
 
 	var req models.OnboardingProcess
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -787,8 +1616,8 @@ func CreateOnboardingProcess(c *gin.Context) {
 		req.InitiatedBy = &user.ID
 	}
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create onboarding process"})
+	if err := requestDB(c).Create(&req).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create onboarding process")
 		return
 	}
 
@@ -816,8 +1645,8 @@ func GetOffboardingProcess(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var process models.OffboardingProcess
-	if err := database.DB.Preload("Tasks").Preload("Assignee").Preload("Initiator").Where("employee_id = ?", employeeID).First(&process).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Offboarding process not found"})
+	if err := requestDB(c).Preload("Tasks").Preload("Assignee").Preload("Initiator").Where("employee_id = ?", employeeID).First(&process).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Offboarding process not found")
 		return
 	}
 
@@ -834,7 +1663,7 @@ func GetOffboardingProcess(c *gin.Context) {
 // @Param id path int true "Employee ID"
 // @Param request body models.OffboardingProcess true "Offboarding process data"
 // @Success 201 {object} models.OffboardingProcess
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -844,7 +1673,7 @@ func CreateOffboardingProcess(c *gin.Context) {
 
 	var req models.OffboardingProcess
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -854,8 +1683,8 @@ func CreateOffboardingProcess(c *gin.Context) {
 		req.InitiatedBy = &user.ID
 	}
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create offboarding process"})
+	if err := requestDB(c).Create(&req).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create offboarding process")
 		return
 	}
 
@@ -879,7 +1708,7 @@ func CreateOffboardingProcess(c *gin.Context) {
 // @Router /api/compliance/requirements [get]
 func GetComplianceRequirements(c *gin.Context) {
 	var requirements []models.ComplianceRequirement
-	database.DB.Where("is_active = ?", true).Find(&requirements)
+	requestDB(c).Where("is_active = ?", true).Find(&requirements)
 	c.JSON(http.StatusOK, requirements)
 }
 
@@ -892,7 +1721,7 @@ func GetComplianceRequirements(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body models.ComplianceRequirement true "Compliance requirement data"
 // @Success 201 {object} models.ComplianceRequirement
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -900,12 +1729,12 @@ func GetComplianceRequirements(c *gin.Context) {
 func CreateComplianceRequirement(c *gin.Context) {
 	var req models.ComplianceRequirement
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create compliance requirement"})
+	if err := requestDB(c).Create(&req).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create compliance requirement")
 		return
 	}
 
@@ -931,7 +1760,7 @@ func GetComplianceRecords(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var records []models.ComplianceRecord
-	database.DB.Preload("Requirement").Preload("Verifier").Preload("Document").Where("employee_id = ?", employeeID).Find(&records)
+	requestDB(c).Preload("Requirement").Preload("Verifier").Preload("Document").Where("employee_id = ?", employeeID).Find(&records)
 
 	c.JSON(http.StatusOK, records)
 }
@@ -946,7 +1775,7 @@ func GetComplianceRecords(c *gin.Context) {
 // @Param id path int true "Employee ID"
 // @Param request body models.ComplianceRecord true "Compliance record data"
 // @Success 201 {object} models.ComplianceRecord
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -956,14 +1785,14 @@ func CreateComplianceRecord(c *gin.Context) {
 
 	var req models.ComplianceRecord
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	req.EmployeeID = uint(employeeID)
 
-	if err := database.DB.Create(&req).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create compliance record"})
+	if err := requestDB(c).Create(&req).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create compliance record")
 		return
 	}
 
@@ -975,39 +1804,217 @@ func CreateComplianceRecord(c *gin.Context) {
 	c.JSON(http.StatusCreated, req)
 }
 
+// ExportExpiringCompliance exports compliance records expiring soon to Excel or PDF
+// @Summary Export expiring compliance records
+// @Description Export compliance records expiring in the next N days, grouped by department and requirement, to Excel or PDF (Manager/Admin only)
+// @Tags Core HR - Compliance
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param format query string true "Export format (excel or pdf)" Enums(excel, pdf) default:"excel"
+// @Param days query int false "Look-ahead window in days (default 30)"
+// @Param department query string false "Filter by department"
+// @Param include_expired query bool false "Include records that have already expired"
+// @Param lang query string false "Language of the file: en, fr or pt (default: the Accept-Language header)"
+// @Param paper_size query string false "Paper size of a PDF: A4 or Letter (default: the export_paper_size setting)"
+// @Success 200 {file} file "Excel or PDF file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/compliance/expiring/export [get]
+func ExportExpiringCompliance(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		format = "excel"
+	}
+	if format != "excel" && format != "pdf" {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'excel' or 'pdf'")
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid days. Use a non-negative number")
+		return
+	}
+	locale, ok := exportLocale(c)
+	if !ok {
+		return
+	}
+
+	records, err := utils.GetExpiringComplianceRecords(requestDB(c), days, c.Query("department"), c.Query("include_expired") == "true")
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch compliance records")
+		return
+	}
+
+	if format == "excel" {
+		filename := fmt.Sprintf("expiring_compliance_%s.xlsx", time.Now().Format("20060102_150405"))
+		streamDownload(c, filename, utils.XLSXContentType, "Failed to generate export file", func(w io.Writer) error {
+			return utils.ExportExpiringComplianceToExcel(w, records, days, locale)
+		})
+		return
+	}
+	filename := fmt.Sprintf("expiring_compliance_%s.pdf", time.Now().Format("20060102_150405"))
+	streamDownload(c, filename, "application/pdf", "Failed to generate export file", func(w io.Writer) error {
+		return utils.ExportExpiringComplianceToPDF(w, records, days, locale)
+	})
+}
+
 // ==================== Audit Log Handlers ====================
 
-// GetAuditLogs retrieves audit logs with optional filtering
+// AuditLogPage represents one page of audit logs
+type AuditLogPage struct {
+	Data       []models.AuditLog `json:"data"`
+	Total      int64             `json:"total" example:"1342"`                                             // Total logs matching the filters
+	Limit      int               `json:"limit" example:"50"`                                               // Page size used
+	NextCursor *string           `json:"next_cursor,omitempty" example:"MTcwNTMxMjAwMDAwMDAwMDAwMDo0MjA="` // Pass as cursor to fetch the next page; omitted on the last page
+}
+
+const (
+	defaultAuditLogPageSize = 50
+	maxAuditLogPageSize     = 500
+)
+
+// GetAuditLogs retrieves audit logs with filtering and cursor-based pagination
 // @Summary Get audit logs
-// @Description Get audit logs with optional filtering by entity type, entity ID, or performed by
+// @Description Get audit logs newest first, filtered by entity type, entity ID, action, performer, batch and created_at range. Use next_cursor from the response to fetch the following page
 // @Tags Core HR - Audit
 // @Produce json
 // @Security BearerAuth
-// @Param entity_type query string false "Entity type filter"
+// @Param entity_type query string false "Entity type filter (comma-separated for several)"
 // @Param entity_id query int false "Entity ID filter"
+// @Param action query string false "Action filter, e.g. CREATE, UPDATE, DELETE (comma-separated for several)"
 // @Param performed_by query int false "Performed by user ID filter"
-// @Success 200 {array} models.AuditLog
+// @Param batch_id query string false "Batch reference filter, for the changes of one bulk operation"
+// @Param from query string false "Only logs created at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only logs created at or before this time (RFC3339, or YYYY-MM-DD for the whole day)"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param cursor query string false "Cursor from a previous page's next_cursor"
+// @Success 200 {object} AuditLogPage
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /api/audit-logs [get]
 func GetAuditLogs(c *gin.Context) {
-	var logs []models.AuditLog
-	query := database.DB.Preload("Performer")
+	query := requestDB(c).Model(&models.AuditLog{})
 
 	if entityType := c.Query("entity_type"); entityType != "" {
-		query = query.Where("entity_type = ?", entityType)
+		query = query.Where("entity_type IN ?", strings.Split(entityType, ","))
 	}
 
 	if entityID := c.Query("entity_id"); entityID != "" {
 		query = query.Where("entity_id = ?", entityID)
 	}
 
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action IN ?", strings.Split(strings.ToUpper(action), ","))
+	}
+
 	if performedBy := c.Query("performed_by"); performedBy != "" {
 		query = query.Where("performed_by = ?", performedBy)
 	}
 
-	query.Order("created_at DESC").Limit(100).Find(&logs)
+	if batchID := c.Query("batch_id"); batchID != "" {
+		query = query.Where("batch_id = ?", batchID)
+	}
+
+	if from := c.Query("from"); from != "" {
+		fromTime, _, err := parseAuditTime(from)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from. Use RFC3339 or YYYY-MM-DD")
+			return
+		}
+		query = query.Where("created_at >= ?", fromTime)
+	}
+
+	if to := c.Query("to"); to != "" {
+		toTime, dateOnly, err := parseAuditTime(to)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to. Use RFC3339 or YYYY-MM-DD")
+			return
+		}
+		if dateOnly {
+			query = query.Where("created_at < ?", toTime.AddDate(0, 0, 1))
+		} else {
+			query = query.Where("created_at <= ?", toTime)
+		}
+	}
+
+	limit := defaultAuditLogPageSize
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = parsed
+		if limit > maxAuditLogPageSize {
+			limit = maxAuditLogPageSize
+		}
+	}
+
+	// Total is counted before the cursor is applied so it reflects the whole filtered set
+	var total int64
+	query.Count(&total)
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		cursorTime, cursorID, err := decodeAuditCursor(cursor)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", cursorTime, cursorTime, cursorID)
+	}
+
+	// Fetch one extra row to know whether another page exists
+	var logs []models.AuditLog
+	query.Preload("Performer").Order("created_at DESC, id DESC").Limit(limit + 1).Find(&logs)
+
+	page := AuditLogPage{Total: total, Limit: limit}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[len(logs)-1]
+		next := encodeAuditCursor(last.CreatedAt, last.ID)
+		page.NextCursor = &next
+	}
+	page.Data = logs
+
+	c.JSON(http.StatusOK, page)
+}
+
+// parseAuditTime parses an RFC3339 timestamp or a YYYY-MM-DD date, reporting whether it was date-only
+func parseAuditTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	return t, true, err
+}
+
+// encodeAuditCursor builds an opaque cursor from the position of the last log on a page
+func encodeAuditCursor(createdAt time.Time, id uint) string {
+	return base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", createdAt.UnixNano(), id)))
+}
 
-	c.JSON(http.StatusOK, logs)
+// decodeAuditCursor reverses encodeAuditCursor
+func decodeAuditCursor(cursor string) (time.Time, uint, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, errors.New("malformed cursor")
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.Unix(0, nanos), uint(id), nil
 }
 
 // GetEmployeeAuditLogs retrieves audit logs for a specific employee
@@ -1017,19 +2024,29 @@ func GetAuditLogs(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
-// @Success 200 {array} models.AuditLog
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.AuditLog}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /api/employees/{id}/audit-logs [get]
 func GetEmployeeAuditLogs(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
 
 	var logs []models.AuditLog
-	database.DB.Preload("Performer").
+	query := requestDB(c).Preload("Performer").
 		Where("(entity_type = ? AND entity_id = ?) OR performed_by = ?",
 			models.AuditEntityEmployee, employeeID, employeeID).
-		Order("created_at DESC").
-		Limit(100).
-		Find(&logs)
+		Order("created_at DESC, id DESC")
+	response, err := paginate(query, pagination, &logs)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch audit logs")
+		return
+	}
 
-	c.JSON(http.StatusOK, logs)
+	c.JSON(http.StatusOK, response)
 }
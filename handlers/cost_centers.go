@@ -0,0 +1,650 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CostCenterRequest represents data for creating or updating a cost center
+type CostCenterRequest struct {
+	Code        string  `json:"code" binding:"required,max=30" example:"CC-100"`
+	Name        string  `json:"name" binding:"required,max=100" example:"Finance Lusaka"`
+	Description *string `json:"description,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"` // Defaults to true
+}
+
+// CostCenterSplitRequest is the share of a cost charged to one cost center
+type CostCenterSplitRequest struct {
+	CostCenterID uint    `json:"cost_center_id" binding:"required" example:"3"`
+	Percentage   float64 `json:"percentage" binding:"gt=0,lte=100" example:"60"`
+}
+
+// CostCenterAllocationRequest replaces how an employee's or a position's cost is split from a date
+type CostCenterAllocationRequest struct {
+	StartDate string                   `json:"start_date" binding:"required" example:"2026-07-01"`
+	Splits    []CostCenterSplitRequest `json:"splits" binding:"dive"` // Must add up to 100 percent; empty ends the allocation
+}
+
+// CostCenterAllocationResponse is how an employee's or a position's cost is split across cost centers
+type CostCenterAllocationResponse struct {
+	Current     []utils.CostCenterSplit       `json:"current"`          // Split in effect today
+	Source      string                        `json:"source,omitempty"` // For employees: employee when allocated themselves, position when allocated through their position
+	Allocations []models.CostCenterAllocation `json:"allocations"`      // Own allocations, past, current and future, newest first
+}
+
+// GetCostCenters lists the organization's cost centers
+// @Summary Get cost centers
+// @Description List the cost centers personnel costs are allocated to, by code. Inactive ones are included with include_inactive=true (Admin only)
+// @Tags Admin - Cost Centers
+// @Produce json
+// @Security BearerAuth
+// @Param include_inactive query bool false "Include inactive cost centers"
+// @Success 200 {array} models.CostCenter
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/cost-centers [get]
+func GetCostCenters(c *gin.Context) {
+	query := requestDB(c).Order("code")
+	if c.Query("include_inactive") != "true" {
+		query = query.Where("is_active = ?", true)
+	}
+	var costCenters []models.CostCenter
+	if err := query.Find(&costCenters).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch cost centers")
+		return
+	}
+	c.JSON(http.StatusOK, costCenters)
+}
+
+// CreateCostCenter creates a cost center
+// @Summary Create a cost center
+// @Description Create a cost center employees and positions can be allocated to. Codes are unique (Admin only)
+// @Tags Admin - Cost Centers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CostCenterRequest true "Cost center"
+// @Success 201 {object} models.CostCenter
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/cost-centers [post]
+func CreateCostCenter(c *gin.Context) {
+	var req CostCenterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	costCenter := models.CostCenter{IsActive: true}
+	applyCostCenterRequest(&costCenter, req)
+	if costCenterCodeTaken(c, costCenter) {
+		return
+	}
+
+	if err := requestDB(c).Create(&costCenter).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create cost center")
+		return
+	}
+
+	createAuditLog(models.AuditEntityCostCenter, costCenter.ID, models.AuditActionCreate, c.GetUint("user_id"), c, nil, costCenter)
+	c.JSON(http.StatusCreated, costCenter)
+}
+
+// UpdateCostCenter updates a cost center
+// @Summary Update a cost center
+// @Description Change a cost center's code, name, description or whether it is active. Inactive cost centers stay on existing allocations but cannot be allocated to anew (Admin only)
+// @Tags Admin - Cost Centers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Cost center ID"
+// @Param request body CostCenterRequest true "Cost center"
+// @Success 200 {object} models.CostCenter
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/cost-centers/{id} [put]
+func UpdateCostCenter(c *gin.Context) {
+	costCenterID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req CostCenterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var costCenter models.CostCenter
+	if err := requestDB(c).First(&costCenter, costCenterID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Cost center not found")
+		return
+	}
+	oldCostCenter := costCenter
+	applyCostCenterRequest(&costCenter, req)
+	if costCenterCodeTaken(c, costCenter) {
+		return
+	}
+
+	if err := requestDB(c).Save(&costCenter).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update cost center")
+		return
+	}
+
+	createAuditLog(models.AuditEntityCostCenter, costCenter.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldCostCenter, costCenter)
+	c.JSON(http.StatusOK, costCenter)
+}
+
+// DeleteCostCenter deletes a cost center
+// @Summary Delete a cost center
+// @Description Delete a cost center nothing was ever allocated to. Deactivate cost centers with allocations instead, so past allocations and exports keep them (Admin only)
+// @Tags Admin - Cost Centers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Cost center ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/cost-centers/{id} [delete]
+func DeleteCostCenter(c *gin.Context) {
+	costCenterID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var costCenter models.CostCenter
+	if err := requestDB(c).First(&costCenter, costCenterID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Cost center not found")
+		return
+	}
+	var inUse int64
+	if err := requestDB(c).Model(&models.CostCenterAllocation{}).Where("cost_center_id = ?", costCenter.ID).Count(&inUse).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete cost center")
+		return
+	}
+	if inUse > 0 {
+		utils.RespondError(c, http.StatusConflict, "The cost center has allocations. Deactivate it instead")
+		return
+	}
+	if err := requestDB(c).Delete(&costCenter).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete cost center")
+		return
+	}
+
+	createAuditLog(models.AuditEntityCostCenter, costCenter.ID, models.AuditActionDelete, c.GetUint("user_id"), c, costCenter, nil)
+	c.JSON(http.StatusOK, gin.H{"message": "Cost center deleted successfully"})
+}
+
+// GetEmployeeCostCenters returns how an employee's cost is split across cost centers
+// @Summary Get an employee's cost centers
+// @Description Get the cost center split in effect today for an employee, from their own allocations or else their primary position's, and the history of their own allocations (Manager/Admin only)
+// @Tags Admin - Cost Centers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} CostCenterAllocationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/cost-centers [get]
+func GetEmployeeCostCenters(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	db := requestDB(c)
+	response := CostCenterAllocationResponse{Current: []utils.CostCenterSplit{}}
+	if err := db.Preload("CostCenter").Where("employee_id = ?", employee.ID).
+		Order("start_date DESC, cost_center_id").Find(&response.Allocations).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch cost centers")
+		return
+	}
+	splits, err := utils.EmployeeCostCenterSplits(db, []uint{employee.ID}, utils.CompanyToday())
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch cost centers")
+		return
+	}
+	if split, ok := splits[employee.ID]; ok {
+		response.Current = split
+		response.Source = "position"
+		for _, allocation := range response.Allocations {
+			if allocationInEffect(allocation, utils.CompanyToday()) {
+				response.Source = "employee"
+				break
+			}
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SetEmployeeCostCenters replaces how an employee's cost is split across cost centers from a date
+// @Summary Set an employee's cost centers
+// @Description Split an employee's cost across cost centers from start_date, overriding their position's split. The percentages must add up to 100. The allocation in effect before start_date ends the day before, and allocations starting on or after it are replaced. Empty splits end the employee's own allocation, so their position's split applies again (Admin only)
+// @Tags Admin - Cost Centers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body CostCenterAllocationRequest true "Cost center split"
+// @Success 200 {array} models.CostCenterAllocation
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/cost-centers [put]
+func SetEmployeeCostCenters(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+	setCostCenterAllocation(c, models.CostCenterAllocation{EmployeeID: &employee.ID}, "employee_id = ?", employee.ID)
+}
+
+// GetPositionCostCenters returns how a position's cost is split across cost centers
+// @Summary Get a position's cost centers
+// @Description Get the cost center split in effect today for a position and the history of its allocations. Employees in the position are charged by it unless they have their own allocation (Manager/Admin only)
+// @Tags Admin - Cost Centers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Position ID"
+// @Success 200 {object} CostCenterAllocationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/positions/{id}/cost-centers [get]
+func GetPositionCostCenters(c *gin.Context) {
+	positionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var position models.Position
+	if err := requestDB(c).First(&position, positionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
+		return
+	}
+
+	db := requestDB(c)
+	response := CostCenterAllocationResponse{Current: []utils.CostCenterSplit{}}
+	if err := db.Preload("CostCenter").Where("position_id = ?", position.ID).
+		Order("start_date DESC, cost_center_id").Find(&response.Allocations).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch cost centers")
+		return
+	}
+	splits, err := utils.PositionCostCenterSplits(db, []uint{position.ID}, utils.CompanyToday())
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch cost centers")
+		return
+	}
+	if split, ok := splits[position.ID]; ok {
+		response.Current = split
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SetPositionCostCenters replaces how a position's cost is split across cost centers from a date
+// @Summary Set a position's cost centers
+// @Description Split a position's cost across cost centers from start_date. The percentages must add up to 100. The allocation in effect before start_date ends the day before, and allocations starting on or after it are replaced. Empty splits end the position's allocation (Admin only)
+// @Tags Admin - Cost Centers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Position ID"
+// @Param request body CostCenterAllocationRequest true "Cost center split"
+// @Success 200 {array} models.CostCenterAllocation
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/positions/{id}/cost-centers [put]
+func SetPositionCostCenters(c *gin.Context) {
+	positionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var position models.Position
+	if err := requestDB(c).First(&position, positionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Position not found")
+		return
+	}
+	setCostCenterAllocation(c, models.CostCenterAllocation{PositionID: &position.ID}, "position_id = ?", position.ID)
+}
+
+// ExportPayrollCostAllocation exports how each employee's pay is split across cost centers
+// @Summary Export payroll cost allocation
+// @Description Export one row per active employee and cost center with the employee's current pay, the percentage charged to the cost center and the amount that comes to, for finance to allocate the payroll. Pay is the employee's compensation record in effect on date, or else the salary of their current primary assignment in ZMW. Employees without an allocation are exported as unallocated. Every export is audit logged (Payroll access only)
+// @Tags Admin - Cost Centers
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv
+// @Security BearerAuth
+// @Param format query string false "Export format: xlsx or csv (default: xlsx)"
+// @Param date query string false "Date the pay and splits are taken on (YYYY-MM-DD, default today)"
+// @Param department query string false "Only export this department"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/payroll/cost-allocation/export [get]
+func ExportPayrollCostAllocation(c *gin.Context) {
+	format, date, ok := parseCostCenterExport(c)
+	if !ok {
+		return
+	}
+
+	db := requestDB(c)
+	query := db.Preload("Employment").Where("status = ? AND role != ?", "active", models.RoleAdmin).Order("lastname, firstname")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var employees []models.Employee
+	if err := query.Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+	ids := make([]uint, len(employees))
+	for i, employee := range employees {
+		ids[i] = employee.ID
+	}
+
+	splits, err := utils.EmployeeCostCenterSplits(db, ids, date)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+		return
+	}
+	pay, err := utils.CurrentCompensation(db, ids, date)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+		return
+	}
+	var assignments []models.PositionAssignment
+	if len(ids) > 0 {
+		err = db.Preload("Position").Where("employee_id IN ? AND is_primary = ? AND start_date <= ? AND (end_date IS NULL OR end_date >= ?)",
+			ids, true, date, date).Order("start_date").Find(&assignments).Error
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+			return
+		}
+	}
+	assignmentOf := map[uint]models.PositionAssignment{}
+	for _, assignment := range assignments {
+		assignmentOf[assignment.EmployeeID] = assignment // The latest primary assignment wins
+	}
+
+	header := []string{"Employee Number", "Employee", "Department", "Position", "Cost Center Code", "Cost Center",
+		"Percentage", "Salary", "Currency", "Allocated Amount"}
+	rows := [][]string{}
+	for i := range employees {
+		employee := &employees[i]
+		assignment := assignmentOf[employee.ID]
+		salary, currency := "", ""
+		var amount *float64
+		if record, ok := pay[employee.ID]; ok {
+			amount, currency = &record.Amount, record.Currency
+		} else if assignment.Salary != nil {
+			amount, currency = assignment.Salary, models.DefaultCurrency
+		}
+		if amount != nil {
+			salary = fmt.Sprintf("%.2f", *amount)
+		}
+		base := []string{rosterEmployeeNumber(employee), employee.Firstname + " " + employee.Lastname, employee.Department,
+			assignment.Position.Title}
+
+		employeeSplits := splits[employee.ID]
+		if len(employeeSplits) == 0 {
+			rows = append(rows, append(append([]string{}, base...), "", "Unallocated", "100", salary, currency, salary))
+			continue
+		}
+		for _, split := range employeeSplits {
+			allocated := ""
+			if amount != nil {
+				allocated = fmt.Sprintf("%.2f", *amount*split.Percentage/100)
+			}
+			rows = append(rows, append(append([]string{}, base...), split.Code, split.Name,
+				utils.FormatPercentage(split.Percentage), salary, currency, allocated))
+		}
+	}
+
+	createAuditLog(models.AuditEntityCompensation, 0, models.AuditActionView, c.GetUint("user_id"), c, nil,
+		gin.H{"export": "cost_allocation", "date": date.Format("2006-01-02"), "department": c.Query("department")})
+	writeTableExport(c, format, "payroll_cost_allocation_"+date.Format("20060102"), "Cost Allocation", header, rows)
+}
+
+// ExportHeadcountCostCenters exports budgeted and filled headcount by position and cost center
+// @Summary Export headcount by cost center
+// @Description Export one row per active position and cost center with the position's budgeted headcount for the fiscal year of date, its seats filled on date, and the full-time equivalents of both charged to the cost center. Positions without an allocation are exported as unallocated (Manager/Admin only)
+// @Tags Core HR - Headcount
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv
+// @Security BearerAuth
+// @Param format query string false "Export format: xlsx or csv (default: xlsx)"
+// @Param date query string false "Date the splits are taken on, whose year is the fiscal year (YYYY-MM-DD, default today)"
+// @Param department query string false "Only export this department"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/headcount/export [get]
+func ExportHeadcountCostCenters(c *gin.Context) {
+	format, date, ok := parseCostCenterExport(c)
+	if !ok {
+		return
+	}
+
+	db := requestDB(c)
+	query := db.Where("is_active = ?", true).Order("department, code")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var positions []models.Position
+	if err := query.Find(&positions).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch positions")
+		return
+	}
+	ids := make([]uint, len(positions))
+	for i, position := range positions {
+		ids[i] = position.ID
+	}
+	splits, err := utils.PositionCostCenterSplits(db, ids, date)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+		return
+	}
+
+	header := []string{"Position Code", "Position", "Department", "Fiscal Year", "Cost Center Code", "Cost Center",
+		"Percentage", "Budgeted Headcount", "Filled", "Budgeted FTE", "Filled FTE"}
+	rows := [][]string{}
+	for _, position := range positions {
+		budgeted := position.Headcount
+		if budget, err := findHeadcountBudget(db, &position.ID, position.Department, date.Year()); err == nil {
+			budgeted = budget.BudgetedHeadcount
+		}
+		filled, err := countFilledHeadcount(db, &position.ID, position.Department, date)
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+			return
+		}
+		base := []string{position.Code, position.Title, position.Department, strconv.Itoa(date.Year())}
+		positionSplits := splits[position.ID]
+		if len(positionSplits) == 0 {
+			positionSplits = []utils.CostCenterSplit{{Name: "Unallocated", Percentage: 100}}
+		}
+		for _, split := range positionSplits {
+			rows = append(rows, append(append([]string{}, base...), split.Code, split.Name, utils.FormatPercentage(split.Percentage),
+				strconv.Itoa(budgeted), strconv.Itoa(filled),
+				fmt.Sprintf("%.2f", float64(budgeted)*split.Percentage/100), fmt.Sprintf("%.2f", float64(filled)*split.Percentage/100)))
+		}
+	}
+
+	writeTableExport(c, format, "headcount_cost_centers_"+date.Format("20060102"), "Headcount", header, rows)
+}
+
+// applyCostCenterRequest copies req onto costCenter
+func applyCostCenterRequest(costCenter *models.CostCenter, req CostCenterRequest) {
+	costCenter.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	costCenter.Name = strings.TrimSpace(req.Name)
+	costCenter.Description = req.Description
+	if req.IsActive != nil {
+		costCenter.IsActive = *req.IsActive
+	}
+}
+
+// costCenterCodeTaken reports, responding with a conflict, whether another cost center has costCenter's code
+func costCenterCodeTaken(c *gin.Context, costCenter models.CostCenter) bool {
+	var existing int64
+	if err := requestDB(c).Model(&models.CostCenter{}).Where("code = ? AND id <> ?", costCenter.Code, costCenter.ID).
+		Count(&existing).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save cost center")
+		return true
+	}
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "A cost center with this code already exists")
+		return true
+	}
+	return false
+}
+
+// setCostCenterAllocation replaces the allocations of the employee or position owner names from the
+// request's start date, with ownerWhere selecting that owner's allocations
+func setCostCenterAllocation(c *gin.Context, owner models.CostCenterAllocation, ownerWhere string, ownerID uint) {
+	var req CostCenterAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
+		return
+	}
+
+	total := 0.0
+	seen := map[uint]bool{}
+	for _, split := range req.Splits {
+		if seen[split.CostCenterID] {
+			utils.RespondError(c, http.StatusBadRequest, "Each cost center can only appear once in a split")
+			return
+		}
+		seen[split.CostCenterID] = true
+		total += split.Percentage
+	}
+	if len(req.Splits) > 0 && math.Abs(total-100) > 0.01 {
+		utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "The percentages add up to %s instead of 100", utils.FormatPercentage(total)))
+		return
+	}
+	if len(seen) > 0 {
+		ids := make([]uint, 0, len(seen))
+		for id := range seen {
+			ids = append(ids, id)
+		}
+		var active int64
+		if err := requestDB(c).Model(&models.CostCenter{}).Where("id IN ? AND is_active = ?", ids, true).Count(&active).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to save cost center allocation")
+			return
+		}
+		if int(active) != len(ids) {
+			utils.RespondError(c, http.StatusBadRequest, "Cost center not found or inactive")
+			return
+		}
+	}
+
+	userID := c.GetUint("user_id")
+	var oldAllocations []models.CostCenterAllocation
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Where(ownerWhere+" AND (end_date IS NULL OR end_date >= ?)", ownerID, startDate).
+			Find(&oldAllocations).Error; err != nil {
+			return err
+		}
+		// Allocations planned from the start date on are replaced, the one running into it ends the day before
+		if err := tx.Where(ownerWhere+" AND start_date >= ?", ownerID, startDate).Delete(&models.CostCenterAllocation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.CostCenterAllocation{}).
+			Where(ownerWhere+" AND start_date < ? AND (end_date IS NULL OR end_date >= ?)", ownerID, startDate, startDate).
+			Update("end_date", startDate.AddDate(0, 0, -1)).Error; err != nil {
+			return err
+		}
+		for _, split := range req.Splits {
+			allocation := owner
+			allocation.CostCenterID = split.CostCenterID
+			allocation.Percentage = split.Percentage
+			allocation.StartDate = startDate
+			allocation.CreatedBy = userID
+			if err := tx.Create(&allocation).Error; err != nil {
+				return err
+			}
+		}
+		return recordAuditLog(tx, models.AuditEntityCostCenter, ownerID, models.AuditActionUpdate, userID, c,
+			oldAllocations, gin.H{"start_date": req.StartDate, "employee_id": owner.EmployeeID, "position_id": owner.PositionID, "splits": req.Splits})
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save cost center allocation")
+		return
+	}
+
+	allocations := []models.CostCenterAllocation{}
+	if err := requestDB(c).Preload("CostCenter").Where(ownerWhere+" AND start_date = ?", ownerID, startDate).
+		Order("cost_center_id").Find(&allocations).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch cost centers")
+		return
+	}
+	c.JSON(http.StatusOK, allocations)
+}
+
+// allocationInEffect reports whether allocation applies on date
+func allocationInEffect(allocation models.CostCenterAllocation, date time.Time) bool {
+	return !allocation.StartDate.After(date) && (allocation.EndDate == nil || !allocation.EndDate.Before(date))
+}
+
+// parseCostCenterExport reads the format and date of a cost center export, responding with an error
+// and returning false if either is invalid
+func parseCostCenterExport(c *gin.Context) (string, time.Time, bool) {
+	format := c.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "csv" {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'csv' or 'xlsx'")
+		return "", time.Time{}, false
+	}
+	date := utils.CompanyToday()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD")
+			return "", time.Time{}, false
+		}
+		date = parsed
+	}
+	return format, date, true
+}
+
+// writeTableExport writes header and rows as a CSV file or an Excel workbook named filename
+func writeTableExport(c *gin.Context, format, filename, sheetName string, header []string, rows [][]string) {
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+		writer := csv.NewWriter(c.Writer)
+		defer writer.Flush()
+		writer.Write(header)
+		writer.WriteAll(rows)
+		return
+	}
+
+	streamDownload(c, filename+".xlsx", utils.XLSXContentType, "Failed to generate export file", func(w io.Writer) error {
+		return utils.ExportTableToExcel(w, sheetName, header, rows)
+	})
+}
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	dashboardUpcomingLeaves      = 10
+	dashboardRecentNotifications = 5
+	unreadNotifications          = "recipient_id = ? AND channel = ? AND read_at IS NULL"
+)
+
+// DashboardResponse is everything the home page shows the current user
+type DashboardResponse struct {
+	Balances            []LeaveBalanceResponse    `json:"balances"`        // One per leave type
+	PendingLeaves       []models.Leave            `json:"pending_leaves"`  // Waiting for approval
+	UpcomingLeaves      []models.Leave            `json:"upcoming_leaves"` // Approved and not yet over, soonest first
+	OnboardingTasks     []models.OnboardingTask   `json:"onboarding_tasks"`
+	ExpiringDocuments   []models.Document         `json:"expiring_documents"`
+	ExpiringCompliance  []models.ComplianceRecord `json:"expiring_compliance"`
+	UnreadNotifications int64                     `json:"unread_notifications" example:"3"`
+	Notifications       []models.Notification     `json:"notifications"` // Latest unread
+}
+
+// GetMyDashboard returns the current user's home page in one call
+// @Summary Get my dashboard
+// @Description Get what the home page shows the current user in one call: the balance of each leave type, their pending and upcoming leaves, their outstanding onboarding tasks (of their own onboarding or assigned to them), their documents and compliance records that have expired or expire within expiring_within_days, and their unread notifications with the latest 5. Balances of leave types using a balance are the current leave year's balance with carry-over; for the others they are what is left of max_days after the leave approved this leave year
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param expiring_within_days query int false "Days ahead to list expiring documents for (default 30, max 365)"
+// @Success 200 {object} DashboardResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/dashboard [get]
+func GetMyDashboard(c *gin.Context) {
+	expiringWithinDays := 30
+	if daysStr := c.Query("expiring_within_days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 0 || days > 365 {
+			utils.RespondError(c, http.StatusBadRequest, "expiring_within_days must be between 0 and 365")
+			return
+		}
+		expiringWithinDays = days
+	}
+
+	employeeID := c.GetUint("user_id")
+	db := requestDB(c)
+	today := utils.CompanyToday()
+	dashboard := DashboardResponse{}
+
+	balances, err := dashboardBalances(c, employeeID, today)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+		return
+	}
+	dashboard.Balances = balances
+
+	queries := []error{
+		db.Preload("LeaveType").Where("employee_id = ? AND status = ?", employeeID, models.StatusPending).
+			Order("start_date").Find(&dashboard.PendingLeaves).Error,
+		db.Preload("LeaveType").Where("employee_id = ? AND status = ? AND end_date >= ?", employeeID, models.StatusApproved, today).
+			Order("start_date").Limit(dashboardUpcomingLeaves).Find(&dashboard.UpcomingLeaves).Error,
+		db.Where("status IN ?", []models.OnboardingTaskStatus{models.OnboardingTaskStatusPending, models.OnboardingTaskStatusInProgress}).
+			Where("assigned_to = ? OR onboarding_process_id IN (?)", employeeID,
+				db.Model(&models.OnboardingProcess{}).Select("id").Where("employee_id = ? AND status IN ?", employeeID,
+					[]models.OnboardingStatus{models.OnboardingStatusPending, models.OnboardingStatusInProgress})).
+			// Tasks without a due date come last; order is quoted the way the database quotes names
+			Order("due_date IS NULL, due_date").Order(clause.OrderByColumn{Column: clause.Column{Name: "order"}}).Order("id").
+			Find(&dashboard.OnboardingTasks).Error,
+		db.Where("employee_id = ? AND expiry_date <= ? AND status <> ?", employeeID, today.AddDate(0, 0, expiringWithinDays),
+			models.DocumentStatusArchived).Order("expiry_date").Find(&dashboard.ExpiringDocuments).Error,
+		db.Preload("Requirement").Where("employee_id = ? AND expiry_date <= ?", employeeID, today.AddDate(0, 0, expiringWithinDays)).
+			Order("expiry_date").Find(&dashboard.ExpiringCompliance).Error,
+		db.Model(&models.Notification{}).Where(unreadNotifications, employeeID, models.NotificationChannelInApp).
+			Count(&dashboard.UnreadNotifications).Error,
+		db.Where(unreadNotifications, employeeID, models.NotificationChannelInApp).
+			Order("created_at DESC, id DESC").Limit(dashboardRecentNotifications).Find(&dashboard.Notifications).Error,
+	}
+	for _, err := range queries {
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// dashboardBalances returns the employee's balance of each leave type
+func dashboardBalances(c *gin.Context, employeeID uint, today time.Time) ([]LeaveBalanceResponse, error) {
+	var leaveTypes []models.LeaveType
+	if err := requestDB(c).Order("display_order, id").Find(&leaveTypes).Error; err != nil {
+		return nil, err
+	}
+	var leaves []models.Leave
+	yearStart := utils.LeaveYearStart(utils.LeaveYearOf(today))
+	if err := requestDB(c).Where("employee_id = ? AND status = ? AND start_date >= ?", employeeID, models.StatusApproved, yearStart).
+		Find(&leaves).Error; err != nil {
+		return nil, err
+	}
+	usedDays := map[uint]int{}
+	for _, leave := range leaves {
+		usedDays[leave.LeaveTypeID] += leave.GetDuration()
+	}
+
+	balances := []LeaveBalanceResponse{}
+	for _, leaveType := range leaveTypes {
+		balance := LeaveBalanceResponse{
+			LeaveTypeID:   leaveType.ID,
+			LeaveTypeName: leaveType.Name,
+			Color:         leaveType.Color,
+			Icon:          leaveType.Icon,
+			MaxDays:       leaveType.MaxDays,
+			UsedDays:      usedDays[leaveType.ID],
+			Balance:       leaveType.MaxDays - usedDays[leaveType.ID],
+		}
+		if leaveType.UsesBalance {
+			current, err := utils.GetCurrentYearLeaveBalance(requestDB(c), employeeID, leaveType.ID)
+			if err != nil {
+				return nil, err
+			}
+			balance.Balance = int(current)
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// TeamLeave is an approved leave of one of the manager's direct reports
+type TeamLeave struct {
+	LeaveID        uint      `json:"leave_id" example:"42"`
+	EmployeeID     uint      `json:"employee_id" example:"7"`
+	EmployeeName   string    `json:"employee_name" example:"Jane Smith"`
+	LeaveTypeName  string    `json:"leave_type_name" example:"Annual"`
+	LeaveTypeColor string    `json:"leave_type_color,omitempty" example:"#4CAF50"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+}
+
+// TeamPendingApprovals counts the direct reports' requests waiting for a decision
+type TeamPendingApprovals struct {
+	Leaves                int64 `json:"leaves" example:"2"`
+	RemoteWork            int64 `json:"remote_work" example:"1"`
+	AttendanceCorrections int64 `json:"attendance_corrections" example:"0"`
+}
+
+// TeamMemberBalance is a direct report's balance of a leave type
+type TeamMemberBalance struct {
+	EmployeeID   uint    `json:"employee_id" example:"7"`
+	EmployeeName string  `json:"employee_name" example:"Jane Smith"`
+	UsedDays     int     `json:"used_days" example:"5"` // Approved days starting this leave year
+	Balance      float64 `json:"balance" example:"11"`
+}
+
+// TeamBalanceSummary sums up the direct reports' balances of a leave type that uses a balance
+type TeamBalanceSummary struct {
+	LeaveTypeID    uint                `json:"leave_type_id" example:"3"`
+	LeaveTypeName  string              `json:"leave_type_name" example:"Annual"`
+	LeaveTypeColor string              `json:"leave_type_color,omitempty" example:"#4CAF50"`
+	TotalBalance   float64             `json:"total_balance" example:"64"`
+	AverageBalance float64             `json:"average_balance" example:"10.7"`
+	Members        []TeamMemberBalance `json:"members"`
+}
+
+// TeamDate is a coming employment date of a direct report
+type TeamDate struct {
+	EmployeeID   uint      `json:"employee_id" example:"7"`
+	EmployeeName string    `json:"employee_name" example:"Jane Smith"`
+	Event        string    `json:"event" example:"probation_end"` // probation_end or contract_end
+	Date         time.Time `json:"date"`
+}
+
+// TeamDashboardResponse is everything the team page shows a manager about their direct reports
+type TeamDashboardResponse struct {
+	TeamSize         int                  `json:"team_size" example:"6"`
+	OffToday         []TeamLeave          `json:"off_today"`
+	OffThisWeek      []TeamLeave          `json:"off_this_week"` // Monday to Sunday, including today's
+	PendingApprovals TeamPendingApprovals `json:"pending_approvals"`
+	Balances         []TeamBalanceSummary `json:"balances"`       // One per leave type using a balance
+	UpcomingDates    []TeamDate           `json:"upcoming_dates"` // Soonest first
+}
+
+// GetTeamDashboard returns the team page of the current manager in one call
+// @Summary Get my team dashboard
+// @Description Get what the team page shows a manager about their direct reports, the active employees whose employment details name them as manager: who is off today and this week (Monday to Sunday), how many of their leave, remote work and attendance correction requests are pending, their balances of each leave type using a balance, and their probation and contract end dates within within_days. Only direct reports are included, for admins too (Manager/Admin only)
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param within_days query int false "Days ahead to list probation and contract end dates for (default 30, max 365)"
+// @Success 200 {object} TeamDashboardResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/manager/dashboard [get]
+func GetTeamDashboard(c *gin.Context) {
+	withinDays := 30
+	if daysStr := c.Query("within_days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 0 || days > 365 {
+			utils.RespondError(c, http.StatusBadRequest, "within_days must be between 0 and 365")
+			return
+		}
+		withinDays = days
+	}
+
+	db := requestDB(c)
+	var team []models.Employee
+	if err := db.Where("id IN (?) AND status <> ?", directReports(db, c.GetUint("user_id")), "inactive").Order("firstname, lastname").Find(&team).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+		return
+	}
+
+	dashboard := TeamDashboardResponse{
+		TeamSize:      len(team),
+		OffToday:      []TeamLeave{},
+		OffThisWeek:   []TeamLeave{},
+		Balances:      []TeamBalanceSummary{},
+		UpcomingDates: []TeamDate{},
+	}
+	if len(team) == 0 {
+		c.JSON(http.StatusOK, dashboard)
+		return
+	}
+	teamIDs := make([]uint, len(team))
+	names := map[uint]string{}
+	for i, employee := range team {
+		teamIDs[i] = employee.ID
+		names[employee.ID] = employee.Firstname + " " + employee.Lastname
+	}
+
+	today := utils.CompanyToday()
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	sunday := monday.AddDate(0, 0, 6)
+	var leaves []models.Leave
+	if err := db.Preload("LeaveType").Where("employee_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?",
+		teamIDs, models.StatusApproved, sunday, monday).Order("start_date, id").Find(&leaves).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+		return
+	}
+	for _, leave := range leaves {
+		teamLeave := TeamLeave{
+			LeaveID:        leave.ID,
+			EmployeeID:     leave.EmployeeID,
+			EmployeeName:   names[leave.EmployeeID],
+			LeaveTypeName:  leave.LeaveType.Name,
+			LeaveTypeColor: leave.LeaveType.Color,
+			StartDate:      leave.StartDate,
+			EndDate:        leave.EndDate,
+		}
+		dashboard.OffThisWeek = append(dashboard.OffThisWeek, teamLeave)
+		if !leave.StartDate.After(today) && !leave.EndDate.Before(today) {
+			dashboard.OffToday = append(dashboard.OffToday, teamLeave)
+		}
+	}
+
+	pending := &dashboard.PendingApprovals
+	queries := []error{
+		db.Model(&models.Leave{}).Where("employee_id IN ? AND status = ?", teamIDs, models.StatusPending).Count(&pending.Leaves).Error,
+		db.Model(&models.RemoteWorkRequest{}).Where("employee_id IN ? AND status = ?", teamIDs, models.RemoteWorkPending).
+			Count(&pending.RemoteWork).Error,
+		db.Model(&models.AttendanceCorrection{}).Where("employee_id IN ? AND status = ?", teamIDs, models.AttendanceCorrectionPending).
+			Count(&pending.AttendanceCorrections).Error,
+	}
+	for _, err := range queries {
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+			return
+		}
+	}
+
+	balances, err := teamBalances(c, team, names, today)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+		return
+	}
+	dashboard.Balances = balances
+
+	var details []models.EmploymentDetails
+	until := today.AddDate(0, 0, withinDays)
+	if err := db.Where("employee_id IN ?", teamIDs).
+		Where("probation_end_date BETWEEN ? AND ? OR end_date BETWEEN ? AND ?", today, until, today, until).
+		Find(&details).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+		return
+	}
+	for _, detail := range details {
+		for event, date := range map[string]*time.Time{"probation_end": detail.ProbationEndDate, "contract_end": detail.EndDate} {
+			if date != nil && !date.Before(today) && !date.After(until) {
+				dashboard.UpcomingDates = append(dashboard.UpcomingDates,
+					TeamDate{EmployeeID: detail.EmployeeID, EmployeeName: names[detail.EmployeeID], Event: event, Date: *date})
+			}
+		}
+	}
+	sort.Slice(dashboard.UpcomingDates, func(i, j int) bool {
+		a, b := dashboard.UpcomingDates[i], dashboard.UpcomingDates[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.EmployeeName+a.Event < b.EmployeeName+b.Event
+	})
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// teamBalances returns the team's balances of each leave type using a balance
+func teamBalances(c *gin.Context, team []models.Employee, names map[uint]string, today time.Time) ([]TeamBalanceSummary, error) {
+	var leaveTypes []models.LeaveType
+	if err := requestDB(c).Where("uses_balance = ?", true).Order("display_order, id").Find(&leaveTypes).Error; err != nil {
+		return nil, err
+	}
+	teamIDs := make([]uint, len(team))
+	for i, employee := range team {
+		teamIDs[i] = employee.ID
+	}
+	var leaves []models.Leave
+	yearStart := utils.LeaveYearStart(utils.LeaveYearOf(today))
+	if err := requestDB(c).Where("employee_id IN ? AND status = ? AND start_date >= ?", teamIDs, models.StatusApproved, yearStart).
+		Find(&leaves).Error; err != nil {
+		return nil, err
+	}
+	usedDays := map[[2]uint]int{}
+	for _, leave := range leaves {
+		usedDays[[2]uint{leave.EmployeeID, leave.LeaveTypeID}] += leave.GetDuration()
+	}
+
+	summaries := []TeamBalanceSummary{}
+	for _, leaveType := range leaveTypes {
+		summary := TeamBalanceSummary{LeaveTypeID: leaveType.ID, LeaveTypeName: leaveType.Name, LeaveTypeColor: leaveType.Color, Members: []TeamMemberBalance{}}
+		for _, employee := range team {
+			balance, err := utils.GetCurrentYearLeaveBalance(requestDB(c), employee.ID, leaveType.ID)
+			if err != nil {
+				return nil, err
+			}
+			balance = utils.RoundLeaveDays(balance)
+			summary.TotalBalance += balance
+			summary.Members = append(summary.Members, TeamMemberBalance{
+				EmployeeID:   employee.ID,
+				EmployeeName: names[employee.ID],
+				UsedDays:     usedDays[[2]uint{employee.ID, leaveType.ID}],
+				Balance:      balance,
+			})
+		}
+		summary.TotalBalance = utils.RoundLeaveDays(summary.TotalBalance)
+		summary.AverageBalance = math.Round(summary.TotalBalance/float64(len(team))*10) / 10
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+const dashboardRecentActivity = 10
+
+// OrganizationPendingRequests counts the organization's requests waiting for a decision
+type OrganizationPendingRequests struct {
+	Leaves                int64 `json:"leaves" example:"7"`
+	RemoteWork            int64 `json:"remote_work" example:"2"`
+	AttendanceCorrections int64 `json:"attendance_corrections" example:"1"`
+	Transfers             int64 `json:"transfers" example:"0"`
+	HeadcountRequests     int64 `json:"headcount_requests" example:"1"`
+	Holidays              int64 `json:"holidays" example:"3"` // Imported public holidays waiting for review
+}
+
+// OrganizationExpiring counts the documents and compliance records expiring from today to the end of the month
+type OrganizationExpiring struct {
+	Documents  int64 `json:"documents" example:"4"`
+	Compliance int64 `json:"compliance" example:"6"`
+}
+
+// OrganizationDashboardResponse is the admin landing page's organization-wide figures
+type OrganizationDashboardResponse struct {
+	Headcount         int64                       `json:"headcount" example:"48"` // Active employees
+	OnLeaveToday      int64                       `json:"on_leave_today" example:"3"`
+	PendingRequests   OrganizationPendingRequests `json:"pending_requests"`
+	ExpiringThisMonth OrganizationExpiring        `json:"expiring_this_month"`
+	RecentActivity    []models.AuditLog           `json:"recent_activity"` // Latest audit log entries by the organization's users
+}
+
+// GetOrganizationDashboard returns the admin landing page in one call
+// @Summary Get organization dashboard
+// @Description Get the organization-wide figures of the admin landing page in one call: active headcount, employees on approved leave today, requests waiting for a decision, documents and compliance records expiring from today to the end of the month, and the latest 10 audit log entries by the organization's users (Admin only)
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} OrganizationDashboardResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/dashboard [get]
+func GetOrganizationDashboard(c *gin.Context) {
+	db := requestDB(c)
+	today := utils.CompanyToday()
+	monthEnd := time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location())
+	dashboard := OrganizationDashboardResponse{}
+	pending := &dashboard.PendingRequests
+	expiring := &dashboard.ExpiringThisMonth
+
+	queries := []error{
+		db.Model(&models.Employee{}).Where("status = ?", "active").Count(&dashboard.Headcount).Error,
+		db.Model(&models.Leave{}).Where("status = ? AND start_date <= ? AND end_date >= ?", models.StatusApproved, today, today).
+			Distinct("employee_id").Count(&dashboard.OnLeaveToday).Error,
+		db.Model(&models.Leave{}).Where("status = ?", models.StatusPending).Count(&pending.Leaves).Error,
+		db.Model(&models.RemoteWorkRequest{}).Where("status = ?", models.RemoteWorkPending).Count(&pending.RemoteWork).Error,
+		db.Model(&models.AttendanceCorrection{}).Where("status = ?", models.AttendanceCorrectionPending).
+			Count(&pending.AttendanceCorrections).Error,
+		db.Model(&models.TransferRequest{}).Where("status = ?", models.TransferStatusPending).Count(&pending.Transfers).Error,
+		db.Model(&models.HeadcountRequest{}).Where("status = ?", models.HeadcountRequestPending).Count(&pending.HeadcountRequests).Error,
+		db.Model(&models.PublicHoliday{}).Where("status = ?", models.HolidayPending).Count(&pending.Holidays).Error,
+		db.Model(&models.Document{}).Where("expiry_date BETWEEN ? AND ? AND status <> ?", today, monthEnd, models.DocumentStatusArchived).
+			Count(&expiring.Documents).Error,
+		db.Model(&models.ComplianceRecord{}).Where("expiry_date BETWEEN ? AND ?", today, monthEnd).Count(&expiring.Compliance).Error,
+		// Audit logs carry no organization, so they are scoped by who performed them
+		db.Preload("Performer").Where("performed_by IN (?)", db.Unscoped().Model(&models.Employee{}).Select("id")).
+			Order("created_at DESC, id DESC").Limit(dashboardRecentActivity).Find(&dashboard.RecentActivity).Error,
+	}
+	for _, err := range queries {
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to load dashboard")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
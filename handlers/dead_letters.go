@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var deadLetterListFields = ListFields{
+	Filters: map[string]string{"kind": "kind", "permanent": "permanent"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "updated_at": "updated_at", "attempts": "attempts", "resolved_at": "resolved_at",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetDeadLetters lists emails, text messages and webhook deliveries that were given up
+// @Summary Get dead letters
+// @Description List notification emails and text messages, and webhook deliveries, that were given up, because they failed permanently or used all their attempts, with the message and the last error. Only those not yet re-sent or delivered are listed unless resolved is given (Admin only)
+// @Tags Admin - Dead Letters
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "Kind (email, sms, webhook)"
+// @Param permanent query bool false "Only permanent failures (true) or only those that used all their attempts (false)"
+// @Param resolved query bool false "List the dead letters re-sent or delivered since (true), or every dead letter (all). Defaults to false"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, updated_at, attempts, resolved_at). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.DeadLetter}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/dead-letters [get]
+func GetDeadLetters(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Notification").Preload("WebhookDelivery")
+	switch c.DefaultQuery("resolved", "false") {
+	case "false":
+		query = query.Where("resolved_at IS NULL")
+	case "true":
+		query = query.Where("resolved_at IS NOT NULL")
+	case "all":
+	default:
+		utils.RespondError(c, http.StatusBadRequest, "resolved must be true, false or all")
+		return
+	}
+	query, ok = applyListQuery(c, query, deadLetterListFields)
+	if !ok {
+		return
+	}
+
+	var letters []models.DeadLetter
+	response, err := paginate(query, pagination, &letters)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch dead letters")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDeadLetter gets a dead letter with its message
+// @Summary Get dead letter
+// @Description Get an email or webhook delivery that was given up, with the message and the last error (Admin only)
+// @Tags Admin - Dead Letters
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Dead letter ID"
+// @Success 200 {object} models.DeadLetter
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/admin/dead-letters/{id} [get]
+func GetDeadLetter(c *gin.Context) {
+	letter, ok := findDeadLetter(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, letter)
+}
+
+// ResendDeadLetter re-sends the message of a dead letter
+// @Summary Re-send dead letter
+// @Description Put the email, text message or webhook delivery back in the delivery queue with all its attempts and send it now. Emails and text messages go to the recipient's current address or mobile number, so a bounced message can be re-sent after correcting it. The dead letter is closed; if the message is given up again, a new dead letter is opened. Returns the dead letter with the message after the attempt (Admin only)
+// @Tags Admin - Dead Letters
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Dead letter ID"
+// @Success 200 {object} models.DeadLetter
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The dead letter has already been re-sent or delivered"
+// @Router /api/admin/dead-letters/{id}/resend [post]
+func ResendDeadLetter(c *gin.Context) {
+	letter, ok := findDeadLetter(c)
+	if !ok {
+		return
+	}
+	old := letter
+
+	userID := c.GetUint("user_id")
+	err := utils.ResendDeadLetter(&letter, userID)
+	switch {
+	case errors.Is(err, utils.ErrDeadLetterResolved):
+		utils.RespondError(c, http.StatusConflict, "Dead letter has already been re-sent or delivered")
+		return
+	case errors.Is(err, utils.ErrEmailNotConfigured):
+		utils.RespondError(c, http.StatusBadRequest, "Email is not configured")
+		return
+	case errors.Is(err, utils.ErrNoEmailAddress):
+		utils.RespondError(c, http.StatusBadRequest, "Recipient has no email address")
+		return
+	case errors.Is(err, utils.ErrSMSNotConfigured):
+		utils.RespondError(c, http.StatusBadRequest, "SMS is not configured")
+		return
+	case errors.Is(err, utils.ErrNoMobileNumber):
+		utils.RespondError(c, http.StatusBadRequest, "Recipient has no mobile number")
+		return
+	case errors.Is(err, utils.ErrSubscriptionInactive):
+		utils.RespondError(c, http.StatusBadRequest, "Webhook subscription has been deleted or deactivated")
+		return
+	case errors.Is(err, utils.ErrDeadLetterMessageGone):
+		utils.RespondError(c, http.StatusNotFound, "Message of the dead letter no longer exists")
+		return
+	}
+	// Any other error is the attempt's, which is recorded on the message
+	createAuditLog(models.AuditEntityDeadLetter, letter.ID, models.AuditActionResend, userID, c, old, letter)
+
+	if reloaded, ok := findDeadLetter(c); ok {
+		c.JSON(http.StatusOK, reloaded)
+	}
+}
+
+func findDeadLetter(c *gin.Context) (models.DeadLetter, bool) {
+	letterID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var letter models.DeadLetter
+	if err := requestDB(c).Preload("Notification").Preload("WebhookDelivery").First(&letter, letterID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Dead letter not found")
+		return letter, false
+	}
+	return letter, true
+}
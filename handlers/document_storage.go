@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStorageUsageLimit = 50
+	maxStorageUsageLimit     = 500
+)
+
+// DocumentStorageReport is the document storage the organization uses, and the employees using the most
+type DocumentStorageReport struct {
+	TotalUsedBytes     int64                  `json:"total_used_bytes" example:"1073741824"`
+	TotalQuotaBytes    int64                  `json:"total_quota_bytes" example:"0"`           // 0 for no limit
+	EmployeeQuotaBytes int64                  `json:"employee_quota_bytes" example:"52428800"` // 0 for no limit
+	DocumentCount      int64                  `json:"document_count" example:"1240"`
+	Employees          []EmployeeStorageUsage `json:"employees"`
+}
+
+// EmployeeStorageUsage is the document storage an employee uses
+type EmployeeStorageUsage struct {
+	EmployeeID    uint    `json:"employee_id" example:"12"`
+	EmployeeName  string  `json:"employee_name" example:"Jane Banda"`
+	Department    string  `json:"department" example:"Finance"`
+	DocumentCount int64   `json:"document_count" example:"18"`
+	UsedBytes     int64   `json:"used_bytes" example:"48234496"`
+	QuotaPercent  float64 `json:"quota_percent,omitempty" example:"92"` // Share of the employee quota used, when there is one
+}
+
+// GetDocumentStorageUsage reports the document storage used against the quotas
+// @Summary Get document storage usage
+// @Description Report the bytes of documents stored for the organization and for each employee, largest first, with the per-employee and organization quotas set through the employee_document_quota_mb and document_storage_quota_mb settings (Admin only)
+// @Tags Core HR - Documents
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of employees to list (default 50, max 500)"
+// @Success 200 {object} DocumentStorageReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/documents/storage-usage [get]
+func GetDocumentStorageUsage(c *gin.Context) {
+	limit := defaultStorageUsageLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = min(parsed, maxStorageUsageLimit)
+	}
+
+	report := DocumentStorageReport{Employees: []EmployeeStorageUsage{}}
+	report.EmployeeQuotaBytes, report.TotalQuotaBytes = utils.DocumentQuotaBytes()
+
+	db := requestDB(c)
+	var err error
+	if report.TotalUsedBytes, err = utils.DocumentStorageUsed(db, 0); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch storage usage")
+		return
+	}
+	if err := db.Model(&models.Document{}).Count(&report.DocumentCount).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch storage usage")
+		return
+	}
+
+	err = db.Model(&models.Document{}).
+		Select("documents.employee_id, " + database.ConcatSQL("employees.firstname", "' '", "employees.lastname") + " AS employee_name, " +
+			"employees.department, COUNT(*) AS document_count, COALESCE(SUM(documents.file_size), 0) AS used_bytes").
+		Joins("JOIN employees ON employees.id = documents.employee_id").
+		Group("documents.employee_id, employees.firstname, employees.lastname, employees.department").
+		Order("used_bytes DESC, documents.employee_id").Limit(limit).
+		Scan(&report.Employees).Error
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch storage usage")
+		return
+	}
+	if report.EmployeeQuotaBytes > 0 {
+		for i := range report.Employees {
+			report.Employees[i].QuotaPercent = float64(report.Employees[i].UsedBytes) * 100 / float64(report.EmployeeQuotaBytes)
+		}
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// respondDocumentQuotaError answers an upload that failed the document storage quota check, with 507
+// and the usage in details when a quota would be exceeded
+func respondDocumentQuotaError(c *gin.Context, usage utils.StorageUsage, err error) {
+	if !errors.Is(err, utils.ErrStorageQuotaExceeded) {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to check document storage quota")
+		return
+	}
+	message := "The employee's document storage quota would be exceeded"
+	if usage.ExceededQuota == utils.StorageQuotaOrganization {
+		message = "The organization's document storage quota would be exceeded"
+	}
+	utils.RespondErrorCode(c, http.StatusInsufficientStorage, utils.CodeStorageQuotaExceeded, message, usage)
+}
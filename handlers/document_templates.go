@@ -0,0 +1,402 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DocumentTemplateRequest represents data for creating or replacing a document template
+type DocumentTemplateRequest struct {
+	Name         string              `json:"name" binding:"required,max=100" example:"Permanent contract"`
+	DocumentType models.DocumentType `json:"document_type" binding:"required,oneof=contract offer_letter" example:"contract"`
+	Title        string              `json:"title" binding:"required,max=200" example:"Employment contract - {{employee.full_name}}"`
+	Body         string              `json:"body" binding:"required" example:"{{organization.name}} employs {{employee.full_name}} as {{position.title}} from {{employment.start_date}}."`
+	IsActive     *bool               `json:"is_active,omitempty" example:"true"` // Defaults to true
+}
+
+// GenerateDocumentRequest chooses the template a document is generated from
+type GenerateDocumentRequest struct {
+	TemplateID   uint `json:"template_id" binding:"required" example:"1"`
+	AllowMissing bool `json:"allow_missing,omitempty"` // Generate even if some placeholders have no value, leaving them blank
+}
+
+// GetDocumentPlaceholders lists the placeholders document templates can use
+// @Summary Get document template placeholders
+// @Description List the placeholders document templates can use, written as {{key}} (Admin only)
+// @Tags Admin - Document Templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} utils.DocumentPlaceholder
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/admin/document-templates/placeholders [get]
+func GetDocumentPlaceholders(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.DocumentPlaceholders)
+}
+
+// GetDocumentTemplates lists the organization's document templates
+// @Summary Get document templates
+// @Description List the templates employment contracts and offer letters are generated from (Admin only)
+// @Tags Admin - Document Templates
+// @Produce json
+// @Security BearerAuth
+// @Param document_type query string false "Filter by document type (contract, offer_letter)"
+// @Success 200 {array} models.DocumentTemplate
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/document-templates [get]
+func GetDocumentTemplates(c *gin.Context) {
+	query := requestDB(c)
+	if documentType := c.Query("document_type"); documentType != "" {
+		query = query.Where("document_type = ?", documentType)
+	}
+	var templates []models.DocumentTemplate
+	if err := query.Order("name").Find(&templates).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch document templates")
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateDocumentTemplate adds a document template
+// @Summary Create a document template
+// @Description Add a template for employment contracts or offer letters. The title and body may use the placeholders listed by /api/admin/document-templates/placeholders, written as {{key}}; unknown placeholders are rejected (Admin only)
+// @Tags Admin - Document Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DocumentTemplateRequest true "Document template"
+// @Success 201 {object} models.DocumentTemplate
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/document-templates [post]
+func CreateDocumentTemplate(c *gin.Context) {
+	var req DocumentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if !checkDocumentPlaceholders(c, req) {
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	template := models.DocumentTemplate{
+		Name:         strings.TrimSpace(req.Name),
+		DocumentType: req.DocumentType,
+		Title:        strings.TrimSpace(req.Title),
+		Body:         req.Body,
+		IsActive:     req.IsActive == nil || *req.IsActive,
+		CreatedBy:    &userID,
+	}
+	if err := requestDB(c).Create(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create document template")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTemplate, template.ID, models.AuditActionCreate, userID, c, nil, template)
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateDocumentTemplate replaces a document template
+// @Summary Update a document template
+// @Description Replace a document template. Documents already generated from it are not changed (Admin only)
+// @Tags Admin - Document Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Document template ID"
+// @Param request body DocumentTemplateRequest true "Document template"
+// @Success 200 {object} models.DocumentTemplate
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/document-templates/{id} [put]
+func UpdateDocumentTemplate(c *gin.Context) {
+	templateID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req DocumentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var template models.DocumentTemplate
+	if err := requestDB(c).First(&template, templateID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Document template not found")
+		return
+	}
+	if !checkDocumentPlaceholders(c, req) {
+		return
+	}
+	oldTemplate := template
+
+	template.Name = strings.TrimSpace(req.Name)
+	template.DocumentType = req.DocumentType
+	template.Title = strings.TrimSpace(req.Title)
+	template.Body = req.Body
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Save(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update document template")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTemplate, template.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldTemplate, template)
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteDocumentTemplate deletes a document template
+// @Summary Delete a document template
+// @Description Delete a document template. Documents already generated from it are kept (Admin only)
+// @Tags Admin - Document Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Document template ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/document-templates/{id} [delete]
+func DeleteDocumentTemplate(c *gin.Context) {
+	templateID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var template models.DocumentTemplate
+	if err := requestDB(c).First(&template, templateID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Document template not found")
+		return
+	}
+	if err := requestDB(c).Delete(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete document template")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTemplate, template.ID, models.AuditActionDelete, c.GetUint("user_id"), c, template, nil)
+	c.JSON(http.StatusOK, gin.H{"message": "Document template deleted successfully"})
+}
+
+// GenerateDocument generates a contract or offer letter for an employee from a template
+// @Summary Generate a document from a template
+// @Description Fill in a document template from the employee's records, employment details and current position, render it as a PDF and store it as one of the employee's documents pending their signature. Placeholders the records have no value for are rejected unless allow_missing is set (Admin only)
+// @Tags Core HR - Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body GenerateDocumentRequest true "Template"
+// @Success 201 {object} models.Document
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 507 {object} ErrorResponse "Document storage quota exceeded, with the usage in details"
+// @Router /api/employees/{id}/documents/generate [post]
+func GenerateDocument(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req GenerateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var template models.DocumentTemplate
+	if err := requestDB(c).Where("id = ? AND is_active = ?", req.TemplateID, true).First(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Document template not found")
+		return
+	}
+	values, err := utils.DocumentTemplateValues(requestDB(c), uint(employeeID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate document")
+		return
+	}
+
+	title, missingInTitle := utils.RenderDocumentTemplate(template.Title, values)
+	body, missing := utils.RenderDocumentTemplate(template.Body, values)
+	missing = mergeMissingPlaceholders(missingInTitle, missing)
+	if len(missing) > 0 && !req.AllowMissing {
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeBadRequest,
+			i18n.T(utils.RequestLanguage(c), "The employee's records have no value for: %s", strings.Join(missing, ", ")),
+			gin.H{"missing": missing})
+		return
+	}
+	title = strings.TrimSpace(title)
+
+	content, err := utils.DocumentTemplatePDF(title, body)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate document")
+		return
+	}
+	fileName := generatedDocumentFileName(title)
+	secureFilename, err := utils.GenerateSecureFileName(fileName, uint(employeeID))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate filename")
+		return
+	}
+	relativePath, fileSize, err := utils.SaveFile(bytes.NewReader(content), secureFilename, uint(employeeID))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save file: "+err.Error())
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	mimeType := "application/pdf"
+	issueDate := utils.CompanyToday()
+	// The text is known, so the document is searchable by content without extracting it from the PDF
+	contentText := utils.CleanContentText(title + "\n\n" + body)
+	extractedAt := time.Now()
+	document := models.Document{
+		EmployeeID:   uint(employeeID),
+		DocumentType: template.DocumentType,
+		Title:        title,
+		FileName:     fileName,
+		FilePath:     relativePath,
+		FileSize:     &fileSize,
+		MimeType:     &mimeType,
+		IssueDate:    &issueDate,
+		Status:       models.DocumentStatusPendingSignature,
+		UploadedBy:   &userID,
+		TemplateID:   &template.ID,
+
+		ContentText:        &contentText,
+		ContentStatus:      models.DocumentContentExtracted,
+		ContentExtractedAt: &extractedAt,
+	}
+	// The document record and its audit entry are saved together; the stored file is removed if either fails
+	var usage utils.StorageUsage
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		var err error
+		if usage, err = utils.CheckDocumentQuota(tx, uint(employeeID), fileSize); err != nil {
+			return err
+		}
+		if err := tx.Create(&document).Error; err != nil {
+			return err
+		}
+		return recordAuditLog(tx, models.AuditEntityDocument, document.ID, models.AuditActionCreate, userID, c, nil, document)
+	})
+	if err != nil {
+		utils.DeleteFile(relativePath)
+		if errors.Is(err, utils.ErrStorageQuotaExceeded) {
+			respondDocumentQuotaError(c, usage, err)
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create document record")
+		return
+	}
+
+	requestDB(c).Preload("Uploader").First(&document, document.ID)
+	c.JSON(http.StatusCreated, document)
+}
+
+// SignDocument records that an employee has signed a document generated for them
+// @Summary Sign a generated document
+// @Description Sign a contract or offer letter generated for you, which makes it active. Only the employee the document is for can sign it
+// @Tags Core HR - Documents
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param doc_id path int true "Document ID"
+// @Success 200 {object} models.Document
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/documents/{doc_id}/sign [post]
+func SignDocument(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	documentID, _ := strconv.ParseUint(c.Param("doc_id"), 10, 32)
+	userID := c.GetUint("user_id")
+	if uint(employeeID) != userID {
+		utils.RespondError(c, http.StatusForbidden, "Only the employee a document is for can sign it")
+		return
+	}
+
+	var document models.Document
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", documentID, employeeID).First(&document).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Document not found")
+		return
+	}
+	if document.Status != models.DocumentStatusPendingSignature {
+		utils.RespondError(c, http.StatusBadRequest, "Document is not waiting for a signature")
+		return
+	}
+
+	now := time.Now()
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Model(&document).Updates(map[string]interface{}{
+			"status": models.DocumentStatusActive, "signed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		return recordAuditLog(tx, models.AuditEntityDocument, document.ID, models.AuditActionApprove, userID, c,
+			gin.H{"status": models.DocumentStatusPendingSignature}, gin.H{"status": models.DocumentStatusActive, "signed_at": now})
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to sign document")
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+// checkDocumentPlaceholders rejects a template whose title or body uses placeholders that do not
+// exist, responding with an error and returning false
+func checkDocumentPlaceholders(c *gin.Context, req DocumentTemplateRequest) bool {
+	unknown := utils.UnknownDocumentPlaceholders(req.Title + "\n" + req.Body)
+	if len(unknown) == 0 {
+		return true
+	}
+	utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Unknown placeholders: %s", strings.Join(unknown, ", ")))
+	return false
+}
+
+// mergeMissingPlaceholders joins two lists of placeholders, sorted and without repeating any
+func mergeMissingPlaceholders(first, second []string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, key := range append(first, second...) {
+		if !seen[key] {
+			seen[key] = true
+			merged = append(merged, key)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// generatedDocumentFileName is the file name a generated document is downloaded as, taken from its title
+func generatedDocumentFileName(title string) string {
+	name := strings.Trim(unsafeFileNameCharacters.ReplaceAllString(title, "_"), "_.")
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	if name == "" {
+		name = "document"
+	}
+	return name + ".pdf"
+}
@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EducationRequest represents data for creating or updating an education record
+type EducationRequest struct {
+	Institution        string  `json:"institution" binding:"required" example:"University of Zambia"`
+	Qualification      string  `json:"qualification" binding:"required" example:"Bachelor of Science"`
+	QualificationLevel *string `json:"qualification_level,omitempty" example:"bachelor"`
+	FieldOfStudy       *string `json:"field_of_study,omitempty" example:"Computer Science"`
+	StartDate          *string `json:"start_date,omitempty" example:"2015-01-15"` // YYYY-MM-DD
+	EndDate            *string `json:"end_date,omitempty" example:"2019-12-10"`   // YYYY-MM-DD
+	Grade              *string `json:"grade,omitempty" example:"Merit"`
+	DocumentID         *uint   `json:"document_id,omitempty" example:"3"` // Uploaded certificate or transcript
+}
+
+// VerifyEducationRequest represents HR's verification decision on an education record
+type VerifyEducationRequest struct {
+	Status models.VerificationStatus `json:"status" binding:"required,oneof=verified rejected" example:"verified"`
+	Notes  *string                   `json:"notes,omitempty" example:"Confirmed with institution"`
+}
+
+// GetEducation retrieves education records for an employee
+// @Summary Get employee education records
+// @Description Get all education and qualification records for an employee. Employees can only view their own records
+// @Tags Core HR - Education
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} models.Education
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/{id}/education [get]
+func GetEducation(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var records []models.Education
+	requestDB(c).Preload("Document").Preload("Verifier").
+		Where("employee_id = ?", employeeID).Order("end_date DESC").Find(&records)
+
+	c.JSON(http.StatusOK, records)
+}
+
+// CreateEducation adds an education record for an employee
+// @Summary Create education record
+// @Description Add an education or qualification record for an employee. New records start with pending verification
+// @Tags Core HR - Education
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body EducationRequest true "Education record"
+// @Success 201 {object} models.Education
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/education [post]
+func CreateEducation(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var req EducationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdBy := userID.(uint)
+	record := models.Education{
+		EmployeeID:         uint(employeeID),
+		VerificationStatus: models.VerificationStatusPending,
+		CreatedBy:          &createdBy,
+	}
+	if status, errMsg := applyEducationRequest(&record, req); errMsg != "" {
+		utils.RespondError(c, status, errMsg)
+		return
+	}
+
+	if err := requestDB(c).Create(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create education record")
+		return
+	}
+
+	createAuditLog(models.AuditEntityEducation, record.ID, models.AuditActionCreate, createdBy, c, nil, record)
+
+	c.JSON(http.StatusCreated, record)
+}
+
+// UpdateEducation updates an education record
+// @Summary Update education record
+// @Description Update an education record. Changing a verified record resets it to pending verification
+// @Tags Core HR - Education
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param education_id path int true "Education record ID"
+// @Param request body EducationRequest true "Education record"
+// @Success 200 {object} models.Education
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/education/{education_id} [put]
+func UpdateEducation(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	educationID, _ := strconv.ParseUint(c.Param("education_id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var req EducationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var record models.Education
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", educationID, employeeID).First(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Education record not found")
+		return
+	}
+
+	oldValues := record
+	if status, errMsg := applyEducationRequest(&record, req); errMsg != "" {
+		utils.RespondError(c, status, errMsg)
+		return
+	}
+
+	// Edited details need to be verified again
+	record.VerificationStatus = models.VerificationStatusPending
+	record.VerifiedBy = nil
+	record.VerifiedAt = nil
+	record.VerificationNotes = nil
+
+	if err := requestDB(c).Save(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update education record")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityEducation, record.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, record)
+
+	c.JSON(http.StatusOK, record)
+}
+
+// DeleteEducation deletes an education record
+// @Summary Delete education record
+// @Description Delete an education record for an employee
+// @Tags Core HR - Education
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param education_id path int true "Education record ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/education/{education_id} [delete]
+func DeleteEducation(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	educationID, _ := strconv.ParseUint(c.Param("education_id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var record models.Education
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", educationID, employeeID).First(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Education record not found")
+		return
+	}
+
+	oldValues := record
+	if err := requestDB(c).Delete(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete education record")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityEducation, record.ID, models.AuditActionDelete, userID.(uint), c, oldValues, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Education record deleted successfully"})
+}
+
+// VerifyEducation records HR's verification decision on an education record
+// @Summary Verify education record
+// @Description Mark an education record as verified or rejected (Manager/Admin only)
+// @Tags Core HR - Education
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param education_id path int true "Education record ID"
+// @Param request body VerifyEducationRequest true "Verification decision"
+// @Success 200 {object} models.Education
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/education/{education_id}/verify [put]
+func VerifyEducation(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	educationID, _ := strconv.ParseUint(c.Param("education_id"), 10, 32)
+
+	var req VerifyEducationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var record models.Education
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", educationID, employeeID).First(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Education record not found")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	verifierID := userID.(uint)
+	if verifierID == record.EmployeeID {
+		utils.RespondError(c, http.StatusForbidden, "You cannot verify your own education records")
+		return
+	}
+
+	oldValues := record
+	now := time.Now()
+	record.VerificationStatus = req.Status
+	record.VerifiedBy = &verifierID
+	record.VerifiedAt = &now
+	record.VerificationNotes = req.Notes
+
+	if err := requestDB(c).Save(&record).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to verify education record")
+		return
+	}
+
+	action := models.AuditActionReject
+	if req.Status == models.VerificationStatusVerified {
+		action = models.AuditActionApprove
+	}
+	createAuditLog(models.AuditEntityEducation, record.ID, action, verifierID, c, oldValues, record)
+
+	c.JSON(http.StatusOK, record)
+}
+
+// educationListFields are the filters and sort keys accepted by the education record list
+var educationListFields = ListFields{
+	Filters: map[string]string{"status": "verification_status", "qualification_level": "qualification_level"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "end_date": "end_date", "status": "verification_status",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetEducationRecords lists education records across employees for HR review
+// @Summary List education records
+// @Description List education records across all employees, optionally filtered by verification status and qualification level (Manager/Admin only)
+// @Tags Core HR - Education
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Verification status filter (pending, verified, rejected)"
+// @Param qualification_level query string false "Qualification level filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, end_date, status). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Education}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/education [get]
+func GetEducationRecords(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").Preload("Document").Preload("Verifier")
+
+	query, ok = applyListQuery(c, query, educationListFields)
+	if !ok {
+		return
+	}
+
+	var records []models.Education
+	response, err := paginate(query, pagination, &records)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch education records")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// applyEducationRequest copies request fields onto the record, returning an HTTP status and message on invalid input
+func applyEducationRequest(record *models.Education, req EducationRequest) (int, string) {
+	record.Institution = req.Institution
+	record.Qualification = req.Qualification
+	record.QualificationLevel = req.QualificationLevel
+	record.FieldOfStudy = req.FieldOfStudy
+	record.Grade = req.Grade
+	record.StartDate = nil
+	record.EndDate = nil
+
+	if req.StartDate != nil && *req.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			return http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD"
+		}
+		record.StartDate = &parsed
+	}
+	if req.EndDate != nil && *req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			return http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD"
+		}
+		record.EndDate = &parsed
+	}
+	if record.StartDate != nil && record.EndDate != nil && record.EndDate.Before(*record.StartDate) {
+		return http.StatusBadRequest, "end_date cannot be before start_date"
+	}
+
+	if req.DocumentID != nil {
+		var document models.Document
+		if err := database.DB.Where("id = ? AND employee_id = ?", *req.DocumentID, record.EmployeeID).First(&document).Error; err != nil {
+			return http.StatusNotFound, "Document not found for this employee"
+		}
+	}
+	record.DocumentID = req.DocumentID
+
+	return 0, ""
+}
+
+// canAccessEmployeeRecords reports whether the current user may manage the given employee's records:
+// employees can only access their own, managers and admins can access anyone's
+func canAccessEmployeeRecords(c *gin.Context, employeeID uint) bool {
+	user := getCurrentUser(c)
+	if user == nil {
+		return false
+	}
+	return user.ID == employeeID || user.Role == models.RoleManager || user.Role == models.RoleAdmin
+}
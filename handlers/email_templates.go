@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailTemplateRequest represents data for creating or replacing an email template
+type EmailTemplateRequest struct {
+	Category models.NotificationCategory `json:"category" binding:"required,oneof=compliance_reminder compliance_expired grievance_assigned grievance_updated grievance_sla_breach kudos_received leave_approved leave_rejected password_changed leave_submitted" example:"compliance_reminder"`
+	Language string                      `json:"language" binding:"required,oneof=en fr pt" example:"en"`
+	Subject  string                      `json:"subject" binding:"required,max=200" example:"Reminder: your {{requirement.name}} expires on {{compliance.expiry_date}}"`
+	TextBody string                      `json:"text_body" binding:"required" example:"Hello {{recipient.firstname}}, {{requirement.name}} expires in {{compliance.days_left}} day(s). Please send HR your renewed certificate."`
+	HTMLBody *string                     `json:"html_body,omitempty" example:"<p>Hello {{recipient.firstname}},</p><p><b>{{requirement.name}}</b> expires in {{compliance.days_left}} day(s).</p>"`
+	IsActive *bool                       `json:"is_active,omitempty" example:"true"` // Defaults to true
+}
+
+// EmailTemplatePreviewRequest overrides the example values a template is previewed or test-sent with
+type EmailTemplatePreviewRequest struct {
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// EmailTemplateTestRequest chooses where a test email is sent
+type EmailTemplateTestRequest struct {
+	To     string            `json:"to,omitempty" binding:"omitempty,email" example:"hr@example.com"` // Defaults to the admin's own address
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// EmailTemplateTestResponse is the test email that was sent
+type EmailTemplateTestResponse struct {
+	To string `json:"to" example:"hr@example.com"`
+	utils.RenderedEmail
+}
+
+// GetEmailPlaceholders lists the placeholders email templates can use
+// @Summary Get email template placeholders
+// @Description List, for each notification category, the placeholders its email templates can use, written as {{key}}, with the example values previews are filled in with (Admin only)
+// @Tags Admin - Email Templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} utils.EmailCategoryPlaceholders
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/admin/email-templates/placeholders [get]
+func GetEmailPlaceholders(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.EmailPlaceholders())
+}
+
+// GetEmailTemplates lists the organization's email templates
+// @Summary Get email templates
+// @Description List the templates that replace the built-in wording of notification emails (Admin only)
+// @Tags Admin - Email Templates
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Filter by notification category"
+// @Param language query string false "Filter by language (en, fr, pt)"
+// @Success 200 {array} models.EmailTemplate
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/email-templates [get]
+func GetEmailTemplates(c *gin.Context) {
+	query := requestDB(c)
+	if category := c.Query("category"); category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if language := c.Query("language"); language != "" {
+		query = query.Where("language = ?", language)
+	}
+	var templates []models.EmailTemplate
+	if err := query.Order("category, language").Find(&templates).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch email templates")
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateEmailTemplate adds an email template
+// @Summary Create an email template
+// @Description Replace the built-in wording of a notification category's emails in one language. The subject and bodies may use the placeholders listed for the category by /api/admin/email-templates/placeholders, written as {{key}}; unknown placeholders are rejected. Each category has at most one template per language, and recipients whose language has none get the built-in email (Admin only)
+// @Tags Admin - Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body EmailTemplateRequest true "Email template"
+// @Success 201 {object} models.EmailTemplate
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/email-templates [post]
+func CreateEmailTemplate(c *gin.Context) {
+	var req EmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if !checkEmailTemplate(c, req, 0) {
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	template := models.EmailTemplate{
+		Category:  req.Category,
+		Language:  req.Language,
+		Subject:   strings.TrimSpace(req.Subject),
+		TextBody:  req.TextBody,
+		HTMLBody:  emailTemplateHTML(req.HTMLBody),
+		IsActive:  req.IsActive == nil || *req.IsActive,
+		CreatedBy: &userID,
+	}
+	if err := requestDB(c).Create(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create email template")
+		return
+	}
+
+	createAuditLog(models.AuditEntityEmailTemplate, template.ID, models.AuditActionCreate, userID, c, nil, template)
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateEmailTemplate replaces an email template
+// @Summary Update an email template
+// @Description Replace an email template. Emails already sent or queued keep their wording (Admin only)
+// @Tags Admin - Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Email template ID"
+// @Param request body EmailTemplateRequest true "Email template"
+// @Success 200 {object} models.EmailTemplate
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/email-templates/{id} [put]
+func UpdateEmailTemplate(c *gin.Context) {
+	var req EmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	template, ok := findEmailTemplate(c)
+	if !ok {
+		return
+	}
+	if !checkEmailTemplate(c, req, template.ID) {
+		return
+	}
+	oldTemplate := template
+
+	template.Category = req.Category
+	template.Language = req.Language
+	template.Subject = strings.TrimSpace(req.Subject)
+	template.TextBody = req.TextBody
+	template.HTMLBody = emailTemplateHTML(req.HTMLBody)
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Save(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update email template")
+		return
+	}
+
+	createAuditLog(models.AuditEntityEmailTemplate, template.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldTemplate, template)
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteEmailTemplate deletes an email template
+// @Summary Delete an email template
+// @Description Delete an email template, so the category's emails in its language go back to the built-in wording (Admin only)
+// @Tags Admin - Email Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Email template ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/email-templates/{id} [delete]
+func DeleteEmailTemplate(c *gin.Context) {
+	template, ok := findEmailTemplate(c)
+	if !ok {
+		return
+	}
+	if err := requestDB(c).Delete(&template).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete email template")
+		return
+	}
+
+	createAuditLog(models.AuditEntityEmailTemplate, template.ID, models.AuditActionDelete, c.GetUint("user_id"), c, template, nil)
+	c.JSON(http.StatusOK, gin.H{"message": "Email template deleted successfully"})
+}
+
+// PreviewEmailTemplate fills in an email template without sending it
+// @Summary Preview an email template
+// @Description Fill in an email template with the example values of its category's placeholders, or the values given, and return the subject and bodies as they would be sent. Placeholders left blank are listed under missing (Admin only)
+// @Tags Admin - Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Email template ID"
+// @Param request body EmailTemplatePreviewRequest false "Values to use instead of the examples"
+// @Success 200 {object} utils.RenderedEmail
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/admin/email-templates/{id}/preview [post]
+func PreviewEmailTemplate(c *gin.Context) {
+	var req EmailTemplatePreviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	template, ok := findEmailTemplate(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.RenderEmailTemplate(template, emailTemplatePreviewValues(template, req.Values)))
+}
+
+// SendTestEmailTemplate sends an email template, filled in with example values, to check how it looks
+// @Summary Send a test email
+// @Description Fill in an email template as the preview does and send it now to the address given, or to the admin's own address. The email is not recorded as a notification and is not retried (Admin only)
+// @Tags Admin - Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Email template ID"
+// @Param request body EmailTemplateTestRequest false "Recipient and values to use instead of the examples"
+// @Success 200 {object} EmailTemplateTestResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse "The mail server did not accept the email"
+// @Router /api/admin/email-templates/{id}/test [post]
+func SendTestEmailTemplate(c *gin.Context) {
+	var req EmailTemplateTestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	template, ok := findEmailTemplate(c)
+	if !ok {
+		return
+	}
+	if !utils.EmailEnabled() {
+		utils.RespondError(c, http.StatusBadRequest, "Email is not configured")
+		return
+	}
+
+	to := req.To
+	if to == "" {
+		var admin models.Employee
+		if err := requestDB(c).First(&admin, c.GetUint("user_id")).Error; err == nil && admin.Email != nil {
+			to = *admin.Email
+		}
+		if to == "" {
+			utils.RespondError(c, http.StatusBadRequest, "You have no email address; give the address to send the test email to")
+			return
+		}
+	}
+
+	rendered := utils.RenderEmailTemplate(template, emailTemplatePreviewValues(template, req.Values))
+	htmlBody := ""
+	if rendered.HTMLBody != nil {
+		htmlBody = *rendered.HTMLBody
+	}
+	if err := utils.SendEmail(to, rendered.Subject, rendered.TextBody, htmlBody); err != nil {
+		utils.RespondError(c, http.StatusBadGateway, i18n.T(utils.RequestLanguage(c), "Failed to send test email: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, EmailTemplateTestResponse{To: to, RenderedEmail: rendered})
+}
+
+func findEmailTemplate(c *gin.Context) (models.EmailTemplate, bool) {
+	templateID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var template models.EmailTemplate
+	if err := requestDB(c).First(&template, templateID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Email template not found")
+		return template, false
+	}
+	return template, true
+}
+
+// checkEmailTemplate rejects a template that uses placeholders its category does not have, or that
+// another template already holds the category and language of, responding with an error and returning false
+func checkEmailTemplate(c *gin.Context, req EmailTemplateRequest, templateID uint) bool {
+	text := req.Subject + "\n" + req.TextBody
+	if req.HTMLBody != nil {
+		text += "\n" + *req.HTMLBody
+	}
+	if unknown := utils.UnknownEmailPlaceholders(req.Category, text); len(unknown) > 0 {
+		utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Unknown placeholders: %s", strings.Join(unknown, ", ")))
+		return false
+	}
+
+	var count int64
+	err := requestDB(c).Model(&models.EmailTemplate{}).
+		Where("category = ? AND language = ? AND id <> ?", req.Category, req.Language, templateID).
+		Count(&count).Error
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch email templates")
+		return false
+	}
+	if count > 0 {
+		utils.RespondError(c, http.StatusConflict, "An email template already exists for this category and language")
+		return false
+	}
+	return true
+}
+
+// emailTemplatePreviewValues are the example values of the template's category with values laid over them
+func emailTemplatePreviewValues(template models.EmailTemplate, values map[string]string) map[string]string {
+	previewValues := utils.EmailTemplateExampleValues(template.Category)
+	for key, value := range values {
+		previewValues[key] = value
+	}
+	return previewValues
+}
+
+// emailTemplateHTML stores an HTML body left blank as none
+func emailTemplateHTML(body *string) *string {
+	if body == nil || strings.TrimSpace(*body) == "" {
+		return nil
+	}
+	return body
+}
@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ImportRowError is a problem with one row of an import file. Rows with an error are not imported.
+type ImportRowError struct {
+	Row     int    `json:"row" example:"3"` // Line in the file, counting the header as line 1
+	Column  string `json:"column,omitempty" example:"hire_date"`
+	Message string `json:"message" example:"Invalid date, use YYYY-MM-DD"`
+}
+
+// ImportResponse summarises an import of employee records from CSV
+type ImportResponse struct {
+	Total   int              `json:"total" example:"10"`
+	Created int              `json:"created" example:"6"`
+	Updated int              `json:"updated" example:"3"`
+	Failed  int              `json:"failed" example:"1"`
+	Errors  []ImportRowError `json:"errors,omitempty"`
+}
+
+// importKeyColumns identify the employee a row is for; a row needs one of them
+var importKeyColumns = []string{"nrc", "employee_number"}
+
+// employmentImportColumns are the employment details columns accepted by the employment import
+var employmentImportColumns = []string{
+	"employment_type", "employment_status", "hire_date", "start_date", "end_date", "probation_end_date",
+	"probation_status", "notice_period", "work_location", "work_schedule", "manager_nrc",
+}
+
+// identityImportColumns are the identity information columns accepted by the identity import
+var identityImportColumns = []string{
+	"date_of_birth", "gender", "nationality", "marital_status", "phone_number", "mobile_number", "address",
+	"city", "state", "postal_code", "country", "emergency_contact", "emergency_phone", "emergency_relation", "blood_group",
+}
+
+var importEmploymentTypes = map[models.EmploymentType]bool{
+	models.EmploymentTypeFullTime: true, models.EmploymentTypePartTime: true, models.EmploymentTypeContract: true,
+	models.EmploymentTypeInternship: true, models.EmploymentTypeConsultant: true,
+}
+
+var importEmploymentStatuses = map[models.EmploymentStatus]bool{
+	models.EmploymentStatusActive: true, models.EmploymentStatusOnLeave: true, models.EmploymentStatusSuspended: true,
+	models.EmploymentStatusTerminated: true, models.EmploymentStatusResigned: true,
+}
+
+// importCellError is an invalid value in one column of a row
+type importCellError struct {
+	column  string
+	message string
+	args    []interface{}
+}
+
+func (e *importCellError) Error() string { return fmt.Sprintf(e.message, e.args...) }
+
+func cellError(column, message string, args ...interface{}) error {
+	return &importCellError{column: column, message: message, args: args}
+}
+
+// importRow is one data row of an import file, by column name. Empty cells are left out.
+type importRow struct {
+	line   int
+	values map[string]string
+}
+
+// DownloadEmploymentTemplate returns a CSV template for importing employment details
+// @Summary Download employment details CSV template
+// @Description Download a CSV template for importing employment details. Each row is keyed by nrc or employee_number (Admin only)
+// @Tags Admin - Employees
+// @Produce text/csv
+// @Security BearerAuth
+// @Success 200 {file} file "CSV template file"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/employment/template [get]
+func DownloadEmploymentTemplate(c *gin.Context) {
+	writeImportTemplate(c, "employment_template.csv", append(append([]string{}, importKeyColumns...), employmentImportColumns...), [][]string{
+		{"123456/78/9", "EMP001", "full_time", "active", "2024-01-15", "2024-02-01", "", "2024-07-31", "in_progress", "30", "Lusaka", "Standard", "987654/32/1"},
+		{"", "EMP002", "contract", "active", "2023-06-01", "2023-06-01", "2025-05-31", "", "", "14", "Ndola", "", ""},
+	})
+}
+
+// DownloadIdentityTemplate returns a CSV template for importing identity information
+// @Summary Download identity information CSV template
+// @Description Download a CSV template for importing identity and contact information. Each row is keyed by nrc or employee_number (Admin only)
+// @Tags Admin - Employees
+// @Produce text/csv
+// @Security BearerAuth
+// @Success 200 {file} file "CSV template file"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/identity/template [get]
+func DownloadIdentityTemplate(c *gin.Context) {
+	writeImportTemplate(c, "identity_template.csv", append(append([]string{}, importKeyColumns...), identityImportColumns...), [][]string{
+		{"123456/78/9", "", "1990-04-12", "Male", "Zambian", "Married", "+260211000000", "+260977000000", "Plot 12, Independence Ave", "Lusaka", "Lusaka", "10101", "Zambia", "Mary Doe", "+260966000000", "Spouse", "O+"},
+	})
+}
+
+// ImportEmploymentDetails creates or updates employment details from a CSV file
+// @Summary Import employment details
+// @Description Create or update employment details from a CSV file laid out like the template. Rows are keyed by nrc or employee_number; only the columns present are imported and empty cells leave the stored value unchanged. New details default to full_time and active. Each row is imported on its own, and rows with an error are reported by line and column without affecting the others (Admin only)
+// @Tags Admin - Employees
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file with employment details"
+// @Success 200 {object} ImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/employment/bulk [post]
+func ImportEmploymentDetails(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	runImport(c, employmentImportColumns, func(tx *gorm.DB, employee models.Employee, row importRow) (bool, error) {
+		return importEmployment(tx, c, userID, employee, row)
+	})
+}
+
+// importEmployment creates or updates an employee's employment details from the employment columns of
+// an import row, and reports whether it created them. New details default to full_time and active.
+func importEmployment(tx *gorm.DB, c *gin.Context, userID uint, employee models.Employee, row importRow) (bool, error) {
+	var details models.EmploymentDetails
+	exists := tx.Where("employee_id = ?", employee.ID).First(&details).Error == nil
+	before := utils.TakeEmploymentSnapshot(tx, employee.ID)
+	old := details
+
+	if !exists {
+		details = models.EmploymentDetails{
+			EmployeeID:       employee.ID,
+			EmploymentType:   models.EmploymentTypeFullTime,
+			EmploymentStatus: models.EmploymentStatusActive,
+		}
+	}
+	if number, ok := row.values["employee_number"]; ok {
+		details.EmployeeNumber = &number
+	}
+	if err := applyEmploymentCells(tx, &details, employee, row); err != nil {
+		return false, err
+	}
+
+	if !exists {
+		if err := tx.Create(&details).Error; err != nil {
+			return false, err
+		}
+		if err := recordEmploymentChange(tx, c, employee.ID, before, "Employment details imported"); err != nil {
+			return false, err
+		}
+		return true, recordAuditLog(tx, models.AuditEntityEmployment, details.ID, models.AuditActionCreate, userID, c, nil, details)
+	}
+
+	saved, err := saveVersioned(tx, &details, &details.Version, old.Version)
+	if err != nil {
+		return false, err
+	}
+	if !saved {
+		return false, cellError("", "Employment details were changed during the import. Try the row again")
+	}
+	if err := recordEmploymentChange(tx, c, employee.ID, before, "Employment details imported"); err != nil {
+		return false, err
+	}
+	return false, recordAuditLog(tx, models.AuditEntityEmployment, details.ID, models.AuditActionUpdate, userID, c, old, details)
+}
+
+// ImportIdentityInformation creates or updates identity information from a CSV file
+// @Summary Import identity information
+// @Description Create or update identity and contact information from a CSV file laid out like the template. Rows are keyed by nrc or employee_number; only the columns present are imported and empty cells leave the stored value unchanged. Each row is imported on its own, and rows with an error are reported by line and column without affecting the others (Admin only)
+// @Tags Admin - Employees
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file with identity information"
+// @Success 200 {object} ImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/identity/bulk [post]
+func ImportIdentityInformation(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	runImport(c, identityImportColumns, func(tx *gorm.DB, employee models.Employee, row importRow) (bool, error) {
+		var identity models.IdentityInformation
+		exists := tx.Where("employee_id = ?", employee.ID).First(&identity).Error == nil
+		old := identity
+		if !exists {
+			identity = models.IdentityInformation{EmployeeID: employee.ID}
+		}
+		if err := applyIdentityCells(&identity, row); err != nil {
+			return false, err
+		}
+
+		if !exists {
+			if err := tx.Create(&identity).Error; err != nil {
+				return false, err
+			}
+			return true, recordAuditLog(tx, models.AuditEntityIdentity, identity.ID, models.AuditActionCreate, userID, c, nil, identity)
+		}
+		if err := tx.Save(&identity).Error; err != nil {
+			return false, err
+		}
+		return false, recordAuditLog(tx, models.AuditEntityIdentity, identity.ID, models.AuditActionUpdate, userID, c, old, identity)
+	})
+}
+
+func writeImportTemplate(c *gin.Context, filename string, header []string, examples [][]string) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+
+	writer := csv.NewWriter(c.Writer)
+	defer writer.Flush()
+
+	writer.Write(header)
+	for _, example := range examples {
+		writer.Write(example)
+	}
+}
+
+// runImport reads the uploaded CSV and calls apply for each data row in its own transaction; apply
+// reports whether the row created a record. A row's changes are rolled back if it fails.
+func runImport(c *gin.Context, columns []string, apply func(tx *gorm.DB, employee models.Employee, row importRow) (bool, error)) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid CSV file")
+		return
+	}
+	known := map[string]bool{}
+	for _, column := range append(append([]string{}, importKeyColumns...), columns...) {
+		known[column] = true
+	}
+	names := make([]string, len(header))
+	hasKey := false
+	for i, name := range header {
+		// Spreadsheet programs may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !known[name] {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Unknown column %s. Download the template for the correct format.", name))
+			return
+		}
+		names[i] = name
+		hasKey = hasKey || name == "nrc" || name == "employee_number"
+	}
+	if !hasKey {
+		utils.RespondError(c, http.StatusBadRequest, "The file needs an nrc or employee_number column")
+		return
+	}
+
+	var response ImportResponse
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		response.Total++
+		if err != nil {
+			response.fail(c, line, "", "Failed to parse row")
+			continue
+		}
+		row := importRow{line: line, values: map[string]string{}}
+		for i, value := range record {
+			if i < len(names) && strings.TrimSpace(value) != "" {
+				row.values[names[i]] = strings.TrimSpace(value)
+			}
+		}
+		if len(row.values) == 0 {
+			response.Total--
+			continue // Blank line
+		}
+
+		created := false
+		employee, err := findImportEmployee(c, row)
+		if err == nil {
+			err = withTransaction(c, func(tx *gorm.DB) error {
+				var err error
+				created, err = apply(tx, employee, row)
+				return err
+			})
+		}
+		response.record(c, line, created, err)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// record counts a row as created or updated, or as failed with the error importing it returned
+func (r *ImportResponse) record(c *gin.Context, line int, created bool, err error) {
+	var cellErr *importCellError
+	switch {
+	case errors.As(err, &cellErr):
+		r.fail(c, line, cellErr.column, cellErr.message, cellErr.args...)
+	case err != nil && database.IsDuplicateKey(err):
+		r.fail(c, line, "", "A value in this row is already used by another employee")
+	case err != nil:
+		r.fail(c, line, "", "Failed to import row")
+	case created:
+		r.Created++
+	default:
+		r.Updated++
+	}
+}
+
+func (r *ImportResponse) fail(c *gin.Context, line int, column, message string, args ...interface{}) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRowError{Row: line, Column: column, Message: i18n.T(utils.RequestLanguage(c), message, args...)})
+}
+
+// findImportEmployee finds the employee a row is for by NRC, or by employee number on the employee or
+// their employment details
+func findImportEmployee(c *gin.Context, row importRow) (models.Employee, error) {
+	var employee models.Employee
+	if nrc, ok := row.values["nrc"]; ok {
+		if err := utils.WhereNationalID(requestDB(c), nrc).First(&employee).Error; err != nil {
+			return employee, cellError("nrc", "No employee with NRC %s", nrc)
+		}
+		return employee, nil
+	}
+	number, ok := row.values["employee_number"]
+	if !ok {
+		return employee, cellError("nrc", "Row needs an nrc or employee_number")
+	}
+	err := requestDB(c).
+		Where("employee_number = ? OR id IN (SELECT employee_id FROM employment_details WHERE employee_number = ? AND deleted_at IS NULL)", number, number).
+		First(&employee).Error
+	if err != nil {
+		return employee, cellError("employee_number", "No employee with employee number %s", number)
+	}
+	return employee, nil
+}
+
+func applyEmploymentCells(tx *gorm.DB, details *models.EmploymentDetails, employee models.Employee, row importRow) error {
+	dates := map[string]**time.Time{
+		"hire_date": &details.HireDate, "start_date": &details.StartDate,
+		"end_date": &details.EndDate, "probation_end_date": &details.ProbationEndDate,
+	}
+	for _, column := range employmentImportColumns {
+		value, ok := row.values[column]
+		if !ok {
+			continue
+		}
+		switch column {
+		case "employment_type":
+			if !importEmploymentTypes[models.EmploymentType(value)] {
+				return cellError(column, "Invalid employment type %s", value)
+			}
+			details.EmploymentType = models.EmploymentType(value)
+		case "employment_status":
+			if !importEmploymentStatuses[models.EmploymentStatus(value)] {
+				return cellError(column, "Invalid employment status %s", value)
+			}
+			details.EmploymentStatus = models.EmploymentStatus(value)
+		case "hire_date", "start_date", "end_date", "probation_end_date":
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return cellError(column, "Invalid date, use YYYY-MM-DD")
+			}
+			*dates[column] = &date
+		case "probation_status":
+			details.ProbationStatus = &value
+		case "notice_period":
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 0 {
+				return cellError(column, "Notice period must be a whole number of days")
+			}
+			details.NoticePeriod = &days
+		case "work_location":
+			details.WorkLocation = &value
+		case "work_schedule":
+			details.WorkSchedule = &value
+		case "manager_nrc":
+			var manager models.Employee
+			if err := utils.WhereNationalID(tx, value).First(&manager).Error; err != nil {
+				return cellError(column, "No employee with NRC %s", value)
+			}
+			if manager.ID == employee.ID {
+				return cellError(column, "An employee cannot be their own manager")
+			}
+			details.ManagerID = &manager.ID
+		}
+	}
+	return nil
+}
+
+func applyIdentityCells(identity *models.IdentityInformation, row importRow) error {
+	fields := map[string]**string{
+		"gender": &identity.Gender, "nationality": &identity.Nationality, "marital_status": &identity.MaritalStatus,
+		"phone_number": &identity.PhoneNumber, "mobile_number": &identity.MobileNumber, "address": &identity.Address,
+		"city": &identity.City, "state": &identity.State, "postal_code": &identity.PostalCode, "country": &identity.Country,
+		"emergency_contact": &identity.EmergencyContact, "emergency_phone": &identity.EmergencyPhone,
+		"emergency_relation": &identity.EmergencyRelation, "blood_group": &identity.BloodGroup,
+	}
+	for _, column := range identityImportColumns {
+		value, ok := row.values[column]
+		if !ok {
+			continue
+		}
+		if column == "date_of_birth" {
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return cellError(column, "Invalid date, use YYYY-MM-DD")
+			}
+			identity.DateOfBirth = &date
+			continue
+		}
+		*fields[column] = &value
+	}
+	return nil
+}
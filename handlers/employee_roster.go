@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rosterAccess is who may export a roster column
+type rosterAccess int
+
+const (
+	rosterPublic   rosterAccess = iota // Managers and admins
+	rosterPersonal                     // Admins only
+	rosterPayroll                      // Users with payroll access only
+)
+
+// rosterColumn is one column of the employee roster export
+type rosterColumn struct {
+	key    string
+	header string
+	access rosterAccess
+	value  func(emp *models.Employee) string
+}
+
+// rosterColumns are the exportable roster columns, in export order
+var rosterColumns = []rosterColumn{
+	{"id", "ID", rosterPublic, func(e *models.Employee) string { return strconv.FormatUint(uint64(e.ID), 10) }},
+	{"employee_number", "Employee Number", rosterPublic, rosterEmployeeNumber},
+	{"firstname", "First Name", rosterPublic, func(e *models.Employee) string { return e.Firstname }},
+	{"lastname", "Last Name", rosterPublic, func(e *models.Employee) string { return e.Lastname }},
+	{"email", "Email", rosterPublic, func(e *models.Employee) string { return rosterString(e.Email) }},
+	{"phone", "Phone", rosterPublic, func(e *models.Employee) string { return rosterString(e.Phone) }},
+	{"mobile", "Mobile", rosterPublic, func(e *models.Employee) string { return rosterString(e.Mobile) }},
+	{"department", "Department", rosterPublic, func(e *models.Employee) string { return e.Department }},
+	{"job_title", "Job Title", rosterPublic, func(e *models.Employee) string { return rosterString(e.JobTitle) }},
+	{"role", "Role", rosterPublic, func(e *models.Employee) string { return string(e.Role) }},
+	{"status", "Status", rosterPublic, func(e *models.Employee) string { return e.Status }},
+	{"position_code", "Position Code", rosterPublic, func(e *models.Employee) string {
+		if e.Position == nil {
+			return ""
+		}
+		return e.Position.Code
+	}},
+	{"position_title", "Position", rosterPublic, func(e *models.Employee) string {
+		if e.Position == nil {
+			return ""
+		}
+		return e.Position.Title
+	}},
+	{"position_level", "Position Level", rosterPublic, func(e *models.Employee) string {
+		if e.Position == nil {
+			return ""
+		}
+		return rosterString(e.Position.Level)
+	}},
+	{"employment_type", "Employment Type", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return ""
+		}
+		return string(e.Employment.EmploymentType)
+	}},
+	{"employment_status", "Employment Status", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return rosterString(e.EmploymentStatus)
+		}
+		return string(e.Employment.EmploymentStatus)
+	}},
+	{"hire_date", "Hire Date", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return rosterDate(e.DateJoined)
+		}
+		return rosterDate(e.Employment.HireDate)
+	}},
+	{"start_date", "Start Date", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return ""
+		}
+		return rosterDate(e.Employment.StartDate)
+	}},
+	{"end_date", "End Date", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return ""
+		}
+		return rosterDate(e.Employment.EndDate)
+	}},
+	{"probation_end_date", "Probation End Date", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return ""
+		}
+		return rosterDate(e.Employment.ProbationEndDate)
+	}},
+	{"manager", "Manager", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil || e.Employment.Manager == nil {
+			return ""
+		}
+		return e.Employment.Manager.Firstname + " " + e.Employment.Manager.Lastname
+	}},
+	{"work_location", "Work Location", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return ""
+		}
+		return rosterString(e.Employment.WorkLocation)
+	}},
+	{"work_schedule", "Work Schedule", rosterPublic, func(e *models.Employee) string {
+		if e.Employment == nil {
+			return ""
+		}
+		return rosterString(e.Employment.WorkSchedule)
+	}},
+	{"nrc", "NRC", rosterPersonal, func(e *models.Employee) string { return rosterString(e.NRC) }},
+	{"date_of_birth", "Date of Birth", rosterPersonal, func(e *models.Employee) string { return rosterDate(e.DateOfBirth) }},
+	{"gender", "Gender", rosterPersonal, func(e *models.Employee) string { return rosterString(e.Gender) }},
+	{"address", "Address", rosterPersonal, func(e *models.Employee) string { return rosterString(e.Address) }},
+	{"city", "City", rosterPersonal, func(e *models.Employee) string { return rosterString(e.City) }},
+	{"postal_code", "Postal Code", rosterPersonal, func(e *models.Employee) string { return rosterString(e.PostalCode) }},
+	{"emergency_contact_name", "Emergency Contact", rosterPersonal, func(e *models.Employee) string { return rosterString(e.EmergencyContactName) }},
+	{"emergency_contact_phone", "Emergency Contact Phone", rosterPersonal, func(e *models.Employee) string { return rosterString(e.EmergencyContactPhone) }},
+	{"emergency_contact_relationship", "Emergency Contact Relationship", rosterPersonal, func(e *models.Employee) string {
+		return rosterString(e.EmergencyContactRelationship)
+	}},
+	{"notes", "Notes", rosterPersonal, func(e *models.Employee) string { return rosterString(e.Notes) }},
+	{"bank_name", "Bank Name", rosterPayroll, func(e *models.Employee) string { return rosterString(e.BankName) }},
+	{"bank_account_number", "Bank Account Number", rosterPayroll, func(e *models.Employee) string { return rosterString(e.BankAccountNumber) }},
+	{"tax_id", "Tax ID", rosterPayroll, func(e *models.Employee) string { return rosterString(e.TaxID) }},
+}
+
+// exportEmployeeRoster writes the employee roster as an Excel or CSV file. The columns query parameter
+// selects and orders the columns; without it every column the user may see is exported. Personal
+// columns are only exported for admins and bank and tax columns for users with payroll access.
+func exportEmployeeRoster(c *gin.Context, format string) {
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	allowed := func(column rosterColumn) bool {
+		switch column.access {
+		case rosterPersonal:
+			return user.Role == models.RoleAdmin
+		case rosterPayroll:
+			return user.PayrollAccess
+		}
+		return true
+	}
+
+	var columns []rosterColumn
+	if requested := c.Query("columns"); requested != "" {
+		byKey := make(map[string]rosterColumn, len(rosterColumns))
+		for _, column := range rosterColumns {
+			byKey[column.key] = column
+		}
+		for _, key := range strings.Split(requested, ",") {
+			column, ok := byKey[strings.TrimSpace(key)]
+			if !ok {
+				utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Unknown column: %s", strings.TrimSpace(key)))
+				return
+			}
+			if !allowed(column) {
+				utils.RespondError(c, http.StatusForbidden, i18n.T(utils.RequestLanguage(c), "You are not allowed to export column: %s", column.key))
+				return
+			}
+			columns = append(columns, column)
+		}
+	} else {
+		for _, column := range rosterColumns {
+			if allowed(column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	// Admin accounts are not part of the roster
+	query := requestDB(c).Preload("Position").Preload("Employment.Manager").
+		Where("role != ?", models.RoleAdmin).Order("lastname, firstname")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var employees []models.Employee
+	if err := query.Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+	}
+	rows := make([][]string, len(employees))
+	for i := range employees {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = column.value(&employees[i])
+		}
+		rows[i] = row
+	}
+
+	filename := fmt.Sprintf("employee_roster_%s", time.Now().Format("20060102_150405"))
+	writeTableExport(c, format, filename, "Roster", header, rows)
+}
+
+// rosterEmployeeNumber prefers the employee number on the employment details, which the HR
+// imports maintain, over the one given when the account was created
+func rosterEmployeeNumber(e *models.Employee) string {
+	if e.Employment != nil && e.Employment.EmployeeNumber != nil {
+		return *e.Employment.EmployeeNumber
+	}
+	return rosterString(e.EmployeeNumber)
+}
+
+func rosterString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func rosterDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
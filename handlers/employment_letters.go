@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EmploymentLetterRequest represents the choices for a proof-of-employment letter
+type EmploymentLetterRequest struct {
+	Addressee     string `json:"addressee,omitempty" binding:"max=200" example:"Zanaco Bank"` // Defaults to "To whom it may concern"
+	IncludeSalary bool   `json:"include_salary,omitempty"`
+}
+
+// EmploymentVerificationResponse is what a verification code confirms about an employment letter
+type EmploymentVerificationResponse struct {
+	Status           string     `json:"status" example:"valid"` // valid, expired or revoked
+	EmployeeName     string     `json:"employee_name" example:"Chanda Mwila"`
+	PositionTitle    string     `json:"position_title,omitempty" example:"Accountant"`
+	Department       string     `json:"department,omitempty" example:"Finance"`
+	EmploymentType   string     `json:"employment_type,omitempty" example:"full_time"`
+	HireDate         *time.Time `json:"hire_date,omitempty"`
+	Salary           *float64   `json:"salary,omitempty"`
+	OrganizationName string     `json:"organization_name" example:"Acme Ltd"`
+	IssuedAt         time.Time  `json:"issued_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+}
+
+// GetMyEmploymentLetters lists the employment letters the current user issued
+// @Summary Get my employment letters
+// @Description List the proof-of-employment letters you issued, newest first
+// @Tags Employment Letters
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.EmploymentLetter
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/employment-letters [get]
+func GetMyEmploymentLetters(c *gin.Context) {
+	var letters []models.EmploymentLetter
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).Order("issued_at DESC").Find(&letters).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employment letters")
+		return
+	}
+	c.JSON(http.StatusOK, letters)
+}
+
+// CreateEmploymentLetter issues a proof-of-employment letter for the current user
+// @Summary Issue an employment letter
+// @Description Issue a proof-of-employment letter, for a bank for example, stating your name, position, department and hire date, and your salary if include_salary is set. The letter carries a verification code third parties can check at /verify/employment/{code} for 90 days. Download it as a PDF from /api/me/employment-letters/{id}/download. Only current employees can issue letters
+// @Tags Employment Letters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body EmploymentLetterRequest false "Letter choices"
+// @Success 201 {object} models.EmploymentLetter
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/employment-letters [post]
+func CreateEmploymentLetter(c *gin.Context) {
+	var req EmploymentLetterRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+	userID := c.GetUint("user_id")
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, userID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+	var details models.EmploymentDetails
+	if err := requestDB(c).Where("employee_id = ?", userID).Limit(1).Find(&details).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to issue employment letter")
+		return
+	}
+	if employee.Status == "inactive" || details.EmploymentStatus == models.EmploymentStatusTerminated ||
+		details.EmploymentStatus == models.EmploymentStatusResigned {
+		utils.RespondError(c, http.StatusBadRequest, "Employment letters are only issued to current employees")
+		return
+	}
+	var assignment models.PositionAssignment
+	err := requestDB(c).Preload("Position").Where("employee_id = ? AND is_primary = ? AND end_date IS NULL", userID, true).
+		Order("start_date DESC").Limit(1).Find(&assignment).Error
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to issue employment letter")
+		return
+	}
+	var organization models.Organization
+	if err := requestDB(c).Where("id = ?", employee.OrganizationID).Limit(1).Find(&organization).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to issue employment letter")
+		return
+	}
+
+	code, err := utils.NewEmploymentLetterCode()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to issue employment letter")
+		return
+	}
+	now := time.Now()
+	letter := models.EmploymentLetter{
+		EmployeeID:       userID,
+		Code:             code,
+		Addressee:        strings.TrimSpace(req.Addressee),
+		EmployeeName:     strings.TrimSpace(employee.Firstname + " " + employee.Lastname),
+		Department:       employee.Department,
+		EmploymentType:   string(details.EmploymentType),
+		HireDate:         details.HireDate,
+		OrganizationName: organization.Name,
+		IssuedAt:         now,
+		ExpiresAt:        now.Add(utils.EmploymentLetterValidity),
+	}
+	if assignment.ID != 0 {
+		letter.PositionTitle = assignment.Position.Title
+	} else if employee.JobTitle != nil {
+		letter.PositionTitle = *employee.JobTitle
+	}
+	if req.IncludeSalary {
+		if assignment.Salary == nil {
+			utils.RespondError(c, http.StatusBadRequest, "No salary is recorded for you, so it cannot be included")
+			return
+		}
+		letter.Salary = assignment.Salary
+	}
+
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&letter).Error; err != nil {
+			return err
+		}
+		return recordAuditLog(tx, models.AuditEntityLetter, letter.ID, models.AuditActionCreate, userID, c, nil, letter)
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to issue employment letter")
+		return
+	}
+	c.JSON(http.StatusCreated, letter)
+}
+
+// DownloadEmploymentLetter downloads one of the current user's employment letters as a PDF
+// @Summary Download an employment letter
+// @Description Download one of your employment letters as a PDF
+// @Tags Employment Letters
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Employment letter ID"
+// @Success 200 {file} file
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/employment-letters/{id}/download [get]
+func DownloadEmploymentLetter(c *gin.Context) {
+	letterID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var letter models.EmploymentLetter
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", letterID, c.GetUint("user_id")).First(&letter).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employment letter not found")
+		return
+	}
+
+	content, err := utils.EmploymentLetterPDF(letter)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate PDF")
+		return
+	}
+	noteFileAccess(c, models.FileAccessLog{EmployeeID: &letter.EmployeeID})
+	filename := fmt.Sprintf("employment_letter_%s.pdf", letter.IssuedAt.In(utils.CompanyLocation()).Format("2006-01-02"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", content)
+}
+
+// RevokeEmploymentLetter revokes one of the current user's employment letters
+// @Summary Revoke an employment letter
+// @Description Revoke one of your employment letters, so its verification code no longer confirms it
+// @Tags Employment Letters
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employment letter ID"
+// @Success 200 {object} models.EmploymentLetter
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/employment-letters/{id}/revoke [post]
+func RevokeEmploymentLetter(c *gin.Context) {
+	letterID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	userID := c.GetUint("user_id")
+	var letter models.EmploymentLetter
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", letterID, userID).First(&letter).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employment letter not found")
+		return
+	}
+	if letter.RevokedAt != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Employment letter is already revoked")
+		return
+	}
+
+	now := time.Now()
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Model(&letter).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return recordAuditLog(tx, models.AuditEntityLetter, letter.ID, models.AuditActionCancel, userID, c,
+			nil, gin.H{"revoked_at": now})
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to revoke employment letter")
+		return
+	}
+	letter.RevokedAt = &now
+	c.JSON(http.StatusOK, letter)
+}
+
+// VerifyEmploymentLetter confirms an employment letter from its verification code
+// @Summary Verify an employment letter
+// @Description Check the verification code printed on an employment letter. Returns what the letter states and whether it is still valid, so banks and other third parties can compare it with the letter they were given. No authentication is needed
+// @Tags Employment Letters
+// @Produce json
+// @Param code path string true "Verification code, e.g. 7KQ2-M9XD-4TPA-H3VW"
+// @Success 200 {object} EmploymentVerificationResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /verify/employment/{code} [get]
+func VerifyEmploymentLetter(c *gin.Context) {
+	var letter models.EmploymentLetter
+	err := requestDB(c).Where("code = ?", utils.NormalizeEmploymentLetterCode(c.Param("code"))).First(&letter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(c, http.StatusNotFound, "No employment letter has this verification code")
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to verify employment letter")
+		return
+	}
+
+	status := "valid"
+	switch {
+	case letter.RevokedAt != nil:
+		status = "revoked"
+	case time.Now().After(letter.ExpiresAt):
+		status = "expired"
+	}
+	c.JSON(http.StatusOK, EmploymentVerificationResponse{
+		Status:           status,
+		EmployeeName:     letter.EmployeeName,
+		PositionTitle:    letter.PositionTitle,
+		Department:       letter.Department,
+		EmploymentType:   letter.EmploymentType,
+		HireDate:         letter.HireDate,
+		Salary:           letter.Salary,
+		OrganizationName: letter.OrganizationName,
+		IssuedAt:         letter.IssuedAt,
+		ExpiresAt:        letter.ExpiresAt,
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventHeartbeatInterval keeps idle connections open through proxies that close silent connections
+const eventHeartbeatInterval = 25 * time.Second
+
+// StreamEvents streams real-time events to the current user using server-sent events
+// @Summary Stream real-time events
+// @Description Server-sent events stream for the current user. Managers and admins receive leave_submitted, leave_approved, leave_rejected and leave_cancelled events for all leave requests in their organization; employees receive leave_approved and leave_rejected for their own requests; everyone receives notification events for their in-app notifications. Each event's data is JSON with type, data and occurred_at. Clients that cannot set headers, such as a browser EventSource, may pass the JWT in the token query parameter
+// @Tags Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param token query string false "JWT, for clients that cannot set the Authorization header"
+// @Success 200 {object} utils.Event
+// @Failure 401 {object} ErrorResponse
+// @Router /api/events [get]
+func StreamEvents(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	role, _ := c.Get("role")
+
+	events, unsubscribe := utils.SubscribeEvents(userID.(uint), c.GetUint("organization_id"), role.(models.Role))
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Stop nginx buffering the stream
+
+	// The stream outlives the server's write timeout
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.SSEvent("connected", gin.H{"user_id": userID})
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-utils.EventStreamsClosed():
+			return false
+		case event := <-events:
+			c.SSEvent(string(event.Type), event)
+		case <-heartbeat.C:
+			c.SSEvent("ping", gin.H{"time": time.Now()})
+		}
+		return true
+	})
+}
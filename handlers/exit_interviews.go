@@ -0,0 +1,597 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// exitInterviewReportMinGroup is the smallest group reported separately in the exit interview report
+const exitInterviewReportMinGroup = 3
+
+// ExitQuestionRequest represents a question in an exit interview question set
+type ExitQuestionRequest struct {
+	Text       string                  `json:"text" binding:"required" example:"How well did your manager support you?"`
+	Type       models.ExitQuestionType `json:"type" binding:"required" example:"rating"`                  // text, rating (1-5), yes_no, choice
+	Options    []string                `json:"options,omitempty" example:"Better pay,Career progression"` // Required for choice questions
+	IsRequired bool                    `json:"is_required" example:"true"`
+}
+
+// ExitQuestionSetRequest represents data for creating or updating an exit interview question set
+type ExitQuestionSetRequest struct {
+	Name        string                `json:"name" binding:"required" example:"Standard Exit Interview"`
+	Description *string               `json:"description,omitempty" example:"Used for all voluntary leavers"`
+	IsActive    *bool                 `json:"is_active,omitempty" example:"true"`
+	Questions   []ExitQuestionRequest `json:"questions,omitempty"` // Replaces the questions; required when creating and not allowed once the set has been used
+}
+
+// ExitInterviewAnswer represents the leaver's answer to one question
+type ExitInterviewAnswer struct {
+	QuestionID uint    `json:"question_id" binding:"required" example:"3"`
+	Answer     *string `json:"answer,omitempty" example:"yes"`
+	Rating     *int    `json:"rating,omitempty" example:"4"`
+}
+
+// RecordExitInterviewRequest represents a completed exit interview
+type RecordExitInterviewRequest struct {
+	QuestionSetID   uint                  `json:"question_set_id" binding:"required" example:"1"`
+	ConductedAt     *string               `json:"conducted_at,omitempty" example:"2025-03-28"` // YYYY-MM-DD, defaults to today
+	PrimaryReason   models.LeavingReason  `json:"primary_reason" binding:"required" example:"career_growth"`
+	SecondaryReason *models.LeavingReason `json:"secondary_reason,omitempty" example:"compensation"`
+	WouldRecommend  *bool                 `json:"would_recommend,omitempty" example:"true"`
+	WouldReturn     *bool                 `json:"would_return,omitempty" example:"false"`
+	Notes           *string               `json:"notes,omitempty" example:"Leaving for a team lead role elsewhere"`
+	Answers         []ExitInterviewAnswer `json:"answers" binding:"required"`
+}
+
+// ExitQuestionAverage is the average score for a rating question
+type ExitQuestionAverage struct {
+	QuestionID uint    `json:"question_id" example:"3"`
+	Question   string  `json:"question" example:"How well did your manager support you?"`
+	Responses  int     `json:"responses" example:"12"`
+	Average    float64 `json:"average" example:"3.4"`
+}
+
+// ExitInterviewReport is an anonymized summary of exit interviews for turnover analysis
+type ExitInterviewReport struct {
+	From               string                `json:"from" example:"2025-01-01"`
+	To                 string                `json:"to" example:"2025-03-31"`
+	Total              int                   `json:"total" example:"12"`
+	Suppressed         bool                  `json:"suppressed" example:"false"` // True when there are too few interviews to report without identifying leavers
+	ByPrimaryReason    map[string]int        `json:"by_primary_reason"`
+	ByAnyReason        map[string]int        `json:"by_any_reason"`                                 // Primary and secondary reasons combined
+	ByDepartment       map[string]int        `json:"by_department"`                                 // Departments with fewer than 3 leavers are grouped as "Other"
+	WouldRecommendRate *float64              `json:"would_recommend_rate,omitempty" example:"58.3"` // Percentage of those asked
+	WouldReturnRate    *float64              `json:"would_return_rate,omitempty" example:"41.7"`
+	RatingAverages     []ExitQuestionAverage `json:"rating_averages"`
+}
+
+var leavingReasons = map[models.LeavingReason]bool{
+	models.LeavingReasonCareerGrowth:    true,
+	models.LeavingReasonCompensation:    true,
+	models.LeavingReasonManagement:      true,
+	models.LeavingReasonWorkLifeBalance: true,
+	models.LeavingReasonCulture:         true,
+	models.LeavingReasonRelocation:      true,
+	models.LeavingReasonPersonal:        true,
+	models.LeavingReasonRetirement:      true,
+	models.LeavingReasonContractEnd:     true,
+	models.LeavingReasonDismissal:       true,
+	models.LeavingReasonOther:           true,
+}
+
+// GetExitQuestionSets lists exit interview question sets
+// @Summary Get exit interview question sets
+// @Description List exit interview question sets with their questions (Admin only)
+// @Tags Exit Interviews
+// @Produce json
+// @Security BearerAuth
+// @Param include_inactive query bool false "Include inactive question sets"
+// @Success 200 {array} models.ExitQuestionSet
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/exit-interviews/question-sets [get]
+func GetExitQuestionSets(c *gin.Context) {
+	query := requestDB(c).Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: "order"}})
+	})
+	if c.Query("include_inactive") != "true" {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var sets []models.ExitQuestionSet
+	query.Order("name").Find(&sets)
+
+	c.JSON(http.StatusOK, sets)
+}
+
+// CreateExitQuestionSet creates an exit interview question set
+// @Summary Create exit interview question set
+// @Description Create an exit interview question set. Questions are asked in the order given (Admin only)
+// @Tags Exit Interviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ExitQuestionSetRequest true "Question set"
+// @Success 201 {object} models.ExitQuestionSet
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/exit-interviews/question-sets [post]
+func CreateExitQuestionSet(c *gin.Context) {
+	var req ExitQuestionSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if len(req.Questions) == 0 {
+		utils.RespondError(c, http.StatusBadRequest, "At least one question is required")
+		return
+	}
+	questions, err := buildExitQuestions(req.Questions)
+	if err != nil {
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var existing int64
+	requestDB(c).Model(&models.ExitQuestionSet{}).Where("LOWER(name) = LOWER(?)", req.Name).Count(&existing)
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "A question set with this name already exists")
+		return
+	}
+
+	set := models.ExitQuestionSet{Name: req.Name, Description: req.Description, IsActive: true, Questions: questions}
+	if req.IsActive != nil {
+		set.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Create(&set).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create question set")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityExitInterview, set.ID, models.AuditActionCreate, userID.(uint), c, nil, set)
+
+	c.JSON(http.StatusCreated, set)
+}
+
+// UpdateExitQuestionSet updates an exit interview question set
+// @Summary Update exit interview question set
+// @Description Rename, describe or deactivate a question set, or replace its questions. Questions cannot be replaced once an interview has used the set; create a new set instead (Admin only)
+// @Tags Exit Interviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Question set ID"
+// @Param request body ExitQuestionSetRequest true "Question set"
+// @Success 200 {object} models.ExitQuestionSet
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/exit-interviews/question-sets/{id} [put]
+func UpdateExitQuestionSet(c *gin.Context) {
+	setID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req ExitQuestionSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var set models.ExitQuestionSet
+	if err := requestDB(c).Preload("Questions").First(&set, setID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Question set not found")
+		return
+	}
+
+	var existing int64
+	requestDB(c).Model(&models.ExitQuestionSet{}).Where("LOWER(name) = LOWER(?) AND id != ?", req.Name, set.ID).Count(&existing)
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "A question set with this name already exists")
+		return
+	}
+
+	var questions []models.ExitQuestion
+	if len(req.Questions) > 0 {
+		var used int64
+		requestDB(c).Model(&models.ExitInterview{}).Where("question_set_id = ?", set.ID).Count(&used)
+		if used > 0 {
+			utils.RespondError(c, http.StatusConflict, "This question set has been used in interviews; create a new set to change its questions")
+			return
+		}
+		var err error
+		if questions, err = buildExitQuestions(req.Questions); err != nil {
+			utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	oldValues := set
+	set.Name = req.Name
+	set.Description = req.Description
+	if req.IsActive != nil {
+		set.IsActive = *req.IsActive
+	}
+
+	tx := requestDB(c).Begin()
+	if err := tx.Omit("Questions").Save(&set).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update question set")
+		return
+	}
+	if questions != nil {
+		if err := tx.Where("question_set_id = ?", set.ID).Delete(&models.ExitQuestion{}).Error; err != nil {
+			tx.Rollback()
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update questions")
+			return
+		}
+		for i := range questions {
+			questions[i].QuestionSetID = set.ID
+		}
+		if err := tx.Create(&questions).Error; err != nil {
+			tx.Rollback()
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update questions")
+			return
+		}
+		set.Questions = questions
+	}
+	tx.Commit()
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityExitInterview, set.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, set)
+
+	c.JSON(http.StatusOK, set)
+}
+
+// RecordExitInterview records the exit interview for an employee's offboarding
+// @Summary Record exit interview
+// @Description Record the exit interview for an employee who has an offboarding process, with answers to the chosen question set and the reason for leaving. Reasons: career_growth, compensation, management, work_life_balance, culture, relocation, personal, retirement, contract_end, dismissal, other (Admin only)
+// @Tags Exit Interviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body RecordExitInterviewRequest true "Exit interview"
+// @Success 201 {object} models.ExitInterview
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/offboarding/exit-interview [post]
+func RecordExitInterview(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req RecordExitInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if !leavingReasons[req.PrimaryReason] {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid primary_reason")
+		return
+	}
+	if req.SecondaryReason != nil && (!leavingReasons[*req.SecondaryReason] || *req.SecondaryReason == req.PrimaryReason) {
+		utils.RespondError(c, http.StatusBadRequest, "secondary_reason must be a different valid reason")
+		return
+	}
+
+	conductedAt := time.Now()
+	if req.ConductedAt != nil {
+		parsed, err := time.Parse("2006-01-02", *req.ConductedAt)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid conducted_at format. Use YYYY-MM-DD")
+			return
+		}
+		conductedAt = parsed
+	}
+
+	var process models.OffboardingProcess
+	if err := requestDB(c).Preload("Employee").Where("employee_id = ?", employeeID).First(&process).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Offboarding process not found")
+		return
+	}
+
+	var existing int64
+	requestDB(c).Model(&models.ExitInterview{}).Where("offboarding_process_id = ?", process.ID).Count(&existing)
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "An exit interview has already been recorded for this offboarding")
+		return
+	}
+
+	var set models.ExitQuestionSet
+	if err := requestDB(c).Preload("Questions").Where("is_active = ?", true).First(&set, req.QuestionSetID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Question set not found")
+		return
+	}
+
+	responses, err := buildExitResponses(set.Questions, req.Answers)
+	if err != nil {
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	interviewerID := userID.(uint)
+	interview := models.ExitInterview{
+		OffboardingProcessID: process.ID,
+		EmployeeID:           process.EmployeeID,
+		QuestionSetID:        set.ID,
+		InterviewerID:        &interviewerID,
+		ConductedAt:          conductedAt,
+		Department:           process.Employee.Department,
+		PrimaryReason:        req.PrimaryReason,
+		SecondaryReason:      req.SecondaryReason,
+		WouldRecommend:       req.WouldRecommend,
+		WouldReturn:          req.WouldReturn,
+		Notes:                req.Notes,
+		Responses:            responses,
+	}
+	if err := requestDB(c).Omit("Employee", "QuestionSet").Create(&interview).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to record exit interview")
+		return
+	}
+
+	createAuditLog(models.AuditEntityExitInterview, interview.ID, models.AuditActionCreate, interviewerID, c, nil, interview)
+
+	c.JSON(http.StatusCreated, interview)
+}
+
+// GetExitInterview returns the exit interview recorded for an employee
+// @Summary Get exit interview
+// @Description Get the exit interview recorded for an employee's offboarding, with answers (Admin only)
+// @Tags Exit Interviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} models.ExitInterview
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/employees/{id}/offboarding/exit-interview [get]
+func GetExitInterview(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var interview models.ExitInterview
+	if err := requestDB(c).Preload("Interviewer").Preload("QuestionSet").Preload("Responses.Question").
+		Where("employee_id = ?", employeeID).First(&interview).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Exit interview not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// GetExitInterviewReport returns an anonymized summary of exit interviews
+// @Summary Get exit interview report
+// @Description Summarise exit interviews held in a period by reason for leaving and department, with average scores for rating questions. No individual is identified: departments with fewer than 3 leavers are grouped as "Other", rating questions with fewer than 3 answers are left out, and the whole report is suppressed below 3 interviews (Admin only)
+// @Tags Exit Interviews
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 12 months ago"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} ExitInterviewReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/exit-interviews/report [get]
+func GetExitInterviewReport(c *gin.Context) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	from := to.AddDate(-1, 0, 0)
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD")
+			return
+		}
+		to = parsed
+	}
+
+	var interviews []models.ExitInterview
+	requestDB(c).Preload("Responses.Question").
+		Where("conducted_at >= ? AND conducted_at < ?", from, to.AddDate(0, 0, 1)).Find(&interviews)
+
+	report := ExitInterviewReport{
+		From:            from.Format("2006-01-02"),
+		To:              to.Format("2006-01-02"),
+		Total:           len(interviews),
+		ByPrimaryReason: map[string]int{},
+		ByAnyReason:     map[string]int{},
+		ByDepartment:    map[string]int{},
+		RatingAverages:  []ExitQuestionAverage{},
+	}
+	if report.Total < exitInterviewReportMinGroup {
+		report.Suppressed = report.Total > 0
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	departments := map[string]int{}
+	ratings := map[uint]*ExitQuestionAverage{}
+	var recommendAsked, recommendYes, returnAsked, returnYes int
+	for _, interview := range interviews {
+		report.ByPrimaryReason[string(interview.PrimaryReason)]++
+		report.ByAnyReason[string(interview.PrimaryReason)]++
+		if interview.SecondaryReason != nil {
+			report.ByAnyReason[string(*interview.SecondaryReason)]++
+		}
+		departments[interview.Department]++
+
+		if interview.WouldRecommend != nil {
+			recommendAsked++
+			if *interview.WouldRecommend {
+				recommendYes++
+			}
+		}
+		if interview.WouldReturn != nil {
+			returnAsked++
+			if *interview.WouldReturn {
+				returnYes++
+			}
+		}
+
+		for _, response := range interview.Responses {
+			if response.Rating == nil || response.Question.Type != models.ExitQuestionRating {
+				continue
+			}
+			average := ratings[response.QuestionID]
+			if average == nil {
+				average = &ExitQuestionAverage{QuestionID: response.QuestionID, Question: response.Question.Text}
+				ratings[response.QuestionID] = average
+			}
+			average.Responses++
+			average.Average += float64(*response.Rating)
+		}
+	}
+
+	for department, count := range departments {
+		if count < exitInterviewReportMinGroup || department == "" {
+			report.ByDepartment["Other"] += count
+			continue
+		}
+		report.ByDepartment[department] = count
+	}
+	// A lone small department folded into "Other" would still be identifiable
+	if other := report.ByDepartment["Other"]; other > 0 && other < exitInterviewReportMinGroup {
+		delete(report.ByDepartment, "Other")
+	}
+
+	if recommendAsked >= exitInterviewReportMinGroup {
+		rate := float64(recommendYes) / float64(recommendAsked) * 100
+		report.WouldRecommendRate = &rate
+	}
+	if returnAsked >= exitInterviewReportMinGroup {
+		rate := float64(returnYes) / float64(returnAsked) * 100
+		report.WouldReturnRate = &rate
+	}
+	for _, average := range ratings {
+		if average.Responses < exitInterviewReportMinGroup {
+			continue
+		}
+		average.Average /= float64(average.Responses)
+		report.RatingAverages = append(report.RatingAverages, *average)
+	}
+	sort.Slice(report.RatingAverages, func(i, j int) bool {
+		return report.RatingAverages[i].QuestionID < report.RatingAverages[j].QuestionID
+	})
+
+	c.JSON(http.StatusOK, report)
+}
+
+// buildExitQuestions validates question definitions and numbers them in the order given
+func buildExitQuestions(requests []ExitQuestionRequest) ([]models.ExitQuestion, error) {
+	questions := make([]models.ExitQuestion, 0, len(requests))
+	for i, req := range requests {
+		question := models.ExitQuestion{Text: req.Text, Type: req.Type, IsRequired: req.IsRequired, Order: i + 1}
+		switch req.Type {
+		case models.ExitQuestionText, models.ExitQuestionRating, models.ExitQuestionYesNo:
+		case models.ExitQuestionChoice:
+			var options []string
+			for _, option := range req.Options {
+				if option = strings.TrimSpace(option); option != "" {
+					if strings.Contains(option, ",") {
+						return nil, fmt.Errorf("question %d: options cannot contain commas", i+1)
+					}
+					options = append(options, option)
+				}
+			}
+			if len(options) < 2 {
+				return nil, fmt.Errorf("question %d: choice questions need at least two options", i+1)
+			}
+			joined := strings.Join(options, ",")
+			question.Options = &joined
+		default:
+			return nil, fmt.Errorf("question %d: invalid type %q. Use text, rating, yes_no or choice", i+1, req.Type)
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+// buildExitResponses checks answers against the question set and returns them as responses
+func buildExitResponses(questions []models.ExitQuestion, answers []ExitInterviewAnswer) ([]models.ExitInterviewResponse, error) {
+	byID := map[uint]models.ExitQuestion{}
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	answered := map[uint]bool{}
+	responses := make([]models.ExitInterviewResponse, 0, len(answers))
+	for _, answer := range answers {
+		question, ok := byID[answer.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("question %d is not part of this question set", answer.QuestionID)
+		}
+		if answered[question.ID] {
+			return nil, fmt.Errorf("question %d is answered more than once", question.ID)
+		}
+
+		response := models.ExitInterviewResponse{QuestionID: question.ID}
+		switch question.Type {
+		case models.ExitQuestionRating:
+			if answer.Rating == nil || *answer.Rating < 1 || *answer.Rating > 5 {
+				return nil, fmt.Errorf("question %d needs a rating from 1 to 5", question.ID)
+			}
+			response.Rating = answer.Rating
+		case models.ExitQuestionYesNo:
+			if answer.Answer == nil || (*answer.Answer != "yes" && *answer.Answer != "no") {
+				return nil, fmt.Errorf("question %d needs an answer of yes or no", question.ID)
+			}
+			response.Answer = answer.Answer
+		case models.ExitQuestionChoice:
+			valid := false
+			if answer.Answer != nil && question.Options != nil {
+				for _, option := range strings.Split(*question.Options, ",") {
+					if option == *answer.Answer {
+						valid = true
+						break
+					}
+				}
+			}
+			if !valid {
+				return nil, fmt.Errorf("question %d needs one of its options as the answer", question.ID)
+			}
+			response.Answer = answer.Answer
+		default:
+			if answer.Answer == nil || strings.TrimSpace(*answer.Answer) == "" {
+				continue
+			}
+			response.Answer = answer.Answer
+		}
+
+		answered[question.ID] = true
+		responses = append(responses, response)
+	}
+
+	for _, question := range questions {
+		if question.IsRequired && !answered[question.ID] {
+			return nil, fmt.Errorf("question %d is required", question.ID)
+		}
+	}
+	return responses, nil
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateExportJobRequest is the export to generate in the background
+type CreateExportJobRequest struct {
+	Format     string  `json:"format" binding:"omitempty,oneof=excel pdf" example:"excel"` // Defaults to excel
+	Department *string `json:"department,omitempty" example:"Finance"`
+	Status     *string `json:"status,omitempty" example:"active"`                                     // Employment status
+	Detailed   bool    `json:"detailed" example:"false"`                                              // Adds a summary sheet by department and a sheet per employee to an Excel file
+	Language   *string `json:"language,omitempty" binding:"omitempty,oneof=en fr pt" example:"fr"`    // Defaults to the Accept-Language header
+	PaperSize  *string `json:"paper_size,omitempty" binding:"omitempty,oneof=A4 Letter" example:"A4"` // Of a PDF; defaults to the export_paper_size setting
+}
+
+// CreateAnnualLeaveBalancesExportJob queues an export of annual leave balances
+// @Summary Queue annual leave balances export
+// @Description Queue an export of the annual leave balances of all employees to Excel or PDF, generated in the background in the language and on the paper size asked for. Poll GET /api/export-jobs/{id} until the job is completed, then download the file from its download_url. Use this instead of GET /api/hr/employees/annual-leave-balances/export for large organizations (Manager/Admin only)
+// @Tags HR - Leave Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateExportJobRequest true "Export to generate"
+// @Success 202 {object} models.ExportJob
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/employees/annual-leave-balances/export-jobs [post]
+func CreateAnnualLeaveBalancesExportJob(c *gin.Context) {
+	var req CreateExportJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if req.Format == "" {
+		req.Format = "excel"
+	}
+	locale := utils.DefaultExportLocale()
+	locale.Language = utils.RequestLanguage(c)
+	if req.Language != nil {
+		locale.Language = i18n.Language(*req.Language)
+	}
+	if req.PaperSize != nil {
+		locale.PaperSize = *req.PaperSize
+	}
+
+	job := models.ExportJob{
+		RequestedBy:      c.GetUint("user_id"),
+		Kind:             models.ExportAnnualLeaveBalances,
+		Format:           req.Format,
+		Department:       req.Department,
+		EmploymentStatus: req.Status,
+		Detailed:         req.Detailed,
+		Language:         string(locale.Language),
+		PaperSize:        locale.PaperSize,
+		Status:           models.ExportJobQueued,
+	}
+	if err := requestDB(c).Create(&job).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to queue export")
+		return
+	}
+	utils.StartExportJobs()
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetExportJobs lists the current user's export jobs
+// @Summary Get my export jobs
+// @Description List the export jobs the current user has queued, newest first. Jobs are deleted with their files a day after they finish
+// @Tags Export Jobs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ExportJob
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/export-jobs [get]
+func GetExportJobs(c *gin.Context) {
+	var jobs []models.ExportJob
+	if err := requestDB(c).Where("requested_by = ?", c.GetUint("user_id")).Order("id DESC").Find(&jobs).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch export jobs")
+		return
+	}
+	for i := range jobs {
+		setExportDownloadURL(&jobs[i])
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetExportJob returns the status of an export job
+// @Summary Get export job
+// @Description Get the status of an export job the current user queued. Once it is completed, download_url is a link to the file that works without signing in for 15 minutes; get the job again for a fresh link
+// @Tags Export Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Export job ID"
+// @Success 200 {object} models.ExportJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/export-jobs/{id} [get]
+func GetExportJob(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid export job ID")
+		return
+	}
+
+	var job models.ExportJob
+	if err := requestDB(c).Where("requested_by = ?", c.GetUint("user_id")).First(&job, uint(jobID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Export job not found")
+		return
+	}
+	setExportDownloadURL(&job)
+
+	c.JSON(http.StatusOK, job)
+}
+
+// DownloadExportJob downloads the file of a completed export job
+// @Summary Download export
+// @Description Download the file of a completed export job through the signed download_url of GET /api/export-jobs/{id}. No token is needed; the link expires after 15 minutes
+// @Tags Export Jobs
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Param id path int true "Export job ID"
+// @Param expires query int true "Expiry of the link, as a Unix time"
+// @Param signature query string true "Signature of the link"
+// @Success 200 {file} file "Excel or PDF file"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/export-jobs/{id}/download [get]
+func DownloadExportJob(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || !utils.VerifyExportDownload(uint(jobID), c.Query("expires"), c.Query("signature")) {
+		utils.RespondError(c, http.StatusForbidden, "Download link is invalid or has expired")
+		return
+	}
+
+	var job models.ExportJob
+	if err := requestDB(c).First(&job, uint(jobID)).Error; err != nil ||
+		job.Status != models.ExportJobCompleted || job.FilePath == nil || job.FileName == nil {
+		utils.RespondError(c, http.StatusNotFound, "Export not found")
+		return
+	}
+	if _, err := os.Stat(*job.FilePath); err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Export not found")
+		return
+	}
+
+	// Signed links carry no token, so the download is logged under the user who queued the export
+	noteFileAccess(c, models.FileAccessLog{OrganizationID: job.OrganizationID, UserID: &job.RequestedBy})
+	c.FileAttachment(*job.FilePath, *job.FileName)
+}
+
+// setExportDownloadURL gives a completed job a fresh signed link to its file
+func setExportDownloadURL(job *models.ExportJob) {
+	if job.Status == models.ExportJobCompleted {
+		job.DownloadURL = utils.ExportDownloadURL(*job)
+	}
+}
@@ -0,0 +1,671 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// grievanceReportMinGroup is the smallest department count shown in grievance reports; smaller groups
+// are merged so individual submitters cannot be singled out
+const grievanceReportMinGroup = 3
+
+// SubmitGrievanceRequest represents a new grievance
+type SubmitGrievanceRequest struct {
+	Category    models.GrievanceCategory `json:"category" binding:"required" example:"workload"`
+	Subject     string                   `json:"subject" binding:"required" example:"Unmanageable overtime expectations"`
+	Description string                   `json:"description" binding:"required" example:"Our team has been asked to work every Saturday for two months."`
+	IsAnonymous bool                     `json:"is_anonymous" example:"true"`
+}
+
+// AssignGrievanceRequest represents assigning a grievance to an HR case owner
+type AssignGrievanceRequest struct {
+	OwnerID uint `json:"owner_id" binding:"required" example:"3"`
+}
+
+// UpdateGrievanceStageRequest represents moving a grievance to its next stage
+type UpdateGrievanceStageRequest struct {
+	Stage      models.GrievanceStage `json:"stage" binding:"required" example:"investigating"`
+	Note       *string               `json:"note,omitempty" example:"Interviews scheduled with the team lead"`
+	Resolution *string               `json:"resolution,omitempty" example:"Overtime rota revised and agreed with the team"` // Required when resolving
+}
+
+// AddGrievanceNoteRequest represents a note on a grievance
+type AddGrievanceNoteRequest struct {
+	Note       string `json:"note" binding:"required" example:"Requested rota records from payroll"`
+	IsInternal bool   `json:"is_internal" example:"true"` // Internal notes are hidden from the submitter; ignored for submitters
+}
+
+// GrievanceResponse is a grievance together with its SLA status
+type GrievanceResponse struct {
+	models.Grievance
+	SLA utils.GrievanceSLA `json:"sla"`
+}
+
+// GrievanceReport is an anonymized summary of grievances for leadership
+type GrievanceReport struct {
+	From                  string         `json:"from" example:"2025-01-01"`
+	To                    string         `json:"to" example:"2025-03-31"`
+	Total                 int            `json:"total" example:"14"`
+	Open                  int            `json:"open" example:"5"`
+	Resolved              int            `json:"resolved" example:"9"`
+	ByCategory            map[string]int `json:"by_category"`
+	ByStage               map[string]int `json:"by_stage"`
+	ByDepartment          map[string]int `json:"by_department"` // Departments with fewer than 3 grievances are grouped as "Other"
+	AcknowledgeBreaches   int            `json:"acknowledge_breaches" example:"1"`
+	ResolveBreaches       int            `json:"resolve_breaches" example:"2"`
+	AvgHoursToAcknowledge float64        `json:"avg_hours_to_acknowledge" example:"20.5"`
+	AvgDaysToResolve      float64        `json:"avg_days_to_resolve" example:"17.3"`
+}
+
+// grievanceStageOrder defines the order grievances move through; stages can be skipped but not reversed
+var grievanceStageOrder = map[models.GrievanceStage]int{
+	models.GrievanceStageSubmitted:     0,
+	models.GrievanceStageAcknowledged:  1,
+	models.GrievanceStageInvestigating: 2,
+	models.GrievanceStageResolved:      3,
+}
+
+var grievanceCategories = map[models.GrievanceCategory]bool{
+	models.GrievanceCategoryHarassment:     true,
+	models.GrievanceCategoryDiscrimination: true,
+	models.GrievanceCategoryPay:            true,
+	models.GrievanceCategoryWorkload:       true,
+	models.GrievanceCategoryHealthSafety:   true,
+	models.GrievanceCategoryManagement:     true,
+	models.GrievanceCategoryOther:          true,
+}
+
+// SubmitGrievance raises a confidential grievance
+// @Summary Submit grievance
+// @Description Raise a confidential grievance. Anonymous grievances never reveal the submitter to HR or in reports; the submitter can still track them
+// @Tags Grievances
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SubmitGrievanceRequest true "Grievance"
+// @Success 201 {object} GrievanceResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/grievances [post]
+func SubmitGrievance(c *gin.Context) {
+	var req SubmitGrievanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if !grievanceCategories[req.Category] {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid category")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+
+	now := time.Now()
+	ackDue, resolveDue := utils.GrievanceDeadlines(now)
+	grievance := models.Grievance{
+		EmployeeID:       &user.ID,
+		IsAnonymous:      req.IsAnonymous,
+		Department:       user.Department,
+		Category:         req.Category,
+		Subject:          req.Subject,
+		Description:      req.Description,
+		Stage:            models.GrievanceStageSubmitted,
+		AcknowledgeDueAt: ackDue,
+		ResolveDueAt:     resolveDue,
+	}
+
+	tx := requestDB(c).Begin()
+	if err := tx.Create(&grievance).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to submit grievance")
+		return
+	}
+	grievance.Reference = fmt.Sprintf("GRV-%d-%05d", now.Year(), grievance.ID)
+	if err := tx.Model(&grievance).Update("reference", grievance.Reference).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to submit grievance")
+		return
+	}
+	tx.Commit()
+
+	// The audit trail records who performed each action, so anonymous submissions are not audited
+	if !grievance.IsAnonymous {
+		createAuditLog(models.AuditEntityGrievance, grievance.ID, models.AuditActionCreate, user.ID, c, nil, grievance)
+	}
+
+	c.JSON(http.StatusCreated, newGrievanceResponse(grievance))
+}
+
+// GetMyGrievances lists the grievances the current user has raised
+// @Summary Get my grievances
+// @Description List grievances raised by the current user, including anonymous ones
+// @Tags Grievances
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]GrievanceResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/grievances/mine [get]
+func GetMyGrievances(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	var grievances []models.Grievance
+	query := requestDB(c).Where("employee_id = ?", userID).Order("created_at DESC, id DESC")
+	response, err := paginate(query, pagination, &grievances)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch grievances")
+		return
+	}
+
+	responses := make([]GrievanceResponse, 0, len(grievances))
+	for _, grievance := range grievances {
+		responses = append(responses, newGrievanceResponse(grievance))
+	}
+	response.Data = responses
+
+	c.JSON(http.StatusOK, response)
+}
+
+// grievanceListFields are the filters and sort keys accepted by the grievance list
+var grievanceListFields = ListFields{
+	Filters: map[string]string{"stage": "stage", "category": "category", "owner_id": "owner_id"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "stage": "stage", "resolve_due_at": "resolve_due_at",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetGrievances lists grievances for HR case handling
+// @Summary Get grievances
+// @Description List grievances with their SLA status. Submitters of anonymous grievances are hidden (Admin only)
+// @Tags Grievances
+// @Produce json
+// @Security BearerAuth
+// @Param stage query string false "Stage (submitted, acknowledged, investigating, resolved)"
+// @Param category query string false "Category"
+// @Param owner_id query int false "Case owner ID"
+// @Param unassigned query bool false "Only grievances without a case owner"
+// @Param breached query bool false "Only open grievances that have missed a deadline"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, stage, resolve_due_at). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]GrievanceResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/grievances [get]
+func GetGrievances(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").Preload("Owner")
+	if c.Query("unassigned") == "true" {
+		query = query.Where("owner_id IS NULL")
+	}
+	if c.Query("breached") == "true" {
+		now := time.Now()
+		query = query.Where("stage != ?", models.GrievanceStageResolved).
+			Where("(acknowledged_at IS NULL AND acknowledge_due_at < ?) OR resolve_due_at < ?", now, now)
+	}
+
+	query, ok = applyListQuery(c, query, grievanceListFields)
+	if !ok {
+		return
+	}
+
+	var grievances []models.Grievance
+	response, err := paginate(query, pagination, &grievances)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch grievances")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	responses := make([]GrievanceResponse, 0, len(grievances))
+	for _, grievance := range grievances {
+		redactGrievance(&grievance, userID.(uint))
+		responses = append(responses, newGrievanceResponse(grievance))
+	}
+	response.Data = responses
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetGrievance returns a grievance with its history
+// @Summary Get grievance
+// @Description Get a grievance with its stage history and notes. Submitters see their own grievances without internal notes; admins see all
+// @Tags Grievances
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Grievance ID"
+// @Success 200 {object} GrievanceResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/grievances/{id} [get]
+func GetGrievance(c *gin.Context) {
+	grievance, user, ok := loadGrievanceForViewer(c)
+	if !ok {
+		return
+	}
+
+	updates := requestDB(c).Preload("Author").Where("grievance_id = ?", grievance.ID).Order("created_at")
+	if user.Role != models.RoleAdmin {
+		updates = updates.Where("is_internal = ?", false)
+	}
+	updates.Find(&grievance.Updates)
+
+	redactGrievance(&grievance, user.ID)
+	c.JSON(http.StatusOK, newGrievanceResponse(grievance))
+}
+
+// AssignGrievance assigns a grievance to an HR case owner
+// @Summary Assign grievance
+// @Description Assign a grievance to an admin who will own the case. The new owner is notified (Admin only)
+// @Tags Grievances
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Grievance ID"
+// @Param request body AssignGrievanceRequest true "Case owner"
+// @Success 200 {object} GrievanceResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/grievances/{id}/assign [put]
+func AssignGrievance(c *gin.Context) {
+	grievanceID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req AssignGrievanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var grievance models.Grievance
+	if err := requestDB(c).First(&grievance, grievanceID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Grievance not found")
+		return
+	}
+
+	var owner models.Employee
+	if err := requestDB(c).First(&owner, req.OwnerID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Case owner not found")
+		return
+	}
+	if owner.Role != models.RoleAdmin {
+		utils.RespondError(c, http.StatusBadRequest, "Case owner must be an admin")
+		return
+	}
+	if grievance.EmployeeID != nil && *grievance.EmployeeID == owner.ID {
+		utils.RespondError(c, http.StatusBadRequest, "A grievance cannot be owned by the person who raised it")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	oldValues := grievance
+	redactGrievance(&oldValues, 0)
+
+	note := "Assigned to " + owner.Firstname + " " + owner.Lastname
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Model(&grievance).Update("owner_id", owner.ID).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.GrievanceUpdate{
+			GrievanceID: grievance.ID,
+			AuthorID:    userID.(uint),
+			Note:        &note,
+			IsInternal:  true,
+		}).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to assign grievance")
+		return
+	}
+	grievance.OwnerID = &owner.ID
+	grievance.Owner = &owner
+
+	subject := i18n.M("Grievance %s assigned to you", grievance.Reference)
+	message := i18n.M("You are now the case owner for grievance %s (%s). Acknowledgement is due by %s.",
+		grievance.Reference, grievance.Category, grievance.AcknowledgeDueAt.Format("2006-01-02 15:04"))
+	values := map[string]string{
+		"grievance.reference": grievance.Reference, "grievance.category": string(grievance.Category),
+		"grievance.acknowledge_due_at": grievance.AcknowledgeDueAt.Format("2006-01-02 15:04"),
+	}
+	afterCommit(c, func() {
+		utils.Notify(owner, models.NotificationGrievanceAssigned, subject, message, values, models.AuditEntityGrievance, grievance.ID)
+	})
+
+	redactGrievance(&grievance, userID.(uint))
+	createAuditLog(models.AuditEntityGrievance, grievance.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, grievance)
+
+	c.JSON(http.StatusOK, newGrievanceResponse(grievance))
+}
+
+// UpdateGrievanceStage moves a grievance to a later stage
+// @Summary Update grievance stage
+// @Description Move a grievance forward to acknowledged, investigating or resolved. A resolution is required when resolving. The submitter is notified (Admin only)
+// @Tags Grievances
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Grievance ID"
+// @Param request body UpdateGrievanceStageRequest true "Stage"
+// @Success 200 {object} GrievanceResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/grievances/{id}/stage [put]
+func UpdateGrievanceStage(c *gin.Context) {
+	grievanceID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req UpdateGrievanceStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var grievance models.Grievance
+	if err := requestDB(c).Preload("Employee").First(&grievance, grievanceID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Grievance not found")
+		return
+	}
+
+	newOrder, ok := grievanceStageOrder[req.Stage]
+	if !ok || req.Stage == models.GrievanceStageSubmitted {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid stage. Use acknowledged, investigating or resolved")
+		return
+	}
+	if newOrder <= grievanceStageOrder[grievance.Stage] {
+		utils.RespondError(c, http.StatusBadRequest, "Grievance is already at or past stage "+string(req.Stage))
+		return
+	}
+	if req.Stage == models.GrievanceStageResolved && (req.Resolution == nil || *req.Resolution == "") {
+		utils.RespondError(c, http.StatusBadRequest, "resolution is required when resolving a grievance")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if grievance.EmployeeID != nil && *grievance.EmployeeID == userID.(uint) {
+		utils.RespondError(c, http.StatusForbidden, "You cannot handle a grievance you raised")
+		return
+	}
+
+	oldValues := grievance
+	redactGrievance(&oldValues, 0)
+	fromStage := grievance.Stage
+	now := time.Now()
+
+	// Skipped stages are stamped too so SLA reporting has a complete timeline
+	if grievance.AcknowledgedAt == nil {
+		grievance.AcknowledgedAt = &now
+	}
+	if newOrder >= grievanceStageOrder[models.GrievanceStageInvestigating] && grievance.InvestigationStartedAt == nil {
+		grievance.InvestigationStartedAt = &now
+	}
+	if req.Stage == models.GrievanceStageResolved {
+		grievance.ResolvedAt = &now
+		grievance.Resolution = req.Resolution
+	}
+	grievance.Stage = req.Stage
+
+	tx := requestDB(c).Begin()
+	if err := tx.Omit("Employee", "Owner", "Updates").Save(&grievance).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update grievance")
+		return
+	}
+	update := models.GrievanceUpdate{
+		GrievanceID: grievance.ID,
+		AuthorID:    userID.(uint),
+		FromStage:   &fromStage,
+		ToStage:     &req.Stage,
+		Note:        req.Note,
+	}
+	if err := tx.Create(&update).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update grievance")
+		return
+	}
+	tx.Commit()
+
+	if grievance.Employee != nil {
+		subject := i18n.M("Your grievance %s is now %s", grievance.Reference, grievance.Stage)
+		message := i18n.M("Your grievance \"%s\" has moved to the %s stage.", grievance.Subject, grievance.Stage)
+		if grievance.Resolution != nil && req.Stage == models.GrievanceStageResolved {
+			message = i18n.M("Your grievance \"%s\" has moved to the %s stage. Resolution: %s",
+				grievance.Subject, grievance.Stage, *grievance.Resolution)
+		}
+		values := map[string]string{
+			"grievance.reference": grievance.Reference, "grievance.subject": grievance.Subject, "grievance.stage": string(grievance.Stage),
+		}
+		if grievance.Resolution != nil {
+			values["grievance.resolution"] = *grievance.Resolution
+		}
+		afterCommit(c, func() {
+			utils.Notify(*grievance.Employee, models.NotificationGrievanceUpdated, subject, message, values, models.AuditEntityGrievance, grievance.ID)
+		})
+	}
+
+	redactGrievance(&grievance, userID.(uint))
+	createAuditLog(models.AuditEntityGrievance, grievance.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, grievance)
+
+	c.JSON(http.StatusOK, newGrievanceResponse(grievance))
+}
+
+// AddGrievanceNote adds a note to a grievance
+// @Summary Add grievance note
+// @Description Add a note to a grievance. Submitters can add information to their own grievances; admins can add internal notes hidden from the submitter
+// @Tags Grievances
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Grievance ID"
+// @Param request body AddGrievanceNoteRequest true "Note"
+// @Success 201 {object} models.GrievanceUpdate
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/grievances/{id}/notes [post]
+func AddGrievanceNote(c *gin.Context) {
+	var req AddGrievanceNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	grievance, user, ok := loadGrievanceForViewer(c)
+	if !ok {
+		return
+	}
+	if grievance.Stage == models.GrievanceStageResolved {
+		utils.RespondError(c, http.StatusBadRequest, "Grievance has been resolved")
+		return
+	}
+
+	isSubmitter := grievance.EmployeeID != nil && *grievance.EmployeeID == user.ID
+	update := models.GrievanceUpdate{
+		GrievanceID: grievance.ID,
+		AuthorID:    user.ID,
+		Note:        &req.Note,
+		IsInternal:  req.IsInternal && !isSubmitter,
+	}
+	if err := requestDB(c).Create(&update).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to add note")
+		return
+	}
+
+	if isSubmitter && grievance.IsAnonymous {
+		update.AuthorID = 0
+	}
+	c.JSON(http.StatusCreated, update)
+}
+
+// GetGrievanceReport returns an anonymized grievance summary for leadership
+// @Summary Get grievance report
+// @Description Summarise grievances raised in a period by category, stage and department with SLA performance. No individual is identified and departments with fewer than 3 grievances are grouped as "Other" (Admin only)
+// @Tags Grievances
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 90 days ago"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} GrievanceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/grievances/report [get]
+func GetGrievanceReport(c *gin.Context) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	from := to.AddDate(0, 0, -90)
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD")
+			return
+		}
+		to = parsed
+	}
+
+	var grievances []models.Grievance
+	requestDB(c).Where("created_at >= ? AND created_at < ?", from, to.AddDate(0, 0, 1)).Find(&grievances)
+
+	report := GrievanceReport{
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		Total:        len(grievances),
+		ByCategory:   map[string]int{},
+		ByStage:      map[string]int{},
+		ByDepartment: map[string]int{},
+	}
+
+	departments := map[string]int{}
+	var ackHours, resolveDays float64
+	acknowledged := 0
+	for _, grievance := range grievances {
+		report.ByCategory[string(grievance.Category)]++
+		report.ByStage[string(grievance.Stage)]++
+		departments[grievance.Department]++
+
+		if grievance.Stage == models.GrievanceStageResolved {
+			report.Resolved++
+		} else {
+			report.Open++
+		}
+
+		sla := utils.ComputeGrievanceSLA(grievance, now)
+		if sla.AcknowledgeBreached {
+			report.AcknowledgeBreaches++
+		}
+		if sla.ResolveBreached {
+			report.ResolveBreaches++
+		}
+		if grievance.AcknowledgedAt != nil {
+			ackHours += grievance.AcknowledgedAt.Sub(grievance.CreatedAt).Hours()
+			acknowledged++
+		}
+		if grievance.ResolvedAt != nil {
+			resolveDays += grievance.ResolvedAt.Sub(grievance.CreatedAt).Hours() / 24
+		}
+	}
+
+	for department, count := range departments {
+		if count < grievanceReportMinGroup || department == "" {
+			report.ByDepartment["Other"] += count
+			continue
+		}
+		report.ByDepartment[department] = count
+	}
+	// A lone small department folded into "Other" would still be identifiable
+	if other := report.ByDepartment["Other"]; other > 0 && other < grievanceReportMinGroup {
+		delete(report.ByDepartment, "Other")
+	}
+	if acknowledged > 0 {
+		report.AvgHoursToAcknowledge = ackHours / float64(acknowledged)
+	}
+	if report.Resolved > 0 {
+		report.AvgDaysToResolve = resolveDays / float64(report.Resolved)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// loadGrievanceForViewer loads the grievance in the path if the current user raised it or is an admin
+func loadGrievanceForViewer(c *gin.Context) (models.Grievance, *models.Employee, bool) {
+	grievanceID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var grievance models.Grievance
+	if err := requestDB(c).Preload("Employee").Preload("Owner").First(&grievance, grievanceID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Grievance not found")
+		return grievance, nil, false
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return grievance, nil, false
+	}
+	isSubmitter := grievance.EmployeeID != nil && *grievance.EmployeeID == user.ID
+	if !isSubmitter && user.Role != models.RoleAdmin {
+		utils.RespondError(c, http.StatusForbidden, "You can only view your own grievances")
+		return grievance, nil, false
+	}
+
+	return grievance, user, true
+}
+
+// redactGrievance hides the submitter of an anonymous grievance from everyone except the submitter
+func redactGrievance(grievance *models.Grievance, viewerID uint) {
+	if !grievance.IsAnonymous || (grievance.EmployeeID != nil && *grievance.EmployeeID == viewerID) {
+		return
+	}
+	submitterID := grievance.EmployeeID
+	grievance.EmployeeID = nil
+	grievance.Employee = nil
+	for i := range grievance.Updates {
+		if submitterID != nil && grievance.Updates[i].AuthorID == *submitterID {
+			grievance.Updates[i].AuthorID = 0
+			grievance.Updates[i].Author = nil
+		}
+	}
+}
+
+func newGrievanceResponse(grievance models.Grievance) GrievanceResponse {
+	return GrievanceResponse{Grievance: grievance, SLA: utils.ComputeGrievanceSLA(grievance, time.Now())}
+}
@@ -0,0 +1,462 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetHeadcountBudgetRequest represents data for setting a headcount budget
+type SetHeadcountBudgetRequest struct {
+	PositionID        *uint   `json:"position_id,omitempty" example:"1"` // Set for a position budget; omit for a department-wide budget
+	Department        string  `json:"department,omitempty" example:"IT"` // Required for department budgets; derived from the position otherwise
+	FiscalYear        int     `json:"fiscal_year" binding:"required" example:"2025"`
+	BudgetedHeadcount int     `json:"budgeted_headcount" binding:"min=0" example:"5"`
+	Notes             *string `json:"notes,omitempty" example:"Approved in annual budget"`
+}
+
+// HeadcountBudgetResponse represents a headcount budget with its current utilisation
+type HeadcountBudgetResponse struct {
+	models.HeadcountBudget
+	Filled    int `json:"filled" example:"3"`
+	Available int `json:"available" example:"2"`
+}
+
+// CreateHeadcountRequestRequest represents a request to increase headcount
+type CreateHeadcountRequestRequest struct {
+	PositionID        *uint   `json:"position_id,omitempty" example:"1"`
+	Department        string  `json:"department,omitempty" example:"IT"`
+	FiscalYear        int     `json:"fiscal_year" binding:"required" example:"2025"`
+	RequestedIncrease int     `json:"requested_increase" binding:"required,min=1" example:"2"`
+	Justification     *string `json:"justification,omitempty" example:"New client project"`
+}
+
+// ReviewHeadcountRequestRequest represents the reviewer's comment on a headcount request
+type ReviewHeadcountRequestRequest struct {
+	Comment *string `json:"comment,omitempty" example:"Approved for Q3 hiring"`
+}
+
+// GetHeadcountBudgets returns headcount budgets with current utilisation
+// @Summary Get headcount budgets
+// @Description Get headcount budgets per position/department with filled and available seats (Manager/Admin only)
+// @Tags Core HR - Headcount
+// @Produce json
+// @Security BearerAuth
+// @Param fiscal_year query int false "Fiscal year (defaults to current year)"
+// @Param department query string false "Filter by department"
+// @Success 200 {array} HeadcountBudgetResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/headcount/budgets [get]
+func GetHeadcountBudgets(c *gin.Context) {
+	fiscalYear := utils.CompanyToday().Year()
+	if fy, err := strconv.Atoi(c.Query("fiscal_year")); err == nil {
+		fiscalYear = fy
+	}
+
+	query := requestDB(c).Preload("Position").Where("fiscal_year = ?", fiscalYear)
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+
+	var budgets []models.HeadcountBudget
+	if err := query.Order("department, position_id").Find(&budgets).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch headcount budgets")
+		return
+	}
+
+	response := make([]HeadcountBudgetResponse, 0, len(budgets))
+	for _, budget := range budgets {
+		filled, err := countFilledHeadcount(requestDB(c), budget.PositionID, budget.Department, fiscalYearDate(fiscalYear))
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch headcount budgets")
+			return
+		}
+		response = append(response, HeadcountBudgetResponse{
+			HeadcountBudget: budget,
+			Filled:          filled,
+			Available:       budget.BudgetedHeadcount - filled,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetHeadcountBudget creates or replaces a headcount budget
+// @Summary Set headcount budget
+// @Description Create or replace the budgeted headcount for a position or department in a fiscal year (Admin only)
+// @Tags Core HR - Headcount
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SetHeadcountBudgetRequest true "Budget data"
+// @Success 200 {object} models.HeadcountBudget
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/headcount/budgets [post]
+func SetHeadcountBudget(c *gin.Context) {
+	var req SetHeadcountBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	department, status, errMsg := resolveHeadcountScope(requestDB(c), req.PositionID, req.Department)
+	if errMsg != "" {
+		utils.RespondError(c, status, errMsg)
+		return
+	}
+
+	user := getCurrentUser(c)
+	budget, err := findHeadcountBudget(requestDB(c), req.PositionID, department, req.FiscalYear)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch headcount budget")
+		return
+	}
+
+	oldValues := *budget
+	budget.BudgetedHeadcount = req.BudgetedHeadcount
+	budget.Notes = req.Notes
+	if budget.ID == 0 && user != nil {
+		budget.CreatedBy = &user.ID
+	}
+	if err := requestDB(c).Save(budget).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save headcount budget")
+		return
+	}
+
+	if user != nil {
+		if oldValues.ID == 0 {
+			createAuditLog(models.AuditEntityHeadcount, budget.ID, models.AuditActionCreate, user.ID, c, nil, budget)
+		} else {
+			createAuditLog(models.AuditEntityHeadcount, budget.ID, models.AuditActionUpdate, user.ID, c, oldValues, budget)
+		}
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// CreateHeadcountRequest submits a request to increase headcount
+// @Summary Request headcount increase
+// @Description Submit a request to increase the budgeted headcount of a position or department (Manager/Admin only)
+// @Tags Core HR - Headcount
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateHeadcountRequestRequest true "Headcount request"
+// @Success 201 {object} models.HeadcountRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/headcount/requests [post]
+func CreateHeadcountRequest(c *gin.Context) {
+	var req CreateHeadcountRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	department, status, errMsg := resolveHeadcountScope(requestDB(c), req.PositionID, req.Department)
+	if errMsg != "" {
+		utils.RespondError(c, status, errMsg)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request := models.HeadcountRequest{
+		PositionID:        req.PositionID,
+		Department:        department,
+		FiscalYear:        req.FiscalYear,
+		RequestedIncrease: req.RequestedIncrease,
+		Justification:     req.Justification,
+		Status:            models.HeadcountRequestPending,
+		RequestedBy:       userID.(uint),
+	}
+
+	if err := requestDB(c).Create(&request).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create headcount request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHeadcount, request.ID, models.AuditActionCreate, request.RequestedBy, c, nil, request)
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// headcountRequestListFields are the filters and sort keys accepted by the headcount request list
+var headcountRequestListFields = ListFields{
+	Filters: map[string]string{"status": "status", "fiscal_year": "fiscal_year"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "fiscal_year": "fiscal_year", "status": "status",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetHeadcountRequests lists headcount increase requests
+// @Summary Get headcount requests
+// @Description List headcount increase requests, optionally filtered by status and fiscal year (Manager/Admin only)
+// @Tags Core HR - Headcount
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected)"
+// @Param fiscal_year query int false "Fiscal year filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, fiscal_year, status). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.HeadcountRequest}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/headcount/requests [get]
+func GetHeadcountRequests(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Position").Preload("Requester").Preload("Reviewer")
+
+	query, ok = applyListQuery(c, query, headcountRequestListFields)
+	if !ok {
+		return
+	}
+
+	var requests []models.HeadcountRequest
+	response, err := paginate(query, pagination, &requests)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch headcount requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveHeadcountRequest approves a headcount request and increases the budget
+// @Summary Approve headcount request
+// @Description Approve a pending headcount request and add the requested seats to the matching budget (Admin only)
+// @Tags Core HR - Headcount
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Headcount request ID"
+// @Param request body ReviewHeadcountRequestRequest false "Review comment"
+// @Success 200 {object} models.HeadcountRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/headcount/requests/{id}/approve [put]
+func ApproveHeadcountRequest(c *gin.Context) {
+	reviewHeadcountRequest(c, models.HeadcountRequestApproved)
+}
+
+// RejectHeadcountRequest rejects a headcount request
+// @Summary Reject headcount request
+// @Description Reject a pending headcount request (Admin only)
+// @Tags Core HR - Headcount
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Headcount request ID"
+// @Param request body ReviewHeadcountRequestRequest false "Review comment"
+// @Success 200 {object} models.HeadcountRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/headcount/requests/{id}/reject [put]
+func RejectHeadcountRequest(c *gin.Context) {
+	reviewHeadcountRequest(c, models.HeadcountRequestRejected)
+}
+
+func reviewHeadcountRequest(c *gin.Context, newStatus models.HeadcountRequestStatus) {
+	requestID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req ReviewHeadcountRequestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	var request models.HeadcountRequest
+	if err := requestDB(c).First(&request, requestID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Headcount request not found")
+		return
+	}
+	if request.Status != models.HeadcountRequestPending {
+		utils.RespondError(c, http.StatusBadRequest, "Headcount request has already been reviewed")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	reviewerID := userID.(uint)
+	now := time.Now()
+	oldValues := request
+
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		request.Status = newStatus
+		request.ReviewedBy = &reviewerID
+		request.ReviewedAt = &now
+		request.ReviewComment = req.Comment
+		if err := tx.Save(&request).Error; err != nil {
+			return err
+		}
+
+		if newStatus != models.HeadcountRequestApproved {
+			return nil
+		}
+
+		budget, err := findHeadcountBudget(tx, request.PositionID, request.Department, request.FiscalYear)
+		if err != nil {
+			return err
+		}
+		budget.BudgetedHeadcount += request.RequestedIncrease
+		if budget.ID == 0 {
+			budget.CreatedBy = &reviewerID
+		}
+		return tx.Save(budget).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to review headcount request")
+		return
+	}
+
+	action := models.AuditActionReject
+	if newStatus == models.HeadcountRequestApproved {
+		action = models.AuditActionApprove
+	}
+	createAuditLog(models.AuditEntityHeadcount, request.ID, action, reviewerID, c, oldValues, request)
+
+	c.JSON(http.StatusOK, request)
+}
+
+// resolveHeadcountScope validates a position/department pair and returns the department the budget applies to
+func resolveHeadcountScope(db *gorm.DB, positionID *uint, department string) (string, int, string) {
+	if positionID == nil {
+		if department == "" {
+			return "", http.StatusBadRequest, "Either position_id or department is required"
+		}
+		return department, 0, ""
+	}
+
+	var position models.Position
+	if err := db.First(&position, *positionID).Error; err != nil {
+		return "", http.StatusNotFound, "Position not found"
+	}
+	return position.Department, 0, ""
+}
+
+// findHeadcountBudget loads the budget for a scope and fiscal year, or returns an unsaved one.
+// New position budgets start from the position's default headcount.
+func findHeadcountBudget(db *gorm.DB, positionID *uint, department string, fiscalYear int) (*models.HeadcountBudget, error) {
+	var budget models.HeadcountBudget
+	query := db.Where("department = ? AND fiscal_year = ?", department, fiscalYear)
+	if positionID != nil {
+		query = query.Where("position_id = ?", *positionID)
+	} else {
+		query = query.Where("position_id IS NULL")
+	}
+
+	err := query.First(&budget).Error
+	if err == nil {
+		return &budget, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	budget = models.HeadcountBudget{
+		PositionID: positionID,
+		Department: department,
+		FiscalYear: fiscalYear,
+	}
+	if positionID != nil {
+		var position models.Position
+		if err := db.First(&position, *positionID).Error; err == nil {
+			budget.BudgetedHeadcount = position.Headcount
+		}
+	}
+	return &budget, nil
+}
+
+// countFilledHeadcount counts the assignments active on a date for a position, or for every position
+// in a department
+func countFilledHeadcount(db *gorm.DB, positionID *uint, department string, onDate time.Time) (int, error) {
+	var count int64
+	query := db.Model(&models.PositionAssignment{}).
+		Where("position_assignments.start_date <= ?", onDate).
+		Where("position_assignments.end_date IS NULL OR position_assignments.end_date >= ?", onDate)
+	if positionID != nil {
+		query = query.Where("position_assignments.position_id = ?", *positionID)
+	} else {
+		query = query.Joins("JOIN positions ON positions.id = position_assignments.position_id").
+			Where("positions.department = ?", department)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// fiscalYearDate is the date within a fiscal year at which its filled headcount is counted: today
+// during the year, else its last day for a past year and its first for a future one
+func fiscalYearDate(fiscalYear int) time.Time {
+	today := utils.CompanyToday()
+	switch {
+	case fiscalYear < today.Year():
+		return time.Date(fiscalYear, time.December, 31, 0, 0, 0, 0, time.UTC)
+	case fiscalYear > today.Year():
+		return time.Date(fiscalYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return today
+}
+
+// checkHeadcountBudget returns warnings when adding one more assignment to the position on the given
+// date would exceed its budget, or the budget of its department, for that date's fiscal year
+func checkHeadcountBudget(db *gorm.DB, position models.Position, onDate time.Time) ([]string, error) {
+	var warnings []string
+	fiscalYear := onDate.Year()
+
+	budget, err := findHeadcountBudget(db, &position.ID, position.Department, fiscalYear)
+	if err == nil {
+		filled, err := countFilledHeadcount(db, &position.ID, position.Department, onDate)
+		if err != nil {
+			return nil, err
+		}
+		if filled+1 > budget.BudgetedHeadcount {
+			warnings = append(warnings, fmt.Sprintf("Position %s exceeds its %d budget of %d (filled: %d)",
+				position.Code, fiscalYear, budget.BudgetedHeadcount, filled))
+		}
+	}
+
+	var deptBudget models.HeadcountBudget
+	if err := db.Where("department = ? AND fiscal_year = ? AND position_id IS NULL", position.Department, fiscalYear).
+		First(&deptBudget).Error; err == nil {
+		filled, err := countFilledHeadcount(db, nil, position.Department, onDate)
+		if err != nil {
+			return nil, err
+		}
+		if filled+1 > deptBudget.BudgetedHeadcount {
+			warnings = append(warnings, fmt.Sprintf("Department %s exceeds its %d budget of %d (filled: %d)",
+				position.Department, fiscalYear, deptBudget.BudgetedHeadcount, filled))
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthCheckTimeout bounds each dependency check so a hung dependency fails the probe instead of stalling it
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck is the status of one dependency
+type HealthCheck struct {
+	Status    string  `json:"status" example:"ok"` // ok or error
+	LatencyMs float64 `json:"latency_ms" example:"1.8"`
+	Error     string  `json:"error,omitempty" example:"dial tcp 127.0.0.1:5432: connect: connection refused"`
+}
+
+// HealthResponse is the overall status with the status of each dependency
+type HealthResponse struct {
+	Status string                 `json:"status" example:"ok"` // ok, or unavailable when any check fails
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// LiveHealth reports that the process is running
+// @Summary Liveness probe
+// @Description Returns 200 while the process is able to serve requests. It does not check dependencies, so a database outage does not get the pod restarted
+// @Tags Health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /health/live [get]
+func LiveHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// ReadyHealth reports whether the API's dependencies are usable
+// @Summary Readiness probe
+// @Description Checks database connectivity, that document storage is writable and that no migrations are pending. Returns 503 with the failing checks when any check fails, so traffic is held back until the dependencies recover
+// @Tags Health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Failure 503 {object} HealthResponse
+// @Router /health/ready [get]
+func ReadyHealth(c *gin.Context) {
+	response := HealthResponse{Status: "ok", Checks: map[string]HealthCheck{}}
+
+	dbCheck := runHealthCheck(c.Request.Context(), checkDatabase)
+	response.Checks["database"] = dbCheck
+	response.Checks["documents"] = runHealthCheck(c.Request.Context(), checkDocumentStorage)
+	if dbCheck.Status == "ok" {
+		response.Checks["migrations"] = runHealthCheck(c.Request.Context(), checkMigrations)
+	} else {
+		response.Checks["migrations"] = HealthCheck{Status: "error", Error: "database unavailable"}
+	}
+
+	status := http.StatusOK
+	for _, check := range response.Checks {
+		if check.Status != "ok" {
+			response.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(status, response)
+}
+
+func runHealthCheck(ctx context.Context, check func(context.Context) error) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := HealthCheck{Status: "ok", LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func checkDatabase(ctx context.Context) error {
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// checkDocumentStorage writes and removes a file in the documents directory
+func checkDocumentStorage(ctx context.Context) error {
+	dir := config.AppConfig.DocumentsPath
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, ".health-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("ok"); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func checkMigrations(ctx context.Context) error {
+	pending, err := database.PendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		const shown = 5
+		columns := pending
+		if len(columns) > shown {
+			columns = append(columns[:shown:shown], fmt.Sprintf("and %d more", len(pending)-shown))
+		}
+		return fmt.Errorf("%d column(s) missing: %s", len(pending), strings.Join(columns, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// HolidayRequest represents data for adding a public holiday by hand
+type HolidayRequest struct {
+	CountryCode string `json:"country_code" binding:"required" example:"ZM"`
+	Date        string `json:"date" binding:"required" example:"2026-10-24"` // YYYY-MM-DD
+	Name        string `json:"name" binding:"required,max=200" example:"Independence Day"`
+	LocalName   string `json:"local_name,omitempty" binding:"max=200"`
+}
+
+// UpdateHolidayRequest represents changes to a public holiday
+type UpdateHolidayRequest struct {
+	Date      *string `json:"date,omitempty" example:"2026-10-26"` // YYYY-MM-DD
+	Name      *string `json:"name,omitempty" binding:"omitempty,max=200" example:"Independence Day (observed)"`
+	LocalName *string `json:"local_name,omitempty" binding:"omitempty,max=200"`
+}
+
+// ReviewHolidaysRequest activates or rejects public holidays
+type ReviewHolidaysRequest struct {
+	IDs    []uint               `json:"ids" binding:"required,min=1" example:"3,4,5"`
+	Status models.HolidayStatus `json:"status" binding:"required,oneof=active rejected" example:"active"`
+}
+
+// ReviewHolidaysResponse reports how many holidays a review changed
+type ReviewHolidaysResponse struct {
+	Message string `json:"message" example:"Holidays reviewed successfully"`
+	Updated int64  `json:"updated" example:"3"`
+}
+
+// ImportHolidaysRequest chooses the year to import public holidays for
+type ImportHolidaysRequest struct {
+	Year int `json:"year,omitempty" binding:"omitempty,min=2000,max=2100" example:"2026"` // Defaults to this year and next
+}
+
+// HolidayCountriesRequest sets the countries whose public holidays are imported
+type HolidayCountriesRequest struct {
+	Countries []string `json:"countries" binding:"required" example:"ZM"` // ISO 3166-1 alpha-2 codes; empty stops importing
+}
+
+// GetHolidays lists the organization's active public holidays
+// @Summary Get public holidays
+// @Description List the active public holidays of a year in the organization's holiday calendar, by date
+// @Tags Holidays
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year, defaults to this year"
+// @Param country query string false "Country code, e.g. ZM"
+// @Success 200 {array} models.PublicHoliday
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/holidays [get]
+func GetHolidays(c *gin.Context) {
+	query, ok := holidayQuery(c)
+	if !ok {
+		return
+	}
+	var holidays []models.PublicHoliday
+	if err := query.Where("status = ?", models.HolidayActive).Order("date, name").Find(&holidays).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch holidays")
+		return
+	}
+	c.JSON(http.StatusOK, holidays)
+}
+
+// GetAdminHolidays lists the organization's public holidays in every status
+// @Summary Get public holidays for review
+// @Description List the public holidays of a year in the organization's holiday calendar, including imported ones pending review and rejected ones (Admin only)
+// @Tags Admin - Holidays
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year, defaults to this year"
+// @Param country query string false "Country code, e.g. ZM"
+// @Param status query string false "Filter by status (pending, active, rejected)"
+// @Success 200 {array} models.PublicHoliday
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holidays [get]
+func GetAdminHolidays(c *gin.Context) {
+	query, ok := holidayQuery(c)
+	if !ok {
+		return
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var holidays []models.PublicHoliday
+	if err := query.Order("date, name").Find(&holidays).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch holidays")
+		return
+	}
+	c.JSON(http.StatusOK, holidays)
+}
+
+// CreateHoliday adds a public holiday by hand
+// @Summary Add a public holiday
+// @Description Add a holiday to the organization's holiday calendar, such as one declared at short notice. It is active straight away (Admin only)
+// @Tags Admin - Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body HolidayRequest true "Holiday"
+// @Success 201 {object} models.PublicHoliday
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holidays [post]
+func CreateHoliday(c *gin.Context) {
+	var req HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	countryCode := strings.ToUpper(strings.TrimSpace(req.CountryCode))
+	if !utils.IsCountryCode(countryCode) {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid country code. Use a two-letter ISO code such as ZM")
+		return
+	}
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	now := time.Now()
+	holiday := models.PublicHoliday{
+		CountryCode: countryCode,
+		Date:        date,
+		Name:        strings.TrimSpace(req.Name),
+		LocalName:   strings.TrimSpace(req.LocalName),
+		Source:      models.HolidaySourceManual,
+		Status:      models.HolidayActive,
+		ReviewedBy:  &userID,
+		ReviewedAt:  &now,
+	}
+	if err := requestDB(c).Create(&holiday).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create holiday")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHoliday, holiday.ID, models.AuditActionCreate, userID, c, nil, holiday)
+	c.JSON(http.StatusCreated, holiday)
+}
+
+// UpdateHoliday changes a public holiday
+// @Summary Update a public holiday
+// @Description Change a holiday's date or name, such as when it is observed on another day. Changes to imported holidays are kept by later imports (Admin only)
+// @Tags Admin - Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Holiday ID"
+// @Param request body UpdateHolidayRequest true "Changes"
+// @Success 200 {object} models.PublicHoliday
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holidays/{id} [put]
+func UpdateHoliday(c *gin.Context) {
+	holidayID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req UpdateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var holiday models.PublicHoliday
+	if err := requestDB(c).First(&holiday, holidayID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Holiday not found")
+		return
+	}
+	oldHoliday := holiday
+
+	if req.Date != nil {
+		date, err := time.Parse("2006-01-02", *req.Date)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		holiday.Date = date
+	}
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			utils.RespondError(c, http.StatusBadRequest, "Holiday name cannot be empty")
+			return
+		}
+		holiday.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.LocalName != nil {
+		holiday.LocalName = strings.TrimSpace(*req.LocalName)
+	}
+	holiday.Overridden = holiday.Source == models.HolidaySourceImport
+	if err := requestDB(c).Save(&holiday).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update holiday")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHoliday, holiday.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldHoliday, holiday)
+	c.JSON(http.StatusOK, holiday)
+}
+
+// DeleteHoliday deletes a public holiday added by hand
+// @Summary Delete a public holiday
+// @Description Delete a holiday added by hand. Imported holidays are rejected instead, so later imports do not add them again (Admin only)
+// @Tags Admin - Holidays
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holidays/{id} [delete]
+func DeleteHoliday(c *gin.Context) {
+	holidayID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var holiday models.PublicHoliday
+	if err := requestDB(c).First(&holiday, holidayID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Holiday not found")
+		return
+	}
+	if holiday.Source == models.HolidaySourceImport {
+		utils.RespondError(c, http.StatusBadRequest, "Imported holidays cannot be deleted; reject them instead so they are not imported again")
+		return
+	}
+	if err := requestDB(c).Delete(&holiday).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete holiday")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHoliday, holiday.ID, models.AuditActionDelete, c.GetUint("user_id"), c, holiday, nil)
+	c.JSON(http.StatusOK, gin.H{"message": "Holiday deleted successfully"})
+}
+
+// ReviewHolidays activates or rejects public holidays
+// @Summary Review public holidays
+// @Description Activate imported holidays once checked, or reject those the organization does not observe. Rejected holidays are kept so later imports do not add them again, and can be activated later (Admin only)
+// @Tags Admin - Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ReviewHolidaysRequest true "Holidays and their new status"
+// @Success 200 {object} ReviewHolidaysResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holidays/review [post]
+func ReviewHolidays(c *gin.Context) {
+	var req ReviewHolidaysRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	action := models.AuditActionApprove
+	if req.Status == models.HolidayRejected {
+		action = models.AuditActionReject
+	}
+	var updated int64
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		var holidays []models.PublicHoliday
+		if err := tx.Where("id IN ? AND status <> ?", req.IDs, req.Status).Find(&holidays).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, holiday := range holidays {
+			oldStatus := holiday.Status
+			if err := tx.Model(&holiday).Updates(map[string]interface{}{
+				"status": req.Status, "reviewed_by": userID, "reviewed_at": now,
+			}).Error; err != nil {
+				return err
+			}
+			if err := recordAuditLog(tx, models.AuditEntityHoliday, holiday.ID, action, userID, c,
+				gin.H{"status": oldStatus}, gin.H{"status": req.Status}); err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to review holidays")
+		return
+	}
+
+	c.JSON(http.StatusOK, ReviewHolidaysResponse{Message: "Holidays reviewed successfully", Updated: updated})
+}
+
+// ImportHolidays imports public holidays from the holiday provider now
+// @Summary Import public holidays
+// @Description Import the public holidays of the organization's holiday countries from Nager.Date now instead of waiting for the monthly import. New holidays are added pending review; holidays already in the calendar, including changed and rejected ones, are left alone. Holidays only observed in part of a country are skipped (Admin only)
+// @Tags Admin - Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ImportHolidaysRequest false "Year to import"
+// @Success 200 {array} utils.HolidayImportResult
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holidays/import [post]
+func ImportHolidays(c *gin.Context) {
+	var req ImportHolidaysRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+	years := []int{req.Year}
+	if req.Year == 0 {
+		thisYear := utils.CompanyToday().Year()
+		years = []int{thisYear, thisYear + 1}
+	}
+
+	results, err := utils.ImportPublicHolidays(requestDB(c), years)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to import holidays")
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// GetHolidayCountries lists the countries whose public holidays are imported
+// @Summary Get holiday countries
+// @Description List the countries whose public holidays the organization imports (Admin only)
+// @Tags Admin - Holidays
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.HolidayCountry
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holiday-countries [get]
+func GetHolidayCountries(c *gin.Context) {
+	var countries []models.HolidayCountry
+	if err := requestDB(c).Order("country_code").Find(&countries).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch holiday countries")
+		return
+	}
+	c.JSON(http.StatusOK, countries)
+}
+
+// UpdateHolidayCountries sets the countries whose public holidays are imported
+// @Summary Set holiday countries
+// @Description Replace the countries whose public holidays the organization imports each month. Holidays already imported are kept (Admin only)
+// @Tags Admin - Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body HolidayCountriesRequest true "Countries"
+// @Success 200 {array} models.HolidayCountry
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/holiday-countries [put]
+func UpdateHolidayCountries(c *gin.Context) {
+	var req HolidayCountriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	codes := []string{}
+	seen := map[string]bool{}
+	for _, code := range req.Countries {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !utils.IsCountryCode(code) {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid country code. Use a two-letter ISO code such as ZM")
+			return
+		}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	var oldCountries, countries []models.HolidayCountry
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Find(&oldCountries).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(&models.HolidayCountry{}).Error; err != nil {
+			return err
+		}
+		for _, code := range codes {
+			countries = append(countries, models.HolidayCountry{CountryCode: code})
+		}
+		if len(countries) > 0 {
+			return tx.Create(&countries).Error
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save holiday countries")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHoliday, 0, models.AuditActionUpdate, c.GetUint("user_id"), c,
+		gin.H{"countries": countryCodes(oldCountries)}, gin.H{"countries": codes})
+	if countries == nil {
+		countries = []models.HolidayCountry{}
+	}
+	c.JSON(http.StatusOK, countries)
+}
+
+// holidayQuery filters holidays on the year and country parameters
+func holidayQuery(c *gin.Context) (*gorm.DB, bool) {
+	year := utils.CompanyToday().Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 1900 || parsed > 9999 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid year")
+			return nil, false
+		}
+		year = parsed
+	}
+	query := requestDB(c).Where("date >= ? AND date < ?",
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if country := c.Query("country"); country != "" {
+		query = query.Where("country_code = ?", strings.ToUpper(country))
+	}
+	return query, true
+}
+
+func countryCodes(countries []models.HolidayCountry) []string {
+	codes := []string{}
+	for _, country := range countries {
+		codes = append(codes, country.CountryCode)
+	}
+	return codes
+}
@@ -0,0 +1,914 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"math"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// hrisSchemaVersion is the version of the HRIS interchange schema exports are written in and imports
+// must declare. It changes only when a field is renamed or removed.
+const hrisSchemaVersion = "1"
+
+// HRISField is a field of the HRIS interchange schema. Fields of nested objects are named with dots,
+// e.g. employment.hire_date is hire_date in a record's employment object.
+type HRISField struct {
+	Name        string `json:"name" example:"employment.hire_date"`
+	Type        string `json:"type" example:"date"` // string, integer, number, date (YYYY-MM-DD), month (YYYY-MM) or array
+	Description string `json:"description" example:"Defaults to the day of the import for new employees"`
+}
+
+// HRISSchema documents the HRIS interchange schema
+type HRISSchema struct {
+	Version            string       `json:"version" example:"1"`
+	Fields             []HRISField  `json:"fields"`               // Fields of an employee record
+	LeaveBalanceFields []HRISField  `json:"leave_balance_fields"` // Fields of each entry of a record's leave_balances
+	Example            HRISDocument `json:"example"`
+}
+
+// HRISDocument is a set of employee records in the HRIS interchange schema, as exported
+type HRISDocument struct {
+	SchemaVersion string                   `json:"schema_version" example:"1"`
+	ExportedAt    time.Time                `json:"exported_at"`
+	Mapping       string                   `json:"mapping,omitempty" example:"sage-300"` // Mapping the field names were written with
+	Employees     []map[string]interface{} `json:"employees"`
+}
+
+// HRISImportRequest is a set of employee records to import, in the HRIS interchange schema or in the
+// field names of a saved mapping
+type HRISImportRequest struct {
+	SchemaVersion   string                   `json:"schema_version" binding:"required,oneof=1" example:"1"`
+	Mapping         string                   `json:"mapping,omitempty" example:"sage-300"`                                       // Saved mapping to rename the records' fields with
+	DefaultPassword string                   `json:"default_password,omitempty" binding:"omitempty,min=6" example:"Welcome123!"` // Password of the employees the import creates
+	DryRun          bool                     `json:"dry_run,omitempty" example:"false"`                                          // Check every record without saving anything
+	Employees       []map[string]interface{} `json:"employees" binding:"required,min=1,max=5000"`
+}
+
+// HRISImportResponse summarises an HRIS import. Errors name the row by the record's position in
+// employees, counting from 1, and the column by the field name the record used.
+type HRISImportResponse struct {
+	ImportResponse
+	DryRun bool `json:"dry_run" example:"false"`
+}
+
+// HRISMappingRequest represents data for creating or replacing an HRIS field mapping
+type HRISMappingRequest struct {
+	Name        string            `json:"name" binding:"required,max=100" example:"sage-300"`
+	Description *string           `json:"description,omitempty" example:"Employee master export of the old payroll system"`
+	Fields      map[string]string `json:"fields" binding:"required,min=1"` // Field name in the other system -> schema field, or "" to drop it on import
+}
+
+var hrisEmployeeFields = []HRISField{
+	{Name: "nrc", Type: "string", Description: "National ID. Records are matched to employees by nrc, or by employee_number when there is no nrc. New employees need one"},
+	{Name: "employee_number", Type: "string", Description: "Payroll or staff number, kept on the employment details"},
+	{Name: "firstname", Type: "string", Description: "Needed for new employees"},
+	{Name: "lastname", Type: "string", Description: "Needed for new employees"},
+	{Name: "email", Type: "string", Description: "Work email address"},
+	{Name: "mobile", Type: "string", Description: "Mobile number, used for text message notifications"},
+	{Name: "department", Type: "string", Description: "Department name"},
+	{Name: "role", Type: "string", Description: "employee or manager. New employees default to employee"},
+	{Name: "employment.employment_type", Type: "string", Description: "full_time, part_time, contract, internship or consultant. New employees default to full_time"},
+	{Name: "employment.employment_status", Type: "string", Description: "active, on_leave, suspended, terminated or resigned. New employees default to active"},
+	{Name: "employment.hire_date", Type: "date", Description: "Defaults to the day of the import for new employees"},
+	{Name: "employment.start_date", Type: "date", Description: "Defaults to the hire date for new employees"},
+	{Name: "employment.end_date", Type: "date", Description: "End of a fixed-term contract"},
+	{Name: "employment.probation_end_date", Type: "date", Description: "End of the probation period"},
+	{Name: "employment.probation_status", Type: "string", Description: "e.g. in_progress, passed or extended"},
+	{Name: "employment.notice_period", Type: "integer", Description: "Notice period in days"},
+	{Name: "employment.work_location", Type: "string", Description: "Office or site"},
+	{Name: "employment.work_schedule", Type: "string", Description: "e.g. Standard or Shift"},
+	{Name: "employment.manager_nrc", Type: "string", Description: "NRC of the employee's manager, who must already be on file"},
+	{Name: "leave_balances", Type: "array", Description: "Balances of the leave types that track one, see leave_balance_fields"},
+}
+
+var hrisLeaveBalanceFields = []HRISField{
+	{Name: "leave_type", Type: "string", Description: "Name of the leave type, e.g. Annual"},
+	{Name: "balance", Type: "number", Description: "Days left at the start of as_of, before leave taken from then on"},
+	{Name: "as_of", Type: "month", Description: "Month the balance was taken at, after the first month of employment. Defaults to the current month"},
+	{Name: "accrued", Type: "number", Description: "Days earned up to as_of. Defaults to balance plus used"},
+	{Name: "used", Type: "number", Description: "Days taken up to as_of"},
+}
+
+var hrisSchemaFields = func() map[string]bool {
+	fields := map[string]bool{}
+	for _, field := range hrisEmployeeFields {
+		fields[field.Name] = true
+	}
+	return fields
+}()
+
+// errHRISDryRun rolls back a record's transaction in a dry run
+var errHRISDryRun = errors.New("dry run")
+
+// GetHRISSchema documents the HRIS interchange schema
+// @Summary Get the HRIS interchange schema
+// @Description Describe the JSON schema employees, their employment details and leave balances are exported and imported in, with an example document. Mappings rename these fields to those of another HR system (Admin only)
+// @Tags Admin - HRIS Connector
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} HRISSchema
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/admin/hris/schema [get]
+func GetHRISSchema(c *gin.Context) {
+	c.JSON(http.StatusOK, HRISSchema{
+		Version:            hrisSchemaVersion,
+		Fields:             hrisEmployeeFields,
+		LeaveBalanceFields: hrisLeaveBalanceFields,
+		Example: HRISDocument{
+			SchemaVersion: hrisSchemaVersion,
+			ExportedAt:    time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+			Employees: []map[string]interface{}{{
+				"nrc": "123456/78/9", "employee_number": "EMP001", "firstname": "Jane", "lastname": "Banda",
+				"email": "jane.banda@example.com", "mobile": "+260977000000", "department": "Finance", "role": "employee",
+				"employment": map[string]interface{}{
+					"employment_type": "full_time", "employment_status": "active", "hire_date": "2024-01-15",
+					"start_date": "2024-02-01", "notice_period": 30, "work_location": "Lusaka", "manager_nrc": "987654/32/1",
+				},
+				"leave_balances": []interface{}{
+					map[string]interface{}{"leave_type": "Annual", "as_of": "2026-09", "balance": 12.5, "used": 8},
+				},
+			}},
+		},
+	})
+}
+
+// ExportHRIS exports employees in the HRIS interchange schema
+// @Summary Export employees for another HR system
+// @Description Export every employee and manager with their employment details and current leave balances, in the HRIS interchange schema or in the field names of a saved mapping. Leave balances are as of the current month (Admin only)
+// @Tags Admin - HRIS Connector
+// @Produce json
+// @Security BearerAuth
+// @Param mapping query string false "Name of the saved mapping to write the field names with"
+// @Param leave_balances query bool false "Include leave balances (default true)"
+// @Success 200 {object} HRISDocument
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/hris/export [get]
+func ExportHRIS(c *gin.Context) {
+	mapping, ok := findHRISMappingByName(c, c.Query("mapping"))
+	if !ok {
+		return
+	}
+
+	var employees []models.Employee
+	if err := requestDB(c).Preload("Employment").Where("role <> ?", models.RoleAdmin).Order("id").Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+	managerNRCs := map[uint]string{}
+	for _, employee := range employees {
+		if employee.NRC != nil {
+			managerNRCs[employee.ID] = *employee.NRC
+		}
+	}
+	var leaveTypes []models.LeaveType
+	if c.Query("leave_balances") != "false" {
+		if err := requestDB(c).Where("uses_balance = ?", true).Order("name").Find(&leaveTypes).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leave types")
+			return
+		}
+	}
+
+	asOf := utils.CompanyNow().Format("2006-01")
+	renames := map[string]string{}
+	for source, field := range mapping.Fields {
+		if field != "" {
+			renames[field] = source
+		}
+	}
+	document := HRISDocument{SchemaVersion: hrisSchemaVersion, ExportedAt: utils.Now(), Mapping: mapping.Name, Employees: []map[string]interface{}{}}
+	for _, employee := range employees {
+		values := hrisEmployeeValues(employee, managerNRCs)
+		if len(leaveTypes) > 0 {
+			balances := []interface{}{}
+			for _, leaveType := range leaveTypes {
+				balance, err := utils.GetCurrentLeaveBalance(requestDB(c), employee.ID, leaveType.ID)
+				if err != nil {
+					continue
+				}
+				balances = append(balances, map[string]interface{}{"leave_type": leaveType.Name, "as_of": asOf, "balance": math.Round(balance*100) / 100})
+			}
+			values["leave_balances"] = balances
+		}
+		document.Employees = append(document.Employees, hrisNest(values, renames))
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+// ImportHRIS creates or updates employees from records in the HRIS interchange schema
+// @Summary Import employees from another HR system
+// @Description Create or update employees, their employment details and leave balances from records in the HRIS interchange schema, or in the field names of a saved mapping. Records are matched to employees by nrc, or by employee_number when there is no nrc; a record for an NRC not on file creates the employee, with default_password. Only the fields present are imported. Leave balances are set as initial balances for their as_of month, and accrual carries on from them. Each record is imported on its own, and records with an error are reported by position and field without affecting the others. With dry_run, every record is checked and nothing is saved (Admin only)
+// @Tags Admin - HRIS Connector
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body HRISImportRequest true "Records to import"
+// @Success 200 {object} HRISImportResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/hris/import [post]
+func ImportHRIS(c *gin.Context) {
+	var req HRISImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	mapping, ok := findHRISMappingByName(c, req.Mapping)
+	if !ok {
+		return
+	}
+	var passwordHash string
+	if req.DefaultPassword != "" {
+		hash, err := utils.HashPassword(req.DefaultPassword)
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to hash password")
+			return
+		}
+		passwordHash = hash
+	}
+
+	userID := c.GetUint("user_id")
+	response := HRISImportResponse{DryRun: req.DryRun}
+	for i, record := range req.Employees {
+		response.Total++
+		created := false
+		row, balances, err := hrisRecordRow(record, mapping.Fields)
+		row.line = i + 1
+		var employee models.Employee
+		if err == nil {
+			employee, err = findHRISEmployee(c, row)
+		}
+		if err == nil {
+			err = withTransaction(c, func(tx *gorm.DB) error {
+				var err error
+				created, err = importHRISRecord(tx, c, userID, employee, row, balances, passwordHash)
+				if err == nil && req.DryRun {
+					return errHRISDryRun
+				}
+				return err
+			})
+			if errors.Is(err, errHRISDryRun) {
+				err = nil
+			}
+		}
+		var cellErr *importCellError
+		if errors.As(err, &cellErr) {
+			cellErr.column = hrisSourceField(cellErr.column, mapping.Fields)
+		}
+		response.record(c, row.line, created, err)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// hrisRecordRow flattens a record's nested objects into dotted field names, renames them with the
+// mapping's fields, and returns its values as an import row, apart from the leave balances
+func hrisRecordRow(record map[string]interface{}, mapping map[string]string) (importRow, []interface{}, error) {
+	row := importRow{values: map[string]string{}}
+	var balances []interface{}
+	flat := map[string]interface{}{}
+	hrisFlatten("", record, flat)
+
+	sources := make([]string, 0, len(flat))
+	for source := range flat {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	seen := map[string]bool{}
+	for _, source := range sources {
+		field := source
+		if mapped, ok := mapping[source]; ok {
+			if mapped == "" {
+				continue
+			}
+			field = mapped
+		}
+		if !hrisSchemaFields[field] {
+			return row, nil, cellError(source, "Unknown field %s", source)
+		}
+		if seen[field] {
+			return row, nil, cellError(source, "Field %s is given more than once", field)
+		}
+		seen[field] = true
+
+		value := flat[source]
+		if field == "leave_balances" {
+			list, ok := value.([]interface{})
+			if !ok && value != nil {
+				return row, nil, cellError(source, "%s must be a list", source)
+			}
+			balances = list
+			continue
+		}
+		switch value := value.(type) {
+		case string:
+			if strings.TrimSpace(value) != "" {
+				row.values[field] = strings.TrimSpace(value)
+			}
+		case float64:
+			row.values[field] = strconv.FormatFloat(value, 'f', -1, 64)
+		case bool:
+			row.values[field] = strconv.FormatBool(value)
+		case []interface{}:
+			return row, nil, cellError(source, "%s cannot be a list", source)
+		}
+	}
+	return row, balances, nil
+}
+
+func hrisFlatten(prefix string, object map[string]interface{}, flat map[string]interface{}) {
+	for key, value := range object {
+		if nested, ok := value.(map[string]interface{}); ok {
+			hrisFlatten(prefix+key+".", nested, flat)
+			continue
+		}
+		flat[prefix+key] = value
+	}
+}
+
+// hrisNest renames dotted field names with renames and turns them back into nested objects
+func hrisNest(values map[string]interface{}, renames map[string]string) map[string]interface{} {
+	record := map[string]interface{}{}
+	for field, value := range values {
+		if source, ok := renames[field]; ok {
+			field = source
+		}
+		parts := strings.Split(field, ".")
+		object := record
+		for _, part := range parts[:len(parts)-1] {
+			nested, ok := object[part].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				object[part] = nested
+			}
+			object = nested
+		}
+		object[parts[len(parts)-1]] = value
+	}
+	return record
+}
+
+// hrisSourceField names a field of an import error as the record named it. Leave balance errors are
+// reported as e.g. leave_balances[2].balance.
+func hrisSourceField(field string, mapping map[string]string) string {
+	name, rest := field, ""
+	if i := strings.Index(field, "["); i >= 0 {
+		name, rest = field[:i], field[i:]
+	}
+	for source, mapped := range mapping {
+		if mapped == name {
+			return source + rest
+		}
+	}
+	return field
+}
+
+// findHRISEmployee finds the employee a record is for. A record with an NRC not on file is for a new
+// employee, and gets an empty Employee.
+func findHRISEmployee(c *gin.Context, row importRow) (models.Employee, error) {
+	_, hasNRC := row.values["nrc"]
+	_, hasNumber := row.values["employee_number"]
+	if !hasNRC && !hasNumber {
+		return models.Employee{}, cellError("nrc", "Record needs an nrc or employee_number")
+	}
+	employee, err := findImportEmployee(c, row)
+	if err != nil && hasNRC {
+		return models.Employee{}, nil
+	}
+	return employee, err
+}
+
+// importHRISRecord creates or updates an employee, their employment details and leave balances from a
+// record, and reports whether it created the employee
+func importHRISRecord(tx *gorm.DB, c *gin.Context, userID uint, employee models.Employee, row importRow, balances []interface{}, passwordHash string) (bool, error) {
+	created := employee.ID == 0
+	employment := importRow{line: row.line, values: map[string]string{}}
+	for field, value := range row.values {
+		if column, ok := strings.CutPrefix(field, "employment."); ok {
+			employment.values[column] = value
+		}
+	}
+	if number, ok := row.values["employee_number"]; ok {
+		employment.values["employee_number"] = number
+	}
+
+	if created {
+		if err := createHRISEmployee(tx, c, userID, &employee, row, passwordHash); err != nil {
+			return false, err
+		}
+		// As when an employee is created by hand, the start date follows the hire date, which defaults to today
+		if _, ok := employment.values["hire_date"]; !ok {
+			employment.values["hire_date"] = utils.CompanyToday().Format("2006-01-02")
+		}
+		if _, ok := employment.values["start_date"]; !ok {
+			employment.values["start_date"] = employment.values["hire_date"]
+		}
+	} else {
+		old := employee
+		changed, err := applyHRISEmployeeFields(&employee, row)
+		if err != nil {
+			return false, err
+		}
+		if changed {
+			if err := tx.Save(&employee).Error; err != nil {
+				return false, err
+			}
+			if err := recordAuditLog(tx, models.AuditEntityEmployee, employee.ID, models.AuditActionUpdate, userID, c, old, employee); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if created || len(employment.values) > 1 || (len(employment.values) == 1 && hrisEmployeeNumberChanged(tx, employee.ID, employment.values["employee_number"])) {
+		if _, err := importEmployment(tx, c, userID, employee, employment); err != nil {
+			var cellErr *importCellError
+			if errors.As(err, &cellErr) && cellErr.column != "" && cellErr.column != "employee_number" {
+				cellErr.column = "employment." + cellErr.column
+			}
+			return false, err
+		}
+	}
+
+	for i, balance := range balances {
+		if err := importHRISLeaveBalance(tx, employee, i+1, balance); err != nil {
+			return false, err
+		}
+	}
+	return created, nil
+}
+
+func createHRISEmployee(tx *gorm.DB, c *gin.Context, userID uint, employee *models.Employee, row importRow, passwordHash string) error {
+	nrc, ok := row.values["nrc"]
+	if !ok {
+		return cellError("nrc", "New employees need an nrc")
+	}
+	if row.values["firstname"] == "" || row.values["lastname"] == "" {
+		return cellError("firstname", "New employees need a firstname and lastname")
+	}
+	if passwordHash == "" {
+		return cellError("", "Give a default_password to create new employees")
+	}
+
+	nrc, err := utils.NormalizeNationalID(tx, c.GetUint("organization_id"), "", nrc)
+	if err != nil {
+		if message := nationalIDErrorMessage(utils.RequestLanguage(c), err, ""); message != "" {
+			return cellError("nrc", "%s", message)
+		}
+		return err
+	}
+	previousEmployeeID, err := formerEmployeeID(tx, nrc)
+	if err != nil {
+		return err
+	}
+
+	*employee = models.Employee{NRC: &nrc, PasswordHash: passwordHash, Role: models.RoleEmployee, PreviousEmployeeID: previousEmployeeID}
+	if _, err := applyHRISEmployeeFields(employee, row); err != nil {
+		return err
+	}
+	if err := tx.Create(employee).Error; err != nil {
+		return err
+	}
+	return recordAuditLog(tx, models.AuditEntityEmployee, employee.ID, models.AuditActionCreate, userID, c, nil, employee)
+}
+
+// applyHRISEmployeeFields sets the employee's own fields from a record, and reports whether any changed
+func applyHRISEmployeeFields(employee *models.Employee, row importRow) (bool, error) {
+	changed := false
+	setString := func(target *string, value string) {
+		if *target != value {
+			*target = value
+			changed = true
+		}
+	}
+	setOptional := func(target **string, value string) {
+		if *target == nil || **target != value {
+			*target = &value
+			changed = true
+		}
+	}
+
+	for _, field := range []string{"firstname", "lastname", "email", "mobile", "department", "role"} {
+		value, ok := row.values[field]
+		if !ok {
+			continue
+		}
+		switch field {
+		case "firstname":
+			setString(&employee.Firstname, value)
+		case "lastname":
+			setString(&employee.Lastname, value)
+		case "email":
+			if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+				return false, cellError(field, "Invalid email address %s", value)
+			}
+			setOptional(&employee.Email, value)
+		case "mobile":
+			setOptional(&employee.Mobile, value)
+		case "department":
+			setString(&employee.Department, value)
+		case "role":
+			if value != string(models.RoleEmployee) && value != string(models.RoleManager) {
+				return false, cellError(field, "Invalid role %s (must be employee or manager)", value)
+			}
+			if employee.Role == models.RoleAdmin {
+				return false, cellError(field, "Admin accounts cannot be changed through an import")
+			}
+			if employee.Role != models.Role(value) {
+				employee.Role = models.Role(value)
+				changed = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// hrisEmployeeNumberChanged reports whether number differs from the employee number on the employee's
+// employment details
+func hrisEmployeeNumberChanged(tx *gorm.DB, employeeID uint, number string) bool {
+	var details models.EmploymentDetails
+	if err := tx.Where("employee_id = ?", employeeID).First(&details).Error; err != nil {
+		return true
+	}
+	return details.EmployeeNumber == nil || *details.EmployeeNumber != number
+}
+
+// importHRISLeaveBalance sets a leave balance entry as the employee's initial balance for its as_of month,
+// as setting an initial balance by hand does, so that accrual carries on from it
+func importHRISLeaveBalance(tx *gorm.DB, employee models.Employee, position int, entry interface{}) error {
+	field := func(name string) string { return fmt.Sprintf("leave_balances[%d].%s", position, name) }
+	values, ok := entry.(map[string]interface{})
+	if !ok {
+		return cellError(field("leave_type"), "Each leave balance must be an object")
+	}
+	for name := range values {
+		known := false
+		for _, balanceField := range hrisLeaveBalanceFields {
+			known = known || balanceField.Name == name
+		}
+		if !known {
+			return cellError(field(name), "Unknown field %s", name)
+		}
+	}
+
+	name, _ := values["leave_type"].(string)
+	if strings.TrimSpace(name) == "" {
+		return cellError(field("leave_type"), "Leave balance needs a leave_type")
+	}
+	var leaveType models.LeaveType
+	if err := tx.Where("LOWER(name) = LOWER(?)", strings.TrimSpace(name)).First(&leaveType).Error; err != nil {
+		return cellError(field("leave_type"), "No leave type named %s", name)
+	}
+	if !leaveType.UsesBalance {
+		return cellError(field("leave_type"), "Leave type %s does not track a balance", leaveType.Name)
+	}
+
+	days := map[string]float64{}
+	for _, name := range []string{"balance", "accrued", "used"} {
+		value, present := values[name]
+		if !present || value == nil {
+			continue
+		}
+		number, ok := value.(float64)
+		if !ok {
+			return cellError(field(name), "%s must be a number", name)
+		}
+		days[name] = number
+	}
+	balance, ok := days["balance"]
+	if !ok {
+		return cellError(field("balance"), "Leave balance needs a balance")
+	}
+	if balance < 0 {
+		return cellError(field("balance"), "Balance cannot be negative")
+	}
+	used := days["used"]
+	accrued, ok := days["accrued"]
+	if !ok {
+		accrued = balance + used
+	}
+
+	now := utils.CompanyNow()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if value, present := values["as_of"]; present && value != nil {
+		asOf, _ := value.(string)
+		parsed, err := time.Parse("2006-01", asOf)
+		if err != nil {
+			return cellError(field("as_of"), "Invalid month, use YYYY-MM")
+		}
+		month = parsed
+	}
+	startDate := employee.CreatedAt
+	var details models.EmploymentDetails
+	if err := tx.Where("employee_id = ?", employee.ID).First(&details).Error; err == nil {
+		if details.HireDate != nil {
+			startDate = *details.HireDate
+		} else if details.StartDate != nil {
+			startDate = *details.StartDate
+		}
+	}
+	if month.Equal(time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC)) {
+		return cellError(field("as_of"), "Cannot set initial balance for the employee's first month of employment. Accrual starts from the second month.")
+	}
+
+	var accrual models.LeaveAccrual
+	oldBalance := 0.0
+	if err := tx.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?", employee.ID, leaveType.ID, month).First(&accrual).Error; err == nil {
+		oldBalance = accrual.DaysBalance
+	} else {
+		accrual = models.LeaveAccrual{EmployeeID: employee.ID, LeaveTypeID: leaveType.ID, AccrualMonth: &month}
+	}
+	notes := fmt.Sprintf("Initial balance set: %.2f days (was %.2f). Reason: Imported through the HRIS connector", balance, oldBalance)
+	if accrual.Notes != nil && *accrual.Notes != "" {
+		notes = *accrual.Notes + "\n" + notes
+	}
+	processedAt := utils.Now()
+	accrual.DaysAccrued = accrued
+	accrual.DaysUsed = used
+	accrual.DaysBalance = balance
+	accrual.IsProcessed = true
+	accrual.ProcessedAt = &processedAt
+	accrual.Notes = &notes
+	return tx.Save(&accrual).Error
+}
+
+// hrisEmployeeValues returns an employee's fields in the HRIS interchange schema, by dotted field name
+func hrisEmployeeValues(employee models.Employee, nrcs map[uint]string) map[string]interface{} {
+	values := map[string]interface{}{
+		"firstname": employee.Firstname, "lastname": employee.Lastname, "role": string(employee.Role),
+	}
+	setOptional := func(field string, value *string) {
+		if value != nil && *value != "" {
+			values[field] = *value
+		}
+	}
+	setDate := func(field string, value *time.Time) {
+		if value != nil {
+			values[field] = value.Format("2006-01-02")
+		}
+	}
+	setOptional("nrc", employee.NRC)
+	setOptional("employee_number", employee.EmployeeNumber)
+	setOptional("email", employee.Email)
+	setOptional("mobile", employee.Mobile)
+	if employee.Department != "" {
+		values["department"] = employee.Department
+	}
+
+	if details := employee.Employment; details != nil {
+		setOptional("employee_number", details.EmployeeNumber)
+		values["employment.employment_type"] = string(details.EmploymentType)
+		values["employment.employment_status"] = string(details.EmploymentStatus)
+		setDate("employment.hire_date", details.HireDate)
+		setDate("employment.start_date", details.StartDate)
+		setDate("employment.end_date", details.EndDate)
+		setDate("employment.probation_end_date", details.ProbationEndDate)
+		setOptional("employment.probation_status", details.ProbationStatus)
+		if details.NoticePeriod != nil {
+			values["employment.notice_period"] = *details.NoticePeriod
+		}
+		setOptional("employment.work_location", details.WorkLocation)
+		setOptional("employment.work_schedule", details.WorkSchedule)
+		if details.ManagerID != nil {
+			if nrc, ok := nrcs[*details.ManagerID]; ok {
+				values["employment.manager_nrc"] = nrc
+			}
+		}
+	}
+	return values
+}
+
+// GetHRISMappings lists the organization's HRIS field mappings
+// @Summary Get HRIS field mappings
+// @Description List the saved mappings that rename the fields of other HR systems to the HRIS interchange schema (Admin only)
+// @Tags Admin - HRIS Connector
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.HRISMapping
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/hris/mappings [get]
+func GetHRISMappings(c *gin.Context) {
+	var mappings []models.HRISMapping
+	if err := requestDB(c).Order("name").Find(&mappings).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch HRIS mappings")
+		return
+	}
+	c.JSON(http.StatusOK, mappings)
+}
+
+// CreateHRISMapping saves an HRIS field mapping
+// @Summary Create an HRIS field mapping
+// @Description Save a mapping from the field names of another HR system to the fields of the HRIS interchange schema, for imports and exports to refer to by name. Field names in the other system use dots for nested objects; mapping a field to "" drops it on import. Schema fields the mapping leaves out keep their own names (Admin only)
+// @Tags Admin - HRIS Connector
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body HRISMappingRequest true "HRIS mapping"
+// @Success 201 {object} models.HRISMapping
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/hris/mappings [post]
+func CreateHRISMapping(c *gin.Context) {
+	var req HRISMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if !checkHRISMapping(c, req, 0) {
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	mapping := models.HRISMapping{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Fields:      req.Fields,
+		CreatedBy:   &userID,
+	}
+	if err := requestDB(c).Create(&mapping).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create HRIS mapping")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHRISMapping, mapping.ID, models.AuditActionCreate, userID, c, nil, mapping)
+	c.JSON(http.StatusCreated, mapping)
+}
+
+// UpdateHRISMapping replaces an HRIS field mapping
+// @Summary Update an HRIS field mapping
+// @Description Replace the name, description and fields of an HRIS field mapping (Admin only)
+// @Tags Admin - HRIS Connector
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "HRIS mapping ID"
+// @Param request body HRISMappingRequest true "HRIS mapping"
+// @Success 200 {object} models.HRISMapping
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/hris/mappings/{id} [put]
+func UpdateHRISMapping(c *gin.Context) {
+	var req HRISMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	mapping, ok := findHRISMapping(c)
+	if !ok {
+		return
+	}
+	if !checkHRISMapping(c, req, mapping.ID) {
+		return
+	}
+	oldMapping := mapping
+
+	mapping.Name = strings.TrimSpace(req.Name)
+	mapping.Description = req.Description
+	mapping.Fields = req.Fields
+	if err := requestDB(c).Save(&mapping).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update HRIS mapping")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHRISMapping, mapping.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldMapping, mapping)
+	c.JSON(http.StatusOK, mapping)
+}
+
+// DeleteHRISMapping deletes an HRIS field mapping
+// @Summary Delete an HRIS field mapping
+// @Description Delete an HRIS field mapping (Admin only)
+// @Tags Admin - HRIS Connector
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "HRIS mapping ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/hris/mappings/{id} [delete]
+func DeleteHRISMapping(c *gin.Context) {
+	mapping, ok := findHRISMapping(c)
+	if !ok {
+		return
+	}
+	if err := requestDB(c).Delete(&mapping).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete HRIS mapping")
+		return
+	}
+
+	createAuditLog(models.AuditEntityHRISMapping, mapping.ID, models.AuditActionDelete, c.GetUint("user_id"), c, mapping, nil)
+	c.JSON(http.StatusOK, gin.H{"message": "HRIS mapping deleted successfully"})
+}
+
+func findHRISMapping(c *gin.Context) (models.HRISMapping, bool) {
+	mappingID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var mapping models.HRISMapping
+	if err := requestDB(c).First(&mapping, mappingID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "HRIS mapping not found")
+		return mapping, false
+	}
+	return mapping, true
+}
+
+// findHRISMappingByName returns the mapping an import or export names, or an empty mapping for none
+func findHRISMappingByName(c *gin.Context, name string) (models.HRISMapping, bool) {
+	var mapping models.HRISMapping
+	if name == "" {
+		return mapping, true
+	}
+	if err := requestDB(c).Where("name = ?", name).First(&mapping).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "HRIS mapping not found")
+		return mapping, false
+	}
+	return mapping, true
+}
+
+// checkHRISMapping rejects a mapping to fields the schema does not have, that would write two fields
+// under the same name or one inside another, or whose name another mapping already has, responding with
+// an error and returning false
+func checkHRISMapping(c *gin.Context, req HRISMappingRequest, mappingID uint) bool {
+	lang := utils.RequestLanguage(c)
+	// The names an export writes: the mapped names, and the schema fields the mapping leaves alone
+	names := map[string]string{}
+	mapped := map[string]string{}
+	for source, field := range req.Fields {
+		if strings.TrimSpace(source) == "" || strings.TrimSpace(source) != source || strings.Contains(source, "[") {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(lang, "Invalid field name %q", source))
+			return false
+		}
+		if field == "" {
+			continue
+		}
+		if !hrisSchemaFields[field] {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(lang, "Unknown schema field %s", field))
+			return false
+		}
+		if other, ok := mapped[field]; ok {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(lang, "Schema field %s is mapped from both %s and %s", field, other, source))
+			return false
+		}
+		mapped[field] = source
+		names[source] = field
+	}
+	for field := range hrisSchemaFields {
+		if _, ok := mapped[field]; !ok {
+			if other, clash := names[field]; clash {
+				utils.RespondError(c, http.StatusBadRequest, i18n.T(lang, "Field %s is mapped to %s but is also the name of schema field %s", field, other, field))
+				return false
+			}
+			names[field] = field
+		}
+	}
+	for name := range names {
+		for other := range names {
+			if strings.HasPrefix(other, name+".") {
+				utils.RespondError(c, http.StatusBadRequest, i18n.T(lang, "Fields %s and %s cannot both be written, as %s would hold %s", name, other, name, other))
+				return false
+			}
+		}
+	}
+
+	var count int64
+	err := requestDB(c).Model(&models.HRISMapping{}).
+		Where("name = ? AND id <> ?", strings.TrimSpace(req.Name), mappingID).
+		Count(&count).Error
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch HRIS mappings")
+		return false
+	}
+	if count > 0 {
+		utils.RespondError(c, http.StatusConflict, "An HRIS mapping with this name already exists")
+		return false
+	}
+	return true
+}
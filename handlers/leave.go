@@ -1,15 +1,19 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
-	"hrms-api/database"
+	"hrms-api/i18n"
 	"hrms-api/models"
+	"hrms-api/services"
 	"hrms-api/utils"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // ApplyLeaveRequest represents a leave application
@@ -24,11 +28,24 @@ type ApplyLeaveRequest struct {
 type LeaveBalanceResponse struct {
 	LeaveTypeID   uint   `json:"leave_type_id" example:"1"`
 	LeaveTypeName string `json:"leave_type_name" example:"Annual"`
+	Color         string `json:"color,omitempty" example:"#4CAF50"` // Leave type's display color and icon
+	Icon          string `json:"icon,omitempty" example:"beach_access"`
 	MaxDays       int    `json:"max_days" example:"20"`
 	UsedDays      int    `json:"used_days" example:"5"`
 	Balance       int    `json:"balance" example:"15"`
 }
 
+// LeaveHandler serves the leave request workflow through a LeaveService, so the business rules
+// behind it can be tested without a database
+type LeaveHandler struct {
+	leaves services.LeaveService
+}
+
+// NewLeaveHandler returns a LeaveHandler that runs the workflow through leaves
+func NewLeaveHandler(leaves services.LeaveService) *LeaveHandler {
+	return &LeaveHandler{leaves: leaves}
+}
+
 // ApplyLeave creates a new leave request
 // @Summary Apply for leave
 // @Description Submit a new leave request
@@ -38,138 +55,117 @@ type LeaveBalanceResponse struct {
 // @Security BearerAuth
 // @Param request body ApplyLeaveRequest true "Leave application data"
 // @Success 201 {object} models.Leave
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse "Overlapping leave exists"
 // @Router /api/leaves [post]
-func ApplyLeave(c *gin.Context) {
+func (h *LeaveHandler) ApplyLeave(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 	employeeID := userID.(uint)
 
 	var req ApplyLeaveRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Parse dates
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	endDate, err := time.Parse("2006-01-02", req.EndDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use YYYY-MM-DD"})
-		return
-	}
-
-	// Validate dates
-	if err := utils.ValidateLeaveDates(startDate, endDate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Check if leave type exists
-	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, req.LeaveTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave type not found"})
-		return
-	}
-
-	// Check for overlapping leaves
-	hasOverlap, err := utils.CheckOverlappingLeaves(employeeID, startDate, endDate, nil)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check overlapping leaves"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
 		return
 	}
-	if hasOverlap {
-		c.JSON(http.StatusConflict, gin.H{"error": utils.ErrOverlappingLeave.Error()})
-		return
-	}
-
-	// Only check balance and accrual for leave types that use balance (e.g. Annual); record-only types are just added
-	if leaveType.UsesBalance {
-		utils.EnsureAccrualsUpToDate(employeeID, req.LeaveTypeID)
-
-		// For annual leave with future start dates, calculate projected balance at start date
-		var balance float64
-		if startDate.After(time.Now()) {
-			projectedBalance, err := utils.CalculateProjectedAnnualLeaveBalance(employeeID, req.LeaveTypeID, startDate)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate projected leave balance"})
-				return
-			}
-			balance = projectedBalance
-		} else {
-			var err error
-			balance, err = utils.GetCurrentLeaveBalance(employeeID, req.LeaveTypeID)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate leave balance"})
-				return
-			}
-		}
-
-		leaveDuration := float64(int(endDate.Sub(startDate).Hours()/24) + 1)
-		if leaveDuration > balance {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":           utils.ErrInsufficientBalance.Error(),
-				"current_balance": balance,
-				"requested_days":  leaveDuration,
-				"message":         fmt.Sprintf("Insufficient leave balance. You have %.2f days available, but requested %.2f days.", balance, leaveDuration),
-			})
-			return
-		}
-	}
 
-	// Create leave request
-	leave := models.Leave{
+	leave, err := h.leaves.Apply(c.Request.Context(), services.ApplyLeaveInput{
 		EmployeeID:  employeeID,
 		LeaveTypeID: req.LeaveTypeID,
 		StartDate:   startDate,
 		EndDate:     endDate,
 		Reason:      req.Reason,
-		Status:      models.StatusPending,
-	}
-
-	if err := database.DB.Create(&leave).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create leave request"})
+		IPAddress:   c.ClientIP(),
+	})
+	if err != nil {
+		if !respondLeaveError(c, err) {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to create leave request")
+		}
 		return
 	}
 
-	// Create audit record
-	createAuditRecord(leave.ID, models.AuditActionCreate, employeeID, "", string(leave.Status), req.Reason, c.ClientIP())
-
-	// Load associations
-	database.DB.Preload("LeaveType").Preload("Employee").First(&leave, leave.ID)
+	// Managers and admins can approve the request, so each is told it is pending
+	var approvers []models.Employee
+	requestDB(c).Where("role IN ? AND status = ? AND id <> ?", []models.Role{models.RoleManager, models.RoleAdmin}, "active", employeeID).Find(&approvers)
+	applicant := strings.TrimSpace(leave.Employee.Firstname + " " + leave.Employee.Lastname)
+	values := leaveValues(leave)
+	values["employee.full_name"] = applicant
+	values["leave.reason"] = leave.Reason
+
+	organizationID := c.GetUint("organization_id")
+	afterCommit(c, func() {
+		utils.PublishEvent(utils.EventLeaveSubmitted, leave, organizationID, nil, models.RoleManager, models.RoleAdmin)
+		utils.DispatchWebhook(utils.EventLeaveSubmitted, organizationID, leave)
+		subject := i18n.M("New leave request from %s", applicant)
+		message := i18n.M("%s requested %s leave from %s to %s (%d day(s)). It is waiting for approval.",
+			applicant, leave.LeaveType.Name, values["leave.start_date"], values["leave.end_date"], leave.GetDuration())
+		for _, approver := range approvers {
+			utils.Notify(approver, models.NotificationLeaveSubmitted, subject, message, values, models.AuditEntityLeave, leave.ID)
+		}
+	})
 
 	c.JSON(http.StatusCreated, leave)
 }
 
+// leaveListFields are the filters and sort keys accepted by the leave lists
+var leaveListFields = ListFields{
+	Filters: map[string]string{"status": "status", "leave_type_id": "leave_type_id"},
+	Sorts: map[string]string{
+		"id": "id", "start_date": "start_date", "end_date": "end_date", "created_at": "created_at",
+		"status": "status",
+	},
+	DefaultSort: "-created_at",
+}
+
 // GetMyLeaves returns the leave history for the authenticated employee
 // @Summary Get my leave history
 // @Description Get all leave requests for the authenticated employee
 // @Tags Leaves
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Leave
+// @Param status query string false "Status filter (comma separated for several)"
+// @Param leave_type_id query int false "Leave type ID"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Leave}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /api/leaves [get]
 func GetMyLeaves(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
 	userID, _ := c.Get("user_id")
 	employeeID := userID.(uint)
 
 	var leaves []models.Leave
-	if err := database.DB.Where("employee_id = ?", employeeID).
-		Preload("LeaveType").
-		Order("created_at DESC").
-		Find(&leaves).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leaves"})
+	query, ok := applyListQuery(c, requestDB(c).Where("employee_id = ?", employeeID).Preload("LeaveType"), leaveListFields)
+	if !ok {
+		return
+	}
+	response, err := paginate(query, pagination, &leaves)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leaves")
 		return
 	}
 
-	c.JSON(http.StatusOK, leaves)
+	c.JSON(http.StatusOK, response)
 }
 
 // GetLeaveBalance returns the leave balance for all leave types
@@ -185,41 +181,26 @@ func GetLeaveBalance(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 	employeeID := userID.(uint)
 
-	// Get Annual leave type only
-	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	// Annual leave only: current leave year's balance from the 24 days entitlement and days used this leave year
+	summary, err := utils.GetAnnualLeaveSummary(requestDB(c), employeeID)
+	if err == utils.ErrNoAnnualLeaveType {
+		utils.RespondErrorCode(c, http.StatusNotFound, utils.CodeNoAnnualLeaveType, "Annual leave type not found", nil)
 		return
 	}
-
-	// Ensure accruals are up to date for annual leave
-	utils.EnsureAccrualsUpToDate(employeeID, annualLeaveType.ID)
-
-	// Get current year's balance (from 24 days annual entitlement)
-	currentBalance, err := utils.GetCurrentYearLeaveBalance(employeeID, annualLeaveType.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate leave balance"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate leave balance")
 		return
 	}
 
-	// Calculate used days in current year for annual leave only
-	now := time.Now()
-	currentYearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
-	var usedDays int
-	var leaves []models.Leave
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date >= ?",
-		employeeID, annualLeaveType.ID, models.StatusApproved, currentYearStart).Find(&leaves)
-	for _, leave := range leaves {
-		usedDays += leave.GetDuration()
-	}
-
 	// Return only annual leave balance
 	balance := LeaveBalanceResponse{
-		LeaveTypeID:   annualLeaveType.ID,
-		LeaveTypeName: annualLeaveType.Name,
-		MaxDays:       annualLeaveType.MaxDays,
-		UsedDays:      usedDays,
-		Balance:       int(currentBalance),
+		LeaveTypeID:   summary.LeaveType.ID,
+		LeaveTypeName: summary.LeaveType.Name,
+		Color:         summary.LeaveType.Color,
+		Icon:          summary.LeaveType.Icon,
+		MaxDays:       summary.LeaveType.MaxDays,
+		UsedDays:      summary.UsedDays,
+		Balance:       int(summary.Balance),
 	}
 
 	c.JSON(http.StatusOK, []LeaveBalanceResponse{balance})
@@ -231,22 +212,38 @@ func GetLeaveBalance(c *gin.Context) {
 // @Tags Manager
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Leave
+// @Param leave_type_id query int false "Leave type ID"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at). Defaults to created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Leave}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /api/leaves/pending [get]
 func GetPendingLeaves(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
 	var leaves []models.Leave
-	if err := database.DB.Where("status = ?", models.StatusPending).
+	fields := leaveListFields
+	fields.Filters = map[string]string{"leave_type_id": "leave_type_id"}
+	fields.DefaultSort = "created_at"
+	query, ok := applyListQuery(c, requestDB(c).Where("status = ?", models.StatusPending).
 		Preload("Employee").
-		Preload("LeaveType").
-		Order("created_at ASC").
-		Find(&leaves).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pending leaves"})
+		Preload("LeaveType"), fields)
+	if !ok {
+		return
+	}
+	response, err := paginate(query, pagination, &leaves)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch pending leaves")
 		return
 	}
 
-	c.JSON(http.StatusOK, leaves)
+	c.JSON(http.StatusOK, response)
 }
 
 // ApproveLeave approves a leave request
@@ -262,75 +259,35 @@ func GetPendingLeaves(c *gin.Context) {
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /api/leaves/{id}/approve [put]
-func ApproveLeave(c *gin.Context) {
+func (h *LeaveHandler) ApproveLeave(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	userID, _ := c.Get("user_id")
 	approverID := userID.(uint)
 
-	var leave models.Leave
-	if err := database.DB.Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave not found"})
-		return
-	}
-
-	if leave.Status != models.StatusPending {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Leave is not in pending status"})
-		return
-	}
-
-	// Only check balance and update carry-over for leave types that use balance; record-only types are just approved
-	if leave.LeaveType.UsesBalance {
-		utils.EnsureAccrualsUpToDate(leave.EmployeeID, leave.LeaveTypeID)
-
-		targetDate := &leave.StartDate
-		balance, err := utils.GetAvailableLeaveBalance(leave.EmployeeID, leave.LeaveTypeID, &leave.ID, targetDate)
-		if err != nil {
-			balance, err = utils.GetCurrentLeaveBalance(leave.EmployeeID, leave.LeaveTypeID)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate leave balance"})
-				return
-			}
-		}
-
-		leaveDuration := float64(leave.GetDuration())
-		if leaveDuration > balance {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":           "Insufficient leave balance",
-				"current_balance": balance,
-				"requested_days":  leaveDuration,
-				"message":         fmt.Sprintf("Insufficient leave balance. Available: %.2f days, Requested: %.2f days.", balance, leaveDuration),
-			})
-			return
+	leave, err := h.leaves.Approve(c.Request.Context(), uint(leaveID), approverID, c.ClientIP())
+	if err != nil {
+		if !respondLeaveError(c, err) {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to approve leave")
 		}
-	}
-
-	oldStatus := string(leave.Status)
-	now := time.Now()
-	leave.Status = models.StatusApproved
-	leave.ApprovedBy = &approverID
-	leave.ApprovedAt = &now
-
-	if err := database.DB.Save(&leave).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve leave"})
 		return
 	}
 
-	if leave.LeaveType.UsesBalance {
-		leaveDuration := float64(leave.GetDuration())
-		if leave.LeaveType.AllowCarryOver {
-			if err := utils.UpdateCarryOverUsage(leave.EmployeeID, leave.LeaveTypeID, leaveDuration); err != nil {
-				// Log error but don't fail the approval
-			}
-		}
-	}
-
-	// Create audit record
-	createAuditRecord(leave.ID, models.AuditActionApprove, approverID, oldStatus, string(leave.Status), "Approved", c.ClientIP())
+	organizationID := c.GetUint("organization_id")
+	values := leaveDecisionValues(c, leave, approverID)
+	afterCommit(c, func() {
+		utils.PublishEvent(utils.EventLeaveApproved, leave, organizationID, []uint{leave.EmployeeID}, models.RoleManager, models.RoleAdmin)
+		utils.DispatchWebhook(utils.EventLeaveApproved, organizationID, leave)
+		utils.SyncLeaveCalendars(leave.ID)
+		subject := i18n.M("Your %s leave was approved", leave.LeaveType.Name)
+		message := i18n.M("Your %s leave from %s to %s (%d day(s)) was approved.",
+			leave.LeaveType.Name, values["leave.start_date"], values["leave.end_date"], leave.GetDuration())
+		utils.Notify(leave.Employee, models.NotificationLeaveApproved, subject, message, values, models.AuditEntityLeave, leave.ID)
+	})
 
 	c.JSON(http.StatusOK, leave)
 }
@@ -350,54 +307,67 @@ type RejectLeaveRequest struct {
 // @Param id path int true "Leave ID"
 // @Param request body RejectLeaveRequest true "Rejection reason"
 // @Success 200 {object} models.Leave
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /api/leaves/{id}/reject [put]
-func RejectLeave(c *gin.Context) {
+func (h *LeaveHandler) RejectLeave(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	var req RejectLeaveRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Rejection reason is required"})
+		respondBindError(c, err)
 		return
 	}
 
 	userID, _ := c.Get("user_id")
 	approverID := userID.(uint)
 
-	var leave models.Leave
-	if err := database.DB.Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave not found"})
+	leave, err := h.leaves.Reject(c.Request.Context(), uint(leaveID), approverID, req.Reason, c.ClientIP())
+	if err != nil {
+		if !respondLeaveError(c, err) {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to reject leave")
+		}
 		return
 	}
 
-	if leave.Status != models.StatusPending {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Leave is not in pending status"})
-		return
-	}
+	organizationID := c.GetUint("organization_id")
+	values := leaveDecisionValues(c, leave, approverID)
+	values["leave.rejection_reason"] = leave.RejectionReason
+	afterCommit(c, func() {
+		utils.PublishEvent(utils.EventLeaveRejected, leave, organizationID, []uint{leave.EmployeeID}, models.RoleManager, models.RoleAdmin)
+		utils.DispatchWebhook(utils.EventLeaveRejected, organizationID, leave)
+		subject := i18n.M("Your %s leave was rejected", leave.LeaveType.Name)
+		message := i18n.M("Your %s leave from %s to %s was rejected: %s",
+			leave.LeaveType.Name, values["leave.start_date"], values["leave.end_date"], leave.RejectionReason)
+		utils.Notify(leave.Employee, models.NotificationLeaveRejected, subject, message, values, models.AuditEntityLeave, leave.ID)
+	})
 
-	oldStatus := string(leave.Status)
-	now := time.Now()
-	leave.Status = models.StatusRejected
-	leave.RejectionReason = req.Reason
-	leave.ApprovedBy = &approverID
-	leave.ApprovedAt = &now
+	c.JSON(http.StatusOK, leave)
+}
 
-	if err := database.DB.Save(&leave).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reject leave"})
-		return
+// leaveValues are the email template values of the notifications of a leave request
+func leaveValues(leave *models.Leave) map[string]string {
+	return map[string]string{
+		"leave.type":       leave.LeaveType.Name,
+		"leave.start_date": leave.StartDate.Format("2006-01-02"),
+		"leave.end_date":   leave.EndDate.Format("2006-01-02"),
+		"leave.days":       strconv.Itoa(leave.GetDuration()),
 	}
+}
 
-	// Create audit record
-	createAuditRecord(leave.ID, models.AuditActionReject, approverID, oldStatus, string(leave.Status), req.Reason, c.ClientIP())
-
-	c.JSON(http.StatusOK, leave)
+// leaveDecisionValues are the email template values of the notification of a leave decision
+func leaveDecisionValues(c *gin.Context, leave *models.Leave, approverID uint) map[string]string {
+	var approver models.Employee
+	requestDB(c).Select("id", "firstname", "lastname").Limit(1).Find(&approver, approverID)
+	values := leaveValues(leave)
+	values["approver.full_name"] = strings.TrimSpace(approver.Firstname + " " + approver.Lastname)
+	return values
 }
 
 // CancelLeave cancels a leave request
@@ -413,50 +383,30 @@ func RejectLeave(c *gin.Context) {
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /api/leaves/{id}/cancel [put]
-func CancelLeave(c *gin.Context) {
+func (h *LeaveHandler) CancelLeave(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	userID, _ := c.Get("user_id")
 	employeeID := userID.(uint)
 
-	var leave models.Leave
-	if err := database.DB.Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave not found"})
-		return
-	}
-
-	// Ensure employee can only cancel their own leave
-	if leave.EmployeeID != employeeID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only cancel your own leave requests"})
-		return
-	}
-
-	// Can only cancel pending or approved (future) leaves
-	if leave.Status != models.StatusPending && leave.Status != models.StatusApproved {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Only pending or approved leaves can be cancelled"})
-		return
-	}
-
-	// For approved leaves, can only cancel if start date is in the future
-	if leave.Status == models.StatusApproved && !leave.StartDate.After(time.Now()) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot cancel leave that has already started"})
-		return
-	}
-
-	oldStatus := string(leave.Status)
-	leave.Status = models.StatusCancelled
-
-	if err := database.DB.Save(&leave).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel leave"})
+	leave, err := h.leaves.Cancel(c.Request.Context(), uint(leaveID), employeeID, c.ClientIP())
+	if err != nil {
+		if !respondLeaveError(c, err) {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to cancel leave")
+		}
 		return
 	}
 
-	// Create audit record
-	createAuditRecord(leave.ID, models.AuditActionCancel, employeeID, oldStatus, string(leave.Status), "Cancelled by employee", c.ClientIP())
+	organizationID := c.GetUint("organization_id")
+	afterCommit(c, func() {
+		utils.PublishEvent(utils.EventLeaveCancelled, leave, organizationID, nil, models.RoleManager, models.RoleAdmin)
+		utils.DispatchWebhook(utils.EventLeaveCancelled, organizationID, leave)
+		utils.SyncLeaveCalendars(leave.ID)
+	})
 
 	c.JSON(http.StatusOK, leave)
 }
@@ -476,24 +426,60 @@ func CancelLeave(c *gin.Context) {
 func GetLeaveAudit(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	var audits []models.LeaveAudit
-	if err := database.DB.Where("leave_id = ?", uint(leaveID)).
+	if err := requestDB(c).Where("leave_id = ?", uint(leaveID)).
 		Preload("Performer").
 		Order("created_at ASC").
 		Find(&audits).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit records"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch audit records")
 		return
 	}
 
 	c.JSON(http.StatusOK, audits)
 }
 
-// Helper function to create audit records
-func createAuditRecord(leaveID uint, action models.AuditAction, performedBy uint, oldStatus, newStatus, comment, ipAddress string) {
+// respondLeaveError writes the response for an error from the leave workflow. It reports false when
+// err is not one of the workflow's own errors and the caller still has to respond.
+func respondLeaveError(c *gin.Context, err error) bool {
+	var insufficient *services.InsufficientBalanceError
+	switch {
+	case errors.As(err, &insufficient):
+		respondInsufficientBalance(c, insufficient.Available, insufficient.Requested)
+	case errors.Is(err, utils.ErrInvalidDateRange), errors.Is(err, utils.ErrPastDate):
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+	case errors.Is(err, utils.ErrOverlappingLeave):
+		utils.RespondErrorFrom(c, http.StatusConflict, err)
+	case errors.Is(err, services.ErrLeaveTypeNotFound):
+		utils.RespondError(c, http.StatusNotFound, "Leave type not found")
+	case errors.Is(err, services.ErrLeaveNotFound):
+		utils.RespondErrorCode(c, http.StatusNotFound, utils.CodeLeaveNotFound, "Leave not found", nil)
+	case errors.Is(err, services.ErrLeaveNotPending):
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeLeaveNotPending, "Leave is not in pending status", nil)
+	case errors.Is(err, services.ErrNotLeaveOwner):
+		utils.RespondError(c, http.StatusForbidden, "You can only cancel your own leave requests")
+	case errors.Is(err, services.ErrLeaveNotCancellable):
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeLeaveNotCancellable, "Only pending or approved leaves can be cancelled", nil)
+	case errors.Is(err, services.ErrLeaveAlreadyStarted):
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeLeaveAlreadyStarted, "Cannot cancel leave that has already started", nil)
+	default:
+		return false
+	}
+	return true
+}
+
+// respondInsufficientBalance reports a leave that needs more days than the employee has available
+func respondInsufficientBalance(c *gin.Context, available, requested float64) {
+	utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeInsufficientBalance,
+		fmt.Sprintf("Insufficient leave balance. Available: %.2f days, Requested: %.2f days.", available, requested),
+		gin.H{"current_balance": available, "requested_days": requested})
+}
+
+// Helper function to create audit records; pass the transaction that changes the leave so both are saved together
+func createAuditRecord(db *gorm.DB, leaveID uint, action models.AuditAction, performedBy uint, oldStatus, newStatus, comment, ipAddress string) error {
 	audit := models.LeaveAudit{
 		LeaveID:     leaveID,
 		Action:      action,
@@ -503,5 +489,5 @@ func createAuditRecord(leaveID uint, action models.AuditAction, performedBy uint
 		Comment:     comment,
 		IPAddress:   ipAddress,
 	}
-	database.DB.Create(&audit)
+	return db.Create(&audit).Error
 }
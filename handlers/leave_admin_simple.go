@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"hrms-api/database"
 	"hrms-api/models"
 	"hrms-api/utils"
 	"net/http"
@@ -29,7 +28,7 @@ type RecordLeaveTakenRequest struct {
 // @Security BearerAuth
 // @Param request body RecordLeaveTakenRequest true "Leave taken data"
 // @Success 201 {object} models.LeaveTaken
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -37,40 +36,40 @@ type RecordLeaveTakenRequest struct {
 func RecordLeaveTaken(c *gin.Context) {
 	var req RecordLeaveTakenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Verify employee exists
 	var employee models.Employee
-	if err := database.DB.First(&employee, req.EmployeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, req.EmployeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
 	// Verify leave type exists
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, req.LeaveTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave type not found"})
+	if err := requestDB(c).First(&leaveType, req.LeaveTypeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Leave type not found")
 		return
 	}
 
 	// Parse dates
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	endDate, err := time.Parse("2006-01-02", req.EndDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	// Validate dates
 	if endDate.Before(startDate) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "End date must be after or equal to start date"})
+		utils.RespondError(c, http.StatusBadRequest, "End date must be after or equal to start date")
 		return
 	}
 
@@ -96,13 +95,13 @@ func RecordLeaveTaken(c *gin.Context) {
 	// Calculate days taken (in case we need to override)
 	leaveTaken.DaysTaken = leaveTaken.CalculateDaysTaken()
 
-	if err := database.DB.Create(&leaveTaken).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create leave taken record"})
+	if err := requestDB(c).Create(&leaveTaken).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create leave taken record")
 		return
 	}
 
 	// Load associations
-	database.DB.Preload("Employee").Preload("LeaveType").Preload("Recorder").First(&leaveTaken, leaveTaken.ID)
+	requestDB(c).Preload("Employee").Preload("LeaveType").Preload("Recorder").First(&leaveTaken, leaveTaken.ID)
 
 	c.JSON(http.StatusCreated, leaveTaken)
 }
@@ -123,14 +122,14 @@ func RecordLeaveTaken(c *gin.Context) {
 func GetLeaveBalanceSimple(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
 	// Verify employee exists
 	var employee models.Employee
-	if err := database.DB.First(&employee, employeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
@@ -140,15 +139,15 @@ func GetLeaveBalanceSimple(c *gin.Context) {
 	if leaveTypeIDStr != "" {
 		parsed, err := strconv.ParseUint(leaveTypeIDStr, 10, 32)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave_type_id"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid leave_type_id")
 			return
 		}
 		leaveTypeID = uint(parsed)
 	} else {
 		// Default to Annual leave
 		var annualLeaveType models.LeaveType
-		if err := database.DB.Where("name = ?", "Annual").First(&annualLeaveType).Error; err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+		if err := requestDB(c).Where("name = ?", "Annual").First(&annualLeaveType).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 			return
 		}
 		leaveTypeID = annualLeaveType.ID
@@ -157,19 +156,19 @@ func GetLeaveBalanceSimple(c *gin.Context) {
 	// Calculate balance using simplified formula
 	balance, err := utils.CalculateLeaveBalanceSimple(uint(employeeID), leaveTypeID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate leave balance"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate leave balance")
 		return
 	}
 
 	// Get total accrued and total taken for details
 	var totalAccrued float64
-	database.DB.Model(&models.LeaveAccrual{}).
+	requestDB(c).Model(&models.LeaveAccrual{}).
 		Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
 		Select("COALESCE(SUM(days_accrued), 0)").
 		Scan(&totalAccrued)
 
 	var totalTaken float64
-	database.DB.Model(&models.LeaveTaken{}).
+	requestDB(c).Model(&models.LeaveTaken{}).
 		Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
 		Select("COALESCE(SUM(days_taken), 0)").
 		Scan(&totalTaken)
@@ -200,19 +199,19 @@ func GetLeaveBalanceSimple(c *gin.Context) {
 func GetEmployeeLeaveHistory(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
 	// Verify employee exists
 	var employee models.Employee
-	if err := database.DB.First(&employee, employeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
 	// Build query
-	query := database.DB.Where("employee_id = ?", employeeID).
+	query := requestDB(c).Where("employee_id = ?", employeeID).
 		Preload("Employee").
 		Preload("LeaveType").
 		Preload("Recorder").
@@ -229,7 +228,7 @@ func GetEmployeeLeaveHistory(c *gin.Context) {
 
 	var leaveTaken []models.LeaveTaken
 	if err := query.Find(&leaveTaken).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leave history"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leave history")
 		return
 	}
 
@@ -260,8 +259,8 @@ func GetAllEmployeesLeaveBalancesSimple(c *gin.Context) {
 	if leaveTypeID == 0 {
 		// Default to Annual leave
 		var annualLeaveType models.LeaveType
-		if err := database.DB.Where("name = ?", "Annual").First(&annualLeaveType).Error; err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+		if err := requestDB(c).Where("name = ?", "Annual").First(&annualLeaveType).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 			return
 		}
 		leaveTypeID = annualLeaveType.ID
@@ -269,8 +268,8 @@ func GetAllEmployeesLeaveBalancesSimple(c *gin.Context) {
 
 	// Get all active employees (exclude admins)
 	var employees []models.Employee
-	if err := database.DB.Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch employees"})
+	if err := requestDB(c).Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
 		return
 	}
 
@@ -285,13 +284,13 @@ func GetAllEmployeesLeaveBalancesSimple(c *gin.Context) {
 
 		// Get totals for details
 		var totalAccrued float64
-		database.DB.Model(&models.LeaveAccrual{}).
+		requestDB(c).Model(&models.LeaveAccrual{}).
 			Where("employee_id = ? AND leave_type_id = ?", emp.ID, leaveTypeID).
 			Select("COALESCE(SUM(days_accrued), 0)").
 			Scan(&totalAccrued)
 
 		var totalTaken float64
-		database.DB.Model(&models.LeaveTaken{}).
+		requestDB(c).Model(&models.LeaveTaken{}).
 			Where("employee_id = ? AND leave_type_id = ?", emp.ID, leaveTypeID).
 			Select("COALESCE(SUM(days_taken), 0)").
 			Scan(&totalTaken)
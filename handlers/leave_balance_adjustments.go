@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LeaveBalanceAdjustment is one employee's annual leave balance adjustment in a bulk adjustment
+type LeaveBalanceAdjustment struct {
+	NRC            string  `json:"nrc,omitempty" example:"123456/78/9"`
+	EmployeeNumber string  `json:"employee_number,omitempty" example:"EMP001"` // Used when there is no nrc
+	Days           float64 `json:"days" example:"-2.5"`                        // Days to add, or to take off when negative
+	Reason         string  `json:"reason,omitempty" example:"Carry-over above the cap forfeited"`
+}
+
+// BulkAdjustLeaveBalancesRequest is a list of annual leave balance adjustments
+type BulkAdjustLeaveBalancesRequest struct {
+	Adjustments []LeaveBalanceAdjustment `json:"adjustments" binding:"required,min=1,max=5000"`
+	Reason      string                   `json:"reason,omitempty" example:"2025 year-start corrections"` // Reason of the adjustments that do not give one
+	DryRun      bool                     `json:"dry_run,omitempty" example:"false"`                      // Check every adjustment without saving anything
+}
+
+// LeaveBalanceAdjustmentResult is an adjustment made, or that would be made in a dry run
+type LeaveBalanceAdjustmentResult struct {
+	Row             int     `json:"row" example:"2"`
+	EmployeeID      uint    `json:"employee_id" example:"12"`
+	EmployeeName    string  `json:"employee_name" example:"John Doe"`
+	Days            float64 `json:"days" example:"-2.5"`
+	PreviousBalance float64 `json:"previous_balance" example:"14"`
+	NewBalance      float64 `json:"new_balance" example:"11.5"`
+}
+
+// BulkAdjustLeaveBalancesResponse summarises a bulk adjustment. Rows are counted from 1 in a JSON
+// list and by line in a CSV file, the header being line 1. The audit log entries of the adjustments
+// all carry the batch ID.
+type BulkAdjustLeaveBalancesResponse struct {
+	BatchID  string                         `json:"batch_id,omitempty" example:"adj_5f1c0a9e7b2d4c86a3e1"`
+	DryRun   bool                           `json:"dry_run" example:"false"`
+	Total    int                            `json:"total" example:"120"`
+	Adjusted int                            `json:"adjusted" example:"118"`
+	Failed   int                            `json:"failed" example:"2"`
+	Results  []LeaveBalanceAdjustmentResult `json:"results"`
+	Errors   []ImportRowError               `json:"errors,omitempty"`
+}
+
+// balanceAdjustmentColumns are the columns of a bulk adjustment CSV file
+var balanceAdjustmentColumns = []string{"nrc", "employee_number", "days", "reason"}
+
+// errBalanceAdjustmentDryRun rolls back an adjustment's transaction in a dry run
+var errBalanceAdjustmentDryRun = errors.New("dry run")
+
+// BulkAdjustLeaveBalances adjusts the annual leave balances of many employees at once
+// @Summary Bulk adjust leave balances
+// @Description Add days to, or take them off, the annual leave balances of many employees. Employees are found by nrc, or by employee_number when there is no nrc. Each adjustment works as the single employee adjustment does, and is made on its own: adjustments with an error are reported by their position in the list, counting from 1, without affecting the others. The request's reason is used for adjustments without one. Every adjustment's audit log entry carries the batch ID returned, to find them by. With dry_run, every adjustment is checked and nothing is saved (Admin only)
+// @Tags HR - Leave Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BulkAdjustLeaveBalancesRequest true "Adjustments"
+// @Success 200 {object} BulkAdjustLeaveBalancesResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/employees/annual-leave-balances/adjust [post]
+func BulkAdjustLeaveBalances(c *gin.Context) {
+	var req BulkAdjustLeaveBalancesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	rows := make([]importRow, 0, len(req.Adjustments))
+	for i, adjustment := range req.Adjustments {
+		row := importRow{line: i + 1, values: map[string]string{
+			"days": strconv.FormatFloat(adjustment.Days, 'f', -1, 64),
+		}}
+		for column, value := range map[string]string{"nrc": adjustment.NRC, "employee_number": adjustment.EmployeeNumber, "reason": adjustment.Reason} {
+			if strings.TrimSpace(value) != "" {
+				row.values[column] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	adjustLeaveBalances(c, rows, strings.TrimSpace(req.Reason), req.DryRun)
+}
+
+// ImportLeaveBalanceAdjustments adjusts the annual leave balances of many employees from a CSV file
+// @Summary Import leave balance adjustments
+// @Description Adjust the annual leave balances of many employees from a CSV file with nrc or employee_number, days and reason columns, as the bulk adjustment does. Rows with an error are reported by line and column without affecting the others. The reason form field is used for rows that leave it empty. With dry_run, every row is checked and nothing is saved (Admin only)
+// @Tags HR - Leave Management
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file with nrc or employee_number, days and reason columns"
+// @Param reason formData string false "Reason of the rows that do not give one"
+// @Param dry_run formData bool false "Check every row without saving anything"
+// @Success 200 {object} BulkAdjustLeaveBalancesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/employees/annual-leave-balances/adjust/import [post]
+func ImportLeaveBalanceAdjustments(c *gin.Context) {
+	rows, ok := readBalanceAdjustmentFile(c)
+	if !ok {
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	adjustLeaveBalances(c, rows, strings.TrimSpace(c.PostForm("reason")), dryRun)
+}
+
+// adjustLeaveBalances makes the adjustments of a batch and responds with what became of each
+func adjustLeaveBalances(c *gin.Context, rows []importRow, reason string, dryRun bool) {
+	var annualLeaveType models.LeaveType
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
+		return
+	}
+
+	response := BulkAdjustLeaveBalancesResponse{DryRun: dryRun, Results: []LeaveBalanceAdjustmentResult{}}
+	if !dryRun {
+		batchID, err := newBalanceAdjustmentBatchID()
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to adjust balance")
+			return
+		}
+		response.BatchID = batchID
+	}
+
+	userID := c.GetUint("user_id")
+	for _, row := range rows {
+		response.Total++
+		if row.values == nil {
+			response.fail(c, row.line, "", "Failed to parse row")
+			continue
+		}
+		result, err := adjustLeaveBalanceRow(c, row, annualLeaveType.ID, reason, userID, response.BatchID, dryRun)
+		var cellErr *importCellError
+		switch {
+		case errors.As(err, &cellErr):
+			response.fail(c, row.line, cellErr.column, cellErr.message, cellErr.args...)
+		case err != nil:
+			response.fail(c, row.line, "", "Failed to adjust balance")
+		default:
+			response.Adjusted++
+			response.Results = append(response.Results, result)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// readBalanceAdjustmentFile reads the rows of an uploaded bulk adjustment CSV file
+func readBalanceAdjustmentFile(c *gin.Context) ([]importRow, bool) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "No file uploaded")
+		return nil, false
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid CSV file")
+		return nil, false
+	}
+	names := make([]string, len(header))
+	columns := map[string]bool{}
+	for i, name := range header {
+		// Spreadsheet programs may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		known := false
+		for _, column := range balanceAdjustmentColumns {
+			known = known || name == column
+		}
+		if !known {
+			utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Unknown column %s. Use nrc or employee_number, days and reason.", name))
+			return nil, false
+		}
+		names[i] = name
+		columns[name] = true
+	}
+	if !columns["nrc"] && !columns["employee_number"] {
+		utils.RespondError(c, http.StatusBadRequest, "The file needs an nrc or employee_number column")
+		return nil, false
+	}
+	if !columns["days"] {
+		utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "The file needs a %s column", "days"))
+		return nil, false
+	}
+
+	var rows []importRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rows = append(rows, importRow{line: line}) // Reported as failing to parse
+			continue
+		}
+		row := importRow{line: line, values: map[string]string{}}
+		for i, value := range record {
+			if i < len(names) && strings.TrimSpace(value) != "" {
+				row.values[names[i]] = strings.TrimSpace(value)
+			}
+		}
+		if len(row.values) == 0 {
+			continue // Blank line
+		}
+		rows = append(rows, row)
+	}
+	return rows, true
+}
+
+// adjustLeaveBalanceRow checks one row of a bulk adjustment and makes it in its own transaction,
+// recording it in the audit log under the batch ID. In a dry run the transaction is rolled back.
+func adjustLeaveBalanceRow(c *gin.Context, row importRow, leaveTypeID uint, defaultReason string, userID uint, batchID string, dryRun bool) (LeaveBalanceAdjustmentResult, error) {
+	result := LeaveBalanceAdjustmentResult{Row: row.line}
+	employee, err := findImportEmployee(c, row)
+	if err != nil {
+		return result, err
+	}
+	days, err := strconv.ParseFloat(row.values["days"], 64)
+	if err != nil || days == 0 || math.IsNaN(days) || math.IsInf(days, 0) {
+		return result, cellError("days", "Days must be a number other than zero")
+	}
+	reason := row.values["reason"]
+	if reason == "" {
+		reason = defaultReason
+	}
+	if reason == "" {
+		return result, cellError("reason", "Reason is required")
+	}
+
+	if err := utils.EnsureAccrualsUpToDate(requestDB(c), employee.ID, leaveTypeID); err != nil {
+		return result, err
+	}
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		accrual, oldBalance, err := adjustAnnualLeaveBalance(tx, employee.ID, leaveTypeID, days, reason)
+		if err != nil {
+			return err
+		}
+		result.PreviousBalance, result.NewBalance = oldBalance, accrual.DaysBalance
+		if dryRun {
+			return errBalanceAdjustmentDryRun
+		}
+		auditLog := newAuditLog(models.AuditEntityEmployee, employee.ID, models.AuditActionUpdate, userID, c,
+			map[string]interface{}{"balance": oldBalance},
+			map[string]interface{}{"balance": accrual.DaysBalance, "adjustment": days, "reason": reason})
+		auditLog.BatchID = &batchID
+		return tx.Create(&auditLog).Error
+	})
+	if err != nil && !errors.Is(err, errBalanceAdjustmentDryRun) {
+		return result, err
+	}
+
+	result.EmployeeID = employee.ID
+	result.EmployeeName = employee.Firstname + " " + employee.Lastname
+	result.Days = days
+	return result, nil
+}
+
+func (r *BulkAdjustLeaveBalancesResponse) fail(c *gin.Context, row int, column, message string, args ...interface{}) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRowError{Row: row, Column: column, Message: i18n.T(utils.RequestLanguage(c), message, args...)})
+}
+
+// newBalanceAdjustmentBatchID returns a reference for the audit log entries of one bulk adjustment
+func newBalanceAdjustmentBatchID() (string, error) {
+	id := make([]byte, 10)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return "adj_" + hex.EncodeToString(id), nil
+}
@@ -3,7 +3,6 @@ package handlers
 import (
 	"encoding/csv"
 	"fmt"
-	"hrms-api/database"
 	"hrms-api/models"
 	"hrms-api/utils"
 	"io"
@@ -12,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // BulkCreateLeavesRequest represents a request to create multiple leaves from CSV
@@ -56,7 +56,7 @@ type BulkLeaveCreateResult struct {
 func BulkCreateLeaves(c *gin.Context) {
 	file, _, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		utils.RespondError(c, http.StatusBadRequest, "No file uploaded")
 		return
 	}
 	defer file.Close()
@@ -67,6 +67,7 @@ func BulkCreateLeaves(c *gin.Context) {
 	reader.TrimLeadingSpace = true
 
 	var results []BulkLeaveCreateResult
+	var created []uint
 	total := 0
 	success := 0
 	failed := 0
@@ -77,15 +78,15 @@ func BulkCreateLeaves(c *gin.Context) {
 
 	// Get Annual leave type (default for bulk import)
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Read header row
 	header, err := reader.Read()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV file: " + err.Error()})
+		utils.RespondError(c, http.StatusBadRequest, "Failed to read CSV file: "+err.Error())
 		return
 	}
 
@@ -99,9 +100,7 @@ func BulkCreateLeaves(c *gin.Context) {
 	requiredHeaders := []string{"employee name", "start date", "end date"}
 	for _, expected := range requiredHeaders {
 		if _, exists := headerMap[expected]; !exists {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("Missing required column: %s. Expected columns: Employee Name, Start Date, End Date, Reason (optional). Leave Type is optional and defaults to Annual.", expected),
-			})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Missing required column: %s. Expected columns: Employee Name, Start Date, End Date, Reason (optional). Leave Type is optional and defaults to Annual.", expected))
 			return
 		}
 	}
@@ -124,7 +123,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				rowNum++
 				continue
 			}
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read row %d: %v", rowNum, err)})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Failed to read row %d: %v", rowNum, err))
 			return
 		}
 
@@ -166,9 +165,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("Row %d: Missing required fields", rowNum-1),
-			})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Row %d: Missing required fields", rowNum-1))
 			return
 		}
 
@@ -179,7 +176,7 @@ func BulkCreateLeaves(c *gin.Context) {
 			// Try firstname + lastname
 			firstname := nameParts[0]
 			lastname := strings.Join(nameParts[1:], " ")
-			if err := database.DB.Where("LOWER(firstname) = LOWER(?) AND LOWER(lastname) = LOWER(?)", firstname, lastname).First(&employee).Error; err != nil {
+			if err := requestDB(c).Where("LOWER(firstname) = LOWER(?) AND LOWER(lastname) = LOWER(?)", firstname, lastname).First(&employee).Error; err != nil {
 				// Try alternative: first word as firstname, rest as lastname
 				if skipInvalid {
 					failed++
@@ -191,9 +188,7 @@ func BulkCreateLeaves(c *gin.Context) {
 					})
 					continue
 				}
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": fmt.Sprintf("Row %d: Employee not found: %s", rowNum-1, employeeName),
-				})
+				utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Row %d: Employee not found: %s", rowNum-1, employeeName))
 				return
 			}
 		} else {
@@ -207,9 +202,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("Row %d: Invalid employee name format", rowNum-1),
-			})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Row %d: Invalid employee name format", rowNum-1))
 			return
 		}
 
@@ -229,9 +222,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("Row %d: Invalid start date format: %s", rowNum-1, startDateStr),
-			})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Row %d: Invalid start date format: %s", rowNum-1, startDateStr))
 			return
 		}
 
@@ -247,9 +238,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("Row %d: Invalid end date format: %s", rowNum-1, endDateStr),
-			})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Row %d: Invalid end date format: %s", rowNum-1, endDateStr))
 			return
 		}
 
@@ -265,9 +254,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("Row %d: Start date must be before or equal to end date", rowNum-1),
-			})
+			utils.RespondError(c, http.StatusBadRequest, fmt.Sprintf("Row %d: Start date must be before or equal to end date", rowNum-1))
 			return
 		}
 
@@ -284,9 +271,7 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": fmt.Sprintf("Row %d: Failed to check overlapping leaves", rowNum-1),
-			})
+			utils.RespondError(c, http.StatusInternalServerError, fmt.Sprintf("Row %d: Failed to check overlapping leaves", rowNum-1))
 			return
 		}
 		if hasOverlap {
@@ -300,17 +285,15 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusConflict, gin.H{
-				"error": fmt.Sprintf("Row %d: Overlapping leave exists for %s", rowNum-1, employeeName),
-			})
+			utils.RespondErrorCode(c, http.StatusConflict, utils.CodeOverlappingLeave, fmt.Sprintf("Row %d: Overlapping leave exists for %s", rowNum-1, employeeName), nil)
 			return
 		}
 
 		var leaveDuration float64
 		if leaveType.UsesBalance {
-			utils.EnsureAccrualsUpToDate(employee.ID, leaveType.ID)
+			utils.EnsureAccrualsUpToDate(requestDB(c), employee.ID, leaveType.ID)
 
-			balance, err := utils.GetCurrentLeaveBalance(employee.ID, leaveType.ID)
+			balance, err := utils.GetCurrentLeaveBalance(requestDB(c), employee.ID, leaveType.ID)
 			if err != nil {
 				if skipInvalid {
 					failed++
@@ -322,9 +305,7 @@ func BulkCreateLeaves(c *gin.Context) {
 					})
 					continue
 				}
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": fmt.Sprintf("Row %d: Failed to calculate leave balance", rowNum-1),
-				})
+				utils.RespondError(c, http.StatusInternalServerError, fmt.Sprintf("Row %d: Failed to calculate leave balance", rowNum-1))
 				return
 			}
 
@@ -340,9 +321,7 @@ func BulkCreateLeaves(c *gin.Context) {
 					})
 					continue
 				}
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": fmt.Sprintf("Row %d: Insufficient leave balance for %s", rowNum-1, employeeName),
-				})
+				utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeInsufficientBalance, fmt.Sprintf("Row %d: Insufficient leave balance for %s", rowNum-1, employeeName), nil)
 				return
 			}
 		} else {
@@ -362,7 +341,19 @@ func BulkCreateLeaves(c *gin.Context) {
 			ApprovedAt:  &now,
 		}
 
-		if err := database.DB.Create(&leave).Error; err != nil {
+		// Each row's leave, carry-over usage and audit record are saved together
+		err = withTransaction(c, func(tx *gorm.DB) error {
+			if err := tx.Create(&leave).Error; err != nil {
+				return err
+			}
+			if leaveType.UsesBalance && leaveType.AllowCarryOver {
+				if err := utils.UpdateCarryOverUsage(tx, employee.ID, leaveType.ID, leaveDuration); err != nil {
+					return err
+				}
+			}
+			return createAuditRecord(tx, leave.ID, models.AuditActionCreate, adminID, "", string(leave.Status), fmt.Sprintf("Bulk imported: %s", reason), c.ClientIP())
+		})
+		if err != nil {
 			if skipInvalid {
 				failed++
 				results = append(results, BulkLeaveCreateResult{
@@ -373,27 +364,18 @@ func BulkCreateLeaves(c *gin.Context) {
 				})
 				continue
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": fmt.Sprintf("Row %d: Failed to create leave", rowNum-1),
-			})
+			utils.RespondError(c, http.StatusInternalServerError, fmt.Sprintf("Row %d: Failed to create leave", rowNum-1))
 			return
 		}
 
-		if leaveType.UsesBalance {
-			if leaveType.AllowCarryOver {
-				utils.UpdateCarryOverUsage(employee.ID, leaveType.ID, leaveDuration)
-			}
-			if leave.Status == models.StatusApproved {
-				if err := utils.EnsureAccrualsUpToDate(employee.ID, leaveType.ID); err != nil {
-					// Log error but don't fail the creation
-				}
+		if leaveType.UsesBalance && leave.Status == models.StatusApproved {
+			if err := utils.EnsureAccrualsUpToDate(requestDB(c), employee.ID, leaveType.ID); err != nil {
+				// Log error but don't fail the creation
 			}
 		}
 
-		// Create audit record
-		createAuditRecord(leave.ID, models.AuditActionCreate, adminID, "", string(leave.Status), fmt.Sprintf("Bulk imported: %s", reason), c.ClientIP())
-
 		success++
+		created = append(created, leave.ID)
 		results = append(results, BulkLeaveCreateResult{
 			RowNumber:     rowNum - 1,
 			EmployeeName:  employeeName,
@@ -405,6 +387,10 @@ func BulkCreateLeaves(c *gin.Context) {
 		})
 	}
 
+	afterCommit(c, func() {
+		utils.SyncLeaveCalendars(created...)
+	})
+
 	c.JSON(http.StatusOK, BulkCreateLeavesResponse{
 		Total:   total,
 		Success: success,
@@ -422,14 +408,14 @@ func BulkCreateLeaves(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body BulkCreateLeavesTemplateRequest true "Template data"
 // @Success 200 {object} BulkCreateLeavesResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /api/hr/leaves/bulk-template [post]
 func BulkCreateLeavesFromTemplate(c *gin.Context) {
 	var req BulkCreateLeavesTemplateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -439,37 +425,38 @@ func BulkCreateLeavesFromTemplate(c *gin.Context) {
 
 	// Verify leave type exists
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, req.LeaveTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave type not found"})
+	if err := requestDB(c).First(&leaveType, req.LeaveTypeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Leave type not found")
 		return
 	}
 
 	// Parse dates
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	endDate, err := time.Parse("2006-01-02", req.EndDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	if startDate.After(endDate) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Start date must be before or equal to end date"})
+		utils.RespondError(c, http.StatusBadRequest, "Start date must be before or equal to end date")
 		return
 	}
 
 	var results []BulkLeaveCreateResult
+	var created []uint
 	success := 0
 	failed := 0
 
 	// Process each employee
 	for i, employeeID := range req.EmployeeIDs {
 		var employee models.Employee
-		if err := database.DB.First(&employee, employeeID).Error; err != nil {
+		if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
 			failed++
 			results = append(results, BulkLeaveCreateResult{
 				RowNumber:    i + 1,
@@ -504,9 +491,9 @@ func BulkCreateLeavesFromTemplate(c *gin.Context) {
 		}
 
 		if leaveType.UsesBalance {
-			utils.EnsureAccrualsUpToDate(employeeID, leaveType.ID)
+			utils.EnsureAccrualsUpToDate(requestDB(c), employeeID, leaveType.ID)
 
-			balance, err := utils.GetCurrentLeaveBalance(employeeID, leaveType.ID)
+			balance, err := utils.GetCurrentLeaveBalance(requestDB(c), employeeID, leaveType.ID)
 			if err != nil {
 				failed++
 				results = append(results, BulkLeaveCreateResult{
@@ -546,7 +533,18 @@ func BulkCreateLeavesFromTemplate(c *gin.Context) {
 			ApprovedAt:  &now,
 		}
 
-		if err := database.DB.Create(&leave).Error; err != nil {
+		err = withTransaction(c, func(tx *gorm.DB) error {
+			if err := tx.Create(&leave).Error; err != nil {
+				return err
+			}
+			if leaveType.UsesBalance && leaveType.AllowCarryOver {
+				if err := utils.UpdateCarryOverUsage(tx, employeeID, leaveType.ID, leaveDuration); err != nil {
+					return err
+				}
+			}
+			return createAuditRecord(tx, leave.ID, models.AuditActionCreate, adminID, "", string(leave.Status), fmt.Sprintf("Bulk template: %s", req.Reason), c.ClientIP())
+		})
+		if err != nil {
 			failed++
 			results = append(results, BulkLeaveCreateResult{
 				RowNumber:    i + 1,
@@ -557,14 +555,8 @@ func BulkCreateLeavesFromTemplate(c *gin.Context) {
 			continue
 		}
 
-		if leaveType.UsesBalance && leaveType.AllowCarryOver {
-			utils.UpdateCarryOverUsage(employeeID, leaveType.ID, leaveDuration)
-		}
-
-		// Create audit record
-		createAuditRecord(leave.ID, models.AuditActionCreate, adminID, "", string(leave.Status), fmt.Sprintf("Bulk template: %s", req.Reason), c.ClientIP())
-
 		success++
+		created = append(created, leave.ID)
 		results = append(results, BulkLeaveCreateResult{
 			RowNumber:     i + 1,
 			EmployeeName:  employee.Firstname + " " + employee.Lastname,
@@ -576,6 +568,10 @@ func BulkCreateLeavesFromTemplate(c *gin.Context) {
 		})
 	}
 
+	afterCommit(c, func() {
+		utils.SyncLeaveCalendars(created...)
+	})
+
 	c.JSON(http.StatusOK, BulkCreateLeavesResponse{
 		Total:   len(req.EmployeeIDs),
 		Success: success,
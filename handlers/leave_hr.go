@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"hrms-api/database"
 	"hrms-api/models"
+	"hrms-api/telemetry"
 	"hrms-api/utils"
 	"io"
 	"net/http"
@@ -15,11 +17,16 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
 )
 
 // LeaveAccrualResponse represents accrual information
 type LeaveAccrualResponse struct {
 	Month       string  `json:"month" example:"2025-01"`
+	PeriodStart string  `json:"period_start,omitempty" example:"2025-01-01"` // Accrual period, for accruals made on a schedule
+	PeriodEnd   string  `json:"period_end,omitempty" example:"2025-01-31"`
 	DaysAccrued float64 `json:"days_accrued" example:"2.0"`
 	DaysUsed    float64 `json:"days_used" example:"1.5"`
 	DaysBalance float64 `json:"days_balance" example:"0.5"`
@@ -27,6 +34,15 @@ type LeaveAccrualResponse struct {
 	ProcessedAt *string `json:"processed_at,omitempty"`
 }
 
+// accrualPeriodDates returns the first and last days of an accrual's period, or empty strings for an
+// accrual not made on a schedule
+func accrualPeriodDates(acc models.LeaveAccrual) (string, string) {
+	if acc.AccrualMonth == nil || acc.PeriodEnd == nil {
+		return "", ""
+	}
+	return acc.AccrualMonth.Format("2006-01-02"), acc.PeriodEnd.Format("2006-01-02")
+}
+
 // AnnualLeaveBalanceResponse represents detailed annual leave balance
 type AnnualLeaveBalanceResponse struct {
 	EmployeeID        uint                   `json:"employee_id"`
@@ -36,24 +52,48 @@ type AnnualLeaveBalanceResponse struct {
 	AllTimeNetBalance float64                `json:"all_time_net_balance" example:"19.0"` // TotalAccrued - TotalUsed (all-time net)
 	CurrentBalance    float64                `json:"current_balance" example:"19.0"`      // Current available (includes carry-over)
 	CarryOverBalance  float64                `json:"carry_over_balance" example:"5.0"`
+	LeaveYear         string                 `json:"leave_year" example:"2025/26"`      // Current leave year
+	LeaveYearAccrued  float64                `json:"leave_year_accrued" example:"14.0"` // Days accrued so far this leave year
+	LeaveYearUsed     float64                `json:"leave_year_used" example:"3.0"`     // Days of approved leave falling in this leave year
 	Accruals          []LeaveAccrualResponse `json:"accruals"`
 	PendingLeaves     int                    `json:"pending_leaves"`
 	UpcomingLeaves    int                    `json:"upcoming_leaves"`
 }
 
+// rounded returns the balance with its days rounded for display by the leave rounding settings
+func (r AnnualLeaveBalanceResponse) rounded() AnnualLeaveBalanceResponse {
+	r.TotalAccrued = utils.RoundLeaveDays(r.TotalAccrued)
+	r.TotalUsed = utils.RoundLeaveDays(r.TotalUsed)
+	r.AllTimeNetBalance = utils.RoundLeaveDays(r.AllTimeNetBalance)
+	r.CurrentBalance = utils.RoundLeaveDays(r.CurrentBalance)
+	r.CarryOverBalance = utils.RoundLeaveDays(r.CarryOverBalance)
+	r.LeaveYearAccrued = utils.RoundLeaveDays(r.LeaveYearAccrued)
+	r.LeaveYearUsed = utils.RoundLeaveDays(r.LeaveYearUsed)
+	accruals := make([]LeaveAccrualResponse, len(r.Accruals))
+	for i, accrual := range r.Accruals {
+		accrual.DaysAccrued = utils.RoundLeaveDays(accrual.DaysAccrued)
+		accrual.DaysUsed = utils.RoundLeaveDays(accrual.DaysUsed)
+		accrual.DaysBalance = utils.RoundLeaveDays(accrual.DaysBalance)
+		accruals[i] = accrual
+	}
+	r.Accruals = accruals
+	return r
+}
+
 // LeaveCalendarResponse represents leave calendar data
 type LeaveCalendarResponse struct {
-	Date         string  `json:"date" example:"2025-12-15"`
-	EmployeeID   uint    `json:"employee_id"`
-	EmployeeName string  `json:"employee_name"`
-	Department   string  `json:"department"`
-	LeaveType    string  `json:"leave_type"`
-	LeaveID      uint    `json:"leave_id"`
-	StartDate    string  `json:"start_date"`
-	EndDate      string  `json:"end_date"`
-	Status       string  `json:"status"`
-	FormFilePath *string `json:"form_file_path,omitempty"`
-	FormFileName *string `json:"form_file_name,omitempty"`
+	Date           string  `json:"date" example:"2025-12-15"`
+	EmployeeID     uint    `json:"employee_id"`
+	EmployeeName   string  `json:"employee_name"`
+	Department     string  `json:"department"`
+	LeaveType      string  `json:"leave_type"`
+	LeaveTypeColor string  `json:"leave_type_color,omitempty" example:"#4CAF50"`
+	LeaveID        uint    `json:"leave_id"`
+	StartDate      string  `json:"start_date"`
+	EndDate        string  `json:"end_date"`
+	Status         string  `json:"status"`
+	FormFilePath   *string `json:"form_file_path,omitempty"`
+	FormFileName   *string `json:"form_file_name,omitempty"`
 }
 
 // DepartmentLeaveReport represents leave statistics by department
@@ -82,35 +122,35 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
 	var employee models.Employee
-	if err := database.DB.First(&employee, employeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Ensure accruals are up to date
-	if err := utils.EnsureAccrualsUpToDate(uint(employeeID), annualLeaveType.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process accruals"})
+	if err := utils.EnsureAccrualsUpToDate(requestDB(c), uint(employeeID), annualLeaveType.ID); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to process accruals")
 		return
 	}
 
 	// Get all accruals
 	// Order by accrual_month if available, otherwise by year and month
 	var accruals []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
-		Order("COALESCE(accrual_month, MAKE_DATE(year::integer, month::integer, 1)) DESC, year DESC, month DESC").
+	requestDB(c).Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
+		Order(utils.AccrualMonthSQL() + " DESC, year DESC, month DESC").
 		Find(&accruals)
 
 	// Get employee start date to exclude first month accruals
 	var employeeStartDate time.Time
 	var employment models.EmploymentDetails
-	if err := database.DB.Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
+	if err := requestDB(c).Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
 		if employment.HireDate != nil {
 			employeeStartDate = *employment.HireDate
 		} else if employment.StartDate != nil {
@@ -153,9 +193,12 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 		if acc.ProcessedAt != nil {
 			processedAtStr = acc.ProcessedAt.Format(time.RFC3339)
 		}
+		periodStart, periodEnd := accrualPeriodDates(acc)
 
 		accrualResponses = append(accrualResponses, LeaveAccrualResponse{
 			Month:       acc.GetAccrualMonthKey(),
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
 			DaysAccrued: acc.DaysAccrued,
 			DaysUsed:    acc.DaysUsed,
 			DaysBalance: acc.DaysBalance,
@@ -168,7 +211,7 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 	// This ensures accuracy even if accrual records have incorrect DaysUsed values
 	var totalUsed float64
 	var approvedLeaves []models.Leave
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+	requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 		employeeID, annualLeaveType.ID, models.StatusApproved).Find(&approvedLeaves)
 	for _, leave := range approvedLeaves {
 		totalUsed += float64(leave.GetDuration())
@@ -177,11 +220,15 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 	// Get carry-over balance
 	var carryOverBalance float64
 	if annualLeaveType.AllowCarryOver {
-		carryOverBalance, _ = utils.GetCarryOverBalance(uint(employeeID), annualLeaveType.ID)
+		carryOverBalance, _ = utils.GetCarryOverBalance(requestDB(c), uint(employeeID), annualLeaveType.ID)
 	}
 
 	// Get total current balance (accrual + carry-over) - this is what's actually available
-	currentBalance, _ := utils.GetCurrentLeaveBalance(uint(employeeID), annualLeaveType.ID)
+	currentBalance, _ := utils.GetCurrentLeaveBalance(requestDB(c), uint(employeeID), annualLeaveType.ID)
+
+	// Get year-to-date totals of the current leave year
+	leaveYear := utils.CurrentLeaveYear()
+	leaveYearAccrued, leaveYearUsed, _ := utils.LeaveYearTotals(requestDB(c), uint(employeeID), annualLeaveType.ID, leaveYear)
 
 	// Calculate all-time net balance using actual accrual records (includes initial balance adjustments)
 	// This reflects the actual accrued amount including any manual adjustments from onboarding
@@ -191,13 +238,13 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 
 	// Get pending and upcoming leaves
 	var pendingLeaves, upcomingLeaves int64
-	now := time.Now()
-	database.DB.Model(&models.Leave{}).
+	today := utils.CompanyToday()
+	requestDB(c).Model(&models.Leave{}).
 		Where("employee_id = ? AND leave_type_id = ? AND status = ?", employeeID, annualLeaveType.ID, models.StatusPending).
 		Count(&pendingLeaves)
-	database.DB.Model(&models.Leave{}).
+	requestDB(c).Model(&models.Leave{}).
 		Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date > ?",
-			employeeID, annualLeaveType.ID, models.StatusApproved, now).
+			employeeID, annualLeaveType.ID, models.StatusApproved, today).
 		Count(&upcomingLeaves)
 
 	response := AnnualLeaveBalanceResponse{
@@ -208,12 +255,15 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 		AllTimeNetBalance: allTimeNetBalance,
 		CurrentBalance:    currentBalance,
 		CarryOverBalance:  carryOverBalance,
+		LeaveYear:         utils.LeaveYearLabel(leaveYear),
+		LeaveYearAccrued:  leaveYearAccrued,
+		LeaveYearUsed:     leaveYearUsed,
 		Accruals:          accrualResponses,
 		PendingLeaves:     int(pendingLeaves),
 		UpcomingLeaves:    int(upcomingLeaves),
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, response.rounded())
 }
 
 // GetLeaveCalendar gets leave calendar for a date range
@@ -230,44 +280,110 @@ func GetAnnualLeaveBalance(c *gin.Context) {
 // @Failure 403 {object} ErrorResponse
 // @Router /api/hr/leaves/calendar [get]
 func GetLeaveCalendar(c *gin.Context) {
-	// Parse date range
+	startDate, endDate, ok := leaveCalendarRange(c)
+	if !ok {
+		return
+	}
+	results, err := calendarLeaves(c, startDate, endDate, c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leaves")
+		return
+	}
+
+	// Generate calendar entries for each day
+	// Streamed, as a company-wide calendar runs to megabytes
+	calendar := newJSONArrayWriter(c)
+	currentDate := startDate
+	for !currentDate.After(endDate) {
+		for _, result := range results {
+			leave := result.Leave
+			if !currentDate.Before(leave.StartDate) && !currentDate.After(leave.EndDate) {
+				// Get employee name and department from joined data
+				employeeName := ""
+				departmentName := ""
+				if result.FirstName != "" && result.LastName != "" {
+					employeeName = result.FirstName + " " + result.LastName
+					departmentName = result.Department
+				}
+				
+				leaveTypeName := result.LeaveTypeName
+				
+				if err := calendar.Write(LeaveCalendarResponse{
+					Date:           currentDate.Format("2006-01-02"),
+					EmployeeID:     leave.EmployeeID,
+					EmployeeName:   employeeName,
+					Department:     departmentName,
+					LeaveType:      leaveTypeName,
+					LeaveTypeColor: result.LeaveTypeColor,
+					LeaveID:        leave.ID,
+					StartDate:      leave.StartDate.Format("2006-01-02"),
+					EndDate:        leave.EndDate.Format("2006-01-02"),
+					Status:         string(leave.Status),
+					FormFilePath:   leave.FormFilePath,
+					FormFileName:   leave.FormFileName,
+				}); err != nil {
+					return // The client has gone away
+				}
+			}
+		}
+		currentDate = currentDate.AddDate(0, 0, 1)
+	}
+
+	calendar.Close()
+}
+
+// calendarLeave is an approved leave on the leave calendar, with its employee and leave type
+type calendarLeave struct {
+	models.Leave
+	FirstName      string `gorm:"column:firstname"`
+	LastName       string `gorm:"column:lastname"`
+	Department     string `gorm:"column:department"`
+	LeaveTypeName  string `gorm:"column:leave_type_name"`
+	LeaveTypeColor string `gorm:"column:leave_type_color"`
+}
+
+// leaveCalendarRange reads the dates a leave calendar runs between, the current month by default
+func leaveCalendarRange(c *gin.Context) (time.Time, time.Time, bool) {
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
-	department := c.Query("department")
 
 	var startDate, endDate time.Time
 	var err error
 
 	if startDateStr == "" {
-		now := time.Now()
+		now := utils.CompanyNow()
 		startDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 	} else {
 		startDate, err = time.Parse("2006-01-02", startDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format"})
-			return
+			utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format")
+			return time.Time{}, time.Time{}, false
 		}
 	}
 
 	if endDateStr == "" {
-		now := time.Now()
+		now := utils.CompanyNow()
 		startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 		endDate = startOfMonth.AddDate(0, 1, 0).AddDate(0, 0, -1)
 	} else {
 		endDate, err = time.Parse("2006-01-02", endDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format"})
-			return
+			utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format")
+			return time.Time{}, time.Time{}, false
 		}
 	}
 
-	// Get approved leaves in date range
+	return startDate, endDate, true
+}
+
+// calendarLeaves gets the approved leaves overlapping a date range, optionally only those of a department
+func calendarLeaves(c *gin.Context, startDate, endDate time.Time, department string) ([]calendarLeave, error) {
 	// Optimize query: filter by status first (indexed), then date range
 	// Use overlapping date range logic: leave overlaps if start_date <= endDate AND end_date >= startDate
 	// Use Joins to ensure Employee and LeaveType data is loaded
 	// Exclude admin users and soft-deleted employees (same filter as employee list)
-	query := database.DB.Model(&models.Leave{}).
-		Select("leaves.*, employees.firstname, employees.lastname, employees.department, leave_types.name as leave_type_name").
+	query := requestDB(c).Model(&models.Leave{}).
+		Select("leaves.*, employees.firstname, employees.lastname, employees.department, leave_types.name as leave_type_name, leave_types.color as leave_type_color").
 		Joins("INNER JOIN employees ON leaves.employee_id = employees.id").
 		Joins("LEFT JOIN leave_types ON leaves.leave_type_id = leave_types.id").
 		Where("leaves.status = ?", models.StatusApproved).
@@ -280,55 +396,96 @@ func GetLeaveCalendar(c *gin.Context) {
 		query = query.Where("employees.department = ?", department)
 	}
 
-	var results []struct {
-		models.Leave
-		FirstName      string `gorm:"column:firstname"`
-		LastName       string `gorm:"column:lastname"`
-		Department     string `gorm:"column:department"`
-		LeaveTypeName  string `gorm:"column:leave_type_name"`
+	var results []calendarLeave
+	err := query.Find(&results).Error
+	return results, err
+}
+
+// ExportLeaveCalendar exports the leave calendar as an Excel grid
+// @Summary Export leave calendar
+// @Description Export the approved leaves in a date range to Excel as a grid for roster planning: a row per active employee, a column per day, the days of leave filled in the color of the leave type and marked with its initial, and weekends and public holidays shaded (HR/Admin only)
+// @Tags HR - Leave Management
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)" default:"current month start"
+// @Param end_date query string false "End date (YYYY-MM-DD), at most 366 days after the start" default:"current month end"
+// @Param department query string false "Filter by department"
+// @Param lang query string false "Language of the file: en, fr or pt (default: the Accept-Language header)"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/leaves/calendar/export [get]
+func ExportLeaveCalendar(c *gin.Context) {
+	startDate, endDate, ok := leaveCalendarRange(c)
+	if !ok {
+		return
+	}
+	if endDate.Before(startDate) {
+		utils.RespondError(c, http.StatusBadRequest, "end_date must be on or after start_date")
+		return
+	}
+	if endDate.Sub(startDate) > 365*24*time.Hour {
+		utils.RespondError(c, http.StatusBadRequest, "Date range cannot exceed 366 days")
+		return
+	}
+	locale, ok := exportLocale(c)
+	if !ok {
+		return
+	}
+	department := c.Query("department")
+
+	results, err := calendarLeaves(c, startDate, endDate, department)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leaves")
+		return
 	}
 
-	if err := query.Find(&results).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leaves"})
+	// Every active employee gets a row, to plan around those not on leave as well
+	query := requestDB(c).Where("role != ? AND status = ?", models.RoleAdmin, "active")
+	if department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var employees []models.Employee
+	if err := query.Order("department, firstname, lastname").Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
 		return
 	}
+	leaves := make(map[uint][]utils.LeaveCalendarEntry)
+	for _, result := range results {
+		leaves[result.EmployeeID] = append(leaves[result.EmployeeID], utils.LeaveCalendarEntry{
+			StartDate: result.StartDate,
+			EndDate:   result.EndDate,
+			LeaveType: result.LeaveTypeName,
+			Color:     result.LeaveTypeColor,
+		})
+	}
+	rows := make([]utils.LeaveCalendarEmployee, 0, len(employees))
+	for _, employee := range employees {
+		rows = append(rows, utils.LeaveCalendarEmployee{
+			EmployeeID:   employee.ID,
+			EmployeeName: employee.Firstname + " " + employee.Lastname,
+			Department:   employee.Department,
+			Leaves:       leaves[employee.ID],
+		})
+	}
 
-	// Generate calendar entries for each day
-	calendar := make([]LeaveCalendarResponse, 0)
-	currentDate := startDate
-	for !currentDate.After(endDate) {
-		for _, result := range results {
-			leave := result.Leave
-			if !currentDate.Before(leave.StartDate) && !currentDate.After(leave.EndDate) {
-				// Get employee name and department from joined data
-				employeeName := ""
-				departmentName := ""
-				if result.FirstName != "" && result.LastName != "" {
-					employeeName = result.FirstName + " " + result.LastName
-					departmentName = result.Department
-				}
-				
-				leaveTypeName := result.LeaveTypeName
-				
-				calendar = append(calendar, LeaveCalendarResponse{
-					Date:         currentDate.Format("2006-01-02"),
-					EmployeeID:   leave.EmployeeID,
-					EmployeeName: employeeName,
-					Department:   departmentName,
-					LeaveType:    leaveTypeName,
-					LeaveID:      leave.ID,
-					StartDate:    leave.StartDate.Format("2006-01-02"),
-					EndDate:      leave.EndDate.Format("2006-01-02"),
-					Status:       string(leave.Status),
-					FormFilePath: leave.FormFilePath,
-					FormFileName: leave.FormFileName,
-				})
-			}
-		}
-		currentDate = currentDate.AddDate(0, 0, 1)
+	var publicHolidays []models.PublicHoliday
+	if err := requestDB(c).Where("status = ? AND date >= ? AND date <= ?", models.HolidayActive, startDate, endDate).
+		Find(&publicHolidays).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch holidays")
+		return
+	}
+	holidays := make(map[string]string, len(publicHolidays))
+	for _, holiday := range publicHolidays {
+		holidays[holiday.Date.Format("2006-01-02")] = holiday.Name
 	}
 
-	c.JSON(http.StatusOK, calendar)
+	filename := fmt.Sprintf("leave_calendar_%s_%s.xlsx", startDate.Format("20060102"), endDate.Format("20060102"))
+	streamDownload(c, filename, utils.XLSXContentType, "Failed to generate export file", func(w io.Writer) error {
+		return utils.ExportLeaveCalendarToExcel(w, rows, startDate, endDate, holidays, locale)
+	})
 }
 
 // GetDepartmentLeaveReport gets leave statistics by department
@@ -342,9 +499,12 @@ func GetLeaveCalendar(c *gin.Context) {
 // @Failure 403 {object} ErrorResponse
 // @Router /api/hr/leaves/department-report [get]
 func GetDepartmentLeaveReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	db := database.DB.WithContext(ctx)
+
 	// Get all departments
 	var departments []string
-	database.DB.Model(&models.Employee{}).
+	db.Model(&models.Employee{}).
 		Where("department IS NOT NULL AND department != ''").
 		Distinct("department").
 		Pluck("department", &departments)
@@ -353,34 +513,39 @@ func GetDepartmentLeaveReport(c *gin.Context) {
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := db.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	for _, dept := range departments {
+		deptCtx, deptSpan := telemetry.Tracer().Start(ctx, "department leave report",
+			trace.WithAttributes(attribute.String("hrms.department", dept)))
+		deptDB := database.DB.WithContext(deptCtx)
+
 		// Count employees in department
 		var totalEmployees int64
-		database.DB.Model(&models.Employee{}).Where("department = ?", dept).Count(&totalEmployees)
+		deptDB.Model(&models.Employee{}).Where("department = ?", dept).Count(&totalEmployees)
 
 		// Get employees in department
 		var employees []models.Employee
-		database.DB.Where("department = ?", dept).Find(&employees)
+		deptDB.Where("department = ?", dept).Find(&employees)
 
 		var totalAccrued, totalUsed, totalBalance float64
 		var pendingRequests, upcomingLeaves int64
 
 		for _, emp := range employees {
-			// Ensure accruals are up to date
-			utils.EnsureAccrualsUpToDate(emp.ID, annualLeaveType.ID)
-
-			// Get current balance
-			balance, _ := utils.GetCurrentLeaveBalance(emp.ID, annualLeaveType.ID)
-			totalBalance += balance
+			// Ensure accruals are up to date and get the current balance
+			_, balanceSpan := telemetry.Tracer().Start(deptCtx, "leave balance",
+				trace.WithAttributes(attribute.Int("hrms.employee_id", int(emp.ID))))
+			utils.EnsureAccrualsUpToDate(requestDB(c), emp.ID, annualLeaveType.ID)
+			balance, _ := utils.GetCurrentLeaveBalance(requestDB(c), emp.ID, annualLeaveType.ID)
+			balanceSpan.End()
+			totalBalance += utils.RoundLeaveDays(balance)
 
 			// Get accruals for totals
 			var accruals []models.LeaveAccrual
-			database.DB.Where("employee_id = ? AND leave_type_id = ?", emp.ID, annualLeaveType.ID).Find(&accruals)
+			deptDB.Where("employee_id = ? AND leave_type_id = ?", emp.ID, annualLeaveType.ID).Find(&accruals)
 			for _, acc := range accruals {
 				totalAccrued += acc.DaysAccrued
 				totalUsed += acc.DaysUsed
@@ -388,25 +553,27 @@ func GetDepartmentLeaveReport(c *gin.Context) {
 
 			// Count pending and upcoming
 			var pending, upcoming int64
-			now := time.Now()
-			database.DB.Model(&models.Leave{}).
+			today := utils.CompanyToday()
+			deptDB.Model(&models.Leave{}).
 				Where("employee_id = ? AND leave_type_id = ? AND status = ?", emp.ID, annualLeaveType.ID, models.StatusPending).
 				Count(&pending)
-			database.DB.Model(&models.Leave{}).
+			deptDB.Model(&models.Leave{}).
 				Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date > ?",
-					emp.ID, annualLeaveType.ID, models.StatusApproved, now).
+					emp.ID, annualLeaveType.ID, models.StatusApproved, today).
 				Count(&upcoming)
 
 			pendingRequests += pending
 			upcomingLeaves += upcoming
 		}
+		deptSpan.SetAttributes(attribute.Int("hrms.employee_count", len(employees)))
+		deptSpan.End()
 
 		reports = append(reports, DepartmentLeaveReport{
 			Department:      dept,
 			TotalEmployees:  int(totalEmployees),
-			TotalAccrued:    totalAccrued,
-			TotalUsed:       totalUsed,
-			TotalBalance:    totalBalance,
+			TotalAccrued:    utils.RoundLeaveDays(totalAccrued),
+			TotalUsed:       utils.RoundLeaveDays(totalUsed),
+			TotalBalance:    utils.RoundLeaveDays(totalBalance),
 			PendingRequests: int(pendingRequests),
 			UpcomingLeaves:  int(upcomingLeaves),
 		})
@@ -423,7 +590,7 @@ type ProcessAccrualsRequest struct {
 
 // ProcessMonthlyAccruals processes leave accruals for employees for a specific month
 // @Summary Process monthly accruals
-// @Description Process leave accruals for all employees or selected employees for a specific month (Manager/Admin only)
+// @Description Process leave accruals for all employees or selected employees for a specific month: the accrual periods of the annual leave type's accrual frequency that start in the month (Manager/Admin only)
 // @Tags HR - Leave Management
 // @Accept json
 // @Produce json
@@ -457,20 +624,20 @@ func ProcessMonthlyAccruals(c *gin.Context) {
 
 	if req.Month == "" {
 		// Default to current month (accruals are processed at end of month)
-		now := time.Now()
+		now := utils.CompanyNow()
 		processMonth = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 	} else {
 		processMonth, err = time.Parse("2006-01", req.Month)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid month format. Use YYYY-MM")
 			return
 		}
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
@@ -478,23 +645,23 @@ func ProcessMonthlyAccruals(c *gin.Context) {
 	var employees []models.Employee
 	if len(req.EmployeeIDs) > 0 {
 		// Process only selected employees (but still exclude admins/inactive)
-		if err := database.DB.
+		if err := requestDB(c).
 			Where("id IN ?", req.EmployeeIDs).
 			Where("role != ? AND status = ?", models.RoleAdmin, "active").
 			Find(&employees).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch selected employees"})
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch selected employees")
 			return
 		}
 		if len(employees) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No valid employees found for the provided IDs"})
+			utils.RespondError(c, http.StatusBadRequest, "No valid employees found for the provided IDs")
 			return
 		}
 	} else {
 		// Process all active, non-admin employees
-		if err := database.DB.
+		if err := requestDB(c).
 			Where("role != ? AND status = ?", models.RoleAdmin, "active").
 			Find(&employees).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch employees"})
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
 			return
 		}
 	}
@@ -504,7 +671,7 @@ func ProcessMonthlyAccruals(c *gin.Context) {
 	var errorDetails []string
 
 	for _, emp := range employees {
-		if err := utils.ProcessMonthlyAccrual(emp.ID, annualLeaveType.ID, processMonth); err != nil {
+		if err := utils.ProcessMonthlyAccrual(requestDB(c), emp.ID, annualLeaveType.ID, processMonth); err != nil {
 			errors++
 			errorDetails = append(errorDetails, fmt.Sprintf("Employee %d (%s %s): %v", emp.ID, emp.Firstname, emp.Lastname, err))
 			continue
@@ -548,12 +715,12 @@ func GetUpcomingLeaves(c *gin.Context) {
 		}
 	}
 
-	now := time.Now()
-	endDate := now.AddDate(0, 0, days)
+	today := utils.CompanyToday()
+	endDate := today.AddDate(0, 0, days)
 
 	var leaves []models.Leave
-	database.DB.Where("status = ? AND start_date >= ? AND start_date <= ?",
-		models.StatusApproved, now, endDate).
+	requestDB(c).Where("status = ? AND start_date >= ? AND start_date <= ?",
+		models.StatusApproved, today, endDate).
 		Preload("Employee").
 		Preload("LeaveType").
 		Order("start_date ASC").
@@ -579,7 +746,7 @@ type AdjustLeaveBalanceRequest struct {
 // @Param id path int true "Employee ID"
 // @Param request body AdjustLeaveBalanceRequest true "Balance adjustment"
 // @Success 200 {object} AnnualLeaveBalanceResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -589,35 +756,57 @@ func AdjustLeaveBalance(c *gin.Context) {
 
 	var req AdjustLeaveBalanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Ensure accruals are up to date
-	if err := utils.EnsureAccrualsUpToDate(uint(employeeID), annualLeaveType.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process accruals"})
+	if err := utils.EnsureAccrualsUpToDate(requestDB(c), uint(employeeID), annualLeaveType.ID); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to process accruals")
 		return
 	}
 
+	latestAccrual, oldBalance, err := adjustAnnualLeaveBalance(requestDB(c), uint(employeeID), annualLeaveType.ID, req.Days, req.Reason)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to adjust balance")
+		return
+	}
+
+	// Create audit log
+	user := getCurrentUser(c)
+	if user != nil {
+		createAuditLog(models.AuditEntityEmployee, uint(employeeID), models.AuditActionUpdate, user.ID, c,
+			map[string]interface{}{"balance": oldBalance},
+			map[string]interface{}{"balance": latestAccrual.DaysBalance, "adjustment": req.Days, "reason": req.Reason})
+	}
+
+	// Return updated balance
+	GetAnnualLeaveBalance(c)
+}
+
+// adjustAnnualLeaveBalance adds days, or takes them off when negative, to the balance of an employee's
+// latest accrual, and returns the accrual with the balance it had before. Accruals should be brought
+// up to date first.
+func adjustAnnualLeaveBalance(db *gorm.DB, employeeID, leaveTypeID uint, days float64, reason string) (models.LeaveAccrual, float64, error) {
 	// Get the latest accrual record
 	// Order by accrual_month if available, otherwise by year and month
 	var latestAccrual models.LeaveAccrual
-	if err := database.DB.Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
-		Order("COALESCE(accrual_month, MAKE_DATE(year::integer, month::integer, 1)) DESC, year DESC, month DESC").
+	if err := db.Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
+		Order(utils.AccrualMonthSQL() + " DESC, year DESC, month DESC").
 		First(&latestAccrual).Error; err != nil {
 		// No accrual record exists, create one for current month
-		now := time.Now()
+		now := utils.CompanyNow()
 		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 		latestAccrual = models.LeaveAccrual{
-			EmployeeID:   uint(employeeID),
-			LeaveTypeID:  annualLeaveType.ID,
+			EmployeeID:   employeeID,
+			LeaveTypeID:  leaveTypeID,
 			AccrualMonth: &monthStart,
 			DaysAccrued:  0,
 			DaysUsed:     0,
@@ -625,12 +814,12 @@ func AdjustLeaveBalance(c *gin.Context) {
 			IsProcessed:  true,
 			ProcessedAt:  &now,
 		}
-		database.DB.Create(&latestAccrual)
+		// Saved together with the adjustment below
 	}
 
 	// Adjust balance
 	oldBalance := latestAccrual.DaysBalance
-	latestAccrual.DaysBalance += req.Days
+	latestAccrual.DaysBalance += days
 	// Allow negative balances (overdrawn) to be visible - don't force to 0
 
 	// DaysUsed should always reflect actual approved leave records
@@ -639,33 +828,19 @@ func AdjustLeaveBalance(c *gin.Context) {
 
 	// Mark as processed to prevent automatic recalculation from overwriting manual adjustments
 	// This ensures manual adjustments are preserved when accruals are processed
-	now := time.Now()
+	now := utils.Now()
 	latestAccrual.IsProcessed = true
 	latestAccrual.ProcessedAt = &now
 
 	// Add adjustment to notes
 	notes := fmt.Sprintf("Manual adjustment: %+.2f days. Previous balance: %.2f, New balance: %.2f. DaysUsed remains: %.2f (calculated from actual leave records). Reason: %s",
-		req.Days, oldBalance, latestAccrual.DaysBalance, latestAccrual.DaysUsed, req.Reason)
+		days, oldBalance, latestAccrual.DaysBalance, latestAccrual.DaysUsed, reason)
 	if latestAccrual.Notes != nil && *latestAccrual.Notes != "" {
 		notes = *latestAccrual.Notes + "\n" + notes
 	}
 	latestAccrual.Notes = &notes
 
-	if err := database.DB.Save(&latestAccrual).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to adjust balance"})
-		return
-	}
-
-	// Create audit log
-	user := getCurrentUser(c)
-	if user != nil {
-		createAuditLog(models.AuditEntityEmployee, uint(employeeID), models.AuditActionUpdate, user.ID, c,
-			map[string]interface{}{"balance": oldBalance},
-			map[string]interface{}{"balance": latestAccrual.DaysBalance, "adjustment": req.Days, "reason": req.Reason})
-	}
-
-	// Return updated balance
-	GetAnnualLeaveBalance(c)
+	return latestAccrual, oldBalance, db.Save(&latestAccrual).Error
 }
 
 // SetInitialBalanceRequest represents a request to set the initial balance (for onboarding)
@@ -689,7 +864,7 @@ type SetInitialBalanceRequest struct {
 // @Param id path int true "Employee ID"
 // @Param request body SetInitialBalanceRequest true "Initial balance data"
 // @Success 200 {object} AnnualLeaveBalanceResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -699,48 +874,39 @@ func SetInitialBalance(c *gin.Context) {
 
 	var req SetInitialBalanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	if req.Balance < 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Balance cannot be negative"})
+		utils.RespondError(c, http.StatusBadRequest, "Balance cannot be negative")
 		return
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
-	// If reset_all is true, delete all existing accruals first
-	if req.ResetAll {
-		if err := database.DB.Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
-			Delete(&models.LeaveAccrual{}).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset accruals: " + err.Error()})
-			return
-		}
-	}
-
 	// Get employee start date to validate against first month
 	var employeeStartDate time.Time
 	var employment models.EmploymentDetails
-	if err := database.DB.Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
+	if err := requestDB(c).Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
 		if employment.HireDate != nil {
 			employeeStartDate = *employment.HireDate
 		} else if employment.StartDate != nil {
 			employeeStartDate = *employment.StartDate
 		} else {
 			var emp models.Employee
-			if err := database.DB.First(&emp, employeeID).Error; err == nil {
+			if err := requestDB(c).First(&emp, employeeID).Error; err == nil {
 				employeeStartDate = emp.CreatedAt
 			}
 		}
 	} else {
 		var emp models.Employee
-		if err := database.DB.First(&emp, employeeID).Error; err == nil {
+		if err := requestDB(c).First(&emp, employeeID).Error; err == nil {
 			employeeStartDate = emp.CreatedAt
 		}
 	}
@@ -751,92 +917,97 @@ func SetInitialBalance(c *gin.Context) {
 	if req.AsOfMonth != "" {
 		parsed, err := time.Parse("2006-01", req.AsOfMonth)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of_month format. Use YYYY-MM"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid as_of_month format. Use YYYY-MM")
 			return
 		}
 		monthStart = time.Date(parsed.Year(), parsed.Month(), 1, 0, 0, 0, 0, time.UTC)
 	} else {
 		// Default to current month
-		now := time.Now()
+		now := utils.CompanyNow()
 		monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 	}
 
 	// Prevent creating accrual records for the first month of employment
 	if monthStart.Equal(firstMonthStart) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cannot set initial balance for the employee's first month of employment. Accrual starts from the second month.",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Cannot set initial balance for the employee's first month of employment. Accrual starts from the second month.")
 		return
 	}
 
-	// Get or create accrual record for this month
+	// Resetting old accruals and setting this month's balance succeed or fail together
 	var accrual models.LeaveAccrual
-	err := database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
-		employeeID, annualLeaveType.ID, monthStart).First(&accrual).Error
-
-	now := time.Now()
-	if err != nil {
-		// Create new accrual record
-		// If days_accrued and days_used are provided, use them; otherwise calculate
-		var daysAccrued, daysUsed float64
-		if req.DaysAccrued != nil {
-			daysAccrued = *req.DaysAccrued
-		}
-		if req.DaysUsed != nil {
-			daysUsed = *req.DaysUsed
+	now := utils.Now()
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		// If reset_all is true, delete all existing accruals first
+		if req.ResetAll {
+			if err := tx.Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
+				Delete(&models.LeaveAccrual{}).Error; err != nil {
+				return err
+			}
 		}
 
-		// If not provided, we need to calculate DaysAccrued properly
-		// The balance represents what's available now, so we need to know what was used
-		// If DaysUsed is not provided, we'll calculate it from existing approved leaves
-		if req.DaysAccrued == nil && req.DaysUsed == nil {
-			// Calculate total days used from approved leave records
-			var existingLeaves []models.Leave
-			database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
-				uint(employeeID), annualLeaveType.ID, models.StatusApproved).Find(&existingLeaves)
-			
-			var totalUsedFromLeaves float64
-			for _, leave := range existingLeaves {
-				totalUsedFromLeaves += float64(leave.GetDuration())
+		// Get or create accrual record for this month
+		err := tx.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+			employeeID, annualLeaveType.ID, monthStart).First(&accrual).Error
+		if err != nil {
+			// Create new accrual record
+			// If days_accrued and days_used are provided, use them; otherwise calculate
+			var daysAccrued, daysUsed float64
+			if req.DaysAccrued != nil {
+				daysAccrued = *req.DaysAccrued
+			}
+			if req.DaysUsed != nil {
+				daysUsed = *req.DaysUsed
 			}
+
+			// If not provided, we need to calculate DaysAccrued properly
+			// The balance represents what's available now, so we need to know what was used
+			// If DaysUsed is not provided, we'll calculate it from existing approved leaves
+			if req.DaysAccrued == nil && req.DaysUsed == nil {
+				// Calculate total days used from approved leave records
+				var existingLeaves []models.Leave
+				tx.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+					uint(employeeID), annualLeaveType.ID, models.StatusApproved).Find(&existingLeaves)
+			
+				var totalUsedFromLeaves float64
+				for _, leave := range existingLeaves {
+					totalUsedFromLeaves += float64(leave.GetDuration())
+				}
 			
-			// DaysAccrued = Current Balance + Total Used (because balance = accrued - used)
-			daysAccrued = req.Balance + totalUsedFromLeaves
-			daysUsed = totalUsedFromLeaves
-		} else if req.DaysAccrued == nil && req.DaysUsed != nil {
-			// DaysUsed provided but DaysAccrued not - calculate it
-			daysAccrued = req.Balance + *req.DaysUsed
-			daysUsed = *req.DaysUsed
-		} else if req.DaysAccrued != nil && req.DaysUsed == nil {
-			// DaysAccrued provided but DaysUsed not - calculate it
-			daysUsed = *req.DaysAccrued - req.Balance
-			if daysUsed < 0 {
-				daysUsed = 0
+				// DaysAccrued = Current Balance + Total Used (because balance = accrued - used)
+				daysAccrued = req.Balance + totalUsedFromLeaves
+				daysUsed = totalUsedFromLeaves
+			} else if req.DaysAccrued == nil && req.DaysUsed != nil {
+				// DaysUsed provided but DaysAccrued not - calculate it
+				daysAccrued = req.Balance + *req.DaysUsed
+				daysUsed = *req.DaysUsed
+			} else if req.DaysAccrued != nil && req.DaysUsed == nil {
+				// DaysAccrued provided but DaysUsed not - calculate it
+				daysUsed = *req.DaysAccrued - req.Balance
+				if daysUsed < 0 {
+					daysUsed = 0
+				}
+				daysAccrued = *req.DaysAccrued
+			} else {
+				// Both provided - use them
+				daysAccrued = *req.DaysAccrued
+				daysUsed = *req.DaysUsed
 			}
-			daysAccrued = *req.DaysAccrued
-		} else {
-			// Both provided - use them
-			daysAccrued = *req.DaysAccrued
-			daysUsed = *req.DaysUsed
-		}
 
-		accrual = models.LeaveAccrual{
-			EmployeeID:   uint(employeeID),
-			LeaveTypeID:  annualLeaveType.ID,
-			AccrualMonth: &monthStart,
-			DaysAccrued:  daysAccrued,
-			DaysUsed:     daysUsed,
-			DaysBalance:  req.Balance, // Set to the requested balance
-			IsProcessed:  true,
-			ProcessedAt:  &now,
-			Notes:        &req.Reason,
-		}
+			accrual = models.LeaveAccrual{
+				EmployeeID:   uint(employeeID),
+				LeaveTypeID:  annualLeaveType.ID,
+				AccrualMonth: &monthStart,
+				DaysAccrued:  daysAccrued,
+				DaysUsed:     daysUsed,
+				DaysBalance:  req.Balance, // Set to the requested balance
+				IsProcessed:  true,
+				ProcessedAt:  &now,
+				Notes:        &req.Reason,
+			}
 
-		if err := database.DB.Create(&accrual).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create accrual record"})
-			return
+			return tx.Create(&accrual).Error
 		}
-	} else {
+
 		// Update existing accrual record
 		oldBalance := accrual.DaysBalance
 
@@ -883,10 +1054,11 @@ func SetInitialBalance(c *gin.Context) {
 		accrual.IsProcessed = true
 		accrual.ProcessedAt = &now
 
-		if err := database.DB.Save(&accrual).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update accrual record"})
-			return
-		}
+		return tx.Save(&accrual).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to set initial balance")
+		return
 	}
 
 	// Create audit log
@@ -927,7 +1099,7 @@ type ManualAccrualRequest struct {
 // @Param id path int true "Employee ID"
 // @Param request body ManualAccrualRequest true "Manual accrual data"
 // @Success 201 {object} models.LeaveAccrual
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -937,28 +1109,28 @@ func AddManualAccrual(c *gin.Context) {
 
 	var req ManualAccrualRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Parse month
 	monthStart, err := time.Parse("2006-01", req.Month)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid month format. Use YYYY-MM")
 		return
 	}
 	monthStart = time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, time.UTC)
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Check if accrual already exists
 	var existing models.LeaveAccrual
-	if err := database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+	if err := requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 		employeeID, annualLeaveType.ID, monthStart).First(&existing).Error; err == nil {
 		// Update existing
 		existing.DaysAccrued += req.Days
@@ -968,12 +1140,12 @@ func AddManualAccrual(c *gin.Context) {
 			notes = *existing.Notes + "\n" + notes
 		}
 		existing.Notes = &notes
-		now := time.Now()
+		now := utils.Now()
 		existing.ProcessedAt = &now
 		existing.IsProcessed = true
 
-		if err := database.DB.Save(&existing).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update accrual"})
+		if err := requestDB(c).Save(&existing).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update accrual")
 			return
 		}
 		c.JSON(http.StatusOK, existing)
@@ -984,17 +1156,17 @@ func AddManualAccrual(c *gin.Context) {
 	prevMonth := monthStart.AddDate(0, -1, 0)
 	var prevAccrual models.LeaveAccrual
 	prevBalance := 0.0
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+	requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 		employeeID, annualLeaveType.ID, prevMonth).First(&prevAccrual)
 	if prevAccrual.ID > 0 {
 		prevBalance = prevAccrual.DaysBalance
 	}
 
 	// Calculate days used in this month
-	daysUsed := utils.CalculateDaysUsedInMonth(uint(employeeID), annualLeaveType.ID, monthStart)
+	daysUsed := utils.CalculateDaysUsedInMonth(requestDB(c), uint(employeeID), annualLeaveType.ID, monthStart)
 
 	// Create new accrual
-	now := time.Now()
+	now := utils.Now()
 	accrual := models.LeaveAccrual{
 		EmployeeID:   uint(employeeID),
 		LeaveTypeID:  annualLeaveType.ID,
@@ -1007,8 +1179,8 @@ func AddManualAccrual(c *gin.Context) {
 		Notes:        &req.Reason,
 	}
 
-	if err := database.DB.Create(&accrual).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create accrual"})
+	if err := requestDB(c).Create(&accrual).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create accrual")
 		return
 	}
 
@@ -1053,7 +1225,7 @@ type BulkAccrualItemResult struct {
 // @Param id path int true "Employee ID"
 // @Param request body BulkAccrualRequest true "Bulk accrual data"
 // @Success 200 {object} BulkAccrualResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -1063,19 +1235,19 @@ func BulkAddManualAccruals(c *gin.Context) {
 
 	var req BulkAccrualRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	if len(req.Accruals) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one accrual must be provided"})
+		utils.RespondError(c, http.StatusBadRequest, "At least one accrual must be provided")
 		return
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
@@ -1105,7 +1277,7 @@ func BulkAddManualAccruals(c *gin.Context) {
 
 		// Check if accrual already exists
 		var existing models.LeaveAccrual
-		if err := database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+		if err := requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 			employeeID, annualLeaveType.ID, monthStart).First(&existing).Error; err == nil {
 			// Update existing
 			existing.DaysAccrued += accrualReq.Days
@@ -1115,11 +1287,11 @@ func BulkAddManualAccruals(c *gin.Context) {
 				notes = *existing.Notes + "\n" + notes
 			}
 			existing.Notes = &notes
-			now := time.Now()
+			now := utils.Now()
 			existing.ProcessedAt = &now
 			existing.IsProcessed = true
 
-			if err := database.DB.Save(&existing).Error; err != nil {
+			if err := requestDB(c).Save(&existing).Error; err != nil {
 				result.Success = false
 				result.Message = "Failed to update existing accrual"
 				response.ErrorCount++
@@ -1139,17 +1311,17 @@ func BulkAddManualAccruals(c *gin.Context) {
 		prevMonth := monthStart.AddDate(0, -1, 0)
 		var prevAccrual models.LeaveAccrual
 		prevBalance := 0.0
-		database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+		requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 			employeeID, annualLeaveType.ID, prevMonth).First(&prevAccrual)
 		if prevAccrual.ID > 0 {
 			prevBalance = prevAccrual.DaysBalance
 		}
 
 		// Calculate days used in this month
-		daysUsed := utils.CalculateDaysUsedInMonth(uint(employeeID), annualLeaveType.ID, monthStart)
+		daysUsed := utils.CalculateDaysUsedInMonth(requestDB(c), uint(employeeID), annualLeaveType.ID, monthStart)
 
 		// Create new accrual
-		now := time.Now()
+		now := utils.Now()
 		accrual := models.LeaveAccrual{
 			EmployeeID:   uint(employeeID),
 			LeaveTypeID:  annualLeaveType.ID,
@@ -1162,7 +1334,7 @@ func BulkAddManualAccruals(c *gin.Context) {
 			Notes:        &accrualReq.Reason,
 		}
 
-		if err := database.DB.Create(&accrual).Error; err != nil {
+		if err := requestDB(c).Create(&accrual).Error; err != nil {
 			result.Success = false
 			result.Message = "Failed to create accrual"
 			response.ErrorCount++
@@ -1209,13 +1381,13 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Build query - exclude admin users
-	query := database.DB.Model(&models.Employee{}).Where("role != ?", models.RoleAdmin)
+	query := requestDB(c).Model(&models.Employee{}).Where("role != ?", models.RoleAdmin)
 	if department != "" {
 		query = query.Where("department = ?", department)
 	}
@@ -1228,7 +1400,7 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 		var filteredEmployees []models.Employee
 		for _, emp := range employees {
 			var employment models.EmploymentDetails
-			if err := database.DB.Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
+			if err := requestDB(c).Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
 				if string(employment.EmploymentStatus) == status {
 					filteredEmployees = append(filteredEmployees, emp)
 				}
@@ -1240,23 +1412,24 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 		employees = filteredEmployees
 	}
 
-	balances := make([]AnnualLeaveBalanceResponse, 0, len(employees))
+	// Streamed, as the balances of a whole company run to megabytes
+	balances := newJSONArrayWriter(c)
 
 	for _, emp := range employees {
 		// Ensure accruals are up to date
-		utils.EnsureAccrualsUpToDate(emp.ID, annualLeaveType.ID)
+		utils.EnsureAccrualsUpToDate(requestDB(c), emp.ID, annualLeaveType.ID)
 
 		// Get all accruals
 		// Order by accrual_month if available, otherwise by year and month
 		var accruals []models.LeaveAccrual
-		database.DB.Where("employee_id = ? AND leave_type_id = ?", emp.ID, annualLeaveType.ID).
-			Order("COALESCE(accrual_month, MAKE_DATE(year::integer, month::integer, 1)) DESC, year DESC, month DESC").
+		requestDB(c).Where("employee_id = ? AND leave_type_id = ?", emp.ID, annualLeaveType.ID).
+			Order(utils.AccrualMonthSQL() + " DESC, year DESC, month DESC").
 			Find(&accruals)
 
 		// Get employee start date to exclude first month accruals
 		var employeeStartDate time.Time
 		var employment models.EmploymentDetails
-		if err := database.DB.Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
+		if err := requestDB(c).Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
 			if employment.HireDate != nil {
 				employeeStartDate = *employment.HireDate
 			} else if employment.StartDate != nil {
@@ -1298,9 +1471,12 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 			if acc.ProcessedAt != nil {
 				processedAtStr = acc.ProcessedAt.Format(time.RFC3339)
 			}
+			periodStart, periodEnd := accrualPeriodDates(acc)
 
 			accrualResponses = append(accrualResponses, LeaveAccrualResponse{
 				Month:       acc.GetAccrualMonthKey(),
+				PeriodStart: periodStart,
+				PeriodEnd:   periodEnd,
 				DaysAccrued: acc.DaysAccrued,
 				DaysUsed:    acc.DaysUsed,
 				DaysBalance: acc.DaysBalance,
@@ -1313,7 +1489,7 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 		// This ensures accuracy even if accrual records have incorrect DaysUsed values
 		var totalUsed float64
 		var approvedLeaves []models.Leave
-		database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+		requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 			emp.ID, annualLeaveType.ID, models.StatusApproved).Find(&approvedLeaves)
 		for _, leave := range approvedLeaves {
 			totalUsed += float64(leave.GetDuration())
@@ -1322,11 +1498,15 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 		// Get carry-over balance
 		var carryOverBalance float64
 		if annualLeaveType.AllowCarryOver {
-			carryOverBalance, _ = utils.GetCarryOverBalance(emp.ID, annualLeaveType.ID)
+			carryOverBalance, _ = utils.GetCarryOverBalance(requestDB(c), emp.ID, annualLeaveType.ID)
 		}
 
 		// Get total current balance (accrual + carry-over) - this is what's actually available
-		currentBalance, _ := utils.GetCurrentLeaveBalance(emp.ID, annualLeaveType.ID)
+		currentBalance, _ := utils.GetCurrentLeaveBalance(requestDB(c), emp.ID, annualLeaveType.ID)
+
+		// Get year-to-date totals of the current leave year
+		leaveYear := utils.CurrentLeaveYear()
+		leaveYearAccrued, leaveYearUsed, _ := utils.LeaveYearTotals(requestDB(c), emp.ID, annualLeaveType.ID, leaveYear)
 
 		// Calculate all-time net balance using actual accrual records (includes initial balance adjustments)
 		// This reflects the actual accrued amount including any manual adjustments from onboarding
@@ -1336,16 +1516,16 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 
 		// Get pending and upcoming leaves
 		var pendingLeaves, upcomingLeaves int64
-		now := time.Now()
-		database.DB.Model(&models.Leave{}).
+		today := utils.CompanyToday()
+		requestDB(c).Model(&models.Leave{}).
 			Where("employee_id = ? AND leave_type_id = ? AND status = ?", emp.ID, annualLeaveType.ID, models.StatusPending).
 			Count(&pendingLeaves)
-		database.DB.Model(&models.Leave{}).
+		requestDB(c).Model(&models.Leave{}).
 			Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date > ?",
-				emp.ID, annualLeaveType.ID, models.StatusApproved, now).
+				emp.ID, annualLeaveType.ID, models.StatusApproved, today).
 			Count(&upcomingLeaves)
 
-		balances = append(balances, AnnualLeaveBalanceResponse{
+		if err := balances.Write(AnnualLeaveBalanceResponse{
 			EmployeeID:        emp.ID,
 			EmployeeName:      emp.Firstname + " " + emp.Lastname,
 			TotalAccrued:      totalAccrued,
@@ -1353,13 +1533,18 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 			AllTimeNetBalance: allTimeNetBalance,
 			CurrentBalance:    currentBalance,
 			CarryOverBalance:  carryOverBalance,
+			LeaveYear:         utils.LeaveYearLabel(leaveYear),
+			LeaveYearAccrued:  leaveYearAccrued,
+			LeaveYearUsed:     leaveYearUsed,
 			Accruals:          accrualResponses,
 			PendingLeaves:     int(pendingLeaves),
 			UpcomingLeaves:    int(upcomingLeaves),
-		})
+		}.rounded()); err != nil {
+			return // The client has gone away
+		}
 	}
 
-	c.JSON(http.StatusOK, balances)
+	balances.Close()
 }
 
 // ExportAnnualLeaveBalances exports annual leave balances to Excel or PDF
@@ -1371,6 +1556,9 @@ func GetAllEmployeesLeaveBalances(c *gin.Context) {
 // @Param format query string true "Export format (excel or pdf)" Enums(excel, pdf) default:"excel"
 // @Param department query string false "Filter by department"
 // @Param status query string false "Filter by employment status"
+// @Param detailed query bool false "Add a summary sheet by department and a sheet per employee with their accrual ledger and approved leaves (Excel only)"
+// @Param lang query string false "Language of the file: en, fr or pt (default: the Accept-Language header)"
+// @Param paper_size query string false "Paper size of a PDF: A4 or Letter (default: the export_paper_size setting)"
 // @Success 200 {file} file "Excel or PDF file"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -1382,190 +1570,37 @@ func ExportAnnualLeaveBalances(c *gin.Context) {
 		format = "excel"
 	}
 	if format != "excel" && format != "pdf" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Use 'excel' or 'pdf'"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'excel' or 'pdf'")
 		return
 	}
-
-	department := c.Query("department")
-	status := c.Query("status")
-
-	// Get Annual leave type
-	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	locale, ok := exportLocale(c)
+	if !ok {
 		return
 	}
+	detailed := format == "excel" && c.Query("detailed") == "true"
 
-	// Build query (same logic as GetAllEmployeesLeaveBalances) - exclude admin users
-	query := database.DB.Model(&models.Employee{}).Where("role != ?", models.RoleAdmin)
-	if department != "" {
-		query = query.Where("department = ?", department)
-	}
-
-	var employees []models.Employee
-	query.Find(&employees)
-
-	// If status filter, need to check employment details
-	if status != "" {
-		var filteredEmployees []models.Employee
-		for _, emp := range employees {
-			var employment models.EmploymentDetails
-			if err := database.DB.Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
-				if string(employment.EmploymentStatus) == status {
-					filteredEmployees = append(filteredEmployees, emp)
-				}
-			} else if status == "active" {
-				filteredEmployees = append(filteredEmployees, emp)
-			}
-		}
-		employees = filteredEmployees
-	}
-
-	// Get balances (same logic as GetAllEmployeesLeaveBalances)
-	balances := make([]AnnualLeaveBalanceResponse, 0, len(employees))
-
-	for _, emp := range employees {
-		utils.EnsureAccrualsUpToDate(emp.ID, annualLeaveType.ID)
-
-		var accruals []models.LeaveAccrual
-		database.DB.Where("employee_id = ? AND leave_type_id = ?", emp.ID, annualLeaveType.ID).
-			Order("accrual_month DESC").
-			Find(&accruals)
-
-		// Get employee start date to exclude first month accruals
-		var employeeStartDate time.Time
-		var employment models.EmploymentDetails
-		if err := database.DB.Where("employee_id = ?", emp.ID).First(&employment).Error; err == nil {
-			if employment.HireDate != nil {
-				employeeStartDate = *employment.HireDate
-			} else if employment.StartDate != nil {
-				employeeStartDate = *employment.StartDate
-			} else {
-				employeeStartDate = emp.CreatedAt
-			}
-		} else {
-			employeeStartDate = emp.CreatedAt
-		}
-		firstMonthStart := time.Date(employeeStartDate.Year(), employeeStartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
-
-		var totalAccrued float64
-		for _, acc := range accruals {
-			// Get accrual month for comparison
-			var accrualMonth time.Time
-			if acc.AccrualMonth != nil {
-				accrualMonth = *acc.AccrualMonth
-			} else if acc.Year > 0 && acc.Month > 0 {
-				accrualMonth = time.Date(acc.Year, time.Month(acc.Month), 1, 0, 0, 0, 0, time.UTC)
-			}
-
-			// Skip regular accruals in the first month of employment
-			// BUT include initial balance adjustments (identified by Notes containing "Initial balance" or "set-initial")
-			isInitialBalance := acc.Notes != nil && 
-				(*acc.Notes != "" && (strings.Contains(*acc.Notes, "Initial balance") || 
-				 strings.Contains(*acc.Notes, "set-initial") || 
-				 strings.Contains(*acc.Notes, "Set initial")))
-			
-			if !accrualMonth.IsZero() && accrualMonth.Equal(firstMonthStart) && !isInitialBalance {
-				continue
-			}
-
-			totalAccrued += acc.DaysAccrued
-		}
-
-		// Calculate total used directly from approved leave records (source of truth)
-		// This ensures accuracy even if accrual records have incorrect DaysUsed values
-		var totalUsed float64
-		var approvedLeaves []models.Leave
-		database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
-			emp.ID, annualLeaveType.ID, models.StatusApproved).Find(&approvedLeaves)
-		for _, leave := range approvedLeaves {
-			totalUsed += float64(leave.GetDuration())
-		}
-
-		// Get carry-over balance
-		var carryOverBalance float64
-		if annualLeaveType.AllowCarryOver {
-			carryOverBalance, _ = utils.GetCarryOverBalance(emp.ID, annualLeaveType.ID)
-		}
-
-		// Get total current balance (accrual + carry-over) - this is what's actually available
-		currentBalance, _ := utils.GetCurrentLeaveBalance(emp.ID, annualLeaveType.ID)
-
-		// Calculate all-time net balance using actual accrual records (includes initial balance adjustments)
-		// This reflects the actual accrued amount including any manual adjustments from onboarding
-		// AllTimeNetBalance = Total Accrued (from records) - Total Used (from approved leaves)
-		allTimeNetBalance := totalAccrued - totalUsed
-		// Don't set to 0 if negative - negative values are valid (overdrawn)
-
-		var pendingLeaves, upcomingLeaves int64
-		now := time.Now()
-		database.DB.Model(&models.Leave{}).
-			Where("employee_id = ? AND leave_type_id = ? AND status = ?", emp.ID, annualLeaveType.ID, models.StatusPending).
-			Count(&pendingLeaves)
-		database.DB.Model(&models.Leave{}).
-			Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date > ?",
-				emp.ID, annualLeaveType.ID, models.StatusApproved, now).
-			Count(&upcomingLeaves)
-
-		balances = append(balances, AnnualLeaveBalanceResponse{
-			EmployeeID:        emp.ID,
-			EmployeeName:      emp.Firstname + " " + emp.Lastname,
-			TotalAccrued:      totalAccrued,
-			TotalUsed:         totalUsed,
-			AllTimeNetBalance: allTimeNetBalance,
-			CurrentBalance:    currentBalance,
-			CarryOverBalance:  carryOverBalance,
-			PendingLeaves:     int(pendingLeaves),
-			UpcomingLeaves:    int(upcomingLeaves),
-		})
+	preparedData, err := utils.AnnualLeaveBalancesForExport(requestDB(c), c.Query("department"), c.Query("status"), detailed, locale)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
+		return
 	}
-
-	// Convert to export format
-	exportData := make([]utils.EmployeeBalanceData, 0, len(balances))
-	for _, balance := range balances {
-		// Get employee department
-		var employee models.Employee
-		database.DB.First(&employee, balance.EmployeeID)
-
-		exportData = append(exportData, utils.EmployeeBalanceData{
-			EmployeeID:     balance.EmployeeID,
-			EmployeeName:   balance.EmployeeName,
-			Department:     employee.Department,
-			TotalAccrued:   balance.TotalAccrued,
-			TotalUsed:      balance.TotalUsed,
-			CurrentBalance: balance.CurrentBalance,
-			PendingLeaves:  balance.PendingLeaves,
-			UpcomingLeaves: balance.UpcomingLeaves,
-		})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+		return
 	}
 
-	preparedData := utils.PrepareBalancesForExport(exportData)
-
-	// Generate file based on format
-	var fileData []byte
-	var filename string
-	var contentType string
-	var err error
-
+	// Stream the file based on format
 	if format == "excel" {
-		fileData, err = utils.ExportAnnualLeaveBalancesToExcel(preparedData)
-		filename = fmt.Sprintf("annual_leave_balances_%s.xlsx", time.Now().Format("20060102_150405"))
-		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-	} else {
-		fileData, err = utils.ExportAnnualLeaveBalancesToPDF(preparedData)
-		filename = fmt.Sprintf("annual_leave_balances_%s.pdf", time.Now().Format("20060102_150405"))
-		contentType = "application/pdf"
-	}
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate export file"})
+		filename := fmt.Sprintf("annual_leave_balances_%s.xlsx", time.Now().Format("20060102_150405"))
+		streamDownload(c, filename, utils.XLSXContentType, "Failed to generate export file", func(w io.Writer) error {
+			return utils.ExportAnnualLeaveBalancesToExcel(w, preparedData, detailed, locale)
+		})
 		return
 	}
-
-	// Set headers for file download
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
-	c.Header("Content-Type", contentType)
-	c.Data(http.StatusOK, contentType, fileData)
+	filename := fmt.Sprintf("annual_leave_balances_%s.pdf", time.Now().Format("20060102_150405"))
+	streamDownload(c, filename, "application/pdf", "Failed to generate export file", func(w io.Writer) error {
+		return utils.ExportAnnualLeaveBalancesToPDF(w, preparedData, locale)
+	})
 }
 
 // ExportEmployeeAnnualLeave exports single employee annual leave report to Excel or PDF
@@ -1576,6 +1611,8 @@ func ExportAnnualLeaveBalances(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
 // @Param format query string true "Export format (excel or pdf)" Enums(excel, pdf) default:"excel"
+// @Param lang query string false "Language of the file: en, fr or pt (default: the Accept-Language header)"
+// @Param paper_size query string false "Paper size of a PDF: A4 or Letter (default: the export_paper_size setting)"
 // @Success 200 {file} file "Excel or PDF file"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -1585,7 +1622,7 @@ func ExportAnnualLeaveBalances(c *gin.Context) {
 func ExportEmployeeAnnualLeave(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
 		return
 	}
 
@@ -1594,40 +1631,44 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 		format = "excel"
 	}
 	if format != "excel" && format != "pdf" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Use 'excel' or 'pdf'"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'excel' or 'pdf'")
+		return
+	}
+	locale, ok := exportLocale(c)
+	if !ok {
 		return
 	}
 
 	// Get employee
 	var employee models.Employee
-	if err := database.DB.First(&employee, employeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Ensure accruals are up to date
-	if err := utils.EnsureAccrualsUpToDate(uint(employeeID), annualLeaveType.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process accruals"})
+	if err := utils.EnsureAccrualsUpToDate(requestDB(c), uint(employeeID), annualLeaveType.ID); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to process accruals")
 		return
 	}
 
 	// Get all accruals
 	var accruals []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
-		Order("COALESCE(accrual_month, MAKE_DATE(year::integer, month::integer, 1)) ASC, year ASC, month ASC").
+	requestDB(c).Where("employee_id = ? AND leave_type_id = ?", employeeID, annualLeaveType.ID).
+		Order(utils.AccrualMonthSQL() + " ASC, year ASC, month ASC").
 		Find(&accruals)
 
 	// Get employee start date to exclude first month accruals
 	var employeeStartDate time.Time
 	var employment models.EmploymentDetails
-	if err := database.DB.Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
+	if err := requestDB(c).Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
 		if employment.HireDate != nil {
 			employeeStartDate = *employment.HireDate
 		} else if employment.StartDate != nil {
@@ -1668,11 +1709,15 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 
 		processedAtStr := ""
 		if acc.ProcessedAt != nil {
-			processedAtStr = acc.ProcessedAt.Format("2006-01-02 15:04:05")
+			processedAtStr = locale.DateTime(*acc.ProcessedAt)
+		}
+		month := acc.GetAccrualMonthKey()
+		if !accrualMonth.IsZero() {
+			month = locale.Month(accrualMonth)
 		}
 
 		accrualExports = append(accrualExports, utils.AccrualExport{
-			Month:       acc.GetAccrualMonthKey(),
+			Month:       month,
 			DaysAccrued: acc.DaysAccrued,
 			DaysUsed:    acc.DaysUsed,
 			DaysBalance: acc.DaysBalance,
@@ -1684,7 +1729,7 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 	// Calculate total used from approved leaves
 	var totalUsed float64
 	var approvedLeaves []models.Leave
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+	requestDB(c).Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 		employeeID, annualLeaveType.ID, models.StatusApproved).
 		Order("start_date DESC").
 		Find(&approvedLeaves)
@@ -1693,8 +1738,8 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 	for _, leave := range approvedLeaves {
 		totalUsed += float64(leave.GetDuration())
 		leaveExports = append(leaveExports, utils.LeaveExport{
-			StartDate: leave.StartDate.Format("2006-01-02"),
-			EndDate:   leave.EndDate.Format("2006-01-02"),
+			StartDate: locale.Date(leave.StartDate),
+			EndDate:   locale.Date(leave.EndDate),
 			Duration:  float64(leave.GetDuration()),
 			Reason:    leave.Reason,
 		})
@@ -1703,11 +1748,15 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 	// Get carry-over balance
 	var carryOverBalance float64
 	if annualLeaveType.AllowCarryOver {
-		carryOverBalance, _ = utils.GetCarryOverBalance(uint(employeeID), annualLeaveType.ID)
+		carryOverBalance, _ = utils.GetCarryOverBalance(requestDB(c), uint(employeeID), annualLeaveType.ID)
 	}
 
 	// Get current balance
-	currentBalance, _ := utils.GetCurrentLeaveBalance(uint(employeeID), annualLeaveType.ID)
+	currentBalance, _ := utils.GetCurrentLeaveBalance(requestDB(c), uint(employeeID), annualLeaveType.ID)
+
+	// Get year-to-date totals of the current leave year
+	leaveYear := utils.CurrentLeaveYear()
+	leaveYearAccrued, leaveYearUsed, _ := utils.LeaveYearTotals(requestDB(c), uint(employeeID), annualLeaveType.ID, leaveYear)
 
 	// Calculate all-time net balance using actual accrual records (includes initial balance adjustments)
 	// This reflects the actual accrued amount including any manual adjustments from onboarding
@@ -1724,6 +1773,9 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 		CurrentBalance:    currentBalance,
 		CarryOverBalance:  carryOverBalance,
 		AllTimeNetBalance: allTimeNetBalance,
+		LeaveYear:         leaveYear,
+		LeaveYearAccrued:  leaveYearAccrued,
+		LeaveYearUsed:     leaveYearUsed,
 		Accruals:          accrualExports,
 		ApprovedLeaves:    leaveExports,
 	}
@@ -1734,20 +1786,22 @@ func ExportEmployeeAnnualLeave(c *gin.Context) {
 	var contentType string
 
 	if format == "excel" {
-		fileData, err = utils.ExportEmployeeAnnualLeaveToExcel(report)
+		fileData, err = utils.ExportEmployeeAnnualLeaveToExcel(report, locale)
 		filename = fmt.Sprintf("annual_leave_report_%s_%d.xlsx", strings.ReplaceAll(report.EmployeeName, " ", "_"), employeeID)
 		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
 	} else {
-		fileData, err = utils.ExportEmployeeAnnualLeaveToPDF(report)
+		fileData, err = utils.ExportEmployeeAnnualLeaveToPDF(report, locale)
 		filename = fmt.Sprintf("annual_leave_report_%s_%d.pdf", strings.ReplaceAll(report.EmployeeName, " ", "_"), employeeID)
 		contentType = "application/pdf"
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate export file"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
 		return
 	}
 
+	noteFileAccess(c, models.FileAccessLog{EmployeeID: &report.EmployeeID})
+
 	// Set headers for file download
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 	c.Header("Content-Type", contentType)
@@ -1781,27 +1835,27 @@ type MonthlyLeaveReportResponse struct {
 func GetMonthlyLeaveReport(c *gin.Context) {
 	monthStr := c.Query("month")
 	if monthStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Month parameter is required (format: YYYY-MM)"})
+		utils.RespondError(c, http.StatusBadRequest, "Month parameter is required (format: YYYY-MM)")
 		return
 	}
 
 	month, err := time.Parse("2006-01", monthStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM (e.g., 2025-02)"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid month format. Use YYYY-MM (e.g., 2025-02)")
 		return
 	}
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Generate monthly report
-	reportData, err := utils.GetMonthlyLeaveReport(month, annualLeaveType.ID)
+	reportData, err := utils.GetMonthlyLeaveReport(requestDB(c), month, annualLeaveType.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate monthly report"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate monthly report")
 		return
 	}
 
@@ -1831,6 +1885,7 @@ func GetMonthlyLeaveReport(c *gin.Context) {
 // @Security BearerAuth
 // @Param month query string true "Month in YYYY-MM format (e.g., 2025-02)"
 // @Param organization query string false "Organization name (default: 'CHUDLEIGH HOUSE SCHOOL')"
+// @Param lang query string false "Language of the file: en, fr or pt (default: the Accept-Language header)"
 // @Success 200 {file} file "Excel file"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -1839,13 +1894,17 @@ func GetMonthlyLeaveReport(c *gin.Context) {
 func ExportMonthlyLeaveReport(c *gin.Context) {
 	monthStr := c.Query("month")
 	if monthStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Month parameter is required (format: YYYY-MM)"})
+		utils.RespondError(c, http.StatusBadRequest, "Month parameter is required (format: YYYY-MM)")
 		return
 	}
 
 	month, err := time.Parse("2006-01", monthStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM (e.g., 2025-02)"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid month format. Use YYYY-MM (e.g., 2025-02)")
+		return
+	}
+	locale, ok := exportLocale(c)
+	if !ok {
 		return
 	}
 
@@ -1856,74 +1915,66 @@ func ExportMonthlyLeaveReport(c *gin.Context) {
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// Generate monthly report
-	reportData, err := utils.GetMonthlyLeaveReport(month, annualLeaveType.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate monthly report"})
-		return
-	}
-
-	// Export to Excel
-	fileData, err := utils.ExportMonthlyLeaveReportToExcel(reportData, month, organizationName)
+	reportData, err := utils.GetMonthlyLeaveReport(requestDB(c), month, annualLeaveType.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate export file"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate monthly report")
 		return
 	}
 
-	// Set headers for file download
+	// Stream the Excel file
 	filename := fmt.Sprintf("leave_days_%s.xlsx", month.Format("200601"))
-	contentType := "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
-	c.Header("Content-Type", contentType)
-	c.Data(http.StatusOK, contentType, fileData)
+	streamDownload(c, filename, utils.XLSXContentType, "Failed to generate export file", func(w io.Writer) error {
+		return utils.ExportMonthlyLeaveReportToExcel(w, reportData, month, organizationName, locale)
+	})
 }
 
 // ProcessYearEndCarryOverRequest represents a request to process year-end carry-over
 type ProcessYearEndCarryOverRequest struct {
 	LeaveTypeID uint `json:"leave_type_id" binding:"required" example:"1"`
-	FromYear    int  `json:"from_year" binding:"required" example:"2024"`
+	FromYear    int  `json:"from_year" binding:"required" example:"2024"` // Leave year to carry over from, named by the calendar year it starts in
 }
 
 // ProcessYearEndCarryOver processes carry-over for all employees at year-end
 // @Summary Process year-end carry-over
-// @Description Process carry-over for all employees for a specific year (HR/Admin only)
+// @Description Process carry-over for all employees from a leave year into the next. Leave years start in the month of the leave_year_start_month setting and are named by the calendar year they start in, so with an April start from_year 2024 carries over what was left of April 2024 to March 2025 (HR/Admin only)
 // @Tags HR - Leave Management
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body ProcessYearEndCarryOverRequest true "Carry-over processing request"
 // @Success 200 {object} MessageResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /api/hr/leaves/process-carryover [post]
 func ProcessYearEndCarryOver(c *gin.Context) {
 	var req ProcessYearEndCarryOverRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Validate year
 	if req.FromYear < 2000 || req.FromYear > 2100 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid year")
 		return
 	}
 
 	// Get leave type
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, req.LeaveTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave type not found"})
+	if err := requestDB(c).First(&leaveType, req.LeaveTypeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Leave type not found")
 		return
 	}
 
 	if !leaveType.AllowCarryOver {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Carry-over is not enabled for this leave type"})
+		utils.RespondError(c, http.StatusBadRequest, "Carry-over is not enabled for this leave type")
 		return
 	}
 
@@ -1932,7 +1983,7 @@ func ProcessYearEndCarryOver(c *gin.Context) {
 	processedBy := userID.(uint)
 
 	// Process carry-over for all employees
-	processed, skipped, errors := utils.ProcessCarryOverForAllEmployees(req.LeaveTypeID, req.FromYear, &processedBy)
+	processed, skipped, errors := utils.ProcessCarryOverForAllEmployees(requestDB(c), req.LeaveTypeID, req.FromYear, &processedBy)
 
 	response := gin.H{
 		"message":   "Carry-over processing completed",
@@ -1970,15 +2021,15 @@ func GetCarryOverHistory(c *gin.Context) {
 	if leaveTypeIDStr != "" {
 		parsed, err := strconv.ParseUint(leaveTypeIDStr, 10, 32)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave_type_id"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid leave_type_id")
 			return
 		}
 		leaveTypeID = uint(parsed)
 	} else {
 		// Default to Annual leave
 		var annualLeaveType models.LeaveType
-		if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+		if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 			return
 		}
 		leaveTypeID = annualLeaveType.ID
@@ -1986,7 +2037,7 @@ func GetCarryOverHistory(c *gin.Context) {
 
 	carryOvers, err := utils.GetCarryOverHistory(uint(employeeID), leaveTypeID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch carry-over history"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch carry-over history")
 		return
 	}
 
@@ -2014,39 +2065,39 @@ func GetCarryOverBalance(c *gin.Context) {
 	if leaveTypeIDStr != "" {
 		parsed, err := strconv.ParseUint(leaveTypeIDStr, 10, 32)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave_type_id"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid leave_type_id")
 			return
 		}
 		leaveTypeID = uint(parsed)
 	} else {
 		// Default to Annual leave
 		var annualLeaveType models.LeaveType
-		if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+		if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 			return
 		}
 		leaveTypeID = annualLeaveType.ID
 	}
 
-	balance, err := utils.GetCarryOverBalance(uint(employeeID), leaveTypeID)
+	balance, err := utils.GetCarryOverBalance(requestDB(c), uint(employeeID), leaveTypeID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate carry-over balance"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate carry-over balance")
 		return
 	}
 
 	// Get detailed carry-over records
 	carryOvers, err := utils.GetCarryOverHistory(uint(employeeID), leaveTypeID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch carry-over details"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch carry-over details")
 		return
 	}
 
 	var activeCarryOvers []models.LeaveCarryOver
 	var expiredCarryOvers []models.LeaveCarryOver
-	now := time.Now()
+	today := utils.CompanyToday()
 
 	for _, co := range carryOvers {
-		if co.IsExpired || (co.ExpiryDate != nil && co.ExpiryDate.Before(now)) {
+		if co.IsExpired || (co.ExpiryDate != nil && co.ExpiryDate.Before(today)) {
 			expiredCarryOvers = append(expiredCarryOvers, co)
 		} else {
 			activeCarryOvers = append(activeCarryOvers, co)
@@ -2072,7 +2123,7 @@ func GetCarryOverBalance(c *gin.Context) {
 // @Router /api/hr/leaves/expire-carryovers [post]
 func ExpireCarryOvers(c *gin.Context) {
 	if err := utils.ExpireCarryOvers(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to expire carry-overs"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to expire carry-overs")
 		return
 	}
 
@@ -2120,7 +2171,7 @@ type ImportResult struct {
 func BulkImportLeaveBalances(c *gin.Context) {
 	file, _, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		utils.RespondError(c, http.StatusBadRequest, "No file uploaded")
 		return
 	}
 	defer file.Close()
@@ -2138,11 +2189,11 @@ func BulkImportLeaveBalances(c *gin.Context) {
 	for {
 		record, err := reader.Read()
 		if err == io.EOF {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSV format: could not find month or header row"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid CSV format: could not find month or header row")
 			return
 		}
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV file: " + err.Error()})
+			utils.RespondError(c, http.StatusBadRequest, "Failed to read CSV file: "+err.Error())
 			return
 		}
 
@@ -2152,7 +2203,7 @@ func BulkImportLeaveBalances(c *gin.Context) {
 		if strings.Contains(strings.ToUpper(lineText), "FOR THE MONTH OF") {
 			monthStr = extractMonthFromLine(lineText)
 			if monthStr == "" && monthOverride == "" {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Could not extract month from CSV. Please provide month parameter."})
+				utils.RespondError(c, http.StatusBadRequest, "Could not extract month from CSV. Please provide month parameter.")
 				return
 			}
 			if monthOverride != "" {
@@ -2171,7 +2222,7 @@ func BulkImportLeaveBalances(c *gin.Context) {
 
 	if monthStr == "" {
 		if monthOverride == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Could not determine month from CSV. Please provide month parameter."})
+			utils.RespondError(c, http.StatusBadRequest, "Could not determine month from CSV. Please provide month parameter.")
 			return
 		}
 		monthStr = monthOverride
@@ -2180,22 +2231,22 @@ func BulkImportLeaveBalances(c *gin.Context) {
 	// Parse month
 	monthStart, err := time.Parse("2006-01", monthStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid month format. Use YYYY-MM")
 		return
 	}
 	monthStart = time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, time.UTC)
 
 	// Get Annual leave type
 	var annualLeaveType models.LeaveType
-	if err := database.DB.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Annual leave type not found"})
+	if err := requestDB(c).Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Annual leave type not found")
 		return
 	}
 
 	// If reset_all, delete all existing accruals
 	if resetAll {
-		if err := database.DB.Where("leave_type_id = ?", annualLeaveType.ID).Delete(&models.LeaveAccrual{}).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset accruals: " + err.Error()})
+		if err := requestDB(c).Where("leave_type_id = ?", annualLeaveType.ID).Delete(&models.LeaveAccrual{}).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to reset accruals: "+err.Error())
 			return
 		}
 	}
@@ -2276,6 +2327,7 @@ func BulkImportLeaveBalances(c *gin.Context) {
 
 		// Find employee by name
 		var employee models.Employee
+		var newEmployee bool
 		nameParts := strings.Fields(employeeName)
 		var firstname, lastname string
 
@@ -2289,12 +2341,12 @@ func BulkImportLeaveBalances(c *gin.Context) {
 		}
 
 		// Try to match by firstname and lastname
-		err = database.DB.Where("LOWER(firstname) = LOWER(?) AND LOWER(lastname) = LOWER(?)", firstname, lastname).
+		err = requestDB(c).Where("LOWER(firstname) = LOWER(?) AND LOWER(lastname) = LOWER(?)", firstname, lastname).
 			First(&employee).Error
 
 		// If not found, try matching by full name in either field
 		if err != nil && len(nameParts) >= 2 {
-			err = database.DB.Where("LOWER(CONCAT(firstname, ' ', lastname)) = LOWER(?)", employeeName).
+			err = requestDB(c).Where("LOWER("+database.ConcatSQL("firstname", "' '", "lastname")+") = LOWER(?)", employeeName).
 				Or("LOWER(firstname) LIKE LOWER(?) OR LOWER(lastname) LIKE LOWER(?)",
 					"%"+firstname+"%", "%"+lastname+"%").
 				First(&employee).Error
@@ -2302,7 +2354,7 @@ func BulkImportLeaveBalances(c *gin.Context) {
 
 		// If still not found, try single name match
 		if err != nil && len(nameParts) == 1 {
-			err = database.DB.Where("LOWER(firstname) = LOWER(?) OR LOWER(lastname) = LOWER(?)", employeeName, employeeName).
+			err = requestDB(c).Where("LOWER(firstname) = LOWER(?) OR LOWER(lastname) = LOWER(?)", employeeName, employeeName).
 				First(&employee).Error
 		}
 
@@ -2320,7 +2372,7 @@ func BulkImportLeaveBalances(c *gin.Context) {
 			// Check if email already exists, if so, add a number
 			var existingEmail models.Employee
 			emailCounter := 1
-			for database.DB.Where("email = ?", email).First(&existingEmail).Error == nil {
+			for requestDB(c).Where("email = ?", email).First(&existingEmail).Error == nil {
 				if lastname != "" {
 					email = fmt.Sprintf("%s.%s.%d@company.com", strings.ToLower(firstname), strings.ToLower(lastname), emailCounter)
 				} else {
@@ -2358,63 +2410,56 @@ func BulkImportLeaveBalances(c *gin.Context) {
 				Role:         models.RoleEmployee,
 			}
 
-			if err := database.DB.Create(&employee).Error; err != nil {
-				failed++
-				results = append(results, ImportResult{
-					EmployeeName: employeeName,
-					Success:      false,
-					Error:        "Failed to create employee: " + err.Error(),
-				})
-				continue
-			}
+			newEmployee = true
+		}
 
-			// Create EmploymentDetails with hire date (use the month from CSV as hire date)
-			hireDate := time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, time.UTC)
-			employmentDetails := models.EmploymentDetails{
-				EmployeeID:       employee.ID,
-				EmploymentType:   models.EmploymentTypeFullTime,
-				EmploymentStatus: models.EmploymentStatusActive,
-				HireDate:         &hireDate,
-				StartDate:        &hireDate,
-			}
+		// A new employee, their employment details and the accrual are saved together, so a failed row leaves nothing behind
+		err = withTransaction(c, func(tx *gorm.DB) error {
+			if newEmployee {
+				if err := tx.Create(&employee).Error; err != nil {
+					return fmt.Errorf("create employee: %w", err)
+				}
 
-			// Create employment details (don't fail if this fails, employee is already created)
-			if err := database.DB.Create(&employmentDetails).Error; err != nil {
-				// Log but don't fail - employment details can be added later
-				// This is a non-critical error
+				// Create EmploymentDetails with hire date (use the month from CSV as hire date)
+				hireDate := time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, time.UTC)
+				employmentDetails := models.EmploymentDetails{
+					EmployeeID:       employee.ID,
+					EmploymentType:   models.EmploymentTypeFullTime,
+					EmploymentStatus: models.EmploymentStatusActive,
+					HireDate:         &hireDate,
+					StartDate:        &hireDate,
+				}
+				if err := tx.Create(&employmentDetails).Error; err != nil {
+					return fmt.Errorf("create employment details: %w", err)
+				}
 			}
-		}
 
-		// Get or create accrual record for this month
-		var accrual models.LeaveAccrual
-		err = database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
-			employee.ID, annualLeaveType.ID, monthStart).First(&accrual).Error
+			// Get or create accrual record for this month
+			var accrual models.LeaveAccrual
+			err := tx.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+				employee.ID, annualLeaveType.ID, monthStart).First(&accrual).Error
 
-		now := time.Now()
-		if err != nil {
-			// Create new accrual record
-			accrual = models.LeaveAccrual{
-				EmployeeID:   employee.ID,
-				LeaveTypeID:  annualLeaveType.ID,
-				AccrualMonth: &monthStart,
-				DaysAccrued:  totalDays,
-				DaysUsed:     daysTaken,
-				DaysBalance:  netBalance,
-				IsProcessed:  true,
-				ProcessedAt:  &now,
-				Notes:        getStringPtrCSV(fmt.Sprintf("Imported from CSV: Opening=%.2f, Earned=%.2f, Total=%.2f, Taken=%.2f, Net=%.2f", opening, daysEarned, totalDays, daysTaken, netBalance)),
-			}
+			now := utils.Now()
+			if err != nil {
+				// Create new accrual record
+				accrual = models.LeaveAccrual{
+					EmployeeID:   employee.ID,
+					LeaveTypeID:  annualLeaveType.ID,
+					AccrualMonth: &monthStart,
+					DaysAccrued:  totalDays,
+					DaysUsed:     daysTaken,
+					DaysBalance:  netBalance,
+					IsProcessed:  true,
+					ProcessedAt:  &now,
+					Notes:        getStringPtrCSV(fmt.Sprintf("Imported from CSV: Opening=%.2f, Earned=%.2f, Total=%.2f, Taken=%.2f, Net=%.2f", opening, daysEarned, totalDays, daysTaken, netBalance)),
+				}
 
-			if err := database.DB.Create(&accrual).Error; err != nil {
-				failed++
-				results = append(results, ImportResult{
-					EmployeeName: employeeName,
-					Success:      false,
-					Error:        "Failed to create accrual: " + err.Error(),
-				})
-				continue
+				if err := tx.Create(&accrual).Error; err != nil {
+					return fmt.Errorf("create accrual: %w", err)
+				}
+				return nil
 			}
-		} else {
+
 			// Update existing accrual record
 			accrual.DaysAccrued = totalDays
 			accrual.DaysUsed = daysTaken
@@ -2428,15 +2473,19 @@ func BulkImportLeaveBalances(c *gin.Context) {
 			}
 			accrual.Notes = &notes
 
-			if err := database.DB.Save(&accrual).Error; err != nil {
-				failed++
-				results = append(results, ImportResult{
-					EmployeeName: employeeName,
-					Success:      false,
-					Error:        "Failed to update accrual: " + err.Error(),
-				})
-				continue
+			if err := tx.Save(&accrual).Error; err != nil {
+				return fmt.Errorf("update accrual: %w", err)
 			}
+			return nil
+		})
+		if err != nil {
+			failed++
+			results = append(results, ImportResult{
+				EmployeeName: employeeName,
+				Success:      false,
+				Error:        "Failed to " + err.Error(),
+			})
+			continue
 		}
 
 		success++
@@ -2557,7 +2606,7 @@ type UpdateLeaveRequest struct {
 // @Security BearerAuth
 // @Param request body AdminLeaveRequest true "Leave data"
 // @Success 201 {object} models.Leave
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -2565,51 +2614,51 @@ type UpdateLeaveRequest struct {
 func CreateLeaveForEmployee(c *gin.Context) {
 	var req AdminLeaveRequest
 	if err := c.ShouldBind(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid form data: " + err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Verify employee exists
 	var employee models.Employee
-	if err := database.DB.First(&employee, req.EmployeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, req.EmployeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
 	// Verify leave type exists
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, req.LeaveTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave type not found"})
+	if err := requestDB(c).First(&leaveType, req.LeaveTypeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Leave type not found")
 		return
 	}
 
 	// Parse dates
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	endDate, err := time.Parse("2006-01-02", req.EndDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use YYYY-MM-DD"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
 		return
 	}
 
 	// Validate dates
-	if err := utils.ValidateLeaveDates(startDate, endDate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := utils.ValidateLeaveDates(startDate, endDate, utils.EmployeeLocation(&employee)); err != nil {
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
 		return
 	}
 
 	// Check for overlapping leaves (excluding cancelled/rejected)
 	hasOverlap, err := utils.CheckOverlappingLeaves(req.EmployeeID, startDate, endDate, nil)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check overlapping leaves"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to check overlapping leaves")
 		return
 	}
 	if hasOverlap {
-		c.JSON(http.StatusConflict, gin.H{"error": utils.ErrOverlappingLeave.Error()})
+		utils.RespondErrorFrom(c, http.StatusConflict, utils.ErrOverlappingLeave)
 		return
 	}
 
@@ -2626,7 +2675,7 @@ func CreateLeaveForEmployee(c *gin.Context) {
 		case string(models.StatusCancelled):
 			status = models.StatusCancelled
 		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Use: Pending, Approved, Rejected, or Cancelled"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid status. Use: Pending, Approved, Rejected, or Cancelled")
 			return
 		}
 	}
@@ -2638,31 +2687,26 @@ func CreateLeaveForEmployee(c *gin.Context) {
 	// If status is Approved and leave type uses balance, check balance and set approver; record-only types skip balance
 	var approvedBy *uint
 	var approvedAt *time.Time
+	var carryOverDays float64
 	if status == models.StatusApproved {
 		if leaveType.UsesBalance {
-			utils.EnsureAccrualsUpToDate(req.EmployeeID, req.LeaveTypeID)
+			utils.EnsureAccrualsUpToDate(requestDB(c), req.EmployeeID, req.LeaveTypeID)
 
-			balance, err := utils.GetCurrentLeaveBalance(req.EmployeeID, req.LeaveTypeID)
+			balance, err := utils.GetCurrentLeaveBalance(requestDB(c), req.EmployeeID, req.LeaveTypeID)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate leave balance"})
+				utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate leave balance")
 				return
 			}
 
 			leaveDuration := float64(int(endDate.Sub(startDate).Hours()/24) + 1)
 			if leaveDuration > balance {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":           "Insufficient leave balance",
-					"current_balance": balance,
-					"requested_days":  leaveDuration,
-					"message":         fmt.Sprintf("Insufficient leave balance. Available: %.2f days, Requested: %.2f days.", balance, leaveDuration),
-				})
+				respondInsufficientBalance(c, balance, leaveDuration)
 				return
 			}
 
+			// Drawn from carry-over when the leave is saved
 			if leaveType.AllowCarryOver {
-				if err := utils.UpdateCarryOverUsage(req.EmployeeID, req.LeaveTypeID, leaveDuration); err != nil {
-					// Log error but don't fail the creation
-				}
+				carryOverDays = leaveDuration
 			}
 		}
 		approvedBy = &adminID
@@ -2676,26 +2720,26 @@ func CreateLeaveForEmployee(c *gin.Context) {
 	
 	file, err := c.FormFile("leave_form")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Leave form attachment is required. Please upload a PNG or PDF file."})
+		utils.RespondError(c, http.StatusBadRequest, "Leave form attachment is required. Please upload a PNG or PDF file.")
 		return
 	}
 
 	// Validate file extension (PNG/PDF only)
 	if err := utils.ValidateLeaveFormFileExtension(file.Filename); err != nil {
-		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		utils.RespondErrorFrom(c, http.StatusUnsupportedMediaType, err)
 		return
 	}
 
 	// Validate file size
 	if err := utils.ValidateFileSize(file.Size); err != nil {
-		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		utils.RespondErrorFrom(c, http.StatusRequestEntityTooLarge, err)
 		return
 	}
 
 	// Open uploaded file
 	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to open uploaded file")
 		return
 	}
 	defer src.Close()
@@ -2703,21 +2747,21 @@ func CreateLeaveForEmployee(c *gin.Context) {
 	// Detect MIME type
 	mimeType := utils.GetFileMimeType(file.Filename)
 	if err := utils.ValidateLeaveFormMimeType(mimeType); err != nil {
-		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		utils.RespondErrorFrom(c, http.StatusUnsupportedMediaType, err)
 		return
 	}
 
 	// Generate secure filename
 	secureFilename, err := utils.GenerateSecureFileName(file.Filename, req.EmployeeID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate filename"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate filename")
 		return
 	}
 
 	// Save file to storage (we'll update leave ID after creation)
 	relativePath, fileSize, err := utils.SaveLeaveFormFile(src, secureFilename, req.EmployeeID, 0)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file: " + err.Error()})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save file: "+err.Error())
 		return
 	}
 
@@ -2742,12 +2786,21 @@ func CreateLeaveForEmployee(c *gin.Context) {
 		FormMimeType: formMimeType,
 	}
 
-	if err := database.DB.Create(&leave).Error; err != nil {
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&leave).Error; err != nil {
+			return err
+		}
+		if carryOverDays > 0 {
+			return utils.UpdateCarryOverUsage(tx, req.EmployeeID, req.LeaveTypeID, carryOverDays)
+		}
+		return nil
+	})
+	if err != nil {
 		// Clean up file if database save fails
 		if formFilePath != nil {
 			utils.DeleteLeaveFormFile(*formFilePath)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create leave record"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create leave record")
 		return
 	}
 
@@ -2758,7 +2811,7 @@ func CreateLeaveForEmployee(c *gin.Context) {
 	}
 
 	if status == models.StatusApproved && leaveType.UsesBalance {
-		if err := utils.EnsureAccrualsUpToDate(req.EmployeeID, req.LeaveTypeID); err != nil {
+		if err := utils.EnsureAccrualsUpToDate(requestDB(c), req.EmployeeID, req.LeaveTypeID); err != nil {
 			// Log error but don't fail the creation
 		}
 	}
@@ -2774,8 +2827,12 @@ func CreateLeaveForEmployee(c *gin.Context) {
 			"reason":        req.Reason,
 		})
 
+	afterCommit(c, func() {
+		utils.SyncLeaveCalendars(leave.ID)
+	})
+
 	// Load associations
-	database.DB.Preload("LeaveType").Preload("Employee").Preload("Approver").First(&leave, leave.ID)
+	requestDB(c).Preload("LeaveType").Preload("Employee").Preload("Approver").First(&leave, leave.ID)
 
 	c.JSON(http.StatusCreated, leave)
 }
@@ -2795,19 +2852,19 @@ func CreateLeaveForEmployee(c *gin.Context) {
 func DownloadLeaveForm(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	var leave models.Leave
-	if err := database.DB.First(&leave, uint(leaveID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave not found"})
+	if err := requestDB(c).First(&leave, uint(leaveID)).Error; err != nil {
+		utils.RespondErrorCode(c, http.StatusNotFound, utils.CodeLeaveNotFound, "Leave not found", nil)
 		return
 	}
 
 	// Check if leave has a form attachment
 	if leave.FormFilePath == nil || *leave.FormFilePath == "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No leave form attachment found for this leave"})
+		utils.RespondError(c, http.StatusNotFound, "No leave form attachment found for this leave")
 		return
 	}
 
@@ -2816,10 +2873,12 @@ func DownloadLeaveForm(c *gin.Context) {
 
 	// Check if file exists
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave form file not found on server"})
+		utils.RespondError(c, http.StatusNotFound, "Leave form file not found on server")
 		return
 	}
 
+	noteFileAccess(c, models.FileAccessLog{EmployeeID: &leave.EmployeeID})
+
 	// Set appropriate headers
 	if leave.FormFileName != nil {
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", *leave.FormFileName))
@@ -2847,7 +2906,7 @@ func DownloadLeaveForm(c *gin.Context) {
 // @Param id path int true "Leave ID"
 // @Param request body UpdateLeaveRequest true "Leave update data"
 // @Success 200 {object} models.Leave
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -2855,20 +2914,20 @@ func DownloadLeaveForm(c *gin.Context) {
 func UpdateLeaveForEmployee(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	var req UpdateLeaveRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
 	// Get leave record
 	var leave models.Leave
-	if err := database.DB.Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave not found"})
+	if err := requestDB(c).Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
+		utils.RespondErrorCode(c, http.StatusNotFound, utils.CodeLeaveNotFound, "Leave not found", nil)
 		return
 	}
 
@@ -2879,12 +2938,13 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 	oldStatus := string(leave.Status)
 	oldStartDate := leave.StartDate
 	oldEndDate := leave.EndDate
+	var carryOverDays float64
 
 	// Update dates if provided
 	if req.StartDate != "" {
 		startDate, err := time.Parse("2006-01-02", req.StartDate)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use YYYY-MM-DD"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
 			return
 		}
 		leave.StartDate = startDate
@@ -2893,26 +2953,26 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 	if req.EndDate != "" {
 		endDate, err := time.Parse("2006-01-02", req.EndDate)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use YYYY-MM-DD"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
 			return
 		}
 		leave.EndDate = endDate
 	}
 
 	// Validate dates
-	if err := utils.ValidateLeaveDates(leave.StartDate, leave.EndDate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := utils.ValidateLeaveDates(leave.StartDate, leave.EndDate, utils.EmployeeLocation(&leave.Employee)); err != nil {
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
 		return
 	}
 
 	// Check for overlapping leaves (excluding this leave)
 	hasOverlap, err := utils.CheckOverlappingLeaves(leave.EmployeeID, leave.StartDate, leave.EndDate, &leave.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check overlapping leaves"})
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to check overlapping leaves")
 		return
 	}
 	if hasOverlap {
-		c.JSON(http.StatusConflict, gin.H{"error": utils.ErrOverlappingLeave.Error()})
+		utils.RespondErrorFrom(c, http.StatusConflict, utils.ErrOverlappingLeave)
 		return
 	}
 
@@ -2932,32 +2992,26 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 		case string(models.StatusApproved):
 			if oldStatus != string(models.StatusApproved) {
 				if leave.LeaveType.UsesBalance {
-					utils.EnsureAccrualsUpToDate(leave.EmployeeID, leave.LeaveTypeID)
+					utils.EnsureAccrualsUpToDate(requestDB(c), leave.EmployeeID, leave.LeaveTypeID)
 
-					balance, err := utils.GetAvailableLeaveBalance(leave.EmployeeID, leave.LeaveTypeID, &leave.ID, &leave.StartDate)
+					balance, err := utils.GetAvailableLeaveBalance(requestDB(c), leave.EmployeeID, leave.LeaveTypeID, &leave.ID, &leave.StartDate)
 					if err != nil {
-						balance, err = utils.GetCurrentLeaveBalance(leave.EmployeeID, leave.LeaveTypeID)
+						balance, err = utils.GetCurrentLeaveBalance(requestDB(c), leave.EmployeeID, leave.LeaveTypeID)
 						if err != nil {
-							c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate leave balance"})
+							utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate leave balance")
 							return
 						}
 					}
 
 					leaveDuration := float64(leave.GetDuration())
 					if leaveDuration > balance {
-						c.JSON(http.StatusBadRequest, gin.H{
-							"error":           "Insufficient leave balance",
-							"current_balance": balance,
-							"requested_days":  leaveDuration,
-							"message":         fmt.Sprintf("Insufficient leave balance. Available: %.2f days, Requested: %.2f days.", balance, leaveDuration),
-						})
+						respondInsufficientBalance(c, balance, leaveDuration)
 						return
 					}
 
+					// Drawn from carry-over when the leave is saved
 					if leave.LeaveType.AllowCarryOver {
-						if err := utils.UpdateCarryOverUsage(leave.EmployeeID, leave.LeaveTypeID, leaveDuration); err != nil {
-							// Log error but don't fail the update
-						}
+						carryOverDays = leaveDuration
 					}
 				}
 				leave.ApprovedBy = &adminID
@@ -2978,7 +3032,7 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 			leave.Status = models.StatusCancelled
 			leave.RejectionReason = ""
 		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Use: Pending, Approved, Rejected, or Cancelled"})
+			utils.RespondError(c, http.StatusBadRequest, "Invalid status. Use: Pending, Approved, Rejected, or Cancelled")
 			return
 		}
 	}
@@ -2988,13 +3042,22 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 		leave.RejectionReason = req.RejectionReason
 	}
 
-	if err := database.DB.Save(&leave).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update leave record"})
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Save(&leave).Error; err != nil {
+			return err
+		}
+		if carryOverDays > 0 {
+			return utils.UpdateCarryOverUsage(tx, leave.EmployeeID, leave.LeaveTypeID, carryOverDays)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update leave record")
 		return
 	}
 
 	if leave.LeaveType.UsesBalance && (leave.Status == models.StatusApproved || oldStatus == string(models.StatusApproved)) {
-		if err := utils.EnsureAccrualsUpToDate(leave.EmployeeID, leave.LeaveTypeID); err != nil {
+		if err := utils.EnsureAccrualsUpToDate(requestDB(c), leave.EmployeeID, leave.LeaveTypeID); err != nil {
 			// Log error but don't fail the update
 		}
 	}
@@ -3015,8 +3078,12 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 			"reason":     leave.Reason,
 		})
 
+	afterCommit(c, func() {
+		utils.SyncLeaveCalendars(leave.ID)
+	})
+
 	// Load associations
-	database.DB.Preload("LeaveType").Preload("Employee").Preload("Approver").First(&leave, leave.ID)
+	requestDB(c).Preload("LeaveType").Preload("Employee").Preload("Approver").First(&leave, leave.ID)
 
 	c.JSON(http.StatusOK, leave)
 }
@@ -3036,14 +3103,14 @@ func UpdateLeaveForEmployee(c *gin.Context) {
 func DeleteLeaveForEmployee(c *gin.Context) {
 	leaveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid leave ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid leave ID")
 		return
 	}
 
 	// Get leave record
 	var leave models.Leave
-	if err := database.DB.Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Leave not found"})
+	if err := requestDB(c).Preload("Employee").Preload("LeaveType").First(&leave, uint(leaveID)).Error; err != nil {
+		utils.RespondErrorCode(c, http.StatusNotFound, utils.CodeLeaveNotFound, "Leave not found", nil)
 		return
 	}
 
@@ -3062,11 +3129,15 @@ func DeleteLeaveForEmployee(c *gin.Context) {
 		}, nil)
 
 	// Delete leave record (soft delete)
-	if err := database.DB.Delete(&leave).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete leave record"})
+	if err := requestDB(c).Delete(&leave).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete leave record")
 		return
 	}
 
+	afterCommit(c, func() {
+		utils.SyncLeaveCalendars(leave.ID)
+	})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Leave record deleted successfully"})
 }
 
@@ -3081,7 +3152,11 @@ func DeleteLeaveForEmployee(c *gin.Context) {
 // @Param leave_type_id query int false "Filter by leave type ID"
 // @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
-// @Success 200 {array} models.Leave
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to -start_date,-created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Leave}
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -3089,36 +3164,33 @@ func DeleteLeaveForEmployee(c *gin.Context) {
 func GetEmployeeLeaves(c *gin.Context) {
 	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+	pagination, ok := parsePagination(c)
+	if !ok {
 		return
 	}
 
 	// Verify employee exists
 	var employee models.Employee
-	if err := database.DB.First(&employee, employeeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
 		return
 	}
 
 	// Build query
-	query := database.DB.Where("employee_id = ?", employeeID).
+	query := requestDB(c).Where("employee_id = ?", employeeID).
 		Preload("LeaveType").
 		Preload("Employee").
-		Preload("Approver").
-		Order("start_date DESC, created_at DESC")
+		Preload("Approver")
 
 	// Apply filters
-	status := c.Query("status")
-	if status != "" {
-		query = query.Where("status = ?", status)
-	}
-
-	leaveTypeIDStr := c.Query("leave_type_id")
-	if leaveTypeIDStr != "" {
-		leaveTypeID, err := strconv.ParseUint(leaveTypeIDStr, 10, 32)
-		if err == nil {
-			query = query.Where("leave_type_id = ?", leaveTypeID)
-		}
+	fields := leaveListFields
+	fields.DefaultSort = "-start_date,-created_at"
+	query, ok = applyListQuery(c, query, fields)
+	if !ok {
+		return
 	}
 
 	startDateStr := c.Query("start_date")
@@ -3138,10 +3210,11 @@ func GetEmployeeLeaves(c *gin.Context) {
 	}
 
 	var leaves []models.Leave
-	if err := query.Find(&leaves).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leaves"})
+	response, err := paginate(query, pagination, &leaves)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch leaves")
 		return
 	}
 
-	c.JSON(http.StatusOK, leaves)
+	c.JSON(http.StatusOK, response)
 }
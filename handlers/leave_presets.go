@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ApplyLeavePresetResponse reports what applying a leave preset did to each of its leave types
+type ApplyLeavePresetResponse struct {
+	Preset  string                    `json:"preset" example:"ZM"`
+	Changes []utils.LeavePresetChange `json:"changes"`
+}
+
+// GetLeavePresets lists the leave presets
+// @Summary Get leave presets
+// @Description List the leave presets an organization can apply: packs of leave types with a country's statutory entitlements, and the standard pack new deployments start with (Admin only)
+// @Tags Admin - Leave Types
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.LeavePreset
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/admin/leave-presets [get]
+func GetLeavePresets(c *gin.Context) {
+	c.JSON(http.StatusOK, models.LeavePresets)
+}
+
+// ApplyLeavePreset sets up the organization's leave types from a leave preset
+// @Summary Apply a leave preset
+// @Description Set up the organization's leave types from a leave preset. Leave types the organization does not have, matched by name, are created; existing ones whose maximum days or accrual rate are below the preset's are raised to it. Nothing is lowered or removed, so applying a preset again is safe (Admin only)
+// @Tags Admin - Leave Types
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Leave preset code, e.g. ZM"
+// @Success 200 {object} ApplyLeavePresetResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/leave-presets/{code}/apply [post]
+func ApplyLeavePreset(c *gin.Context) {
+	preset, ok := models.FindLeavePreset(c.Param("code"))
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, "Unknown leave preset")
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	var changes []utils.LeavePresetChange
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		var err error
+		if changes, err = utils.ApplyLeavePreset(tx, c.GetUint("organization_id"), preset); err != nil {
+			return err
+		}
+		for _, change := range changes {
+			switch change.Action {
+			case utils.LeavePresetCreated:
+				err = recordAuditLog(tx, models.AuditEntityLeaveType, change.LeaveType.ID, models.AuditActionCreate, userID, c, nil, change.LeaveType)
+			case utils.LeavePresetRaised:
+				err = recordAuditLog(tx, models.AuditEntityLeaveType, change.LeaveType.ID, models.AuditActionUpdate, userID, c, change.Previous, change.LeaveType)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to apply leave preset")
+		return
+	}
+
+	c.JSON(http.StatusOK, ApplyLeavePresetResponse{Preset: preset.Code, Changes: changes})
+}
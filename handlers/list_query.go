@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"hrms-api/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListFields is the allowlist of filters and sort keys a list endpoint accepts. Map keys are the names
+// used in the query string and values are the columns they map to, so only listed columns ever reach SQL.
+type ListFields struct {
+	Filters     map[string]string
+	Sorts       map[string]string
+	DefaultSort string // Used when no sort is given, e.g. "-created_at"
+	Tiebreaker  string // Column appended to every sort so pages are stable; defaults to "id"
+}
+
+// applyListQuery applies the filter and sort query parameters allowed by fields to query.
+// Filters match exactly; a comma separated value matches any of the values (?status=pending,approved).
+// sort takes comma separated keys, each prefixed with - for descending (?sort=-start_date,lastname).
+// On an unknown sort key it responds with 400 and returns false.
+func applyListQuery(c *gin.Context, query *gorm.DB, fields ListFields) (*gorm.DB, bool) {
+	for param, column := range fields.Filters {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		values := strings.Split(value, ",")
+		if len(values) == 1 {
+			query = query.Where(clause.Eq{Column: clause.Column{Name: column}, Value: value})
+		} else {
+			in := make([]interface{}, len(values))
+			for i, v := range values {
+				in[i] = strings.TrimSpace(v)
+			}
+			query = query.Where(clause.IN{Column: clause.Column{Name: column}, Values: in})
+		}
+	}
+
+	sort := c.Query("sort")
+	if sort == "" {
+		sort = fields.DefaultSort
+	}
+	tiebreaker := fields.Tiebreaker
+	if tiebreaker == "" {
+		tiebreaker = "id"
+	}
+
+	var order []clause.OrderByColumn
+	for _, key := range strings.Split(sort, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		desc := strings.HasPrefix(key, "-")
+		column, ok := fields.Sorts[strings.TrimPrefix(key, "-")]
+		if !ok {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid sort field: "+strings.TrimPrefix(key, "-"))
+			return query, false
+		}
+		order = append(order, clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc})
+	}
+
+	// Break ties in the direction of the last sort key so newest-first lists stay newest-first
+	tiebreakDesc := len(order) > 0 && order[len(order)-1].Desc
+	if len(order) == 0 || order[len(order)-1].Column.Name != tiebreaker {
+		order = append(order, clause.OrderByColumn{Column: clause.Column{Name: tiebreaker}, Desc: tiebreakDesc})
+	}
+	for _, column := range order {
+		query = query.Order(column)
+	}
+	return query, true
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// NationalIDFormatRequest represents data for setting up a country's national ID format
+type NationalIDFormatRequest struct {
+	CountryCode string                    `json:"country_code" binding:"required" example:"ZM"`
+	Name        string                    `json:"name" binding:"required,max=100" example:"National Registration Card"`
+	Pattern     string                    `json:"pattern" binding:"required,max=200" example:"(\\d{6})(\\d{2})(\\d)"` // Regular expression the whole ID must match, upper case with spaces and punctuation removed
+	Format      string                    `json:"format,omitempty" binding:"max=100" example:"$1/$2/$3"`              // Layout built from the pattern's groups; empty stores the compact ID
+	Checksum    models.NationalIDChecksum `json:"checksum,omitempty" binding:"omitempty,oneof=luhn" example:""`
+	Example     string                    `json:"example" binding:"required,max=50" example:"123456/78/9"` // A valid ID, checked against the format
+	IsDefault   bool                      `json:"is_default"`                                              // Used when no country is given, and for bulk uploads
+}
+
+// GetNationalIDFormats lists the organization's national ID formats
+// @Summary Get national ID formats
+// @Description List the national ID formats NRCs are validated and stored by, by country (Admin only)
+// @Tags Admin - National ID Formats
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.NationalIDFormat
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/national-id-formats [get]
+func GetNationalIDFormats(c *gin.Context) {
+	var formats []models.NationalIDFormat
+	if err := requestDB(c).Order("country_code").Find(&formats).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch national ID formats")
+		return
+	}
+	c.JSON(http.StatusOK, formats)
+}
+
+// CreateNationalIDFormat sets up a country's national ID format
+// @Summary Create a national ID format
+// @Description Set up how NRCs of a country are validated and stored. IDs are matched in compact form, upper case with spaces and punctuation removed, against the pattern, their check digit is verified if the format has one, and they are stored laid out by the format so the same ID cannot be registered twice written differently. The example must pass the format. NRCs already stored are not changed (Admin only)
+// @Tags Admin - National ID Formats
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body NationalIDFormatRequest true "National ID format"
+// @Success 201 {object} models.NationalIDFormat
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/national-id-formats [post]
+func CreateNationalIDFormat(c *gin.Context) {
+	var req NationalIDFormatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	format, ok := nationalIDFormatFromRequest(c, req)
+	if !ok {
+		return
+	}
+
+	var existing int64
+	if err := requestDB(c).Model(&models.NationalIDFormat{}).Where("country_code = ?", format.CountryCode).Count(&existing).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create national ID format")
+		return
+	}
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "A national ID format for this country already exists")
+		return
+	}
+
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := clearDefaultNationalIDFormat(tx, format); err != nil {
+			return err
+		}
+		return tx.Create(&format).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create national ID format")
+		return
+	}
+
+	createAuditLog(models.AuditEntityNationalID, format.ID, models.AuditActionCreate, c.GetUint("user_id"), c, nil, format)
+	c.JSON(http.StatusCreated, format)
+}
+
+// UpdateNationalIDFormat changes a country's national ID format
+// @Summary Update a national ID format
+// @Description Replace a national ID format. The example must pass the new format. NRCs already stored are not changed (Admin only)
+// @Tags Admin - National ID Formats
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "National ID format ID"
+// @Param request body NationalIDFormatRequest true "National ID format"
+// @Success 200 {object} models.NationalIDFormat
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/national-id-formats/{id} [put]
+func UpdateNationalIDFormat(c *gin.Context) {
+	formatID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	var req NationalIDFormatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var format models.NationalIDFormat
+	if err := requestDB(c).First(&format, formatID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "National ID format not found")
+		return
+	}
+	oldFormat := format
+
+	updated, ok := nationalIDFormatFromRequest(c, req)
+	if !ok {
+		return
+	}
+	if updated.CountryCode != format.CountryCode {
+		var existing int64
+		if err := requestDB(c).Model(&models.NationalIDFormat{}).Where("country_code = ?", updated.CountryCode).Count(&existing).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to update national ID format")
+			return
+		}
+		if existing > 0 {
+			utils.RespondError(c, http.StatusConflict, "A national ID format for this country already exists")
+			return
+		}
+	}
+	updated.ID = format.ID
+	updated.OrganizationID = format.OrganizationID
+	updated.CreatedAt = format.CreatedAt
+	format = updated
+
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		if err := clearDefaultNationalIDFormat(tx, format); err != nil {
+			return err
+		}
+		return tx.Save(&format).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update national ID format")
+		return
+	}
+
+	createAuditLog(models.AuditEntityNationalID, format.ID, models.AuditActionUpdate, c.GetUint("user_id"), c, oldFormat, format)
+	c.JSON(http.StatusOK, format)
+}
+
+// DeleteNationalIDFormat deletes a country's national ID format
+// @Summary Delete a national ID format
+// @Description Delete a national ID format. NRCs of that country are then rejected until it is set up again (Admin only)
+// @Tags Admin - National ID Formats
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "National ID format ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/national-id-formats/{id} [delete]
+func DeleteNationalIDFormat(c *gin.Context) {
+	formatID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var format models.NationalIDFormat
+	if err := requestDB(c).First(&format, formatID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "National ID format not found")
+		return
+	}
+	if err := requestDB(c).Delete(&format).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete national ID format")
+		return
+	}
+
+	createAuditLog(models.AuditEntityNationalID, format.ID, models.AuditActionDelete, c.GetUint("user_id"), c, format, nil)
+	c.JSON(http.StatusOK, gin.H{"message": "National ID format deleted successfully"})
+}
+
+// nationalIDFormatFromRequest builds the national ID format req describes, responding with an error
+// and returning false if the pattern is invalid or the example does not pass it
+func nationalIDFormatFromRequest(c *gin.Context, req NationalIDFormatRequest) (models.NationalIDFormat, bool) {
+	format := models.NationalIDFormat{
+		CountryCode: strings.ToUpper(strings.TrimSpace(req.CountryCode)),
+		Name:        strings.TrimSpace(req.Name),
+		Pattern:     strings.TrimSpace(req.Pattern),
+		Format:      strings.TrimSpace(req.Format),
+		Checksum:    req.Checksum,
+		IsDefault:   req.IsDefault,
+	}
+	if !utils.IsCountryCode(format.CountryCode) {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid country code. Use a two-letter ISO code such as ZM")
+		return format, false
+	}
+	if _, err := utils.CompileNationalIDPattern(format.Pattern); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Invalid pattern: %s", err.Error()))
+		return format, false
+	}
+	example, err := utils.ApplyNationalIDFormat(format, req.Example)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "The example does not pass the format")
+		return format, false
+	}
+	// Stored IDs are compared in compact form, so the layout must keep every character of the ID
+	if utils.CompactNationalID(example) != utils.CompactNationalID(req.Example) {
+		utils.RespondError(c, http.StatusBadRequest, "The format must keep every character of the ID")
+		return format, false
+	}
+	format.Example = example
+	return format, true
+}
+
+// clearDefaultNationalIDFormat unsets the organization's other default national ID format when format
+// is made the default
+func clearDefaultNationalIDFormat(tx *gorm.DB, format models.NationalIDFormat) error {
+	if !format.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.NationalIDFormat{}).Where("is_default = ? AND id <> ?", true, format.ID).Update("is_default", false).Error
+}
+
+// normalizeNRC validates nrc against the organization's national ID format for countryCode and
+// returns it as it should be stored, responding with an error and returning false if it is invalid
+func normalizeNRC(c *gin.Context, organizationID uint, countryCode, nrc string) (string, bool) {
+	normalized, err := utils.NormalizeNationalID(requestDB(c), organizationID, countryCode, nrc)
+	if err == nil {
+		return normalized, true
+	}
+	if message := nationalIDErrorMessage(utils.RequestLanguage(c), err, countryCode); message != "" {
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeInvalidNationalID, message, nil)
+		return "", false
+	}
+	utils.RespondError(c, http.StatusInternalServerError, "Failed to validate NRC")
+	return "", false
+}
+
+// nationalIDErrorMessage explains why an NRC failed its national ID format, or returns "" if err is
+// not a national ID error
+func nationalIDErrorMessage(lang i18n.Language, err error, countryCode string) string {
+	var idErr *utils.NationalIDError
+	switch {
+	case errors.Is(err, utils.ErrNationalIDCountry):
+		return i18n.T(lang, "No national ID format is set up for country %s", strings.ToUpper(strings.TrimSpace(countryCode)))
+	case errors.As(err, &idErr) && errors.Is(err, utils.ErrNationalIDChecksum):
+		return i18n.T(lang, "NRC check digit is wrong for %s", idErr.Format.Name)
+	case errors.As(err, &idErr):
+		return i18n.T(lang, "NRC does not match the %s format, for example %s", idErr.Format.Name, idErr.Format.Example)
+	}
+	return ""
+}
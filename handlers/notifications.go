@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// NotificationPreferencesResponse is the current user's choice of channels for every notification category
+type NotificationPreferencesResponse struct {
+	EmailAvailable bool                            `json:"email_available" example:"true"` // Email is configured and the user has an email address
+	SMSAvailable   bool                            `json:"sms_available" example:"true"`   // An SMS gateway is configured and the user has a mobile number
+	PushAvailable  bool                            `json:"push_available" example:"true"`  // Push is configured and the user has registered a device of its push service
+	SMSCategories  []models.NotificationCategory   `json:"sms_categories"`                 // Categories that may be sent by text message
+	Preferences    []models.NotificationPreference `json:"preferences"`
+}
+
+// NotificationPreferenceRequest sets the channels of one notification category; a channel left out is unchanged
+type NotificationPreferenceRequest struct {
+	Category models.NotificationCategory `json:"category" binding:"required,oneof=compliance_reminder compliance_expired grievance_assigned grievance_updated grievance_sla_breach kudos_received leave_approved leave_rejected password_changed leave_submitted" example:"leave_approved"`
+	Email    *bool                       `json:"email,omitempty" example:"false"`
+	SMS      *bool                       `json:"sms,omitempty" example:"true"` // Only for the categories that may be sent by text message
+	Push     *bool                       `json:"push,omitempty" example:"true"`
+}
+
+// MyPreferencesResponse is the current user's choice of the channels and groups of notifications that
+// reach them at all
+type MyPreferencesResponse struct {
+	Channels       NotificationChannelSettings                                `json:"channels"`
+	Categories     NotificationGroupSettings                                  `json:"categories"`
+	CategoryGroups map[models.NotificationGroup][]models.NotificationCategory `json:"category_groups"`                // Notification categories of each group; password change notices are in none and always sent
+	EmailAvailable bool                                                       `json:"email_available" example:"true"` // Email is configured and the user has an email address
+	SMSAvailable   bool                                                       `json:"sms_available" example:"true"`   // An SMS gateway is configured and the user has a mobile number
+	PushAvailable  bool                                                       `json:"push_available" example:"true"`  // Push is configured and the user has registered a device of its push service
+}
+
+// NotificationChannelSettings is whether notifications reach the current user on each channel
+type NotificationChannelSettings struct {
+	InApp bool `json:"in_app" example:"true"`
+	Email bool `json:"email" example:"false"`
+	SMS   bool `json:"sms" example:"true"`
+	Push  bool `json:"push" example:"true"`
+}
+
+// NotificationGroupSettings is whether the current user receives each group of notifications
+type NotificationGroupSettings struct {
+	Approvals     bool `json:"approvals" example:"true"`      // Leave requests waiting for approval, and leave decisions
+	Reminders     bool `json:"reminders" example:"true"`      // Compliance reminders and expiries, and missed grievance deadlines
+	Announcements bool `json:"announcements" example:"false"` // Kudos, and grievances assigned or updated
+}
+
+// UpdateMyPreferencesRequest switches channels and groups of notifications on or off; one left out is unchanged
+type UpdateMyPreferencesRequest struct {
+	Channels   *NotificationChannelSettingsRequest `json:"channels,omitempty"`
+	Categories *NotificationGroupSettingsRequest   `json:"categories,omitempty"`
+}
+
+// NotificationChannelSettingsRequest switches notification channels on or off
+type NotificationChannelSettingsRequest struct {
+	InApp *bool `json:"in_app,omitempty" example:"true"`
+	Email *bool `json:"email,omitempty" example:"false"`
+	SMS   *bool `json:"sms,omitempty" example:"true"`
+	Push  *bool `json:"push,omitempty" example:"true"`
+}
+
+// NotificationGroupSettingsRequest switches groups of notifications on or off
+type NotificationGroupSettingsRequest struct {
+	Approvals     *bool `json:"approvals,omitempty" example:"true"`
+	Reminders     *bool `json:"reminders,omitempty" example:"true"`
+	Announcements *bool `json:"announcements,omitempty" example:"false"`
+}
+
+// RegisterDeviceRequest registers a mobile app installation for push notifications
+type RegisterDeviceRequest struct {
+	Provider   models.PushProvider `json:"provider" binding:"required,oneof=fcm apns" example:"fcm"`
+	Token      string              `json:"token" binding:"required,max=255" example:"dQw4w9WgXcQ:APA91bH..."` // Registration token from the FCM SDK, or hex device token from APNs
+	DeviceName *string             `json:"device_name" binding:"omitempty,max=100" example:"Pixel 8"`
+}
+
+// UpdateNotificationPreferencesRequest sets the channels of some notification categories
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" binding:"required,min=1,dive"`
+}
+
+// GetMyNotifications returns the current user's in-app notifications
+// @Summary Get my notifications
+// @Description Get the current user's in-app notifications, newest first
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only return unread notifications"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Notification}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/notifications [get]
+func GetMyNotifications(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	query := requestDB(c).Where("recipient_id = ? AND channel = ?", userID, models.NotificationChannelInApp)
+	if c.Query("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []models.Notification
+	response, err := paginate(query.Order("created_at DESC, id DESC"), pagination, &notifications)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkNotificationRead marks one of the current user's notifications as read
+// @Summary Mark notification as read
+// @Description Mark one of the current user's notifications as read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} models.Notification
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/notifications/{id}/read [put]
+func MarkNotificationRead(c *gin.Context) {
+	notificationID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	userID, _ := c.Get("user_id")
+
+	var notification models.Notification
+	if err := requestDB(c).Where("id = ? AND recipient_id = ?", notificationID, userID).First(&notification).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Notification not found")
+		return
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		requestDB(c).Model(&notification).Update("read_at", now)
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+// GetNotificationPreferences returns the current user's notification channels
+// @Summary Get my notification preferences
+// @Description Get the channels the current user's notifications of every category reach them on besides in-app. Categories never set use the defaults: email and push, and a text message for leave decisions and password changes
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} NotificationPreferencesResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/preferences [get]
+func GetNotificationPreferences(c *gin.Context) {
+	respondNotificationPreferences(c, c.GetUint("user_id"))
+}
+
+// UpdateNotificationPreferences sets the current user's notification channels
+// @Summary Update my notification preferences
+// @Description Choose whether notifications of some categories also reach the current user by email, by push to their mobile devices and, for leave decisions and password changes, by text message. In-app notifications are kept unless switched off for every category in /api/me/preferences, which also switches off channels and groups of categories as a whole
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateNotificationPreferencesRequest true "Channels per category"
+// @Success 200 {object} NotificationPreferencesResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/preferences [put]
+func UpdateNotificationPreferences(c *gin.Context) {
+	var req UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	for _, item := range req.Preferences {
+		if item.SMS != nil && *item.SMS && !models.IsSMSCategory(item.Category) {
+			utils.RespondError(c, http.StatusBadRequest, "Text messages are only sent for leave decisions and password changes")
+			return
+		}
+	}
+
+	userID := c.GetUint("user_id")
+	err := withTransaction(c, func(tx *gorm.DB) error {
+		for _, item := range req.Preferences {
+			var preference models.NotificationPreference
+			if err := tx.Where("employee_id = ? AND category = ?", userID, item.Category).Limit(1).Find(&preference).Error; err != nil {
+				return err
+			}
+			if preference.ID == 0 {
+				preference = utils.DefaultNotificationPreference(userID, item.Category)
+			}
+			if item.Email != nil {
+				preference.Email = *item.Email
+			}
+			if item.SMS != nil {
+				preference.SMS = *item.SMS
+			}
+			if item.Push != nil {
+				preference.Push = item.Push
+			}
+			if err := tx.Save(&preference).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save notification preferences")
+		return
+	}
+
+	respondNotificationPreferences(c, userID)
+}
+
+func respondNotificationPreferences(c *gin.Context, employeeID uint) {
+	emailAvailable, smsAvailable, pushAvailable, ok := notificationChannelsAvailable(c, employeeID)
+	if !ok {
+		return
+	}
+	preferences, err := utils.NotificationPreferences(employeeID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, NotificationPreferencesResponse{
+		EmailAvailable: emailAvailable,
+		SMSAvailable:   smsAvailable,
+		PushAvailable:  pushAvailable,
+		SMSCategories:  models.SMSNotificationCategories,
+		Preferences:    preferences,
+	})
+}
+
+// notificationChannelsAvailable reports whether email, text messages and pushes can reach the employee:
+// the channel is configured, and they have an email address, a mobile number or a device of a configured
+// push service
+func notificationChannelsAvailable(c *gin.Context, employeeID uint) (email, sms, push, ok bool) {
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return false, false, false, false
+	}
+	var devices []models.DeviceToken
+	if err := requestDB(c).Where("employee_id = ?", employeeID).Find(&devices).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch devices")
+		return false, false, false, false
+	}
+	for _, device := range devices {
+		push = push || utils.PushProviderEnabled(device.Provider)
+	}
+	email = utils.EmailEnabled() && employee.Email != nil && *employee.Email != ""
+	sms = utils.SMSEnabled() && employee.Mobile != nil && *employee.Mobile != ""
+	return email, sms, push, true
+}
+
+// GetMyPreferences returns the current user's notification settings
+// @Summary Get my preferences
+// @Description Get the channels (in-app, email, SMS and push) and groups of notifications (approvals, reminders and announcements) that reach the current user at all, with the categories of each group and whether email, SMS and push can reach them. Everything is on until they choose otherwise. The channels of each category are set in the notification preferences
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MyPreferencesResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/preferences [get]
+func GetMyPreferences(c *gin.Context) {
+	respondMyPreferences(c, c.GetUint("user_id"))
+}
+
+// UpdateMyPreferences switches the current user's notification channels and groups on or off
+// @Summary Update my preferences
+// @Description Switch notification channels (in-app, email, SMS and push) and groups of notifications (approvals, reminders and announcements) on or off for the current user. A channel switched off carries none of their notifications, whatever the preference of the category; a group switched off is not sent on any channel. Password change notices are in no group and are always sent on the channels left on
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateMyPreferencesRequest true "Channels and groups to switch"
+// @Success 200 {object} MyPreferencesResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/preferences [put]
+func UpdateMyPreferences(c *gin.Context) {
+	var req UpdateMyPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	var settings models.NotificationSettings
+	if err := requestDB(c).Where("employee_id = ?", userID).Limit(1).Find(&settings).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save notification preferences")
+		return
+	}
+	if settings.ID == 0 {
+		settings = utils.DefaultNotificationSettings(userID)
+	}
+	if channels := req.Channels; channels != nil {
+		setBool(&settings.InApp, channels.InApp)
+		setBool(&settings.Email, channels.Email)
+		setBool(&settings.SMS, channels.SMS)
+		setBool(&settings.Push, channels.Push)
+	}
+	if categories := req.Categories; categories != nil {
+		setBool(&settings.Approvals, categories.Approvals)
+		setBool(&settings.Reminders, categories.Reminders)
+		setBool(&settings.Announcements, categories.Announcements)
+	}
+	if err := requestDB(c).Save(&settings).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save notification preferences")
+		return
+	}
+
+	respondMyPreferences(c, userID)
+}
+
+func respondMyPreferences(c *gin.Context, employeeID uint) {
+	emailAvailable, smsAvailable, pushAvailable, ok := notificationChannelsAvailable(c, employeeID)
+	if !ok {
+		return
+	}
+	settings := utils.NotificationSettingsOf(employeeID)
+
+	c.JSON(http.StatusOK, MyPreferencesResponse{
+		Channels: NotificationChannelSettings{
+			InApp: settings.InApp,
+			Email: settings.Email,
+			SMS:   settings.SMS,
+			Push:  settings.Push,
+		},
+		Categories: NotificationGroupSettings{
+			Approvals:     settings.Approvals,
+			Reminders:     settings.Reminders,
+			Announcements: settings.Announcements,
+		},
+		CategoryGroups: models.NotificationGroupCategories,
+		EmailAvailable: emailAvailable,
+		SMSAvailable:   smsAvailable,
+		PushAvailable:  pushAvailable,
+	})
+}
+
+// setBool sets a value to a requested one, unless it was left out
+func setBool(value *bool, requested *bool) {
+	if requested != nil {
+		*value = *requested
+	}
+}
+
+// GetMyDevices lists the current user's devices registered for push notifications
+// @Summary Get my devices
+// @Description List the mobile app installations of the current user that notifications are pushed to, with the last push and error
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.DeviceToken
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/devices [get]
+func GetMyDevices(c *gin.Context) {
+	var devices []models.DeviceToken
+	if err := requestDB(c).Where("employee_id = ?", c.GetUint("user_id")).Order("created_at DESC").Find(&devices).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch devices")
+		return
+	}
+
+	c.JSON(http.StatusOK, devices)
+}
+
+// RegisterDevice registers a device of the current user for push notifications
+// @Summary Register device
+// @Description Register the mobile app on a device, with the token FCM or APNs issued it, so the current user's notifications are pushed to it. Call it on every app start and whenever the token changes; registering a known token again updates it, and moves it to the current user if another user signed in on the device before
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RegisterDeviceRequest true "Device token"
+// @Success 200 {object} models.DeviceToken "The token was already registered"
+// @Success 201 {object} models.DeviceToken
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/devices [post]
+func RegisterDevice(c *gin.Context) {
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var device models.DeviceToken
+	if err := requestDB(c).Where("token = ?", req.Token).Limit(1).Find(&device).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to register device")
+		return
+	}
+	status := http.StatusOK
+	if device.ID == 0 {
+		status = http.StatusCreated
+	}
+	device.EmployeeID = c.GetUint("user_id")
+	device.Provider = req.Provider
+	device.Token = req.Token
+	device.DeviceName = req.DeviceName
+	device.LastError = nil
+	if err := requestDB(c).Save(&device).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to register device")
+		return
+	}
+
+	c.JSON(status, device)
+}
+
+// DeleteDevice unregisters one of the current user's devices
+// @Summary Unregister device
+// @Description Stop pushing notifications to a device of the current user, e.g. when they sign out of the app
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Device ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/devices/{id} [delete]
+func DeleteDevice(c *gin.Context) {
+	device, ok := findMyDevice(c)
+	if !ok {
+		return
+	}
+	if err := requestDB(c).Delete(&device).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to unregister device")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Device unregistered successfully"})
+}
+
+// TestDevice pushes a test notification to one of the current user's devices
+// @Summary Send test push
+// @Description Push a test notification to a device of the current user straight away, to check the app receives pushes. A token the push service reports as no longer valid is unregistered
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Device ID"
+// @Success 200 {object} models.DeviceToken
+// @Failure 400 {object} ErrorResponse "The device's push service is not configured"
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse "The push service did not accept the notification"
+// @Router /api/notifications/devices/{id}/test [post]
+func TestDevice(c *gin.Context) {
+	device, ok := findMyDevice(c)
+	if !ok {
+		return
+	}
+
+	lang := utils.RequestLanguage(c)
+	err := utils.SendTestPush(device, i18n.T(lang, "Test notification"), i18n.T(lang, "Push notifications reach this device."))
+	switch {
+	case errors.Is(err, utils.ErrPushNotConfigured):
+		utils.RespondError(c, http.StatusBadRequest, "Push notifications are not configured for this device's service")
+		return
+	case err != nil:
+		utils.RespondError(c, http.StatusBadGateway, i18n.T(lang, "Failed to send test push: %s", err.Error()))
+		return
+	}
+
+	if reloaded, ok := findMyDevice(c); ok {
+		c.JSON(http.StatusOK, reloaded)
+	}
+}
+
+func findMyDevice(c *gin.Context) (models.DeviceToken, bool) {
+	var device models.DeviceToken
+	err := requestDB(c).Where("id = ? AND employee_id = ?", c.Param("id"), c.GetUint("user_id")).Limit(1).Find(&device).Error
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch devices")
+		return device, false
+	}
+	if device.ID == 0 {
+		utils.RespondError(c, http.StatusNotFound, "Device not found")
+		return device, false
+	}
+	return device, true
+}
+
+// GetComplianceNotifications lists compliance notifications that have been sent
+// @Summary Get compliance notifications
+// @Description List compliance reminder and expiry notifications sent on all channels, optionally filtered by employee (Manager/Admin only)
+// @Tags Core HR - Compliance
+// @Produce json
+// @Security BearerAuth
+// @Param employee_id query int false "Recipient employee ID"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Notification}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/compliance/notifications [get]
+func GetComplianceNotifications(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Recipient").Where("category IN ?",
+		[]models.NotificationCategory{models.NotificationComplianceReminder, models.NotificationComplianceExpired})
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		query = query.Where("recipient_id = ?", employeeID)
+	}
+
+	var notifications []models.Notification
+	response, err := paginate(query.Order("created_at DESC, id DESC"), pagination, &notifications)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ProcessComplianceExpiry runs the compliance expiry job on demand
+// @Summary Process compliance expiry
+// @Description Mark lapsed compliance records as expired and send due reminders now instead of waiting for the daily job (Manager/Admin only)
+// @Tags Core HR - Compliance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ComplianceExpiryResult
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/compliance/process-expiry [post]
+func ProcessComplianceExpiry(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.ProcessComplianceExpiry())
+}
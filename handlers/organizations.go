@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateOrganizationRequest represents a new organization and its first admin account
+type CreateOrganizationRequest struct {
+	Name        string             `json:"name" binding:"required,max=100" example:"Acme Zambia"`
+	Code        string             `json:"code" binding:"required,max=20" example:"acme"` // Given by employees when they register
+	LeavePreset string             `json:"leave_preset,omitempty" example:"ZM"`           // Leave types to start with, defaults to LEAVE_PRESET
+	Admin       CreateAdminRequest `json:"admin" binding:"required"`
+}
+
+// CreateOrganizationResponse is a new organization with its first admin account
+type CreateOrganizationResponse struct {
+	Organization models.Organization `json:"organization"`
+	Admin        models.Employee     `json:"admin"`
+}
+
+// GetCurrentOrganization returns the organization of the current user
+// @Summary Get current organization
+// @Description Get the organization the current user belongs to
+// @Tags Organizations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.Organization
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/organization [get]
+func GetCurrentOrganization(c *gin.Context) {
+	var organization models.Organization
+	if err := requestDB(c).First(&organization, c.GetUint("organization_id")).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// GetOrganizations lists every organization
+// @Summary Get organizations
+// @Description List every organization (Admins of the default organization only)
+// @Tags Organizations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Organization
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/organizations [get]
+func GetOrganizations(c *gin.Context) {
+	if !requireDefaultOrganization(c) {
+		return
+	}
+
+	var organizations []models.Organization
+	requestDB(c).Order("id").Find(&organizations)
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// CreateOrganization creates an organization with its first admin and the leave types of a leave preset
+// @Summary Create organization
+// @Description Create an organization together with its first admin account and the leave types of a leave preset, such as a country's statutory leave (see GET /api/admin/leave-presets). The admin signs in with their username and manages the new organization's data, which no other organization can see (Admins of the default organization only)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateOrganizationRequest true "Organization and admin data"
+// @Success 201 {object} CreateOrganizationResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Organization code, admin username or email already exists"
+// @Router /api/organizations [post]
+func CreateOrganization(c *gin.Context) {
+	if !requireDefaultOrganization(c) {
+		return
+	}
+
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	presetCode := req.LeavePreset
+	if presetCode == "" {
+		presetCode = config.AppConfig.LeavePreset
+	}
+	preset, ok := models.FindLeavePreset(presetCode)
+	if !ok {
+		utils.RespondError(c, http.StatusBadRequest, "Unknown leave preset")
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Admin.Password)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	organization := models.Organization{Name: req.Name, Code: strings.ToLower(strings.TrimSpace(req.Code)), LeavePreset: preset.Code, IsActive: true}
+	username := req.Admin.Username
+	var email *string
+	if req.Admin.Email != "" {
+		email = &req.Admin.Email
+	}
+	admin := models.Employee{
+		Username:     &username,
+		Firstname:    req.Admin.Firstname,
+		Lastname:     req.Admin.Lastname,
+		Email:        email,
+		PasswordHash: hashedPassword,
+		Department:   req.Admin.Department,
+		Role:         models.RoleAdmin,
+	}
+
+	// The admin and leave types are created in the new organization, so they belong to it
+	err = withTransaction(c, func(tx *gorm.DB) error {
+		if err := tx.Create(&organization).Error; err != nil {
+			return err
+		}
+		orgTx := tx.WithContext(database.WithOrganization(c.Request.Context(), organization.ID))
+		if err := orgTx.Create(&admin).Error; err != nil {
+			return err
+		}
+		return database.SeedLeaveTypes(orgTx, preset)
+	})
+	if err != nil {
+		if database.IsDuplicateKey(err) {
+			utils.RespondError(c, http.StatusConflict, "Organization code, admin username or email already exists")
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create organization")
+		return
+	}
+
+	admin.PasswordHash = ""
+	c.JSON(http.StatusCreated, CreateOrganizationResponse{Organization: organization, Admin: admin})
+}
+
+// requireDefaultOrganization only lets admins of the default organization manage other organizations
+func requireDefaultOrganization(c *gin.Context) bool {
+	if c.GetUint("organization_id") != models.DefaultOrganizationID {
+		utils.RespondError(c, http.StatusForbidden, "Only admins of the default organization can manage organizations")
+		return false
+	}
+	return true
+}
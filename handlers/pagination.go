@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPerPage = 25
+	maxPerPage     = 100
+)
+
+// Pagination is the page requested through the page and per_page query parameters
+type Pagination struct {
+	Page    int
+	PerPage int
+}
+
+// PaginatedResponse is the envelope returned by paginated list endpoints
+type PaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	Page       int         `json:"page" example:"1"`
+	PerPage    int         `json:"per_page" example:"25"`
+	Total      int64       `json:"total" example:"132"` // Total records matching the filters
+	TotalPages int         `json:"total_pages" example:"6"`
+}
+
+// parsePagination reads page (default 1) and per_page (default 25, capped at 100) from the query string
+func parsePagination(c *gin.Context) (Pagination, bool) {
+	p := Pagination{Page: 1, PerPage: defaultPerPage}
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid page. Use a number from 1")
+			return p, false
+		}
+		p.Page = page
+	}
+	if perPageStr := c.Query("per_page"); perPageStr != "" {
+		perPage, err := strconv.Atoi(perPageStr)
+		if err != nil || perPage < 1 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid per_page. Use a number from 1")
+			return p, false
+		}
+		p.PerPage = perPage
+		if p.PerPage > maxPerPage {
+			p.PerPage = maxPerPage
+		}
+	}
+	return p, true
+}
+
+// paginate counts the records matching query and loads the requested page into dest, which must be a
+// pointer to a slice. The query should be ordered so pages are stable.
+func paginate(query *gorm.DB, p Pagination, dest interface{}) (PaginatedResponse, error) {
+	response := PaginatedResponse{Data: dest, Page: p.Page, PerPage: p.PerPage}
+
+	// Count on a copy without preloads; preloading into the count result would fail
+	countQuery := query.Session(&gorm.Session{Context: query.Statement.Context})
+	countQuery.Statement.Preloads = nil
+	if err := countQuery.Model(dest).Count(&response.Total).Error; err != nil {
+		return response, err
+	}
+	response.TotalPages = int((response.Total + int64(p.PerPage) - 1) / int64(p.PerPage))
+
+	if err := query.Offset((p.Page - 1) * p.PerPage).Limit(p.PerPage).Find(dest).Error; err != nil {
+		return response, err
+	}
+	return response, nil
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetPIIAccessRequest represents a change to an employee's PII access permission
+type SetPIIAccessRequest struct {
+	PIIAccess bool `json:"pii_access" example:"true"`
+}
+
+// SetPIIAccess grants or revokes an employee's access to other people's unmasked personal data
+// @Summary Set PII access
+// @Description Grant or revoke an employee's permission to see other people's NRC, date of birth and address unmasked. Without it, managers and employees see these fields masked in every response, except in their own records; admins always see them (Admin only)
+// @Tags Admin - Employees
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body SetPIIAccessRequest true "PII access"
+// @Success 200 {object} models.Employee
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/pii-access [put]
+func SetPIIAccess(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req SetPIIAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	oldValues := gin.H{"pii_access": employee.PIIAccess}
+	if err := requestDB(c).Model(&employee).Update("pii_access", req.PIIAccess).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update PII access")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityEmployee, employee.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, gin.H{"pii_access": req.PIIAccess})
+
+	c.JSON(http.StatusOK, employee)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DepartmentCompleteness rolls up profile completeness for one department
+type DepartmentCompleteness struct {
+	Department         string         `json:"department" example:"Finance"`
+	Employees          int            `json:"employees" example:"12"`
+	FullyComplete      int            `json:"fully_complete" example:"7"`
+	AveragePercentage  float64        `json:"average_percentage" example:"86.7"`
+	IncompleteSections map[string]int `json:"incomplete_sections"` // Number of employees with each section missing or stale
+}
+
+// ProfileCompletenessReport represents the HR roll-up of profile completeness
+type ProfileCompletenessReport struct {
+	Employees         int                      `json:"employees" example:"48"`
+	AveragePercentage float64                  `json:"average_percentage" example:"81.2"`
+	Departments       []DepartmentCompleteness `json:"departments"`
+}
+
+// GetProfileCompleteness reports which sections of an employee's profile are missing or stale
+// @Summary Get employee profile completeness
+// @Description Report which profile sections (identity, employment, documents, emergency contact, compliance) are complete, missing or stale for an employee. Employees can only view their own profile
+// @Tags Core HR - Profile
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} utils.ProfileCompleteness
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/employees/{id}/profile-completeness [get]
+func GetProfileCompleteness(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.CalculateProfileCompleteness(employee))
+}
+
+// GetProfileCompletenessReport rolls up profile completeness by department
+// @Summary Get profile completeness report
+// @Description Show the average profile completeness percentage per department and how many employees have each section missing or stale (Manager/Admin only)
+// @Tags Core HR - Profile
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Department filter"
+// @Success 200 {object} ProfileCompletenessReport
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/profile-completeness [get]
+func GetProfileCompletenessReport(c *gin.Context) {
+	query := requestDB(c).Where("role != ? AND status = ?", models.RoleAdmin, "active")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+
+	var employees []models.Employee
+	if err := query.Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+
+	departments := map[string]*DepartmentCompleteness{}
+	report := ProfileCompletenessReport{Employees: len(employees)}
+	totalPercentage := 0.0
+
+	for _, employee := range employees {
+		completeness := utils.CalculateProfileCompleteness(employee)
+
+		dept, ok := departments[employee.Department]
+		if !ok {
+			dept = &DepartmentCompleteness{
+				Department:         employee.Department,
+				IncompleteSections: map[string]int{},
+			}
+			for _, section := range utils.ProfileSections {
+				dept.IncompleteSections[section] = 0
+			}
+			departments[employee.Department] = dept
+		}
+
+		dept.Employees++
+		dept.AveragePercentage += completeness.Percentage
+		totalPercentage += completeness.Percentage
+		if completeness.Percentage == 100 {
+			dept.FullyComplete++
+		}
+		for _, section := range completeness.Sections {
+			if section.Status != utils.ProfileSectionComplete {
+				dept.IncompleteSections[section.Section]++
+			}
+		}
+	}
+
+	for _, dept := range departments {
+		dept.AveragePercentage = dept.AveragePercentage / float64(dept.Employees)
+		report.Departments = append(report.Departments, *dept)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+	if len(employees) > 0 {
+		report.AveragePercentage = totalPercentage / float64(len(employees))
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CompanyValueRequest represents data for creating or updating a company value
+type CompanyValueRequest struct {
+	Name        string  `json:"name" binding:"required" example:"Teamwork"`
+	Description *string `json:"description,omitempty" example:"We succeed together and share the credit"`
+	IsActive    *bool   `json:"is_active,omitempty" example:"true"` // Defaults to true; inactive values cannot receive new kudos
+}
+
+// SendKudosRequest represents kudos sent to a colleague
+type SendKudosRequest struct {
+	RecipientID uint   `json:"recipient_id" binding:"required" example:"15"`
+	ValueID     uint   `json:"value_id" binding:"required" example:"2"`
+	Message     string `json:"message" binding:"required,max=1000" example:"Thanks for covering the month-end close while I was out"`
+}
+
+// MyKudosResponse lists the kudos the current user has received and sent
+type MyKudosResponse struct {
+	Received []models.Kudos `json:"received"`
+	Sent     []models.Kudos `json:"sent"`
+}
+
+// GetCompanyValues lists the company values kudos can be given against
+// @Summary Get company values
+// @Description List active company values. Admins can include inactive values with include_inactive=true
+// @Tags Recognition
+// @Produce json
+// @Security BearerAuth
+// @Param include_inactive query bool false "Include inactive values (Admin only)"
+// @Success 200 {array} models.CompanyValue
+// @Failure 401 {object} ErrorResponse
+// @Router /api/recognition/values [get]
+func GetCompanyValues(c *gin.Context) {
+	query := requestDB(c).Order("name")
+	user := getCurrentUser(c)
+	if c.Query("include_inactive") != "true" || user == nil || user.Role != models.RoleAdmin {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var values []models.CompanyValue
+	query.Find(&values)
+
+	c.JSON(http.StatusOK, values)
+}
+
+// CreateCompanyValue adds a company value
+// @Summary Create company value
+// @Description Add a company value that kudos can be given against (Admin only)
+// @Tags Recognition
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CompanyValueRequest true "Company value"
+// @Success 201 {object} models.CompanyValue
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/recognition/values [post]
+func CreateCompanyValue(c *gin.Context) {
+	var req CompanyValueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var existing int64
+	requestDB(c).Model(&models.CompanyValue{}).Where("LOWER(name) = LOWER(?)", req.Name).Count(&existing)
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "A company value with this name already exists")
+		return
+	}
+
+	value := models.CompanyValue{Name: req.Name, Description: req.Description, IsActive: true}
+	if req.IsActive != nil {
+		value.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Create(&value).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create company value")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityRecognition, value.ID, models.AuditActionCreate, userID.(uint), c, nil, value)
+
+	c.JSON(http.StatusCreated, value)
+}
+
+// UpdateCompanyValue updates or deactivates a company value
+// @Summary Update company value
+// @Description Rename, describe or deactivate a company value. Existing kudos keep their value (Admin only)
+// @Tags Recognition
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Company value ID"
+// @Param request body CompanyValueRequest true "Company value"
+// @Success 200 {object} models.CompanyValue
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/recognition/values/{id} [put]
+func UpdateCompanyValue(c *gin.Context) {
+	valueID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req CompanyValueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var value models.CompanyValue
+	if err := requestDB(c).First(&value, valueID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Company value not found")
+		return
+	}
+
+	var existing int64
+	requestDB(c).Model(&models.CompanyValue{}).Where("LOWER(name) = LOWER(?) AND id != ?", req.Name, value.ID).Count(&existing)
+	if existing > 0 {
+		utils.RespondError(c, http.StatusConflict, "A company value with this name already exists")
+		return
+	}
+
+	oldValues := value
+	value.Name = req.Name
+	value.Description = req.Description
+	if req.IsActive != nil {
+		value.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Save(&value).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update company value")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityRecognition, value.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, value)
+
+	c.JSON(http.StatusOK, value)
+}
+
+// SendKudos recognises a colleague for living a company value
+// @Summary Send kudos
+// @Description Send kudos to a colleague tied to an active company value. The recipient is notified
+// @Tags Recognition
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SendKudosRequest true "Kudos"
+// @Success 201 {object} models.Kudos
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/recognition/kudos [post]
+func SendKudos(c *gin.Context) {
+	var req SendKudosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	sender := getCurrentUser(c)
+	if sender == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if req.RecipientID == sender.ID {
+		utils.RespondError(c, http.StatusBadRequest, "You cannot send kudos to yourself")
+		return
+	}
+
+	var recipient models.Employee
+	if err := requestDB(c).Where("status = ?", "active").First(&recipient, req.RecipientID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Recipient not found")
+		return
+	}
+	var value models.CompanyValue
+	if err := requestDB(c).Where("is_active = ?", true).First(&value, req.ValueID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Company value not found")
+		return
+	}
+
+	kudos := models.Kudos{
+		SenderID:    sender.ID,
+		RecipientID: recipient.ID,
+		ValueID:     value.ID,
+		Message:     req.Message,
+	}
+	if err := requestDB(c).Create(&kudos).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to send kudos")
+		return
+	}
+	kudos.Sender = *sender
+	kudos.Recipient = recipient
+	kudos.Value = value
+
+	subject := i18n.M("%s %s sent you kudos for %s", sender.Firstname, sender.Lastname, value.Name)
+	values := map[string]string{
+		"sender.full_name": strings.TrimSpace(sender.Firstname + " " + sender.Lastname), "value.name": value.Name, "kudos.message": req.Message,
+	}
+	afterCommit(c, func() {
+		utils.Notify(recipient, models.NotificationKudosReceived, subject, i18n.Untranslated(req.Message), values, models.AuditEntityRecognition, kudos.ID)
+	})
+
+	createAuditLog(models.AuditEntityRecognition, kudos.ID, models.AuditActionCreate, sender.ID, c, nil, kudos)
+
+	c.JSON(http.StatusCreated, kudos)
+}
+
+// GetRecognitionFeed lists recent kudos across the organisation
+// @Summary Get recognition feed
+// @Description List kudos, newest first, across the organisation, optionally filtered by company value or department
+// @Tags Recognition
+// @Produce json
+// @Security BearerAuth
+// @Param value_id query int false "Company value ID"
+// @Param department query string false "Recipient department"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Kudos}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/recognition/feed [get]
+func GetRecognitionFeed(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := kudosFeedQuery(c)
+	if department := c.Query("department"); department != "" {
+		query = query.Joins("JOIN employees ON employees.id = kudos.recipient_id").
+			Where("employees.department = ?", department)
+	}
+
+	var kudos []models.Kudos
+	response, err := paginate(query, pagination, &kudos)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch kudos")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMyKudos lists kudos the current user has received and sent
+// @Summary Get my kudos
+// @Description List the kudos the current user has received and sent, newest first
+// @Tags Recognition
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MyKudosResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/recognition/me [get]
+func GetMyKudos(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	response := MyKudosResponse{}
+	requestDB(c).Preload("Sender").Preload("Value").Where("recipient_id = ?", userID).
+		Order("created_at DESC").Find(&response.Received)
+	requestDB(c).Preload("Recipient").Preload("Value").Where("sender_id = ?", userID).
+		Order("created_at DESC").Find(&response.Sent)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTeamRecognition lists kudos received by the current manager's team
+// @Summary Get team recognition
+// @Description List kudos, newest first, received by the manager's direct reports. Admins see kudos across all teams (Manager/Admin only)
+// @Tags Recognition
+// @Produce json
+// @Security BearerAuth
+// @Param value_id query int false "Company value ID"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.Kudos}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/recognition/team [get]
+func GetTeamRecognition(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := kudosFeedQuery(c)
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin {
+		reports := requestDB(c).Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ?", user.ID)
+		query = query.Where("kudos.recipient_id IN (?)", reports)
+	}
+
+	var kudos []models.Kudos
+	response, err := paginate(query, pagination, &kudos)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch kudos")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteKudos removes inappropriate kudos
+// @Summary Delete kudos
+// @Description Remove kudos from the feed and reports (Admin only)
+// @Tags Recognition
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Kudos ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/recognition/kudos/{id} [delete]
+func DeleteKudos(c *gin.Context) {
+	kudosID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var kudos models.Kudos
+	if err := requestDB(c).First(&kudos, kudosID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Kudos not found")
+		return
+	}
+	if err := requestDB(c).Delete(&kudos).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete kudos")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityRecognition, kudos.ID, models.AuditActionDelete, userID.(uint), c, kudos, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Kudos deleted successfully"})
+}
+
+// GetRecognitionStats reports recognition stats for a quarter
+// @Summary Get recognition stats
+// @Description Report kudos by company value, department and top recipients for a quarter, with participation rate. Defaults to the current quarter (HR/Admin only)
+// @Tags Recognition
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year"
+// @Param quarter query int false "Quarter (1-4)"
+// @Success 200 {object} utils.RecognitionStats
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/recognition/stats [get]
+func GetRecognitionStats(c *gin.Context) {
+	year, quarter, ok := parseRecognitionQuarter(c)
+	if !ok {
+		return
+	}
+
+	stats, err := utils.GetRecognitionStats(requestDB(c), year, quarter)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate recognition stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// ExportRecognitionStats exports recognition stats for a quarter to Excel
+// @Summary Export recognition stats
+// @Description Export quarterly recognition stats to Excel. Defaults to the current quarter (HR/Admin only)
+// @Tags Recognition
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param year query int false "Year"
+// @Param quarter query int false "Quarter (1-4)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/recognition/stats/export [get]
+func ExportRecognitionStats(c *gin.Context) {
+	year, quarter, ok := parseRecognitionQuarter(c)
+	if !ok {
+		return
+	}
+
+	stats, err := utils.GetRecognitionStats(requestDB(c), year, quarter)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to calculate recognition stats")
+		return
+	}
+
+	fileData, err := utils.ExportRecognitionStatsToExcel(stats)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+		return
+	}
+
+	filename := fmt.Sprintf("recognition_%d_Q%d.xlsx", year, quarter)
+	contentType := "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", contentType)
+	c.Data(http.StatusOK, contentType, fileData)
+}
+
+// kudosFeedQuery builds a newest-first kudos query honouring the value_id query parameter
+func kudosFeedQuery(c *gin.Context) *gorm.DB {
+	query := requestDB(c).Preload("Sender").Preload("Recipient").Preload("Value").
+		Order("kudos.created_at DESC, kudos.id DESC")
+	if valueID := c.Query("value_id"); valueID != "" {
+		query = query.Where("kudos.value_id = ?", valueID)
+	}
+	return query
+}
+
+// parseRecognitionQuarter reads the year and quarter query parameters, defaulting to the current quarter
+func parseRecognitionQuarter(c *gin.Context) (int, int, bool) {
+	now := time.Now()
+	year, quarter := now.Year(), (int(now.Month())-1)/3+1
+
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 2000 || parsed > 2100 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid year")
+			return 0, 0, false
+		}
+		year = parsed
+	}
+	if quarterStr := c.Query("quarter"); quarterStr != "" {
+		parsed, err := strconv.Atoi(quarterStr)
+		if err != nil || parsed < 1 || parsed > 4 {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid quarter. Use 1-4")
+			return 0, 0, false
+		}
+		quarter = parsed
+	}
+
+	return year, quarter, true
+}
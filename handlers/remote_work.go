@@ -0,0 +1,459 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRemoteWorkRequest represents a request to work remotely for one or more days
+type CreateRemoteWorkRequest struct {
+	StartDate string  `json:"start_date" binding:"required" example:"2025-03-17"`
+	EndDate   string  `json:"end_date" binding:"required" example:"2025-03-18"`
+	Location  *string `json:"location,omitempty" example:"Home"`
+	Reason    *string `json:"reason,omitempty" example:"Contractor visit at home"`
+}
+
+// ReviewRemoteWorkRequest represents an optional comment when reviewing a remote work request
+type ReviewRemoteWorkRequest struct {
+	Comment *string `json:"comment,omitempty" example:"Fine, please stay reachable on Teams"`
+}
+
+// DepartmentRemoteWork totals remote work utilization for a department
+type DepartmentRemoteWork struct {
+	Department       string  `json:"department" example:"Finance"`
+	Employees        int     `json:"employees" example:"8"`
+	WorkingDays      int     `json:"working_days" example:"168"`
+	OfficeDays       int     `json:"office_days" example:"120"`
+	RemoteDays       int     `json:"remote_days" example:"38"`
+	LeaveDays        int     `json:"leave_days" example:"10"`
+	RemotePercentage float64 `json:"remote_percentage" example:"24.1"`
+}
+
+// RemoteWorkUtilizationReport is the remote work utilization report for a month
+type RemoteWorkUtilizationReport struct {
+	Month       string                        `json:"month" example:"2025-03"`
+	Departments []DepartmentRemoteWork        `json:"departments"`
+	Employees   []utils.RemoteWorkUtilization `json:"employees"`
+}
+
+// CreateRemoteWork submits a remote work request for the current user
+// @Summary Request remote work
+// @Description Request to work remotely for a date range. The range may not overlap pending or approved leave or another open remote work request
+// @Tags Remote Work
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateRemoteWorkRequest true "Remote work request"
+// @Success 201 {object} models.RemoteWorkRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/remote-work [post]
+func CreateRemoteWork(c *gin.Context) {
+	var req CreateRemoteWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use YYYY-MM-DD")
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use YYYY-MM-DD")
+		return
+	}
+	if endDate.Before(startDate) {
+		utils.RespondError(c, http.StatusBadRequest, "end_date must be on or after start_date")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	employeeID := userID.(uint)
+
+	hasLeave, err := utils.CheckOverlappingLeaves(employeeID, startDate, endDate, nil)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to check overlapping leaves")
+		return
+	}
+	if hasLeave {
+		utils.RespondError(c, http.StatusConflict, "You have pending or approved leave during this period")
+		return
+	}
+
+	var open int64
+	requestDB(c).Model(&models.RemoteWorkRequest{}).
+		Where("employee_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?", employeeID,
+			[]models.RemoteWorkStatus{models.RemoteWorkPending, models.RemoteWorkApproved}, endDate, startDate).
+		Count(&open)
+	if open > 0 {
+		utils.RespondError(c, http.StatusConflict, "You already have a remote work request covering this period")
+		return
+	}
+
+	request := models.RemoteWorkRequest{
+		EmployeeID: employeeID,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Location:   req.Location,
+		Reason:     req.Reason,
+		Status:     models.RemoteWorkPending,
+	}
+	if err := requestDB(c).Create(&request).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create remote work request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityRemoteWork, request.ID, models.AuditActionCreate, employeeID, c, nil, request)
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// GetMyRemoteWork lists the current user's remote work requests
+// @Summary Get my remote work requests
+// @Description List the current user's remote work requests, newest first
+// @Tags Remote Work
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected, cancelled)"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to -start_date"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.RemoteWorkRequest}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/remote-work/me [get]
+func GetMyRemoteWork(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	fields := remoteWorkListFields
+	fields.Filters = map[string]string{"status": "status"}
+	fields.DefaultSort = "-start_date"
+	query, ok := applyListQuery(c, requestDB(c).Preload("Approver").Where("employee_id = ?", userID), fields)
+	if !ok {
+		return
+	}
+
+	var requests []models.RemoteWorkRequest
+	response, err := paginate(query, pagination, &requests)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch remote work requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CancelRemoteWork cancels one of the current user's remote work requests
+// @Summary Cancel remote work request
+// @Description Cancel a pending or approved remote work request that has not started yet
+// @Tags Remote Work
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Remote work request ID"
+// @Success 200 {object} models.RemoteWorkRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/remote-work/{id}/cancel [put]
+func CancelRemoteWork(c *gin.Context) {
+	requestID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var request models.RemoteWorkRequest
+	if err := requestDB(c).First(&request, requestID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Remote work request not found")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if request.EmployeeID != userID.(uint) {
+		utils.RespondError(c, http.StatusForbidden, "You can only cancel your own remote work requests")
+		return
+	}
+	if request.Status != models.RemoteWorkPending && request.Status != models.RemoteWorkApproved {
+		utils.RespondError(c, http.StatusBadRequest, "Only pending or approved requests can be cancelled")
+		return
+	}
+	var employee models.Employee
+	requestDB(c).First(&employee, request.EmployeeID)
+	today := utils.DateIn(time.Now(), utils.EmployeeLocation(&employee))
+	if request.StartDate.Before(today) {
+		utils.RespondError(c, http.StatusBadRequest, "Requests that have already started cannot be cancelled")
+		return
+	}
+
+	oldValues := request
+	request.Status = models.RemoteWorkCancelled
+	if err := requestDB(c).Save(&request).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to cancel remote work request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityRemoteWork, request.ID, models.AuditActionUpdate, request.EmployeeID, c, oldValues, request)
+
+	c.JSON(http.StatusOK, request)
+}
+
+// remoteWorkListFields are the filters and sort keys accepted by the remote work request list
+var remoteWorkListFields = ListFields{
+	Filters: map[string]string{"employee_id": "employee_id"},
+	Sorts: map[string]string{
+		"id": "id", "start_date": "start_date", "end_date": "end_date", "created_at": "created_at", "status": "status",
+	},
+	DefaultSort: "start_date",
+}
+
+// GetRemoteWorkRequests lists remote work requests for review
+// @Summary Get remote work requests
+// @Description List remote work requests. Managers see their direct reports; admins see all. Defaults to pending requests (Manager/Admin only)
+// @Tags Remote Work
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected, cancelled, all)" default(pending)
+// @Param employee_id query int false "Employee ID"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, start_date, end_date, created_at, status). Defaults to start_date"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.RemoteWorkRequest}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/remote-work [get]
+func GetRemoteWorkRequests(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").Preload("Approver")
+
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin {
+		reports := requestDB(c).Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ?", user.ID)
+		query = query.Where("employee_id IN (?)", reports)
+	}
+	status := c.DefaultQuery("status", string(models.RemoteWorkPending))
+	if status != "all" {
+		query = query.Where("status = ?", status)
+	}
+
+	query, ok = applyListQuery(c, query, remoteWorkListFields)
+	if !ok {
+		return
+	}
+
+	var requests []models.RemoteWorkRequest
+	response, err := paginate(query, pagination, &requests)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch remote work requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveRemoteWork approves a remote work request
+// @Summary Approve remote work request
+// @Description Approve a pending remote work request (Employee's manager or Admin)
+// @Tags Remote Work
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Remote work request ID"
+// @Param request body ReviewRemoteWorkRequest false "Review comment"
+// @Success 200 {object} models.RemoteWorkRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/remote-work/{id}/approve [put]
+func ApproveRemoteWork(c *gin.Context) {
+	reviewRemoteWork(c, models.RemoteWorkApproved)
+}
+
+// RejectRemoteWork rejects a remote work request
+// @Summary Reject remote work request
+// @Description Reject a pending remote work request (Employee's manager or Admin)
+// @Tags Remote Work
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Remote work request ID"
+// @Param request body ReviewRemoteWorkRequest false "Review comment"
+// @Success 200 {object} models.RemoteWorkRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/remote-work/{id}/reject [put]
+func RejectRemoteWork(c *gin.Context) {
+	reviewRemoteWork(c, models.RemoteWorkRejected)
+}
+
+func reviewRemoteWork(c *gin.Context, newStatus models.RemoteWorkStatus) {
+	requestID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req ReviewRemoteWorkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	var request models.RemoteWorkRequest
+	if err := requestDB(c).First(&request, requestID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Remote work request not found")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if user.Role != models.RoleAdmin && !managesEmployee(user.ID, request.EmployeeID) {
+		utils.RespondError(c, http.StatusForbidden, "Only the employee's manager or an admin can review this request")
+		return
+	}
+	if request.Status != models.RemoteWorkPending {
+		utils.RespondError(c, http.StatusBadRequest, "Remote work request has already been reviewed")
+		return
+	}
+
+	oldValues := request
+	now := time.Now()
+	request.Status = newStatus
+	request.ApprovedBy = &user.ID
+	request.ApprovedAt = &now
+	request.ReviewComment = req.Comment
+	if err := requestDB(c).Save(&request).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to review remote work request")
+		return
+	}
+
+	action := models.AuditActionReject
+	if newStatus == models.RemoteWorkApproved {
+		action = models.AuditActionApprove
+	}
+	createAuditLog(models.AuditEntityRemoteWork, request.ID, action, user.ID, c, oldValues, request)
+
+	c.JSON(http.StatusOK, request)
+}
+
+// GetTeamCalendar shows who is in the office, remote or on leave each day
+// @Summary Get team calendar
+// @Description Show each employee's status per day (in_office, remote, on_leave, non_working) from approved leave, approved remote work and work schedules. Managers see their direct reports; admins see everyone. Defaults to the next 14 days (Manager/Admin only)
+// @Tags Remote Work
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param department query string false "Filter by department"
+// @Success 200 {array} utils.EmployeePresence
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/hr/team-calendar [get]
+func GetTeamCalendar(c *gin.Context) {
+	from, to, ok := parseRotaRange(c, 14)
+	if !ok {
+		return
+	}
+	if to.Sub(from) > 92*24*time.Hour {
+		utils.RespondError(c, http.StatusBadRequest, "Date range cannot exceed 93 days")
+		return
+	}
+
+	employees := teamCalendarEmployees(c)
+	c.JSON(http.StatusOK, utils.BuildPresenceCalendar(employees, from, to))
+}
+
+// GetRemoteWorkUtilization reports remote work utilization for a month
+// @Summary Get remote work utilization
+// @Description Report office, remote and leave days per employee and department for a month. Remote percentage excludes leave days (Manager/Admin only)
+// @Tags Remote Work
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM), defaults to the current month"
+// @Param department query string false "Filter by department"
+// @Success 200 {object} RemoteWorkUtilizationReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/hr/remote-work/utilization [get]
+func GetRemoteWorkUtilization(c *gin.Context) {
+	monthStart, ok := parseAttendanceMonth(c)
+	if !ok {
+		return
+	}
+
+	employees := teamCalendarEmployees(c)
+	calendar := utils.BuildPresenceCalendar(employees, monthStart, monthStart.AddDate(0, 1, -1))
+	utilization := utils.SummarizeRemoteWork(calendar)
+
+	byDepartment := map[string]*DepartmentRemoteWork{}
+	for _, u := range utilization {
+		dept := byDepartment[u.Department]
+		if dept == nil {
+			dept = &DepartmentRemoteWork{Department: u.Department}
+			byDepartment[u.Department] = dept
+		}
+		dept.Employees++
+		dept.WorkingDays += u.WorkingDays
+		dept.OfficeDays += u.OfficeDays
+		dept.RemoteDays += u.RemoteDays
+		dept.LeaveDays += u.LeaveDays
+	}
+
+	report := RemoteWorkUtilizationReport{
+		Month:       monthStart.Format("2006-01"),
+		Departments: make([]DepartmentRemoteWork, 0, len(byDepartment)),
+		Employees:   utilization,
+	}
+	for _, dept := range byDepartment {
+		if available := dept.WorkingDays - dept.LeaveDays; available > 0 {
+			dept.RemotePercentage = float64(dept.RemoteDays) / float64(available) * 100
+		}
+		report.Departments = append(report.Departments, *dept)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+
+	c.JSON(http.StatusOK, report)
+}
+
+// teamCalendarEmployees loads the active employees the current user may see on the team calendar
+func teamCalendarEmployees(c *gin.Context) []models.Employee {
+	query := requestDB(c).Where("status = ? AND role != ?", "active", models.RoleAdmin)
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin {
+		reports := requestDB(c).Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ?", user.ID)
+		query = query.Where("id IN (?)", reports)
+	}
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+
+	var employees []models.Employee
+	query.Order("department, lastname, firstname").Find(&employees)
+	return employees
+}
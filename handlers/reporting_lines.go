@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReportingLineEmployee is an employee as shown in a reporting line
+type ReportingLineEmployee struct {
+	ID                uint                  `json:"id" example:"12"`
+	Firstname         string                `json:"firstname" example:"Chanda"`
+	Lastname          string                `json:"lastname" example:"Mwila"`
+	Email             *string               `json:"email,omitempty" example:"chanda.mwila@example.com"`
+	Department        string                `json:"department" example:"Finance"`
+	JobTitle          string                `json:"job_title,omitempty" example:"Accountant"` // Title of the current primary position, or the job title on the profile
+	EmploymentType    models.EmploymentType `json:"employment_type,omitempty" example:"full_time"`
+	HireDate          *time.Time            `json:"hire_date,omitempty"`
+	DirectReportCount int                   `json:"direct_report_count" example:"0"` // How many current direct reports the employee has in turn
+}
+
+// TeamResponse is the current user's place in the reporting line
+type TeamResponse struct {
+	Manager       *ReportingLineEmployee  `json:"manager,omitempty"` // Who the current user reports to, if anyone
+	DirectReports []ReportingLineEmployee `json:"direct_reports"`
+}
+
+// ReportingLineChange is a change of who an employee reports to
+type ReportingLineChange struct {
+	ID                  uint      `json:"id" example:"7"` // Employment history entry ID
+	ChangeDate          time.Time `json:"change_date"`
+	PreviousManagerID   *uint     `json:"previous_manager_id,omitempty" example:"3"`
+	PreviousManagerName string    `json:"previous_manager_name,omitempty" example:"Mutale Banda"`
+	NewManagerID        *uint     `json:"new_manager_id,omitempty" example:"5"`
+	NewManagerName      string    `json:"new_manager_name,omitempty" example:"Bwalya Phiri"`
+	Reason              *string   `json:"reason,omitempty" example:"Position transfer"`
+	ChangedBy           *uint     `json:"changed_by,omitempty" example:"1"`
+	ChangedByName       string    `json:"changed_by_name,omitempty" example:"Admin User"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// directReports returns a subquery of the IDs of the employees who currently report to managerID,
+// leaving out those who have been terminated or resigned
+func directReports(db *gorm.DB, managerID uint) *gorm.DB {
+	return db.Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ? AND employment_status NOT IN ?",
+		managerID, []models.EmploymentStatus{models.EmploymentStatusTerminated, models.EmploymentStatusResigned})
+}
+
+// GetDirectReports lists the employees who report to an employee
+// @Summary Get direct reports
+// @Description List the current direct reports of an employee, the employees whose employment details name them as manager, leaving out those who left or are inactive. Each comes with their position and how many direct reports they have in turn, so the organization chart can be walked down. Employees can only see their own direct reports, managers and admins anyone's
+// @Tags Core HR - Reporting Lines
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} ReportingLineEmployee
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/direct-reports [get]
+func GetDirectReports(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	reports, err := loadDirectReports(requestDB(c), employee.ID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch direct reports")
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+// GetMyTeam returns the current user's manager and direct reports
+// @Summary Get my team
+// @Description Get who you report to and your current direct reports, built from the manager on employment details
+// @Tags Core HR - Reporting Lines
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TeamResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/team [get]
+func GetMyTeam(c *gin.Context) {
+	db := requestDB(c)
+	userID := c.GetUint("user_id")
+
+	var details models.EmploymentDetails
+	if err := db.Where("employee_id = ?", userID).Limit(1).Find(&details).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch team")
+		return
+	}
+	response := TeamResponse{}
+	if details.ManagerID != nil {
+		managers, err := reportingLineEmployees(db, db.Where("id = ?", *details.ManagerID))
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch team")
+			return
+		}
+		if len(managers) > 0 {
+			response.Manager = &managers[0]
+		}
+	}
+	reports, err := loadDirectReports(db, userID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch team")
+		return
+	}
+	response.DirectReports = reports
+	c.JSON(http.StatusOK, response)
+}
+
+// GetReportingLineHistory lists the changes of who an employee reports to
+// @Summary Get reporting line history
+// @Description List the changes of an employee's manager recorded in their employment history, newest first, with the names of the previous and new manager. Employees can only see their own history, managers and admins anyone's
+// @Tags Core HR - Reporting Lines
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} ReportingLineChange
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/reporting-line/history [get]
+func GetReportingLineHistory(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	db := requestDB(c)
+	var history []models.EmploymentHistory
+	if err := db.Where("employee_id = ? AND previous_manager_id IS DISTINCT FROM new_manager_id", employeeID).
+		Order("change_date DESC, id DESC").Find(&history).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch reporting line history")
+		return
+	}
+
+	// Former managers may since have been deleted, so their names are looked up among deleted employees too
+	ids := []uint{}
+	for _, entry := range history {
+		for _, id := range []*uint{entry.PreviousManagerID, entry.NewManagerID, entry.ChangedBy} {
+			if id != nil {
+				ids = append(ids, *id)
+			}
+		}
+	}
+	names := map[uint]string{}
+	if len(ids) > 0 {
+		var people []models.Employee
+		if err := db.Unscoped().Select("id", "firstname", "lastname").Where("id IN ?", ids).Find(&people).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch reporting line history")
+			return
+		}
+		for _, person := range people {
+			names[person.ID] = strings.TrimSpace(person.Firstname + " " + person.Lastname)
+		}
+	}
+	name := func(id *uint) string {
+		if id == nil {
+			return ""
+		}
+		return names[*id]
+	}
+
+	changes := make([]ReportingLineChange, len(history))
+	for i, entry := range history {
+		changes[i] = ReportingLineChange{
+			ID:                  entry.ID,
+			ChangeDate:          entry.ChangeDate,
+			PreviousManagerID:   entry.PreviousManagerID,
+			PreviousManagerName: name(entry.PreviousManagerID),
+			NewManagerID:        entry.NewManagerID,
+			NewManagerName:      name(entry.NewManagerID),
+			Reason:              entry.ChangeReason,
+			ChangedBy:           entry.ChangedBy,
+			ChangedByName:       name(entry.ChangedBy),
+			CreatedAt:           entry.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// loadDirectReports returns the current direct reports of managerID who are not inactive, by name
+func loadDirectReports(db *gorm.DB, managerID uint) ([]ReportingLineEmployee, error) {
+	return reportingLineEmployees(db, db.Where("id IN (?) AND status <> ?", directReports(db, managerID), "inactive"))
+}
+
+// reportingLineEmployees returns the employees query finds, by name, with their position, employment
+// details and number of direct reports
+func reportingLineEmployees(db *gorm.DB, query *gorm.DB) ([]ReportingLineEmployee, error) {
+	var employees []models.Employee
+	if err := query.Order("firstname, lastname").Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	result := make([]ReportingLineEmployee, len(employees))
+	if len(employees) == 0 {
+		return result, nil
+	}
+	ids := make([]uint, len(employees))
+	for i, employee := range employees {
+		ids[i] = employee.ID
+	}
+
+	var details []models.EmploymentDetails
+	if err := db.Where("employee_id IN ?", ids).Find(&details).Error; err != nil {
+		return nil, err
+	}
+	detailsByEmployee := map[uint]models.EmploymentDetails{}
+	for _, d := range details {
+		detailsByEmployee[d.EmployeeID] = d
+	}
+
+	var assignments []models.PositionAssignment
+	if err := db.Preload("Position").Where("employee_id IN ? AND is_primary = ? AND end_date IS NULL", ids, true).
+		Order("start_date").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	titles := map[uint]string{}
+	for _, assignment := range assignments {
+		titles[assignment.EmployeeID] = assignment.Position.Title // The latest primary assignment wins
+	}
+
+	var counts []struct {
+		ManagerID uint
+		Count     int
+	}
+	err := db.Model(&models.EmploymentDetails{}).Select("employment_details.manager_id, COUNT(*) AS count").
+		Joins("JOIN employees ON employees.id = employment_details.employee_id AND employees.deleted_at IS NULL").
+		Where("employment_details.manager_id IN ? AND employment_details.employment_status NOT IN ? AND employees.status <> ?",
+			ids, []models.EmploymentStatus{models.EmploymentStatusTerminated, models.EmploymentStatusResigned}, "inactive").
+		Group("employment_details.manager_id").Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	reportCounts := map[uint]int{}
+	for _, count := range counts {
+		reportCounts[count.ManagerID] = count.Count
+	}
+
+	for i, employee := range employees {
+		entry := ReportingLineEmployee{
+			ID:                employee.ID,
+			Firstname:         employee.Firstname,
+			Lastname:          employee.Lastname,
+			Email:             employee.Email,
+			Department:        employee.Department,
+			JobTitle:          titles[employee.ID],
+			DirectReportCount: reportCounts[employee.ID],
+		}
+		if entry.JobTitle == "" && employee.JobTitle != nil {
+			entry.JobTitle = *employee.JobTitle
+		}
+		if d, ok := detailsByEmployee[employee.ID]; ok {
+			entry.EmploymentType = d.EmploymentType
+			entry.HireDate = d.HireDate
+		}
+		result[i] = entry
+	}
+	return result, nil
+}
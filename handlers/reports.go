@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/utils"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LeaveUtilizationReport is leave taken by month
+type LeaveUtilizationReport struct {
+	From   string                        `json:"from" example:"2024-04"`
+	To     string                        `json:"to" example:"2025-03"`
+	Months []utils.LeaveUtilizationMonth `json:"months"`
+}
+
+// AbsenceByTypeReport is absence over a period by type
+type AbsenceByTypeReport struct {
+	From string `json:"from" example:"2024-04"`
+	To   string `json:"to" example:"2025-03"`
+	utils.AbsenceReport
+}
+
+// AbsenceRateReport is absence rates by department and month
+type AbsenceRateReport struct {
+	From string `json:"from" example:"2024-04"`
+	To   string `json:"to" example:"2025-03"`
+	utils.AbsenceRates
+}
+
+// BalanceLiabilityReport is the leave owed to employees
+type BalanceLiabilityReport struct {
+	AsOf        string                      `json:"as_of" example:"2025-03-14"`
+	Days        float64                     `json:"days" example:"1043.5"`
+	LeaveTypes  []utils.LeaveTypeLiability  `json:"leave_types"`
+	Departments []utils.DepartmentLiability `json:"departments"`
+}
+
+// GetLeaveUtilizationReport reports leave taken by month
+// @Summary Get leave utilization report
+// @Description Days of approved leave taken each month, in total and by leave type, with the number of employees on leave, month-end headcount and days taken per employee. A leave spanning months counts towards each month by its days in that month. Admin accounts are excluded. Defaults to the last 12 months (HR/Admin only)
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Success 200 {object} LeaveUtilizationReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/reports/leave-utilization [get]
+func GetLeaveUtilizationReport(c *gin.Context) {
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return
+	}
+	months, err := utils.LeaveUtilizationByMonth(requestDB(c), members, from, to)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate report")
+		return
+	}
+
+	c.JSON(http.StatusOK, LeaveUtilizationReport{From: from.Format("2006-01"), To: to.Format("2006-01"), Months: months})
+}
+
+// GetAbsenceByTypeReport reports absence over a period by type
+// @Summary Get absence by type report
+// @Description Days absent over the months given, by leave type, overall and by department, with each type's share of all days absent and the employees and leaves behind it. Days marked absent by attendance without leave are reported as "Unauthorized absence". Admin accounts are excluded. Defaults to the last 12 months (HR/Admin only)
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Success 200 {object} AbsenceByTypeReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/reports/absence-by-type [get]
+func GetAbsenceByTypeReport(c *gin.Context) {
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return
+	}
+	report, err := utils.AbsenceByType(requestDB(c), members, from, to.AddDate(0, 1, -1))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate report")
+		return
+	}
+
+	c.JSON(http.StatusOK, AbsenceByTypeReport{From: from.Format("2006-01"), To: to.Format("2006-01"), AbsenceReport: report})
+}
+
+// GetAbsenceRateReport reports absence rates by department and month
+// @Summary Get absence rate report
+// @Description Working days lost to absence as a percentage of the working days available, over the months given and for each month, overall and by department, split into planned and unplanned absence. Working days are the days of the default work schedule that are not public holidays, for each employee in post. Leave is unplanned when its leave type is, and days marked absent by attendance without leave are unplanned. Departments whose rate or unplanned rate is above the target are flagged; the targets default to the absence_rate_target and unplanned_absence_rate_target settings. Admin accounts are excluded. Defaults to the last 12 months (HR/Admin only)
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Param target query number false "Absence rate to flag departments above, in percent, 0 for none"
+// @Param unplanned_target query number false "Unplanned absence rate to flag departments above, in percent, 0 for none"
+// @Success 200 {object} AbsenceRateReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/reports/absence-rate [get]
+func GetAbsenceRateReport(c *gin.Context) {
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+	settings := utils.CurrentSettings()
+	targets := utils.AbsenceRateTargets{Rate: settings.AbsenceRateTarget, UnplannedRate: settings.UnplannedAbsenceTarget}
+	for param, target := range map[string]*float64{"target": &targets.Rate, "unplanned_target": &targets.UnplannedRate} {
+		if value := c.Query(param); value != "" {
+			percent, err := strconv.ParseFloat(value, 64)
+			if err != nil || percent < 0 || percent > 100 {
+				utils.RespondError(c, http.StatusBadRequest, "Invalid target. Use a percentage between 0 and 100")
+				return
+			}
+			*target = percent
+		}
+	}
+
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return
+	}
+	rates, err := utils.ComputeAbsenceRates(requestDB(c), members, from, to, targets)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate report")
+		return
+	}
+
+	c.JSON(http.StatusOK, AbsenceRateReport{From: from.Format("2006-01"), To: to.Format("2006-01"), AbsenceRates: rates})
+}
+
+// GetBalanceLiabilityReport reports the leave owed to employees
+// @Summary Get leave balance liability report
+// @Description Days of leave owed to current employees today, for each leave type that keeps a balance, overall and by department. Overdrawn balances are reported separately rather than netted off. Balances are brought up to date first (HR/Admin only)
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Filter by department"
+// @Success 200 {object} BalanceLiabilityReport
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/reports/balance-liability [get]
+func GetBalanceLiabilityReport(c *gin.Context) {
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return
+	}
+	leaveTypes, departments, err := utils.BalanceLiability(requestDB(c), members)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate report")
+		return
+	}
+
+	report := BalanceLiabilityReport{AsOf: utils.CompanyToday().Format("2006-01-02"), LeaveTypes: leaveTypes, Departments: departments}
+	for _, liability := range leaveTypes {
+		report.Days += liability.Days
+	}
+	report.Days = math.Round(report.Days*100) / 100
+	c.JSON(http.StatusOK, report)
+}
+
+// GetHeadcountReport reports the headcount by department and employment type
+// @Summary Get headcount report
+// @Description Headcount today and at the end of each month given, overall and by department, split by employment type, with the budgeted and filled seats of each department's active positions and the vacancies between them. Seats are budgeted by this year's headcount budget for the position, else its headcount. Employees are counted under their current employment type, "unspecified" when they have none. Admin accounts are excluded. Defaults to the last 12 months (HR/Admin only)
+// @Tags Reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Success 200 {object} utils.HeadcountReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/reports/headcount [get]
+func GetHeadcountReport(c *gin.Context) {
+	report, ok := headcountReport(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportHeadcountReport exports the headcount report to Excel
+// @Summary Export headcount report
+// @Description Export the headcount report to Excel for management packs: a sheet of today's headcount and vacancies by department and employment type, one of the month-end headcount by employment type, and one of the month-end headcount by department, a column per month (HR/Admin only)
+// @Tags Reports
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM)"
+// @Param to query string false "Last month (YYYY-MM)"
+// @Param department query string false "Filter by department"
+// @Param lang query string false "Language of the file: en, fr or pt (default: the Accept-Language header)"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/hr/reports/headcount/export [get]
+func ExportHeadcountReport(c *gin.Context) {
+	locale, ok := exportLocale(c)
+	if !ok {
+		return
+	}
+	report, ok := headcountReport(c)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("headcount_%s_%s.xlsx", report.From, report.To)
+	streamDownload(c, filename, utils.XLSXContentType, "Failed to generate export file", func(w io.Writer) error {
+		return utils.ExportHeadcountReportToExcel(w, report, locale)
+	})
+}
+
+// headcountReport builds the headcount report for the period and department asked for
+func headcountReport(c *gin.Context) (utils.HeadcountReport, bool) {
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return utils.HeadcountReport{}, false
+	}
+
+	members, err := utils.LoadWorkforce(requestDB(c), c.Query("department"))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load workforce data")
+		return utils.HeadcountReport{}, false
+	}
+	report, err := utils.BuildHeadcountReport(requestDB(c), members, c.Query("department"), from, to)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate report")
+		return utils.HeadcountReport{}, false
+	}
+	return report, true
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionPolicyRequest represents data for setting a retention policy
+type RetentionPolicyRequest struct {
+	RetentionDays int   `json:"retention_days" binding:"required,min=1" example:"2555"` // Records older than this many days are purged
+	Enabled       *bool `json:"enabled,omitempty" example:"true"`                       // Defaults to true
+}
+
+// LegalHoldRequest represents data for placing an employee's records under legal hold
+type LegalHoldRequest struct {
+	EmployeeID uint   `json:"employee_id" binding:"required" example:"12"`
+	Reason     string `json:"reason" binding:"required" example:"Pending employment tribunal case"`
+}
+
+// GetRetentionPolicies lists the retention policy of every data category
+// @Summary Get retention policies
+// @Description List the retention policy of every data category (audit_logs, ex_employee_documents, leave_history, login_logs). Categories without a policy are listed disabled with retention_days 0 and are never purged (Admin only)
+// @Tags Admin - Data Retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.RetentionPolicy
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/retention-policies [get]
+func GetRetentionPolicies(c *gin.Context) {
+	var policies []models.RetentionPolicy
+	if err := requestDB(c).Find(&policies).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch retention policies")
+		return
+	}
+	byCategory := make(map[models.RetentionCategory]models.RetentionPolicy, len(policies))
+	for _, policy := range policies {
+		byCategory[policy.Category] = policy
+	}
+
+	response := make([]models.RetentionPolicy, 0, len(models.RetentionCategories))
+	for _, category := range models.RetentionCategories {
+		policy, ok := byCategory[category]
+		if !ok {
+			policy = models.RetentionPolicy{OrganizationID: c.GetUint("organization_id"), Category: category}
+		}
+		response = append(response, policy)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateRetentionPolicy sets the retention policy of a data category
+// @Summary Set retention policy
+// @Description Set how many days the records of a data category are kept. audit_logs purges audit entries by age, login_logs sign-in attempts, leave_history leave requests (with their approval trail and forms) and recorded leave taken by end date, and ex_employee_documents the documents of former employees by the date they left. Records of employees under legal hold are never purged. Check GET /api/admin/retention-policies/report before enabling a policy (Admin only)
+// @Tags Admin - Data Retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category path string true "Data category"
+// @Param request body RetentionPolicyRequest true "Retention policy"
+// @Success 200 {object} models.RetentionPolicy
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/retention-policies/{category} [put]
+func UpdateRetentionPolicy(c *gin.Context) {
+	category := models.RetentionCategory(c.Param("category"))
+	known := false
+	for _, candidate := range models.RetentionCategories {
+		known = known || candidate == category
+	}
+	if !known {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid retention category")
+		return
+	}
+
+	var req RetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	var policy models.RetentionPolicy
+	action := models.AuditActionUpdate
+	if err := requestDB(c).Where("category = ?", category).First(&policy).Error; err != nil {
+		policy = models.RetentionPolicy{Category: category}
+		action = models.AuditActionCreate
+	}
+	oldPolicy := policy
+
+	policy.RetentionDays = req.RetentionDays
+	policy.Enabled = req.Enabled == nil || *req.Enabled
+	policy.UpdatedBy = &userID
+	if err := requestDB(c).Save(&policy).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save retention policy")
+		return
+	}
+
+	if action == models.AuditActionCreate {
+		createAuditLog(models.AuditEntityRetention, policy.ID, action, userID, c, nil, policy)
+	} else {
+		createAuditLog(models.AuditEntityRetention, policy.ID, action, userID, c, oldPolicy, policy)
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// GetRetentionReport reports what the retention policies would purge
+// @Summary Get retention report
+// @Description Dry run of the enabled retention policies: how many records and files each would purge now, and how many are kept because of a legal hold. Nothing is deleted (Admin only)
+// @Tags Admin - Data Retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} utils.RetentionResult
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/retention-policies/report [get]
+func GetRetentionReport(c *gin.Context) {
+	results, err := utils.ApplyRetentionPolicies(requestDB(c), true)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch retention policies")
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// RunRetentionPolicies purges the records past their retention policy now
+// @Summary Run retention policies
+// @Description Purge the records past the enabled retention policies now instead of waiting for the daily run at 03:30. Purged records and files cannot be recovered (Admin only)
+// @Tags Admin - Data Retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} utils.RetentionResult
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/retention-policies/run [post]
+func RunRetentionPolicies(c *gin.Context) {
+	results, err := utils.ApplyRetentionPolicies(requestDB(c), false)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch retention policies")
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetLegalHolds lists legal holds
+// @Summary Get legal holds
+// @Description List legal holds, newest first. Only active holds are listed unless include_released is true (Admin only)
+// @Tags Admin - Data Retention
+// @Produce json
+// @Security BearerAuth
+// @Param include_released query bool false "Include released holds"
+// @Success 200 {array} models.LegalHold
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/legal-holds [get]
+func GetLegalHolds(c *gin.Context) {
+	query := requestDB(c).Preload("Employee").Order("created_at DESC")
+	if c.Query("include_released") != "true" {
+		query = query.Where("released_at IS NULL")
+	}
+	var holds []models.LegalHold
+	if err := query.Find(&holds).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch legal holds")
+		return
+	}
+
+	c.JSON(http.StatusOK, holds)
+}
+
+// CreateLegalHold places an employee's records under legal hold
+// @Summary Create legal hold
+// @Description Exempt all of an employee's records from retention purges, e.g. while litigation or an investigation is pending, until the hold is released (Admin only)
+// @Tags Admin - Data Retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body LegalHoldRequest true "Legal hold"
+// @Success 201 {object} models.LegalHold
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/legal-holds [post]
+func CreateLegalHold(c *gin.Context) {
+	var req LegalHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).Unscoped().First(&employee, req.EmployeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	hold := models.LegalHold{EmployeeID: employee.ID, Reason: req.Reason, PlacedBy: userID}
+	if err := requestDB(c).Create(&hold).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create legal hold")
+		return
+	}
+
+	createAuditLog(models.AuditEntityLegalHold, hold.ID, models.AuditActionCreate, userID, c, nil, hold)
+
+	c.JSON(http.StatusCreated, hold)
+}
+
+// ReleaseLegalHold releases a legal hold
+// @Summary Release legal hold
+// @Description Release a legal hold, so the employee's records are purged again by the retention policies unless another hold is active (Admin only)
+// @Tags Admin - Data Retention
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Legal hold ID"
+// @Success 200 {object} models.LegalHold
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Already released"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/legal-holds/{id}/release [post]
+func ReleaseLegalHold(c *gin.Context) {
+	holdID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var hold models.LegalHold
+	if err := requestDB(c).First(&hold, holdID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Legal hold not found")
+		return
+	}
+	if hold.ReleasedAt != nil {
+		utils.RespondError(c, http.StatusConflict, "Legal hold has already been released")
+		return
+	}
+	oldHold := hold
+
+	now := time.Now()
+	userID := c.GetUint("user_id")
+	hold.ReleasedAt = &now
+	hold.ReleasedBy = &userID
+	if err := requestDB(c).Save(&hold).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to release legal hold")
+		return
+	}
+
+	createAuditLog(models.AuditEntityLegalHold, hold.ID, models.AuditActionUpdate, userID, c, oldHold, hold)
+
+	c.JSON(http.StatusOK, hold)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"hrms-api/models"
+	"hrms-api/scheduler"
+	"hrms-api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetScheduledJobs lists the background jobs
+// @Summary Get scheduled jobs
+// @Description List the background jobs, such as monthly accruals, with their schedule, next run on this server, whether they are paused, and when and how their last run on any server went (Admins of the default organization only)
+// @Tags Admin - Scheduled Jobs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} scheduler.JobStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/scheduled-jobs [get]
+func GetScheduledJobs(c *gin.Context) {
+	if !requireJobAccess(c) {
+		return
+	}
+
+	jobs, err := scheduler.Jobs()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch scheduled jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetScheduledJob gets a background job
+// @Summary Get scheduled job
+// @Description Get a background job with its schedule and last run, e.g. to follow a run started by hand (Admins of the default organization only)
+// @Tags Admin - Scheduled Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Job name"
+// @Success 200 {object} scheduler.JobStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/scheduled-jobs/{name} [get]
+func GetScheduledJob(c *gin.Context) {
+	if !requireJobAccess(c) {
+		return
+	}
+
+	job, err := scheduler.Job(c.Param("name"))
+	if err != nil {
+		respondScheduledJobError(c, err, "Failed to fetch scheduled job")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// RunScheduledJob runs a background job now
+// @Summary Run scheduled job
+// @Description Start a run of a background job on this server now, in the background, even when the job is paused. Follow it with GET /api/admin/scheduled-jobs/{name} (Admins of the default organization only)
+// @Tags Admin - Scheduled Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Job name"
+// @Success 202 {object} scheduler.JobStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The job is already running on this server"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/scheduled-jobs/{name}/run [post]
+func RunScheduledJob(c *gin.Context) {
+	if !requireJobAccess(c) {
+		return
+	}
+
+	if err := scheduler.RunJob(c.Param("name")); err != nil {
+		respondScheduledJobError(c, err, "Failed to start scheduled job")
+		return
+	}
+	job, err := scheduler.Job(c.Param("name"))
+	if err != nil {
+		respondScheduledJobError(c, err, "Failed to fetch scheduled job")
+		return
+	}
+	createAuditLog(models.AuditEntityScheduledJob, job.ID, models.AuditActionRun, c.GetUint("user_id"), c, nil, job.ScheduledJob)
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// PauseScheduledJob pauses a background job
+// @Summary Pause scheduled job
+// @Description Stop a background job from running on its schedule or at startup, on every server, until it is resumed. A run in progress finishes, and the job can still be run by hand (Admins of the default organization only)
+// @Tags Admin - Scheduled Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Job name"
+// @Success 200 {object} scheduler.JobStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/scheduled-jobs/{name}/pause [post]
+func PauseScheduledJob(c *gin.Context) {
+	setScheduledJobPaused(c, true)
+}
+
+// ResumeScheduledJob resumes a paused background job
+// @Summary Resume scheduled job
+// @Description Let a paused background job run on its schedule again, on every server (Admins of the default organization only)
+// @Tags Admin - Scheduled Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Job name"
+// @Success 200 {object} scheduler.JobStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/scheduled-jobs/{name}/resume [post]
+func ResumeScheduledJob(c *gin.Context) {
+	setScheduledJobPaused(c, false)
+}
+
+func setScheduledJobPaused(c *gin.Context, paused bool) {
+	if !requireJobAccess(c) {
+		return
+	}
+
+	old, err := scheduler.Job(c.Param("name"))
+	if err != nil {
+		respondScheduledJobError(c, err, "Failed to fetch scheduled job")
+		return
+	}
+	userID := c.GetUint("user_id")
+	job, err := scheduler.SetJobPaused(c.Param("name"), paused, userID)
+	if err != nil {
+		respondScheduledJobError(c, err, "Failed to save scheduled job")
+		return
+	}
+	if old.Paused != paused {
+		createAuditLog(models.AuditEntityScheduledJob, job.ID, models.AuditActionUpdate, userID, c, old.ScheduledJob, job.ScheduledJob)
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// requireJobAccess allows admins of the default organization only, as the jobs work on every organization
+func requireJobAccess(c *gin.Context) bool {
+	if c.GetUint("organization_id") != models.DefaultOrganizationID {
+		utils.RespondError(c, http.StatusForbidden, "Only admins of the default organization can manage scheduled jobs")
+		return false
+	}
+	return true
+}
+
+func respondScheduledJobError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		utils.RespondError(c, http.StatusNotFound, "Scheduled job not found")
+	case errors.Is(err, scheduler.ErrJobRunning):
+		utils.RespondError(c, http.StatusConflict, "The job is already running on this server")
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, message)
+	}
+}
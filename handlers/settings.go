@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SettingRequest represents a new value for a runtime setting
+type SettingRequest struct {
+	Value json.RawMessage `json:"value" binding:"required" swaggertype:"object"` // Number, boolean or list, depending on the setting
+}
+
+// SettingResponse represents a runtime setting with its current value
+type SettingResponse struct {
+	utils.SettingDefinition
+	Value     json.RawMessage `json:"value" swaggertype:"object"`
+	IsDefault bool            `json:"is_default" example:"false"`
+	UpdatedBy *uint           `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time      `json:"updated_at,omitempty"`
+}
+
+// newSettingResponse describes a setting with its stored value, or its default when nil
+func newSettingResponse(definition utils.SettingDefinition, stored *models.Setting) SettingResponse {
+	response := SettingResponse{SettingDefinition: definition, IsDefault: stored == nil}
+	if stored == nil {
+		response.Value, _ = json.Marshal(definition.Default)
+		return response
+	}
+	response.Value = json.RawMessage(stored.Value)
+	response.UpdatedBy = stored.UpdatedBy
+	response.UpdatedAt = &stored.UpdatedAt
+	return response
+}
+
+// reloadSettings applies a settings change on this server straight away; the others pick it up
+// within seconds
+func reloadSettings() {
+	if err := utils.LoadSettings(); err != nil {
+		log.Printf("Failed to reload settings: %v", err)
+	}
+}
+
+// GetSettings lists the runtime settings
+// @Summary Get runtime settings
+// @Description List the settings that can be changed without a restart, with their current value and default. They apply to the whole installation (Admin only)
+// @Tags Admin - Settings
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} SettingResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/settings [get]
+func GetSettings(c *gin.Context) {
+	var stored []models.Setting
+	if err := requestDB(c).Find(&stored).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch settings")
+		return
+	}
+	byKey := make(map[string]*models.Setting, len(stored))
+	for i := range stored {
+		byKey[stored[i].Key] = &stored[i]
+	}
+
+	response := make([]SettingResponse, 0, len(utils.SettingDefinitions))
+	for _, definition := range utils.SettingDefinitions {
+		response = append(response, newSettingResponse(definition, byKey[definition.Key]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateSetting changes a runtime setting
+// @Summary Update runtime setting
+// @Description Change a setting for the whole installation. It takes effect on this server immediately and on other servers sharing the database within 15 seconds, without a restart: annual_leave_days_per_month for accruals processed from then on, email_notifications_enabled and muted_email_categories for notifications sent from then on, cors_allowed_origins for the next browser request, and the company_* settings for PDFs and exports generated from then on (Admin only)
+// @Tags Admin - Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Setting key"
+// @Param request body SettingRequest true "New value"
+// @Success 200 {object} SettingResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/settings/{key} [put]
+func UpdateSetting(c *gin.Context) {
+	definition, ok := utils.FindSettingDefinition(c.Param("key"))
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, "Setting not found")
+		return
+	}
+
+	var req SettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if err := utils.ValidateSetting(definition, req.Value); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, i18n.T(utils.RequestLanguage(c), "Invalid value for setting %s: %s", definition.Key, err.Error()))
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	var setting models.Setting
+	action := models.AuditActionUpdate
+	if err := requestDB(c).Where(&models.Setting{Key: definition.Key}).First(&setting).Error; err != nil {
+		setting = models.Setting{Key: definition.Key}
+		action = models.AuditActionCreate
+	}
+	oldSetting := setting
+
+	setting.Value = string(req.Value)
+	setting.UpdatedBy = &userID
+	if err := requestDB(c).Save(&setting).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to save setting")
+		return
+	}
+	reloadSettings()
+
+	if action == models.AuditActionCreate {
+		createAuditLog(models.AuditEntitySetting, setting.ID, action, userID, c, nil, setting)
+	} else {
+		createAuditLog(models.AuditEntitySetting, setting.ID, action, userID, c, oldSetting, setting)
+	}
+
+	c.JSON(http.StatusOK, newSettingResponse(*definition, &setting))
+}
+
+// ResetSetting restores the default of a runtime setting
+// @Summary Reset runtime setting
+// @Description Restore a setting to its default, taking effect like an update (Admin only)
+// @Tags Admin - Settings
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Setting key"
+// @Success 200 {object} SettingResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/settings/{key} [delete]
+func ResetSetting(c *gin.Context) {
+	definition, ok := utils.FindSettingDefinition(c.Param("key"))
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, "Setting not found")
+		return
+	}
+
+	var setting models.Setting
+	if err := requestDB(c).Where(&models.Setting{Key: definition.Key}).First(&setting).Error; err == nil {
+		if err := requestDB(c).Delete(&setting).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to save setting")
+			return
+		}
+		reloadSettings()
+		createAuditLog(models.AuditEntitySetting, setting.ID, models.AuditActionDelete, c.GetUint("user_id"), c, setting, nil)
+	}
+
+	c.JSON(http.StatusOK, newSettingResponse(*definition, nil))
+}
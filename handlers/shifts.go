@@ -0,0 +1,774 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateShiftRequest represents data for defining a shift
+type CreateShiftRequest struct {
+	Name         string  `json:"name" binding:"required" example:"Night"`
+	StartTime    string  `json:"start_time" binding:"required" example:"22:00"`
+	EndTime      string  `json:"end_time" binding:"required" example:"06:00"`
+	BreakMinutes int     `json:"break_minutes" example:"30"`
+	GraceMinutes int     `json:"grace_minutes" example:"10"`
+	Department   *string `json:"department,omitempty" example:"Operations"`
+}
+
+// AssignShiftRequest represents rostering an employee onto a shift for one or more days
+type AssignShiftRequest struct {
+	EmployeeID          uint     `json:"employee_id" binding:"required" example:"12"`
+	ShiftID             uint     `json:"shift_id" binding:"required" example:"3"`
+	Dates               []string `json:"dates" binding:"required,min=1" example:"2025-03-17,2025-03-18"`
+	Notes               *string  `json:"notes,omitempty" example:"Covering stock take"`
+	AllowLeaveConflicts bool     `json:"allow_leave_conflicts" example:"false"` // Roster days that clash with pending or approved leave instead of skipping them
+}
+
+// ShiftLeaveConflict describes a rostered day that clashes with leave
+type ShiftLeaveConflict struct {
+	EmployeeID   uint   `json:"employee_id" example:"12"`
+	EmployeeName string `json:"employee_name" example:"Jane Doe"`
+	Date         string `json:"date" example:"2025-03-18"`
+	AssignmentID *uint  `json:"assignment_id,omitempty" example:"41"`
+	LeaveID      uint   `json:"leave_id" example:"7"`
+	LeaveType    string `json:"leave_type" example:"Annual"`
+	LeaveStatus  string `json:"leave_status" example:"Approved"`
+}
+
+// AssignShiftResponse reports the days rostered and those skipped because of leave
+type AssignShiftResponse struct {
+	Assignments []models.ShiftAssignment `json:"assignments"`
+	Conflicts   []ShiftLeaveConflict     `json:"conflicts,omitempty"`
+}
+
+// CreateShiftSwapRequest represents a request to swap or hand over a rostered shift
+type CreateShiftSwapRequest struct {
+	AssignmentID       uint    `json:"assignment_id" binding:"required" example:"41"`
+	TargetAssignmentID *uint   `json:"target_assignment_id,omitempty" example:"52"` // The colleague's shift to take in exchange
+	TargetEmployeeID   *uint   `json:"target_employee_id,omitempty" example:"15"`   // Required when handing the shift over without taking one back
+	Reason             *string `json:"reason,omitempty" example:"Family commitment"`
+}
+
+// ReviewShiftSwapRequest represents an optional comment when reviewing a shift swap
+type ReviewShiftSwapRequest struct {
+	Comment *string `json:"comment,omitempty" example:"Both employees are trained for the night shift"`
+}
+
+// GetShifts lists shift definitions
+// @Summary Get shifts
+// @Description List active shift definitions, optionally filtered by department
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Department filter"
+// @Success 200 {array} models.Shift
+// @Failure 401 {object} ErrorResponse
+// @Router /api/shifts [get]
+func GetShifts(c *gin.Context) {
+	query := requestDB(c).Where("is_active = ?", true)
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ? OR department IS NULL", department)
+	}
+
+	var shifts []models.Shift
+	query.Order("start_time").Find(&shifts)
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// CreateShift defines a new shift
+// @Summary Create shift
+// @Description Define a shift. An end time earlier than the start time makes an overnight shift (Manager/Admin only)
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateShiftRequest true "Shift"
+// @Success 201 {object} models.Shift
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts [post]
+func CreateShift(c *gin.Context) {
+	var req CreateShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	start, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_time format. Use HH:MM")
+		return
+	}
+	end, err := time.Parse("15:04", req.EndTime)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_time format. Use HH:MM")
+		return
+	}
+	if start.Equal(end) {
+		utils.RespondError(c, http.StatusBadRequest, "start_time and end_time cannot be the same")
+		return
+	}
+
+	shift := models.Shift{
+		Name:         req.Name,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		BreakMinutes: req.BreakMinutes,
+		GraceMinutes: req.GraceMinutes,
+		Department:   req.Department,
+		IsActive:     true,
+	}
+	if err := requestDB(c).Create(&shift).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create shift")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityShift, shift.ID, models.AuditActionCreate, userID.(uint), c, nil, shift)
+
+	c.JSON(http.StatusCreated, shift)
+}
+
+// GetRota returns shift assignments for a date range
+// @Summary Get rota
+// @Description Get shift assignments for a date range, optionally filtered by department, employee or shift. Defaults to the next 7 days (Manager/Admin only)
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param department query string false "Department filter"
+// @Param employee_id query int false "Employee ID"
+// @Param shift_id query int false "Shift ID"
+// @Success 200 {array} models.ShiftAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/shifts/rota [get]
+func GetRota(c *gin.Context) {
+	from, to, ok := parseRotaRange(c, 7)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").Preload("Shift").
+		Where("shift_assignments.date >= ? AND shift_assignments.date <= ?", from, to)
+	if department := c.Query("department"); department != "" {
+		query = query.Joins("JOIN employees ON employees.id = shift_assignments.employee_id").
+			Where("employees.department = ?", department)
+	}
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		query = query.Where("shift_assignments.employee_id = ?", employeeID)
+	}
+	if shiftID := c.Query("shift_id"); shiftID != "" {
+		query = query.Where("shift_assignments.shift_id = ?", shiftID)
+	}
+
+	var assignments []models.ShiftAssignment
+	query.Order("shift_assignments.date, shift_assignments.employee_id").Find(&assignments)
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// GetMySchedule returns the current user's rostered shifts
+// @Summary Get my schedule
+// @Description Get the current user's rostered shifts for a date range. Defaults to the next 14 days
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} models.ShiftAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/shifts/me [get]
+func GetMySchedule(c *gin.Context) {
+	from, to, ok := parseRotaRange(c, 14)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	var assignments []models.ShiftAssignment
+	requestDB(c).Preload("Shift").
+		Where("employee_id = ? AND date >= ? AND date <= ?", userID, from, to).
+		Order("date").Find(&assignments)
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// AssignShift rosters an employee onto a shift for one or more days
+// @Summary Assign shift
+// @Description Roster an employee onto a shift for the given days, replacing any shift already rostered on those days. Days that clash with pending or approved leave are skipped and reported unless allow_leave_conflicts is set (Manager/Admin only)
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body AssignShiftRequest true "Assignment"
+// @Success 200 {object} AssignShiftResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts/assignments [post]
+func AssignShift(c *gin.Context) {
+	var req AssignShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, req.EmployeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	var shift models.Shift
+	if err := requestDB(c).First(&shift, req.ShiftID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Shift not found")
+		return
+	}
+	if !shift.IsActive {
+		utils.RespondError(c, http.StatusBadRequest, "Cannot assign an inactive shift")
+		return
+	}
+
+	dates := make([]time.Time, 0, len(req.Dates))
+	for _, dateStr := range req.Dates {
+		date, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD: "+dateStr)
+			return
+		}
+		dates = append(dates, date)
+	}
+
+	userID, _ := c.Get("user_id")
+	assignedBy := userID.(uint)
+	response := AssignShiftResponse{Assignments: []models.ShiftAssignment{}}
+
+	for _, date := range dates {
+		if leave := utils.LeaveConflictOn(employee.ID, date); leave != nil {
+			response.Conflicts = append(response.Conflicts, newShiftLeaveConflict(employee, date, nil, *leave))
+			if !req.AllowLeaveConflicts {
+				continue
+			}
+		}
+
+		var assignment models.ShiftAssignment
+		err := requestDB(c).Where("employee_id = ? AND date = ?", employee.ID, date).First(&assignment).Error
+		isNew := err != nil
+		oldValues := assignment
+
+		assignment.EmployeeID = employee.ID
+		assignment.ShiftID = shift.ID
+		assignment.Date = date
+		assignment.Notes = req.Notes
+		assignment.AssignedBy = assignedBy
+		if err := requestDB(c).Save(&assignment).Error; err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to assign shift")
+			return
+		}
+
+		if isNew {
+			createAuditLog(models.AuditEntityShift, assignment.ID, models.AuditActionCreate, assignedBy, c, nil, assignment)
+		} else {
+			createAuditLog(models.AuditEntityShift, assignment.ID, models.AuditActionUpdate, assignedBy, c, oldValues, assignment)
+		}
+
+		assignment.Shift = shift
+		response.Assignments = append(response.Assignments, assignment)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteShiftAssignment removes a day from the rota
+// @Summary Delete shift assignment
+// @Description Remove an employee's shift from the rota (Manager/Admin only)
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts/assignments/{id} [delete]
+func DeleteShiftAssignment(c *gin.Context) {
+	assignmentID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var assignment models.ShiftAssignment
+	if err := requestDB(c).First(&assignment, assignmentID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Shift assignment not found")
+		return
+	}
+
+	var pendingSwaps int64
+	requestDB(c).Model(&models.ShiftSwapRequest{}).
+		Where("status = ? AND (requester_assignment_id = ? OR target_assignment_id = ?)", models.ShiftSwapPending, assignment.ID, assignment.ID).
+		Count(&pendingSwaps)
+	if pendingSwaps > 0 {
+		utils.RespondError(c, http.StatusBadRequest, "Shift assignment has a pending swap request")
+		return
+	}
+
+	if err := requestDB(c).Delete(&assignment).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete shift assignment")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityShift, assignment.ID, models.AuditActionDelete, userID.(uint), c, assignment, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Shift assignment deleted"})
+}
+
+// GetShiftLeaveConflicts lists rostered shifts that clash with leave
+// @Summary Get shift leave conflicts
+// @Description List rostered shifts that fall on days the employee has pending or approved leave. Defaults to the next 30 days (Manager/Admin only)
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param department query string false "Department filter"
+// @Success 200 {array} ShiftLeaveConflict
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/shifts/conflicts [get]
+func GetShiftLeaveConflicts(c *gin.Context) {
+	from, to, ok := parseRotaRange(c, 30)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").
+		Joins("JOIN leaves ON leaves.employee_id = shift_assignments.employee_id AND leaves.deleted_at IS NULL AND leaves.start_date <= shift_assignments.date AND leaves.end_date >= shift_assignments.date").
+		Where("leaves.status IN ?", []models.LeaveStatus{models.StatusPending, models.StatusApproved}).
+		Where("shift_assignments.date >= ? AND shift_assignments.date <= ?", from, to)
+	if department := c.Query("department"); department != "" {
+		query = query.Joins("JOIN employees ON employees.id = shift_assignments.employee_id").
+			Where("employees.department = ?", department)
+	}
+
+	var assignments []models.ShiftAssignment
+	query.Distinct("shift_assignments.*").Order("shift_assignments.date").Find(&assignments)
+
+	conflicts := []ShiftLeaveConflict{}
+	for _, assignment := range assignments {
+		if leave := utils.LeaveConflictOn(assignment.EmployeeID, assignment.Date); leave != nil {
+			conflicts = append(conflicts, newShiftLeaveConflict(assignment.Employee, assignment.Date, &assignment.ID, *leave))
+		}
+	}
+
+	c.JSON(http.StatusOK, conflicts)
+}
+
+// CreateShiftSwap requests to swap a rostered shift with a colleague
+// @Summary Request shift swap
+// @Description Request to swap one of your rostered shifts for a colleague's shift, or hand it over to a colleague. The swap takes effect once a manager approves it
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateShiftSwapRequest true "Swap"
+// @Success 201 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts/swaps [post]
+func CreateShiftSwap(c *gin.Context) {
+	var req CreateShiftSwapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	requesterID := userID.(uint)
+
+	var assignment models.ShiftAssignment
+	if err := requestDB(c).First(&assignment, req.AssignmentID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Shift assignment not found")
+		return
+	}
+	if assignment.EmployeeID != requesterID {
+		utils.RespondError(c, http.StatusForbidden, "You can only swap your own shifts")
+		return
+	}
+
+	swap := models.ShiftSwapRequest{
+		RequesterID:           requesterID,
+		RequesterAssignmentID: assignment.ID,
+		Reason:                req.Reason,
+		Status:                models.ShiftSwapPending,
+	}
+
+	switch {
+	case req.TargetAssignmentID != nil:
+		var target models.ShiftAssignment
+		if err := requestDB(c).First(&target, *req.TargetAssignmentID).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Target shift assignment not found")
+			return
+		}
+		if target.EmployeeID == requesterID {
+			utils.RespondError(c, http.StatusBadRequest, "Target shift must belong to another employee")
+			return
+		}
+		swap.TargetAssignmentID = &target.ID
+		swap.TargetEmployeeID = target.EmployeeID
+	case req.TargetEmployeeID != nil:
+		if *req.TargetEmployeeID == requesterID {
+			utils.RespondError(c, http.StatusBadRequest, "Target employee must be another employee")
+			return
+		}
+		var target models.Employee
+		if err := requestDB(c).First(&target, *req.TargetEmployeeID).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Target employee not found")
+			return
+		}
+		swap.TargetEmployeeID = target.ID
+	default:
+		utils.RespondError(c, http.StatusBadRequest, "Either target_assignment_id or target_employee_id is required")
+		return
+	}
+
+	var open int64
+	requestDB(c).Model(&models.ShiftSwapRequest{}).
+		Where("status = ? AND requester_assignment_id = ?", models.ShiftSwapPending, assignment.ID).
+		Count(&open)
+	if open > 0 {
+		utils.RespondError(c, http.StatusBadRequest, "A swap for this shift is already pending")
+		return
+	}
+
+	if err := requestDB(c).Create(&swap).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create shift swap request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityShift, swap.ID, models.AuditActionCreate, requesterID, c, nil, swap)
+
+	c.JSON(http.StatusCreated, swap)
+}
+
+// shiftSwapListFields are the filters and sort keys accepted by the shift swap list
+var shiftSwapListFields = ListFields{
+	Filters: map[string]string{"status": "status"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "status": "status",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetShiftSwaps lists shift swap requests
+// @Summary Get shift swaps
+// @Description List shift swap requests. Employees see swaps they requested or are the target of; admins see all
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected, cancelled)"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, status). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.ShiftSwapRequest}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/shifts/swaps [get]
+func GetShiftSwaps(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Requester").Preload("TargetEmployee").Preload("Reviewer").
+		Preload("RequesterAssignment.Shift").Preload("TargetAssignment.Shift")
+
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin {
+		if user.Role == models.RoleManager {
+			reports := requestDB(c).Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ?", user.ID)
+			query = query.Where("requester_id = ? OR target_employee_id = ? OR requester_id IN (?)", user.ID, user.ID, reports)
+		} else {
+			query = query.Where("requester_id = ? OR target_employee_id = ?", user.ID, user.ID)
+		}
+	}
+
+	query, ok = applyListQuery(c, query, shiftSwapListFields)
+	if !ok {
+		return
+	}
+
+	var swaps []models.ShiftSwapRequest
+	response, err := paginate(query, pagination, &swaps)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch shift swaps")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveShiftSwap approves a shift swap and updates the rota
+// @Summary Approve shift swap
+// @Description Approve a shift swap. The rota is updated straight away; the swap is refused if either employee has leave or another shift on the new day (Requester's manager or Admin)
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Swap request ID"
+// @Param request body ReviewShiftSwapRequest false "Review comment"
+// @Success 200 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts/swaps/{id}/approve [put]
+func ApproveShiftSwap(c *gin.Context) {
+	reviewShiftSwap(c, models.ShiftSwapApproved)
+}
+
+// RejectShiftSwap rejects a shift swap
+// @Summary Reject shift swap
+// @Description Reject a shift swap (Requester's manager or Admin)
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Swap request ID"
+// @Param request body ReviewShiftSwapRequest false "Review comment"
+// @Success 200 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts/swaps/{id}/reject [put]
+func RejectShiftSwap(c *gin.Context) {
+	reviewShiftSwap(c, models.ShiftSwapRejected)
+}
+
+// CancelShiftSwap cancels a pending shift swap
+// @Summary Cancel shift swap
+// @Description Cancel a pending shift swap you requested
+// @Tags Shifts
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Swap request ID"
+// @Success 200 {object} models.ShiftSwapRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/shifts/swaps/{id}/cancel [put]
+func CancelShiftSwap(c *gin.Context) {
+	swapID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	userID, _ := c.Get("user_id")
+
+	var swap models.ShiftSwapRequest
+	if err := requestDB(c).First(&swap, swapID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Shift swap request not found")
+		return
+	}
+	if swap.RequesterID != userID.(uint) {
+		utils.RespondError(c, http.StatusForbidden, "You can only cancel your own swap requests")
+		return
+	}
+	if swap.Status != models.ShiftSwapPending {
+		utils.RespondError(c, http.StatusBadRequest, "Only pending swap requests can be cancelled")
+		return
+	}
+
+	oldValues := swap
+	swap.Status = models.ShiftSwapCancelled
+	if err := requestDB(c).Save(&swap).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to cancel shift swap request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityShift, swap.ID, models.AuditActionCancel, swap.RequesterID, c, oldValues, swap)
+
+	c.JSON(http.StatusOK, swap)
+}
+
+func reviewShiftSwap(c *gin.Context, newStatus models.ShiftSwapStatus) {
+	swapID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req ReviewShiftSwapRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	var swap models.ShiftSwapRequest
+	if err := requestDB(c).Preload("RequesterAssignment").Preload("TargetAssignment").First(&swap, swapID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Shift swap request not found")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if user.Role != models.RoleAdmin && !managesEmployee(user.ID, swap.RequesterID) {
+		utils.RespondError(c, http.StatusForbidden, "Only the requester's manager or an admin can review this swap")
+		return
+	}
+	if swap.Status != models.ShiftSwapPending {
+		utils.RespondError(c, http.StatusBadRequest, "Shift swap request has already been reviewed")
+		return
+	}
+
+	oldValues := swap
+	tx := requestDB(c).Begin()
+
+	if newStatus == models.ShiftSwapApproved {
+		mine := swap.RequesterAssignment
+		// The target takes over the requester's day
+		if msg := shiftSwapBlocker(swap.TargetEmployeeID, mine.Date, swap.TargetAssignmentID); msg != "" {
+			tx.Rollback()
+			utils.RespondError(c, http.StatusConflict, msg)
+			return
+		}
+
+		if swap.TargetAssignment == nil {
+			if err := tx.Model(&mine).Update("employee_id", swap.TargetEmployeeID).Error; err != nil {
+				tx.Rollback()
+				utils.RespondError(c, http.StatusInternalServerError, "Failed to update rota")
+				return
+			}
+		} else {
+			theirs := *swap.TargetAssignment
+			if theirs.EmployeeID != swap.TargetEmployeeID {
+				tx.Rollback()
+				utils.RespondError(c, http.StatusConflict, "Target shift is no longer assigned to the target employee")
+				return
+			}
+			// The requester takes over the target's day
+			if msg := shiftSwapBlocker(swap.RequesterID, theirs.Date, &mine.ID); msg != "" {
+				tx.Rollback()
+				utils.RespondError(c, http.StatusConflict, msg)
+				return
+			}
+
+			// Same-day swaps exchange the shifts; otherwise the employees exchange days
+			var err error
+			if mine.Date.Equal(theirs.Date) {
+				err = tx.Model(&mine).Update("shift_id", theirs.ShiftID).Error
+				if err == nil {
+					err = tx.Model(&theirs).Update("shift_id", mine.ShiftID).Error
+				}
+			} else {
+				err = tx.Model(&mine).Update("employee_id", theirs.EmployeeID).Error
+				if err == nil {
+					err = tx.Model(&theirs).Update("employee_id", mine.EmployeeID).Error
+				}
+			}
+			if err != nil {
+				tx.Rollback()
+				utils.RespondError(c, http.StatusInternalServerError, "Failed to update rota")
+				return
+			}
+		}
+	}
+
+	now := time.Now()
+	swap.Status = newStatus
+	swap.ReviewedBy = &user.ID
+	swap.ReviewedAt = &now
+	swap.ReviewComment = req.Comment
+	if err := tx.Omit("RequesterAssignment", "TargetAssignment").Save(&swap).Error; err != nil {
+		tx.Rollback()
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to review shift swap request")
+		return
+	}
+	tx.Commit()
+
+	action := models.AuditActionReject
+	if newStatus == models.ShiftSwapApproved {
+		action = models.AuditActionApprove
+	}
+	createAuditLog(models.AuditEntityShift, swap.ID, action, user.ID, c, oldValues, swap)
+
+	c.JSON(http.StatusOK, swap)
+}
+
+// shiftSwapBlocker explains why an employee cannot take over a shift on the given day, or returns ""
+// if they can. The assignment being given up in the same swap does not count as a clash.
+func shiftSwapBlocker(employeeID uint, day time.Time, givingUp *uint) string {
+	if leave := utils.LeaveConflictOn(employeeID, day); leave != nil {
+		return "Employee " + strconv.FormatUint(uint64(employeeID), 10) + " has leave on " + day.Format("2006-01-02")
+	}
+	if existing := utils.ShiftAssignmentOn(employeeID, day); existing != nil && (givingUp == nil || existing.ID != *givingUp) {
+		return "Employee " + strconv.FormatUint(uint64(employeeID), 10) + " already has a shift on " + day.Format("2006-01-02")
+	}
+	return ""
+}
+
+// newShiftLeaveConflict describes a rostered day that clashes with leave
+func newShiftLeaveConflict(employee models.Employee, date time.Time, assignmentID *uint, leave models.Leave) ShiftLeaveConflict {
+	return ShiftLeaveConflict{
+		EmployeeID:   employee.ID,
+		EmployeeName: employee.Firstname + " " + employee.Lastname,
+		Date:         date.Format("2006-01-02"),
+		AssignmentID: assignmentID,
+		LeaveID:      leave.ID,
+		LeaveType:    leave.LeaveType.Name,
+		LeaveStatus:  string(leave.Status),
+	}
+}
+
+// parseRotaRange reads the from/to query parameters, defaulting to today and the given number of days ahead
+func parseRotaRange(c *gin.Context, defaultDays int) (time.Time, time.Time, bool) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 0, defaultDays-1)
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+		if c.Query("to") == "" {
+			to = from.AddDate(0, 0, defaultDays-1)
+		}
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, now.Location())
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		utils.RespondError(c, http.StatusBadRequest, "to must be on or after from")
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, to, true
+}
@@ -0,0 +1,635 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateSkillRequest represents data for adding a skill to the catalogue
+type CreateSkillRequest struct {
+	Name        string  `json:"name" binding:"required" example:"Go programming"`
+	Category    *string `json:"category,omitempty" example:"Software Development"`
+	Description *string `json:"description,omitempty"`
+}
+
+// CreateCertificationRequest represents data for adding a certification to the catalogue
+type CreateCertificationRequest struct {
+	Code                    string  `json:"code" binding:"required" example:"FIRST-AID"`
+	Name                    string  `json:"name" binding:"required" example:"First Aid Certificate"`
+	IssuingBody             *string `json:"issuing_body,omitempty" example:"Red Cross"`
+	Description             *string `json:"description,omitempty"`
+	ValidityPeriod          *int    `json:"validity_period,omitempty" example:"730"`         // in days
+	ComplianceRequirementID *uint   `json:"compliance_requirement_id,omitempty" example:"2"` // Link to send expiry reminders through compliance
+}
+
+// AssignSkillRequest represents data for assigning a skill to an employee
+type AssignSkillRequest struct {
+	SkillID           uint                    `json:"skill_id" binding:"required" example:"1"`
+	Proficiency       models.ProficiencyLevel `json:"proficiency" binding:"required,oneof=beginner intermediate advanced expert" example:"advanced"`
+	YearsOfExperience *float64                `json:"years_of_experience,omitempty" example:"3.5"`
+	ExpiryDate        *string                 `json:"expiry_date,omitempty" example:"2026-12-31"` // YYYY-MM-DD
+	Notes             *string                 `json:"notes,omitempty"`
+}
+
+// AddEmployeeCertificationRequest represents data for recording a certification held by an employee
+type AddEmployeeCertificationRequest struct {
+	CertificationID   uint    `json:"certification_id" binding:"required" example:"1"`
+	CertificateNumber *string `json:"certificate_number,omitempty" example:"FA-123456"`
+	IssueDate         *string `json:"issue_date,omitempty" example:"2025-01-10"`  // YYYY-MM-DD
+	ExpiryDate        *string `json:"expiry_date,omitempty" example:"2027-01-10"` // YYYY-MM-DD; derived from the validity period when omitted
+	DocumentID        *uint   `json:"document_id,omitempty" example:"5"`
+	Notes             *string `json:"notes,omitempty"`
+}
+
+// ==================== Skills Catalogue ====================
+
+// GetSkills lists the skills catalogue
+// @Summary Get skills
+// @Description List active skills in the catalogue, optionally filtered by category
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Category filter"
+// @Success 200 {array} models.Skill
+// @Failure 401 {object} ErrorResponse
+// @Router /api/skills [get]
+func GetSkills(c *gin.Context) {
+	query := requestDB(c).Where("is_active = ?", true)
+	if category := c.Query("category"); category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var skills []models.Skill
+	query.Order("name").Find(&skills)
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// CreateSkill adds a skill to the catalogue
+// @Summary Create skill
+// @Description Add a new skill to the catalogue (Manager/Admin only)
+// @Tags Core HR - Skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateSkillRequest true "Skill"
+// @Success 201 {object} models.Skill
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/skills [post]
+func CreateSkill(c *gin.Context) {
+	var req CreateSkillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	skill := models.Skill{
+		Name:        req.Name,
+		Category:    req.Category,
+		Description: req.Description,
+		IsActive:    true,
+	}
+	if err := requestDB(c).Create(&skill).Error; err != nil {
+		utils.RespondError(c, http.StatusConflict, "Skill already exists")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntitySkill, skill.ID, models.AuditActionCreate, userID.(uint), c, nil, skill)
+
+	c.JSON(http.StatusCreated, skill)
+}
+
+// GetCertifications lists the certifications catalogue
+// @Summary Get certifications
+// @Description List active certifications in the catalogue
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Certification
+// @Failure 401 {object} ErrorResponse
+// @Router /api/certifications [get]
+func GetCertifications(c *gin.Context) {
+	var certifications []models.Certification
+	requestDB(c).Preload("ComplianceRequirement").Where("is_active = ?", true).Order("name").Find(&certifications)
+
+	c.JSON(http.StatusOK, certifications)
+}
+
+// CreateCertification adds a certification to the catalogue
+// @Summary Create certification
+// @Description Add a new certification to the catalogue. Linking a compliance requirement mirrors employee holdings as compliance records so expiry reminders go through the compliance pipeline (Manager/Admin only)
+// @Tags Core HR - Skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateCertificationRequest true "Certification"
+// @Success 201 {object} models.Certification
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/certifications [post]
+func CreateCertification(c *gin.Context) {
+	var req CreateCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if req.ComplianceRequirementID != nil {
+		var requirement models.ComplianceRequirement
+		if err := requestDB(c).First(&requirement, *req.ComplianceRequirementID).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Compliance requirement not found")
+			return
+		}
+	}
+
+	certification := models.Certification{
+		Code:                    req.Code,
+		Name:                    req.Name,
+		IssuingBody:             req.IssuingBody,
+		Description:             req.Description,
+		ValidityPeriod:          req.ValidityPeriod,
+		ComplianceRequirementID: req.ComplianceRequirementID,
+		IsActive:                true,
+	}
+	if err := requestDB(c).Create(&certification).Error; err != nil {
+		utils.RespondError(c, http.StatusConflict, "Certification code already exists")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityCertification, certification.ID, models.AuditActionCreate, userID.(uint), c, nil, certification)
+
+	c.JSON(http.StatusCreated, certification)
+}
+
+// ==================== Employee Skills ====================
+
+// GetEmployeeSkills retrieves the skills assigned to an employee
+// @Summary Get employee skills
+// @Description Get all skills assigned to an employee with proficiency levels. Employees can only view their own skills
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} models.EmployeeSkill
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/{id}/skills [get]
+func GetEmployeeSkills(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var skills []models.EmployeeSkill
+	requestDB(c).Preload("Skill").Preload("Assessor").Where("employee_id = ?", employeeID).Find(&skills)
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// AssignEmployeeSkill assigns a skill to an employee or updates the existing assignment
+// @Summary Assign employee skill
+// @Description Assign a skill to an employee with a proficiency level, or update it if already assigned
+// @Tags Core HR - Skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body AssignSkillRequest true "Skill assignment"
+// @Success 200 {object} models.EmployeeSkill
+// @Success 201 {object} models.EmployeeSkill
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/skills [post]
+func AssignEmployeeSkill(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var req AssignSkillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var skill models.Skill
+	if err := requestDB(c).Where("id = ? AND is_active = ?", req.SkillID, true).First(&skill).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Skill not found")
+		return
+	}
+
+	var expiryDate *time.Time
+	if req.ExpiryDate != nil && *req.ExpiryDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.ExpiryDate)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid expiry_date format. Use YYYY-MM-DD")
+			return
+		}
+		expiryDate = &parsed
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uint)
+
+	var assignment models.EmployeeSkill
+	err := requestDB(c).Where("employee_id = ? AND skill_id = ?", employeeID, req.SkillID).First(&assignment).Error
+	isNew := err != nil
+	oldValues := assignment
+
+	assignment.EmployeeID = uint(employeeID)
+	assignment.SkillID = req.SkillID
+	assignment.Proficiency = req.Proficiency
+	assignment.YearsOfExperience = req.YearsOfExperience
+	assignment.ExpiryDate = expiryDate
+	assignment.Notes = req.Notes
+	// A proficiency set by someone other than the employee counts as an assessment
+	assignment.AssessedBy = nil
+	if currentUserID != uint(employeeID) {
+		assignment.AssessedBy = &currentUserID
+	}
+
+	if err := requestDB(c).Save(&assignment).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to assign skill")
+		return
+	}
+	assignment.Skill = skill
+
+	if isNew {
+		createAuditLog(models.AuditEntitySkill, assignment.ID, models.AuditActionCreate, currentUserID, c, nil, assignment)
+		c.JSON(http.StatusCreated, assignment)
+		return
+	}
+	createAuditLog(models.AuditEntitySkill, assignment.ID, models.AuditActionUpdate, currentUserID, c, oldValues, assignment)
+	c.JSON(http.StatusOK, assignment)
+}
+
+// RemoveEmployeeSkill removes a skill from an employee
+// @Summary Remove employee skill
+// @Description Remove a skill assignment from an employee
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param skill_id path int true "Skill ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/skills/{skill_id} [delete]
+func RemoveEmployeeSkill(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	skillID, _ := strconv.ParseUint(c.Param("skill_id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var assignment models.EmployeeSkill
+	if err := requestDB(c).Where("employee_id = ? AND skill_id = ?", employeeID, skillID).First(&assignment).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Skill assignment not found")
+		return
+	}
+
+	oldValues := assignment
+	if err := requestDB(c).Delete(&assignment).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to remove skill")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntitySkill, assignment.ID, models.AuditActionDelete, userID.(uint), c, oldValues, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skill removed successfully"})
+}
+
+// SearchEmployeesBySkill finds employees holding a skill
+// @Summary Search employees by skill
+// @Description Find employees who have a skill, optionally at or above a minimum proficiency. Expired skill assignments are excluded unless include_expired=true (Manager/Admin only)
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param skill_id query int false "Skill ID"
+// @Param skill query string false "Skill name (partial match)"
+// @Param min_proficiency query string false "Minimum proficiency (beginner, intermediate, advanced, expert)"
+// @Param department query string false "Department filter"
+// @Param include_expired query bool false "Include expired skill assignments"
+// @Success 200 {array} models.EmployeeSkill
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/skills/search [get]
+func SearchEmployeesBySkill(c *gin.Context) {
+	skillID := c.Query("skill_id")
+	skillName := c.Query("skill")
+	if skillID == "" && skillName == "" {
+		utils.RespondError(c, http.StatusBadRequest, "skill_id or skill is required")
+		return
+	}
+
+	query := requestDB(c).Model(&models.EmployeeSkill{}).
+		Joins("JOIN skills ON skills.id = employee_skills.skill_id").
+		Joins("JOIN employees ON employees.id = employee_skills.employee_id AND employees.deleted_at IS NULL").
+		Preload("Employee").Preload("Skill")
+
+	if skillID != "" {
+		query = query.Where("employee_skills.skill_id = ?", skillID)
+	} else {
+		query = query.Where("LOWER(skills.name) LIKE LOWER(?)", "%"+skillName+"%")
+	}
+
+	if minProficiency := c.Query("min_proficiency"); minProficiency != "" {
+		minRank, ok := models.ProficiencyRank[models.ProficiencyLevel(minProficiency)]
+		if !ok {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid min_proficiency")
+			return
+		}
+		var levels []models.ProficiencyLevel
+		for level, rank := range models.ProficiencyRank {
+			if rank >= minRank {
+				levels = append(levels, level)
+			}
+		}
+		query = query.Where("employee_skills.proficiency IN ?", levels)
+	}
+
+	if department := c.Query("department"); department != "" {
+		query = query.Where("employees.department = ?", department)
+	}
+
+	if c.Query("include_expired") != "true" {
+		query = query.Where("employee_skills.expiry_date IS NULL OR employee_skills.expiry_date >= ?", time.Now().Format("2006-01-02"))
+	}
+
+	var results []models.EmployeeSkill
+	query.Order("employee_skills.employee_id").Find(&results)
+
+	c.JSON(http.StatusOK, results)
+}
+
+// ==================== Employee Certifications ====================
+
+// GetEmployeeCertifications retrieves the certifications held by an employee
+// @Summary Get employee certifications
+// @Description Get all certifications held by an employee. Employees can only view their own certifications
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {array} models.EmployeeCertification
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/employees/{id}/certifications [get]
+func GetEmployeeCertifications(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var certifications []models.EmployeeCertification
+	requestDB(c).Preload("Certification").Preload("Document").
+		Where("employee_id = ?", employeeID).Order("expiry_date").Find(&certifications)
+
+	c.JSON(http.StatusOK, certifications)
+}
+
+// AddEmployeeCertification records a certification held by an employee
+// @Summary Add employee certification
+// @Description Record a certification held by an employee. If the certification is linked to a compliance requirement, a matching compliance record is kept in sync so expiry reminders are sent
+// @Tags Core HR - Skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param request body AddEmployeeCertificationRequest true "Certification details"
+// @Success 201 {object} models.EmployeeCertification
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/certifications [post]
+func AddEmployeeCertification(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var req AddEmployeeCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	var certification models.Certification
+	if err := requestDB(c).Where("id = ? AND is_active = ?", req.CertificationID, true).First(&certification).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Certification not found")
+		return
+	}
+
+	holding := models.EmployeeCertification{
+		EmployeeID:        uint(employeeID),
+		CertificationID:   certification.ID,
+		CertificateNumber: req.CertificateNumber,
+		DocumentID:        req.DocumentID,
+		Notes:             req.Notes,
+	}
+
+	if req.IssueDate != nil && *req.IssueDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.IssueDate)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid issue_date format. Use YYYY-MM-DD")
+			return
+		}
+		holding.IssueDate = &parsed
+	}
+	if req.ExpiryDate != nil && *req.ExpiryDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.ExpiryDate)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid expiry_date format. Use YYYY-MM-DD")
+			return
+		}
+		holding.ExpiryDate = &parsed
+	} else if holding.IssueDate != nil && certification.ValidityPeriod != nil {
+		expiry := holding.IssueDate.AddDate(0, 0, *certification.ValidityPeriod)
+		holding.ExpiryDate = &expiry
+	}
+	if holding.IssueDate != nil && holding.ExpiryDate != nil && holding.ExpiryDate.Before(*holding.IssueDate) {
+		utils.RespondError(c, http.StatusBadRequest, "expiry_date cannot be before issue_date")
+		return
+	}
+
+	if req.DocumentID != nil {
+		var document models.Document
+		if err := requestDB(c).Where("id = ? AND employee_id = ?", *req.DocumentID, employeeID).First(&document).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Document not found for this employee")
+			return
+		}
+	}
+
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&holding).Error; err != nil {
+			return err
+		}
+		return syncCertificationCompliance(tx, &holding, certification)
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to add certification")
+		return
+	}
+	holding.Certification = certification
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityCertification, holding.ID, models.AuditActionCreate, userID.(uint), c, nil, holding)
+
+	c.JSON(http.StatusCreated, holding)
+}
+
+// RemoveEmployeeCertification removes a certification held by an employee
+// @Summary Remove employee certification
+// @Description Remove a certification record from an employee
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param certification_id path int true "Employee certification record ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/certifications/{certification_id} [delete]
+func RemoveEmployeeCertification(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	recordID, _ := strconv.ParseUint(c.Param("certification_id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var holding models.EmployeeCertification
+	if err := requestDB(c).Where("id = ? AND employee_id = ?", recordID, employeeID).First(&holding).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Certification record not found")
+		return
+	}
+
+	oldValues := holding
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if holding.ComplianceRecordID != nil {
+			if err := tx.Delete(&models.ComplianceRecord{}, *holding.ComplianceRecordID).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&holding).Error
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to remove certification")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityCertification, holding.ID, models.AuditActionDelete, userID.(uint), c, oldValues, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Certification removed successfully"})
+}
+
+// GetExpiringCertifications lists employee certifications and skills expiring soon
+// @Summary Get expiring certifications and skills
+// @Description List employee certifications and skill assignments that expire within the given number of days, including those already expired (Manager/Admin only)
+// @Tags Core HR - Skills
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Look-ahead window in days (default 30)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/certifications/expiring [get]
+func GetExpiringCertifications(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		days = 30
+	}
+	cutoff := time.Now().AddDate(0, 0, days).Format("2006-01-02")
+
+	var certifications []models.EmployeeCertification
+	requestDB(c).Preload("Employee").Preload("Certification").
+		Where("expiry_date IS NOT NULL AND expiry_date <= ?", cutoff).
+		Order("expiry_date").Find(&certifications)
+
+	var skills []models.EmployeeSkill
+	requestDB(c).Preload("Employee").Preload("Skill").
+		Where("expiry_date IS NOT NULL AND expiry_date <= ?", cutoff).
+		Order("expiry_date").Find(&skills)
+
+	c.JSON(http.StatusOK, gin.H{
+		"days":           days,
+		"certifications": certifications,
+		"skills":         skills,
+	})
+}
+
+// syncCertificationCompliance mirrors a certification holding as a compliance record when the
+// certification is linked to a compliance requirement, so the compliance expiry reminders cover it
+func syncCertificationCompliance(tx *gorm.DB, holding *models.EmployeeCertification, certification models.Certification) error {
+	if certification.ComplianceRequirementID == nil {
+		return nil
+	}
+
+	status := models.ComplianceStatusCompliant
+	if holding.ExpiryDate != nil && holding.ExpiryDate.Before(time.Now()) {
+		status = models.ComplianceStatusExpired
+	}
+
+	record := models.ComplianceRecord{
+		EmployeeID:    holding.EmployeeID,
+		RequirementID: *certification.ComplianceRequirementID,
+	}
+	if holding.ComplianceRecordID != nil {
+		tx.First(&record, *holding.ComplianceRecordID)
+	}
+	record.Status = status
+	record.IssueDate = holding.IssueDate
+	record.ExpiryDate = holding.ExpiryDate
+	record.DocumentID = holding.DocumentID
+	notes := "Synced from certification " + certification.Code
+	record.Notes = &notes
+
+	if err := tx.Save(&record).Error; err != nil {
+		return err
+	}
+	holding.ComplianceRecordID = &record.ID
+	return tx.Model(holding).Update("compliance_record_id", record.ID).Error
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"hrms-api/i18n"
+	"hrms-api/utils"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamDownload sends a generated file as an attachment, writing it straight to the response instead
+// of holding a copy in memory. A failure is reported with the message as long as nothing has been sent;
+// after that the client is left with a truncated file.
+func streamDownload(c *gin.Context, filename, contentType, failure string, write func(w io.Writer) error) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+
+	if err := write(c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			utils.RespondError(c, http.StatusInternalServerError, failure)
+			return
+		}
+		log.Printf("Streaming %s failed: %v", filename, err)
+		c.Error(err)
+	}
+}
+
+// exportLocale returns the locale an export file is written in: the language of the lang query
+// parameter, else the request's, and the paper size of the paper_size query parameter, else the
+// export_paper_size setting's. An invalid parameter is reported and false returned.
+func exportLocale(c *gin.Context) (utils.ExportLocale, bool) {
+	locale := utils.DefaultExportLocale()
+	locale.Language = utils.RequestLanguage(c)
+	if tag := c.Query("lang"); tag != "" {
+		lang, ok := i18n.Parse(tag)
+		if !ok {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid language. Use en, fr or pt")
+			return locale, false
+		}
+		locale.Language = lang
+	}
+	if size := c.Query("paper_size"); size != "" {
+		switch {
+		case strings.EqualFold(size, utils.PaperA4):
+			locale.PaperSize = utils.PaperA4
+		case strings.EqualFold(size, utils.PaperLetter):
+			locale.PaperSize = utils.PaperLetter
+		default:
+			utils.RespondError(c, http.StatusBadRequest, "Invalid paper size. Use A4 or Letter")
+			return locale, false
+		}
+	}
+	return locale, true
+}
+
+// jsonArrayWriter streams a JSON array response element by element, so a large list is sent as it is
+// built rather than marshalled into memory all at once. The status is sent with the first element, so
+// errors can still be reported until then.
+type jsonArrayWriter struct {
+	c       *gin.Context
+	encoder *json.Encoder
+	started bool
+}
+
+// newJSONArrayWriter starts a JSON array response. Only use it for records without personal data: the
+// response is not held back for PII masking.
+func newJSONArrayWriter(c *gin.Context) *jsonArrayWriter {
+	utils.SkipPIIMasking(c)
+	return &jsonArrayWriter{c: c, encoder: json.NewEncoder(c.Writer)}
+}
+
+// Write sends the next element of the array
+func (w *jsonArrayWriter) Write(element interface{}) error {
+	separator := ","
+	if !w.started {
+		w.c.Header("Content-Type", "application/json; charset=utf-8")
+		w.c.Status(http.StatusOK)
+		w.started = true
+		separator = "["
+	}
+	if _, err := io.WriteString(w.c.Writer, separator); err != nil {
+		return err
+	}
+	return w.encoder.Encode(element)
+}
+
+// Close ends the array, sending an empty one when no element was written
+func (w *jsonArrayWriter) Close() error {
+	if !w.started {
+		w.c.JSON(http.StatusOK, []interface{}{})
+		return nil
+	}
+	_, err := io.WriteString(w.c.Writer, "]")
+	return err
+}
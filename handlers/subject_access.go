@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportSubjectAccess assembles everything held about an employee for a subject access request
+// @Summary Export subject access data
+// @Description Assemble everything held about one employee (profile, identity, employment, leaves, documents list, audit trail and every other record that references them) for a data protection subject access request. The default zip bundle holds the data as JSON and as a readable PDF; format=json or format=pdf returns one of them. Document files themselves are listed but not included. Bank account numbers are masked unless the user has payroll access. Review the export before releasing it, as records such as grievance updates may mention other people. Every export is recorded in the employee's audit trail (Admin only)
+// @Tags Admin - Employees
+// @Produce application/zip,application/json,application/pdf
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param format query string false "zip, json or pdf (default: zip)"
+// @Success 200 {file} file "Subject access bundle"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/employees/{id}/subject-access [get]
+func ExportSubjectAccess(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+	format := c.DefaultQuery("format", "zip")
+	if format != "zip" && format != "json" && format != "pdf" {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid format. Use 'zip', 'json' or 'pdf'")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+
+	// Soft-deleted employees are still subjects of the data held about them
+	var employee models.Employee
+	if err := requestDB(c).Unscoped().First(&employee, uint(employeeID)).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	sections, err := utils.CollectSubjectAccessData(requestDB(c), employee.ID, !user.PayrollAccess)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to collect employee data")
+		return
+	}
+	report := utils.SubjectAccessReport{
+		EmployeeID:  employee.ID,
+		Name:        employee.Firstname + " " + employee.Lastname,
+		GeneratedAt: time.Now(),
+		GeneratedBy: user.ID,
+		Sections:    sections,
+	}
+
+	var jsonData, pdfData []byte
+	if format != "pdf" {
+		if jsonData, err = json.MarshalIndent(report, "", "  "); err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate export file")
+			return
+		}
+	}
+	if format != "json" {
+		if pdfData, err = utils.ExportSubjectAccessToPDF(report); err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate PDF")
+			return
+		}
+	}
+
+	createAuditLog(models.AuditEntityEmployee, employee.ID, models.AuditActionView, user.ID, c, nil, gin.H{"subject_access_export": format})
+
+	noteFileAccess(c, models.FileAccessLog{EmployeeID: &employee.ID})
+	basename := fmt.Sprintf("subject_access_%d_%s", employee.ID, report.GeneratedAt.Format("20060102"))
+	switch format {
+	case "json":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.json", basename))
+		c.Data(http.StatusOK, "application/json", jsonData)
+	case "pdf":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", basename))
+		c.Data(http.StatusOK, "application/pdf", pdfData)
+	default:
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", basename))
+		c.Header("Content-Type", "application/zip")
+		archive := zip.NewWriter(c.Writer)
+		for _, file := range []struct {
+			name string
+			data []byte
+		}{{basename + ".json", jsonData}, {basename + ".pdf", pdfData}} {
+			w, err := archive.Create(file.name)
+			if err != nil {
+				return
+			}
+			w.Write(file.data)
+		}
+		archive.Close()
+	}
+}
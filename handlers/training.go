@@ -0,0 +1,794 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateTrainingCourseRequest represents data for adding a course to the catalogue
+type CreateTrainingCourseRequest struct {
+	Code                    string   `json:"code" binding:"required" example:"FIRE-101"`
+	Title                   string   `json:"title" binding:"required" example:"Fire Safety Awareness"`
+	Description             *string  `json:"description,omitempty" example:"Evacuation procedures and extinguisher use"`
+	Provider                *string  `json:"provider,omitempty" example:"Zambia Fire Services"`
+	DurationHours           *float64 `json:"duration_hours,omitempty" example:"3"`
+	ValidityPeriod          *int     `json:"validity_period,omitempty" example:"365"` // in days
+	ComplianceRequirementID *uint    `json:"compliance_requirement_id,omitempty" example:"2"`
+}
+
+// CreateTrainingSessionRequest represents data for scheduling a session of a course
+type CreateTrainingSessionRequest struct {
+	CourseID  uint    `json:"course_id" binding:"required" example:"4"`
+	StartDate string  `json:"start_date" binding:"required" example:"2025-04-02T09:00:00Z"` // RFC3339
+	EndDate   string  `json:"end_date" binding:"required" example:"2025-04-02T12:00:00Z"`   // RFC3339
+	Location  *string `json:"location,omitempty" example:"Main hall"`
+	Trainer   *string `json:"trainer,omitempty" example:"John Banda"`
+	Capacity  *int    `json:"capacity,omitempty" example:"20"`
+}
+
+// EnrollTrainingRequest represents enrolling employees on a session
+type EnrollTrainingRequest struct {
+	EmployeeIDs []uint `json:"employee_ids,omitempty"` // Defaults to the current user; managers and admins may enroll others
+}
+
+// TrainingAttendanceRequest represents recording whether an enrolled employee attended
+type TrainingAttendanceRequest struct {
+	Attended bool `json:"attended" example:"true"`
+}
+
+// CompleteTrainingRequest represents the outcome of a training enrollment
+type CompleteTrainingRequest struct {
+	Passed         bool     `json:"passed" example:"true"`
+	Score          *float64 `json:"score,omitempty" example:"86"`
+	CompletionDate *string  `json:"completion_date,omitempty" example:"2025-04-02"` // Defaults to today
+	Notes          *string  `json:"notes,omitempty" example:"Practical assessment passed"`
+}
+
+// SetMandatoryTrainingRequest represents making a course mandatory for a role
+type SetMandatoryTrainingRequest struct {
+	CourseID      uint        `json:"course_id" binding:"required" example:"4"`
+	Role          models.Role `json:"role" binding:"required" example:"employee"`
+	DueWithinDays *int        `json:"due_within_days,omitempty" example:"30"`
+}
+
+// EmployeeTraining represents an employee's training history and mandatory training status
+type EmployeeTraining struct {
+	Enrollments []models.TrainingEnrollment   `json:"enrollments"`
+	Mandatory   []utils.MandatoryTrainingItem `json:"mandatory"`
+}
+
+// MandatoryTrainingGap lists the mandatory courses an employee has not completed
+type MandatoryTrainingGap struct {
+	EmployeeID   uint                          `json:"employee_id" example:"12"`
+	EmployeeName string                        `json:"employee_name" example:"Jane Doe"`
+	Department   string                        `json:"department" example:"Finance"`
+	Courses      []utils.MandatoryTrainingItem `json:"courses"`
+}
+
+// GetTrainingCourses lists the training course catalogue
+// @Summary Get training courses
+// @Description List active training courses
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.TrainingCourse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/training/courses [get]
+func GetTrainingCourses(c *gin.Context) {
+	var courses []models.TrainingCourse
+	requestDB(c).Preload("ComplianceRequirement").Where("is_active = ?", true).Order("title").Find(&courses)
+
+	c.JSON(http.StatusOK, courses)
+}
+
+// CreateTrainingCourse adds a course to the catalogue
+// @Summary Create training course
+// @Description Add a training course. Linking a compliance requirement mirrors completions as compliance records (Manager/Admin only)
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateTrainingCourseRequest true "Course"
+// @Success 201 {object} models.TrainingCourse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/courses [post]
+func CreateTrainingCourse(c *gin.Context) {
+	var req CreateTrainingCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if req.ComplianceRequirementID != nil {
+		var requirement models.ComplianceRequirement
+		if err := requestDB(c).First(&requirement, *req.ComplianceRequirementID).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Compliance requirement not found")
+			return
+		}
+	}
+
+	course := models.TrainingCourse{
+		Code:                    req.Code,
+		Title:                   req.Title,
+		Description:             req.Description,
+		Provider:                req.Provider,
+		DurationHours:           req.DurationHours,
+		ValidityPeriod:          req.ValidityPeriod,
+		ComplianceRequirementID: req.ComplianceRequirementID,
+		IsActive:                true,
+	}
+	if err := requestDB(c).Create(&course).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create training course")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityTraining, course.ID, models.AuditActionCreate, userID.(uint), c, nil, course)
+
+	c.JSON(http.StatusCreated, course)
+}
+
+// trainingSessionListFields are the filters and sort keys accepted by the training session list
+var trainingSessionListFields = ListFields{
+	Filters: map[string]string{"course_id": "course_id", "status": "status"},
+	Sorts: map[string]string{
+		"id": "id", "start_date": "start_date", "end_date": "end_date", "status": "status",
+	},
+	DefaultSort: "start_date",
+}
+
+// GetTrainingSessions lists training sessions
+// @Summary Get training sessions
+// @Description List training sessions, optionally filtered by course and status. Upcoming sessions are returned by default
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Param course_id query int false "Course ID"
+// @Param status query string false "Status (scheduled, completed, cancelled)"
+// @Param include_past query bool false "Include sessions that have already started"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, start_date, end_date, status). Defaults to start_date"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.TrainingSession}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/training/sessions [get]
+func GetTrainingSessions(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Course")
+	if c.Query("include_past") != "true" {
+		query = query.Where("start_date >= ?", time.Now())
+	}
+
+	query, ok = applyListQuery(c, query, trainingSessionListFields)
+	if !ok {
+		return
+	}
+
+	var sessions []models.TrainingSession
+	response, err := paginate(query, pagination, &sessions)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch training sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateTrainingSession schedules a session of a course
+// @Summary Create training session
+// @Description Schedule a session of a training course (Manager/Admin only)
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateTrainingSessionRequest true "Session"
+// @Success 201 {object} models.TrainingSession
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/sessions [post]
+func CreateTrainingSession(c *gin.Context) {
+	var req CreateTrainingSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var course models.TrainingCourse
+	if err := requestDB(c).First(&course, req.CourseID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Training course not found")
+		return
+	}
+	if !course.IsActive {
+		utils.RespondError(c, http.StatusBadRequest, "Cannot schedule an inactive course")
+		return
+	}
+
+	startDate, err := time.Parse(time.RFC3339, req.StartDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid start_date format. Use RFC3339")
+		return
+	}
+	endDate, err := time.Parse(time.RFC3339, req.EndDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid end_date format. Use RFC3339")
+		return
+	}
+	if !endDate.After(startDate) {
+		utils.RespondError(c, http.StatusBadRequest, "end_date must be after start_date")
+		return
+	}
+	if req.Capacity != nil && *req.Capacity < 1 {
+		utils.RespondError(c, http.StatusBadRequest, "capacity must be at least 1")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	session := models.TrainingSession{
+		CourseID:  course.ID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Location:  req.Location,
+		Trainer:   req.Trainer,
+		Capacity:  req.Capacity,
+		Status:    models.TrainingSessionScheduled,
+		CreatedBy: userID.(uint),
+	}
+	if err := requestDB(c).Create(&session).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create training session")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTraining, session.ID, models.AuditActionCreate, session.CreatedBy, c, nil, session)
+
+	session.Course = course
+	c.JSON(http.StatusCreated, session)
+}
+
+// EnrollInTrainingSession enrolls employees on a session
+// @Summary Enroll in training session
+// @Description Enroll yourself on a training session. Managers and admins can enroll other employees by passing employee_ids
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Param request body EnrollTrainingRequest false "Employees to enroll"
+// @Success 201 {array} models.TrainingEnrollment
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/sessions/{id}/enroll [post]
+func EnrollInTrainingSession(c *gin.Context) {
+	sessionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req EnrollTrainingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+
+	employeeIDs := req.EmployeeIDs
+	if len(employeeIDs) == 0 {
+		employeeIDs = []uint{user.ID}
+	}
+	for _, id := range employeeIDs {
+		if id != user.ID && user.Role != models.RoleManager && user.Role != models.RoleAdmin {
+			utils.RespondError(c, http.StatusForbidden, "You can only enroll yourself")
+			return
+		}
+	}
+
+	var session models.TrainingSession
+	if err := requestDB(c).First(&session, sessionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Training session not found")
+		return
+	}
+	if session.Status != models.TrainingSessionScheduled {
+		utils.RespondError(c, http.StatusBadRequest, "Enrollment is only open for scheduled sessions")
+		return
+	}
+
+	if session.Capacity != nil {
+		var enrolled int64
+		requestDB(c).Model(&models.TrainingEnrollment{}).
+			Where("session_id = ? AND status != ?", session.ID, models.TrainingEnrollmentCancelled).
+			Count(&enrolled)
+		if int(enrolled)+len(employeeIDs) > *session.Capacity {
+			utils.RespondError(c, http.StatusBadRequest, "Not enough places left on this session")
+			return
+		}
+	}
+
+	var enrollments []models.TrainingEnrollment
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		for _, employeeID := range employeeIDs {
+			var employee models.Employee
+			if err := tx.First(&employee, employeeID).Error; err != nil {
+				return err
+			}
+
+			// Re-enrolling after a cancellation reuses the existing row
+			var enrollment models.TrainingEnrollment
+			if tx.Where("session_id = ? AND employee_id = ?", session.ID, employeeID).First(&enrollment).Error == nil &&
+				enrollment.Status != models.TrainingEnrollmentCancelled {
+				continue
+			}
+			enrollment.SessionID = session.ID
+			enrollment.EmployeeID = employeeID
+			enrollment.Status = models.TrainingEnrollmentEnrolled
+			enrollment.EnrolledBy = user.ID
+			if err := tx.Save(&enrollment).Error; err != nil {
+				return err
+			}
+			enrollments = append(enrollments, enrollment)
+		}
+		return nil
+	})
+	if err == gorm.ErrRecordNotFound {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to enroll on training session")
+		return
+	}
+
+	for _, enrollment := range enrollments {
+		createAuditLog(models.AuditEntityTraining, enrollment.ID, models.AuditActionCreate, user.ID, c, nil, enrollment)
+	}
+
+	c.JSON(http.StatusCreated, enrollments)
+}
+
+// GetTrainingSessionEnrollments lists the enrollments of a session
+// @Summary Get session enrollments
+// @Description List employees enrolled on a training session with their attendance and completion status (Manager/Admin only)
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Success 200 {array} models.TrainingEnrollment
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/training/sessions/{id}/enrollments [get]
+func GetTrainingSessionEnrollments(c *gin.Context) {
+	sessionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var enrollments []models.TrainingEnrollment
+	requestDB(c).Preload("Employee").Where("session_id = ?", sessionID).Order("created_at").Find(&enrollments)
+
+	c.JSON(http.StatusOK, enrollments)
+}
+
+// RecordTrainingAttendance records whether an enrolled employee attended
+// @Summary Record training attendance
+// @Description Mark an enrolled employee as attended or a no-show (Manager/Admin only)
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Enrollment ID"
+// @Param request body TrainingAttendanceRequest true "Attendance"
+// @Success 200 {object} models.TrainingEnrollment
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/enrollments/{id}/attendance [put]
+func RecordTrainingAttendance(c *gin.Context) {
+	enrollmentID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req TrainingAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var enrollment models.TrainingEnrollment
+	if err := requestDB(c).First(&enrollment, enrollmentID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Training enrollment not found")
+		return
+	}
+	if enrollment.Status != models.TrainingEnrollmentEnrolled && enrollment.Status != models.TrainingEnrollmentAttended &&
+		enrollment.Status != models.TrainingEnrollmentNoShow {
+		utils.RespondError(c, http.StatusBadRequest, "Attendance can no longer be changed for this enrollment")
+		return
+	}
+
+	oldValues := enrollment
+	enrollment.Status = models.TrainingEnrollmentNoShow
+	if req.Attended {
+		enrollment.Status = models.TrainingEnrollmentAttended
+	}
+	if err := requestDB(c).Save(&enrollment).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to record attendance")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityTraining, enrollment.ID, models.AuditActionUpdate, userID.(uint), c, oldValues, enrollment)
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// CompleteTraining records the outcome of an attended enrollment
+// @Summary Complete training
+// @Description Record whether an attended employee passed. A pass generates a completion certificate stored in the employee's documents and updates the linked compliance record (Manager/Admin only)
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Enrollment ID"
+// @Param request body CompleteTrainingRequest true "Outcome"
+// @Success 200 {object} models.TrainingEnrollment
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/enrollments/{id}/complete [put]
+func CompleteTraining(c *gin.Context) {
+	enrollmentID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req CompleteTrainingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var enrollment models.TrainingEnrollment
+	if err := requestDB(c).Preload("Session.Course").Preload("Employee").First(&enrollment, enrollmentID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Training enrollment not found")
+		return
+	}
+	if enrollment.Status != models.TrainingEnrollmentAttended {
+		utils.RespondError(c, http.StatusBadRequest, "Only attended enrollments can be completed")
+		return
+	}
+
+	now := time.Now()
+	completedAt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if req.CompletionDate != nil && *req.CompletionDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.CompletionDate)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, "Invalid completion_date format. Use YYYY-MM-DD")
+			return
+		}
+		completedAt = parsed
+	}
+
+	userID, _ := c.Get("user_id")
+	recordedBy := userID.(uint)
+	course := enrollment.Session.Course
+	oldValues := enrollment
+
+	enrollment.Score = req.Score
+	enrollment.Notes = req.Notes
+	enrollment.CompletedAt = &completedAt
+	enrollment.Status = models.TrainingEnrollmentFailed
+	if req.Passed {
+		enrollment.Status = models.TrainingEnrollmentCompleted
+		if course.ValidityPeriod != nil {
+			expiry := completedAt.AddDate(0, 0, *course.ValidityPeriod)
+			enrollment.ExpiryDate = &expiry
+		}
+
+		document, err := utils.SaveTrainingCertificate(enrollment.Employee, course, enrollment, recordedBy)
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "Failed to generate certificate: "+err.Error())
+			return
+		}
+		enrollment.CertificateDocumentID = &document.ID
+	}
+
+	err := requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Session", "Employee", "Certificate").Save(&enrollment).Error; err != nil {
+			return err
+		}
+		if enrollment.Status == models.TrainingEnrollmentCompleted {
+			return syncTrainingCompliance(tx, &enrollment, course, recordedBy)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to record training completion")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTraining, enrollment.ID, models.AuditActionUpdate, recordedBy, c, oldValues, enrollment)
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// CancelTrainingEnrollment cancels an enrollment before the session is delivered
+// @Summary Cancel training enrollment
+// @Description Cancel an enrollment. Employees can cancel their own; managers and admins can cancel anyone's
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Enrollment ID"
+// @Success 200 {object} models.TrainingEnrollment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/enrollments/{id}/cancel [put]
+func CancelTrainingEnrollment(c *gin.Context) {
+	enrollmentID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var enrollment models.TrainingEnrollment
+	if err := requestDB(c).First(&enrollment, enrollmentID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Training enrollment not found")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if !canAccessEmployeeRecords(c, enrollment.EmployeeID) {
+		utils.RespondError(c, http.StatusForbidden, "You can only cancel your own enrollments")
+		return
+	}
+	if enrollment.Status != models.TrainingEnrollmentEnrolled {
+		utils.RespondError(c, http.StatusBadRequest, "Only enrollments that have not been attended can be cancelled")
+		return
+	}
+
+	oldValues := enrollment
+	enrollment.Status = models.TrainingEnrollmentCancelled
+	if err := requestDB(c).Save(&enrollment).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to cancel enrollment")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTraining, enrollment.ID, models.AuditActionCancel, user.ID, c, oldValues, enrollment)
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// GetEmployeeTraining returns an employee's training history and mandatory training status
+// @Summary Get employee training
+// @Description Get an employee's training enrollments and progress on the courses mandatory for their role. Employees can only view their own
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} EmployeeTraining
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/employees/{id}/training [get]
+func GetEmployeeTraining(c *gin.Context) {
+	employeeID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if !canAccessEmployeeRecords(c, uint(employeeID)) {
+		utils.RespondError(c, http.StatusForbidden, "You can only access your own records")
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, employeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	var enrollments []models.TrainingEnrollment
+	requestDB(c).Preload("Session.Course").Preload("Certificate").
+		Where("employee_id = ?", employeeID).Order("created_at DESC").Find(&enrollments)
+
+	c.JSON(http.StatusOK, EmployeeTraining{
+		Enrollments: enrollments,
+		Mandatory:   utils.MandatoryTrainingStatus(employee),
+	})
+}
+
+// GetMandatoryTraining lists the mandatory training rules
+// @Summary Get mandatory training
+// @Description List the courses each role is required to complete (Manager/Admin only)
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.MandatoryTraining
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/training/mandatory [get]
+func GetMandatoryTraining(c *gin.Context) {
+	var rules []models.MandatoryTraining
+	requestDB(c).Preload("Course").Order("role, course_id").Find(&rules)
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// SetMandatoryTraining makes a course mandatory for a role
+// @Summary Set mandatory training
+// @Description Require every employee with a role to complete a course, optionally within a number of days of their hire date (Admin only)
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SetMandatoryTrainingRequest true "Mandatory training"
+// @Success 200 {object} models.MandatoryTraining
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/mandatory [post]
+func SetMandatoryTraining(c *gin.Context) {
+	var req SetMandatoryTrainingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if req.Role != models.RoleEmployee && req.Role != models.RoleManager && req.Role != models.RoleAdmin {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid role")
+		return
+	}
+
+	var course models.TrainingCourse
+	if err := requestDB(c).First(&course, req.CourseID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Training course not found")
+		return
+	}
+
+	var rule models.MandatoryTraining
+	requestDB(c).Where("course_id = ? AND role = ?", course.ID, req.Role).First(&rule)
+	rule.CourseID = course.ID
+	rule.Role = req.Role
+	rule.DueWithinDays = req.DueWithinDays
+	if err := requestDB(c).Save(&rule).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to set mandatory training")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityTraining, rule.ID, models.AuditActionUpdate, userID.(uint), c, nil, rule)
+
+	rule.Course = course
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteMandatoryTraining stops a course being mandatory for a role
+// @Summary Delete mandatory training
+// @Description Stop requiring a course for a role (Admin only)
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Mandatory training ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/mandatory/{id} [delete]
+func DeleteMandatoryTraining(c *gin.Context) {
+	ruleID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var rule models.MandatoryTraining
+	if err := requestDB(c).First(&rule, ruleID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Mandatory training not found")
+		return
+	}
+
+	// Hard delete so the course can be made mandatory for the role again later
+	if err := requestDB(c).Unscoped().Delete(&rule).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete mandatory training")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityTraining, rule.ID, models.AuditActionDelete, userID.(uint), c, rule, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Mandatory training removed"})
+}
+
+// GetMandatoryTrainingGaps lists employees with outstanding, overdue or expired mandatory training
+// @Summary Get mandatory training gaps
+// @Description List active employees who have not completed, or whose completion has expired for, courses mandatory for their role (Manager/Admin only)
+// @Tags Training
+// @Produce json
+// @Security BearerAuth
+// @Param department query string false "Department filter"
+// @Success 200 {array} MandatoryTrainingGap
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/training/mandatory/gaps [get]
+func GetMandatoryTrainingGaps(c *gin.Context) {
+	query := requestDB(c).Where("status = ?", "active")
+	if department := c.Query("department"); department != "" {
+		query = query.Where("department = ?", department)
+	}
+
+	var employees []models.Employee
+	if err := query.Order("department, firstname, lastname").Find(&employees).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch employees")
+		return
+	}
+
+	gaps := []MandatoryTrainingGap{}
+	for _, employee := range employees {
+		var outstanding []utils.MandatoryTrainingItem
+		for _, item := range utils.MandatoryTrainingStatus(employee) {
+			if item.Status != utils.MandatoryTrainingComplete {
+				outstanding = append(outstanding, item)
+			}
+		}
+		if len(outstanding) > 0 {
+			gaps = append(gaps, MandatoryTrainingGap{
+				EmployeeID:   employee.ID,
+				EmployeeName: employee.Firstname + " " + employee.Lastname,
+				Department:   employee.Department,
+				Courses:      outstanding,
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, gaps)
+}
+
+// syncTrainingCompliance mirrors a completed enrollment as a compliance record when the course has a compliance requirement
+func syncTrainingCompliance(tx *gorm.DB, enrollment *models.TrainingEnrollment, course models.TrainingCourse, verifiedBy uint) error {
+	if course.ComplianceRequirementID == nil {
+		return nil
+	}
+
+	status := models.ComplianceStatusCompliant
+	if enrollment.ExpiryDate != nil && enrollment.ExpiryDate.Before(time.Now()) {
+		status = models.ComplianceStatusExpired
+	}
+
+	// Reuse the employee's latest record for the requirement so renewals update it in place
+	var record models.ComplianceRecord
+	tx.Where("employee_id = ? AND requirement_id = ?", enrollment.EmployeeID, *course.ComplianceRequirementID).
+		Order("updated_at DESC").First(&record)
+	record.EmployeeID = enrollment.EmployeeID
+	record.RequirementID = *course.ComplianceRequirementID
+	record.Status = status
+	record.IssueDate = enrollment.CompletedAt
+	record.ExpiryDate = enrollment.ExpiryDate
+	record.LastVerifiedDate = enrollment.CompletedAt
+	record.VerifiedBy = &verifiedBy
+	record.DocumentID = enrollment.CertificateDocumentID
+	notes := "Synced from training course " + course.Code
+	record.Notes = &notes
+
+	if err := tx.Save(&record).Error; err != nil {
+		return err
+	}
+	enrollment.ComplianceRecordID = &record.ID
+	return tx.Model(enrollment).Update("compliance_record_id", record.ID).Error
+}
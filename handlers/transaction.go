@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"hrms-api/database"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// requestDB returns the database bound to the request's context. Queries made through it are traced
+// under the request and only see the caller's organization (see middleware.Tenancy). In an atomic
+// batch they run in the batch's transaction (see BatchHandler).
+func requestDB(c *gin.Context) *gorm.DB {
+	return database.Session(c.Request.Context(), database.DB)
+}
+
+// withTransaction runs fn in a database transaction bound to the request's context. Every write
+// fn makes through tx is committed together if fn returns nil, and rolled back if it returns an
+// error or panics. Responses, events and webhooks belong after withTransaction returns, so nothing
+// is reported for changes that were rolled back.
+func withTransaction(c *gin.Context, fn func(tx *gorm.DB) error) error {
+	return requestDB(c).Transaction(fn)
+}
+
+type afterCommitKey struct{}
+
+// afterCommit runs fn once the request's changes are committed: straight away, or when the atomic
+// batch the request belongs to commits. Events, webhooks and notifications about a change go through
+// it, so none are sent for a batch that is rolled back. fn may run after the request has finished, so
+// it must not use c.
+func afterCommit(c *gin.Context, fn func()) {
+	if queue, ok := c.Request.Context().Value(afterCommitKey{}).(*[]func()); ok {
+		*queue = append(*queue, fn)
+		return
+	}
+	fn()
+}
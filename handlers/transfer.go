@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTransferRequestRequest represents data for raising an employee transfer
+type CreateTransferRequestRequest struct {
+	EmployeeID    uint    `json:"employee_id" binding:"required" example:"12"`
+	ToDepartment  *string `json:"to_department,omitempty" example:"Finance"` // Defaults to the new position's department
+	ToPositionID  *uint   `json:"to_position_id,omitempty" example:"4"`
+	ToManagerID   *uint   `json:"to_manager_id,omitempty" example:"7"` // Receiving manager who approves the transfer
+	EffectiveDate string  `json:"effective_date" binding:"required" example:"2025-08-01"`
+	Reason        *string `json:"reason,omitempty" example:"Team restructuring"`
+}
+
+// ReviewTransferRequestRequest represents the reviewer's comment on a transfer request
+type ReviewTransferRequestRequest struct {
+	Comment *string `json:"comment,omitempty" example:"Welcome to the team"`
+}
+
+// CreateTransferRequest raises a transfer request for an employee
+// @Summary Create transfer request
+// @Description Raise a request to move an employee to a new department, position and/or manager from an effective date (Manager/Admin only)
+// @Tags Core HR - Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateTransferRequestRequest true "Transfer details"
+// @Success 201 {object} models.TransferRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/transfers [post]
+func CreateTransferRequest(c *gin.Context) {
+	var req CreateTransferRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if req.ToDepartment == nil && req.ToPositionID == nil && req.ToManagerID == nil {
+		utils.RespondError(c, http.StatusBadRequest, "At least one of to_department, to_position_id or to_manager_id is required")
+		return
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid effective_date format. Use YYYY-MM-DD")
+		return
+	}
+
+	var employee models.Employee
+	if err := requestDB(c).First(&employee, req.EmployeeID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	toDepartment := req.ToDepartment
+	if req.ToPositionID != nil {
+		var position models.Position
+		if err := requestDB(c).First(&position, *req.ToPositionID).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Position not found")
+			return
+		}
+		if !position.IsActive {
+			utils.RespondError(c, http.StatusBadRequest, "Cannot transfer to an inactive position")
+			return
+		}
+		if toDepartment == nil {
+			toDepartment = &position.Department
+		}
+	}
+
+	if req.ToManagerID != nil {
+		if *req.ToManagerID == employee.ID {
+			utils.RespondError(c, http.StatusBadRequest, "An employee cannot be their own manager")
+			return
+		}
+		var manager models.Employee
+		if err := requestDB(c).First(&manager, *req.ToManagerID).Error; err != nil {
+			utils.RespondError(c, http.StatusNotFound, "Receiving manager not found")
+			return
+		}
+		if manager.Role != models.RoleManager && manager.Role != models.RoleAdmin {
+			utils.RespondError(c, http.StatusBadRequest, "Receiving manager must have the manager or admin role")
+			return
+		}
+	}
+
+	var open int64
+	requestDB(c).Model(&models.TransferRequest{}).
+		Where("employee_id = ? AND status IN ?", employee.ID, []models.TransferStatus{models.TransferStatusPending, models.TransferStatusApproved}).
+		Count(&open)
+	if open > 0 {
+		utils.RespondError(c, http.StatusConflict, "Employee already has an open transfer request")
+		return
+	}
+
+	var employment models.EmploymentDetails
+	requestDB(c).Where("employee_id = ?", employee.ID).First(&employment)
+
+	userID, _ := c.Get("user_id")
+	fromDepartment := employee.Department
+	transfer := models.TransferRequest{
+		EmployeeID:     employee.ID,
+		FromDepartment: &fromDepartment,
+		ToDepartment:   toDepartment,
+		FromPositionID: employee.PositionID,
+		ToPositionID:   req.ToPositionID,
+		FromManagerID:  employment.ManagerID,
+		ToManagerID:    req.ToManagerID,
+		EffectiveDate:  effectiveDate,
+		Reason:         req.Reason,
+		Status:         models.TransferStatusPending,
+		RequestedBy:    userID.(uint),
+	}
+
+	if err := requestDB(c).Create(&transfer).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create transfer request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTransfer, transfer.ID, models.AuditActionCreate, transfer.RequestedBy, c, nil, transfer)
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// transferListFields are the filters and sort keys accepted by the transfer request list
+var transferListFields = ListFields{
+	Filters: map[string]string{"status": "status", "employee_id": "employee_id"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "effective_date": "effective_date", "status": "status",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetTransferRequests lists transfer requests
+// @Summary Get transfer requests
+// @Description List transfer requests. Admins see all requests; managers see requests they raised or that involve them as current or receiving manager (Manager/Admin only)
+// @Tags Core HR - Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status filter (pending, approved, rejected, completed, cancelled)"
+// @Param employee_id query int false "Employee ID filter"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, effective_date, status). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.TransferRequest}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/transfers [get]
+func GetTransferRequests(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Employee").Preload("FromPosition").Preload("ToPosition").
+		Preload("FromManager").Preload("ToManager").Preload("Requester").Preload("Approver")
+
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin {
+		query = query.Where("requested_by = ? OR from_manager_id = ? OR to_manager_id = ?", user.ID, user.ID, user.ID)
+	}
+
+	query, ok = applyListQuery(c, query, transferListFields)
+	if !ok {
+		return
+	}
+
+	var transfers []models.TransferRequest
+	response, err := paginate(query, pagination, &transfers)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch transfer requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTransferRequest returns a single transfer request
+// @Summary Get transfer request
+// @Description Get a transfer request by ID (Manager/Admin only)
+// @Tags Core HR - Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer request ID"
+// @Success 200 {object} models.TransferRequest
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/transfers/{id} [get]
+func GetTransferRequest(c *gin.Context) {
+	transferID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var transfer models.TransferRequest
+	if err := requestDB(c).Preload("Employee").Preload("FromPosition").Preload("ToPosition").
+		Preload("FromManager").Preload("ToManager").Preload("Requester").Preload("Approver").
+		First(&transfer, transferID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Transfer request not found")
+		return
+	}
+
+	if user := getCurrentUser(c); user != nil && user.Role != models.RoleAdmin && !transferInvolves(transfer, user.ID) {
+		utils.RespondError(c, http.StatusForbidden, "You are not involved in this transfer request")
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// ApproveTransferRequest approves a pending transfer request
+// @Summary Approve transfer request
+// @Description Approve a pending transfer. Only the receiving manager or an admin can approve. Transfers effective today or earlier are applied immediately; later ones are applied on their effective date (Manager/Admin only)
+// @Tags Core HR - Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer request ID"
+// @Param request body ReviewTransferRequestRequest false "Review comment"
+// @Success 200 {object} models.TransferRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/transfers/{id}/approve [put]
+func ApproveTransferRequest(c *gin.Context) {
+	reviewTransferRequest(c, models.TransferStatusApproved)
+}
+
+// RejectTransferRequest rejects a pending transfer request
+// @Summary Reject transfer request
+// @Description Reject a pending transfer. Only the receiving manager or an admin can reject (Manager/Admin only)
+// @Tags Core HR - Transfers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer request ID"
+// @Param request body ReviewTransferRequestRequest false "Review comment"
+// @Success 200 {object} models.TransferRequest
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/transfers/{id}/reject [put]
+func RejectTransferRequest(c *gin.Context) {
+	reviewTransferRequest(c, models.TransferStatusRejected)
+}
+
+// CancelTransferRequest cancels a transfer that has not yet been applied
+// @Summary Cancel transfer request
+// @Description Cancel a pending or approved transfer before it takes effect. Only the requester or an admin can cancel (Manager/Admin only)
+// @Tags Core HR - Transfers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer request ID"
+// @Success 200 {object} models.TransferRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/transfers/{id}/cancel [put]
+func CancelTransferRequest(c *gin.Context) {
+	transferID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var transfer models.TransferRequest
+	if err := requestDB(c).First(&transfer, transferID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Transfer request not found")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	if user.Role != models.RoleAdmin && transfer.RequestedBy != user.ID {
+		utils.RespondError(c, http.StatusForbidden, "Only the requester or an admin can cancel this transfer")
+		return
+	}
+	if transfer.Status != models.TransferStatusPending && transfer.Status != models.TransferStatusApproved {
+		utils.RespondError(c, http.StatusBadRequest, "Only pending or approved transfers can be cancelled")
+		return
+	}
+
+	oldValues := transfer
+	transfer.Status = models.TransferStatusCancelled
+	if err := requestDB(c).Save(&transfer).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to cancel transfer request")
+		return
+	}
+
+	createAuditLog(models.AuditEntityTransfer, transfer.ID, models.AuditActionUpdate, user.ID, c, oldValues, transfer)
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+func reviewTransferRequest(c *gin.Context, newStatus models.TransferStatus) {
+	transferID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req ReviewTransferRequestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	var transfer models.TransferRequest
+	if err := requestDB(c).First(&transfer, transferID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Transfer request not found")
+		return
+	}
+
+	user := getCurrentUser(c)
+	if user == nil {
+		utils.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+	// The receiving manager approves; without one, the decision falls to an admin
+	if user.Role != models.RoleAdmin && (transfer.ToManagerID == nil || *transfer.ToManagerID != user.ID) {
+		utils.RespondError(c, http.StatusForbidden, "Only the receiving manager or an admin can review this transfer")
+		return
+	}
+	if transfer.Status != models.TransferStatusPending {
+		utils.RespondError(c, http.StatusBadRequest, "Transfer request has already been reviewed")
+		return
+	}
+
+	oldValues := transfer
+	now := time.Now()
+	transfer.Status = newStatus
+	transfer.ApprovedBy = &user.ID
+	transfer.ApprovedAt = &now
+	transfer.ReviewComment = req.Comment
+	if err := requestDB(c).Save(&transfer).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to review transfer request")
+		return
+	}
+
+	action := models.AuditActionReject
+	if newStatus == models.TransferStatusApproved {
+		action = models.AuditActionApprove
+	}
+	createAuditLog(models.AuditEntityTransfer, transfer.ID, action, user.ID, c, oldValues, transfer)
+
+	// Apply straight away when the effective date has already been reached
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if newStatus == models.TransferStatusApproved && !transfer.EffectiveDate.After(today) {
+		applied, err := utils.ApplyTransfer(transfer.ID)
+		if err != nil {
+			c.JSON(http.StatusOK, gin.H{"transfer": transfer, "warning": "Transfer approved but could not be applied yet: " + err.Error()})
+			return
+		}
+		transfer = *applied
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// transferInvolves reports whether the user raised the transfer or is its current or receiving manager
+func transferInvolves(transfer models.TransferRequest, userID uint) bool {
+	if transfer.RequestedBy == userID {
+		return true
+	}
+	if transfer.FromManagerID != nil && *transfer.FromManagerID == userID {
+		return true
+	}
+	return transfer.ToManagerID != nil && *transfer.ToManagerID == userID
+}
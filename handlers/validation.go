@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"hrms-api/i18n"
+	"hrms-api/utils"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why one request field was rejected
+type FieldError struct {
+	Field   string `json:"field" example:"leave_type_id"`
+	Message string `json:"message" example:"leave_type_id is required"`
+}
+
+// ValidationErrorResponse is returned when a request body, form or query fails validation, with
+// the rejected fields as details
+type ValidationErrorResponse struct {
+	ErrorResponse
+	Details []FieldError `json:"details"`
+}
+
+// Report fields by the name the client sent (json, then form tag) rather than the Go field name
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	}
+}
+
+// respondBindError returns 400 for an error from ShouldBind*, listing each rejected field with a
+// readable message, in the request's language, instead of the raw validator output
+func respondBindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	lang := utils.RequestLanguage(c)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, FieldError{Field: fe.Field(), Message: fieldErrorMessage(lang, fe)})
+		}
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeValidationFailed, "Validation failed", fields)
+	case errors.As(err, &typeErr):
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeValidationFailed, "Validation failed", []FieldError{
+			{Field: typeErr.Field, Message: i18n.T(lang, jsonTypeMessage(typeErr.Type), typeErr.Field)},
+		})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		utils.RespondErrorCode(c, http.StatusBadRequest, utils.CodeInvalidJSON, "Request body must be valid JSON", nil)
+	default:
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+	}
+}
+
+// fieldErrorMessage turns a failed validation rule into a sentence about the field
+func fieldErrorMessage(lang i18n.Language, fe validator.FieldError) string {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return i18n.T(lang, "%s is required", field)
+	case "email":
+		return i18n.T(lang, "%s must be a valid email address", field)
+	case "oneof":
+		return i18n.T(lang, "%s must be one of: %s", field, strings.Join(strings.Fields(fe.Param()), ", "))
+	case "min", "max", "len":
+		return i18n.T(lang, sizeMessages[sizeKind(fe.Kind())][fe.Tag()], field, fe.Param())
+	case "gt", "gte", "lt", "lte":
+		return i18n.T(lang, comparisonMessages[fe.Tag()], field, fe.Param())
+	default:
+		return i18n.T(lang, "%s is invalid (%s)", field, fe.Tag())
+	}
+}
+
+// sizeMessages words the min, max and len rules for strings, collections and numbers
+var sizeMessages = map[string]map[string]string{
+	"string": {
+		"min": "%s must be at least %s characters long",
+		"max": "%s must be at most %s characters long",
+		"len": "%s must be exactly %s characters long",
+	},
+	"collection": {
+		"min": "%s must contain at least %s items",
+		"max": "%s must contain at most %s items",
+		"len": "%s must contain exactly %s items",
+	},
+	"number": {
+		"min": "%s must be at least %s",
+		"max": "%s must be at most %s",
+		"len": "%s must be exactly %s",
+	},
+}
+
+var comparisonMessages = map[string]string{
+	"gt":  "%s must be greater than %s",
+	"gte": "%s must be at least %s",
+	"lt":  "%s must be less than %s",
+	"lte": "%s must be at most %s",
+}
+
+func sizeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "collection"
+	default:
+		return "number"
+	}
+}
+
+// jsonTypeMessage says which JSON type a field needs, named the way a JSON client would think of it
+func jsonTypeMessage(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "%s must be a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "%s must be a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "%s must be a number"
+	case reflect.String:
+		return "%s must be a string"
+	case reflect.Slice, reflect.Array:
+		return "%s must be a list"
+	default:
+		return "%s must be an object"
+	}
+}
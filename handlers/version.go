@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"hrms-api/database"
+	"hrms-api/utils"
+	"hrms-api/version"
+	"net/http"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionResponse identifies the build that is running
+type VersionResponse struct {
+	Version       string `json:"version" example:"1.4.0"` // dev for builds that are not releases
+	Commit        string `json:"commit,omitempty" example:"88c818f3b1e4c2d7a9f05e6b2c8d41a7f3e9b0c5"`
+	BuildTime     string `json:"build_time,omitempty" example:"2025-07-01T02:00:00Z"`
+	GoVersion     string `json:"go_version" example:"go1.23.4"`
+	SchemaVersion string `json:"schema_version" example:"3f9a2c41d07a"` // Fingerprint of the tables and columns the build migrates to
+}
+
+// GetVersion reports the version of the build that is running
+// @Summary Get the build version
+// @Description Returns the release version, git commit and build time set when the binary was built, and a fingerprint of the database schema the build migrates to, so operators can check what is deployed. Servers running the same build report the same values
+// @Tags Health
+// @Produce json
+// @Success 200 {object} VersionResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /version [get]
+func GetVersion(c *gin.Context) {
+	schemaVersion, err := database.SchemaVersion()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to read the schema version")
+		return
+	}
+	c.JSON(http.StatusOK, VersionResponse{
+		Version:       version.Version,
+		Commit:        version.Commit,
+		BuildTime:     version.BuildTime,
+		GoVersion:     runtime.Version(),
+		SchemaVersion: schemaVersion,
+	})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"hrms-api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConflictResponse is returned when an update was based on a version of the record that has since changed
+type ConflictResponse struct {
+	ErrorResponse
+	Details interface{} `json:"details"` // The record as it is now, including its current version
+}
+
+// saveVersioned writes every column of a record with a Version column, but only if the stored version
+// still equals expected, and stores the next version in *version. It reports false when the record
+// was changed in the meantime, in which case nothing is written.
+func saveVersioned(db *gorm.DB, model interface{}, version *uint, expected uint) (bool, error) {
+	*version = expected + 1
+	result := db.Model(model).Where("version = ?", expected).
+		Select("*").Omit(clause.Associations, "CreatedAt").
+		Updates(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// respondStaleVersion returns 409 Conflict with the record as it is now, so the client can merge its
+// changes and resubmit with the current version
+func respondStaleVersion(c *gin.Context, current interface{}) {
+	utils.RespondErrorCode(c, http.StatusConflict, utils.CodeStaleVersion,
+		"Record was changed by someone else. Reload and try again", current)
+}
@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// webhookMinSecretLength is the shortest signing secret accepted from the caller
+const webhookMinSecretLength = 16
+
+// WebhookSubscriptionRequest represents data for creating or updating a webhook subscription
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required" example:"https://payroll.example.com/hooks/hrms"`
+	Description *string  `json:"description,omitempty" example:"Payroll leave sync"`
+	EventTypes  []string `json:"event_types" binding:"required" example:"leave_approved,leave_cancelled"` // leave_submitted, leave_approved, leave_rejected, leave_cancelled
+	Secret      *string  `json:"secret,omitempty" example:"a-long-shared-secret"`                         // Generated when omitted on create; a new value rotates the secret
+	IsActive    *bool    `json:"is_active,omitempty" example:"true"`
+}
+
+// WebhookSubscriptionResponse is a webhook subscription, with its signing secret when it has just been set
+type WebhookSubscriptionResponse struct {
+	models.WebhookSubscription
+	Secret string `json:"secret,omitempty" example:"whsec_3f9a..."`
+}
+
+var webhookDeliveryListFields = ListFields{
+	Filters: map[string]string{"subscription_id": "subscription_id", "event_type": "event_type", "status": "status"},
+	Sorts: map[string]string{
+		"id": "id", "created_at": "created_at", "updated_at": "updated_at", "attempts": "attempts", "next_attempt_at": "next_attempt_at",
+	},
+	DefaultSort: "-created_at",
+}
+
+// GetWebhookSubscriptions lists webhook subscriptions
+// @Summary Get webhook subscriptions
+// @Description List webhook subscriptions. Signing secrets are not returned (Admin only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/webhooks [get]
+func GetWebhookSubscriptions(c *gin.Context) {
+	var subscriptions []models.WebhookSubscription
+	requestDB(c).Order("id").Find(&subscriptions)
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// CreateWebhookSubscription registers a webhook endpoint
+// @Summary Create webhook subscription
+// @Description Register an endpoint to receive the given event types. Each delivery is a JSON POST signed with the subscription secret: X-Webhook-Signature is sha256= followed by the hex HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>". The secret is only returned here and when it is rotated (Admin only)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body WebhookSubscriptionRequest true "Subscription"
+// @Success 201 {object} WebhookSubscriptionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/webhooks [post]
+func CreateWebhookSubscription(c *gin.Context) {
+	var req WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	eventTypes, err := validateWebhookRequest(req)
+	if err != nil {
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+		return
+	}
+
+	secret := ""
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else if secret, err = utils.GenerateWebhookSecret(); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate secret")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	subscription := models.WebhookSubscription{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		IsActive:    true,
+		CreatedBy:   userID.(uint),
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	if err := requestDB(c).Create(&subscription).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create webhook subscription")
+		return
+	}
+
+	createAuditLog(models.AuditEntityWebhook, subscription.ID, models.AuditActionCreate, userID.(uint), c, nil, subscription)
+
+	c.JSON(http.StatusCreated, WebhookSubscriptionResponse{WebhookSubscription: subscription, Secret: secret})
+}
+
+// UpdateWebhookSubscription updates a webhook subscription
+// @Summary Update webhook subscription
+// @Description Change a subscription's URL, event types or active flag, or rotate its secret by sending a new one. Deactivated subscriptions receive no new deliveries and their pending retries are given up (Admin only)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Param request body WebhookSubscriptionRequest true "Subscription"
+// @Success 200 {object} WebhookSubscriptionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/webhooks/{id} [put]
+func UpdateWebhookSubscription(c *gin.Context) {
+	subscriptionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	eventTypes, err := validateWebhookRequest(req)
+	if err != nil {
+		utils.RespondErrorFrom(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var subscription models.WebhookSubscription
+	if err := requestDB(c).First(&subscription, subscriptionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Webhook subscription not found")
+		return
+	}
+	oldSubscription := subscription
+
+	subscription.URL = req.URL
+	subscription.Description = req.Description
+	subscription.EventTypes = eventTypes
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	response := WebhookSubscriptionResponse{}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+		response.Secret = *req.Secret
+	}
+	if err := requestDB(c).Save(&subscription).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to update webhook subscription")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityWebhook, subscription.ID, models.AuditActionUpdate, userID.(uint), c, oldSubscription, subscription)
+
+	response.WebhookSubscription = subscription
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription
+// @Summary Delete webhook subscription
+// @Description Delete a subscription. Its delivery log is kept and its pending retries are given up (Admin only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/webhooks/{id} [delete]
+func DeleteWebhookSubscription(c *gin.Context) {
+	subscriptionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var subscription models.WebhookSubscription
+	if err := requestDB(c).First(&subscription, subscriptionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Webhook subscription not found")
+		return
+	}
+
+	if err := requestDB(c).Delete(&subscription).Error; err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to delete webhook subscription")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createAuditLog(models.AuditEntityWebhook, subscription.ID, models.AuditActionDelete, userID.(uint), c, subscription, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook subscription deleted successfully"})
+}
+
+// TestWebhookSubscription sends a ping event to a webhook endpoint
+// @Summary Test webhook subscription
+// @Description Send a signed ping event to the endpoint now and return the delivery with the endpoint's response. Test deliveries are logged but not retried (Admin only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/webhooks/{id}/test [post]
+func TestWebhookSubscription(c *gin.Context) {
+	subscriptionID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var subscription models.WebhookSubscription
+	if err := requestDB(c).First(&subscription, subscriptionID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Webhook subscription not found")
+		return
+	}
+
+	event := utils.Event{Type: utils.EventWebhookPing, Data: gin.H{"subscription_id": subscription.ID}, OccurredAt: time.Now()}
+	delivery, err := utils.QueueWebhookDelivery(subscription, event)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to create test delivery")
+		return
+	}
+
+	if err := utils.DeliverWebhook(&delivery); err != nil && delivery.Status == models.WebhookDeliveryPending {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		requestDB(c).Model(&delivery).Updates(map[string]interface{}{"status": delivery.Status, "next_attempt_at": nil})
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// GetWebhookDeliveries lists webhook deliveries for debugging integrations
+// @Summary Get webhook deliveries
+// @Description List webhook deliveries with their attempts, the endpoint's last response and the last error. Use status=failed to find deliveries that were given up (Admin only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param subscription_id query int false "Subscription ID"
+// @Param event_type query string false "Event type"
+// @Param status query string false "Status (pending, succeeded, failed)"
+// @Param sort query string false "Sort keys, comma separated, - prefix for descending (id, created_at, updated_at, attempts, next_attempt_at). Defaults to -created_at"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 25, max 100)"
+// @Success 200 {object} PaginatedResponse{data=[]models.WebhookDelivery}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/webhooks/deliveries [get]
+func GetWebhookDeliveries(c *gin.Context) {
+	pagination, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	query := requestDB(c).Preload("Subscription", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+	query, ok = applyListQuery(c, query, webhookDeliveryListFields)
+	if !ok {
+		return
+	}
+
+	var deliveries []models.WebhookDelivery
+	response, err := paginate(query, pagination, &deliveries)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to fetch webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RetryWebhookDelivery re-sends a webhook delivery now
+// @Summary Retry webhook delivery
+// @Description Re-send a pending or failed delivery now and return it with the endpoint's response. A delivery that fails again is given up if it has used all its attempts (Admin only)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/webhooks/deliveries/{id}/retry [post]
+func RetryWebhookDelivery(c *gin.Context) {
+	deliveryID, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var delivery models.WebhookDelivery
+	if err := requestDB(c).Preload("Subscription").First(&delivery, deliveryID).Error; err != nil {
+		utils.RespondError(c, http.StatusNotFound, "Webhook delivery not found")
+		return
+	}
+	if delivery.Status == models.WebhookDeliverySucceeded {
+		utils.RespondError(c, http.StatusBadRequest, "Delivery has already succeeded")
+		return
+	}
+	if delivery.Subscription.ID == 0 {
+		utils.RespondError(c, http.StatusBadRequest, "Webhook subscription has been deleted")
+		return
+	}
+
+	utils.DeliverWebhook(&delivery)
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// validateWebhookRequest checks the endpoint URL, event types and secret, returning the event types
+// comma-separated for storage
+func validateWebhookRequest(req WebhookSubscriptionRequest) (string, error) {
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return "", fmt.Errorf("URL must be an absolute http or https URL")
+	}
+
+	if len(req.EventTypes) == 0 {
+		return "", fmt.Errorf("At least one event type is required")
+	}
+	seen := map[string]bool{}
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		if !utils.IsWebhookEventType(eventType) {
+			return "", fmt.Errorf("Invalid event type: %s", eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	if req.Secret != nil && len(*req.Secret) < webhookMinSecretLength {
+		return "", fmt.Errorf("Secret must be at least %d characters", webhookMinSecretLength)
+	}
+
+	return strings.Join(eventTypes, ","), nil
+}
@@ -0,0 +1,50 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var monthNames = map[Language][12]string{
+	French:     {"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	Portuguese: {"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+}
+
+// FormatDate formats a date the way it is written in lang: 2025-03-31 in English, and 31/03/2025 in
+// French and Portuguese
+func FormatDate(lang Language, date time.Time) string {
+	if lang == French || lang == Portuguese {
+		return date.Format("02/01/2006")
+	}
+	return date.Format("2006-01-02")
+}
+
+// FormatDateTime formats a date and time the way it is written in lang, on the 24-hour clock
+func FormatDateTime(lang Language, t time.Time) string {
+	return FormatDate(lang, t) + " " + t.Format("15:04")
+}
+
+// FormatMonth names the month of a date with its year in lang: March 2025, mars 2025 or março de 2025
+func FormatMonth(lang Language, date time.Time) string {
+	names, ok := monthNames[lang]
+	if !ok {
+		return date.Format("January 2006")
+	}
+	name := names[date.Month()-1]
+	if lang == Portuguese {
+		return fmt.Sprintf("%s de %d", name, date.Year())
+	}
+	return fmt.Sprintf("%s %d", name, date.Year())
+}
+
+// FormatNumber formats a number without trailing zeros, with the decimal separator of lang: 1.5 in
+// English, and 1,5 in French and Portuguese
+func FormatNumber(lang Language, number float64) string {
+	formatted := strconv.FormatFloat(number, 'f', -1, 64)
+	if lang == French || lang == Portuguese {
+		formatted = strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
@@ -0,0 +1,133 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Messages are looked up by their English text, so English needs no catalog and any message
+// missing from a catalog falls back to English. Catalogs live in locales/<language>.json.
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Language is a supported language, identified by its ISO 639-1 code
+type Language string
+
+const (
+	English    Language = "en"
+	French     Language = "fr"
+	Portuguese Language = "pt"
+)
+
+// Default is used when the client asks for no supported language
+const Default = English
+
+var catalogs = map[Language]map[string]string{}
+
+func init() {
+	for _, lang := range []Language{French, Portuguese} {
+		data, err := localeFiles.ReadFile("locales/" + string(lang) + ".json")
+		if err != nil {
+			log.Fatalf("i18n: missing catalog for %s: %v", lang, err)
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			log.Fatalf("i18n: invalid catalog for %s: %v", lang, err)
+		}
+		catalogs[lang] = catalog
+	}
+}
+
+// Parse returns the supported language for a language tag such as "pt-BR", ignoring the region
+func Parse(tag string) (Language, bool) {
+	primary := strings.ToLower(strings.TrimSpace(strings.SplitN(tag, "-", 2)[0]))
+	switch lang := Language(primary); lang {
+	case English, French, Portuguese:
+		return lang, true
+	}
+	return "", false
+}
+
+// Negotiate picks the supported language the client prefers most from an Accept-Language header,
+// falling back to Default
+func Negotiate(acceptLanguage string) Language {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		lang, ok := Parse(fields[0])
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if q, found := strings.CutPrefix(strings.TrimSpace(param), "q="); found {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang
+}
+
+// T translates message into lang and formats it with args like fmt.Sprintf. Messages without a
+// translation are formatted in English. Message args are translated into the same language.
+func T(lang Language, message string, args ...interface{}) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	rendered := make([]interface{}, len(args))
+	for i, arg := range args {
+		if m, ok := arg.(Message); ok {
+			arg = m.In(lang)
+		}
+		rendered[i] = arg
+	}
+	return fmt.Sprintf(message, rendered...)
+}
+
+// Message is text to be translated later, once the reader's language is known, such as a
+// notification for another employee
+type Message struct {
+	key          string
+	args         []interface{}
+	untranslated bool
+}
+
+// M returns a message that is translated and formatted with args when rendered
+func M(message string, args ...interface{}) Message {
+	return Message{key: message, args: args}
+}
+
+// Untranslated returns a message that is rendered as is in every language, for text written by users
+func Untranslated(text string) Message {
+	return Message{key: text, untranslated: true}
+}
+
+// In renders the message in lang
+func (m Message) In(lang Language) string {
+	if m.untranslated {
+		return m.key
+	}
+	return T(lang, m.key, m.args...)
+}
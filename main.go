@@ -1,12 +1,23 @@
 package main
 
 import (
+	"context"
+	"hrms-api/cli"
 	"hrms-api/config"
 	"hrms-api/database"
 	_ "hrms-api/docs"
+	"hrms-api/grpcapi"
 	"hrms-api/routes"
 	"hrms-api/scheduler"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"hrms-api/version"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,9 +47,23 @@ func main() {
 		log.Fatal("Failed to load config:", err)
 	}
 
+	// "hrms-api admin <command>" runs a maintenance command instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		if err := cli.RunAdmin(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Set Gin mode
 	gin.SetMode(config.AppConfig.GinMode)
 
+	// Set up tracing before anything creates spans
+	if err := telemetry.Init(); err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	defer telemetry.Shutdown()
+
 	// Connect to database
 	if err := database.Connect(); err != nil {
 		log.Fatal("Failed to connect to database:", err)
@@ -49,9 +74,21 @@ func main() {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
-	// Seed initial data
-	if err := database.SeedData(); err != nil {
-		log.Fatal("Failed to seed database:", err)
+	// Seed reference data and the initial admin account (opt-in with SEED_DATA)
+	if config.AppConfig.SeedData {
+		if err := database.SeedData(); err != nil {
+			log.Fatal("Failed to seed database:", err)
+		}
+	}
+
+	// Encrypt OAuth tokens stored before tokens were encrypted at rest
+	if err := utils.EncryptStoredTokens(); err != nil {
+		log.Fatal("Failed to encrypt stored tokens:", err)
+	}
+
+	// Load runtime settings before anything reads them
+	if err := utils.LoadSettings(); err != nil {
+		log.Fatal("Failed to load settings:", err)
 	}
 
 	// Setup routes
@@ -59,12 +96,96 @@ func main() {
 
 	// Start automatic accrual scheduler
 	scheduler.StartAccrualScheduler()
-	defer scheduler.StopAccrualScheduler()
+
+	// Start scheduler that applies approved transfers on their effective date
+	scheduler.StartTransferScheduler()
+
+	// Start daily compliance expiry checks and reminders
+	scheduler.StartComplianceScheduler()
+
+	// Start daily absence marking for attendance
+	scheduler.StartAttendanceScheduler()
+
+	// Start hourly grievance SLA escalation
+	scheduler.StartGrievanceScheduler()
+
+	// Start retries of failed webhook deliveries
+	scheduler.StartWebhookScheduler()
+
+	// Start retries of failed notification emails
+	scheduler.StartNotificationScheduler()
+
+	// Start daily purges of records past their retention policy
+	scheduler.StartRetentionScheduler()
+
+	// Start watching for runtime settings changed by other servers
+	scheduler.StartSettingsScheduler()
+
+	// Start recording API key usage, which daily quotas are checked against
+	scheduler.StartAPIKeyUsageScheduler()
+
+	// Start retrying leaves that could not be synced to connected calendars
+	scheduler.StartCalendarScheduler()
+
+	// Start monthly imports of public holidays for review
+	scheduler.StartHolidayScheduler()
+
+	// Start picking up queued export jobs and deleting expired export files
+	scheduler.StartExportScheduler()
+
+	// Start extracting the text of stored documents for content search, when TEXT_EXTRACTOR is set
+	scheduler.StartTextExtractionScheduler()
+
+	// Start the gRPC server for internal services, when GRPC_PORT is set
+	grpcapi.Start()
 
 	// Start server - bind to all interfaces (0.0.0.0) to allow network access
-	address := "0.0.0.0:" + config.AppConfig.Port
-	log.Printf("Server starting on %s", address)
-	if err := r.Run(address); err != nil {
-		log.Fatal("Failed to start server:", err)
+	server := &http.Server{
+		Addr:         "0.0.0.0:" + config.AppConfig.Port,
+		Handler:      r,
+		ReadTimeout:  time.Duration(config.AppConfig.HTTPReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(config.AppConfig.HTTPWriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(config.AppConfig.HTTPIdleTimeout) * time.Second,
+	}
+	// Event streams would otherwise keep Shutdown waiting until it times out
+	server.RegisterOnShutdown(utils.CloseEventStreams)
+	redirectServer := configureTLS(server)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		log.Printf("Server %s (commit %s) starting on %s (TLS: %t)", version.Version, version.Commit, server.Addr, config.AppConfig.TLSEnabled())
+		if err := listenAndServe(server); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+	if redirectServer != nil {
+		go func() {
+			log.Printf("Redirecting HTTP to HTTPS on %s", redirectServer.Addr)
+			if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatal("Failed to start HTTP redirect server:", err)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	stop() // A second signal kills the process immediately
+	log.Println("Shutting down - finishing in-flight requests and background jobs...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(config.AppConfig.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server did not shut down cleanly: %v", err)
+	}
+	if redirectServer != nil {
+		redirectServer.Shutdown(shutdownCtx)
 	}
+	grpcapi.Stop(shutdownCtx)
+	if err := scheduler.StopAll(shutdownCtx); err != nil {
+		log.Printf("Background jobs were still running at shutdown: %v", err)
+	}
+
+	log.Println("Server stopped")
 }
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"hrms-api/database"
 	"hrms-api/models"
 	"hrms-api/utils"
 	"net/http"
@@ -13,14 +14,14 @@ func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			utils.RespondError(c, http.StatusUnauthorized, "Authorization header required")
 			c.Abort()
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			utils.RespondError(c, http.StatusUnauthorized, "Invalid authorization header format")
 			c.Abort()
 			return
 		}
@@ -28,13 +29,14 @@ func AuthMiddleware() gin.HandlerFunc {
 		tokenString := parts[1]
 		claims, err := utils.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			utils.RespondErrorCode(c, http.StatusUnauthorized, utils.CodeInvalidToken, "Invalid or expired token", nil)
 			c.Abort()
 			return
 		}
 
 		// Store user info in context
 		c.Set("user_id", claims.UserID)
+		c.Set("organization_id", claims.OrganizationID)
 		c.Set("nrc", claims.NRC)
 		c.Set("role", claims.Role)
 
@@ -42,18 +44,31 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// QueryTokenAuth lets clients that cannot set headers, such as a browser EventSource, pass the JWT in the
+// token query parameter. It must run before AuthMiddleware.
+func QueryTokenAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
 func RequireRole(allowedRoles ...models.Role) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Role not found in token"})
+			utils.RespondError(c, http.StatusUnauthorized, "Role not found in token")
 			c.Abort()
 			return
 		}
 
 		userRole, ok := role.(models.Role)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid role type"})
+			utils.RespondError(c, http.StatusUnauthorized, "Invalid role type")
 			c.Abort()
 			return
 		}
@@ -67,7 +82,7 @@ func RequireRole(allowedRoles ...models.Role) gin.HandlerFunc {
 		}
 
 		if !allowed {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			utils.RespondError(c, http.StatusForbidden, "Insufficient permissions")
 			c.Abort()
 			return
 		}
@@ -76,3 +91,23 @@ func RequireRole(allowedRoles ...models.Role) gin.HandlerFunc {
 	}
 }
 
+// RequirePayrollAccess only lets through users who have been granted payroll access
+func RequirePayrollAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			utils.RespondError(c, http.StatusUnauthorized, "User not found in token")
+			c.Abort()
+			return
+		}
+
+		var employee models.Employee
+		if err := database.DB.WithContext(c.Request.Context()).Select("id", "payroll_access").First(&employee, userID).Error; err != nil || !employee.PayrollAccess {
+			utils.RespondError(c, http.StatusForbidden, "Payroll access required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compressibleTypes are the content types worth compressing; files such as xlsx, pdf and zip already are
+var compressibleTypes = []string{
+	"application/json", "application/javascript", "application/xml", "image/svg+xml", "text/",
+}
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		writer, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return writer
+	},
+}
+
+// compressingWriter gzips a response as it is written once its content type turns out to be worth it.
+// Event streams and responses that are already encoded pass straight through.
+type compressingWriter struct {
+	gin.ResponseWriter
+	gzip    *gzip.Writer
+	decided bool
+}
+
+func (w *compressingWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		if w.shouldCompress() {
+			header := w.Header()
+			header.Set("Content-Encoding", "gzip")
+			header.Del("Content-Length")
+			w.gzip = gzipWriters.Get().(*gzip.Writer)
+			w.gzip.Reset(w.ResponseWriter)
+		}
+	}
+	if w.gzip != nil {
+		return w.gzip.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what has been compressed so far, so streamed responses keep flowing
+func (w *compressingWriter) Flush() {
+	if w.gzip != nil {
+		w.gzip.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressingWriter) shouldCompress() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || w.Status() == http.StatusPartialContent {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	for _, compressible := range compressibleTypes {
+		if strings.HasPrefix(contentType, compressible) {
+			return !strings.HasPrefix(contentType, "text/event-stream")
+		}
+	}
+	return false
+}
+
+// close ends the compressed stream, if one was started
+func (w *compressingWriter) close() {
+	if w.gzip == nil {
+		return
+	}
+	w.gzip.Close()
+	gzipWriters.Put(w.gzip)
+	w.gzip = nil
+}
+
+// Compress gzips JSON, text and other compressible responses for clients that accept it, as the
+// company-wide balance and calendar responses run to megabytes of JSON. Responses are compressed as
+// they are written, so streamed ones are not buffered.
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+		c.Header("Vary", "Accept-Encoding")
+
+		writer := &compressingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			writer.close()
+			c.Writer = writer.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding allows gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"hrms-api/config"
+	"hrms-api/utils"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS lets browsers call the API from the origins in CORS_ALLOWED_ORIGINS and the cors_allowed_origins
+// runtime setting. Same-origin requests, such as those of the web app and Swagger UI served by the API
+// itself, and requests without an Origin header are always allowed.
+func CORS() gin.HandlerFunc {
+	patterns := config.AppConfig.CORSAllowedOrigins
+	return cors.New(cors.Config{
+		AllowOriginFunc: func(origin string) bool {
+			return config.OriginAllowed(patterns, origin) || utils.CORSOriginAllowed(origin)
+		},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
+		ExposeHeaders:    []string{"Content-Length", "X-Request-Id"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	})
+}
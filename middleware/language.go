@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"hrms-api/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language picks the language for the response from the Accept-Language header, falling back to
+// English, and reports it in the Content-Language header
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set("language", lang)
+		c.Header("Content-Language", string(lang))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
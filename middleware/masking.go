@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"bytes"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maskingWriter holds back JSON responses so their personal fields can be masked before they are sent.
+// Other responses, such as files, event streams and JSON streamed by handlers that call
+// utils.SkipPIIMasking, pass straight through.
+type maskingWriter struct {
+	gin.ResponseWriter
+	context   *gin.Context
+	body      bytes.Buffer
+	decided   bool
+	buffering bool
+}
+
+func (w *maskingWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		w.buffering = strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") &&
+			!w.context.GetBool(utils.SkipPIIMaskingKey)
+	}
+	if w.buffering {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *maskingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// MaskPII masks the NRC, date of birth and address of other people's records in JSON responses, unless
+// the caller is an admin or has been granted PII access. Callers always see their own records in full.
+// It must run after AuthMiddleware.
+func MaskPII() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if role, _ := c.Get("role"); role == models.RoleAdmin {
+			c.Next()
+			return
+		}
+
+		writer := &maskingWriter{ResponseWriter: c.Writer, context: c}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if !writer.buffering {
+			return
+		}
+		body := writer.body.Bytes()
+		if utils.ContainsPersonalFields(body) {
+			userID := c.GetUint("user_id")
+			if masked, changed, err := utils.MaskPersonalData(body, userID); err == nil && changed && !hasPIIAccess(c, userID) {
+				body = masked
+				c.Writer.Header().Del("Content-Length")
+			}
+		}
+		c.Writer.Write(body)
+	}
+}
+
+// hasPIIAccess reports whether the user has been granted access to other people's unmasked personal data
+func hasPIIAccess(c *gin.Context, userID uint) bool {
+	var employee models.Employee
+	err := database.DB.WithContext(c.Request.Context()).Select("id", "pii_access").First(&employee, userID).Error
+	return err == nil && employee.PIIAccess
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"hrms-api/database"
+	"hrms-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tenancy confines every query made with the request's context to the caller's organization. It must
+// run after AuthMiddleware. Tokens issued before organizations were introduced carry no organization
+// and are treated as belonging to the default one.
+func Tenancy() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		organizationID := c.GetUint("organization_id")
+		if organizationID == 0 {
+			organizationID = models.DefaultOrganizationID
+		}
+		c.Set("organization_id", organizationID)
+		c.Request = c.Request.WithContext(database.WithOrganization(c.Request.Context(), organizationID))
+		c.Next()
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hrms-api/telemetry"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Tracing starts a server span for each request, continuing the caller's trace when it sends a
+// traceparent header. Swagger and health probes are not traced.
+func Tracing(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !strings.HasPrefix(r.URL.Path, "/swagger") && !strings.HasPrefix(r.URL.Path, "/health")
+	}))
+}
+
+// TraceID exposes the request's trace ID in the X-Trace-Id header and adds it as trace_id to JSON
+// error responses, so a failed request reported by a client can be found in the tracing backend.
+// It must run after Tracing.
+func TraceID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := telemetry.TraceID(c.Request.Context())
+		if traceID == "" {
+			c.Next()
+			return
+		}
+
+		c.Set("trace_id", traceID)
+		c.Header("X-Trace-Id", traceID)
+
+		writer := &traceErrorWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		writer.flush(traceID)
+	}
+}
+
+// LogFormatter is gin's default request log line with the trace ID appended
+func LogFormatter(param gin.LogFormatterParams) string {
+	traceID, _ := param.Keys["trace_id"].(string)
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v trace_id=%s\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+		traceID,
+		param.ErrorMessage,
+	)
+}
+
+// traceErrorWriter holds back JSON error bodies so the trace ID can be added before they are sent
+type traceErrorWriter struct {
+	gin.ResponseWriter
+	buffer      *bytes.Buffer
+	passthrough bool
+}
+
+// Unwrap lets http.ResponseController reach the underlying connection
+func (w *traceErrorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *traceErrorWriter) Write(data []byte) (int, error) {
+	if w.buffering() {
+		return w.buffer.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *traceErrorWriter) WriteString(s string) (int, error) {
+	if w.buffering() {
+		return w.buffer.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// buffering decides on the first write whether the response is a JSON error
+func (w *traceErrorWriter) buffering() bool {
+	if w.buffer == nil && !w.passthrough && !w.Written() {
+		if w.Status() >= http.StatusBadRequest && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			w.buffer = &bytes.Buffer{}
+		} else {
+			w.passthrough = true
+		}
+	}
+	return w.buffer != nil
+}
+
+func (w *traceErrorWriter) flush(traceID string) {
+	if w.buffer == nil {
+		return
+	}
+	body := w.buffer.Bytes()
+	var payload map[string]interface{}
+	if json.Unmarshal(body, &payload) == nil {
+		payload["trace_id"] = traceID
+		if withTraceID, err := json.Marshal(payload); err == nil {
+			body = withTraceID
+		}
+	}
+	w.buffer = nil
+	w.ResponseWriter.Write(body)
+}
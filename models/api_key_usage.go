@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKeyUsage counts the calls an internal service made with an API key to a gRPC method on a day
+type APIKeyUsage struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	KeyName   string    `gorm:"size:100;not null;uniqueIndex:idx_api_key_usage_key_method_date" json:"key_name"`
+	Method    string    `gorm:"size:255;not null;uniqueIndex:idx_api_key_usage_key_method_date" json:"method"` // Full gRPC method name
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_api_key_usage_key_method_date;index" json:"date"`
+	Calls     int64     `gorm:"not null;default:0" json:"calls"`
+	Rejected  int64     `gorm:"not null;default:0" json:"rejected"` // Calls refused for exceeding the key's limits
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (APIKeyUsage) TableName() string {
+	return "api_key_usages"
+}
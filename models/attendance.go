@@ -0,0 +1,166 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent AttendanceStatus = "present"
+	AttendanceStatusLate    AttendanceStatus = "late"
+	AttendanceStatusAbsent  AttendanceStatus = "absent"
+	AttendanceStatusOnLeave AttendanceStatus = "on_leave"
+)
+
+type AttendanceCorrectionStatus string
+
+const (
+	AttendanceCorrectionPending  AttendanceCorrectionStatus = "pending"
+	AttendanceCorrectionApproved AttendanceCorrectionStatus = "approved"
+	AttendanceCorrectionRejected AttendanceCorrectionStatus = "rejected"
+)
+
+// WorkSchedule defines expected working hours. Employees are matched to a schedule by the
+// name in EmploymentDetails.WorkSchedule, falling back to the default schedule.
+type WorkSchedule struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	Name         string         `gorm:"uniqueIndex;size:50;not null" json:"name"`
+	StartTime    string         `gorm:"size:5;not null" json:"start_time"` // HH:MM
+	EndTime      string         `gorm:"size:5;not null" json:"end_time"`   // HH:MM
+	GraceMinutes int            `gorm:"default:0" json:"grace_minutes"`
+	WorkDays     string         `gorm:"size:20;default:'1,2,3,4,5'" json:"work_days"` // Comma-separated weekdays, 0=Sunday
+	IsDefault    bool           `gorm:"default:false" json:"is_default"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (WorkSchedule) TableName() string {
+	return "work_schedules"
+}
+
+// AttendanceRecord holds one employee's attendance for one day
+type AttendanceRecord struct {
+	ID                uint             `gorm:"primaryKey" json:"id"`
+	EmployeeID        uint             `gorm:"not null;uniqueIndex:idx_attendance_employee_date" json:"employee_id"`
+	Date              time.Time        `gorm:"type:date;not null;uniqueIndex:idx_attendance_employee_date;index" json:"date"`
+	ClockIn           *time.Time       `json:"clock_in,omitempty"`
+	ClockOut          *time.Time       `json:"clock_out,omitempty"`
+	ClockInIP         *string          `gorm:"type:varchar(45)" json:"clock_in_ip,omitempty"`
+	ClockOutIP        *string          `gorm:"type:varchar(45)" json:"clock_out_ip,omitempty"`
+	ClockInLatitude   *float64         `json:"clock_in_latitude,omitempty"`
+	ClockInLongitude  *float64         `json:"clock_in_longitude,omitempty"`
+	ClockOutLatitude  *float64         `json:"clock_out_latitude,omitempty"`
+	ClockOutLongitude *float64         `json:"clock_out_longitude,omitempty"`
+	Status            AttendanceStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	MinutesLate       int              `gorm:"default:0" json:"minutes_late"`
+	WorkedMinutes     int              `gorm:"default:0" json:"worked_minutes"`
+	Notes             *string          `gorm:"type:text" json:"notes,omitempty"`
+	Corrected         bool             `gorm:"default:false" json:"corrected"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+func (AttendanceRecord) TableName() string {
+	return "attendance_records"
+}
+
+// AttendanceCorrection is a request to fix the clock times of an attendance day.
+// It is reviewed by the employee's manager or an admin.
+type AttendanceCorrection struct {
+	ID                uint                       `gorm:"primaryKey" json:"id"`
+	EmployeeID        uint                       `gorm:"not null;index" json:"employee_id"`
+	AttendanceID      *uint                      `gorm:"index" json:"attendance_id,omitempty"`
+	Date              time.Time                  `gorm:"type:date;not null" json:"date"`
+	RequestedClockIn  *time.Time                 `json:"requested_clock_in,omitempty"`
+	RequestedClockOut *time.Time                 `json:"requested_clock_out,omitempty"`
+	Reason            string                     `gorm:"type:text;not null" json:"reason"`
+	Status            AttendanceCorrectionStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	RequestedBy       uint                       `gorm:"not null;index" json:"requested_by"`
+	ReviewedBy        *uint                      `gorm:"index" json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time                 `json:"reviewed_at,omitempty"`
+	ReviewComment     *string                    `gorm:"type:text" json:"review_comment,omitempty"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt             `gorm:"index" json:"-"`
+
+	Employee   Employee          `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Attendance *AttendanceRecord `gorm:"foreignKey:AttendanceID" json:"attendance,omitempty"`
+	Requester  *Employee         `gorm:"foreignKey:RequestedBy" json:"requester,omitempty"`
+	Reviewer   *Employee         `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+func (AttendanceCorrection) TableName() string {
+	return "attendance_corrections"
+}
+
+// ClockDirection is whether a clock event was an arrival or a departure. Terminals that do not
+// record it leave it empty, and the first and last events of the day are taken instead.
+type ClockDirection string
+
+const (
+	ClockDirectionIn  ClockDirection = "in"
+	ClockDirectionOut ClockDirection = "out"
+)
+
+type ClockEventStatus string
+
+const (
+	ClockEventApplied   ClockEventStatus = "applied"   // Counted towards the employee's attendance
+	ClockEventDuplicate ClockEventStatus = "duplicate" // A repeat of an event already counted, such as a badge swiped twice
+	ClockEventUnmatched ClockEventStatus = "unmatched" // No employee has the badge; matched again when the badge is assigned
+	ClockEventIgnored   ClockEventStatus = "ignored"   // The day's attendance has been corrected by hand
+)
+
+type ClockEventSource string
+
+const (
+	ClockEventSourceDevice ClockEventSource = "device" // Pushed by the terminal
+	ClockEventSourceCSV    ClockEventSource = "csv"    // Imported from a file exported from the terminal
+)
+
+// AttendanceDevice is a biometric or badge terminal that sends clock events. It authenticates with a
+// key of its own, of which only the hash is kept.
+type AttendanceDevice struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	OrganizationID uint       `gorm:"not null;default:1;uniqueIndex:idx_attendance_device_serial" json:"organization_id"`
+	DeviceID       string     `gorm:"size:100;not null;uniqueIndex:idx_attendance_device_serial" json:"device_id"` // Serial number or name the terminal reports, used in CSV exports
+	Name           string     `gorm:"size:100;not null" json:"name"`
+	Location       *string    `gorm:"size:100" json:"location,omitempty"`
+	KeyHash        string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	IsActive       bool       `gorm:"default:true" json:"is_active"`
+	LastSeenAt     *time.Time `json:"last_seen_at,omitempty"` // Last time the terminal pushed events
+	CreatedBy      *uint      `json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (AttendanceDevice) TableName() string {
+	return "attendance_devices"
+}
+
+// ClockEvent is one badge swipe or fingerprint read at a terminal. Every event received is kept,
+// including those that did not count towards attendance, so that terminals can resend their logs.
+type ClockEvent struct {
+	ID             uint             `gorm:"primaryKey" json:"id"`
+	OrganizationID uint             `gorm:"not null;default:1;index" json:"organization_id"`
+	DeviceID       uint             `gorm:"not null;uniqueIndex:idx_clock_event_swipe" json:"device_id"` // ID of the AttendanceDevice
+	Badge          string           `gorm:"size:50;not null;uniqueIndex:idx_clock_event_swipe" json:"badge"`
+	OccurredAt     time.Time        `gorm:"not null;uniqueIndex:idx_clock_event_swipe;index" json:"occurred_at"`
+	Direction      ClockDirection   `gorm:"type:varchar(10)" json:"direction,omitempty"`
+	EmployeeID     *uint            `gorm:"index" json:"employee_id,omitempty"`
+	Status         ClockEventStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	Source         ClockEventSource `gorm:"type:varchar(20);not null" json:"source"`
+	CreatedAt      time.Time        `json:"created_at"`
+
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+func (ClockEvent) TableName() string {
+	return "clock_events"
+}
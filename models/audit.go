@@ -7,12 +7,17 @@ import (
 type AuditAction string
 
 const (
-	AuditActionCreate  AuditAction = "CREATE"
-	AuditActionApprove AuditAction = "APPROVE"
-	AuditActionReject  AuditAction = "REJECT"
-	AuditActionCancel  AuditAction = "CANCEL"
-	AuditActionUpdate  AuditAction = "UPDATE"
-	AuditActionDelete  AuditAction = "DELETE"
+	AuditActionCreate    AuditAction = "CREATE"
+	AuditActionApprove   AuditAction = "APPROVE"
+	AuditActionReject    AuditAction = "REJECT"
+	AuditActionCancel    AuditAction = "CANCEL"
+	AuditActionUpdate    AuditAction = "UPDATE"
+	AuditActionDelete    AuditAction = "DELETE"
+	AuditActionView      AuditAction = "VIEW"
+	AuditActionRestore   AuditAction = "RESTORE"
+	AuditActionAnonymize AuditAction = "ANONYMIZE"
+	AuditActionRun       AuditAction = "RUN"
+	AuditActionResend    AuditAction = "RESEND"
 )
 
 type LeaveAudit struct {
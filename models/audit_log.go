@@ -7,17 +7,45 @@ import (
 type AuditEntityType string
 
 const (
-	AuditEntityEmployee    AuditEntityType = "employee"
-	AuditEntityIdentity    AuditEntityType = "identity"
-	AuditEntityEmployment  AuditEntityType = "employment"
-	AuditEntityPosition    AuditEntityType = "position"
-	AuditEntityDocument    AuditEntityType = "document"
-	AuditEntityCompliance  AuditEntityType = "compliance"
-	AuditEntityOnboarding  AuditEntityType = "onboarding"
-	AuditEntityOffboarding AuditEntityType = "offboarding"
-	AuditEntityLifecycle   AuditEntityType = "lifecycle"
-	AuditEntityLeave       AuditEntityType = "leave"
-	AuditEntityLeaveType   AuditEntityType = "leave_type"
+	AuditEntityEmployee      AuditEntityType = "employee"
+	AuditEntityIdentity      AuditEntityType = "identity"
+	AuditEntityEmployment    AuditEntityType = "employment"
+	AuditEntityPosition      AuditEntityType = "position"
+	AuditEntityDocument      AuditEntityType = "document"
+	AuditEntityCompliance    AuditEntityType = "compliance"
+	AuditEntityOnboarding    AuditEntityType = "onboarding"
+	AuditEntityOffboarding   AuditEntityType = "offboarding"
+	AuditEntityLifecycle     AuditEntityType = "lifecycle"
+	AuditEntityLeave         AuditEntityType = "leave"
+	AuditEntityLeaveType     AuditEntityType = "leave_type"
+	AuditEntityHeadcount     AuditEntityType = "headcount"
+	AuditEntityTransfer      AuditEntityType = "transfer"
+	AuditEntityEducation     AuditEntityType = "education"
+	AuditEntitySkill         AuditEntityType = "skill"
+	AuditEntityCertification AuditEntityType = "certification"
+	AuditEntityBankDetails   AuditEntityType = "bank_details"
+	AuditEntityAttendance    AuditEntityType = "attendance"
+	AuditEntityShift         AuditEntityType = "shift"
+	AuditEntityTraining      AuditEntityType = "training"
+	AuditEntityGrievance     AuditEntityType = "grievance"
+	AuditEntityRemoteWork    AuditEntityType = "remote_work"
+	AuditEntityRecognition   AuditEntityType = "recognition"
+	AuditEntityExitInterview AuditEntityType = "exit_interview"
+	AuditEntityWebhook       AuditEntityType = "webhook"
+	AuditEntityRetention     AuditEntityType = "retention_policy"
+	AuditEntityLegalHold     AuditEntityType = "legal_hold"
+	AuditEntitySetting       AuditEntityType = "setting"
+	AuditEntityHoliday       AuditEntityType = "holiday"
+	AuditEntityNationalID    AuditEntityType = "national_id_format"
+	AuditEntityTemplate      AuditEntityType = "document_template"
+	AuditEntityLetter        AuditEntityType = "employment_letter"
+	AuditEntityCompensation  AuditEntityType = "compensation"
+	AuditEntityCostCenter    AuditEntityType = "cost_center"
+	AuditEntityScheduledJob  AuditEntityType = "scheduled_job"
+	AuditEntityDeadLetter    AuditEntityType = "dead_letter"
+	AuditEntityEmailTemplate AuditEntityType = "email_template"
+	AuditEntityHRISMapping   AuditEntityType = "hris_mapping"
+	AuditEntityDevice        AuditEntityType = "attendance_device"
 )
 
 // AuditLog provides comprehensive audit logging for all HR operations
@@ -33,8 +61,9 @@ type AuditLog struct {
 	RequestPath   *string         `gorm:"type:varchar(500)" json:"request_path,omitempty"`
 	OldValues     *string         `gorm:"type:jsonb" json:"old_values,omitempty"` // JSON representation of old values
 	NewValues     *string         `gorm:"type:jsonb" json:"new_values,omitempty"` // JSON representation of new values
-	Changes       *string         `gorm:"type:jsonb" json:"changes,omitempty"`    // JSON representation of what changed
+	Changes       *string         `gorm:"type:jsonb" json:"changes,omitempty"`    // JSON array of changed fields: field, label, old, new
 	Comment       *string         `gorm:"type:text" json:"comment,omitempty"`
+	BatchID       *string         `gorm:"type:varchar(50);index" json:"batch_id,omitempty"` // Shared by the entries of one bulk operation
 	CreatedAt     time.Time       `gorm:"index" json:"created_at"`
 
 	Performer Employee `gorm:"foreignKey:PerformedBy" json:"performer,omitempty"`
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BankDetails stores an employee's bank account used for payroll
+type BankDetails struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	EmployeeID    uint           `gorm:"not null;uniqueIndex" json:"employee_id"`
+	BankName      string         `gorm:"size:100;not null" json:"bank_name"`
+	BranchName    *string        `gorm:"size:100" json:"branch_name,omitempty"`
+	BranchCode    *string        `gorm:"size:20" json:"branch_code,omitempty"`
+	AccountName   string         `gorm:"size:150;not null" json:"account_name"`
+	AccountNumber string         `gorm:"size:50;not null" json:"account_number"` // Masked in normal reads
+	AccountType   *string        `gorm:"size:30" json:"account_type,omitempty"`
+	SwiftCode     *string        `gorm:"size:20" json:"swift_code,omitempty"`
+	Currency      string         `gorm:"size:3;default:'ZMW'" json:"currency"`
+	UpdatedBy     *uint          `gorm:"index" json:"updated_by,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+func (BankDetails) TableName() string {
+	return "bank_details"
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarProvider is a calendar service approved leaves are synced to
+type CalendarProvider string
+
+const (
+	CalendarProviderGoogle  CalendarProvider = "google"
+	CalendarProviderOutlook CalendarProvider = "outlook"
+)
+
+// CalendarConnection is an employee's calendar that approved leaves are added to, with the OAuth
+// tokens used to reach it. Its sync settings are the employee's preferences for that calendar.
+type CalendarConnection struct {
+	ID             uint             `gorm:"primaryKey" json:"id"`
+	EmployeeID     uint             `gorm:"not null;uniqueIndex:idx_calendar_connection_employee_provider" json:"employee_id"`
+	Provider       CalendarProvider `gorm:"type:varchar(20);not null;uniqueIndex:idx_calendar_connection_employee_provider" json:"provider"`
+	AccountEmail   string           `gorm:"size:255" json:"account_email"`
+	AccessToken    string           `gorm:"type:text;not null" json:"-"`
+	RefreshToken   string           `gorm:"type:text;not null" json:"-"`
+	TokenExpiresAt time.Time        `json:"-"`
+	SyncOwnLeaves  bool             `gorm:"not null" json:"sync_own_leaves"`       // Add the employee's own approved leaves
+	SyncTeamLeaves bool             `gorm:"not null" json:"sync_team_leaves"`      // Add the approved leaves of the employee's direct reports
+	LastError      *string          `gorm:"type:text" json:"last_error,omitempty"` // Latest failure to update the calendar, cleared on success
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+func (CalendarConnection) TableName() string {
+	return "calendar_connections"
+}
+
+// CalendarEvent is the event a leave has in a connected calendar. A row whose last attempt failed is
+// pending, and retried until the calendar matches the leave or the attempts run out.
+type CalendarEvent struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ConnectionID uint      `gorm:"not null;uniqueIndex:idx_calendar_event_connection_leave" json:"connection_id"`
+	LeaveID      uint      `gorm:"not null;uniqueIndex:idx_calendar_event_connection_leave;index" json:"leave_id"`
+	ExternalID   string    `gorm:"size:255" json:"external_id"` // Event ID at the provider, empty until the event is created
+	Digest       string    `gorm:"size:64" json:"-"`            // Hash of the event as last written, to skip unchanged updates
+	Pending      bool      `gorm:"not null;index" json:"pending"`
+	Attempts     int       `gorm:"not null" json:"attempts"` // Failed attempts since the last success
+	LastError    *string   `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (CalendarEvent) TableName() string {
+	return "calendar_events"
+}
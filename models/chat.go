@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// ChatPlatform is a chat tool the leave bot answers in
+type ChatPlatform string
+
+const (
+	ChatPlatformSlack ChatPlatform = "slack"
+	ChatPlatformTeams ChatPlatform = "teams"
+)
+
+// ChatAccount links a Slack or Teams user to the employee the leave bot acts as
+type ChatAccount struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	EmployeeID uint         `gorm:"not null;index" json:"employee_id"`
+	Platform   ChatPlatform `gorm:"type:varchar(20);not null;uniqueIndex:idx_chat_account_platform_user" json:"platform"`
+	ExternalID string       `gorm:"size:255;not null;uniqueIndex:idx_chat_account_platform_user" json:"external_id"` // Workspace or tenant ID and user ID, as workspace:user
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+func (ChatAccount) TableName() string {
+	return "chat_accounts"
+}
+
+// ChatLinkCode is a one-time code an employee sends the leave bot to link their chat account. Only a
+// hash of the code is stored.
+type ChatLinkCode struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	EmployeeID uint      `gorm:"not null;index" json:"employee_id"`
+	CodeHash   string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt  time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (ChatLinkCode) TableName() string {
+	return "chat_link_codes"
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultCurrency is the currency salary bands on positions are set in
+const DefaultCurrency = "ZMW"
+
+// CompensationReason is why an employee's pay changed
+type CompensationReason string
+
+const (
+	CompensationReasonHire       CompensationReason = "hire"
+	CompensationReasonPromotion  CompensationReason = "promotion"
+	CompensationReasonMerit      CompensationReason = "merit"
+	CompensationReasonMarket     CompensationReason = "market_adjustment"
+	CompensationReasonCorrection CompensationReason = "correction"
+	CompensationReasonOther      CompensationReason = "other"
+)
+
+// CompensationRecord is an employee's pay from its effective date until the next record takes over.
+// Records are only added, so together they are the employee's compensation history.
+type CompensationRecord struct {
+	ID              uint               `gorm:"primaryKey" json:"id"`
+	EmployeeID      uint               `gorm:"not null;index" json:"employee_id"`
+	PositionID      *uint              `gorm:"index" json:"position_id,omitempty"` // Position whose salary band the amount was checked against
+	EffectiveDate   time.Time          `gorm:"type:date;not null;index" json:"effective_date"`
+	Amount          float64            `gorm:"not null" json:"amount" example:"15000"` // Monthly base salary
+	Currency        string             `gorm:"size:3;not null;default:'ZMW'" json:"currency" example:"ZMW"`
+	Reason          CompensationReason `gorm:"type:varchar(30);not null" json:"reason" example:"merit"`
+	Notes           *string            `gorm:"type:text" json:"notes,omitempty"`
+	OutOfBandReason *string            `gorm:"type:text" json:"out_of_band_reason,omitempty"` // Why the amount is outside the position's salary band
+	CreatedBy       uint               `gorm:"not null" json:"created_by"`
+	CreatedAt       time.Time          `json:"created_at"`
+
+	Employee Employee  `gorm:"foreignKey:EmployeeID" json:"-"`
+	Position *Position `gorm:"foreignKey:PositionID" json:"position,omitempty"`
+}
+
+func (CompensationRecord) TableName() string {
+	return "compensation_records"
+}
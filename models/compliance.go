@@ -18,6 +18,7 @@ const (
 // ComplianceRequirement represents a compliance requirement that employees must meet
 type ComplianceRequirement struct {
 	ID             uint           `gorm:"primaryKey" json:"id"`
+	OrganizationID uint           `gorm:"not null;default:1;index" json:"organization_id"`
 	Code           string         `gorm:"uniqueIndex;size:50;not null" json:"code"`
 	Name           string         `gorm:"size:200;not null" json:"name"`
 	Description    *string        `gorm:"type:text" json:"description,omitempty"`
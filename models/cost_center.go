@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// CostCenter is a unit of the organization that finance allocates personnel costs to
+type CostCenter struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	OrganizationID uint      `gorm:"not null;default:1;uniqueIndex:idx_cost_center_code" json:"organization_id"`
+	Code           string    `gorm:"size:30;not null;uniqueIndex:idx_cost_center_code" json:"code" example:"CC-100"`
+	Name           string    `gorm:"size:100;not null" json:"name" example:"Finance Lusaka"`
+	Description    *string   `gorm:"type:text" json:"description,omitempty"`
+	IsActive       bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (CostCenter) TableName() string {
+	return "cost_centers"
+}
+
+// CostCenterAllocation is the share of an employee's or a position's cost charged to a cost center from
+// its start date. The allocations of an employee or position in effect on a date add up to 100 percent;
+// an employee's own allocations take precedence over those of their position.
+type CostCenterAllocation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	OrganizationID uint       `gorm:"not null;default:1;index" json:"organization_id"`
+	CostCenterID   uint       `gorm:"not null;index" json:"cost_center_id"`
+	EmployeeID     *uint      `gorm:"index" json:"employee_id,omitempty"` // Set for an employee's allocation
+	PositionID     *uint      `gorm:"index" json:"position_id,omitempty"` // Set for a position's allocation
+	Percentage     float64    `gorm:"not null" json:"percentage" example:"60"`
+	StartDate      time.Time  `gorm:"type:date;not null" json:"start_date"`
+	EndDate        *time.Time `gorm:"type:date" json:"end_date,omitempty"` // Last day in effect; open-ended when empty
+	CreatedBy      uint       `gorm:"not null" json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	CostCenter CostCenter `gorm:"foreignKey:CostCenterID" json:"cost_center"`
+}
+
+func (CostCenterAllocation) TableName() string {
+	return "cost_center_allocations"
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// The kind of outgoing message a dead letter holds
+type DeadLetterKind string
+
+const (
+	DeadLetterEmail   DeadLetterKind = "email"
+	DeadLetterSMS     DeadLetterKind = "sms"
+	DeadLetterWebhook DeadLetterKind = "webhook"
+)
+
+// DeadLetter parks an outgoing email, text message or webhook delivery that was given up, because it failed
+// permanently or used all its attempts, until an admin re-sends it. A message given up again after
+// being re-sent gets a new dead letter.
+type DeadLetter struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	OrganizationID    uint           `gorm:"not null;default:1;index" json:"organization_id"`
+	Kind              DeadLetterKind `gorm:"type:varchar(20);not null;index" json:"kind"`
+	NotificationID    *uint          `gorm:"index" json:"notification_id,omitempty"`     // Set for emails and text messages
+	WebhookDeliveryID *uint          `gorm:"index" json:"webhook_delivery_id,omitempty"` // Set for webhook deliveries
+	Reason            string         `gorm:"type:text;not null" json:"reason"`           // Error of the last attempt
+	Permanent         bool           `gorm:"not null;default:false" json:"permanent"`    // Whether the failure was one retrying cannot fix, rather than the attempts running out
+	Attempts          int            `gorm:"default:0" json:"attempts"`
+	ResolvedAt        *time.Time     `gorm:"index" json:"resolved_at,omitempty"` // When it was re-sent, or delivered by a later retry
+	ResolvedBy        *uint          `json:"resolved_by,omitempty"`              // Admin who re-sent it
+	CreatedAt         time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+
+	Notification    *Notification    `gorm:"foreignKey:NotificationID" json:"notification,omitempty"`
+	WebhookDelivery *WebhookDelivery `gorm:"foreignKey:WebhookDeliveryID" json:"webhook_delivery,omitempty"`
+}
+
+func (DeadLetter) TableName() string {
+	return "dead_letters"
+}
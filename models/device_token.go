@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// The push service that issued a device token, and that notifications to the device are sent through
+type PushProvider string
+
+const (
+	PushProviderFCM  PushProvider = "fcm"  // Firebase Cloud Messaging: Android, and iOS apps using the Firebase SDK
+	PushProviderAPNs PushProvider = "apns" // Apple Push Notification service
+)
+
+// DeviceToken registers a mobile app installation of an employee for push notifications. A token is
+// removed once the push service reports it is no longer valid, such as after the app was uninstalled.
+type DeviceToken struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	EmployeeID uint         `gorm:"not null;index" json:"employee_id"`
+	Provider   PushProvider `gorm:"type:varchar(10);not null" json:"provider"`
+	Token      string       `gorm:"size:255;not null;uniqueIndex" json:"token"`
+	DeviceName *string      `gorm:"size:100" json:"device_name,omitempty"`
+	LastPushAt *time.Time   `json:"last_push_at,omitempty"` // Last push the service accepted
+	LastError  *string      `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+func (DeviceToken) TableName() string {
+	return "device_tokens"
+}
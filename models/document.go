@@ -11,6 +11,7 @@ type DocumentType string
 const (
 	DocumentTypeID           DocumentType = "id"
 	DocumentTypeContract     DocumentType = "contract"
+	DocumentTypeOfferLetter  DocumentType = "offer_letter"
 	DocumentTypeResume       DocumentType = "resume"
 	DocumentTypeCertificate  DocumentType = "certificate"
 	DocumentTypeLicense      DocumentType = "license"
@@ -27,6 +28,19 @@ const (
 	DocumentStatusExpired  DocumentStatus = "expired"
 	DocumentStatusPending  DocumentStatus = "pending"
 	DocumentStatusArchived DocumentStatus = "archived"
+	// Generated from a template and waiting for the employee to sign it
+	DocumentStatusPendingSignature DocumentStatus = "pending_signature"
+)
+
+// DocumentContentStatus is how far extracting a document's text for content search has got. It is
+// empty until the document is picked up, including while no text extractor is configured.
+type DocumentContentStatus string
+
+const (
+	DocumentContentExtracting  DocumentContentStatus = "extracting"
+	DocumentContentExtracted   DocumentContentStatus = "extracted"
+	DocumentContentUnsupported DocumentContentStatus = "unsupported" // The extractor cannot read files of its type
+	DocumentContentFailed      DocumentContentStatus = "failed"
 )
 
 // Document represents a document associated with an employee
@@ -48,10 +62,20 @@ type Document struct {
 	VerifiedBy     *uint          `gorm:"index" json:"verified_by,omitempty"`
 	VerifiedAt     *time.Time     `json:"verified_at,omitempty"`
 	Tags           *string        `gorm:"size:200" json:"tags,omitempty"`
+	TemplateID     *uint          `gorm:"index" json:"template_id,omitempty"` // Template the document was generated from
+	SignedAt       *time.Time     `json:"signed_at,omitempty"`
+	SearchVector   string         `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', regexp_replace(title || ' ' || coalesce(description, '') || ' ' || coalesce(tags, ''), '[^[:alnum:]]+', ' ', 'g'))) STORED;index:idx_documents_search_vector,type:gin" json:"-"` // Title, description and tags for full-text search, kept up to date by Postgres
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Text extracted from the file for content search, by OCR for scans and images. The vector is kept
+	// up to date by Postgres.
+	ContentText        *string               `gorm:"type:text" json:"-"`
+	ContentStatus      DocumentContentStatus `gorm:"type:varchar(20);not null;default:'';index" json:"content_status,omitempty"`
+	ContentExtractedAt *time.Time            `json:"content_extracted_at,omitempty"` // When the text was extracted, or while extracting, when it started
+	ContentVector      string                `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(content_text, ''))) STORED;index:idx_documents_content_vector,type:gin" json:"-"`
+
 	Employee Employee  `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
 	Uploader *Employee `gorm:"foreignKey:UploadedBy" json:"uploader,omitempty"`
 	Verifier *Employee `gorm:"foreignKey:VerifiedBy" json:"verifier,omitempty"`
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// DocumentTemplate is an admin-managed template that employment contracts and offer letters are
+// generated from. Its title and body hold placeholders such as {{employee.full_name}}, filled in from
+// the employee's records when a document is generated.
+type DocumentTemplate struct {
+	ID             uint         `gorm:"primaryKey" json:"id"`
+	OrganizationID uint         `gorm:"not null;default:1;index" json:"organization_id"`
+	Name           string       `gorm:"size:100;not null" json:"name" example:"Permanent contract"`
+	DocumentType   DocumentType `gorm:"type:varchar(50);not null" json:"document_type" example:"contract"` // contract or offer_letter
+	Title          string       `gorm:"size:200;not null" json:"title" example:"Employment contract - {{employee.full_name}}"`
+	Body           string       `gorm:"type:text;not null" json:"body"`
+	IsActive       bool         `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy      *uint        `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+func (DocumentTemplate) TableName() string {
+	return "document_templates"
+}
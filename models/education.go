@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusVerified VerificationStatus = "verified"
+	VerificationStatusRejected VerificationStatus = "rejected"
+)
+
+// Education stores an employee's education and qualification record
+type Education struct {
+	ID                 uint               `gorm:"primaryKey" json:"id"`
+	EmployeeID         uint               `gorm:"not null;index" json:"employee_id"`
+	Institution        string             `gorm:"size:200;not null" json:"institution"`
+	Qualification      string             `gorm:"size:200;not null" json:"qualification"`
+	QualificationLevel *string            `gorm:"size:50" json:"qualification_level,omitempty"` // e.g. certificate, diploma, bachelor, master, doctorate
+	FieldOfStudy       *string            `gorm:"size:200" json:"field_of_study,omitempty"`
+	StartDate          *time.Time         `gorm:"type:date" json:"start_date,omitempty"`
+	EndDate            *time.Time         `gorm:"type:date" json:"end_date,omitempty"`
+	Grade              *string            `gorm:"size:50" json:"grade,omitempty"`
+	DocumentID         *uint              `gorm:"index" json:"document_id,omitempty"` // Supporting certificate or transcript
+	VerificationStatus VerificationStatus `gorm:"type:varchar(20);default:'pending';index" json:"verification_status"`
+	VerifiedBy         *uint              `gorm:"index" json:"verified_by,omitempty"`
+	VerifiedAt         *time.Time         `json:"verified_at,omitempty"`
+	VerificationNotes  *string            `gorm:"type:text" json:"verification_notes,omitempty"`
+	CreatedBy          *uint              `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt     `gorm:"index" json:"-"`
+
+	Employee Employee  `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Document *Document `gorm:"foreignKey:DocumentID" json:"document,omitempty"`
+	Verifier *Employee `gorm:"foreignKey:VerifiedBy" json:"verifier,omitempty"`
+}
+
+func (Education) TableName() string {
+	return "education"
+}
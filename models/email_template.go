@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// EmailTemplate replaces the built-in wording of the emails sent for a notification category, in one
+// language. Its subject and bodies hold placeholders such as {{recipient.firstname}}, filled in from the
+// notification when the email is sent. Recipients whose language has no active template get the
+// built-in email.
+type EmailTemplate struct {
+	ID             uint                 `gorm:"primaryKey" json:"id"`
+	OrganizationID uint                 `gorm:"not null;default:1;uniqueIndex:idx_email_template_variant" json:"organization_id"`
+	Category       NotificationCategory `gorm:"type:varchar(50);not null;uniqueIndex:idx_email_template_variant" json:"category" example:"compliance_reminder"`
+	Language       string               `gorm:"type:varchar(5);not null;uniqueIndex:idx_email_template_variant" json:"language" example:"en"`
+	Subject        string               `gorm:"size:200;not null" json:"subject" example:"Reminder: your {{requirement.name}} expires on {{compliance.expiry_date}}"`
+	TextBody       string               `gorm:"type:text;not null" json:"text_body"`
+	HTMLBody       *string              `gorm:"type:text" json:"html_body,omitempty"` // Sent alongside the text body when set
+	IsActive       bool                 `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy      *uint                `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
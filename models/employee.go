@@ -16,18 +16,25 @@ const (
 
 type Employee struct {
 	ID             uint           `gorm:"primaryKey" json:"id"`
-	EmployeeNumber *string        `gorm:"uniqueIndex;size:50" json:"employee_number,omitempty"`
-	NRC            *string        `gorm:"uniqueIndex;size:20" json:"nrc,omitempty"`
-	Username       *string        `gorm:"uniqueIndex;size:50" json:"username,omitempty"`
+	OrganizationID uint           `gorm:"not null;default:1;index" json:"organization_id"`
+	EmployeeNumber *string        `gorm:"uniqueIndex:idx_employees_employee_number,where:deleted_at IS NULL;size:50" json:"employee_number,omitempty"`
+	BadgeNumber    *string        `gorm:"uniqueIndex:idx_employees_badge_number,where:deleted_at IS NULL;size:50" json:"badge_number,omitempty"` // Badge or enrolment number on attendance terminals
+	NRC            *string        `gorm:"uniqueIndex:idx_employees_nrc,where:deleted_at IS NULL;size:20" json:"nrc,omitempty"`
+	Username       *string        `gorm:"uniqueIndex:idx_employees_username,where:deleted_at IS NULL;size:50" json:"username,omitempty"`
 	Firstname      string         `gorm:"size:50;not null" json:"firstname"`
 	Lastname       string         `gorm:"size:50;not null" json:"lastname"`
-	Email          *string        `gorm:"uniqueIndex;size:100" json:"email,omitempty"`
+	Email          *string        `gorm:"uniqueIndex:idx_employees_email,where:deleted_at IS NULL;size:100" json:"email,omitempty"`
 	PasswordHash   string         `gorm:"column:password_hash;not null;size:256" json:"-"`
 	Department     string         `gorm:"size:50" json:"department"`
 	DateJoined     *time.Time     `gorm:"type:date" json:"date_joined,omitempty"`
 	Status         string         `gorm:"size:20;default:'active'" json:"status"` // active, inactive
 	PositionID     *uint          `gorm:"index" json:"position_id,omitempty"`
+	PreviousEmployeeID *uint      `gorm:"index" json:"previous_employee_id,omitempty"` // Deleted record of the same person, when they were hired again
 	Role           Role           `gorm:"type:varchar(50);default:'employee'" json:"role"`
+	PayrollAccess  bool           `gorm:"default:false" json:"payroll_access"` // Grants access to unmasked bank details
+	PIIAccess      bool           `gorm:"column:pii_access;default:false" json:"pii_access"` // Grants access to other people's unmasked NRC, date of birth and address
+	Language       string         `gorm:"size:10;default:'en'" json:"language"` // Language for notifications, taken from Accept-Language at login
+	Timezone       string         `gorm:"size:64" json:"timezone,omitempty"` // IANA timezone when the employee works outside the company timezone
 	// Additional employee fields
 	Phone                        *string        `gorm:"size:20" json:"phone,omitempty"`
 	Mobile                        *string        `gorm:"size:20" json:"mobile,omitempty"`
@@ -45,6 +52,8 @@ type Employee struct {
 	BankAccountNumber             *string        `gorm:"size:50" json:"bank_account_number,omitempty"`
 	TaxID                         *string        `gorm:"size:50" json:"tax_id,omitempty"`
 	Notes                         *string        `gorm:"type:text" json:"notes,omitempty"`
+	AnonymizedAt   *time.Time     `json:"anonymized_at,omitempty"` // Set once personal data has been irreversibly scrubbed
+	SearchVector   string         `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', regexp_replace(coalesce(firstname, '') || ' ' || coalesce(lastname, '') || ' ' || coalesce(email, ''), '[^[:alnum:]]+', ' ', 'g'))) STORED;index:idx_employees_search_vector,type:gin" json:"-"` // Names and email for full-text search, kept up to date by Postgres
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
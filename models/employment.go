@@ -30,7 +30,7 @@ const (
 type EmploymentDetails struct {
 	ID                uint             `gorm:"primaryKey" json:"id"`
 	EmployeeID        uint             `gorm:"not null;uniqueIndex" json:"employee_id"`
-	EmployeeNumber    *string          `gorm:"uniqueIndex;size:50" json:"employee_number,omitempty"`
+	EmployeeNumber    *string          `gorm:"uniqueIndex:idx_employment_details_employee_number,where:deleted_at IS NULL;size:50" json:"employee_number,omitempty"`
 	EmploymentType    EmploymentType   `gorm:"type:varchar(50)" json:"employment_type"`
 	EmploymentStatus  EmploymentStatus `gorm:"type:varchar(50);default:'active'" json:"employment_status"`
 	HireDate          *time.Time       `gorm:"type:date" json:"hire_date,omitempty"`
@@ -44,6 +44,7 @@ type EmploymentDetails struct {
 	ProbationEndDate  *time.Time       `gorm:"type:date" json:"probation_end_date,omitempty"`
 	ProbationStatus   *string          `gorm:"size:20" json:"probation_status,omitempty"`
 	NoticePeriod      *int             `json:"notice_period,omitempty"` // in days
+	Version           uint             `gorm:"not null;default:1" json:"version"`
 	CreatedAt         time.Time        `json:"created_at"`
 	UpdatedAt         time.Time        `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt   `gorm:"index" json:"-"`
@@ -66,6 +67,8 @@ type EmploymentHistory struct {
 	NewPosition        *string           `gorm:"size:100" json:"new_position,omitempty"`
 	PreviousDepartment *string           `gorm:"size:50" json:"previous_department,omitempty"`
 	NewDepartment      *string           `gorm:"size:50" json:"new_department,omitempty"`
+	PreviousManagerID  *uint             `gorm:"index" json:"previous_manager_id,omitempty"`
+	NewManagerID       *uint             `gorm:"index" json:"new_manager_id,omitempty"`
 	ChangeDate         time.Time         `gorm:"type:date;not null" json:"change_date"`
 	ChangeReason       *string           `gorm:"type:text" json:"change_reason,omitempty"`
 	ChangedBy          *uint             `gorm:"index" json:"changed_by,omitempty"`
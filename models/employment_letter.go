@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// EmploymentLetter is a proof-of-employment letter an employee issued for themselves. It keeps what the
+// letter states, so third parties can check a letter against it with its verification code.
+type EmploymentLetter struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID       uint       `gorm:"not null;index" json:"employee_id"`
+	Code             string     `gorm:"size:32;not null;uniqueIndex" json:"code" example:"7KQ2-M9XD-4TPA-H3VW"` // Verification code printed on the letter
+	Addressee        string     `gorm:"size:200" json:"addressee,omitempty" example:"Zanaco Bank"`
+	EmployeeName     string     `gorm:"size:101;not null" json:"employee_name"`
+	PositionTitle    string     `gorm:"size:100" json:"position_title,omitempty"`
+	Department       string     `gorm:"size:50" json:"department,omitempty"`
+	EmploymentType   string     `gorm:"size:50" json:"employment_type,omitempty"`
+	HireDate         *time.Time `gorm:"type:date" json:"hire_date,omitempty"`
+	Salary           *float64   `json:"salary,omitempty"` // Only when the employee chose to include it
+	OrganizationName string     `gorm:"size:100" json:"organization_name"`
+	IssuedAt         time.Time  `gorm:"not null" json:"issued_at"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func (EmploymentLetter) TableName() string {
+	return "employment_letters"
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ExitQuestionType string
+
+const (
+	ExitQuestionText   ExitQuestionType = "text"
+	ExitQuestionRating ExitQuestionType = "rating" // 1 to 5
+	ExitQuestionYesNo  ExitQuestionType = "yes_no"
+	ExitQuestionChoice ExitQuestionType = "choice"
+)
+
+// LeavingReason is the reason-for-leaving taxonomy used to classify exit interviews
+type LeavingReason string
+
+const (
+	LeavingReasonCareerGrowth    LeavingReason = "career_growth"
+	LeavingReasonCompensation    LeavingReason = "compensation"
+	LeavingReasonManagement      LeavingReason = "management"
+	LeavingReasonWorkLifeBalance LeavingReason = "work_life_balance"
+	LeavingReasonCulture         LeavingReason = "culture"
+	LeavingReasonRelocation      LeavingReason = "relocation"
+	LeavingReasonPersonal        LeavingReason = "personal"
+	LeavingReasonRetirement      LeavingReason = "retirement"
+	LeavingReasonContractEnd     LeavingReason = "contract_end"
+	LeavingReasonDismissal       LeavingReason = "dismissal"
+	LeavingReasonOther           LeavingReason = "other"
+)
+
+// ExitQuestionSet is a configurable questionnaire used during exit interviews
+type ExitQuestionSet struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	Description *string        `gorm:"type:text" json:"description,omitempty"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Questions []ExitQuestion `gorm:"foreignKey:QuestionSetID" json:"questions,omitempty"`
+}
+
+func (ExitQuestionSet) TableName() string {
+	return "exit_question_sets"
+}
+
+// ExitQuestion is a question in an exit interview question set
+type ExitQuestion struct {
+	ID            uint             `gorm:"primaryKey" json:"id"`
+	QuestionSetID uint             `gorm:"not null;index" json:"question_set_id"`
+	Text          string           `gorm:"type:text;not null" json:"text"`
+	Type          ExitQuestionType `gorm:"type:varchar(20);not null" json:"type"`
+	Options       *string          `gorm:"type:text" json:"options,omitempty"` // Comma-separated choices for choice questions
+	IsRequired    bool             `gorm:"default:false" json:"is_required"`
+	Order         int              `gorm:"default:0" json:"order"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+func (ExitQuestion) TableName() string {
+	return "exit_questions"
+}
+
+// ExitInterview records the exit interview held as part of an offboarding process
+type ExitInterview struct {
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	OffboardingProcessID uint           `gorm:"not null;uniqueIndex" json:"offboarding_process_id"`
+	EmployeeID           uint           `gorm:"not null;index" json:"employee_id"`
+	QuestionSetID        uint           `gorm:"not null;index" json:"question_set_id"`
+	InterviewerID        *uint          `gorm:"index" json:"interviewer_id,omitempty"`
+	ConductedAt          time.Time      `gorm:"not null;index" json:"conducted_at"`
+	Department           string         `gorm:"size:50;index" json:"department"` // Leaver's department at the time, kept for reporting
+	PrimaryReason        LeavingReason  `gorm:"type:varchar(50);not null;index" json:"primary_reason"`
+	SecondaryReason      *LeavingReason `gorm:"type:varchar(50)" json:"secondary_reason,omitempty"`
+	WouldRecommend       *bool          `json:"would_recommend,omitempty"`
+	WouldReturn          *bool          `json:"would_return,omitempty"`
+	Notes                *string        `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Employee    Employee                `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Interviewer *Employee               `gorm:"foreignKey:InterviewerID" json:"interviewer,omitempty"`
+	QuestionSet ExitQuestionSet         `gorm:"foreignKey:QuestionSetID" json:"question_set,omitempty"`
+	Responses   []ExitInterviewResponse `gorm:"foreignKey:ExitInterviewID" json:"responses,omitempty"`
+}
+
+func (ExitInterview) TableName() string {
+	return "exit_interviews"
+}
+
+// ExitInterviewResponse is the leaver's answer to one exit interview question
+type ExitInterviewResponse struct {
+	ID              uint    `gorm:"primaryKey" json:"id"`
+	ExitInterviewID uint    `gorm:"not null;index" json:"exit_interview_id"`
+	QuestionID      uint    `gorm:"not null;index" json:"question_id"`
+	Answer          *string `gorm:"type:text" json:"answer,omitempty"`
+	Rating          *int    `json:"rating,omitempty"`
+
+	Question ExitQuestion `gorm:"foreignKey:QuestionID" json:"question,omitempty"`
+}
+
+func (ExitInterviewResponse) TableName() string {
+	return "exit_interview_responses"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+type ExportJobStatus string
+
+const (
+	ExportJobQueued    ExportJobStatus = "queued"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// Kinds of export job
+const (
+	ExportAnnualLeaveBalances = "annual_leave_balances"
+)
+
+// ExportJob is an export file generated in the background for the user who requested it, who polls the
+// job and downloads the file once it is completed
+type ExportJob struct {
+	ID               uint            `gorm:"primaryKey" json:"id"`
+	OrganizationID   uint            `gorm:"not null;default:1;index" json:"organization_id"`
+	RequestedBy      uint            `gorm:"not null;index" json:"requested_by"`
+	Kind             string          `gorm:"type:varchar(50);not null" json:"kind"`
+	Format           string          `gorm:"type:varchar(10);not null" json:"format"` // excel or pdf
+	Department       *string         `gorm:"size:50" json:"department,omitempty"`
+	EmploymentStatus *string         `gorm:"size:20" json:"employment_status,omitempty"`
+	Detailed         bool            `gorm:"default:false" json:"detailed"`                            // An Excel file with a summary sheet by department and a sheet per employee
+	Language         string          `gorm:"type:varchar(5);not null;default:'en'" json:"language"`    // Language the file is written in
+	PaperSize        string          `gorm:"type:varchar(10);not null;default:'A4'" json:"paper_size"` // Of a PDF
+	Status           ExportJobStatus `gorm:"type:varchar(20);default:'queued';index" json:"status"`
+	FileName         *string         `gorm:"size:255" json:"file_name,omitempty"` // Name the file is downloaded as
+	FilePath         *string         `gorm:"size:500" json:"-"`
+	FileSize         *int64          `json:"file_size,omitempty"`
+	Error            *string         `gorm:"type:text" json:"error,omitempty"`
+	StartedAt        *time.Time      `json:"started_at,omitempty"`
+	FinishedAt       *time.Time      `json:"finished_at,omitempty"`
+	ExpiresAt        *time.Time      `gorm:"index" json:"expires_at,omitempty"` // The file is deleted with the job after this
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+
+	// Signed link to download the file, valid for a short while; set on completed jobs when returned
+	DownloadURL string `gorm:"-" json:"download_url,omitempty"`
+}
+
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
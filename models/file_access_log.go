@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// FileAccessLog records a file downloaded or a report exported: who did, what they got and from where
+type FileAccessLog struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	OrganizationID uint      `gorm:"not null;default:1;index" json:"organization_id"`
+	UserID         *uint     `gorm:"index" json:"user_id,omitempty"`
+	Route          string    `gorm:"size:200;not null;index" json:"route" example:"GET /api/employees/:id/documents/:doc_id/download"`
+	Path           string    `gorm:"type:text;not null" json:"path" example:"/api/employees/12/documents/40/download"` // With the query, such as a report's filters
+	FileName       *string   `gorm:"size:255" json:"file_name,omitempty"`
+	EmployeeID     *uint     `gorm:"index" json:"employee_id,omitempty"` // Employee whose document, form or report it was
+	DocumentID     *uint     `gorm:"index" json:"document_id,omitempty"`
+	Confidential   bool      `gorm:"default:false;index" json:"confidential"` // The document is marked confidential
+	IPAddress      *string   `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent      *string   `gorm:"type:text" json:"user_agent,omitempty"`
+	CreatedAt      time.Time `gorm:"index" json:"created_at"`
+}
+
+func (FileAccessLog) TableName() string {
+	return "file_access_logs"
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GrievanceStage string
+
+const (
+	GrievanceStageSubmitted     GrievanceStage = "submitted"
+	GrievanceStageAcknowledged  GrievanceStage = "acknowledged"
+	GrievanceStageInvestigating GrievanceStage = "investigating"
+	GrievanceStageResolved      GrievanceStage = "resolved"
+)
+
+type GrievanceCategory string
+
+const (
+	GrievanceCategoryHarassment     GrievanceCategory = "harassment"
+	GrievanceCategoryDiscrimination GrievanceCategory = "discrimination"
+	GrievanceCategoryPay            GrievanceCategory = "pay"
+	GrievanceCategoryWorkload       GrievanceCategory = "workload"
+	GrievanceCategoryHealthSafety   GrievanceCategory = "health_safety"
+	GrievanceCategoryManagement     GrievanceCategory = "management"
+	GrievanceCategoryOther          GrievanceCategory = "other"
+)
+
+// Grievance is a confidential complaint raised by an employee and handled by an HR case owner.
+// For anonymous grievances the submitter is only ever shown to the submitter themselves.
+type Grievance struct {
+	ID                     uint              `gorm:"primaryKey" json:"id"`
+	Reference              string            `gorm:"uniqueIndex;size:30" json:"reference"`
+	EmployeeID             *uint             `gorm:"index" json:"employee_id,omitempty"`
+	IsAnonymous            bool              `gorm:"default:false" json:"is_anonymous"`
+	Department             string            `gorm:"size:50;index" json:"department"` // Submitter's department at the time, kept for reporting
+	Category               GrievanceCategory `gorm:"type:varchar(50);not null;index" json:"category"`
+	Subject                string            `gorm:"size:200;not null" json:"subject"`
+	Description            string            `gorm:"type:text;not null" json:"description"`
+	Stage                  GrievanceStage    `gorm:"type:varchar(20);default:'submitted';index" json:"stage"`
+	OwnerID                *uint             `gorm:"index" json:"owner_id,omitempty"`
+	AcknowledgeDueAt       time.Time         `json:"acknowledge_due_at"`
+	ResolveDueAt           time.Time         `json:"resolve_due_at"`
+	AcknowledgedAt         *time.Time        `json:"acknowledged_at,omitempty"`
+	InvestigationStartedAt *time.Time        `json:"investigation_started_at,omitempty"`
+	ResolvedAt             *time.Time        `json:"resolved_at,omitempty"`
+	Resolution             *string           `gorm:"type:text" json:"resolution,omitempty"`
+	SLABreachNotifiedAt    *time.Time        `json:"-"`
+	CreatedAt              time.Time         `json:"created_at"`
+	UpdatedAt              time.Time         `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt    `gorm:"index" json:"-"`
+
+	Employee *Employee         `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Owner    *Employee         `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	Updates  []GrievanceUpdate `gorm:"foreignKey:GrievanceID" json:"updates,omitempty"`
+}
+
+func (Grievance) TableName() string {
+	return "grievances"
+}
+
+// GrievanceUpdate records a stage change or note on a grievance. Internal notes are hidden from the submitter.
+type GrievanceUpdate struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	GrievanceID uint            `gorm:"not null;index" json:"grievance_id"`
+	AuthorID    uint            `gorm:"not null" json:"author_id"`
+	FromStage   *GrievanceStage `gorm:"type:varchar(20)" json:"from_stage,omitempty"`
+	ToStage     *GrievanceStage `gorm:"type:varchar(20)" json:"to_stage,omitempty"`
+	Note        *string         `gorm:"type:text" json:"note,omitempty"`
+	IsInternal  bool            `gorm:"default:false" json:"is_internal"`
+	CreatedAt   time.Time       `json:"created_at"`
+
+	Author *Employee `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+}
+
+func (GrievanceUpdate) TableName() string {
+	return "grievance_updates"
+}
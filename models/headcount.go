@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HeadcountBudget stores the approved headcount for a position or a whole department in a fiscal year.
+// A budget with PositionID set applies to that position; one without applies to the department as a whole.
+type HeadcountBudget struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	OrganizationID    uint           `gorm:"not null;default:1;index" json:"organization_id"`
+	PositionID        *uint          `gorm:"index:idx_headcount_budget_scope" json:"position_id,omitempty"`
+	Department        string         `gorm:"size:50;not null;index:idx_headcount_budget_scope" json:"department"`
+	FiscalYear        int            `gorm:"not null;index:idx_headcount_budget_scope" json:"fiscal_year"`
+	BudgetedHeadcount int            `gorm:"not null;default:0" json:"budgeted_headcount"`
+	Notes             *string        `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy         *uint          `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Position *Position `gorm:"foreignKey:PositionID" json:"position,omitempty"`
+	Creator  *Employee `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+}
+
+func (HeadcountBudget) TableName() string {
+	return "headcount_budgets"
+}
+
+type HeadcountRequestStatus string
+
+const (
+	HeadcountRequestPending  HeadcountRequestStatus = "pending"
+	HeadcountRequestApproved HeadcountRequestStatus = "approved"
+	HeadcountRequestRejected HeadcountRequestStatus = "rejected"
+)
+
+// HeadcountRequest is a request to increase the budgeted headcount of a position or department
+type HeadcountRequest struct {
+	ID                uint                   `gorm:"primaryKey" json:"id"`
+	OrganizationID    uint                   `gorm:"not null;default:1;index" json:"organization_id"`
+	PositionID        *uint                  `gorm:"index" json:"position_id,omitempty"`
+	Department        string                 `gorm:"size:50;not null;index" json:"department"`
+	FiscalYear        int                    `gorm:"not null;index" json:"fiscal_year"`
+	RequestedIncrease int                    `gorm:"not null" json:"requested_increase"`
+	Justification     *string                `gorm:"type:text" json:"justification,omitempty"`
+	Status            HeadcountRequestStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	RequestedBy       uint                   `gorm:"not null;index" json:"requested_by"`
+	ReviewedBy        *uint                  `gorm:"index" json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time             `json:"reviewed_at,omitempty"`
+	ReviewComment     *string                `gorm:"type:text" json:"review_comment,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt         `gorm:"index" json:"-"`
+
+	Position  *Position `gorm:"foreignKey:PositionID" json:"position,omitempty"`
+	Requester Employee  `gorm:"foreignKey:RequestedBy" json:"requester,omitempty"`
+	Reviewer  *Employee `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+func (HeadcountRequest) TableName() string {
+	return "headcount_requests"
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+)
+
+// HolidayStatus is where a public holiday is in its review
+type HolidayStatus string
+
+const (
+	HolidayPending  HolidayStatus = "pending" // Imported, waiting for an admin to review it
+	HolidayActive   HolidayStatus = "active"
+	HolidayRejected HolidayStatus = "rejected" // Kept so later imports do not add it again
+)
+
+// HolidaySource is how a public holiday was added
+type HolidaySource string
+
+const (
+	HolidaySourceImport HolidaySource = "import"
+	HolidaySourceManual HolidaySource = "manual"
+)
+
+// PublicHoliday is a day in an organization's holiday calendar. Imported holidays wait for review
+// before they are active; manual changes to an imported holiday are kept by later imports.
+type PublicHoliday struct {
+	ID             uint          `gorm:"primaryKey" json:"id"`
+	OrganizationID uint          `gorm:"not null;default:1;uniqueIndex:idx_public_holiday_import" json:"organization_id"`
+	CountryCode    string        `gorm:"size:2;not null;uniqueIndex:idx_public_holiday_import" json:"country_code" example:"ZM"`
+	Date           time.Time     `gorm:"type:date;not null;index" json:"date"`
+	Name           string        `gorm:"size:200;not null" json:"name" example:"Independence Day"`
+	LocalName      string        `gorm:"size:200" json:"local_name,omitempty"`
+	Source         HolidaySource `gorm:"type:varchar(20);not null" json:"source"`
+	ImportedDate   *time.Time    `gorm:"type:date;uniqueIndex:idx_public_holiday_import" json:"imported_date,omitempty"` // Date given by the provider, which imports match on
+	ImportedName   *string       `gorm:"size:200;uniqueIndex:idx_public_holiday_import" json:"imported_name,omitempty"`  // Name given by the provider, which imports match on
+	Overridden     bool          `gorm:"not null" json:"overridden"`                                                     // Changed by an admin since it was imported
+	Status         HolidayStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	ReviewedBy     *uint         `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func (PublicHoliday) TableName() string {
+	return "public_holidays"
+}
+
+// HolidayCountry is a country whose public holidays an organization imports each year
+type HolidayCountry struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	OrganizationID uint      `gorm:"not null;default:1;uniqueIndex:idx_holiday_country" json:"organization_id"`
+	CountryCode    string    `gorm:"size:2;not null;uniqueIndex:idx_holiday_country" json:"country_code" example:"ZM"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (HolidayCountry) TableName() string {
+	return "holiday_countries"
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// HRISMapping renames the fields of another HR system's records to the fields of the HRIS interchange
+// schema, so its exports can be imported, and exports sent to it, without reshaping them first. Fields
+// maps each field name in the other system, with dots for nested objects, to a schema field; an empty
+// schema field drops the field on import.
+type HRISMapping struct {
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	OrganizationID uint              `gorm:"not null;default:1;uniqueIndex:idx_hris_mapping_name" json:"organization_id"`
+	Name           string            `gorm:"size:100;not null;uniqueIndex:idx_hris_mapping_name" json:"name" example:"sage-300"`
+	Description    *string           `gorm:"type:text" json:"description,omitempty"`
+	Fields         map[string]string `gorm:"type:text;not null;serializer:json" json:"fields"`
+	CreatedBy      *uint             `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func (HRISMapping) TableName() string {
+	return "hris_mappings"
+}
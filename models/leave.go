@@ -17,12 +17,12 @@ const (
 
 type Leave struct {
 	ID              uint           `gorm:"primaryKey" json:"id"`
-	EmployeeID      uint           `gorm:"not null;index" json:"employee_id"`
-	LeaveTypeID     uint           `gorm:"not null;index" json:"leave_type_id"`
-	StartDate       time.Time      `gorm:"type:date;not null;index" json:"start_date"`
+	EmployeeID      uint           `gorm:"not null;index;index:idx_leaves_lookup,priority:1" json:"employee_id"`
+	LeaveTypeID     uint           `gorm:"not null;index;index:idx_leaves_lookup,priority:2" json:"leave_type_id"`
+	StartDate       time.Time      `gorm:"type:date;not null;index;index:idx_leaves_lookup,priority:4" json:"start_date"`
 	EndDate         time.Time      `gorm:"type:date;not null;index" json:"end_date"`
 	Reason          string         `gorm:"type:text" json:"reason,omitempty"`
-	Status          LeaveStatus    `gorm:"type:varchar(20);default:'Pending';index" json:"status"`
+	Status          LeaveStatus    `gorm:"type:varchar(20);default:'Pending';index;index:idx_leaves_lookup,priority:3" json:"status"`
 	RejectionReason string         `gorm:"type:text" json:"rejection_reason,omitempty"`
 	ApprovedBy      *uint          `gorm:"index" json:"approved_by,omitempty"`
 	ApprovedAt      *time.Time     `json:"approved_at,omitempty"`
@@ -7,24 +7,26 @@ import (
 	"gorm.io/gorm"
 )
 
-// LeaveAccrual tracks monthly leave accruals for employees
+// LeaveAccrual tracks leave accruals for employees, one per accrual period of the leave type's frequency
 // Supports both simplified schema (Year/Month) and full schema (AccrualMonth with balance tracking)
 type LeaveAccrual struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	EmployeeID   uint           `gorm:"not null;index" json:"employee_id"`
-	LeaveTypeID  uint           `gorm:"not null;index" json:"leave_type_id"`
-	Year         int            `gorm:"index" json:"year"`                           // Year (e.g., 2026) - for simplified schema
-	Month        int            `gorm:"index" json:"month"`                          // Month (1-12) - for simplified schema
-	AccrualMonth *time.Time     `gorm:"index" json:"accrual_month,omitempty"`        // Accrual month (for full schema)
-	DaysAccrued  float64        `gorm:"not null;default:0" json:"days_accrued"`      // Days accrued (e.g., 2.0)
-	DaysUsed     float64        `gorm:"default:0" json:"days_used,omitempty"`        // Days used in this month
-	DaysBalance  float64        `gorm:"default:0" json:"days_balance,omitempty"`     // Running balance
-	IsProcessed  bool           `gorm:"default:false" json:"is_processed,omitempty"` // Whether this accrual has been processed
-	ProcessedAt  *time.Time     `json:"processed_at,omitempty"`                      // When this accrual was processed
-	Notes        *string        `json:"notes,omitempty"`                             // Notes about manual adjustments or processing
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           uint             `gorm:"primaryKey" json:"id"`
+	EmployeeID   uint             `gorm:"not null;index;index:idx_leave_accruals_lookup,priority:1" json:"employee_id"`
+	LeaveTypeID  uint             `gorm:"not null;index;index:idx_leave_accruals_lookup,priority:2" json:"leave_type_id"`
+	Year         int              `gorm:"index" json:"year"`                                                               // Year (e.g., 2026) - for simplified schema
+	Month        int              `gorm:"index" json:"month"`                                                              // Month (1-12) - for simplified schema
+	AccrualMonth *time.Time       `gorm:"index;index:idx_leave_accruals_lookup,priority:3" json:"accrual_month,omitempty"` // Accrual month, or start of the accrual period (for full schema)
+	PeriodEnd    *time.Time       `gorm:"type:date" json:"period_end,omitempty"`                                           // Last day of the accrual period, for accruals made on a schedule
+	Frequency    AccrualFrequency `gorm:"type:varchar(20)" json:"frequency,omitempty"`                                     // Schedule the accrual was made on; empty for manual accruals
+	DaysAccrued  float64          `gorm:"not null;default:0" json:"days_accrued"`                                          // Days accrued (e.g., 2.0)
+	DaysUsed     float64          `gorm:"default:0" json:"days_used,omitempty"`                                            // Days used in this period
+	DaysBalance  float64          `gorm:"default:0" json:"days_balance,omitempty"`                                         // Running balance
+	IsProcessed  bool             `gorm:"default:false" json:"is_processed,omitempty"`                                     // Whether this accrual has been processed
+	ProcessedAt  *time.Time       `json:"processed_at,omitempty"`                                                          // When this accrual was processed
+	Notes        *string          `json:"notes,omitempty"`                                                                 // Notes about manual adjustments or processing
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt   `gorm:"index" json:"-"`
 
 	Employee  Employee  `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
 	LeaveType LeaveType `gorm:"foreignKey:LeaveTypeID" json:"leave_type,omitempty"`
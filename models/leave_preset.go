@@ -0,0 +1,148 @@
+package models
+
+import (
+	"strings"
+)
+
+// DefaultLeavePreset is the leave preset seeded when none is chosen
+const DefaultLeavePreset = "standard"
+
+// LeavePresetType is a leave type as a leave preset sets it up
+type LeavePresetType struct {
+	Name                  string   `json:"name" example:"Maternity"`
+	AccrualRate           float64  `json:"accrual_rate" example:"0"` // Days per month
+	MaxDays               int      `json:"max_days" example:"98"`
+	UsesBalance           bool     `json:"uses_balance"`
+	Unplanned             bool     `json:"unplanned,omitempty"`
+	AllowCarryOver        bool     `json:"allow_carry_over"`
+	MaxCarryOverDays      *float64 `json:"max_carry_over_days,omitempty"`
+	CarryOverExpiryMonths *int     `json:"carry_over_expiry_months,omitempty"`
+	Note                  string   `json:"note,omitempty" example:"14 weeks"` // How the entitlement is set by law
+}
+
+// LeaveType returns the leave type the preset creates
+func (t LeavePresetType) LeaveType() LeaveType {
+	return LeaveType{
+		Name:                  t.Name,
+		AccrualRate:           t.AccrualRate,
+		MaxDays:               t.MaxDays,
+		UsesBalance:           t.UsesBalance,
+		Unplanned:             t.Unplanned,
+		AllowCarryOver:        t.AllowCarryOver,
+		MaxCarryOverDays:      t.MaxCarryOverDays,
+		CarryOverExpiryMonths: t.CarryOverExpiryMonths,
+	}
+}
+
+// LeavePreset is a pack of leave types with the statutory entitlements of a country, so an organization
+// does not have to configure its legal minimums by hand
+type LeavePreset struct {
+	Code       string            `json:"code" example:"ZM"` // ISO country code, or standard
+	Name       string            `json:"name" example:"Zambia"`
+	Law        string            `json:"law,omitempty" example:"Employment Code Act No. 3 of 2019"` // Legislation the entitlements follow
+	LeaveTypes []LeavePresetType `json:"leave_types"`
+}
+
+// LeavePresets lists the leave presets organizations can choose from
+var LeavePresets = []LeavePreset{
+	{
+		Code: DefaultLeavePreset,
+		Name: "Standard",
+		LeaveTypes: []LeavePresetType{
+			{Name: "Sick", MaxDays: 3, Unplanned: true},
+			{Name: "Compassionate", MaxDays: 7, Unplanned: true},
+			{
+				Name:                  "Annual",
+				AccrualRate:           2.0, // 2 days per month
+				MaxDays:               24,  // 24 days/year, accrues 2 days/month
+				UsesBalance:           true,
+				AllowCarryOver:        true,
+				MaxCarryOverDays:      floatPtr(5), // Allow up to 5 days carry-over
+				CarryOverExpiryMonths: intPtr(3),   // Carry-over expires 3 months into next year (end of Q1)
+			},
+			{Name: "Maternity", MaxDays: 90},
+			{Name: "Paternity", MaxDays: 7},
+		},
+	},
+	{
+		Code: "ZM",
+		Name: "Zambia",
+		Law:  "Employment Code Act No. 3 of 2019",
+		LeaveTypes: []LeavePresetType{
+			{
+				Name:                  "Annual",
+				AccrualRate:           2.0,
+				MaxDays:               24,
+				UsesBalance:           true,
+				AllowCarryOver:        true,
+				MaxCarryOverDays:      floatPtr(5),
+				CarryOverExpiryMonths: intPtr(3),
+				Note:                  "2 days for each month of service",
+			},
+			{Name: "Sick", MaxDays: 90, Unplanned: true, Note: "3 months on full pay, then 3 months on half pay, with a medical certificate"},
+			{Name: "Maternity", MaxDays: 98, Note: "14 weeks"},
+			{Name: "Paternity", MaxDays: 5, Note: "5 continuous working days, taken within 7 days of the birth"},
+			{Name: "Compassionate", MaxDays: 12, Unplanned: true, Note: "12 days a year, on the death of a spouse, child, parent or dependant"},
+			{Name: "Family Responsibility", MaxDays: 7, Unplanned: true, Note: "7 days a year to care for a sick spouse, child or dependant"},
+		},
+	},
+	{
+		Code: "ZA",
+		Name: "South Africa",
+		Law:  "Basic Conditions of Employment Act 75 of 1997",
+		LeaveTypes: []LeavePresetType{
+			{
+				Name:                  "Annual",
+				AccrualRate:           1.25,
+				MaxDays:               15,
+				UsesBalance:           true,
+				AllowCarryOver:        true,
+				MaxCarryOverDays:      floatPtr(15),
+				CarryOverExpiryMonths: intPtr(6),
+				Note:                  "15 working days a year, granted within 6 months of the end of the leave cycle",
+			},
+			{Name: "Sick", MaxDays: 30, Unplanned: true, Note: "30 days in each 36-month cycle for a five-day week"},
+			{Name: "Maternity", MaxDays: 120, Note: "4 consecutive months"},
+			{Name: "Parental", MaxDays: 10, Note: "10 consecutive days for a parent not taking maternity leave"},
+			{Name: "Family Responsibility", MaxDays: 3, Unplanned: true, Note: "3 days a year"},
+		},
+	},
+	{
+		Code: "KE",
+		Name: "Kenya",
+		Law:  "Employment Act, 2007",
+		LeaveTypes: []LeavePresetType{
+			{
+				Name:                  "Annual",
+				AccrualRate:           1.75,
+				MaxDays:               21,
+				UsesBalance:           true,
+				AllowCarryOver:        true,
+				MaxCarryOverDays:      floatPtr(10),
+				CarryOverExpiryMonths: intPtr(18),
+				Note:                  "21 working days a year; at least 6 consecutive days taken in the year, the rest within 18 months",
+			},
+			{Name: "Sick", MaxDays: 14, Unplanned: true, Note: "7 days on full pay, then 7 days on half pay, after two months of service"},
+			{Name: "Maternity", MaxDays: 90, Note: "3 months on full pay"},
+			{Name: "Paternity", MaxDays: 14, Note: "2 weeks on full pay"},
+		},
+	},
+}
+
+// FindLeavePreset returns the leave preset with code, ignoring case
+func FindLeavePreset(code string) (LeavePreset, bool) {
+	for _, preset := range LeavePresets {
+		if strings.EqualFold(preset.Code, strings.TrimSpace(code)) {
+			return preset, true
+		}
+	}
+	return LeavePreset{}, false
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
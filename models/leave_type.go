@@ -6,19 +6,35 @@ import (
 	"gorm.io/gorm"
 )
 
+// AccrualFrequency is how often a leave type that uses a balance accrues
+type AccrualFrequency string
+
+const (
+	AccrualMonthly  AccrualFrequency = "monthly"  // A month's accrual at the start of each month of service
+	AccrualBiweekly AccrualFrequency = "biweekly" // 1/26 of the year's entitlement every two weeks from the start date
+	AccrualAnnual   AccrualFrequency = "annual"   // The year's entitlement up front at the start of each leave year
+)
+
 type LeaveType struct {
-	ID                    uint           `gorm:"primaryKey" json:"id"`
-	Name                  string         `gorm:"size:50;not null" json:"name"`
-	AccrualRate           float64        `gorm:"not null;default:2.0" json:"accrual_rate"` // Days per month (e.g., 2.0)
-	MaxDays               int            `gorm:"not null" json:"max_days"`
-	UsesBalance           bool           `gorm:"default:false" json:"uses_balance"`                      // If true, leave is deducted from accrual/carry-over balance; if false, leave is record-only
-	AllowCarryOver        bool           `gorm:"default:false" json:"allow_carry_over"`                // Whether carry-over is allowed
-	MaxCarryOverDays      *float64       `gorm:"default:0" json:"max_carry_over_days,omitempty"`       // Maximum days that can be carried over (nil = unlimited)
-	CarryOverExpiryMonths *int           `gorm:"default:12" json:"carry_over_expiry_months,omitempty"` // Months before carry-over expires (nil = no expiry)
-	CarryOverExpiryDate   *time.Time     `gorm:"type:date" json:"carry_over_expiry_date,omitempty"`    // Fixed expiry date (e.g., end of Q1)
-	CreatedAt             time.Time      `json:"created_at"`
-	UpdatedAt             time.Time      `json:"updated_at"`
-	DeletedAt             gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                    uint             `gorm:"primaryKey" json:"id"`
+	OrganizationID        uint             `gorm:"not null;default:1;index" json:"organization_id"`
+	Name                  string           `gorm:"size:50;not null" json:"name"`
+	AccrualRate           float64          `gorm:"not null;default:2.0" json:"accrual_rate"` // Days per month (e.g., 2.0)
+	AccrualFrequency      AccrualFrequency `gorm:"type:varchar(20);not null;default:monthly" json:"accrual_frequency"`
+	MaxDays               int              `gorm:"not null" json:"max_days"`
+	UsesBalance           bool             `gorm:"default:false" json:"uses_balance"`                    // If true, leave is deducted from accrual/carry-over balance; if false, leave is record-only
+	Unplanned             bool             `gorm:"default:false" json:"unplanned"`                       // Taken at short notice, such as sick leave, rather than booked ahead; absence rates split on it
+	AllowCarryOver        bool             `gorm:"default:false" json:"allow_carry_over"`                // Whether carry-over is allowed
+	MaxCarryOverDays      *float64         `gorm:"default:0" json:"max_carry_over_days,omitempty"`       // Maximum days that can be carried over (nil = unlimited)
+	CarryOverExpiryMonths *int             `gorm:"default:12" json:"carry_over_expiry_months,omitempty"` // Months before carry-over expires (nil = no expiry)
+	CarryOverExpiryDate   *time.Time       `gorm:"type:date" json:"carry_over_expiry_date,omitempty"`    // Fixed expiry date (e.g., end of Q1)
+	Color                 string           `gorm:"size:7" json:"color,omitempty"`                        // Hex color calendars and dashboards show the type in, e.g. #4CAF50
+	Icon                  string           `gorm:"size:50" json:"icon,omitempty"`                        // Icon name for the frontend's icon set, e.g. beach_access
+	DisplayOrder          int              `gorm:"default:0" json:"display_order"`                       // Position in lists of leave types, lowest first
+	Description           string           `gorm:"size:500" json:"description,omitempty"`
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt   `gorm:"index" json:"-"`
 
 	Leaves     []Leave          `gorm:"foreignKey:LeaveTypeID" json:"leaves,omitempty"`
 	CarryOvers []LeaveCarryOver `gorm:"foreignKey:LeaveTypeID" json:"carry_overs,omitempty"`
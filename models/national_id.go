@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// NationalIDChecksum is the check digit scheme a national ID format verifies
+type NationalIDChecksum string
+
+const (
+	NationalIDChecksumNone NationalIDChecksum = ""
+	NationalIDChecksumLuhn NationalIDChecksum = "luhn" // Last digit is a Luhn check digit, as in South African IDs
+)
+
+// NationalIDFormat is how an organization validates and stores the national ID numbers (NRCs) of a
+// country. IDs are matched and checked in their compact form, upper case with spaces and punctuation
+// removed, then stored laid out by Format so the same ID is never stored in two ways.
+type NationalIDFormat struct {
+	ID             uint               `gorm:"primaryKey" json:"id"`
+	OrganizationID uint               `gorm:"not null;default:1;uniqueIndex:idx_national_id_format_country" json:"organization_id"`
+	CountryCode    string             `gorm:"size:2;not null;uniqueIndex:idx_national_id_format_country" json:"country_code" example:"ZM"`
+	Name           string             `gorm:"size:100;not null" json:"name" example:"National Registration Card"`
+	Pattern        string             `gorm:"size:200;not null" json:"pattern" example:"(\\d{6})(\\d{2})(\\d)"` // Regular expression the whole compact ID must match
+	Format         string             `gorm:"size:100" json:"format,omitempty" example:"$1/$2/$3"`              // Layout built from Pattern's groups; empty stores the compact ID
+	Checksum       NationalIDChecksum `gorm:"type:varchar(20)" json:"checksum,omitempty" example:""`
+	Example        string             `gorm:"size:50;not null" json:"example" example:"123456/78/9"`
+	IsDefault      bool               `gorm:"not null" json:"is_default"` // Used when no country is given
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+func (NationalIDFormat) TableName() string {
+	return "national_id_formats"
+}
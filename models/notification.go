@@ -0,0 +1,163 @@
+package models
+
+import (
+	"time"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+type NotificationCategory string
+
+const (
+	NotificationComplianceReminder NotificationCategory = "compliance_reminder"
+	NotificationComplianceExpired  NotificationCategory = "compliance_expired"
+	NotificationGrievanceAssigned  NotificationCategory = "grievance_assigned"
+	NotificationGrievanceUpdated   NotificationCategory = "grievance_updated"
+	NotificationGrievanceSLABreach NotificationCategory = "grievance_sla_breach"
+	NotificationKudosReceived      NotificationCategory = "kudos_received"
+	NotificationLeaveApproved      NotificationCategory = "leave_approved"
+	NotificationLeaveRejected      NotificationCategory = "leave_rejected"
+	NotificationPasswordChanged    NotificationCategory = "password_changed"
+	NotificationLeaveSubmitted     NotificationCategory = "leave_submitted"
+)
+
+// NotificationCategories lists every notification category
+var NotificationCategories = []NotificationCategory{
+	NotificationComplianceReminder, NotificationComplianceExpired, NotificationGrievanceAssigned,
+	NotificationGrievanceUpdated, NotificationGrievanceSLABreach, NotificationKudosReceived,
+	NotificationLeaveApproved, NotificationLeaveRejected, NotificationPasswordChanged, NotificationLeaveSubmitted,
+}
+
+// SMSNotificationCategories lists the critical categories that may also be sent by text message
+var SMSNotificationCategories = []NotificationCategory{
+	NotificationLeaveApproved, NotificationLeaveRejected, NotificationPasswordChanged,
+}
+
+// IsSMSCategory reports whether notifications of the category may be sent by text message
+func IsSMSCategory(category NotificationCategory) bool {
+	for _, c := range SMSNotificationCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationGroup is a kind of notification employees can switch off as a whole
+type NotificationGroup string
+
+const (
+	NotificationGroupApprovals     NotificationGroup = "approvals"     // Leave requests waiting for approval, and leave decisions
+	NotificationGroupReminders     NotificationGroup = "reminders"     // Compliance reminders and expiries, and missed grievance deadlines
+	NotificationGroupAnnouncements NotificationGroup = "announcements" // Kudos, and grievances assigned or updated
+)
+
+// NotificationGroupCategories lists the categories of each notification group. Password change notices
+// are in none, so they cannot be switched off: they warn of someone else changing the password.
+var NotificationGroupCategories = map[NotificationGroup][]NotificationCategory{
+	NotificationGroupApprovals:     {NotificationLeaveSubmitted, NotificationLeaveApproved, NotificationLeaveRejected},
+	NotificationGroupReminders:     {NotificationComplianceReminder, NotificationComplianceExpired, NotificationGrievanceSLABreach},
+	NotificationGroupAnnouncements: {NotificationKudosReceived, NotificationGrievanceAssigned, NotificationGrievanceUpdated},
+}
+
+// Notification records a notification sent to an employee on a given channel
+type Notification struct {
+	ID            uint                 `gorm:"primaryKey" json:"id"`
+	RecipientID   uint                 `gorm:"not null;index" json:"recipient_id"`
+	Channel       NotificationChannel  `gorm:"type:varchar(20);not null" json:"channel"`
+	Category      NotificationCategory `gorm:"type:varchar(50);not null;index" json:"category"`
+	Subject       string               `gorm:"size:200;not null" json:"subject"`
+	Message       string               `gorm:"type:text;not null" json:"message"`
+	HTMLMessage   *string              `gorm:"type:text" json:"html_message,omitempty"` // HTML alternative of an email's message, from its email template
+	EntityType    *AuditEntityType     `gorm:"type:varchar(50);index:idx_notification_entity" json:"entity_type,omitempty"`
+	EntityID      *uint                `gorm:"index:idx_notification_entity" json:"entity_id,omitempty"`
+	Address       *string              `gorm:"size:100" json:"address,omitempty"` // Email address an email, or mobile number a text message, is sent to
+	Status        NotificationStatus   `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	Attempts      int                  `gorm:"default:0" json:"attempts"`
+	NextAttemptAt *time.Time           `gorm:"index" json:"next_attempt_at,omitempty"` // Of a pending email or text message; cleared once it is sent or given up
+	Error         *string              `gorm:"type:text" json:"error,omitempty"`
+	SentAt        *time.Time           `json:"sent_at,omitempty"`
+	ReadAt        *time.Time           `json:"read_at,omitempty"`
+	CreatedAt     time.Time            `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+
+	Recipient Employee `gorm:"foreignKey:RecipientID" json:"recipient,omitempty"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationPreference is an employee's choice of the channels notifications of a category reach them
+// on besides in-app. Categories without one use the defaults: email and push on, and SMS on for the
+// categories that may be sent by text message.
+type NotificationPreference struct {
+	ID         uint                 `gorm:"primaryKey" json:"id"`
+	EmployeeID uint                 `gorm:"not null;uniqueIndex:idx_notification_preference" json:"employee_id"`
+	Category   NotificationCategory `gorm:"type:varchar(50);not null;uniqueIndex:idx_notification_preference" json:"category"`
+	Email      bool                 `gorm:"not null" json:"email"`
+	SMS        bool                 `gorm:"not null" json:"sms"`
+	Push       *bool                `gorm:"not null;default:true" json:"push"` // A pointer so that false is saved rather than the column default
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// NotificationSettings is an employee's choice of the channels and groups of notifications that reach
+// them at all, applied before their NotificationPreference of each category. Employees without one get
+// every group on every channel.
+type NotificationSettings struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	EmployeeID    uint      `gorm:"not null;uniqueIndex" json:"employee_id"`
+	InApp         bool      `gorm:"not null" json:"in_app"`
+	Email         bool      `gorm:"not null" json:"email"`
+	SMS           bool      `gorm:"not null" json:"sms"`
+	Push          bool      `gorm:"not null" json:"push"`
+	Approvals     bool      `gorm:"not null" json:"approvals"`
+	Reminders     bool      `gorm:"not null" json:"reminders"`
+	Announcements bool      `gorm:"not null" json:"announcements"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (NotificationSettings) TableName() string {
+	return "notification_settings"
+}
+
+// Receives reports whether notifications of the category reach the employee, on the channels they
+// have on: the category's group is on, or it is in none
+func (s NotificationSettings) Receives(category NotificationCategory) bool {
+	for group, categories := range NotificationGroupCategories {
+		for _, c := range categories {
+			if c != category {
+				continue
+			}
+			switch group {
+			case NotificationGroupApprovals:
+				return s.Approvals
+			case NotificationGroupReminders:
+				return s.Reminders
+			case NotificationGroupAnnouncements:
+				return s.Announcements
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultOrganizationID is the organization created on first migration. Records that existed before
+// organizations were introduced belong to it.
+const DefaultOrganizationID uint = 1
+
+// Organization is a company whose employees, leave types and other records are kept apart from
+// every other organization's. Records that carry an OrganizationID belong to that organization;
+// records owned by an employee belong to the employee's organization.
+type Organization struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"size:100;not null" json:"name"`
+	Code        string         `gorm:"uniqueIndex;size:20;not null" json:"code"` // Short identifier used at registration
+	LeavePreset string         `gorm:"size:20" json:"leave_preset,omitempty"`    // Code of the last leave preset applied (see LeavePresets)
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (Organization) TableName() string {
+	return "organizations"
+}
@@ -9,6 +9,7 @@ import (
 // Position represents a job position in the organization
 type Position struct {
 	ID                uint           `gorm:"primaryKey" json:"id"`
+	OrganizationID    uint           `gorm:"not null;default:1;index" json:"organization_id"`
 	Code              string         `gorm:"uniqueIndex;size:50;not null" json:"code"`
 	Title             string         `gorm:"size:100;not null" json:"title"`
 	Description       *string        `gorm:"type:text" json:"description,omitempty"`
@@ -17,7 +18,9 @@ type Position struct {
 	ReportsToPosition *uint          `gorm:"index" json:"reports_to_position,omitempty"`
 	MinSalary         *float64       `json:"min_salary,omitempty"`
 	MaxSalary         *float64       `json:"max_salary,omitempty"`
+	Headcount         int            `gorm:"default:1" json:"headcount"` // Budgeted number of seats for this position
 	IsActive          bool           `gorm:"default:true" json:"is_active"`
+	Version           uint           `gorm:"not null;default:1" json:"version"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CompanyValue is one of the organisation's values that kudos are given against
+type CompanyValue struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	Description *string        `gorm:"type:text" json:"description,omitempty"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (CompanyValue) TableName() string {
+	return "company_values"
+}
+
+// Kudos is peer recognition sent from one employee to another for living a company value
+type Kudos struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	SenderID    uint           `gorm:"not null;index" json:"sender_id"`
+	RecipientID uint           `gorm:"not null;index" json:"recipient_id"`
+	ValueID     uint           `gorm:"not null;index" json:"value_id"`
+	Message     string         `gorm:"type:text;not null" json:"message"`
+	CreatedAt   time.Time      `gorm:"index" json:"created_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Sender    Employee     `gorm:"foreignKey:SenderID" json:"sender,omitempty"`
+	Recipient Employee     `gorm:"foreignKey:RecipientID" json:"recipient,omitempty"`
+	Value     CompanyValue `gorm:"foreignKey:ValueID" json:"value,omitempty"`
+}
+
+func (Kudos) TableName() string {
+	return "kudos"
+}
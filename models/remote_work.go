@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RemoteWorkStatus string
+
+const (
+	RemoteWorkPending   RemoteWorkStatus = "pending"
+	RemoteWorkApproved  RemoteWorkStatus = "approved"
+	RemoteWorkRejected  RemoteWorkStatus = "rejected"
+	RemoteWorkCancelled RemoteWorkStatus = "cancelled"
+)
+
+// RemoteWorkRequest is a request to work from home or another remote location. It is tracked
+// separately from leave and does not touch leave balances.
+type RemoteWorkRequest struct {
+	ID            uint             `gorm:"primaryKey" json:"id"`
+	EmployeeID    uint             `gorm:"not null;index" json:"employee_id"`
+	StartDate     time.Time        `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate       time.Time        `gorm:"type:date;not null;index" json:"end_date"`
+	Location      *string          `gorm:"size:200" json:"location,omitempty"`
+	Reason        *string          `gorm:"type:text" json:"reason,omitempty"`
+	Status        RemoteWorkStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	ApprovedBy    *uint            `gorm:"index" json:"approved_by,omitempty"`
+	ApprovedAt    *time.Time       `json:"approved_at,omitempty"`
+	ReviewComment *string          `gorm:"type:text" json:"review_comment,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt   `gorm:"index" json:"-"`
+
+	Employee Employee  `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Approver *Employee `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+}
+
+func (RemoteWorkRequest) TableName() string {
+	return "remote_work_requests"
+}
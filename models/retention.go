@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+type RetentionCategory string
+
+const (
+	RetentionAuditLogs           RetentionCategory = "audit_logs"
+	RetentionExEmployeeDocuments RetentionCategory = "ex_employee_documents"
+	RetentionLeaveHistory        RetentionCategory = "leave_history"
+	RetentionLoginLogs           RetentionCategory = "login_logs"
+)
+
+// RetentionCategories lists every category a retention policy can be set for
+var RetentionCategories = []RetentionCategory{
+	RetentionAuditLogs, RetentionExEmployeeDocuments, RetentionLeaveHistory, RetentionLoginLogs,
+}
+
+// RetentionPolicy sets how long an organization keeps the records of one data category. Records older
+// than RetentionDays are purged by the daily retention job while the policy is enabled.
+type RetentionPolicy struct {
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	OrganizationID uint              `gorm:"not null;default:1;uniqueIndex:idx_retention_policy_category" json:"organization_id"`
+	Category       RetentionCategory `gorm:"type:varchar(50);not null;uniqueIndex:idx_retention_policy_category" json:"category"`
+	RetentionDays  int               `gorm:"not null" json:"retention_days"`
+	Enabled        bool              `gorm:"default:false" json:"enabled"`
+	UpdatedBy      *uint             `json:"updated_by,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func (RetentionPolicy) TableName() string {
+	return "retention_policies"
+}
+
+// LegalHold exempts all of an employee's records from retention purges until it is released
+type LegalHold struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID uint       `gorm:"not null;index" json:"employee_id"`
+	Reason     string     `gorm:"type:text;not null" json:"reason"`
+	PlacedBy   uint       `gorm:"not null" json:"placed_by"`
+	ReleasedAt *time.Time `gorm:"index" json:"released_at,omitempty"`
+	ReleasedBy *uint      `json:"released_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+func (LegalHold) TableName() string {
+	return "legal_holds"
+}
+
+// LoginLog records a sign-in attempt, successful or not
+type LoginLog struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	OrganizationID uint      `gorm:"not null;default:1;index" json:"organization_id"`
+	EmployeeID     *uint     `gorm:"index" json:"employee_id,omitempty"` // Unset when no account matched
+	Identifier     string    `gorm:"size:100" json:"identifier"`         // NRC or username the attempt was made with
+	Success        bool      `gorm:"not null" json:"success"`
+	IPAddress      *string   `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent      *string   `gorm:"type:text" json:"user_agent,omitempty"`
+	CreatedAt      time.Time `gorm:"index" json:"created_at"`
+}
+
+func (LoginLog) TableName() string {
+	return "login_logs"
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+type JobRunStatus string
+
+const (
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+)
+
+// What started a run of a scheduled job
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerStartup  JobTrigger = "startup"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+// ScheduledJob is the state of a background job that every server shares: whether an admin paused it,
+// and how its last run, on whichever server, went. The row is created the first time the job runs or
+// is paused.
+type ScheduledJob struct {
+	ID              uint         `gorm:"primaryKey" json:"id"`
+	Name            string       `gorm:"size:50;not null;uniqueIndex" json:"name"`
+	Paused          bool         `gorm:"not null;default:false" json:"paused"`
+	PausedBy        *uint        `json:"paused_by,omitempty"`
+	PausedAt        *time.Time   `json:"paused_at,omitempty"`
+	LastStatus      JobRunStatus `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	LastTrigger     JobTrigger   `gorm:"type:varchar(20)" json:"last_trigger,omitempty"`
+	LastServer      string       `gorm:"size:255" json:"last_server,omitempty"` // Host name of the server that ran it
+	LastStartedAt   *time.Time   `json:"last_started_at,omitempty"`
+	LastFinishedAt  *time.Time   `json:"last_finished_at,omitempty"`
+	LastError       *string      `gorm:"type:text" json:"last_error,omitempty"`
+	LastSucceededAt *time.Time   `json:"last_succeeded_at,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+func (ScheduledJob) TableName() string {
+	return "scheduled_jobs"
+}
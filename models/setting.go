@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// Setting overrides the default of a runtime setting for the whole installation. Changes are picked
+// up by every running server without a restart.
+type Setting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Key       string    `gorm:"size:100;not null;uniqueIndex" json:"key"`
+	Value     string    `gorm:"type:text;not null" json:"value"` // JSON encoded
+	UpdatedBy *uint     `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `gorm:"index" json:"updated_at"`
+}
+
+func (Setting) TableName() string {
+	return "settings"
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShiftSwapStatus string
+
+const (
+	ShiftSwapPending   ShiftSwapStatus = "pending"
+	ShiftSwapApproved  ShiftSwapStatus = "approved"
+	ShiftSwapRejected  ShiftSwapStatus = "rejected"
+	ShiftSwapCancelled ShiftSwapStatus = "cancelled"
+)
+
+// Shift defines a named block of working hours that employees can be rostered onto
+type Shift struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	Name         string         `gorm:"uniqueIndex;size:50;not null" json:"name"`
+	StartTime    string         `gorm:"size:5;not null" json:"start_time"` // HH:MM
+	EndTime      string         `gorm:"size:5;not null" json:"end_time"`   // HH:MM, earlier than start_time for overnight shifts
+	BreakMinutes int            `gorm:"default:0" json:"break_minutes"`
+	GraceMinutes int            `gorm:"default:0" json:"grace_minutes"`
+	Department   *string        `gorm:"size:50;index" json:"department,omitempty"`
+	IsActive     bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (Shift) TableName() string {
+	return "shifts"
+}
+
+// ShiftAssignment places an employee on a shift for one day of the rota
+type ShiftAssignment struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	EmployeeID uint      `gorm:"not null;uniqueIndex:idx_shift_assignment_employee_date" json:"employee_id"`
+	ShiftID    uint      `gorm:"not null;index" json:"shift_id"`
+	Date       time.Time `gorm:"type:date;not null;uniqueIndex:idx_shift_assignment_employee_date;index" json:"date"`
+	Notes      *string   `gorm:"type:text" json:"notes,omitempty"`
+	AssignedBy uint      `gorm:"not null" json:"assigned_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Employee Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Shift    Shift    `gorm:"foreignKey:ShiftID" json:"shift,omitempty"`
+}
+
+func (ShiftAssignment) TableName() string {
+	return "shift_assignments"
+}
+
+// ShiftSwapRequest asks to swap a rostered shift with a colleague's shift, or hand it to a colleague
+// when no target assignment is given. Swaps take effect once approved by a manager or admin.
+type ShiftSwapRequest struct {
+	ID                    uint            `gorm:"primaryKey" json:"id"`
+	RequesterID           uint            `gorm:"not null;index" json:"requester_id"`
+	RequesterAssignmentID uint            `gorm:"not null;index" json:"requester_assignment_id"`
+	TargetEmployeeID      uint            `gorm:"not null;index" json:"target_employee_id"`
+	TargetAssignmentID    *uint           `gorm:"index" json:"target_assignment_id,omitempty"`
+	Reason                *string         `gorm:"type:text" json:"reason,omitempty"`
+	Status                ShiftSwapStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	ReviewedBy            *uint           `gorm:"index" json:"reviewed_by,omitempty"`
+	ReviewedAt            *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewComment         *string         `gorm:"type:text" json:"review_comment,omitempty"`
+	CreatedAt             time.Time       `json:"created_at"`
+	UpdatedAt             time.Time       `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt  `gorm:"index" json:"-"`
+
+	Requester           Employee         `gorm:"foreignKey:RequesterID" json:"requester,omitempty"`
+	RequesterAssignment ShiftAssignment  `gorm:"foreignKey:RequesterAssignmentID" json:"requester_assignment,omitempty"`
+	TargetEmployee      Employee         `gorm:"foreignKey:TargetEmployeeID" json:"target_employee,omitempty"`
+	TargetAssignment    *ShiftAssignment `gorm:"foreignKey:TargetAssignmentID" json:"target_assignment,omitempty"`
+	Reviewer            *Employee        `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+func (ShiftSwapRequest) TableName() string {
+	return "shift_swap_requests"
+}
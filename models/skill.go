@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProficiencyLevel string
+
+const (
+	ProficiencyBeginner     ProficiencyLevel = "beginner"
+	ProficiencyIntermediate ProficiencyLevel = "intermediate"
+	ProficiencyAdvanced     ProficiencyLevel = "advanced"
+	ProficiencyExpert       ProficiencyLevel = "expert"
+)
+
+// ProficiencyRank orders proficiency levels from lowest to highest
+var ProficiencyRank = map[ProficiencyLevel]int{
+	ProficiencyBeginner:     1,
+	ProficiencyIntermediate: 2,
+	ProficiencyAdvanced:     3,
+	ProficiencyExpert:       4,
+}
+
+// Skill represents a skill in the organisation's skills catalogue
+type Skill struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	Category    *string        `gorm:"size:100;index" json:"category,omitempty"`
+	Description *string        `gorm:"type:text" json:"description,omitempty"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (Skill) TableName() string {
+	return "skills"
+}
+
+// Certification represents a professional certification or licence that employees can hold
+type Certification struct {
+	ID                      uint           `gorm:"primaryKey" json:"id"`
+	Code                    string         `gorm:"uniqueIndex;size:50;not null" json:"code"`
+	Name                    string         `gorm:"size:200;not null" json:"name"`
+	IssuingBody             *string        `gorm:"size:200" json:"issuing_body,omitempty"`
+	Description             *string        `gorm:"type:text" json:"description,omitempty"`
+	ValidityPeriod          *int           `json:"validity_period,omitempty"`                        // in days; used to derive expiry when none is given
+	ComplianceRequirementID *uint          `gorm:"index" json:"compliance_requirement_id,omitempty"` // Holdings are mirrored as compliance records for expiry reminders
+	IsActive                bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt `gorm:"index" json:"-"`
+
+	ComplianceRequirement *ComplianceRequirement `gorm:"foreignKey:ComplianceRequirementID" json:"compliance_requirement,omitempty"`
+}
+
+func (Certification) TableName() string {
+	return "certifications"
+}
+
+// EmployeeSkill assigns a skill to an employee with a proficiency level
+type EmployeeSkill struct {
+	ID                uint             `gorm:"primaryKey" json:"id"`
+	EmployeeID        uint             `gorm:"not null;uniqueIndex:idx_employee_skill" json:"employee_id"`
+	SkillID           uint             `gorm:"not null;uniqueIndex:idx_employee_skill;index" json:"skill_id"`
+	Proficiency       ProficiencyLevel `gorm:"type:varchar(20);not null" json:"proficiency"`
+	YearsOfExperience *float64         `json:"years_of_experience,omitempty"`
+	ExpiryDate        *time.Time       `gorm:"type:date;index" json:"expiry_date,omitempty"` // For skills that must be re-assessed
+	Notes             *string          `gorm:"type:text" json:"notes,omitempty"`
+	AssessedBy        *uint            `gorm:"index" json:"assessed_by,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+
+	Employee Employee  `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Skill    Skill     `gorm:"foreignKey:SkillID" json:"skill,omitempty"`
+	Assessor *Employee `gorm:"foreignKey:AssessedBy" json:"assessor,omitempty"`
+}
+
+func (EmployeeSkill) TableName() string {
+	return "employee_skills"
+}
+
+// EmployeeCertification records a certification held by an employee
+type EmployeeCertification struct {
+	ID                 uint           `gorm:"primaryKey" json:"id"`
+	EmployeeID         uint           `gorm:"not null;index" json:"employee_id"`
+	CertificationID    uint           `gorm:"not null;index" json:"certification_id"`
+	CertificateNumber  *string        `gorm:"size:100" json:"certificate_number,omitempty"`
+	IssueDate          *time.Time     `gorm:"type:date" json:"issue_date,omitempty"`
+	ExpiryDate         *time.Time     `gorm:"type:date;index" json:"expiry_date,omitempty"`
+	DocumentID         *uint          `gorm:"index" json:"document_id,omitempty"`
+	ComplianceRecordID *uint          `gorm:"index" json:"compliance_record_id,omitempty"`
+	Notes              *string        `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Employee      Employee      `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Certification Certification `gorm:"foreignKey:CertificationID" json:"certification,omitempty"`
+	Document      *Document     `gorm:"foreignKey:DocumentID" json:"document,omitempty"`
+}
+
+func (EmployeeCertification) TableName() string {
+	return "employee_certifications"
+}
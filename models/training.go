@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TrainingSessionStatus string
+
+const (
+	TrainingSessionScheduled TrainingSessionStatus = "scheduled"
+	TrainingSessionCompleted TrainingSessionStatus = "completed"
+	TrainingSessionCancelled TrainingSessionStatus = "cancelled"
+)
+
+type TrainingEnrollmentStatus string
+
+const (
+	TrainingEnrollmentEnrolled  TrainingEnrollmentStatus = "enrolled"
+	TrainingEnrollmentAttended  TrainingEnrollmentStatus = "attended"
+	TrainingEnrollmentNoShow    TrainingEnrollmentStatus = "no_show"
+	TrainingEnrollmentCompleted TrainingEnrollmentStatus = "completed"
+	TrainingEnrollmentFailed    TrainingEnrollmentStatus = "failed"
+	TrainingEnrollmentCancelled TrainingEnrollmentStatus = "cancelled"
+)
+
+// TrainingCourse represents a training course in the catalogue
+type TrainingCourse struct {
+	ID                      uint           `gorm:"primaryKey" json:"id"`
+	Code                    string         `gorm:"uniqueIndex;size:50;not null" json:"code"`
+	Title                   string         `gorm:"size:200;not null" json:"title"`
+	Description             *string        `gorm:"type:text" json:"description,omitempty"`
+	Provider                *string        `gorm:"size:200" json:"provider,omitempty"`
+	DurationHours           *float64       `json:"duration_hours,omitempty"`
+	ValidityPeriod          *int           `json:"validity_period,omitempty"`                        // in days; completions expire after this period
+	ComplianceRequirementID *uint          `gorm:"index" json:"compliance_requirement_id,omitempty"` // Completions are mirrored as compliance records
+	IsActive                bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt `gorm:"index" json:"-"`
+
+	ComplianceRequirement *ComplianceRequirement `gorm:"foreignKey:ComplianceRequirementID" json:"compliance_requirement,omitempty"`
+}
+
+func (TrainingCourse) TableName() string {
+	return "training_courses"
+}
+
+// TrainingSession is a scheduled delivery of a course
+type TrainingSession struct {
+	ID        uint                  `gorm:"primaryKey" json:"id"`
+	CourseID  uint                  `gorm:"not null;index" json:"course_id"`
+	StartDate time.Time             `gorm:"not null;index" json:"start_date"`
+	EndDate   time.Time             `gorm:"not null" json:"end_date"`
+	Location  *string               `gorm:"size:200" json:"location,omitempty"`
+	Trainer   *string               `gorm:"size:200" json:"trainer,omitempty"`
+	Capacity  *int                  `json:"capacity,omitempty"`
+	Status    TrainingSessionStatus `gorm:"type:varchar(20);default:'scheduled';index" json:"status"`
+	CreatedBy uint                  `gorm:"not null" json:"created_by"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+	DeletedAt gorm.DeletedAt        `gorm:"index" json:"-"`
+
+	Course TrainingCourse `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+}
+
+func (TrainingSession) TableName() string {
+	return "training_sessions"
+}
+
+// TrainingEnrollment tracks an employee's enrollment, attendance and completion of a session
+type TrainingEnrollment struct {
+	ID                    uint                     `gorm:"primaryKey" json:"id"`
+	SessionID             uint                     `gorm:"not null;uniqueIndex:idx_training_enrollment_session_employee" json:"session_id"`
+	EmployeeID            uint                     `gorm:"not null;uniqueIndex:idx_training_enrollment_session_employee;index" json:"employee_id"`
+	Status                TrainingEnrollmentStatus `gorm:"type:varchar(20);default:'enrolled';index" json:"status"`
+	Score                 *float64                 `json:"score,omitempty"`
+	CompletedAt           *time.Time               `gorm:"type:date" json:"completed_at,omitempty"`
+	ExpiryDate            *time.Time               `gorm:"type:date;index" json:"expiry_date,omitempty"`
+	CertificateDocumentID *uint                    `gorm:"index" json:"certificate_document_id,omitempty"`
+	ComplianceRecordID    *uint                    `gorm:"index" json:"compliance_record_id,omitempty"`
+	EnrolledBy            uint                     `gorm:"not null" json:"enrolled_by"`
+	Notes                 *string                  `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt             time.Time                `json:"created_at"`
+	UpdatedAt             time.Time                `json:"updated_at"`
+
+	Session     TrainingSession `gorm:"foreignKey:SessionID" json:"session,omitempty"`
+	Employee    Employee        `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Certificate *Document       `gorm:"foreignKey:CertificateDocumentID" json:"certificate,omitempty"`
+}
+
+func (TrainingEnrollment) TableName() string {
+	return "training_enrollments"
+}
+
+// MandatoryTraining requires every employee with a role to complete a course
+type MandatoryTraining struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	CourseID      uint           `gorm:"not null;uniqueIndex:idx_mandatory_training_course_role" json:"course_id"`
+	Role          Role           `gorm:"type:varchar(50);not null;uniqueIndex:idx_mandatory_training_course_role" json:"role"`
+	DueWithinDays *int           `json:"due_within_days,omitempty"` // Days after hire date by which the course must be completed
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Course TrainingCourse `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+}
+
+func (MandatoryTraining) TableName() string {
+	return "mandatory_trainings"
+}
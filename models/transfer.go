@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TransferStatus string
+
+const (
+	TransferStatusPending   TransferStatus = "pending"
+	TransferStatusApproved  TransferStatus = "approved"
+	TransferStatusRejected  TransferStatus = "rejected"
+	TransferStatusCompleted TransferStatus = "completed"
+	TransferStatusCancelled TransferStatus = "cancelled"
+)
+
+// TransferRequest tracks a request to move an employee to another department, position or manager.
+// Approved transfers are applied automatically on their effective date.
+type TransferRequest struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	EmployeeID       uint           `gorm:"not null;index" json:"employee_id"`
+	FromDepartment   *string        `gorm:"size:50" json:"from_department,omitempty"`
+	ToDepartment     *string        `gorm:"size:50" json:"to_department,omitempty"`
+	FromPositionID   *uint          `gorm:"index" json:"from_position_id,omitempty"`
+	ToPositionID     *uint          `gorm:"index" json:"to_position_id,omitempty"`
+	FromManagerID    *uint          `gorm:"index" json:"from_manager_id,omitempty"`
+	ToManagerID      *uint          `gorm:"index" json:"to_manager_id,omitempty"`
+	EffectiveDate    time.Time      `gorm:"type:date;not null;index" json:"effective_date"`
+	Reason           *string        `gorm:"type:text" json:"reason,omitempty"`
+	Status           TransferStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	RequestedBy      uint           `gorm:"not null;index" json:"requested_by"`
+	ApprovedBy       *uint          `gorm:"index" json:"approved_by,omitempty"`
+	ApprovedAt       *time.Time     `json:"approved_at,omitempty"`
+	ReviewComment    *string        `gorm:"type:text" json:"review_comment,omitempty"`
+	CompletedAt      *time.Time     `json:"completed_at,omitempty"`
+	LifecycleEventID *uint          `gorm:"index" json:"lifecycle_event_id,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Employee     Employee  `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	FromPosition *Position `gorm:"foreignKey:FromPositionID" json:"from_position,omitempty"`
+	ToPosition   *Position `gorm:"foreignKey:ToPositionID" json:"to_position,omitempty"`
+	FromManager  *Employee `gorm:"foreignKey:FromManagerID" json:"from_manager,omitempty"`
+	ToManager    *Employee `gorm:"foreignKey:ToManagerID" json:"to_manager,omitempty"`
+	Requester    *Employee `gorm:"foreignKey:RequestedBy" json:"requester,omitempty"`
+	Approver     *Employee `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+}
+
+func (TransferRequest) TableName() string {
+	return "transfer_requests"
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription is an external endpoint that receives the events it subscribes to
+type WebhookSubscription struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	OrganizationID uint           `gorm:"not null;default:1;index" json:"organization_id"`
+	URL            string         `gorm:"size:500;not null" json:"url"`
+	Description    *string        `gorm:"type:text" json:"description,omitempty"`
+	EventTypes     string         `gorm:"type:text;not null" json:"event_types"` // Comma-separated event types
+	Secret         string         `gorm:"size:100;not null" json:"-"`            // Key for the HMAC signature on each delivery
+	IsActive       bool           `gorm:"default:true" json:"is_active"`
+	CreatedBy      uint           `gorm:"not null" json:"created_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery is one event sent, or still to be sent, to a subscription, with the outcome of the latest attempt
+type WebhookDelivery struct {
+	ID             uint                  `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint                  `gorm:"not null;index" json:"subscription_id"`
+	EventType      string                `gorm:"type:varchar(50);not null;index" json:"event_type"`
+	Payload        string                `gorm:"type:text;not null" json:"payload"` // JSON request body, signed as sent
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	Attempts       int                   `gorm:"default:0" json:"attempts"`
+	NextAttemptAt  *time.Time            `gorm:"index" json:"next_attempt_at,omitempty"` // Cleared once the delivery succeeds or is given up
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	ResponseBody   *string               `gorm:"type:text" json:"response_body,omitempty"` // Truncated
+	Error          *string               `gorm:"type:text" json:"error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `gorm:"index" json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+
+	Subscription WebhookSubscription `gorm:"foreignKey:SubscriptionID" json:"subscription,omitempty"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,873 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: hrms/v1/hrms.proto
+
+package hrmsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Employee struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EmployeeNumber string                 `protobuf:"bytes,2,opt,name=employee_number,json=employeeNumber,proto3" json:"employee_number,omitempty"`
+	Firstname      string                 `protobuf:"bytes,3,opt,name=firstname,proto3" json:"firstname,omitempty"`
+	Lastname       string                 `protobuf:"bytes,4,opt,name=lastname,proto3" json:"lastname,omitempty"`
+	Email          string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Department     string                 `protobuf:"bytes,6,opt,name=department,proto3" json:"department,omitempty"`
+	JobTitle       string                 `protobuf:"bytes,7,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
+	Role           string                 `protobuf:"bytes,8,opt,name=role,proto3" json:"role,omitempty"`
+	Status         string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	PositionId     uint32                 `protobuf:"varint,10,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	ManagerId      uint32                 `protobuf:"varint,11,opt,name=manager_id,json=managerId,proto3" json:"manager_id,omitempty"`
+	DateJoined     string                 `protobuf:"bytes,12,opt,name=date_joined,json=dateJoined,proto3" json:"date_joined,omitempty"` // YYYY-MM-DD
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Employee) Reset() {
+	*x = Employee{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Employee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Employee) ProtoMessage() {}
+
+func (x *Employee) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Employee.ProtoReflect.Descriptor instead.
+func (*Employee) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Employee) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Employee) GetEmployeeNumber() string {
+	if x != nil {
+		return x.EmployeeNumber
+	}
+	return ""
+}
+
+func (x *Employee) GetFirstname() string {
+	if x != nil {
+		return x.Firstname
+	}
+	return ""
+}
+
+func (x *Employee) GetLastname() string {
+	if x != nil {
+		return x.Lastname
+	}
+	return ""
+}
+
+func (x *Employee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Employee) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *Employee) GetJobTitle() string {
+	if x != nil {
+		return x.JobTitle
+	}
+	return ""
+}
+
+func (x *Employee) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Employee) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Employee) GetPositionId() uint32 {
+	if x != nil {
+		return x.PositionId
+	}
+	return 0
+}
+
+func (x *Employee) GetManagerId() uint32 {
+	if x != nil {
+		return x.ManagerId
+	}
+	return 0
+}
+
+func (x *Employee) GetDateJoined() string {
+	if x != nil {
+		return x.DateJoined
+	}
+	return ""
+}
+
+type GetEmployeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmployeeRequest) Reset() {
+	*x = GetEmployeeRequest{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmployeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmployeeRequest) ProtoMessage() {}
+
+func (x *GetEmployeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmployeeRequest.ProtoReflect.Descriptor instead.
+func (*GetEmployeeRequest) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEmployeeRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListEmployeesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Department    string                 `protobuf:"bytes,1,opt,name=department,proto3" json:"department,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                      // Default 1
+	PerPage       int32                  `protobuf:"varint,3,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"` // Default 25, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmployeesRequest) Reset() {
+	*x = ListEmployeesRequest{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmployeesRequest) ProtoMessage() {}
+
+func (x *ListEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*ListEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListEmployeesRequest) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *ListEmployeesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListEmployeesRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type ListEmployeesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Employees     []*Employee            `protobuf:"bytes,1,rep,name=employees,proto3" json:"employees,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmployeesResponse) Reset() {
+	*x = ListEmployeesResponse{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmployeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmployeesResponse) ProtoMessage() {}
+
+func (x *ListEmployeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmployeesResponse.ProtoReflect.Descriptor instead.
+func (*ListEmployeesResponse) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListEmployeesResponse) GetEmployees() []*Employee {
+	if x != nil {
+		return x.Employees
+	}
+	return nil
+}
+
+func (x *ListEmployeesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetLeaveBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId    uint32                 `protobuf:"varint,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaveBalanceRequest) Reset() {
+	*x = GetLeaveBalanceRequest{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaveBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaveBalanceRequest) ProtoMessage() {}
+
+func (x *GetLeaveBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaveBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaveBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLeaveBalanceRequest) GetEmployeeId() uint32 {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return 0
+}
+
+type LeaveBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId    uint32                 `protobuf:"varint,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	LeaveTypeId   uint32                 `protobuf:"varint,2,opt,name=leave_type_id,json=leaveTypeId,proto3" json:"leave_type_id,omitempty"`
+	LeaveTypeName string                 `protobuf:"bytes,3,opt,name=leave_type_name,json=leaveTypeName,proto3" json:"leave_type_name,omitempty"`
+	MaxDays       int32                  `protobuf:"varint,4,opt,name=max_days,json=maxDays,proto3" json:"max_days,omitempty"`
+	UsedDays      int32                  `protobuf:"varint,5,opt,name=used_days,json=usedDays,proto3" json:"used_days,omitempty"`
+	Balance       float64                `protobuf:"fixed64,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveBalance) Reset() {
+	*x = LeaveBalance{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveBalance) ProtoMessage() {}
+
+func (x *LeaveBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveBalance.ProtoReflect.Descriptor instead.
+func (*LeaveBalance) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LeaveBalance) GetEmployeeId() uint32 {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return 0
+}
+
+func (x *LeaveBalance) GetLeaveTypeId() uint32 {
+	if x != nil {
+		return x.LeaveTypeId
+	}
+	return 0
+}
+
+func (x *LeaveBalance) GetLeaveTypeName() string {
+	if x != nil {
+		return x.LeaveTypeName
+	}
+	return ""
+}
+
+func (x *LeaveBalance) GetMaxDays() int32 {
+	if x != nil {
+		return x.MaxDays
+	}
+	return 0
+}
+
+func (x *LeaveBalance) GetUsedDays() int32 {
+	if x != nil {
+		return x.UsedDays
+	}
+	return 0
+}
+
+func (x *LeaveBalance) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type LeaveEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // Pass back as after_cursor to continue after this event
+	LeaveId       uint32                 `protobuf:"varint,2,opt,name=leave_id,json=leaveId,proto3" json:"leave_id,omitempty"`
+	EmployeeId    uint32                 `protobuf:"varint,3,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	LeaveTypeId   uint32                 `protobuf:"varint,4,opt,name=leave_type_id,json=leaveTypeId,proto3" json:"leave_type_id,omitempty"`
+	LeaveTypeName string                 `protobuf:"bytes,5,opt,name=leave_type_name,json=leaveTypeName,proto3" json:"leave_type_name,omitempty"`
+	StartDate     string                 `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // YYYY-MM-DD
+	EndDate       string                 `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // YYYY-MM-DD
+	Days          int32                  `protobuf:"varint,8,opt,name=days,proto3" json:"days,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveEvent) Reset() {
+	*x = LeaveEvent{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveEvent) ProtoMessage() {}
+
+func (x *LeaveEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveEvent.ProtoReflect.Descriptor instead.
+func (*LeaveEvent) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LeaveEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *LeaveEvent) GetLeaveId() uint32 {
+	if x != nil {
+		return x.LeaveId
+	}
+	return 0
+}
+
+func (x *LeaveEvent) GetEmployeeId() uint32 {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return 0
+}
+
+func (x *LeaveEvent) GetLeaveTypeId() uint32 {
+	if x != nil {
+		return x.LeaveTypeId
+	}
+	return 0
+}
+
+func (x *LeaveEvent) GetLeaveTypeName() string {
+	if x != nil {
+		return x.LeaveTypeName
+	}
+	return ""
+}
+
+func (x *LeaveEvent) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *LeaveEvent) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *LeaveEvent) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *LeaveEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LeaveEvent) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListLeaveEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterCursor   string                 `protobuf:"bytes,1,opt,name=after_cursor,json=afterCursor,proto3" json:"after_cursor,omitempty"` // Empty to start from the first event
+	EmployeeId    uint32                 `protobuf:"varint,2,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`   // Optional
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                               // Default 100, max 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeaveEventsRequest) Reset() {
+	*x = ListLeaveEventsRequest{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeaveEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeaveEventsRequest) ProtoMessage() {}
+
+func (x *ListLeaveEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeaveEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListLeaveEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListLeaveEventsRequest) GetAfterCursor() string {
+	if x != nil {
+		return x.AfterCursor
+	}
+	return ""
+}
+
+func (x *ListLeaveEventsRequest) GetEmployeeId() uint32 {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return 0
+}
+
+func (x *ListLeaveEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLeaveEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*LeaveEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Cursor of the last event, or after_cursor when there are none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeaveEventsResponse) Reset() {
+	*x = ListLeaveEventsResponse{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeaveEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeaveEventsResponse) ProtoMessage() {}
+
+func (x *ListLeaveEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeaveEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListLeaveEventsResponse) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListLeaveEventsResponse) GetEvents() []*LeaveEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListLeaveEventsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type WatchLeaveEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterCursor   string                 `protobuf:"bytes,1,opt,name=after_cursor,json=afterCursor,proto3" json:"after_cursor,omitempty"` // Empty to start from the first event
+	EmployeeId    uint32                 `protobuf:"varint,2,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`   // Optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLeaveEventsRequest) Reset() {
+	*x = WatchLeaveEventsRequest{}
+	mi := &file_hrms_v1_hrms_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLeaveEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLeaveEventsRequest) ProtoMessage() {}
+
+func (x *WatchLeaveEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hrms_v1_hrms_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLeaveEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchLeaveEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hrms_v1_hrms_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchLeaveEventsRequest) GetAfterCursor() string {
+	if x != nil {
+		return x.AfterCursor
+	}
+	return ""
+}
+
+func (x *WatchLeaveEventsRequest) GetEmployeeId() uint32 {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return 0
+}
+
+var File_hrms_v1_hrms_proto protoreflect.FileDescriptor
+
+const file_hrms_v1_hrms_proto_rawDesc = "" +
+	"\n" +
+	"\x12hrms/v1/hrms.proto\x12\ahrms.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x02\n" +
+	"\bEmployee\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12'\n" +
+	"\x0femployee_number\x18\x02 \x01(\tR\x0eemployeeNumber\x12\x1c\n" +
+	"\tfirstname\x18\x03 \x01(\tR\tfirstname\x12\x1a\n" +
+	"\blastname\x18\x04 \x01(\tR\blastname\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"department\x18\x06 \x01(\tR\n" +
+	"department\x12\x1b\n" +
+	"\tjob_title\x18\a \x01(\tR\bjobTitle\x12\x12\n" +
+	"\x04role\x18\b \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1f\n" +
+	"\vposition_id\x18\n" +
+	" \x01(\rR\n" +
+	"positionId\x12\x1d\n" +
+	"\n" +
+	"manager_id\x18\v \x01(\rR\tmanagerId\x12\x1f\n" +
+	"\vdate_joined\x18\f \x01(\tR\n" +
+	"dateJoined\"$\n" +
+	"\x12GetEmployeeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"e\n" +
+	"\x14ListEmployeesRequest\x12\x1e\n" +
+	"\n" +
+	"department\x18\x01 \x01(\tR\n" +
+	"department\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x03 \x01(\x05R\aperPage\"^\n" +
+	"\x15ListEmployeesResponse\x12/\n" +
+	"\temployees\x18\x01 \x03(\v2\x11.hrms.v1.EmployeeR\temployees\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"9\n" +
+	"\x16GetLeaveBalanceRequest\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\rR\n" +
+	"employeeId\"\xcd\x01\n" +
+	"\fLeaveBalance\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\rR\n" +
+	"employeeId\x12\"\n" +
+	"\rleave_type_id\x18\x02 \x01(\rR\vleaveTypeId\x12&\n" +
+	"\x0fleave_type_name\x18\x03 \x01(\tR\rleaveTypeName\x12\x19\n" +
+	"\bmax_days\x18\x04 \x01(\x05R\amaxDays\x12\x1b\n" +
+	"\tused_days\x18\x05 \x01(\x05R\busedDays\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x01R\abalance\"\xcd\x02\n" +
+	"\n" +
+	"LeaveEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x19\n" +
+	"\bleave_id\x18\x02 \x01(\rR\aleaveId\x12\x1f\n" +
+	"\vemployee_id\x18\x03 \x01(\rR\n" +
+	"employeeId\x12\"\n" +
+	"\rleave_type_id\x18\x04 \x01(\rR\vleaveTypeId\x12&\n" +
+	"\x0fleave_type_name\x18\x05 \x01(\tR\rleaveTypeName\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x06 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\a \x01(\tR\aendDate\x12\x12\n" +
+	"\x04days\x18\b \x01(\x05R\x04days\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"r\n" +
+	"\x16ListLeaveEventsRequest\x12!\n" +
+	"\fafter_cursor\x18\x01 \x01(\tR\vafterCursor\x12\x1f\n" +
+	"\vemployee_id\x18\x02 \x01(\rR\n" +
+	"employeeId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"g\n" +
+	"\x17ListLeaveEventsResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.hrms.v1.LeaveEventR\x06events\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"]\n" +
+	"\x17WatchLeaveEventsRequest\x12!\n" +
+	"\fafter_cursor\x18\x01 \x01(\tR\vafterCursor\x12\x1f\n" +
+	"\vemployee_id\x18\x02 \x01(\rR\n" +
+	"employeeId2\xa0\x01\n" +
+	"\x0fEmployeeService\x12=\n" +
+	"\vGetEmployee\x12\x1b.hrms.v1.GetEmployeeRequest\x1a\x11.hrms.v1.Employee\x12N\n" +
+	"\rListEmployees\x12\x1d.hrms.v1.ListEmployeesRequest\x1a\x1e.hrms.v1.ListEmployeesResponse2\xfc\x01\n" +
+	"\fLeaveService\x12I\n" +
+	"\x0fGetLeaveBalance\x12\x1f.hrms.v1.GetLeaveBalanceRequest\x1a\x15.hrms.v1.LeaveBalance\x12T\n" +
+	"\x0fListLeaveEvents\x12\x1f.hrms.v1.ListLeaveEventsRequest\x1a .hrms.v1.ListLeaveEventsResponse\x12K\n" +
+	"\x10WatchLeaveEvents\x12 .hrms.v1.WatchLeaveEventsRequest\x1a\x13.hrms.v1.LeaveEvent0\x01B\x1fZ\x1dhrms-api/proto/hrms/v1;hrmsv1b\x06proto3"
+
+var (
+	file_hrms_v1_hrms_proto_rawDescOnce sync.Once
+	file_hrms_v1_hrms_proto_rawDescData []byte
+)
+
+func file_hrms_v1_hrms_proto_rawDescGZIP() []byte {
+	file_hrms_v1_hrms_proto_rawDescOnce.Do(func() {
+		file_hrms_v1_hrms_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hrms_v1_hrms_proto_rawDesc), len(file_hrms_v1_hrms_proto_rawDesc)))
+	})
+	return file_hrms_v1_hrms_proto_rawDescData
+}
+
+var file_hrms_v1_hrms_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_hrms_v1_hrms_proto_goTypes = []any{
+	(*Employee)(nil),                // 0: hrms.v1.Employee
+	(*GetEmployeeRequest)(nil),      // 1: hrms.v1.GetEmployeeRequest
+	(*ListEmployeesRequest)(nil),    // 2: hrms.v1.ListEmployeesRequest
+	(*ListEmployeesResponse)(nil),   // 3: hrms.v1.ListEmployeesResponse
+	(*GetLeaveBalanceRequest)(nil),  // 4: hrms.v1.GetLeaveBalanceRequest
+	(*LeaveBalance)(nil),            // 5: hrms.v1.LeaveBalance
+	(*LeaveEvent)(nil),              // 6: hrms.v1.LeaveEvent
+	(*ListLeaveEventsRequest)(nil),  // 7: hrms.v1.ListLeaveEventsRequest
+	(*ListLeaveEventsResponse)(nil), // 8: hrms.v1.ListLeaveEventsResponse
+	(*WatchLeaveEventsRequest)(nil), // 9: hrms.v1.WatchLeaveEventsRequest
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
+}
+var file_hrms_v1_hrms_proto_depIdxs = []int32{
+	0,  // 0: hrms.v1.ListEmployeesResponse.employees:type_name -> hrms.v1.Employee
+	10, // 1: hrms.v1.LeaveEvent.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: hrms.v1.ListLeaveEventsResponse.events:type_name -> hrms.v1.LeaveEvent
+	1,  // 3: hrms.v1.EmployeeService.GetEmployee:input_type -> hrms.v1.GetEmployeeRequest
+	2,  // 4: hrms.v1.EmployeeService.ListEmployees:input_type -> hrms.v1.ListEmployeesRequest
+	4,  // 5: hrms.v1.LeaveService.GetLeaveBalance:input_type -> hrms.v1.GetLeaveBalanceRequest
+	7,  // 6: hrms.v1.LeaveService.ListLeaveEvents:input_type -> hrms.v1.ListLeaveEventsRequest
+	9,  // 7: hrms.v1.LeaveService.WatchLeaveEvents:input_type -> hrms.v1.WatchLeaveEventsRequest
+	0,  // 8: hrms.v1.EmployeeService.GetEmployee:output_type -> hrms.v1.Employee
+	3,  // 9: hrms.v1.EmployeeService.ListEmployees:output_type -> hrms.v1.ListEmployeesResponse
+	5,  // 10: hrms.v1.LeaveService.GetLeaveBalance:output_type -> hrms.v1.LeaveBalance
+	8,  // 11: hrms.v1.LeaveService.ListLeaveEvents:output_type -> hrms.v1.ListLeaveEventsResponse
+	6,  // 12: hrms.v1.LeaveService.WatchLeaveEvents:output_type -> hrms.v1.LeaveEvent
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_hrms_v1_hrms_proto_init() }
+func file_hrms_v1_hrms_proto_init() {
+	if File_hrms_v1_hrms_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hrms_v1_hrms_proto_rawDesc), len(file_hrms_v1_hrms_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_hrms_v1_hrms_proto_goTypes,
+		DependencyIndexes: file_hrms_v1_hrms_proto_depIdxs,
+		MessageInfos:      file_hrms_v1_hrms_proto_msgTypes,
+	}.Build()
+	File_hrms_v1_hrms_proto = out.File
+	file_hrms_v1_hrms_proto_goTypes = nil
+	file_hrms_v1_hrms_proto_depIdxs = nil
+}
@@ -0,0 +1,353 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: hrms/v1/hrms.proto
+
+package hrmsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EmployeeService_GetEmployee_FullMethodName   = "/hrms.v1.EmployeeService/GetEmployee"
+	EmployeeService_ListEmployees_FullMethodName = "/hrms.v1.EmployeeService/ListEmployees"
+)
+
+// EmployeeServiceClient is the client API for EmployeeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EmployeeService exposes employee records to internal services
+type EmployeeServiceClient interface {
+	GetEmployee(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*Employee, error)
+	ListEmployees(ctx context.Context, in *ListEmployeesRequest, opts ...grpc.CallOption) (*ListEmployeesResponse, error)
+}
+
+type employeeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmployeeServiceClient(cc grpc.ClientConnInterface) EmployeeServiceClient {
+	return &employeeServiceClient{cc}
+}
+
+func (c *employeeServiceClient) GetEmployee(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_GetEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ListEmployees(ctx context.Context, in *ListEmployeesRequest, opts ...grpc.CallOption) (*ListEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_ListEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmployeeServiceServer is the server API for EmployeeService service.
+// All implementations must embed UnimplementedEmployeeServiceServer
+// for forward compatibility.
+//
+// EmployeeService exposes employee records to internal services
+type EmployeeServiceServer interface {
+	GetEmployee(context.Context, *GetEmployeeRequest) (*Employee, error)
+	ListEmployees(context.Context, *ListEmployeesRequest) (*ListEmployeesResponse, error)
+	mustEmbedUnimplementedEmployeeServiceServer()
+}
+
+// UnimplementedEmployeeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEmployeeServiceServer struct{}
+
+func (UnimplementedEmployeeServiceServer) GetEmployee(context.Context, *GetEmployeeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ListEmployees(context.Context, *ListEmployeesRequest) (*ListEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) mustEmbedUnimplementedEmployeeServiceServer() {}
+func (UnimplementedEmployeeServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeEmployeeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmployeeServiceServer will
+// result in compilation errors.
+type UnsafeEmployeeServiceServer interface {
+	mustEmbedUnimplementedEmployeeServiceServer()
+}
+
+func RegisterEmployeeServiceServer(s grpc.ServiceRegistrar, srv EmployeeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEmployeeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EmployeeService_ServiceDesc, srv)
+}
+
+func _EmployeeService_GetEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetEmployee(ctx, req.(*GetEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ListEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListEmployees(ctx, req.(*ListEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmployeeService_ServiceDesc is the grpc.ServiceDesc for EmployeeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmployeeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hrms.v1.EmployeeService",
+	HandlerType: (*EmployeeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEmployee",
+			Handler:    _EmployeeService_GetEmployee_Handler,
+		},
+		{
+			MethodName: "ListEmployees",
+			Handler:    _EmployeeService_ListEmployees_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hrms/v1/hrms.proto",
+}
+
+const (
+	LeaveService_GetLeaveBalance_FullMethodName  = "/hrms.v1.LeaveService/GetLeaveBalance"
+	LeaveService_ListLeaveEvents_FullMethodName  = "/hrms.v1.LeaveService/ListLeaveEvents"
+	LeaveService_WatchLeaveEvents_FullMethodName = "/hrms.v1.LeaveService/WatchLeaveEvents"
+)
+
+// LeaveServiceClient is the client API for LeaveService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LeaveService exposes leave balances and leave changes to internal services
+type LeaveServiceClient interface {
+	GetLeaveBalance(ctx context.Context, in *GetLeaveBalanceRequest, opts ...grpc.CallOption) (*LeaveBalance, error)
+	// ListLeaveEvents returns leave changes after a cursor, oldest first
+	ListLeaveEvents(ctx context.Context, in *ListLeaveEventsRequest, opts ...grpc.CallOption) (*ListLeaveEventsResponse, error)
+	// WatchLeaveEvents streams leave changes after a cursor as they happen
+	WatchLeaveEvents(ctx context.Context, in *WatchLeaveEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaveEvent], error)
+}
+
+type leaveServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaveServiceClient(cc grpc.ClientConnInterface) LeaveServiceClient {
+	return &leaveServiceClient{cc}
+}
+
+func (c *leaveServiceClient) GetLeaveBalance(ctx context.Context, in *GetLeaveBalanceRequest, opts ...grpc.CallOption) (*LeaveBalance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveBalance)
+	err := c.cc.Invoke(ctx, LeaveService_GetLeaveBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) ListLeaveEvents(ctx context.Context, in *ListLeaveEventsRequest, opts ...grpc.CallOption) (*ListLeaveEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLeaveEventsResponse)
+	err := c.cc.Invoke(ctx, LeaveService_ListLeaveEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) WatchLeaveEvents(ctx context.Context, in *WatchLeaveEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaveEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LeaveService_ServiceDesc.Streams[0], LeaveService_WatchLeaveEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLeaveEventsRequest, LeaveEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaveService_WatchLeaveEventsClient = grpc.ServerStreamingClient[LeaveEvent]
+
+// LeaveServiceServer is the server API for LeaveService service.
+// All implementations must embed UnimplementedLeaveServiceServer
+// for forward compatibility.
+//
+// LeaveService exposes leave balances and leave changes to internal services
+type LeaveServiceServer interface {
+	GetLeaveBalance(context.Context, *GetLeaveBalanceRequest) (*LeaveBalance, error)
+	// ListLeaveEvents returns leave changes after a cursor, oldest first
+	ListLeaveEvents(context.Context, *ListLeaveEventsRequest) (*ListLeaveEventsResponse, error)
+	// WatchLeaveEvents streams leave changes after a cursor as they happen
+	WatchLeaveEvents(*WatchLeaveEventsRequest, grpc.ServerStreamingServer[LeaveEvent]) error
+	mustEmbedUnimplementedLeaveServiceServer()
+}
+
+// UnimplementedLeaveServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaveServiceServer struct{}
+
+func (UnimplementedLeaveServiceServer) GetLeaveBalance(context.Context, *GetLeaveBalanceRequest) (*LeaveBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaveBalance not implemented")
+}
+func (UnimplementedLeaveServiceServer) ListLeaveEvents(context.Context, *ListLeaveEventsRequest) (*ListLeaveEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaveEvents not implemented")
+}
+func (UnimplementedLeaveServiceServer) WatchLeaveEvents(*WatchLeaveEventsRequest, grpc.ServerStreamingServer[LeaveEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLeaveEvents not implemented")
+}
+func (UnimplementedLeaveServiceServer) mustEmbedUnimplementedLeaveServiceServer() {}
+func (UnimplementedLeaveServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeLeaveServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaveServiceServer will
+// result in compilation errors.
+type UnsafeLeaveServiceServer interface {
+	mustEmbedUnimplementedLeaveServiceServer()
+}
+
+func RegisterLeaveServiceServer(s grpc.ServiceRegistrar, srv LeaveServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeaveServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeaveService_ServiceDesc, srv)
+}
+
+func _LeaveService_GetLeaveBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaveBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).GetLeaveBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_GetLeaveBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).GetLeaveBalance(ctx, req.(*GetLeaveBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_ListLeaveEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaveEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).ListLeaveEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_ListLeaveEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).ListLeaveEvents(ctx, req.(*ListLeaveEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_WatchLeaveEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLeaveEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaveServiceServer).WatchLeaveEvents(m, &grpc.GenericServerStream[WatchLeaveEventsRequest, LeaveEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaveService_WatchLeaveEventsServer = grpc.ServerStreamingServer[LeaveEvent]
+
+// LeaveService_ServiceDesc is the grpc.ServiceDesc for LeaveService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaveService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hrms.v1.LeaveService",
+	HandlerType: (*LeaveServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLeaveBalance",
+			Handler:    _LeaveService_GetLeaveBalance_Handler,
+		},
+		{
+			MethodName: "ListLeaveEvents",
+			Handler:    _LeaveService_ListLeaveEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLeaveEvents",
+			Handler:       _LeaveService_WatchLeaveEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hrms/v1/hrms.proto",
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"hrms-api/database"
+	"hrms-api/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BalanceRepository reads leave balances from the accrual ledger
+type BalanceRepository interface {
+	// EnsureAccrualsUpToDate records any monthly accruals still missing for the employee
+	EnsureAccrualsUpToDate(ctx context.Context, employeeID, leaveTypeID uint) error
+	CurrentBalance(ctx context.Context, employeeID, leaveTypeID uint) (float64, error)
+	// ProjectedBalance includes the accruals still to come before the date
+	ProjectedBalance(ctx context.Context, employeeID, leaveTypeID uint, at time.Time) (float64, error)
+	// AvailableBalance is the balance at the date, ignoring the excluded leave
+	AvailableBalance(ctx context.Context, employeeID, leaveTypeID uint, excludeLeaveID *uint, at *time.Time) (float64, error)
+}
+
+type ledgerBalanceRepository struct {
+	db *gorm.DB
+}
+
+// NewBalanceRepository returns a BalanceRepository over the accrual ledger in utils, backed by db
+func NewBalanceRepository(db *gorm.DB) BalanceRepository {
+	return &ledgerBalanceRepository{db: db}
+}
+
+// session returns the repository's database bound to ctx, in the transaction of a batch if there is one
+func (r *ledgerBalanceRepository) session(ctx context.Context) *gorm.DB {
+	return database.Session(ctx, r.db)
+}
+
+func (r *ledgerBalanceRepository) EnsureAccrualsUpToDate(ctx context.Context, employeeID, leaveTypeID uint) error {
+	return utils.EnsureAccrualsUpToDate(r.session(ctx), employeeID, leaveTypeID)
+}
+
+func (r *ledgerBalanceRepository) CurrentBalance(ctx context.Context, employeeID, leaveTypeID uint) (float64, error) {
+	return utils.GetCurrentLeaveBalance(r.session(ctx), employeeID, leaveTypeID)
+}
+
+func (r *ledgerBalanceRepository) ProjectedBalance(ctx context.Context, employeeID, leaveTypeID uint, at time.Time) (float64, error) {
+	return utils.CalculateProjectedAnnualLeaveBalance(r.session(ctx), employeeID, leaveTypeID, at)
+}
+
+func (r *ledgerBalanceRepository) AvailableBalance(ctx context.Context, employeeID, leaveTypeID uint, excludeLeaveID *uint, at *time.Time) (float64, error) {
+	return utils.GetAvailableLeaveBalance(r.session(ctx), employeeID, leaveTypeID, excludeLeaveID, at)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"flag"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/testutil"
+	"testing"
+	"time"
+)
+
+// benchRows is the number of rows generated in each table, spread over an employee per 50 rows, the
+// seeded leave types and benchMonths accrual months
+var benchRows = flag.Int("bench-rows", 100000, "rows generated in each table by BenchmarkLeaveQueries")
+
+const benchMonths = 10
+
+// BenchmarkLeaveQueries times the leave and leave accrual lookups the API runs most, with the
+// composite indexes idx_leaves_lookup and idx_leave_accruals_lookup that migrations create and again
+// with only the single-column ones:
+//
+//	go test -run '^$' -bench LeaveQueries ./repository
+//
+// Pass -bench-rows to seed fewer or more than 100000 rows.
+func BenchmarkLeaveQueries(b *testing.B) {
+	testutil.Setup(b)
+	employees, leaveTypes := seedLeaveQueries(b)
+
+	lookups := []struct {
+		name  string
+		model interface{}
+		index string
+		query func(i int) error
+	}{
+		{
+			name:  "leaves by employee, leave type, status and start date",
+			model: &models.Leave{},
+			index: "idx_leaves_lookup",
+			query: func(i int) error {
+				var leaves []models.Leave
+				return database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date > ?",
+					employees[i%len(employees)], leaveTypes[i%len(leaveTypes)], models.StatusApproved,
+					time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)).Find(&leaves).Error
+			},
+		},
+		{
+			name:  "leave accruals by employee, leave type and accrual month",
+			model: &models.LeaveAccrual{},
+			index: "idx_leave_accruals_lookup",
+			query: func(i int) error {
+				var accruals []models.LeaveAccrual
+				return database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+					employees[i%len(employees)], leaveTypes[i%len(leaveTypes)], benchMonth(i)).Find(&accruals).Error
+			},
+		},
+	}
+
+	run := func(query func(i int) error) func(b *testing.B) {
+		return func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := query(i); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	migrator := database.DB.Migrator()
+	for _, lookup := range lookups {
+		b.Run(lookup.name+"/composite index", run(lookup.query))
+
+		if err := migrator.DropIndex(lookup.model, lookup.index); err != nil {
+			b.Fatalf("dropping %s: %v", lookup.index, err)
+		}
+		b.Run(lookup.name+"/single-column indexes", run(lookup.query))
+		if err := migrator.CreateIndex(lookup.model, lookup.index); err != nil {
+			b.Fatalf("recreating %s: %v", lookup.index, err)
+		}
+	}
+}
+
+// seedLeaveQueries adds the employees, and benchRows leaves and accruals spread over them and the
+// seeded leave types, returning the employee and leave type IDs
+func seedLeaveQueries(b *testing.B) (employees, leaveTypes []uint) {
+	b.Helper()
+	hired := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < max(*benchRows/50, 1); i++ {
+		employees = append(employees, testutil.CreateEmployee(b, fmt.Sprintf("Department %d", i%10), hired).ID)
+	}
+	if err := database.DB.Model(&models.LeaveType{}).Order("id").Pluck("id", &leaveTypes).Error; err != nil {
+		b.Fatal(err)
+	}
+
+	statuses := []models.LeaveStatus{models.StatusPending, models.StatusApproved, models.StatusRejected, models.StatusCancelled}
+	leaves := make([]models.Leave, 0, *benchRows)
+	accruals := make([]models.LeaveAccrual, 0, *benchRows)
+	for i := 0; i < *benchRows; i++ {
+		start := time.Date(2020, time.January, 1+i%1500, 0, 0, 0, 0, time.UTC)
+		leaves = append(leaves, models.Leave{
+			EmployeeID:  employees[i%len(employees)],
+			LeaveTypeID: leaveTypes[(i/len(employees))%len(leaveTypes)],
+			StartDate:   start,
+			EndDate:     start.AddDate(0, 0, 3),
+			Status:      statuses[i%len(statuses)],
+		})
+		month := benchMonth(i / (len(employees) * len(leaveTypes)))
+		accruals = append(accruals, models.LeaveAccrual{
+			EmployeeID:   employees[i%len(employees)],
+			LeaveTypeID:  leaveTypes[(i/len(employees))%len(leaveTypes)],
+			Year:         month.Year(),
+			Month:        int(month.Month()),
+			AccrualMonth: &month,
+			DaysAccrued:  2,
+		})
+	}
+	if err := database.DB.CreateInBatches(&leaves, 500).Error; err != nil {
+		b.Fatalf("creating leaves: %v", err)
+	}
+	if err := database.DB.CreateInBatches(&accruals, 500).Error; err != nil {
+		b.Fatalf("creating accruals: %v", err)
+	}
+	return employees, leaveTypes
+}
+
+// benchMonth is the accrual month of the ith lookup or row
+func benchMonth(i int) time.Time {
+	return time.Date(2022, time.Month(i%benchMonths+1), 1, 0, 0, 0, 0, time.UTC)
+}
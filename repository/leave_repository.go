@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// LeaveRepository loads and stores leave requests and their audit trail
+type LeaveRepository interface {
+	// FindLeave loads a leave with its Employee and LeaveType
+	FindLeave(ctx context.Context, id uint) (*models.Leave, error)
+	FindLeaveType(ctx context.Context, id uint) (*models.LeaveType, error)
+	FindEmployee(ctx context.Context, id uint) (*models.Employee, error)
+	// HasOverlappingLeave reports whether the employee has a pending or approved leave overlapping the dates
+	HasOverlappingLeave(ctx context.Context, employeeID uint, startDate, endDate time.Time, excludeLeaveID *uint) (bool, error)
+	// Create saves a new leave together with its first audit record
+	Create(ctx context.Context, leave *models.Leave, audit *models.LeaveAudit) error
+	// UpdateStatus saves a leave's new status together with its audit record, drawing carryOverDays
+	// from the employee's carry-over when greater than zero
+	UpdateStatus(ctx context.Context, leave *models.Leave, audit *models.LeaveAudit, carryOverDays float64) error
+}
+
+type gormLeaveRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaveRepository returns a LeaveRepository backed by db
+func NewLeaveRepository(db *gorm.DB) LeaveRepository {
+	return &gormLeaveRepository{db: db}
+}
+
+// session returns the repository's database bound to ctx, in the transaction of a batch if there is one
+func (r *gormLeaveRepository) session(ctx context.Context) *gorm.DB {
+	return database.Session(ctx, r.db)
+}
+
+func (r *gormLeaveRepository) FindLeave(ctx context.Context, id uint) (*models.Leave, error) {
+	var leave models.Leave
+	if err := r.session(ctx).Preload("Employee").Preload("LeaveType").First(&leave, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &leave, nil
+}
+
+func (r *gormLeaveRepository) FindLeaveType(ctx context.Context, id uint) (*models.LeaveType, error) {
+	var leaveType models.LeaveType
+	if err := r.session(ctx).First(&leaveType, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &leaveType, nil
+}
+
+func (r *gormLeaveRepository) FindEmployee(ctx context.Context, id uint) (*models.Employee, error) {
+	var employee models.Employee
+	if err := r.session(ctx).First(&employee, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &employee, nil
+}
+
+func (r *gormLeaveRepository) HasOverlappingLeave(ctx context.Context, employeeID uint, startDate, endDate time.Time, excludeLeaveID *uint) (bool, error) {
+	var count int64
+	query := r.session(ctx).Model(&models.Leave{}).
+		Where("employee_id = ?", employeeID).
+		Where("status IN ?", []models.LeaveStatus{models.StatusPending, models.StatusApproved}).
+		Where("(start_date <= ? AND end_date >= ?) OR (start_date <= ? AND end_date >= ?) OR (start_date >= ? AND end_date <= ?)",
+			endDate, startDate, startDate, endDate, startDate, endDate)
+	if excludeLeaveID != nil {
+		query = query.Where("id != ?", *excludeLeaveID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *gormLeaveRepository) Create(ctx context.Context, leave *models.Leave, audit *models.LeaveAudit) error {
+	return r.session(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(leave).Error; err != nil {
+			return err
+		}
+		audit.LeaveID = leave.ID
+		return tx.Create(audit).Error
+	})
+}
+
+func (r *gormLeaveRepository) UpdateStatus(ctx context.Context, leave *models.Leave, audit *models.LeaveAudit, carryOverDays float64) error {
+	return r.session(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(leave).Error; err != nil {
+			return err
+		}
+		if carryOverDays > 0 {
+			if err := utils.UpdateCarryOverUsage(tx, leave.EmployeeID, leave.LeaveTypeID, carryOverDays); err != nil {
+				return err
+			}
+		}
+		audit.LeaveID = leave.ID
+		return tx.Create(audit).Error
+	})
+}
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -1,94 +1,37 @@
 package routes
 
 import (
+	"hrms-api/config"
+	"hrms-api/database"
 	"hrms-api/handlers"
 	"hrms-api/middleware"
 	"hrms-api/models"
+	"hrms-api/repository"
+	"hrms-api/services"
+	"hrms-api/utils"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func SetupRoutes() *gin.Engine {
-	r := gin.Default()
-
-	// CORS configuration
-	// Check if we're in development mode for more permissive CORS
-	ginMode := os.Getenv("GIN_MODE")
-	isDevelopment := ginMode == "" || ginMode == "debug" || ginMode == "test"
-	// Allow explicit override via environment variable
-	corsAllowAll := os.Getenv("CORS_ALLOW_ALL") == "true" || os.Getenv("CORS_ALLOW_ALL") == "1"
-
-	r.Use(cors.New(cors.Config{
-		AllowOriginFunc: func(origin string) bool {
-			// Allow empty origin (same-origin requests, mobile apps, etc.)
-			if origin == "" {
-				return true
-			}
-
-			// Always allow requests from Swagger UI (same origin)
-			// Swagger is served from the same server, so same-origin requests have empty origin
-			// But we also allow explicit same-origin requests
-
-			// In development mode or if CORS_ALLOW_ALL is set, allow all http/https origins
-			if isDevelopment || corsAllowAll {
-				// Check if it's a valid http/https URL (must start with http:// or https://)
-				if strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://") {
-					return true
-				}
-			}
-
-			// Production: More restrictive origin checking
-			// Allow if origin ends with :8070 (any IP or hostname)
-			// This handles http://192.168.1.100:8070, http://localhost:8070, etc.
-			if len(origin) >= 6 {
-				suffix := origin[len(origin)-5:]
-				if suffix == ":8070" {
-					return true
-				}
-			}
-
-			// Allow common development ports from any host
-			allowedDevPorts := []string{":5173", ":3000", ":8080", ":5174", ":5175", ":4200", ":5176"}
-			for _, port := range allowedDevPorts {
-				if len(origin) >= len(port) {
-					suffix := origin[len(origin)-len(port):]
-					if suffix == port {
-						return true
-					}
-				}
-			}
-
-			// Allow localhost variants (with or without port)
-			if origin == "http://localhost" || origin == "https://localhost" ||
-				origin == "http://127.0.0.1" || origin == "https://127.0.0.1" {
-				return true
-			}
-
-			// Allow any localhost with port
-			if len(origin) > 16 && (origin[:16] == "http://localhost:" || origin[:17] == "https://localhost:") {
-				return true
-			}
-
-			// Allow any 127.0.0.1 with port
-			if len(origin) > 17 && (origin[:17] == "http://127.0.0.1:" || origin[:18] == "https://127.0.0.1:") {
-				return true
-			}
-
-			return false
-		},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * 3600, // 12 hours
-	}))
+	r := gin.New()
+
+	// Tracing runs first so the request log line and error responses carry the trace ID
+	r.Use(middleware.Tracing(config.AppConfig.ServiceName), middleware.TraceID(), middleware.RequestID(), middleware.Language())
+	r.Use(gin.LoggerWithFormatter(middleware.LogFormatter), gin.Recovery())
+	r.Use(middleware.SecurityHeaders(), middleware.Compress())
+
+	// CORS: CORS_ALLOWED_ORIGINS and the cors_allowed_origins runtime setting
+	r.Use(middleware.CORS())
+
+	// Every file download and report export is recorded in the file access log
+	r.Use(middleware.FileAccessLog())
 
 	// Swagger documentation
 	// Configure Swagger with CORS support
@@ -99,6 +42,9 @@ func SetupRoutes() *gin.Engine {
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
+	r.GET("/health/live", handlers.LiveHealth)
+	r.GET("/health/ready", handlers.ReadyHealth)
+	r.GET("/version", handlers.GetVersion)
 
 	// Serve static files from static directory (built Vue app)
 	staticDir := "./static"
@@ -115,7 +61,7 @@ func SetupRoutes() *gin.Engine {
 				if _, err := os.Stat(indexPath); err == nil {
 					c.File(indexPath)
 				} else {
-					c.JSON(http.StatusNotFound, gin.H{"error": "Frontend not built. Please build the client first."})
+					utils.RespondError(c, http.StatusNotFound, "Frontend not built. Please build the client first.")
 				}
 			})
 
@@ -132,10 +78,10 @@ func SetupRoutes() *gin.Engine {
 					if _, err := os.Stat(indexPath); err == nil {
 						c.File(indexPath)
 					} else {
-						c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+						utils.RespondError(c, http.StatusNotFound, "Not found")
 					}
 				} else {
-					c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+					utils.RespondError(c, http.StatusNotFound, "Not found")
 				}
 			})
 		}
@@ -146,37 +92,114 @@ func SetupRoutes() *gin.Engine {
 	{
 		// GET handler for /auth/login to prevent 404 when browser navigates to the route
 		auth.GET("/login", func(c *gin.Context) {
-			c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "Use POST method to login"})
+			utils.RespondError(c, http.StatusMethodNotAllowed, "Use POST method to login")
 		})
 		auth.POST("/login", handlers.Login)            // Employee/Manager login with NRC
 		auth.POST("/admin/login", handlers.AdminLogin) // Admin login with username
 		auth.POST("/register", handlers.Register)
 	}
 
+	// Third parties check employment letters by their verification code, without an account
+	r.GET("/verify/employment/:code", handlers.VerifyEmploymentLetter)
+
+	// Export files are downloaded through signed links instead of the token, so browsers can open them
+	r.GET("/api/export-jobs/:id/download", handlers.DownloadExportJob)
+
+	// Real-time events (server-sent events); accepts the token as a query parameter for EventSource clients
+	r.GET("/api/events", middleware.QueryTokenAuth(), middleware.AuthMiddleware(), middleware.Tenancy(), handlers.StreamEvents)
+
+	// Leave workflow handlers get their service and repositories injected
+	leaveHandler := handlers.NewLeaveHandler(services.NewLeaveService(
+		repository.NewLeaveRepository(database.DB),
+		repository.NewBalanceRepository(database.DB),
+	))
+
+	batchHandler := handlers.NewBatchHandler(r)
+
+	// Leave bot commands from Slack and Teams, authenticated by the platforms' request signatures
+	chatHandler := handlers.NewChatHandler(r)
+	integrations := r.Group("/integrations")
+	{
+		integrations.POST("/slack/commands", chatHandler.SlackCommand)
+		integrations.POST("/slack/interactions", chatHandler.SlackInteraction)
+		integrations.POST("/teams/messages", chatHandler.TeamsMessage)
+
+		// Google and Microsoft redirect here once a calendar connection is consented to, authenticated by its signed state
+		integrations.GET("/calendar/:provider/callback", handlers.CalendarCallback)
+
+		// Attendance terminals push clock events, authenticated by their device key
+		integrations.POST("/attendance/events", handlers.PushClockEvents)
+	}
+
 	// Protected routes
 	api := r.Group("/api")
 	api.Use(middleware.AuthMiddleware())
+	api.Use(middleware.Tenancy())
+	api.Use(middleware.MaskPII())
 	{
 		// Employee routes (all authenticated users)
 		leaves := api.Group("/leaves")
 		{
-			leaves.POST("", handlers.ApplyLeave)
+			leaves.POST("", leaveHandler.ApplyLeave)
 			leaves.GET("", handlers.GetMyLeaves)
 			leaves.GET("/balance", handlers.GetLeaveBalance)
-			leaves.PUT("/:id/cancel", handlers.CancelLeave) // Employees can cancel their own leaves
+			leaves.PUT("/:id/cancel", leaveHandler.CancelLeave) // Employees can cancel their own leaves
 		}
 
+		// Everything the home page shows the current user, in one call
+		api.GET("/me/dashboard", handlers.GetMyDashboard)
+
+		// Who the current user reports to and who reports to them
+		api.GET("/me/team", handlers.GetMyTeam)
+
+		// The current user's own pay history; everyone else's is behind payroll access
+		api.GET("/me/compensation", handlers.GetMyCompensation)
+
+		// Channels and groups of notifications the current user receives at all
+		api.GET("/me/preferences", handlers.GetMyPreferences)
+		api.PUT("/me/preferences", handlers.UpdateMyPreferences)
+
+		// Exports generated in the background for the current user
+		api.GET("/export-jobs", handlers.GetExportJobs)
+		api.GET("/export-jobs/:id", handlers.GetExportJob)
+
+		// Proof-of-employment letters employees issue for themselves
+		api.GET("/me/employment-letters", handlers.GetMyEmploymentLetters)
+		api.POST("/me/employment-letters", handlers.CreateEmploymentLetter)
+		api.GET("/me/employment-letters/:id/download", handlers.DownloadEmploymentLetter)
+		api.POST("/me/employment-letters/:id/revoke", handlers.RevokeEmploymentLetter)
+
 		// Leave types - GET is available to all, other operations require admin
 		api.GET("/leave-types", handlers.GetLeaveTypes)
 
+		// Batches of requests, each authorized as if sent on its own
+		api.POST("/batch", batchHandler.Batch)
+
+		// Slack and Teams accounts the leave bot acts as the current user for
+		api.POST("/chat-accounts/link-code", handlers.CreateChatLinkCode)
+		api.GET("/chat-accounts", handlers.GetChatAccounts)
+		api.DELETE("/chat-accounts/:id", handlers.DeleteChatAccount)
+
+		// Google and Outlook calendars approved leaves are added to
+		api.GET("/calendar-connections", handlers.GetCalendarConnections)
+		api.POST("/calendar-connections/authorize", handlers.ConnectCalendar)
+		api.PUT("/calendar-connections/:id", handlers.UpdateCalendarConnection)
+		api.DELETE("/calendar-connections/:id", handlers.DeleteCalendarConnection)
+
+		// Active public holidays of the organization's holiday calendar
+		api.GET("/holidays", handlers.GetHolidays)
+
 		// Manager routes
 		manager := api.Group("")
 		manager.Use(middleware.RequireRole(models.RoleManager, models.RoleAdmin))
 		{
 			manager.GET("/leaves/pending", handlers.GetPendingLeaves)
-			manager.PUT("/leaves/:id/approve", handlers.ApproveLeave)
-			manager.PUT("/leaves/:id/reject", handlers.RejectLeave)
+			manager.PUT("/leaves/:id/approve", leaveHandler.ApproveLeave)
+			manager.PUT("/leaves/:id/reject", leaveHandler.RejectLeave)
 			manager.GET("/leaves/:id/audit", handlers.GetLeaveAudit) // View audit trail
+
+			// The manager's direct reports at a glance
+			manager.GET("/manager/dashboard", handlers.GetTeamDashboard)
 		}
 
 		// HR Leave Management routes (Manager/Admin only)
@@ -186,12 +209,17 @@ func SetupRoutes() *gin.Engine {
 			// View endpoints
 			hr.GET("/employees/annual-leave-balances", handlers.GetAllEmployeesLeaveBalances)
 			hr.GET("/employees/annual-leave-balances/export", handlers.ExportAnnualLeaveBalances)
+			hr.POST("/employees/annual-leave-balances/export-jobs", handlers.CreateAnnualLeaveBalancesExportJob)
+			hr.GET("/file-access-logs", handlers.GetFileAccessLogs)
+			hr.GET("/documents/content-search", handlers.SearchDocumentContents)
 			// More specific routes must come before less specific ones
 			hr.GET("/employees/:id/annual-leave-balance/export", handlers.ExportEmployeeAnnualLeave)
 			hr.GET("/employees/:id/annual-leave-balance", handlers.GetAnnualLeaveBalance)
 			hr.GET("/leaves/calendar", handlers.GetLeaveCalendar)
+			hr.GET("/leaves/calendar/export", handlers.ExportLeaveCalendar)
 			hr.GET("/leaves/department-report", handlers.GetDepartmentLeaveReport)
 			hr.GET("/leaves/upcoming", handlers.GetUpcomingLeaves)
+			hr.GET("/profile-completeness", handlers.GetProfileCompletenessReport)
 
 			// Management endpoints
 			hr.POST("/employees/:id/annual-leave-balance/adjust", handlers.AdjustLeaveBalance)
@@ -199,6 +227,8 @@ func SetupRoutes() *gin.Engine {
 			hr.POST("/employees/:id/annual-leave-balance/accrual", handlers.AddManualAccrual)
 			hr.POST("/employees/:id/annual-leave-balance/accruals/bulk", handlers.BulkAddManualAccruals)
 			hr.POST("/leave-balances/import", handlers.BulkImportLeaveBalances)
+			hr.POST("/employees/annual-leave-balances/adjust", handlers.BulkAdjustLeaveBalances)
+			hr.POST("/employees/annual-leave-balances/adjust/import", handlers.ImportLeaveBalanceAdjustments)
 			hr.POST("/leaves/process-accruals", handlers.ProcessMonthlyAccruals)
 
 			// Bulk leave operations
@@ -239,6 +269,117 @@ func SetupRoutes() *gin.Engine {
 			adminSimple.GET("/employees/:id/leave-taken", handlers.GetEmployeeLeaveHistory)
 			// Get all employees leave balances
 			adminSimple.GET("/employees/leave-balances", handlers.GetAllEmployeesLeaveBalancesSimple)
+
+			// Deleted employees
+			adminSimple.GET("/employees/deleted", handlers.GetDeletedEmployees)
+			adminSimple.POST("/employees/:id/restore", handlers.RestoreEmployee)
+
+			// Right-to-erasure anonymization of former employees (preview, then confirm)
+			adminSimple.GET("/employees/:id/anonymization", handlers.PreviewEmployeeAnonymization)
+			adminSimple.POST("/employees/:id/anonymize", handlers.AnonymizeEmployee)
+
+			// Backups of the whole instance (database and documents)
+			adminSimple.POST("/backups", handlers.CreateBackup)
+			adminSimple.GET("/backups", handlers.GetBackups)
+			adminSimple.GET("/backups/:name", handlers.DownloadBackup)
+			adminSimple.POST("/backups/restore", handlers.RestoreBackup)
+			adminSimple.GET("/backup-jobs/:id", handlers.GetBackupJob)
+
+			// Background jobs: schedule, last run and outcome, manual runs and pausing
+			adminSimple.GET("/scheduled-jobs", handlers.GetScheduledJobs)
+			adminSimple.GET("/scheduled-jobs/:name", handlers.GetScheduledJob)
+			adminSimple.POST("/scheduled-jobs/:name/run", handlers.RunScheduledJob)
+			adminSimple.POST("/scheduled-jobs/:name/pause", handlers.PauseScheduledJob)
+			adminSimple.POST("/scheduled-jobs/:name/resume", handlers.ResumeScheduledJob)
+
+			// Emails and webhook deliveries that were given up
+			adminSimple.GET("/dead-letters", handlers.GetDeadLetters)
+			adminSimple.GET("/dead-letters/:id", handlers.GetDeadLetter)
+			adminSimple.POST("/dead-letters/:id/resend", handlers.ResendDeadLetter)
+
+			// Data retention policies and legal holds
+			adminSimple.GET("/retention-policies", handlers.GetRetentionPolicies)
+			adminSimple.GET("/retention-policies/report", handlers.GetRetentionReport)
+			adminSimple.POST("/retention-policies/run", handlers.RunRetentionPolicies)
+			adminSimple.PUT("/retention-policies/:category", handlers.UpdateRetentionPolicy)
+			adminSimple.GET("/legal-holds", handlers.GetLegalHolds)
+			adminSimple.POST("/legal-holds", handlers.CreateLegalHold)
+			adminSimple.POST("/legal-holds/:id/release", handlers.ReleaseLegalHold)
+			adminSimple.GET("/settings", handlers.GetSettings)
+			adminSimple.PUT("/settings/:key", handlers.UpdateSetting)
+			adminSimple.DELETE("/settings/:key", handlers.ResetSetting)
+
+			// Organization-wide figures for the admin landing page
+			adminSimple.GET("/dashboard", handlers.GetOrganizationDashboard)
+
+			// Statutory leave presets for the organization's leave types
+			adminSimple.GET("/leave-presets", handlers.GetLeavePresets)
+			adminSimple.POST("/leave-presets/:code/apply", handlers.ApplyLeavePreset)
+
+			// Holiday calendar: imported public holidays are reviewed before they are active
+			adminSimple.GET("/holidays", handlers.GetAdminHolidays)
+			adminSimple.POST("/holidays", handlers.CreateHoliday)
+			adminSimple.POST("/holidays/review", handlers.ReviewHolidays)
+			adminSimple.POST("/holidays/import", handlers.ImportHolidays)
+			adminSimple.PUT("/holidays/:id", handlers.UpdateHoliday)
+			adminSimple.DELETE("/holidays/:id", handlers.DeleteHoliday)
+			adminSimple.GET("/holiday-countries", handlers.GetHolidayCountries)
+			adminSimple.PUT("/holiday-countries", handlers.UpdateHolidayCountries)
+
+			// National ID formats NRCs are validated and stored by
+			adminSimple.GET("/national-id-formats", handlers.GetNationalIDFormats)
+			adminSimple.POST("/national-id-formats", handlers.CreateNationalIDFormat)
+			adminSimple.PUT("/national-id-formats/:id", handlers.UpdateNationalIDFormat)
+			adminSimple.DELETE("/national-id-formats/:id", handlers.DeleteNationalIDFormat)
+
+			// Cost centers personnel costs are allocated to
+			adminSimple.GET("/cost-centers", handlers.GetCostCenters)
+			adminSimple.POST("/cost-centers", handlers.CreateCostCenter)
+			adminSimple.PUT("/cost-centers/:id", handlers.UpdateCostCenter)
+			adminSimple.DELETE("/cost-centers/:id", handlers.DeleteCostCenter)
+
+			// Full-text search across the documents of all employees
+			adminSimple.GET("/documents", handlers.SearchDocuments)
+
+			// Document storage used against the quotas
+			adminSimple.GET("/documents/storage-usage", handlers.GetDocumentStorageUsage)
+
+			// Templates employment contracts and offer letters are generated from
+			adminSimple.GET("/document-templates/placeholders", handlers.GetDocumentPlaceholders)
+			adminSimple.GET("/document-templates", handlers.GetDocumentTemplates)
+			adminSimple.POST("/document-templates", handlers.CreateDocumentTemplate)
+			adminSimple.PUT("/document-templates/:id", handlers.UpdateDocumentTemplate)
+			adminSimple.DELETE("/document-templates/:id", handlers.DeleteDocumentTemplate)
+
+			// Email templates replacing the built-in wording of notification emails
+			adminSimple.GET("/email-templates/placeholders", handlers.GetEmailPlaceholders)
+			adminSimple.GET("/email-templates", handlers.GetEmailTemplates)
+			adminSimple.POST("/email-templates", handlers.CreateEmailTemplate)
+			adminSimple.PUT("/email-templates/:id", handlers.UpdateEmailTemplate)
+			adminSimple.DELETE("/email-templates/:id", handlers.DeleteEmailTemplate)
+			adminSimple.POST("/email-templates/:id/preview", handlers.PreviewEmailTemplate)
+			adminSimple.POST("/email-templates/:id/test", handlers.SendTestEmailTemplate)
+
+			// Moving employees, employment details and leave balances to and from other HR systems
+			adminSimple.GET("/hris/schema", handlers.GetHRISSchema)
+			adminSimple.GET("/hris/export", handlers.ExportHRIS)
+			adminSimple.POST("/hris/import", handlers.ImportHRIS)
+			adminSimple.GET("/hris/mappings", handlers.GetHRISMappings)
+			adminSimple.POST("/hris/mappings", handlers.CreateHRISMapping)
+			adminSimple.PUT("/hris/mappings/:id", handlers.UpdateHRISMapping)
+			adminSimple.DELETE("/hris/mappings/:id", handlers.DeleteHRISMapping)
+
+			// Biometric and badge terminals, and the clock events they send
+			adminSimple.GET("/attendance/devices", handlers.GetAttendanceDevices)
+			adminSimple.POST("/attendance/devices", handlers.CreateAttendanceDevice)
+			adminSimple.PUT("/attendance/devices/:id", handlers.UpdateAttendanceDevice)
+			adminSimple.DELETE("/attendance/devices/:id", handlers.DeleteAttendanceDevice)
+			adminSimple.GET("/attendance/clock-events", handlers.GetClockEvents)
+			adminSimple.POST("/attendance/clock-events/import", handlers.ImportClockEvents)
+			adminSimple.POST("/attendance/clock-events/rematch", handlers.RematchClockEvents)
+
+			// Call volume of the API keys internal services use, admins of the default organization only
+			adminSimple.GET("/api-keys/usage", handlers.GetAPIKeyUsage)
 		}
 
 		// Admin routes
@@ -256,11 +397,19 @@ func SetupRoutes() *gin.Engine {
 			admin.POST("/admins", handlers.CreateAdmin)                         // For admins (username)
 			admin.GET("/employees/template", handlers.DownloadEmployeeTemplate) // CSV template
 			admin.POST("/employees/bulk", handlers.BulkUploadEmployees)         // Bulk upload
-			admin.GET("/employees/export", handlers.ExportEmployees)            // Export all employees to PDF
 			admin.GET("/employees/:id", handlers.GetEmployee)
 			admin.GET("/employees/:id/export", handlers.ExportEmployee)          // Export single employee to PDF
 			admin.PUT("/employees/:id", handlers.UpdateEmployee)
 			admin.DELETE("/employees/:id", handlers.DeleteEmployee)
+
+			// Employment and identity details imported from CSV
+			admin.GET("/employees/employment/template", handlers.DownloadEmploymentTemplate)
+			admin.POST("/employees/employment/bulk", handlers.ImportEmploymentDetails)
+			admin.GET("/employees/identity/template", handlers.DownloadIdentityTemplate)
+			admin.POST("/employees/identity/bulk", handlers.ImportIdentityInformation)
+
+			// Data protection subject access export (JSON + PDF bundle)
+			admin.GET("/employees/:id/subject-access", handlers.ExportSubjectAccess)
 		}
 
 		// User profile routes (all authenticated users can change their own password)
@@ -275,22 +424,62 @@ func SetupRoutes() *gin.Engine {
 		api.POST("/employees/:id/employment", handlers.CreateOrUpdateEmploymentDetails)
 		api.GET("/employees/:id/employment/history", handlers.GetEmploymentHistory)
 
+		// Core HR routes - Reporting lines
+		api.GET("/employees/:id/direct-reports", handlers.GetDirectReports)
+		api.GET("/employees/:id/reporting-line/history", handlers.GetReportingLineHistory)
+
+		// Core HR routes - Profile completeness
+		api.GET("/employees/:id/profile-completeness", handlers.GetProfileCompleteness)
+
 		// Core HR routes - Positions
 		api.GET("/positions", handlers.GetPositions)
+		api.GET("/positions/vacancies", handlers.GetPositionVacancies)
 		api.GET("/positions/:id", handlers.GetPosition)
+		api.GET("/employees/:id/positions", handlers.GetPositionAssignments)
 		managerAdmin := api.Group("")
 		managerAdmin.Use(middleware.RequireRole(models.RoleManager, models.RoleAdmin))
 		{
 			managerAdmin.POST("/positions", handlers.CreatePosition)
 			managerAdmin.PUT("/positions/:id", handlers.UpdatePosition)
+			managerAdmin.DELETE("/positions/:id", handlers.DeactivatePosition)
 			managerAdmin.POST("/employees/:id/positions", handlers.AssignPosition)
+			managerAdmin.POST("/employees/:id/positions/transfer", handlers.TransferPosition)
+			managerAdmin.PUT("/employees/:id/positions/:assignment_id/end", handlers.EndPositionAssignment)
+
+			// Cost centers the cost of positions and employees is charged to
+			managerAdmin.GET("/positions/:id/cost-centers", handlers.GetPositionCostCenters)
+			managerAdmin.GET("/employees/:id/cost-centers", handlers.GetEmployeeCostCenters)
+			admin.PUT("/positions/:id/cost-centers", handlers.SetPositionCostCenters)
+			admin.PUT("/employees/:id/cost-centers", handlers.SetEmployeeCostCenters)
 		}
 
+		// Core HR routes - Employee roster export (Excel/CSV); the PDF export is admin only
+		managerAdmin.GET("/employees/export", handlers.ExportEmployees)
+
+		// Core HR routes - Headcount budgeting
+		managerAdmin.GET("/headcount/budgets", handlers.GetHeadcountBudgets)
+		managerAdmin.GET("/headcount/requests", handlers.GetHeadcountRequests)
+		managerAdmin.GET("/headcount/export", handlers.ExportHeadcountCostCenters)
+		managerAdmin.POST("/headcount/requests", handlers.CreateHeadcountRequest)
+		admin.POST("/headcount/budgets", handlers.SetHeadcountBudget)
+		admin.PUT("/headcount/requests/:id/approve", handlers.ApproveHeadcountRequest)
+		admin.PUT("/headcount/requests/:id/reject", handlers.RejectHeadcountRequest)
+
+		// Core HR routes - Transfers
+		managerAdmin.GET("/transfers", handlers.GetTransferRequests)
+		managerAdmin.GET("/transfers/:id", handlers.GetTransferRequest)
+		managerAdmin.POST("/transfers", handlers.CreateTransferRequest)
+		managerAdmin.PUT("/transfers/:id/approve", handlers.ApproveTransferRequest)
+		managerAdmin.PUT("/transfers/:id/reject", handlers.RejectTransferRequest)
+		managerAdmin.PUT("/transfers/:id/cancel", handlers.CancelTransferRequest)
+
 		// Core HR routes - Documents
 		api.GET("/employees/:id/documents", handlers.GetDocuments)
 		api.POST("/employees/:id/documents", handlers.CreateDocument)
 		api.GET("/employees/:id/documents/:doc_id/download", handlers.DownloadDocument)
 		api.DELETE("/employees/:id/documents/:doc_id", handlers.DeleteDocument)
+		admin.POST("/employees/:id/documents/generate", handlers.GenerateDocument)
+		api.POST("/employees/:id/documents/:doc_id/sign", handlers.SignDocument)
 
 		// Core HR routes - Work Lifecycle
 		api.GET("/employees/:id/lifecycle", handlers.GetLifecycleEvents)
@@ -309,6 +498,167 @@ func SetupRoutes() *gin.Engine {
 		api.GET("/employees/:id/compliance", handlers.GetComplianceRecords)
 		managerAdmin.POST("/compliance/requirements", handlers.CreateComplianceRequirement)
 		managerAdmin.POST("/employees/:id/compliance", handlers.CreateComplianceRecord)
+		managerAdmin.GET("/compliance/notifications", handlers.GetComplianceNotifications)
+		managerAdmin.POST("/compliance/process-expiry", handlers.ProcessComplianceExpiry)
+		managerAdmin.GET("/compliance/expiring/export", handlers.ExportExpiringCompliance)
+
+		// Notifications
+		api.GET("/notifications", handlers.GetMyNotifications)
+		api.PUT("/notifications/:id/read", handlers.MarkNotificationRead)
+		api.GET("/notifications/preferences", handlers.GetNotificationPreferences)
+		api.PUT("/notifications/preferences", handlers.UpdateNotificationPreferences)
+		api.GET("/notifications/devices", handlers.GetMyDevices)
+		api.POST("/notifications/devices", handlers.RegisterDevice)
+		api.DELETE("/notifications/devices/:id", handlers.DeleteDevice)
+		api.POST("/notifications/devices/:id/test", handlers.TestDevice)
+
+		// Core HR routes - Education
+		api.GET("/employees/:id/education", handlers.GetEducation)
+		api.POST("/employees/:id/education", handlers.CreateEducation)
+		api.PUT("/employees/:id/education/:education_id", handlers.UpdateEducation)
+		api.DELETE("/employees/:id/education/:education_id", handlers.DeleteEducation)
+		managerAdmin.PUT("/employees/:id/education/:education_id/verify", handlers.VerifyEducation)
+		managerAdmin.GET("/education", handlers.GetEducationRecords)
+
+		// Core HR routes - Skills and certifications
+		api.GET("/skills", handlers.GetSkills)
+		api.GET("/certifications", handlers.GetCertifications)
+		managerAdmin.POST("/skills", handlers.CreateSkill)
+		managerAdmin.POST("/certifications", handlers.CreateCertification)
+		managerAdmin.GET("/skills/search", handlers.SearchEmployeesBySkill)
+		managerAdmin.GET("/certifications/expiring", handlers.GetExpiringCertifications)
+		api.GET("/employees/:id/skills", handlers.GetEmployeeSkills)
+		api.POST("/employees/:id/skills", handlers.AssignEmployeeSkill)
+		api.DELETE("/employees/:id/skills/:skill_id", handlers.RemoveEmployeeSkill)
+		api.GET("/employees/:id/certifications", handlers.GetEmployeeCertifications)
+		api.POST("/employees/:id/certifications", handlers.AddEmployeeCertification)
+		api.DELETE("/employees/:id/certifications/:certification_id", handlers.RemoveEmployeeCertification)
+
+		// Core HR routes - Bank details
+		api.GET("/employees/:id/bank-details", handlers.GetBankDetails)
+		admin.PUT("/employees/:id/bank-details", handlers.CreateOrUpdateBankDetails)
+		admin.PUT("/employees/:id/payroll-access", handlers.SetPayrollAccess)
+		admin.PUT("/employees/:id/pii-access", handlers.SetPIIAccess)
+		payroll := api.Group("/payroll")
+		payroll.Use(middleware.RequirePayrollAccess())
+		{
+			payroll.GET("/bank-details", handlers.GetPayrollBankDetails)
+			payroll.GET("/employees/:id/bank-details", handlers.GetUnmaskedBankDetails)
+			payroll.GET("/employees/:id/compensation", handlers.GetCompensationHistory)
+			payroll.POST("/employees/:id/compensation", handlers.CreateCompensationRecord)
+			payroll.GET("/reports/compa-ratio", handlers.GetCompaRatioReport)
+			payroll.GET("/cost-allocation/export", handlers.ExportPayrollCostAllocation)
+		}
+
+		// Attendance
+		api.GET("/work-schedules", handlers.GetWorkSchedules)
+		admin.POST("/work-schedules", handlers.CreateWorkSchedule)
+		api.POST("/attendance/clock-in", handlers.ClockIn)
+		api.POST("/attendance/clock-out", handlers.ClockOut)
+		api.GET("/attendance/me", handlers.GetMyAttendance)
+		api.GET("/employees/:id/attendance", handlers.GetEmployeeAttendance)
+		api.POST("/attendance/corrections", handlers.CreateAttendanceCorrection)
+		managerAdmin.GET("/attendance/corrections", handlers.GetAttendanceCorrections)
+		managerAdmin.PUT("/attendance/corrections/:id/approve", handlers.ApproveAttendanceCorrection)
+		managerAdmin.PUT("/attendance/corrections/:id/reject", handlers.RejectAttendanceCorrection)
+		managerAdmin.GET("/attendance/report", handlers.GetAttendanceReport)
+		managerAdmin.POST("/attendance/process-absences", handlers.ProcessAbsences)
+
+		// Shift scheduling
+		api.GET("/shifts", handlers.GetShifts)
+		api.GET("/shifts/me", handlers.GetMySchedule)
+		managerAdmin.POST("/shifts", handlers.CreateShift)
+		managerAdmin.GET("/shifts/rota", handlers.GetRota)
+		managerAdmin.GET("/shifts/conflicts", handlers.GetShiftLeaveConflicts)
+		managerAdmin.POST("/shifts/assignments", handlers.AssignShift)
+		managerAdmin.DELETE("/shifts/assignments/:id", handlers.DeleteShiftAssignment)
+		api.GET("/shifts/swaps", handlers.GetShiftSwaps)
+		api.POST("/shifts/swaps", handlers.CreateShiftSwap)
+		api.PUT("/shifts/swaps/:id/cancel", handlers.CancelShiftSwap)
+		managerAdmin.PUT("/shifts/swaps/:id/approve", handlers.ApproveShiftSwap)
+		managerAdmin.PUT("/shifts/swaps/:id/reject", handlers.RejectShiftSwap)
+
+		// Training and development
+		api.GET("/training/courses", handlers.GetTrainingCourses)
+		api.GET("/training/sessions", handlers.GetTrainingSessions)
+		api.POST("/training/sessions/:id/enroll", handlers.EnrollInTrainingSession)
+		api.PUT("/training/enrollments/:id/cancel", handlers.CancelTrainingEnrollment)
+		api.GET("/employees/:id/training", handlers.GetEmployeeTraining)
+		managerAdmin.POST("/training/courses", handlers.CreateTrainingCourse)
+		managerAdmin.POST("/training/sessions", handlers.CreateTrainingSession)
+		managerAdmin.GET("/training/sessions/:id/enrollments", handlers.GetTrainingSessionEnrollments)
+		managerAdmin.PUT("/training/enrollments/:id/attendance", handlers.RecordTrainingAttendance)
+		managerAdmin.PUT("/training/enrollments/:id/complete", handlers.CompleteTraining)
+		managerAdmin.GET("/training/mandatory", handlers.GetMandatoryTraining)
+		managerAdmin.GET("/training/mandatory/gaps", handlers.GetMandatoryTrainingGaps)
+		admin.POST("/training/mandatory", handlers.SetMandatoryTraining)
+		admin.DELETE("/training/mandatory/:id", handlers.DeleteMandatoryTraining)
+
+		// Grievances
+		api.POST("/grievances", handlers.SubmitGrievance)
+		api.GET("/grievances/mine", handlers.GetMyGrievances)
+		api.GET("/grievances/:id", handlers.GetGrievance)
+		api.POST("/grievances/:id/notes", handlers.AddGrievanceNote)
+		admin.GET("/grievances", handlers.GetGrievances)
+		admin.GET("/grievances/report", handlers.GetGrievanceReport)
+		admin.PUT("/grievances/:id/assign", handlers.AssignGrievance)
+		admin.PUT("/grievances/:id/stage", handlers.UpdateGrievanceStage)
+
+		// Remote work
+		api.POST("/remote-work", handlers.CreateRemoteWork)
+		api.GET("/remote-work/me", handlers.GetMyRemoteWork)
+		api.PUT("/remote-work/:id/cancel", handlers.CancelRemoteWork)
+		managerAdmin.GET("/remote-work", handlers.GetRemoteWorkRequests)
+		managerAdmin.PUT("/remote-work/:id/approve", handlers.ApproveRemoteWork)
+		managerAdmin.PUT("/remote-work/:id/reject", handlers.RejectRemoteWork)
+		hr.GET("/team-calendar", handlers.GetTeamCalendar)
+		hr.GET("/remote-work/utilization", handlers.GetRemoteWorkUtilization)
+
+		// Recognition
+		api.GET("/recognition/values", handlers.GetCompanyValues)
+		api.POST("/recognition/kudos", handlers.SendKudos)
+		api.GET("/recognition/feed", handlers.GetRecognitionFeed)
+		api.GET("/recognition/me", handlers.GetMyKudos)
+		managerAdmin.GET("/recognition/team", handlers.GetTeamRecognition)
+		admin.POST("/recognition/values", handlers.CreateCompanyValue)
+		admin.PUT("/recognition/values/:id", handlers.UpdateCompanyValue)
+		admin.DELETE("/recognition/kudos/:id", handlers.DeleteKudos)
+		hr.GET("/recognition/stats", handlers.GetRecognitionStats)
+		hr.GET("/recognition/stats/export", handlers.ExportRecognitionStats)
+
+		// Exit interviews
+		admin.GET("/exit-interviews/question-sets", handlers.GetExitQuestionSets)
+		admin.POST("/exit-interviews/question-sets", handlers.CreateExitQuestionSet)
+		admin.PUT("/exit-interviews/question-sets/:id", handlers.UpdateExitQuestionSet)
+		admin.GET("/exit-interviews/report", handlers.GetExitInterviewReport)
+		admin.GET("/employees/:id/offboarding/exit-interview", handlers.GetExitInterview)
+		admin.POST("/employees/:id/offboarding/exit-interview", handlers.RecordExitInterview)
+
+		// Workforce analytics
+		hr.GET("/analytics/headcount", handlers.GetHeadcountAnalytics)
+		hr.GET("/analytics/turnover", handlers.GetTurnoverAnalytics)
+
+		// Pre-aggregated figures for BI tools
+		hr.GET("/reports/leave-utilization", handlers.GetLeaveUtilizationReport)
+		hr.GET("/reports/absence-by-type", handlers.GetAbsenceByTypeReport)
+		hr.GET("/reports/absence-rate", handlers.GetAbsenceRateReport)
+		hr.GET("/reports/balance-liability", handlers.GetBalanceLiabilityReport)
+		hr.GET("/reports/headcount", handlers.GetHeadcountReport)
+		hr.GET("/reports/headcount/export", handlers.ExportHeadcountReport)
+
+		// Webhooks
+		admin.GET("/webhooks", handlers.GetWebhookSubscriptions)
+		admin.POST("/webhooks", handlers.CreateWebhookSubscription)
+		admin.PUT("/webhooks/:id", handlers.UpdateWebhookSubscription)
+		admin.DELETE("/webhooks/:id", handlers.DeleteWebhookSubscription)
+		admin.POST("/webhooks/:id/test", handlers.TestWebhookSubscription)
+		admin.GET("/webhooks/deliveries", handlers.GetWebhookDeliveries)
+		admin.POST("/webhooks/deliveries/:id/retry", handlers.RetryWebhookDelivery)
+
+		// Organizations
+		api.GET("/organization", handlers.GetCurrentOrganization)
+		admin.GET("/organizations", handlers.GetOrganizations)
+		admin.POST("/organizations", handlers.CreateOrganization)
 
 		// Core HR routes - Audit Logs
 		api.GET("/audit-logs", handlers.GetAuditLogs)
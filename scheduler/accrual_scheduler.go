@@ -1,9 +1,12 @@
 package scheduler
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"hrms-api/database"
 	"hrms-api/models"
+	"hrms-api/telemetry"
 	"hrms-api/utils"
 	"log"
 	"time"
@@ -13,15 +16,22 @@ import (
 
 var cronScheduler *cron.Cron
 
+var accrualJob = registerJob(&job{
+	name:        "monthly_accruals",
+	description: "Accrues the previous month's leave for every active employee; on startup, only when it is missing",
+	spec:        "0 0 2 1 * *",
+	run:         processMonthlyAccruals,
+})
+
 // StartAccrualScheduler starts the automatic monthly accrual processing scheduler
 // It runs on the 1st of each month at 2:00 AM to process accruals for the previous month
 func StartAccrualScheduler() {
-	// Create a new cron scheduler with seconds precision
-	cronScheduler = cron.New(cron.WithSeconds())
+	// Create a new cron scheduler with seconds precision, running on the company's clock
+	cronScheduler = cron.New(cron.WithSeconds(), cron.WithLocation(utils.CompanyLocation()))
 
 	// Schedule to run on the 1st of each month at 2:00 AM
 	// Cron expression: "0 0 2 1 * *" means: second=0, minute=0, hour=2, day=1, month=*, weekday=*
-	_, err := cronScheduler.AddFunc("0 0 2 1 * *", processMonthlyAccruals)
+	err := accrualJob.schedule(cronScheduler)
 	if err != nil {
 		log.Printf("Failed to schedule accrual processing: %v", err)
 		return
@@ -33,86 +43,101 @@ func StartAccrualScheduler() {
 
 	// Also check if we need to process the current month on startup
 	// This handles cases where the server was down on the 1st
-	go checkAndProcessPendingAccruals()
+	runAtStartup(func() {
+		// Wait a bit for the server to fully start
+		time.Sleep(5 * time.Second)
+		accrualJob.execute(models.JobTriggerStartup, checkAndProcessPendingAccruals)
+	})
 }
 
-// StopAccrualScheduler stops the accrual scheduler
+// StopAccrualScheduler stops the accrual scheduler and waits for a running job to finish
 func StopAccrualScheduler() {
 	if cronScheduler != nil {
-		cronScheduler.Stop()
+		<-cronScheduler.Stop().Done()
 		log.Println("Accrual scheduler stopped")
 	}
 }
 
 // processMonthlyAccruals processes accruals for the previous month
 // This is called automatically on the 1st of each month
-func processMonthlyAccruals() {
+func processMonthlyAccruals(ctx context.Context) error {
 	log.Println("🔄 Starting automatic monthly accrual processing...")
 
 	// Process accruals for the previous month
-	now := time.Now()
-	previousMonth := now.AddDate(0, -1, 0)
-	processMonth := time.Date(previousMonth.Year(), previousMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	previousMonth := utils.CompanyNow().AddDate(0, -1, 0)
+	processed, errorDetails, err := ProcessAccrualsForMonth(ctx, previousMonth.Year(), previousMonth.Month())
+	if err != nil {
+		telemetry.Logf(ctx, "❌ %v", err)
+		return err
+	}
+
+	log.Printf("✅ Accrual processing completed: %d processed, %d errors", processed, len(errorDetails))
+	if len(errorDetails) > 0 {
+		telemetry.Logf(ctx, "Error details: %v", errorDetails)
+	}
+	return jobError(errorDetails)
+}
 
-	log.Printf("Processing accruals for month: %s", processMonth.Format("2006-01"))
+// ProcessAccrualsForMonth accrues the month's leave on every leave type that uses a balance for every
+// active, non-admin employee. It returns how many accruals were processed and a description of each
+// one that failed. Months that were already processed are left as they are.
+func ProcessAccrualsForMonth(ctx context.Context, year int, month time.Month) (int, []string, error) {
+	db := database.DB.WithContext(ctx)
+	log.Printf("Processing accruals for month: %s", time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Format("2006-01"))
 
 	// Get all leave types that use balance (e.g. Annual)
 	var balanceLeaveTypes []models.LeaveType
-	if err := database.DB.Where("uses_balance = ?", true).Find(&balanceLeaveTypes).Error; err != nil || len(balanceLeaveTypes) == 0 {
-		log.Printf("❌ No leave types with uses_balance=true found")
-		return
+	if err := db.Where("uses_balance = ?", true).Find(&balanceLeaveTypes).Error; err != nil {
+		return 0, nil, fmt.Errorf("fetching leave types: %w", err)
+	}
+	if len(balanceLeaveTypes) == 0 {
+		return 0, nil, errors.New("no leave types with uses_balance=true found")
 	}
 
 	// Get all active employees (exclude admins and inactive)
 	var employees []models.Employee
-	if err := database.DB.Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees).Error; err != nil {
-		log.Printf("❌ Error fetching employees: %v", err)
-		return
+	if err := db.Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees).Error; err != nil {
+		return 0, nil, fmt.Errorf("fetching employees: %w", err)
 	}
 
 	processed := 0
-	errors := 0
 	var errorDetails []string
-
 	for _, leaveType := range balanceLeaveTypes {
 		for _, emp := range employees {
-			if err := utils.ProcessMonthlyAccrualSimple(emp.ID, leaveType.ID, previousMonth.Year(), int(previousMonth.Month())); err != nil {
-				errors++
+			if err := utils.ProcessMonthlyAccrualSimple(emp.ID, leaveType.ID, year, int(month)); err != nil {
 				errorDetails = append(errorDetails, fmt.Sprintf("Employee %d (%s %s) %s: %v", emp.ID, emp.Firstname, emp.Lastname, leaveType.Name, err))
-				log.Printf("⚠️  Failed to process accrual for employee %d (%s %s) %s: %v", emp.ID, emp.Firstname, emp.Lastname, leaveType.Name, err)
+				telemetry.Logf(ctx, "⚠️  Failed to process accrual for employee %d (%s %s) %s: %v", emp.ID, emp.Firstname, emp.Lastname, leaveType.Name, err)
 				continue
 			}
 			processed++
 		}
 	}
-
-	log.Printf("✅ Accrual processing completed: %d processed, %d errors", processed, errors)
-	if len(errorDetails) > 0 {
-		log.Printf("Error details: %v", errorDetails)
-	}
+	return processed, errorDetails, nil
 }
 
 // checkAndProcessPendingAccruals checks if there are any pending accruals that need to be processed
 // This runs on server startup to catch up on any missed accruals
-func checkAndProcessPendingAccruals() {
-	// Wait a bit for the server to fully start
-	time.Sleep(5 * time.Second)
+func checkAndProcessPendingAccruals(ctx context.Context) error {
+	db := database.DB.WithContext(ctx)
 
 	log.Println("🔍 Checking for pending accruals...")
 
 	var balanceLeaveTypes []models.LeaveType
-	if err := database.DB.Where("uses_balance = ?", true).Find(&balanceLeaveTypes).Error; err != nil || len(balanceLeaveTypes) == 0 {
+	if err := db.Where("uses_balance = ?", true).Find(&balanceLeaveTypes).Error; err != nil {
+		return fmt.Errorf("fetching leave types: %w", err)
+	}
+	if len(balanceLeaveTypes) == 0 {
 		log.Printf("⚠️  No leave types with uses_balance=true found")
-		return
+		return nil
 	}
 
 	var employees []models.Employee
-	if err := database.DB.Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees).Error; err != nil {
+	if err := db.Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees).Error; err != nil {
 		log.Printf("⚠️  Could not check pending accruals: Error fetching employees")
-		return
+		return fmt.Errorf("fetching employees: %w", err)
 	}
 
-	now := time.Now()
+	now := utils.CompanyNow()
 	previousMonth := now.AddDate(0, -1, 0)
 	prevMonthStart := time.Date(previousMonth.Year(), previousMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
 
@@ -120,7 +145,7 @@ func checkAndProcessPendingAccruals() {
 	for _, leaveType := range balanceLeaveTypes {
 		for _, emp := range employees {
 			var accruals []models.LeaveAccrual
-			database.DB.Where("employee_id = ? AND leave_type_id = ? AND year = ? AND month = ?",
+			db.Where("employee_id = ? AND leave_type_id = ? AND year = ? AND month = ?",
 				emp.ID, leaveType.ID, previousMonth.Year(), int(previousMonth.Month())).Limit(1).Find(&accruals)
 			if len(accruals) == 0 || accruals[0].ID == 0 {
 				needsProcessing = true
@@ -134,18 +159,23 @@ func checkAndProcessPendingAccruals() {
 
 	if needsProcessing {
 		log.Printf("📅 Found pending accruals for %s - processing now...", prevMonthStart.Format("2006-01"))
-		processed := 0
+		processed, failed := 0, 0
 		for _, leaveType := range balanceLeaveTypes {
 			for _, emp := range employees {
 				if err := utils.ProcessMonthlyAccrualSimple(emp.ID, leaveType.ID, previousMonth.Year(), int(previousMonth.Month())); err != nil {
-					log.Printf("⚠️  Failed to process accrual for employee %d: %v", emp.ID, err)
+					telemetry.Logf(ctx, "⚠️  Failed to process accrual for employee %d: %v", emp.ID, err)
+					failed++
 					continue
 				}
 				processed++
 			}
 		}
 		log.Printf("✅ Processed %d pending accruals for %s", processed, prevMonthStart.Format("2006-01"))
+		if failed > 0 {
+			return fmt.Errorf("%d accrual(s) failed", failed)
+		}
 	} else {
 		log.Println("✅ No pending accruals found - all up to date")
 	}
+	return nil
 }
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"hrms-api/config"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var apiKeyUsageScheduler *cron.Cron
+
+// StartAPIKeyUsageScheduler starts the job that writes the API key calls counted in memory to the
+// database and reloads the day's totals every server has counted, which daily quotas are checked against
+// It runs every 15 seconds, and once at startup to load the totals. It is not started when no API keys
+// are configured.
+func StartAPIKeyUsageScheduler() {
+	if len(config.AppConfig.GRPCAPIKeys) == 0 {
+		return
+	}
+	apiKeyUsageScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "*/15 * * * * *" means: every 15 seconds
+	_, err := apiKeyUsageScheduler.AddFunc("*/15 * * * * *", flushAPIKeyUsage)
+	if err != nil {
+		log.Printf("Failed to schedule API key usage flushes: %v", err)
+		return
+	}
+
+	apiKeyUsageScheduler.Start()
+	log.Println("✅ API key usage scheduler started - usage is recorded every 15 seconds")
+
+	runAtStartup(flushAPIKeyUsage)
+}
+
+// StopAPIKeyUsageScheduler stops the API key usage scheduler, then records the calls counted since the
+// last flush so they are not lost
+func StopAPIKeyUsageScheduler() {
+	if apiKeyUsageScheduler != nil {
+		<-apiKeyUsageScheduler.Stop().Done()
+		flushAPIKeyUsage()
+		log.Println("API key usage scheduler stopped")
+	}
+}
+
+func flushAPIKeyUsage() {
+	if err := utils.FlushAPIKeyUsage(); err != nil {
+		log.Printf("❌ Failed to record API key usage: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var attendanceScheduler *cron.Cron
+
+var attendanceJob = registerJob(&job{
+	name:        "absence_marking",
+	description: "Marks employees who did not clock in the previous day absent or on leave",
+	spec:        "0 0 1 * * *",
+	run:         markAbsences,
+})
+
+// StartAttendanceScheduler starts the daily job that marks the previous day's absences
+// It runs every day at 01:00 and once on startup to cover days missed while the server was down
+func StartAttendanceScheduler() {
+	attendanceScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "0 0 1 * * *" means: second=0, minute=0, hour=1, every day
+	err := attendanceJob.schedule(attendanceScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule absence marking: %v", err)
+		return
+	}
+
+	attendanceScheduler.Start()
+	log.Println("✅ Attendance scheduler started - absences will be marked daily at 01:00")
+
+	attendanceJob.runAtStartup()
+}
+
+// StopAttendanceScheduler stops the attendance scheduler and waits for a running job to finish
+func StopAttendanceScheduler() {
+	if attendanceScheduler != nil {
+		<-attendanceScheduler.Stop().Done()
+		log.Println("Attendance scheduler stopped")
+	}
+}
+
+// markAbsences flags employees who did not clock in yesterday as absent or on leave
+func markAbsences(ctx context.Context) error {
+	result := utils.MarkAbsences(time.Now().AddDate(0, 0, -1))
+	for _, err := range result.Errors {
+		telemetry.Logf(ctx, "❌ Absence marking: %s", err)
+	}
+	if result.Absent > 0 || result.OnLeave > 0 {
+		log.Printf("✅ Attendance for %s: %d absent, %d on leave", result.Date, result.Absent, result.OnLeave)
+	}
+	return jobError(result.Errors)
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var calendarScheduler *cron.Cron
+
+var calendarJob = registerJob(&job{
+	name:        "calendar_sync_retry",
+	description: "Syncs again the leaves whose last sync to a connected calendar failed",
+	spec:        "0 */10 * * * *",
+	run:         retryCalendarSyncs,
+})
+
+// StartCalendarScheduler starts the job that retries leaves whose last sync to a connected calendar failed
+// It runs every 10 minutes and once on startup. It is not started when no calendar provider is configured.
+func StartCalendarScheduler() {
+	if len(utils.CalendarProviders()) == 0 {
+		return
+	}
+	calendarScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "0 */10 * * * *" means: second=0, every 10 minutes
+	err := calendarJob.schedule(calendarScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule calendar sync retries: %v", err)
+		return
+	}
+
+	calendarScheduler.Start()
+	log.Println("✅ Calendar scheduler started - failed calendar syncs will be retried every 10 minutes")
+
+	calendarJob.runAtStartup()
+}
+
+// StopCalendarScheduler stops the calendar scheduler and waits for a running job to finish
+func StopCalendarScheduler() {
+	if calendarScheduler != nil {
+		<-calendarScheduler.Stop().Done()
+		log.Println("Calendar scheduler stopped")
+	}
+}
+
+// retryCalendarSyncs syncs the leaves whose events could not be written to a calendar
+func retryCalendarSyncs(ctx context.Context) error {
+	retried, errs := utils.RetryCalendarSyncs()
+	for _, err := range errs {
+		telemetry.Logf(ctx, "❌ Calendar sync retry: %v", err)
+	}
+	if retried > 0 && len(errs) == 0 {
+		log.Printf("✅ Synced %d leave(s) to calendars on retry", retried)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var complianceScheduler *cron.Cron
+
+var complianceJob = registerJob(&job{
+	name:        "compliance_expiry",
+	description: "Marks lapsed compliance records expired and sends expiry reminders",
+	spec:        "0 0 6 * * *",
+	run:         processComplianceExpiry,
+})
+
+// StartComplianceScheduler starts the daily compliance expiry job
+// It runs every day at 6:00 AM and once on startup, marking expired records and sending reminders
+func StartComplianceScheduler() {
+	complianceScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "0 0 6 * * *" means: second=0, minute=0, hour=6, every day
+	err := complianceJob.schedule(complianceScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule compliance expiry processing: %v", err)
+		return
+	}
+
+	complianceScheduler.Start()
+	log.Println("✅ Compliance scheduler started - expiry checks and reminders will run daily at 6:00 AM")
+
+	complianceJob.runAtStartup()
+}
+
+// StopComplianceScheduler stops the compliance scheduler and waits for a running job to finish
+func StopComplianceScheduler() {
+	if complianceScheduler != nil {
+		<-complianceScheduler.Stop().Done()
+		log.Println("Compliance scheduler stopped")
+	}
+}
+
+// processComplianceExpiry expires lapsed compliance records and sends reminders
+func processComplianceExpiry(ctx context.Context) error {
+	result := utils.ProcessComplianceExpiry()
+	for _, err := range result.Errors {
+		telemetry.Logf(ctx, "❌ Compliance expiry: %s", err)
+	}
+	if result.Expired > 0 || result.RemindersSent > 0 {
+		log.Printf("✅ Compliance expiry: %d record(s) expired, %d reminder(s) sent", result.Expired, result.RemindersSent)
+	}
+	return jobError(result.Errors)
+}
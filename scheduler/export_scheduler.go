@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var exportScheduler *cron.Cron
+
+var exportJob = registerJob(&job{
+	name:        "export_jobs",
+	description: "Fails interrupted export jobs, deletes expired ones and runs the queued ones",
+	spec:        "30 * * * * *",
+	run:         processExportJobs,
+})
+
+// StartExportScheduler starts the job that picks up queued export jobs no server is working on, fails
+// interrupted ones and deletes expired export files
+// It runs every minute and once on startup
+func StartExportScheduler() {
+	exportScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "30 * * * * *" means: second=30, every minute
+	err := exportJob.schedule(exportScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule export jobs: %v", err)
+		return
+	}
+
+	exportScheduler.Start()
+	log.Println("✅ Export scheduler started - queued export jobs are checked every minute")
+
+	exportJob.runAtStartup()
+}
+
+// StopExportScheduler stops the export scheduler and waits for a running job to finish
+func StopExportScheduler() {
+	if exportScheduler != nil {
+		<-exportScheduler.Stop().Done()
+		log.Println("Export scheduler stopped")
+	}
+}
+
+// processExportJobs fails interrupted export jobs, purges expired ones and runs the queued ones
+func processExportJobs(ctx context.Context) error {
+	var errs []error
+	if failed, err := utils.FailStaleExportJobs(); err != nil {
+		errs = append(errs, fmt.Errorf("failing interrupted export jobs: %w", err))
+		telemetry.Logf(ctx, "❌ Export jobs: failed to fail interrupted jobs: %v", err)
+	} else if failed > 0 {
+		log.Printf("⚠️  Marked %d interrupted export job(s) failed", failed)
+	}
+
+	if purged, err := utils.PurgeExpiredExportJobs(); err != nil {
+		errs = append(errs, fmt.Errorf("purging expired export jobs: %w", err))
+		telemetry.Logf(ctx, "❌ Export jobs: failed to purge expired jobs: %v", err)
+	} else if purged > 0 {
+		log.Printf("✅ Deleted %d expired export job(s)", purged)
+	}
+
+	utils.RunExportJobs()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var grievanceScheduler *cron.Cron
+
+var grievanceJob = registerJob(&job{
+	name:        "grievance_escalation",
+	description: "Notifies case owners of grievances past their SLA",
+	spec:        "0 0 * * * *",
+	run:         escalateGrievances,
+})
+
+// StartGrievanceScheduler starts the hourly job that escalates grievances which have missed their SLA
+// It runs at the top of every hour and once on startup
+func StartGrievanceScheduler() {
+	grievanceScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "0 0 * * * *" means: second=0, minute=0, every hour
+	err := grievanceJob.schedule(grievanceScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule grievance SLA checks: %v", err)
+		return
+	}
+
+	grievanceScheduler.Start()
+	log.Println("✅ Grievance scheduler started - SLA breaches will be checked hourly")
+
+	grievanceJob.runAtStartup()
+}
+
+// StopGrievanceScheduler stops the grievance scheduler and waits for a running job to finish
+func StopGrievanceScheduler() {
+	if grievanceScheduler != nil {
+		<-grievanceScheduler.Stop().Done()
+		log.Println("Grievance scheduler stopped")
+	}
+}
+
+// escalateGrievances notifies case owners about grievances past their SLA
+func escalateGrievances(ctx context.Context) error {
+	notified, errs := utils.ProcessGrievanceSLABreaches()
+	for _, err := range errs {
+		telemetry.Logf(ctx, "❌ Grievance SLA escalation: %v", err)
+	}
+	if notified > 0 {
+		log.Printf("✅ Escalated %d grievance(s) past their SLA", notified)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var holidayScheduler *cron.Cron
+
+var holidayJob = registerJob(&job{
+	name:        "holiday_import",
+	description: "Imports every organization's public holidays for this year and next, pending review",
+	spec:        "0 0 4 1 * *",
+	run:         importPublicHolidays,
+})
+
+// StartHolidayScheduler starts the monthly job that imports public holidays for review
+// It runs on the 1st of every month at 04:00 and imports this year's and next year's holidays, so the
+// next year's calendar is ready for review well before it starts. It does not run on startup, so that
+// restarts do not call the holiday provider.
+func StartHolidayScheduler() {
+	holidayScheduler = cron.New(cron.WithSeconds(), cron.WithLocation(utils.CompanyLocation()))
+
+	// Cron expression: "0 0 4 1 * *" means: second=0, minute=0, hour=4, 1st day of every month
+	err := holidayJob.schedule(holidayScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule public holiday imports: %v", err)
+		return
+	}
+
+	holidayScheduler.Start()
+	log.Println("✅ Holiday scheduler started - public holidays will be imported on the 1st of every month at 04:00")
+}
+
+// StopHolidayScheduler stops the holiday scheduler and waits for a running job to finish
+func StopHolidayScheduler() {
+	if holidayScheduler != nil {
+		<-holidayScheduler.Stop().Done()
+		log.Println("Holiday scheduler stopped")
+	}
+}
+
+// importPublicHolidays imports every organization's public holidays for this year and next
+func importPublicHolidays(ctx context.Context) error {
+	thisYear := utils.CompanyToday().Year()
+	results, err := utils.ImportPublicHolidays(database.DB.WithContext(ctx), []int{thisYear, thisYear + 1})
+	if err != nil {
+		telemetry.Logf(ctx, "❌ Holiday import: %v", err)
+		return fmt.Errorf("loading holiday countries: %w", err)
+	}
+	var failed []string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s %d for organization %d: %s", result.CountryCode, result.Year, result.OrganizationID, result.Error))
+			telemetry.Logf(ctx, "❌ Holiday import of %s %d for organization %d: %s", result.CountryCode, result.Year, result.OrganizationID, result.Error)
+			continue
+		}
+		if result.Added > 0 {
+			log.Printf("✅ Imported %d %s public holiday(s) for %d for organization %d, pending review",
+				result.Added, result.CountryCode, result.Year, result.OrganizationID)
+		}
+	}
+	return jobError(failed)
+}
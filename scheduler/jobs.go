@@ -0,0 +1,266 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/telemetry"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrJobNotFound = errors.New("scheduled job not found")
+	ErrJobRunning  = errors.New("the job is already running on this server")
+)
+
+// job is a background job run on a schedule, which admins can follow, run and pause through the API.
+// The settings and API key usage schedulers are housekeeping every server does every few seconds and
+// are not jobs.
+type job struct {
+	name        string // Name in the API and in traces
+	description string
+	spec        string // Cron expression, with seconds
+	run         func(ctx context.Context) error
+
+	running sync.Mutex // Held while this server runs the job
+	mu      sync.Mutex // Guards cron and entryID
+	cron    *cron.Cron // The scheduler the job was added to on this server, nil if it was not started
+	entryID cron.EntryID
+}
+
+// jobs are the scheduled jobs in the order they are listed
+var jobs []*job
+
+// manualRuns tracks the runs started through RunJob so shutdown can wait for them
+var manualRuns sync.WaitGroup
+
+// registerJob adds a job to those the API lists
+func registerJob(j *job) *job {
+	jobs = append(jobs, j)
+	return j
+}
+
+// findJob returns the job named name
+func findJob(name string) (*job, bool) {
+	for _, j := range jobs {
+		if j.name == name {
+			return j, true
+		}
+	}
+	return nil, false
+}
+
+// schedule adds the job to c, to run at its cron expression
+func (j *job) schedule(c *cron.Cron) error {
+	id, err := c.AddFunc(j.spec, func() { j.execute(models.JobTriggerSchedule, j.run) })
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cron, j.entryID = c, id
+	return nil
+}
+
+// runAtStartup runs the job once in the background as the server starts
+func (j *job) runAtStartup() {
+	runAtStartup(func() { j.execute(models.JobTriggerStartup, j.run) })
+}
+
+// execute runs fn as a run of the job, unless the job is already running on this server or, for runs
+// not started by hand, paused. The run and its outcome are recorded for every server to report.
+func (j *job) execute(trigger models.JobTrigger, fn func(ctx context.Context) error) {
+	if !j.running.TryLock() {
+		log.Printf("⏭️  Skipped %s: it is still running", j.name)
+		return
+	}
+	defer j.running.Unlock()
+	j.executeLocked(trigger, fn)
+}
+
+// executeLocked is execute for a caller holding j.running
+func (j *job) executeLocked(trigger models.JobTrigger, fn func(ctx context.Context) error) {
+	if trigger != models.JobTriggerManual && j.paused() {
+		log.Printf("⏸️  Skipped %s: it is paused", j.name)
+		return
+	}
+
+	ctx, span := telemetry.StartJob(j.name)
+	defer span.End()
+
+	recordJobStart(j.name, trigger)
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	recordJobFinish(j.name, err)
+}
+
+// jobError summarizes the errors of a run, nil when there were none
+func jobError(messages []string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d error(s): %s", len(messages), strings.Join(messages, "; "))
+}
+
+// paused reports whether an admin paused the job. A job whose state cannot be read runs, as it would
+// before any job was paused.
+func (j *job) paused() bool {
+	var state models.ScheduledJob
+	err := database.DB.Where("name = ?", j.name).Limit(1).Find(&state).Error
+	return err == nil && state.Paused
+}
+
+// serverName identifies this server in the state of the jobs it runs
+var serverName, _ = os.Hostname()
+
+// recordJobStart records that a run of a job started on this server
+func recordJobStart(name string, trigger models.JobTrigger) {
+	now := time.Now()
+	state := models.ScheduledJob{Name: name, LastStatus: models.JobRunRunning, LastTrigger: trigger, LastServer: serverName, LastStartedAt: &now}
+	err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_status", "last_trigger", "last_server", "last_started_at", "updated_at"}),
+	}).Create(&state).Error
+	if err != nil {
+		log.Printf("❌ Failed to record the start of %s: %v", name, err)
+	}
+}
+
+// recordJobFinish records how a run of a job ended
+func recordJobFinish(name string, runErr error) {
+	now := time.Now()
+	updates := map[string]interface{}{"last_status": models.JobRunSucceeded, "last_finished_at": now, "last_error": nil}
+	if runErr != nil {
+		updates["last_status"] = models.JobRunFailed
+		updates["last_error"] = runErr.Error()
+	} else {
+		updates["last_succeeded_at"] = now
+	}
+	if err := database.DB.Model(&models.ScheduledJob{}).Where("name = ?", name).Updates(updates).Error; err != nil {
+		log.Printf("❌ Failed to record the outcome of %s: %v", name, err)
+	}
+}
+
+// JobStatus is a scheduled job with its schedule on this server and the state every server shares
+type JobStatus struct {
+	models.ScheduledJob
+	Description string     `json:"description" example:"Accrues the previous month's leave for every active employee"`
+	Schedule    string     `json:"schedule" example:"0 0 2 1 * *"`   // Cron expression: second, minute, hour, day of month, month, day of week
+	Timezone    string     `json:"timezone,omitempty" example:"UTC"` // Of the schedule; Local is the server's
+	Scheduled   bool       `json:"scheduled" example:"true"`         // False when this server does not run the job, such as calendar retries without calendar providers
+	NextRunAt   *time.Time `json:"next_run_at,omitempty"`            // On this server; a paused job is skipped
+	Running     bool       `json:"running" example:"false"`          // Whether a run is in progress on this server
+}
+
+// status describes the job with its stored state
+func (j *job) status(state models.ScheduledJob) JobStatus {
+	state.Name = j.name
+	status := JobStatus{ScheduledJob: state, Description: j.description, Schedule: j.spec}
+
+	j.mu.Lock()
+	scheduler, entryID := j.cron, j.entryID
+	j.mu.Unlock()
+	if scheduler != nil {
+		status.Scheduled = true
+		status.Timezone = scheduler.Location().String()
+		if next := scheduler.Entry(entryID).Next; !next.IsZero() {
+			status.NextRunAt = &next
+		}
+	}
+	if j.running.TryLock() {
+		j.running.Unlock()
+	} else {
+		status.Running = true
+	}
+	return status
+}
+
+// Jobs lists the scheduled jobs
+func Jobs() ([]JobStatus, error) {
+	var states []models.ScheduledJob
+	if err := database.DB.Find(&states).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.ScheduledJob, len(states))
+	for _, state := range states {
+		byName[state.Name] = state
+	}
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for _, j := range jobs {
+		statuses = append(statuses, j.status(byName[j.name]))
+	}
+	return statuses, nil
+}
+
+// Job returns the scheduled job named name, or ErrJobNotFound
+func Job(name string) (JobStatus, error) {
+	j, ok := findJob(name)
+	if !ok {
+		return JobStatus{}, ErrJobNotFound
+	}
+	var state models.ScheduledJob
+	if err := database.DB.Where("name = ?", name).Limit(1).Find(&state).Error; err != nil {
+		return JobStatus{}, err
+	}
+	return j.status(state), nil
+}
+
+// RunJob starts a run of a job on this server now, in the background, even when it is paused. It returns
+// ErrJobRunning when the job is already running here.
+func RunJob(name string) error {
+	j, ok := findJob(name)
+	if !ok {
+		return ErrJobNotFound
+	}
+	if !j.running.TryLock() {
+		return ErrJobRunning
+	}
+	// The job's row is created now, so that it can be reported on as soon as this returns
+	err := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ScheduledJob{Name: name}).Error
+	if err != nil {
+		j.running.Unlock()
+		return err
+	}
+	manualRuns.Add(1)
+	go func() {
+		defer manualRuns.Done()
+		defer j.running.Unlock()
+		j.executeLocked(models.JobTriggerManual, j.run)
+	}()
+	return nil
+}
+
+// SetJobPaused pauses or resumes a job on every server. A paused job skips its scheduled and startup
+// runs until it is resumed, but can still be run by hand.
+func SetJobPaused(name string, paused bool, userID uint) (JobStatus, error) {
+	if _, ok := findJob(name); !ok {
+		return JobStatus{}, ErrJobNotFound
+	}
+	state := models.ScheduledJob{Name: name, Paused: paused}
+	if paused {
+		now := time.Now()
+		state.PausedBy, state.PausedAt = &userID, &now
+	}
+	err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"paused", "paused_by", "paused_at", "updated_at"}),
+	}).Create(&state).Error
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return Job(name)
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var notificationScheduler *cron.Cron
+
+var notificationJob = registerJob(&job{
+	name:        "notification_retry",
+	description: "Sends again the notification emails and text messages whose next attempt is due",
+	spec:        "30 * * * * *",
+	run:         retryNotifications,
+})
+
+// StartNotificationScheduler starts the job that retries failed notification emails and text messages
+// It runs every minute and once on startup
+func StartNotificationScheduler() {
+	notificationScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "30 * * * * *" means: second=30, every minute, half a minute after webhook retries
+	err := notificationJob.schedule(notificationScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule notification retries: %v", err)
+		return
+	}
+
+	notificationScheduler.Start()
+	log.Println("✅ Notification scheduler started - failed emails and text messages will be retried every minute")
+
+	notificationJob.runAtStartup()
+}
+
+// StopNotificationScheduler stops the notification scheduler and waits for a running job to finish
+func StopNotificationScheduler() {
+	if notificationScheduler != nil {
+		<-notificationScheduler.Stop().Done()
+		log.Println("Notification scheduler stopped")
+	}
+}
+
+// retryNotifications re-sends notification emails and text messages whose next attempt is due
+func retryNotifications(ctx context.Context) error {
+	sent, errs := utils.ProcessNotificationRetries()
+	for _, err := range errs {
+		telemetry.Logf(ctx, "❌ Notification retry: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("✅ Sent %d notification(s) on retry", sent)
+	}
+	return errors.Join(errs...)
+}
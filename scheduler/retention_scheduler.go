@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var retentionScheduler *cron.Cron
+
+var retentionJob = registerJob(&job{
+	name:        "retention_purge",
+	description: "Purges the records past every organization's enabled retention policies",
+	spec:        "0 30 3 * * *",
+	run:         purgeExpiredRecords,
+})
+
+// StartRetentionScheduler starts the daily job that purges records past their retention policy
+// It runs every day at 03:30; unlike the other jobs it does not run on startup, so that a restart never
+// purges anything before an admin could check the retention report
+func StartRetentionScheduler() {
+	retentionScheduler = cron.New(cron.WithSeconds(), cron.WithLocation(utils.CompanyLocation()))
+
+	// Cron expression: "0 30 3 * * *" means: second=0, minute=30, hour=3, every day
+	err := retentionJob.schedule(retentionScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule retention purges: %v", err)
+		return
+	}
+
+	retentionScheduler.Start()
+	log.Println("✅ Retention scheduler started - records past their retention policy will be purged daily at 03:30")
+}
+
+// StopRetentionScheduler stops the retention scheduler and waits for a running job to finish
+func StopRetentionScheduler() {
+	if retentionScheduler != nil {
+		<-retentionScheduler.Stop().Done()
+		log.Println("Retention scheduler stopped")
+	}
+}
+
+// purgeExpiredRecords applies every organization's enabled retention policies
+func purgeExpiredRecords(ctx context.Context) error {
+	results, err := utils.ApplyRetentionPolicies(database.DB.WithContext(ctx), false)
+	if err != nil {
+		telemetry.Logf(ctx, "❌ Retention purge: %v", err)
+		return fmt.Errorf("loading retention policies: %w", err)
+	}
+	var failed []string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s for organization %d: %s", result.Category, result.OrganizationID, result.Error))
+			telemetry.Logf(ctx, "❌ Retention purge of %s for organization %d: %s", result.Category, result.OrganizationID, result.Error)
+			continue
+		}
+		if result.Records > 0 {
+			log.Printf("✅ Purged %d %s record(s) older than %d days for organization %d (%d kept under legal hold)",
+				result.Records, result.Category, result.RetentionDays, result.OrganizationID, result.Held)
+		}
+	}
+	return jobError(failed)
+}
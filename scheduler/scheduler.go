@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"hrms-api/backup"
+	"hrms-api/utils"
+	"sync"
+)
+
+// startupRuns tracks the catch-up runs each scheduler makes on startup so shutdown can wait for them
+var startupRuns sync.WaitGroup
+
+// runAtStartup runs a job once in the background
+func runAtStartup(job func()) {
+	startupRuns.Add(1)
+	go func() {
+		defer startupRuns.Done()
+		job()
+	}()
+}
+
+// StopAll stops every scheduler and waits for running jobs, background webhook sends and calendar
+// syncs, export jobs, and a running backup or restore to finish.
+// It returns ctx's error if they are still running when ctx is done.
+func StopAll(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		var stopping sync.WaitGroup
+		for _, stop := range []func(){
+			StopAccrualScheduler,
+			StopTransferScheduler,
+			StopComplianceScheduler,
+			StopAttendanceScheduler,
+			StopGrievanceScheduler,
+			StopWebhookScheduler,
+			StopNotificationScheduler,
+			StopRetentionScheduler,
+			StopSettingsScheduler,
+			StopAPIKeyUsageScheduler,
+			StopCalendarScheduler,
+			StopHolidayScheduler,
+			StopExportScheduler,
+			StopTextExtractionScheduler,
+		} {
+			stopping.Add(1)
+			go func() {
+				defer stopping.Done()
+				stop()
+			}()
+		}
+		stopping.Wait()
+		startupRuns.Wait()
+		manualRuns.Wait()
+		utils.WaitForWebhookSends()
+		utils.WaitForPushSends()
+		utils.WaitForCalendarSyncs()
+		backup.WaitForJobs()
+		utils.WaitForExportJobs()
+		utils.WaitForTextExtraction()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
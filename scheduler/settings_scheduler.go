@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var settingsScheduler *cron.Cron
+
+// StartSettingsScheduler starts the job that picks up runtime settings changed since they were loaded,
+// so changes made through another server sharing the database take effect here too
+// It runs every 15 seconds
+func StartSettingsScheduler() {
+	settingsScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "*/15 * * * * *" means: every 15 seconds
+	_, err := settingsScheduler.AddFunc("*/15 * * * * *", reloadSettings)
+	if err != nil {
+		log.Printf("Failed to schedule settings reloads: %v", err)
+		return
+	}
+
+	settingsScheduler.Start()
+	log.Println("✅ Settings scheduler started - runtime settings changes are picked up every 15 seconds")
+}
+
+// StopSettingsScheduler stops the settings scheduler and waits for a running reload to finish
+func StopSettingsScheduler() {
+	if settingsScheduler != nil {
+		<-settingsScheduler.Stop().Done()
+		log.Println("Settings scheduler stopped")
+	}
+}
+
+// reloadSettings reloads the runtime settings when they have changed
+func reloadSettings() {
+	reloaded, err := utils.ReloadSettingsIfChanged()
+	if err != nil {
+		log.Printf("❌ Failed to reload settings: %v", err)
+		return
+	}
+	if reloaded {
+		log.Println("✅ Reloaded runtime settings")
+	}
+}
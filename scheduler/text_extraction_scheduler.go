@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var textExtractionScheduler *cron.Cron
+
+var textExtractionJob = registerJob(&job{
+	name:        "text_extraction",
+	description: "Extracts the text of documents not read yet for content search and requeues interrupted extractions",
+	spec:        "45 * * * * *",
+	run:         processTextExtraction,
+})
+
+// StartTextExtractionScheduler starts the job that extracts the text of documents no server has read
+// yet, such as those stored before an extractor was configured, and requeues interrupted extractions
+// It runs every minute and once on startup. It is not started when TEXT_EXTRACTOR is empty.
+func StartTextExtractionScheduler() {
+	if !utils.TextExtractionEnabled() {
+		return
+	}
+	textExtractionScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "45 * * * * *" means: second=45, every minute
+	err := textExtractionJob.schedule(textExtractionScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule text extraction: %v", err)
+		return
+	}
+
+	textExtractionScheduler.Start()
+	log.Println("✅ Text extraction scheduler started - documents are checked for text to extract every minute")
+
+	textExtractionJob.runAtStartup()
+}
+
+// StopTextExtractionScheduler stops the text extraction scheduler and waits for a running job to finish
+func StopTextExtractionScheduler() {
+	if textExtractionScheduler != nil {
+		<-textExtractionScheduler.Stop().Done()
+		log.Println("Text extraction scheduler stopped")
+	}
+}
+
+// processTextExtraction requeues interrupted extractions and extracts the documents waiting
+func processTextExtraction(ctx context.Context) error {
+	requeued, err := utils.RequeueStaleExtractions()
+	if err != nil {
+		telemetry.Logf(ctx, "❌ Text extraction: failed to requeue interrupted extractions: %v", err)
+		err = fmt.Errorf("requeueing interrupted extractions: %w", err)
+	} else if requeued > 0 {
+		log.Printf("⚠️  Requeued %d interrupted text extraction(s)", requeued)
+	}
+
+	utils.RunTextExtraction()
+	return err
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var transferScheduler *cron.Cron
+
+var transferJob = registerJob(&job{
+	name:        "transfer_application",
+	description: "Applies approved transfers whose effective date has been reached",
+	spec:        "0 30 0 * * *",
+	run:         processDueTransfers,
+})
+
+// StartTransferScheduler starts the daily job that applies approved transfers on their effective date
+// It runs every day at 00:30 and once on startup to catch transfers that fell due while the server was down
+func StartTransferScheduler() {
+	transferScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "0 30 0 * * *" means: second=0, minute=30, hour=0, every day
+	err := transferJob.schedule(transferScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule transfer processing: %v", err)
+		return
+	}
+
+	transferScheduler.Start()
+	log.Println("✅ Transfer scheduler started - approved transfers will be applied daily at 00:30")
+
+	transferJob.runAtStartup()
+}
+
+// StopTransferScheduler stops the transfer scheduler and waits for a running job to finish
+func StopTransferScheduler() {
+	if transferScheduler != nil {
+		<-transferScheduler.Stop().Done()
+		log.Println("Transfer scheduler stopped")
+	}
+}
+
+// processDueTransfers applies approved transfers whose effective date has been reached
+func processDueTransfers(ctx context.Context) error {
+	applied, errs := utils.ProcessDueTransfers()
+	for _, err := range errs {
+		telemetry.Logf(ctx, "❌ Error applying transfer: %v", err)
+	}
+	if applied > 0 {
+		log.Printf("✅ Applied %d transfer(s)", applied)
+	}
+	return errors.Join(errs...)
+}
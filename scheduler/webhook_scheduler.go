@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hrms-api/telemetry"
+	"hrms-api/utils"
+	"log"
+
+	"github.com/robfig/cron/v3"
+)
+
+var webhookScheduler *cron.Cron
+
+var webhookJob = registerJob(&job{
+	name:        "webhook_retry",
+	description: "Sends again the webhook deliveries whose next attempt is due",
+	spec:        "0 * * * * *",
+	run:         retryWebhooks,
+})
+
+// StartWebhookScheduler starts the job that retries failed webhook deliveries
+// It runs every minute and once on startup
+func StartWebhookScheduler() {
+	webhookScheduler = cron.New(cron.WithSeconds())
+
+	// Cron expression: "0 * * * * *" means: second=0, every minute
+	err := webhookJob.schedule(webhookScheduler)
+	if err != nil {
+		log.Printf("Failed to schedule webhook retries: %v", err)
+		return
+	}
+
+	webhookScheduler.Start()
+	log.Println("✅ Webhook scheduler started - failed deliveries will be retried every minute")
+
+	webhookJob.runAtStartup()
+}
+
+// StopWebhookScheduler stops the webhook scheduler and waits for a running job to finish
+func StopWebhookScheduler() {
+	if webhookScheduler != nil {
+		<-webhookScheduler.Stop().Done()
+		log.Println("Webhook scheduler stopped")
+	}
+}
+
+// retryWebhooks re-sends webhook deliveries whose next attempt is due
+func retryWebhooks(ctx context.Context) error {
+	delivered, errs := utils.ProcessWebhookRetries()
+	for _, err := range errs {
+		telemetry.Logf(ctx, "❌ Webhook retry: %v", err)
+	}
+	if delivered > 0 {
+		log.Printf("✅ Delivered %d webhook(s) on retry", delivered)
+	}
+	return errors.Join(errs...)
+}
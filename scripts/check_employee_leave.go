@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -86,7 +88,7 @@ func main() {
 	fmt.Println(strings.Repeat("-", 60))
 
 	// Ensure accruals are up to date
-	if err := utils.EnsureAccrualsUpToDate(employee.ID, annualLeaveType.ID); err != nil {
+	if err := utils.EnsureAccrualsUpToDate(database.DB, employee.ID, annualLeaveType.ID); err != nil {
 		log.Printf("Warning: Failed to process accruals: %v", err)
 	}
 
@@ -150,11 +152,11 @@ func main() {
 	// Get carry-over balance
 	var carryOverBalance float64
 	if annualLeaveType.AllowCarryOver {
-		carryOverBalance, _ = utils.GetCarryOverBalance(employee.ID, annualLeaveType.ID)
+		carryOverBalance, _ = utils.GetCarryOverBalance(database.DB, employee.ID, annualLeaveType.ID)
 	}
 
 	// Get current balance
-	currentBalance, _ := utils.GetCurrentLeaveBalance(employee.ID, annualLeaveType.ID)
+	currentBalance, _ := utils.GetCurrentLeaveBalance(database.DB, employee.ID, annualLeaveType.ID)
 
 	// Calculate all-time net balance
 	allTimeNetBalance := totalAccrued - totalUsed
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hrms-api/models"
+	"hrms-api/repository"
+	"hrms-api/utils"
+	"time"
+)
+
+var (
+	ErrLeaveNotFound       = utils.ErrLeaveNotFound
+	ErrLeaveTypeNotFound   = errors.New("leave type not found")
+	ErrLeaveNotPending     = errors.New("leave is not in pending status")
+	ErrNotLeaveOwner       = errors.New("only the employee can cancel their own leave request")
+	ErrLeaveNotCancellable = errors.New("only pending or approved leaves can be cancelled")
+	ErrLeaveAlreadyStarted = errors.New("cannot cancel leave that has already started")
+)
+
+// InsufficientBalanceError is returned when a leave needs more days than the employee has available.
+// It matches utils.ErrInsufficientBalance with errors.Is.
+type InsufficientBalanceError struct {
+	Available float64
+	Requested float64
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("insufficient leave balance: %.2f days available, %.2f requested", e.Available, e.Requested)
+}
+
+func (e *InsufficientBalanceError) Unwrap() error {
+	return utils.ErrInsufficientBalance
+}
+
+// ApplyLeaveInput is a leave request submitted by an employee
+type ApplyLeaveInput struct {
+	EmployeeID  uint
+	LeaveTypeID uint
+	StartDate   time.Time
+	EndDate     time.Time
+	Reason      string
+	IPAddress   string // Recorded on the audit trail
+}
+
+// LeaveService runs the leave request workflow: applying, approving, rejecting and cancelling
+type LeaveService interface {
+	Apply(ctx context.Context, input ApplyLeaveInput) (*models.Leave, error)
+	Approve(ctx context.Context, leaveID, approverID uint, ipAddress string) (*models.Leave, error)
+	Reject(ctx context.Context, leaveID, approverID uint, reason, ipAddress string) (*models.Leave, error)
+	Cancel(ctx context.Context, leaveID, employeeID uint, ipAddress string) (*models.Leave, error)
+}
+
+type leaveService struct {
+	leaves   repository.LeaveRepository
+	balances repository.BalanceRepository
+	now      func() time.Time
+}
+
+// NewLeaveService returns a LeaveService that stores leaves in leaves and checks balances against balances
+func NewLeaveService(leaves repository.LeaveRepository, balances repository.BalanceRepository) LeaveService {
+	return &leaveService{leaves: leaves, balances: balances, now: utils.Now}
+}
+
+func (s *leaveService) Apply(ctx context.Context, input ApplyLeaveInput) (*models.Leave, error) {
+	if input.StartDate.After(input.EndDate) {
+		return nil, utils.ErrInvalidDateRange
+	}
+
+	// Leave dates are calendar dates, so "today" is the employee's, not the server's
+	employee, err := s.leaves.FindEmployee(ctx, input.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("load employee: %w", err)
+	}
+	today := utils.DateIn(s.now(), utils.EmployeeLocation(employee))
+	if input.StartDate.Before(today) {
+		return nil, utils.ErrPastDate
+	}
+
+	leaveType, err := s.leaves.FindLeaveType(ctx, input.LeaveTypeID)
+	if err != nil {
+		return nil, notFoundAs(err, ErrLeaveTypeNotFound)
+	}
+
+	overlap, err := s.leaves.HasOverlappingLeave(ctx, input.EmployeeID, input.StartDate, input.EndDate, nil)
+	if err != nil {
+		return nil, fmt.Errorf("check overlapping leaves: %w", err)
+	}
+	if overlap {
+		return nil, utils.ErrOverlappingLeave
+	}
+
+	leave := models.Leave{
+		EmployeeID:  input.EmployeeID,
+		LeaveTypeID: input.LeaveTypeID,
+		StartDate:   input.StartDate,
+		EndDate:     input.EndDate,
+		Reason:      input.Reason,
+		Status:      models.StatusPending,
+	}
+
+	// Only leave types that use balance (e.g. Annual) are checked; record-only types are just added.
+	// Leave starting in the future is checked against the balance projected to its start date.
+	if leaveType.UsesBalance {
+		if err := s.balances.EnsureAccrualsUpToDate(ctx, input.EmployeeID, input.LeaveTypeID); err != nil {
+			return nil, fmt.Errorf("update leave accruals: %w", err)
+		}
+
+		var balance float64
+		if input.StartDate.After(today) {
+			balance, err = s.balances.ProjectedBalance(ctx, input.EmployeeID, input.LeaveTypeID, input.StartDate)
+		} else {
+			balance, err = s.balances.CurrentBalance(ctx, input.EmployeeID, input.LeaveTypeID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("calculate leave balance: %w", err)
+		}
+		if requested := float64(leave.GetDuration()); requested > balance {
+			return nil, &InsufficientBalanceError{Available: balance, Requested: requested}
+		}
+	}
+
+	audit := models.LeaveAudit{
+		Action:      models.AuditActionCreate,
+		PerformedBy: input.EmployeeID,
+		NewStatus:   string(leave.Status),
+		Comment:     input.Reason,
+		IPAddress:   input.IPAddress,
+	}
+	if err := s.leaves.Create(ctx, &leave, &audit); err != nil {
+		return nil, fmt.Errorf("create leave request: %w", err)
+	}
+	return s.leaves.FindLeave(ctx, leave.ID)
+}
+
+func (s *leaveService) Approve(ctx context.Context, leaveID, approverID uint, ipAddress string) (*models.Leave, error) {
+	leave, err := s.findPending(ctx, leaveID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Approval draws on the balance at the leave's start date, not counting the leave itself
+	var carryOverDays float64
+	if leave.LeaveType.UsesBalance {
+		if err := s.balances.EnsureAccrualsUpToDate(ctx, leave.EmployeeID, leave.LeaveTypeID); err != nil {
+			return nil, fmt.Errorf("update leave accruals: %w", err)
+		}
+
+		balance, err := s.balances.AvailableBalance(ctx, leave.EmployeeID, leave.LeaveTypeID, &leave.ID, &leave.StartDate)
+		if err != nil {
+			balance, err = s.balances.CurrentBalance(ctx, leave.EmployeeID, leave.LeaveTypeID)
+			if err != nil {
+				return nil, fmt.Errorf("calculate leave balance: %w", err)
+			}
+		}
+		requested := float64(leave.GetDuration())
+		if requested > balance {
+			return nil, &InsufficientBalanceError{Available: balance, Requested: requested}
+		}
+		if leave.LeaveType.AllowCarryOver {
+			carryOverDays = requested
+		}
+	}
+
+	now := s.now()
+	leave.Status = models.StatusApproved
+	leave.ApprovedBy = &approverID
+	leave.ApprovedAt = &now
+
+	audit := statusAudit(models.AuditActionApprove, approverID, models.StatusPending, leave.Status, "Approved", ipAddress)
+	if err := s.leaves.UpdateStatus(ctx, leave, &audit, carryOverDays); err != nil {
+		return nil, fmt.Errorf("approve leave: %w", err)
+	}
+	return leave, nil
+}
+
+func (s *leaveService) Reject(ctx context.Context, leaveID, approverID uint, reason, ipAddress string) (*models.Leave, error) {
+	leave, err := s.findPending(ctx, leaveID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	leave.Status = models.StatusRejected
+	leave.RejectionReason = reason
+	leave.ApprovedBy = &approverID
+	leave.ApprovedAt = &now
+
+	audit := statusAudit(models.AuditActionReject, approverID, models.StatusPending, leave.Status, reason, ipAddress)
+	if err := s.leaves.UpdateStatus(ctx, leave, &audit, 0); err != nil {
+		return nil, fmt.Errorf("reject leave: %w", err)
+	}
+	return leave, nil
+}
+
+func (s *leaveService) Cancel(ctx context.Context, leaveID, employeeID uint, ipAddress string) (*models.Leave, error) {
+	leave, err := s.leaves.FindLeave(ctx, leaveID)
+	if err != nil {
+		return nil, notFoundAs(err, ErrLeaveNotFound)
+	}
+	if leave.EmployeeID != employeeID {
+		return nil, ErrNotLeaveOwner
+	}
+	if leave.Status != models.StatusPending && leave.Status != models.StatusApproved {
+		return nil, ErrLeaveNotCancellable
+	}
+	// Approved leave can only be cancelled before it starts
+	if leave.Status == models.StatusApproved && !leave.StartDate.After(utils.DateIn(s.now(), utils.EmployeeLocation(&leave.Employee))) {
+		return nil, ErrLeaveAlreadyStarted
+	}
+
+	oldStatus := leave.Status
+	leave.Status = models.StatusCancelled
+
+	audit := statusAudit(models.AuditActionCancel, employeeID, oldStatus, leave.Status, "Cancelled by employee", ipAddress)
+	if err := s.leaves.UpdateStatus(ctx, leave, &audit, 0); err != nil {
+		return nil, fmt.Errorf("cancel leave: %w", err)
+	}
+	return leave, nil
+}
+
+func (s *leaveService) findPending(ctx context.Context, leaveID uint) (*models.Leave, error) {
+	leave, err := s.leaves.FindLeave(ctx, leaveID)
+	if err != nil {
+		return nil, notFoundAs(err, ErrLeaveNotFound)
+	}
+	if leave.Status != models.StatusPending {
+		return nil, ErrLeaveNotPending
+	}
+	return leave, nil
+}
+
+func statusAudit(action models.AuditAction, performedBy uint, oldStatus, newStatus models.LeaveStatus, comment, ipAddress string) models.LeaveAudit {
+	return models.LeaveAudit{
+		Action:      action,
+		PerformedBy: performedBy,
+		OldStatus:   string(oldStatus),
+		NewStatus:   string(newStatus),
+		Comment:     comment,
+		IPAddress:   ipAddress,
+	}
+}
+
+// notFoundAs replaces repository.ErrNotFound with the service's own error for the missing record
+func notFoundAs(err, target error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return target
+	}
+	return err
+}
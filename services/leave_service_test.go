@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"hrms-api/models"
+	"hrms-api/repository"
+	"hrms-api/testutil"
+	"hrms-api/utils"
+	"testing"
+	"time"
+)
+
+// fakeLeaveRepository keeps leaves in memory and reports an overlap when overlapping is set
+type fakeLeaveRepository struct {
+	employees   map[uint]*models.Employee
+	leaveTypes  map[uint]*models.LeaveType
+	leaves      map[uint]*models.Leave
+	audits      []models.LeaveAudit
+	overlapping bool
+}
+
+func newFakeLeaveRepository() *fakeLeaveRepository {
+	return &fakeLeaveRepository{
+		employees: map[uint]*models.Employee{1: {ID: 1, Firstname: "Test", Lastname: "Employee"}},
+		leaveTypes: map[uint]*models.LeaveType{
+			1: {ID: 1, Name: "Annual", UsesBalance: true},
+			2: {ID: 2, Name: "Sick", UsesBalance: false},
+		},
+		leaves: map[uint]*models.Leave{},
+	}
+}
+
+func (r *fakeLeaveRepository) FindLeave(ctx context.Context, id uint) (*models.Leave, error) {
+	leave, ok := r.leaves[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	found := *leave
+	found.Employee = *r.employees[leave.EmployeeID]
+	found.LeaveType = *r.leaveTypes[leave.LeaveTypeID]
+	return &found, nil
+}
+
+func (r *fakeLeaveRepository) FindLeaveType(ctx context.Context, id uint) (*models.LeaveType, error) {
+	leaveType, ok := r.leaveTypes[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return leaveType, nil
+}
+
+func (r *fakeLeaveRepository) FindEmployee(ctx context.Context, id uint) (*models.Employee, error) {
+	employee, ok := r.employees[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return employee, nil
+}
+
+func (r *fakeLeaveRepository) HasOverlappingLeave(ctx context.Context, employeeID uint, startDate, endDate time.Time, excludeLeaveID *uint) (bool, error) {
+	return r.overlapping, nil
+}
+
+func (r *fakeLeaveRepository) Create(ctx context.Context, leave *models.Leave, audit *models.LeaveAudit) error {
+	leave.ID = uint(len(r.leaves) + 1)
+	stored := *leave
+	r.leaves[leave.ID] = &stored
+	audit.LeaveID = leave.ID
+	r.audits = append(r.audits, *audit)
+	return nil
+}
+
+func (r *fakeLeaveRepository) UpdateStatus(ctx context.Context, leave *models.Leave, audit *models.LeaveAudit, carryOverDays float64) error {
+	stored := *leave
+	r.leaves[leave.ID] = &stored
+	audit.LeaveID = leave.ID
+	r.audits = append(r.audits, *audit)
+	return nil
+}
+
+// fakeBalanceRepository has a fixed current balance and projected balance, and records which one
+// was asked for. Bringing accruals up to date fails with accrualErr.
+type fakeBalanceRepository struct {
+	current    float64
+	projected  float64
+	asked      string
+	accrualErr error
+}
+
+func (r *fakeBalanceRepository) EnsureAccrualsUpToDate(ctx context.Context, employeeID, leaveTypeID uint) error {
+	return r.accrualErr
+}
+
+func (r *fakeBalanceRepository) CurrentBalance(ctx context.Context, employeeID, leaveTypeID uint) (float64, error) {
+	r.asked = "current"
+	return r.current, nil
+}
+
+func (r *fakeBalanceRepository) ProjectedBalance(ctx context.Context, employeeID, leaveTypeID uint, at time.Time) (float64, error) {
+	r.asked = "projected"
+	return r.projected, nil
+}
+
+func (r *fakeBalanceRepository) AvailableBalance(ctx context.Context, employeeID, leaveTypeID uint, excludeLeaveID *uint, at *time.Time) (float64, error) {
+	r.asked = "available"
+	return r.current, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestApplyInsufficientBalance(t *testing.T) {
+	testutil.SetClock(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		asked     string
+		available float64
+	}{
+		{"starting today uses the current balance", date(2025, time.March, 10), date(2025, time.March, 14), "current", 3},
+		{"starting later uses the projected balance", date(2025, time.April, 7), date(2025, time.April, 11), "projected", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaves := newFakeLeaveRepository()
+			balances := &fakeBalanceRepository{current: 3, projected: 4}
+			service := NewLeaveService(leaves, balances)
+
+			_, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: 1, StartDate: tt.start, EndDate: tt.end})
+			var insufficient *InsufficientBalanceError
+			if !errors.As(err, &insufficient) {
+				t.Fatalf("Apply error = %v, want InsufficientBalanceError", err)
+			}
+			if !errors.Is(err, utils.ErrInsufficientBalance) {
+				t.Errorf("Apply error %v does not match utils.ErrInsufficientBalance", err)
+			}
+			if insufficient.Available != tt.available || insufficient.Requested != 5 {
+				t.Errorf("Apply error reports %v available, %v requested, want %v and 5",
+					insufficient.Available, insufficient.Requested, tt.available)
+			}
+			if balances.asked != tt.asked {
+				t.Errorf("Apply checked the %s balance, want the %s balance", balances.asked, tt.asked)
+			}
+			if len(leaves.leaves) != 0 {
+				t.Errorf("Apply stored %d leaves, want none", len(leaves.leaves))
+			}
+		})
+	}
+}
+
+func TestApplyWithoutBalanceCheck(t *testing.T) {
+	testutil.SetClock(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+	leaves := newFakeLeaveRepository()
+	balances := &fakeBalanceRepository{}
+	service := NewLeaveService(leaves, balances)
+
+	// Enough balance for an annual leave, and no balance at all for a sick leave, which does not use one
+	balances.current = 5
+	for _, leaveTypeID := range []uint{1, 2} {
+		if _, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: leaveTypeID,
+			StartDate: date(2025, time.March, 10), EndDate: date(2025, time.March, 14)}); err != nil {
+			t.Fatalf("Apply leave type %d: %v", leaveTypeID, err)
+		}
+		balances.current = 0
+	}
+	if len(leaves.leaves) != 2 {
+		t.Fatalf("Apply stored %d leaves, want 2", len(leaves.leaves))
+	}
+	for _, leave := range leaves.leaves {
+		if leave.Status != models.StatusPending {
+			t.Errorf("leave %d has status %s, want %s", leave.ID, leave.Status, models.StatusPending)
+		}
+	}
+	if len(leaves.audits) != 2 || leaves.audits[0].Action != models.AuditActionCreate {
+		t.Errorf("Apply recorded audits %+v, want a create audit per leave", leaves.audits)
+	}
+}
+
+func TestApplyOverlappingLeave(t *testing.T) {
+	testutil.SetClock(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+	leaves := newFakeLeaveRepository()
+	leaves.overlapping = true
+	service := NewLeaveService(leaves, &fakeBalanceRepository{current: 20})
+
+	_, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: 1,
+		StartDate: date(2025, time.March, 12), EndDate: date(2025, time.March, 13)})
+	if !errors.Is(err, utils.ErrOverlappingLeave) {
+		t.Fatalf("Apply error = %v, want %v", err, utils.ErrOverlappingLeave)
+	}
+	if len(leaves.leaves) != 0 {
+		t.Errorf("Apply stored %d leaves, want none", len(leaves.leaves))
+	}
+}
+
+func TestApplyPastDate(t *testing.T) {
+	clock := testutil.SetClock(t, time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC))
+	leaves := newFakeLeaveRepository()
+	// Auckland is at UTC+13 in March, so already on 11 March
+	leaves.employees[2] = &models.Employee{ID: 2, Timezone: "Pacific/Auckland"}
+	service := NewLeaveService(leaves, &fakeBalanceRepository{current: 20, projected: 20})
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		employeeID uint
+		start      time.Time
+		want       error
+	}{
+		{"yesterday", time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC), 1, date(2025, time.March, 9), utils.ErrPastDate},
+		{"today", time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC), 1, date(2025, time.March, 10), nil},
+		{"today, after midnight", time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC), 1, date(2025, time.March, 10), utils.ErrPastDate},
+		{"today in UTC, yesterday in the employee's timezone", time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC), 2, date(2025, time.March, 10), utils.ErrPastDate},
+		{"today in the employee's timezone", time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC), 2, date(2025, time.March, 11), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Set(tt.now)
+			_, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: tt.employeeID, LeaveTypeID: 1,
+				StartDate: tt.start, EndDate: tt.start.AddDate(0, 0, 1)})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Apply error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyInvalidDateRange(t *testing.T) {
+	testutil.SetClock(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+	service := NewLeaveService(newFakeLeaveRepository(), &fakeBalanceRepository{current: 20})
+
+	_, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: 1,
+		StartDate: date(2025, time.March, 14), EndDate: date(2025, time.March, 12)})
+	if !errors.Is(err, utils.ErrInvalidDateRange) {
+		t.Fatalf("Apply error = %v, want %v", err, utils.ErrInvalidDateRange)
+	}
+}
+
+func TestAccrualError(t *testing.T) {
+	testutil.SetClock(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+	leaves := newFakeLeaveRepository()
+	balances := &fakeBalanceRepository{current: 20, projected: 20}
+	service := NewLeaveService(leaves, balances)
+	leave, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: 1,
+		StartDate: date(2025, time.March, 17), EndDate: date(2025, time.March, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balances.accrualErr = errors.New("database unavailable")
+	if _, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: 1,
+		StartDate: date(2025, time.April, 7), EndDate: date(2025, time.April, 11)}); !errors.Is(err, balances.accrualErr) {
+		t.Errorf("Apply error = %v, want %v", err, balances.accrualErr)
+	}
+	if _, err := service.Approve(context.Background(), leave.ID, 9, ""); !errors.Is(err, balances.accrualErr) {
+		t.Errorf("Approve error = %v, want %v", err, balances.accrualErr)
+	}
+	if len(leaves.leaves) != 1 || leaves.leaves[leave.ID].Status != models.StatusPending {
+		t.Errorf("leaves after failed accruals = %+v, want only the first leave, still pending", leaves.leaves)
+	}
+}
+
+func TestApproveInsufficientBalance(t *testing.T) {
+	testutil.SetClock(t, time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+	leaves := newFakeLeaveRepository()
+	balances := &fakeBalanceRepository{current: 5, projected: 5}
+	service := NewLeaveService(leaves, balances)
+	leave, err := service.Apply(context.Background(), ApplyLeaveInput{EmployeeID: 1, LeaveTypeID: 1,
+		StartDate: date(2025, time.March, 17), EndDate: date(2025, time.March, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another leave approved meanwhile used up part of the balance
+	balances.current = 2
+	_, err = service.Approve(context.Background(), leave.ID, 9, "")
+	var insufficient *InsufficientBalanceError
+	if !errors.As(err, &insufficient) || insufficient.Available != 2 || insufficient.Requested != 5 {
+		t.Fatalf("Approve error = %v, want 2 days available for 5 requested", err)
+	}
+	if balances.asked != "available" {
+		t.Errorf("Approve checked the %s balance, want the available balance", balances.asked)
+	}
+	if status := leaves.leaves[leave.ID].Status; status != models.StatusPending {
+		t.Errorf("leave has status %s after a refused approval, want %s", status, models.StatusPending)
+	}
+}
@@ -0,0 +1,89 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/version"
+	"log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "hrms-api"
+
+var provider *sdktrace.TracerProvider
+
+// Init installs the global tracer provider. Spans are exported over OTLP/HTTP when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set; otherwise they are still created so that trace IDs appear in
+// logs and error responses, but are not exported.
+func Init() error {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.AppConfig.ServiceName),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return err
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if config.AppConfig.OTLPEndpoint != "" {
+		// The exporter reads the endpoint, headers and TLS settings from the standard OTEL_EXPORTER_OTLP_* variables
+		exporter, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			return err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+		log.Printf("✅ Tracing enabled - exporting spans to %s", config.AppConfig.OTLPEndpoint)
+	}
+
+	provider = sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return nil
+}
+
+// Shutdown flushes spans that have not been exported yet
+func Shutdown() {
+	if provider == nil {
+		return
+	}
+	if err := provider.Shutdown(context.Background()); err != nil {
+		log.Printf("Failed to shut down tracing: %v", err)
+	}
+}
+
+// Tracer returns the tracer for the application's own spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartJob starts the root span for one run of a background job
+func StartJob(name string) (context.Context, trace.Span) {
+	return Tracer().Start(context.Background(), "job "+name, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// TraceID returns the ID of the trace in ctx, or an empty string when there is none
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// Logf logs like log.Printf, appending the trace ID from ctx so log lines can be matched to traces
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if traceID := TraceID(ctx); traceID != "" {
+		message += " trace_id=" + traceID
+	}
+	log.Print(message)
+}
@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"hrms-api/utils"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock is a utils.Clock that stands still at the time it was set to until it is moved
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// SetClock stops the clock utils.Now reads, and so the one leave accrual, carry-over and leave date
+// validation read, at now until the test ends
+func SetClock(t testing.TB, now time.Time) *Clock {
+	t.Helper()
+	clock := &Clock{now: now}
+	t.Cleanup(utils.SetClock(clock))
+	return clock
+}
+
+// Now returns the time the clock stands at
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock on by d
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,143 @@
+// Package testutil sets up the API's packages for tests: a fresh database, by default SQLite held in
+// memory so tests need no database server, and a clock tests can set, so code depending on the date
+// can be run at any moment.
+package testutil
+
+import (
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/utils"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// databaseCount numbers the databases Setup creates, so each test gets one of its own
+var databaseCount atomic.Int64
+
+// Setup loads the configuration, with the company timezone UTC, and connects to a new database,
+// migrated and seeded with the default organization, leave types and other reference data, and with
+// the default runtime settings in effect. The database is dropped when the test ends.
+//
+// The database is SQLite held in memory, unless DB_DRIVER selects postgres or mysql. The server is then
+// the one DB_DSN, or the DB_HOST settings, connect to, and each test gets a schema (PostgreSQL) or
+// database (MySQL) of its own on it, so packages can be tested in parallel against the same server.
+//
+// Setup fills package globals such as config.AppConfig and database.DB, so tests calling it cannot
+// run in parallel.
+func Setup(t testing.TB) {
+	t.Helper()
+	number := databaseCount.Add(1)
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = database.DriverSQLite
+	}
+	env := map[string]string{
+		"DB_DRIVER":        driver,
+		"DB_LOG_LEVEL":     "silent",
+		"GIN_MODE":         gin.TestMode,
+		"TIMEZONE":         "UTC",
+		"SECRETS_PROVIDER": "",
+		"ADMIN_PASSWORD":   "",
+		"SEED_DEMO_DATA":   "false",
+		"TEXT_EXTRACTOR":   "",
+	}
+	if driver == database.DriverSQLite {
+		env["DB_PATH"] = fmt.Sprintf("file:hrms-test-%d?mode=memory&cache=shared", number)
+		env["DB_DSN"] = ""
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	gin.SetMode(gin.TestMode)
+	if driver != database.DriverSQLite {
+		createServerDatabase(t, fmt.Sprintf("hrms_test_%d_%d", os.Getpid(), number))
+	}
+
+	if err := database.Connect(); err != nil {
+		t.Fatalf("connecting to the test database: %v", err)
+	}
+	t.Cleanup(func() {
+		// A database in memory goes with the last connection to it
+		if sqlDB, err := database.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+		database.DB = nil
+	})
+	if err := database.Migrate(); err != nil {
+		t.Fatalf("migrating the test database: %v", err)
+	}
+	if err := database.SeedData(); err != nil {
+		t.Fatalf("seeding the test database: %v", err)
+	}
+	if err := utils.LoadSettings(); err != nil {
+		t.Fatalf("loading settings: %v", err)
+	}
+}
+
+// createServerDatabase creates a PostgreSQL schema or MySQL database called name on the configured
+// server, drops it when the test ends, and points config.AppConfig.DBDSN at it
+func createServerDatabase(t testing.TB, name string) {
+	t.Helper()
+	dsn := config.AppConfig.GetDSN()
+	var dialector gorm.Dialector
+	var create, drop string
+	switch config.AppConfig.DBDriver {
+	case database.DriverPostgres:
+		dialector = postgres.Open(dsn)
+		create, drop = "CREATE SCHEMA "+name, "DROP SCHEMA "+name+" CASCADE"
+		dsn = withSearchPath(dsn, name)
+	case database.DriverMySQL:
+		dialector = mysql.Open(dsn)
+		create, drop = "CREATE DATABASE "+name, "DROP DATABASE "+name
+		cfg, err := mysqldriver.ParseDSN(dsn)
+		if err != nil {
+			t.Fatalf("parsing DB_DSN: %v", err)
+		}
+		cfg.DBName = name
+		dsn = cfg.FormatDSN()
+	}
+
+	server, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connecting to the test database server: %v", err)
+	}
+	if err := server.Exec(create).Error; err != nil {
+		t.Fatalf("creating the test database: %v", err)
+	}
+	// Registered before Setup closes the test's own connection, so it runs after it
+	t.Cleanup(func() {
+		if err := server.Exec(drop).Error; err != nil {
+			t.Errorf("dropping the test database: %v", err)
+		}
+		if sqlDB, err := server.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	config.AppConfig.DBDSN = dsn
+}
+
+// withSearchPath adds a search_path to a PostgreSQL DSN, either a URL or key=value pairs, so that the
+// connection's tables are created in and read from the schema
+func withSearchPath(dsn, schema string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		return dsn + separator + "search_path=" + schema
+	}
+	return dsn + " search_path=" + schema
+}
@@ -0,0 +1,67 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/utils"
+	"testing"
+	"time"
+)
+
+// CreateEmployee adds an active employee of department, hired on hired, with their employment details
+func CreateEmployee(t testing.TB, department string, hired time.Time) models.Employee {
+	t.Helper()
+	count := databaseCount.Add(1)
+	nrc := fmt.Sprintf("%06d/10/1", count)
+	employee := models.Employee{
+		NRC:          &nrc,
+		Firstname:    "Test",
+		Lastname:     fmt.Sprintf("Employee %d", count),
+		PasswordHash: "not-a-hash",
+		Department:   department,
+		DateJoined:   &hired,
+		Role:         models.RoleEmployee,
+		Status:       "active",
+	}
+	if err := database.DB.Create(&employee).Error; err != nil {
+		t.Fatalf("creating employee: %v", err)
+	}
+	details := models.EmploymentDetails{
+		EmployeeID:       employee.ID,
+		EmploymentType:   models.EmploymentTypeFullTime,
+		EmploymentStatus: models.EmploymentStatusActive,
+		HireDate:         &hired,
+	}
+	if err := database.DB.Create(&details).Error; err != nil {
+		t.Fatalf("creating employment details: %v", err)
+	}
+	return employee
+}
+
+// LeaveType returns the seeded leave type called name
+func LeaveType(t testing.TB, name string) models.LeaveType {
+	t.Helper()
+	var leaveType models.LeaveType
+	if err := database.DB.Where("name = ?", name).First(&leaveType).Error; err != nil {
+		t.Fatalf("finding leave type %s: %v", name, err)
+	}
+	return leaveType
+}
+
+// SetSetting stores a runtime setting and loads it, as changing it through the API does
+func SetSetting(t testing.TB, key string, value interface{}) {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("encoding setting %s: %v", key, err)
+	}
+	setting := models.Setting{Key: key, Value: string(encoded)}
+	if err := database.DB.Create(&setting).Error; err != nil {
+		t.Fatalf("storing setting %s: %v", key, err)
+	}
+	if err := utils.LoadSettings(); err != nil {
+		t.Fatalf("loading settings: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/tls"
+	"hrms-api/config"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// configureTLS prepares the server to serve HTTPS when TLS is configured, with the certificate files
+// or with certificates obtained and renewed from Let's Encrypt for the autocert domains. It returns the
+// plain HTTP server that redirects to HTTPS and answers ACME HTTP-01 challenges, or nil when TLS or
+// HTTP_REDIRECT_PORT is not set.
+func configureTLS(server *http.Server) *http.Server {
+	cfg := config.AppConfig
+	if !cfg.TLSEnabled() {
+		return nil
+	}
+
+	var redirect http.Handler = http.HandlerFunc(redirectToHTTPS)
+	server.TLSConfig = &tls.Config{}
+	if cfg.TLSAutocertDomains != "" {
+		var domains []string
+		for _, domain := range strings.Split(cfg.TLSAutocertDomains, ",") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				domains = append(domains, domain)
+			}
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(cfg.TLSAutocertCache),
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		server.TLSConfig = manager.TLSConfig()
+		redirect = manager.HTTPHandler(redirect)
+	}
+	server.TLSConfig.MinVersion = tls.VersionTLS12
+
+	if cfg.HTTPRedirectPort == "" {
+		return nil
+	}
+	return &http.Server{
+		Addr:              "0.0.0.0:" + cfg.HTTPRedirectPort,
+		Handler:           redirect,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// listenAndServe serves HTTPS when TLS is configured and plain HTTP otherwise
+func listenAndServe(server *http.Server) error {
+	if config.AppConfig.TLSEnabled() {
+		// Autocert supplies certificates through TLSConfig, so its file names are empty
+		return server.ListenAndServeTLS(config.AppConfig.TLSCertFile, config.AppConfig.TLSKeyFile)
+	}
+	return server.ListenAndServe()
+}
+
+// redirectToHTTPS permanently redirects a request to the same URL on the HTTPS port
+func redirectToHTTPS(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if port := config.AppConfig.Port; port != "443" {
+		host = net.JoinHostPort(host, port)
+	}
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// initialisms are written in capitals in Go names
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "url": "URL", "nrc": "NRC", "api": "API", "ip": "IP", "sla": "SLA", "csv": "CSV", "pdf": "PDF",
+}
+
+// goName turns a snake_case parameter name into a Go name, exported or not
+func goName(name string, exported bool) string {
+	var b strings.Builder
+	for i, word := range strings.Split(name, "_") {
+		switch {
+		case i == 0 && !exported:
+			b.WriteString(strings.ToLower(word))
+		case initialisms[strings.ToLower(word)] != "":
+			b.WriteString(initialisms[strings.ToLower(word)])
+		case word != "":
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	if !exported && token.IsKeyword(b.String()) {
+		return b.String() + "_"
+	}
+	return b.String()
+}
+
+// wrap splits text into lines of about width characters
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// operationsFile generates a client method for each operation, with a struct for its query and form
+// parameters when it has any
+func (g *typeGenerator) operationsFile(operations []operation) ([]byte, error) {
+	var b bytes.Buffer
+	imports := map[string]bool{"context": true}
+	for _, op := range operations {
+		if err := g.writeOperation(&b, op, imports); err != nil {
+			return nil, fmt.Errorf("%s: %v", op.name, err)
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString(generatedHeader + "package client\n\n")
+	writeImports(&file, imports)
+	file.Write(b.Bytes())
+	return file.Bytes(), nil
+}
+
+func (g *typeGenerator) writeOperation(b *bytes.Buffer, op operation, imports map[string]bool) error {
+	byName := map[string]param{}
+	var body *param
+	var fields []param // Query and form parameters
+	multipart := false
+	for i, p := range op.params {
+		byName[p.name] = p
+		switch p.in {
+		case "body":
+			body = &op.params[i]
+		case "query":
+			fields = append(fields, p)
+		case "formData":
+			fields = append(fields, p)
+			multipart = true
+		case "path":
+		default:
+			return fmt.Errorf("unsupported %s parameter %s", p.in, p.name)
+		}
+	}
+
+	// The path, with its parameters as arguments
+	arguments := []string{"ctx context.Context"}
+	format := pathParamPattern.ReplaceAllStringFunc(op.path, func(placeholder string) string {
+		p := byName[placeholder[1:len(placeholder)-1]]
+		if p.typ == "int" || p.typ == "integer" {
+			return "%d"
+		}
+		return "%s"
+	})
+	var pathValues []string
+	for _, m := range pathParamPattern.FindAllStringSubmatch(op.path, -1) {
+		p, ok := byName[m[1]]
+		if !ok || p.in != "path" {
+			return fmt.Errorf("path parameter %s is not annotated", m[1])
+		}
+		name := goName(p.name, false)
+		switch p.typ {
+		case "int", "integer":
+			arguments = append(arguments, name+" uint")
+			pathValues = append(pathValues, name)
+		case "string":
+			arguments = append(arguments, name+" string")
+			pathValues = append(pathValues, "url.PathEscape("+name+")")
+			imports["net/url"] = true
+		default:
+			return fmt.Errorf("unsupported path parameter type %s", p.typ)
+		}
+	}
+	path := fmt.Sprintf("%q", op.path)
+	if len(pathValues) > 0 {
+		path = fmt.Sprintf("fmt.Sprintf(%q, %s)", format, strings.Join(pathValues, ", "))
+		imports["fmt"] = true
+	}
+
+	bodyValue := "nil"
+	if body != nil {
+		typ, err := g.annotationType(body.typ)
+		if err != nil {
+			return err
+		}
+		bodyValue = goName(body.name, false)
+		if !body.required {
+			typ = "*" + typ // nil sends no body
+		}
+		arguments = append(arguments, bodyValue+" "+typ)
+	}
+
+	paramsType := op.name + "Params"
+	if len(fields) > 0 {
+		arguments = append(arguments, "params *"+paramsType)
+		if err := writeParams(b, op.name, paramsType, fields); err != nil {
+			return err
+		}
+	}
+
+	var result string
+	if op.success != nil {
+		switch op.success.kind {
+		case "file":
+			result = "io.ReadCloser"
+			imports["io"] = true
+		case "object":
+			typ, err := g.annotationType(op.success.typ)
+			if err != nil {
+				return err
+			}
+			result = typ
+			if !strings.HasPrefix(typ, "map[") && !strings.HasPrefix(typ, "[]") && annotationBasics[typ] == "" {
+				result = "*" + typ
+			}
+		case "array":
+			typ, err := g.annotationType(op.success.typ)
+			if err != nil {
+				return err
+			}
+			result = "[]" + typ
+		default:
+			return fmt.Errorf("unsupported @Success {%s}", op.success.kind)
+		}
+	}
+
+	// Documentation: the handler's own, the description and the route
+	for _, line := range op.doc {
+		b.WriteString("// " + line + "\n")
+	}
+	if op.description != "" {
+		// A full stop keeps a one-line description from being formatted as a heading
+		description := strings.TrimRight(op.description, ".") + "."
+		b.WriteString("//\n")
+		for _, line := range wrap(description, 100) {
+			b.WriteString("// " + line + "\n")
+		}
+	}
+	fmt.Fprintf(b, "//\n// %s %s\n", op.method, op.path)
+
+	returns := "error"
+	if result != "" {
+		returns = "(" + result + ", error)"
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", op.name, strings.Join(arguments, ", "), returns)
+
+	if body != nil && !body.required {
+		fmt.Fprintf(b, "\tvar body interface{}\n\tif %s != nil {\n\t\tbody = %s\n\t}\n", bodyValue, bodyValue)
+		bodyValue = "body"
+	}
+	query := "nil"
+	if len(fields) > 0 {
+		query = "query"
+		writeFieldValues(b, fields, multipart, imports)
+		if multipart {
+			bodyValue = "form"
+		}
+	}
+
+	call := fmt.Sprintf("%q, %s, %s, %s", op.method, path, query, bodyValue)
+	switch {
+	case result == "":
+		fmt.Fprintf(b, "\treturn c.call(ctx, %s, nil)\n", call)
+	case result == "io.ReadCloser":
+		fmt.Fprintf(b, "\treturn c.download(ctx, %s)\n", call)
+	case strings.HasPrefix(result, "*"):
+		fmt.Fprintf(b, "\tvar out %s\n", result[1:])
+		fmt.Fprintf(b, "\tif err := c.call(ctx, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &out, nil\n", call)
+	default:
+		fmt.Fprintf(b, "\tvar out %s\n", result)
+		fmt.Fprintf(b, "\terr := c.call(ctx, %s, &out)\n\treturn out, err\n", call)
+	}
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// paramTypes maps annotated query and form parameter types to Go types
+var paramTypes = map[string]string{
+	"string": "string", "int": "int", "integer": "int", "number": "float64", "bool": "bool", "boolean": "bool", "file": "*File",
+}
+
+// writeParams writes the struct holding an operation's query and form parameters
+func writeParams(b *bytes.Buffer, operation, paramsType string, fields []param) error {
+	fmt.Fprintf(b, "// %s holds the parameters of %s. Parameters left at their zero value are not sent.\n", paramsType, operation)
+	fmt.Fprintf(b, "type %s struct {\n", paramsType)
+	for _, p := range fields {
+		typ, ok := paramTypes[p.typ]
+		if !ok {
+			return fmt.Errorf("unsupported %s parameter type %s", p.in, p.typ)
+		}
+		comment := p.description
+		if p.required {
+			comment = strings.TrimSpace(comment + " (required)")
+		}
+		fmt.Fprintf(b, "\t%s %s", goName(p.name, true), typ)
+		if comment != "" {
+			b.WriteString(" // " + comment)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// writeFieldValues writes the statements building the query string, and the form of a multipart request
+func writeFieldValues(b *bytes.Buffer, fields []param, multipart bool, imports map[string]bool) {
+	imports["net/url"] = true
+	b.WriteString("\tquery := url.Values{}\n")
+	if multipart {
+		b.WriteString("\tform := &multipartForm{}\n")
+	}
+	b.WriteString("\tif params != nil {\n")
+	for _, p := range fields {
+		field := "params." + goName(p.name, true)
+		target := "query.Set"
+		if p.in == "formData" {
+			target = "form.set"
+		}
+		switch paramTypes[p.typ] {
+		case "string":
+			fmt.Fprintf(b, "\t\tif %s != \"\" {\n\t\t\t%s(%q, %s)\n\t\t}\n", field, target, p.name, field)
+		case "int":
+			fmt.Fprintf(b, "\t\tif %s != 0 {\n\t\t\t%s(%q, strconv.Itoa(%s))\n\t\t}\n", field, target, p.name, field)
+			imports["strconv"] = true
+		case "float64":
+			fmt.Fprintf(b, "\t\tif %s != 0 {\n\t\t\t%s(%q, strconv.FormatFloat(%s, 'f', -1, 64))\n\t\t}\n", field, target, p.name, field)
+			imports["strconv"] = true
+		case "bool":
+			fmt.Fprintf(b, "\t\tif %s {\n\t\t\t%s(%q, \"true\")\n\t\t}\n", field, target, p.name)
+		case "*File":
+			fmt.Fprintf(b, "\t\tif %s != nil {\n\t\t\tform.setFile(%q, %s)\n\t\t}\n", field, p.name, field)
+		}
+	}
+	b.WriteString("\t}\n")
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// typeGenerator writes client declarations for the API's Go types. Every type declared in the module
+// that an operation uses, directly or through a field, is generated under its own name.
+type typeGenerator struct {
+	handlers *packages.Package
+	fset     *token.FileSet
+
+	// fields and typeDocs hold the source declarations of the module, to carry over their comments
+	fields   map[token.Position]*ast.Field
+	typeDocs map[token.Position]*ast.CommentGroup
+
+	used    map[string]*types.Named
+	generic map[string]string // Types used with a field overridden in an annotation, by that field's JSON name
+	imports map[string]bool   // Packages the types file imports
+}
+
+func newTypeGenerator(handlers *packages.Package) *typeGenerator {
+	g := &typeGenerator{
+		handlers: handlers,
+		fset:     handlers.Fset,
+		fields:   map[token.Position]*ast.Field{},
+		typeDocs: map[token.Position]*ast.CommentGroup{},
+		used:     map[string]*types.Named{},
+		generic:  map[string]string{},
+		imports:  map[string]bool{},
+	}
+	packages.Visit([]*packages.Package{handlers}, nil, func(pkg *packages.Package) {
+		if !inModule(pkg.PkgPath) {
+			return
+		}
+		for _, file := range pkg.Syntax {
+			g.indexDeclarations(file)
+		}
+	})
+	return g
+}
+
+func (g *typeGenerator) indexDeclarations(file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.GenDecl:
+			for _, spec := range node.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok {
+					doc := spec.Doc
+					if doc == nil && len(node.Specs) == 1 {
+						doc = node.Doc
+					}
+					g.typeDocs[g.fset.Position(spec.Name.Pos())] = doc
+				}
+			}
+		case *ast.StructType:
+			for _, field := range node.Fields.List {
+				for _, name := range field.Names {
+					g.fields[g.fset.Position(name.Pos())] = field
+				}
+				if len(field.Names) == 0 {
+					g.fields[g.fset.Position(field.Type.Pos())] = field
+				}
+			}
+		}
+		return true
+	})
+}
+
+func inModule(path string) bool {
+	return path == modulePath || strings.HasPrefix(path, modulePath+"/")
+}
+
+// annotationBasics maps the basic types of annotations to Go types
+var annotationBasics = map[string]string{
+	"string": "string", "int": "int", "integer": "int", "number": "float64", "bool": "bool", "boolean": "bool",
+}
+
+// overridePattern matches an annotated type with a field overridden, such as PaginatedResponse{data=[]models.Leave}
+var overridePattern = regexp.MustCompile(`^([\w.]+)\{(\w+)=(.+)\}$`)
+
+// annotationType returns the client type for a type named in an annotation
+func (g *typeGenerator) annotationType(expr string) (string, error) {
+	switch {
+	case expr == "map[string]interface{}" || expr == "map[string]string":
+		return expr, nil
+	case strings.HasPrefix(expr, "[]"):
+		elem, err := g.annotationType(expr[2:])
+		return "[]" + elem, err
+	case annotationBasics[expr] != "":
+		return annotationBasics[expr], nil
+	}
+
+	if m := overridePattern.FindStringSubmatch(expr); m != nil {
+		base, err := g.annotationType(m[1])
+		if err != nil {
+			return "", err
+		}
+		if field, ok := g.generic[base]; ok && field != m[2] {
+			return "", fmt.Errorf("%s is used with both %s and %s overridden", base, field, m[2])
+		}
+		g.generic[base] = m[2]
+		arg, err := g.annotationType(m[3])
+		return base + "[" + arg + "]", err
+	}
+
+	scope := g.handlers.Types.Scope()
+	name := expr
+	if pkgName, typeName, ok := strings.Cut(expr, "."); ok {
+		scope, name = nil, typeName
+		for _, imported := range g.handlers.Types.Imports() {
+			if imported.Name() == pkgName {
+				scope = imported.Scope()
+			}
+		}
+		if scope == nil {
+			return "", fmt.Errorf("the handlers do not import %s", pkgName)
+		}
+	}
+	object, ok := scope.Lookup(name).(*types.TypeName)
+	if !ok {
+		return "", fmt.Errorf("unknown type %s", expr)
+	}
+	return g.goType(object.Type())
+}
+
+// goType returns the client type for a Go type, marking the module's named types as used
+func (g *typeGenerator) goType(t types.Type) (string, error) {
+	switch t := types.Unalias(t).(type) {
+	case *types.Basic:
+		return t.Name(), nil
+	case *types.Pointer:
+		elem, err := g.goType(t.Elem())
+		return "*" + elem, err
+	case *types.Slice:
+		elem, err := g.goType(t.Elem())
+		return "[]" + elem, err
+	case *types.Map:
+		key, err := g.goType(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.goType(t.Elem())
+		return "map[" + key + "]" + elem, err
+	case *types.Interface:
+		if !t.Empty() {
+			return "", fmt.Errorf("interface %s cannot be decoded", t)
+		}
+		return "interface{}", nil
+	case *types.Named:
+		return g.namedType(t)
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+func (g *typeGenerator) namedType(t *types.Named) (string, error) {
+	object := t.Obj()
+	if object.Pkg() == nil {
+		return object.Name(), nil // The predeclared error
+	}
+	if !inModule(object.Pkg().Path()) {
+		switch path := object.Pkg().Path() + "." + object.Name(); path {
+		case "time.Time", "time.Duration":
+			g.imports["time"] = true
+			return path, nil
+		case "encoding/json.RawMessage", "encoding/json/jsontext.Value":
+			g.imports["encoding/json"] = true
+			return "json.RawMessage", nil
+		case "gorm.io/gorm.DeletedAt":
+			g.imports["time"] = true
+			return "*time.Time", nil
+		default:
+			return "", fmt.Errorf("unsupported type %s", path)
+		}
+	}
+
+	if used, ok := g.used[object.Name()]; ok && used.Obj() != object {
+		return "", fmt.Errorf("%s and %s would both be generated as %s", used, t, object.Name())
+	}
+	if marshaler := types.NewMethodSet(types.NewPointer(t)).Lookup(nil, "MarshalJSON"); marshaler != nil {
+		return "", fmt.Errorf("%s has its own JSON encoding", t)
+	}
+	g.used[object.Name()] = t
+	return object.Name(), nil
+}
+
+// typesFile generates the declarations of every used type, in alphabetical order
+func (g *typeGenerator) typesFile() ([]byte, error) {
+	declarations := map[string]string{}
+	for {
+		var pending []string
+		for name := range g.used {
+			if _, done := declarations[name]; !done {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		for _, name := range pending {
+			declaration, err := g.declaration(g.used[name])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", g.used[name], err)
+			}
+			declarations[name] = declaration
+		}
+	}
+
+	names := make([]string, 0, len(declarations))
+	for name := range declarations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString(generatedHeader + "package client\n\n")
+	writeImports(&b, g.imports)
+	for _, name := range names {
+		b.WriteString(declarations[name])
+	}
+	return b.Bytes(), nil
+}
+
+// declaration generates a named type with its documentation, and the constants of an enumeration
+func (g *typeGenerator) declaration(t *types.Named) (string, error) {
+	var b bytes.Buffer
+	object := t.Obj()
+	writeComment(&b, "", g.typeDocs[g.fset.Position(object.Pos())])
+
+	switch underlying := t.Underlying().(type) {
+	case *types.Struct:
+		typeParams := ""
+		if g.generic[object.Name()] != "" {
+			typeParams = "[T any]"
+		}
+		fmt.Fprintf(&b, "type %s%s struct {\n", object.Name(), typeParams)
+		if err := g.writeFields(&b, object.Name(), underlying); err != nil {
+			return "", err
+		}
+		b.WriteString("}\n\n")
+	case *types.Basic:
+		fmt.Fprintf(&b, "type %s %s\n\n", object.Name(), underlying.Name())
+		g.writeConstants(&b, t)
+	default:
+		definition, err := g.goType(underlying)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "type %s %s\n\n", object.Name(), definition)
+	}
+	return b.String(), nil
+}
+
+// writeFields writes the fields of a struct that appear in its JSON. The fields of embedded structs from
+// outside the module are written in place, as encoding/json promotes them.
+func (g *typeGenerator) writeFields(b *bytes.Buffer, typeName string, s *types.Struct) error {
+	for i := 0; i < s.NumFields(); i++ {
+		field := s.Field(i)
+		tag := reflect.StructTag(s.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if !field.Exported() && !(field.Embedded() && jsonName == "") {
+			continue
+		}
+
+		source := g.fields[g.fset.Position(field.Pos())]
+		if source != nil {
+			writeComment(b, "\t", source.Doc)
+		}
+
+		var declaration string
+		switch {
+		case g.generic[typeName] != "" && g.generic[typeName] == jsonName:
+			declaration = field.Name() + " T"
+		case field.Embedded() && jsonName == "":
+			embedded := field.Type()
+			if pointer, ok := embedded.(*types.Pointer); ok {
+				embedded = pointer.Elem()
+			}
+			named, ok := types.Unalias(embedded).(*types.Named)
+			if !ok || !inModule(named.Obj().Pkg().Path()) {
+				nested, ok := embedded.Underlying().(*types.Struct)
+				if !ok {
+					return fmt.Errorf("cannot embed %s", embedded)
+				}
+				if err := g.writeFields(b, typeName, nested); err != nil {
+					return err
+				}
+				continue
+			}
+			typ, err := g.goType(field.Type())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", field.Name(), err)
+			}
+			declaration = typ
+		default:
+			typ, err := g.goType(field.Type())
+			if err != nil {
+				return fmt.Errorf("field %s: %v", field.Name(), err)
+			}
+			declaration = field.Name() + " " + typ
+		}
+
+		b.WriteString("\t" + declaration)
+		if tag != "" {
+			fmt.Fprintf(b, " `json:%q`", tag)
+		}
+		if source != nil && source.Comment != nil {
+			b.WriteString(" // " + strings.TrimSpace(source.Comment.Text()))
+		}
+		b.WriteString("\n")
+	}
+	return nil
+}
+
+// writeConstants writes the constants of an enumeration declared with its type, in source order
+func (g *typeGenerator) writeConstants(b *bytes.Buffer, t *types.Named) {
+	scope := t.Obj().Pkg().Scope()
+	var constants []*types.Const
+	for _, name := range scope.Names() {
+		if constant, ok := scope.Lookup(name).(*types.Const); ok && constant.Exported() && types.Identical(constant.Type(), t) {
+			constants = append(constants, constant)
+		}
+	}
+	if len(constants) == 0 {
+		return
+	}
+	sort.Slice(constants, func(i, j int) bool { return constants[i].Pos() < constants[j].Pos() })
+
+	b.WriteString("const (\n")
+	for _, constant := range constants {
+		fmt.Fprintf(b, "\t%s %s = %s\n", constant.Name(), t.Obj().Name(), constant.Val().ExactString())
+	}
+	b.WriteString(")\n\n")
+}
+
+func writeComment(b *bytes.Buffer, indent string, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(doc.Text(), "\n"), "\n") {
+		b.WriteString(strings.TrimRight(indent+"// "+line, " ") + "\n")
+	}
+}
+
+func writeImports(b *bytes.Buffer, imports map[string]bool) {
+	if len(imports) == 0 {
+		return
+	}
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	b.WriteString("import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(b, "\t%q\n", path)
+	}
+	b.WriteString(")\n\n")
+}
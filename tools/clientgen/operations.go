@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"hrms-api/config"
+	"hrms-api/routes"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/tools/go/packages"
+)
+
+// operation is an API operation as annotated on its handler
+type operation struct {
+	name        string // Handler name, used as the client method name
+	doc         []string
+	description string
+	method      string
+	path        string
+	params      []param
+	success     *response
+}
+
+// param is an @Param annotation
+type param struct {
+	name        string
+	in          string // path, query, body or formData
+	typ         string
+	required    bool
+	description string
+}
+
+// response is the first @Success annotation of an operation
+type response struct {
+	kind string // object, array or file
+	typ  string
+}
+
+var (
+	paramPattern   = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(true|false)(?:\s+"([^"]*)")?`)
+	successPattern = regexp.MustCompile(`^(\d+)\s+\{(\w+)\}\s+(\S+)`)
+	routerPattern  = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]`)
+)
+
+// parseOperations reads the operations annotated with @Router on the handlers, in source order
+func parseOperations(handlers *packages.Package) ([]operation, error) {
+	var operations []operation
+	names := map[string]bool{}
+	for _, file := range handlers.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil || !strings.Contains(fn.Doc.Text(), "@Router") {
+				continue
+			}
+			op, err := parseOperation(fn)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", handlers.Fset.Position(fn.Pos()), err)
+			}
+			if names[op.name] {
+				return nil, fmt.Errorf("%s: a second handler is named %s", handlers.Fset.Position(fn.Pos()), op.name)
+			}
+			names[op.name] = true
+			operations = append(operations, op)
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool { return operations[i].name < operations[j].name })
+	return operations, nil
+}
+
+func parseOperation(fn *ast.FuncDecl) (operation, error) {
+	op := operation{name: fn.Name.Name}
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			if line != "" {
+				op.doc = append(op.doc, line)
+			}
+			continue
+		}
+		annotation, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+		switch annotation {
+		case "@Description":
+			op.description = value
+		case "@Param":
+			m := paramPattern.FindStringSubmatch(value)
+			if m == nil {
+				return op, fmt.Errorf("cannot parse @Param %s", value)
+			}
+			op.params = append(op.params, param{name: m[1], in: m[2], typ: m[3], required: m[4] == "true", description: m[5]})
+		case "@Success":
+			if op.success != nil {
+				continue
+			}
+			m := successPattern.FindStringSubmatch(value)
+			if m == nil {
+				return op, fmt.Errorf("cannot parse @Success %s", value)
+			}
+			op.success = &response{kind: m[2], typ: m[3]}
+		case "@Router":
+			m := routerPattern.FindStringSubmatch(value)
+			if m == nil {
+				return op, fmt.Errorf("cannot parse @Router %s", value)
+			}
+			op.path, op.method = m[1], strings.ToUpper(m[2])
+		}
+	}
+	return op, nil
+}
+
+// routePlaceholder matches the parameters of annotated ({id}) and routed (:id, *path) paths
+var routePlaceholder = regexp.MustCompile(`\{[^}]+\}|:[^/]+|\*.+`)
+
+// checkRouted fails when an annotated operation is not served by the router, as its annotation is stale
+func checkRouted(operations []operation) error {
+	// Only the routes are needed, not a configuration fit for release
+	os.Setenv("GIN_MODE", gin.DebugMode)
+	if err := config.LoadConfig(); err != nil {
+		return err
+	}
+	gin.SetMode(gin.ReleaseMode)
+	routed := map[string]bool{}
+	for _, route := range routes.SetupRoutes().Routes() {
+		routed[route.Method+" "+routePlaceholder.ReplaceAllString(route.Path, "{}")] = true
+	}
+
+	var missing []string
+	for _, op := range operations {
+		if !routed[op.method+" "+routePlaceholder.ReplaceAllString(op.path, "{}")] {
+			missing = append(missing, fmt.Sprintf("%s (%s %s)", op.name, op.method, op.path))
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintln(os.Stderr, "annotated operations without a route:")
+		for _, m := range missing {
+			fmt.Fprintln(os.Stderr, "  "+m)
+		}
+		return fmt.Errorf("%d stale @Router annotations", len(missing))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"hrms-api/models"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// biweeklyPeriodsPerYear is the number of two-week accrual periods the year's entitlement is spread over
+const biweeklyPeriodsPerYear = 26
+
+// AccrualPeriod is a period of service leave accrues for. Its accrual is recorded on its first day,
+// and leave taken during it is counted against that accrual.
+type AccrualPeriod struct {
+	Start time.Time
+	End   time.Time
+	Days  float64 // Days accrued for the period
+}
+
+// AccrualFrequencyOf returns a leave type's accrual frequency, monthly when it has none
+func AccrualFrequencyOf(leaveType models.LeaveType) models.AccrualFrequency {
+	if leaveType.AccrualFrequency == "" {
+		return models.AccrualMonthly
+	}
+	return leaveType.AccrualFrequency
+}
+
+// ValidAccrualFrequency reports whether a frequency is one leave types can accrue at
+func ValidAccrualFrequency(frequency models.AccrualFrequency) bool {
+	switch frequency {
+	case models.AccrualMonthly, models.AccrualBiweekly, models.AccrualAnnual:
+		return true
+	}
+	return false
+}
+
+// AccrualPeriods returns the accrual periods of an employee who started on a date, up to the last
+// one starting on or before a date, with their days rounded by the leave rounding settings. The
+// annual leave entitlement is spread over them:
+//   - monthly: a month's accrual on the first of each month, from the month after the start date
+//   - biweekly: 1/26 of the entitlement every 14 days, from two weeks after the start date
+//   - annual: the whole entitlement on the first day of each leave year. An employee starting
+//     during a leave year is granted, on their start date, the months of it left after their
+//     start month.
+func AccrualPeriods(frequency models.AccrualFrequency, started, through time.Time) []AccrualPeriod {
+	started = time.Date(started.Year(), started.Month(), started.Day(), 0, 0, 0, 0, time.UTC)
+	through = time.Date(through.Year(), through.Month(), through.Day(), 0, 0, 0, 0, time.UTC)
+	if started.After(through) {
+		return nil
+	}
+
+	var periods []AccrualPeriod
+	switch frequency {
+	case models.AccrualBiweekly:
+		for start := started.AddDate(0, 0, 14); !start.After(through); start = start.AddDate(0, 0, 14) {
+			periods = append(periods, AccrualPeriod{
+				Start: start,
+				End:   start.AddDate(0, 0, 13),
+				Days:  AnnualLeaveDaysPerYear() / biweeklyPeriodsPerYear,
+			})
+		}
+	case models.AccrualAnnual:
+		year := LeaveYearOf(started)
+		if !started.Equal(LeaveYearStart(year)) {
+			end := LeaveYearEnd(year)
+			months := (end.Year()-started.Year())*12 + int(end.Month()) - int(started.Month())
+			periods = append(periods, AccrualPeriod{
+				Start: started,
+				End:   end,
+				Days:  float64(months) * AnnualLeaveDaysPerMonth(),
+			})
+			year++
+		}
+		for ; !LeaveYearStart(year).After(through); year++ {
+			periods = append(periods, AccrualPeriod{Start: LeaveYearStart(year), End: LeaveYearEnd(year), Days: AnnualLeaveDaysPerYear()})
+		}
+	default:
+		month := time.Date(started.Year(), started.Month(), 1, 0, 0, 0, 0, time.UTC)
+		for month = month.AddDate(0, 1, 0); !month.After(through); month = month.AddDate(0, 1, 0) {
+			periods = append(periods, AccrualPeriod{Start: month, End: month.AddDate(0, 1, -1), Days: AnnualLeaveDaysPerMonth()})
+		}
+	}
+
+	// Accruals are rounded as they add up rather than one by one, so rounding never drifts from the
+	// entitlement: 1/26 of 24 days in half days accrues 1, 1, 1, 0.5, 1, ...
+	var accrued, rounded float64
+	for i := range periods {
+		accrued += periods[i].Days
+		total := RoundLeaveDays(accrued)
+		periods[i].Days = math.Round((total-rounded)*100) / 100
+		rounded = total
+	}
+	return periods
+}
+
+// accrualStartDate returns the date an employee accrues leave from: their hire date, else their
+// start date, else when their account was created
+func accrualStartDate(db *gorm.DB, employeeID uint) time.Time {
+	var employment models.EmploymentDetails
+	startDate := CompanyNow()
+	if err := db.Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
+		if employment.HireDate != nil {
+			startDate = *employment.HireDate
+		} else if employment.StartDate != nil {
+			startDate = *employment.StartDate
+		}
+	} else {
+		// If no employment details, try to get from employee created_at
+		var employee models.Employee
+		if err := db.First(&employee, employeeID).Error; err == nil {
+			startDate = employee.CreatedAt
+		}
+	}
+	return startDate
+}
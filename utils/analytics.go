@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"hrms-api/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkforceMember is an employee's period of employment as used for headcount and turnover analytics
+type WorkforceMember struct {
+	EmployeeID     uint
+	Department     string
+	EmploymentType models.EmploymentType // Current one, empty when the employment details give none
+	StartDate      time.Time
+	LeaveDate      *time.Time
+	Voluntary      bool // Resigned or retired rather than terminated
+}
+
+// WorkforceMonth is headcount movement for one month
+type WorkforceMonth struct {
+	Month              string  `json:"month" example:"2025-03"`
+	OpeningHeadcount   int     `json:"opening_headcount" example:"82"`
+	Joiners            int     `json:"joiners" example:"3"`
+	Leavers            int     `json:"leavers" example:"2"`
+	Headcount          int     `json:"headcount" example:"83"`             // At month end
+	TurnoverRate       float64 `json:"turnover_rate" example:"2.4"`        // Leavers as a percentage of average headcount
+	AverageTenureYears float64 `json:"average_tenure_years" example:"4.2"` // Of employees in post at month end
+}
+
+// DepartmentWorkforce is the monthly headcount movement for one department
+type DepartmentWorkforce struct {
+	Department string           `json:"department" example:"Finance"`
+	Months     []WorkforceMonth `json:"months"`
+}
+
+// leavingEvents are the lifecycle events that end employment, and whether leaving was voluntary
+var leavingEvents = map[models.LifecycleEventType]bool{
+	models.LifecycleEventResigned:   true,
+	models.LifecycleEventRetired:    true,
+	models.LifecycleEventTerminated: false,
+	models.LifecycleEventOffboarded: true,
+}
+
+// LoadWorkforce builds employment periods for every non-admin employee, including those who have left.
+// Start dates come from the hire or start date, falling back to the date joined or record creation.
+// Leave dates come from the termination or end date, then the latest leaving lifecycle event, then
+// deletion of the employee record.
+func LoadWorkforce(db *gorm.DB, department string) ([]WorkforceMember, error) {
+	query := db.Unscoped().Where("role != ?", models.RoleAdmin)
+	if department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var employees []models.Employee
+	if err := query.Find(&employees).Error; err != nil {
+		return nil, err
+	}
+
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	var details []models.EmploymentDetails
+	db.Where("employee_id IN ?", employeeIDs).Find(&details)
+	detailsByEmployee := map[uint]models.EmploymentDetails{}
+	for _, d := range details {
+		detailsByEmployee[d.EmployeeID] = d
+	}
+
+	eventTypes := make([]models.LifecycleEventType, 0, len(leavingEvents))
+	for eventType := range leavingEvents {
+		eventTypes = append(eventTypes, eventType)
+	}
+	var events []models.WorkLifecycleEvent
+	db.Where("employee_id IN ? AND event_type IN ?", employeeIDs, eventTypes).Order("event_date").Find(&events)
+	leavingByEmployee := map[uint]models.WorkLifecycleEvent{}
+	for _, event := range events {
+		leavingByEmployee[event.EmployeeID] = event
+	}
+
+	members := make([]WorkforceMember, 0, len(employees))
+	for _, employee := range employees {
+		member := WorkforceMember{EmployeeID: employee.ID, Department: employee.Department, StartDate: employee.CreatedAt}
+		if employee.DateJoined != nil {
+			member.StartDate = *employee.DateJoined
+		}
+
+		d, hasDetails := detailsByEmployee[employee.ID]
+		if hasDetails {
+			member.EmploymentType = d.EmploymentType
+			if d.HireDate != nil {
+				member.StartDate = *d.HireDate
+			} else if d.StartDate != nil {
+				member.StartDate = *d.StartDate
+			}
+		}
+
+		event, hasEvent := leavingByEmployee[employee.ID]
+		switch {
+		case hasDetails && d.TerminationDate != nil:
+			member.LeaveDate = d.TerminationDate
+		case hasDetails && d.EndDate != nil && (d.EmploymentStatus == models.EmploymentStatusResigned || d.EmploymentStatus == models.EmploymentStatusTerminated):
+			member.LeaveDate = d.EndDate
+		case hasEvent:
+			leaveDate := event.EventDate
+			if event.EffectiveDate != nil {
+				leaveDate = *event.EffectiveDate
+			}
+			member.LeaveDate = &leaveDate
+		case employee.DeletedAt.Valid:
+			leaveDate := employee.DeletedAt.Time
+			member.LeaveDate = &leaveDate
+		}
+
+		if member.LeaveDate != nil {
+			member.Voluntary = true
+			if hasDetails && d.EmploymentStatus == models.EmploymentStatusTerminated {
+				member.Voluntary = false
+			} else if hasEvent {
+				member.Voluntary = leavingEvents[event.EventType]
+			}
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// ComputeWorkforceMonths works out headcount, joiners, leavers, turnover and tenure for each month from
+// the month of from to the month of to. Employees leave at the end of their leave date.
+func ComputeWorkforceMonths(members []WorkforceMember, from, to time.Time) []WorkforceMonth {
+	var months []WorkforceMonth
+	for monthStart := monthOf(from); !monthStart.After(to); monthStart = monthStart.AddDate(0, 1, 0) {
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		month := WorkforceMonth{Month: monthStart.Format("2006-01")}
+
+		var tenureYears float64
+		for _, member := range members {
+			start := dateOf(member.StartDate)
+			if inPost(member, monthStart.AddDate(0, 0, -1)) {
+				month.OpeningHeadcount++
+			}
+			if !start.Before(monthStart) && !start.After(monthEnd) {
+				month.Joiners++
+			}
+			if member.LeaveDate != nil {
+				leave := dateOf(*member.LeaveDate)
+				if !leave.Before(monthStart) && !leave.After(monthEnd) && !leave.Before(start) {
+					month.Leavers++
+				}
+			}
+			if inPost(member, monthEnd) {
+				month.Headcount++
+				tenureYears += monthEnd.Sub(start).Hours() / 24 / 365.25
+			}
+		}
+
+		if average := float64(month.OpeningHeadcount+month.Headcount) / 2; average > 0 {
+			month.TurnoverRate = float64(month.Leavers) / average * 100
+		}
+		if month.Headcount > 0 {
+			month.AverageTenureYears = tenureYears / float64(month.Headcount)
+		}
+		months = append(months, month)
+	}
+	return months
+}
+
+// ComputeDepartmentWorkforce runs ComputeWorkforceMonths for each department, ordered by department name
+func ComputeDepartmentWorkforce(members []WorkforceMember, from, to time.Time) []DepartmentWorkforce {
+	byDepartment := map[string][]WorkforceMember{}
+	for _, member := range members {
+		byDepartment[member.Department] = append(byDepartment[member.Department], member)
+	}
+
+	departments := make([]DepartmentWorkforce, 0, len(byDepartment))
+	for department, deptMembers := range byDepartment {
+		departments = append(departments, DepartmentWorkforce{
+			Department: department,
+			Months:     ComputeWorkforceMonths(deptMembers, from, to),
+		})
+	}
+	sort.Slice(departments, func(i, j int) bool {
+		return departments[i].Department < departments[j].Department
+	})
+	return departments
+}
+
+// inPost reports whether the member was employed at the end of the given day
+func inPost(member WorkforceMember, day time.Time) bool {
+	if dateOf(member.StartDate).After(day) {
+		return false
+	}
+	return member.LeaveDate == nil || dateOf(*member.LeaveDate).After(day)
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// TurnoverSummary totals leavers and turnover over a whole period
+type TurnoverSummary struct {
+	Leavers                  int     `json:"leavers" example:"9"`
+	VoluntaryLeavers         int     `json:"voluntary_leavers" example:"7"`
+	InvoluntaryLeavers       int     `json:"involuntary_leavers" example:"2"`
+	AverageHeadcount         float64 `json:"average_headcount" example:"81.5"`
+	TurnoverRate             float64 `json:"turnover_rate" example:"11.0"`            // Leavers as a percentage of average headcount over the period
+	AnnualizedTurnoverRate   float64 `json:"annualized_turnover_rate" example:"11.0"` // Turnover rate scaled to twelve months
+	AverageLeaverTenureYears float64 `json:"average_leaver_tenure_years" example:"2.8"`
+}
+
+// SummarizeTurnover totals turnover from the month of from to the month of to
+func SummarizeTurnover(members []WorkforceMember, from, to time.Time) TurnoverSummary {
+	var summary TurnoverSummary
+	months := ComputeWorkforceMonths(members, from, to)
+	if len(months) == 0 {
+		return summary
+	}
+
+	var headcount float64
+	for _, month := range months {
+		headcount += float64(month.OpeningHeadcount+month.Headcount) / 2
+	}
+	summary.AverageHeadcount = headcount / float64(len(months))
+
+	periodStart := monthOf(from)
+	periodEnd := periodStart.AddDate(0, len(months), -1)
+	var tenureYears float64
+	for _, member := range members {
+		if member.LeaveDate == nil {
+			continue
+		}
+		start, leave := dateOf(member.StartDate), dateOf(*member.LeaveDate)
+		if leave.Before(periodStart) || leave.After(periodEnd) || leave.Before(start) {
+			continue
+		}
+		summary.Leavers++
+		if member.Voluntary {
+			summary.VoluntaryLeavers++
+		} else {
+			summary.InvoluntaryLeavers++
+		}
+		tenureYears += leave.Sub(start).Hours() / 24 / 365.25
+	}
+
+	if summary.AverageHeadcount > 0 {
+		summary.TurnoverRate = float64(summary.Leavers) / summary.AverageHeadcount * 100
+		summary.AnnualizedTurnoverRate = summary.TurnoverRate * 12 / float64(len(months))
+	}
+	if summary.Leavers > 0 {
+		summary.AverageLeaverTenureYears = tenureYears / float64(summary.Leavers)
+	}
+	return summary
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"hrms-api/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AnnualLeaveBalancesForExport gathers the annual leave balances of the employees other than admins,
+// optionally only those in department or with employment status, ready to export. Detailed exports
+// also get each employee's carry-over balance, accrual ledger and approved leaves, written in the
+// locale. It returns gorm.ErrRecordNotFound when there is no annual leave type.
+func AnnualLeaveBalancesForExport(db *gorm.DB, department, status string, detailed bool, locale ExportLocale) ([]AnnualLeaveBalanceExport, error) {
+	var annualLeaveType models.LeaveType
+	if err := db.Where("name = ? OR max_days = ?", "Annual", 24).First(&annualLeaveType).Error; err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.Employee{}).Where("role != ?", models.RoleAdmin)
+	if department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var employees []models.Employee
+	if err := query.Find(&employees).Error; err != nil {
+		return nil, err
+	}
+
+	today := CompanyToday()
+	leaveYear := LeaveYearOf(today)
+	balances := make([]EmployeeBalanceData, 0, len(employees))
+	for _, emp := range employees {
+		var employment models.EmploymentDetails
+		hasEmployment := db.Where("employee_id = ?", emp.ID).First(&employment).Error == nil
+
+		// Employees without employment details count as active
+		if status != "" {
+			if (hasEmployment && string(employment.EmploymentStatus) != status) || (!hasEmployment && status != "active") {
+				continue
+			}
+		}
+
+		EnsureAccrualsUpToDate(db, emp.ID, annualLeaveType.ID)
+
+		var accruals []models.LeaveAccrual
+		if err := db.Where("employee_id = ? AND leave_type_id = ?", emp.ID, annualLeaveType.ID).
+			Order(AccrualMonthSQL() + " ASC, year ASC, month ASC").Find(&accruals).Error; err != nil {
+			return nil, err
+		}
+
+		// Regular accruals in the first month of employment are not counted, initial balances are
+		employeeStartDate := emp.CreatedAt
+		if hasEmployment && employment.HireDate != nil {
+			employeeStartDate = *employment.HireDate
+		} else if hasEmployment && employment.StartDate != nil {
+			employeeStartDate = *employment.StartDate
+		}
+		firstMonthStart := time.Date(employeeStartDate.Year(), employeeStartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+		var totalAccrued float64
+		var ledger []AccrualExport
+		for _, acc := range accruals {
+			var accrualMonth time.Time
+			if acc.AccrualMonth != nil {
+				accrualMonth = *acc.AccrualMonth
+			} else if acc.Year > 0 && acc.Month > 0 {
+				accrualMonth = time.Date(acc.Year, time.Month(acc.Month), 1, 0, 0, 0, 0, time.UTC)
+			}
+			isInitialBalance := acc.Notes != nil && (strings.Contains(*acc.Notes, "Initial balance") ||
+				strings.Contains(*acc.Notes, "set-initial") || strings.Contains(*acc.Notes, "Set initial"))
+			if !accrualMonth.IsZero() && accrualMonth.Equal(firstMonthStart) && !isInitialBalance {
+				continue
+			}
+			totalAccrued += acc.DaysAccrued
+
+			if detailed {
+				month := acc.GetAccrualMonthKey()
+				if !accrualMonth.IsZero() {
+					month = locale.Month(accrualMonth)
+				}
+				processedAt := ""
+				if acc.ProcessedAt != nil {
+					processedAt = locale.DateTime(*acc.ProcessedAt)
+				}
+				ledger = append(ledger, AccrualExport{
+					Month:       month,
+					DaysAccrued: acc.DaysAccrued,
+					DaysUsed:    acc.DaysUsed,
+					DaysBalance: acc.DaysBalance,
+					IsProcessed: acc.IsProcessed,
+					ProcessedAt: processedAt,
+				})
+			}
+		}
+
+		// Days used come from approved leaves, which are the source of truth
+		var totalUsed float64
+		var approvedLeaves []models.Leave
+		if err := db.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+			emp.ID, annualLeaveType.ID, models.StatusApproved).Order("start_date DESC").Find(&approvedLeaves).Error; err != nil {
+			return nil, err
+		}
+		var leaves []LeaveExport
+		for _, leave := range approvedLeaves {
+			totalUsed += float64(leave.GetDuration())
+			if detailed {
+				leaves = append(leaves, LeaveExport{
+					StartDate: locale.Date(leave.StartDate),
+					EndDate:   locale.Date(leave.EndDate),
+					Duration:  float64(leave.GetDuration()),
+					Reason:    leave.Reason,
+				})
+			}
+		}
+
+		var carryOverBalance float64
+		if detailed && annualLeaveType.AllowCarryOver {
+			carryOverBalance, _ = GetCarryOverBalance(db, emp.ID, annualLeaveType.ID)
+		}
+
+		currentBalance, _ := GetCurrentLeaveBalance(db, emp.ID, annualLeaveType.ID)
+		leaveYearAccrued, leaveYearUsed, err := LeaveYearTotals(db, emp.ID, annualLeaveType.ID, leaveYear)
+		if err != nil {
+			return nil, err
+		}
+
+		var pendingLeaves, upcomingLeaves int64
+		db.Model(&models.Leave{}).
+			Where("employee_id = ? AND leave_type_id = ? AND status = ?", emp.ID, annualLeaveType.ID, models.StatusPending).
+			Count(&pendingLeaves)
+		db.Model(&models.Leave{}).
+			Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date > ?",
+				emp.ID, annualLeaveType.ID, models.StatusApproved, today).
+			Count(&upcomingLeaves)
+
+		balances = append(balances, EmployeeBalanceData{
+			EmployeeID:       emp.ID,
+			EmployeeName:     emp.Firstname + " " + emp.Lastname,
+			Department:       emp.Department,
+			TotalAccrued:     totalAccrued,
+			TotalUsed:        totalUsed,
+			CurrentBalance:   currentBalance,
+			PendingLeaves:    int(pendingLeaves),
+			UpcomingLeaves:   int(upcomingLeaves),
+			LeaveYearAccrued: leaveYearAccrued,
+			LeaveYearUsed:    leaveYearUsed,
+			CarryOverBalance: carryOverBalance,
+			Accruals:         ledger,
+			ApprovedLeaves:   leaves,
+		})
+	}
+
+	return PrepareBalancesForExport(balances), nil
+}
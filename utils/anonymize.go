@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/models"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFormerEmployee is returned when anonymizing someone who still works for the organization
+var ErrNotFormerEmployee = errors.New("only former employees can be anonymized")
+
+// ErrAlreadyAnonymized is returned when the employee's personal data has already been scrubbed
+var ErrAlreadyAnonymized = errors.New("employee has already been anonymized")
+
+// anonymizedPasswordHash is not a valid bcrypt hash, so no password ever matches it
+const anonymizedPasswordHash = "!"
+
+// AnonymizationSummary counts what anonymizing an employee scrubs
+type AnonymizationSummary struct {
+	IdentityRecords   int64 `json:"identity_records"`
+	BankDetails       int64 `json:"bank_details"`
+	EducationRecords  int64 `json:"education_records"`
+	Documents         int64 `json:"documents"`
+	LeaveForms        int64 `json:"leave_forms"`
+	LeaveReasons      int64 `json:"leave_reasons"`
+	EmploymentLetters int64 `json:"employment_letters"`
+	AuditEntries      int64 `json:"audit_entries"`
+
+	// Files are the document and leave form files to delete once the transaction commits
+	Files []string `json:"-"`
+}
+
+// IsFormerEmployee reports whether the employee has left: deleted, deactivated, or terminated or
+// resigned in their employment details
+func IsFormerEmployee(db *gorm.DB, employee *models.Employee) (bool, error) {
+	if employee.DeletedAt.Valid || employee.Status == "inactive" {
+		return true, nil
+	}
+	var count int64
+	err := db.Model(&models.EmploymentDetails{}).
+		Where("employee_id = ? AND employment_status IN ?", employee.ID,
+			[]models.EmploymentStatus{models.EmploymentStatusTerminated, models.EmploymentStatusResigned}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// AnonymizeEmployee irreversibly scrubs a former employee's personal data through tx: their name,
+// identifiers, contact, emergency and bank details on the employee record, their identity, bank and
+// education records, their documents and leave forms, leave reasons, the name and salary on their
+// employment letters, which are revoked, the values recorded in the audit trail of those records, and
+// the identifiers and addresses in their login log. Leaves, employment details, positions and
+// lifecycle events are kept, so leave and headcount statistics are unchanged. Files are only listed
+// in the summary; delete them with DeleteAnonymizedFiles once tx commits.
+func AnonymizeEmployee(tx *gorm.DB, employee *models.Employee) (AnonymizationSummary, error) {
+	var summary AnonymizationSummary
+	if employee.AnonymizedAt != nil {
+		return summary, ErrAlreadyAnonymized
+	}
+	former, err := IsFormerEmployee(tx, employee)
+	if err != nil {
+		return summary, err
+	}
+	if !former {
+		return summary, ErrNotFormerEmployee
+	}
+
+	now := time.Now()
+	if err := tx.Unscoped().Model(&models.Employee{}).Where("id = ?", employee.ID).Updates(map[string]interface{}{
+		"firstname": "Anonymized", "lastname": fmt.Sprintf("Employee %d", employee.ID),
+		"employee_number": nil, "nrc": nil, "username": nil, "email": nil, "password_hash": anonymizedPasswordHash,
+		"status": "inactive", "timezone": "", "phone": nil, "mobile": nil, "address": nil, "city": nil,
+		"postal_code": nil, "date_of_birth": nil, "gender": nil, "emergency_contact_name": nil,
+		"emergency_contact_phone": nil, "emergency_contact_relationship": nil, "bank_name": nil,
+		"bank_account_number": nil, "tax_id": nil, "notes": nil, "anonymized_at": now,
+	}).Error; err != nil {
+		return summary, err
+	}
+	if err := tx.Unscoped().Model(&models.EmploymentDetails{}).Where("employee_id = ?", employee.ID).
+		Updates(map[string]interface{}{"employee_number": nil, "termination_reason": nil}).Error; err != nil {
+		return summary, err
+	}
+
+	// Records that are nothing but personal data are deleted outright, remembering their IDs to scrub
+	// their audit trail
+	scrubbed := map[models.AuditEntityType][]uint{models.AuditEntityEmployee: {employee.ID}}
+	for _, record := range []struct {
+		entity models.AuditEntityType
+		model  interface{}
+		count  *int64
+	}{
+		{models.AuditEntityIdentity, &models.IdentityInformation{}, &summary.IdentityRecords},
+		{models.AuditEntityBankDetails, &models.BankDetails{}, &summary.BankDetails},
+		{models.AuditEntityEducation, &models.Education{}, &summary.EducationRecords},
+		{models.AuditEntityDocument, &models.Document{}, &summary.Documents},
+	} {
+		var ids []uint
+		if err := tx.Unscoped().Model(record.model).Where("employee_id = ?", employee.ID).Pluck("id", &ids).Error; err != nil {
+			return summary, err
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if record.entity == models.AuditEntityDocument {
+			var paths []string
+			if err := tx.Unscoped().Model(&models.Document{}).Where("id IN ?", ids).Pluck("file_path", &paths).Error; err != nil {
+				return summary, err
+			}
+			summary.Files = append(summary.Files, paths...)
+		}
+		if err := tx.Unscoped().Where("id IN ?", ids).Delete(record.model).Error; err != nil {
+			return summary, err
+		}
+		*record.count = int64(len(ids))
+		scrubbed[record.entity] = ids
+	}
+
+	// Leaves stay for the statistics, without their free-text reasons or attached forms
+	var forms []string
+	if err := tx.Unscoped().Model(&models.Leave{}).Where("employee_id = ? AND form_file_path IS NOT NULL", employee.ID).
+		Pluck("form_file_path", &forms).Error; err != nil {
+		return summary, err
+	}
+	summary.Files = append(summary.Files, forms...)
+	summary.LeaveForms = int64(len(forms))
+	result := tx.Unscoped().Model(&models.Leave{}).Where("employee_id = ? AND (reason <> '' OR form_file_path IS NOT NULL)", employee.ID).
+		Updates(map[string]interface{}{
+			"reason": "", "form_file_name": nil, "form_file_path": nil, "form_file_size": nil, "form_mime_type": nil,
+		})
+	if result.Error != nil {
+		return summary, result.Error
+	}
+	summary.LeaveReasons = result.RowsAffected
+
+	// Employment letters can be verified publicly by their code, so they are revoked and no longer
+	// name the employee or state their salary
+	result = tx.Model(&models.EmploymentLetter{}).Where("employee_id = ?", employee.ID).
+		Updates(map[string]interface{}{
+			"employee_name": fmt.Sprintf("Anonymized Employee %d", employee.ID), "salary": nil,
+			"revoked_at": gorm.Expr("COALESCE(revoked_at, ?)", now),
+		})
+	if result.Error != nil {
+		return summary, result.Error
+	}
+	summary.EmploymentLetters = result.RowsAffected
+
+	// The audit trail keeps who did what and when, but not the personal values that were recorded
+	for entity, ids := range scrubbed {
+		result := tx.Model(&models.AuditLog{}).Where("entity_type = ? AND entity_id IN ?", entity, ids).
+			Updates(map[string]interface{}{"old_values": nil, "new_values": nil, "changes": nil})
+		if result.Error != nil {
+			return summary, result.Error
+		}
+		summary.AuditEntries += result.RowsAffected
+	}
+	if err := tx.Model(&models.AuditLog{}).Where("performed_by = ?", employee.ID).
+		Updates(map[string]interface{}{"ip_address": nil, "user_agent": nil}).Error; err != nil {
+		return summary, err
+	}
+	if err := tx.Model(&models.LoginLog{}).Where("employee_id = ?", employee.ID).
+		Updates(map[string]interface{}{"identifier": "", "ip_address": nil, "user_agent": nil}).Error; err != nil {
+		return summary, err
+	}
+	if err := tx.Model(&models.FileAccessLog{}).Where("user_id = ?", employee.ID).
+		Updates(map[string]interface{}{"ip_address": nil, "user_agent": nil}).Error; err != nil {
+		return summary, err
+	}
+	// Linked chat accounts would still name the employee's Slack or Teams user
+	for _, model := range []interface{}{&models.ChatAccount{}, &models.ChatLinkCode{}} {
+		if err := tx.Where("employee_id = ?", employee.ID).Delete(model).Error; err != nil {
+			return summary, err
+		}
+	}
+	// So do connected calendars, and their tokens give access to the employee's account
+	connections := tx.Model(&models.CalendarConnection{}).Select("id").Where("employee_id = ?", employee.ID)
+	if err := tx.Where("connection_id IN (?)", connections).Delete(&models.CalendarEvent{}).Error; err != nil {
+		return summary, err
+	}
+	if err := tx.Where("employee_id = ?", employee.ID).Delete(&models.CalendarConnection{}).Error; err != nil {
+		return summary, err
+	}
+	// Registered devices would still receive push notifications meant for the employee
+	if err := tx.Where("employee_id = ?", employee.ID).Delete(&models.DeviceToken{}).Error; err != nil {
+		return summary, err
+	}
+
+	employee.AnonymizedAt = &now
+	return summary, nil
+}
+
+// DeleteAnonymizedFiles deletes the files listed by AnonymizeEmployee and the employee's document and
+// leave form directories, returning the first error after attempting every file
+func DeleteAnonymizedFiles(employeeID uint, files []string) error {
+	var firstErr error
+	for _, file := range files {
+		if err := DeleteFile(file); err != nil && !os.IsNotExist(err) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	employeeDir := fmt.Sprintf("employee_%d", employeeID)
+	for _, dir := range []string{employeeDir, filepath.Join("leave_forms", employeeDir)} {
+		if err := os.RemoveAll(filepath.Join(config.AppConfig.DocumentsPath, dir)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
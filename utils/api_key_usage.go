@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"errors"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors returned for calls over an API key's limits
+var (
+	ErrAPIKeyRateLimited   = errors.New("API key rate limit exceeded, retry next minute")
+	ErrAPIKeyQuotaExceeded = errors.New("API key daily quota exceeded")
+)
+
+// apiKeyUsageKey identifies a row of api_key_usages
+type apiKeyUsageKey struct {
+	keyName string
+	method  string
+	date    time.Time
+}
+
+type apiKeyCounts struct {
+	calls    int64
+	rejected int64
+}
+
+// apiKeyUsage counts API key calls in memory until FlushAPIKeyUsage writes them to the database. The
+// per-minute rate is counted by each server on its own; the daily quota counts every server's calls
+// as of the last flush, plus this server's since.
+var apiKeyUsage = struct {
+	sync.Mutex
+	pending map[apiKeyUsageKey]*apiKeyCounts
+
+	minute     time.Time      // Start of the current rate window
+	thisMinute map[string]int // Calls accepted in the window, by key name
+
+	today time.Time        // Company calendar date the daily counts are for
+	daily map[string]int64 // Calls accepted today, by key name
+}{
+	pending:    map[apiKeyUsageKey]*apiKeyCounts{},
+	thisMinute: map[string]int{},
+	daily:      map[string]int64{},
+}
+
+// AllowAPIKeyCall counts a call made with an API key to a method, returning ErrAPIKeyRateLimited or
+// ErrAPIKeyQuotaExceeded when the key is over its limits. Refused calls are counted as rejected and do
+// not use up the quota.
+func AllowAPIKeyCall(key config.APIKey, method string) error {
+	now := time.Now()
+	today := CompanyToday()
+
+	apiKeyUsage.Lock()
+	defer apiKeyUsage.Unlock()
+
+	if minute := now.Truncate(time.Minute); !minute.Equal(apiKeyUsage.minute) {
+		apiKeyUsage.minute = minute
+		apiKeyUsage.thisMinute = map[string]int{}
+	}
+	if !today.Equal(apiKeyUsage.today) {
+		apiKeyUsage.today = today
+		apiKeyUsage.daily = map[string]int64{}
+	}
+
+	usageKey := apiKeyUsageKey{keyName: key.Name, method: method, date: today}
+	counts := apiKeyUsage.pending[usageKey]
+	if counts == nil {
+		counts = &apiKeyCounts{}
+		apiKeyUsage.pending[usageKey] = counts
+	}
+
+	var err error
+	switch {
+	case key.PerMinute > 0 && apiKeyUsage.thisMinute[key.Name] >= key.PerMinute:
+		err = ErrAPIKeyRateLimited
+	case key.PerDay > 0 && apiKeyUsage.daily[key.Name] >= int64(key.PerDay):
+		err = ErrAPIKeyQuotaExceeded
+	}
+	if err != nil {
+		counts.rejected++
+		return err
+	}
+
+	counts.calls++
+	apiKeyUsage.thisMinute[key.Name]++
+	apiKeyUsage.daily[key.Name]++
+	return nil
+}
+
+// FlushAPIKeyUsage adds the calls counted since the last flush to api_key_usages, then reloads today's
+// totals so daily quotas take the calls made through other servers into account
+func FlushAPIKeyUsage() error {
+	apiKeyUsage.Lock()
+	pending := apiKeyUsage.pending
+	apiKeyUsage.pending = map[apiKeyUsageKey]*apiKeyCounts{}
+	apiKeyUsage.Unlock()
+
+	rows := make([]models.APIKeyUsage, 0, len(pending))
+	for key, counts := range pending {
+		rows = append(rows, models.APIKeyUsage{
+			KeyName: key.keyName, Method: key.method, Date: key.date, Calls: counts.calls, Rejected: counts.rejected,
+		})
+	}
+	if len(rows) > 0 {
+		err := database.DB.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key_name"}, {Name: "method"}, {Name: "date"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"calls":      gorm.Expr("api_key_usages.calls + " + database.ExcludedSQL("calls")),
+				"rejected":   gorm.Expr("api_key_usages.rejected + " + database.ExcludedSQL("rejected")),
+				"updated_at": gorm.Expr(database.ExcludedSQL("updated_at")),
+			}),
+		}).Create(&rows).Error
+		if err != nil {
+			// Keep the counts for the next flush rather than losing them
+			apiKeyUsage.Lock()
+			for key, counts := range pending {
+				if current := apiKeyUsage.pending[key]; current != nil {
+					current.calls += counts.calls
+					current.rejected += counts.rejected
+				} else {
+					apiKeyUsage.pending[key] = counts
+				}
+			}
+			apiKeyUsage.Unlock()
+			return err
+		}
+	}
+
+	today := CompanyToday()
+	var totals []struct {
+		KeyName string
+		Calls   int64
+	}
+	if err := database.DB.Model(&models.APIKeyUsage{}).Select("key_name, SUM(calls) AS calls").
+		Where("date = ?", today).Group("key_name").Scan(&totals).Error; err != nil {
+		return err
+	}
+
+	apiKeyUsage.Lock()
+	defer apiKeyUsage.Unlock()
+	apiKeyUsage.today = today
+	daily := map[string]int64{}
+	for _, total := range totals {
+		daily[total.KeyName] = total.Calls
+	}
+	// Calls counted since the rows were written are not in the totals yet
+	for key, counts := range apiKeyUsage.pending {
+		if key.date.Equal(today) {
+			daily[key.keyName] += counts.calls
+		}
+	}
+	apiKeyUsage.daily = daily
+	return nil
+}
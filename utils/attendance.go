@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fallbackWorkSchedule is used when no schedule has been configured at all
+var fallbackWorkSchedule = models.WorkSchedule{Name: "Standard", StartTime: "08:00", EndTime: "17:00", GraceMinutes: 15, WorkDays: "1,2,3,4,5"}
+
+// AttendanceAbsenceResult summarises a run of the absence marking job
+type AttendanceAbsenceResult struct {
+	Date    string   `json:"date" example:"2025-03-14"`
+	Absent  int      `json:"absent" example:"2"`
+	OnLeave int      `json:"on_leave" example:"3"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// AttendanceSummary totals an employee's attendance over a period
+type AttendanceSummary struct {
+	EmployeeID   uint    `json:"employee_id" example:"12"`
+	EmployeeName string  `json:"employee_name" example:"Jane Doe"`
+	Department   string  `json:"department" example:"Finance"`
+	Present      int     `json:"present" example:"18"`
+	Late         int     `json:"late" example:"2"`
+	Absent       int     `json:"absent" example:"1"`
+	OnLeave      int     `json:"on_leave" example:"1"`
+	MinutesLate  int     `json:"minutes_late" example:"35"`
+	WorkedHours  float64 `json:"worked_hours" example:"171.5"`
+	Corrections  int     `json:"corrections" example:"1"`
+}
+
+// ResolveWorkSchedule returns the work schedule that applies to an employee on the given day.
+// A rostered shift takes precedence over the employee's regular schedule.
+func ResolveWorkSchedule(employeeID uint, day time.Time) models.WorkSchedule {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	if assignment := ShiftAssignmentOn(employeeID, day); assignment != nil {
+		return shiftWorkSchedule(assignment.Shift, day)
+	}
+	return baseWorkSchedule(employeeID)
+}
+
+// baseWorkSchedule returns the employee's regular work schedule, ignoring the rota
+func baseWorkSchedule(employeeID uint) models.WorkSchedule {
+	var employment models.EmploymentDetails
+	if database.DB.Where("employee_id = ?", employeeID).First(&employment).Error == nil &&
+		employment.WorkSchedule != nil && *employment.WorkSchedule != "" {
+		var schedule models.WorkSchedule
+		if database.DB.Where("name = ?", *employment.WorkSchedule).First(&schedule).Error == nil {
+			return schedule
+		}
+	}
+
+	var schedule models.WorkSchedule
+	if database.DB.Where("is_default = ?", true).First(&schedule).Error == nil {
+		return schedule
+	}
+	return fallbackWorkSchedule
+}
+
+// IsScheduledWorkDay reports whether the schedule expects the employee to work on the given day
+func IsScheduledWorkDay(schedule models.WorkSchedule, day time.Time) bool {
+	weekday := strconv.Itoa(int(day.Weekday()))
+	for _, d := range strings.Split(schedule.WorkDays, ",") {
+		if strings.TrimSpace(d) == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduledStart returns the time the schedule starts on the given day
+func ScheduledStart(schedule models.WorkSchedule, day time.Time) (time.Time, error) {
+	clock, err := time.Parse("15:04", schedule.StartTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start time %q on schedule %s", schedule.StartTime, schedule.Name)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location()), nil
+}
+
+// EvaluateAttendance sets the status, lateness and worked time of a record from its clock times
+func EvaluateAttendance(record *models.AttendanceRecord, schedule models.WorkSchedule) {
+	record.MinutesLate = 0
+	record.WorkedMinutes = 0
+
+	if record.ClockIn == nil {
+		if record.Status != models.AttendanceStatusOnLeave {
+			record.Status = models.AttendanceStatusAbsent
+		}
+		return
+	}
+
+	record.Status = models.AttendanceStatusPresent
+	if start, err := ScheduledStart(schedule, *record.ClockIn); err == nil {
+		late := int(record.ClockIn.Sub(start).Minutes())
+		if late > schedule.GraceMinutes {
+			record.Status = models.AttendanceStatusLate
+			record.MinutesLate = late
+		}
+	}
+	if record.ClockOut != nil && record.ClockOut.After(*record.ClockIn) {
+		record.WorkedMinutes = int(record.ClockOut.Sub(*record.ClockIn).Minutes())
+	}
+}
+
+// HasApprovedLeaveOn reports whether an employee has approved leave covering the given day
+func HasApprovedLeaveOn(employeeID uint, day time.Time) bool {
+	var count int64
+	database.DB.Model(&models.Leave{}).
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeID, models.StatusApproved, day, day).
+		Count(&count)
+	return count > 0
+}
+
+// MarkAbsences records an absent or on-leave day for every active employee who was scheduled
+// to work on the given day but never clocked in
+func MarkAbsences(day time.Time) AttendanceAbsenceResult {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	result := AttendanceAbsenceResult{Date: day.Format("2006-01-02")}
+
+	var employees []models.Employee
+	database.DB.Where("role != ? AND status = ?", models.RoleAdmin, "active").Find(&employees)
+
+	for _, employee := range employees {
+		var existing int64
+		database.DB.Model(&models.AttendanceRecord{}).Where("employee_id = ? AND date = ?", employee.ID, day).Count(&existing)
+		if existing > 0 {
+			continue
+		}
+
+		schedule := ResolveWorkSchedule(employee.ID, day)
+		if !IsScheduledWorkDay(schedule, day) {
+			continue
+		}
+
+		record := models.AttendanceRecord{EmployeeID: employee.ID, Date: day, Status: models.AttendanceStatusAbsent}
+		if HasApprovedLeaveOn(employee.ID, day) {
+			record.Status = models.AttendanceStatusOnLeave
+		}
+		if err := database.DB.Create(&record).Error; err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("employee %d: %v", employee.ID, err))
+			continue
+		}
+
+		if record.Status == models.AttendanceStatusOnLeave {
+			result.OnLeave++
+		} else {
+			result.Absent++
+		}
+	}
+
+	return result
+}
+
+// SummarizeAttendance totals attendance records per employee
+func SummarizeAttendance(employees []models.Employee, records []models.AttendanceRecord) []AttendanceSummary {
+	index := map[uint]int{}
+	summaries := make([]AttendanceSummary, 0, len(employees))
+	for _, employee := range employees {
+		index[employee.ID] = len(summaries)
+		summaries = append(summaries, AttendanceSummary{
+			EmployeeID:   employee.ID,
+			EmployeeName: employee.Firstname + " " + employee.Lastname,
+			Department:   employee.Department,
+		})
+	}
+
+	for _, record := range records {
+		i, ok := index[record.EmployeeID]
+		if !ok {
+			continue
+		}
+		summary := &summaries[i]
+		switch record.Status {
+		case models.AttendanceStatusPresent:
+			summary.Present++
+		case models.AttendanceStatusLate:
+			summary.Late++
+		case models.AttendanceStatusAbsent:
+			summary.Absent++
+		case models.AttendanceStatusOnLeave:
+			summary.OnLeave++
+		}
+		summary.MinutesLate += record.MinutesLate
+		summary.WorkedHours += float64(record.WorkedMinutes) / 60
+		if record.Corrected {
+			summary.Corrections++
+		}
+	}
+
+	return summaries
+}
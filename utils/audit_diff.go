@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldChange describes a single field that changed between two versions of a record
+type FieldChange struct {
+	Field string      `json:"field" example:"department"`
+	Label string      `json:"label" example:"Department"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// auditIgnoredFields are bookkeeping fields that change on every save and carry no meaning for reviewers
+var auditIgnoredFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+}
+
+// auditSensitiveFields are never written to the changes column, even as a diff
+var auditSensitiveFields = map[string]bool{
+	"password":            true,
+	"password_hash":       true,
+	"account_number":      true,
+	"bank_account_number": true,
+	"tax_id":              true,
+}
+
+// ComputeFieldChanges compares the JSON representation of two values and returns the top-level
+// fields whose values differ, sorted by field name. Timestamps, sensitive fields and nested
+// relations (objects and arrays) are skipped.
+func ComputeFieldChanges(oldValues, newValues interface{}) []FieldChange {
+	oldFields := toFieldMap(oldValues)
+	newFields := toFieldMap(newValues)
+
+	keys := map[string]bool{}
+	for key := range oldFields {
+		keys[key] = true
+	}
+	for key := range newFields {
+		keys[key] = true
+	}
+
+	changes := []FieldChange{}
+	for key := range keys {
+		if auditIgnoredFields[key] || auditSensitiveFields[key] {
+			continue
+		}
+		oldValue, newValue := oldFields[key], newFields[key]
+		if isNested(oldValue) || isNested(newValue) {
+			continue
+		}
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field: key,
+			Label: fieldLabel(key),
+			Old:   oldValue,
+			New:   newValue,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// toFieldMap converts a value into a map of its JSON fields
+func toFieldMap(value interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if value == nil {
+		return fields
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(raw, &fields)
+	return fields
+}
+
+func isNested(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+// fieldLabel turns a snake_case JSON field name into a readable label, e.g. "manager_id" -> "Manager ID"
+func fieldLabel(field string) string {
+	words := strings.Split(field, "_")
+	for i, word := range words {
+		switch word {
+		case "id", "nrc", "ip":
+			words[i] = strings.ToUpper(word)
+		default:
+			if word != "" {
+				words[i] = strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,491 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	calendarStateTTL      = 10 * time.Minute
+	calendarMaxAttempts   = 12 // Retried every 10 minutes, so a calendar is given up on after about two hours
+	calendarTokenLeeway   = time.Minute
+	calendarStatePurpose  = "calendar-oauth-state"
+	calendarCallbackRoute = "/integrations/calendar/%s/callback"
+)
+
+var (
+	// ErrCalendarNotConfigured is returned for a provider without an OAuth client configured
+	ErrCalendarNotConfigured = errors.New("calendar provider is not configured")
+	// ErrCalendarState is returned when an OAuth callback's state was not issued by us or has expired
+	ErrCalendarState = errors.New("calendar authorization is invalid or has expired")
+)
+
+// calendarSyncs tracks syncs started in the background by SyncLeaveCalendars and SyncEmployeeCalendars
+var calendarSyncs sync.WaitGroup
+
+// calendarSyncMu makes syncs of the same leave take turns, so two changes in quick succession cannot
+// both create its event
+var calendarSyncMu sync.Mutex
+
+// CalendarProviders returns the calendar providers employees can connect, in a stable order
+func CalendarProviders() []models.CalendarProvider {
+	providers := []models.CalendarProvider{}
+	for provider := range calendarProviders() {
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i] < providers[j] })
+	return providers
+}
+
+// CalendarRedirectURI is where the provider sends the browser back to after the employee consents
+func CalendarRedirectURI(provider models.CalendarProvider) string {
+	return config.AppConfig.PublicURL + fmt.Sprintf(calendarCallbackRoute, provider)
+}
+
+// CalendarAuthorizationURL returns the provider's consent page for the employee to connect their
+// calendar. The state parameter is signed, so the callback knows which employee consented without a
+// session.
+func CalendarAuthorizationURL(employeeID uint, provider models.CalendarProvider) (string, error) {
+	calendar, ok := calendarProviders()[provider]
+	if !ok {
+		return "", ErrCalendarNotConfigured
+	}
+	expires := time.Now().Add(calendarStateTTL).Unix()
+	payload := fmt.Sprintf("%d:%s:%d", employeeID, provider, expires)
+	state := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + calendarStateSignature(payload)
+	return calendar.authURL(state, CalendarRedirectURI(provider)), nil
+}
+
+// ConnectCalendar completes an OAuth callback: it exchanges the code for tokens, saves the employee's
+// connection, and adds their upcoming leaves to the calendar in the background. Reconnecting keeps
+// the connection's preferences.
+func ConnectCalendar(provider models.CalendarProvider, code, state string) (models.CalendarConnection, error) {
+	var connection models.CalendarConnection
+	calendar, ok := calendarProviders()[provider]
+	if !ok {
+		return connection, ErrCalendarNotConfigured
+	}
+	employeeID, err := verifyCalendarState(state, provider)
+	if err != nil {
+		return connection, err
+	}
+
+	token, err := calendar.tokenRequest(url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {CalendarRedirectURI(provider)},
+	})
+	if err != nil {
+		return connection, err
+	}
+	if token.RefreshToken == "" {
+		return connection, fmt.Errorf("%s did not issue a refresh token", provider)
+	}
+	email, err := calendar.accountEmail(token.AccessToken)
+	if err != nil {
+		return connection, err
+	}
+
+	err = database.DB.Where("employee_id = ? AND provider = ?", employeeID, provider).First(&connection).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		connection = models.CalendarConnection{
+			EmployeeID: employeeID, Provider: provider, SyncOwnLeaves: true, SyncTeamLeaves: true,
+		}
+	} else if err != nil {
+		return connection, err
+	}
+	connection.AccountEmail = email
+	if connection.AccessToken, err = EncryptToken(token.AccessToken); err != nil {
+		return connection, err
+	}
+	if connection.RefreshToken, err = EncryptToken(token.RefreshToken); err != nil {
+		return connection, err
+	}
+	connection.TokenExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	connection.LastError = nil
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&connection).Error; err != nil {
+			return err
+		}
+		// The account may be a different one, so every event is written again, and recreated where missing
+		return tx.Model(&models.CalendarEvent{}).Where("connection_id = ?", connection.ID).Update("digest", "").Error
+	})
+	if err != nil {
+		return connection, err
+	}
+
+	SyncEmployeeCalendars(employeeID)
+	return connection, nil
+}
+
+// DisconnectCalendar deletes a connection, then removes the events it added from the calendar in the
+// background. Events that cannot be removed are left behind.
+func DisconnectCalendar(connection models.CalendarConnection) error {
+	var events []models.CalendarEvent
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("connection_id = ?", connection.ID).Find(&events).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("connection_id = ?", connection.ID).Delete(&models.CalendarEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&connection).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	calendar, ok := calendarProviders()[connection.Provider]
+	if !ok || len(events) == 0 {
+		return nil
+	}
+	calendarSyncs.Add(1)
+	go func() {
+		defer calendarSyncs.Done()
+		accessToken, err := calendarAccessToken(calendar, &connection)
+		if err != nil {
+			log.Printf("❌ Calendars: failed to remove events of disconnected calendar %d: %v", connection.ID, err)
+			return
+		}
+		for _, event := range events {
+			if event.ExternalID == "" {
+				continue
+			}
+			if err := calendar.deleteEvent(accessToken, event.ExternalID); err != nil && !errors.Is(err, errCalendarEventGone) {
+				log.Printf("❌ Calendars: failed to remove event of leave %d from disconnected calendar %d: %v", event.LeaveID, connection.ID, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// SyncLeaveCalendars brings the leaves' events in connected calendars up to date in the background:
+// approved leaves are added to or updated in the calendars of the employee and their manager, and
+// leaves no longer approved are removed. Failures are retried by the calendar scheduler.
+func SyncLeaveCalendars(leaveIDs ...uint) {
+	if len(leaveIDs) == 0 || len(calendarProviders()) == 0 {
+		return
+	}
+	calendarSyncs.Add(1)
+	go func() {
+		defer calendarSyncs.Done()
+		syncLeaves(leaveIDs)
+	}()
+}
+
+// SyncEmployeeCalendars brings the employee's calendars up to date with their preferences in the
+// background, adding upcoming approved leaves they sync and removing the events of leaves they no
+// longer sync
+func SyncEmployeeCalendars(employeeID uint) {
+	if len(calendarProviders()) == 0 {
+		return
+	}
+	calendarSyncs.Add(1)
+	go func() {
+		defer calendarSyncs.Done()
+		var leaveIDs []uint
+		reports := database.DB.Model(&models.EmploymentDetails{}).Select("employee_id").Where("manager_id = ?", employeeID)
+		if err := database.DB.Model(&models.Leave{}).
+			Where("status = ? AND end_date >= ?", models.StatusApproved, CompanyToday()).
+			Where("employee_id = ? OR employee_id IN (?)", employeeID, reports).
+			Pluck("id", &leaveIDs).Error; err != nil {
+			log.Printf("❌ Calendars: failed to load leaves for employee %d: %v", employeeID, err)
+			return
+		}
+		var synced []uint
+		if err := database.DB.Model(&models.CalendarEvent{}).
+			Joins("JOIN calendar_connections ON calendar_connections.id = calendar_events.connection_id").
+			Where("calendar_connections.employee_id = ?", employeeID).
+			Distinct().Pluck("calendar_events.leave_id", &synced).Error; err != nil {
+			log.Printf("❌ Calendars: failed to load synced leaves for employee %d: %v", employeeID, err)
+			return
+		}
+		syncLeaves(append(leaveIDs, synced...))
+	}()
+}
+
+// WaitForCalendarSyncs waits for syncs started in the background to finish
+func WaitForCalendarSyncs() {
+	calendarSyncs.Wait()
+}
+
+// RetryCalendarSyncs syncs the leaves whose last sync to a calendar failed
+func RetryCalendarSyncs() (int, []error) {
+	var leaveIDs []uint
+	if err := database.DB.Model(&models.CalendarEvent{}).Where("pending = ?", true).
+		Distinct().Pluck("leave_id", &leaveIDs).Error; err != nil {
+		return 0, []error{err}
+	}
+	var errs []error
+	for _, leaveID := range leaveIDs {
+		errs = append(errs, syncLeaveCalendar(leaveID)...)
+	}
+	return len(leaveIDs), errs
+}
+
+func syncLeaves(leaveIDs []uint) {
+	seen := map[uint]bool{}
+	for _, leaveID := range leaveIDs {
+		if seen[leaveID] {
+			continue
+		}
+		seen[leaveID] = true
+		for _, err := range syncLeaveCalendar(leaveID) {
+			log.Printf("❌ Calendars: %v", err)
+		}
+	}
+}
+
+// syncLeaveCalendar makes the leave's events match the calendars that should show it. Each failure is
+// recorded on the event, which stays pending until its attempts run out.
+func syncLeaveCalendar(leaveID uint) []error {
+	calendarSyncMu.Lock()
+	defer calendarSyncMu.Unlock()
+
+	wanted, err := wantedCalendarEntries(leaveID)
+	if err != nil {
+		return []error{fmt.Errorf("leave %d: %w", leaveID, err)}
+	}
+	var events []models.CalendarEvent
+	if err := database.DB.Where("leave_id = ?", leaveID).Find(&events).Error; err != nil {
+		return []error{fmt.Errorf("leave %d: %w", leaveID, err)}
+	}
+	existing := map[uint]*models.CalendarEvent{}
+	connectionIDs := []uint{}
+	for i := range events {
+		existing[events[i].ConnectionID] = &events[i]
+		connectionIDs = append(connectionIDs, events[i].ConnectionID)
+	}
+	for connectionID := range wanted {
+		if existing[connectionID] == nil {
+			connectionIDs = append(connectionIDs, connectionID)
+		}
+	}
+	if len(connectionIDs) == 0 {
+		return nil
+	}
+	var connections []models.CalendarConnection
+	if err := database.DB.Where("id IN ?", connectionIDs).Find(&connections).Error; err != nil {
+		return []error{fmt.Errorf("leave %d: %w", leaveID, err)}
+	}
+
+	var errs []error
+	for i := range connections {
+		connection := &connections[i]
+		event := existing[connection.ID]
+		entry, want := wanted[connection.ID]
+		if !want && event == nil {
+			continue
+		}
+		digest := ""
+		if want {
+			digest = calendarEntryDigest(entry)
+			if event != nil && event.ExternalID != "" && event.Digest == digest && !event.Pending {
+				continue
+			}
+		}
+		if event == nil {
+			event = &models.CalendarEvent{ConnectionID: connection.ID, LeaveID: leaveID}
+		}
+
+		syncErr := writeCalendarEvent(connection, event, entry, want)
+		if syncErr == nil && !want {
+			syncErr = database.DB.Delete(event).Error
+		} else {
+			if syncErr == nil {
+				event.Digest = digest
+				event.Pending = false
+				event.Attempts = 0
+				event.LastError = nil
+			} else {
+				message := syncErr.Error()
+				event.Attempts++
+				event.Pending = event.Attempts < calendarMaxAttempts
+				event.LastError = &message
+			}
+			if err := database.DB.Save(event).Error; err != nil && syncErr == nil {
+				syncErr = err
+			}
+		}
+
+		var lastError *string
+		if syncErr != nil {
+			message := syncErr.Error()
+			lastError = &message
+			errs = append(errs, fmt.Errorf("leave %d in calendar %d: %w", leaveID, connection.ID, syncErr))
+		}
+		database.DB.Model(connection).Update("last_error", lastError)
+	}
+	return errs
+}
+
+// writeCalendarEvent creates, updates or deletes the event in the connection's calendar
+func writeCalendarEvent(connection *models.CalendarConnection, event *models.CalendarEvent, entry calendarEntry, want bool) error {
+	calendar, ok := calendarProviders()[connection.Provider]
+	if !ok {
+		return ErrCalendarNotConfigured
+	}
+	accessToken, err := calendarAccessToken(calendar, connection)
+	if err != nil {
+		return err
+	}
+
+	if !want {
+		if event.ExternalID == "" {
+			return nil
+		}
+		if err := calendar.deleteEvent(accessToken, event.ExternalID); err != nil && !errors.Is(err, errCalendarEventGone) {
+			return err
+		}
+		return nil
+	}
+	if event.ExternalID != "" {
+		err := calendar.updateEvent(accessToken, event.ExternalID, entry)
+		if !errors.Is(err, errCalendarEventGone) {
+			return err
+		}
+		// Deleted from the calendar, or added to another account before reconnecting
+	}
+	externalID, err := calendar.createEvent(accessToken, entry)
+	if err != nil {
+		return err
+	}
+	event.ExternalID = externalID
+	return nil
+}
+
+// wantedCalendarEntries returns the event the leave should have in each connected calendar, keyed by
+// connection: approved leaves are shown in the employee's calendars that sync their own leaves and
+// their manager's calendars that sync team leaves
+func wantedCalendarEntries(leaveID uint) (map[uint]calendarEntry, error) {
+	wanted := map[uint]calendarEntry{}
+	var leave models.Leave
+	err := database.DB.Preload("Employee", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("LeaveType", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		First(&leave, leaveID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return wanted, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if leave.Status != models.StatusApproved {
+		return wanted, nil
+	}
+
+	var own []models.CalendarConnection
+	if err := database.DB.Where("employee_id = ? AND sync_own_leaves = ?", leave.EmployeeID, true).Find(&own).Error; err != nil {
+		return nil, err
+	}
+	for _, connection := range own {
+		wanted[connection.ID] = calendarEntry{Title: leave.LeaveType.Name, StartDate: leave.StartDate, EndDate: leave.EndDate}
+	}
+
+	var details models.EmploymentDetails
+	err = database.DB.Where("employee_id = ?", leave.EmployeeID).First(&details).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if details.ManagerID == nil || *details.ManagerID == leave.EmployeeID {
+		return wanted, nil
+	}
+	var team []models.CalendarConnection
+	if err := database.DB.Where("employee_id = ? AND sync_team_leaves = ?", *details.ManagerID, true).Find(&team).Error; err != nil {
+		return nil, err
+	}
+	title := fmt.Sprintf("%s %s: %s", leave.Employee.Firstname, leave.Employee.Lastname, leave.LeaveType.Name)
+	for _, connection := range team {
+		wanted[connection.ID] = calendarEntry{Title: title, StartDate: leave.StartDate, EndDate: leave.EndDate}
+	}
+	return wanted, nil
+}
+
+// calendarAccessToken returns the connection's access token, refreshing it first when it has expired.
+// The tokens are stored encrypted.
+func calendarAccessToken(calendar calendarProvider, connection *models.CalendarConnection) (string, error) {
+	if time.Now().Add(calendarTokenLeeway).Before(connection.TokenExpiresAt) {
+		return DecryptToken(connection.AccessToken)
+	}
+	refreshToken, err := DecryptToken(connection.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("reading the refresh token, the calendar may need reconnecting: %w", err)
+	}
+	token, err := calendar.tokenRequest(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return "", fmt.Errorf("refreshing access, the calendar may need reconnecting: %w", err)
+	}
+	if token.RefreshToken != "" {
+		refreshToken = token.RefreshToken
+	}
+	if connection.AccessToken, err = EncryptToken(token.AccessToken); err != nil {
+		return "", err
+	}
+	if connection.RefreshToken, err = EncryptToken(refreshToken); err != nil {
+		return "", err
+	}
+	connection.TokenExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	err = database.DB.Model(connection).Updates(map[string]interface{}{
+		"access_token": connection.AccessToken, "refresh_token": connection.RefreshToken,
+		"token_expires_at": connection.TokenExpiresAt,
+	}).Error
+	return token.AccessToken, err
+}
+
+func calendarEntryDigest(entry calendarEntry) string {
+	sum := sha256.Sum256([]byte(entry.Title + "\n" + entry.StartDate.Format("2006-01-02") + "\n" + entry.EndDate.Format("2006-01-02")))
+	return hex.EncodeToString(sum[:])
+}
+
+// calendarStateSignature signs an OAuth state with a key derived from the JWT secret
+func calendarStateSignature(payload string) string {
+	key := hmac.New(sha256.New, []byte(config.AppConfig.JWTSecret))
+	key.Write([]byte(calendarStatePurpose))
+	mac := hmac.New(sha256.New, key.Sum(nil))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verifyCalendarState returns the employee an OAuth state was issued to
+func verifyCalendarState(state string, provider models.CalendarProvider) (uint, error) {
+	encoded, signature, found := strings.Cut(state, ".")
+	if !found {
+		return 0, ErrCalendarState
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(calendarStateSignature(string(payload)))) {
+		return 0, ErrCalendarState
+	}
+	parts := strings.Split(string(payload), ":")
+	if len(parts) != 3 || parts[1] != string(provider) {
+		return 0, ErrCalendarState
+	}
+	employeeID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, ErrCalendarState
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return 0, ErrCalendarState
+	}
+	return uint(employeeID), nil
+}
@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/models"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	calendarTimeout         = 15 * time.Second
+	calendarMaxResponseBody = 1 << 20
+)
+
+var calendarClient = &http.Client{Timeout: calendarTimeout}
+
+// errCalendarEventGone is returned when the event was deleted from the calendar by its owner
+var errCalendarEventGone = errors.New("event no longer exists in the calendar")
+
+// calendarToken is the outcome of an OAuth authorization code exchange or token refresh
+type calendarToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"` // Only sent when a new refresh token is issued
+	ExpiresIn    int    `json:"expires_in"`    // Seconds
+}
+
+// calendarEntry is the all-day event a leave has in a calendar
+type calendarEntry struct {
+	Title     string
+	StartDate time.Time // First day of the leave
+	EndDate   time.Time // Last day of the leave, inclusive
+}
+
+// calendarProvider talks to a calendar service over its REST API
+type calendarProvider interface {
+	authURL(state, redirectURI string) string
+	tokenRequest(form url.Values) (calendarToken, error)
+	accountEmail(accessToken string) (string, error)
+	createEvent(accessToken string, entry calendarEntry) (string, error)
+	updateEvent(accessToken, eventID string, entry calendarEntry) error
+	deleteEvent(accessToken, eventID string) error
+}
+
+// calendarProviders returns the providers with an OAuth client configured
+func calendarProviders() map[models.CalendarProvider]calendarProvider {
+	providers := map[models.CalendarProvider]calendarProvider{}
+	if config.AppConfig == nil {
+		return providers
+	}
+	if config.AppConfig.GoogleClientID != "" {
+		providers[models.CalendarProviderGoogle] = googleCalendar{}
+	}
+	if config.AppConfig.MicrosoftClientID != "" {
+		providers[models.CalendarProviderOutlook] = outlookCalendar{}
+	}
+	return providers
+}
+
+// googleCalendar adds events to the primary calendar of a Google account
+type googleCalendar struct{}
+
+const googleEventsURL = "https://www.googleapis.com/calendar/v3/calendars/primary/events"
+
+func (googleCalendar) authURL(state, redirectURI string) string {
+	return "https://accounts.google.com/o/oauth2/v2/auth?" + url.Values{
+		"client_id":     {config.AppConfig.GoogleClientID},
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"scope":         {"openid email https://www.googleapis.com/auth/calendar.events"},
+		"access_type":   {"offline"},
+		// Google only issues a refresh token on consent, so consent is asked for again on reconnecting
+		"prompt": {"consent"},
+		"state":  {state},
+	}.Encode()
+}
+
+func (googleCalendar) tokenRequest(form url.Values) (calendarToken, error) {
+	form.Set("client_id", config.AppConfig.GoogleClientID)
+	form.Set("client_secret", config.AppConfig.GoogleClientSecret)
+	return requestCalendarToken("https://oauth2.googleapis.com/token", form)
+}
+
+func (googleCalendar) accountEmail(accessToken string) (string, error) {
+	var userInfo struct {
+		Email string `json:"email"`
+	}
+	err := calendarRequest(http.MethodGet, "https://openidconnect.googleapis.com/v1/userinfo", accessToken, nil, &userInfo)
+	return userInfo.Email, err
+}
+
+func (googleCalendar) createEvent(accessToken string, entry calendarEntry) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	err := calendarRequest(http.MethodPost, googleEventsURL, accessToken, googleEvent(entry), &created)
+	return created.ID, err
+}
+
+func (googleCalendar) updateEvent(accessToken, eventID string, entry calendarEntry) error {
+	return calendarRequest(http.MethodPut, googleEventsURL+"/"+url.PathEscape(eventID), accessToken, googleEvent(entry), nil)
+}
+
+func (googleCalendar) deleteEvent(accessToken, eventID string) error {
+	return calendarRequest(http.MethodDelete, googleEventsURL+"/"+url.PathEscape(eventID), accessToken, nil, nil)
+}
+
+// googleEvent is an all-day event; Google's end date is exclusive
+func googleEvent(entry calendarEntry) map[string]interface{} {
+	return map[string]interface{}{
+		"summary":      entry.Title,
+		"start":        map[string]string{"date": entry.StartDate.Format("2006-01-02")},
+		"end":          map[string]string{"date": entry.EndDate.AddDate(0, 0, 1).Format("2006-01-02")},
+		"transparency": "opaque",
+	}
+}
+
+// outlookCalendar adds events to the default calendar of a Microsoft 365 or Outlook.com account
+// through Microsoft Graph
+type outlookCalendar struct{}
+
+const (
+	outlookEventsURL = "https://graph.microsoft.com/v1.0/me/events"
+	outlookScopes    = "openid email offline_access User.Read Calendars.ReadWrite"
+)
+
+func (outlookCalendar) authURL(state, redirectURI string) string {
+	return outlookLoginURL("authorize") + "?" + url.Values{
+		"client_id":     {config.AppConfig.MicrosoftClientID},
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"response_mode": {"query"},
+		"scope":         {outlookScopes},
+		"state":         {state},
+	}.Encode()
+}
+
+func (outlookCalendar) tokenRequest(form url.Values) (calendarToken, error) {
+	form.Set("client_id", config.AppConfig.MicrosoftClientID)
+	form.Set("client_secret", config.AppConfig.MicrosoftClientSecret)
+	form.Set("scope", outlookScopes)
+	return requestCalendarToken(outlookLoginURL("token"), form)
+}
+
+func (outlookCalendar) accountEmail(accessToken string) (string, error) {
+	var user struct {
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+	}
+	if err := calendarRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/me", accessToken, nil, &user); err != nil {
+		return "", err
+	}
+	if user.Mail != "" {
+		return user.Mail, nil
+	}
+	return user.UserPrincipalName, nil
+}
+
+func (outlookCalendar) createEvent(accessToken string, entry calendarEntry) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	err := calendarRequest(http.MethodPost, outlookEventsURL, accessToken, outlookEvent(entry), &created)
+	return created.ID, err
+}
+
+func (outlookCalendar) updateEvent(accessToken, eventID string, entry calendarEntry) error {
+	return calendarRequest(http.MethodPatch, outlookEventsURL+"/"+url.PathEscape(eventID), accessToken, outlookEvent(entry), nil)
+}
+
+func (outlookCalendar) deleteEvent(accessToken, eventID string) error {
+	return calendarRequest(http.MethodDelete, outlookEventsURL+"/"+url.PathEscape(eventID), accessToken, nil, nil)
+}
+
+// outlookEvent is an all-day event shown as out of office. Graph wants all-day events to run from
+// midnight to midnight of the day after the last.
+func outlookEvent(entry calendarEntry) map[string]interface{} {
+	timezone := CompanyLocation().String()
+	return map[string]interface{}{
+		"subject":  entry.Title,
+		"isAllDay": true,
+		"showAs":   "oof",
+		"start":    map[string]string{"dateTime": entry.StartDate.Format("2006-01-02T15:04:05"), "timeZone": timezone},
+		"end":      map[string]string{"dateTime": entry.EndDate.AddDate(0, 0, 1).Format("2006-01-02T15:04:05"), "timeZone": timezone},
+	}
+}
+
+func outlookLoginURL(endpoint string) string {
+	return "https://login.microsoftonline.com/" + url.PathEscape(config.AppConfig.MicrosoftTenant) + "/oauth2/v2.0/" + endpoint
+}
+
+// requestCalendarToken posts an OAuth token request
+func requestCalendarToken(tokenURL string, form url.Values) (calendarToken, error) {
+	var token calendarToken
+	resp, err := calendarClient.PostForm(tokenURL, form)
+	if err != nil {
+		return token, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, calendarMaxResponseBody))
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return token, fmt.Errorf("token request refused: %s %s", oauthErr.Error, oauthErr.Description)
+		}
+		return token, fmt.Errorf("token request responded with status %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, err
+	}
+	if token.AccessToken == "" {
+		return token, fmt.Errorf("token response has no access token")
+	}
+	return token, nil
+}
+
+// calendarRequest calls a calendar API with a JSON body, decoding the JSON response into out when it
+// is not nil
+func calendarRequest(method, requestURL, accessToken string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, requestURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := calendarClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, calendarMaxResponseBody))
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return errCalendarEventGone
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s responded with status %d: %s", method, strings.SplitN(requestURL, "?", 2)[0],
+			resp.StatusCode, truncateCalendarError(string(responseBody)))
+	}
+	if out != nil {
+		return json.Unmarshal(responseBody, out)
+	}
+	return nil
+}
+
+// truncateCalendarError keeps recorded errors short
+func truncateCalendarError(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) > 300 {
+		return text[:300] + "..."
+	}
+	return text
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Leave accrual, carry-over and leave date validation read the time
+// through Now, so tests can set the clock to any moment, such as either side of a month boundary.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the clock on the wall
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var (
+	clockMu      sync.RWMutex
+	currentClock Clock = systemClock{}
+)
+
+// Now returns the current time by the clock set with SetClock, the system clock unless one was set
+func Now() time.Time {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return currentClock.Now()
+}
+
+// SetClock replaces the clock Now reads and returns a function that puts the previous one back
+func SetClock(clock Clock) (restore func()) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	previous := currentClock
+	currentClock = clock
+	return func() {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		currentClock = previous
+	}
+}
@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hrms-api/models"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// clockEventBounceWindow is how close together two events of an employee in the same direction are
+// taken to be one, such as a badge swiped twice or held to two terminals at the door
+const clockEventBounceWindow = 2 * time.Minute
+
+// ClockEventInput is a clock event as received from a terminal or an import file
+type ClockEventInput struct {
+	DeviceID  uint
+	Badge     string
+	Timestamp time.Time
+	Direction models.ClockDirection
+}
+
+// ClockEventCounts says what became of a batch of clock events
+type ClockEventCounts struct {
+	Applied    int `json:"applied" example:"42"`
+	Duplicates int `json:"duplicates" example:"3"`
+	Unmatched  int `json:"unmatched" example:"1"`
+	Ignored    int `json:"ignored" example:"0"`
+}
+
+// GenerateDeviceKey returns a random key for an attendance terminal to authenticate with
+func GenerateDeviceKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "dev_" + hex.EncodeToString(key), nil
+}
+
+// HashDeviceKey returns the hash a device key is stored and looked up by
+func HashDeviceKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IngestClockEvents stores clock events and feeds them into attendance. Events are taken in time
+// order, so a terminal that uploads its log out of order gives the same result as one that does not.
+// An event already received from the same terminal is skipped, and one repeating an event the
+// employee has just made is kept as a duplicate. Each day an event is applied to has its clock-in
+// and clock-out recalculated from all the events of the day, only ever moving clock-in earlier and
+// clock-out later, so clock times recorded through the app are kept.
+func IngestClockEvents(db *gorm.DB, source models.ClockEventSource, inputs []ClockEventInput) (ClockEventCounts, error) {
+	sorted := append([]ClockEventInput{}, inputs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	batch := newClockEventBatch(db)
+	for _, input := range sorted {
+		event := models.ClockEvent{
+			DeviceID:   input.DeviceID,
+			Badge:      strings.TrimSpace(input.Badge),
+			OccurredAt: input.Timestamp.UTC().Truncate(time.Second),
+			Direction:  input.Direction,
+			Source:     source,
+		}
+
+		var existing int64
+		if err := db.Model(&models.ClockEvent{}).
+			Where("device_id = ? AND badge = ? AND occurred_at = ?", event.DeviceID, event.Badge, event.OccurredAt).
+			Count(&existing).Error; err != nil {
+			return batch.counts, err
+		}
+		if existing > 0 {
+			batch.counts.Duplicates++
+			continue
+		}
+
+		event.EmployeeID = batch.match(event.Badge)
+		if err := batch.place(&event); err != nil {
+			return batch.counts, err
+		}
+		if err := db.Create(&event).Error; err != nil {
+			return batch.counts, err
+		}
+	}
+
+	return batch.counts, batch.apply()
+}
+
+// RematchClockEvents matches unmatched clock events to employees again, for when badges have been
+// assigned since the events were received, and applies those that now match
+func RematchClockEvents(db *gorm.DB) (ClockEventCounts, error) {
+	var events []models.ClockEvent
+	if err := db.Where("status = ?", models.ClockEventUnmatched).Order("occurred_at, id").Find(&events).Error; err != nil {
+		return ClockEventCounts{}, err
+	}
+
+	batch := newClockEventBatch(db)
+	for i := range events {
+		event := &events[i]
+		event.EmployeeID = batch.match(event.Badge)
+		if err := batch.place(event); err != nil {
+			return batch.counts, err
+		}
+		if event.Status == models.ClockEventUnmatched {
+			continue
+		}
+		if err := db.Model(event).Updates(map[string]interface{}{"employee_id": event.EmployeeID, "status": event.Status}).Error; err != nil {
+			return batch.counts, err
+		}
+	}
+
+	return batch.counts, batch.apply()
+}
+
+// clockEventBatch places the events of one upload and remembers the attendance days they touch
+type clockEventBatch struct {
+	db        *gorm.DB
+	employees map[string]*uint
+	days      map[clockEventDay]bool
+	counts    ClockEventCounts
+}
+
+type clockEventDay struct {
+	employeeID uint
+	date       time.Time
+}
+
+func newClockEventBatch(db *gorm.DB) *clockEventBatch {
+	return &clockEventBatch{db: db, employees: map[string]*uint{}, days: map[clockEventDay]bool{}}
+}
+
+// match finds the employee a badge belongs to, by badge number or else by employee number
+func (b *clockEventBatch) match(badge string) *uint {
+	if id, ok := b.employees[badge]; ok {
+		return id
+	}
+	var employee models.Employee
+	var id *uint
+	if b.db.Where("badge_number = ?", badge).First(&employee).Error == nil ||
+		b.db.Where("employee_number = ? OR id IN (SELECT employee_id FROM employment_details WHERE employee_number = ? AND deleted_at IS NULL)", badge, badge).
+			First(&employee).Error == nil {
+		id = &employee.ID
+	}
+	b.employees[badge] = id
+	return id
+}
+
+// place decides the status of an event and counts it
+func (b *clockEventBatch) place(event *models.ClockEvent) error {
+	if event.EmployeeID == nil {
+		event.Status = models.ClockEventUnmatched
+		b.counts.Unmatched++
+		return nil
+	}
+
+	var repeats int64
+	err := b.db.Model(&models.ClockEvent{}).
+		Where("employee_id = ? AND status = ? AND direction = ? AND occurred_at > ? AND occurred_at < ?",
+			*event.EmployeeID, models.ClockEventApplied, event.Direction,
+			event.OccurredAt.Add(-clockEventBounceWindow), event.OccurredAt.Add(clockEventBounceWindow)).
+		Count(&repeats).Error
+	if err != nil {
+		return err
+	}
+	if repeats > 0 {
+		event.Status = models.ClockEventDuplicate
+		b.counts.Duplicates++
+		return nil
+	}
+
+	day := clockEventDay{employeeID: *event.EmployeeID, date: attendanceDate(event.OccurredAt)}
+	var corrected int64
+	if err := b.db.Model(&models.AttendanceRecord{}).
+		Where("employee_id = ? AND date = ? AND corrected = ?", day.employeeID, day.date, true).
+		Count(&corrected).Error; err != nil {
+		return err
+	}
+	if corrected > 0 {
+		event.Status = models.ClockEventIgnored
+		b.counts.Ignored++
+		return nil
+	}
+
+	event.Status = models.ClockEventApplied
+	b.counts.Applied++
+	b.days[day] = true
+	return nil
+}
+
+// apply recalculates the attendance of every day the batch applied events to
+func (b *clockEventBatch) apply() error {
+	for day := range b.days {
+		if err := applyClockEvents(b.db, day.employeeID, day.date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyClockEvents sets an employee's clock-in for a day from their earliest arrival and their
+// clock-out from their latest departure. Events without a direction count as either.
+func applyClockEvents(db *gorm.DB, employeeID uint, date time.Time) error {
+	var events []models.ClockEvent
+	if err := db.Where("employee_id = ? AND status = ? AND occurred_at >= ? AND occurred_at < ?",
+		employeeID, models.ClockEventApplied, date.UTC(), date.AddDate(0, 0, 1).UTC()).
+		Order("occurred_at").Find(&events).Error; err != nil {
+		return err
+	}
+
+	var first, last *time.Time
+	for i := range events {
+		at := events[i].OccurredAt.In(date.Location())
+		if events[i].Direction != models.ClockDirectionOut && first == nil {
+			first = &at
+		}
+		if events[i].Direction != models.ClockDirectionIn {
+			last = &at
+		}
+	}
+
+	var record models.AttendanceRecord
+	err := db.Where("employee_id = ? AND date = ?", employeeID, date).First(&record).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if first != nil && (record.ClockIn == nil || first.Before(*record.ClockIn)) {
+		record.ClockIn = first
+	}
+	if record.ClockIn == nil {
+		return nil // Departures alone do not make an attendance day
+	}
+	if last != nil && last.After(*record.ClockIn) && (record.ClockOut == nil || last.After(*record.ClockOut)) {
+		record.ClockOut = last
+	}
+
+	record.EmployeeID = employeeID
+	record.Date = date
+	// A day already marked absent or on leave is overwritten by an actual clock-in
+	record.Status = ""
+	EvaluateAttendance(&record, ResolveWorkSchedule(employeeID, date))
+	return db.Save(&record).Error
+}
+
+// attendanceDate returns the attendance day a moment falls on. Attendance days run on the server's
+// local time, as clocking in through the app does.
+func attendanceDate(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
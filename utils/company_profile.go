@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	// DefaultCompanyName is the company name until the company_name setting is changed
+	DefaultCompanyName = "Chudleigh House School"
+	// LogoPath is the logo on PDFs until a company_logo is set
+	LogoPath = "static/assets/chslogo.png"
+	// maxCompanyLogoBytes is the largest image the company_logo setting takes
+	maxCompanyLogoBytes = 512 * 1024
+)
+
+// companyLogoTypes are the data URL prefixes of the images the company_logo setting takes, with the
+// image type gofpdf knows them by
+var companyLogoTypes = map[string]string{
+	"data:image/png;base64,":  "PNG",
+	"data:image/jpeg;base64,": "JPG",
+}
+
+// CompanyName is the name export files, reports and generated documents are branded with, from the
+// company_name setting
+func CompanyName() string {
+	return CurrentSettings().CompanyName
+}
+
+// decodeCompanyLogo decodes the value of the company_logo setting, a PNG or JPEG image as a base64
+// data URL, into the image and its type. An empty value decodes to no image, for the default logo.
+func decodeCompanyLogo(logo string) ([]byte, string, error) {
+	if logo == "" {
+		return nil, "", nil
+	}
+	for prefix, imageType := range companyLogoTypes {
+		if !strings.HasPrefix(logo, prefix) {
+			continue
+		}
+		image, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(logo, prefix))
+		if err != nil {
+			return nil, "", fmt.Errorf("must be base64 encoded")
+		}
+		if len(image) > maxCompanyLogoBytes {
+			return nil, "", fmt.Errorf("must be an image of at most 512 KB")
+		}
+		// gofpdf reads fewer images than browsers do, such as no interlaced PNGs, so try it out
+		pdf := gofpdf.New("P", "mm", "A4", "")
+		pdf.RegisterImageOptionsReader("logo", gofpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(image))
+		if err := pdf.Error(); err != nil {
+			return nil, "", fmt.Errorf("cannot be placed on a PDF: %v", err)
+		}
+		return image, imageType, nil
+	}
+	return nil, "", fmt.Errorf("must be a PNG or JPEG image as a data URL, or empty for the default logo")
+}
+
+// defaultLogoPath finds the logo bundled with the API, which depends on the working directory
+func defaultLogoPath() (string, bool) {
+	possiblePaths := []string{
+		LogoPath,
+		filepath.Join("static", "assets", "chslogo.png"),
+		"./static/assets/chslogo.png",
+		filepath.Join(".", "static", "assets", "chslogo.png"),
+		"/home/andrea/Documents/Sources/hrms-api/static/assets/chslogo.png",
+	}
+	for _, path := range possiblePaths {
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
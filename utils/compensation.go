@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"errors"
+	"hrms-api/models"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSalaryOutOfBand is returned when an amount is outside its position's salary band and no reason
+// was given for it
+var ErrSalaryOutOfBand = errors.New("salary is outside the position's salary band")
+
+// Where an amount falls against a salary band
+const (
+	SalaryBandBelow  = "below"
+	SalaryBandWithin = "within"
+	SalaryBandAbove  = "above"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// IsCurrencyCode reports whether code is an upper case ISO 4217 currency code, such as ZMW
+func IsCurrencyCode(code string) bool {
+	return currencyCodePattern.MatchString(code)
+}
+
+// HasSalaryBand reports whether position has both ends of a salary band set
+func HasSalaryBand(position models.Position) bool {
+	return position.MinSalary != nil && position.MaxSalary != nil && *position.MaxSalary > 0
+}
+
+// SalaryBandStatus returns whether amount is below, within or above position's salary band, or "" if
+// the position has no band
+func SalaryBandStatus(position models.Position, amount float64) string {
+	switch {
+	case !HasSalaryBand(position):
+		return ""
+	case amount < *position.MinSalary:
+		return SalaryBandBelow
+	case amount > *position.MaxSalary:
+		return SalaryBandAbove
+	}
+	return SalaryBandWithin
+}
+
+// CompaRatio returns amount divided by the midpoint of position's salary band, so 1 is paid exactly
+// at the midpoint, or nil if the position has no band
+func CompaRatio(position models.Position, amount float64) *float64 {
+	if !HasSalaryBand(position) {
+		return nil
+	}
+	ratio := amount / ((*position.MinSalary + *position.MaxSalary) / 2)
+	return &ratio
+}
+
+// CurrentCompensation returns the latest compensation record of each of employeeIDs in effect on
+// date, keyed by employee. Employees with no record in effect are left out.
+func CurrentCompensation(db *gorm.DB, employeeIDs []uint, date time.Time) (map[uint]models.CompensationRecord, error) {
+	current := map[uint]models.CompensationRecord{}
+	if len(employeeIDs) == 0 {
+		return current, nil
+	}
+	var records []models.CompensationRecord
+	err := db.Where("employee_id IN ? AND effective_date <= ?", employeeIDs, date).
+		Order("employee_id, effective_date, id").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		current[record.EmployeeID] = record // Ordered so the latest record wins
+	}
+	return current, nil
+}
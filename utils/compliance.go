@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ComplianceExpiryResult summarises a run of the compliance expiry job
+type ComplianceExpiryResult struct {
+	Expired       int      `json:"expired" example:"3"`
+	RemindersSent int      `json:"reminders_sent" example:"5"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+// ProcessComplianceExpiry marks compliance records past their expiry date as expired and notifies the
+// employee and their manager, then sends reminders for records entering their requirement's reminder window.
+// A record gets at most one reminder per expiry date, so renewing it re-arms the reminder.
+func ProcessComplianceExpiry() ComplianceExpiryResult {
+	var result ComplianceExpiryResult
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Flip records that have passed their expiry date
+	var expiring []models.ComplianceRecord
+	database.DB.Preload("Requirement").
+		Where("expiry_date IS NOT NULL AND expiry_date < ? AND status IN ?", today,
+			[]models.ComplianceStatus{models.ComplianceStatusCompliant, models.ComplianceStatusPending}).
+		Find(&expiring)
+
+	for _, record := range expiring {
+		if err := database.DB.Model(&record).Update("status", models.ComplianceStatusExpired).Error; err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("record %d: %v", record.ID, err))
+			continue
+		}
+		result.Expired++
+
+		subject := i18n.M("Compliance expired: %s", record.Requirement.Name)
+		message := i18n.M("%s expired on %s. Please renew it and provide updated evidence to HR.",
+			record.Requirement.Name, record.ExpiryDate.Format("2006-01-02"))
+		values := map[string]string{
+			"requirement.name": record.Requirement.Name, "compliance.expiry_date": record.ExpiryDate.Format("2006-01-02"),
+		}
+		for _, err := range notifyComplianceRecipients(record, models.NotificationComplianceExpired, subject, message, values) {
+			result.Errors = append(result.Errors, fmt.Sprintf("record %d: %v", record.ID, err))
+		}
+	}
+
+	// Remind about records inside their reminder window
+	var upcoming []models.ComplianceRecord
+	database.DB.Preload("Requirement").
+		Joins("JOIN compliance_requirements ON compliance_requirements.id = compliance_records.requirement_id").
+		Where("compliance_requirements.reminder_days IS NOT NULL AND compliance_requirements.reminder_days > 0").
+		Where("compliance_records.status = ? AND compliance_records.expiry_date IS NOT NULL AND compliance_records.expiry_date >= ?",
+			models.ComplianceStatusCompliant, today).
+		Find(&upcoming)
+
+	for _, record := range upcoming {
+		windowStart := record.ExpiryDate.AddDate(0, 0, -*record.Requirement.ReminderDays)
+		if today.Before(windowStart) {
+			continue
+		}
+
+		var alreadySent int64
+		database.DB.Model(&models.Notification{}).
+			Where("category = ? AND entity_type = ? AND entity_id = ? AND created_at >= ?",
+				models.NotificationComplianceReminder, models.AuditEntityCompliance, record.ID, windowStart).
+			Count(&alreadySent)
+		if alreadySent > 0 {
+			continue
+		}
+
+		daysLeft := int(record.ExpiryDate.Sub(today).Hours() / 24)
+		subject := i18n.M("Compliance expiring: %s", record.Requirement.Name)
+		message := i18n.M("%s expires on %s (in %d day(s)). Please arrange renewal before it lapses.",
+			record.Requirement.Name, record.ExpiryDate.Format("2006-01-02"), daysLeft)
+		values := map[string]string{
+			"requirement.name": record.Requirement.Name, "compliance.expiry_date": record.ExpiryDate.Format("2006-01-02"),
+			"compliance.days_left": strconv.Itoa(daysLeft),
+		}
+		errs := notifyComplianceRecipients(record, models.NotificationComplianceReminder, subject, message, values)
+		for _, err := range errs {
+			result.Errors = append(result.Errors, fmt.Sprintf("record %d: %v", record.ID, err))
+		}
+		result.RemindersSent++
+	}
+
+	return result
+}
+
+// notifyComplianceRecipients notifies the employee who owns the record and their manager, if any
+func notifyComplianceRecipients(record models.ComplianceRecord, category models.NotificationCategory, subject, message i18n.Message, values map[string]string) []error {
+	var errs []error
+
+	var employee models.Employee
+	if err := database.DB.First(&employee, record.EmployeeID).Error; err != nil {
+		return []error{fmt.Errorf("employee %d not found", record.EmployeeID)}
+	}
+	values["employee.full_name"] = strings.TrimSpace(employee.Firstname + " " + employee.Lastname)
+	if err := Notify(employee, category, subject, message, values, models.AuditEntityCompliance, record.ID); err != nil {
+		errs = append(errs, err)
+	}
+
+	var employment models.EmploymentDetails
+	if err := database.DB.Where("employee_id = ?", employee.ID).First(&employment).Error; err == nil && employment.ManagerID != nil {
+		var manager models.Employee
+		if err := database.DB.First(&manager, *employment.ManagerID).Error; err == nil {
+			managerMessage := i18n.M("%s %s: %s", employee.Firstname, employee.Lastname, message)
+			if err := Notify(manager, category, subject, managerMessage, values, models.AuditEntityCompliance, record.ID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
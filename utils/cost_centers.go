@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CostCenterSplit is the share of a cost charged to one cost center
+type CostCenterSplit struct {
+	CostCenterID uint    `json:"cost_center_id" example:"3"`
+	Code         string  `json:"code" example:"CC-100"`
+	Name         string  `json:"name" example:"Finance Lusaka"`
+	Percentage   float64 `json:"percentage" example:"60"`
+}
+
+// CostCenterAllocationsOn returns the allocations in effect on date that query selects, with their
+// cost center, in cost center code order
+func CostCenterAllocationsOn(query *gorm.DB, date time.Time) ([]models.CostCenterAllocation, error) {
+	var allocations []models.CostCenterAllocation
+	err := query.Preload("CostCenter").
+		Where("start_date <= ? AND (end_date IS NULL OR end_date >= ?)", date, date).
+		Order("cost_center_id").Find(&allocations).Error
+	return allocations, err
+}
+
+// PositionCostCenterSplits returns how the cost of each of positionIDs is split across cost centers on
+// date. Positions without allocations are left out.
+func PositionCostCenterSplits(db *gorm.DB, positionIDs []uint, date time.Time) (map[uint][]CostCenterSplit, error) {
+	splits := map[uint][]CostCenterSplit{}
+	if len(positionIDs) == 0 {
+		return splits, nil
+	}
+	allocations, err := CostCenterAllocationsOn(db.Where("position_id IN ?", positionIDs), date)
+	if err != nil {
+		return nil, err
+	}
+	for _, allocation := range allocations {
+		splits[*allocation.PositionID] = append(splits[*allocation.PositionID], costCenterSplit(allocation))
+	}
+	return splits, nil
+}
+
+// EmployeeCostCenterSplits returns how the cost of each of employeeIDs is split across cost centers on
+// date: by the employee's own allocations, or else by those of their primary position on that date.
+// Employees allocated neither way are left out.
+func EmployeeCostCenterSplits(db *gorm.DB, employeeIDs []uint, date time.Time) (map[uint][]CostCenterSplit, error) {
+	splits := map[uint][]CostCenterSplit{}
+	if len(employeeIDs) == 0 {
+		return splits, nil
+	}
+	allocations, err := CostCenterAllocationsOn(db.Where("employee_id IN ?", employeeIDs), date)
+	if err != nil {
+		return nil, err
+	}
+	for _, allocation := range allocations {
+		splits[*allocation.EmployeeID] = append(splits[*allocation.EmployeeID], costCenterSplit(allocation))
+	}
+
+	var assignments []models.PositionAssignment
+	err = db.Where("employee_id IN ? AND is_primary = ? AND start_date <= ? AND (end_date IS NULL OR end_date >= ?)",
+		employeeIDs, true, date, date).Order("start_date").Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+	positionOf := map[uint]uint{}
+	positionIDs := []uint{}
+	for _, assignment := range assignments {
+		if _, own := splits[assignment.EmployeeID]; own {
+			continue
+		}
+		positionOf[assignment.EmployeeID] = assignment.PositionID // The latest primary assignment wins
+		positionIDs = append(positionIDs, assignment.PositionID)
+	}
+	positionSplits, err := PositionCostCenterSplits(db, positionIDs, date)
+	if err != nil {
+		return nil, err
+	}
+	for employeeID, positionID := range positionOf {
+		if split, ok := positionSplits[positionID]; ok {
+			splits[employeeID] = split
+		}
+	}
+	return splits, nil
+}
+
+// FormatPercentage writes a percentage without trailing zeros, such as 60 or 33.33
+func FormatPercentage(percentage float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", percentage), "0"), ".")
+}
+
+func costCenterSplit(allocation models.CostCenterAllocation) CostCenterSplit {
+	return CostCenterSplit{
+		CostCenterID: allocation.CostCenterID,
+		Code:         allocation.CostCenter.Code,
+		Name:         allocation.CostCenter.Name,
+		Percentage:   allocation.Percentage,
+	}
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"errors"
+	"hrms-api/database"
+	"hrms-api/models"
+	"log"
+	"time"
+)
+
+var (
+	ErrDeadLetterResolved    = errors.New("dead letter has already been re-sent or delivered")
+	ErrEmailNotConfigured    = errors.New("email is not configured")
+	ErrNoEmailAddress        = errors.New("recipient has no email address")
+	ErrSMSNotConfigured      = errors.New("SMS is not configured")
+	ErrNoMobileNumber        = errors.New("recipient has no mobile number")
+	ErrInvalidMobileNumber   = errors.New("not a valid mobile number")
+	ErrSubscriptionInactive  = errors.New("webhook subscription has been deleted or deactivated")
+	ErrDeadLetterMessageGone = errors.New("message of the dead letter no longer exists")
+)
+
+// permanentError is a delivery failure that retrying will not fix, such as an address the mail server
+// rejects or a webhook endpoint answering 404. The message is given up straight away.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// isPermanent reports whether a delivery failure is one retrying will not fix
+func isPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// backoff doubles the wait after each failed attempt, starting at base, up to max
+func backoff(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// recordDeadLetter parks a message that was given up. A message that still has an open dead letter,
+// such as a failed webhook delivery retried by hand, has that letter updated instead of a second one.
+func recordDeadLetter(letter models.DeadLetter) {
+	query := database.DB.Where("kind = ? AND resolved_at IS NULL", letter.Kind)
+	if letter.NotificationID != nil {
+		query = query.Where("notification_id = ?", *letter.NotificationID)
+	} else {
+		query = query.Where("webhook_delivery_id = ?", *letter.WebhookDeliveryID)
+	}
+
+	var open models.DeadLetter
+	err := query.Limit(1).Find(&open).Error
+	if err == nil && open.ID != 0 {
+		err = database.DB.Model(&open).Updates(map[string]interface{}{
+			"reason": letter.Reason, "permanent": letter.Permanent, "attempts": letter.Attempts,
+		}).Error
+	} else if err == nil {
+		err = database.DB.Create(&letter).Error
+	}
+	if err != nil {
+		log.Printf("❌ Failed to record dead letter for %s: %v", letter.Kind, err)
+	}
+}
+
+// resolveDeadLetters closes the open dead letters of a message that has now been delivered
+func resolveDeadLetters(column string, messageID uint) {
+	err := database.DB.Model(&models.DeadLetter{}).
+		Where(column+" = ? AND resolved_at IS NULL", messageID).
+		Update("resolved_at", time.Now()).Error
+	if err != nil {
+		log.Printf("❌ Failed to resolve dead letters of %s %d: %v", column, messageID, err)
+	}
+}
+
+// ResendDeadLetter puts the message of a dead letter back in the delivery queue with all its attempts,
+// closes the letter, and makes the first attempt now. Emails and text messages go to the recipient's
+// current address or mobile number, so a bounced message can be re-sent once it is corrected. It returns the attempt's error, if any;
+// the message is then retried, or parked in a new dead letter.
+func ResendDeadLetter(letter *models.DeadLetter, userID uint) error {
+	if letter.ResolvedAt != nil {
+		return ErrDeadLetterResolved
+	}
+
+	switch letter.Kind {
+	case models.DeadLetterEmail, models.DeadLetterSMS:
+		return resendNotification(letter, userID)
+	case models.DeadLetterWebhook:
+		return resendWebhookDelivery(letter, userID)
+	}
+	return ErrDeadLetterMessageGone
+}
+
+func resendNotification(letter *models.DeadLetter, userID uint) error {
+	var message models.Notification
+	if letter.NotificationID == nil || database.DB.Preload("Recipient").Limit(1).Find(&message, *letter.NotificationID).Error != nil || message.ID == 0 {
+		return ErrDeadLetterMessageGone
+	}
+
+	if message.Channel == models.NotificationChannelSMS {
+		if !SMSEnabled() {
+			return ErrSMSNotConfigured
+		}
+		if message.Recipient.Mobile == nil || *message.Recipient.Mobile == "" {
+			return ErrNoMobileNumber
+		}
+		message.Address = message.Recipient.Mobile
+	} else {
+		if !EmailEnabled() {
+			return ErrEmailNotConfigured
+		}
+		if message.Recipient.Email == nil || *message.Recipient.Email == "" {
+			return ErrNoEmailAddress
+		}
+		message.Address = message.Recipient.Email
+	}
+
+	message.Status = models.NotificationStatusPending
+	message.Attempts = 0
+	if err := closeDeadLetter(letter, userID); err != nil {
+		return err
+	}
+	return DeliverNotification(&message)
+}
+
+func resendWebhookDelivery(letter *models.DeadLetter, userID uint) error {
+	var delivery models.WebhookDelivery
+	if letter.WebhookDeliveryID == nil || database.DB.Preload("Subscription").Limit(1).Find(&delivery, *letter.WebhookDeliveryID).Error != nil || delivery.ID == 0 {
+		return ErrDeadLetterMessageGone
+	}
+	if delivery.Subscription.ID == 0 || !delivery.Subscription.IsActive {
+		return ErrSubscriptionInactive
+	}
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	if err := closeDeadLetter(letter, userID); err != nil {
+		return err
+	}
+	return DeliverWebhook(&delivery)
+}
+
+// closeDeadLetter marks a dead letter as re-sent by the admin
+func closeDeadLetter(letter *models.DeadLetter, userID uint) error {
+	now := time.Now()
+	letter.ResolvedAt, letter.ResolvedBy = &now, &userID
+	return database.DB.Model(letter).Updates(map[string]interface{}{"resolved_at": now, "resolved_by": userID}).Error
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"hrms-api/database"
+	"hrms-api/models"
+
+	"gorm.io/gorm"
+)
+
+// Which quota a document would exceed
+const (
+	StorageQuotaEmployee     = "employee"
+	StorageQuotaOrganization = "organization"
+)
+
+// StorageUsage is the document storage an employee and their organization use, and may use, in bytes.
+// A quota of 0 is no limit.
+type StorageUsage struct {
+	EmployeeID         uint   `json:"employee_id" example:"12"`
+	EmployeeUsedBytes  int64  `json:"employee_used_bytes" example:"48234496"`
+	EmployeeQuotaBytes int64  `json:"employee_quota_bytes" example:"52428800"`
+	TotalUsedBytes     int64  `json:"total_used_bytes" example:"1073741824"`
+	TotalQuotaBytes    int64  `json:"total_quota_bytes" example:"0"`
+	RequestedBytes     int64  `json:"requested_bytes,omitempty" example:"5242880"` // Size of the document being stored
+	ExceededQuota      string `json:"exceeded_quota,omitempty" example:"employee"` // employee or organization
+}
+
+// DocumentQuotaBytes returns the per-employee and organization-wide document storage quotas set through
+// the runtime settings, in bytes, 0 for no limit
+func DocumentQuotaBytes() (employee, total int64) {
+	settings := CurrentSettings()
+	return int64(settings.EmployeeDocumentQuotaMB * 1024 * 1024), int64(settings.DocumentStorageQuotaMB * 1024 * 1024)
+}
+
+// DocumentStorageUsed returns the bytes of documents stored for an employee, or for every employee the
+// query sees when employeeID is 0. Deleted documents no longer count, their files are removed.
+func DocumentStorageUsed(db *gorm.DB, employeeID uint) (int64, error) {
+	query := db.Model(&models.Document{})
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	var used int64
+	err := query.Select("COALESCE(SUM(file_size), 0)").Scan(&used).Error
+	return used, err
+}
+
+// CheckDocumentQuota returns ErrStorageQuotaExceeded, with the usage, when storing size more bytes of
+// documents for an employee would exceed their quota or their organization's. When a quota is set, it
+// takes a lock that lasts until db's transaction ends, so that concurrent uploads cannot both fit into
+// the last of the space; call it in the transaction that creates the document. MySQL has no advisory
+// locks that end with the transaction, so the organization's row is locked instead, and SQLite needs no
+// lock as it lets one transaction write at a time.
+func CheckDocumentQuota(db *gorm.DB, employeeID uint, size int64) (StorageUsage, error) {
+	usage := StorageUsage{EmployeeID: employeeID, RequestedBytes: size}
+	usage.EmployeeQuotaBytes, usage.TotalQuotaBytes = DocumentQuotaBytes()
+	if usage.EmployeeQuotaBytes == 0 && usage.TotalQuotaBytes == 0 {
+		return usage, nil
+	}
+
+	organizationID, _ := database.OrganizationFrom(db.Statement.Context)
+	var err error
+	switch database.Dialect() {
+	case database.DriverPostgres:
+		err = db.Exec("SELECT pg_advisory_xact_lock(hashtext('document_storage'), ?)", organizationID).Error
+	case database.DriverMySQL:
+		var locked []uint
+		err = db.Raw("SELECT id FROM organizations WHERE id = ? FOR UPDATE", organizationID).Scan(&locked).Error
+	}
+	if err != nil {
+		return usage, err
+	}
+
+	if usage.EmployeeUsedBytes, err = DocumentStorageUsed(db, employeeID); err != nil {
+		return usage, err
+	}
+	if usage.TotalUsedBytes, err = DocumentStorageUsed(db, 0); err != nil {
+		return usage, err
+	}
+
+	if usage.EmployeeQuotaBytes > 0 && usage.EmployeeUsedBytes+size > usage.EmployeeQuotaBytes {
+		usage.ExceededQuota = StorageQuotaEmployee
+	} else if usage.TotalQuotaBytes > 0 && usage.TotalUsedBytes+size > usage.TotalQuotaBytes {
+		usage.ExceededQuota = StorageQuotaOrganization
+	}
+	if usage.ExceededQuota != "" {
+		return usage, ErrStorageQuotaExceeded
+	}
+	return usage, nil
+}
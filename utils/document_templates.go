@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"hrms-api/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"gorm.io/gorm"
+)
+
+// DocumentPlaceholder is a value document templates can refer to as {{key}}
+type DocumentPlaceholder struct {
+	Key         string `json:"key" example:"employee.full_name"`
+	Description string `json:"description" example:"First and last name"`
+}
+
+// DocumentPlaceholders lists the placeholders document templates can use
+var DocumentPlaceholders = []DocumentPlaceholder{
+	{"employee.full_name", "First and last name"},
+	{"employee.firstname", "First name"},
+	{"employee.lastname", "Last name"},
+	{"employee.nrc", "NRC (national ID number)"},
+	{"employee.email", "Email address"},
+	{"employee.employee_number", "Employee number"},
+	{"employee.address", "Street address"},
+	{"employee.city", "City"},
+	{"employee.department", "Department"},
+	{"employment.type", "Employment type, such as full time"},
+	{"employment.hire_date", "Hire date"},
+	{"employment.start_date", "Start date"},
+	{"employment.end_date", "End date of a fixed-term contract"},
+	{"employment.probation_end_date", "End of probation"},
+	{"employment.notice_period_days", "Notice period in days"},
+	{"employment.work_location", "Work location"},
+	{"employment.work_schedule", "Work schedule"},
+	{"position.title", "Title of the employee's current position"},
+	{"position.code", "Code of the current position"},
+	{"position.department", "Department of the current position"},
+	{"position.level", "Level of the current position"},
+	{"position.salary", "Salary of the current position assignment"},
+	{"organization.name", "Name of the employee's organization"},
+	{"today", "Date the document is generated"},
+}
+
+var documentPlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-z_.]+)\s*\}\}`)
+
+// documentDateLayout is how dates are written in generated documents
+const documentDateLayout = "2 January 2006"
+
+// UnknownDocumentPlaceholders returns the placeholders in text that are not in DocumentPlaceholders
+func UnknownDocumentPlaceholders(text string) []string {
+	known := map[string]bool{}
+	for _, placeholder := range DocumentPlaceholders {
+		known[placeholder.Key] = true
+	}
+	unknown := []string{}
+	seen := map[string]bool{}
+	for _, match := range documentPlaceholderPattern.FindAllStringSubmatch(text, -1) {
+		if key := match[1]; !known[key] && !seen[key] {
+			seen[key] = true
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
+// DocumentTemplateValues collects the values of every placeholder for an employee. Values the
+// employee's records do not have are empty. db must be scoped to the employee's organization.
+func DocumentTemplateValues(db *gorm.DB, employeeID uint) (map[string]string, error) {
+	var employee models.Employee
+	if err := db.First(&employee, employeeID).Error; err != nil {
+		return nil, err
+	}
+	var details models.EmploymentDetails
+	if err := db.Where("employee_id = ?", employeeID).Limit(1).Find(&details).Error; err != nil {
+		return nil, err
+	}
+	var assignment models.PositionAssignment
+	err := db.Preload("Position").Where("employee_id = ? AND is_primary = ? AND end_date IS NULL", employeeID, true).
+		Order("start_date DESC").Limit(1).Find(&assignment).Error
+	if err != nil {
+		return nil, err
+	}
+	var organization models.Organization
+	if err := db.Where("id = ?", employee.OrganizationID).Limit(1).Find(&organization).Error; err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{
+		"employee.full_name":            strings.TrimSpace(employee.Firstname + " " + employee.Lastname),
+		"employee.firstname":            employee.Firstname,
+		"employee.lastname":             employee.Lastname,
+		"employee.nrc":                  stringValue(employee.NRC),
+		"employee.email":                stringValue(employee.Email),
+		"employee.employee_number":      stringValue(employee.EmployeeNumber),
+		"employee.address":              stringValue(employee.Address),
+		"employee.city":                 stringValue(employee.City),
+		"employee.department":           employee.Department,
+		"employment.type":               strings.ReplaceAll(string(details.EmploymentType), "_", " "),
+		"employment.hire_date":          documentDate(details.HireDate),
+		"employment.start_date":         documentDate(details.StartDate),
+		"employment.end_date":           documentDate(details.EndDate),
+		"employment.probation_end_date": documentDate(details.ProbationEndDate),
+		"employment.work_location":      stringValue(details.WorkLocation),
+		"employment.work_schedule":      stringValue(details.WorkSchedule),
+		"organization.name":             organization.Name,
+		"today":                         CompanyToday().Format(documentDateLayout),
+	}
+	if employee.EmployeeNumber == nil && details.EmployeeNumber != nil {
+		values["employee.employee_number"] = *details.EmployeeNumber
+	}
+	if details.NoticePeriod != nil {
+		values["employment.notice_period_days"] = fmt.Sprint(*details.NoticePeriod)
+	}
+	if assignment.ID != 0 {
+		values["position.title"] = assignment.Position.Title
+		values["position.code"] = assignment.Position.Code
+		values["position.department"] = assignment.Position.Department
+		values["position.level"] = stringValue(assignment.Position.Level)
+		if assignment.Salary != nil {
+			values["position.salary"] = fmt.Sprintf("%.2f", *assignment.Salary)
+		}
+	}
+	return values, nil
+}
+
+// RenderDocumentTemplate fills in the placeholders of text from values. It returns the placeholders
+// text uses that have no value, sorted, and leaves them blank.
+func RenderDocumentTemplate(text string, values map[string]string) (string, []string) {
+	missing := map[string]bool{}
+	rendered := documentPlaceholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		key := documentPlaceholderPattern.FindStringSubmatch(match)[1]
+		if values[key] == "" {
+			missing[key] = true
+		}
+		return values[key]
+	})
+	keys := make([]string, 0, len(missing))
+	for key := range missing {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return rendered, keys
+}
+
+// DocumentTemplatePDF lays out a generated document as an A4 PDF: the letterhead, the title, the body
+// with its paragraphs and line breaks kept, and space for the employee's signature
+func DocumentTemplatePDF(title, body string) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(title, true)
+	pdf.SetAuthor(CompanyName(), true)
+	pdf.SetCreator("HRMS API", false)
+	pdf.SetMargins(20, 15, 20)
+	pdf.AddPage()
+	if err := addPDFHeader(pdf); err != nil {
+		return nil, err
+	}
+
+	pdf.SetX(20)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.MultiCell(0, 7, translate(title), "", "C", false)
+	pdf.Ln(6)
+
+	pdf.SetFont("Arial", "", 11)
+	pdf.MultiCell(0, 6, translate(strings.ReplaceAll(body, "\r\n", "\n")), "", "", false)
+
+	pdf.Ln(18)
+	pdf.Cell(80, 6, "______________________________")
+	pdf.Cell(0, 6, "______________________________")
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "", 9)
+	pdf.Cell(80, 5, "Employee signature")
+	pdf.Cell(0, 5, "Date")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// documentDate writes date as generated documents show it, or "" when it is not set
+func documentDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(documentDateLayout)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"html"
+	"sort"
+	"strings"
+)
+
+// EmailPlaceholder is a value email templates can refer to as {{key}}
+type EmailPlaceholder struct {
+	Key         string `json:"key" example:"recipient.firstname"`
+	Description string `json:"description" example:"Recipient's first name"`
+	Example     string `json:"example" example:"Jane"` // Filled in when a template is previewed or test-sent
+}
+
+// EmailCategoryPlaceholders lists the placeholders the email templates of a notification category can use
+type EmailCategoryPlaceholders struct {
+	Category     models.NotificationCategory `json:"category" example:"kudos_received"`
+	Placeholders []EmailPlaceholder          `json:"placeholders"`
+}
+
+// RenderedEmail is an email template filled in for one email
+type RenderedEmail struct {
+	Subject  string   `json:"subject" example:"Reminder: your First aid certificate expires on 2025-07-31"`
+	TextBody string   `json:"text_body"`
+	HTMLBody *string  `json:"html_body,omitempty"`
+	Missing  []string `json:"missing"` // Placeholders the template uses that had no value, left blank
+}
+
+// emailSubjectLength is the longest subject the notifications table holds
+const emailSubjectLength = 200
+
+// commonEmailPlaceholders can be used in the email templates of every category
+var commonEmailPlaceholders = []EmailPlaceholder{
+	{"recipient.full_name", "First and last name of the recipient", "Jane Banda"},
+	{"recipient.firstname", "First name of the recipient", "Jane"},
+	{"default.subject", "Built-in subject, in the recipient's language", "Compliance expiring: First aid certificate"},
+	{"default.message", "Built-in message, in the recipient's language", "First aid certificate expires on 2025-07-31 (in 14 day(s)). Please arrange renewal before it lapses."},
+}
+
+// categoryEmailPlaceholders are the placeholders only the email templates of one category can use
+var categoryEmailPlaceholders = map[models.NotificationCategory][]EmailPlaceholder{
+	models.NotificationComplianceReminder: {
+		{"employee.full_name", "Employee the record belongs to: the recipient, or their report in a manager's copy", "Jane Banda"},
+		{"requirement.name", "Compliance requirement", "First aid certificate"},
+		{"compliance.expiry_date", "Expiry date", "2025-07-31"},
+		{"compliance.days_left", "Days left until it expires", "14"},
+	},
+	models.NotificationComplianceExpired: {
+		{"employee.full_name", "Employee the record belongs to: the recipient, or their report in a manager's copy", "Jane Banda"},
+		{"requirement.name", "Compliance requirement", "First aid certificate"},
+		{"compliance.expiry_date", "Expiry date", "2025-07-31"},
+	},
+	models.NotificationGrievanceAssigned: {
+		{"grievance.reference", "Grievance reference", "GRV-2025-00042"},
+		{"grievance.category", "Grievance category", "pay"},
+		{"grievance.acknowledge_due_at", "When acknowledgement is due", "2025-07-03 09:30"},
+	},
+	models.NotificationGrievanceUpdated: {
+		{"grievance.reference", "Grievance reference", "GRV-2025-00042"},
+		{"grievance.subject", "Grievance subject", "Unpaid overtime"},
+		{"grievance.stage", "Stage the grievance moved to", "resolved"},
+		{"grievance.resolution", "Resolution, once resolved", "Overtime will be paid with the July salary"},
+	},
+	models.NotificationGrievanceSLABreach: {
+		{"grievance.reference", "Grievance reference", "GRV-2025-00042"},
+		{"grievance.category", "Grievance category", "pay"},
+		{"grievance.stage", "Current stage", "investigating"},
+		{"grievance.missed_deadline", "Deadline missed: acknowledgement or resolution", "resolution"},
+	},
+	models.NotificationKudosReceived: {
+		{"sender.full_name", "Employee who sent the kudos", "John Phiri"},
+		{"value.name", "Company value the kudos is for", "Teamwork"},
+		{"kudos.message", "Message of the kudos", "Thanks for covering the month-end close while I was out"},
+	},
+	models.NotificationLeaveApproved: leaveDecisionPlaceholders,
+	models.NotificationLeaveRejected: append(append([]EmailPlaceholder{}, leaveDecisionPlaceholders...),
+		EmailPlaceholder{"leave.rejection_reason", "Reason the leave was rejected", "Insufficient staffing during requested period"}),
+	models.NotificationLeaveSubmitted: append([]EmailPlaceholder{
+		{"employee.full_name", "Employee who requested the leave", "Jane Banda"},
+		{"leave.reason", "Reason given for the leave", "Family wedding"},
+	}, leaveDecisionPlaceholders[:4]...),
+	models.NotificationPasswordChanged: {
+		{"password.changed_at", "When the password was changed, in the company timezone", "2025-07-01 14:05"},
+	},
+}
+
+// leaveDecisionPlaceholders can be used in the email templates of leave approvals and rejections; all
+// but the approver in those of leave requests
+var leaveDecisionPlaceholders = []EmailPlaceholder{
+	{"leave.type", "Leave type", "Annual Leave"},
+	{"leave.start_date", "First day of the leave", "2025-08-04"},
+	{"leave.end_date", "Last day of the leave", "2025-08-08"},
+	{"leave.days", "Number of days", "5"},
+	{"approver.full_name", "Manager or admin who decided", "John Phiri"},
+}
+
+// EmailPlaceholders lists the placeholders the email templates of every category can use
+func EmailPlaceholders() []EmailCategoryPlaceholders {
+	placeholders := make([]EmailCategoryPlaceholders, 0, len(models.NotificationCategories))
+	for _, category := range models.NotificationCategories {
+		placeholders = append(placeholders, EmailCategoryPlaceholders{Category: category, Placeholders: emailPlaceholdersOf(category)})
+	}
+	return placeholders
+}
+
+func emailPlaceholdersOf(category models.NotificationCategory) []EmailPlaceholder {
+	return append(append([]EmailPlaceholder{}, commonEmailPlaceholders...), categoryEmailPlaceholders[category]...)
+}
+
+// UnknownEmailPlaceholders returns the placeholders in text that the category's email templates cannot use
+func UnknownEmailPlaceholders(category models.NotificationCategory, text string) []string {
+	known := map[string]bool{}
+	for _, placeholder := range emailPlaceholdersOf(category) {
+		known[placeholder.Key] = true
+	}
+	unknown := []string{}
+	seen := map[string]bool{}
+	for _, match := range documentPlaceholderPattern.FindAllStringSubmatch(text, -1) {
+		if key := match[1]; !known[key] && !seen[key] {
+			seen[key] = true
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
+// EmailTemplateExampleValues is the example value of every placeholder of the category, which previews
+// and test emails are filled in with
+func EmailTemplateExampleValues(category models.NotificationCategory) map[string]string {
+	values := map[string]string{}
+	for _, placeholder := range emailPlaceholdersOf(category) {
+		values[placeholder.Key] = placeholder.Example
+	}
+	return values
+}
+
+// RenderEmailTemplate fills in the placeholders of a template's subject and bodies from values. Values are
+// escaped in the HTML body, and line breaks in the subject become spaces.
+func RenderEmailTemplate(template models.EmailTemplate, values map[string]string) RenderedEmail {
+	subject, missing := RenderDocumentTemplate(template.Subject, values)
+	subject = strings.Join(strings.Fields(subject), " ")
+	if runes := []rune(subject); len(runes) > emailSubjectLength {
+		subject = string(runes[:emailSubjectLength])
+	}
+	textBody, textMissing := RenderDocumentTemplate(template.TextBody, values)
+	missing = append(missing, textMissing...)
+
+	rendered := RenderedEmail{Subject: subject, TextBody: textBody}
+	if template.HTMLBody != nil {
+		escaped := make(map[string]string, len(values))
+		for key, value := range values {
+			escaped[key] = html.EscapeString(value)
+		}
+		htmlBody, htmlMissing := RenderDocumentTemplate(*template.HTMLBody, escaped)
+		rendered.HTMLBody = &htmlBody
+		missing = append(missing, htmlMissing...)
+	}
+
+	rendered.Missing = []string{}
+	seen := map[string]bool{}
+	for _, key := range missing {
+		if !seen[key] {
+			seen[key] = true
+			rendered.Missing = append(rendered.Missing, key)
+		}
+	}
+	sort.Strings(rendered.Missing)
+	return rendered
+}
+
+// activeEmailTemplate returns the organization's active template for the category in the language, or nil
+// when the built-in email is sent
+func activeEmailTemplate(organizationID uint, category models.NotificationCategory, lang i18n.Language) *models.EmailTemplate {
+	var template models.EmailTemplate
+	err := database.DB.Where("organization_id = ? AND category = ? AND language = ? AND is_active = ?",
+		organizationID, category, string(lang), true).Limit(1).Find(&template).Error
+	if err != nil || template.ID == 0 {
+		return nil
+	}
+	return &template
+}
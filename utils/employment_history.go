@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"hrms-api/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EmploymentSnapshot captures the employment attributes tracked in employment history
+type EmploymentSnapshot struct {
+	Status     *models.EmploymentStatus
+	Position   *string
+	Department *string
+	ManagerID  *uint
+}
+
+// TakeEmploymentSnapshot reads the current status, position, department and manager of an employee.
+// Pass a transaction to read uncommitted changes made within it.
+func TakeEmploymentSnapshot(db *gorm.DB, employeeID uint) EmploymentSnapshot {
+	var snapshot EmploymentSnapshot
+
+	var employee models.Employee
+	if err := db.Preload("Position").First(&employee, employeeID).Error; err == nil {
+		if employee.Department != "" {
+			department := employee.Department
+			snapshot.Department = &department
+		}
+		if employee.Position != nil {
+			title := employee.Position.Title
+			snapshot.Position = &title
+		}
+	}
+
+	var employment models.EmploymentDetails
+	if err := db.Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
+		status := employment.EmploymentStatus
+		snapshot.Status = &status
+		snapshot.ManagerID = employment.ManagerID
+	}
+
+	return snapshot
+}
+
+// RecordEmploymentChange appends an employment history row when any tracked attribute differs
+// between the before and after snapshots. It returns nil without writing when nothing changed.
+func RecordEmploymentChange(db *gorm.DB, employeeID uint, before, after EmploymentSnapshot, changeDate time.Time, reason string, changedBy *uint) error {
+	if sameString(before.Position, after.Position) &&
+		sameString(before.Department, after.Department) &&
+		sameUint(before.ManagerID, after.ManagerID) &&
+		sameStatus(before.Status, after.Status) {
+		return nil
+	}
+
+	return db.Create(newEmploymentHistory(employeeID, before, after, changeDate, reason, changedBy)).Error
+}
+
+// RecordEmploymentEvent appends an employment history row unconditionally, e.g. for lifecycle events
+// that should appear in the history even when no tracked attribute changed
+func RecordEmploymentEvent(db *gorm.DB, employeeID uint, before, after EmploymentSnapshot, changeDate time.Time, reason string, changedBy *uint) error {
+	return db.Create(newEmploymentHistory(employeeID, before, after, changeDate, reason, changedBy)).Error
+}
+
+func newEmploymentHistory(employeeID uint, before, after EmploymentSnapshot, changeDate time.Time, reason string, changedBy *uint) *models.EmploymentHistory {
+	newStatus := models.EmploymentStatusActive
+	if after.Status != nil {
+		newStatus = *after.Status
+	}
+
+	history := &models.EmploymentHistory{
+		EmployeeID:         employeeID,
+		PreviousStatus:     before.Status,
+		NewStatus:          newStatus,
+		PreviousPosition:   before.Position,
+		NewPosition:        after.Position,
+		PreviousDepartment: before.Department,
+		NewDepartment:      after.Department,
+		PreviousManagerID:  before.ManagerID,
+		NewManagerID:       after.ManagerID,
+		ChangeDate:         changeDate,
+		ChangedBy:          changedBy,
+	}
+	if reason != "" {
+		history.ChangeReason = &reason
+	}
+	return history
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameUint(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameStatus(a, b *models.EmploymentStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/models"
+	"strings"
+	"time"
+
+	"github.com/boombuler/barcode/qr"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/jung-kurt/gofpdf/contrib/barcode"
+)
+
+// EmploymentLetterValidity is how long third parties can verify an employment letter after it is issued
+const EmploymentLetterValidity = 90 * 24 * time.Hour
+
+// employmentLetterQRSize is the width and height in millimetres of the QR code linking to the
+// verification page
+const employmentLetterQRSize = 30.0
+
+// employmentLetterCodeAlphabet leaves out characters that are easily misread, such as 0 and O
+const employmentLetterCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// NewEmploymentLetterCode returns a random verification code in four groups of four, such as
+// 7KQ2-M9XD-4TPA-H3VW, too long to be guessed
+func NewEmploymentLetterCode() (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	var code strings.Builder
+	for i, b := range random {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(employmentLetterCodeAlphabet[int(b)%len(employmentLetterCodeAlphabet)])
+	}
+	return code.String(), nil
+}
+
+// NormalizeEmploymentLetterCode returns code as it is stored, so codes typed in lower case or
+// without dashes are still found
+func NormalizeEmploymentLetterCode(code string) string {
+	compact := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	var normalized strings.Builder
+	for i, r := range compact {
+		if i > 0 && i%4 == 0 {
+			normalized.WriteByte('-')
+		}
+		normalized.WriteRune(r)
+	}
+	return normalized.String()
+}
+
+// EmploymentLetterVerifyURL is the public page third parties check an employment letter at
+func EmploymentLetterVerifyURL(code string) string {
+	return config.AppConfig.PublicURL + "/verify/employment/" + code
+}
+
+// EmploymentLetterPDF lays out an employment letter as an A4 PDF on the letterhead, with its
+// verification code and where to check it
+func EmploymentLetterPDF(letter models.EmploymentLetter) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle("Confirmation of employment - "+letter.EmployeeName, true)
+	pdf.SetSubject("Verification code "+letter.Code, false)
+	pdf.SetAuthor(letter.OrganizationName, true)
+	pdf.SetCreator("HRMS API", false)
+	pdf.SetMargins(20, 15, 20)
+	pdf.AddPage()
+	if err := addPDFHeader(pdf); err != nil {
+		return nil, err
+	}
+
+	pdf.SetX(20)
+	pdf.SetFont("Arial", "", 11)
+	pdf.Cell(0, 6, letter.IssuedAt.In(CompanyLocation()).Format(documentDateLayout))
+	pdf.Ln(12)
+	addressee := letter.Addressee
+	if addressee == "" {
+		addressee = "To whom it may concern"
+	}
+	pdf.MultiCell(0, 6, translate(addressee), "", "", false)
+	pdf.Ln(6)
+
+	pdf.SetFont("Arial", "B", 13)
+	pdf.Cell(0, 7, "Confirmation of employment")
+	pdf.Ln(11)
+
+	pdf.SetFont("Arial", "", 11)
+	statement := fmt.Sprintf("This is to confirm that %s is employed by %s", letter.EmployeeName, letter.OrganizationName)
+	if letter.PositionTitle != "" {
+		statement += " as " + letter.PositionTitle
+	}
+	if letter.Department != "" {
+		statement += " in the " + letter.Department + " department"
+	}
+	if letter.EmploymentType != "" {
+		statement += ", on a " + strings.ReplaceAll(letter.EmploymentType, "_", " ") + " basis"
+	}
+	if letter.HireDate != nil {
+		statement += ", and has been since " + letter.HireDate.Format(documentDateLayout)
+	}
+	statement += "."
+	if letter.Salary != nil {
+		statement += fmt.Sprintf(" Their current salary is %.2f.", *letter.Salary)
+	}
+	pdf.MultiCell(0, 6, translate(statement), "", "", false)
+	pdf.Ln(4)
+	pdf.MultiCell(0, 6, "This letter is issued at the employee's request and confirms their employment on the date it was issued.", "", "", false)
+	pdf.Ln(12)
+
+	pdf.Cell(0, 6, "Issued electronically by")
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(0, 6, translate(letter.OrganizationName+", Human Resources"))
+	pdf.Ln(16)
+
+	// The QR code opens the verification page, to the right of the code printed for typing it in
+	verifyURL := EmploymentLetterVerifyURL(letter.Code)
+	qrCode := barcode.RegisterQR(pdf, verifyURL, qr.M, qr.Auto)
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+	pageWidth, _ := pdf.GetPageSize()
+	leftMargin, _, rightMargin, _ := pdf.GetMargins()
+	barcode.Barcode(pdf, qrCode, pageWidth-rightMargin-employmentLetterQRSize, pdf.GetY(),
+		employmentLetterQRSize, employmentLetterQRSize, false)
+
+	textWidth := pageWidth - leftMargin - rightMargin - employmentLetterQRSize - 5
+	pdf.SetFont("Arial", "", 9)
+	pdf.MultiCell(textWidth, 5, fmt.Sprintf("This letter carries no handwritten signature. Its authenticity can be "+
+		"verified until %s by scanning the QR code, or by entering the code below at %s",
+		letter.ExpiresAt.In(CompanyLocation()).Format(documentDateLayout), verifyURL), "", "", false)
+	pdf.Ln(2)
+	pdf.SetFont("Courier", "B", 14)
+	pdf.Cell(textWidth, 8, letter.Code)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
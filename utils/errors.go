@@ -12,5 +12,108 @@ var (
 	ErrLeaveNotFound      = errors.New("leave not found")
 	ErrUnauthorized       = errors.New("unauthorized access")
 	ErrInvalidLeaveType   = errors.New("invalid leave type")
+	ErrNoAnnualLeaveType  = errors.New("annual leave type not found")
+	ErrAlreadyInPosition  = errors.New("employee already holds this position")
+	ErrTransferBeforeStart = errors.New("transfer date must be after the current assignment start date")
+	ErrStorageQuotaExceeded = errors.New("document storage quota exceeded")
 )
 
+// ErrorCode is a stable, machine-readable error identifier returned in the code field of error
+// responses. Clients should branch on it rather than on the message, which may change.
+type ErrorCode string
+
+const (
+	CodeBadRequest           ErrorCode = "bad_request"
+	CodeValidationFailed     ErrorCode = "validation_failed"
+	CodeInvalidJSON          ErrorCode = "invalid_json"
+	CodeUnauthorized         ErrorCode = "unauthorized"
+	CodeInvalidToken         ErrorCode = "invalid_token"
+	CodeForbidden            ErrorCode = "forbidden"
+	CodeNotFound             ErrorCode = "not_found"
+	CodeMethodNotAllowed     ErrorCode = "method_not_allowed"
+	CodeConflict             ErrorCode = "conflict"
+	CodeStaleVersion         ErrorCode = "stale_version"
+	CodePayloadTooLarge      ErrorCode = "payload_too_large"
+	CodeUnsupportedMediaType ErrorCode = "unsupported_media_type"
+	CodeInternal             ErrorCode = "internal_error"
+	CodeNotImplemented       ErrorCode = "not_implemented"
+	CodeUnavailable          ErrorCode = "service_unavailable"
+
+	CodeInvalidCredentials  ErrorCode = "invalid_credentials"
+	CodeUserNotFound        ErrorCode = "user_not_found"
+	CodeInvalidDateRange    ErrorCode = "invalid_date_range"
+	CodePastDate            ErrorCode = "past_date"
+	CodeOverlappingLeave    ErrorCode = "overlapping_leave"
+	CodeInsufficientBalance ErrorCode = "insufficient_balance"
+	CodeLeaveNotFound       ErrorCode = "leave_not_found"
+	CodeLeaveNotPending     ErrorCode = "leave_not_pending"
+	CodeLeaveNotCancellable ErrorCode = "leave_not_cancellable"
+	CodeLeaveAlreadyStarted ErrorCode = "leave_already_started"
+	CodeInvalidLeaveType    ErrorCode = "invalid_leave_type"
+	CodeNoAnnualLeaveType   ErrorCode = "no_annual_leave_type"
+	CodeAlreadyInPosition   ErrorCode = "already_in_position"
+	CodeTransferBeforeStart ErrorCode = "transfer_before_start"
+	CodeInvalidNationalID   ErrorCode = "invalid_national_id"
+	CodeSalaryOutOfBand     ErrorCode = "salary_out_of_band"
+	CodeStorageQuotaExceeded ErrorCode = "storage_quota_exceeded"
+)
+
+// sentinelCodes gives each sentinel error above its code
+var sentinelCodes = map[error]ErrorCode{
+	ErrInvalidCredentials:  CodeInvalidCredentials,
+	ErrUserNotFound:        CodeUserNotFound,
+	ErrInvalidDateRange:    CodeInvalidDateRange,
+	ErrPastDate:            CodePastDate,
+	ErrOverlappingLeave:    CodeOverlappingLeave,
+	ErrInsufficientBalance: CodeInsufficientBalance,
+	ErrLeaveNotFound:       CodeLeaveNotFound,
+	ErrUnauthorized:        CodeForbidden,
+	ErrInvalidLeaveType:    CodeInvalidLeaveType,
+	ErrNoAnnualLeaveType:   CodeNoAnnualLeaveType,
+	ErrAlreadyInPosition:   CodeAlreadyInPosition,
+	ErrTransferBeforeStart: CodeTransferBeforeStart,
+	ErrNationalIDFormat:    CodeInvalidNationalID,
+	ErrNationalIDChecksum:  CodeInvalidNationalID,
+	ErrSalaryOutOfBand:     CodeSalaryOutOfBand,
+	ErrStorageQuotaExceeded: CodeStorageQuotaExceeded,
+}
+
+// ErrorCodeFor returns the code of the sentinel error err is or wraps, or "" if it is none of them
+func ErrorCodeFor(err error) ErrorCode {
+	for sentinel, code := range sentinelCodes {
+		if errors.Is(err, sentinel) {
+			return code
+		}
+	}
+	return ""
+}
+
+// CodeForStatus is the code used for an error that has no more specific one
+func CodeForStatus(status int) ErrorCode {
+	switch status {
+	case 400:
+		return CodeBadRequest
+	case 401:
+		return CodeUnauthorized
+	case 403:
+		return CodeForbidden
+	case 404:
+		return CodeNotFound
+	case 405:
+		return CodeMethodNotAllowed
+	case 409:
+		return CodeConflict
+	case 413:
+		return CodePayloadTooLarge
+	case 415:
+		return CodeUnsupportedMediaType
+	case 501:
+		return CodeNotImplemented
+	case 503:
+		return CodeUnavailable
+	case 507:
+		return CodeStorageQuotaExceeded
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"hrms-api/models"
+	"sync"
+	"time"
+)
+
+// EventType identifies a real-time event pushed to connected clients
+type EventType string
+
+const (
+	EventLeaveSubmitted EventType = "leave_submitted"
+	EventLeaveApproved  EventType = "leave_approved"
+	EventLeaveRejected  EventType = "leave_rejected"
+	EventLeaveCancelled EventType = "leave_cancelled"
+	EventNotification   EventType = "notification"
+)
+
+// eventBufferSize is how many events a slow client may fall behind before further events are dropped
+const eventBufferSize = 32
+
+// Event is a real-time update for a connected client
+type Event struct {
+	Type       EventType   `json:"type"`
+	Data       interface{} `json:"data"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
+
+// eventSubscriber is one connected client, identified by the employee, organization and role from its token
+type eventSubscriber struct {
+	employeeID     uint
+	organizationID uint
+	role           models.Role
+	events         chan Event
+}
+
+var (
+	eventStreamsClosed    = make(chan struct{})
+	closeEventStreamsOnce sync.Once
+)
+
+var eventHub = struct {
+	sync.RWMutex
+	subscribers map[*eventSubscriber]struct{}
+}{subscribers: map[*eventSubscriber]struct{}{}}
+
+// SubscribeEvents registers a client for the events addressed to the employee or to their role in their
+// organization. The returned function unsubscribes and must be called when the client disconnects.
+func SubscribeEvents(employeeID, organizationID uint, role models.Role) (<-chan Event, func()) {
+	subscriber := &eventSubscriber{employeeID: employeeID, organizationID: organizationID, role: role, events: make(chan Event, eventBufferSize)}
+
+	eventHub.Lock()
+	eventHub.subscribers[subscriber] = struct{}{}
+	eventHub.Unlock()
+
+	return subscriber.events, func() {
+		eventHub.Lock()
+		delete(eventHub.subscribers, subscriber)
+		eventHub.Unlock()
+	}
+}
+
+// PublishEvent pushes an event to the connected clients of the given employees and of everyone in the
+// organization with one of the given roles. Each client receives the event at most once. Clients that
+// are too far behind miss the event rather than block the caller.
+func PublishEvent(eventType EventType, data interface{}, organizationID uint, employeeIDs []uint, roles ...models.Role) {
+	event := Event{Type: eventType, Data: data, OccurredAt: time.Now()}
+
+	eventHub.RLock()
+	defer eventHub.RUnlock()
+	for subscriber := range eventHub.subscribers {
+		if !subscriber.wants(organizationID, employeeIDs, roles) {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+		}
+	}
+}
+
+// CloseEventStreams tells open event streams to end, so they do not hold a graceful shutdown open
+func CloseEventStreams() {
+	closeEventStreamsOnce.Do(func() { close(eventStreamsClosed) })
+}
+
+// EventStreamsClosed returns a channel that is closed once CloseEventStreams has been called
+func EventStreamsClosed() <-chan struct{} {
+	return eventStreamsClosed
+}
+
+func (s *eventSubscriber) wants(organizationID uint, employeeIDs []uint, roles []models.Role) bool {
+	for _, id := range employeeIDs {
+		if s.employeeID == id {
+			return true
+		}
+	}
+	if s.organizationID != organizationID {
+		return false
+	}
+	for _, role := range roles {
+		if s.role == role {
+			return true
+		}
+	}
+	return false
+}
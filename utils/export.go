@@ -3,84 +3,84 @@ package utils
 import (
 	"bytes"
 	"fmt"
-	"hrms-api/database"
 	"hrms-api/models"
-	"os"
-	"path/filepath"
+	"io"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jung-kurt/gofpdf"
 	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
 )
 
-const (
-	InstitutionName = "Chudleigh House School"
-	LogoPath        = "static/assets/chslogo.png"
-)
-
-// addPDFHeader adds logo and institution name to PDF
+// addPDFHeader puts the company letterhead at the top of the current page: the logo, with the company
+// name and address beside it, over a rule. From then on every page ends with the company footer, if any.
 func addPDFHeader(pdf *gofpdf.Fpdf) error {
-	// Try multiple possible paths for the logo
-	possiblePaths := []string{
-		LogoPath,
-		filepath.Join("static", "assets", "chslogo.png"),
-		"./static/assets/chslogo.png",
-		filepath.Join(".", "static", "assets", "chslogo.png"),
-		"/home/andrea/Documents/Sources/hrms-api/static/assets/chslogo.png",
-	}
-
-	var logoPath string
-	var found bool
-	for _, path := range possiblePaths {
-		if _, err := os.Stat(path); err == nil {
-			logoPath = path
-			found = true
-			break
-		}
+	settings := CurrentSettings()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	left, top, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+
+	if settings.CompanyFooter != "" {
+		pdf.SetFooterFunc(func() {
+			pdf.SetY(-12)
+			pdf.SetFont("Arial", "I", 8)
+			pdf.SetTextColor(100, 100, 100)
+			pdf.CellFormat(0, 5, tr(settings.CompanyFooter), "", 0, "C", false, 0, "")
+		})
 	}
 
-	if !found {
-		// Logo not found, just add text header
-		pdf.SetXY(10, 10)
-		pdf.SetFont("Arial", "B", 18)
-		pdf.Cell(0, 10, InstitutionName)
-		pdf.Ln(8)
-		return nil
+	// The logo set in the company_logo setting, else the bundled one
+	var logo *gofpdf.ImageInfoType
+	logoName := "company_logo"
+	opt := gofpdf.ImageOptions{ImageType: "PNG"}
+	if len(settings.CompanyLogo) > 0 {
+		opt.ImageType = settings.CompanyLogoType
+		logo = pdf.RegisterImageOptionsReader(logoName, opt, bytes.NewReader(settings.CompanyLogo))
+	} else if path, ok := defaultLogoPath(); ok {
+		logoName = path
+		logo = pdf.RegisterImageOptions(path, opt)
 	}
 
-	// Register image option
-	opt := gofpdf.ImageOptions{
-		ImageType: "PNG",
+	// Logos are 30 mm high, or less when that would make them wider than 60 mm
+	textX, logoHeight := left, 0.0
+	if logo != nil && logo.Height() > 0 {
+		logoWidth, height := 30*logo.Width()/logo.Height(), 30.0
+		if logoWidth > 60 {
+			logoWidth, height = 60, 60*logo.Height()/logo.Width()
+		}
+		pdf.ImageOptions(logoName, left, top, logoWidth, height, false, opt, 0, "")
+		textX, logoHeight = left+logoWidth+5, height
 	}
 
-	// Try to register the image
-	imageInfo := pdf.RegisterImageOptions(logoPath, opt)
-	if imageInfo == nil {
-		// Image registration failed, just add text header
-		pdf.SetXY(10, 10)
-		pdf.SetFont("Arial", "B", 18)
-		pdf.Cell(0, 10, InstitutionName)
-		pdf.Ln(8)
-		return nil
+	// The name and address are centered vertically on the logo
+	var addressLines []string
+	for _, line := range strings.Split(settings.CompanyAddress, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			addressLines = append(addressLines, line)
+		}
 	}
-
-	// Get image dimensions and scale appropriately
-	imgWidth := 30.0  // mm
-	imgHeight := 30.0 // mm
-
-	// Add logo on the left at position (10, 10)
-	pdf.ImageOptions(logoPath, 10, 10, imgWidth, imgHeight, false, opt, 0, "")
-
-	// Add institution name next to logo (vertically centered with logo)
-	pdf.SetXY(10+imgWidth+5, 10+(imgHeight/2)-9)
+	textHeight := 10 + 4.5*float64(len(addressLines))
+	y := top
+	if textHeight < logoHeight {
+		y += (logoHeight - textHeight) / 2
+	}
+	pdf.SetXY(textX, y)
 	pdf.SetFont("Arial", "B", 18)
-	pdf.Cell(0, 10, InstitutionName)
-	
+	pdf.Cell(0, 10, tr(settings.CompanyName))
+	y += 10
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range addressLines {
+		pdf.SetXY(textX, y)
+		pdf.Cell(0, 4.5, tr(line))
+		y += 4.5
+	}
+
 	// Move to position after header for content
-	pdf.SetXY(10, 10+imgHeight+10)
-	
-	// Add a line separator
-	pdf.Line(10, pdf.GetY(), 200, pdf.GetY())
+	pdf.SetXY(left, math.Max(y, top+logoHeight)+5)
+	pdf.Line(left, pdf.GetY(), pageWidth-right, pdf.GetY())
 	pdf.Ln(5)
 
 	return nil
@@ -88,39 +88,55 @@ func addPDFHeader(pdf *gofpdf.Fpdf) error {
 
 // AnnualLeaveBalanceExport represents data for export
 type AnnualLeaveBalanceExport struct {
-	EmployeeID     uint
-	EmployeeName   string
-	Department     string
-	TotalAccrued   float64
-	TotalUsed      float64
-	CurrentBalance float64
-	PendingLeaves  int
-	UpcomingLeaves int
+	EmployeeID       uint
+	EmployeeName     string
+	Department       string
+	TotalAccrued     float64
+	TotalUsed        float64
+	CurrentBalance   float64
+	PendingLeaves    int
+	UpcomingLeaves   int
+	LeaveYearAccrued float64 // Days accrued so far in the current leave year
+	LeaveYearUsed    float64 // Days of approved leave in the current leave year
+
+	// The carry-over balance, monthly accrual ledger and approved leaves, gathered only for detailed exports
+	CarryOverBalance float64
+	Accruals         []AccrualExport
+	ApprovedLeaves   []LeaveExport
 }
 
 // EmployeeBalanceData represents employee balance data for export
 type EmployeeBalanceData struct {
-	EmployeeID     uint
-	EmployeeName   string
-	Department     string
-	TotalAccrued   float64
-	TotalUsed      float64
-	CurrentBalance float64
-	PendingLeaves  int
-	UpcomingLeaves int
+	EmployeeID       uint
+	EmployeeName     string
+	Department       string
+	TotalAccrued     float64
+	TotalUsed        float64
+	CurrentBalance   float64
+	PendingLeaves    int
+	UpcomingLeaves   int
+	LeaveYearAccrued float64 // Days accrued so far in the current leave year
+	LeaveYearUsed    float64 // Days of approved leave in the current leave year
+
+	// The carry-over balance, monthly accrual ledger and approved leaves, gathered only for detailed exports
+	CarryOverBalance float64
+	Accruals         []AccrualExport
+	ApprovedLeaves   []LeaveExport
 }
 
-// ExportAnnualLeaveBalancesToExcel exports annual leave balances to Excel format
-func ExportAnnualLeaveBalancesToExcel(balances []AnnualLeaveBalanceExport) ([]byte, error) {
+// ExportAnnualLeaveBalancesToExcel writes annual leave balances to w in Excel format, in the locale. A
+// detailed export adds a sheet summarizing the balances by department and a sheet per employee with
+// their accrual ledger and approved leaves.
+func ExportAnnualLeaveBalancesToExcel(w io.Writer, balances []AnnualLeaveBalanceExport, detailed bool, locale ExportLocale) error {
 	f := excelize.NewFile()
 	defer f.Close()
 
-	sheetName := "Annual Leave Balances"
+	sheetName := locale.T("Annual Leave Balances")
 	f.NewSheet(sheetName)
 	f.DeleteSheet("Sheet1")
 
 	// Add institution name in first row
-	f.SetCellValue(sheetName, "A1", InstitutionName)
+	f.SetCellValue(sheetName, "A1", CompanyName())
 	instStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
 			Bold: true,
@@ -130,7 +146,9 @@ func ExportAnnualLeaveBalancesToExcel(balances []AnnualLeaveBalanceExport) ([]by
 	f.SetCellStyle(sheetName, "A1", "A1", instStyle)
 
 	// Set column headers (starting from row 2)
-	headers := []string{"Employee ID", "Employee Name", "Department", "Total Accrued", "Total Used", "Current Balance"}
+	leaveYear := LeaveYearLabel(CurrentLeaveYear())
+	headers := []string{locale.T("Employee ID"), locale.T("Employee Name"), locale.T("Department"), locale.T("Total Accrued"),
+		locale.T("Total Used"), locale.T("Current Balance"), locale.T("Accrued %s", leaveYear), locale.T("Used %s", leaveYear)}
 	headerStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
 			Bold: true,
@@ -157,7 +175,7 @@ func ExportAnnualLeaveBalancesToExcel(balances []AnnualLeaveBalanceExport) ([]by
 	f.SetColWidth(sheetName, "A", "A", 12)
 	f.SetColWidth(sheetName, "B", "B", 25)
 	f.SetColWidth(sheetName, "C", "C", 20)
-	f.SetColWidth(sheetName, "D", "F", 15)
+	f.SetColWidth(sheetName, "D", "H", 15)
 
 	// Write data (starting from row 3)
 	for i, balance := range balances {
@@ -165,9 +183,11 @@ func ExportAnnualLeaveBalancesToExcel(balances []AnnualLeaveBalanceExport) ([]by
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), balance.EmployeeID)
 		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), balance.EmployeeName)
 		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), balance.Department)
-		f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), balance.TotalAccrued, 2, 64)
-		f.SetCellFloat(sheetName, fmt.Sprintf("E%d", row), balance.TotalUsed, 2, 64)
-		f.SetCellFloat(sheetName, fmt.Sprintf("F%d", row), balance.CurrentBalance, 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), RoundLeaveDays(balance.TotalAccrued), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("E%d", row), RoundLeaveDays(balance.TotalUsed), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("F%d", row), RoundLeaveDays(balance.CurrentBalance), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("G%d", row), RoundLeaveDays(balance.LeaveYearAccrued), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("H%d", row), RoundLeaveDays(balance.LeaveYearUsed), 2, 64)
 	}
 
 	// Add summary row
@@ -176,34 +196,147 @@ func ExportAnnualLeaveBalancesToExcel(balances []AnnualLeaveBalanceExport) ([]by
 		Font: &excelize.Font{Bold: true},
 		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0E0E0"}, Pattern: 1},
 	})
-	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow), "TOTAL")
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryRow), locale.T("TOTAL"))
 	f.SetCellFormula(sheetName, fmt.Sprintf("D%d", summaryRow), fmt.Sprintf("SUM(D3:D%d)", len(balances)+2))
 	f.SetCellFormula(sheetName, fmt.Sprintf("E%d", summaryRow), fmt.Sprintf("SUM(E3:E%d)", len(balances)+2))
 	f.SetCellFormula(sheetName, fmt.Sprintf("F%d", summaryRow), fmt.Sprintf("SUM(F3:F%d)", len(balances)+2))
-	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow), fmt.Sprintf("F%d", summaryRow), summaryStyle)
+	f.SetCellFormula(sheetName, fmt.Sprintf("G%d", summaryRow), fmt.Sprintf("SUM(G3:G%d)", len(balances)+2))
+	f.SetCellFormula(sheetName, fmt.Sprintf("H%d", summaryRow), fmt.Sprintf("SUM(H3:H%d)", len(balances)+2))
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryRow), fmt.Sprintf("H%d", summaryRow), summaryStyle)
 
 	// Add timestamp
 	timestampRow := summaryRow + 2
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", timestampRow), fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", timestampRow), locale.Generated())
+
+	if detailed {
+		writeDepartmentBalancesSheet(f, balances, locale)
+		for _, balance := range balances {
+			employeeSheet := employeeSheetName(balance.EmployeeID, balance.EmployeeName)
+			f.NewSheet(employeeSheet)
+			writeEmployeeAnnualLeaveSheet(f, employeeSheet, EmployeeAnnualLeaveReport{
+				EmployeeID:        balance.EmployeeID,
+				EmployeeName:      balance.EmployeeName,
+				Department:        balance.Department,
+				TotalAccrued:      balance.TotalAccrued,
+				TotalUsed:         balance.TotalUsed,
+				CurrentBalance:    balance.CurrentBalance,
+				CarryOverBalance:  balance.CarryOverBalance,
+				AllTimeNetBalance: balance.TotalAccrued - balance.TotalUsed,
+				LeaveYear:         CurrentLeaveYear(),
+				LeaveYearAccrued:  balance.LeaveYearAccrued,
+				LeaveYearUsed:     balance.LeaveYearUsed,
+				Accruals:          balance.Accruals,
+				ApprovedLeaves:    balance.ApprovedLeaves,
+			}, locale)
+		}
+	}
 
-	buf, err := f.WriteToBuffer()
-	if err != nil {
-		return nil, err
+	return f.Write(w)
+}
+
+// writeDepartmentBalancesSheet adds a sheet to f totalling annual leave balances by department, in the locale
+func writeDepartmentBalancesSheet(f *excelize.File, balances []AnnualLeaveBalanceExport, locale ExportLocale) {
+	type departmentTotals struct {
+		employees                                               int
+		accrued, used, balance, leaveYearAccrued, leaveYearUsed float64
 	}
-	return buf.Bytes(), nil
+	totals := make(map[string]*departmentTotals)
+	var departments []string
+	for _, balance := range balances {
+		t, ok := totals[balance.Department]
+		if !ok {
+			t = &departmentTotals{}
+			totals[balance.Department] = t
+			departments = append(departments, balance.Department)
+		}
+		t.employees++
+		t.accrued += balance.TotalAccrued
+		t.used += balance.TotalUsed
+		t.balance += balance.CurrentBalance
+		t.leaveYearAccrued += balance.LeaveYearAccrued
+		t.leaveYearUsed += balance.LeaveYearUsed
+	}
+	sort.Strings(departments)
+
+	sheetName := locale.T("By Department")
+	f.NewSheet(sheetName)
+
+	f.SetCellValue(sheetName, "A1", CompanyName())
+	instStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 14},
+	})
+	f.SetCellStyle(sheetName, "A1", "A1", instStyle)
+
+	leaveYear := LeaveYearLabel(CurrentLeaveYear())
+	headers := []string{locale.T("Department"), locale.T("Employees"), locale.T("Total Accrued"), locale.T("Total Used"),
+		locale.T("Current Balance"), locale.T("Accrued %s", leaveYear), locale.T("Used %s", leaveYear)}
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	for i, header := range headers {
+		cell := fmt.Sprintf("%c2", 'A'+i)
+		f.SetCellValue(sheetName, cell, header)
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+	}
+
+	f.SetColWidth(sheetName, "A", "A", 25)
+	f.SetColWidth(sheetName, "B", "G", 15)
+
+	for i, department := range departments {
+		row := i + 3
+		t := totals[department]
+		if department == "" {
+			department = locale.T("No department")
+		}
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), department)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), t.employees)
+		f.SetCellFloat(sheetName, fmt.Sprintf("C%d", row), RoundLeaveDays(t.accrued), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), RoundLeaveDays(t.used), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("E%d", row), RoundLeaveDays(t.balance), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("F%d", row), RoundLeaveDays(t.leaveYearAccrued), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("G%d", row), RoundLeaveDays(t.leaveYearUsed), 2, 64)
+	}
+
+	summaryRow := len(departments) + 4
+	summaryStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0E0E0"}, Pattern: 1},
+	})
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryRow), locale.T("TOTAL"))
+	for _, col := range []string{"B", "C", "D", "E", "F", "G"} {
+		f.SetCellFormula(sheetName, fmt.Sprintf("%s%d", col, summaryRow), fmt.Sprintf("SUM(%s3:%s%d)", col, col, len(departments)+2))
+	}
+	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", summaryRow), fmt.Sprintf("G%d", summaryRow), summaryStyle)
 }
 
-// ExportAnnualLeaveBalancesToPDF exports annual leave balances to PDF format
-func ExportAnnualLeaveBalancesToPDF(balances []AnnualLeaveBalanceExport) ([]byte, error) {
-	pdf := gofpdf.New("L", "mm", "A4", "")
+// employeeSheetName names an employee's sheet by their ID and name, leaving out the characters Excel
+// does not allow in sheet names and keeping within its 31 characters
+func employeeSheetName(employeeID uint, employeeName string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]'`, r) {
+			return -1
+		}
+		return r
+	}, fmt.Sprintf("%d %s", employeeID, employeeName))
+	if runes := []rune(name); len(runes) > 31 {
+		name = strings.TrimSpace(string(runes[:31]))
+	}
+	return name
+}
+
+// ExportAnnualLeaveBalancesToPDF writes annual leave balances to w in PDF format, in the locale
+func ExportAnnualLeaveBalancesToPDF(w io.Writer, balances []AnnualLeaveBalanceExport, locale ExportLocale) error {
+	pdf, tr := locale.newPDF("L")
 	pdf.AddPage()
-	
+
 	// Add logo and header
 	_ = addPDFHeader(pdf)
-	
+
 	// Add report title
 	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(40, 10, "Annual Leave Balance Report")
+	pdf.Cell(40, 10, tr(locale.T("Annual Leave Balance Report")))
 	pdf.Ln(12)
 
 	// Set font for table
@@ -211,12 +344,14 @@ func ExportAnnualLeaveBalancesToPDF(balances []AnnualLeaveBalanceExport) ([]byte
 	pdf.SetFillColor(200, 200, 200)
 
 	// Table headers
-	headers := []string{"ID", "Employee Name", "Department", "Accrued", "Used", "Balance"}
-	colWidths := []float64{15, 50, 40, 25, 25, 25}
+	leaveYear := LeaveYearLabel(CurrentLeaveYear())
+	headers := []string{locale.T("ID"), locale.T("Employee Name"), locale.T("Department"), locale.T("Accrued"), locale.T("Used"),
+		locale.T("Balance"), locale.T("Accrued %s", leaveYear), locale.T("Used %s", leaveYear)}
+	colWidths := []float64{15, 50, 40, 25, 25, 25, 35, 35}
 
 	// Draw header row
 	for i, header := range headers {
-		pdf.CellFormat(colWidths[i], 8, header, "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths[i], 8, tr(header), "1", 0, "C", true, 0, "")
 	}
 	pdf.Ln(8)
 
@@ -230,7 +365,7 @@ func ExportAnnualLeaveBalancesToPDF(balances []AnnualLeaveBalanceExport) ([]byte
 			pdf.SetFont("Arial", "B", 10)
 			pdf.SetFillColor(200, 200, 200)
 			for j, header := range headers {
-				pdf.CellFormat(colWidths[j], 8, header, "1", 0, "C", true, 0, "")
+				pdf.CellFormat(colWidths[j], 8, tr(header), "1", 0, "C", true, 0, "")
 			}
 			pdf.Ln(8)
 			pdf.SetFont("Arial", "", 9)
@@ -238,11 +373,13 @@ func ExportAnnualLeaveBalancesToPDF(balances []AnnualLeaveBalanceExport) ([]byte
 		}
 
 		pdf.CellFormat(colWidths[0], 7, fmt.Sprintf("%d", balance.EmployeeID), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[1], 7, balance.EmployeeName, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[2], 7, balance.Department, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[3], 7, fmt.Sprintf("%.1f", balance.TotalAccrued), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(colWidths[4], 7, fmt.Sprintf("%.1f", balance.TotalUsed), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(colWidths[5], 7, fmt.Sprintf("%.1f", balance.CurrentBalance), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[1], 7, tr(balance.EmployeeName), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[2], 7, tr(balance.Department), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[3], 7, locale.Days(balance.TotalAccrued), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[4], 7, locale.Days(balance.TotalUsed), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[5], 7, locale.Days(balance.CurrentBalance), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[6], 7, locale.Days(balance.LeaveYearAccrued), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[7], 7, locale.Days(balance.LeaveYearUsed), "1", 0, "R", false, 0, "")
 		pdf.Ln(7)
 	}
 
@@ -257,48 +394,49 @@ func ExportAnnualLeaveBalancesToPDF(balances []AnnualLeaveBalanceExport) ([]byte
 	}
 	// Calculate expected balance from accruals (without carry-over)
 	calculatedBalance = totalAccrued - totalUsed
-	pdf.Cell(40, 8, fmt.Sprintf("Total Employees: %d", len(balances)))
+	pdf.Cell(40, 8, tr(locale.T("Total Employees: %d", len(balances))))
 	pdf.Ln(5)
-	pdf.Cell(40, 8, fmt.Sprintf("Total Accrued: %.1f days", totalAccrued))
+	pdf.Cell(40, 8, tr(locale.T("Total Accrued: %s days", locale.Days(totalAccrued))))
 	pdf.Ln(5)
-	pdf.Cell(40, 8, fmt.Sprintf("Total Used: %.1f days", totalUsed))
+	pdf.Cell(40, 8, tr(locale.T("Total Used: %s days", locale.Days(totalUsed))))
 	pdf.Ln(5)
-	pdf.Cell(40, 8, fmt.Sprintf("Balance (Accrued - Used): %.1f days", calculatedBalance))
+	pdf.Cell(40, 8, tr(locale.T("Balance (Accrued - Used): %s days", locale.Days(calculatedBalance))))
 	pdf.Ln(5)
-	pdf.Cell(40, 8, fmt.Sprintf("Total Current Balance: %.1f days", totalBalance))
+	pdf.Cell(40, 8, tr(locale.T("Total Current Balance: %s days", locale.Days(totalBalance))))
 	pdf.Ln(5)
 	if totalBalance != calculatedBalance {
 		carryOverDiff := totalBalance - calculatedBalance
 		pdf.SetFont("Arial", "", 9)
-		pdf.Cell(40, 6, fmt.Sprintf("(Difference: %.1f days - includes carry-over and manual adjustments)", carryOverDiff))
+		pdf.Cell(40, 6, tr(locale.T("(Difference: %s days - includes carry-over and manual adjustments)", locale.Days(carryOverDiff))))
 		pdf.Ln(5)
 		pdf.SetFont("Arial", "B", 10)
 	}
 	pdf.SetFont("Arial", "", 8)
-	pdf.Cell(40, 6, fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+	pdf.Cell(40, 6, tr(locale.Generated()))
 
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return pdf.Output(w)
 }
 
-// PrepareBalancesForExport converts balance data to export format
+// PrepareBalancesForExport converts balance data to export format, with days rounded for display so
+// that totals add up to the figures shown
 func PrepareBalancesForExport(employeeBalances []EmployeeBalanceData) []AnnualLeaveBalanceExport {
 	exports := make([]AnnualLeaveBalanceExport, 0, len(employeeBalances))
 
 	for _, balance := range employeeBalances {
 		exports = append(exports, AnnualLeaveBalanceExport{
-			EmployeeID:     balance.EmployeeID,
-			EmployeeName:   balance.EmployeeName,
-			Department:     balance.Department,
-			TotalAccrued:   balance.TotalAccrued,
-			TotalUsed:      balance.TotalUsed,
-			CurrentBalance: balance.CurrentBalance,
-			PendingLeaves:  balance.PendingLeaves,
-			UpcomingLeaves: balance.UpcomingLeaves,
+			EmployeeID:       balance.EmployeeID,
+			EmployeeName:     balance.EmployeeName,
+			Department:       balance.Department,
+			TotalAccrued:     RoundLeaveDays(balance.TotalAccrued),
+			TotalUsed:        RoundLeaveDays(balance.TotalUsed),
+			CurrentBalance:   RoundLeaveDays(balance.CurrentBalance),
+			PendingLeaves:    balance.PendingLeaves,
+			UpcomingLeaves:   balance.UpcomingLeaves,
+			LeaveYearAccrued: RoundLeaveDays(balance.LeaveYearAccrued),
+			LeaveYearUsed:    RoundLeaveDays(balance.LeaveYearUsed),
+			CarryOverBalance: RoundLeaveDays(balance.CarryOverBalance),
+			Accruals:         balance.Accruals,
+			ApprovedLeaves:   balance.ApprovedLeaves,
 		})
 	}
 
@@ -315,6 +453,9 @@ type EmployeeAnnualLeaveReport struct {
 	CurrentBalance    float64
 	CarryOverBalance  float64
 	AllTimeNetBalance float64
+	LeaveYear         int     // Current leave year
+	LeaveYearAccrued  float64 // Days accrued so far in the leave year
+	LeaveYearUsed     float64 // Days of approved leave in the leave year
 	Accruals          []AccrualExport
 	ApprovedLeaves    []LeaveExport
 }
@@ -337,15 +478,27 @@ type LeaveExport struct {
 	Reason    string
 }
 
-// ExportEmployeeAnnualLeaveToExcel exports single employee annual leave report to Excel
-func ExportEmployeeAnnualLeaveToExcel(report EmployeeAnnualLeaveReport) ([]byte, error) {
+// ExportEmployeeAnnualLeaveToExcel exports single employee annual leave report to Excel, in the locale
+func ExportEmployeeAnnualLeaveToExcel(report EmployeeAnnualLeaveReport, locale ExportLocale) ([]byte, error) {
 	f := excelize.NewFile()
 	defer f.Close()
 
-	sheetName := "Annual Leave Report"
+	sheetName := locale.T("Annual Leave Report")
 	f.NewSheet(sheetName)
 	f.DeleteSheet("Sheet1")
 
+	writeEmployeeAnnualLeaveSheet(f, sheetName, report, locale)
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeEmployeeAnnualLeaveSheet writes an employee's annual leave report, with the accrual ledger and
+// approved leaves, to a sheet of f, in the locale
+func writeEmployeeAnnualLeaveSheet(f *excelize.File, sheetName string, report EmployeeAnnualLeaveReport, locale ExportLocale) {
 	// Header style
 	headerStyle, _ := f.NewStyle(&excelize.Style{
 		Font:      &excelize.Font{Bold: true, Size: 14},
@@ -359,7 +512,7 @@ func ExportEmployeeAnnualLeaveToExcel(report EmployeeAnnualLeaveReport) ([]byte,
 	})
 
 	// Add institution name
-	f.SetCellValue(sheetName, "A1", InstitutionName)
+	f.SetCellValue(sheetName, "A1", CompanyName())
 	instStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
 			Bold: true,
@@ -370,52 +523,68 @@ func ExportEmployeeAnnualLeaveToExcel(report EmployeeAnnualLeaveReport) ([]byte,
 
 	// Employee Information
 	row := 2
-	f.SetCellValue(sheetName, "A2", "Employee Annual Leave Report")
+	f.SetCellValue(sheetName, "A2", locale.T("Employee Annual Leave Report"))
 	f.MergeCell(sheetName, "A2", "F2")
 	f.SetCellStyle(sheetName, "A2", "F2", headerStyle)
 
 	row = 4
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Employee Name:")
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Employee Name:"))
 	f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), report.EmployeeName)
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Employee ID:")
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Employee ID:"))
 	f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), report.EmployeeID)
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Department:")
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Department:"))
 	f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), report.Department)
 	row++
 
 	// Summary Section
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Summary")
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Summary"))
 	f.MergeCell(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row))
 	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row), subHeaderStyle)
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Total Accrued:")
-	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), report.TotalAccrued, 2, 64)
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Total Accrued:"))
+	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.TotalAccrued), 2, 64)
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Total Used:")
-	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), report.TotalUsed, 2, 64)
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Total Used:"))
+	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.TotalUsed), 2, 64)
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Current Balance:")
-	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), report.CurrentBalance, 2, 64)
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Current Balance:"))
+	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.CurrentBalance), 2, 64)
 	if report.CarryOverBalance > 0 {
 		row++
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Carry-Over Balance:")
-		f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), report.CarryOverBalance, 2, 64)
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Carry-Over Balance:"))
+		f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.CarryOverBalance), 2, 64)
 	}
 	row++
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "All-Time Net Balance:")
-	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), report.AllTimeNetBalance, 2, 64)
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("All-Time Net Balance:"))
+	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.AllTimeNetBalance), 2, 64)
+
+	// Leave Year Section
+	row += 2
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Leave Year %s", LeaveYearLabel(report.LeaveYear)))
+	f.MergeCell(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row))
+	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row), subHeaderStyle)
+	row++
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Period:"))
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), locale.T("%s to %s", locale.Date(LeaveYearStart(report.LeaveYear)), locale.Date(LeaveYearEnd(report.LeaveYear))))
+	row++
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Accrued to Date:"))
+	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.LeaveYearAccrued), 2, 64)
+	row++
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Used to Date:"))
+	f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(report.LeaveYearUsed), 2, 64)
 
 	// Monthly Accrual History
 	row += 2
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Monthly Accrual History")
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Monthly Accrual History"))
 	f.MergeCell(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("F%d", row))
 	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("F%d", row), subHeaderStyle)
 
 	row++
-	accrualHeaders := []string{"Month", "Days Accrued", "Days Used", "Days Balance", "Processed", "Processed At"}
+	accrualHeaders := []string{locale.T("Month"), locale.T("Days Accrued"), locale.T("Days Used"), locale.T("Days Balance"),
+		locale.T("Processed"), locale.T("Processed At")}
 	accrualHeaderStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{Bold: true},
 		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D0D0D0"}, Pattern: 1},
@@ -433,22 +602,22 @@ func ExportEmployeeAnnualLeaveToExcel(report EmployeeAnnualLeaveReport) ([]byte,
 	for _, acc := range report.Accruals {
 		row++
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), acc.Month)
-		f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), acc.DaysAccrued, 2, 64)
-		f.SetCellFloat(sheetName, fmt.Sprintf("C%d", row), acc.DaysUsed, 2, 64)
-		f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), acc.DaysBalance, 2, 64)
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), map[bool]string{true: "Yes", false: "No"}[acc.IsProcessed])
+		f.SetCellFloat(sheetName, fmt.Sprintf("B%d", row), RoundLeaveDays(acc.DaysAccrued), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("C%d", row), RoundLeaveDays(acc.DaysUsed), 2, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), RoundLeaveDays(acc.DaysBalance), 2, 64)
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), locale.YesNo(acc.IsProcessed))
 		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), acc.ProcessedAt)
 	}
 
 	// Approved Leaves History
 	if len(report.ApprovedLeaves) > 0 {
 		row += 2
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), "Approved Leaves History")
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Approved Leaves History"))
 		f.MergeCell(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("D%d", row))
 		f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("D%d", row), subHeaderStyle)
 
 		row++
-		leaveHeaders := []string{"Start Date", "End Date", "Duration (Days)", "Reason"}
+		leaveHeaders := []string{locale.T("Start Date"), locale.T("End Date"), locale.T("Duration (Days)"), locale.T("Reason")}
 		for i, header := range leaveHeaders {
 			cell := fmt.Sprintf("%c%d", 'A'+i, row)
 			f.SetCellValue(sheetName, cell, header)
@@ -459,97 +628,99 @@ func ExportEmployeeAnnualLeaveToExcel(report EmployeeAnnualLeaveReport) ([]byte,
 			row++
 			f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), leave.StartDate)
 			f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), leave.EndDate)
-			f.SetCellFloat(sheetName, fmt.Sprintf("C%d", row), leave.Duration, 2, 64)
+			f.SetCellFloat(sheetName, fmt.Sprintf("C%d", row), RoundLeaveDays(leave.Duration), 2, 64)
 			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), leave.Reason)
 		}
 	}
 
 	// Timestamp
 	row += 2
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
-
-	buf, err := f.WriteToBuffer()
-	if err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.Generated())
 }
 
-// ExportEmployeeAnnualLeaveToPDF exports single employee annual leave report to PDF
-func ExportEmployeeAnnualLeaveToPDF(report EmployeeAnnualLeaveReport) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
+// ExportEmployeeAnnualLeaveToPDF exports single employee annual leave report to PDF, in the locale
+func ExportEmployeeAnnualLeaveToPDF(report EmployeeAnnualLeaveReport, locale ExportLocale) ([]byte, error) {
+	pdf, tr := locale.newPDF("P")
 	pdf.AddPage()
 
 	// Add logo and header
 	if err := addPDFHeader(pdf); err != nil {
 		// If logo fails, continue without it
 		pdf.SetFont("Arial", "B", 18)
-		pdf.Cell(0, 10, InstitutionName)
+		pdf.Cell(0, 10, tr(CompanyName()))
 		pdf.Ln(8)
 	}
 
 	// Title
 	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(40, 10, "Employee Annual Leave Report")
+	pdf.Cell(40, 10, tr(locale.T("Employee Annual Leave Report")))
 	pdf.Ln(12)
 
 	// Employee Information
 	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 8, "Employee Information")
+	pdf.Cell(40, 8, tr(locale.T("Employee Information")))
 	pdf.Ln(8)
 	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(40, 6, fmt.Sprintf("Name: %s", report.EmployeeName))
+	pdf.Cell(40, 6, tr(locale.T("Name: %s", report.EmployeeName)))
 	pdf.Ln(6)
-	pdf.Cell(40, 6, fmt.Sprintf("ID: %d", report.EmployeeID))
+	pdf.Cell(40, 6, tr(locale.T("ID: %d", report.EmployeeID)))
 	pdf.Ln(6)
-	pdf.Cell(40, 6, fmt.Sprintf("Department: %s", report.Department))
+	pdf.Cell(40, 6, tr(locale.T("Department: %s", report.Department)))
 	pdf.Ln(10)
 
 	// Summary
 	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 8, "Summary")
+	pdf.Cell(40, 8, tr(locale.T("Summary")))
 	pdf.Ln(8)
 	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(40, 6, fmt.Sprintf("Total Accrued: %.1f days", report.TotalAccrued))
+	pdf.Cell(40, 6, tr(locale.T("Total Accrued: %s days", locale.Days(report.TotalAccrued))))
 	pdf.Ln(6)
-	pdf.Cell(40, 6, fmt.Sprintf("Total Used: %.1f days", report.TotalUsed))
+	pdf.Cell(40, 6, tr(locale.T("Total Used: %s days", locale.Days(report.TotalUsed))))
 	pdf.Ln(6)
-	pdf.Cell(40, 6, fmt.Sprintf("Current Balance: %.1f days", report.CurrentBalance))
+	pdf.Cell(40, 6, tr(locale.T("Current Balance: %s days", locale.Days(report.CurrentBalance))))
 	pdf.Ln(6)
 	if report.CarryOverBalance > 0 {
-		pdf.Cell(40, 6, fmt.Sprintf("Carry-Over Balance: %.1f days", report.CarryOverBalance))
+		pdf.Cell(40, 6, tr(locale.T("Carry-Over Balance: %s days", locale.Days(report.CarryOverBalance))))
 		pdf.Ln(6)
 	}
-	pdf.Cell(40, 6, fmt.Sprintf("All-Time Net Balance: %.1f days", report.AllTimeNetBalance))
+	pdf.Cell(40, 6, tr(locale.T("All-Time Net Balance: %s days", locale.Days(report.AllTimeNetBalance))))
+	pdf.Ln(10)
+
+	// Leave Year
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(40, 8, tr(locale.T("Leave Year %s", LeaveYearLabel(report.LeaveYear))))
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(40, 6, tr(locale.T("Period: %s to %s", locale.Date(LeaveYearStart(report.LeaveYear)), locale.Date(LeaveYearEnd(report.LeaveYear)))))
+	pdf.Ln(6)
+	pdf.Cell(40, 6, tr(locale.T("Accrued to Date: %s days", locale.Days(report.LeaveYearAccrued))))
+	pdf.Ln(6)
+	pdf.Cell(40, 6, tr(locale.T("Used to Date: %s days", locale.Days(report.LeaveYearUsed))))
 	pdf.Ln(10)
 
 	// Monthly Accrual History
 	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 8, "Monthly Accrual History")
+	pdf.Cell(40, 8, tr(locale.T("Monthly Accrual History")))
 	pdf.Ln(8)
 
 	pdf.SetFont("Arial", "B", 9)
 	pdf.SetFillColor(200, 200, 200)
-	headers := []string{"Month", "Accrued", "Used", "Balance", "Processed"}
+	headers := []string{locale.T("Month"), locale.T("Accrued"), locale.T("Used"), locale.T("Balance"), locale.T("Processed")}
 	colWidths := []float64{35, 25, 25, 25, 30}
 
 	for i, header := range headers {
-		pdf.CellFormat(colWidths[i], 7, header, "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths[i], 7, tr(header), "1", 0, "C", true, 0, "")
 	}
 	pdf.Ln(7)
 
 	pdf.SetFont("Arial", "", 8)
 	pdf.SetFillColor(255, 255, 255)
 	for _, acc := range report.Accruals {
-		processed := "Yes"
-		if !acc.IsProcessed {
-			processed = "No"
-		}
-		pdf.CellFormat(colWidths[0], 6, acc.Month, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[1], 6, fmt.Sprintf("%.1f", acc.DaysAccrued), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(colWidths[2], 6, fmt.Sprintf("%.1f", acc.DaysUsed), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(colWidths[3], 6, fmt.Sprintf("%.1f", acc.DaysBalance), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(colWidths[4], 6, processed, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[0], 6, tr(acc.Month), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[1], 6, locale.Days(acc.DaysAccrued), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[2], 6, locale.Days(acc.DaysUsed), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[3], 6, locale.Days(acc.DaysBalance), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[4], 6, tr(locale.YesNo(acc.IsProcessed)), "1", 0, "C", false, 0, "")
 		pdf.Ln(6)
 	}
 
@@ -557,16 +728,16 @@ func ExportEmployeeAnnualLeaveToPDF(report EmployeeAnnualLeaveReport) ([]byte, e
 	if len(report.ApprovedLeaves) > 0 {
 		pdf.Ln(5)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(40, 8, "Approved Leaves History")
+		pdf.Cell(40, 8, tr(locale.T("Approved Leaves History")))
 		pdf.Ln(8)
 
 		pdf.SetFont("Arial", "B", 9)
 		pdf.SetFillColor(200, 200, 200)
-		leaveHeaders := []string{"Start Date", "End Date", "Duration", "Reason"}
+		leaveHeaders := []string{locale.T("Start Date"), locale.T("End Date"), locale.T("Duration"), locale.T("Reason")}
 		leaveColWidths := []float64{40, 40, 30, 80}
 
 		for i, header := range leaveHeaders {
-			pdf.CellFormat(leaveColWidths[i], 7, header, "1", 0, "C", true, 0, "")
+			pdf.CellFormat(leaveColWidths[i], 7, tr(header), "1", 0, "C", true, 0, "")
 		}
 		pdf.Ln(7)
 
@@ -574,13 +745,13 @@ func ExportEmployeeAnnualLeaveToPDF(report EmployeeAnnualLeaveReport) ([]byte, e
 		pdf.SetFillColor(255, 255, 255)
 		for _, leave := range report.ApprovedLeaves {
 			reason := leave.Reason
-			if len(reason) > 30 {
-				reason = reason[:27] + "..."
+			if runes := []rune(reason); len(runes) > 30 {
+				reason = string(runes[:27]) + "..."
 			}
 			pdf.CellFormat(leaveColWidths[0], 6, leave.StartDate, "1", 0, "L", false, 0, "")
 			pdf.CellFormat(leaveColWidths[1], 6, leave.EndDate, "1", 0, "L", false, 0, "")
-			pdf.CellFormat(leaveColWidths[2], 6, fmt.Sprintf("%.1f", leave.Duration), "1", 0, "R", false, 0, "")
-			pdf.CellFormat(leaveColWidths[3], 6, reason, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(leaveColWidths[2], 6, locale.Days(leave.Duration), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(leaveColWidths[3], 6, tr(reason), "1", 0, "L", false, 0, "")
 			pdf.Ln(6)
 		}
 	}
@@ -588,7 +759,7 @@ func ExportEmployeeAnnualLeaveToPDF(report EmployeeAnnualLeaveReport) ([]byte, e
 	// Timestamp
 	pdf.Ln(10)
 	pdf.SetFont("Arial", "", 8)
-	pdf.Cell(40, 6, fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+	pdf.Cell(40, 6, tr(locale.Generated()))
 
 	var buf bytes.Buffer
 	err := pdf.Output(&buf)
@@ -611,20 +782,20 @@ type MonthlyLeaveReportData struct {
 }
 
 // GetMonthlyLeaveReport generates monthly leave report data for a specific month
-func GetMonthlyLeaveReport(month time.Time, annualLeaveTypeID uint) ([]MonthlyLeaveReportData, error) {
+func GetMonthlyLeaveReport(db *gorm.DB, month time.Time, annualLeaveTypeID uint) ([]MonthlyLeaveReportData, error) {
 	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
 	prevMonthStart := monthStart.AddDate(0, -1, 0)
 
 	// Get all employees (excluding admins)
 	var employees []models.Employee
-	database.DB.Where("role != ?", models.RoleAdmin).Order("firstname, lastname").Find(&employees)
+	db.Where("role != ?", models.RoleAdmin).Order("firstname, lastname").Find(&employees)
 
 	reportData := make([]MonthlyLeaveReportData, 0, len(employees))
 
 	for i, emp := range employees {
 		// Get accrual for the specified month
 		var currentAccrual models.LeaveAccrual
-		err := database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+		err := db.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 			emp.ID, annualLeaveTypeID, monthStart).First(&currentAccrual).Error
 
 		var opening, daysEarned, daysTaken, total, net float64
@@ -637,7 +808,7 @@ func GetMonthlyLeaveReport(month time.Time, annualLeaveTypeID uint) ([]MonthlyLe
 
 			// Get previous month's balance for opening
 			var prevAccrual models.LeaveAccrual
-			prevErr := database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+			prevErr := db.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 				emp.ID, annualLeaveTypeID, prevMonthStart).First(&prevAccrual).Error
 			if prevErr == nil {
 				opening = prevAccrual.DaysBalance
@@ -650,7 +821,7 @@ func GetMonthlyLeaveReport(month time.Time, annualLeaveTypeID uint) ([]MonthlyLe
 			// No accrual for this month - calculate from scratch
 			// Get previous month's balance
 			var prevAccrual models.LeaveAccrual
-			prevErr := database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+			prevErr := db.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
 				emp.ID, annualLeaveTypeID, prevMonthStart).First(&prevAccrual).Error
 			if prevErr == nil {
 				opening = prevAccrual.DaysBalance
@@ -659,10 +830,10 @@ func GetMonthlyLeaveReport(month time.Time, annualLeaveTypeID uint) ([]MonthlyLe
 			}
 
 			// Calculate days earned (should be 2.0 for annual leave)
-			daysEarned = AnnualLeaveDaysPerMonth
+			daysEarned = AnnualLeaveDaysPerMonth()
 
 			// Calculate days taken in this month
-			daysTaken = CalculateDaysUsedInMonth(emp.ID, annualLeaveTypeID, monthStart)
+			daysTaken = CalculateDaysUsedInMonth(db, emp.ID, annualLeaveTypeID, monthStart)
 
 			total = opening + daysEarned
 			net = total - daysTaken
@@ -672,7 +843,7 @@ func GetMonthlyLeaveReport(month time.Time, annualLeaveTypeID uint) ([]MonthlyLe
 		position := "N/A"
 		if emp.PositionID != nil {
 			var pos models.Position
-			if err := database.DB.First(&pos, *emp.PositionID).Error; err == nil {
+			if err := db.First(&pos, *emp.PositionID).Error; err == nil {
 				position = pos.Title
 			}
 		}
@@ -695,12 +866,12 @@ func GetMonthlyLeaveReport(month time.Time, annualLeaveTypeID uint) ([]MonthlyLe
 	return reportData, nil
 }
 
-// ExportMonthlyLeaveReportToExcel exports monthly leave report to Excel format matching CSV structure
-func ExportMonthlyLeaveReportToExcel(reportData []MonthlyLeaveReportData, month time.Time, organizationName string) ([]byte, error) {
+// ExportMonthlyLeaveReportToExcel writes the monthly leave report to w in Excel format matching CSV structure, in the locale
+func ExportMonthlyLeaveReportToExcel(w io.Writer, reportData []MonthlyLeaveReportData, month time.Time, organizationName string, locale ExportLocale) error {
 	f := excelize.NewFile()
 	defer f.Close()
 
-	sheetName := "Monthly Leave Report"
+	sheetName := locale.T("Monthly Leave Report")
 	f.NewSheet(sheetName)
 	f.DeleteSheet("Sheet1")
 
@@ -722,17 +893,18 @@ func ExportMonthlyLeaveReportToExcel(reportData []MonthlyLeaveReportData, month
 	})
 
 	// Title rows (matching CSV format)
-	monthName := month.Format("January 2006")
-	// Use InstitutionName if organizationName is empty
+	monthName := locale.Month(month)
+	// Use the company name if organizationName is empty
 	orgName := organizationName
 	if orgName == "" {
-		orgName = InstitutionName
+		orgName = CompanyName()
 	}
-	f.SetCellValue(sheetName, "C2", fmt.Sprintf("%s STAFF LEAVE DAYS", orgName))
-	f.SetCellValue(sheetName, "C3", fmt.Sprintf("FOR THE MONTH OF %s", monthName))
+	f.SetCellValue(sheetName, "C2", locale.T("%s STAFF LEAVE DAYS", orgName))
+	f.SetCellValue(sheetName, "C3", locale.T("FOR THE MONTH OF %s", monthName))
 
 	// Column headers (row 4, matching CSV)
-	headers := []string{"", "NAME", "POSITION", "OPENING", "DAYS EARNED", "TOTAL", "DAYS TAKEN", "NET"}
+	headers := []string{"", locale.T("NAME"), locale.T("POSITION"), locale.T("OPENING"), locale.T("DAYS EARNED"),
+		locale.T("TOTAL"), locale.T("DAYS TAKEN"), locale.T("NET")}
 	for i, header := range headers {
 		cell := fmt.Sprintf("%c4", 'A'+i)
 		f.SetCellValue(sheetName, cell, header)
@@ -758,40 +930,194 @@ func ExportMonthlyLeaveReportToExcel(reportData []MonthlyLeaveReportData, month
 		if data.Opening == 0 {
 			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), " -  ")
 		} else {
-			f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), data.Opening, 1, 64)
+			f.SetCellFloat(sheetName, fmt.Sprintf("D%d", row), RoundLeaveDays(data.Opening), 2, 64)
 		}
 
-		f.SetCellFloat(sheetName, fmt.Sprintf("E%d", row), data.DaysEarned, 1, 64)
+		f.SetCellFloat(sheetName, fmt.Sprintf("E%d", row), RoundLeaveDays(data.DaysEarned), 2, 64)
 
 		// Format total (can be negative or zero)
 		if data.Total == 0 {
 			f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), " -  ")
 		} else {
-			f.SetCellFloat(sheetName, fmt.Sprintf("F%d", row), data.Total, 1, 64)
+			f.SetCellFloat(sheetName, fmt.Sprintf("F%d", row), RoundLeaveDays(data.Total), 2, 64)
 		}
 
 		// Days taken - leave empty if 0 (matching CSV format)
 		if data.DaysTaken > 0 {
-			f.SetCellFloat(sheetName, fmt.Sprintf("G%d", row), data.DaysTaken, 1, 64)
+			f.SetCellFloat(sheetName, fmt.Sprintf("G%d", row), RoundLeaveDays(data.DaysTaken), 2, 64)
 		}
 
 		// Format net balance (can be negative or zero)
 		if data.Net == 0 {
 			f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), " -  ")
 		} else {
-			f.SetCellFloat(sheetName, fmt.Sprintf("H%d", row), data.Net, 1, 64)
+			f.SetCellFloat(sheetName, fmt.Sprintf("H%d", row), RoundLeaveDays(data.Net), 2, 64)
 		}
 	}
 
 	// Add timestamp
 	timestampRow := len(reportData) + 6
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", timestampRow), fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", timestampRow), locale.Generated())
 
-	buf, err := f.WriteToBuffer()
-	if err != nil {
-		return nil, err
+	return f.Write(w)
+}
+
+// defaultLeaveColor fills leave calendar cells of leave types without a color of their own
+const defaultLeaveColor = "#5B9BD5"
+
+// LeaveCalendarEmployee is an employee's row of the leave calendar export, with their approved leaves
+type LeaveCalendarEmployee struct {
+	EmployeeID   uint
+	EmployeeName string
+	Department   string
+	Leaves       []LeaveCalendarEntry
+}
+
+// LeaveCalendarEntry is an approved leave on the leave calendar export
+type LeaveCalendarEntry struct {
+	StartDate time.Time
+	EndDate   time.Time
+	LeaveType string
+	Color     string // Hex color of the leave type, empty for the default
+}
+
+// ExportLeaveCalendarToExcel writes the leave calendar from start to end to w as an Excel grid, in the
+// locale: a row per employee and a column per day, with the days of leave filled in the color of the
+// leave type, and weekends and public holidays shaded. holidays names the public holidays by date.
+func ExportLeaveCalendarToExcel(w io.Writer, employees []LeaveCalendarEmployee, start, end time.Time, holidays map[string]string, locale ExportLocale) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := locale.T("Leave Calendar")
+	f.NewSheet(sheetName)
+	f.DeleteSheet("Sheet1")
+
+	// Add institution name and the period
+	f.SetCellValue(sheetName, "A1", CompanyName())
+	instStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 14},
+	})
+	f.SetCellStyle(sheetName, "A1", "A1", instStyle)
+	f.SetCellValue(sheetName, "A2", locale.T("Leave calendar from %s to %s", locale.Date(start), locale.Date(end)))
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	closedStyle, _ := f.NewStyle(&excelize.Style{
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#D9D9D9"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	monthStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+	})
+
+	// Row 3 names the month over its first day, row 4 numbers the days
+	f.SetCellValue(sheetName, "A4", locale.T("Employee"))
+	f.SetCellValue(sheetName, "B4", locale.T("Department"))
+	f.SetCellStyle(sheetName, "A4", "B4", headerStyle)
+	f.SetColWidth(sheetName, "A", "A", 25)
+	f.SetColWidth(sheetName, "B", "B", 18)
+
+	days := int(end.Sub(start).Hours()/24) + 1
+	closed := make([]bool, days)
+	for day := 0; day < days; day++ {
+		date := start.AddDate(0, 0, day)
+		col, _ := excelize.ColumnNumberToName(day + 3)
+		if day == 0 || date.Day() == 1 {
+			f.SetCellValue(sheetName, col+"3", locale.Month(date))
+			f.SetCellStyle(sheetName, col+"3", col+"3", monthStyle)
+		}
+		f.SetCellValue(sheetName, col+"4", date.Day())
+		f.SetCellStyle(sheetName, col+"4", col+"4", headerStyle)
+		f.SetColWidth(sheetName, col, col, 4)
+		_, holiday := holidays[date.Format("2006-01-02")]
+		closed[day] = holiday || date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
 	}
-	return buf.Bytes(), nil
+	f.SetPanes(sheetName, &excelize.Panes{Freeze: true, XSplit: 2, YSplit: 4, TopLeftCell: "C5", ActivePane: "bottomRight"})
+
+	// Each leave type gets a style of its color, and a letter in its cells for black and white prints
+	leaveStyles := map[string]int{}
+	leaveStyle := func(color string) int {
+		if color == "" {
+			color = defaultLeaveColor
+		}
+		if style, ok := leaveStyles[color]; ok {
+			return style
+		}
+		style, _ := f.NewStyle(&excelize.Style{
+			Font:      &excelize.Font{Bold: true},
+			Fill:      excelize.Fill{Type: "pattern", Color: []string{color}, Pattern: 1},
+			Alignment: &excelize.Alignment{Horizontal: "center"},
+		})
+		leaveStyles[color] = style
+		return style
+	}
+	var legend []LeaveCalendarEntry
+	inLegend := map[string]bool{}
+
+	row := 5
+	for _, employee := range employees {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), employee.EmployeeName)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), employee.Department)
+		for day := 0; day < days; day++ {
+			if closed[day] {
+				cell, _ := excelize.CoordinatesToCellName(day+3, row)
+				f.SetCellStyle(sheetName, cell, cell, closedStyle)
+			}
+		}
+		for _, leave := range employee.Leaves {
+			for date := leave.StartDate; !date.After(leave.EndDate); date = date.AddDate(0, 0, 1) {
+				if date.Before(start) || date.After(end) {
+					continue
+				}
+				cell, _ := excelize.CoordinatesToCellName(int(date.Sub(start).Hours()/24)+3, row)
+				f.SetCellValue(sheetName, cell, leaveTypeLetter(leave.LeaveType))
+				f.SetCellStyle(sheetName, cell, cell, leaveStyle(leave.Color))
+			}
+			if !inLegend[leave.LeaveType] {
+				inLegend[leave.LeaveType] = true
+				legend = append(legend, leave)
+			}
+		}
+		row++
+	}
+
+	// The legend names the leave types in the calendar and the shaded days
+	row++
+	sort.Slice(legend, func(i, j int) bool { return legend[i].LeaveType < legend[j].LeaveType })
+	for _, leave := range legend {
+		cell := fmt.Sprintf("C%d", row)
+		f.SetCellValue(sheetName, cell, leaveTypeLetter(leave.LeaveType))
+		f.SetCellStyle(sheetName, cell, cell, leaveStyle(leave.Color))
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), leave.LeaveType)
+		row++
+	}
+	f.SetCellStyle(sheetName, fmt.Sprintf("C%d", row), fmt.Sprintf("C%d", row), closedStyle)
+	f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), locale.T("Weekend or public holiday"))
+	holidayDates := make([]string, 0, len(holidays))
+	for date := range holidays {
+		holidayDates = append(holidayDates, date)
+	}
+	sort.Strings(holidayDates)
+	for _, date := range holidayDates {
+		row++
+		day, _ := time.Parse("2006-01-02", date)
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), fmt.Sprintf("%s: %s", locale.Date(day), holidays[date]))
+	}
+
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row+2), locale.Generated())
+
+	return f.Write(w)
+}
+
+// leaveTypeLetter is the letter a leave type is marked with on the leave calendar export
+func leaveTypeLetter(leaveType string) string {
+	for _, r := range leaveType {
+		return strings.ToUpper(string(r))
+	}
+	return "?"
 }
 
 // EmployeeDataExport represents employee data for export
@@ -825,91 +1151,96 @@ type EmployeeDataExport struct {
 	Notes                     string
 }
 
-// ExportEmployeesToPDF exports all employees data to PDF
-func ExportEmployeesToPDF(employees []EmployeeDataExport) ([]byte, error) {
-	pdf := gofpdf.New("L", "mm", "A4", "")
-	pdf.SetTitle("Employee Directory", false)
-	pdf.SetAuthor(InstitutionName, false)
+// ExportEmployeesToPDF writes all employees data to w as a PDF, in the locale
+func ExportEmployeesToPDF(w io.Writer, employees []EmployeeDataExport, locale ExportLocale) error {
+	pdf, tr := locale.newPDF("L")
+	pdf.SetTitle(locale.T("Employee Directory"), true)
+	pdf.SetAuthor(CompanyName(), true)
 	pdf.SetCreator("HRMS API", false)
 
 	pdf.AddPage()
-	
+
 	// Add logo and header
 	if err := addPDFHeader(pdf); err != nil {
 		// If logo fails, continue without it
 		pdf.SetFont("Arial", "B", 18)
-		pdf.Cell(0, 10, InstitutionName)
+		pdf.Cell(0, 10, tr(CompanyName()))
 		pdf.Ln(8)
 	}
-	
+
 	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(0, 10, "Employee Directory")
+	pdf.Cell(0, 10, tr(locale.T("Employee Directory")))
 	pdf.Ln(10)
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(0, 6, fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+	pdf.Cell(0, 6, tr(locale.Generated()))
 	pdf.Ln(8)
 
 	// Table headers
 	pdf.SetFont("Arial", "B", 8)
-	headers := []string{"Name", "NRC", "Email", "Department", "Role", "Phone", "Start Date"}
+	headers := []string{locale.T("Name"), locale.T("NRC"), locale.T("Email"), locale.T("Department"), locale.T("Role"),
+		locale.T("Phone"), locale.T("Start Date")}
 	colWidths := []float64{40, 30, 45, 30, 20, 30, 25}
-	
+
 	// Header row
 	for i, header := range headers {
-		pdf.CellFormat(colWidths[i], 7, header, "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths[i], 7, tr(header), "1", 0, "C", true, 0, "")
 	}
 	pdf.Ln(-1)
 
 	// Data rows
 	pdf.SetFont("Arial", "", 7)
 	for _, emp := range employees {
-		pdf.CellFormat(colWidths[0], 6, fmt.Sprintf("%s %s", emp.Firstname, emp.Lastname), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[1], 6, emp.NRC, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[2], 6, emp.Email, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[3], 6, emp.Department, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[4], 6, emp.Role, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[5], 6, emp.Mobile, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[0], 6, tr(fmt.Sprintf("%s %s", emp.Firstname, emp.Lastname)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[1], 6, tr(emp.NRC), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[2], 6, tr(emp.Email), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[3], 6, tr(emp.Department), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[4], 6, tr(emp.Role), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[5], 6, tr(emp.Mobile), "1", 0, "L", false, 0, "")
 		pdf.CellFormat(colWidths[6], 6, emp.StartDate, "1", 0, "L", false, 0, "")
 		pdf.Ln(-1)
 	}
 
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return pdf.Output(w)
 }
 
-// ExportEmployeeToPDF exports single employee detailed data to PDF
-func ExportEmployeeToPDF(emp EmployeeDataExport) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetTitle(fmt.Sprintf("Employee Details - %s %s", emp.Firstname, emp.Lastname), false)
-	pdf.SetAuthor(InstitutionName, false)
+// ExportEmployeeToPDF exports single employee detailed data to PDF, in the locale
+func ExportEmployeeToPDF(emp EmployeeDataExport, locale ExportLocale) ([]byte, error) {
+	pdf, tr := locale.newPDF("P")
+	pdf.SetTitle(locale.T("Employee Details - %s %s", emp.Firstname, emp.Lastname), true)
+	pdf.SetAuthor(CompanyName(), true)
 	pdf.SetCreator("HRMS API", false)
 
 	pdf.AddPage()
-	
+
 	// Add logo and header
 	if err := addPDFHeader(pdf); err != nil {
 		// If logo fails, continue without it
 		pdf.SetFont("Arial", "B", 18)
-		pdf.Cell(0, 10, InstitutionName)
+		pdf.Cell(0, 10, tr(CompanyName()))
 		pdf.Ln(8)
 	}
-	
+
 	pdf.SetFont("Arial", "B", 18)
-	pdf.Cell(0, 10, "Employee Details")
+	pdf.Cell(0, 10, tr(locale.T("Employee Details")))
 	pdf.Ln(12)
 
+	// Each section is a heading over rows of a label and a value
+	section := func(heading string, rows [][]string) {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.Cell(0, 8, tr(locale.T(heading)))
+		pdf.Ln(6)
+		for _, row := range rows {
+			pdf.SetFont("Arial", "B", 10)
+			pdf.Cell(50, 6, tr(locale.T(row[0])))
+			pdf.SetFont("Arial", "", 10)
+			pdf.Cell(0, 6, tr(row[1]))
+			pdf.Ln(5)
+		}
+	}
+
 	// Basic Information
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(0, 8, "Basic Information")
-	pdf.Ln(6)
-	pdf.SetFont("Arial", "", 10)
-	
-	basicInfo := [][]string{
+	section("Basic Information", [][]string{
 		{"Name:", fmt.Sprintf("%s %s", emp.Firstname, emp.Lastname)},
 		{"NRC/Username:", emp.NRC},
 		{"Email:", emp.Email},
@@ -919,24 +1250,11 @@ func ExportEmployeeToPDF(emp EmployeeDataExport) ([]byte, error) {
 		{"Employment Status:", emp.EmploymentStatus},
 		{"Start Date:", emp.StartDate},
 		{"Tenure:", emp.Tenure},
-	}
-
-	for _, row := range basicInfo {
-		pdf.SetFont("Arial", "B", 10)
-		pdf.Cell(50, 6, row[0])
-		pdf.SetFont("Arial", "", 10)
-		pdf.Cell(0, 6, row[1])
-		pdf.Ln(5)
-	}
+	})
 
 	// Personal Information
 	pdf.Ln(3)
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(0, 8, "Personal Information")
-	pdf.Ln(6)
-	pdf.SetFont("Arial", "", 10)
-
-	personalInfo := [][]string{
+	section("Personal Information", [][]string{
 		{"Date of Birth:", emp.DateOfBirth},
 		{"Gender:", emp.Gender},
 		{"Address:", emp.Address},
@@ -944,71 +1262,37 @@ func ExportEmployeeToPDF(emp EmployeeDataExport) ([]byte, error) {
 		{"Postal Code:", emp.PostalCode},
 		{"Phone:", emp.Phone},
 		{"Mobile:", emp.Mobile},
-	}
-
-	for _, row := range personalInfo {
-		pdf.SetFont("Arial", "B", 10)
-		pdf.Cell(50, 6, row[0])
-		pdf.SetFont("Arial", "", 10)
-		pdf.Cell(0, 6, row[1])
-		pdf.Ln(5)
-	}
+	})
 
 	// Emergency Contact
 	pdf.Ln(3)
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(0, 8, "Emergency Contact")
-	pdf.Ln(6)
-	pdf.SetFont("Arial", "", 10)
-
-	emergencyInfo := [][]string{
+	section("Emergency Contact", [][]string{
 		{"Contact Name:", emp.EmergencyContactName},
 		{"Contact Phone:", emp.EmergencyContactPhone},
 		{"Relationship:", emp.EmergencyContactRelationship},
-	}
-
-	for _, row := range emergencyInfo {
-		pdf.SetFont("Arial", "B", 10)
-		pdf.Cell(50, 6, row[0])
-		pdf.SetFont("Arial", "", 10)
-		pdf.Cell(0, 6, row[1])
-		pdf.Ln(5)
-	}
+	})
 
 	// Financial Information
 	pdf.Ln(3)
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(0, 8, "Financial Information")
-	pdf.Ln(6)
-	pdf.SetFont("Arial", "", 10)
-
-	financialInfo := [][]string{
+	section("Financial Information", [][]string{
 		{"Bank Name:", emp.BankName},
 		{"Bank Account Number:", emp.BankAccountNumber},
 		{"Tax ID:", emp.TaxID},
-	}
-
-	for _, row := range financialInfo {
-		pdf.SetFont("Arial", "B", 10)
-		pdf.Cell(50, 6, row[0])
-		pdf.SetFont("Arial", "", 10)
-		pdf.Cell(0, 6, row[1])
-		pdf.Ln(5)
-	}
+	})
 
 	// Notes
 	if emp.Notes != "" && emp.Notes != "-" {
 		pdf.Ln(3)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(0, 8, "Additional Notes")
+		pdf.Cell(0, 8, tr(locale.T("Additional Notes")))
 		pdf.Ln(6)
 		pdf.SetFont("Arial", "", 10)
-		pdf.MultiCell(0, 6, emp.Notes, "", "", false)
+		pdf.MultiCell(0, 6, tr(emp.Notes), "", "", false)
 	}
 
 	pdf.Ln(5)
 	pdf.SetFont("Arial", "", 8)
-	pdf.Cell(0, 6, fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+	pdf.Cell(0, 6, tr(locale.Generated()))
 
 	var buf bytes.Buffer
 	err := pdf.Output(&buf)
@@ -1017,3 +1301,230 @@ func ExportEmployeeToPDF(emp EmployeeDataExport) ([]byte, error) {
 	}
 	return buf.Bytes(), nil
 }
+
+// ExpiringComplianceExport represents a compliance record row in the expiring compliance report
+type ExpiringComplianceExport struct {
+	Department      string
+	RequirementCode string
+	RequirementName string
+	EmployeeID      uint
+	EmployeeName    string
+	Status          string
+	ExpiryDate      time.Time
+	DaysUntilExpiry int
+}
+
+// GetExpiringComplianceRecords returns compliance records expiring within the given number of days,
+// ordered by department, requirement and expiry date. Already-expired records are included when includeExpired is set.
+func GetExpiringComplianceRecords(db *gorm.DB, days int, department string, includeExpired bool) ([]ExpiringComplianceExport, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	cutoff := today.AddDate(0, 0, days)
+
+	query := db.Model(&models.ComplianceRecord{}).
+		Preload("Employee").Preload("Requirement").
+		Joins("JOIN employees ON employees.id = compliance_records.employee_id AND employees.deleted_at IS NULL").
+		Joins("JOIN compliance_requirements ON compliance_requirements.id = compliance_records.requirement_id").
+		Where("compliance_records.expiry_date IS NOT NULL AND compliance_records.expiry_date <= ?", cutoff)
+	if !includeExpired {
+		query = query.Where("compliance_records.expiry_date >= ?", today)
+	}
+	if department != "" {
+		query = query.Where("employees.department = ?", department)
+	}
+
+	var records []models.ComplianceRecord
+	if err := query.Order("employees.department, compliance_requirements.name, compliance_records.expiry_date").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	exports := make([]ExpiringComplianceExport, 0, len(records))
+	for _, record := range records {
+		exports = append(exports, ExpiringComplianceExport{
+			Department:      record.Employee.Department,
+			RequirementCode: record.Requirement.Code,
+			RequirementName: record.Requirement.Name,
+			EmployeeID:      record.EmployeeID,
+			EmployeeName:    record.Employee.Firstname + " " + record.Employee.Lastname,
+			Status:          string(record.Status),
+			ExpiryDate:      *record.ExpiryDate,
+			DaysUntilExpiry: int(record.ExpiryDate.Sub(today).Hours() / 24),
+		})
+	}
+	return exports, nil
+}
+
+// ExportExpiringComplianceToExcel writes expiring compliance records to w in Excel format, grouped by department and requirement, in the locale
+func ExportExpiringComplianceToExcel(w io.Writer, records []ExpiringComplianceExport, days int, locale ExportLocale) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := locale.T("Expiring Compliance")
+	f.NewSheet(sheetName)
+	f.DeleteSheet("Sheet1")
+
+	// Add institution name and report title
+	f.SetCellValue(sheetName, "A1", CompanyName())
+	instStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Bold: true,
+			Size: 14,
+		},
+	})
+	f.SetCellStyle(sheetName, "A1", "A1", instStyle)
+	f.SetCellValue(sheetName, "A2", locale.T("Compliance expiring in the next %d days", days))
+
+	headers := []string{locale.T("Department"), locale.T("Requirement"), locale.T("Employee ID"), locale.T("Employee Name"),
+		locale.T("Status"), locale.T("Expiry Date"), locale.T("Days Left")}
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Bold: true,
+			Size: 12,
+		},
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{"#4472C4"},
+			Pattern: 1,
+		},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+	for i, header := range headers {
+		cell := fmt.Sprintf("%c4", 'A'+i)
+		f.SetCellValue(sheetName, cell, header)
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+	}
+
+	f.SetColWidth(sheetName, "A", "A", 20)
+	f.SetColWidth(sheetName, "B", "B", 30)
+	f.SetColWidth(sheetName, "C", "C", 12)
+	f.SetColWidth(sheetName, "D", "D", 25)
+	f.SetColWidth(sheetName, "E", "G", 14)
+
+	groupStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0E0E0"}, Pattern: 1},
+	})
+
+	// Write a group row whenever the department or requirement changes
+	row := 5
+	for i, record := range records {
+		if i == 0 || record.Department != records[i-1].Department || record.RequirementCode != records[i-1].RequirementCode {
+			count := 0
+			for _, r := range records[i:] {
+				if r.Department != record.Department || r.RequirementCode != record.RequirementCode {
+					break
+				}
+				count++
+			}
+			f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), record.Department)
+			f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), fmt.Sprintf("%s (%d)", record.RequirementName, count))
+			f.SetCellStyle(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("G%d", row), groupStyle)
+			row++
+		}
+
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), record.Department)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), record.RequirementName)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), record.EmployeeID)
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), record.EmployeeName)
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), record.Status)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), locale.Date(record.ExpiryDate))
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), record.DaysUntilExpiry)
+		row++
+	}
+
+	// Add summary and timestamp
+	row++
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), locale.T("Total records: %d", len(records)))
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row+1), locale.Generated())
+
+	return f.Write(w)
+}
+
+// ExportExpiringComplianceToPDF writes expiring compliance records to w as a PDF, grouped by department and requirement, in the locale
+func ExportExpiringComplianceToPDF(w io.Writer, records []ExpiringComplianceExport, days int, locale ExportLocale) error {
+	pdf, tr := locale.newPDF("P")
+	pdf.SetTitle(locale.T("Expiring Compliance Report"), true)
+	pdf.SetAuthor(CompanyName(), true)
+	pdf.SetCreator("HRMS API", false)
+	pdf.AddPage()
+
+	// Add logo and header
+	_ = addPDFHeader(pdf)
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, tr(locale.T("Expiring Compliance Report")))
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(0, 6, tr(locale.T("Records expiring in the next %d days", days)))
+	pdf.Ln(10)
+
+	headers := []string{locale.T("ID"), locale.T("Employee Name"), locale.T("Status"), locale.T("Expiry Date"), locale.T("Days Left")}
+	colWidths := []float64{15, 70, 35, 35, 25}
+	drawHeaders := func() {
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetFillColor(200, 200, 200)
+		for i, header := range headers {
+			pdf.CellFormat(colWidths[i], 7, tr(header), "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(7)
+		pdf.SetFont("Arial", "", 9)
+	}
+
+	if len(records) == 0 {
+		pdf.Cell(0, 8, tr(locale.T("No compliance records expire in this period.")))
+		pdf.Ln(8)
+	}
+
+	// Departments and requirements start on a new page rather than have their heading end one
+	for i, record := range records {
+		newDepartment := i == 0 || record.Department != records[i-1].Department
+		newRequirement := newDepartment || record.RequirementCode != records[i-1].RequirementCode
+
+		if newDepartment {
+			if pdf.GetY() > pageBottom(pdf, 27) {
+				pdf.AddPage()
+			}
+			pdf.Ln(3)
+			pdf.SetFont("Arial", "B", 12)
+			department := record.Department
+			if department == "" {
+				department = locale.T("No department")
+			}
+			pdf.Cell(0, 8, tr(department))
+			pdf.Ln(8)
+		}
+		if newRequirement {
+			if pdf.GetY() > pageBottom(pdf, 17) {
+				pdf.AddPage()
+			}
+			pdf.SetFont("Arial", "B", 10)
+			pdf.Cell(0, 7, tr(fmt.Sprintf("%s (%s)", record.RequirementName, record.RequirementCode)))
+			pdf.Ln(7)
+			drawHeaders()
+		}
+		if pdf.GetY() > pageBottom(pdf, 2) {
+			pdf.AddPage()
+			drawHeaders()
+		}
+
+		pdf.CellFormat(colWidths[0], 6, fmt.Sprintf("%d", record.EmployeeID), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[1], 6, tr(record.EmployeeName), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[2], 6, tr(record.Status), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[3], 6, locale.Date(record.ExpiryDate), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[4], 6, fmt.Sprintf("%d", record.DaysUntilExpiry), "1", 0, "R", false, 0, "")
+		pdf.Ln(6)
+	}
+
+	pdf.Ln(5)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.Cell(40, 8, tr(locale.T("Total records: %d", len(records))))
+	pdf.Ln(5)
+	pdf.SetFont("Arial", "", 8)
+	pdf.Cell(40, 6, tr(locale.Generated()))
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,275 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// ExportDownloadTTL is how long a signed link to download an export file stays valid
+	ExportDownloadTTL = 15 * time.Minute
+	// exportRetention is how long export files are kept after they are generated
+	exportRetention = 24 * time.Hour
+	// exportStaleAfter is how long a job may run before it is taken to have been interrupted, such as
+	// by a restart, and is marked failed
+	exportStaleAfter = time.Hour
+)
+
+// exportGenerators write the file of each kind of export job in the job's format
+var exportGenerators = map[string]func(db *gorm.DB, job models.ExportJob, w io.Writer) error{
+	models.ExportAnnualLeaveBalances: func(db *gorm.DB, job models.ExportJob, w io.Writer) error {
+		locale := exportJobLocale(job)
+		detailed := job.Detailed && job.Format == "excel"
+		balances, err := AnnualLeaveBalancesForExport(db, stringValue(job.Department), stringValue(job.EmploymentStatus), detailed, locale)
+		if err != nil {
+			return err
+		}
+		if job.Format == "pdf" {
+			return ExportAnnualLeaveBalancesToPDF(w, balances, locale)
+		}
+		return ExportAnnualLeaveBalancesToExcel(w, balances, detailed, locale)
+	},
+}
+
+// exportJobLocale is the locale a job's file is written in, the one it was queued with
+func exportJobLocale(job models.ExportJob) ExportLocale {
+	locale := DefaultExportLocale()
+	if lang, ok := i18n.Parse(job.Language); ok {
+		locale.Language = lang
+	}
+	if ValidPaperSize(job.PaperSize) {
+		locale.PaperSize = job.PaperSize
+	}
+	return locale
+}
+
+var (
+	exportRunning sync.Mutex     // Held while this server works through the queue
+	exportRuns    sync.WaitGroup // Lets shutdown wait for a run started by StartExportJobs
+)
+
+// StartExportJobs works through the queued export jobs in the background
+func StartExportJobs() {
+	exportRuns.Add(1)
+	go func() {
+		defer exportRuns.Done()
+		RunExportJobs()
+	}()
+}
+
+// WaitForExportJobs waits for export jobs started by StartExportJobs to finish
+func WaitForExportJobs() {
+	exportRuns.Wait()
+}
+
+// RunExportJobs generates the queued export jobs one at a time, oldest first, until none is left. Each
+// job is claimed before it runs, so servers sharing the database share the queue. It returns straight
+// away if this server is already working through the queue.
+func RunExportJobs() {
+	if !exportRunning.TryLock() {
+		return
+	}
+	defer exportRunning.Unlock()
+
+	for {
+		job, ok, err := claimExportJob()
+		if err != nil {
+			log.Printf("❌ Export jobs: failed to claim a job: %v", err)
+			return
+		}
+		if !ok {
+			return
+		}
+		runExportJob(job)
+	}
+}
+
+// claimExportJob marks the oldest queued job running and returns it, or false when none is queued
+func claimExportJob() (models.ExportJob, bool, error) {
+	for {
+		var job models.ExportJob
+		err := database.DB.Where("status = ?", models.ExportJobQueued).Order("id").First(&job).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return job, false, nil
+		}
+		if err != nil {
+			return job, false, err
+		}
+
+		now := time.Now()
+		result := database.DB.Model(&models.ExportJob{}).
+			Where("id = ? AND status = ?", job.ID, models.ExportJobQueued).
+			Updates(map[string]interface{}{"status": models.ExportJobRunning, "started_at": now})
+		if result.Error != nil {
+			return job, false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			job.Status = models.ExportJobRunning
+			job.StartedAt = &now
+			return job, true, nil
+		}
+		// Another server claimed it first
+	}
+}
+
+// runExportJob generates a claimed job's file and records the outcome
+func runExportJob(job models.ExportJob) {
+	path, size, err := writeExportFile(job)
+
+	now := time.Now()
+	updates := map[string]interface{}{"finished_at": now}
+	if err != nil {
+		updates["status"] = models.ExportJobFailed
+		updates["error"] = err.Error()
+		log.Printf("❌ Export job %d (%s) failed: %v", job.ID, job.Kind, err)
+	} else {
+		updates["status"] = models.ExportJobCompleted
+		updates["file_name"] = exportFileName(job, now)
+		updates["file_path"] = path
+		updates["file_size"] = size
+		updates["expires_at"] = now.Add(exportRetention)
+		log.Printf("✅ Export job %d (%s) completed", job.ID, job.Kind)
+	}
+	if err := database.DB.Model(&models.ExportJob{}).Where("id = ?", job.ID).Updates(updates).Error; err != nil {
+		log.Printf("❌ Export job %d: failed to record the outcome: %v", job.ID, err)
+		if path != "" {
+			os.Remove(path)
+		}
+	}
+}
+
+// writeExportFile generates a job's file in EXPORTS_PATH and returns its path and size. The job's
+// queries only see its organization.
+func writeExportFile(job models.ExportJob) (path string, size int64, err error) {
+	generate, ok := exportGenerators[job.Kind]
+	if !ok {
+		return "", 0, fmt.Errorf("unknown export kind %q", job.Kind)
+	}
+	if err := os.MkdirAll(config.AppConfig.ExportsPath, 0750); err != nil {
+		return "", 0, err
+	}
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", 0, err
+	}
+	path = filepath.Join(config.AppConfig.ExportsPath,
+		fmt.Sprintf("export-%d-%s%s", job.ID, hex.EncodeToString(suffix), exportExtension(job.Format)))
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("export panicked: %v", r)
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+			path, size = "", 0
+		}
+	}()
+
+	db := database.DB.WithContext(database.WithOrganization(context.Background(), job.OrganizationID))
+	if err := generate(db, job, file); err != nil {
+		return "", 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	return path, info.Size(), nil
+}
+
+// FailStaleExportJobs marks failed the jobs that have been running for too long to still be running,
+// and returns how many there were
+func FailStaleExportJobs() (int64, error) {
+	result := database.DB.Model(&models.ExportJob{}).
+		Where("status = ? AND started_at < ?", models.ExportJobRunning, time.Now().Add(-exportStaleAfter)).
+		Updates(map[string]interface{}{
+			"status":      models.ExportJobFailed,
+			"error":       "the export was interrupted",
+			"finished_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// PurgeExpiredExportJobs deletes the jobs whose files have expired, with their files, and returns how
+// many there were. Failed jobs are kept for as long as files are, so users can see why they failed.
+func PurgeExpiredExportJobs() (int, error) {
+	var jobs []models.ExportJob
+	now := time.Now()
+	if err := database.DB.Where("expires_at < ? OR (status = ? AND finished_at < ?)",
+		now, models.ExportJobFailed, now.Add(-exportRetention)).Find(&jobs).Error; err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		if job.FilePath != nil {
+			if err := os.Remove(*job.FilePath); err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+		}
+		if err := database.DB.Delete(&models.ExportJob{}, job.ID).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(jobs), nil
+}
+
+// ExportDownloadURL returns a link to download a completed job's file without signing in, valid for
+// ExportDownloadTTL
+func ExportDownloadURL(job models.ExportJob) string {
+	expires := time.Now().Add(ExportDownloadTTL).Unix()
+	return fmt.Sprintf("%s/api/export-jobs/%d/download?expires=%d&signature=%s",
+		config.AppConfig.PublicURL, job.ID, expires, exportSignature(job.ID, expires))
+}
+
+// VerifyExportDownload reports whether a download link's expires and signature were issued by
+// ExportDownloadURL for the job and the link has not expired
+func VerifyExportDownload(jobID uint, expires, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(exportSignature(jobID, expiresAt)))
+}
+
+// exportSignature signs a job's download link as hex HMAC-SHA256 keyed with the JWT secret
+func exportSignature(jobID uint, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.JWTSecret))
+	fmt.Fprintf(mac, "export-download:%d:%d", jobID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// exportFileName is the name a job's file is downloaded as, such as annual_leave_balances_20260131_093000.xlsx
+func exportFileName(job models.ExportJob, generatedAt time.Time) string {
+	return job.Kind + "_" + generatedAt.Format("20060102_150405") + exportExtension(job.Format)
+}
+
+func exportExtension(format string) string {
+	if format == "pdf" {
+		return ".pdf"
+	}
+	return ".xlsx"
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"hrms-api/i18n"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Paper sizes PDF exports can be printed on, for the export_paper_size setting
+const (
+	PaperA4     = "A4"
+	PaperLetter = "Letter"
+)
+
+// ExportLocale is the language export files are written in, with its date and number formats, and the
+// paper size of PDF exports
+type ExportLocale struct {
+	Language  i18n.Language
+	PaperSize string
+}
+
+// DefaultExportLocale is the locale of exports nobody asked for another one of: English, on the paper
+// size of the export_paper_size setting
+func DefaultExportLocale() ExportLocale {
+	return ExportLocale{Language: i18n.Default, PaperSize: CurrentSettings().ExportPaperSize}
+}
+
+// ValidPaperSize reports whether PDF exports can be printed on a paper size
+func ValidPaperSize(size string) bool {
+	return size == PaperA4 || size == PaperLetter
+}
+
+// T translates a message into the locale's language, like i18n.T
+func (l ExportLocale) T(message string, args ...interface{}) string {
+	return i18n.T(l.Language, message, args...)
+}
+
+// Date formats a date in the locale
+func (l ExportLocale) Date(date time.Time) string {
+	return i18n.FormatDate(l.Language, date)
+}
+
+// DateTime formats a date and time in the locale
+func (l ExportLocale) DateTime(t time.Time) string {
+	return i18n.FormatDateTime(l.Language, t)
+}
+
+// Month names the month of a date with its year in the locale
+func (l ExportLocale) Month(date time.Time) string {
+	return i18n.FormatMonth(l.Language, date)
+}
+
+// Days formats days of leave rounded by RoundLeaveDays, with the locale's decimal separator
+func (l ExportLocale) Days(days float64) string {
+	return i18n.FormatNumber(l.Language, RoundLeaveDays(days))
+}
+
+// YesNo translates a yes or no answer
+func (l ExportLocale) YesNo(yes bool) string {
+	if yes {
+		return l.T("Yes")
+	}
+	return l.T("No")
+}
+
+// Generated is the line export files end with, saying when they were generated
+func (l ExportLocale) Generated() string {
+	return l.T("Generated: %s", l.DateTime(CompanyNow()))
+}
+
+// newPDF starts a PDF export on the locale's paper size. Text is written in the core fonts, so it goes
+// through the returned function, which converts it from UTF-8 to their encoding.
+func (l ExportLocale) newPDF(orientation string) (*gofpdf.Fpdf, func(string) string) {
+	size := l.PaperSize
+	if !ValidPaperSize(size) {
+		size = PaperA4
+	}
+	pdf := gofpdf.New(orientation, "mm", size, "")
+	return pdf, pdf.UnicodeTranslatorFromDescriptor("")
+}
+
+// pageBottom is how far down the page content may run before a PDF export starts a new page, leaving
+// room below for a row of the given height
+func pageBottom(pdf *gofpdf.Fpdf, room float64) float64 {
+	_, height := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	return height - bottom - room
+}
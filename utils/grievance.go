@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"time"
+)
+
+// GrievanceSLA reports a grievance's progress against its acknowledgement and resolution deadlines
+type GrievanceSLA struct {
+	AcknowledgeDueAt    time.Time `json:"acknowledge_due_at"`
+	ResolveDueAt        time.Time `json:"resolve_due_at"`
+	AcknowledgeBreached bool      `json:"acknowledge_breached" example:"false"`
+	ResolveBreached     bool      `json:"resolve_breached" example:"false"`
+	HoursRemaining      float64   `json:"hours_remaining" example:"312.5"` // Until the resolution deadline; negative when overdue, zero once resolved
+}
+
+// GrievanceDeadlines returns the acknowledgement and resolution deadlines for a grievance submitted at the given time
+func GrievanceDeadlines(submittedAt time.Time) (time.Time, time.Time) {
+	ackHours, slaDays := 48, 30
+	if config.AppConfig != nil {
+		ackHours, slaDays = config.AppConfig.GrievanceAckHours, config.AppConfig.GrievanceSLADays
+	}
+	return submittedAt.Add(time.Duration(ackHours) * time.Hour), submittedAt.AddDate(0, 0, slaDays)
+}
+
+// ComputeGrievanceSLA evaluates a grievance against its deadlines at the given time
+func ComputeGrievanceSLA(grievance models.Grievance, now time.Time) GrievanceSLA {
+	sla := GrievanceSLA{AcknowledgeDueAt: grievance.AcknowledgeDueAt, ResolveDueAt: grievance.ResolveDueAt}
+
+	acknowledgedAt := now
+	if grievance.AcknowledgedAt != nil {
+		acknowledgedAt = *grievance.AcknowledgedAt
+	}
+	sla.AcknowledgeBreached = acknowledgedAt.After(grievance.AcknowledgeDueAt)
+
+	resolvedAt := now
+	if grievance.ResolvedAt != nil {
+		resolvedAt = *grievance.ResolvedAt
+	} else {
+		sla.HoursRemaining = grievance.ResolveDueAt.Sub(now).Hours()
+	}
+	sla.ResolveBreached = resolvedAt.After(grievance.ResolveDueAt)
+
+	return sla
+}
+
+// ProcessGrievanceSLABreaches notifies case owners about open grievances that have missed a deadline.
+// Unassigned grievances are escalated to every admin of the submitter's organization. Each grievance is escalated once.
+func ProcessGrievanceSLABreaches() (int, []error) {
+	now := time.Now()
+	var grievances []models.Grievance
+	database.DB.Preload("Owner").Preload("Employee").
+		Where("stage != ? AND sla_breach_notified_at IS NULL", models.GrievanceStageResolved).
+		Where("(acknowledged_at IS NULL AND acknowledge_due_at < ?) OR resolve_due_at < ?", now, now).
+		Find(&grievances)
+
+	adminsByOrganization := map[uint][]models.Employee{}
+	if len(grievances) > 0 {
+		var admins []models.Employee
+		database.DB.Where("role = ? AND status = ?", models.RoleAdmin, "active").Find(&admins)
+		for _, admin := range admins {
+			adminsByOrganization[admin.OrganizationID] = append(adminsByOrganization[admin.OrganizationID], admin)
+		}
+	}
+
+	notified := 0
+	var errs []error
+	for _, grievance := range grievances {
+		organizationID := models.DefaultOrganizationID
+		if grievance.Employee != nil {
+			organizationID = grievance.Employee.OrganizationID
+		}
+		recipients := adminsByOrganization[organizationID]
+		if grievance.Owner != nil {
+			recipients = []models.Employee{*grievance.Owner}
+		}
+
+		values := map[string]string{
+			"grievance.reference": grievance.Reference, "grievance.category": string(grievance.Category),
+			"grievance.stage": string(grievance.Stage), "grievance.missed_deadline": "resolution",
+		}
+		subject := i18n.M("Grievance %s has missed its resolution deadline", grievance.Reference)
+		message := i18n.M("Grievance %s (%s) is at stage %s and has passed its resolution deadline. Please action it as a priority.",
+			grievance.Reference, grievance.Category, grievance.Stage)
+		if grievance.AcknowledgedAt == nil && grievance.AcknowledgeDueAt.Before(now) {
+			subject = i18n.M("Grievance %s has missed its acknowledgement deadline", grievance.Reference)
+			message = i18n.M("Grievance %s (%s) is at stage %s and has passed its acknowledgement deadline. Please action it as a priority.",
+				grievance.Reference, grievance.Category, grievance.Stage)
+			values["grievance.missed_deadline"] = "acknowledgement"
+		}
+
+		failed := false
+		for _, recipient := range recipients {
+			if err := Notify(recipient, models.NotificationGrievanceSLABreach, subject, message, values, models.AuditEntityGrievance, grievance.ID); err != nil {
+				errs = append(errs, fmt.Errorf("grievance %s: %w", grievance.Reference, err))
+				failed = true
+			}
+		}
+		if failed {
+			continue
+		}
+
+		if err := database.DB.Model(&grievance).Update("sla_breach_notified_at", now).Error; err != nil {
+			errs = append(errs, fmt.Errorf("grievance %s: %w", grievance.Reference, err))
+			continue
+		}
+		notified++
+	}
+
+	return notified, errs
+}
@@ -0,0 +1,383 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// UnspecifiedEmploymentType counts employees whose employment details give no employment type
+const UnspecifiedEmploymentType = "unspecified"
+
+// employmentTypeOrder is the order employment types are listed in, with their labels
+var employmentTypeOrder = []struct {
+	Type  string
+	Label string
+}{
+	{string(models.EmploymentTypeFullTime), "Full-time"},
+	{string(models.EmploymentTypePartTime), "Part-time"},
+	{string(models.EmploymentTypeContract), "Contract"},
+	{string(models.EmploymentTypeInternship), "Internship"},
+	{string(models.EmploymentTypeConsultant), "Consultant"},
+	{UnspecifiedEmploymentType, "Unspecified"},
+}
+
+// HeadcountBreakdown is a headcount split by employment type
+type HeadcountBreakdown struct {
+	Headcount        int            `json:"headcount" example:"83"`
+	ByEmploymentType map[string]int `json:"by_employment_type"`
+}
+
+// DepartmentHeadcount is the headcount of one department
+type DepartmentHeadcount struct {
+	Department string `json:"department" example:"Finance"`
+	HeadcountBreakdown
+}
+
+// HeadcountMonth is the headcount at the end of one month, overall and by department
+type HeadcountMonth struct {
+	Month string `json:"month" example:"2025-03"`
+	HeadcountBreakdown
+	Departments []DepartmentHeadcount `json:"departments"`
+}
+
+// CurrentDepartmentHeadcount is a department's headcount today, with the seats of its active positions
+type CurrentDepartmentHeadcount struct {
+	DepartmentHeadcount
+	BudgetedSeats int `json:"budgeted_seats" example:"12"` // This fiscal year's budget, else the position's headcount
+	FilledSeats   int `json:"filled_seats" example:"10"`   // Current position assignments
+	Vacancies     int `json:"vacancies" example:"2"`       // Budgeted seats not filled, position by position
+}
+
+// HeadcountReport is the headcount today and at the end of each month of a period, by department and
+// employment type, with the vacancies of today's positions
+type HeadcountReport struct {
+	AsOf string `json:"as_of" example:"2025-03-14"`
+	From string `json:"from" example:"2024-04"`
+	To   string `json:"to" example:"2025-03"`
+	HeadcountBreakdown
+	Vacancies   int                          `json:"vacancies" example:"5"`
+	Departments []CurrentDepartmentHeadcount `json:"departments"`
+	Months      []HeadcountMonth             `json:"months"`
+}
+
+// BuildHeadcountReport reports the headcount of the members, from LoadWorkforce, today and at the end
+// of each month from the month of from to the month of to, and the vacancies of the active positions,
+// optionally only those of department. Employees are counted under their current employment type.
+func BuildHeadcountReport(db *gorm.DB, members []WorkforceMember, department string, from, to time.Time) (HeadcountReport, error) {
+	today := CompanyToday()
+	report := HeadcountReport{
+		AsOf:               today.Format("2006-01-02"),
+		From:               from.Format("2006-01"),
+		To:                 to.Format("2006-01"),
+		HeadcountBreakdown: newHeadcountBreakdown(),
+		Departments:        []CurrentDepartmentHeadcount{},
+	}
+
+	departments := map[string]*CurrentDepartmentHeadcount{}
+	departmentOf := func(name string) *CurrentDepartmentHeadcount {
+		if departments[name] == nil {
+			departments[name] = &CurrentDepartmentHeadcount{
+				DepartmentHeadcount: DepartmentHeadcount{Department: name, HeadcountBreakdown: newHeadcountBreakdown()},
+			}
+		}
+		return departments[name]
+	}
+	for _, member := range members {
+		if inPost(member, today) {
+			report.add(member)
+			departmentOf(member.Department).add(member)
+		}
+	}
+
+	seats, err := positionSeats(db, department, today)
+	if err != nil {
+		return report, err
+	}
+	for _, seat := range seats {
+		current := departmentOf(seat.Department)
+		current.BudgetedSeats += seat.budgeted
+		current.FilledSeats += seat.filled
+		if seat.budgeted > seat.filled {
+			current.Vacancies += seat.budgeted - seat.filled
+			report.Vacancies += seat.budgeted - seat.filled
+		}
+	}
+	for _, current := range departments {
+		report.Departments = append(report.Departments, *current)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+
+	for monthStart := monthOf(from); !monthStart.After(to); monthStart = monthStart.AddDate(0, 1, 0) {
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		month := HeadcountMonth{Month: monthStart.Format("2006-01"), HeadcountBreakdown: newHeadcountBreakdown()}
+		byDepartment := map[string]*DepartmentHeadcount{}
+		for _, member := range members {
+			if !inPost(member, monthEnd) {
+				continue
+			}
+			month.add(member)
+			if byDepartment[member.Department] == nil {
+				byDepartment[member.Department] = &DepartmentHeadcount{Department: member.Department, HeadcountBreakdown: newHeadcountBreakdown()}
+			}
+			byDepartment[member.Department].add(member)
+		}
+		month.Departments = make([]DepartmentHeadcount, 0, len(byDepartment))
+		for _, headcount := range byDepartment {
+			month.Departments = append(month.Departments, *headcount)
+		}
+		sort.Slice(month.Departments, func(i, j int) bool {
+			return month.Departments[i].Department < month.Departments[j].Department
+		})
+		report.Months = append(report.Months, month)
+	}
+
+	return report, nil
+}
+
+func newHeadcountBreakdown() HeadcountBreakdown {
+	return HeadcountBreakdown{ByEmploymentType: map[string]int{}}
+}
+
+// add counts a member under their employment type
+func (b *HeadcountBreakdown) add(member WorkforceMember) {
+	employmentType := string(member.EmploymentType)
+	if employmentType == "" {
+		employmentType = UnspecifiedEmploymentType
+	}
+	b.Headcount++
+	b.ByEmploymentType[employmentType]++
+}
+
+// positionSeat is an active position's budgeted and filled seats
+type positionSeat struct {
+	Department string
+	budgeted   int
+	filled     int
+}
+
+// positionSeats gets the seats of the active positions, optionally only those of department. The
+// budget is the position's headcount budget for the fiscal year of today, else its default headcount.
+func positionSeats(db *gorm.DB, department string, today time.Time) ([]positionSeat, error) {
+	query := db.Where("is_active = ?", true)
+	if department != "" {
+		query = query.Where("department = ?", department)
+	}
+	var positions []models.Position
+	if err := query.Find(&positions).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(positions))
+	for i, position := range positions {
+		ids[i] = position.ID
+	}
+
+	var budgets []models.HeadcountBudget
+	if err := db.Where("position_id IN ? AND fiscal_year = ?", ids, today.Year()).Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	budgeted := map[uint]int{}
+	for _, budget := range budgets {
+		budgeted[*budget.PositionID] = budget.BudgetedHeadcount
+	}
+
+	var filled []struct {
+		PositionID uint
+		Count      int
+	}
+	if err := db.Model(&models.PositionAssignment{}).Select("position_id, COUNT(*) AS count").
+		Where("position_id IN ? AND (end_date IS NULL OR end_date >= ?)", ids, today).
+		Group("position_id").Scan(&filled).Error; err != nil {
+		return nil, err
+	}
+	filledByPosition := map[uint]int{}
+	for _, f := range filled {
+		filledByPosition[f.PositionID] = f.Count
+	}
+
+	seats := make([]positionSeat, 0, len(positions))
+	for _, position := range positions {
+		seat := positionSeat{Department: position.Department, budgeted: position.Headcount, filled: filledByPosition[position.ID]}
+		if budget, ok := budgeted[position.ID]; ok {
+			seat.budgeted = budget
+		}
+		seats = append(seats, seat)
+	}
+	return seats, nil
+}
+
+// reportEmploymentTypes are the employment types with employees in a report, in the usual order
+func reportEmploymentTypes(report HeadcountReport) []string {
+	present := map[string]bool{}
+	for employmentType := range report.ByEmploymentType {
+		present[employmentType] = true
+	}
+	for _, month := range report.Months {
+		for employmentType := range month.ByEmploymentType {
+			present[employmentType] = true
+		}
+	}
+	var types []string
+	for _, employmentType := range employmentTypeOrder {
+		if present[employmentType.Type] {
+			types = append(types, employmentType.Type)
+		}
+	}
+	return types
+}
+
+// employmentTypeLabel is the English name of an employment type, to translate
+func employmentTypeLabel(employmentType string) string {
+	for _, candidate := range employmentTypeOrder {
+		if candidate.Type == employmentType {
+			return candidate.Label
+		}
+	}
+	return employmentType
+}
+
+// ExportHeadcountReportToExcel writes the headcount report to w in Excel format, in the locale: a sheet
+// of today's headcount and vacancies by department and employment type, one of the headcount by month
+// and employment type, and one of the headcount by department and month
+func ExportHeadcountReportToExcel(w io.Writer, report HeadcountReport, locale ExportLocale) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	instStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 14},
+	})
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	totalStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0E0E0"}, Pattern: 1},
+	})
+
+	// Each sheet has the company and a title over a header row, and ends with a total row
+	newSheet := func(name, title string, headers []string) {
+		f.NewSheet(name)
+		f.SetCellValue(name, "A1", CompanyName())
+		f.SetCellStyle(name, "A1", "A1", instStyle)
+		f.SetCellValue(name, "A2", title)
+		for i, header := range headers {
+			cell, _ := excelize.CoordinatesToCellName(i+1, 4)
+			f.SetCellValue(name, cell, header)
+			f.SetCellStyle(name, cell, cell, headerStyle)
+		}
+		lastCol, _ := excelize.ColumnNumberToName(len(headers))
+		f.SetColWidth(name, "A", "A", 25)
+		if len(headers) > 1 {
+			f.SetColWidth(name, "B", lastCol, 13)
+		}
+	}
+	writeRow := func(name string, row int, values []interface{}) {
+		for i, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(i+1, row)
+			f.SetCellValue(name, cell, value)
+		}
+	}
+	writeTotals := func(name string, row, columns int) {
+		f.SetCellValue(name, fmt.Sprintf("A%d", row), locale.T("TOTAL"))
+		for col := 2; col <= columns; col++ {
+			colName, _ := excelize.ColumnNumberToName(col)
+			f.SetCellFormula(name, fmt.Sprintf("%s%d", colName, row), fmt.Sprintf("SUM(%s5:%s%d)", colName, colName, row-1))
+		}
+		lastCol, _ := excelize.ColumnNumberToName(columns)
+		f.SetCellStyle(name, fmt.Sprintf("A%d", row), fmt.Sprintf("%s%d", lastCol, row), totalStyle)
+		f.SetCellValue(name, fmt.Sprintf("A%d", row+2), locale.Generated())
+	}
+	departmentName := func(department string) string {
+		if department == "" {
+			return locale.T("No department")
+		}
+		return department
+	}
+
+	types := reportEmploymentTypes(report)
+	typeHeaders := make([]string, len(types))
+	for i, employmentType := range types {
+		typeHeaders[i] = locale.T(employmentTypeLabel(employmentType))
+	}
+
+	// Today's headcount and vacancies by department
+	current := locale.T("Current Headcount")
+	headers := append(append([]string{locale.T("Department"), locale.T("Headcount")}, typeHeaders...),
+		locale.T("Budgeted Seats"), locale.T("Filled Seats"), locale.T("Vacancies"))
+	newSheet(current, locale.T("Headcount on %s", locale.Date(CompanyToday())), headers)
+	row := 5
+	for _, department := range report.Departments {
+		values := []interface{}{departmentName(department.Department), department.Headcount}
+		for _, employmentType := range types {
+			values = append(values, department.ByEmploymentType[employmentType])
+		}
+		writeRow(current, row, append(values, department.BudgetedSeats, department.FilledSeats, department.Vacancies))
+		row++
+	}
+	writeTotals(current, row, len(headers))
+	f.DeleteSheet("Sheet1")
+
+	// Month-end headcount by employment type
+	byMonth := locale.T("By Month")
+	monthLabel := func(month string) string {
+		date, _ := time.Parse("2006-01", month)
+		return locale.Month(date)
+	}
+	newSheet(byMonth, locale.T("Headcount at month end from %s to %s", monthLabel(report.From), monthLabel(report.To)),
+		append([]string{locale.T("Month"), locale.T("Headcount")}, typeHeaders...))
+	row = 5
+	for _, month := range report.Months {
+		values := []interface{}{monthLabel(month.Month), month.Headcount}
+		for _, employmentType := range types {
+			values = append(values, month.ByEmploymentType[employmentType])
+		}
+		writeRow(byMonth, row, values)
+		row++
+	}
+	f.SetCellValue(byMonth, fmt.Sprintf("A%d", row+1), locale.Generated())
+
+	// Month-end headcount by department, a column per month
+	byDepartment := locale.T("By Department")
+	headers = []string{locale.T("Department")}
+	var departments []string
+	seen := map[string]bool{}
+	for _, month := range report.Months {
+		headers = append(headers, monthLabel(month.Month))
+		for _, department := range month.Departments {
+			if !seen[department.Department] {
+				seen[department.Department] = true
+				departments = append(departments, department.Department)
+			}
+		}
+	}
+	sort.Strings(departments)
+	newSheet(byDepartment, locale.T("Headcount at month end from %s to %s", monthLabel(report.From), monthLabel(report.To)), headers)
+	row = 5
+	for _, department := range departments {
+		values := []interface{}{departmentName(department)}
+		for _, month := range report.Months {
+			headcount := 0
+			for _, candidate := range month.Departments {
+				if candidate.Department == department {
+					headcount = candidate.Headcount
+				}
+			}
+			values = append(values, headcount)
+		}
+		writeRow(byDepartment, row, values)
+		row++
+	}
+	writeTotals(byDepartment, row, len(headers))
+
+	f.SetActiveSheet(0)
+	return f.Write(w)
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	holidayAPITimeout     = 15 * time.Second
+	holidayMaxResponse    = 1 << 20
+	holidayProviderSource = "Nager.Date"
+)
+
+var holidayClient = &http.Client{Timeout: holidayAPITimeout}
+
+// countryCodePattern matches ISO 3166-1 alpha-2 country codes, as the holiday provider uses them
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// IsCountryCode reports whether code is an upper case ISO 3166-1 alpha-2 country code, such as ZM
+func IsCountryCode(code string) bool {
+	return countryCodePattern.MatchString(code)
+}
+
+// HolidayImportResult reports what importing a country's public holidays for a year added to an
+// organization's holiday calendar
+type HolidayImportResult struct {
+	OrganizationID uint   `json:"organization_id" example:"1"`
+	CountryCode    string `json:"country_code" example:"ZM"`
+	Year           int    `json:"year" example:"2026"`
+	Fetched        int    `json:"fetched" example:"12"`  // Holidays the provider lists
+	Added          int    `json:"added" example:"1"`     // New holidays, pending review
+	Existing       int    `json:"existing" example:"10"` // Already in the calendar, whatever their status
+	Regional       int    `json:"regional" example:"1"`  // Skipped as they only apply to part of the country
+	Error          string `json:"error,omitempty"`
+}
+
+// providerHoliday is a public holiday as listed by the Nager.Date API
+type providerHoliday struct {
+	Date      string `json:"date"`
+	LocalName string `json:"localName"`
+	Name      string `json:"name"`
+	Global    bool   `json:"global"` // False for holidays only observed in some regions
+}
+
+// ImportPublicHolidays adds the public holidays of each organization's holiday countries for the years
+// to its holiday calendar, pending review. Holidays already imported are left alone, so admins' changes,
+// activations and rejections are kept. db decides which organizations import, so a request's database
+// only imports into its own organization and a background one into all of them. A country that fails is
+// reported with its error and the others are still imported.
+func ImportPublicHolidays(db *gorm.DB, years []int) ([]HolidayImportResult, error) {
+	var countries []models.HolidayCountry
+	if err := db.Order("organization_id, country_code").Find(&countries).Error; err != nil {
+		return nil, err
+	}
+
+	results := []HolidayImportResult{}
+	fetched := map[string][]providerHoliday{} // Organizations importing the same country share the provider's answer
+	for _, country := range countries {
+		for _, year := range years {
+			result := HolidayImportResult{OrganizationID: country.OrganizationID, CountryCode: country.CountryCode, Year: year}
+			key := fmt.Sprintf("%s/%d", country.CountryCode, year)
+			holidays, ok := fetched[key]
+			if !ok {
+				var err error
+				if holidays, err = fetchPublicHolidays(country.CountryCode, year); err != nil {
+					result.Error = err.Error()
+					results = append(results, result)
+					continue
+				}
+				fetched[key] = holidays
+			}
+
+			orgDB := db.WithContext(database.WithOrganization(db.Statement.Context, country.OrganizationID))
+			if err := importCountryHolidays(orgDB, &result, holidays); err != nil {
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func importCountryHolidays(db *gorm.DB, result *HolidayImportResult, holidays []providerHoliday) error {
+	result.Fetched = len(holidays)
+	for _, holiday := range holidays {
+		if !holiday.Global {
+			result.Regional++
+			continue
+		}
+		date, err := time.Parse("2006-01-02", holiday.Date)
+		if err != nil {
+			return fmt.Errorf("%s returned an invalid date %q", holidayProviderSource, holiday.Date)
+		}
+		importedName := holiday.Name
+		record := models.PublicHoliday{
+			OrganizationID: result.OrganizationID,
+			CountryCode:    result.CountryCode,
+			Date:           date,
+			Name:           holiday.Name,
+			LocalName:      holiday.LocalName,
+			Source:         models.HolidaySourceImport,
+			ImportedDate:   &date,
+			ImportedName:   &importedName,
+			Status:         models.HolidayPending,
+		}
+		created := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+		if created.Error != nil {
+			return created.Error
+		}
+		if created.RowsAffected > 0 {
+			result.Added++
+		} else {
+			result.Existing++
+		}
+	}
+	return nil
+}
+
+// fetchPublicHolidays lists a country's public holidays for a year from the holiday provider
+func fetchPublicHolidays(countryCode string, year int) ([]providerHoliday, error) {
+	resp, err := holidayClient.Get(fmt.Sprintf("%s/PublicHolidays/%d/%s", config.AppConfig.HolidayAPIURL, year, countryCode))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return nil, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s has no public holidays for country %s", holidayProviderSource, countryCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s responded with status %d", holidayProviderSource, resp.StatusCode)
+	}
+	var holidays []providerHoliday
+	if err := json.NewDecoder(io.LimitReader(resp.Body, holidayMaxResponse)).Decode(&holidays); err != nil {
+		return nil, fmt.Errorf("decoding %s response: %w", holidayProviderSource, err)
+	}
+	return holidays, nil
+}
@@ -11,10 +11,11 @@ import (
 )
 
 type Claims struct {
-	UserID   uint        `json:"user_id"`
-	NRC      string      `json:"nrc,omitempty"`
-	Username string      `json:"username,omitempty"`
-	Role     models.Role `json:"role"`
+	UserID         uint        `json:"user_id"`
+	OrganizationID uint        `json:"organization_id"`
+	NRC            string      `json:"nrc,omitempty"`
+	Username       string      `json:"username,omitempty"`
+	Role           models.Role `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -32,8 +33,9 @@ func GenerateToken(employee *models.Employee) (string, error) {
 	expirationTime := time.Now().Add(time.Duration(config.AppConfig.JWTExpirationHours) * time.Hour)
 
 	claims := &Claims{
-		UserID: employee.ID,
-		Role:   employee.Role,
+		UserID:         employee.ID,
+		OrganizationID: employee.OrganizationID,
+		Role:           employee.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
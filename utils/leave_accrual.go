@@ -6,28 +6,35 @@ import (
 	"hrms-api/models"
 	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// AccrualMonthSQL returns the SQL for the month of a leave accrual, from its year and month columns
+// for accruals recorded before accrual_month was
+func AccrualMonthSQL() string {
+	return "COALESCE(accrual_month, " + database.MonthStartSQL("year", "month") + ")"
+}
+
 // CalculateProjectedAnnualLeaveBalance calculates the projected annual leave balance
 // at a future date, accounting for monthly accruals between now and the target date
 // This uses the same calculation approach as GetCurrentLeaveBalance for consistency
-func CalculateProjectedAnnualLeaveBalance(employeeID uint, leaveTypeID uint, targetDate time.Time) (float64, error) {
-	now := time.Now()
-	if targetDate.Before(now) || targetDate.Equal(now) {
+func CalculateProjectedAnnualLeaveBalance(db *gorm.DB, employeeID uint, leaveTypeID uint, targetDate time.Time) (float64, error) {
+	if !targetDate.After(CompanyToday()) {
 		// If target date is today or in the past, return current balance
-		return GetCurrentLeaveBalance(employeeID, leaveTypeID)
+		return GetCurrentLeaveBalance(db, employeeID, leaveTypeID)
 	}
 
 	// Ensure accruals are up to date first (for consistency with GetCurrentLeaveBalance)
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, leaveTypeID).Error; err != nil {
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
 		return 0, err
 	}
 
 	// For leave types that use balance (e.g. Annual), use accrual-based calculation
 	if leaveType.UsesBalance {
 		// Calculate projected accrued by target date
-		projectedAccrued, err := CalculateAnnualLeaveAccrued(employeeID, leaveTypeID, targetDate)
+		projectedAccrued, err := CalculateAnnualLeaveAccrued(db, employeeID, leaveTypeID, targetDate)
 		if err != nil {
 			return 0, err
 		}
@@ -35,7 +42,7 @@ func CalculateProjectedAnnualLeaveBalance(employeeID uint, leaveTypeID uint, tar
 		// Get total used (only approved leaves - don't assume pending will be approved)
 		var usedDays float64
 		var leaves []models.Leave
-		database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+		db.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 			employeeID, leaveTypeID, models.StatusApproved).Find(&leaves)
 
 		for _, leave := range leaves {
@@ -51,7 +58,7 @@ func CalculateProjectedAnnualLeaveBalance(employeeID uint, leaveTypeID uint, tar
 
 		// Add carry-over balance if carry-over is enabled (consistent with GetCurrentLeaveBalance)
 		if leaveType.AllowCarryOver {
-			carryOverBalance, err := GetCarryOverBalance(employeeID, leaveTypeID)
+			carryOverBalance, err := GetCarryOverBalance(db, employeeID, leaveTypeID)
 			if err == nil {
 				projectedBalance += carryOverBalance
 			}
@@ -61,27 +68,39 @@ func CalculateProjectedAnnualLeaveBalance(employeeID uint, leaveTypeID uint, tar
 	}
 
 	// For other leave types, use current balance (no projection needed)
-	return GetCurrentLeaveBalance(employeeID, leaveTypeID)
+	return GetCurrentLeaveBalance(db, employeeID, leaveTypeID)
 }
 
-const (
-	AnnualLeaveDaysPerYear  = 24.0
-	AnnualLeaveDaysPerMonth = 2.0
-)
+// AnnualLeaveDaysPerMonth returns the days of annual leave accrued per month of service, set by the
+// annual_leave_days_per_month setting (2 by default)
+func AnnualLeaveDaysPerMonth() float64 {
+	return CurrentSettings().AnnualLeaveDaysPerMonth
+}
+
+// AnnualLeaveDaysPerYear returns the annual leave entitlement, twelve months of accrual
+func AnnualLeaveDaysPerYear() float64 {
+	return 12 * AnnualLeaveDaysPerMonth()
+}
 
 // CalculateAnnualLeaveAccrued calculates how many days of annual leave an employee has accrued
-// based on their employment start date and the current date
-func CalculateAnnualLeaveAccrued(employeeID uint, leaveTypeID uint, asOfDate time.Time) (float64, error) {
+// based on their employment start date and the current date, at the leave type's accrual frequency
+func CalculateAnnualLeaveAccrued(db *gorm.DB, employeeID uint, leaveTypeID uint, asOfDate time.Time) (float64, error) {
+	var leaveType models.LeaveType
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
+		return 0, err
+	}
+	frequency := AccrualFrequencyOf(leaveType)
+
 	// Get employee's employment details to find start date
 	var employment models.EmploymentDetails
-	if err := database.DB.Where("employee_id = ?", employeeID).First(&employment).Error; err != nil {
+	if err := db.Where("employee_id = ?", employeeID).First(&employment).Error; err != nil {
 		// If no employment details, try to get from employee created_at
 		var employee models.Employee
-		if err := database.DB.First(&employee, employeeID).Error; err != nil {
+		if err := db.First(&employee, employeeID).Error; err != nil {
 			return 0, fmt.Errorf("employee not found")
 		}
 		// Use employee creation date as fallback
-		return calculateAccruedFromDate(employee.CreatedAt, asOfDate), nil
+		return calculateAccruedFromDate(frequency, employee.CreatedAt, asOfDate), nil
 	}
 
 	// Use hire date or start date
@@ -93,93 +112,103 @@ func CalculateAnnualLeaveAccrued(employeeID uint, leaveTypeID uint, asOfDate tim
 	} else {
 		// Fallback to employee creation date
 		var employee models.Employee
-		if err := database.DB.First(&employee, employeeID).Error; err != nil {
+		if err := db.First(&employee, employeeID).Error; err != nil {
 			return 0, fmt.Errorf("employee not found")
 		}
 		startDate = employee.CreatedAt
 	}
 
-	return calculateAccruedFromDate(startDate, asOfDate), nil
+	return calculateAccruedFromDate(frequency, startDate, asOfDate), nil
 }
 
 // calculateAccruedFromDate calculates accrued leave from start date to end date
-// This calculates cumulative accrual across all years (not capped at 12 months)
-func calculateAccruedFromDate(startDate, endDate time.Time) float64 {
-	// Normalize dates to first of month for accurate month calculation
-	start := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC)
-	end := time.Date(endDate.Year(), endDate.Month(), 1, 0, 0, 0, 0, time.UTC)
-
-	// Calculate months between dates
-	months := 0
-	current := start
-	for current.Before(end) || current.Equal(end) {
-		months++
-		current = current.AddDate(0, 1, 0)
+// This calculates cumulative accrual across all years (not capped at one year's entitlement)
+func calculateAccruedFromDate(frequency models.AccrualFrequency, startDate, endDate time.Time) float64 {
+	// Accrual happens at the start of each period after the first has been served
+	// For example: if employee started in January, they earn 2 days in February (monthly)
+	var accrued float64
+	for _, period := range AccrualPeriods(frequency, startDate, endDate) {
+		accrued += period.Days
 	}
+	return accrued
+}
 
-	// Subtract 1 if we're counting from start month (employee earns in the month after starting)
-	// For example: if employee started in January, they earn 2 days in February
-	if months > 0 {
-		months-- // First month doesn't count (accrual happens at end of first month)
+// ProcessMonthlyAccrual processes leave accrual for a specific month: the accrual periods of the
+// leave type's frequency that start in the month
+func ProcessMonthlyAccrual(db *gorm.DB, employeeID uint, leaveTypeID uint, accrualMonth time.Time) error {
+	var leaveType models.LeaveType
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
+		return err
 	}
 
-	// No cap - employees accrue 2 days per month indefinitely
-	// Each year they get 24 days (12 months * 2 days/month)
-	return float64(months) * AnnualLeaveDaysPerMonth
+	monthStart := time.Date(accrualMonth.Year(), accrualMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, period := range AccrualPeriods(AccrualFrequencyOf(leaveType), accrualStartDate(db, employeeID), monthStart.AddDate(0, 1, -1)) {
+		if period.Start.Before(monthStart) {
+			continue
+		}
+		if err := ProcessAccrualPeriod(db, employeeID, leaveType, period); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// ProcessMonthlyAccrual processes leave accrual for a specific month
-func ProcessMonthlyAccrual(employeeID uint, leaveTypeID uint, accrualMonth time.Time) error {
+// ProcessAccrualPeriod processes leave accrual for an accrual period of a leave type, recorded
+// against the first day of the period
+func ProcessAccrualPeriod(db *gorm.DB, employeeID uint, leaveType models.LeaveType, period AccrualPeriod) error {
+	leaveTypeID := leaveType.ID
+	frequency := AccrualFrequencyOf(leaveType)
+
 	// Check if already processed
-	monthStart := time.Date(accrualMonth.Year(), accrualMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodStart := period.Start
 
 	// Use Find() with Limit(1) instead of First() to avoid logging "record not found" errors
 	var existingAccruals []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
-		employeeID, leaveTypeID, monthStart).Limit(1).Find(&existingAccruals)
+	db.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
+		employeeID, leaveTypeID, periodStart).Limit(1).Find(&existingAccruals)
 	
 	var existing models.LeaveAccrual
 	if len(existingAccruals) > 0 && existingAccruals[0].ID > 0 {
 		existing = existingAccruals[0]
 	}
 
-	// Get previous month's balance
-	prevMonth := monthStart.AddDate(0, -1, 0)
+	// Get previous period's balance
 	prevBalance := 0.0
 	
-	// Check if there's an initial balance record for this month or earlier
-	// If this month IS the initial balance month, we should NOT use previous month's balance
+	// Check if there's an initial balance record for this period or earlier
+	// If this period IS the initial balance period, we should NOT use previous period's balance
 	// because it might be calculated from employment start date, not from the initial balance
 	var initialBalanceForThisMonth []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
+	db.Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
 		Where("notes IS NOT NULL AND notes != '' AND (notes LIKE '%Initial balance%' OR notes LIKE '%set-initial%' OR notes LIKE '%Set initial%')").
-		Where("COALESCE(accrual_month, MAKE_DATE(year::integer, month::integer, 1)) = ?", monthStart).
+		Where(AccrualMonthSQL()+" = ?", periodStart).
 		Limit(1).Find(&initialBalanceForThisMonth)
 	
-	// If this month has an initial balance record, don't use previous month's balance
+	// If this period has an initial balance record, don't use previous period's balance
 	// The initial balance itself is the starting point
 	if len(initialBalanceForThisMonth) > 0 {
 		prevBalance = 0.0
 	} else {
-		// Not an initial balance month - use previous month's balance normally
+		// Not an initial balance period - use the latest earlier accrual's balance, which also
+		// carries balances over from accruals made on another schedule or by hand
 		var prevAccruals []models.LeaveAccrual
-		database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month = ?",
-			employeeID, leaveTypeID, prevMonth).Limit(1).Find(&prevAccruals)
+		db.Where("employee_id = ? AND leave_type_id = ? AND accrual_month < ?",
+			employeeID, leaveTypeID, periodStart).Order("accrual_month DESC").Limit(1).Find(&prevAccruals)
 		if len(prevAccruals) > 0 && prevAccruals[0].ID > 0 {
 			prevBalance = prevAccruals[0].DaysBalance
 		}
 	}
 
-	// Calculate days used in this month from approved leaves
+	// Calculate days used in this period from approved leaves
 	// This MUST always be recalculated from actual leave records, even if accrual is already processed
 	// This ensures DaysUsed stays accurate when new leaves are approved after manual adjustments
-	daysUsedFromLeaves := CalculateDaysUsedInMonth(employeeID, leaveTypeID, monthStart)
+	daysUsedFromLeaves := CalculateDaysUsedInPeriod(db, employeeID, leaveTypeID, period.Start, period.End)
 
 	// Calculate new balance
-	newAccrued := AnnualLeaveDaysPerMonth
+	newAccrued := period.Days
 
 	// Create or update accrual record
-	now := time.Now()
+	now := Now()
 	if existing.ID > 0 {
 		// Always recalculate DaysUsed from actual leave records as the source of truth
 		// This ensures DaysUsed matches actual approved leave records, even if accrual was manually processed
@@ -239,8 +268,8 @@ func ProcessMonthlyAccrual(employeeID uint, leaveTypeID uint, accrualMonth time.
 			// Get all approved leaves from the initial balance month onwards
 			var totalDaysUsedSinceInitial float64
 			var allApprovedLeaves []models.Leave
-			database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date >= ?",
-				employeeID, leaveTypeID, models.StatusApproved, monthStart).Find(&allApprovedLeaves)
+			db.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date >= ?",
+				employeeID, leaveTypeID, models.StatusApproved, periodStart).Find(&allApprovedLeaves)
 			
 			for _, leave := range allApprovedLeaves {
 				totalDaysUsedSinceInitial += float64(leave.GetDuration())
@@ -273,9 +302,11 @@ func ProcessMonthlyAccrual(employeeID uint, leaveTypeID uint, accrualMonth time.
 		}
 
 		// Always mark as processed and update timestamp
+		existing.PeriodEnd = &period.End
+		existing.Frequency = frequency
 		existing.IsProcessed = true
 		existing.ProcessedAt = &now
-		return database.DB.Save(&existing).Error
+		return db.Save(&existing).Error
 	}
 
 	// New accrual - use calculated values
@@ -285,7 +316,9 @@ func ProcessMonthlyAccrual(employeeID uint, leaveTypeID uint, accrualMonth time.
 	accrual := models.LeaveAccrual{
 		EmployeeID:   employeeID,
 		LeaveTypeID:  leaveTypeID,
-		AccrualMonth: &monthStart,
+		AccrualMonth: &periodStart,
+		PeriodEnd:    &period.End,
+		Frequency:    frequency,
 		DaysAccrued:  newAccrued,
 		DaysUsed:     daysUsed,
 		DaysBalance:  newBalance,
@@ -293,27 +326,30 @@ func ProcessMonthlyAccrual(employeeID uint, leaveTypeID uint, accrualMonth time.
 		ProcessedAt:  &now,
 	}
 
-	return database.DB.Create(&accrual).Error
+	return db.Create(&accrual).Error
 }
 
 // CalculateDaysUsedInMonth calculates days used in a specific month
-func CalculateDaysUsedInMonth(employeeID uint, leaveTypeID uint, monthStart time.Time) float64 {
-	monthEnd := monthStart.AddDate(0, 1, 0).AddDate(0, 0, -1)
+func CalculateDaysUsedInMonth(db *gorm.DB, employeeID uint, leaveTypeID uint, monthStart time.Time) float64 {
+	return CalculateDaysUsedInPeriod(db, employeeID, leaveTypeID, monthStart, monthStart.AddDate(0, 1, 0).AddDate(0, 0, -1))
+}
 
+// CalculateDaysUsedInPeriod calculates days used from one date to another, both included
+func CalculateDaysUsedInPeriod(db *gorm.DB, employeeID uint, leaveTypeID uint, periodStart, periodEnd time.Time) float64 {
 	var leaves []models.Leave
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date <= ? AND end_date >= ?",
-		employeeID, leaveTypeID, models.StatusApproved, monthEnd, monthStart).Find(&leaves)
+	db.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date <= ? AND end_date >= ?",
+		employeeID, leaveTypeID, models.StatusApproved, periodEnd, periodStart).Find(&leaves)
 
 	var daysUsed float64
 	for _, leave := range leaves {
-		// Calculate overlap with the month
+		// Calculate overlap with the period
 		overlapStart := leave.StartDate
-		if overlapStart.Before(monthStart) {
-			overlapStart = monthStart
+		if overlapStart.Before(periodStart) {
+			overlapStart = periodStart
 		}
 		overlapEnd := leave.EndDate
-		if overlapEnd.After(monthEnd) {
-			overlapEnd = monthEnd
+		if overlapEnd.After(periodEnd) {
+			overlapEnd = periodEnd
 		}
 
 		if !overlapStart.After(overlapEnd) {
@@ -327,10 +363,10 @@ func CalculateDaysUsedInMonth(employeeID uint, leaveTypeID uint, monthStart time
 
 // GetCurrentLeaveBalance calculates current leave balance including accruals and carry-over
 // For annual leave, it uses accrual records to ensure manual adjustments are reflected
-func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error) {
+func GetCurrentLeaveBalance(db *gorm.DB, employeeID uint, leaveTypeID uint) (float64, error) {
 	// Get leave type to check if it's annual leave
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, leaveTypeID).Error; err != nil {
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
 		return 0, err
 	}
 
@@ -339,7 +375,7 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 	// For leave types that use balance (e.g. Annual), use accrual records
 	if leaveType.UsesBalance {
 		// Ensure accruals are up to date first
-		if err := EnsureAccrualsUpToDate(employeeID, leaveTypeID); err != nil {
+		if err := EnsureAccrualsUpToDate(db, employeeID, leaveTypeID); err != nil {
 			return 0, err
 		}
 
@@ -350,13 +386,13 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 		var err error
 
 		// Try to find record with accrual_month first (full schema)
-		err = database.DB.Where("employee_id = ? AND leave_type_id = ? AND accrual_month IS NOT NULL", employeeID, leaveTypeID).
+		err = db.Where("employee_id = ? AND leave_type_id = ? AND accrual_month IS NOT NULL", employeeID, leaveTypeID).
 			Order("accrual_month DESC").
 			First(&latestAccrual).Error
 
 		// If no record with accrual_month, try year/month schema (simplified)
 		if err != nil {
-			err = database.DB.Where("employee_id = ? AND leave_type_id = ? AND year > 0 AND month > 0", employeeID, leaveTypeID).
+			err = db.Where("employee_id = ? AND leave_type_id = ? AND year > 0 AND month > 0", employeeID, leaveTypeID).
 				Order("year DESC, month DESC").
 				First(&latestAccrual).Error
 		}
@@ -366,7 +402,7 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 			baseBalance = latestAccrual.DaysBalance
 		} else {
 			// If no accrual records exist, calculate from scratch
-			accrued, err := CalculateAnnualLeaveAccrued(employeeID, leaveTypeID, time.Now())
+			accrued, err := CalculateAnnualLeaveAccrued(db, employeeID, leaveTypeID, CompanyNow())
 			if err != nil {
 				return 0, err
 			}
@@ -374,7 +410,7 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 			// Get total used (all approved leaves)
 			var usedDays float64
 			var leaves []models.Leave
-			database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+			db.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 				employeeID, leaveTypeID, models.StatusApproved).Find(&leaves)
 
 			for _, leave := range leaves {
@@ -386,7 +422,7 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 		}
 	} else {
 		// For other leave types, use the original calculation
-		balance, err := CalculateLeaveBalance(employeeID, leaveTypeID)
+		balance, err := CalculateLeaveBalance(db, employeeID, leaveTypeID)
 		if err != nil {
 			return 0, err
 		}
@@ -395,7 +431,7 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 
 	// Add carry-over balance if carry-over is enabled
 	if leaveType.AllowCarryOver {
-		carryOverBalance, err := GetCarryOverBalance(employeeID, leaveTypeID)
+		carryOverBalance, err := GetCarryOverBalance(db, employeeID, leaveTypeID)
 		if err != nil {
 			// Log error but don't fail - carry-over is optional
 			carryOverBalance = 0
@@ -406,25 +442,16 @@ func GetCurrentLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error)
 	return baseBalance, nil
 }
 
-// EnsureAccrualsUpToDate ensures all accruals are processed up to the current month
-func EnsureAccrualsUpToDate(employeeID uint, leaveTypeID uint) error {
-	// Get employee start date
-	var employment models.EmploymentDetails
-	startDate := time.Now()
-	if err := database.DB.Where("employee_id = ?", employeeID).First(&employment).Error; err == nil {
-		if employment.HireDate != nil {
-			startDate = *employment.HireDate
-		} else if employment.StartDate != nil {
-			startDate = *employment.StartDate
-		}
-	} else {
-		// If no employment details, try to get from employee created_at
-		var employee models.Employee
-		if err := database.DB.First(&employee, employeeID).Error; err == nil {
-			startDate = employee.CreatedAt
-		}
+// EnsureAccrualsUpToDate ensures all accruals are processed up to the current accrual period
+func EnsureAccrualsUpToDate(db *gorm.DB, employeeID uint, leaveTypeID uint) error {
+	var leaveType models.LeaveType
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
+		return err
 	}
 
+	// Get employee start date
+	startDate := accrualStartDate(db, employeeID)
+
 	// Check if there's an initial balance record - if so, use it as the starting point
 	// This ensures we don't recalculate balances from employment start when an initial balance was set
 	var initialBalanceRecord models.LeaveAccrual
@@ -433,9 +460,9 @@ func EnsureAccrualsUpToDate(employeeID uint, leaveTypeID uint) error {
 	
 	// Find the earliest initial balance record (identified by Notes containing "Initial balance")
 	var allAccruals []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
+	db.Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
 		Where("notes IS NOT NULL AND notes != '' AND (notes LIKE '%Initial balance%' OR notes LIKE '%set-initial%' OR notes LIKE '%Set initial%')").
-		Order("COALESCE(accrual_month, MAKE_DATE(year::integer, month::integer, 1)) ASC").
+		Order(AccrualMonthSQL() + " ASC").
 		Find(&allAccruals)
 	
 	if len(allAccruals) > 0 {
@@ -450,27 +477,17 @@ func EnsureAccrualsUpToDate(employeeID uint, leaveTypeID uint) error {
 		}
 	}
 
-	// Process accruals from start date to current month
-	currentMonth := time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC)
-	processMonth := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC)
-
-	// Start from the month after employment (first accrual happens at end of first month)
-	processMonth = processMonth.AddDate(0, 1, 0)
-
-	// If there's an initial balance, only process months from the initial balance month forward
-	// This prevents recalculating balances from employment start when an initial balance was set
-	if hasInitialBalance && initialBalanceMonth != nil {
-		// Start processing from the initial balance month (or the month after if it's in the past)
-		if processMonth.Before(*initialBalanceMonth) {
-			processMonth = *initialBalanceMonth
+	// Process accruals from start date to the current period
+	// Accrual starts once a first period has been served (first accrual happens at end of first month)
+	for _, period := range AccrualPeriods(AccrualFrequencyOf(leaveType), startDate, CompanyNow()) {
+		// If there's an initial balance, only process periods from the initial balance month forward
+		// This prevents recalculating balances from employment start when an initial balance was set
+		if hasInitialBalance && initialBalanceMonth != nil && period.Start.Before(*initialBalanceMonth) {
+			continue
 		}
-	}
-
-	for !processMonth.After(currentMonth) {
-		if err := ProcessMonthlyAccrual(employeeID, leaveTypeID, processMonth); err != nil {
+		if err := ProcessAccrualPeriod(db, employeeID, leaveType, period); err != nil {
 			return err
 		}
-		processMonth = processMonth.AddDate(0, 1, 0)
 	}
 
 	return nil
@@ -479,15 +496,15 @@ func EnsureAccrualsUpToDate(employeeID uint, leaveTypeID uint) error {
 // GetAvailableLeaveBalance calculates the available leave balance accounting for pending leaves
 // This is useful for approval checks to ensure we don't approve more than available
 // For future-dated leaves, it uses projected balance; for current/past-dated, it uses current balance
-func GetAvailableLeaveBalance(employeeID uint, leaveTypeID uint, excludeLeaveID *uint, targetDate *time.Time) (float64, error) {
+func GetAvailableLeaveBalance(db *gorm.DB, employeeID uint, leaveTypeID uint, excludeLeaveID *uint, targetDate *time.Time) (float64, error) {
 	var balance float64
 	var err error
 
 	// For future-dated annual leave, use projected balance
-	if targetDate != nil && targetDate.After(time.Now()) {
+	if targetDate != nil && targetDate.After(CompanyToday()) {
 		// Get projected balance at target date
 		// This already accounts for all pending leaves, so we need to add back the excluded leave
-		projectedBalance, err := CalculateProjectedAnnualLeaveBalance(employeeID, leaveTypeID, *targetDate)
+		projectedBalance, err := CalculateProjectedAnnualLeaveBalance(db, employeeID, leaveTypeID, *targetDate)
 		if err != nil {
 			return 0, err
 		}
@@ -496,7 +513,7 @@ func GetAvailableLeaveBalance(employeeID uint, leaveTypeID uint, excludeLeaveID
 		// So we need to add back the excluded leave's duration to get the balance available for it
 		if excludeLeaveID != nil {
 			var excludedLeave models.Leave
-			if err := database.DB.First(&excludedLeave, *excludeLeaveID).Error; err == nil {
+			if err := db.First(&excludedLeave, *excludeLeaveID).Error; err == nil {
 				// Add back the excluded leave's duration since it was already subtracted in projected balance
 				projectedBalance += float64(excludedLeave.GetDuration())
 			}
@@ -506,14 +523,14 @@ func GetAvailableLeaveBalance(employeeID uint, leaveTypeID uint, excludeLeaveID
 		// Don't subtract other pending leaves here - projected balance already accounts for them
 	} else {
 		// For current/past-dated leaves, use current balance and subtract other pending leaves
-		balance, err = GetCurrentLeaveBalance(employeeID, leaveTypeID)
+		balance, err = GetCurrentLeaveBalance(db, employeeID, leaveTypeID)
 		if err != nil {
 			return 0, err
 		}
 
 		// Subtract other pending leaves (excluding the one being approved if specified)
 		var pendingLeaves []models.Leave
-		query := database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+		query := db.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 			employeeID, leaveTypeID, models.StatusPending)
 
 		if excludeLeaveID != nil {
@@ -532,12 +549,12 @@ func GetAvailableLeaveBalance(employeeID uint, leaveTypeID uint, excludeLeaveID
 	return balance, nil
 }
 
-// GetCurrentYearLeaveBalance calculates the current year's leave balance from the 24 days annual entitlement
-// This shows only the balance from the current year, not cumulative all-time balance
-func GetCurrentYearLeaveBalance(employeeID uint, leaveTypeID uint) (float64, error) {
+// GetCurrentYearLeaveBalance calculates the current leave year's balance from the 24 days annual entitlement
+// This shows only the balance from the current leave year, not cumulative all-time balance
+func GetCurrentYearLeaveBalance(db *gorm.DB, employeeID uint, leaveTypeID uint) (float64, error) {
 	// Get leave type to check if it's annual leave
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, leaveTypeID).Error; err != nil {
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
 		return 0, err
 	}
 
@@ -547,23 +564,22 @@ func GetCurrentYearLeaveBalance(employeeID uint, leaveTypeID uint) (float64, err
 	}
 
 	// Ensure accruals are up to date
-	if err := EnsureAccrualsUpToDate(employeeID, leaveTypeID); err != nil {
+	if err := EnsureAccrualsUpToDate(db, employeeID, leaveTypeID); err != nil {
 		return 0, err
 	}
 
-	// Get current year's start date
-	now := time.Now()
-	currentYearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	// Get the current leave year's start date
+	currentYearStart := LeaveYearStart(CurrentLeaveYear())
 
-	// Get accruals for the current year only
+	// Get accruals for the current leave year only
 	// Handle both accrual_month and year/month schemas
 	var currentYearAccruals []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND (accrual_month >= ? OR (year >= ? AND accrual_month IS NULL))",
-		employeeID, leaveTypeID, currentYearStart, currentYearStart.Year()).
-		Order("COALESCE(accrual_month, MAKE_DATE(year, month, 1)) ASC").
+	db.Where("employee_id = ? AND leave_type_id = ? AND "+AccrualMonthSQL()+" >= ?",
+		employeeID, leaveTypeID, currentYearStart).
+		Order(AccrualMonthSQL() + " ASC").
 		Find(&currentYearAccruals)
 
-	// Calculate days accrued and used in current year
+	// Calculate days accrued and used in the current leave year
 	var yearAccrued, yearUsed float64
 	for _, acc := range currentYearAccruals {
 		yearAccrued += acc.DaysAccrued
@@ -573,8 +589,8 @@ func GetCurrentYearLeaveBalance(employeeID uint, leaveTypeID uint) (float64, err
 	// Current year balance = Days Accrued This Year - Days Used This Year
 	// Cap current year accrual at 24 days (annual entitlement)
 	// Note: Balance can exceed 24 if carry-over is added, but current year accrual is capped
-	if yearAccrued > AnnualLeaveDaysPerYear {
-		yearAccrued = AnnualLeaveDaysPerYear
+	if yearAccrued > AnnualLeaveDaysPerYear() {
+		yearAccrued = AnnualLeaveDaysPerYear()
 	}
 	currentYearBalance := yearAccrued - yearUsed
 	// Allow negative balances (overdrawn) to be visible
@@ -583,7 +599,7 @@ func GetCurrentYearLeaveBalance(employeeID uint, leaveTypeID uint) (float64, err
 	var carryOverBalance float64
 	if leaveType.AllowCarryOver {
 		var err error
-		carryOverBalance, err = GetCarryOverBalance(employeeID, leaveTypeID)
+		carryOverBalance, err = GetCarryOverBalance(db, employeeID, leaveTypeID)
 		if err != nil {
 			// Log error but don't fail - carry-over is optional
 			carryOverBalance = 0
@@ -595,3 +611,37 @@ func GetCurrentYearLeaveBalance(employeeID uint, leaveTypeID uint) (float64, err
 	balance := currentYearBalance + carryOverBalance
 	return balance, nil
 }
+
+// AnnualLeaveSummary is an employee's annual leave position for the current leave year
+type AnnualLeaveSummary struct {
+	LeaveType models.LeaveType
+	UsedDays  int     // Approved annual leave days starting this leave year
+	Balance   float64 // Current year balance from the annual entitlement, rounded for display
+}
+
+// GetAnnualLeaveSummary brings annual leave accruals up to date and returns the current leave year's balance
+// and days used. Returns ErrNoAnnualLeaveType when no annual leave type is configured.
+func GetAnnualLeaveSummary(db *gorm.DB, employeeID uint) (*AnnualLeaveSummary, error) {
+	var summary AnnualLeaveSummary
+	if err := db.Where("name = ? OR max_days = ?", "Annual", 24).First(&summary.LeaveType).Error; err != nil {
+		return nil, ErrNoAnnualLeaveType
+	}
+
+	EnsureAccrualsUpToDate(db, employeeID, summary.LeaveType.ID)
+
+	balance, err := GetCurrentYearLeaveBalance(db, employeeID, summary.LeaveType.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary.Balance = RoundLeaveDays(balance)
+
+	currentYearStart := LeaveYearStart(CurrentLeaveYear())
+	var leaves []models.Leave
+	db.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date >= ?",
+		employeeID, summary.LeaveType.ID, models.StatusApproved, currentYearStart).Find(&leaves)
+	for _, leave := range leaves {
+		summary.UsedDays += leave.GetDuration()
+	}
+
+	return &summary, nil
+}
@@ -54,10 +54,10 @@ func ProcessMonthlyAccrualSimple(employeeID uint, leaveTypeID uint, year int, mo
 		return nil
 	}
 
-	// Calculate days to accrue (use accrual_rate from leave type, default 2.0)
+	// Calculate days to accrue (use accrual_rate from leave type, default to the annual leave setting)
 	daysToAccrue := leaveType.AccrualRate
 	if daysToAccrue == 0 {
-		daysToAccrue = 2.0 // Default to 2.0 days per month for Annual Leave
+		daysToAccrue = AnnualLeaveDaysPerMonth()
 	}
 
 	// Optional: Prorate for mid-month join
@@ -65,7 +65,7 @@ func ProcessMonthlyAccrualSimple(employeeID uint, leaveTypeID uint, year int, mo
 		// Employee joined mid-month, prorate
 		daysInMonth := float64(monthEnd.Day())
 		daysWorked := float64(monthEnd.Day() - employee.DateJoined.Day() + 1)
-		daysToAccrue = (daysToAccrue / daysInMonth) * daysWorked
+		daysToAccrue = RoundLeaveDays((daysToAccrue / daysInMonth) * daysWorked)
 	}
 
 	// Create accrual record
@@ -0,0 +1,101 @@
+package utils_test
+
+import (
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"hrms-api/testutil"
+	"hrms-api/utils"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// balanceAt moves the clock to now and returns the employee's balance of the leave type
+func balanceAt(t *testing.T, clock *testutil.Clock, now time.Time, employee models.Employee, leaveType models.LeaveType) float64 {
+	t.Helper()
+	clock.Set(now)
+	balance, err := utils.GetCurrentLeaveBalance(database.DB, employee.ID, leaveType.ID)
+	if err != nil {
+		t.Fatalf("balance at %s: %v", now, err)
+	}
+	return balance
+}
+
+func TestMonthlyAccrualAtMonthBoundary(t *testing.T) {
+	testutil.Setup(t)
+	clock := testutil.SetClock(t, date(2025, time.January, 31))
+	annual := testutil.LeaveType(t, "Annual")
+	employee := testutil.CreateEmployee(t, "Finance", date(2024, time.November, 20))
+
+	// A month's accrual is made on the first of each month from the month after the start date
+	tests := []struct {
+		now  time.Time
+		want float64
+	}{
+		{time.Date(2025, time.January, 31, 23, 59, 59, 0, time.UTC), 4},
+		{date(2025, time.February, 1), 6},
+		{time.Date(2025, time.February, 28, 23, 59, 59, 0, time.UTC), 6},
+		{date(2025, time.March, 1), 8},
+	}
+	for _, tt := range tests {
+		if got := balanceAt(t, clock, tt.now, employee, annual); got != tt.want {
+			t.Errorf("balance at %s = %v, want %v", tt.now.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
+
+func TestMonthlyAccrualInCompanyTimezone(t *testing.T) {
+	testutil.Setup(t)
+	location, err := time.LoadLocation("Africa/Lusaka")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+	previous := config.AppConfig.Location
+	config.AppConfig.Location = location
+	t.Cleanup(func() { config.AppConfig.Location = previous })
+	clock := testutil.SetClock(t, date(2025, time.January, 1))
+	annual := testutil.LeaveType(t, "Annual")
+	employee := testutil.CreateEmployee(t, "Finance", date(2024, time.December, 2))
+
+	// 22:30 UTC on 31 January is already 1 February in Lusaka, UTC+2
+	if got := balanceAt(t, clock, time.Date(2025, time.January, 31, 21, 30, 0, 0, time.UTC), employee, annual); got != 2 {
+		t.Errorf("balance before midnight in Lusaka = %v, want 2", got)
+	}
+	if got := balanceAt(t, clock, time.Date(2025, time.January, 31, 22, 30, 0, 0, time.UTC), employee, annual); got != 4 {
+		t.Errorf("balance after midnight in Lusaka = %v, want 4", got)
+	}
+}
+
+func TestAnnualAccrualAtLeaveYearStart(t *testing.T) {
+	testutil.Setup(t)
+	testutil.SetSetting(t, utils.SettingLeaveYearStartMonth, 4)
+	clock := testutil.SetClock(t, date(2025, time.March, 31))
+	annual := testutil.LeaveType(t, "Annual")
+	if err := database.DB.Model(&annual).Update("accrual_frequency", models.AccrualAnnual).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Started in January of the leave year running April 2024 to March 2025, so granted the two
+	// months of it left after January on the start date
+	employee := testutil.CreateEmployee(t, "Finance", date(2025, time.January, 10))
+	tests := []struct {
+		now  time.Time
+		want float64
+	}{
+		{date(2025, time.January, 9), 0},
+		{date(2025, time.January, 10), 4},
+		{time.Date(2025, time.March, 31, 23, 59, 59, 0, time.UTC), 4},
+		{date(2025, time.April, 1), 28},
+		{date(2026, time.March, 31), 28},
+		{date(2026, time.April, 1), 52},
+	}
+	for _, tt := range tests {
+		if got := balanceAt(t, clock, tt.now, employee, annual); got != tt.want {
+			t.Errorf("balance at %s = %v, want %v", tt.now.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
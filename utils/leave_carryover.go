@@ -5,20 +5,22 @@ import (
 	"hrms-api/database"
 	"hrms-api/models"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // GetCarryOverBalance calculates the total available carry-over balance for an employee
 // This includes all non-expired carry-over days
-func GetCarryOverBalance(employeeID uint, leaveTypeID uint) (float64, error) {
-	now := time.Now()
+func GetCarryOverBalance(db *gorm.DB, employeeID uint, leaveTypeID uint) (float64, error) {
+	today := CompanyToday()
 	var carryOvers []models.LeaveCarryOver
 
 	// Get all non-expired carry-overs
-	query := database.DB.Where("employee_id = ? AND leave_type_id = ? AND is_expired = ?",
+	query := db.Where("employee_id = ? AND leave_type_id = ? AND is_expired = ?",
 		employeeID, leaveTypeID, false)
 
 	// Check expiry dates
-	query = query.Where("(expiry_date IS NULL OR expiry_date >= ?)", now)
+	query = query.Where("(expiry_date IS NULL OR expiry_date >= ?)", today)
 
 	if err := query.Find(&carryOvers).Error; err != nil {
 		return 0, err
@@ -32,8 +34,8 @@ func GetCarryOverBalance(employeeID uint, leaveTypeID uint) (float64, error) {
 	return totalBalance, nil
 }
 
-// ProcessYearEndCarryOver processes carry-over for an employee at year-end
-// This should be called at the end of each year to carry over unused leave
+// ProcessYearEndCarryOver processes carry-over for an employee at the end of a leave year
+// This should be called at the end of each leave year to carry over unused leave
 func ProcessYearEndCarryOver(employeeID uint, leaveTypeID uint, fromYear int, processedBy *uint) (*models.LeaveCarryOver, error) {
 	// Get leave type to check carry-over settings
 	var leaveType models.LeaveType
@@ -53,22 +55,10 @@ func ProcessYearEndCarryOver(employeeID uint, leaveTypeID uint, fromYear int, pr
 		return &existing, nil // Already processed
 	}
 
-	// Calculate year-end balance using ONLY current year's accrual (not including previous carry-over)
-	yearEnd := time.Date(fromYear, 12, 31, 23, 59, 59, 0, time.UTC)
-	yearStart := time.Date(fromYear, 1, 1, 0, 0, 0, 0, time.UTC)
-
-	// Get accruals for the current year only (to calculate current year's accrued balance)
-	// Handle both accrual_month and year/month schemas
-	var currentYearAccruals []models.LeaveAccrual
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND (accrual_month >= ? AND accrual_month <= ? OR (year = ? AND accrual_month IS NULL))",
-		employeeID, leaveTypeID, yearStart, yearEnd, yearStart.Year()).
-		Order("COALESCE(accrual_month, MAKE_DATE(year, month, 1)) ASC").
-		Find(&currentYearAccruals)
-
-	// Calculate days accrued in the current year only
-	var yearAccrued float64
-	for _, acc := range currentYearAccruals {
-		yearAccrued += acc.DaysAccrued
+	// Calculate year-end balance using ONLY the leave year's accrual (not including previous carry-over)
+	yearAccrued, daysUsed, err := LeaveYearTotals(database.DB, employeeID, leaveTypeID, fromYear)
+	if err != nil {
+		return nil, err
 	}
 
 	// Cap current year accrual at 24 days (annual entitlement)
@@ -76,28 +66,6 @@ func ProcessYearEndCarryOver(employeeID uint, leaveTypeID uint, fromYear int, pr
 		yearAccrued = 24.0
 	}
 
-	// Calculate days used in the year
-	var leaves []models.Leave
-	database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date >= ? AND start_date <= ?",
-		employeeID, leaveTypeID, models.StatusApproved, yearStart, yearEnd).Find(&leaves)
-
-	var daysUsed float64
-	for _, leave := range leaves {
-		// Only count days within the year
-		leaveStart := leave.StartDate
-		if leaveStart.Before(yearStart) {
-			leaveStart = yearStart
-		}
-		leaveEnd := leave.EndDate
-		if leaveEnd.After(yearEnd) {
-			leaveEnd = yearEnd
-		}
-		if !leaveStart.After(leaveEnd) {
-			duration := leaveEnd.Sub(leaveStart)
-			daysUsed += duration.Hours()/24 + 1
-		}
-	}
-
 	// Calculate unused balance from current year only (this is what can be carried over)
 	// This is current year's accrued minus current year's used (excluding previous carry-over)
 	unusedBalance := yearAccrued - daysUsed
@@ -119,18 +87,22 @@ func ProcessYearEndCarryOver(employeeID uint, leaveTypeID uint, fromYear int, pr
 	// Calculate expiry date
 	var expiryDate *time.Time
 	toYear := fromYear + 1
+	toYearStart := LeaveYearStart(toYear)
 	if leaveType.CarryOverExpiryMonths != nil {
-		// Expiry is X months after year-end
-		expiry := yearEnd.AddDate(0, *leaveType.CarryOverExpiryMonths, 0)
+		// Expiry is the end of the Xth month after year-end
+		expiry := toYearStart.AddDate(0, *leaveType.CarryOverExpiryMonths, -1)
 		expiryDate = &expiry
 	} else if leaveType.CarryOverExpiryDate != nil {
-		// Fixed expiry date (e.g., end of Q1)
-		expiry := time.Date(toYear, leaveType.CarryOverExpiryDate.Month(), leaveType.CarryOverExpiryDate.Day(), 0, 0, 0, 0, time.UTC)
+		// Fixed expiry date (e.g., end of Q1), its first occurrence in the new leave year
+		expiry := time.Date(toYearStart.Year(), leaveType.CarryOverExpiryDate.Month(), leaveType.CarryOverExpiryDate.Day(), 0, 0, 0, 0, time.UTC)
+		if expiry.Before(toYearStart) {
+			expiry = expiry.AddDate(1, 0, 0)
+		}
 		expiryDate = &expiry
 	}
 
 	// Create carry-over record
-	now := time.Now()
+	now := Now()
 	carryOver := models.LeaveCarryOver{
 		EmployeeID:      employeeID,
 		LeaveTypeID:     leaveTypeID,
@@ -152,10 +124,10 @@ func ProcessYearEndCarryOver(employeeID uint, leaveTypeID uint, fromYear int, pr
 	return &carryOver, nil
 }
 
-// ProcessCarryOverForAllEmployees processes carry-over for all employees at year-end
-func ProcessCarryOverForAllEmployees(leaveTypeID uint, fromYear int, processedBy *uint) (int, int, []error) {
+// ProcessCarryOverForAllEmployees processes carry-over at year-end for all employees visible through db
+func ProcessCarryOverForAllEmployees(db *gorm.DB, leaveTypeID uint, fromYear int, processedBy *uint) (int, int, []error) {
 	var employees []models.Employee
-	if err := database.DB.Find(&employees).Error; err != nil {
+	if err := db.Find(&employees).Error; err != nil {
 		return 0, 0, []error{err}
 	}
 
@@ -180,14 +152,14 @@ func ProcessCarryOverForAllEmployees(leaveTypeID uint, fromYear int, processedBy
 }
 
 // UpdateCarryOverUsage updates the usage of carry-over days when leave is taken
-// This should be called when a leave is approved
-func UpdateCarryOverUsage(employeeID uint, leaveTypeID uint, daysUsed float64) error {
+// This should be called when a leave is approved, in the same transaction as the approval
+func UpdateCarryOverUsage(db *gorm.DB, employeeID uint, leaveTypeID uint, daysUsed float64) error {
 	// Get all non-expired carry-overs, ordered by oldest first (FIFO)
 	var carryOvers []models.LeaveCarryOver
-	now := time.Now()
-	if err := database.DB.Where("employee_id = ? AND leave_type_id = ? AND is_expired = ? AND days_remaining > 0",
+	today := CompanyToday()
+	if err := db.Where("employee_id = ? AND leave_type_id = ? AND is_expired = ? AND days_remaining > 0",
 		employeeID, leaveTypeID, false).
-		Where("(expiry_date IS NULL OR expiry_date >= ?)", now).
+		Where("(expiry_date IS NULL OR expiry_date >= ?)", today).
 		Order("from_year ASC, created_at ASC").
 		Find(&carryOvers).Error; err != nil {
 		return err
@@ -212,7 +184,7 @@ func UpdateCarryOverUsage(employeeID uint, leaveTypeID uint, daysUsed float64) e
 			remainingDays -= available
 		}
 
-		if err := database.DB.Save(&carryOvers[i]).Error; err != nil {
+		if err := db.Save(&carryOvers[i]).Error; err != nil {
 			return fmt.Errorf("failed to update carry-over usage: %w", err)
 		}
 	}
@@ -222,9 +194,9 @@ func UpdateCarryOverUsage(employeeID uint, leaveTypeID uint, daysUsed float64) e
 
 // ExpireCarryOvers marks expired carry-overs as expired
 func ExpireCarryOvers() error {
-	now := time.Now()
+	today := CompanyToday()
 	result := database.DB.Model(&models.LeaveCarryOver{}).
-		Where("is_expired = ? AND expiry_date IS NOT NULL AND expiry_date < ?", false, now).
+		Where("is_expired = ? AND expiry_date IS NOT NULL AND expiry_date < ?", false, today).
 		Update("is_expired", true)
 
 	return result.Error
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"hrms-api/models"
+
+	"gorm.io/gorm"
+)
+
+// LeavePresetAction is what applying a leave preset did to one of its leave types
+type LeavePresetAction string
+
+const (
+	LeavePresetCreated   LeavePresetAction = "created"   // The organization had no leave type of that name
+	LeavePresetRaised    LeavePresetAction = "raised"    // Its entitlement was below the preset's and was raised to it
+	LeavePresetUnchanged LeavePresetAction = "unchanged" // It already gives at least the preset's entitlement
+)
+
+// LeavePresetChange reports what applying a leave preset did to one of its leave types
+type LeavePresetChange struct {
+	Action    LeavePresetAction `json:"action" example:"raised"`
+	LeaveType models.LeaveType  `json:"leave_type"`
+	Previous  *models.LeaveType `json:"previous,omitempty"` // The leave type before it was raised
+}
+
+// ApplyLeavePreset sets up the leave types of a leave preset for an organization and records the preset
+// on it. db must be scoped to the organization (see database.WithOrganization) and should be a
+// transaction, so a failure leaves nothing half applied. Leave types are matched by name, ignoring case:
+// missing ones are created as the preset has them, and existing ones whose maximum days, or accrual
+// rate for leave types using a balance, are below the preset's are raised to it. Nothing is ever
+// lowered or removed, so an organization's more generous policies are kept.
+func ApplyLeavePreset(db *gorm.DB, organizationID uint, preset models.LeavePreset) ([]LeavePresetChange, error) {
+	changes := []LeavePresetChange{}
+	for _, presetType := range preset.LeaveTypes {
+		var leaveType models.LeaveType
+		err := db.Where("LOWER(name) = LOWER(?)", presetType.Name).Order("id").Limit(1).Find(&leaveType).Error
+		if err != nil {
+			return nil, err
+		}
+
+		if leaveType.ID == 0 {
+			leaveType = presetType.LeaveType()
+			if err := db.Create(&leaveType).Error; err != nil {
+				return nil, err
+			}
+			changes = append(changes, LeavePresetChange{Action: LeavePresetCreated, LeaveType: leaveType})
+			continue
+		}
+
+		previous := leaveType
+		if leaveType.MaxDays < presetType.MaxDays {
+			leaveType.MaxDays = presetType.MaxDays
+		}
+		if leaveType.UsesBalance && leaveType.AccrualRate < presetType.AccrualRate {
+			leaveType.AccrualRate = presetType.AccrualRate
+		}
+		if leaveType.MaxDays == previous.MaxDays && leaveType.AccrualRate == previous.AccrualRate {
+			changes = append(changes, LeavePresetChange{Action: LeavePresetUnchanged, LeaveType: leaveType})
+			continue
+		}
+		if err := db.Model(&leaveType).Select("max_days", "accrual_rate").Updates(&leaveType).Error; err != nil {
+			return nil, err
+		}
+		changes = append(changes, LeavePresetChange{Action: LeavePresetRaised, LeaveType: leaveType, Previous: &previous})
+	}
+
+	err := db.Model(&models.Organization{}).Where("id = ?", organizationID).Update("leave_preset", preset.Code).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
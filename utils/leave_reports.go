@@ -0,0 +1,558 @@
+package utils
+
+import (
+	"hrms-api/models"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LeaveTypeDays is the leave of one type taken over a period
+type LeaveTypeDays struct {
+	LeaveTypeID uint    `json:"leave_type_id,omitempty" example:"1"` // Not set for unauthorized absence
+	LeaveType   string  `json:"leave_type" example:"Annual"`
+	Days        float64 `json:"days" example:"46"`
+	Employees   int     `json:"employees" example:"12"` // Employees who took any
+}
+
+// LeaveUtilizationMonth is the leave taken in one month
+type LeaveUtilizationMonth struct {
+	Month            string          `json:"month" example:"2025-03"`
+	Headcount        int             `json:"headcount" example:"83"` // At month end
+	EmployeesOnLeave int             `json:"employees_on_leave" example:"17"`
+	DaysTaken        float64         `json:"days_taken" example:"61"`
+	DaysPerEmployee  float64         `json:"days_per_employee" example:"0.73"` // Days taken divided by headcount
+	LeaveTypes       []LeaveTypeDays `json:"leave_types"`
+}
+
+// LeaveTypeAbsence is the absence of one kind over a period
+type LeaveTypeAbsence struct {
+	LeaveTypeDays
+	Leaves  int     `json:"leaves" example:"15"`    // Approved leaves overlapping the period
+	Percent float64 `json:"percent" example:"42.5"` // Share of all days absent
+}
+
+// DepartmentAbsence is a department's absence over a period
+type DepartmentAbsence struct {
+	Department      string             `json:"department" example:"Finance"`
+	Headcount       int                `json:"headcount" example:"12"` // At the end of the period
+	Days            float64            `json:"days" example:"38"`
+	DaysPerEmployee float64            `json:"days_per_employee" example:"3.17"`
+	Types           []LeaveTypeAbsence `json:"types"`
+}
+
+// AbsenceReport is absence over a period by type, overall and by department
+type AbsenceReport struct {
+	Days        float64             `json:"days" example:"108"`
+	Types       []LeaveTypeAbsence  `json:"types"`
+	Departments []DepartmentAbsence `json:"departments"`
+}
+
+// LeaveTypeLiability is the leave owed to employees for one balance-tracked leave type
+type LeaveTypeLiability struct {
+	LeaveTypeID    uint    `json:"leave_type_id" example:"1"`
+	LeaveType      string  `json:"leave_type" example:"Annual"`
+	Days           float64 `json:"days" example:"912.5"`            // Sum of positive balances
+	Employees      int     `json:"employees" example:"80"`          // Employees with a positive balance
+	AverageDays    float64 `json:"average_days" example:"11.41"`    // Per employee with a positive balance
+	OverdrawnDays  float64 `json:"overdrawn_days" example:"3"`      // Sum of negative balances, as a positive number
+	OverdrawnCount int     `json:"overdrawn_employees" example:"1"` // Employees with a negative balance
+}
+
+// DepartmentLiability is the leave owed to a department's employees
+type DepartmentLiability struct {
+	Department string               `json:"department" example:"Finance"`
+	Days       float64              `json:"days" example:"131"`
+	LeaveTypes []LeaveTypeLiability `json:"leave_types"`
+}
+
+// reportLeave is an approved leave with the employee's department
+type reportLeave struct {
+	models.Leave
+	Department string
+}
+
+// LeaveUtilizationByMonth reports, for each month from the month of from to the month of to, the days
+// of approved leave taken, in total and by leave type. A leave spanning months counts towards each
+// month by its days in that month, counted as leave balances count them.
+func LeaveUtilizationByMonth(db *gorm.DB, members []WorkforceMember, from, to time.Time) ([]LeaveUtilizationMonth, error) {
+	leaves, err := loadReportLeaves(db, members, monthOf(from), monthOf(to).AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	headcounts := ComputeWorkforceMonths(members, from, to)
+	months := make([]LeaveUtilizationMonth, 0, len(headcounts))
+	monthStart := monthOf(from)
+	for _, workforce := range headcounts {
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		month := LeaveUtilizationMonth{Month: workforce.Month, Headcount: workforce.Headcount}
+
+		onLeave := map[uint]bool{}
+		types := newLeaveTypeTotals()
+		for _, leave := range leaves {
+			days := overlapDays(leave.StartDate, leave.EndDate, monthStart, monthEnd)
+			if days == 0 {
+				continue
+			}
+			month.DaysTaken += days
+			onLeave[leave.EmployeeID] = true
+			types.add(leave, days)
+		}
+		month.EmployeesOnLeave = len(onLeave)
+		if month.Headcount > 0 {
+			month.DaysPerEmployee = roundReport(month.DaysTaken / float64(month.Headcount))
+		}
+		for _, t := range types.list() {
+			month.LeaveTypes = append(month.LeaveTypes, t.LeaveTypeDays)
+		}
+		if month.LeaveTypes == nil {
+			month.LeaveTypes = []LeaveTypeDays{}
+		}
+
+		months = append(months, month)
+		monthStart = monthStart.AddDate(0, 1, 0)
+	}
+	return months, nil
+}
+
+// AbsenceByType reports the days of approved leave between from and to, both included, by leave type,
+// overall and by department. Days an employee was marked absent by attendance without leave are
+// reported as the type "Unauthorized absence", without a leave type ID.
+func AbsenceByType(db *gorm.DB, members []WorkforceMember, from, to time.Time) (AbsenceReport, error) {
+	leaves, err := loadReportLeaves(db, members, from, to)
+	if err != nil {
+		return AbsenceReport{}, err
+	}
+
+	employeeIDs := make([]uint, 0, len(members))
+	departments := map[uint]string{}
+	for _, member := range members {
+		employeeIDs = append(employeeIDs, member.EmployeeID)
+		departments[member.EmployeeID] = member.Department
+	}
+	var absences []models.AttendanceRecord
+	if err := db.Where("employee_id IN ? AND status = ? AND date >= ? AND date <= ?", employeeIDs, models.AttendanceStatusAbsent, from, to).
+		Find(&absences).Error; err != nil {
+		return AbsenceReport{}, err
+	}
+
+	overall := newLeaveTypeTotals()
+	byDepartment := map[string]*leaveTypeTotals{}
+	count := func(department string, leave reportLeave, days float64) {
+		overall.add(leave, days)
+		if byDepartment[department] == nil {
+			byDepartment[department] = newLeaveTypeTotals()
+		}
+		byDepartment[department].add(leave, days)
+	}
+	for _, leave := range leaves {
+		if days := overlapDays(leave.StartDate, leave.EndDate, from, to); days > 0 {
+			count(leave.Department, leave, days)
+		}
+	}
+	for _, absence := range absences {
+		leave := reportLeave{Leave: models.Leave{EmployeeID: absence.EmployeeID, LeaveType: models.LeaveType{Name: "Unauthorized absence"}}}
+		count(departments[absence.EmployeeID], leave, 1)
+	}
+
+	report := AbsenceReport{Types: overall.list(), Departments: []DepartmentAbsence{}}
+	report.Days = overall.days
+	headcounts := map[string]int{}
+	for _, member := range members {
+		if inPost(member, to) {
+			headcounts[member.Department]++
+		}
+	}
+	for department, totals := range byDepartment {
+		entry := DepartmentAbsence{Department: department, Headcount: headcounts[department], Days: totals.days, Types: totals.list()}
+		if entry.Headcount > 0 {
+			entry.DaysPerEmployee = roundReport(entry.Days / float64(entry.Headcount))
+		}
+		report.Departments = append(report.Departments, entry)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+	return report, nil
+}
+
+// BalanceLiability reports the days of leave owed to employees in post today, by balance-tracked
+// leave type, overall and by department. Balances are brought up to date first, as when they are viewed.
+func BalanceLiability(db *gorm.DB, members []WorkforceMember) ([]LeaveTypeLiability, []DepartmentLiability, error) {
+	var leaveTypes []models.LeaveType
+	if err := db.Where("uses_balance = ?", true).Order("name").Find(&leaveTypes).Error; err != nil {
+		return nil, nil, err
+	}
+
+	newLiabilities := func() []LeaveTypeLiability {
+		liabilities := make([]LeaveTypeLiability, len(leaveTypes))
+		for i, leaveType := range leaveTypes {
+			liabilities[i] = LeaveTypeLiability{LeaveTypeID: leaveType.ID, LeaveType: leaveType.Name}
+		}
+		return liabilities
+	}
+
+	today := CompanyToday()
+	overall := newLiabilities()
+	byDepartment := map[string][]LeaveTypeLiability{}
+	for _, member := range members {
+		if !inPost(member, today) {
+			continue
+		}
+		if byDepartment[member.Department] == nil {
+			byDepartment[member.Department] = newLiabilities()
+		}
+		for i, leaveType := range leaveTypes {
+			balance, err := GetCurrentLeaveBalance(db, member.EmployeeID, leaveType.ID)
+			if err != nil {
+				continue
+			}
+			addLiability(&overall[i], balance)
+			addLiability(&byDepartment[member.Department][i], balance)
+		}
+	}
+
+	for i := range overall {
+		finishLiability(&overall[i])
+	}
+	departments := make([]DepartmentLiability, 0, len(byDepartment))
+	for department, liabilities := range byDepartment {
+		entry := DepartmentLiability{Department: department, LeaveTypes: liabilities}
+		for i := range liabilities {
+			finishLiability(&liabilities[i])
+			entry.Days += liabilities[i].Days
+		}
+		entry.Days = roundReport(entry.Days)
+		departments = append(departments, entry)
+	}
+	sort.Slice(departments, func(i, j int) bool { return departments[i].Department < departments[j].Department })
+	return overall, departments, nil
+}
+
+func addLiability(liability *LeaveTypeLiability, balance float64) {
+	switch {
+	case balance > 0:
+		liability.Days += balance
+		liability.Employees++
+	case balance < 0:
+		liability.OverdrawnDays -= balance
+		liability.OverdrawnCount++
+	}
+}
+
+func finishLiability(liability *LeaveTypeLiability) {
+	if liability.Employees > 0 {
+		liability.AverageDays = roundReport(liability.Days / float64(liability.Employees))
+	}
+	liability.Days = roundReport(liability.Days)
+	liability.OverdrawnDays = roundReport(liability.OverdrawnDays)
+}
+
+// loadReportLeaves loads the approved leaves of the members that overlap from to to
+func loadReportLeaves(db *gorm.DB, members []WorkforceMember, from, to time.Time) ([]reportLeave, error) {
+	employeeIDs := make([]uint, 0, len(members))
+	departments := map[uint]string{}
+	for _, member := range members {
+		employeeIDs = append(employeeIDs, member.EmployeeID)
+		departments[member.EmployeeID] = member.Department
+	}
+
+	var leaves []models.Leave
+	if err := db.Preload("LeaveType", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("employee_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeIDs, models.StatusApproved, to, from).
+		Find(&leaves).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]reportLeave, 0, len(leaves))
+	for _, leave := range leaves {
+		result = append(result, reportLeave{Leave: leave, Department: departments[leave.EmployeeID]})
+	}
+	return result, nil
+}
+
+// leaveTypeTotals adds up leave days by leave type
+type leaveTypeTotals struct {
+	days      float64
+	types     map[string]*LeaveTypeAbsence
+	employees map[string]map[uint]bool
+	leaves    map[string]map[uint]bool
+}
+
+func newLeaveTypeTotals() *leaveTypeTotals {
+	return &leaveTypeTotals{types: map[string]*LeaveTypeAbsence{}, employees: map[string]map[uint]bool{}, leaves: map[string]map[uint]bool{}}
+}
+
+func (t *leaveTypeTotals) add(leave reportLeave, days float64) {
+	name := leave.LeaveType.Name
+	entry, ok := t.types[name]
+	if !ok {
+		entry = &LeaveTypeAbsence{LeaveTypeDays: LeaveTypeDays{LeaveTypeID: leave.LeaveTypeID, LeaveType: name}}
+		t.types[name] = entry
+		t.employees[name] = map[uint]bool{}
+		t.leaves[name] = map[uint]bool{}
+	}
+	entry.Days += days
+	t.days += days
+	t.employees[name][leave.EmployeeID] = true
+	if leave.ID != 0 {
+		t.leaves[name][leave.ID] = true
+	}
+}
+
+// list returns the totals by leave type, largest first
+func (t *leaveTypeTotals) list() []LeaveTypeAbsence {
+	list := make([]LeaveTypeAbsence, 0, len(t.types))
+	for name, entry := range t.types {
+		entry.Employees = len(t.employees[name])
+		entry.Leaves = len(t.leaves[name])
+		if t.days > 0 {
+			entry.Percent = math.Round(entry.Days/t.days*1000) / 10
+		}
+		list = append(list, *entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Days != list[j].Days {
+			return list[i].Days > list[j].Days
+		}
+		return list[i].LeaveType < list[j].LeaveType
+	})
+	return list
+}
+
+// overlapDays counts the calendar days from start to end, both included, that fall between from and to
+func overlapDays(start, end, from, to time.Time) float64 {
+	start, end = dateOf(start), dateOf(end)
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if end.Before(start) {
+		return 0
+	}
+	return math.Round(end.Sub(start).Hours()/24) + 1
+}
+
+func roundReport(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// AbsenceRate is the working days lost to absence over a period, against the working days available
+type AbsenceRate struct {
+	AvailableDays float64 `json:"available_days" example:"1826"` // Working days of the employees in post
+	LostDays      float64 `json:"lost_days" example:"142"`
+	PlannedDays   float64 `json:"planned_days" example:"97"`
+	UnplannedDays float64 `json:"unplanned_days" example:"45"`   // Leave of unplanned types, and unauthorized absence
+	Rate          float64 `json:"rate" example:"7.78"`           // Lost days as a percentage of available days
+	PlannedRate   float64 `json:"planned_rate" example:"5.31"`   // Planned days as a percentage of available days
+	UnplannedRate float64 `json:"unplanned_rate" example:"2.46"` // Unplanned days as a percentage of available days
+}
+
+// DepartmentAbsenceRate is a department's absence rate, flagged when above the targets
+type DepartmentAbsenceRate struct {
+	Department string `json:"department" example:"Finance"`
+	AbsenceRate
+	AboveTarget          bool `json:"above_target" example:"false"`          // Rate above the absence rate target
+	UnplannedAboveTarget bool `json:"unplanned_above_target" example:"true"` // Unplanned rate above the unplanned absence rate target
+}
+
+// AbsenceRateMonth is the absence rate of one month, overall and by department
+type AbsenceRateMonth struct {
+	Month string `json:"month" example:"2025-03"`
+	AbsenceRate
+	Departments []DepartmentAbsenceRate `json:"departments"`
+}
+
+// AbsenceRateTargets are the absence rates departments are flagged above, 0 for no target
+type AbsenceRateTargets struct {
+	Rate          float64 `json:"rate" example:"0"`
+	UnplannedRate float64 `json:"unplanned_rate" example:"3"`
+}
+
+// AbsenceRates are absence rates over a period, overall and by department, and for each month
+type AbsenceRates struct {
+	Targets AbsenceRateTargets `json:"targets"`
+	AbsenceRate
+	Departments        []DepartmentAbsenceRate `json:"departments"`
+	FlaggedDepartments []string                `json:"flagged_departments"` // Above either target over the whole period
+	Months             []AbsenceRateMonth      `json:"months"`
+}
+
+// absenceDays adds up available and lost working days
+type absenceDays struct {
+	available, planned, unplanned float64
+}
+
+func (d absenceDays) rate() AbsenceRate {
+	rate := AbsenceRate{
+		AvailableDays: d.available,
+		LostDays:      d.planned + d.unplanned,
+		PlannedDays:   d.planned,
+		UnplannedDays: d.unplanned,
+	}
+	if d.available > 0 {
+		rate.Rate = roundReport(rate.LostDays / d.available * 100)
+		rate.PlannedRate = roundReport(d.planned / d.available * 100)
+		rate.UnplannedRate = roundReport(d.unplanned / d.available * 100)
+	}
+	return rate
+}
+
+// ComputeAbsenceRates reports, for the whole period and each month from the month of from to the month of to,
+// the working days lost to absence as a percentage of the working days available, overall and by
+// department. Working days are the days of the default work schedule that are not active public
+// holidays, counted for each employee in post. Approved leave is planned or unplanned by its leave
+// type, and days marked absent by attendance without leave are unplanned. Departments above the
+// targets are flagged.
+func ComputeAbsenceRates(db *gorm.DB, members []WorkforceMember, from, to time.Time, targets AbsenceRateTargets) (AbsenceRates, error) {
+	start, end := monthOf(from), monthOf(to).AddDate(0, 1, -1)
+
+	schedule := fallbackWorkSchedule
+	db.Where("is_default = ?", true).Limit(1).Find(&schedule)
+	var holidays []models.PublicHoliday
+	if err := db.Where("status = ? AND date >= ? AND date <= ?", models.HolidayActive, start, end).Find(&holidays).Error; err != nil {
+		return AbsenceRates{}, err
+	}
+	closed := map[time.Time]bool{}
+	for _, holiday := range holidays {
+		closed[dateOf(holiday.Date)] = true
+	}
+	var workDays []time.Time
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if IsScheduledWorkDay(schedule, day) && !closed[day] {
+			workDays = append(workDays, day)
+		}
+	}
+
+	// Days are added up by month and department, the month being the index of its first day
+	months := []time.Time{}
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	monthIndex := func(day time.Time) int {
+		return (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+	}
+	byMonth := make([]map[string]*absenceDays, len(months))
+	for i := range byMonth {
+		byMonth[i] = map[string]*absenceDays{}
+	}
+	daysOf := func(day time.Time, department string) *absenceDays {
+		month := byMonth[monthIndex(day)]
+		if month[department] == nil {
+			month[department] = &absenceDays{}
+		}
+		return month[department]
+	}
+
+	membersByID := map[uint]WorkforceMember{}
+	for _, member := range members {
+		membersByID[member.EmployeeID] = member
+		for _, day := range workDays {
+			if inPost(member, day) {
+				daysOf(day, member.Department).available++
+			}
+		}
+	}
+
+	// A working day is lost once per employee, however many leaves or absences cover it
+	lost := map[uint]map[time.Time]bool{}
+	lose := func(employeeID uint, day time.Time, unplanned bool) {
+		member, ok := membersByID[employeeID]
+		if !ok || !inPost(member, day) || !IsScheduledWorkDay(schedule, day) || closed[day] || lost[employeeID][day] {
+			return
+		}
+		if lost[employeeID] == nil {
+			lost[employeeID] = map[time.Time]bool{}
+		}
+		lost[employeeID][day] = true
+		if unplanned {
+			daysOf(day, member.Department).unplanned++
+		} else {
+			daysOf(day, member.Department).planned++
+		}
+	}
+
+	leaves, err := loadReportLeaves(db, members, start, end)
+	if err != nil {
+		return AbsenceRates{}, err
+	}
+	for _, leave := range leaves {
+		first, last := dateOf(leave.StartDate), dateOf(leave.EndDate)
+		if first.Before(start) {
+			first = start
+		}
+		if last.After(end) {
+			last = end
+		}
+		for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+			lose(leave.EmployeeID, day, leave.LeaveType.Unplanned)
+		}
+	}
+
+	employeeIDs := make([]uint, 0, len(members))
+	for _, member := range members {
+		employeeIDs = append(employeeIDs, member.EmployeeID)
+	}
+	var absences []models.AttendanceRecord
+	if err := db.Where("employee_id IN ? AND status = ? AND date >= ? AND date <= ?", employeeIDs, models.AttendanceStatusAbsent, start, end).
+		Find(&absences).Error; err != nil {
+		return AbsenceRates{}, err
+	}
+	for _, absence := range absences {
+		lose(absence.EmployeeID, dateOf(absence.Date), true)
+	}
+
+	flag := func(department string, days absenceDays) DepartmentAbsenceRate {
+		rate := DepartmentAbsenceRate{Department: department, AbsenceRate: days.rate()}
+		rate.AboveTarget = targets.Rate > 0 && rate.Rate > targets.Rate
+		rate.UnplannedAboveTarget = targets.UnplannedRate > 0 && rate.UnplannedRate > targets.UnplannedRate
+		return rate
+	}
+	departmentRates := func(days map[string]*absenceDays) []DepartmentAbsenceRate {
+		rates := make([]DepartmentAbsenceRate, 0, len(days))
+		for department, d := range days {
+			rates = append(rates, flag(department, *d))
+		}
+		sort.Slice(rates, func(i, j int) bool { return rates[i].Department < rates[j].Department })
+		return rates
+	}
+
+	report := AbsenceRates{Targets: targets, FlaggedDepartments: []string{}, Months: []AbsenceRateMonth{}}
+	var overall absenceDays
+	period := map[string]*absenceDays{}
+	for i, month := range months {
+		var monthDays absenceDays
+		for department, d := range byMonth[i] {
+			monthDays.available += d.available
+			monthDays.planned += d.planned
+			monthDays.unplanned += d.unplanned
+			if period[department] == nil {
+				period[department] = &absenceDays{}
+			}
+			period[department].available += d.available
+			period[department].planned += d.planned
+			period[department].unplanned += d.unplanned
+		}
+		overall.available += monthDays.available
+		overall.planned += monthDays.planned
+		overall.unplanned += monthDays.unplanned
+		report.Months = append(report.Months, AbsenceRateMonth{
+			Month:       month.Format("2006-01"),
+			AbsenceRate: monthDays.rate(),
+			Departments: departmentRates(byMonth[i]),
+		})
+	}
+	report.AbsenceRate = overall.rate()
+	report.Departments = departmentRates(period)
+	for _, department := range report.Departments {
+		if department.AboveTarget || department.UnplannedAboveTarget {
+			report.FlaggedDepartments = append(report.FlaggedDepartments, department.Department)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+)
+
+// Leave rounding modes, for the leave_rounding_mode setting
+const (
+	LeaveRoundNearest = "nearest"
+	LeaveRoundUp      = "up"
+	LeaveRoundDown    = "down"
+)
+
+// RoundLeaveDays rounds days of leave to the leave_rounding_increment setting, in the direction set by
+// leave_rounding_mode. Without an increment days are rounded to two decimal places.
+func RoundLeaveDays(days float64) float64 {
+	settings := CurrentSettings()
+	increment := settings.LeaveRoundingIncrement
+	if increment == 0 {
+		increment = 0.01
+	}
+
+	steps := days / increment
+	switch settings.LeaveRoundingMode {
+	case LeaveRoundUp:
+		// Allow for floating point error, so 1.5 days in half days is not rounded up to 2
+		steps = math.Ceil(steps - 1e-9)
+	case LeaveRoundDown:
+		steps = math.Floor(steps + 1e-9)
+	default:
+		steps = math.Round(steps)
+	}
+	// Every increment is a whole number of hundredths, so this removes floating point error only
+	return math.Round(steps*increment*100) / 100
+}
+
+// FormatLeaveDays formats days of leave for display, rounded by RoundLeaveDays and without trailing
+// zeros: 2, 1.5 or 0.25
+func FormatLeaveDays(days float64) string {
+	return strconv.FormatFloat(RoundLeaveDays(days), 'f', -1, 64)
+}
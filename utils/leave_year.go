@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Leave years run for twelve months from the month set by the leave_year_start_month setting, and
+// are named by the calendar year they start in: with an April start, leave year 2025 runs from
+// April 2025 to March 2026.
+
+// LeaveYearOf returns the leave year a date falls in
+func LeaveYearOf(date time.Time) int {
+	if date.Month() < CurrentSettings().LeaveYearStartMonth {
+		return date.Year() - 1
+	}
+	return date.Year()
+}
+
+// CurrentLeaveYear returns the leave year of the company's today
+func CurrentLeaveYear() int {
+	return LeaveYearOf(CompanyToday())
+}
+
+// LeaveYearStart returns the first day of a leave year
+func LeaveYearStart(year int) time.Time {
+	return time.Date(year, CurrentSettings().LeaveYearStartMonth, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// LeaveYearEnd returns the last day of a leave year
+func LeaveYearEnd(year int) time.Time {
+	return LeaveYearStart(year+1).AddDate(0, 0, -1)
+}
+
+// LeaveYearLabel names a leave year for display: 2025 when leave years are calendar years, and
+// 2025/26 when they straddle two
+func LeaveYearLabel(year int) string {
+	if CurrentSettings().LeaveYearStartMonth == time.January {
+		return fmt.Sprintf("%d", year)
+	}
+	return fmt.Sprintf("%d/%02d", year, (year+1)%100)
+}
+
+// LeaveYearTotals returns the days of a leave type an employee accrued in a leave year, and the days
+// of approved leave they took in it. Leave spanning the start or end of the year counts by its days
+// in the year.
+func LeaveYearTotals(db *gorm.DB, employeeID, leaveTypeID uint, year int) (float64, float64, error) {
+	start, next := LeaveYearStart(year), LeaveYearStart(year+1)
+
+	var accruals []models.LeaveAccrual
+	if err := db.Where("employee_id = ? AND leave_type_id = ?", employeeID, leaveTypeID).
+		Where(AccrualMonthSQL()+" >= ? AND "+AccrualMonthSQL()+" < ?", start, next).
+		Find(&accruals).Error; err != nil {
+		return 0, 0, err
+	}
+	var accrued float64
+	for _, accrual := range accruals {
+		accrued += accrual.DaysAccrued
+	}
+
+	var leaves []models.Leave
+	if err := db.Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date < ? AND end_date >= ?",
+		employeeID, leaveTypeID, models.StatusApproved, next, start).
+		Find(&leaves).Error; err != nil {
+		return 0, 0, err
+	}
+	var used float64
+	for _, leave := range leaves {
+		used += overlapDays(leave.StartDate, leave.EndDate, start, LeaveYearEnd(year))
+	}
+
+	return accrued, used, nil
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaskAccountNumber hides all but the last four characters of an account number
+func MaskAccountNumber(accountNumber string) string {
+	const visible = 4
+	if len(accountNumber) <= visible {
+		return strings.Repeat("*", len(accountNumber))
+	}
+	return strings.Repeat("*", len(accountNumber)-visible) + accountNumber[len(accountNumber)-visible:]
+}
+
+// SkipPIIMaskingKey marks a response that middleware.MaskPII passes through without holding it back
+const SkipPIIMaskingKey = "skip_pii_masking"
+
+// SkipPIIMasking lets a handler stream a JSON response without personal data past middleware.MaskPII,
+// which otherwise holds JSON responses back until they are complete
+func SkipPIIMasking(c *gin.Context) {
+	c.Set(SkipPIIMaskingKey, true)
+}
+
+// MaskNRC hides all but the first four characters of an NRC, keeping its separators, so that
+// "123456/78/9" becomes "1234**/**/*"
+func MaskNRC(nrc string) string {
+	const visible = 4
+	masked := []rune(nrc)
+	for i, r := range masked {
+		if i >= visible && r != '/' && r != '-' && r != ' ' {
+			masked[i] = '*'
+		}
+	}
+	return string(masked)
+}
+
+// personalFields are the fields masked in other people's records for callers without PII access:
+// masking functions for strings, or nil for fields that are removed, such as dates that would no
+// longer parse once masked
+var personalFields = map[string]func(string) string{
+	"nrc":           MaskNRC,
+	"date_of_birth": nil,
+	"address":       maskAll,
+	"city":          maskAll,
+	"postal_code":   maskAll,
+}
+
+func maskAll(value string) string {
+	return strings.Repeat("*", len([]rune(value)))
+}
+
+// ContainsPersonalFields reports whether a JSON body may hold fields MaskPersonalData masks, so that
+// bodies that cannot are not decoded
+func ContainsPersonalFields(body []byte) bool {
+	for field := range personalFields {
+		if bytes.Contains(body, []byte(`"`+field+`"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskPersonalData masks the personal fields of every record in a JSON body that belongs to someone
+// other than ownerID. Records are employees, recognised by their firstname and owned through their id,
+// and records with an employee_id, such as identity information, owned through it. It returns whether
+// anything was masked; the body is only re-encoded when it was.
+func MaskPersonalData(body []byte, ownerID uint) ([]byte, bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return body, false, err
+	}
+	if !maskPersonalValue(data, strconv.FormatUint(uint64(ownerID), 10)) {
+		return body, false, nil
+	}
+	masked, err := json.Marshal(data)
+	if err != nil {
+		return body, false, err
+	}
+	return masked, true, nil
+}
+
+func maskPersonalValue(value interface{}, owner string) bool {
+	masked := false
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			masked = maskPersonalValue(item, owner) || masked
+		}
+	case map[string]interface{}:
+		recordOwner, isRecord := v["employee_id"]
+		if !isRecord {
+			if _, isEmployee := v["firstname"]; isEmployee {
+				recordOwner, isRecord = v["id"]
+			}
+		}
+		if number, ok := recordOwner.(json.Number); isRecord && (!ok || number.String() != owner) {
+			for field, mask := range personalFields {
+				switch text, isText := v[field].(string); {
+				case v[field] == nil:
+				case mask == nil:
+					delete(v, field)
+					masked = true
+				case isText && text != "":
+					v[field] = mask(text)
+					masked = true
+				}
+			}
+		}
+		for _, item := range v {
+			masked = maskPersonalValue(item, owner) || masked
+		}
+	}
+	return masked
+}
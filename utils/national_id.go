@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"errors"
+	"hrms-api/database"
+	"hrms-api/models"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNationalIDCountry is returned for a country the organization has no national ID format for
+	ErrNationalIDCountry = errors.New("no national ID format for the country")
+	// ErrNationalIDFormat is returned for a national ID that does not match its country's format
+	ErrNationalIDFormat = errors.New("national ID does not match the format")
+	// ErrNationalIDChecksum is returned for a national ID whose check digit is wrong
+	ErrNationalIDChecksum = errors.New("national ID check digit is wrong")
+)
+
+// NationalIDError is a national ID that failed its country's format, with the format it failed
+type NationalIDError struct {
+	Err    error // ErrNationalIDFormat or ErrNationalIDChecksum
+	Format models.NationalIDFormat
+}
+
+func (e *NationalIDError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NationalIDError) Unwrap() error {
+	return e.Err
+}
+
+// NationalIDCompactSQL returns the SQL for an employee's NRC in compact form, to match NRCs however
+// they were written, including ones stored before national ID formats were set up
+func NationalIDCompactSQL() string {
+	return database.CompactSQL("nrc")
+}
+
+var nationalIDSeparators = regexp.MustCompile(`[^A-Z0-9]`)
+
+// CompactNationalID returns id in upper case with spaces and punctuation removed, so "123456/78/9"
+// and "123456 78 9" are the same
+func CompactNationalID(id string) string {
+	return nationalIDSeparators.ReplaceAllString(strings.ToUpper(id), "")
+}
+
+// WhereNationalID narrows db to employees whose NRC is id, however either was written
+func WhereNationalID(db *gorm.DB, id string) *gorm.DB {
+	return db.Where("nrc = ? OR "+NationalIDCompactSQL()+" = ?", strings.TrimSpace(id), CompactNationalID(id))
+}
+
+// CompileNationalIDPattern compiles a national ID format's pattern to match a whole compact ID
+func CompileNationalIDPattern(pattern string) (*regexp.Regexp, error) {
+	// Compiled alone first so errors quote the pattern as it was given
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// ApplyNationalIDFormat checks id against format and returns it laid out as the format stores it
+func ApplyNationalIDFormat(format models.NationalIDFormat, id string) (string, error) {
+	pattern, err := CompileNationalIDPattern(format.Pattern)
+	if err != nil {
+		return "", err
+	}
+	compact := CompactNationalID(id)
+	if !pattern.MatchString(compact) {
+		return "", &NationalIDError{Err: ErrNationalIDFormat, Format: format}
+	}
+	if format.Checksum == models.NationalIDChecksumLuhn && !luhnValid(compact) {
+		return "", &NationalIDError{Err: ErrNationalIDChecksum, Format: format}
+	}
+	if format.Format == "" {
+		return compact, nil
+	}
+	return pattern.ReplaceAllString(compact, format.Format), nil
+}
+
+// NormalizeNationalID validates id against the organization's national ID format for countryCode, or
+// its default format when countryCode is empty, and returns it as it should be stored. IDs are only
+// trimmed when no country is given and the organization has no default format.
+func NormalizeNationalID(db *gorm.DB, organizationID uint, countryCode, id string) (string, error) {
+	query := db.Where("organization_id = ?", organizationID)
+	if countryCode = strings.ToUpper(strings.TrimSpace(countryCode)); countryCode != "" {
+		query = query.Where("country_code = ?", countryCode)
+	} else {
+		query = query.Where("is_default = ?", true)
+	}
+	var formats []models.NationalIDFormat
+	if err := query.Limit(1).Find(&formats).Error; err != nil {
+		return "", err
+	}
+	if len(formats) == 0 {
+		if countryCode != "" {
+			return "", ErrNationalIDCountry
+		}
+		return strings.TrimSpace(id), nil
+	}
+	return ApplyNationalIDFormat(formats[0], id)
+}
+
+// luhnValid reports whether the last digit of digits is its Luhn check digit
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return len(digits) > 1 && sum%10 == 0
+}
@@ -0,0 +1,366 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/i18n"
+	"hrms-api/models"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+const (
+	notificationRetryBase     = time.Minute
+	notificationMaxRetryDelay = 12 * time.Hour
+)
+
+// errNoAddress and errNoMobile fail a message whose recipient had no address or mobile number;
+// retrying will not give them one
+var (
+	errNoAddress error = permanentError{ErrNoEmailAddress}
+	errNoMobile  error = permanentError{ErrNoMobileNumber}
+)
+
+// EmailEnabled reports whether SMTP is configured for email notifications
+func EmailEnabled() bool {
+	return config.AppConfig != nil && config.AppConfig.SMTPHost != ""
+}
+
+// emailNotificationsEnabled reports whether notifications of the category are also sent by email: SMTP
+// is configured, and neither email notifications nor the category are switched off in the settings
+func emailNotificationsEnabled(category models.NotificationCategory) bool {
+	return EmailEnabled() && CurrentSettings().EmailNotifications && !EmailCategoryMuted(category)
+}
+
+// SendEmail sends an email through the configured SMTP server: plain text, or with an HTML alternative
+// when htmlBody is not empty
+func SendEmail(to, subject, body, htmlBody string) error {
+	if !EmailEnabled() {
+		return ErrEmailNotConfigured
+	}
+
+	cfg := config.AppConfig
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	contentType, content := "text/plain; charset=UTF-8", body
+	if htmlBody != "" {
+		var buf bytes.Buffer
+		parts := multipart.NewWriter(&buf)
+		for _, part := range []struct{ contentType, body string }{{"text/plain", body}, {"text/html", htmlBody}} {
+			w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType + "; charset=UTF-8"}})
+			if err != nil {
+				return err
+			}
+			io.WriteString(w, part.body)
+		}
+		parts.Close()
+		contentType, content = "multipart/alternative; boundary="+parts.Boundary(), buf.String()
+	}
+
+	msg := strings.Join([]string{
+		"From: " + cfg.SMTPFrom,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("UTF-8", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: " + contentType,
+		"",
+		content,
+	}, "\r\n")
+
+	err := smtp.SendMail(cfg.SMTPHost+":"+cfg.SMTPPort, auth, cfg.SMTPFrom, []string{to}, []byte(msg))
+	if err != nil && smtpRejected(err) {
+		return permanentError{err}
+	}
+	return err
+}
+
+// Notify records an in-app notification for the recipient, pushes it to their mobile devices, and queues
+// copies on the other channels the recipient wants the category on, making their first attempt: an email
+// when email is configured and enabled for the category and the recipient has an address, and a text
+// message for the critical categories when an SMS gateway is configured and the recipient has a mobile
+// number. Pushes, like the in-app notification, follow the recipient's preference for the category. Nothing
+// is sent on a channel, or for a group of categories, the recipient has switched off in their notification
+// settings. Every message is stored in the notifications table, and one that fails for a reason that may pass is retried by the
+// notification scheduler. The error returned is that of a copy given up on its first attempt. The
+// subject and message are rendered in the recipient's language. The email uses the organization's email
+// template for the category in that language, if there is an active one, filled in from values and the
+// recipient.
+func Notify(recipient models.Employee, category models.NotificationCategory, subjectText, messageText i18n.Message, values map[string]string, entityType models.AuditEntityType, entityID uint) error {
+	lang, ok := i18n.Parse(recipient.Language)
+	if !ok {
+		lang = i18n.Default
+	}
+	subject, message := subjectText.In(lang), messageText.In(lang)
+
+	settings := NotificationSettingsOf(recipient.ID)
+	if !settings.Receives(category) {
+		return nil
+	}
+
+	now := time.Now()
+	inApp := models.Notification{
+		RecipientID: recipient.ID,
+		Channel:     models.NotificationChannelInApp,
+		Category:    category,
+		Subject:     subject,
+		Message:     message,
+		EntityType:  &entityType,
+		EntityID:    &entityID,
+		Status:      models.NotificationStatusSent,
+		SentAt:      &now,
+	}
+	// Without in-app notifications the push is still sent, but has no notification for the app to open
+	if settings.InApp {
+		if err := database.DB.Create(&inApp).Error; err != nil {
+			return err
+		}
+		PublishEvent(EventNotification, inApp, recipient.OrganizationID, []uint{recipient.ID})
+	}
+
+	preference := notificationPreference(recipient.ID, category)
+	if settings.Push && (preference.Push == nil || *preference.Push) {
+		SendPush(recipient.ID, inApp)
+	}
+	var copies []models.Notification
+	if settings.Email && preference.Email && emailNotificationsEnabled(category) && recipient.Email != nil && *recipient.Email != "" {
+		email := models.Notification{Channel: models.NotificationChannelEmail, Subject: subject, Message: message, Address: recipient.Email}
+		if template := activeEmailTemplate(recipient.OrganizationID, category, lang); template != nil {
+			rendered := RenderEmailTemplate(*template, emailTemplateValues(recipient, subject, message, values))
+			email.Subject, email.Message, email.HTMLMessage = rendered.Subject, rendered.TextBody, rendered.HTMLBody
+		}
+		copies = append(copies, email)
+	}
+	if settings.SMS && preference.SMS && SMSEnabled() && models.IsSMSCategory(category) && recipient.Mobile != nil && *recipient.Mobile != "" {
+		copies = append(copies, models.Notification{Channel: models.NotificationChannelSMS, Subject: subject, Message: message, Address: recipient.Mobile})
+	}
+
+	var errs []error
+	for i := range copies {
+		// As with webhooks, scheduling the first attempt a retry interval out stops the scheduler picking
+		// the message up while that attempt is in flight
+		nextAttemptAt := time.Now().Add(notificationRetryBase)
+		queued := &copies[i]
+		queued.RecipientID = recipient.ID
+		queued.Category = category
+		queued.EntityType = &entityType
+		queued.EntityID = &entityID
+		queued.Status = models.NotificationStatusPending
+		queued.NextAttemptAt = &nextAttemptAt
+		if err := database.DB.Create(queued).Error; err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := DeliverNotification(queued); err != nil && queued.Status == models.NotificationStatusFailed {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeliverNotification makes one attempt to send a queued email or text message and records the outcome.
+// A failed attempt is rescheduled with exponential backoff until the configured maximum number of
+// attempts for the channel, after which the message is marked failed and parked in the dead letters. A
+// message rejected permanently, such as by a 5xx reply from the mail server or an invalid mobile number,
+// is given up straight away.
+func DeliverNotification(message *models.Notification) error {
+	sendErr := sendNotification(message)
+
+	now := time.Now()
+	message.Attempts++
+	if sendErr == nil {
+		message.Status = models.NotificationStatusSent
+		message.SentAt = &now
+		message.NextAttemptAt = nil
+		message.Error = nil
+	} else {
+		errMsg := sendErr.Error()
+		message.Error = &errMsg
+		if isPermanent(sendErr) || message.Attempts >= notificationMaxAttempts(message.Channel) {
+			message.Status = models.NotificationStatusFailed
+			message.NextAttemptAt = nil
+		} else {
+			nextAttemptAt := now.Add(backoff(notificationRetryBase, notificationMaxRetryDelay, message.Attempts))
+			message.Status = models.NotificationStatusPending
+			message.NextAttemptAt = &nextAttemptAt
+		}
+	}
+
+	if err := database.DB.Omit("Recipient").Save(message).Error; err != nil {
+		return err
+	}
+	switch message.Status {
+	case models.NotificationStatusSent:
+		resolveDeadLetters("notification_id", message.ID)
+	case models.NotificationStatusFailed:
+		kind := models.DeadLetterEmail
+		if message.Channel == models.NotificationChannelSMS {
+			kind = models.DeadLetterSMS
+		}
+		var organizationID uint
+		database.DB.Model(&models.Employee{}).Unscoped().Where("id = ?", message.RecipientID).Pluck("organization_id", &organizationID)
+		recordDeadLetter(models.DeadLetter{
+			OrganizationID: organizationID,
+			Kind:           kind,
+			NotificationID: &message.ID,
+			Reason:         *message.Error,
+			Permanent:      isPermanent(sendErr),
+			Attempts:       message.Attempts,
+		})
+	}
+	return sendErr
+}
+
+// sendNotification sends a message on its channel to the address it was queued with
+func sendNotification(message *models.Notification) error {
+	hasAddress := message.Address != nil && *message.Address != ""
+	if message.Channel == models.NotificationChannelSMS {
+		if !hasAddress {
+			return errNoMobile
+		}
+		return SendSMS(*message.Address, message.Message)
+	}
+	if !hasAddress {
+		return errNoAddress
+	}
+	return SendEmail(*message.Address, message.Subject, message.Message, stringValue(message.HTMLMessage))
+}
+
+// emailTemplateValues adds the placeholders every email template can use to the notification's values
+func emailTemplateValues(recipient models.Employee, subject, message string, values map[string]string) map[string]string {
+	all := map[string]string{
+		"recipient.full_name": strings.TrimSpace(recipient.Firstname + " " + recipient.Lastname),
+		"recipient.firstname": recipient.Firstname,
+		"default.subject":     subject,
+		"default.message":     message,
+	}
+	for key, value := range values {
+		all[key] = value
+	}
+	return all
+}
+
+// ProcessNotificationRetries re-sends pending emails and text messages whose next attempt is due. Nothing
+// is sent on a channel that is not configured, SMTP or the SMS gateway; its messages wait until it is.
+func ProcessNotificationRetries() (int, []error) {
+	var channels []models.NotificationChannel
+	if EmailEnabled() {
+		channels = append(channels, models.NotificationChannelEmail)
+	}
+	if SMSEnabled() {
+		channels = append(channels, models.NotificationChannelSMS)
+	}
+	if len(channels) == 0 {
+		return 0, nil
+	}
+
+	var messages []models.Notification
+	database.DB.Where("channel IN ? AND status = ? AND next_attempt_at <= ?",
+		channels, models.NotificationStatusPending, time.Now()).
+		Order("next_attempt_at").
+		Find(&messages)
+
+	sent := 0
+	var errs []error
+	for i := range messages {
+		message := &messages[i]
+		if err := DeliverNotification(message); err != nil {
+			errs = append(errs, fmt.Errorf("%s %d to employee %d (attempt %d): %w", message.Channel, message.ID, message.RecipientID, message.Attempts, err))
+			continue
+		}
+		sent++
+	}
+	return sent, errs
+}
+
+// smtpRejected reports whether an SMTP error is a permanent rejection, a 5xx reply, rather than a
+// temporary one or a connection failure that may pass
+func smtpRejected(err error) bool {
+	var reply *textproto.Error
+	return errors.As(err, &reply) && reply.Code >= 500
+}
+
+func notificationMaxAttempts(channel models.NotificationChannel) int {
+	if channel == models.NotificationChannelSMS {
+		if config.AppConfig != nil && config.AppConfig.SMSMaxAttempts > 0 {
+			return config.AppConfig.SMSMaxAttempts
+		}
+		return 5
+	}
+	if config.AppConfig != nil && config.AppConfig.EmailMaxAttempts > 0 {
+		return config.AppConfig.EmailMaxAttempts
+	}
+	return 8
+}
+
+// NotificationSettingsOf returns the employee's notification settings, or the defaults when they have not
+// chosen any
+func NotificationSettingsOf(employeeID uint) models.NotificationSettings {
+	var settings models.NotificationSettings
+	err := database.DB.Where("employee_id = ?", employeeID).Limit(1).Find(&settings).Error
+	if err != nil || settings.ID == 0 {
+		return DefaultNotificationSettings(employeeID)
+	}
+	return settings
+}
+
+// DefaultNotificationSettings are the notification settings of an employee who has not chosen any: every
+// group of notifications, on every channel
+func DefaultNotificationSettings(employeeID uint) models.NotificationSettings {
+	return models.NotificationSettings{
+		EmployeeID: employeeID,
+		InApp:      true, Email: true, SMS: true, Push: true,
+		Approvals: true, Reminders: true, Announcements: true,
+	}
+}
+
+// notificationPreference returns the employee's channel choice for the category, or the defaults when
+// they have not made one
+func notificationPreference(employeeID uint, category models.NotificationCategory) models.NotificationPreference {
+	var preference models.NotificationPreference
+	err := database.DB.Where("employee_id = ? AND category = ?", employeeID, category).Limit(1).Find(&preference).Error
+	if err != nil || preference.ID == 0 {
+		return DefaultNotificationPreference(employeeID, category)
+	}
+	return preference
+}
+
+// DefaultNotificationPreference is the channel choice of an employee who has not made one for the
+// category: email and push, and a text message for the categories that may be sent by SMS
+func DefaultNotificationPreference(employeeID uint, category models.NotificationCategory) models.NotificationPreference {
+	push := true
+	return models.NotificationPreference{EmployeeID: employeeID, Category: category, Email: true, SMS: models.IsSMSCategory(category), Push: &push}
+}
+
+// NotificationPreferences returns the employee's channel choice for every category, the defaults for
+// those they have not made one for
+func NotificationPreferences(employeeID uint) ([]models.NotificationPreference, error) {
+	var saved []models.NotificationPreference
+	if err := database.DB.Where("employee_id = ?", employeeID).Find(&saved).Error; err != nil {
+		return nil, err
+	}
+	byCategory := make(map[models.NotificationCategory]models.NotificationPreference, len(saved))
+	for _, preference := range saved {
+		byCategory[preference.Category] = preference
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(models.NotificationCategories))
+	for _, category := range models.NotificationCategories {
+		preference, ok := byCategory[category]
+		if !ok {
+			preference = DefaultNotificationPreference(employeeID, category)
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
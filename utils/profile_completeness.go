@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"time"
+)
+
+// Profile section statuses
+const (
+	ProfileSectionComplete = "complete"
+	ProfileSectionMissing  = "missing"
+	ProfileSectionStale    = "stale"
+)
+
+// Profile sections checked for completeness
+const (
+	ProfileSectionIdentity         = "identity"
+	ProfileSectionEmployment       = "employment"
+	ProfileSectionDocuments        = "documents"
+	ProfileSectionEmergencyContact = "emergency_contact"
+	ProfileSectionCompliance       = "compliance"
+)
+
+// ProfileSections lists the sections in the order they are reported
+var ProfileSections = []string{
+	ProfileSectionIdentity,
+	ProfileSectionEmployment,
+	ProfileSectionDocuments,
+	ProfileSectionEmergencyContact,
+	ProfileSectionCompliance,
+}
+
+// identityStaleAfter is how long identity details can go without an update before they need reconfirming
+const identityStaleAfter = 365 * 24 * time.Hour
+
+// ProfileSectionResult describes the state of one section of an employee profile
+type ProfileSectionResult struct {
+	Section string `json:"section" example:"identity"`
+	Status  string `json:"status" example:"stale"`
+	Detail  string `json:"detail,omitempty" example:"Identity information not updated in over a year"`
+}
+
+// ProfileCompleteness summarises which sections of an employee profile are complete
+type ProfileCompleteness struct {
+	EmployeeID   uint                   `json:"employee_id" example:"12"`
+	EmployeeName string                 `json:"employee_name" example:"Jane Doe"`
+	Department   string                 `json:"department" example:"Finance"`
+	Percentage   float64                `json:"percentage" example:"80"`
+	Sections     []ProfileSectionResult `json:"sections"`
+}
+
+// CalculateProfileCompleteness checks each profile section of an employee for missing or stale data
+func CalculateProfileCompleteness(employee models.Employee) ProfileCompleteness {
+	now := time.Now()
+	result := ProfileCompleteness{
+		EmployeeID:   employee.ID,
+		EmployeeName: employee.Firstname + " " + employee.Lastname,
+		Department:   employee.Department,
+	}
+
+	var identity models.IdentityInformation
+	hasIdentity := database.DB.Where("employee_id = ?", employee.ID).First(&identity).Error == nil
+
+	// Identity
+	switch {
+	case !hasIdentity || identity.DateOfBirth == nil || identity.Nationality == nil:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionIdentity, ProfileSectionMissing, "Identity information or date of birth/nationality not recorded"})
+	case now.Sub(identity.UpdatedAt) > identityStaleAfter:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionIdentity, ProfileSectionStale, "Identity information not updated in over a year"})
+	default:
+		result.Sections = append(result.Sections, ProfileSectionResult{Section: ProfileSectionIdentity, Status: ProfileSectionComplete})
+	}
+
+	// Employment
+	var employment models.EmploymentDetails
+	switch {
+	case database.DB.Where("employee_id = ?", employee.ID).First(&employment).Error != nil:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionEmployment, ProfileSectionMissing, "Employment details not recorded"})
+	case employment.HireDate == nil || employment.EmploymentType == "":
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionEmployment, ProfileSectionMissing, "Hire date or employment type not recorded"})
+	case employment.ProbationEndDate != nil && employment.ProbationEndDate.Before(now) &&
+		(employment.ProbationStatus == nil || *employment.ProbationStatus == "pending"):
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionEmployment, ProfileSectionStale, "Probation has ended but its outcome is not recorded"})
+	default:
+		result.Sections = append(result.Sections, ProfileSectionResult{Section: ProfileSectionEmployment, Status: ProfileSectionComplete})
+	}
+
+	// Documents: an ID and a contract are required and must not have expired
+	var documents []models.Document
+	database.DB.Where("employee_id = ? AND document_type IN ? AND status != ?", employee.ID,
+		[]models.DocumentType{models.DocumentTypeID, models.DocumentTypeContract}, models.DocumentStatusArchived).Find(&documents)
+	hasDocument := map[models.DocumentType]bool{}
+	expiredDocuments := 0
+	for _, document := range documents {
+		hasDocument[document.DocumentType] = true
+		if document.Status == models.DocumentStatusExpired || (document.ExpiryDate != nil && document.ExpiryDate.Before(now)) {
+			expiredDocuments++
+		}
+	}
+	switch {
+	case !hasDocument[models.DocumentTypeID] || !hasDocument[models.DocumentTypeContract]:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionDocuments, ProfileSectionMissing, "ID document or employment contract not uploaded"})
+	case expiredDocuments > 0:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionDocuments, ProfileSectionStale, fmt.Sprintf("%d required document(s) expired", expiredDocuments)})
+	default:
+		result.Sections = append(result.Sections, ProfileSectionResult{Section: ProfileSectionDocuments, Status: ProfileSectionComplete})
+	}
+
+	// Emergency contact: either on the identity record or the employee record
+	hasEmergencyContact := (hasIdentity && identity.EmergencyContact != nil && identity.EmergencyPhone != nil) ||
+		(employee.EmergencyContactName != nil && employee.EmergencyContactPhone != nil)
+	if hasEmergencyContact {
+		result.Sections = append(result.Sections, ProfileSectionResult{Section: ProfileSectionEmergencyContact, Status: ProfileSectionComplete})
+	} else {
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionEmergencyContact, ProfileSectionMissing, "Emergency contact name or phone not recorded"})
+	}
+
+	// Compliance: every active mandatory requirement needs a current record
+	var requirements []models.ComplianceRequirement
+	database.DB.Where("is_mandatory = ? AND is_active = ? AND organization_id = ?", true, true, employee.OrganizationID).Find(&requirements)
+	var records []models.ComplianceRecord
+	database.DB.Where("employee_id = ?", employee.ID).Find(&records)
+	latest := map[uint]models.ComplianceRecord{}
+	for _, record := range records {
+		if existing, ok := latest[record.RequirementID]; !ok || record.UpdatedAt.After(existing.UpdatedAt) {
+			latest[record.RequirementID] = record
+		}
+	}
+	missing, stale := 0, 0
+	for _, requirement := range requirements {
+		record, ok := latest[requirement.ID]
+		switch {
+		case !ok || record.Status == models.ComplianceStatusPending || record.Status == models.ComplianceStatusNonCompliant:
+			missing++
+		case record.Status == models.ComplianceStatusExpired || (record.ExpiryDate != nil && record.ExpiryDate.Before(now)):
+			stale++
+		}
+	}
+	switch {
+	case missing > 0:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionCompliance, ProfileSectionMissing, fmt.Sprintf("%d mandatory requirement(s) not met", missing)})
+	case stale > 0:
+		result.Sections = append(result.Sections, ProfileSectionResult{ProfileSectionCompliance, ProfileSectionStale, fmt.Sprintf("%d mandatory requirement(s) expired", stale)})
+	default:
+		result.Sections = append(result.Sections, ProfileSectionResult{Section: ProfileSectionCompliance, Status: ProfileSectionComplete})
+	}
+
+	complete := 0
+	for _, section := range result.Sections {
+		if section.Status == ProfileSectionComplete {
+			complete++
+		}
+	}
+	result.Percentage = float64(complete) / float64(len(result.Sections)) * 100
+
+	return result
+}
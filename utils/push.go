@@ -0,0 +1,387 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	pushTimeout = 15 * time.Second
+	// pushBodyLength keeps the message well inside the 4 KB payload FCM and APNs accept
+	pushBodyLength = 1000
+	// apnsTokenLifetime is how long an APNs provider token is reused; Apple rejects them after an hour
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+var pushClient = &http.Client{Timeout: pushTimeout}
+
+// pushSends tracks pushes sent in the background by SendPush
+var pushSends sync.WaitGroup
+
+// ErrPushNotConfigured is returned for a device whose push service is not configured
+var ErrPushNotConfigured = errors.New("push service of the device is not configured")
+
+// errDeviceTokenInvalid is returned by push providers when the service no longer knows the device, such
+// as after the app was uninstalled; the token is then removed
+var errDeviceTokenInvalid = errors.New("device token is no longer valid")
+
+// PushMessage is a push notification as shown on the device, with data the app opens it with
+type PushMessage struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// pushProvider sends push notifications through one push service
+type pushProvider interface {
+	send(token string, message PushMessage) error
+}
+
+// pushProviderFor returns the sender of the push service, or nil when that service is not configured
+func pushProviderFor(provider models.PushProvider) pushProvider {
+	if config.AppConfig == nil {
+		return nil
+	}
+	switch {
+	case provider == models.PushProviderFCM && config.AppConfig.FCMCredentials != "":
+		return fcmProvider{}
+	case provider == models.PushProviderAPNs && config.AppConfig.APNsAuthKey != "":
+		return apnsProvider{}
+	}
+	return nil
+}
+
+// PushEnabled reports whether push notifications can be sent through FCM or APNs
+func PushEnabled() bool {
+	return PushProviderEnabled(models.PushProviderFCM) || PushProviderEnabled(models.PushProviderAPNs)
+}
+
+// PushProviderEnabled reports whether the push service is configured
+func PushProviderEnabled(provider models.PushProvider) bool {
+	return pushProviderFor(provider) != nil
+}
+
+// SendPush sends a notification, in the background, to each registered device of the employee whose push
+// service is configured. Pushes are not retried, as a late one is of little use; the in-app notification
+// is still there.
+func SendPush(employeeID uint, notification models.Notification) {
+	if !PushEnabled() {
+		return
+	}
+	var devices []models.DeviceToken
+	if err := database.DB.Where("employee_id = ?", employeeID).Find(&devices).Error; err != nil {
+		log.Printf("❌ Push: failed to load devices of employee %d: %v", employeeID, err)
+		return
+	}
+
+	message := pushMessage(notification)
+	for _, device := range devices {
+		provider := pushProviderFor(device.Provider)
+		if provider == nil {
+			continue
+		}
+		pushSends.Add(1)
+		go func() {
+			defer pushSends.Done()
+			if err := deliverPush(provider, device, message); err != nil {
+				log.Printf("❌ Push: notification %d to device %d of employee %d: %v", notification.ID, device.ID, employeeID, err)
+			}
+		}()
+	}
+}
+
+// SendTestPush sends a test notification to a device straight away and returns the push service's error
+func SendTestPush(device models.DeviceToken, title, body string) error {
+	provider := pushProviderFor(device.Provider)
+	if provider == nil {
+		return ErrPushNotConfigured
+	}
+	return deliverPush(provider, device, PushMessage{Title: title, Body: body, Data: map[string]string{"type": "test"}})
+}
+
+// WaitForPushSends waits for pushes started by SendPush to finish
+func WaitForPushSends() {
+	pushSends.Wait()
+}
+
+// deliverPush sends a message to a device and records the outcome on it, removing a token the push
+// service no longer accepts
+func deliverPush(provider pushProvider, device models.DeviceToken, message PushMessage) error {
+	err := provider.send(device.Token, message)
+	switch {
+	case err == nil:
+		database.DB.Model(&device).Updates(map[string]interface{}{"last_push_at": time.Now(), "last_error": nil})
+	case errors.Is(err, errDeviceTokenInvalid):
+		database.DB.Delete(&device)
+	default:
+		database.DB.Model(&device).Update("last_error", err.Error())
+	}
+	return err
+}
+
+// pushMessage is the push of an in-app notification. The data lets the app open what it is about;
+// there is no notification ID when the recipient has in-app notifications switched off.
+func pushMessage(notification models.Notification) PushMessage {
+	body := notification.Message
+	if runes := []rune(body); len(runes) > pushBodyLength {
+		body = string(runes[:pushBodyLength-1]) + "…"
+	}
+	data := map[string]string{"category": string(notification.Category)}
+	if notification.ID != 0 {
+		data["notification_id"] = strconv.FormatUint(uint64(notification.ID), 10)
+	}
+	if notification.EntityType != nil && notification.EntityID != nil {
+		data["entity_type"] = string(*notification.EntityType)
+		data["entity_id"] = strconv.FormatUint(uint64(*notification.EntityID), 10)
+	}
+	return PushMessage{Title: notification.Subject, Body: body, Data: data}
+}
+
+// fcmProvider sends through the FCM HTTP v1 API, authenticating as the Firebase service account
+type fcmProvider struct{}
+
+// fcmServiceAccount is the part of a service account key FCM needs
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// fcmAccessToken caches the OAuth access token of the service account until shortly before it expires
+var fcmAccessToken struct {
+	sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (fcmProvider) send(token string, message PushMessage) error {
+	var account fcmServiceAccount
+	if err := json.Unmarshal([]byte(config.AppConfig.FCMCredentials), &account); err != nil {
+		return fmt.Errorf("reading FCM_CREDENTIALS: %w", err)
+	}
+	accessToken, err := fcmServiceAccountToken(account)
+	if err != nil {
+		return err
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"message": map[string]interface{}{
+			"token":        token,
+			"notification": map[string]string{"title": message.Title, "body": message.Body},
+			"data":         message.Data,
+			"android":      map[string]string{"priority": "high"},
+		},
+	})
+	baseURL := "https://fcm.googleapis.com"
+	if config.AppConfig.FCMAPIURL != "" {
+		baseURL = config.AppConfig.FCMAPIURL
+	}
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/v1/projects/"+url.PathEscape(account.ProjectID)+"/messages:send", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := pushClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	var failure struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&failure)
+	errorCode := failure.Error.Status
+	for _, detail := range failure.Error.Details {
+		if detail.ErrorCode != "" {
+			errorCode = detail.ErrorCode
+		}
+	}
+	err = fmt.Errorf("fcm returned %d: %s (%s)", resp.StatusCode, failure.Error.Message, errorCode)
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		// The access token may have been revoked; fetch a new one next time
+		fcmAccessToken.Lock()
+		fcmAccessToken.token = ""
+		fcmAccessToken.Unlock()
+	case errorCode == "UNREGISTERED" || resp.StatusCode == http.StatusNotFound,
+		errorCode == "INVALID_ARGUMENT" && strings.Contains(failure.Error.Message, "registration token"):
+		return fmt.Errorf("%w: %v", errDeviceTokenInvalid, err)
+	}
+	return err
+}
+
+// fcmServiceAccountToken exchanges a JWT signed with the service account's key for an access token
+func fcmServiceAccountToken(account fcmServiceAccount) (string, error) {
+	fcmAccessToken.Lock()
+	defer fcmAccessToken.Unlock()
+	if fcmAccessToken.token != "" && time.Now().Before(fcmAccessToken.expiresAt) {
+		return fcmAccessToken.token, nil
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return "", fmt.Errorf("reading the private key of FCM_CREDENTIALS: %w", err)
+	}
+	tokenURI := account.TokenURI
+	if tokenURI == "" {
+		tokenURI = "https://oauth2.googleapis.com/token"
+	}
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   account.ClientEmail,
+		"scope": "https://www.googleapis.com/auth/firebase.messaging",
+		"aud":   tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := pushClient.PostForm(tokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		ErrorDescription string `json:"error_description"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&result)
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return "", fmt.Errorf("fetching an FCM access token returned %d: %s", resp.StatusCode, result.ErrorDescription)
+	}
+
+	fcmAccessToken.token = result.AccessToken
+	fcmAccessToken.expiresAt = now.Add(time.Duration(result.ExpiresIn)*time.Second - time.Minute)
+	return result.AccessToken, nil
+}
+
+// apnsProvider sends through the APNs HTTP/2 API, authenticating with a token signed by the team's key
+type apnsProvider struct{}
+
+// apnsProviderToken caches the signed provider token, as Apple throttles providers that sign too often
+var apnsProviderToken struct {
+	sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func (apnsProvider) send(token string, message PushMessage) error {
+	cfg := config.AppConfig
+	authToken, err := apnsAuthToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{"title": message.Title, "body": message.Body},
+			"sound": "default",
+		},
+	}
+	for key, value := range message.Data {
+		payload[key] = value
+	}
+	body, _ := json.Marshal(payload)
+
+	baseURL := "https://api.push.apple.com"
+	switch {
+	case cfg.APNsAPIURL != "":
+		baseURL = cfg.APNsAPIURL
+	case cfg.APNsSandbox:
+		baseURL = "https://api.sandbox.push.apple.com"
+	}
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/3/device/"+url.PathEscape(token), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+authToken)
+	req.Header.Set("apns-topic", cfg.APNsTopic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := pushClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var failure struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&failure)
+	err = fmt.Errorf("apns returned %d: %s", resp.StatusCode, failure.Reason)
+
+	switch {
+	case failure.Reason == "ExpiredProviderToken":
+		apnsProviderToken.Lock()
+		apnsProviderToken.token = ""
+		apnsProviderToken.Unlock()
+	case resp.StatusCode == http.StatusGone, failure.Reason == "BadDeviceToken", failure.Reason == "DeviceTokenNotForTopic":
+		return fmt.Errorf("%w: %v", errDeviceTokenInvalid, err)
+	}
+	return err
+}
+
+// apnsAuthToken returns the provider token, signing a new one when the cached one is due for renewal
+func apnsAuthToken() (string, error) {
+	apnsProviderToken.Lock()
+	defer apnsProviderToken.Unlock()
+	if apnsProviderToken.token != "" && time.Since(apnsProviderToken.issuedAt) < apnsTokenLifetime {
+		return apnsProviderToken.token, nil
+	}
+
+	cfg := config.AppConfig
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(cfg.APNsAuthKey))
+	if err != nil {
+		return "", fmt.Errorf("reading APNS_AUTH_KEY: %w", err)
+	}
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"iss": cfg.APNsTeamID, "iat": now.Unix()})
+	token.Header["kid"] = cfg.APNsKeyID
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	apnsProviderToken.token, apnsProviderToken.issuedAt = signed, now
+	return signed, nil
+}
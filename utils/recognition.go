@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/models"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// RecognitionCount is a kudos tally for one company value, department or employee
+type RecognitionCount struct {
+	ID    uint   `json:"id,omitempty" example:"3"`
+	Name  string `json:"name" example:"Teamwork"`
+	Count int    `json:"count" example:"42"`
+}
+
+// DepartmentRecognition tallies kudos sent and received by a department
+type DepartmentRecognition struct {
+	Department string `json:"department" example:"Finance"`
+	Sent       int    `json:"sent" example:"18"`
+	Received   int    `json:"received" example:"21"`
+}
+
+// RecognitionStats summarises peer recognition over a quarter
+type RecognitionStats struct {
+	Quarter           string                  `json:"quarter" example:"2025-Q1"`
+	PeriodStart       time.Time               `json:"period_start"`
+	PeriodEnd         time.Time               `json:"period_end"`
+	TotalKudos        int                     `json:"total_kudos" example:"126"`
+	UniqueSenders     int                     `json:"unique_senders" example:"48"`
+	UniqueRecipients  int                     `json:"unique_recipients" example:"61"`
+	ActiveEmployees   int                     `json:"active_employees" example:"80"`
+	ParticipationRate float64                 `json:"participation_rate" example:"60"` // Percentage of active employees who sent at least one kudos
+	ByValue           []RecognitionCount      `json:"by_value"`
+	ByDepartment      []DepartmentRecognition `json:"by_department"`
+	TopRecipients     []RecognitionCount      `json:"top_recipients"`
+}
+
+// QuarterRange returns the first day of the quarter and the first day of the following quarter
+func QuarterRange(year, quarter int) (time.Time, time.Time) {
+	start := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 3, 0)
+}
+
+// GetRecognitionStats tallies kudos given during a quarter by value, department and recipient
+func GetRecognitionStats(db *gorm.DB, year, quarter int) (RecognitionStats, error) {
+	start, end := QuarterRange(year, quarter)
+	stats := RecognitionStats{
+		Quarter:     fmt.Sprintf("%d-Q%d", year, quarter),
+		PeriodStart: start,
+		PeriodEnd:   end.AddDate(0, 0, -1),
+	}
+
+	var kudos []models.Kudos
+	if err := db.Preload("Sender").Preload("Recipient").Preload("Value").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Find(&kudos).Error; err != nil {
+		return stats, err
+	}
+
+	var activeEmployees int64
+	db.Model(&models.Employee{}).Where("status = ? AND role != ?", "active", models.RoleAdmin).Count(&activeEmployees)
+	stats.ActiveEmployees = int(activeEmployees)
+
+	senders := map[uint]bool{}
+	byValue := map[uint]*RecognitionCount{}
+	byDepartment := map[string]*DepartmentRecognition{}
+	byRecipient := map[uint]*RecognitionCount{}
+	department := func(name string) *DepartmentRecognition {
+		if byDepartment[name] == nil {
+			byDepartment[name] = &DepartmentRecognition{Department: name}
+		}
+		return byDepartment[name]
+	}
+
+	for _, k := range kudos {
+		senders[k.SenderID] = true
+		if byValue[k.ValueID] == nil {
+			byValue[k.ValueID] = &RecognitionCount{ID: k.ValueID, Name: k.Value.Name}
+		}
+		byValue[k.ValueID].Count++
+		if byRecipient[k.RecipientID] == nil {
+			byRecipient[k.RecipientID] = &RecognitionCount{ID: k.RecipientID, Name: k.Recipient.Firstname + " " + k.Recipient.Lastname}
+		}
+		byRecipient[k.RecipientID].Count++
+		department(k.Sender.Department).Sent++
+		department(k.Recipient.Department).Received++
+	}
+
+	stats.TotalKudos = len(kudos)
+	stats.UniqueSenders = len(senders)
+	stats.UniqueRecipients = len(byRecipient)
+	if stats.ActiveEmployees > 0 {
+		stats.ParticipationRate = float64(stats.UniqueSenders) / float64(stats.ActiveEmployees) * 100
+	}
+
+	stats.ByValue = sortedRecognitionCounts(byValue)
+	stats.TopRecipients = sortedRecognitionCounts(byRecipient)
+	if len(stats.TopRecipients) > 10 {
+		stats.TopRecipients = stats.TopRecipients[:10]
+	}
+	stats.ByDepartment = make([]DepartmentRecognition, 0, len(byDepartment))
+	for _, dept := range byDepartment {
+		stats.ByDepartment = append(stats.ByDepartment, *dept)
+	}
+	sort.Slice(stats.ByDepartment, func(i, j int) bool {
+		return stats.ByDepartment[i].Department < stats.ByDepartment[j].Department
+	})
+
+	return stats, nil
+}
+
+// sortedRecognitionCounts orders tallies by count, highest first, then by name
+func sortedRecognitionCounts(counts map[uint]*RecognitionCount) []RecognitionCount {
+	sorted := make([]RecognitionCount, 0, len(counts))
+	for _, count := range counts {
+		sorted = append(sorted, *count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// ExportRecognitionStatsToExcel exports quarterly recognition stats to Excel with a summary and one section per breakdown
+func ExportRecognitionStatsToExcel(stats RecognitionStats) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Recognition"
+	f.NewSheet(sheetName)
+	f.DeleteSheet("Sheet1")
+
+	f.SetCellValue(sheetName, "A1", CompanyName())
+	instStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Bold: true,
+			Size: 14,
+		},
+	})
+	f.SetCellStyle(sheetName, "A1", "A1", instStyle)
+	f.SetCellValue(sheetName, "A2", fmt.Sprintf("Recognition report %s (%s to %s)", stats.Quarter,
+		stats.PeriodStart.Format("2006-01-02"), stats.PeriodEnd.Format("2006-01-02")))
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0E0E0"}, Pattern: 1},
+	})
+	f.SetColWidth(sheetName, "A", "A", 30)
+	f.SetColWidth(sheetName, "B", "C", 14)
+
+	row := 4
+	summary := [][]interface{}{
+		{"Total kudos", stats.TotalKudos},
+		{"Unique senders", stats.UniqueSenders},
+		{"Unique recipients", stats.UniqueRecipients},
+		{"Active employees", stats.ActiveEmployees},
+		{"Participation rate (%)", fmt.Sprintf("%.1f", stats.ParticipationRate)},
+	}
+	for _, line := range summary {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), line[0])
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), line[1])
+		row++
+	}
+
+	section := func(headers ...string) {
+		row++
+		for i, header := range headers {
+			cell := fmt.Sprintf("%c%d", 'A'+i, row)
+			f.SetCellValue(sheetName, cell, header)
+			f.SetCellStyle(sheetName, cell, cell, headerStyle)
+		}
+		row++
+	}
+
+	section("Company Value", "Kudos")
+	for _, value := range stats.ByValue {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), value.Name)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), value.Count)
+		row++
+	}
+
+	section("Department", "Sent", "Received")
+	for _, dept := range stats.ByDepartment {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), dept.Department)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), dept.Sent)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), dept.Received)
+		row++
+	}
+
+	section("Top Recipient", "Kudos")
+	for _, recipient := range stats.TopRecipients {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), recipient.Name)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), recipient.Count)
+		row++
+	}
+
+	row++
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04:05")))
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
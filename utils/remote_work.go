@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"hrms-api/database"
+	"hrms-api/models"
+	"time"
+)
+
+// Presence statuses shown on the team calendar
+const (
+	PresenceInOffice   = "in_office"
+	PresenceRemote     = "remote"
+	PresenceOnLeave    = "on_leave"
+	PresenceNonWorking = "non_working"
+)
+
+// PresenceDay is an employee's expected whereabouts on one day
+type PresenceDay struct {
+	Date   string `json:"date" example:"2025-03-17"`
+	Status string `json:"status" example:"remote"`
+	Detail string `json:"detail,omitempty" example:"Annual"`
+}
+
+// EmployeePresence is an employee's row on the team calendar
+type EmployeePresence struct {
+	EmployeeID   uint          `json:"employee_id" example:"12"`
+	EmployeeName string        `json:"employee_name" example:"Jane Doe"`
+	Department   string        `json:"department" example:"Finance"`
+	Days         []PresenceDay `json:"days"`
+}
+
+// RemoteWorkUtilization totals how an employee's working days were spent over a period
+type RemoteWorkUtilization struct {
+	EmployeeID       uint    `json:"employee_id" example:"12"`
+	EmployeeName     string  `json:"employee_name" example:"Jane Doe"`
+	Department       string  `json:"department" example:"Finance"`
+	WorkingDays      int     `json:"working_days" example:"21"`
+	OfficeDays       int     `json:"office_days" example:"14"`
+	RemoteDays       int     `json:"remote_days" example:"5"`
+	LeaveDays        int     `json:"leave_days" example:"2"`
+	RemotePercentage float64 `json:"remote_percentage" example:"26.3"` // Remote days as a share of working days not on leave
+}
+
+// BuildPresenceCalendar works out whether each employee is in the office, remote, on leave or not
+// scheduled to work on each day of the range. Approved leave takes precedence over approved remote work.
+func BuildPresenceCalendar(employees []models.Employee, from, to time.Time) []EmployeePresence {
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	var leaves []models.Leave
+	database.DB.Preload("LeaveType").
+		Where("employee_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeIDs, models.StatusApproved, to, from).
+		Find(&leaves)
+	var remote []models.RemoteWorkRequest
+	database.DB.Where("employee_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeIDs, models.RemoteWorkApproved, to, from).
+		Find(&remote)
+	var shifts []models.ShiftAssignment
+	database.DB.Where("employee_id IN ? AND date >= ? AND date <= ?", employeeIDs, from, to).Find(&shifts)
+
+	rostered := map[uint]map[string]bool{}
+	for _, shift := range shifts {
+		if rostered[shift.EmployeeID] == nil {
+			rostered[shift.EmployeeID] = map[string]bool{}
+		}
+		rostered[shift.EmployeeID][shift.Date.Format("2006-01-02")] = true
+	}
+
+	calendar := make([]EmployeePresence, 0, len(employees))
+	for _, employee := range employees {
+		schedule := baseWorkSchedule(employee.ID)
+		row := EmployeePresence{
+			EmployeeID:   employee.ID,
+			EmployeeName: employee.Firstname + " " + employee.Lastname,
+			Department:   employee.Department,
+		}
+
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			date := day.Format("2006-01-02")
+			entry := PresenceDay{Date: date, Status: PresenceNonWorking}
+			if rostered[employee.ID][date] || IsScheduledWorkDay(schedule, day) {
+				entry.Status = PresenceInOffice
+			}
+
+			if entry.Status != PresenceNonWorking {
+				for _, request := range remote {
+					if request.EmployeeID == employee.ID && coversDate(request.StartDate, request.EndDate, date) {
+						entry.Status = PresenceRemote
+						if request.Location != nil {
+							entry.Detail = *request.Location
+						}
+						break
+					}
+				}
+				for _, leave := range leaves {
+					if leave.EmployeeID == employee.ID && coversDate(leave.StartDate, leave.EndDate, date) {
+						entry.Status = PresenceOnLeave
+						entry.Detail = leave.LeaveType.Name
+						break
+					}
+				}
+			}
+
+			row.Days = append(row.Days, entry)
+		}
+		calendar = append(calendar, row)
+	}
+
+	return calendar
+}
+
+// SummarizeRemoteWork totals a presence calendar into remote work utilization per employee
+func SummarizeRemoteWork(calendar []EmployeePresence) []RemoteWorkUtilization {
+	utilization := make([]RemoteWorkUtilization, 0, len(calendar))
+	for _, row := range calendar {
+		u := RemoteWorkUtilization{EmployeeID: row.EmployeeID, EmployeeName: row.EmployeeName, Department: row.Department}
+		for _, day := range row.Days {
+			switch day.Status {
+			case PresenceInOffice:
+				u.WorkingDays++
+				u.OfficeDays++
+			case PresenceRemote:
+				u.WorkingDays++
+				u.RemoteDays++
+			case PresenceOnLeave:
+				u.WorkingDays++
+				u.LeaveDays++
+			}
+		}
+		if available := u.WorkingDays - u.LeaveDays; available > 0 {
+			u.RemotePercentage = float64(u.RemoteDays) / float64(available) * 100
+		}
+		utilization = append(utilization, u)
+	}
+	return utilization
+}
+
+// coversDate reports whether a YYYY-MM-DD date falls within an inclusive date range
+func coversDate(start, end time.Time, date string) bool {
+	return start.Format("2006-01-02") <= date && end.Format("2006-01-02") >= date
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"hrms-api/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Code      ErrorCode   `json:"code" example:"invalid_credentials"`
+	Message   string      `json:"message" example:"Invalid credentials"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty" example:"4f9c2a7d1e0b8c36"`
+	Error     string      `json:"error" example:"Invalid credentials"` // Same as message, kept for existing clients
+}
+
+// RespondError writes an error response with the generic code for its status
+func RespondError(c *gin.Context, status int, message string) {
+	RespondErrorCode(c, status, CodeForStatus(status), message, nil)
+}
+
+// RespondErrorFrom writes err as an error response, using the code of the sentinel error it wraps
+// when there is one
+func RespondErrorFrom(c *gin.Context, status int, err error) {
+	code := ErrorCodeFor(err)
+	if code == "" {
+		code = CodeForStatus(status)
+	}
+	RespondErrorCode(c, status, code, err.Error(), nil)
+}
+
+// RespondErrorCode writes an error response with a specific code and optional details. The message
+// is translated into the request's language.
+func RespondErrorCode(c *gin.Context, status int, code ErrorCode, message string, details interface{}) {
+	message = i18n.T(RequestLanguage(c), message)
+	c.JSON(status, ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: c.GetString("request_id"),
+		Error:     message,
+	})
+}
+
+// RequestLanguage is the language chosen for the request by middleware.Language, or negotiated from
+// its Accept-Language header when that middleware did not run
+func RequestLanguage(c *gin.Context) i18n.Language {
+	if lang, ok := c.Value("language").(i18n.Language); ok {
+		return lang
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
+}
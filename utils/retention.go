@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// retentionBatchSize is how many leaves are deleted per statement
+const retentionBatchSize = 1000
+
+// heldEmployees selects the employees under an active legal hold
+const heldEmployees = "SELECT employee_id FROM legal_holds WHERE released_at IS NULL"
+
+// formerEmployeesLeftBefore selects the former employees (see IsFormerEmployee) who left before a
+// date: their termination date, else their employment end date, else when they were deleted. Former
+// employees with none of these dates are never selected.
+const formerEmployeesLeftBefore = `SELECT e.id FROM employees e LEFT JOIN employment_details d ON d.employee_id = e.id
+	WHERE (e.deleted_at IS NOT NULL OR e.status = 'inactive' OR d.employment_status IN ('terminated', 'resigned'))
+	AND COALESCE(d.termination_date, d.end_date, e.deleted_at) < ?`
+
+// RetentionResult reports what a retention policy purged, or would purge in a dry run
+type RetentionResult struct {
+	OrganizationID uint                     `json:"organization_id" example:"1"`
+	Category       models.RetentionCategory `json:"category" example:"audit_logs"`
+	RetentionDays  int                      `json:"retention_days" example:"2555"`
+	Cutoff         time.Time                `json:"cutoff"`                 // Records older than this are purged
+	Records        int64                    `json:"records" example:"1520"` // Records purged, or that would be purged
+	Files          int64                    `json:"files" example:"0"`      // Document and leave form files among them
+	Held           int64                    `json:"held" example:"12"`      // Records past the cutoff kept because of a legal hold
+	Error          string                   `json:"error,omitempty"`
+}
+
+// ApplyRetentionPolicies purges the records older than every enabled retention policy allows, except
+// those of employees under an active legal hold. db decides which organizations' policies apply, so a
+// request's database only sees its own organization's and a background one sees them all. Each policy
+// is applied in its own transaction, within its organization; with dryRun every transaction is rolled
+// back, so the results report what would be purged without deleting anything. Files are only deleted
+// once their records are. A policy that fails is reported with its error and the others still run.
+func ApplyRetentionPolicies(db *gorm.DB, dryRun bool) ([]RetentionResult, error) {
+	var policies []models.RetentionPolicy
+	if err := db.Where("enabled = ?", true).Order("organization_id, category").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	results := []RetentionResult{}
+	for _, policy := range policies {
+		result := RetentionResult{
+			OrganizationID: policy.OrganizationID,
+			Category:       policy.Category,
+			RetentionDays:  policy.RetentionDays,
+			Cutoff:         time.Now().AddDate(0, 0, -policy.RetentionDays),
+		}
+		files, err := applyRetentionPolicy(db.WithContext(database.WithOrganization(db.Statement.Context, policy.OrganizationID)),
+			&result, dryRun)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		for _, file := range files {
+			if err := DeleteFile(file); err != nil && !os.IsNotExist(err) && result.Error == "" {
+				result.Error = fmt.Sprintf("deleting %s: %v", file, err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// applyRetentionPolicy purges one category in a transaction and returns the files to delete now that
+// it has committed. Nothing is returned for a dry run or a failure, as their transaction is rolled back.
+func applyRetentionPolicy(db *gorm.DB, result *RetentionResult, dryRun bool) ([]string, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+
+	var files []string
+	var err error
+	switch result.Category {
+	case models.RetentionAuditLogs:
+		err = purgeAuditLogs(tx, result)
+	case models.RetentionLoginLogs:
+		err = purgeLoginLogs(tx, result)
+	case models.RetentionLeaveHistory:
+		files, err = purgeLeaveHistory(tx, result)
+	case models.RetentionExEmployeeDocuments:
+		files, err = purgeExEmployeeDocuments(tx, result)
+	default:
+		err = fmt.Errorf("unknown retention category %q", result.Category)
+	}
+	if err != nil || dryRun {
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// purgeAuditLogs deletes audit entries older than the cutoff, keeping those made by or about the
+// record of an employee under legal hold
+func purgeAuditLogs(tx *gorm.DB, result *RetentionResult) error {
+	held := "(performed_by IN (" + heldEmployees + ") OR (entity_type = ? AND entity_id IN (" + heldEmployees + ")))"
+	if err := tx.Model(&models.AuditLog{}).Where("created_at < ?", result.Cutoff).
+		Where(held, models.AuditEntityEmployee).Count(&result.Held).Error; err != nil {
+		return err
+	}
+	deleted := tx.Where("created_at < ?", result.Cutoff).Where("NOT "+held, models.AuditEntityEmployee).Delete(&models.AuditLog{})
+	result.Records = deleted.RowsAffected
+	return deleted.Error
+}
+
+// purgeLoginLogs deletes sign-in attempts older than the cutoff, keeping those of employees under legal hold
+func purgeLoginLogs(tx *gorm.DB, result *RetentionResult) error {
+	if err := tx.Model(&models.LoginLog{}).Where("created_at < ? AND employee_id IN ("+heldEmployees+")", result.Cutoff).
+		Count(&result.Held).Error; err != nil {
+		return err
+	}
+	deleted := tx.Where("created_at < ? AND (employee_id IS NULL OR employee_id NOT IN ("+heldEmployees+"))", result.Cutoff).
+		Delete(&models.LoginLog{})
+	result.Records = deleted.RowsAffected
+	return deleted.Error
+}
+
+// purgeLeaveHistory deletes leave requests, with their approval trail and forms, and recorded leave
+// taken that ended before the cutoff, keeping those of employees under legal hold
+func purgeLeaveHistory(tx *gorm.DB, result *RetentionResult) ([]string, error) {
+	var heldLeaves, heldTaken int64
+	if err := tx.Unscoped().Model(&models.Leave{}).Where("end_date < ? AND employee_id IN ("+heldEmployees+")", result.Cutoff).
+		Count(&heldLeaves).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&models.LeaveTaken{}).Where("end_date < ? AND employee_id IN ("+heldEmployees+")", result.Cutoff).
+		Count(&heldTaken).Error; err != nil {
+		return nil, err
+	}
+	result.Held = heldLeaves + heldTaken
+
+	expired := "end_date < ? AND employee_id NOT IN (" + heldEmployees + ")"
+	var leaveIDs []uint
+	if err := tx.Unscoped().Model(&models.Leave{}).Where(expired, result.Cutoff).Pluck("id", &leaveIDs).Error; err != nil {
+		return nil, err
+	}
+	var files []string
+	for start := 0; start < len(leaveIDs); start += retentionBatchSize {
+		batch := leaveIDs[start:min(start+retentionBatchSize, len(leaveIDs))]
+		var forms []string
+		if err := tx.Unscoped().Model(&models.Leave{}).Where("id IN ? AND form_file_path IS NOT NULL", batch).
+			Pluck("form_file_path", &forms).Error; err != nil {
+			return nil, err
+		}
+		files = append(files, forms...)
+		if err := tx.Where("leave_id IN ?", batch).Delete(&models.LeaveAudit{}).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.Unscoped().Where("id IN ?", batch).Delete(&models.Leave{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	deleted := tx.Unscoped().Where(expired, result.Cutoff).Delete(&models.LeaveTaken{})
+	if deleted.Error != nil {
+		return nil, deleted.Error
+	}
+	result.Records = int64(len(leaveIDs)) + deleted.RowsAffected
+	result.Files = int64(len(files))
+	return files, nil
+}
+
+// purgeExEmployeeDocuments deletes the documents of former employees who left before the cutoff,
+// keeping those of employees under legal hold
+func purgeExEmployeeDocuments(tx *gorm.DB, result *RetentionResult) ([]string, error) {
+	expired := "employee_id IN (" + formerEmployeesLeftBefore + ")"
+	if err := tx.Unscoped().Model(&models.Document{}).Where(expired, result.Cutoff).
+		Where("employee_id IN (" + heldEmployees + ")").Count(&result.Held).Error; err != nil {
+		return nil, err
+	}
+	var files []string
+	if err := tx.Unscoped().Model(&models.Document{}).Where(expired, result.Cutoff).
+		Where("employee_id NOT IN ("+heldEmployees+")").Pluck("file_path", &files).Error; err != nil {
+		return nil, err
+	}
+	deleted := tx.Unscoped().Where(expired, result.Cutoff).Where("employee_id NOT IN (" + heldEmployees + ")").
+		Delete(&models.Document{})
+	if deleted.Error != nil {
+		return nil, deleted.Error
+	}
+	result.Records = deleted.RowsAffected
+	result.Files = int64(len(files))
+	return files, nil
+}
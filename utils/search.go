@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"hrms-api/database"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// SearchQuery turns a search term into a Postgres text search query matching every word of it as the
+// start of a word, such as "jo:* & ban:*" for "Jo Ban". Words are split at anything other than a letter
+// or digit, the same way search_vector columns are built, so the result is safe to pass to to_tsquery.
+func SearchQuery(term string) string {
+	words := searchWords(term)
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// searchWords splits a search term into lower case words at anything other than a letter or digit
+func searchWords(term string) []string {
+	return strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// WhereSearch narrows query to the rows whose search_vector column matches term. A term without
+// letters or digits matches nothing. MySQL and SQLite have no search_vector columns, so there every
+// word of term must be found in one of columns, those the search_vector is built from (see WhereWords).
+func WhereSearch(query *gorm.DB, term string, columns ...string) *gorm.DB {
+	if !database.IsPostgres() {
+		return WhereWords(query, term, columns...)
+	}
+	return query.Where("search_vector @@ to_tsquery('simple', ?)", SearchQuery(term))
+}
+
+// WhereWords narrows query to the rows where every word of term is found, in any case, in one of
+// columns, anywhere in it rather than at the start of a word as full-text search does. A term without
+// letters or digits matches nothing.
+func WhereWords(query *gorm.DB, term string, columns ...string) *gorm.DB {
+	words := searchWords(term)
+	if len(words) == 0 {
+		return query.Where("1 = 0")
+	}
+	for _, word := range words {
+		conditions := make([]string, len(columns))
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			conditions[i] = "LOWER(" + column + ") LIKE ?"
+			args[i] = "%" + word + "%"
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	return query
+}
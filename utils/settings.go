@@ -0,0 +1,442 @@
+package utils
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Keys of the settings that can be changed at runtime
+const (
+	SettingAnnualLeaveDaysPerMonth = "annual_leave_days_per_month"
+	SettingEmailNotifications      = "email_notifications_enabled"
+	SettingMutedEmailCategories    = "muted_email_categories"
+	SettingCORSAllowedOrigins      = "cors_allowed_origins"
+	SettingEmployeeDocumentQuota   = "employee_document_quota_mb"
+	SettingDocumentStorageQuota    = "document_storage_quota_mb"
+	SettingLeaveYearStartMonth     = "leave_year_start_month"
+	SettingLeaveRoundingIncrement  = "leave_rounding_increment"
+	SettingLeaveRoundingMode       = "leave_rounding_mode"
+	SettingExportPaperSize         = "export_paper_size"
+	SettingCompanyName             = "company_name"
+	SettingCompanyAddress          = "company_address"
+	SettingCompanyFooter           = "company_footer"
+	SettingCompanyLogo             = "company_logo"
+	SettingAbsenceRateTarget       = "absence_rate_target"
+	SettingUnplannedAbsenceTarget  = "unplanned_absence_rate_target"
+)
+
+// RuntimeSettings are the settings in effect, the stored values over the defaults
+type RuntimeSettings struct {
+	AnnualLeaveDaysPerMonth float64
+	EmailNotifications      bool
+	MutedEmailCategories    []models.NotificationCategory
+	CORSAllowedOrigins      []string
+	EmployeeDocumentQuotaMB float64 // 0 for no limit
+	DocumentStorageQuotaMB  float64 // 0 for no limit
+	LeaveYearStartMonth     time.Month
+	LeaveRoundingIncrement  float64 // 0 for two decimal places
+	LeaveRoundingMode       string
+	ExportPaperSize         string // A4 or Letter
+	CompanyName             string
+	CompanyAddress          string // Lines separated by newlines
+	CompanyFooter           string
+	CompanyLogo             []byte  // Empty for the default logo
+	CompanyLogoType         string  // PNG or JPG, of CompanyLogo
+	AbsenceRateTarget       float64 // Percent, 0 for no target
+	UnplannedAbsenceTarget  float64 // Percent, 0 for no target
+}
+
+// SettingDefinition describes a runtime setting
+type SettingDefinition struct {
+	Key         string      `json:"key" example:"annual_leave_days_per_month"`
+	Type        string      `json:"type" example:"number"` // number, boolean, text or list
+	Description string      `json:"description"`
+	Default     interface{} `json:"default"`
+
+	// apply validates a JSON value and sets it on the settings
+	apply func(settings *RuntimeSettings, value json.RawMessage) error
+}
+
+// SettingDefinitions lists every runtime setting
+var SettingDefinitions = []SettingDefinition{
+	{
+		Key:         SettingAnnualLeaveDaysPerMonth,
+		Type:        "number",
+		Description: "Days of annual leave accrued per month of service",
+		Default:     2.0,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var days float64
+			if err := json.Unmarshal(value, &days); err != nil || days < 0 || days > 31 {
+				return fmt.Errorf("must be a number of days between 0 and 31")
+			}
+			settings.AnnualLeaveDaysPerMonth = days
+			return nil
+		},
+	},
+	{
+		Key:         SettingEmailNotifications,
+		Type:        "boolean",
+		Description: "Send email copies of notifications when SMTP is configured",
+		Default:     true,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			if err := json.Unmarshal(value, &settings.EmailNotifications); err != nil {
+				return fmt.Errorf("must be true or false")
+			}
+			return nil
+		},
+	},
+	{
+		Key:         SettingMutedEmailCategories,
+		Type:        "list",
+		Description: "Notification categories only sent in-app, never by email",
+		Default:     []string{},
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var categories []models.NotificationCategory
+			if err := json.Unmarshal(value, &categories); err != nil {
+				return fmt.Errorf("must be a list of notification categories")
+			}
+			for _, category := range categories {
+				known := false
+				for _, candidate := range models.NotificationCategories {
+					known = known || candidate == category
+				}
+				if !known {
+					return fmt.Errorf("unknown notification category %q", category)
+				}
+			}
+			settings.MutedEmailCategories = categories
+			return nil
+		},
+	},
+	{
+		Key:         SettingCORSAllowedOrigins,
+		Type:        "list",
+		Description: "Browser origins allowed to call the API in addition to CORS_ALLOWED_ORIGINS, e.g. https://hr.example.com or https://*.example.com",
+		Default:     []string{},
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var origins []string
+			if err := json.Unmarshal(value, &origins); err != nil {
+				return fmt.Errorf("must be a list of origins")
+			}
+			for i, origin := range origins {
+				origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+				if err := config.ValidateOriginPattern(origin); err != nil {
+					return err
+				}
+				origins[i] = origin
+			}
+			settings.CORSAllowedOrigins = origins
+			return nil
+		},
+	},
+	{
+		Key:         SettingEmployeeDocumentQuota,
+		Type:        "number",
+		Description: "Megabytes of documents that may be stored for one employee, 0 for no limit",
+		Default:     0.0,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var megabytes float64
+			if err := json.Unmarshal(value, &megabytes); err != nil || megabytes < 0 {
+				return fmt.Errorf("must be a number of megabytes, 0 for no limit")
+			}
+			settings.EmployeeDocumentQuotaMB = megabytes
+			return nil
+		},
+	},
+	{
+		Key:         SettingDocumentStorageQuota,
+		Type:        "number",
+		Description: "Megabytes of documents that may be stored for all the employees of an organization, 0 for no limit",
+		Default:     0.0,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var megabytes float64
+			if err := json.Unmarshal(value, &megabytes); err != nil || megabytes < 0 {
+				return fmt.Errorf("must be a number of megabytes, 0 for no limit")
+			}
+			settings.DocumentStorageQuotaMB = megabytes
+			return nil
+		},
+	},
+	{
+		Key:         SettingLeaveYearStartMonth,
+		Type:        "number",
+		Description: "Month the leave year starts in, 1 for January to 12 for December, used for carry-over and year-to-date totals",
+		Default:     1,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var month int
+			if err := json.Unmarshal(value, &month); err != nil || month < 1 || month > 12 {
+				return fmt.Errorf("must be a month number between 1 and 12")
+			}
+			settings.LeaveYearStartMonth = time.Month(month)
+			return nil
+		},
+	},
+	{
+		Key:         SettingLeaveRoundingIncrement,
+		Type:        "number",
+		Description: "Days accruals and balances are rounded to: 0.25, 0.5 or 1, or 0 for two decimal places",
+		Default:     0.0,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var increment float64
+			if err := json.Unmarshal(value, &increment); err != nil ||
+				(increment != 0 && increment != 0.25 && increment != 0.5 && increment != 1) {
+				return fmt.Errorf("must be 0.25, 0.5 or 1 day, or 0 for two decimal places")
+			}
+			settings.LeaveRoundingIncrement = increment
+			return nil
+		},
+	},
+	{
+		Key:         SettingLeaveRoundingMode,
+		Type:        "text",
+		Description: "How accruals and balances are rounded to leave_rounding_increment: nearest, up or down",
+		Default:     LeaveRoundNearest,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var mode string
+			if err := json.Unmarshal(value, &mode); err != nil ||
+				(mode != LeaveRoundNearest && mode != LeaveRoundUp && mode != LeaveRoundDown) {
+				return fmt.Errorf("must be nearest, up or down")
+			}
+			settings.LeaveRoundingMode = mode
+			return nil
+		},
+	},
+	{
+		Key:         SettingExportPaperSize,
+		Type:        "text",
+		Description: "Paper size of PDF exports that do not ask for one: A4 or Letter",
+		Default:     PaperA4,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var size string
+			if err := json.Unmarshal(value, &size); err != nil || !ValidPaperSize(size) {
+				return fmt.Errorf("must be A4 or Letter")
+			}
+			settings.ExportPaperSize = size
+			return nil
+		},
+	},
+	{
+		Key:         SettingCompanyName,
+		Type:        "text",
+		Description: "Company name on the letterhead of PDFs and at the top of Excel exports",
+		Default:     DefaultCompanyName,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var name string
+			if err := json.Unmarshal(value, &name); err != nil || strings.TrimSpace(name) == "" || len(name) > 100 {
+				return fmt.Errorf("must be a name of 1 to 100 characters")
+			}
+			settings.CompanyName = strings.TrimSpace(name)
+			return nil
+		},
+	},
+	{
+		Key:         SettingCompanyAddress,
+		Type:        "text",
+		Description: "Company address under the name on the letterhead of PDFs, one line per line of the address",
+		Default:     "",
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var address string
+			if err := json.Unmarshal(value, &address); err != nil || len(address) > 300 {
+				return fmt.Errorf("must be an address of at most 300 characters")
+			}
+			settings.CompanyAddress = strings.TrimSpace(address)
+			return nil
+		},
+	},
+	{
+		Key:         SettingCompanyFooter,
+		Type:        "text",
+		Description: "Text at the foot of every page of PDFs, such as the registered office or a confidentiality notice",
+		Default:     "",
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var footer string
+			if err := json.Unmarshal(value, &footer); err != nil || len(footer) > 200 {
+				return fmt.Errorf("must be a text of at most 200 characters")
+			}
+			settings.CompanyFooter = strings.TrimSpace(footer)
+			return nil
+		},
+	},
+	{
+		Key:         SettingCompanyLogo,
+		Type:        "text",
+		Description: "Company logo on the letterhead of PDFs, a PNG or JPEG image of up to 512 KB as a data URL such as data:image/png;base64,..., or empty for the default logo",
+		Default:     "",
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var logo string
+			if err := json.Unmarshal(value, &logo); err != nil {
+				return fmt.Errorf("must be a PNG or JPEG image as a data URL, or empty for the default logo")
+			}
+			image, imageType, err := decodeCompanyLogo(logo)
+			if err != nil {
+				return err
+			}
+			settings.CompanyLogo, settings.CompanyLogoType = image, imageType
+			return nil
+		},
+	},
+	{
+		Key:         SettingAbsenceRateTarget,
+		Type:        "number",
+		Description: "Percentage of working days lost to absence of any kind above which the absence rate report flags a department, 0 for no target",
+		Default:     0.0,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var percent float64
+			if err := json.Unmarshal(value, &percent); err != nil || percent < 0 || percent > 100 {
+				return fmt.Errorf("must be a percentage between 0 and 100, 0 for no target")
+			}
+			settings.AbsenceRateTarget = percent
+			return nil
+		},
+	},
+	{
+		Key:         SettingUnplannedAbsenceTarget,
+		Type:        "number",
+		Description: "Percentage of working days lost to unplanned absence, such as sick leave, above which the absence rate report flags a department, 0 for no target",
+		Default:     3.0,
+		apply: func(settings *RuntimeSettings, value json.RawMessage) error {
+			var percent float64
+			if err := json.Unmarshal(value, &percent); err != nil || percent < 0 || percent > 100 {
+				return fmt.Errorf("must be a percentage between 0 and 100, 0 for no target")
+			}
+			settings.UnplannedAbsenceTarget = percent
+			return nil
+		},
+	},
+}
+
+var (
+	currentSettings atomic.Pointer[RuntimeSettings]
+
+	// settingsVersion identifies the stored settings last loaded, to tell when they change
+	settingsMu      sync.Mutex
+	settingsVersion string
+)
+
+// FindSettingDefinition returns the definition of a runtime setting
+func FindSettingDefinition(key string) (*SettingDefinition, bool) {
+	for i := range SettingDefinitions {
+		if SettingDefinitions[i].Key == key {
+			return &SettingDefinitions[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateSetting checks a JSON value for a runtime setting
+func ValidateSetting(definition *SettingDefinition, value json.RawMessage) error {
+	if strings.TrimSpace(string(value)) == "null" {
+		return fmt.Errorf("must not be null")
+	}
+	return definition.apply(defaultSettings(), value)
+}
+
+// CurrentSettings returns the runtime settings in effect. The result must not be modified.
+func CurrentSettings() *RuntimeSettings {
+	if settings := currentSettings.Load(); settings != nil {
+		return settings
+	}
+	return defaultSettings()
+}
+
+// defaultSettings returns the settings with every default value
+func defaultSettings() *RuntimeSettings {
+	settings := &RuntimeSettings{}
+	for _, definition := range SettingDefinitions {
+		value, _ := json.Marshal(definition.Default)
+		if err := definition.apply(settings, value); err != nil {
+			panic(fmt.Sprintf("default of setting %s: %v", definition.Key, err))
+		}
+	}
+	return settings
+}
+
+// LoadSettings loads the stored runtime settings over the defaults. Stored values that are no longer
+// valid are logged and left at their default.
+func LoadSettings() error {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	version, err := storedSettingsVersion()
+	if err != nil {
+		return err
+	}
+	return loadSettings(version)
+}
+
+// ReloadSettingsIfChanged reloads the runtime settings when they have been changed, by this server or
+// another one sharing the database, since they were last loaded
+func ReloadSettingsIfChanged() (bool, error) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	version, err := storedSettingsVersion()
+	if err != nil || version == settingsVersion {
+		return false, err
+	}
+	return true, loadSettings(version)
+}
+
+func loadSettings(version string) error {
+	var stored []models.Setting
+	if err := database.DB.Find(&stored).Error; err != nil {
+		return err
+	}
+
+	settings := defaultSettings()
+	for _, setting := range stored {
+		definition, ok := FindSettingDefinition(setting.Key)
+		if !ok {
+			continue
+		}
+		if err := definition.apply(settings, json.RawMessage(setting.Value)); err != nil {
+			log.Printf("Ignoring stored setting %s: %v", setting.Key, err)
+		}
+	}
+
+	currentSettings.Store(settings)
+	settingsVersion = version
+	return nil
+}
+
+// storedSettingsVersion summarizes the settings table so that any change to it, including a reset
+// that deletes a row, gives a different result
+func storedSettingsVersion() (string, error) {
+	// SQLite returns the latest time as text, so it is only compared, never parsed
+	var summary struct {
+		Count  int64
+		Latest sql.NullString
+	}
+	err := database.DB.Model(&models.Setting{}).Select("COUNT(*) AS count, MAX(updated_at) AS latest").Scan(&summary).Error
+	if err != nil {
+		return "", err
+	}
+	if !summary.Latest.Valid {
+		return fmt.Sprintf("%d", summary.Count), nil
+	}
+	return fmt.Sprintf("%d/%s", summary.Count, summary.Latest.String), nil
+}
+
+// EmailCategoryMuted reports whether notifications of the category are only sent in-app
+func EmailCategoryMuted(category models.NotificationCategory) bool {
+	for _, muted := range CurrentSettings().MutedEmailCategories {
+		if muted == category {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSOriginAllowed reports whether a browser origin has been allowed through the cors_allowed_origins setting
+func CORSOriginAllowed(origin string) bool {
+	return config.OriginAllowed(CurrentSettings().CORSAllowedOrigins, origin)
+}
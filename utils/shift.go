@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"hrms-api/database"
+	"hrms-api/models"
+	"strconv"
+	"time"
+)
+
+// ShiftAssignmentOn returns the employee's rostered shift for the given day, if any
+func ShiftAssignmentOn(employeeID uint, day time.Time) *models.ShiftAssignment {
+	var assignment models.ShiftAssignment
+	if err := database.DB.Preload("Shift").Where("employee_id = ? AND date = ?", employeeID, day).First(&assignment).Error; err != nil {
+		return nil
+	}
+	return &assignment
+}
+
+// LeaveConflictOn returns a pending or approved leave of the employee that covers the given day, if any
+func LeaveConflictOn(employeeID uint, day time.Time) *models.Leave {
+	var leave models.Leave
+	err := database.DB.Preload("LeaveType").
+		Where("employee_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?", employeeID,
+			[]models.LeaveStatus{models.StatusPending, models.StatusApproved}, day, day).
+		First(&leave).Error
+	if err != nil {
+		return nil
+	}
+	return &leave
+}
+
+// shiftWorkSchedule expresses a rostered shift as a one-day work schedule for attendance checks
+func shiftWorkSchedule(shift models.Shift, day time.Time) models.WorkSchedule {
+	return models.WorkSchedule{
+		Name:         shift.Name,
+		StartTime:    shift.StartTime,
+		EndTime:      shift.EndTime,
+		GraceMinutes: shift.GraceMinutes,
+		WorkDays:     strconv.Itoa(int(day.Weekday())),
+	}
+}
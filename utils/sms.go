@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"hrms-api/config"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	smsTimeout = 15 * time.Second
+	// smsMaxLength keeps a text message to three concatenated parts of plain GSM text
+	smsMaxLength = 459
+)
+
+var smsClient = &http.Client{Timeout: smsTimeout}
+
+// smsProvider sends text messages through an SMS gateway. to is an E.164 number, such as +260971234567.
+type smsProvider interface {
+	send(to, body string) error
+}
+
+// currentSMSProvider returns the gateway chosen with SMS_PROVIDER, or nil when SMS is off
+func currentSMSProvider() smsProvider {
+	if config.AppConfig == nil {
+		return nil
+	}
+	switch config.AppConfig.SMSProvider {
+	case "twilio":
+		return twilioProvider{}
+	case "africastalking":
+		return africasTalkingProvider{}
+	}
+	return nil
+}
+
+// SMSEnabled reports whether an SMS gateway is configured for text message notifications
+func SMSEnabled() bool {
+	return currentSMSProvider() != nil
+}
+
+// SendSMS sends a text message to a mobile number through the configured gateway. Local numbers starting
+// with 0 are sent with SMS_DEFAULT_COUNTRY_CODE. Bodies longer than three message parts are cut short.
+func SendSMS(to, body string) error {
+	provider := currentSMSProvider()
+	if provider == nil {
+		return ErrSMSNotConfigured
+	}
+	number, ok := NormalizeMobileNumber(to)
+	if !ok {
+		return permanentError{fmt.Errorf("%w: %q", ErrInvalidMobileNumber, to)}
+	}
+	if runes := []rune(body); len(runes) > smsMaxLength {
+		body = string(runes[:smsMaxLength-1]) + "…"
+	}
+	return provider.send(number, body)
+}
+
+// NormalizeMobileNumber returns a phone number in E.164 form, dropping spaces, dashes, dots and brackets.
+// A number starting with 00 is taken as international, and one starting with a single 0 as local to
+// SMS_DEFAULT_COUNTRY_CODE. It reports false for anything else that is not a + and 8 to 15 digits.
+func NormalizeMobileNumber(number string) (string, bool) {
+	number = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, number)
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = "+" + number[2:]
+	case strings.HasPrefix(number, "0") && config.AppConfig != nil && config.AppConfig.SMSCountryCode != "":
+		number = "+" + config.AppConfig.SMSCountryCode + number[1:]
+	default:
+		return "", false
+	}
+
+	digits := number[1:]
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' || strings.Trim(digits, "0123456789") != "" {
+		return "", false
+	}
+	return number, true
+}
+
+// smsAPIURL returns SMS_API_URL, or the provider's live API when it is not set
+func smsAPIURL(live string) string {
+	if config.AppConfig.SMSAPIURL != "" {
+		return config.AppConfig.SMSAPIURL
+	}
+	return live
+}
+
+// twilioProvider sends through the Twilio Messages API, authenticating with the account SID and auth token
+type twilioProvider struct{}
+
+func (twilioProvider) send(to, body string) error {
+	cfg := config.AppConfig
+	endpoint := smsAPIURL("https://api.twilio.com") + "/2010-04-01/Accounts/" + url.PathEscape(cfg.SMSAccount) + "/Messages.json"
+	form := url.Values{"To": {to}, "From": {cfg.SMSFrom}, "Body": {body}}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.SetBasicAuth(cfg.SMSAccount, cfg.SMSAPIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := smsClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	var failure struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&failure)
+	err = fmt.Errorf("twilio returned %d: %s (code %d)", resp.StatusCode, failure.Message, failure.Code)
+	// As with webhooks, a client error other than a timeout or rate limit will fail the same way again,
+	// e.g. code 21211 for a number that is not a mobile number
+	if webhookRejected(resp.StatusCode) {
+		return permanentError{err}
+	}
+	return err
+}
+
+// africasTalkingProvider sends through the Africa's Talking bulk SMS API, authenticating with the app's
+// username and API key
+type africasTalkingProvider struct{}
+
+// Status codes Africa's Talking reports for a recipient
+const (
+	atStatusProcessed          = 100
+	atStatusSent               = 101
+	atStatusQueued             = 102
+	atStatusInvalidPhoneNumber = 403
+	atStatusUnsupportedNumber  = 404
+	atStatusUserInBlacklist    = 406
+)
+
+func (africasTalkingProvider) send(to, body string) error {
+	cfg := config.AppConfig
+	form := url.Values{"username": {cfg.SMSAccount}, "to": {to}, "message": {body}}
+	if cfg.SMSFrom != "" {
+		form.Set("from", cfg.SMSFrom)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, smsAPIURL("https://api.africastalking.com")+"/version1/messaging", strings.NewReader(form.Encode()))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("apiKey", cfg.SMSAPIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := smsClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("africa's talking returned %d: %s", resp.StatusCode, strings.TrimSpace(string(content)))
+		if webhookRejected(resp.StatusCode) {
+			return permanentError{err}
+		}
+		return err
+	}
+
+	var result struct {
+		SMSMessageData struct {
+			Message    string `json:"Message"`
+			Recipients []struct {
+				StatusCode int    `json:"statusCode"`
+				Status     string `json:"status"`
+			} `json:"Recipients"`
+		} `json:"SMSMessageData"`
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return fmt.Errorf("africa's talking returned an unreadable response: %w", err)
+	}
+	if len(result.SMSMessageData.Recipients) == 0 {
+		return fmt.Errorf("africa's talking did not accept the message: %s", result.SMSMessageData.Message)
+	}
+
+	recipient := result.SMSMessageData.Recipients[0]
+	switch recipient.StatusCode {
+	case atStatusProcessed, atStatusSent, atStatusQueued:
+		return nil
+	case atStatusInvalidPhoneNumber, atStatusUnsupportedNumber, atStatusUserInBlacklist:
+		return permanentError{fmt.Errorf("africa's talking rejected the number: %s (status %d)", recipient.Status, recipient.StatusCode)}
+	}
+	// Insufficient balance, risk holds and gateway errors may clear
+	return fmt.Errorf("africa's talking could not send the message: %s (status %d)", recipient.Status, recipient.StatusCode)
+}
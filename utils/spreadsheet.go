@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// XLSXContentType is the content type of Excel workbooks
+const XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// templateRows is how many rows of an upload template carry the text format and dropdowns
+const templateRows = 1000
+
+// IsXLSXUpload reports whether an uploaded file is an Excel workbook, judged by its name or content type
+func IsXLSXUpload(filename, contentType string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".xlsx") || strings.HasPrefix(contentType, XLSXContentType)
+}
+
+// ReadXLSXRows reads the rows of the first sheet of a workbook as text, as they are displayed in Excel.
+// Excel leaves out empty trailing cells, so every row is padded to the width of the header row.
+func ReadXLSXRows(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(f.GetSheetName(0))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("workbook is empty")
+	}
+	for i, row := range rows {
+		for len(row) < len(rows[0]) {
+			row = append(row, "")
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// TemplateDropdown restricts a template column to a list of values
+type TemplateDropdown struct {
+	Column string // Header of the column
+	Values []string
+}
+
+// UploadTemplateToExcel creates an upload template workbook with the given header and example rows.
+// The text columns are formatted as text, so that Excel keeps values such as NRCs as they are typed,
+// and each dropdown column only accepts its values. The dropdown values are kept on a hidden sheet,
+// which lifts Excel's limit on the length of an inline list.
+func UploadTemplateToExcel(sheetName string, header []string, examples [][]string, textColumns []string, dropdowns []TemplateDropdown) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.NewSheet(sheetName)
+	f.DeleteSheet("Sheet1")
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D9E1F2"}, Pattern: 1},
+	})
+	textStyle, _ := f.NewStyle(&excelize.Style{NumFmt: 49}) // "@"
+
+	columns := make(map[string]string, len(header))
+	for i, name := range header {
+		column, _ := excelize.ColumnNumberToName(i + 1)
+		columns[name] = column
+		f.SetColWidth(sheetName, column, column, 20)
+	}
+	for _, name := range textColumns {
+		if column, ok := columns[name]; ok {
+			f.SetColStyle(sheetName, column, textStyle)
+		}
+	}
+
+	for i, name := range header {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellStr(sheetName, cell, name)
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+	}
+	for r, example := range examples {
+		for i, value := range example {
+			cell, _ := excelize.CoordinatesToCellName(i+1, r+2)
+			f.SetCellStr(sheetName, cell, value)
+		}
+	}
+
+	listSheet := "Lists"
+	listColumn := 0
+	for _, dropdown := range dropdowns {
+		column, ok := columns[dropdown.Column]
+		if !ok || len(dropdown.Values) == 0 {
+			continue
+		}
+		if listColumn == 0 {
+			f.NewSheet(listSheet)
+			f.SetSheetVisible(listSheet, false)
+		}
+		listColumn++
+		listName, _ := excelize.ColumnNumberToName(listColumn)
+		for i, value := range dropdown.Values {
+			f.SetCellStr(listSheet, fmt.Sprintf("%s%d", listName, i+1), value)
+		}
+
+		dv := excelize.NewDataValidation(true)
+		dv.SetSqref(fmt.Sprintf("%s2:%s%d", column, column, templateRows))
+		dv.SetSqrefDropList(fmt.Sprintf("%s!$%s$1:$%s$%d", listSheet, listName, listName, len(dropdown.Values)))
+		dv.SetError(excelize.DataValidationErrorStyleStop, "Invalid "+dropdown.Column, "Choose a "+dropdown.Column+" from the list")
+		if err := f.AddDataValidation(sheetName, dv); err != nil {
+			return nil, err
+		}
+	}
+
+	f.SetActiveSheet(0)
+	buffer, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// ExportTableToExcel writes a workbook to w with a single sheet holding the header and rows as text,
+// with the header row frozen and filterable
+func ExportTableToExcel(w io.Writer, sheetName string, header []string, rows [][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.NewSheet(sheetName)
+	f.DeleteSheet("Sheet1")
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D9E1F2"}, Pattern: 1},
+	})
+	for i, name := range header {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellStr(sheetName, cell, name)
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+		column, _ := excelize.ColumnNumberToName(i + 1)
+		f.SetColWidth(sheetName, column, column, 18)
+	}
+	for r, row := range rows {
+		for i, value := range row {
+			cell, _ := excelize.CoordinatesToCellName(i+1, r+2)
+			f.SetCellStr(sheetName, cell, value)
+		}
+	}
+
+	if len(header) > 0 {
+		lastCell, _ := excelize.CoordinatesToCellName(len(header), len(rows)+1)
+		f.AutoFilter(sheetName, "A1:"+lastCell, nil)
+		f.SetPanes(sheetName, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	}
+
+	return f.Write(w)
+}
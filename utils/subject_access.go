@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"gorm.io/gorm"
+)
+
+// SubjectAccessSection holds the rows of one table that are about an employee
+type SubjectAccessSection struct {
+	Table   string                   `json:"table"`
+	Records []map[string]interface{} `json:"records"`
+}
+
+// SubjectAccessReport is everything held about one employee, assembled for a subject access request
+type SubjectAccessReport struct {
+	EmployeeID  uint                   `json:"employee_id"`
+	Name        string                 `json:"name"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	GeneratedBy uint                   `json:"generated_by"`
+	Sections    []SubjectAccessSection `json:"sections"`
+}
+
+// subjectColumns are the columns through which a row is about the employee it references
+var subjectColumns = []string{"employee_id", "recipient_id", "sender_id", "requester_id"}
+
+// subjectParents are the columns through which a row belongs to a record of the employee, such as the
+// audit entries of their leaves or the updates on their grievances
+var subjectParents = map[string]string{
+	"leave_id":               "leaves",
+	"onboarding_process_id":  "onboarding_processes",
+	"offboarding_process_id": "offboarding_processes",
+	"grievance_id":           "grievances",
+	"exit_interview_id":      "exit_interviews",
+}
+
+// subjectHiddenColumns are never exported, per table
+var subjectHiddenColumns = map[string][]string{
+	"employees":            {"password_hash"},
+	"calendar_connections": {"access_token", "refresh_token"},
+	"device_tokens":        {"token"},
+}
+
+// CollectSubjectAccessData reads every row of every application table that is about the employee: their
+// employee record, rows that reference them or one of their records, and the audit entries about their
+// record or made by them. Tables are discovered from the schema, so new tables are included as they are
+// added. Bank account numbers are masked unless maskAccounts is false.
+func CollectSubjectAccessData(db *gorm.DB, employeeID uint, maskAccounts bool) ([]SubjectAccessSection, error) {
+	tables, err := database.Tables()
+	if err != nil {
+		return nil, err
+	}
+	tableColumns, err := database.TableColumns(db)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []SubjectAccessSection{}
+	for _, table := range tables {
+		var conditions []string
+		var vars []interface{}
+		switch table {
+		case "employees":
+			conditions, vars = []string{"t.id = ?"}, []interface{}{employeeID}
+		case "audit_logs":
+			conditions = []string{"(t.entity_type = ? AND t.entity_id = ?)", "t.performed_by = ?"}
+			vars = []interface{}{models.AuditEntityEmployee, employeeID, employeeID}
+		default:
+			for _, column := range subjectColumns {
+				if tableColumns[table][column] {
+					conditions = append(conditions, "t."+column+" = ?")
+					vars = append(vars, employeeID)
+				}
+			}
+			for column, parent := range subjectParents {
+				if tableColumns[table][column] && parent != table {
+					conditions = append(conditions, "t."+column+" IN (SELECT id FROM "+parent+" WHERE employee_id = ?)")
+					vars = append(vars, employeeID)
+				}
+			}
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+
+		rows, err := db.Raw("SELECT "+database.RowJSONSQL(database.SortedColumns(tableColumns[table]))+" FROM "+db.Statement.Quote(table)+" AS t WHERE "+
+			strings.Join(conditions, " OR ")+" ORDER BY t.id", vars...).Rows()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", table, err)
+		}
+		section := SubjectAccessSection{Table: table}
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			decoder := json.NewDecoder(strings.NewReader(line))
+			decoder.UseNumber()
+			var record map[string]interface{}
+			if err := decoder.Decode(&record); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			for _, column := range subjectHiddenColumns[table] {
+				delete(record, column)
+			}
+			if maskAccounts {
+				maskSubjectAccount(table, record)
+			}
+			section.Records = append(section.Records, record)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(section.Records) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections, nil
+}
+
+// maskSubjectAccount masks the bank account numbers the API masks for users without payroll access
+func maskSubjectAccount(table string, record map[string]interface{}) {
+	column := map[string]string{"bank_details": "account_number", "employees": "bank_account_number"}[table]
+	if value, ok := record[column].(string); ok {
+		record[column] = MaskAccountNumber(value)
+	}
+}
+
+// ExportSubjectAccessToPDF renders a subject access report as a readable PDF, one section per table
+// and one block of fields per row
+func ExportSubjectAccessToPDF(report SubjectAccessReport) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(tr("Subject Access Report - "+report.Name), false)
+	pdf.SetAuthor(CompanyName(), true)
+	pdf.SetCreator("HRMS API", false)
+	pdf.SetAutoPageBreak(true, 15)
+
+	pdf.AddPage()
+	if err := addPDFHeader(pdf); err != nil {
+		pdf.SetFont("Arial", "B", 18)
+		pdf.Cell(0, 10, tr(CompanyName()))
+		pdf.Ln(8)
+	}
+
+	pdf.SetFont("Arial", "B", 18)
+	pdf.Cell(0, 10, "Subject Access Report")
+	pdf.Ln(12)
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(0, 6, tr(fmt.Sprintf("Employee: %s (ID %d)", report.Name, report.EmployeeID)))
+	pdf.Ln(5)
+	pdf.Cell(0, 6, fmt.Sprintf("Generated: %s", report.GeneratedAt.Format("2006-01-02 15:04:05 MST")))
+	pdf.Ln(5)
+	pdf.MultiCell(0, 5, "This report lists the personal data held about the employee, grouped by the table it is stored in. "+
+		"The accompanying JSON file holds the same data in machine-readable form.", "", "", false)
+
+	for _, section := range report.Sections {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, fmt.Sprintf("%s (%d)", humanizeColumn(section.Table), len(section.Records)))
+		pdf.Ln(8)
+
+		for i, record := range section.Records {
+			if i > 0 {
+				pdf.Ln(2)
+			}
+			keys := make([]string, 0, len(record))
+			for key := range record {
+				if record[key] != nil {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				pdf.SetFont("Arial", "B", 9)
+				pdf.Cell(55, 5, humanizeColumn(key))
+				pdf.SetFont("Arial", "", 9)
+				pdf.MultiCell(0, 5, tr(subjectValue(record[key])), "", "", false)
+			}
+			pdf.SetDrawColor(200, 200, 200)
+			pdf.Line(10, pdf.GetY()+1, 200, pdf.GetY()+1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// subjectValue formats a JSON value for the PDF report
+func subjectValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// humanizeColumn turns a table or column name such as "leave_accruals" into "Leave accruals"
+func humanizeColumn(name string) string {
+	name = strings.ReplaceAll(name, "_", " ")
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
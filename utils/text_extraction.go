@@ -0,0 +1,296 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// textExtractionTimeout bounds the time spent reading one document, OCR of long scans included
+	textExtractionTimeout = 5 * time.Minute
+	// textExtractionStaleAfter is how long a document may be extracting before it is taken to have been
+	// interrupted, such as by a restart, and is queued again
+	textExtractionStaleAfter = time.Hour
+	// maxContentTextBytes caps the text kept for a document, well under the size Postgres allows a tsvector
+	maxContentTextBytes = 512 * 1024
+)
+
+// errUnsupportedContent is returned by extractors for files of a type they cannot read
+var errUnsupportedContent = errors.New("file type not supported by the text extractor")
+
+var tikaClient = &http.Client{} // Bounded by textExtractionTimeout through the request context
+
+// textExtractor reads the text of a stored document file
+type textExtractor interface {
+	extract(ctx context.Context, path, mimeType string) (string, error)
+}
+
+// currentTextExtractor returns the extractor chosen with TEXT_EXTRACTOR, or nil when extraction is off
+func currentTextExtractor() textExtractor {
+	if config.AppConfig == nil {
+		return nil
+	}
+	switch config.AppConfig.TextExtractor {
+	case "tesseract":
+		return tesseractExtractor{}
+	case "tika":
+		return tikaExtractor{}
+	}
+	return nil
+}
+
+// TextExtractionEnabled reports whether document text is extracted for content search
+func TextExtractionEnabled() bool {
+	return currentTextExtractor() != nil
+}
+
+// tesseractExtractor reads text and CSV files as they are, the text layer of PDFs with pdftotext, and
+// images and scanned PDFs with Tesseract OCR, in OCR_LANGUAGES. pdftotext and pdftoppm come with
+// poppler-utils. Word and Excel files are not supported.
+type tesseractExtractor struct{}
+
+func (tesseractExtractor) extract(ctx context.Context, path, mimeType string) (string, error) {
+	switch strings.TrimSpace(strings.Split(mimeType, ";")[0]) {
+	case "text/plain", "text/csv":
+		content, err := os.ReadFile(path)
+		return string(content), err
+	case "image/png", "image/jpeg":
+		return runExtractionCommand(ctx, "tesseract", path, "stdout", "-l", config.AppConfig.OCRLanguages)
+	case "application/pdf":
+		text, err := runExtractionCommand(ctx, "pdftotext", "-enc", "UTF-8", path, "-")
+		if err != nil || strings.TrimSpace(text) != "" {
+			return text, err
+		}
+		// No text layer, so the PDF is a scan
+		return ocrPDF(ctx, path)
+	}
+	return "", errUnsupportedContent
+}
+
+// ocrPDF renders each page of a scanned PDF as an image and reads it with Tesseract
+func ocrPDF(ctx context.Context, path string) (string, error) {
+	dir, err := os.MkdirTemp("", "hrms-ocr-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := runExtractionCommand(ctx, "pdftoppm", "-r", "300", "-png", path, filepath.Join(dir, "page")); err != nil {
+		return "", err
+	}
+	// pdftoppm pads page numbers to the same width, so the names sort in page order
+	pages, err := filepath.Glob(filepath.Join(dir, "page*.png"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(pages)
+
+	var text strings.Builder
+	for _, page := range pages {
+		pageText, err := runExtractionCommand(ctx, "tesseract", page, "stdout", "-l", config.AppConfig.OCRLanguages)
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(pageText)
+		text.WriteString("\n")
+		if text.Len() > maxContentTextBytes {
+			break
+		}
+	}
+	return text.String(), nil
+}
+
+// runExtractionCommand runs a command and returns what it wrote, or an error with what it complained of
+func runExtractionCommand(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// tikaExtractor sends documents to the Apache Tika server at TIKA_URL, which reads Word and Excel files
+// as well and, in its full image, runs Tesseract on images and scanned PDFs
+type tikaExtractor struct{}
+
+func (tikaExtractor) extract(ctx context.Context, path, mimeType string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, config.AppConfig.TikaURL+"/tika", file)
+	if err != nil {
+		return "", err
+	}
+	if mimeType != "" {
+		req.Header.Set("Content-Type", mimeType)
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := tikaClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+	case http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return "", errUnsupportedContent
+	default:
+		return "", fmt.Errorf("tika responded %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxContentTextBytes+utf8.UTFMax))
+	return string(body), err
+}
+
+var (
+	extractionRunning sync.Mutex     // Held while this server works through the documents to extract
+	extractionRuns    sync.WaitGroup // Lets shutdown wait for a run started by StartTextExtraction
+)
+
+// StartTextExtraction extracts the text of documents not extracted yet in the background, when an
+// extractor is configured
+func StartTextExtraction() {
+	if !TextExtractionEnabled() {
+		return
+	}
+	extractionRuns.Add(1)
+	go func() {
+		defer extractionRuns.Done()
+		RunTextExtraction()
+	}()
+}
+
+// WaitForTextExtraction waits for extractions started by StartTextExtraction to finish
+func WaitForTextExtraction() {
+	extractionRuns.Wait()
+}
+
+// RunTextExtraction extracts the text of the documents not extracted yet, oldest first, one at a time
+// until none is left, including documents stored before an extractor was configured. Each document is
+// claimed before it is read, so servers sharing the database share the work. It returns straight away
+// if this server is already working through them.
+func RunTextExtraction() {
+	extractor := currentTextExtractor()
+	if extractor == nil || !extractionRunning.TryLock() {
+		return
+	}
+	defer extractionRunning.Unlock()
+
+	for {
+		document, ok, err := claimDocumentForExtraction()
+		if err != nil {
+			log.Printf("❌ Text extraction: failed to claim a document: %v", err)
+			return
+		}
+		if !ok {
+			return
+		}
+		extractDocumentText(extractor, document)
+	}
+}
+
+// claimDocumentForExtraction marks the oldest document not extracted yet extracting and returns it, or
+// false when there is none
+func claimDocumentForExtraction() (models.Document, bool, error) {
+	for {
+		var document models.Document
+		err := database.DB.Where("content_status = ?", "").Order("id").First(&document).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return document, false, nil
+		}
+		if err != nil {
+			return document, false, err
+		}
+
+		// Columns are updated directly so that extraction leaves the document's updated_at alone
+		result := database.DB.Model(&models.Document{}).
+			Where("id = ? AND content_status = ?", document.ID, "").
+			UpdateColumns(map[string]interface{}{
+				"content_status":       models.DocumentContentExtracting,
+				"content_extracted_at": time.Now(),
+			})
+		if result.Error != nil {
+			return document, false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return document, true, nil
+		}
+		// Another server claimed it first
+	}
+}
+
+// extractDocumentText reads a claimed document's text and stores it with the outcome
+func extractDocumentText(extractor textExtractor, document models.Document) {
+	ctx, cancel := context.WithTimeout(context.Background(), textExtractionTimeout)
+	defer cancel()
+
+	mimeType := ""
+	if document.MimeType != nil {
+		mimeType = *document.MimeType
+	}
+	text, err := extractor.extract(ctx, GetFullFilePath(document.FilePath), mimeType)
+
+	updates := map[string]interface{}{"content_extracted_at": time.Now()}
+	switch {
+	case errors.Is(err, errUnsupportedContent):
+		updates["content_status"] = models.DocumentContentUnsupported
+	case err != nil:
+		updates["content_status"] = models.DocumentContentFailed
+		log.Printf("❌ Text extraction of document %d failed: %v", document.ID, err)
+	default:
+		updates["content_status"] = models.DocumentContentExtracted
+		updates["content_text"] = CleanContentText(text)
+	}
+	if err := database.DB.Model(&models.Document{}).Where("id = ?", document.ID).UpdateColumns(updates).Error; err != nil {
+		log.Printf("❌ Text extraction of document %d: failed to store the text: %v", document.ID, err)
+	}
+}
+
+// CleanContentText makes extracted text fit to store: valid UTF-8 without NUL characters, which
+// Postgres refuses, trimmed and cut to maxContentTextBytes
+func CleanContentText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\x00", ""))
+	if len(text) > maxContentTextBytes {
+		cut := maxContentTextBytes
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut]
+	}
+	return text
+}
+
+// RequeueStaleExtractions queues again the documents that have been extracting for too long to still
+// be, and returns how many there were
+func RequeueStaleExtractions() (int64, error) {
+	result := database.DB.Model(&models.Document{}).
+		Where("content_status = ? AND content_extracted_at < ?", models.DocumentContentExtracting, time.Now().Add(-textExtractionStaleAfter)).
+		UpdateColumn("content_status", "")
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"hrms-api/config"
+	"hrms-api/models"
+	"time"
+)
+
+// Calendar dates (leave days, accrual months, expiry dates) are held at midnight UTC, the form they
+// take when parsed with time.Parse or read from a date column. The helpers below work out which date
+// it is now in the company's or an employee's timezone, so that "today" does not roll over at UTC
+// midnight while the office is still on the previous day, or vice versa.
+
+// CompanyLocation returns the timezone the company calendar runs on, set by TIMEZONE
+func CompanyLocation() *time.Location {
+	if config.AppConfig == nil || config.AppConfig.Location == nil {
+		return time.UTC
+	}
+	return config.AppConfig.Location
+}
+
+// EmployeeLocation returns the employee's own timezone, or the company timezone when they have none
+func EmployeeLocation(employee *models.Employee) *time.Location {
+	if employee != nil && employee.Timezone != "" {
+		if loc, err := time.LoadLocation(employee.Timezone); err == nil {
+			return loc
+		}
+	}
+	return CompanyLocation()
+}
+
+// CompanyNow returns the current time in the company timezone, so its Year, Month and Day are the
+// company's calendar date
+func CompanyNow() time.Time {
+	return Now().In(CompanyLocation())
+}
+
+// CompanyToday returns the company's current calendar date
+func CompanyToday() time.Time {
+	return DateIn(Now(), CompanyLocation())
+}
+
+// DateIn returns the calendar date of t in loc, at midnight UTC
+func DateIn(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"log"
+	"strings"
+)
+
+// encryptedTokenPrefix marks a stored token as encrypted, as opposed to one stored before tokens were
+const encryptedTokenPrefix = "enc:v1:"
+
+// tokenCipher is AES-256-GCM with the key of TOKEN_ENCRYPTION_KEY, else one derived from JWT_SECRET
+func tokenCipher() (cipher.AEAD, error) {
+	secret := config.AppConfig.TokenEncryptionKey
+	if secret == "" {
+		secret = "token-encryption:" + config.AppConfig.JWTSecret
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptToken encrypts a third-party credential, such as an OAuth token, to be stored in the database
+func EncryptToken(token string) (string, error) {
+	aead, err := tokenCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(token), nil)
+	return encryptedTokenPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptToken decrypts a token encrypted by EncryptToken. Tokens stored in plaintext, before they
+// were encrypted, are returned as they are.
+func DecryptToken(stored string) (string, error) {
+	if !strings.HasPrefix(stored, encryptedTokenPrefix) {
+		return stored, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encryptedTokenPrefix))
+	if err != nil {
+		return "", fmt.Errorf("stored token is not valid base64")
+	}
+	aead, err := tokenCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("stored token is too short")
+	}
+	token, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("stored token cannot be decrypted, TOKEN_ENCRYPTION_KEY may have changed")
+	}
+	return string(token), nil
+}
+
+// EncryptStoredTokens encrypts the calendar OAuth tokens stored in plaintext before tokens were
+// encrypted at rest. It runs on startup and leaves encrypted tokens alone.
+func EncryptStoredTokens() error {
+	var connections []models.CalendarConnection
+	if err := database.DB.Where("access_token NOT LIKE ? OR refresh_token NOT LIKE ?", encryptedTokenPrefix+"%", encryptedTokenPrefix+"%").
+		Find(&connections).Error; err != nil {
+		return err
+	}
+	for _, connection := range connections {
+		updates := map[string]interface{}{}
+		for column, token := range map[string]string{"access_token": connection.AccessToken, "refresh_token": connection.RefreshToken} {
+			if strings.HasPrefix(token, encryptedTokenPrefix) {
+				continue
+			}
+			encrypted, err := EncryptToken(token)
+			if err != nil {
+				return err
+			}
+			updates[column] = encrypted
+		}
+		if err := database.DB.Model(&connection).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	if len(connections) > 0 {
+		log.Printf("Encrypted the OAuth tokens of %d calendar connections", len(connections))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Mandatory training statuses
+const (
+	MandatoryTrainingComplete    = "complete"
+	MandatoryTrainingOutstanding = "outstanding"
+	MandatoryTrainingOverdue     = "overdue"
+	MandatoryTrainingExpired     = "expired"
+)
+
+// MandatoryTrainingItem describes an employee's progress on one mandatory course
+type MandatoryTrainingItem struct {
+	CourseID    uint       `json:"course_id" example:"4"`
+	CourseCode  string     `json:"course_code" example:"FIRE-101"`
+	CourseTitle string     `json:"course_title" example:"Fire Safety Awareness"`
+	Status      string     `json:"status" example:"outstanding"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiryDate  *time.Time `json:"expiry_date,omitempty"`
+}
+
+// MandatoryTrainingStatus checks the employee's completions against the courses mandatory for their role
+func MandatoryTrainingStatus(employee models.Employee) []MandatoryTrainingItem {
+	var rules []models.MandatoryTraining
+	database.DB.Preload("Course").Where("role = ?", employee.Role).Find(&rules)
+
+	var hireDate *time.Time
+	var employment models.EmploymentDetails
+	if database.DB.Where("employee_id = ?", employee.ID).First(&employment).Error == nil {
+		hireDate = employment.HireDate
+	}
+
+	now := time.Now()
+	items := []MandatoryTrainingItem{}
+	for _, rule := range rules {
+		if !rule.Course.IsActive {
+			continue
+		}
+
+		item := MandatoryTrainingItem{
+			CourseID:    rule.Course.ID,
+			CourseCode:  rule.Course.Code,
+			CourseTitle: rule.Course.Title,
+			Status:      MandatoryTrainingOutstanding,
+		}
+		if hireDate != nil && rule.DueWithinDays != nil {
+			due := hireDate.AddDate(0, 0, *rule.DueWithinDays)
+			item.DueDate = &due
+		}
+
+		var latest models.TrainingEnrollment
+		err := database.DB.Joins("JOIN training_sessions ON training_sessions.id = training_enrollments.session_id").
+			Where("training_enrollments.employee_id = ? AND training_enrollments.status = ? AND training_sessions.course_id = ?",
+				employee.ID, models.TrainingEnrollmentCompleted, rule.CourseID).
+			Order("training_enrollments.completed_at DESC").
+			First(&latest).Error
+
+		switch {
+		case err == nil && (latest.ExpiryDate == nil || !latest.ExpiryDate.Before(now)):
+			item.Status = MandatoryTrainingComplete
+			item.CompletedAt = latest.CompletedAt
+			item.ExpiryDate = latest.ExpiryDate
+		case err == nil:
+			item.Status = MandatoryTrainingExpired
+			item.CompletedAt = latest.CompletedAt
+			item.ExpiryDate = latest.ExpiryDate
+		case item.DueDate != nil && item.DueDate.Before(now):
+			item.Status = MandatoryTrainingOverdue
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// GenerateTrainingCertificatePDF renders a completion certificate for a training enrollment
+func GenerateTrainingCertificatePDF(employee models.Employee, course models.TrainingCourse, enrollment models.TrainingEnrollment) ([]byte, error) {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle("Certificate of Completion", false)
+	pdf.SetAuthor(CompanyName(), true)
+	pdf.SetCreator("HRMS API", false)
+	pdf.AddPage()
+
+	_ = addPDFHeader(pdf)
+
+	pdf.Ln(20)
+	pdf.SetFont("Arial", "B", 28)
+	pdf.CellFormat(0, 14, "Certificate of Completion", "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "", 14)
+	pdf.CellFormat(0, 8, "This certifies that", "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 22)
+	pdf.CellFormat(0, 12, employee.Firstname+" "+employee.Lastname, "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "", 14)
+	pdf.CellFormat(0, 8, "has successfully completed", "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 18)
+	pdf.CellFormat(0, 10, fmt.Sprintf("%s (%s)", course.Title, course.Code), "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	pdf.SetFont("Arial", "", 12)
+	if enrollment.CompletedAt != nil {
+		pdf.CellFormat(0, 7, "Completed on "+enrollment.CompletedAt.Format("2 January 2006"), "", 1, "C", false, 0, "")
+	}
+	if enrollment.Score != nil {
+		pdf.CellFormat(0, 7, fmt.Sprintf("Score: %.1f", *enrollment.Score), "", 1, "C", false, 0, "")
+	}
+	if enrollment.ExpiryDate != nil {
+		pdf.CellFormat(0, 7, "Valid until "+enrollment.ExpiryDate.Format("2 January 2006"), "", 1, "C", false, 0, "")
+	}
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "I", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s - certificate reference TRN-%d", CompanyName(), enrollment.ID), "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SaveTrainingCertificate generates a completion certificate and stores it as one of the employee's documents
+func SaveTrainingCertificate(employee models.Employee, course models.TrainingCourse, enrollment models.TrainingEnrollment, uploadedBy uint) (*models.Document, error) {
+	content, err := GenerateTrainingCertificatePDF(employee, course, enrollment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate: %w", err)
+	}
+
+	fileName := fmt.Sprintf("certificate_%s.pdf", course.Code)
+	secureName, err := GenerateSecureFileName(fileName, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	relativePath, size, err := SaveFile(bytes.NewReader(content), secureName, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := "application/pdf"
+	tags := "training"
+	document := models.Document{
+		EmployeeID:   employee.ID,
+		DocumentType: models.DocumentTypeCertificate,
+		Title:        "Training certificate: " + course.Title,
+		FileName:     fileName,
+		FilePath:     relativePath,
+		FileSize:     &size,
+		MimeType:     &mimeType,
+		IssueDate:    enrollment.CompletedAt,
+		ExpiryDate:   enrollment.ExpiryDate,
+		Status:       models.DocumentStatusActive,
+		UploadedBy:   &uploadedBy,
+		Tags:         &tags,
+	}
+	if err := database.DB.Create(&document).Error; err != nil {
+		DeleteFile(relativePath)
+		return nil, err
+	}
+
+	return &document, nil
+}
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"fmt"
+	"hrms-api/database"
+	"hrms-api/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ApplyTransfer carries out an approved transfer in a single transaction: it updates the employee's
+// department and current position, the manager in EmploymentDetails, closes the previous primary
+// position assignment, opens the new one, and writes the employment history row and lifecycle event
+func ApplyTransfer(transferID uint) (*models.TransferRequest, error) {
+	var transfer models.TransferRequest
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&transfer, transferID).Error; err != nil {
+			return fmt.Errorf("transfer request not found")
+		}
+		if transfer.Status != models.TransferStatusApproved {
+			return fmt.Errorf("transfer request is %s, only approved transfers can be applied", transfer.Status)
+		}
+
+		var employee models.Employee
+		if err := tx.First(&employee, transfer.EmployeeID).Error; err != nil {
+			return fmt.Errorf("employee not found")
+		}
+
+		before := TakeEmploymentSnapshot(tx, employee.ID)
+
+		// Department
+		if transfer.ToDepartment != nil && *transfer.ToDepartment != employee.Department {
+			if err := tx.Model(&employee).Update("department", *transfer.ToDepartment).Error; err != nil {
+				return err
+			}
+		}
+
+		// Manager
+		if transfer.ToManagerID != nil {
+			var employment models.EmploymentDetails
+			err := tx.Where("employee_id = ?", employee.ID).First(&employment).Error
+			if err == gorm.ErrRecordNotFound {
+				employment = models.EmploymentDetails{
+					EmployeeID:       employee.ID,
+					EmploymentStatus: models.EmploymentStatusActive,
+					ManagerID:        transfer.ToManagerID,
+				}
+				if err := tx.Create(&employment).Error; err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			} else if err := tx.Model(&employment).Updates(map[string]interface{}{
+				"manager_id": *transfer.ToManagerID,
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Position: close open primary assignments the day before and open the new one
+		if transfer.ToPositionID != nil {
+			endDate := transfer.EffectiveDate.AddDate(0, 0, -1)
+			if err := tx.Model(&models.PositionAssignment{}).
+				Where("employee_id = ? AND is_primary = ? AND end_date IS NULL AND position_id <> ?", employee.ID, true, *transfer.ToPositionID).
+				Update("end_date", endDate).Error; err != nil {
+				return err
+			}
+
+			var existing int64
+			tx.Model(&models.PositionAssignment{}).
+				Where("employee_id = ? AND position_id = ? AND end_date IS NULL", employee.ID, *transfer.ToPositionID).
+				Count(&existing)
+			if existing == 0 {
+				notes := fmt.Sprintf("Transfer request #%d", transfer.ID)
+				assignment := models.PositionAssignment{
+					EmployeeID:      employee.ID,
+					PositionID:      *transfer.ToPositionID,
+					StartDate:       transfer.EffectiveDate,
+					IsPrimary:       true,
+					AssignedBy:      transfer.ApprovedBy,
+					AssignmentNotes: &notes,
+				}
+				if err := tx.Create(&assignment).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Model(&employee).Update("position_id", *transfer.ToPositionID).Error; err != nil {
+				return err
+			}
+		}
+
+		after := TakeEmploymentSnapshot(tx, employee.ID)
+		reason := fmt.Sprintf("Transfer request #%d", transfer.ID)
+		if err := RecordEmploymentChange(tx, employee.ID, before, after, transfer.EffectiveDate, reason, transfer.ApprovedBy); err != nil {
+			return err
+		}
+
+		previousValue := describeTransferSide(before)
+		newValue := describeTransferSide(after)
+		now := time.Now()
+		event := models.WorkLifecycleEvent{
+			EmployeeID:     employee.ID,
+			EventType:      models.LifecycleEventTransferred,
+			EventDate:      now,
+			EffectiveDate:  &transfer.EffectiveDate,
+			PreviousValue:  &previousValue,
+			NewValue:       &newValue,
+			Description:    transfer.Reason,
+			InitiatedBy:    &transfer.RequestedBy,
+			ApprovedBy:     transfer.ApprovedBy,
+			ApprovedAt:     transfer.ApprovedAt,
+			IsCompleted:    true,
+			CompletionDate: &now,
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+
+		transfer.Status = models.TransferStatusCompleted
+		transfer.CompletedAt = &now
+		transfer.LifecycleEventID = &event.ID
+		return tx.Save(&transfer).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// ProcessDueTransfers applies every approved transfer whose effective date has been reached
+func ProcessDueTransfers() (int, []error) {
+	var transfers []models.TransferRequest
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if err := database.DB.Where("status = ? AND effective_date <= ?", models.TransferStatusApproved, today).
+		Order("effective_date").Find(&transfers).Error; err != nil {
+		return 0, []error{err}
+	}
+
+	applied := 0
+	var errs []error
+	for _, transfer := range transfers {
+		if _, err := ApplyTransfer(transfer.ID); err != nil {
+			errs = append(errs, fmt.Errorf("transfer %d: %w", transfer.ID, err))
+			continue
+		}
+		applied++
+	}
+
+	return applied, errs
+}
+
+func describeTransferSide(snapshot EmploymentSnapshot) string {
+	department, position := "-", "-"
+	if snapshot.Department != nil {
+		department = *snapshot.Department
+	}
+	if snapshot.Position != nil {
+		position = *snapshot.Position
+	}
+	description := fmt.Sprintf("Department: %s, Position: %s", department, position)
+	if snapshot.ManagerID != nil {
+		description += fmt.Sprintf(", Manager ID: %d", *snapshot.ManagerID)
+	}
+	return description
+}
@@ -4,14 +4,17 @@ import (
 	"hrms-api/database"
 	"hrms-api/models"
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// ValidateLeaveDates checks if start date is before end date
-func ValidateLeaveDates(startDate, endDate time.Time) error {
+// ValidateLeaveDates checks if start date is before end date, and that the leave does not start before
+// today in loc
+func ValidateLeaveDates(startDate, endDate time.Time, loc *time.Location) error {
 	if startDate.After(endDate) {
 		return ErrInvalidDateRange
 	}
-	if startDate.Before(time.Now().Truncate(24 * time.Hour)) {
+	if startDate.Before(DateIn(Now(), loc)) {
 		return ErrPastDate
 	}
 	return nil
@@ -40,15 +43,15 @@ func CheckOverlappingLeaves(employeeID uint, startDate, endDate time.Time, exclu
 // CalculateLeaveBalance calculates the remaining leave balance for an employee
 // For Annual leave, it uses accrual-based calculation (2 days/month)
 // For other leave types, it uses the traditional max days approach
-func CalculateLeaveBalance(employeeID uint, leaveTypeID uint) (int, error) {
+func CalculateLeaveBalance(db *gorm.DB, employeeID uint, leaveTypeID uint) (int, error) {
 	var leaveType models.LeaveType
-	if err := database.DB.First(&leaveType, leaveTypeID).Error; err != nil {
+	if err := db.First(&leaveType, leaveTypeID).Error; err != nil {
 		return 0, err
 	}
 
 	// For leave types that use balance (e.g. Annual), use accrual-based calculation
 	if leaveType.UsesBalance {
-		balance, err := GetCurrentLeaveBalance(employeeID, leaveTypeID)
+		balance, err := GetCurrentLeaveBalance(db, employeeID, leaveTypeID)
 		if err != nil {
 			return 0, err
 		}
@@ -58,7 +61,7 @@ func CalculateLeaveBalance(employeeID uint, leaveTypeID uint) (int, error) {
 	// For other leave types, use traditional calculation
 	var usedDays int
 	var leaves []models.Leave
-	if err := database.DB.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
+	if err := db.Where("employee_id = ? AND leave_type_id = ? AND status = ?",
 		employeeID, leaveTypeID, models.StatusApproved).Find(&leaves).Error; err != nil {
 		return 0, err
 	}
@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hrms-api/config"
+	"hrms-api/database"
+	"hrms-api/models"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventWebhookPing is sent by the webhook test endpoint; it is not subscribable
+const EventWebhookPing EventType = "ping"
+
+// WebhookEventTypes are the events webhook subscriptions may filter on
+var WebhookEventTypes = []EventType{EventLeaveSubmitted, EventLeaveApproved, EventLeaveRejected, EventLeaveCancelled}
+
+const (
+	webhookTimeout         = 10 * time.Second
+	webhookRetryBase       = time.Minute
+	webhookMaxRetryDelay   = 12 * time.Hour
+	webhookMaxResponseBody = 1000
+)
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// webhookSends tracks deliveries sent in the background by DispatchWebhook
+var webhookSends sync.WaitGroup
+
+// IsWebhookEventType reports whether a subscription may filter on the event type
+func IsWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if string(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateWebhookSecret returns a random signing secret for a new subscription
+func GenerateWebhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+// WebhookSignature signs a delivery as hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// subscription secret. Receivers recompute it to check the request came from us and was not replayed.
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DispatchWebhook queues the event for every active subscription of the organization that filters on it and sends each
+// delivery in the background. Failed deliveries are retried by the webhook scheduler.
+func DispatchWebhook(eventType EventType, organizationID uint, data interface{}) {
+	var subscriptions []models.WebhookSubscription
+	if err := database.DB.Where("is_active = ? AND organization_id = ?", true, organizationID).Find(&subscriptions).Error; err != nil {
+		log.Printf("❌ Webhooks: failed to load subscriptions for %s: %v", eventType, err)
+		return
+	}
+
+	event := Event{Type: eventType, Data: data, OccurredAt: time.Now()}
+	for _, subscription := range subscriptions {
+		if !subscribesTo(subscription, eventType) {
+			continue
+		}
+		delivery, err := QueueWebhookDelivery(subscription, event)
+		if err != nil {
+			log.Printf("❌ Webhooks: failed to queue %s for subscription %d: %v", eventType, subscription.ID, err)
+			continue
+		}
+		webhookSends.Add(1)
+		go func() {
+			defer webhookSends.Done()
+			DeliverWebhook(&delivery)
+		}()
+	}
+}
+
+// WaitForWebhookSends waits for deliveries started by DispatchWebhook to finish their attempt
+func WaitForWebhookSends() {
+	webhookSends.Wait()
+}
+
+// QueueWebhookDelivery records a pending delivery of the event to the subscription without sending it
+func QueueWebhookDelivery(subscription models.WebhookSubscription, event Event) (models.WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	// The first attempt is made straight away; scheduling it a retry interval out stops the scheduler
+	// picking it up while that attempt is still in flight
+	nextAttemptAt := time.Now().Add(webhookRetryBase)
+	delivery := models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventType:      string(event.Type),
+		Payload:        string(payload),
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  &nextAttemptAt,
+		Subscription:   subscription,
+	}
+	if err := database.DB.Omit("Subscription").Create(&delivery).Error; err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// DeliverWebhook makes one attempt to send a delivery and records the outcome. The delivery's
+// Subscription must be loaded. A failed attempt is rescheduled with exponential backoff until the
+// configured maximum number of attempts, after which the delivery is marked failed and parked in the
+// dead letters. A delivery the endpoint rejects permanently, with a 4xx status other than 408, 425 or
+// 429, is given up straight away.
+func DeliverWebhook(delivery *models.WebhookDelivery) error {
+	sendErr := sendWebhook(delivery)
+
+	now := time.Now()
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.Error = nil
+	} else {
+		errMsg := sendErr.Error()
+		delivery.Error = &errMsg
+		if isPermanent(sendErr) || delivery.Attempts >= webhookMaxAttempts() {
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			nextAttemptAt := now.Add(webhookRetryDelay(delivery.Attempts))
+			delivery.Status = models.WebhookDeliveryPending
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+	}
+
+	if err := database.DB.Omit("Subscription").Save(delivery).Error; err != nil {
+		return err
+	}
+	switch delivery.Status {
+	case models.WebhookDeliverySucceeded:
+		resolveDeadLetters("webhook_delivery_id", delivery.ID)
+	case models.WebhookDeliveryFailed:
+		deadLetterWebhook(delivery, *delivery.Error, isPermanent(sendErr))
+	}
+	return sendErr
+}
+
+// deadLetterWebhook parks a delivery that was given up. Test pings are only ever sent once and are not
+// parked.
+func deadLetterWebhook(delivery *models.WebhookDelivery, reason string, permanent bool) {
+	if delivery.EventType == string(EventWebhookPing) {
+		return
+	}
+	recordDeadLetter(models.DeadLetter{
+		OrganizationID:    delivery.Subscription.OrganizationID,
+		Kind:              models.DeadLetterWebhook,
+		WebhookDeliveryID: &delivery.ID,
+		Reason:            reason,
+		Permanent:         permanent,
+		Attempts:          delivery.Attempts,
+	})
+}
+
+// ProcessWebhookRetries re-sends pending deliveries whose next attempt is due. Deliveries to
+// subscriptions that have been deactivated or deleted are given up and parked in the dead letters.
+func ProcessWebhookRetries() (int, []error) {
+	var deliveries []models.WebhookDelivery
+	database.DB.Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at").
+		Find(&deliveries)
+
+	delivered := 0
+	var errs []error
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		if delivery.Subscription.ID == 0 || !delivery.Subscription.IsActive {
+			errMsg := "subscription is no longer active"
+			database.DB.Model(delivery).Updates(map[string]interface{}{
+				"status": models.WebhookDeliveryFailed, "next_attempt_at": nil, "error": errMsg,
+			})
+			if delivery.Subscription.ID != 0 {
+				deadLetterWebhook(delivery, errMsg, true)
+			}
+			continue
+		}
+		if err := DeliverWebhook(delivery); err != nil {
+			errs = append(errs, fmt.Errorf("delivery %d to subscription %d (attempt %d): %w",
+				delivery.ID, delivery.SubscriptionID, delivery.Attempts, err))
+			continue
+		}
+		delivered++
+	}
+	return delivered, errs
+}
+
+func sendWebhook(delivery *models.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, delivery.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hrms-api-webhooks")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+WebhookSignature(delivery.Subscription.Secret, timestamp, body))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		delivery.ResponseStatus = nil
+		delivery.ResponseBody = nil
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	responseText := string(responseBody)
+	delivery.ResponseStatus = &resp.StatusCode
+	delivery.ResponseBody = &responseText
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+		if webhookRejected(resp.StatusCode) {
+			return permanentError{err}
+		}
+		return err
+	}
+	return nil
+}
+
+// webhookRejected reports whether a response status means the endpoint will not accept the delivery
+// however often it is sent: a client error other than a timeout, too early or too many requests
+func webhookRejected(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return false
+	}
+	return status >= 400 && status <= 499
+}
+
+func subscribesTo(subscription models.WebhookSubscription, eventType EventType) bool {
+	for _, t := range strings.Split(subscription.EventTypes, ",") {
+		if strings.TrimSpace(t) == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookRetryDelay doubles the wait after each failed attempt: 1m, 2m, 4m, ... up to 12h
+func webhookRetryDelay(attempts int) time.Duration {
+	return backoff(webhookRetryBase, webhookMaxRetryDelay, attempts)
+}
+
+func webhookMaxAttempts() int {
+	if config.AppConfig != nil && config.AppConfig.WebhookMaxAttempts > 0 {
+		return config.AppConfig.WebhookMaxAttempts
+	}
+	return 8
+}
@@ -0,0 +1,39 @@
+// Package version identifies the build that is running. Its variables are set when building, e.g.
+//
+//	go build -ldflags "-X hrms-api/version.Version=1.4.0 -X hrms-api/version.Commit=$(git rev-parse HEAD) -X hrms-api/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// which make build and the Docker image do.
+package version
+
+import "runtime/debug"
+
+var (
+	// Version is the semantic version of the release, or dev for builds that are not releases
+	Version = "dev"
+	// Commit is the git commit built. When not set, it is the one Go recorded, if built in a checkout.
+	Commit = ""
+	// BuildTime is when the binary was built, in RFC 3339
+	BuildTime = ""
+)
+
+func init() {
+	if Commit != "" {
+		return
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	modified := false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			Commit = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if Commit != "" && modified {
+		Commit += "-dirty"
+	}
+}